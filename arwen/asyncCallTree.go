@@ -0,0 +1,94 @@
+package arwen
+
+// AsyncCallNode is an async call in the AsyncCallTree of an execution.
+//
+// An async call executed on the same host is a node whose Children are the
+// async calls registered while executing its destination, either by the
+// destination itself or by the contracts it called synchronously. These
+// nested calls form groups of their own, owned by the contract which
+// registered them (their Caller), and are never merged into the groups of
+// the parent call:
+//   - gas: the nested calls are paid from the GasLimit of the parent call,
+//     and the gas they leave unused returns to the destination of the parent
+//     call, which in turn returns its GasRemaining to the parent callback;
+//   - callbacks: the nested calls and their callbacks, including the group
+//     callbacks of the nested groups, all complete before the callback of the
+//     parent call is executed;
+//   - pending calls: when the destination leaves some of its own async calls
+//     pending (sent to another shard), the parent call also remains pending,
+//     and its callback is deferred until these calls are resolved; the legacy
+//     async call of a contract (LegacyAsyncContextIdentifier) is an exception,
+//     since its callback always follows the execution of its destination.
+//
+// An async call which leaves the host is a CrossShard leaf of the tree.
+type AsyncCallNode struct {
+	Caller           []byte
+	GroupID          string
+	Destination      []byte
+	Data             []byte
+	GasLimit         uint64
+	GasLocked        uint64
+	GasRemaining     uint64
+	Status           AsyncCallStatus
+	CrossShard       bool
+	Callback         string
+	CallbackPosition int
+	Children         []*AsyncCallNode
+}
+
+// AsyncCallTree holds the async calls of the last execution, as they were
+// composed through the nested executions; Calls are the async calls
+// registered while executing the contract called by the transaction
+type AsyncCallTree struct {
+	Calls []*AsyncCallNode
+}
+
+// NewAsyncCallTree creates an empty AsyncCallTree
+func NewAsyncCallTree() *AsyncCallTree {
+	return &AsyncCallTree{
+		Calls: make([]*AsyncCallNode, 0),
+	}
+}
+
+// Walk visits the nodes of the AsyncCallTree depth-first, in the order of
+// their registration; the calls of the transaction have depth 0
+func (tree *AsyncCallTree) Walk(visit func(node *AsyncCallNode, depth int)) {
+	walkAsyncCallNodes(tree.Calls, 0, visit)
+}
+
+func walkAsyncCallNodes(nodes []*AsyncCallNode, depth int, visit func(node *AsyncCallNode, depth int)) {
+	for _, node := range nodes {
+		visit(node, depth)
+		walkAsyncCallNodes(node.Children, depth+1, visit)
+	}
+}
+
+// Depth returns the number of levels of nested async calls in the AsyncCallTree
+func (tree *AsyncCallTree) Depth() int {
+	maxDepth := 0
+	tree.Walk(func(_ *AsyncCallNode, depth int) {
+		if depth+1 > maxDepth {
+			maxDepth = depth + 1
+		}
+	})
+
+	return maxDepth
+}
+
+// Callbacks returns the nodes whose callbacks were executed, in the order of
+// the execution of the callbacks
+func (tree *AsyncCallTree) Callbacks() []*AsyncCallNode {
+	callbacks := make([]*AsyncCallNode, 0)
+	tree.Walk(func(node *AsyncCallNode, _ int) {
+		if node.CallbackPosition > 0 {
+			callbacks = append(callbacks, node)
+		}
+	})
+
+	result := make([]*AsyncCallNode, len(callbacks))
+	for _, node := range callbacks {
+		result[node.CallbackPosition-1] = node
+	}
+
+	return result
+}
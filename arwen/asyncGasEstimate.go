@@ -0,0 +1,20 @@
+package arwen
+
+// AsyncCallGasEstimate is the minimum gas required by an async call, as
+// computed from the current gas schedule, split by the stage of the call
+// which consumes it; the gas used by the called function and by the callback
+// itself comes on top of it
+type AsyncCallGasEstimate struct {
+	// Registration is the gas consumed by the caller to register the async call
+	Registration uint64
+
+	// Dispatch is the gas consumed to prepare the destination contract for
+	// execution; it is 0 if the code of the destination is not available
+	Dispatch uint64
+
+	// Callback is the gas locked by the caller to receive the callback
+	Callback uint64
+
+	// Total is the sum of all the above
+	Total uint64
+}
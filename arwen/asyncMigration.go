@@ -0,0 +1,25 @@
+package arwen
+
+// LegacyAsyncContextIdentifier is the identifier of the AsyncContext under
+// which the migrated legacy async calls are grouped
+const LegacyAsyncContextIdentifier = ""
+
+// AsyncMigrationEntry describes the outcome of migrating a single async data entry found in storage
+type AsyncMigrationEntry struct {
+	Key    []byte
+	Reason string
+}
+
+// AsyncMigrationResult is the outcome of scanning the storage of a contract
+// for legacy async data entries and converting them to the AsyncContextInfo format
+type AsyncMigrationResult struct {
+	Address []byte
+	// Migrated are the entries converted to the AsyncContextInfo format
+	Migrated []AsyncMigrationEntry
+	// NotMigrated are the entries which could not be converted, with the reason
+	NotMigrated []AsyncMigrationEntry
+	// UpToDate is the number of entries already in the AsyncContextInfo format
+	UpToDate int
+	// StorageUpdates holds the new values of the migrated entries, to be written by the caller
+	StorageUpdates map[string][]byte
+}
@@ -0,0 +1,38 @@
+package arwen
+
+import (
+	"github.com/ElrondNetwork/elrond-go/core/vmcommon"
+)
+
+// AsyncTraceEvent is a structured record of a step in the lifecycle of an
+// async call, as reported to an AsyncTracer; the fields which do not apply
+// to a step are left empty
+type AsyncTraceEvent struct {
+	OriginalTxHash []byte
+	CurrentTxHash  []byte
+	Caller         []byte
+	Destination    []byte
+	GroupID        string
+	Function       string
+	Data           []byte
+	GasLimit       uint64
+	GasLocked      uint64
+	GasRemaining   uint64
+	ReturnCode     vmcommon.ReturnCode
+	Status         AsyncCallStatus
+	CrossShard     bool
+}
+
+// AsyncTracer is an optional observer of async calls, injected by the node
+// or by tests into the VMHost, to follow multi-hop async flows
+type AsyncTracer interface {
+	// OnAsyncCallRegistered is called when the VMHost begins dispatching an async call, either locally or cross-shard
+	OnAsyncCallRegistered(event AsyncTraceEvent)
+	// OnAsyncCallExecuted is called after an async call has been executed on its destination, in the same shard
+	OnAsyncCallExecuted(event AsyncTraceEvent)
+	// OnCallbackExecuted is called after the callback of an async call has been executed
+	OnCallbackExecuted(event AsyncTraceEvent)
+	// OnGroupCompleted is called when all the async calls of an AsyncContext have been resolved
+	OnGroupCompleted(event AsyncTraceEvent)
+	IsInterfaceNil() bool
+}
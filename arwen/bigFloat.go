@@ -0,0 +1,94 @@
+package arwen
+
+import (
+	"math/big"
+)
+
+// BigFloatPrecision is the number of bits of the mantissa of all the values
+// of the BigFloat API. Together with the rounding mode, it is fixed so that
+// the operations yield the same results on all nodes.
+const BigFloatPrecision = 53
+
+// BigFloatRoundingMode is the rounding mode of all the operations of the
+// BigFloat API
+const BigFloatRoundingMode = big.ToNearestEven
+
+// MinBigFloatPartsExponent is the lowest decimal exponent accepted when
+// creating a big float from its parts
+const MinBigFloatPartsExponent = -400
+
+// MaxBigFloatExponent is the largest binary exponent of the values of the
+// BigFloat API, which are therefore less than 2 to this power in magnitude;
+// it bounds the size of the big integers the values are truncated to
+const MaxBigFloatExponent = 1024
+
+// NewBigFloat returns a big float of value 0, having the precision and the
+// rounding mode of the BigFloat API
+func NewBigFloat() *big.Float {
+	return new(big.Float).SetPrec(BigFloatPrecision).SetMode(BigFloatRoundingMode)
+}
+
+// BigFloatFromParts returns the big float whose value is the integral part
+// plus the fractional part multiplied by 10 to the given exponent, which must
+// be between MinBigFloatPartsExponent and 0
+func BigFloatFromParts(integralPart int32, fractionalPart int32, exponent int32) (*big.Float, error) {
+	if exponent > 0 || exponent < MinBigFloatPartsExponent {
+		return nil, ErrInvalidBigFloatOperation
+	}
+
+	scale := big.NewInt(0).Exp(big.NewInt(10), big.NewInt(int64(-exponent)), nil)
+	fraction := NewBigFloat().SetInt64(int64(fractionalPart))
+	fraction.Quo(fraction, NewBigFloat().SetInt(scale))
+
+	value := NewBigFloat().SetInt64(int64(integralPart))
+	return value.Add(value, fraction), nil
+}
+
+// BigFloatPow returns the given base raised to the given integer exponent,
+// computed by repeated squaring, along with the number of multiplications it
+// took. A negative exponent yields the inverse of the power.
+func BigFloatPow(base *big.Float, exponent int32) (*big.Float, uint64, error) {
+	if base.Sign() == 0 && exponent < 0 {
+		return nil, 0, ErrDivZero
+	}
+
+	magnitude := int64(exponent)
+	if magnitude < 0 {
+		magnitude = -magnitude
+	}
+
+	result := NewBigFloat().SetInt64(1)
+	power := NewBigFloat().Set(base)
+	multiplications := uint64(0)
+	for magnitude > 0 {
+		if magnitude&1 == 1 {
+			result.Mul(result, power)
+			multiplications++
+		}
+		magnitude >>= 1
+		if magnitude > 0 {
+			power.Mul(power, power)
+			multiplications++
+		}
+	}
+
+	if exponent < 0 {
+		result.Quo(NewBigFloat().SetInt64(1), result)
+	}
+
+	return result, multiplications, CheckBigFloat(result)
+}
+
+// CheckBigFloat returns an error if the given big float is not finite, or if
+// its exponent exceeds MaxBigFloatExponent, which happens when an operation of
+// the BigFloat API overflows
+func CheckBigFloat(value *big.Float) error {
+	if value.IsInf() {
+		return ErrInvalidBigFloatOperation
+	}
+	if value.MantExp(nil) > MaxBigFloatExponent {
+		return ErrInvalidBigFloatOperation
+	}
+
+	return nil
+}
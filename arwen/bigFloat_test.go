@@ -0,0 +1,72 @@
+package arwen
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBigFloat_FromParts(t *testing.T) {
+	value, err := BigFloatFromParts(3, 25, -2)
+	require.Nil(t, err)
+	require.Equal(t, "3.25", value.Text('f', 2))
+	require.Equal(t, uint(BigFloatPrecision), value.Prec())
+
+	value, err = BigFloatFromParts(-1, -5, -1)
+	require.Nil(t, err)
+	require.Equal(t, "-1.5", value.Text('f', 1))
+
+	value, err = BigFloatFromParts(7, 0, 0)
+	require.Nil(t, err)
+	require.Equal(t, 0, value.Cmp(big.NewFloat(7)))
+
+	_, err = BigFloatFromParts(1, 1, 1)
+	require.Equal(t, ErrInvalidBigFloatOperation, err)
+
+	_, err = BigFloatFromParts(1, 1, MinBigFloatPartsExponent-1)
+	require.Equal(t, ErrInvalidBigFloatOperation, err)
+}
+
+func TestBigFloat_Pow(t *testing.T) {
+	base, _ := BigFloatFromParts(1, 5, -1)
+
+	result, multiplications, err := BigFloatPow(base, 3)
+	require.Nil(t, err)
+	require.Equal(t, "3.375", result.Text('f', 3))
+	require.Equal(t, uint64(3), multiplications)
+
+	result, _, err = BigFloatPow(base, 0)
+	require.Nil(t, err)
+	require.Equal(t, 0, result.Cmp(big.NewFloat(1)))
+
+	result, _, err = BigFloatPow(NewBigFloat().SetInt64(2), -2)
+	require.Nil(t, err)
+	require.Equal(t, 0, result.Cmp(big.NewFloat(0.25)))
+
+	_, _, err = BigFloatPow(NewBigFloat(), -1)
+	require.Equal(t, ErrDivZero, err)
+
+	_, _, err = BigFloatPow(NewBigFloat().SetInt64(2), 1<<31-1)
+	require.Equal(t, ErrInvalidBigFloatOperation, err)
+
+	_, _, err = BigFloatPow(NewBigFloat().SetInt64(2), 1<<30)
+	require.Equal(t, ErrInvalidBigFloatOperation, err)
+
+	result, _, err = BigFloatPow(NewBigFloat().SetInt64(2), MaxBigFloatExponent-1)
+	require.Nil(t, err)
+	require.Equal(t, MaxBigFloatExponent, result.MantExp(nil))
+
+	_, _, err = BigFloatPow(NewBigFloat().SetInt64(2), MaxBigFloatExponent)
+	require.Equal(t, ErrInvalidBigFloatOperation, err)
+}
+
+func TestBigFloat_Check(t *testing.T) {
+	require.Nil(t, CheckBigFloat(NewBigFloat().SetInt64(-7)))
+	require.Nil(t, CheckBigFloat(NewBigFloat().SetMantExp(big.NewFloat(-0.5), MaxBigFloatExponent)))
+	require.Nil(t, CheckBigFloat(NewBigFloat().SetMantExp(big.NewFloat(0.5), -1<<20)))
+
+	require.Equal(t, ErrInvalidBigFloatOperation, CheckBigFloat(NewBigFloat().SetInf(false)))
+	require.Equal(t, ErrInvalidBigFloatOperation, CheckBigFloat(NewBigFloat().SetMantExp(big.NewFloat(0.5), MaxBigFloatExponent+1)))
+	require.Equal(t, ErrInvalidBigFloatOperation, CheckBigFloat(NewBigFloat().SetMantExp(big.NewFloat(-0.5), 1<<30)))
+}
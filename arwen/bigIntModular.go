@@ -0,0 +1,70 @@
+package arwen
+
+import (
+	"math/big"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/math"
+)
+
+// BigIntPrimalityRounds is the number of Miller-Rabin rounds of the primality
+// test of the BigInt API; it is fixed so that all nodes reach the same verdict
+const BigIntPrimalityRounds = 20
+
+// BigIntWords returns the number of 64-bit words of the magnitude of the
+// given value, which is at least 1
+func BigIntWords(value *big.Int) uint64 {
+	words := uint64(value.BitLen()+63) / 64
+	if words == 0 {
+		return 1
+	}
+	return words
+}
+
+// BigIntPowMod sets the destination to the base raised to the exponent,
+// modulo the modulus, which must be positive
+func BigIntPowMod(destination *big.Int, base *big.Int, exponent *big.Int, modulus *big.Int) error {
+	if modulus.Sign() == 0 {
+		return ErrDivZero
+	}
+	if exponent.Sign() < 0 || modulus.Sign() < 0 {
+		return ErrPowModNegative
+	}
+
+	destination.Exp(base, exponent, modulus)
+	return nil
+}
+
+// PowModGasUnits returns the units of work of a modular exponentiation, as
+// the words of the exponent times the square of the words of the modulus
+func PowModGasUnits(exponent *big.Int, modulus *big.Int) uint64 {
+	modulusWords := BigIntWords(modulus)
+	return math.MulUint64(BigIntWords(exponent), math.MulUint64(modulusWords, modulusWords))
+}
+
+// BigIntIsPrime returns true if the given value is prime, with the fixed
+// number of rounds of BigIntPrimalityRounds
+func BigIntIsPrime(value *big.Int) bool {
+	if value.Sign() <= 0 {
+		return false
+	}
+
+	return value.ProbablyPrime(BigIntPrimalityRounds)
+}
+
+// IsPrimeGasUnits returns the units of work of a primality test, as a
+// modular exponentiation of the value to itself for each round
+func IsPrimeGasUnits(value *big.Int) uint64 {
+	return math.MulUint64(BigIntPrimalityRounds+1, PowModGasUnits(value, value))
+}
+
+// BigIntGcd sets the destination to the greatest common divisor of the
+// absolute values of the given operands
+func BigIntGcd(destination *big.Int, a *big.Int, b *big.Int) {
+	destination.GCD(nil, nil, big.NewInt(0).Abs(a), big.NewInt(0).Abs(b))
+}
+
+// GcdGasUnits returns the units of work of a greatest common divisor, as the
+// product of the words of the operands
+func GcdGasUnits(a *big.Int, b *big.Int) uint64 {
+	return math.MulUint64(BigIntWords(a), BigIntWords(b))
+}
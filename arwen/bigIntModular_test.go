@@ -0,0 +1,58 @@
+package arwen
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBigIntModular_PowMod(t *testing.T) {
+	result := big.NewInt(0)
+	err := BigIntPowMod(result, big.NewInt(4), big.NewInt(13), big.NewInt(497))
+	require.Nil(t, err)
+	require.Equal(t, big.NewInt(445), result)
+
+	err = BigIntPowMod(result, big.NewInt(4), big.NewInt(13), big.NewInt(0))
+	require.Equal(t, ErrDivZero, err)
+
+	err = BigIntPowMod(result, big.NewInt(4), big.NewInt(-1), big.NewInt(497))
+	require.Equal(t, ErrPowModNegative, err)
+
+	err = BigIntPowMod(result, big.NewInt(4), big.NewInt(13), big.NewInt(-497))
+	require.Equal(t, ErrPowModNegative, err)
+}
+
+func TestBigIntModular_IsPrime(t *testing.T) {
+	require.True(t, BigIntIsPrime(big.NewInt(2)))
+	require.True(t, BigIntIsPrime(big.NewInt(7919)))
+	require.False(t, BigIntIsPrime(big.NewInt(7917)))
+	require.False(t, BigIntIsPrime(big.NewInt(1)))
+	require.False(t, BigIntIsPrime(big.NewInt(0)))
+	require.False(t, BigIntIsPrime(big.NewInt(-7)))
+
+	mersenne := big.NewInt(0).Lsh(big.NewInt(1), 127)
+	mersenne.Sub(mersenne, big.NewInt(1))
+	require.True(t, BigIntIsPrime(mersenne))
+}
+
+func TestBigIntModular_Gcd(t *testing.T) {
+	result := big.NewInt(0)
+	BigIntGcd(result, big.NewInt(-84), big.NewInt(36))
+	require.Equal(t, big.NewInt(12), result)
+
+	BigIntGcd(result, big.NewInt(0), big.NewInt(-5))
+	require.Equal(t, big.NewInt(5), result)
+}
+
+func TestBigIntModular_GasUnits(t *testing.T) {
+	small := big.NewInt(3)
+	large := big.NewInt(0).Lsh(big.NewInt(1), 255)
+
+	require.Equal(t, uint64(1), BigIntWords(big.NewInt(0)))
+	require.Equal(t, uint64(4), BigIntWords(large))
+	require.Equal(t, uint64(1), PowModGasUnits(small, small))
+	require.Equal(t, uint64(4*16), PowModGasUnits(large, large))
+	require.Equal(t, uint64((BigIntPrimalityRounds+1)*64), IsPrimeGasUnits(large))
+	require.Equal(t, uint64(4), GcdGasUnits(small, large))
+}
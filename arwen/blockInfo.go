@@ -0,0 +1,32 @@
+package arwen
+
+import (
+	"encoding/binary"
+)
+
+// BlockInfoRandomSeedOffset is the offset of the random seed in an encoded
+// BlockInfo, after the nonce, the round, the epoch and the timestamp
+const BlockInfoRandomSeedOffset = 8 + 8 + 4 + 8
+
+// BlockInfo holds the metadata of a block which contracts may read, so that it
+// can be retrieved in a single call instead of one call for each field
+type BlockInfo struct {
+	Nonce      uint64
+	Round      uint64
+	Epoch      uint32
+	Timestamp  uint64
+	RandomSeed []byte
+}
+
+// Encode serializes the BlockInfo as the nonce, the round, the epoch and the
+// timestamp, in big-endian order, followed by the whole random seed
+func (info *BlockInfo) Encode() []byte {
+	encoded := make([]byte, BlockInfoRandomSeedOffset+len(info.RandomSeed))
+	binary.BigEndian.PutUint64(encoded[0:8], info.Nonce)
+	binary.BigEndian.PutUint64(encoded[8:16], info.Round)
+	binary.BigEndian.PutUint32(encoded[16:20], info.Epoch)
+	binary.BigEndian.PutUint64(encoded[20:28], info.Timestamp)
+	copy(encoded[BlockInfoRandomSeedOffset:], info.RandomSeed)
+
+	return encoded
+}
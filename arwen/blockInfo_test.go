@@ -0,0 +1,33 @@
+package arwen
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockInfo_Encode(t *testing.T) {
+	t.Parallel()
+
+	info := &BlockInfo{
+		Nonce:      98,
+		Round:      99,
+		Epoch:      4,
+		Timestamp:  6800,
+		RandomSeed: []byte("seed"),
+	}
+
+	encoded := info.Encode()
+	require.Len(t, encoded, BlockInfoRandomSeedOffset+4)
+	require.Equal(t, uint64(98), binary.BigEndian.Uint64(encoded[0:8]))
+	require.Equal(t, uint64(99), binary.BigEndian.Uint64(encoded[8:16]))
+	require.Equal(t, uint32(4), binary.BigEndian.Uint32(encoded[16:20]))
+	require.Equal(t, uint64(6800), binary.BigEndian.Uint64(encoded[20:28]))
+	require.Equal(t, []byte("seed"), encoded[BlockInfoRandomSeedOffset:])
+
+	// the random seed is kept whole, whatever its length
+	info.RandomSeed = bytes.Repeat([]byte{1}, 58)
+	require.Equal(t, info.RandomSeed, info.Encode()[BlockInfoRandomSeedOffset:])
+}
@@ -0,0 +1,87 @@
+package arwen
+
+import (
+	"encoding/hex"
+	"strings"
+
+	"github.com/ElrondNetwork/elrond-go/core/parsers"
+)
+
+// CallDataSeparator separates the function from the arguments in call data, as well as the arguments between them
+const CallDataSeparator = "@"
+
+// EncodeCallData produces call data of the form "function@hexArg1@hexArg2...".
+// Every argument is preceded by exactly one separator, so that an empty
+// argument is encoded as an empty token: "function@" holds one empty argument
+// and "function@@01" holds an empty argument followed by 0x01.
+func EncodeCallData(function string, arguments [][]byte) []byte {
+	data := make([]byte, 0, CallDataLength(function, arguments))
+	data = append(data, function...)
+	return append(data, EncodeCallArguments(arguments)...)
+}
+
+// EncodeCallArguments produces the arguments part of call data, "@hexArg1@hexArg2...",
+// which may be appended to a function name or to existing call data
+func EncodeCallArguments(arguments [][]byte) []byte {
+	data := make([]byte, 0, CallDataLength("", arguments))
+	for _, argument := range arguments {
+		data = append(data, CallDataSeparator...)
+		data = append(data, hex.EncodeToString(argument)...)
+	}
+
+	return data
+}
+
+// CallDataLength returns the length of the call data which EncodeCallData
+// produces for the given function and arguments, without encoding them
+func CallDataLength(function string, arguments [][]byte) int {
+	length := len(function)
+	for _, argument := range arguments {
+		length += len(CallDataSeparator) + hex.EncodedLen(len(argument))
+	}
+
+	return length
+}
+
+// DecodeCallData is the inverse of EncodeCallData: each token following a
+// separator is an argument, including the empty tokens produced by "@@" or by
+// a trailing separator. The errors are the ones of the CallArgsParser of the
+// node, which accepts the same format.
+func DecodeCallData(data []byte) (string, [][]byte, error) {
+	tokens := strings.Split(string(data), CallDataSeparator)
+	if len(tokens[0]) == 0 {
+		return "", nil, parsers.ErrTokenizeFailed
+	}
+
+	arguments := make([][]byte, 0, len(tokens)-1)
+	for _, token := range tokens[1:] {
+		argument, err := hex.DecodeString(token)
+		if err != nil {
+			return "", nil, parsers.ErrTokenizeFailed
+		}
+
+		arguments = append(arguments, argument)
+	}
+
+	return tokens[0], arguments, nil
+}
+
+var _ CallArgsParser = (*callDataParser)(nil)
+
+type callDataParser struct {
+}
+
+// NewCallDataParser creates a CallArgsParser which decodes call data with DecodeCallData
+func NewCallDataParser() *callDataParser {
+	return &callDataParser{}
+}
+
+// ParseData decodes call data of the form "function@hexArg1@hexArg2..."
+func (parser *callDataParser) ParseData(data string) (string, [][]byte, error) {
+	return DecodeCallData([]byte(data))
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (parser *callDataParser) IsInterfaceNil() bool {
+	return parser == nil
+}
@@ -0,0 +1,67 @@
+package arwen
+
+import (
+	"testing"
+
+	"github.com/ElrondNetwork/elrond-go/core/parsers"
+	"github.com/ElrondNetwork/elrond-go/testscommon/txDataBuilder"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCallData_EncodeDecode(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		arguments [][]byte
+		data      string
+	}{
+		{arguments: [][]byte{}, data: "function"},
+		{arguments: [][]byte{{}}, data: "function@"},
+		{arguments: [][]byte{{}, {1}}, data: "function@@01"},
+		{arguments: [][]byte{{1}, {}}, data: "function@01@"},
+		{arguments: [][]byte{{1}, {}, {}, []byte("ab")}, data: "function@01@@@6162"},
+	}
+
+	parser := parsers.NewCallArgsParser()
+	for _, testCase := range testCases {
+		encoded := EncodeCallData("function", testCase.arguments)
+		require.Equal(t, testCase.data, string(encoded))
+		require.Equal(t, len(encoded), CallDataLength("function", testCase.arguments))
+
+		builder := txDataBuilder.NewBuilder().Func("function")
+		for _, argument := range testCase.arguments {
+			builder.Bytes(argument)
+		}
+		require.Equal(t, testCase.data, builder.ToString())
+
+		function, arguments, err := DecodeCallData(encoded)
+		require.Nil(t, err)
+		require.Equal(t, "function", function)
+		require.Equal(t, testCase.arguments, arguments)
+
+		function, arguments, err = parser.ParseData(testCase.data)
+		require.Nil(t, err)
+		require.Equal(t, "function", function)
+		require.Equal(t, testCase.arguments, arguments)
+	}
+}
+
+func TestCallData_EncodeCallArguments(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, "", string(EncodeCallArguments(nil)))
+	require.Equal(t, "@@01", string(EncodeCallArguments([][]byte{{}, {1}})))
+	require.Equal(t, "function@01@", string(append([]byte("function"), EncodeCallArguments([][]byte{{1}, {}})...)))
+}
+
+func TestCallData_DecodeInvalid(t *testing.T) {
+	t.Parallel()
+
+	for _, data := range []string{"", "@01", "function@0", "function@xy"} {
+		_, _, err := DecodeCallData([]byte(data))
+		require.Equal(t, parsers.ErrTokenizeFailed, err, data)
+
+		_, _, err = NewCallDataParser().ParseData(data)
+		require.Equal(t, parsers.ErrTokenizeFailed, err, data)
+	}
+}
@@ -0,0 +1,63 @@
+package arwen
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+
+	"github.com/ElrondNetwork/elrond-go/core/vmcommon"
+)
+
+// CallStackTraceLogIdentifier is the identifier of the log entry which
+// records the CallStackTrace in the VMOutput of a failed execution
+const CallStackTraceLogIdentifier = "callStackTrace"
+
+// CallStackFrame is a frame of the execution stack of a failed execution:
+// the contract, the function it was executing and the gas it had left. The
+// gas left of a caller is the one it had when it started its nested
+// execution, while the gas left of the innermost frame is the one it had
+// when it failed.
+type CallStackFrame struct {
+	ContractAddress []byte
+	Function        string
+	GasRemaining    uint64
+}
+
+// CallStackTrace is the chain of frames of the execution stack, from the
+// outermost to the innermost, captured by the Runtime context when an
+// execution fails, along with the message it failed with. The traces are only
+// captured when enabled through the VMHostParameters; they are then recorded
+// in the VMOutput of the failed executions, as a log entry.
+type CallStackTrace struct {
+	Frames  []CallStackFrame
+	Message string
+}
+
+// LogEntry encodes the CallStackTrace as a VMOutput log entry, attributed to
+// the contract of the innermost frame; it has two topics per frame, from the
+// outermost to the innermost: "<hex address>.<function>" and the gas left as
+// 8 big-endian bytes. The data of the log entry is the message of the failure.
+func (trace *CallStackTrace) LogEntry() *vmcommon.LogEntry {
+	topics := make([][]byte, 0, 2*len(trace.Frames))
+	for _, frame := range trace.Frames {
+		gasRemaining := make([]byte, 8)
+		binary.BigEndian.PutUint64(gasRemaining, frame.GasRemaining)
+		topics = append(topics, []byte(frame.String()), gasRemaining)
+	}
+
+	var address []byte
+	if len(trace.Frames) > 0 {
+		address = trace.Frames[len(trace.Frames)-1].ContractAddress
+	}
+
+	return &vmcommon.LogEntry{
+		Identifier: []byte(CallStackTraceLogIdentifier),
+		Address:    address,
+		Topics:     topics,
+		Data:       []byte(trace.Message),
+	}
+}
+
+// String formats the frame as "<hex address>.<function>"
+func (frame CallStackFrame) String() string {
+	return hex.EncodeToString(frame.ContractAddress) + "." + frame.Function
+}
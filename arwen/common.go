@@ -1,6 +1,8 @@
 package arwen
 
 import (
+	"time"
+
 	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/config"
 	"github.com/ElrondNetwork/elrond-go/core/vmcommon"
 )
@@ -25,6 +27,9 @@ const (
 
 	// BreakpointOutOfGas means that Wasmer must stop immediately due to gas being exhausted
 	BreakpointOutOfGas
+
+	// BreakpointExecutionTimeout means that Wasmer must stop immediately because the execution lasted longer than allowed
+	BreakpointExecutionTimeout
 )
 
 // AsyncCallExecutionMode encodes the execution modes of an AsyncCall
@@ -65,6 +70,13 @@ const TimeLockKeyPrefix = ProtectedStoragePrefix + "TIMELOCK"
 // AsyncDataPrefix is the storage key prefix used for AsyncContext-related storage.
 const AsyncDataPrefix = ProtectedStoragePrefix + "ASYNC"
 
+// StorageSizeKey is the storage key under which the size of the storage of a
+// contract is kept, while the storage quota of the contracts is set. It cannot
+// clash with the keys of the contracts, which may not write under
+// ProtectedStoragePrefix, nor with the other keys of the VM, none of which is
+// a prefix of it or starts with it.
+const StorageSizeKey = ProtectedStoragePrefix + "STORAGESIZE"
+
 // AsyncCallStatus represents the different status an async call can have
 type AsyncCallStatus uint8
 
@@ -108,19 +120,84 @@ type CodeDeployInput struct {
 	CodeDeployerAddress  []byte
 }
 
+// CompilationOptions contains the options with which an Executor creates the
+// instances of the contracts
+type CompilationOptions struct {
+	GasLimit           uint64
+	UnmeteredLocals    uint64
+	OpcodeTrace        bool
+	Metering           bool
+	RuntimeBreakpoints bool
+}
+
 // VMHostParameters represents the parameters to be passed to VMHost
 type VMHostParameters struct {
-	VMType                        []byte
-	BlockGasLimit                 uint64
-	GasSchedule                   config.GasScheduleMap
-	ProtocolBuiltinFunctions      vmcommon.FunctionNames
-	ElrondProtectedKeyPrefix      []byte
-	ArwenV2EnableEpoch            uint32
-	AheadOfTimeEnableEpoch        uint32
-	DynGasLockEnableEpoch         uint32
-	ArwenV3EnableEpoch            uint32
-	ArwenESDTFunctionsEnableEpoch uint32
-	UseWarmInstance               bool
+	VMType                         []byte
+	BlockGasLimit                  uint64
+	GasSchedule                    config.GasScheduleMap
+	ProtocolBuiltinFunctions       vmcommon.FunctionNames
+	ElrondProtectedKeyPrefix       []byte
+	ArwenV2EnableEpoch             uint32
+	AheadOfTimeEnableEpoch         uint32
+	DynGasLockEnableEpoch          uint32
+	ArwenV3EnableEpoch             uint32
+	ArwenESDTFunctionsEnableEpoch  uint32
+	CallbackValidationEnableEpoch  uint32
+	RefundReceiptsEnableEpoch      uint32
+	StorageLoadCacheEnableEpoch    uint32
+	AsyncCallsFixEnableEpoch       uint32
+	FailedExecutionGasEnableEpoch  uint32
+	EventLogValidationEnableEpoch  uint32
+	ReadOnlyEnforcementEnableEpoch uint32
+	AsyncCallGasCapEnableEpoch     uint32
+	LogEntryGasEnableEpoch         uint32
+	GroupCallbacksEnableEpoch      uint32
+	AsyncValueTransferEnableEpoch  uint32
+	CallbackGasEscrowEnableEpoch   uint32
+	EEIExtensionsEnableEpoch       uint32
+	UseWarmInstance                bool
+	InstancePoolSize               uint64
+	CompiledCodeCacheDirectory     string
+	CompiledCodeCacheSize          uint64
+	InitialMemoryPages             uint32
+	MaxMemoryPages                 uint32
+	MaxMemoryGrow                  uint32
+	ExecutionTimeout               time.Duration
+	MaxExecutionStackDepth         uint64
+	DeploymentLimits               DeploymentLimits
+	DebugStop                      DebugStop
+	ValidationConfig               RuntimeValidationConfig
+	FunctionActivationMap          FunctionActivationMap
+	ErrorCodesInReturnMessage      bool
+	ExecutionWitnessEnabled        bool
+	HookCacheEnabled               bool
+	GasProfilingEnabled            bool
+	ExecutionProfilingEnabled      bool
+	StorageKeyRecordingEnabled     bool
+	StorageDiffEnabled             bool
+	MemoryCeiling                  uint64
+	MeteringAuditEnabled           bool
+	UserErrorDebugEnabled          bool
+	ItemizedRefundsInVMOutput      bool
+	OutOfGasReceiptsEnabled        bool
+	CallStackTracesEnabled         bool
+	MaxLogEntries                  uint64
+	MaxLogBytes                    uint64
+	MaxLogTopics                   uint64
+	MaxLogTopicLength              uint64
+	MaxReturnDataSize              uint64
+	TransferAggregationEnabled     bool
+	ReceiptsEnabled                bool
+	MaxStorageKeyLength            uint64
+	MaxStorageValueLength          uint64
+	MaxContractStorageSize         uint64
+	PaymentNotificationGasLimit    uint64
+	MaxQueryGasLimit               uint64
+	ExecutionPolicy                ExecutionPolicy     `json:"-"`
+	AsyncTracer                    AsyncTracer         `json:"-"`
+	EnableEpochsHandler            EnableEpochsHandler `json:"-"`
+	ExecutorFactory                ExecutorFactory     `json:"-"`
+	DebugStepCallback              DebugStepCallback   `json:"-"`
 }
 
 // AsyncCallInfo contains the information required to handle the asynchronous call of another SmartContract
@@ -167,13 +244,26 @@ type AsyncGeneratedCall struct {
 	SuccessCallback string
 	ErrorCallback   string
 	ProvidedGas     uint64
+	GasLocked       uint64
 }
 
+// AsyncCallbackOrder encodes when the callbacks of an AsyncContext are executed, relative to its calls
+type AsyncCallbackOrder uint8
+
+const (
+	// AsyncCallbackOrderCompletion executes each callback as soon as its call completes
+	AsyncCallbackOrderCompletion AsyncCallbackOrder = iota
+
+	// AsyncCallbackOrderRegistration executes the callbacks after all the calls, in the order of registration
+	AsyncCallbackOrderRegistration
+)
+
 // AsyncContext is a structure containing a group of async calls and a callback
 //  that should be called when all these async calls are resolved
 type AsyncContext struct {
-	Callback   string
-	AsyncCalls []*AsyncGeneratedCall
+	Callback      string
+	CallbackOrder AsyncCallbackOrder
+	AsyncCalls    []*AsyncGeneratedCall
 }
 
 // AsyncContextInfo is the structure resulting after a smart contract call that has initiated
@@ -201,7 +291,7 @@ func (ac *AsyncGeneratedCall) GetGasLimit() uint64 {
 
 // GetGasLocked returns the gas locked for the async callback
 func (ac *AsyncGeneratedCall) GetGasLocked() uint64 {
-	return 0
+	return ac.GasLocked
 }
 
 // GetValueBytes returns the byte representation of the value of the async call
@@ -2,8 +2,12 @@ package contexts
 
 import (
 	"math/big"
+	"unsafe"
 )
 
+// bigIntOverhead approximates the memory taken by a big.Int and its map entry, besides its digits
+const bigIntOverhead = 64
+
 type bigIntMap map[int32]*big.Int
 
 type bigIntContext struct {
@@ -102,6 +106,26 @@ func (context *bigIntContext) GetThree(handle1 int32, handle2 int32, handle3 int
 	return context.GetOne(handle1), context.GetOne(handle2), context.GetOne(handle3)
 }
 
+// MemoryUsage approximates the memory held by the values in the current
+// values map and in all the values maps on the state stack
+func (context *bigIntContext) MemoryUsage() uint64 {
+	usage := memoryUsageOfValues(context.values)
+	for _, values := range context.stateStack {
+		usage += memoryUsageOfValues(values)
+	}
+	return usage
+}
+
+func memoryUsageOfValues(values bigIntMap) uint64 {
+	wordSize := uint64(unsafe.Sizeof(big.Word(0)))
+
+	usage := uint64(0)
+	for _, value := range values {
+		usage += bigIntOverhead + uint64(cap(value.Bits()))*wordSize
+	}
+	return usage
+}
+
 // IsInterfaceNil returns true if there is no value under the interface
 func (context *bigIntContext) IsInterfaceNil() bool {
 	return context == nil
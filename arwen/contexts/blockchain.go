@@ -124,6 +124,11 @@ func (context *blockchainContext) GetESDTToken(address []byte, tokenID []byte, n
 	return context.blockChainHook.GetESDTToken(address, tokenID, nonce)
 }
 
+// GetAllState returns the whole storage of the account mapped to the given address
+func (context *blockchainContext) GetAllState(address []byte) (map[string][]byte, error) {
+	return context.blockChainHook.GetAllState(address)
+}
+
 // GetCodeHash returns the code hash that is set tho the given account
 func (context *blockchainContext) GetCodeHash(address []byte) []byte {
 	account, err := context.blockChainHook.GetUserAccount(address)
@@ -245,6 +250,18 @@ func (context *blockchainContext) CurrentRandomSeed() []byte {
 	return context.blockChainHook.CurrentRandomSeed()
 }
 
+// CurrentBlockInfo returns the nonce, the round, the epoch, the timestamp and
+// the random seed of the current block
+func (context *blockchainContext) CurrentBlockInfo() *arwen.BlockInfo {
+	return &arwen.BlockInfo{
+		Nonce:      context.blockChainHook.CurrentNonce(),
+		Round:      context.blockChainHook.CurrentRound(),
+		Epoch:      context.blockChainHook.CurrentEpoch(),
+		Timestamp:  context.blockChainHook.CurrentTimeStamp(),
+		RandomSeed: context.blockChainHook.CurrentRandomSeed(),
+	}
+}
+
 // GetOwnerAddress returns the address of the owner of the SC that is set in the runtime context
 func (context *blockchainContext) GetOwnerAddress() ([]byte, error) {
 	scAddress := context.host.Runtime().GetSCAddress()
@@ -329,6 +346,22 @@ func (context *blockchainContext) PopDiscard() {
 	context.stateStack = context.stateStack[:stateStackLen-1]
 }
 
+// GetGasModifier returns the GasModifier defined for the contract at the
+// given address, if the BlockchainHook is a GasModifierProvider
+func (context *blockchainContext) GetGasModifier(address []byte) (arwen.GasModifier, bool) {
+	provider, ok := context.blockChainHook.(arwen.GasModifierProvider)
+	if !ok {
+		return arwen.GasModifier{}, false
+	}
+
+	modifier, ok := provider.GetGasModifier(address)
+	if !ok || !modifier.IsValid() {
+		return arwen.GasModifier{}, false
+	}
+
+	return modifier, true
+}
+
 // GetSnapshot - gets the latest snapshot via blockchain hook
 func (context *blockchainContext) GetSnapshot() int {
 	return context.blockChainHook.GetSnapshot()
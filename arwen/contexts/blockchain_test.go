@@ -439,4 +439,12 @@ func TestBlockchainContext_Getters(t *testing.T) {
 	require.Equal(t, []byte("root hash"), blockchainContext.GetStateRootHash())
 	require.Equal(t, randomSeed1[:], blockchainContext.LastRandomSeed())
 	require.Equal(t, randomSeed2[:], blockchainContext.CurrentRandomSeed())
+
+	require.Equal(t, &arwen.BlockInfo{
+		Nonce:      98,
+		Round:      99,
+		Epoch:      4,
+		Timestamp:  6800,
+		RandomSeed: randomSeed2[:],
+	}, blockchainContext.CurrentBlockInfo())
 }
@@ -0,0 +1,182 @@
+package contexts
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const compiledCodeFileExtension = ".compiled"
+
+// compiledCodeDiskCache keeps the compiled code of the contracts in a local
+// directory, one file per code hash, so that it survives restarts. Each file
+// starts with the SHA256 hash of the compiled code it holds, which is checked
+// when the file is read; a file failing the check is removed. At most
+// capacity files are kept, removing the least recently used one first.
+type compiledCodeDiskCache struct {
+	directory string
+	capacity  int
+	order     []string
+}
+
+func newCompiledCodeDiskCache(directory string, capacity int) (*compiledCodeDiskCache, error) {
+	err := os.MkdirAll(directory, 0750)
+	if err != nil {
+		return nil, err
+	}
+
+	cache := &compiledCodeDiskCache{
+		directory: directory,
+		capacity:  capacity,
+	}
+
+	err = cache.loadOrder()
+	if err != nil {
+		return nil, err
+	}
+
+	cache.evict()
+	return cache, nil
+}
+
+// loadOrder orders the files found in the directory by their last use,
+// which is recorded as their modification time
+func (cache *compiledCodeDiskCache) loadOrder() error {
+	files, err := ioutil.ReadDir(cache.directory)
+	if err != nil {
+		return err
+	}
+
+	sort.SliceStable(files, func(i, j int) bool {
+		return files[i].ModTime().Before(files[j].ModTime())
+	})
+
+	cache.order = make([]string, 0, len(files))
+	for _, file := range files {
+		if file.IsDir() || !strings.HasSuffix(file.Name(), compiledCodeFileExtension) {
+			continue
+		}
+		cache.order = append(cache.order, strings.TrimSuffix(file.Name(), compiledCodeFileExtension))
+	}
+
+	return nil
+}
+
+// get returns the compiled code of the given code hash, and false if it is
+// not cached or if its file is corrupted
+func (cache *compiledCodeDiskCache) get(codeHash []byte) (bool, []byte) {
+	key := hex.EncodeToString(codeHash)
+	if !cache.contains(key) {
+		return false, nil
+	}
+
+	contents, err := ioutil.ReadFile(cache.path(key))
+	if err != nil {
+		logRuntime.Trace("compiled code disk cache", "read", key, "error", err)
+		cache.removeFile(key)
+		return false, nil
+	}
+
+	if len(contents) < sha256.Size {
+		logRuntime.Warn("compiled code disk cache", "read", key, "error", "file too short")
+		cache.removeFile(key)
+		return false, nil
+	}
+
+	integrityHash := contents[:sha256.Size]
+	compiledCode := contents[sha256.Size:]
+	actualHash := sha256.Sum256(compiledCode)
+	if !bytes.Equal(integrityHash, actualHash[:]) {
+		logRuntime.Warn("compiled code disk cache", "read", key, "error", "integrity hash mismatch")
+		cache.removeFile(key)
+		return false, nil
+	}
+
+	now := time.Now()
+	_ = os.Chtimes(cache.path(key), now, now)
+	cache.removeFromOrder(key)
+	cache.order = append(cache.order, key)
+
+	return true, compiledCode
+}
+
+// put writes the compiled code of the given code hash to the cache; the file
+// is written under a temporary name first, so that it is never seen partial
+func (cache *compiledCodeDiskCache) put(codeHash []byte, compiledCode []byte) {
+	key := hex.EncodeToString(codeHash)
+	integrityHash := sha256.Sum256(compiledCode)
+
+	contents := make([]byte, 0, sha256.Size+len(compiledCode))
+	contents = append(contents, integrityHash[:]...)
+	contents = append(contents, compiledCode...)
+
+	temporaryPath := cache.path(key) + ".tmp"
+	err := ioutil.WriteFile(temporaryPath, contents, 0640)
+	if err == nil {
+		err = os.Rename(temporaryPath, cache.path(key))
+	}
+	if err != nil {
+		logRuntime.Warn("compiled code disk cache", "write", key, "error", err)
+		_ = os.Remove(temporaryPath)
+		return
+	}
+
+	cache.removeFromOrder(key)
+	cache.order = append(cache.order, key)
+	cache.evict()
+}
+
+// remove deletes the compiled code of the given code hash from the cache
+func (cache *compiledCodeDiskCache) remove(codeHash []byte) {
+	cache.removeFile(hex.EncodeToString(codeHash))
+}
+
+// clear deletes all the compiled code from the cache
+func (cache *compiledCodeDiskCache) clear() {
+	for _, key := range cache.order {
+		_ = os.Remove(cache.path(key))
+	}
+	cache.order = make([]string, 0)
+}
+
+func (cache *compiledCodeDiskCache) removeFile(key string) {
+	_ = os.Remove(cache.path(key))
+	cache.removeFromOrder(key)
+}
+
+func (cache *compiledCodeDiskCache) evict() {
+	for len(cache.order) > cache.capacity {
+		leastRecentlyUsed := cache.order[0]
+		_ = os.Remove(cache.path(leastRecentlyUsed))
+		cache.order = cache.order[1:]
+	}
+}
+
+func (cache *compiledCodeDiskCache) contains(key string) bool {
+	for _, cachedKey := range cache.order {
+		if cachedKey == key {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (cache *compiledCodeDiskCache) removeFromOrder(key string) {
+	for i, cachedKey := range cache.order {
+		if cachedKey == key {
+			cache.order = append(cache.order[:i], cache.order[i+1:]...)
+			return
+		}
+	}
+}
+
+func (cache *compiledCodeDiskCache) path(key string) string {
+	return filepath.Join(cache.directory, key+compiledCodeFileExtension)
+}
@@ -0,0 +1,133 @@
+package contexts
+
+import (
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/math"
+)
+
+const hostFunctionPrefix = ".v1_3_"
+const hostPackageMarker = "/arwen/host."
+const meteringMethodMarker = "(*meteringContext)."
+const maxProfiledStackDepth = 64
+
+// gasProfiler accumulates the gas used during an execution, by category. It
+// identifies the host function charging the gas by walking the Go call
+// stack, which is too slow for production use; therefore it only exists
+// while gas profiling is enabled.
+type gasProfiler struct {
+	gasUsed          uint64
+	initialCost      uint64
+	builtinFunctions uint64
+	hostFunctions    map[string]uint64
+}
+
+func newGasProfiler() *gasProfiler {
+	profiler := &gasProfiler{}
+	profiler.reset()
+
+	return profiler
+}
+
+func (profiler *gasProfiler) reset() {
+	profiler.gasUsed = 0
+	profiler.initialCost = 0
+	profiler.builtinFunctions = 0
+	profiler.hostFunctions = make(map[string]uint64)
+}
+
+// recordGasUsedByCaller attributes the given gas to the innermost host
+// function on the call stack. Gas used directly by the host, which forwards
+// gas to nested executions and restores it afterwards, is not recorded,
+// because the nested executions are profiled on their own.
+func (profiler *gasProfiler) recordGasUsedByCaller(gas uint64) {
+	name, ok := callingHostFunction(1)
+	if !ok {
+		return
+	}
+
+	profiler.hostFunctions[name] = math.AddUint64(profiler.hostFunctions[name], gas)
+}
+
+func (profiler *gasProfiler) recordInitialCost(gas uint64) {
+	profiler.initialCost = math.AddUint64(profiler.initialCost, gas)
+}
+
+func (profiler *gasProfiler) recordBuiltinFunction(gas uint64) {
+	profiler.builtinFunctions = math.AddUint64(profiler.builtinFunctions, gas)
+}
+
+func (profiler *gasProfiler) setGasUsed(gas uint64) {
+	profiler.gasUsed = gas
+}
+
+func (profiler *gasProfiler) buildProfile() *arwen.GasProfile {
+	profile := &arwen.GasProfile{
+		GasUsed:          profiler.gasUsed,
+		InitialCost:      profiler.initialCost,
+		BuiltinFunctions: profiler.builtinFunctions,
+		HostFunctions:    make([]arwen.HostFunctionGas, 0, len(profiler.hostFunctions)),
+	}
+
+	for name, gas := range profiler.hostFunctions {
+		profile.HostFunctions = append(profile.HostFunctions, arwen.HostFunctionGas{
+			Name: name,
+			Gas:  gas,
+		})
+	}
+	sort.Slice(profile.HostFunctions, func(i, j int) bool {
+		if profile.HostFunctions[i].Gas != profile.HostFunctions[j].Gas {
+			return profile.HostFunctions[i].Gas > profile.HostFunctions[j].Gas
+		}
+		return profile.HostFunctions[i].Name < profile.HostFunctions[j].Name
+	})
+
+	gasAccounted := math.AddUint64(profile.InitialCost, profile.BuiltinFunctions)
+	gasAccounted = math.AddUint64(gasAccounted, profile.HostFunctionsGas())
+	profile.WASMOpcodes = math.SubUint64(profile.GasUsed, gasAccounted)
+
+	return profile
+}
+
+// callingHostFunction returns the name of the host function imported by the
+// contract which is currently being called, skipping the given number of
+// frames above its caller, the methods of the Metering context and the host
+// functions it calls internally; it returns false if the caller is called
+// directly by the host
+func callingHostFunction(skip int) (string, bool) {
+	programCounters := make([]uintptr, maxProfiledStackDepth)
+	numFrames := runtime.Callers(skip+2, programCounters)
+	frames := runtime.CallersFrames(programCounters[:numFrames])
+
+	name := ""
+	isDirectCaller := true
+	for {
+		frame, more := frames.Next()
+		if !strings.Contains(frame.Function, meteringMethodMarker) {
+			// the host package calls the contract, so the host functions
+			// of the current contract end at its first frame
+			if strings.Contains(frame.Function, hostPackageMarker) {
+				break
+			}
+			isDirectCaller = false
+
+			index := strings.LastIndex(frame.Function, hostFunctionPrefix)
+			if index >= 0 {
+				name = frame.Function[index+len(hostFunctionPrefix):]
+			}
+		}
+
+		if !more {
+			break
+		}
+	}
+
+	if isDirectCaller || len(name) == 0 {
+		return "", false
+	}
+
+	return name, true
+}
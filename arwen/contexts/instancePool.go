@@ -0,0 +1,167 @@
+package contexts
+
+import (
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/wasmer"
+)
+
+// pooledInstance is a Wasmer instance created for a contract code, along
+// with the contents of its memory right after instantiation, which are
+// restored before the instance is reused
+type pooledInstance struct {
+	codeKey       string
+	instance      wasmer.InstanceHandler
+	initialMemory []byte
+}
+
+// instancePool keeps the Wasmer instances of the contracts after their
+// executions, so that later executions of the same code reuse them instead
+// of instantiating the code again. It holds at most one idle instance per
+// code, for at most capacity codes, evicting the least recently used one
+// first. The instances are kept under the keys of the compiled code of the
+// contracts, which also identify the executor which created them. An
+// instance whose memory has grown is not kept, because its memory cannot be
+// restored. The globals of the instances cannot be restored either, so only
+// the instances of codes without mutable globals are kept, and only if their
+// executions ended normally. The copies of the initial memory of the tracked
+// instances are accounted in memory.
+type instancePool struct {
+	capacity int
+	memory   *arwen.MemoryAccountant
+	tracked  map[wasmer.InstanceHandler]*pooledInstance
+	idle     map[string]*pooledInstance
+	order    []string
+}
+
+func newInstancePool(capacity int, memory *arwen.MemoryAccountant) *instancePool {
+	pool := &instancePool{
+		capacity: capacity,
+		memory:   memory,
+		tracked:  make(map[wasmer.InstanceHandler]*pooledInstance),
+	}
+	pool.clear()
+
+	return pool
+}
+
+// track makes the given instance, just created for the code with the given
+// key, eligible to be kept in the pool once its execution is done
+func (pool *instancePool) track(codeKey []byte, instance wasmer.InstanceHandler) {
+	var initialMemory []byte
+	if instance.HasMemory() && instance.GetMemory() != nil {
+		data := instance.GetMemory().Data()
+		initialMemory = make([]byte, len(data))
+		copy(initialMemory, data)
+	}
+
+	pool.tracked[instance] = &pooledInstance{
+		codeKey:       string(codeKey),
+		instance:      instance,
+		initialMemory: initialMemory,
+	}
+	pool.memory.Reserve(arwen.MemoryInstancePool, uint64(len(initialMemory)))
+}
+
+// take removes the idle instance of the code with the given key from the
+// pool and restores its memory, returning false if there is none
+func (pool *instancePool) take(codeKey []byte) (wasmer.InstanceHandler, bool) {
+	pooled, ok := pool.idle[string(codeKey)]
+	if !ok {
+		return nil, false
+	}
+
+	delete(pool.idle, pooled.codeKey)
+	pool.removeFromOrder(pooled.codeKey)
+
+	if pooled.initialMemory != nil {
+		copy(pooled.instance.GetMemory().Data(), pooled.initialMemory)
+	}
+
+	return pooled.instance, true
+}
+
+// put keeps the given instance in the pool, returning false if it cannot be
+// kept, in which case it is no longer tracked and must be cleaned by the
+// caller, along with the instances evicted to make room for it
+func (pool *instancePool) put(instance wasmer.InstanceHandler) (bool, []wasmer.InstanceHandler) {
+	pooled, ok := pool.tracked[instance]
+	if !ok {
+		return false, nil
+	}
+
+	_, alreadyIdle := pool.idle[pooled.codeKey]
+	if alreadyIdle || pool.capacity == 0 || !pooled.hasInitialMemoryLength() {
+		pool.untrack(instance)
+		return false, nil
+	}
+
+	evicted := make([]wasmer.InstanceHandler, 0)
+	for len(pool.idle) >= pool.capacity {
+		leastRecentlyUsed := pool.idle[pool.order[0]]
+		delete(pool.idle, leastRecentlyUsed.codeKey)
+		pool.untrack(leastRecentlyUsed.instance)
+		pool.order = pool.order[1:]
+		evicted = append(evicted, leastRecentlyUsed.instance)
+	}
+
+	pool.idle[pooled.codeKey] = pooled
+	pool.order = append(pool.order, pooled.codeKey)
+
+	return true, evicted
+}
+
+// forget stops tracking the given instance, which will not be kept in the pool
+func (pool *instancePool) forget(instance wasmer.InstanceHandler) {
+	pool.untrack(instance)
+}
+
+func (pool *instancePool) untrack(instance wasmer.InstanceHandler) {
+	pooled, ok := pool.tracked[instance]
+	if !ok {
+		return
+	}
+
+	delete(pool.tracked, instance)
+	pool.memory.Release(arwen.MemoryInstancePool, uint64(len(pooled.initialMemory)))
+}
+
+// clear empties the pool, returning its idle instances, which must be
+// cleaned by the caller; the instances in use are no longer tracked
+func (pool *instancePool) clear() []wasmer.InstanceHandler {
+	idleInstances := make([]wasmer.InstanceHandler, 0, len(pool.idle))
+	for _, codeKey := range pool.order {
+		idleInstances = append(idleInstances, pool.idle[codeKey].instance)
+	}
+	for instance := range pool.tracked {
+		pool.untrack(instance)
+	}
+
+	pool.tracked = make(map[wasmer.InstanceHandler]*pooledInstance)
+	pool.idle = make(map[string]*pooledInstance)
+	pool.order = make([]string, 0)
+
+	return idleInstances
+}
+
+// numIdle returns the number of instances kept in the pool
+func (pool *instancePool) numIdle() int {
+	return len(pool.idle)
+}
+
+func (pool *instancePool) removeFromOrder(codeKey string) {
+	for i, pooledCodeKey := range pool.order {
+		if pooledCodeKey == codeKey {
+			pool.order = append(pool.order[:i], pool.order[i+1:]...)
+			return
+		}
+	}
+}
+
+func (pooled *pooledInstance) hasInitialMemoryLength() bool {
+	if pooled.initialMemory == nil {
+		return true
+	}
+
+	memory := pooled.instance.GetMemory()
+	return memory != nil && int(memory.Length()) == len(pooled.initialMemory)
+}
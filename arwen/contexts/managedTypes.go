@@ -0,0 +1,333 @@
+package contexts
+
+import (
+	"math/big"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
+)
+
+// bigFloatSize approximates the memory taken by a big.Float of the BigFloat
+// API and its map entry, its mantissa included
+const bigFloatSize = 96
+
+// managedMapOverhead approximates the memory taken by an empty managed map
+// and its map entry
+const managedMapOverhead = 96
+
+// managedBufferOverhead approximates the memory taken by a managed buffer and
+// its map entry, besides its data
+const managedBufferOverhead = 48
+
+// curvePointSize approximates the memory taken by a compressed curve point and
+// its map entry
+const curvePointSize = 80
+
+type bigFloatMap map[int32]*big.Float
+
+type managedMapMap map[int32]*arwen.ManagedMap
+
+type managedBufferMap map[int32][]byte
+
+type curvePointMap map[int32]*arwen.CurvePoint
+
+// managedTypesValues are the values of all the managed types, by handle. The
+// values are shared with the values on the state stack until they are first
+// retrieved after PushState: the big floats and the managed maps, which are
+// modified in place, are copied at that point, and marked as owned. The
+// managed buffers and the curve points are never modified in place, but
+// replaced, so they are never copied.
+type managedTypesValues struct {
+	bigFloats      bigFloatMap
+	managedMaps    managedMapMap
+	managedBuffers managedBufferMap
+	curvePoints    curvePointMap
+
+	ownedBigFloats   map[int32]struct{}
+	ownedManagedMaps map[int32]struct{}
+}
+
+// managedTypesContainer holds the values which the contracts manipulate
+// through handles on behalf of the Runtime context
+type managedTypesContainer struct {
+	values     managedTypesValues
+	stateStack []managedTypesValues
+}
+
+// newManagedTypesContainer creates a new managedTypesContainer
+func newManagedTypesContainer() *managedTypesContainer {
+	container := &managedTypesContainer{
+		stateStack: make([]managedTypesValues, 0),
+	}
+	container.InitState()
+
+	return container
+}
+
+// InitState initializes the underlying values maps
+func (container *managedTypesContainer) InitState() {
+	container.values = managedTypesValues{
+		bigFloats:        make(bigFloatMap),
+		managedMaps:      make(managedMapMap),
+		managedBuffers:   make(managedBufferMap),
+		curvePoints:      make(curvePointMap),
+		ownedBigFloats:   make(map[int32]struct{}),
+		ownedManagedMaps: make(map[int32]struct{}),
+	}
+}
+
+// PushState appends the values maps to the state stack; the values themselves
+// are only copied when retrieved afterwards, so the big floats and the managed
+// maps retrieved before must not be modified after PushState
+func (container *managedTypesContainer) PushState() {
+	container.stateStack = append(container.stateStack, container.values)
+	container.values = container.shallowCopy()
+}
+
+// PopSetActiveState removes the latest entry from the state stack and sets it as the current values maps
+func (container *managedTypesContainer) PopSetActiveState() {
+	stateStackLen := len(container.stateStack)
+	if stateStackLen == 0 {
+		return
+	}
+
+	prevValues := container.stateStack[stateStackLen-1]
+	container.stateStack = container.stateStack[:stateStackLen-1]
+
+	container.values = prevValues
+}
+
+// PopDiscard removes the latest entry from the state stack
+func (container *managedTypesContainer) PopDiscard() {
+	stateStackLen := len(container.stateStack)
+	if stateStackLen == 0 {
+		return
+	}
+
+	container.stateStack = container.stateStack[:stateStackLen-1]
+}
+
+// ClearStateStack initializes the state stack
+func (container *managedTypesContainer) ClearStateStack() {
+	container.stateStack = make([]managedTypesValues, 0)
+}
+
+func (container *managedTypesContainer) shallowCopy() managedTypesValues {
+	newState := managedTypesValues{
+		bigFloats:        make(bigFloatMap, len(container.values.bigFloats)),
+		managedMaps:      make(managedMapMap, len(container.values.managedMaps)),
+		managedBuffers:   make(managedBufferMap, len(container.values.managedBuffers)),
+		curvePoints:      make(curvePointMap, len(container.values.curvePoints)),
+		ownedBigFloats:   make(map[int32]struct{}),
+		ownedManagedMaps: make(map[int32]struct{}),
+	}
+	for handle, bigFloat := range container.values.bigFloats {
+		newState.bigFloats[handle] = bigFloat
+	}
+	for handle, managedMap := range container.values.managedMaps {
+		newState.managedMaps[handle] = managedMap
+	}
+	for handle, data := range container.values.managedBuffers {
+		newState.managedBuffers[handle] = data
+	}
+	for handle, point := range container.values.curvePoints {
+		newState.curvePoints[handle] = point
+	}
+	return newState
+}
+
+// PutBigFloat adds the given big float to the current values map and returns its handle
+func (container *managedTypesContainer) PutBigFloat(value *big.Float) int32 {
+	newHandle := int32(len(container.values.bigFloats))
+	for {
+		if _, ok := container.values.bigFloats[newHandle]; !ok {
+			break
+		}
+		newHandle++
+	}
+
+	container.values.bigFloats[newHandle] = arwen.NewBigFloat().Set(value)
+	container.values.ownedBigFloats[newHandle] = struct{}{}
+
+	return newHandle
+}
+
+// GetBigFloat returns the big float under the given handle. Unlike the
+// BigInt context, it does not create missing values, returning an error instead.
+func (container *managedTypesContainer) GetBigFloat(handle int32) (*big.Float, error) {
+	value, ok := container.values.bigFloats[handle]
+	if !ok {
+		return nil, arwen.ErrNoBigFloatUnderThisHandle
+	}
+
+	_, owned := container.values.ownedBigFloats[handle]
+	if !owned {
+		value = arwen.NewBigFloat().Set(value)
+		container.values.bigFloats[handle] = value
+		container.values.ownedBigFloats[handle] = struct{}{}
+	}
+
+	return value, nil
+}
+
+// NewManagedMap adds an empty managed map to the current values and returns its handle
+func (container *managedTypesContainer) NewManagedMap() int32 {
+	newHandle := int32(len(container.values.managedMaps))
+	for {
+		if _, ok := container.values.managedMaps[newHandle]; !ok {
+			break
+		}
+		newHandle++
+	}
+
+	container.values.managedMaps[newHandle] = arwen.NewManagedMap()
+	container.values.ownedManagedMaps[newHandle] = struct{}{}
+
+	return newHandle
+}
+
+// GetManagedMap returns the managed map under the given handle
+func (container *managedTypesContainer) GetManagedMap(handle int32) (*arwen.ManagedMap, error) {
+	managedMap, ok := container.values.managedMaps[handle]
+	if !ok {
+		return nil, arwen.ErrNoManagedMapUnderThisHandle
+	}
+
+	_, owned := container.values.ownedManagedMaps[handle]
+	if !owned {
+		managedMap = managedMap.Clone()
+		container.values.managedMaps[handle] = managedMap
+		container.values.ownedManagedMaps[handle] = struct{}{}
+	}
+
+	return managedMap, nil
+}
+
+// NewManagedBuffer adds a managed buffer holding a copy of the given data to
+// the current values and returns its handle
+func (container *managedTypesContainer) NewManagedBuffer(data []byte) int32 {
+	newHandle := int32(len(container.values.managedBuffers))
+	for {
+		if _, ok := container.values.managedBuffers[newHandle]; !ok {
+			break
+		}
+		newHandle++
+	}
+
+	container.values.managedBuffers[newHandle] = append([]byte{}, data...)
+
+	return newHandle
+}
+
+// GetManagedBuffer returns the data of the managed buffer under the given handle
+func (container *managedTypesContainer) GetManagedBuffer(handle int32) ([]byte, error) {
+	data, ok := container.values.managedBuffers[handle]
+	if !ok {
+		return nil, arwen.ErrNoManagedBufferUnderThisHandle
+	}
+
+	return data, nil
+}
+
+// SetManagedBuffer replaces the data of the managed buffer under the given
+// handle with a copy of the given data
+func (container *managedTypesContainer) SetManagedBuffer(handle int32, data []byte) error {
+	_, ok := container.values.managedBuffers[handle]
+	if !ok {
+		return arwen.ErrNoManagedBufferUnderThisHandle
+	}
+
+	container.values.managedBuffers[handle] = append([]byte{}, data...)
+	return nil
+}
+
+// PutCurvePoint adds a copy of the given curve point to the current values and returns its handle
+func (container *managedTypesContainer) PutCurvePoint(curveID int32, encoding []byte) int32 {
+	newHandle := int32(len(container.values.curvePoints))
+	for {
+		if _, ok := container.values.curvePoints[newHandle]; !ok {
+			break
+		}
+		newHandle++
+	}
+
+	container.values.curvePoints[newHandle] = &arwen.CurvePoint{
+		CurveID:  curveID,
+		Encoding: append([]byte{}, encoding...),
+	}
+
+	return newHandle
+}
+
+// GetCurvePoint returns the curve point under the given handle
+func (container *managedTypesContainer) GetCurvePoint(handle int32) (*arwen.CurvePoint, error) {
+	point, ok := container.values.curvePoints[handle]
+	if !ok {
+		return nil, arwen.ErrNoCurvePointUnderThisHandle
+	}
+
+	return point, nil
+}
+
+// MemoryUsage approximates the memory held by the values in the current
+// values maps and in all the values maps on the state stack; the values shared
+// between them are accounted once
+func (container *managedTypesContainer) MemoryUsage() uint64 {
+	usage := &managedTypesMemoryUsage{
+		bigFloats:      make(map[*big.Float]struct{}),
+		managedMaps:    make(map[*arwen.ManagedMap]struct{}),
+		managedBuffers: make(map[managedBufferKey]struct{}),
+		curvePoints:    make(map[*arwen.CurvePoint]struct{}),
+	}
+	for _, values := range container.stateStack {
+		usage.add(values)
+	}
+	usage.add(container.values)
+
+	return usage.total
+}
+
+// managedBufferKey identifies the data of a managed buffer, which is shared
+// between values maps as long as it is not replaced
+type managedBufferKey struct {
+	handle int32
+	data   *byte
+}
+
+type managedTypesMemoryUsage struct {
+	total          uint64
+	bigFloats      map[*big.Float]struct{}
+	managedMaps    map[*arwen.ManagedMap]struct{}
+	managedBuffers map[managedBufferKey]struct{}
+	curvePoints    map[*arwen.CurvePoint]struct{}
+}
+
+func (usage *managedTypesMemoryUsage) add(values managedTypesValues) {
+	for _, bigFloat := range values.bigFloats {
+		if _, ok := usage.bigFloats[bigFloat]; !ok {
+			usage.bigFloats[bigFloat] = struct{}{}
+			usage.total += bigFloatSize
+		}
+	}
+	for _, managedMap := range values.managedMaps {
+		if _, ok := usage.managedMaps[managedMap]; !ok {
+			usage.managedMaps[managedMap] = struct{}{}
+			usage.total += managedMapOverhead + managedMap.MemoryUsage()
+		}
+	}
+	for handle, data := range values.managedBuffers {
+		key := managedBufferKey{handle: handle}
+		if len(data) > 0 {
+			key.data = &data[0]
+		}
+		if _, ok := usage.managedBuffers[key]; !ok {
+			usage.managedBuffers[key] = struct{}{}
+			usage.total += managedBufferOverhead + uint64(len(data))
+		}
+	}
+	for _, point := range values.curvePoints {
+		if _, ok := usage.curvePoints[point]; !ok {
+			usage.curvePoints[point] = struct{}{}
+			usage.total += curvePointSize
+		}
+	}
+}
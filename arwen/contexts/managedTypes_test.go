@@ -0,0 +1,179 @@
+package contexts
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManagedTypes_PutGetBigFloat(t *testing.T) {
+	t.Parallel()
+
+	container := newManagedTypesContainer()
+
+	value := big.NewFloat(1.5)
+	handle := container.PutBigFloat(value)
+	require.Equal(t, int32(0), handle)
+
+	// The container keeps its own copy, at the precision of the BigFloat API
+	value.SetInt64(7)
+	stored, err := container.GetBigFloat(handle)
+	require.Nil(t, err)
+	require.Equal(t, 0, stored.Cmp(big.NewFloat(1.5)))
+	require.Equal(t, uint(arwen.BigFloatPrecision), stored.Prec())
+
+	_, err = container.GetBigFloat(handle + 1)
+	require.Equal(t, arwen.ErrNoBigFloatUnderThisHandle, err)
+}
+
+func TestManagedTypes_InitPushPopState(t *testing.T) {
+	t.Parallel()
+
+	container := newManagedTypesContainer()
+	handle1 := container.PutBigFloat(big.NewFloat(1))
+
+	// A nested execution starts with no values
+	container.PushState()
+	container.InitState()
+	_, err := container.GetBigFloat(handle1)
+	require.Equal(t, arwen.ErrNoBigFloatUnderThisHandle, err)
+	container.PutBigFloat(big.NewFloat(2))
+	require.Equal(t, uint64(2*bigFloatSize), container.MemoryUsage())
+
+	container.PopSetActiveState()
+	value1, err := container.GetBigFloat(handle1)
+	require.Nil(t, err)
+	require.Equal(t, 0, value1.Cmp(big.NewFloat(1)))
+
+	// Modifications are reverted by PopSetActiveState and kept by PopDiscard
+	container.PushState()
+	value1, _ = container.GetBigFloat(handle1)
+	value1.SetInt64(5)
+	container.PopSetActiveState()
+	value1, _ = container.GetBigFloat(handle1)
+	require.Equal(t, 0, value1.Cmp(big.NewFloat(1)))
+
+	container.PushState()
+	value1, _ = container.GetBigFloat(handle1)
+	value1.SetInt64(5)
+	container.PopDiscard()
+	value1, _ = container.GetBigFloat(handle1)
+	require.Equal(t, 0, value1.Cmp(big.NewFloat(5)))
+
+	container.PushState()
+	container.ClearStateStack()
+	require.Equal(t, 0, len(container.stateStack))
+	require.Equal(t, uint64(bigFloatSize), container.MemoryUsage())
+}
+
+func TestManagedTypes_ManagedMaps(t *testing.T) {
+	t.Parallel()
+
+	container := newManagedTypesContainer()
+	handle := container.NewManagedMap()
+	require.Equal(t, int32(0), handle)
+
+	_, err := container.GetManagedMap(handle + 1)
+	require.Equal(t, arwen.ErrNoManagedMapUnderThisHandle, err)
+
+	managedMap, err := container.GetManagedMap(handle)
+	require.Nil(t, err)
+	managedMap.Put([]byte("key"), []byte("value"))
+
+	// The changes of a failed nested execution on the same context are reverted
+	container.PushState()
+	managedMap, _ = container.GetManagedMap(handle)
+	managedMap.Put([]byte("other"), []byte("value"))
+	container.PopSetActiveState()
+
+	managedMap, _ = container.GetManagedMap(handle)
+	require.Equal(t, 1, managedMap.Len())
+	require.Equal(t, []byte("value"), managedMap.Get([]byte("key")))
+	require.Equal(t, uint64(managedMapOverhead)+managedMap.MemoryUsage(), container.MemoryUsage())
+}
+
+func TestManagedTypes_CopiedOnFirstRetrieval(t *testing.T) {
+	t.Parallel()
+
+	container := newManagedTypesContainer()
+	floatHandle := container.PutBigFloat(big.NewFloat(1))
+	mapHandle := container.NewManagedMap()
+	bufferHandle := container.NewManagedBuffer([]byte("abc"))
+	usage := container.MemoryUsage()
+
+	// PushState copies no value, so the memory held is unchanged
+	container.PushState()
+	require.Equal(t, usage, container.MemoryUsage())
+
+	// the values retrieved afterwards are copied, once
+	_, _ = container.GetBigFloat(floatHandle)
+	_, _ = container.GetBigFloat(floatHandle)
+	_, _ = container.GetManagedMap(mapHandle)
+	_, _ = container.GetManagedBuffer(bufferHandle)
+	require.Equal(t, usage+bigFloatSize+managedMapOverhead, container.MemoryUsage())
+
+	err := container.SetManagedBuffer(bufferHandle, []byte("changed"))
+	require.Nil(t, err)
+	require.Equal(t, usage+bigFloatSize+managedMapOverhead+managedBufferOverhead+7, container.MemoryUsage())
+
+	container.PopDiscard()
+	require.Equal(t, usage+7-3, container.MemoryUsage())
+}
+
+func TestManagedTypes_ManagedBuffers(t *testing.T) {
+	t.Parallel()
+
+	container := newManagedTypesContainer()
+	data := []byte("abc")
+	handle := container.NewManagedBuffer(data)
+	require.Equal(t, int32(0), handle)
+
+	// The container keeps its own copy of the data
+	data[0] = 'x'
+	stored, err := container.GetManagedBuffer(handle)
+	require.Nil(t, err)
+	require.Equal(t, []byte("abc"), stored)
+	require.Equal(t, uint64(managedBufferOverhead+3), container.MemoryUsage())
+
+	container.PushState()
+	err = container.SetManagedBuffer(handle, []byte("changed"))
+	require.Nil(t, err)
+	container.PopSetActiveState()
+
+	stored, _ = container.GetManagedBuffer(handle)
+	require.Equal(t, []byte("abc"), stored)
+
+	_, err = container.GetManagedBuffer(handle + 1)
+	require.Equal(t, arwen.ErrNoManagedBufferUnderThisHandle, err)
+	err = container.SetManagedBuffer(handle+1, nil)
+	require.Equal(t, arwen.ErrNoManagedBufferUnderThisHandle, err)
+}
+
+func TestManagedTypes_CurvePoints(t *testing.T) {
+	t.Parallel()
+
+	container := newManagedTypesContainer()
+	encoding := []byte{1, 2, 3}
+	handle := container.PutCurvePoint(1, encoding)
+	require.Equal(t, int32(0), handle)
+
+	// The container keeps its own copy of the encoding
+	encoding[0] = 9
+	point, err := container.GetCurvePoint(handle)
+	require.Nil(t, err)
+	require.Equal(t, int32(1), point.CurveID)
+	require.Equal(t, []byte{1, 2, 3}, point.Encoding)
+	require.Equal(t, uint64(curvePointSize), container.MemoryUsage())
+
+	container.PushState()
+	container.InitState()
+	_, err = container.GetCurvePoint(handle)
+	require.Equal(t, arwen.ErrNoCurvePointUnderThisHandle, err)
+	container.PopSetActiveState()
+
+	point, err = container.GetCurvePoint(handle)
+	require.Nil(t, err)
+	require.Equal(t, []byte{1, 2, 3}, point.Encoding)
+}
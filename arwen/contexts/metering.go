@@ -21,6 +21,24 @@ type meteringContext struct {
 	initialCost        uint64
 	gasForExecution    uint64
 	gasUsedByAccounts  map[string]uint64
+	callerGasLeft      uint64
+	gasProfiler        *gasProfiler
+	auditor            *meteringAuditor
+	snapshots          []*meteringSnapshot
+
+	hostFunctionTrackingEnabled bool
+	lastHostFunction            string
+}
+
+// meteringSnapshot is a checkpoint of the gas state of an execution, taken
+// before a nested execution is started
+type meteringSnapshot struct {
+	initialGasProvided uint64
+	initialCost        uint64
+	gasForExecution    uint64
+	gasUsedByAccounts  map[string]uint64
+	pointsUsed         uint64
+	stateStackLen      int
 }
 
 // NewMeteringContext creates a new meteringContext
@@ -41,6 +59,7 @@ func NewMeteringContext(
 		gasSchedule:       gasSchedule,
 		blockGasLimit:     blockGasLimit,
 		gasUsedByAccounts: make(map[string]uint64),
+		snapshots:         make([]*meteringSnapshot, 0),
 	}
 
 	context.InitState()
@@ -55,6 +74,14 @@ func (context *meteringContext) InitState() {
 	context.initialCost = 0
 	context.gasForExecution = 0
 	context.gasUsedByAccounts = make(map[string]uint64)
+
+	if len(context.stateStack) == 0 {
+		context.snapshots = make([]*meteringSnapshot, 0)
+		context.lastHostFunction = ""
+		if context.auditor != nil {
+			context.auditor.reset()
+		}
+	}
 }
 
 // InitStateFromContractCallInput initializes the internal state of the
@@ -73,6 +100,7 @@ func (context *meteringContext) PushState() {
 		initialCost:        context.initialCost,
 		gasForExecution:    context.gasForExecution,
 		gasUsedByAccounts:  context.cloneGasUsedByAccounts(),
+		callerGasLeft:      context.GasLeft(),
 	}
 
 	context.stateStack = append(context.stateStack, newState)
@@ -123,6 +151,60 @@ func (context *meteringContext) PopMergeActiveState() {
 	context.addToGasUsedByAccounts(prevState.gasUsedByAccounts)
 }
 
+// Snapshot saves the gas state of the current execution, including the gas
+// used so far by the current Wasmer instance, and returns an identifier of
+// the snapshot, to be passed to RestoreToSnapshot() or DiscardSnapshot().
+func (context *meteringContext) Snapshot() int {
+	snapshot := &meteringSnapshot{
+		initialGasProvided: context.initialGasProvided,
+		initialCost:        context.initialCost,
+		gasForExecution:    context.gasForExecution,
+		gasUsedByAccounts:  context.cloneGasUsedByAccounts(),
+		pointsUsed:         context.host.Runtime().GetPointsUsed(),
+		stateStackLen:      len(context.stateStack),
+	}
+
+	context.snapshots = append(context.snapshots, snapshot)
+	return len(context.snapshots) - 1
+}
+
+// RestoreToSnapshot restores the gas state saved by the given snapshot,
+// discarding the states pushed on the internal state stack after it was
+// taken, then removes the snapshot and all those taken after it. The Wasmer
+// instance which was current when the snapshot was taken must be the current
+// one again.
+func (context *meteringContext) RestoreToSnapshot(snapshot int) {
+	if snapshot < 0 || snapshot >= len(context.snapshots) {
+		return
+	}
+
+	savedState := context.snapshots[snapshot]
+	context.snapshots = context.snapshots[:snapshot]
+
+	context.initialGasProvided = savedState.initialGasProvided
+	context.initialCost = savedState.initialCost
+	context.gasForExecution = savedState.gasForExecution
+	context.gasUsedByAccounts = savedState.gasUsedByAccounts
+	if savedState.stateStackLen < len(context.stateStack) {
+		context.stateStack = context.stateStack[:savedState.stateStackLen]
+	}
+
+	context.host.Runtime().SetPointsUsed(savedState.pointsUsed)
+	if context.auditor != nil {
+		context.recordTransition("RestoreToSnapshot", 0, savedState.pointsUsed)
+	}
+}
+
+// DiscardSnapshot removes the given snapshot and all those taken after it,
+// keeping the current gas state.
+func (context *meteringContext) DiscardSnapshot(snapshot int) {
+	if snapshot < 0 || snapshot >= len(context.snapshots) {
+		return
+	}
+
+	context.snapshots = context.snapshots[:snapshot]
+}
+
 func (context *meteringContext) cloneGasUsedByAccounts() map[string]uint64 {
 	clone := make(map[string]uint64, len(context.gasUsedByAccounts))
 
@@ -142,11 +224,20 @@ func (context *meteringContext) addToGasUsedByAccounts(gasUsed map[string]uint64
 // UpdateGasStateOnSuccess performs final gas accounting after a successful execution.
 func (context *meteringContext) UpdateGasStateOnSuccess(vmOutput *vmcommon.VMOutput) error {
 	context.updateSCGasUsed()
+	context.applyGasModifier(vmOutput)
 	err := context.setGasUsedToOutputAccounts(vmOutput)
 	if err != nil {
 		return err
 	}
 
+	if context.isProfilingTopLevelExecution() {
+		totalGasUsed := uint64(0)
+		for _, gasUsed := range context.gasUsedByAccounts {
+			totalGasUsed = math.AddUint64(totalGasUsed, gasUsed)
+		}
+		context.gasProfiler.setGasUsed(totalGasUsed)
+	}
+
 	err = context.checkGas(vmOutput)
 	if err != nil {
 		return err
@@ -162,6 +253,14 @@ func (context *meteringContext) UpdateGasStateOnFailure(vmOutput *vmcommon.VMOut
 
 	account, _ := output.GetOutputAccount(runtime.GetSCAddress())
 	account.GasUsed = math.AddUint64(account.GasUsed, context.GetGasProvided())
+
+	if context.isProfilingTopLevelExecution() {
+		context.gasProfiler.setGasUsed(context.GetGasProvided())
+	}
+}
+
+func (context *meteringContext) isProfilingTopLevelExecution() bool {
+	return context.gasProfiler != nil && len(context.stateStack) == 0
 }
 
 func (context *meteringContext) updateSCGasUsed() {
@@ -182,6 +281,34 @@ func (context *meteringContext) updateSCGasUsed() {
 	context.gasUsedByAccounts[string(currentAccountAddress)] = gasUsed
 }
 
+// applyGasModifier scales the gas used by the current contract itself, not
+// including the gas used by the contracts it called, by the GasModifier
+// defined for its address; a premium is bounded by the gas remaining
+func (context *meteringContext) applyGasModifier(vmOutput *vmcommon.VMOutput) {
+	address := context.host.Runtime().GetSCAddress()
+	modifier, ok := context.host.Blockchain().GetGasModifier(address)
+	if !ok {
+		return
+	}
+
+	gasUsed := context.gasUsedByAccounts[string(address)]
+	modifiedGasUsed := modifier.Apply(gasUsed)
+	if modifiedGasUsed < gasUsed {
+		vmOutput.GasRemaining = math.AddUint64(vmOutput.GasRemaining, gasUsed-modifiedGasUsed)
+	} else {
+		premium := modifiedGasUsed - gasUsed
+		if premium > vmOutput.GasRemaining {
+			premium = vmOutput.GasRemaining
+		}
+		modifiedGasUsed = gasUsed + premium
+		vmOutput.GasRemaining -= premium
+	}
+
+	context.gasUsedByAccounts[string(address)] = modifiedGasUsed
+	vmOutput.Logs = append(vmOutput.Logs, modifier.LogEntry(address, gasUsed, modifiedGasUsed))
+	logMetering.Trace("gas modifier applied", "address", address, "gas used", gasUsed, "modified gas used", modifiedGasUsed)
+}
+
 // TrackGasUsedByBuiltinFunction computes the gas used by a builtin function
 // execution and consumes it on the current contract instance.
 func (context *meteringContext) TrackGasUsedByBuiltinFunction(
@@ -201,6 +328,10 @@ func (context *meteringContext) TrackGasUsedByBuiltinFunction(
 
 	context.UseGas(gasUsed)
 	logMetering.Trace("gas used by builtin function", "gas", gasUsed)
+
+	if context.gasProfiler != nil {
+		context.gasProfiler.recordBuiltinFunction(gasUsed)
+	}
 }
 
 func (context *meteringContext) checkGas(vmOutput *vmcommon.VMOutput) error {
@@ -311,23 +442,69 @@ func (context *meteringContext) SetGasSchedule(gasMap config.GasScheduleMap) {
 
 // UseGas sets in the runtime context the given gas as gas used
 func (context *meteringContext) UseGas(gas uint64) {
-	gasUsed := math.AddUint64(context.host.Runtime().GetPointsUsed(), gas)
+	pointsUsed := context.host.Runtime().GetPointsUsed()
+	if context.auditor != nil {
+		context.recordTransition("UseGas", gas, pointsUsed)
+		context.auditor.checkUseGas(gas, pointsUsed)
+	}
+
+	gasUsed := math.AddUint64(pointsUsed, gas)
 	context.host.Runtime().SetPointsUsed(gasUsed)
+
+	if context.gasProfiler != nil {
+		context.gasProfiler.recordGasUsedByCaller(gas)
+	}
+	if context.hostFunctionTrackingEnabled {
+		context.trackHostFunction()
+	}
+}
+
+func (context *meteringContext) trackHostFunction() {
+	name, ok := callingHostFunction(1)
+	if ok {
+		context.lastHostFunction = name
+	}
 }
 
 // RestoreGas subtracts the given gas from the gas used that is set in the runtime context.
 func (context *meteringContext) RestoreGas(gas uint64) {
 	gasUsed := context.host.Runtime().GetPointsUsed()
+	if context.auditor != nil {
+		context.recordTransition("RestoreGas", gas, gasUsed)
+		context.auditor.checkRestoreGas(gas, gasUsed)
+	}
+
 	if gas <= gasUsed {
 		gasUsed = math.SubUint64(gasUsed, gas)
 		context.host.Runtime().SetPointsUsed(gasUsed)
 	}
 }
 
+// RestoreLockedGas restores gas previously escrowed for a callback, right
+// before it is passed on to the callback or released because the callback
+// will not be executed
+func (context *meteringContext) RestoreLockedGas(gas uint64) {
+	if context.auditor != nil {
+		context.auditor.checkRestoreLockedGas(gas)
+	}
+
+	context.RestoreGas(gas)
+}
+
 // FreeGas adds the given gas to the refunded gas.
 func (context *meteringContext) FreeGas(gas uint64) {
-	refund := math.AddUint64(context.host.Output().GetRefund(), gas)
-	context.host.Output().SetRefund(refund)
+	currentRefund := context.host.Output().GetRefund()
+	if context.auditor != nil {
+		context.recordTransition("FreeGas", gas, context.host.Runtime().GetPointsUsed())
+		context.auditor.checkFreeGas(gas, currentRefund)
+	}
+
+	context.host.Output().AddRefund(arwen.RefundStorageRelease, gas)
+}
+
+func (context *meteringContext) recordTransition(operation string, gas uint64, pointsUsed uint64) {
+	address := context.host.Runtime().GetSCAddress()
+	context.auditor.record(operation, gas, pointsUsed, address)
 }
 
 // GasLeft returns how much gas is left.
@@ -335,6 +512,10 @@ func (context *meteringContext) GasLeft() uint64 {
 	gasProvided := context.gasForExecution
 	gasUsed := context.host.Runtime().GetPointsUsed()
 
+	if context.auditor != nil {
+		context.auditor.checkGasLeft(gasProvided, context.initialGasProvided)
+	}
+
 	if gasProvided < gasUsed {
 		return 0
 	}
@@ -342,6 +523,18 @@ func (context *meteringContext) GasLeft() uint64 {
 	return gasProvided - gasUsed
 }
 
+// GetGasLeftOfCallers returns the gas left of each execution suspended on
+// the state stack, from the outermost to the innermost, as it was when the
+// execution started its nested execution
+func (context *meteringContext) GetGasLeftOfCallers() []uint64 {
+	gasLeft := make([]uint64, len(context.stateStack))
+	for i, state := range context.stateStack {
+		gasLeft[i] = state.callerGasLeft
+	}
+
+	return gasLeft
+}
+
 // GasSpentByContract calculates the entire gas consumption of the contract,
 // without any gas forwarding.
 func (context *meteringContext) GasSpentByContract() uint64 {
@@ -411,9 +604,13 @@ func (context *meteringContext) UseGasBounded(gasToUse uint64) error {
 
 // ComputeGasLockedForAsync calculates the minimum amount of gas to lock for async callbacks
 func (context *meteringContext) ComputeGasLockedForAsync() uint64 {
+	codeSize := context.host.Runtime().GetSCCodeSize()
+	return context.computeGasLockedForCodeSize(codeSize)
+}
+
+func (context *meteringContext) computeGasLockedForCodeSize(codeSize uint64) uint64 {
 	baseGasSchedule := context.GasSchedule().BaseOperationCost
 	apiGasSchedule := context.GasSchedule().ElrondAPICost
-	codeSize := context.host.Runtime().GetSCCodeSize()
 
 	costPerByte := baseGasSchedule.CompilePerByte
 	if context.host.IsAheadOfTimeCompileEnabled() {
@@ -433,6 +630,67 @@ func (context *meteringContext) ComputeGasLockedForAsync() uint64 {
 	return gasLockedForAsync
 }
 
+// EstimateAsyncCallGas computes the minimum gas required by an async call
+// carrying dataLength bytes of data, registered by a contract having the code
+// size callerCodeSize, towards a contract having the code size destinationCodeSize
+func (context *meteringContext) EstimateAsyncCallGas(
+	callerCodeSize uint64,
+	destinationCodeSize uint64,
+	dataLength uint64,
+) *arwen.AsyncCallGasEstimate {
+	baseGasSchedule := context.GasSchedule().BaseOperationCost
+	apiGasSchedule := context.GasSchedule().ElrondAPICost
+
+	// The AsyncCallStep is consumed once by the EEI function and once more
+	// when the async call is set up by the runtime
+	registration := math.MulUint64(2, apiGasSchedule.AsyncCallStep)
+	registration = math.AddUint64(registration, math.MulUint64(baseGasSchedule.DataCopyPerByte, dataLength))
+
+	dispatch := uint64(0)
+	if destinationCodeSize > 0 {
+		costPerByte := baseGasSchedule.CompilePerByte
+		if context.host.IsAheadOfTimeCompileEnabled() {
+			costPerByte = baseGasSchedule.AoTPreparePerByte
+			dispatch = baseGasSchedule.GetCode
+		}
+		dispatch = math.AddUint64(dispatch, math.MulUint64(destinationCodeSize, costPerByte))
+	}
+
+	callback := context.computeGasLockedForCodeSize(callerCodeSize)
+
+	total := math.AddUint64(registration, dispatch)
+	total = math.AddUint64(total, callback)
+
+	return &arwen.AsyncCallGasEstimate{
+		Registration: registration,
+		Dispatch:     dispatch,
+		Callback:     callback,
+		Total:        total,
+	}
+}
+
+// EscrowGasForCallback removes from the available gas the amount required to
+// execute the callback of an async call, and returns it; the caller must keep
+// the escrowed gas alongside the async call until the callback is executed.
+// With dynamic gas locking, nothing is escrowed for calls without a callback.
+func (context *meteringContext) EscrowGasForCallback(hasCallback bool) (uint64, error) {
+	if !hasCallback && context.host.IsDynamicGasLockingEnabled() {
+		return 0, nil
+	}
+
+	gasToLock := context.ComputeGasLockedForAsync()
+	err := context.UseGasBounded(gasToLock)
+	if err != nil {
+		return 0, err
+	}
+
+	if context.auditor != nil {
+		context.auditor.recordGasEscrowed(gasToLock)
+	}
+
+	return gasToLock, nil
+}
+
 // GetGasLocked returns the locked gas
 func (context *meteringContext) GetGasLocked() uint64 {
 	input := context.host.Runtime().GetVMInput()
@@ -494,5 +752,58 @@ func (context *meteringContext) deductInitialGas(
 
 	context.initialCost = initialCost
 	context.gasForExecution = input.GasProvided - initialCost
+
+	if context.gasProfiler != nil {
+		context.gasProfiler.recordInitialCost(initialCost)
+	}
+
 	return nil
 }
+
+// EnableGasProfiling starts recording where the gas of each execution is
+// spent, to be retrieved with GetGasProfile()
+func (context *meteringContext) EnableGasProfiling() {
+	if context.gasProfiler == nil {
+		context.gasProfiler = newGasProfiler()
+	}
+}
+
+// EnableAudit makes the Metering context check every gas transition against
+// the invariants of the gas pools, panicking with a trace of the most recent
+// transitions when one is violated
+func (context *meteringContext) EnableAudit() {
+	if context.auditor == nil {
+		context.auditor = newMeteringAuditor()
+	}
+}
+
+// EnableHostFunctionTracking makes the Metering context record the last host
+// function which charged gas during each execution; like gas profiling, it
+// walks the Go call stack on every charge
+func (context *meteringContext) EnableHostFunctionTracking() {
+	context.hostFunctionTrackingEnabled = true
+}
+
+// GetLastHostFunction returns the last host function which charged gas
+// during the current execution, or an empty string if none did or if host
+// function tracking is not enabled
+func (context *meteringContext) GetLastHostFunction() string {
+	return context.lastHostFunction
+}
+
+// ResetGasProfile clears the gas profile, before a new execution
+func (context *meteringContext) ResetGasProfile() {
+	if context.gasProfiler != nil {
+		context.gasProfiler.reset()
+	}
+}
+
+// GetGasProfile returns the gas profile of the last execution, or nil if
+// gas profiling is not enabled
+func (context *meteringContext) GetGasProfile() *arwen.GasProfile {
+	if context.gasProfiler == nil {
+		return nil
+	}
+
+	return context.gasProfiler.buildProfile()
+}
@@ -0,0 +1,105 @@
+package contexts
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/math"
+)
+
+const meteringAuditTraceLength = 32
+
+// meteringTransition is a change of the gas pools of the Metering context,
+// as recorded by the meteringAuditor
+type meteringTransition struct {
+	operation  string
+	gas        uint64
+	pointsUsed uint64
+	address    []byte
+}
+
+// meteringAuditor checks every gas transition of the Metering context
+// against the invariants of the gas pools and panics with the most recent
+// transitions when one of them is violated. The panic is caught by the
+// VMHost, which then returns it as an error of the execution.
+type meteringAuditor struct {
+	trace               []meteringTransition
+	gasEscrowed         uint64
+	gasEscrowedRestored uint64
+}
+
+func newMeteringAuditor() *meteringAuditor {
+	auditor := &meteringAuditor{}
+	auditor.reset()
+
+	return auditor
+}
+
+func (auditor *meteringAuditor) reset() {
+	auditor.trace = make([]meteringTransition, 0, meteringAuditTraceLength)
+	auditor.gasEscrowed = 0
+	auditor.gasEscrowedRestored = 0
+}
+
+func (auditor *meteringAuditor) record(operation string, gas uint64, pointsUsed uint64, address []byte) {
+	if len(auditor.trace) == meteringAuditTraceLength {
+		auditor.trace = auditor.trace[1:]
+	}
+	auditor.trace = append(auditor.trace, meteringTransition{
+		operation:  operation,
+		gas:        gas,
+		pointsUsed: pointsUsed,
+		address:    address,
+	})
+}
+
+func (auditor *meteringAuditor) checkUseGas(gas uint64, pointsUsed uint64) {
+	_, err := math.AddUint64WithErr(pointsUsed, gas)
+	if err != nil {
+		auditor.fail("gas used overflows: %d used, %d more requested", pointsUsed, gas)
+	}
+}
+
+func (auditor *meteringAuditor) checkRestoreGas(gas uint64, pointsUsed uint64) {
+	if gas > pointsUsed {
+		auditor.fail("negative gas used: %d restored, but only %d used", gas, pointsUsed)
+	}
+}
+
+func (auditor *meteringAuditor) checkFreeGas(gas uint64, refund uint64) {
+	_, err := math.AddUint64WithErr(refund, gas)
+	if err != nil {
+		auditor.fail("gas refund overflows: %d refunded, %d more requested", refund, gas)
+	}
+}
+
+func (auditor *meteringAuditor) checkGasLeft(gasForExecution uint64, gasProvided uint64) {
+	if gasForExecution > gasProvided {
+		auditor.fail("negative initial cost: %d gas for execution, but only %d provided", gasForExecution, gasProvided)
+	}
+}
+
+func (auditor *meteringAuditor) recordGasEscrowed(gas uint64) {
+	auditor.gasEscrowed = math.AddUint64(auditor.gasEscrowed, gas)
+}
+
+func (auditor *meteringAuditor) checkRestoreLockedGas(gas uint64) {
+	gasOutstanding := auditor.gasEscrowed - auditor.gasEscrowedRestored
+	if gas > gasOutstanding {
+		auditor.fail("locked gas spent twice: %d restored, but only %d escrowed and not yet restored", gas, gasOutstanding)
+	}
+	auditor.gasEscrowedRestored += gas
+}
+
+func (auditor *meteringAuditor) fail(format string, args ...interface{}) {
+	var sb strings.Builder
+	sb.WriteString("metering audit: ")
+	sb.WriteString(fmt.Sprintf(format, args...))
+	sb.WriteString("\nmost recent metering transitions:")
+	for _, transition := range auditor.trace {
+		sb.WriteString(fmt.Sprintf("\n\t%-18s gas=%-12d pointsUsed=%-12d address=%x",
+			transition.operation, transition.gas, transition.pointsUsed, transition.address))
+	}
+
+	panic(sb.String())
+}
@@ -255,6 +255,159 @@ func TestMeteringContext_AsyncCallGasLocking(t *testing.T) {
 	require.Equal(t, gasProvided-1, meteringContext.GasLeft())
 }
 
+func TestMeteringContext_EscrowGasForCallback(t *testing.T) {
+	t.Parallel()
+
+	mockRuntime := &contextmock.RuntimeContextMock{}
+	input := &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			GasProvided: 1_000_000,
+		},
+	}
+	mockRuntime.SCCodeSize = 1000
+	mockRuntime.SetVMInput(&input.VMInput)
+	mockRuntime.SetPointsUsed(0)
+
+	host := &contextmock.VMHostMock{
+		RuntimeContext: mockRuntime,
+	}
+
+	meteringContext, _ := NewMeteringContext(host, config.MakeGasMapForTests(), uint64(15000))
+	meteringContext.gasForExecution = input.GasProvided
+
+	expectedGasLocked := meteringContext.ComputeGasLockedForAsync()
+	gasLocked, err := meteringContext.EscrowGasForCallback(true)
+	require.Nil(t, err)
+	require.Equal(t, expectedGasLocked, gasLocked)
+	require.Equal(t, input.GasProvided-gasLocked, meteringContext.GasLeft())
+
+	meteringContext.UseGas(meteringContext.GasLeft())
+	gasLocked, err = meteringContext.EscrowGasForCallback(true)
+	require.Equal(t, arwen.ErrNotEnoughGas, err)
+	require.Equal(t, uint64(0), gasLocked)
+}
+
+func TestMeteringContext_EscrowGasForCallback_NoCallback(t *testing.T) {
+	t.Parallel()
+
+	mockRuntime := &contextmock.RuntimeContextMock{}
+	input := &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			GasProvided: 1_000_000,
+		},
+	}
+	mockRuntime.SCCodeSize = 1000
+	mockRuntime.SetVMInput(&input.VMInput)
+	mockRuntime.SetPointsUsed(0)
+
+	host := &contextmock.VMHostMock{
+		RuntimeContext: mockRuntime,
+	}
+
+	meteringContext, _ := NewMeteringContext(host, config.MakeGasMapForTests(), uint64(15000))
+	meteringContext.gasForExecution = input.GasProvided
+
+	// the mocked host has dynamic gas locking enabled
+	gasLocked, err := meteringContext.EscrowGasForCallback(false)
+	require.Nil(t, err)
+	require.Equal(t, uint64(0), gasLocked)
+	require.Equal(t, input.GasProvided, meteringContext.GasLeft())
+}
+
+func TestMeteringContext_Audit_NegativeGasUsed(t *testing.T) {
+	t.Parallel()
+
+	mockRuntime := &contextmock.RuntimeContextMock{}
+	mockRuntime.SetPointsUsed(0)
+	host := &contextmock.VMHostMock{
+		RuntimeContext: mockRuntime,
+	}
+
+	meteringContext, _ := NewMeteringContext(host, config.MakeGasMapForTests(), uint64(15000))
+	meteringContext.RestoreGas(100)
+	require.Equal(t, uint64(0), mockRuntime.GetPointsUsed())
+
+	meteringContext.EnableAudit()
+	meteringContext.UseGas(50)
+	require.PanicsWithValue(t,
+		"metering audit: negative gas used: 100 restored, but only 50 used\n"+
+			"most recent metering transitions:\n"+
+			"\tUseGas             gas=50           pointsUsed=0            address=\n"+
+			"\tRestoreGas         gas=100          pointsUsed=50           address=",
+		func() { meteringContext.RestoreGas(100) },
+	)
+}
+
+func TestMeteringContext_Audit_LockedGasSpentTwice(t *testing.T) {
+	t.Parallel()
+
+	mockRuntime := &contextmock.RuntimeContextMock{}
+	input := &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			GasProvided: 1_000_000,
+		},
+	}
+	mockRuntime.SCCodeSize = 1000
+	mockRuntime.SetVMInput(&input.VMInput)
+	mockRuntime.SetPointsUsed(0)
+
+	host := &contextmock.VMHostMock{
+		RuntimeContext: mockRuntime,
+	}
+
+	meteringContext, _ := NewMeteringContext(host, config.MakeGasMapForTests(), uint64(15000))
+	meteringContext.EnableAudit()
+	meteringContext.InitStateFromContractCallInput(&input.VMInput)
+
+	gasLocked, err := meteringContext.EscrowGasForCallback(true)
+	require.Nil(t, err)
+
+	meteringContext.RestoreLockedGas(gasLocked)
+	require.Equal(t, input.GasProvided, meteringContext.GasLeft())
+
+	meteringContext.UseGas(gasLocked)
+	require.Panics(t, func() { meteringContext.RestoreLockedGas(gasLocked) })
+}
+
+func TestMeteringContext_Snapshots(t *testing.T) {
+	t.Parallel()
+
+	mockRuntime := &contextmock.RuntimeContextMock{}
+	host := &contextmock.VMHostMock{
+		RuntimeContext: mockRuntime,
+	}
+
+	meteringContext, _ := NewMeteringContext(host, config.MakeGasMapForTests(), uint64(15000))
+	meteringContext.InitStateFromContractCallInput(&vmcommon.VMInput{GasProvided: 1000})
+	meteringContext.UseGas(100)
+	meteringContext.gasUsedByAccounts["parent"] = 100
+
+	outer := meteringContext.Snapshot()
+
+	// Simulate a nested execution which changes the gas state.
+	meteringContext.UseGas(300)
+	meteringContext.PushState()
+	meteringContext.InitStateFromContractCallInput(&vmcommon.VMInput{GasProvided: 300})
+	meteringContext.gasUsedByAccounts["child"] = 200
+
+	inner := meteringContext.Snapshot()
+	meteringContext.UseGas(50)
+	meteringContext.DiscardSnapshot(inner)
+	require.Equal(t, 1, len(meteringContext.snapshots))
+
+	meteringContext.RestoreToSnapshot(outer)
+	require.Equal(t, 0, len(meteringContext.snapshots))
+	require.Equal(t, 0, len(meteringContext.stateStack))
+	require.Equal(t, uint64(100), mockRuntime.GetPointsUsed())
+	require.Equal(t, uint64(1000), meteringContext.GetGasProvided())
+	require.Equal(t, map[string]uint64{"parent": 100}, meteringContext.gasUsedByAccounts)
+
+	// Restoring a removed snapshot does nothing.
+	meteringContext.UseGas(10)
+	meteringContext.RestoreToSnapshot(outer)
+	require.Equal(t, uint64(110), mockRuntime.GetPointsUsed())
+}
+
 func TestMeteringContext_GasUsed_NoStacking(t *testing.T) {
 	t.Parallel()
 	const BlockGasLimit = uint64(15000)
@@ -300,6 +453,7 @@ func setUpStackOneLevel(t *testing.T, parentInput *vmcommon.ContractCallInput, c
 	host := &contextmock.VMHostMock{
 		RuntimeContext: mockRuntime,
 	}
+	host.BlockchainContext, _ = NewBlockchainContext(host, &contextmock.BlockchainHookStub{})
 
 	contractSize := uint64(1000)
 	contract := make([]byte, contractSize)
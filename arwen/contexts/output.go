@@ -3,9 +3,11 @@ package contexts
 import (
 	"encoding/hex"
 	"errors"
+	"fmt"
 	"math/big"
 
 	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/math"
 	logger "github.com/ElrondNetwork/elrond-go-logger"
 	"github.com/ElrondNetwork/elrond-go/core"
 	"github.com/ElrondNetwork/elrond-go/core/vmcommon"
@@ -20,6 +22,46 @@ type outputContext struct {
 	outputState *vmcommon.VMOutput
 	stateStack  []*vmcommon.VMOutput
 	codeUpdates map[string]struct{}
+	snapshots   []*outputSnapshot
+	levelStack  []outputLevel
+
+	order         *outputOrder
+	refunds       arwen.ItemizedRefunds
+	receipts      []arwen.LogReceipt
+	externalValue *big.Int
+
+	errorCodesInReturnMessage bool
+	itemizedRefundsInVMOutput bool
+	transferAggregation       bool
+
+	logRetention       arwen.LogRetentionPolicy
+	eventLogLimits     arwen.EventLogLimits
+	numLogEntries      uint64
+	numLogBytes        uint64
+	numDroppedLogs     uint64
+	numDroppedLogBytes uint64
+	logsTruncated      bool
+
+	maxReturnDataSize  uint64
+	numReturnDataBytes uint64
+}
+
+// outputLevel is the bookkeeping of an output state which is not part of its
+// VMOutput; it is saved along with the output state, both on the state stack
+// and in the snapshots, and restored when the output state is reverted.
+type outputLevel struct {
+	order              *outputOrder
+	refunds            arwen.ItemizedRefunds
+	numReturnDataBytes uint64
+	numReceipts        int
+	externalValue      *big.Int
+}
+
+type outputSnapshot struct {
+	key         string
+	state       *vmcommon.VMOutput
+	codeUpdates map[string]struct{}
+	level       outputLevel
 }
 
 // NewOutputContext creates a new outputContext
@@ -27,6 +69,8 @@ func NewOutputContext(host arwen.VMHost) (*outputContext, error) {
 	context := &outputContext{
 		host:       host,
 		stateStack: make([]*vmcommon.VMOutput, 0),
+		snapshots:  make([]*outputSnapshot, 0),
+		levelStack: make([]outputLevel, 0),
 	}
 
 	context.InitState()
@@ -34,10 +78,56 @@ func NewOutputContext(host arwen.VMHost) (*outputContext, error) {
 	return context, nil
 }
 
+// SetErrorCodesInReturnMessage enables or disables prefixing the ReturnMessage
+// of failed executions with the code and subsystem of the error
+func (context *outputContext) SetErrorCodesInReturnMessage(enabled bool) {
+	context.errorCodesInReturnMessage = enabled
+}
+
+// SetItemizedRefundsInVMOutput enables or disables recording the
+// ItemizedRefunds of top-level executions in their VMOutput, as a log entry
+func (context *outputContext) SetItemizedRefundsInVMOutput(enabled bool) {
+	context.itemizedRefundsInVMOutput = enabled
+}
+
+// SetTransferAggregation sets whether the adjacent compatible OutputTransfers
+// made to the same destination during a transaction are merged into one
+func (context *outputContext) SetTransferAggregation(enabled bool) {
+	context.transferAggregation = enabled
+}
+
+// SetLogRetentionPolicy sets the caps on the logs which the contracts may
+// write during a transaction
+func (context *outputContext) SetLogRetentionPolicy(policy arwen.LogRetentionPolicy) {
+	context.logRetention = policy
+}
+
+// SetMaxReturnDataSize sets the maximum number of bytes of return data which
+// may be finished during a transaction; 0 leaves it unlimited
+func (context *outputContext) SetMaxReturnDataSize(maxReturnDataSize uint64) {
+	context.maxReturnDataSize = maxReturnDataSize
+}
+
+// SetEventLogLimits sets the limits on the shape of the event logs which the
+// contracts may write
+func (context *outputContext) SetEventLogLimits(limits arwen.EventLogLimits) {
+	context.eventLogLimits = limits
+}
+
 // InitState initializes the output state and the code updates.
 func (context *outputContext) InitState() {
 	context.outputState = newVMOutput()
 	context.codeUpdates = make(map[string]struct{})
+	context.refunds = arwen.ItemizedRefunds{}
+	context.order = newOutputOrder()
+	context.numReturnDataBytes = 0
+	context.numLogEntries = 0
+	context.numLogBytes = 0
+	context.numDroppedLogs = 0
+	context.numDroppedLogBytes = 0
+	context.logsTruncated = false
+	context.receipts = make([]arwen.LogReceipt, 0)
+	context.externalValue = big.NewInt(0)
 }
 
 func newVMOutput() *vmcommon.VMOutput {
@@ -70,6 +160,7 @@ func (context *outputContext) PushState() {
 	newState := newVMOutput()
 	mergeVMOutputs(newState, context.outputState)
 	context.stateStack = append(context.stateStack, newState)
+	context.levelStack = append(context.levelStack, context.saveLevel())
 }
 
 // PopSetActiveState removes the latest entry from the state stack and sets it as the current vm output
@@ -82,6 +173,7 @@ func (context *outputContext) PopSetActiveState() {
 	prevState := context.stateStack[stateStackLen-1]
 	context.stateStack = context.stateStack[:stateStackLen-1]
 	context.outputState = prevState
+	context.restoreLevel(context.popLevel())
 }
 
 // PopMergeActiveState merges the current state into the head of the stateStack,
@@ -101,6 +193,9 @@ func (context *outputContext) PopMergeActiveState() {
 	mergeVMOutputs(prevState, context.outputState)
 	context.outputState = newVMOutput()
 	mergeVMOutputs(context.outputState, prevState)
+
+	// the current level already includes the bookkeeping of the previous state
+	context.popLevel()
 }
 
 // PopDiscard removes the latest entry from the state stack, but maintaining
@@ -112,11 +207,109 @@ func (context *outputContext) PopDiscard() {
 	}
 
 	context.stateStack = context.stateStack[:stateStackLen-1]
+	context.popLevel()
+}
+
+// saveLevel returns a copy of the bookkeeping of the current output state
+func (context *outputContext) saveLevel() outputLevel {
+	return outputLevel{
+		order:              context.order.clone(),
+		refunds:            context.refunds,
+		numReturnDataBytes: context.numReturnDataBytes,
+		numReceipts:        len(context.receipts),
+		externalValue:      big.NewInt(0).Set(context.externalValue),
+	}
+}
+
+// restoreLevel reverts the bookkeeping of the current output state to the
+// given level; the receipts added since the level was saved are dropped,
+// because the outcomes they describe were reverted as well
+func (context *outputContext) restoreLevel(level outputLevel) {
+	context.order = level.order
+	context.refunds = level.refunds
+	context.numReturnDataBytes = level.numReturnDataBytes
+	context.externalValue = level.externalValue
+	if level.numReceipts < len(context.receipts) {
+		context.receipts = context.receipts[:level.numReceipts]
+	}
+}
+
+// popLevel removes the level pushed along with the latest entry of the state
+// stack and returns it.
+func (context *outputContext) popLevel() outputLevel {
+	levelStackLen := len(context.levelStack)
+	if levelStackLen == 0 {
+		return context.saveLevel()
+	}
+
+	level := context.levelStack[levelStackLen-1]
+	context.levelStack = context.levelStack[:levelStackLen-1]
+	return level
 }
 
-// ClearStateStack reinitializes the state stack.
+// ClearStateStack reinitializes the state stack and the snapshots.
 func (context *outputContext) ClearStateStack() {
 	context.stateStack = make([]*vmcommon.VMOutput, 0)
+	context.snapshots = make([]*outputSnapshot, 0)
+	context.levelStack = make([]outputLevel, 0)
+}
+
+// TakeSnapshot saves a copy of the current output state under the given key
+func (context *outputContext) TakeSnapshot(key string) {
+	state := newVMOutput()
+	mergeVMOutputs(state, context.outputState)
+	for _, account := range state.OutputAccounts {
+		account.BalanceDelta = big.NewInt(0).Set(account.BalanceDelta)
+	}
+
+	codeUpdates := make(map[string]struct{}, len(context.codeUpdates))
+	for address := range context.codeUpdates {
+		codeUpdates[address] = struct{}{}
+	}
+
+	context.snapshots = append(context.snapshots, &outputSnapshot{
+		key:         key,
+		state:       state,
+		codeUpdates: codeUpdates,
+		level:       context.saveLevel(),
+	})
+}
+
+// RevertToSnapshot restores the output state saved under the given key and removes the snapshots taken since
+func (context *outputContext) RevertToSnapshot(key string) error {
+	index := context.findSnapshot(key)
+	if index < 0 {
+		return arwen.ErrOutputSnapshotNotFound
+	}
+
+	snapshot := context.snapshots[index]
+	context.snapshots = context.snapshots[:index]
+	context.outputState = snapshot.state
+	context.codeUpdates = snapshot.codeUpdates
+	context.restoreLevel(snapshot.level)
+
+	logOutput.Trace("reverted to snapshot", "key", key)
+	return nil
+}
+
+// DiscardSnapshot removes the snapshot saved under the given key and the snapshots taken since
+func (context *outputContext) DiscardSnapshot(key string) {
+	index := context.findSnapshot(key)
+	if index < 0 {
+		return
+	}
+
+	context.snapshots = context.snapshots[:index]
+}
+
+func (context *outputContext) findSnapshot(key string) int {
+	for index := len(context.snapshots) - 1; index >= 0; index-- {
+		if context.snapshots[index].key == key {
+			return index
+		}
+	}
+
+	return -1
 }
 
 // CensorVMOutput will cause the next executed SC to appear isolated, as if
@@ -143,6 +336,7 @@ func (context *outputContext) GetOutputAccount(address []byte) (*vmcommon.Output
 	if !ok {
 		account = NewVMOutputAccount(address)
 		context.outputState.OutputAccounts[string(address)] = account
+		context.order.addAccount(address)
 		accountIsNew = true
 	}
 
@@ -154,10 +348,18 @@ func (context *outputContext) GetOutputAccounts() map[string]*vmcommon.OutputAcc
 	return context.outputState.OutputAccounts
 }
 
-// DeleteOutputAccount removes the given address from the output accounts and code updates
+// DeleteOutputAccount removes the given address from the output accounts and
+// code updates; the balance delta of the removed account leaves the output
+// accounts along with it
 func (context *outputContext) DeleteOutputAccount(address []byte) {
+	account, ok := context.outputState.OutputAccounts[string(address)]
+	if ok {
+		context.addExternalValue(big.NewInt(0).Neg(balanceDeltaOf(account)))
+	}
+
 	delete(context.outputState.OutputAccounts, string(address))
 	delete(context.codeUpdates, string(address))
+	context.order.removeAccount(address)
 }
 
 // GetRefund returns the value of the gas refund for the current output state.
@@ -170,6 +372,24 @@ func (context *outputContext) SetRefund(refund uint64) {
 	context.outputState.GasRefund = big.NewInt(int64(refund))
 }
 
+// AddRefund adds the given gas to a category of the ItemizedRefunds of the
+// current output state; gas refunded for storage release is also added to
+// the GasRefund of the current output state.
+func (context *outputContext) AddRefund(category arwen.RefundCategory, gas uint64) {
+	if category == arwen.RefundStorageRelease {
+		context.SetRefund(math.AddUint64(context.GetRefund(), gas))
+	}
+
+	context.refunds = context.refunds.Add(category, gas)
+	logOutput.Trace("refund", "category", category.String(), "gas", gas)
+}
+
+// GetItemizedRefunds returns the gas refunded so far during the execution,
+// by category; the refunds of failed nested executions are not included.
+func (context *outputContext) GetItemizedRefunds() arwen.ItemizedRefunds {
+	return context.refunds
+}
+
 // ReturnData returns the data of the current output state.
 func (context *outputContext) ReturnData() [][]byte {
 	return context.outputState.ReturnData
@@ -205,9 +425,31 @@ func (context *outputContext) ClearReturnData() {
 func (context *outputContext) SelfDestruct(_ []byte, _ []byte) {
 }
 
-// Finish appends the given data to the return data of the current output state.
-func (context *outputContext) Finish(data []byte) {
+// Finish appends the given data to the return data of the current output
+// state. The return data is accounted for the whole transaction, including
+// the data finished by the VM itself; the data of nested executions which
+// fail is no longer accounted once their state is reverted. Data which would
+// exceed the maximum return data size is refused with ErrReturnDataTooLarge.
+func (context *outputContext) Finish(data []byte) error {
+	dataSize := uint64(len(data))
+	if context.maxReturnDataSize > 0 && context.numReturnDataBytes+dataSize > context.maxReturnDataSize {
+		logOutput.Trace("return data", "error", "return data too large", "size", dataSize)
+		return arwen.ErrReturnDataTooLarge
+	}
+
+	context.numReturnDataBytes += dataSize
 	context.outputState.ReturnData = append(context.outputState.ReturnData, data)
+	return nil
+}
+
+// GetReturnDataBudget returns the number of bytes of return data which
+// may still be finished during the current transaction, or -1 if the return
+// data is unlimited
+func (context *outputContext) GetReturnDataBudget() int64 {
+	if context.maxReturnDataSize == 0 {
+		return -1
+	}
+	return int64(context.maxReturnDataSize - context.numReturnDataBytes)
 }
 
 // PrependFinish appends the given data to the return data of the current output state.
@@ -240,6 +482,47 @@ func (context *outputContext) WriteLog(address []byte, topics [][]byte, data []b
 	logOutput.Trace("log entry", "identifier", newLogEntry.Identifier, "topics", newLogEntry.Topics)
 }
 
+// WriteContractLog writes a log entry on behalf of a contract, subject to the
+// LogRetentionPolicy. The logs are accounted for the whole transaction, even
+// if written by nested executions which later fail; once a log entry is
+// dropped, all the following ones are dropped as well, and the VMOutput of the
+// transaction is marked with a LogsTruncatedLogEntry.
+func (context *outputContext) WriteContractLog(address []byte, topics [][]byte, data []byte) {
+	if context.host.Runtime().ReadOnly() {
+		logOutput.Trace("log entry", "error", "cannot write logs in readonly mode")
+		return
+	}
+
+	entrySize := arwen.LogEntrySize(topics, data)
+	if context.logsTruncated || !context.logRetention.Retains(context.numLogEntries, context.numLogBytes, entrySize) {
+		context.logsTruncated = true
+		context.numDroppedLogs++
+		context.numDroppedLogBytes += entrySize
+		logOutput.Trace("log entry", "dropped", context.numDroppedLogs, "size", entrySize)
+		return
+	}
+
+	context.numLogEntries++
+	context.numLogBytes += entrySize
+	context.WriteLog(address, topics, data)
+}
+
+// WriteEventLog validates the given EventLog against the EventLogLimits and
+// writes it on behalf of the contract at the given address, subject to the
+// LogRetentionPolicy, like WriteContractLog. The EventLog is not validated
+// before the activation of the event log validation.
+func (context *outputContext) WriteEventLog(address []byte, event *arwen.EventLog) error {
+	if context.host.IsEventLogValidationEnabled() {
+		err := context.eventLogLimits.Validate(event)
+		if err != nil {
+			return err
+		}
+	}
+
+	context.WriteContractLog(address, event.LogTopics(), event.Data)
+	return nil
+}
+
 // TransferValueOnly will transfer the big.int value and checks if it is possible
 func (context *outputContext) TransferValueOnly(destination []byte, sender []byte, value *big.Int, checkPayable bool) error {
 	logOutput.Trace("transfer value", "sender", sender, "dest", destination, "value", value)
@@ -297,6 +580,7 @@ func (context *outputContext) Transfer(destination []byte, sender []byte, gasLim
 		SenderAddress: sender,
 	}
 	destAcc.OutputTransfers = append(destAcc.OutputTransfers, outputTransfer)
+	context.order.addTransfer(destination, len(destAcc.OutputTransfers)-1)
 
 	logOutput.Trace("transfer value added")
 	return nil
@@ -375,14 +659,84 @@ func (context *outputContext) TransferESDT(
 	}
 
 	if callInput != nil {
-		scCallData := "@" + hex.EncodeToString([]byte(callInput.Function))
-		for _, arg := range callInput.Arguments {
-			scCallData += "@" + hex.EncodeToString(arg)
+		scCallArguments := append([][]byte{[]byte(callInput.Function)}, callInput.Arguments...)
+		outputTransfer.Data = append(outputTransfer.Data, arwen.EncodeCallArguments(scCallArguments)...)
+	}
+
+	destAcc.OutputTransfers = append(destAcc.OutputTransfers, outputTransfer)
+	context.order.addTransfer(destination, len(destAcc.OutputTransfers)-1)
+
+	return gasRemaining, nil
+}
+
+// TransferMultiESDT transfers several esdt/nft tokens to the same destination
+// at once and exports the data if it is cross shard
+func (context *outputContext) TransferMultiESDT(
+	destination []byte,
+	sender []byte,
+	transfers []*arwen.ESDTTransfer,
+	callInput *vmcommon.ContractCallInput,
+) (uint64, error) {
+	isSmartContract := context.host.Blockchain().IsSmartContract(destination)
+	sameShard := context.host.AreInSameShard(sender, destination)
+	callType := vmcommon.DirectCall
+	isExecution := isSmartContract && callInput != nil
+	if isExecution {
+		callType = vmcommon.ESDTTransferAndExecute
+	}
+
+	vmOutput, gasConsumedByTransfer, err := context.host.ExecuteMultiESDTTransfer(destination, sender, transfers, callType)
+	if err != nil {
+		return 0, err
+	}
+
+	gasRemaining := uint64(0)
+
+	if isExecution {
+		if gasConsumedByTransfer > callInput.GasProvided {
+			logOutput.Trace("multi ESDT post-transfer execution", "error", arwen.ErrNotEnoughGas)
+			return 0, arwen.ErrNotEnoughGas
+		}
+		gasRemaining = callInput.GasProvided - gasConsumedByTransfer
+
+		if gasRemaining > context.host.Metering().GasLeft() {
+			logOutput.Trace("multi ESDT post-transfer execution", "error", arwen.ErrNotEnoughGas)
+			return 0, arwen.ErrNotEnoughGas
+		}
+
+		if !sameShard {
+			context.host.Metering().UseGas(gasRemaining)
 		}
-		outputTransfer.Data = append(outputTransfer.Data, []byte(scCallData)...)
+	}
+
+	destAcc, _ := context.GetOutputAccount(destination)
+	outputTransfer := vmcommon.OutputTransfer{
+		Value:         big.NewInt(0),
+		GasLimit:      gasRemaining,
+		GasLocked:     0,
+		Data:          arwen.EncodeCallData(arwen.BuiltInFunctionMultiESDTNFTTransfer, arwen.MultiESDTNFTTransferArguments(destination, transfers)),
+		CallType:      vmcommon.DirectCall,
+		SenderAddress: sender,
+	}
+
+	if !sameShard && vmOutput != nil {
+		outTransfer, ok := vmOutput.OutputAccounts[string(destination)]
+		if ok && len(outTransfer.OutputTransfers) == 1 {
+			outputTransfer.Data = outTransfer.OutputTransfers[0].Data
+		}
+	}
+
+	if sameShard {
+		outputTransfer.GasLimit = 0
+	}
+
+	if callInput != nil {
+		scCallArguments := append([][]byte{[]byte(callInput.Function)}, callInput.Arguments...)
+		outputTransfer.Data = append(outputTransfer.Data, arwen.EncodeCallArguments(scCallArguments)...)
 	}
 
 	destAcc.OutputTransfers = append(destAcc.OutputTransfers, outputTransfer)
+	context.order.addTransfer(destination, len(destAcc.OutputTransfers)-1)
 
 	return gasRemaining, nil
 }
@@ -396,9 +750,69 @@ func (context *outputContext) hasSufficientBalance(address []byte, value *big.In
 func (context *outputContext) AddTxValueToAccount(address []byte, value *big.Int) {
 	destAcc, _ := context.GetOutputAccount(address)
 	destAcc.BalanceDelta = big.NewInt(0).Add(destAcc.BalanceDelta, value)
+	context.addExternalValue(value)
+}
+
+// CheckValueConservation verifies that the balance deltas of the output
+// accounts add up to the value which entered them from outside the VM, i.e.
+// the value of the transaction and the balance changes made by built-in
+// functions. Transfers between the output accounts leave this sum unchanged,
+// so a mismatch means that value was created or lost, e.g. by accounting the
+// value of a nested execution twice.
+func (context *outputContext) CheckValueConservation() error {
+	sum := big.NewInt(0)
+	for _, account := range context.outputState.OutputAccounts {
+		sum.Add(sum, balanceDeltaOf(account))
+	}
+
+	if sum.Cmp(context.externalValue) != 0 {
+		return fmt.Errorf("%w: balance deltas add up to %s, expected %s", arwen.ErrValueNotConserved, sum, context.externalValue)
+	}
+
+	return nil
+}
+
+func (context *outputContext) addExternalValue(value *big.Int) {
+	if value == nil {
+		return
+	}
+
+	context.externalValue = big.NewInt(0).Add(context.externalValue, value)
+}
+
+func balanceDeltaOf(account *vmcommon.OutputAccount) *big.Int {
+	if account.BalanceDelta == nil {
+		return arwen.Zero
+	}
+
+	return account.BalanceDelta
+}
+
+// GetOrderedVMOutput returns a view of the given VMOutput in which its
+// OutputAccounts are listed in the order in which they were created and its
+// OutputTransfers in the order in which they were made, as recorded for the
+// current output state
+func (context *outputContext) GetOrderedVMOutput(vmOutput *vmcommon.VMOutput) *arwen.OrderedVMOutput {
+	accounts := context.order.orderAccounts(vmOutput)
+
+	return &arwen.OrderedVMOutput{
+		ReturnData:      vmOutput.ReturnData,
+		ReturnCode:      vmOutput.ReturnCode,
+		ReturnMessage:   vmOutput.ReturnMessage,
+		GasRemaining:    vmOutput.GasRemaining,
+		GasRefund:       vmOutput.GasRefund,
+		OutputAccounts:  accounts,
+		OutputTransfers: context.order.orderTransfers(accounts),
+		DeletedAccounts: vmOutput.DeletedAccounts,
+		TouchedAccounts: vmOutput.TouchedAccounts,
+		Logs:            vmOutput.Logs,
+	}
 }
 
-// GetVMOutput updates the current VMOutput and returns it
+// GetVMOutput updates the current VMOutput and returns a copy of it; at the
+// top level, the logs of the copy are followed by the itemized refunds, the
+// receipts and the marker of truncated logs, which are not added to the
+// output state, so that repeated calls return the same VMOutput
 func (context *outputContext) GetVMOutput() *vmcommon.VMOutput {
 	context.removeNonUpdatedCode()
 
@@ -410,7 +824,102 @@ func (context *outputContext) GetVMOutput() *vmcommon.VMOutput {
 		return context.CreateVMOutputInCaseOfError(err)
 	}
 
-	return context.outputState
+	vmOutput := *context.outputState
+	vmOutput.Logs = append(make([]*vmcommon.LogEntry, 0, len(context.outputState.Logs)), context.outputState.Logs...)
+
+	isTopLevel := len(context.stateStack) == 0
+	if !isTopLevel {
+		return &vmOutput
+	}
+
+	if context.transferAggregation {
+		context.aggregateOutputTransfers()
+	}
+
+	address := context.host.Runtime().GetSCAddress()
+	if context.itemizedRefundsInVMOutput && !context.refunds.IsZero() {
+		vmOutput.Logs = append(vmOutput.Logs, context.refunds.LogEntry(address))
+	}
+
+	receipts := context.receipts
+	if context.host.IsRefundReceiptsEnabled() {
+		receipts = append(receipts[:len(receipts):len(receipts)], context.refundReceipts()...)
+	}
+	for _, receipt := range receipts {
+		vmOutput.Logs = append(vmOutput.Logs, receipt.LogEntry())
+	}
+
+	if context.logsTruncated {
+		marker := arwen.LogsTruncatedLogEntry(address, context.numDroppedLogs, context.numDroppedLogBytes)
+		vmOutput.Logs = append(vmOutput.Logs, marker)
+	}
+
+	return &vmOutput
+}
+
+// aggregateOutputTransfers merges the adjacent compatible OutputTransfers made
+// to each account of the current output state, i.e. the plain transfers of
+// the same token, from the same sender and with the same call type, to reduce
+// the number of transfers the node has to dispatch, especially cross-shard.
+func (context *outputContext) aggregateOutputTransfers() {
+	for _, address := range sortedAccountAddresses(context.outputState.OutputAccounts) {
+		account := context.outputState.OutputAccounts[address]
+		if len(account.OutputTransfers) < 2 {
+			continue
+		}
+
+		transfers, positions := aggregateTransfers(account.OutputTransfers)
+		account.OutputTransfers = transfers
+		context.order.remapTransfers(account.Address, positions)
+	}
+}
+
+// refundReceipts returns the RefundReceipts of the top-level execution; only
+// the executions started directly by their caller have a payer, the refunds
+// of async calls and callbacks being settled by the node with the original
+// caller.
+func (context *outputContext) refundReceipts() []arwen.LogReceipt {
+	input := context.host.Runtime().GetVMInput()
+	if input.CallType != vmcommon.DirectCall && input.CallType != vmcommon.ESDTTransferAndExecute {
+		return nil
+	}
+
+	storageRelease := uint64(0)
+	if context.outputState.GasRefund != nil && context.outputState.GasRefund.IsUint64() {
+		storageRelease = context.outputState.GasRefund.Uint64()
+	}
+
+	receipts := arwen.RefundReceipts(
+		input.CallerAddr,
+		context.outputState.GasRemaining,
+		storageRelease,
+		context.refunds,
+		input.GasPrice,
+	)
+
+	logReceipts := make([]arwen.LogReceipt, 0, len(receipts))
+	for _, receipt := range receipts {
+		logReceipts = append(logReceipts, receipt)
+	}
+	return logReceipts
+}
+
+// AddReceipt records the given receipt of an outcome of the current
+// execution, to be attached to the VMOutput of the transaction; it is
+// dropped if the execution is reverted.
+func (context *outputContext) AddReceipt(receipt arwen.LogReceipt) {
+	context.receipts = append(context.receipts, receipt)
+}
+
+// AttachReceipt appends the given receipt directly to the given VMOutput; it
+// is meant for the outcomes of failed executions, whose output state is
+// discarded along with the receipts added to it.
+func (context *outputContext) AttachReceipt(vmOutput *vmcommon.VMOutput, receipt arwen.LogReceipt) {
+	if vmOutput == nil {
+		return
+	}
+
+	vmOutput.Logs = append(vmOutput.Logs, receipt.LogEntry())
 }
 
 // DeployCode sets the given code to a an account, and creates a new codeUpdates entry at the accounts address.
@@ -442,6 +951,10 @@ func (context *outputContext) CreateVMOutputInCaseOfError(err error) *vmcommon.V
 		}
 	}
 
+	if context.errorCodesInReturnMessage {
+		message = arwen.FormatReturnMessage(err, message)
+	}
+
 	returnCode := context.resolveReturnCodeFromError(err)
 	vmOutput := &vmcommon.VMOutput{
 		GasRemaining:  0,
@@ -450,6 +963,29 @@ func (context *outputContext) CreateVMOutputInCaseOfError(err error) *vmcommon.V
 		ReturnMessage: message,
 	}
 
+	// the call stack is only reconstructed in debug mode, which is also when
+	// the reason is recorded in the VMOutput
+	userErrorReason := runtime.GetUserErrorReason()
+	if userErrorReason != nil && len(userErrorReason.CallStack) > 0 {
+		vmOutput.Logs = []*vmcommon.LogEntry{userErrorReason.LogEntry()}
+	}
+
+	// running out of gas outside a contract, e.g. while deducting the initial
+	// cost, is attributed to the function about to be executed
+	if returnCode == vmcommon.OutOfGas {
+		runtime.CaptureOutOfGasReceipt()
+		outOfGasReceipt := runtime.GetOutOfGasReceipt()
+		if outOfGasReceipt != nil {
+			context.AttachReceipt(vmOutput, outOfGasReceipt)
+		}
+	}
+
+	runtime.CaptureCallStackTrace(message)
+	callStackTrace := runtime.GetCallStackTrace()
+	if callStackTrace != nil {
+		vmOutput.Logs = append(vmOutput.Logs, callStackTrace.LogEntry())
+	}
+
 	context.host.Metering().UpdateGasStateOnFailure(vmOutput)
 
 	return vmOutput
@@ -498,6 +1034,9 @@ func (context *outputContext) resolveReturnCodeFromError(err error) vmcommon.Ret
 	if errors.Is(err, arwen.ErrTransferInsufficientFunds) {
 		return vmcommon.OutOfFunds
 	}
+	if errors.Is(err, arwen.ErrExecutionRejectedByPolicy) {
+		return vmcommon.UserError
+	}
 
 	return vmcommon.ExecutionFailed
 }
@@ -508,8 +1047,23 @@ func (context *outputContext) AddToActiveState(rightOutput *vmcommon.VMOutput) {
 		rightOutput.GasRefund.Add(rightOutput.GasRefund, context.outputState.GasRefund)
 	}
 
-	for _, rightAccount := range rightOutput.OutputAccounts {
-		leftAccount, ok := context.outputState.OutputAccounts[string(rightAccount.Address)]
+	// the accounts of the given vmOutput are added in the order of their
+	// addresses, so that their order does not depend on the iteration of its map
+	for _, address := range sortedAccountAddresses(rightOutput.OutputAccounts) {
+		rightAccount := rightOutput.OutputAccounts[address]
+		context.addExternalValue(rightAccount.BalanceDelta)
+
+		firstTransfer := 0
+		leftAccount, ok := context.outputState.OutputAccounts[address]
+		if ok {
+			firstTransfer = len(leftAccount.OutputTransfers)
+		} else {
+			context.order.addAccount(rightAccount.Address)
+		}
+		for i := range rightAccount.OutputTransfers {
+			context.order.addTransfer(rightAccount.Address, firstTransfer+i)
+		}
+
 		if !ok {
 			continue
 		}
@@ -0,0 +1,176 @@
+package contexts
+
+import (
+	"sort"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
+	"github.com/ElrondNetwork/elrond-go/core/vmcommon"
+)
+
+// transferRef locates an OutputTransfer by the address of the account it was
+// made to and its position among the OutputTransfers of that account
+type transferRef struct {
+	destination string
+	position    int
+}
+
+// outputOrder records the order in which the Output context created its
+// output accounts and made its transfers, which the maps of the VMOutput do
+// not retain. It follows the output state through the state stack and the
+// snapshots of the Output context.
+type outputOrder struct {
+	accounts  []string
+	transfers []transferRef
+}
+
+func newOutputOrder() *outputOrder {
+	return &outputOrder{
+		accounts:  make([]string, 0),
+		transfers: make([]transferRef, 0),
+	}
+}
+
+func (order *outputOrder) clone() *outputOrder {
+	clone := &outputOrder{
+		accounts:  make([]string, len(order.accounts)),
+		transfers: make([]transferRef, len(order.transfers)),
+	}
+	copy(clone.accounts, order.accounts)
+	copy(clone.transfers, order.transfers)
+
+	return clone
+}
+
+func (order *outputOrder) addAccount(address []byte) {
+	order.accounts = append(order.accounts, string(address))
+}
+
+func (order *outputOrder) addTransfer(destination []byte, position int) {
+	order.transfers = append(order.transfers, transferRef{
+		destination: string(destination),
+		position:    position,
+	})
+}
+
+func (order *outputOrder) removeAccount(address []byte) {
+	accounts := make([]string, 0, len(order.accounts))
+	for _, account := range order.accounts {
+		if account != string(address) {
+			accounts = append(accounts, account)
+		}
+	}
+	order.accounts = accounts
+
+	transfers := make([]transferRef, 0, len(order.transfers))
+	for _, transfer := range order.transfers {
+		if transfer.destination != string(address) {
+			transfers = append(transfers, transfer)
+		}
+	}
+	order.transfers = transfers
+}
+
+// remapTransfers moves the transfers to the given destination to the given
+// new positions, indexed by their previous positions; a transfer moved to the
+// position of a previous one was merged into it, and is no longer listed
+func (order *outputOrder) remapTransfers(destination []byte, positions []int) {
+	transfers := make([]transferRef, 0, len(order.transfers))
+	listed := make(map[int]struct{})
+	for _, transfer := range order.transfers {
+		if transfer.destination != string(destination) {
+			transfers = append(transfers, transfer)
+			continue
+		}
+		if transfer.position >= len(positions) {
+			continue
+		}
+
+		newPosition := positions[transfer.position]
+		_, isListed := listed[newPosition]
+		if isListed {
+			continue
+		}
+
+		listed[newPosition] = struct{}{}
+		transfers = append(transfers, transferRef{destination: transfer.destination, position: newPosition})
+	}
+	order.transfers = transfers
+}
+
+// orderAccounts lists the accounts of the given VMOutput in the recorded
+// order; the accounts without a record, e.g. those of a VMOutput which was
+// not produced by the Output context, follow in the order of their addresses
+func (order *outputOrder) orderAccounts(vmOutput *vmcommon.VMOutput) []*vmcommon.OutputAccount {
+	accounts := make([]*vmcommon.OutputAccount, 0, len(vmOutput.OutputAccounts))
+	listed := make(map[string]struct{}, len(vmOutput.OutputAccounts))
+	for _, address := range order.accounts {
+		account, ok := vmOutput.OutputAccounts[address]
+		_, isListed := listed[address]
+		if ok && !isListed {
+			accounts = append(accounts, account)
+			listed[address] = struct{}{}
+		}
+	}
+
+	for _, address := range sortedAccountAddresses(vmOutput.OutputAccounts) {
+		_, isListed := listed[address]
+		if !isListed {
+			accounts = append(accounts, vmOutput.OutputAccounts[address])
+		}
+	}
+
+	return accounts
+}
+
+// orderTransfers lists the OutputTransfers of the given accounts in the
+// recorded order; the transfers without a record follow, in the order of
+// their accounts
+func (order *outputOrder) orderTransfers(accounts []*vmcommon.OutputAccount) []arwen.IndexedOutputTransfer {
+	byAddress := make(map[string]*vmcommon.OutputAccount, len(accounts))
+	for _, account := range accounts {
+		byAddress[string(account.Address)] = account
+	}
+
+	transfers := make([]arwen.IndexedOutputTransfer, 0)
+	listed := make(map[transferRef]struct{})
+	addTransfer := func(account *vmcommon.OutputAccount, position int) {
+		transfers = append(transfers, arwen.IndexedOutputTransfer{
+			Index:       len(transfers),
+			Destination: account.Address,
+			Transfer:    account.OutputTransfers[position],
+		})
+	}
+
+	for _, ref := range order.transfers {
+		account, ok := byAddress[ref.destination]
+		_, isListed := listed[ref]
+		if !ok || isListed || ref.position >= len(account.OutputTransfers) {
+			continue
+		}
+
+		addTransfer(account, ref.position)
+		listed[ref] = struct{}{}
+	}
+
+	for _, account := range accounts {
+		for position := range account.OutputTransfers {
+			ref := transferRef{destination: string(account.Address), position: position}
+			_, isListed := listed[ref]
+			if !isListed {
+				addTransfer(account, position)
+			}
+		}
+	}
+
+	return transfers
+}
+
+func sortedAccountAddresses(accounts map[string]*vmcommon.OutputAccount) []string {
+	addresses := make([]string, 0, len(accounts))
+	for address := range accounts {
+		addresses = append(addresses, address)
+	}
+	sort.Strings(addresses)
+
+	return addresses
+}
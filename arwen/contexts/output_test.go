@@ -1,12 +1,14 @@
 package contexts
 
 import (
+	"errors"
 	"math/big"
 	"testing"
 
 	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
 	contextmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/context"
 	worldmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/world"
+	"github.com/ElrondNetwork/elrond-go/core"
 	"github.com/ElrondNetwork/elrond-go/core/vmcommon"
 	"github.com/stretchr/testify/require"
 )
@@ -106,6 +108,56 @@ func TestOutputContext_PushPopState(t *testing.T) {
 	require.Equal(t, 0, len(outputContext.stateStack))
 }
 
+func TestOutputContext_Snapshots(t *testing.T) {
+	t.Parallel()
+
+	host := &contextmock.VMHostStub{}
+	outputContext, _ := NewOutputContext(host)
+
+	address1 := []byte("address1")
+	address2 := []byte("address2")
+
+	account, _ := outputContext.GetOutputAccount(address1)
+	account.Nonce = 10
+	account.BalanceDelta = big.NewInt(100)
+
+	outputContext.TakeSnapshot("outer")
+
+	account.Nonce = 11
+	account.BalanceDelta.Add(account.BalanceDelta, big.NewInt(50))
+	outputContext.Finish([]byte("outer result"))
+
+	outputContext.TakeSnapshot("inner")
+	account, _ = outputContext.GetOutputAccount(address2)
+	account.Nonce = 42
+
+	// Discarding the inner snapshot keeps the current state.
+	outputContext.DiscardSnapshot("inner")
+	require.Equal(t, 1, len(outputContext.snapshots))
+	require.Equal(t, 2, len(outputContext.outputState.OutputAccounts))
+
+	// Reverting the outer snapshot restores the state from before it,
+	// including the balance delta, which was modified in place.
+	outputContext.TakeSnapshot("inner")
+	err := outputContext.RevertToSnapshot("outer")
+	require.Nil(t, err)
+	require.Equal(t, 0, len(outputContext.snapshots))
+	require.Equal(t, 1, len(outputContext.outputState.OutputAccounts))
+	require.Equal(t, 0, len(outputContext.ReturnData()))
+
+	account, isNew := outputContext.GetOutputAccount(address1)
+	require.False(t, isNew)
+	require.Equal(t, uint64(10), account.Nonce)
+	require.Equal(t, big.NewInt(100), account.BalanceDelta)
+
+	err = outputContext.RevertToSnapshot("inner")
+	require.Equal(t, arwen.ErrOutputSnapshotNotFound, err)
+
+	outputContext.TakeSnapshot("outer")
+	outputContext.ClearStateStack()
+	require.Equal(t, 0, len(outputContext.snapshots))
+}
+
 func TestOutputContext_GetOutputAccount(t *testing.T) {
 	t.Parallel()
 
@@ -586,3 +638,526 @@ func TestOutputContext_PopDiscardIfStackIsEmptyShouldNotPanic(t *testing.T) {
 
 	require.Equal(t, 0, len(bigIntContext.stateStack))
 }
+
+func TestOutputContext_VMOutputErrorWithErrorCodes(t *testing.T) {
+	t.Parallel()
+
+	host := &contextmock.VMHostMock{
+		MeteringContext: &contextmock.MeteringContextMock{},
+		RuntimeContext: &contextmock.RuntimeContextMock{
+			VMInput: &vmcommon.VMInput{},
+		},
+	}
+
+	outputContext, _ := NewOutputContext(host)
+	outputContext.SetErrorCodesInReturnMessage(true)
+
+	vmOutput := outputContext.CreateVMOutputInCaseOfError(arwen.ErrContractNotFound)
+	require.Equal(t, vmcommon.ContractNotFound, vmOutput.ReturnCode)
+	require.Equal(t, "[1013:runtime] "+arwen.ErrContractNotFound.Error(), vmOutput.ReturnMessage)
+
+	code, subsystem, message, ok := arwen.ParseReturnMessage(vmOutput.ReturnMessage)
+	require.True(t, ok)
+	require.Equal(t, arwen.ErrorCode(1013), code)
+	require.Equal(t, arwen.SubsystemRuntime, subsystem)
+	require.Equal(t, arwen.ErrContractNotFound.Error(), message)
+}
+
+func TestOutputContext_ItemizedRefunds(t *testing.T) {
+	t.Parallel()
+
+	host := &contextmock.VMHostMock{
+		MeteringContext: &contextmock.MeteringContextMock{},
+		RuntimeContext: &contextmock.RuntimeContextMock{
+			VMInput:   &vmcommon.VMInput{},
+			SCAddress: []byte("address"),
+		},
+	}
+	outputContext, _ := NewOutputContext(host)
+
+	outputContext.AddRefund(arwen.RefundStorageRelease, 10)
+	outputContext.AddRefund(arwen.RefundUnusedLockedGas, 20)
+	require.Equal(t, uint64(10), outputContext.GetRefund())
+
+	// The refunds of a failed nested execution are discarded.
+	outputContext.PushState()
+	outputContext.CensorVMOutput()
+	outputContext.AddRefund(arwen.RefundAsyncCall, 30)
+	outputContext.PopSetActiveState()
+	require.Equal(t, arwen.ItemizedRefunds{StorageRelease: 10, UnusedLockedGas: 20}, outputContext.GetItemizedRefunds())
+
+	// The refunds of a successful nested execution are kept.
+	outputContext.PushState()
+	outputContext.CensorVMOutput()
+	outputContext.AddRefund(arwen.RefundAsyncCall, 30)
+	outputContext.PopMergeActiveState()
+	expectedRefunds := arwen.ItemizedRefunds{StorageRelease: 10, UnusedLockedGas: 20, AsyncCall: 30}
+	require.Equal(t, expectedRefunds, outputContext.GetItemizedRefunds())
+
+	outputContext.TakeSnapshot("refunds")
+	outputContext.AddRefund(arwen.RefundStorageRelease, 5)
+	err := outputContext.RevertToSnapshot("refunds")
+	require.Nil(t, err)
+	require.Equal(t, expectedRefunds, outputContext.GetItemizedRefunds())
+
+	vmOutput := outputContext.GetVMOutput()
+	require.Empty(t, vmOutput.Logs)
+
+	outputContext.SetItemizedRefundsInVMOutput(true)
+	vmOutput = outputContext.GetVMOutput()
+	require.Len(t, vmOutput.Logs, 1)
+	require.Equal(t, expectedRefunds.LogEntry([]byte("address")), vmOutput.Logs[0])
+
+	// the log entry is added to the VMOutput only, so repeated calls return the same logs
+	require.Equal(t, vmOutput, outputContext.GetVMOutput())
+	require.Empty(t, outputContext.outputState.Logs)
+
+	outputContext.InitState()
+	require.True(t, outputContext.GetItemizedRefunds().IsZero())
+}
+
+func TestOutputContext_LogRetentionPolicy(t *testing.T) {
+	t.Parallel()
+
+	host := &contextmock.VMHostMock{
+		MeteringContext: &contextmock.MeteringContextMock{},
+		RuntimeContext: &contextmock.RuntimeContextMock{
+			VMInput:   &vmcommon.VMInput{},
+			SCAddress: []byte("address"),
+		},
+	}
+	outputContext, _ := NewOutputContext(host)
+	outputContext.SetLogRetentionPolicy(arwen.LogRetentionPolicy{MaxEntries: 3, MaxBytes: 21})
+
+	address := []byte("address")
+	identifier := []byte("event")
+	outputContext.WriteContractLog(address, [][]byte{identifier}, []byte("first"))
+
+	// The logs of nested executions are accounted for the whole transaction.
+	outputContext.PushState()
+	outputContext.CensorVMOutput()
+	outputContext.WriteContractLog(address, [][]byte{identifier}, []byte("second"))
+	outputContext.PopMergeActiveState()
+
+	// The third log entry exceeds the byte cap, so it is dropped along with
+	// the fourth, even though the fourth would fit.
+	outputContext.WriteContractLog(address, [][]byte{identifier}, []byte("third"))
+	outputContext.WriteContractLog(address, nil, nil)
+
+	// The logs written by the host are not subject to the policy.
+	outputContext.WriteLog(address, [][]byte{[]byte("host")}, nil)
+
+	vmOutput := outputContext.GetVMOutput()
+	require.Len(t, vmOutput.Logs, 4)
+	require.Equal(t, []byte("first"), vmOutput.Logs[0].Data)
+	require.Equal(t, []byte("second"), vmOutput.Logs[1].Data)
+	require.Equal(t, []byte("host"), vmOutput.Logs[2].Identifier)
+	require.Equal(t, arwen.LogsTruncatedLogEntry(address, 2, 10), vmOutput.Logs[3])
+
+	// The marker is not added to the output state, so repeated calls return the same logs.
+	require.Equal(t, vmOutput, outputContext.GetVMOutput())
+	require.Len(t, outputContext.outputState.Logs, 3)
+
+	outputContext.InitState()
+	outputContext.WriteContractLog(address, [][]byte{identifier}, []byte("first"))
+	vmOutput = outputContext.GetVMOutput()
+	require.Len(t, vmOutput.Logs, 1)
+}
+
+func TestOutputContext_LogRetentionPolicy_Unlimited(t *testing.T) {
+	t.Parallel()
+
+	host := &contextmock.VMHostMock{
+		MeteringContext: &contextmock.MeteringContextMock{},
+		RuntimeContext: &contextmock.RuntimeContextMock{
+			VMInput:   &vmcommon.VMInput{},
+			SCAddress: []byte("address"),
+		},
+	}
+	outputContext, _ := NewOutputContext(host)
+
+	address := []byte("address")
+	for i := 0; i < 100; i++ {
+		outputContext.WriteContractLog(address, [][]byte{[]byte("event")}, make([]byte, 100))
+	}
+
+	vmOutput := outputContext.GetVMOutput()
+	require.Len(t, vmOutput.Logs, 100)
+}
+
+func TestOutputContext_CheckValueConservation(t *testing.T) {
+	t.Parallel()
+
+	sender := []byte("sender")
+	receiver := []byte("receiver")
+
+	host := &contextmock.VMHostMock{}
+	host.RuntimeContext = &contextmock.RuntimeContextMock{VMInput: &vmcommon.VMInput{}}
+	mockWorld := worldmock.NewMockWorld()
+	mockWorld.AcctMap.PutAccount(&worldmock.Account{
+		Address: sender,
+		Balance: big.NewInt(10000),
+	})
+
+	blockchainContext, _ := NewBlockchainContext(host, mockWorld)
+	outputContext, _ := NewOutputContext(host)
+	host.OutputContext = outputContext
+	host.BlockchainContext = blockchainContext
+
+	outputContext.AddTxValueToAccount(sender, big.NewInt(500))
+	err := outputContext.TransferValueOnly(receiver, sender, big.NewInt(100), false)
+	require.Nil(t, err)
+	require.Nil(t, outputContext.CheckValueConservation())
+
+	// the value credited by a reverted state is dropped along with it
+	outputContext.PushState()
+	outputContext.AddTxValueToAccount(receiver, big.NewInt(1000))
+	require.Nil(t, outputContext.CheckValueConservation())
+	outputContext.PopSetActiveState()
+	require.Nil(t, outputContext.CheckValueConservation())
+
+	outputContext.TakeSnapshot("credit")
+	outputContext.AddTxValueToAccount(receiver, big.NewInt(30))
+	err = outputContext.RevertToSnapshot("credit")
+	require.Nil(t, err)
+	require.Nil(t, outputContext.CheckValueConservation())
+
+	// the output of a built-in function enters the output accounts from outside
+	outputContext.AddToActiveState(&vmcommon.VMOutput{
+		OutputAccounts: map[string]*vmcommon.OutputAccount{
+			string(receiver): {Address: receiver, BalanceDelta: big.NewInt(5)},
+		},
+	})
+	require.Nil(t, outputContext.CheckValueConservation())
+
+	outputContext.DeleteOutputAccount(receiver)
+	require.Nil(t, outputContext.CheckValueConservation())
+
+	// value accounted twice is detected
+	senderAccount, _ := outputContext.GetOutputAccount(sender)
+	senderAccount.BalanceDelta = big.NewInt(0).Add(senderAccount.BalanceDelta, big.NewInt(1))
+	err = outputContext.CheckValueConservation()
+	require.True(t, errors.Is(err, arwen.ErrValueNotConserved))
+
+	outputContext.InitState()
+	require.Nil(t, outputContext.CheckValueConservation())
+}
+
+func TestOutputContext_OrderedVMOutput(t *testing.T) {
+	t.Parallel()
+
+	sender := []byte("sender")
+	addressC := []byte("addressC")
+	addressA := []byte("addressA")
+	addressB := []byte("addressB")
+
+	host := &contextmock.VMHostMock{}
+	host.RuntimeContext = &contextmock.RuntimeContextMock{VMInput: &vmcommon.VMInput{}}
+	mockWorld := worldmock.NewMockWorld()
+	mockWorld.AcctMap.PutAccount(&worldmock.Account{
+		Address: sender,
+		Balance: big.NewInt(10000),
+	})
+
+	blockchainContext, _ := NewBlockchainContext(host, mockWorld)
+	outputContext, _ := NewOutputContext(host)
+	host.OutputContext = outputContext
+	host.BlockchainContext = blockchainContext
+
+	transfer := func(destination []byte, data string) {
+		err := outputContext.Transfer(destination, sender, 0, 0, big.NewInt(1), []byte(data), vmcommon.DirectCall)
+		require.Nil(t, err)
+	}
+
+	_, _ = outputContext.GetOutputAccount(sender)
+	transfer(addressC, "first")
+	transfer(addressA, "second")
+	transfer(addressC, "third")
+
+	// the transfers of a reverted state are forgotten
+	outputContext.PushState()
+	transfer(addressB, "reverted")
+	outputContext.PopSetActiveState()
+
+	outputContext.PushState()
+	transfer(addressB, "fourth")
+	outputContext.PopMergeActiveState()
+
+	// the accounts of a foreign output are added in the order of their addresses
+	outputContext.AddToActiveState(&vmcommon.VMOutput{
+		OutputAccounts: map[string]*vmcommon.OutputAccount{
+			"foreignZ": {Address: []byte("foreignZ"), BalanceDelta: big.NewInt(0)},
+			"foreignY": {
+				Address:         []byte("foreignY"),
+				BalanceDelta:    big.NewInt(0),
+				OutputTransfers: []vmcommon.OutputTransfer{{Data: []byte("fifth")}},
+			},
+		},
+	})
+
+	vmOutput := &vmcommon.VMOutput{OutputAccounts: outputContext.GetOutputAccounts()}
+	orderedOutput := outputContext.GetOrderedVMOutput(vmOutput)
+
+	addresses := make([]string, 0)
+	for _, account := range orderedOutput.OutputAccounts {
+		addresses = append(addresses, string(account.Address))
+	}
+	require.Equal(t, []string{"sender", "addressC", "addressA", "addressB", "foreignY", "foreignZ"}, addresses)
+
+	transfers := make([]string, 0)
+	for i, transfer := range orderedOutput.OutputTransfers {
+		require.Equal(t, i, transfer.Index)
+		transfers = append(transfers, string(transfer.Destination)+":"+string(transfer.Transfer.Data))
+	}
+	require.Equal(t, []string{
+		"addressC:first",
+		"addressA:second",
+		"addressC:third",
+		"addressB:fourth",
+		"foreignY:fifth",
+	}, transfers)
+
+	encoded, err := orderedOutput.Encode()
+	require.Nil(t, err)
+	reencoded, err := outputContext.GetOrderedVMOutput(vmOutput).Encode()
+	require.Nil(t, err)
+	require.Equal(t, encoded, reencoded)
+}
+
+func TestOutputContext_WriteEventLog(t *testing.T) {
+	t.Parallel()
+
+	host := &contextmock.VMHostMock{
+		MeteringContext: &contextmock.MeteringContextMock{},
+		RuntimeContext: &contextmock.RuntimeContextMock{
+			VMInput:   &vmcommon.VMInput{},
+			SCAddress: []byte("address"),
+		},
+	}
+	outputContext, _ := NewOutputContext(host)
+	outputContext.SetEventLogLimits(arwen.EventLogLimits{MaxTopics: 1, MaxTopicLength: 8})
+
+	address := []byte("address")
+	event := arwen.NewEventLog([]byte("transfer")).AddTopic([]byte("receiver")).WithData([]byte("value"))
+	err := outputContext.WriteEventLog(address, event)
+	require.Nil(t, err)
+
+	err = outputContext.WriteEventLog(address, event.AddTopic([]byte("sender")))
+	require.True(t, errors.Is(err, arwen.ErrEventLogTooManyTopics))
+
+	err = outputContext.WriteEventLog(address, arwen.NewEventLog(nil))
+	require.True(t, errors.Is(err, arwen.ErrEventLogMissingIdentifier))
+
+	vmOutput := outputContext.GetVMOutput()
+	require.Len(t, vmOutput.Logs, 1)
+	require.Equal(t, &vmcommon.LogEntry{
+		Identifier: []byte("transfer"),
+		Address:    address,
+		Topics:     [][]byte{[]byte("receiver")},
+		Data:       []byte("value"),
+	}, vmOutput.Logs[0])
+}
+
+func TestOutputContext_MaxReturnDataSize(t *testing.T) {
+	t.Parallel()
+
+	host := &contextmock.VMHostMock{}
+	outputContext, _ := NewOutputContext(host)
+	require.Equal(t, int64(-1), outputContext.GetReturnDataBudget())
+
+	outputContext.SetMaxReturnDataSize(10)
+	require.Equal(t, int64(10), outputContext.GetReturnDataBudget())
+
+	err := outputContext.Finish([]byte("first"))
+	require.Nil(t, err)
+
+	// the return data of nested executions which fail is no longer accounted
+	outputContext.PushState()
+	outputContext.CensorVMOutput()
+	err = outputContext.Finish([]byte("sec"))
+	require.Nil(t, err)
+	require.Equal(t, int64(2), outputContext.GetReturnDataBudget())
+	outputContext.PopSetActiveState()
+	require.Equal(t, int64(5), outputContext.GetReturnDataBudget())
+
+	// the return data of nested executions which succeed is kept
+	outputContext.PushState()
+	outputContext.CensorVMOutput()
+	err = outputContext.Finish([]byte("sec"))
+	require.Nil(t, err)
+	outputContext.PopMergeActiveState()
+	require.Equal(t, int64(2), outputContext.GetReturnDataBudget())
+
+	err = outputContext.Finish([]byte("third"))
+	require.Equal(t, arwen.ErrReturnDataTooLarge, err)
+	require.Equal(t, [][]byte{[]byte("first"), []byte("sec")}, outputContext.ReturnData())
+
+	// reverting to a snapshot also reverts the accounted return data
+	outputContext.TakeSnapshot("snapshot")
+	err = outputContext.Finish([]byte("ok"))
+	require.Nil(t, err)
+	require.Equal(t, int64(0), outputContext.GetReturnDataBudget())
+	err = outputContext.RevertToSnapshot("snapshot")
+	require.Nil(t, err)
+	require.Equal(t, int64(2), outputContext.GetReturnDataBudget())
+
+	outputContext.InitState()
+	require.Equal(t, int64(10), outputContext.GetReturnDataBudget())
+}
+
+func TestOutputContext_TransferAggregation(t *testing.T) {
+	t.Parallel()
+
+	sender := []byte("sender")
+	addressA := []byte("addressA")
+	addressB := []byte("addressB")
+
+	runTransfers := func(aggregation bool) (*vmcommon.VMOutput, *arwen.OrderedVMOutput) {
+		host := &contextmock.VMHostMock{}
+		host.MeteringContext = &contextmock.MeteringContextMock{}
+		host.RuntimeContext = &contextmock.RuntimeContextMock{VMInput: &vmcommon.VMInput{}, SCAddress: sender}
+		mockWorld := worldmock.NewMockWorld()
+		mockWorld.AcctMap.PutAccount(&worldmock.Account{
+			Address: sender,
+			Balance: big.NewInt(10000),
+		})
+
+		blockchainContext, _ := NewBlockchainContext(host, mockWorld)
+		outputContext, _ := NewOutputContext(host)
+		outputContext.SetTransferAggregation(aggregation)
+		host.OutputContext = outputContext
+		host.BlockchainContext = blockchainContext
+
+		transfer := func(destination []byte, value int64, data string) {
+			err := outputContext.Transfer(destination, sender, 0, 0, big.NewInt(value), []byte(data), vmcommon.DirectCall)
+			require.Nil(t, err)
+		}
+
+		transfer(addressA, 1, "")
+		transfer(addressB, 2, "")
+		transfer(addressA, 3, "call")
+		transfer(addressA, 4, "")
+		transfer(addressB, 5, "")
+		transfer(addressA, 6, "")
+
+		vmOutput := outputContext.GetVMOutput()
+		return vmOutput, outputContext.GetOrderedVMOutput(vmOutput)
+	}
+
+	vmOutput, orderedOutput := runTransfers(false)
+	require.Len(t, vmOutput.OutputAccounts[string(addressA)].OutputTransfers, 4)
+	require.Len(t, vmOutput.OutputAccounts[string(addressB)].OutputTransfers, 2)
+	require.Len(t, orderedOutput.OutputTransfers, 6)
+
+	vmOutput, orderedOutput = runTransfers(true)
+	// the transfers of 4 and 6 to addressA are adjacent, but not those of 1 and 4
+	transfersA := vmOutput.OutputAccounts[string(addressA)].OutputTransfers
+	require.Len(t, transfersA, 3)
+	require.Equal(t, big.NewInt(1), transfersA[0].Value)
+	require.Equal(t, big.NewInt(3), transfersA[1].Value)
+	require.Equal(t, []byte("call"), transfersA[1].Data)
+	require.Equal(t, big.NewInt(10), transfersA[2].Value)
+	require.Empty(t, transfersA[2].Data)
+
+	transfersB := vmOutput.OutputAccounts[string(addressB)].OutputTransfers
+	require.Len(t, transfersB, 1)
+	require.Equal(t, big.NewInt(7), transfersB[0].Value)
+
+	// the aggregated transfers take the place of the first of them
+	require.Len(t, orderedOutput.OutputTransfers, 4)
+	require.Equal(t, addressA, orderedOutput.OutputTransfers[0].Destination)
+	require.Equal(t, big.NewInt(1), orderedOutput.OutputTransfers[0].Transfer.Value)
+	require.Equal(t, addressB, orderedOutput.OutputTransfers[1].Destination)
+	require.Equal(t, addressA, orderedOutput.OutputTransfers[2].Destination)
+	require.Equal(t, []byte("call"), orderedOutput.OutputTransfers[2].Transfer.Data)
+	require.Equal(t, addressA, orderedOutput.OutputTransfers[3].Destination)
+	require.Equal(t, big.NewInt(10), orderedOutput.OutputTransfers[3].Transfer.Value)
+
+	// the balance deltas are not affected
+	require.Equal(t, big.NewInt(14), vmOutput.OutputAccounts[string(addressA)].BalanceDelta)
+	require.Equal(t, big.NewInt(-21), vmOutput.OutputAccounts[string(sender)].BalanceDelta)
+}
+
+func TestOutputContext_AggregateESDTTransfers(t *testing.T) {
+	t.Parallel()
+
+	esdtTransfer := func(sender string, token string, value int64) vmcommon.OutputTransfer {
+		return vmcommon.OutputTransfer{
+			Value:         big.NewInt(0),
+			Data:          arwen.EncodeCallData(core.BuiltInFunctionESDTTransfer, [][]byte{[]byte(token), big.NewInt(value).Bytes()}),
+			CallType:      vmcommon.DirectCall,
+			SenderAddress: []byte(sender),
+		}
+	}
+	withGas := esdtTransfer("sender", "TOKEN", 4)
+	withGas.GasLimit = 100
+
+	transfers := []vmcommon.OutputTransfer{
+		esdtTransfer("sender", "TOKEN", 1),
+		esdtTransfer("sender", "TOKEN", 3),
+		esdtTransfer("sender", "OTHER", 2),
+		withGas,
+		esdtTransfer("sender", "TOKEN", 5),
+		esdtTransfer("another", "TOKEN", 6),
+		esdtTransfer("another", "TOKEN", 7),
+		esdtTransfer("sender", "OTHER", 8),
+	}
+
+	// only the adjacent transfers of the same token and sender are merged
+	aggregated, positions := aggregateTransfers(transfers)
+	require.Equal(t, []vmcommon.OutputTransfer{
+		esdtTransfer("sender", "TOKEN", 4),
+		esdtTransfer("sender", "OTHER", 2),
+		withGas,
+		esdtTransfer("sender", "TOKEN", 5),
+		esdtTransfer("another", "TOKEN", 13),
+		esdtTransfer("sender", "OTHER", 8),
+	}, aggregated)
+	require.Equal(t, []int{0, 0, 1, 2, 3, 4, 4, 5}, positions)
+
+	// the given transfers are left unchanged
+	require.Equal(t, esdtTransfer("sender", "TOKEN", 1), transfers[0])
+}
+
+func TestOutputContext_Receipts(t *testing.T) {
+	t.Parallel()
+
+	host := &contextmock.VMHostMock{}
+	outputContext, _ := NewOutputContext(host)
+
+	newReceipt := func(value int64) *arwen.Receipt {
+		return arwen.NewFailedCallReceipt([]byte("caller"), []byte("recipient"), big.NewInt(value), vmcommon.DirectCall)
+	}
+
+	outOfGasReceipt := &arwen.OutOfGasReceipt{
+		ContractAddress: []byte("recipient"),
+		Function:        "function",
+	}
+
+	outputContext.AddReceipt(newReceipt(1))
+
+	// the receipts of a reverted execution are dropped along with its state
+	outputContext.PushState()
+	outputContext.AddReceipt(newReceipt(2))
+	outputContext.PopSetActiveState()
+
+	outputContext.PushState()
+	outputContext.AddReceipt(newReceipt(3))
+	outputContext.AddReceipt(outOfGasReceipt)
+	outputContext.PopMergeActiveState()
+
+	outputContext.TakeSnapshot("snapshot")
+	outputContext.AddReceipt(newReceipt(4))
+	err := outputContext.RevertToSnapshot("snapshot")
+	require.Nil(t, err)
+
+	expectedReceipts := []arwen.LogReceipt{newReceipt(1), newReceipt(3), outOfGasReceipt}
+	require.Equal(t, expectedReceipts, outputContext.receipts)
+
+	vmOutput := &vmcommon.VMOutput{}
+	outputContext.AttachReceipt(vmOutput, newReceipt(5))
+	require.Equal(t, []*vmcommon.LogEntry{newReceipt(5).LogEntry()}, vmOutput.Logs)
+
+	outputContext.InitState()
+	require.Empty(t, outputContext.receipts)
+}
@@ -2,9 +2,13 @@ package contexts
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
 	"fmt"
 	builtinMath "math"
 	"math/big"
+	"sort"
+	"sync"
 	"unsafe"
 
 	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
@@ -33,22 +37,80 @@ type runtimeContext struct {
 	stateStack    []*runtimeContext
 	instanceStack []wasmer.InstanceHandler
 
+	mutInstances sync.Mutex
+	interrupted  bool
+
 	maxWasmerInstances uint64
 
+	maxExecutionStackDepth uint64
+
 	asyncCallInfo    *arwen.AsyncCallInfo
 	asyncContextInfo *arwen.AsyncContextInfo
 
+	esdtTransfers []*arwen.ESDTTransfer
+
 	validator *wasmValidator
 
 	useWarmInstance     bool
 	warmInstanceAddress []byte
 	warmInstance        wasmer.InstanceHandler
 
+	instancePool          *instancePool
+	compiledCodeDiskCache *compiledCodeDiskCache
+	compilationSettings   []byte
+
+	codeUpgrades map[string]*codeUpgrade
+
 	instanceBuilder arwen.InstanceBuilder
 
+	managedTypes *managedTypesContainer
+
+	randomCounter uint64
+
+	memory               *arwen.MemoryAccountant
+	instanceMemory       map[wasmer.InstanceHandler]*instanceMemoryUsage
+	managedObjectsMemory uint64
+
+	memoryLimits       arwen.InstanceMemoryLimits
+	memoryPagesAtStart map[wasmer.InstanceHandler]uint32
+
+	validationConfig      arwen.RuntimeValidationConfig
+	functionActivationMap arwen.FunctionActivationMap
+
+	debugStop          arwen.DebugStop
+	debugStepCallback  arwen.DebugStepCallback
+	debugInstanceCount uint64
+	debugStopInstance  wasmer.InstanceHandler
+	debugStopGasLimit  uint64
+
+	userErrorReason         *arwen.UserErrorReason
+	userErrorCaptureEnabled bool
+	userErrorDebugEnabled   bool
+
+	outOfGasReceipt         *arwen.OutOfGasReceipt
+	outOfGasReceiptsEnabled bool
+
+	callStackTrace         *arwen.CallStackTrace
+	callStackTraceDepth    int
+	callStackTracesEnabled bool
+
 	errors arwen.WrappableError
 }
 
+// instanceMemoryUsage is the memory accounted for a Wasmer instance: the size
+// of its linear memory, and the size of its compiled code, if known
+type instanceMemoryUsage struct {
+	linearMemory uint64
+	compiledCode uint64
+}
+
+// codeUpgrade is the code a contract was upgraded to during the current
+// transaction, along with its hash
+type codeUpgrade struct {
+	code     []byte
+	codeHash []byte
+}
+
 // NewRuntimeContext creates a new runtimeContext
 func NewRuntimeContext(host arwen.VMHost, vmType []byte, useWarmInstance bool) (*runtimeContext, error) {
 	scAPINames := host.GetAPIMethods().Names()
@@ -63,10 +125,14 @@ func NewRuntimeContext(host arwen.VMHost, vmType []byte, useWarmInstance bool) (
 		useWarmInstance:     useWarmInstance,
 		warmInstanceAddress: nil,
 		warmInstance:        nil,
+		memory:              arwen.NewMemoryAccountant(),
+		instanceMemory:      make(map[wasmer.InstanceHandler]*instanceMemoryUsage),
+		memoryPagesAtStart:  make(map[wasmer.InstanceHandler]uint32),
+		managedTypes:        newManagedTypesContainer(),
 		errors:              nil,
 	}
 
-	context.instanceBuilder = &wasmerInstanceBuilder{}
+	context.instanceBuilder = &wasmerExecutor{}
 	context.InitState()
 
 	return context, nil
@@ -84,10 +150,55 @@ func (context *runtimeContext) InitState() {
 		AsyncContextMap: make(map[string]*arwen.AsyncContext),
 	}
 	context.errors = nil
+	context.userErrorReason = nil
+	context.outOfGasReceipt = nil
+	context.callStackTrace = nil
+	context.debugInstanceCount = 0
+	context.debugStopInstance = nil
+	context.codeUpgrades = make(map[string]*codeUpgrade)
+	context.managedTypes.InitState()
+	context.releaseManagedObjectsMemory()
+	context.randomCounter = 0
+
+	context.mutInstances.Lock()
+	context.interrupted = false
+	context.mutInstances.Unlock()
 
 	logRuntime.Trace("init state")
 }
 
+// SetExecutor sets the engine creating the instances of the contracts; the
+// warm instance and the pooled instances, created by the previous engine, are
+// cleaned
+func (context *runtimeContext) SetExecutor(executor arwen.Executor) {
+	context.ResetWarmInstance()
+	context.instanceBuilder = executor
+	context.compilationSettings = nil
+	logRuntime.Debug("executor set", "name", executor.Name())
+}
+
+// GetExecutorName returns the name of the engine creating the instances of the
+// contracts, or an empty string if their builder is not an Executor
+func (context *runtimeContext) GetExecutorName() string {
+	executor, ok := context.instanceBuilder.(arwen.Executor)
+	if !ok {
+		return ""
+	}
+
+	return executor.Name()
+}
+
+// getExecutorVersion returns the version of the engine creating the instances
+// of the contracts, or an empty string if their builder is not an Executor
+func (context *runtimeContext) getExecutorVersion() string {
+	executor, ok := context.instanceBuilder.(arwen.Executor)
+	if !ok {
+		return ""
+	}
+
+	return executor.Version()
+}
+
 // ReplaceInstanceBuilder replaces the instance builder, allowing the creation
 // of mocked Wasmer instances
 // TODO remove after implementing proper mocking of
@@ -96,10 +207,15 @@ func (context *runtimeContext) ReplaceInstanceBuilder(builder arwen.InstanceBuil
 	context.instanceBuilder = builder
 }
 
+// setWarmInstanceWhenNeeded reuses the warm instance for the current contract,
+// unless the warm instance is running, waiting for a nested call to the same
+// contract to end; a re-entrant call is given an instance of its own, so that
+// it shares neither the memory, nor the globals, nor the gas counter of the
+// suspended call
 func (context *runtimeContext) setWarmInstanceWhenNeeded(gasLimit uint64) bool {
 	scAddress := context.GetSCAddress()
 	useWarm := context.useWarmInstance && context.warmInstanceAddress != nil && bytes.Equal(scAddress, context.warmInstanceAddress)
-	if scAddress != nil && useWarm {
+	if scAddress != nil && useWarm && !context.isInstanceRunning(context.warmInstance) {
 		logRuntime.Trace("reusing warm instance")
 
 		context.instance = context.warmInstance
@@ -115,6 +231,25 @@ func (context *runtimeContext) setWarmInstanceWhenNeeded(gasLimit uint64) bool {
 
 // StartWasmerInstance creates a new wasmer instance if the maxWasmerInstances has not been reached.
 func (context *runtimeContext) StartWasmerInstance(contract []byte, gasLimit uint64, newCode bool) error {
+	context.mutInstances.Lock()
+	defer context.mutInstances.Unlock()
+
+	err := context.startWasmerInstance(contract, gasLimit, newCode)
+	if err != nil {
+		return err
+	}
+
+	context.applyDebugStop(gasLimit)
+	return context.checkFunctionActivation()
+}
+
+func (context *runtimeContext) startWasmerInstance(contract []byte, gasLimit uint64, newCode bool) error {
+	if context.interrupted {
+		context.instance = nil
+		logRuntime.Trace("create instance", "error", arwen.ErrExecutionTimeout)
+		return arwen.ErrExecutionTimeout
+	}
+
 	if context.RunningInstancesCount() >= context.maxWasmerInstances {
 		context.instance = nil
 		logRuntime.Error("create instance", "error", arwen.ErrMaxInstancesReached)
@@ -123,17 +258,293 @@ func (context *runtimeContext) StartWasmerInstance(contract []byte, gasLimit uin
 
 	warmInstanceUsed := context.setWarmInstanceWhenNeeded(gasLimit)
 	if warmInstanceUsed {
-		return nil
+		return context.applyMemoryLimits()
+	}
+
+	codeHash := context.getCodeHash(contract)
+	pooledInstanceUsed := context.takeInstanceFromPool(codeHash, gasLimit, newCode)
+	if pooledInstanceUsed {
+		return context.applyMemoryLimits()
+	}
+
+	err := context.checkMemoryForNewInstance()
+	if err != nil {
+		context.instance = nil
+		return err
 	}
 
-	blockchain := context.host.Blockchain()
-	codeHash := blockchain.GetCodeHash(context.GetSCAddress())
 	compiledCodeUsed := context.makeInstanceFromCompiledCode(codeHash, gasLimit, newCode)
-	if compiledCodeUsed {
+	if !compiledCodeUsed {
+		err = context.makeInstanceFromContractByteCode(contract, codeHash, gasLimit, newCode)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = context.applyMemoryLimits()
+	if err != nil {
+		return err
+	}
+
+	context.trackInstanceInPool(codeHash, contract, newCode)
+	return nil
+}
+
+// getCodeHash returns the hash of the code of the current contract; the code
+// hash of the account is stale while the contract runs the code it was
+// upgraded to earlier in the same transaction
+func (context *runtimeContext) getCodeHash(contract []byte) []byte {
+	scAddress := context.GetSCAddress()
+	upgrade, ok := context.codeUpgrades[string(scAddress)]
+	if ok && bytes.Equal(upgrade.code, contract) {
+		return upgrade.codeHash
+	}
+
+	return context.host.Blockchain().GetCodeHash(scAddress)
+}
+
+// RegisterCodeUpgrade records that the given contract was upgraded to the
+// given code during the current transaction, so that the instances of its
+// previous code are no longer used for it: the warm instance of the contract
+// is discarded, and the instances started later are created from the new code
+func (context *runtimeContext) RegisterCodeUpgrade(address []byte, code []byte) {
+	context.mutInstances.Lock()
+	defer context.mutInstances.Unlock()
+
+	codeHash, err := context.host.Crypto().Sha256(code)
+	if err != nil {
+		logRuntime.Trace("register code upgrade", "error", err)
+		codeHash = nil
+	}
+
+	context.codeUpgrades[string(address)] = &codeUpgrade{
+		code:     code,
+		codeHash: codeHash,
+	}
+
+	isStaleWarmInstance := context.warmInstance != nil &&
+		context.warmInstance != context.instance &&
+		bytes.Equal(context.warmInstanceAddress, address)
+	if !isStaleWarmInstance {
+		return
+	}
+
+	if context.isInstanceRunning(context.warmInstance) {
+		// the running instance of the previous code is cleaned when its
+		// execution resumes and ends, like any instance that is not warm
+		context.warmInstance = nil
+		context.warmInstanceAddress = nil
+		logRuntime.Trace("running warm instance detached after upgrade")
+		return
+	}
+
+	context.evictWarmInstance()
+}
+
+// SetInstanceMemoryLimits sets the bounds of the linear memory of the Wasmer instances
+func (context *runtimeContext) SetInstanceMemoryLimits(limits arwen.InstanceMemoryLimits) {
+	context.memoryLimits = limits
+	context.compilationSettings = nil
+}
+
+// GetInstanceMemoryLimits returns the bounds of the linear memory of the Wasmer instances
+func (context *runtimeContext) GetInstanceMemoryLimits() arwen.InstanceMemoryLimits {
+	return context.memoryLimits
+}
+
+// SetValidationConfig sets the rules checked on the code of the contracts before
+// they are instantiated on deployment or upgrade
+func (context *runtimeContext) SetValidationConfig(config arwen.RuntimeValidationConfig) {
+	context.validationConfig = config
+}
+
+// GetValidationConfig returns the rules checked on the code of the contracts
+// before they are instantiated on deployment or upgrade
+func (context *runtimeContext) GetValidationConfig() arwen.RuntimeValidationConfig {
+	return context.validationConfig
+}
+
+// SetFunctionActivationMap sets the epochs from which contracts may import the
+// EEI functions; an instance importing a function which is not active yet
+// fails to start
+func (context *runtimeContext) SetFunctionActivationMap(activationMap arwen.FunctionActivationMap) {
+	context.functionActivationMap = activationMap
+}
+
+// ManagedTypes returns the container of the values held under handles for
+// the contracts, besides the big ints
+func (context *runtimeContext) ManagedTypes() arwen.ManagedTypesContainer {
+	return context.managedTypes
+}
+
+// NextRandomCounter returns the number of previous calls to this method within
+// the current transaction, which is not reset by nested executions
+func (context *runtimeContext) NextRandomCounter() uint64 {
+	counter := context.randomCounter
+	context.randomCounter++
+	return counter
+}
+
+// checkFunctionActivation refuses the current instance if it imports an EEI
+// function which is not active in the current epoch
+func (context *runtimeContext) checkFunctionActivation() error {
+	if len(context.functionActivationMap) == 0 {
 		return nil
 	}
 
-	return context.makeInstanceFromContractByteCode(contract, codeHash, gasLimit, newCode)
+	epoch := context.host.Blockchain().CurrentEpoch()
+	err := context.functionActivationMap.CheckImports(epoch, context.instance.IsFunctionImported)
+	if err != nil {
+		logRuntime.Trace("create instance", "error", err)
+		return err
+	}
+
+	return nil
+}
+
+// applyMemoryLimits grows the memory of the current instance to the initial
+// number of pages and records the pages it starts the contract call with,
+// refusing the instance if it holds more pages than allowed
+func (context *runtimeContext) applyMemoryLimits() error {
+	instance := context.instance
+	if !instance.HasMemory() || instance.GetMemory() == nil {
+		return nil
+	}
+
+	memory := instance.GetMemory()
+	pages := memory.Length() / arwen.WasmPageSize
+	err := context.growToInitialMemoryPages(pages)
+	if err == nil {
+		pages = memory.Length() / arwen.WasmPageSize
+		err = context.memoryLimits.CheckPages(pages, pages)
+	}
+	if err != nil {
+		logRuntime.Trace("instance creation", "error", err)
+		context.cleanWasmerInstance()
+		context.instance = nil
+		return err
+	}
+
+	context.memoryPagesAtStart[instance] = pages
+	return nil
+}
+
+func (context *runtimeContext) growToInitialMemoryPages(pages uint32) error {
+	initialPages := context.memoryLimits.InitialMemoryPages
+	if pages >= initialPages {
+		return nil
+	}
+
+	err := context.instance.GetMemory().Grow(initialPages - pages)
+	if err != nil {
+		return err
+	}
+
+	context.updateInstanceMemory(context.instance)
+	return nil
+}
+
+// CheckInstanceMemoryLimits returns the error of the current instance holding
+// more pages than allowed, or having grown by more pages than allowed since
+// the start of the contract call; the grown memory is accounted as well
+func (context *runtimeContext) CheckInstanceMemoryLimits() error {
+	instance := context.instance
+	if instance == nil || !instance.HasMemory() || instance.GetMemory() == nil {
+		return nil
+	}
+	context.updateInstanceMemory(instance)
+
+	pages := instance.GetMemory().Length() / arwen.WasmPageSize
+	pagesAtStart, ok := context.memoryPagesAtStart[instance]
+	if !ok {
+		pagesAtStart = pages
+	}
+
+	return context.memoryLimits.CheckPages(pagesAtStart, pages)
+}
+
+// EnableInstancePool makes the runtime keep the Wasmer instances of at most
+// the given number of contract codes after their executions, to be reused by
+// the later executions of the same code
+func (context *runtimeContext) EnableInstancePool(size uint64) {
+	context.clearInstancePool()
+	context.instancePool = nil
+	if size == 0 {
+		return
+	}
+
+	context.instancePool = newInstancePool(int(size), context.memory)
+}
+
+func (context *runtimeContext) takeInstanceFromPool(codeHash []byte, gasLimit uint64, newCode bool) bool {
+	if context.instancePool == nil || newCode || len(codeHash) == 0 {
+		return false
+	}
+
+	instance, ok := context.instancePool.take(context.getCompiledCodeKey(codeHash))
+	if !ok {
+		return false
+	}
+
+	context.instance = instance
+	context.SetPointsUsed(0)
+	context.instance.SetGasLimit(gasLimit)
+	context.SetRuntimeBreakpointValue(arwen.BreakpointNone)
+
+	hostReference := uintptr(unsafe.Pointer(&context.host))
+	context.instance.SetContextData(hostReference)
+
+	logRuntime.Trace("reusing pooled instance")
+	return true
+}
+
+// trackInstanceInPool makes the current instance eligible for the pool, unless
+// its code has mutable globals, which cannot be restored before it is reused;
+// the pooled instances are kept under the same keys as the compiled code, so
+// that an instance is reused only by the executor and settings which made it
+func (context *runtimeContext) trackInstanceInPool(codeHash []byte, contract []byte, newCode bool) {
+	if context.instancePool == nil || newCode || len(codeHash) == 0 || context.IsWarmInstance() {
+		return
+	}
+	if arwen.HasMutableGlobals(contract) {
+		return
+	}
+
+	context.instancePool.track(context.getCompiledCodeKey(codeHash), context.instance)
+}
+
+// putInstanceInPool keeps the current instance in the pool, unless its
+// execution was interrupted, which may have left its globals changed
+func (context *runtimeContext) putInstanceInPool() bool {
+	if context.instancePool == nil {
+		return false
+	}
+
+	if context.GetRuntimeBreakpointValue() != arwen.BreakpointNone {
+		context.instancePool.forget(context.instance)
+		return false
+	}
+
+	pooled, evicted := context.instancePool.put(context.instance)
+	for _, instance := range evicted {
+		context.releaseInstanceMemory(instance)
+		instance.Clean()
+	}
+
+	return pooled
+}
+
+// clearInstancePool cleans the instances kept in the pool
+func (context *runtimeContext) clearInstancePool() {
+	if context.instancePool == nil {
+		return
+	}
+
+	for _, instance := range context.instancePool.clear() {
+		context.releaseInstanceMemory(instance)
+		instance.Clean()
+	}
+	logRuntime.Trace("instance pool cleared")
 }
 
 func (context *runtimeContext) makeInstanceFromCompiledCode(codeHash []byte, gasLimit uint64, newCode bool) bool {
@@ -145,15 +556,15 @@ func (context *runtimeContext) makeInstanceFromCompiledCode(codeHash []byte, gas
 		return false
 	}
 
-	blockchain := context.host.Blockchain()
-	found, compiledCode := blockchain.GetCompiledCode(codeHash)
+	compiledCodeKey := context.getCompiledCodeKey(codeHash)
+	found, compiledCode := context.getCompiledCode(compiledCodeKey)
 	if !found {
 		logRuntime.Trace("instance creation", "code", "cached compilation", "error", "compiled code was not found")
 		return false
 	}
 
 	gasSchedule := context.host.Metering().GasSchedule()
-	options := wasmer.CompilationOptions{
+	options := arwen.CompilationOptions{
 		GasLimit:           gasLimit,
 		UnmeteredLocals:    uint64(gasSchedule.WASMOpcodeCost.LocalsUnmetered),
 		OpcodeTrace:        false,
@@ -163,10 +574,13 @@ func (context *runtimeContext) makeInstanceFromCompiledCode(codeHash []byte, gas
 	newInstance, err := context.instanceBuilder.NewInstanceFromCompiledCodeWithOptions(compiledCode, options)
 	if err != nil {
 		logRuntime.Error("instance creation", "code", "cached compilation", "error", err)
+		context.removeCompiledCodeFromDisk(compiledCodeKey)
 		return false
 	}
 
 	context.instance = newInstance
+	context.reserveInstanceMemory(newInstance)
+	context.reserveCompiledCodeMemory(newInstance, len(compiledCode))
 
 	hostReference := uintptr(unsafe.Pointer(&context.host))
 	context.instance.SetContextData(hostReference)
@@ -177,15 +591,26 @@ func (context *runtimeContext) makeInstanceFromCompiledCode(codeHash []byte, gas
 }
 
 func (context *runtimeContext) makeInstanceFromContractByteCode(contract []byte, codeHash []byte, gasLimit uint64, newCode bool) error {
+	if newCode && context.verifyCode {
+		err := context.validationConfig.ValidateModule(contract)
+		if err != nil {
+			context.instance = nil
+			context.verifyCode = false
+			logRuntime.Trace("instance creation", "code", "bytecode", "error", err)
+			return err
+		}
+	}
+
 	gasSchedule := context.host.Metering().GasSchedule()
-	options := wasmer.CompilationOptions{
+	options := arwen.CompilationOptions{
 		GasLimit:           gasLimit,
 		UnmeteredLocals:    uint64(gasSchedule.WASMOpcodeCost.LocalsUnmetered),
 		OpcodeTrace:        false,
 		Metering:           true,
 		RuntimeBreakpoints: true,
 	}
-	newInstance, err := context.instanceBuilder.NewInstanceWithOptions(contract, options)
+	cappedContract := context.memoryLimits.CapModuleMemory(contract)
+	newInstance, err := context.instanceBuilder.NewInstanceWithOptions(cappedContract, options)
 	if err != nil {
 		context.instance = nil
 		logRuntime.Trace("instance creation", "code", "bytecode", "error", err)
@@ -193,17 +618,18 @@ func (context *runtimeContext) makeInstanceFromContractByteCode(contract []byte,
 	}
 
 	context.instance = newInstance
+	context.reserveInstanceMemory(newInstance)
 
 	if newCode || len(codeHash) == 0 {
 		codeHash, err = context.host.Crypto().Sha256(contract)
 		if err != nil {
-			context.CleanWasmerInstance()
+			context.cleanWasmerInstance()
 			logRuntime.Error("instance creation", "code", "bytecode", "error", err)
 			return err
 		}
 	}
 
-	context.saveCompiledCode(codeHash)
+	context.saveCompiledCode(context.getCompiledCodeKey(codeHash))
 
 	hostReference := uintptr(unsafe.Pointer(&context.host))
 	context.instance.SetContextData(hostReference)
@@ -211,13 +637,13 @@ func (context *runtimeContext) makeInstanceFromContractByteCode(contract []byte,
 	if newCode {
 		err = context.VerifyContractCode()
 		if err != nil {
-			context.CleanWasmerInstance()
+			context.cleanWasmerInstance()
 			logRuntime.Trace("instance creation", "code", "bytecode", "error", err)
 			return err
 		}
 	}
 
-	if context.useWarmInstance {
+	if context.useWarmInstance && !context.isInstanceRunning(context.warmInstance) {
 		context.warmInstanceAddress = context.GetSCAddress()
 		context.warmInstance = context.instance
 		logRuntime.Trace("updated warm instance")
@@ -228,6 +654,133 @@ func (context *runtimeContext) makeInstanceFromContractByteCode(contract []byte,
 	return nil
 }
 
+// checkMemoryForNewInstance refuses the creation of a new Wasmer instance
+// while the memory of the VMHost is near its ceiling, after trying to free
+// memory by clearing the instance pool and evicting the warm instance
+func (context *runtimeContext) checkMemoryForNewInstance() error {
+	memory := context.memory
+	for instance := range context.instanceMemory {
+		context.updateInstanceMemory(instance)
+	}
+	context.updateManagedObjectsMemory()
+	if !memory.IsNearCeiling() {
+		return nil
+	}
+
+	context.clearInstancePool()
+	context.evictWarmInstance()
+	if !memory.IsNearCeiling() {
+		return nil
+	}
+
+	logRuntime.Warn("create instance", "error", arwen.ErrMemoryLimitReached,
+		"in use", memory.InUse(), "ceiling", memory.Ceiling())
+	context.AddError(arwen.ErrMemoryLimitReached)
+	return arwen.ErrMemoryLimitReached
+}
+
+// evictWarmInstance cleans the warm instance, unless it is currently running;
+// the running instances are all on the instance stack while a new one is started
+func (context *runtimeContext) evictWarmInstance() {
+	if context.warmInstance == nil || context.isInstanceRunning(context.warmInstance) {
+		return
+	}
+
+	if context.instance == context.warmInstance {
+		context.instance = nil
+	}
+
+	context.releaseInstanceMemory(context.warmInstance)
+	context.warmInstance.Clean()
+	context.warmInstance = nil
+	context.warmInstanceAddress = nil
+	logRuntime.Trace("warm instance evicted")
+}
+
+// isInstanceRunning returns true if the given instance is on the instance
+// stack, its execution being suspended until a nested call ends
+func (context *runtimeContext) isInstanceRunning(instance wasmer.InstanceHandler) bool {
+	for _, runningInstance := range context.instanceStack {
+		if runningInstance == instance {
+			return true
+		}
+	}
+
+	return false
+}
+
+// MemoryAccountant returns the MemoryAccountant of the VMHost
+func (context *runtimeContext) MemoryAccountant() *arwen.MemoryAccountant {
+	return context.memory
+}
+
+func (context *runtimeContext) reserveInstanceMemory(instance wasmer.InstanceHandler) {
+	size := linearMemorySize(instance)
+	context.instanceMemory[instance] = &instanceMemoryUsage{linearMemory: size}
+	context.memory.Reserve(arwen.MemoryWasmerInstances, size)
+}
+
+// updateInstanceMemory accounts the current size of the linear memory of the
+// given instance, which grows whenever its contract executes memory.grow
+func (context *runtimeContext) updateInstanceMemory(instance wasmer.InstanceHandler) {
+	usage, ok := context.instanceMemory[instance]
+	if !ok {
+		return
+	}
+
+	size := linearMemorySize(instance)
+	context.memory.Release(arwen.MemoryWasmerInstances, usage.linearMemory)
+	context.memory.Reserve(arwen.MemoryWasmerInstances, size)
+	usage.linearMemory = size
+}
+
+func (context *runtimeContext) reserveCompiledCodeMemory(instance wasmer.InstanceHandler, size int) {
+	usage, ok := context.instanceMemory[instance]
+	if !ok {
+		return
+	}
+
+	context.memory.Release(arwen.MemoryCompiledCode, usage.compiledCode)
+	context.memory.Reserve(arwen.MemoryCompiledCode, uint64(size))
+	usage.compiledCode = uint64(size)
+}
+
+func (context *runtimeContext) releaseInstanceMemory(instance wasmer.InstanceHandler) {
+	usage, ok := context.instanceMemory[instance]
+	if !ok {
+		return
+	}
+
+	delete(context.instanceMemory, instance)
+	delete(context.memoryPagesAtStart, instance)
+	context.memory.Release(arwen.MemoryWasmerInstances, usage.linearMemory)
+	context.memory.Release(arwen.MemoryCompiledCode, usage.compiledCode)
+}
+
+func linearMemorySize(instance wasmer.InstanceHandler) uint64 {
+	if !instance.HasMemory() || instance.GetMemory() == nil {
+		return 0
+	}
+
+	return uint64(instance.GetMemory().Length())
+}
+
+func (context *runtimeContext) updateManagedObjectsMemory() {
+	bigInt := context.host.BigInt()
+	if bigInt == nil {
+		return
+	}
+
+	context.releaseManagedObjectsMemory()
+	context.managedObjectsMemory = bigInt.MemoryUsage() + context.managedTypes.MemoryUsage()
+	context.memory.Reserve(arwen.MemoryManagedObjects, context.managedObjectsMemory)
+}
+
+func (context *runtimeContext) releaseManagedObjectsMemory() {
+	context.memory.Release(arwen.MemoryManagedObjects, context.managedObjectsMemory)
+	context.managedObjectsMemory = 0
+}
+
 // GetSCCode returns the SC code of the current SC.
 func (context *runtimeContext) GetSCCode() ([]byte, error) {
 	blockchain := context.host.Blockchain()
@@ -245,15 +798,102 @@ func (context *runtimeContext) GetSCCodeSize() uint64 {
 	return context.codeSize
 }
 
-func (context *runtimeContext) saveCompiledCode(codeHash []byte) {
+// getCompiledCodeKey returns the key under which the compiled code of the
+// given code hash is cached: the code compiled with other opcode costs, or by
+// another executor or another version of it, is kept under other keys
+func (context *runtimeContext) getCompiledCodeKey(codeHash []byte) []byte {
+	if context.compilationSettings == nil {
+		context.compilationSettings = context.computeCompilationSettings()
+	}
+
+	key := sha256.Sum256(append(append([]byte{}, codeHash...), context.compilationSettings...))
+	return key[:]
+}
+
+// computeCompilationSettings hashes everything the compiled code depends on,
+// besides the code of the contract
+func (context *runtimeContext) computeCompilationSettings() []byte {
+	hasher := sha256.New()
+	if context.host.Metering() != nil {
+		opcodeCosts := context.host.Metering().GasSchedule().WASMOpcodeCost.ToOpcodeCostsArray()
+		_ = binary.Write(hasher, binary.BigEndian, opcodeCosts)
+	}
+	_, _ = hasher.Write([]byte(context.GetExecutorName()))
+	_, _ = hasher.Write([]byte{0})
+	_, _ = hasher.Write([]byte(context.getExecutorVersion()))
+	if context.memoryLimits.MaxMemoryPages > 0 {
+		// the maximum memory is written into the module before compiling it
+		_ = binary.Write(hasher, binary.BigEndian, context.memoryLimits.MaxMemoryPages)
+	}
+	return hasher.Sum(nil)
+}
+
+func (context *runtimeContext) saveCompiledCode(compiledCodeKey []byte) {
 	compiledCode, err := context.instance.Cache()
 	if err != nil {
 		logRuntime.Error("getCompiledCode from instance", "error", err)
 		return
 	}
+	context.reserveCompiledCodeMemory(context.instance, len(compiledCode))
+
+	blockchain := context.host.Blockchain()
+	blockchain.SaveCompiledCode(compiledCodeKey, compiledCode)
+
+	if context.compiledCodeDiskCache != nil {
+		context.compiledCodeDiskCache.put(compiledCodeKey, compiledCode)
+	}
+}
 
+// getCompiledCode returns the compiled code cached under the given key,
+// looking it up through the blockchain hook first, then in the disk cache;
+// the code found on disk is saved through the blockchain hook as well
+func (context *runtimeContext) getCompiledCode(compiledCodeKey []byte) (bool, []byte) {
 	blockchain := context.host.Blockchain()
-	blockchain.SaveCompiledCode(codeHash, compiledCode)
+	found, compiledCode := blockchain.GetCompiledCode(compiledCodeKey)
+	if found || context.compiledCodeDiskCache == nil {
+		return found, compiledCode
+	}
+
+	found, compiledCode = context.compiledCodeDiskCache.get(compiledCodeKey)
+	if found {
+		logRuntime.Trace("compiled code found in the disk cache")
+		blockchain.SaveCompiledCode(compiledCodeKey, compiledCode)
+	}
+
+	return found, compiledCode
+}
+
+// removeCompiledCodeFromDisk removes compiled code which could not be
+// instantiated, e.g. because it was compiled by another version of Wasmer
+func (context *runtimeContext) removeCompiledCodeFromDisk(compiledCodeKey []byte) {
+	if context.compiledCodeDiskCache == nil {
+		return
+	}
+
+	context.compiledCodeDiskCache.remove(compiledCodeKey)
+}
+
+// ClearCompiledCode discards the compiled code kept on disk, and makes the
+// compiled code cached through the blockchain hook unreachable, because it
+// was compiled with the previous opcode costs
+func (context *runtimeContext) ClearCompiledCode() {
+	context.compilationSettings = nil
+	if context.compiledCodeDiskCache != nil {
+		context.compiledCodeDiskCache.clear()
+	}
+}
+
+// EnableCompiledCodeDiskCache makes the runtime also keep the compiled code
+// of at most the given number of contracts in the given directory, to skip
+// their compilation after restarts
+func (context *runtimeContext) EnableCompiledCodeDiskCache(directory string, size uint64) error {
+	cache, err := newCompiledCodeDiskCache(directory, int(size))
+	if err != nil {
+		return err
+	}
+
+	context.compiledCodeDiskCache = cache
+	return nil
 }
 
 // IsWarmInstance returns true if there is a warm instance equal to the current wasmer instance.
@@ -265,12 +905,18 @@ func (context *runtimeContext) IsWarmInstance() bool {
 	return false
 }
 
-// ResetWarmInstance clears the fields for the current wasmer instance, warm instance, and warm instance address
+// ResetWarmInstance clears the fields for the current wasmer instance, warm instance, and warm instance address,
+// and cleans the instances kept in the instance pool
 func (context *runtimeContext) ResetWarmInstance() {
+	context.mutInstances.Lock()
+	defer context.mutInstances.Unlock()
+
+	context.clearInstancePool()
 	if context.instance == nil {
 		return
 	}
 
+	context.releaseInstanceMemory(context.instance)
 	context.instance.Clean()
 
 	context.instance = nil
@@ -289,11 +935,94 @@ func (context *runtimeContext) SetMaxInstanceCount(maxInstances uint64) {
 	context.maxWasmerInstances = maxInstances
 }
 
+// SetDebugStop enables the single-step debugging mode, in which executions
+// are stopped where the given DebugStop designates, invoking the callback
+func (context *runtimeContext) SetDebugStop(stop arwen.DebugStop, callback arwen.DebugStepCallback) {
+	context.debugStop = stop
+	context.debugStepCallback = callback
+	context.debugStopInstance = nil
+}
+
+// applyDebugStop lowers the points limit of the instance just started, if it
+// is the one designated by the DebugStop, so that its metering stops it once
+// it has used the points of the DebugStop
+func (context *runtimeContext) applyDebugStop(gasLimit uint64) {
+	if !context.debugStop.IsSet() || context.debugStepCallback == nil {
+		return
+	}
+	if context.debugStopInstance == context.instance {
+		context.debugStopInstance = nil
+	}
+
+	context.debugInstanceCount++
+	if context.debugInstanceCount != context.debugStop.Instance || context.debugStop.Points >= gasLimit {
+		return
+	}
+
+	context.debugStopInstance = context.instance
+	context.debugStopGasLimit = gasLimit
+	context.instance.SetGasLimit(context.debugStop.Points)
+}
+
+// TakeDebugStep returns true if the current instance was stopped by its
+// metering at the points of the DebugStop, rather than for running out of
+// gas, in which case the callback of the single-step debugging mode is
+// invoked while the memory of the instance is still available
+func (context *runtimeContext) TakeDebugStep() bool {
+	instance := context.instance
+	if instance == nil || instance != context.debugStopInstance {
+		return false
+	}
+	context.debugStopInstance = nil
+
+	pointsUsed := instance.GetPointsUsed()
+	if pointsUsed > context.debugStopGasLimit {
+		return false
+	}
+
+	var memory wasmer.MemoryHandler
+	if instance.HasMemory() {
+		memory = instance.GetMemory()
+	}
+
+	step := arwen.NewDebugStep(context.debugStop.Instance, context.GetSCAddress(), context.Function(), context.host.Metering().GasLeft(), pointsUsed, memory)
+	logRuntime.Trace("debug step", "instance", step.Instance, "function", step.Function, "points", pointsUsed)
+	context.debugStepCallback(step)
+	return true
+}
+
+// SetMaxExecutionStackDepth sets the maximum number of nested executions on the
+// same or on a destination context; a value of 0 leaves the depth unlimited
+func (context *runtimeContext) SetMaxExecutionStackDepth(maxDepth uint64) {
+	context.maxExecutionStackDepth = maxDepth
+}
+
+// GetExecutionStackDepth returns the number of executions nested under the
+// current one, which is the number of runtime states pushed on the stack
+func (context *runtimeContext) GetExecutionStackDepth() uint64 {
+	return uint64(len(context.stateStack))
+}
+
+// CheckExecutionStackDepth returns ErrExecutionStackOverflow if a new nested
+// execution would exceed the maximum execution stack depth
+func (context *runtimeContext) CheckExecutionStackDepth() error {
+	if context.maxExecutionStackDepth == 0 {
+		return nil
+	}
+	if context.GetExecutionStackDepth() >= context.maxExecutionStackDepth {
+		logRuntime.Trace("nested execution", "error", arwen.ErrExecutionStackOverflow, "depth", context.GetExecutionStackDepth())
+		return arwen.ErrExecutionStackOverflow
+	}
+
+	return nil
+}
+
 // InitStateFromContractCallInput initializes the runtime context state with the values from the given input
 func (context *runtimeContext) InitStateFromContractCallInput(input *vmcommon.ContractCallInput) {
 	context.SetVMInput(&input.VMInput)
 	context.scAddress = input.RecipientAddr
 	context.callFunction = input.Function
+	context.esdtTransfers = nil
 	// Reset async map for initial state
 	context.asyncContextInfo = &arwen.AsyncContextInfo{
 		CallerAddr:      input.CallerAddr,
@@ -322,6 +1051,7 @@ func (context *runtimeContext) PushState() {
 		readOnly:         context.readOnly,
 		asyncCallInfo:    context.asyncCallInfo,
 		asyncContextInfo: context.asyncContextInfo,
+		esdtTransfers:    context.esdtTransfers,
 	}
 	newState.SetVMInput(context.vmInput)
 
@@ -351,6 +1081,7 @@ func (context *runtimeContext) PopSetActiveState() {
 	context.readOnly = prevState.readOnly
 	context.asyncCallInfo = prevState.asyncCallInfo
 	context.asyncContextInfo = prevState.asyncContextInfo
+	context.esdtTransfers = prevState.esdtTransfers
 	context.popInstance()
 }
 
@@ -368,16 +1099,23 @@ func (context *runtimeContext) PopDiscard() {
 // ClearStateStack reinitializes the state stack.
 func (context *runtimeContext) ClearStateStack() {
 	context.stateStack = make([]*runtimeContext, 0)
+	context.managedTypes.ClearStateStack()
 }
 
 // pushInstance appends the current wasmer instance to the instance stack.
 func (context *runtimeContext) pushInstance() {
+	context.mutInstances.Lock()
+	defer context.mutInstances.Unlock()
+
 	context.instanceStack = append(context.instanceStack, context.instance)
 }
 
 // popInstance removes the latest entry from the wasmer instance stack and sets it
 // as the current wasmer instance
 func (context *runtimeContext) popInstance() {
+	context.mutInstances.Lock()
+	defer context.mutInstances.Unlock()
+
 	instanceStackLen := len(context.instanceStack)
 	if instanceStackLen == 0 {
 		return
@@ -396,7 +1134,7 @@ func (context *runtimeContext) popInstance() {
 		return
 	}
 
-	context.CleanWasmerInstance()
+	context.cleanWasmerInstance()
 	context.instance = prevInstance
 }
 
@@ -410,6 +1148,32 @@ func (context *runtimeContext) GetVMType() []byte {
 	return context.vmType
 }
 
+// SetESDTTransfers sets the tokens received by the current call, for the calls
+// receiving several tokens, which the vm input cannot describe
+func (context *runtimeContext) SetESDTTransfers(transfers []*arwen.ESDTTransfer) {
+	context.esdtTransfers = transfers
+}
+
+// GetESDTTransfers returns the tokens received by the current call; the
+// single token described by the vm input is returned when no others were set
+func (context *runtimeContext) GetESDTTransfers() []*arwen.ESDTTransfer {
+	if context.esdtTransfers != nil {
+		return context.esdtTransfers
+	}
+
+	if context.vmInput == nil || len(context.vmInput.ESDTTokenName) == 0 {
+		return nil
+	}
+
+	return []*arwen.ESDTTransfer{
+		{
+			TokenIdentifier: context.vmInput.ESDTTokenName,
+			Nonce:           context.vmInput.ESDTTokenNonce,
+			Value:           context.vmInput.ESDTValue,
+		},
+	}
+}
+
 // GetVMInput returns the vm input for the current context.
 func (context *runtimeContext) GetVMInput() *vmcommon.VMInput {
 	return context.vmInput
@@ -542,9 +1306,194 @@ func (context *runtimeContext) SignalUserError(message string) {
 	context.host.Output().SetReturnCode(vmcommon.UserError)
 	context.host.Output().SetReturnMessage(message)
 	context.SetRuntimeBreakpointValue(arwen.BreakpointSignalError)
+	context.captureUserErrorReason(message)
 	logRuntime.Trace("user error signalled", "message", message)
 }
 
+func (context *runtimeContext) captureUserErrorReason(message string) {
+	if !context.userErrorCaptureEnabled && !context.userErrorDebugEnabled {
+		return
+	}
+
+	reason := &arwen.UserErrorReason{
+		Message:         message,
+		ContractAddress: context.scAddress,
+		Function:        context.callFunction,
+		FunctionIndex:   arwen.UnknownFunctionIndex,
+	}
+
+	code, err := context.host.Blockchain().GetCode(context.scAddress)
+	if err == nil {
+		index, ok := arwen.ExportedFunctionIndex(code, context.callFunction)
+		if ok {
+			reason.FunctionIndex = int32(index)
+		}
+	}
+
+	if context.userErrorDebugEnabled {
+		reason.CallStack = context.reconstructCallStack()
+	}
+
+	context.userErrorReason = reason
+}
+
+// reconstructCallStack returns the exported functions of the contracts in
+// the chain of nested executions, followed by the host function currently
+// called by the innermost contract, if any
+func (context *runtimeContext) reconstructCallStack() []arwen.UserErrorFrame {
+	callStack := make([]arwen.UserErrorFrame, 0, len(context.stateStack)+2)
+	for _, state := range context.stateStack {
+		if len(state.scAddress) == 0 {
+			continue
+		}
+		callStack = append(callStack, arwen.UserErrorFrame{
+			ContractAddress: state.scAddress,
+			Function:        state.callFunction,
+		})
+	}
+	callStack = append(callStack, arwen.UserErrorFrame{
+		ContractAddress: context.scAddress,
+		Function:        context.callFunction,
+	})
+
+	hostFunction, ok := callingHostFunction(2)
+	if ok {
+		callStack = append(callStack, arwen.UserErrorFrame{
+			Function:       hostFunction,
+			IsHostFunction: true,
+		})
+	}
+
+	return callStack
+}
+
+// GetUserErrorReason returns the description of the last error signalled by
+// a contract during the current execution, or nil if none was signalled or
+// capturing them is disabled
+func (context *runtimeContext) GetUserErrorReason() *arwen.UserErrorReason {
+	return context.userErrorReason
+}
+
+// SetUserErrorCaptureEnabled sets whether the Runtime context describes the
+// errors signalled by contracts, which is always the case in debug mode
+func (context *runtimeContext) SetUserErrorCaptureEnabled(enabled bool) {
+	context.userErrorCaptureEnabled = enabled
+}
+
+// EnableUserErrorDebug makes the Runtime context reconstruct the call stack
+// of the errors signalled by contracts, which are then also recorded in the
+// VMOutput of the failed executions
+func (context *runtimeContext) EnableUserErrorDebug() {
+	context.userErrorDebugEnabled = true
+}
+
+// CaptureOutOfGasReceipt records how far the current execution got before
+// running out of gas, if out-of-gas receipts are enabled; only the first
+// receipt of an execution is kept, which is the one of the innermost
+// contract, because running out of gas aborts all the executions above it
+func (context *runtimeContext) CaptureOutOfGasReceipt() {
+	if !context.outOfGasReceiptsEnabled || context.outOfGasReceipt != nil {
+		return
+	}
+
+	storageUpdates := context.host.Storage().GetStorageUpdates(context.scAddress)
+	storageKeys := make([][]byte, 0, len(storageUpdates))
+	for key := range storageUpdates {
+		storageKeys = append(storageKeys, []byte(key))
+	}
+	sort.Slice(storageKeys, func(i, j int) bool {
+		return bytes.Compare(storageKeys[i], storageKeys[j]) < 0
+	})
+
+	context.outOfGasReceipt = &arwen.OutOfGasReceipt{
+		ContractAddress:  context.scAddress,
+		Function:         context.callFunction,
+		LastHostFunction: context.host.Metering().GetLastHostFunction(),
+		StorageKeys:      storageKeys,
+	}
+}
+
+// GetOutOfGasReceipt returns the receipt of the current execution, or nil if
+// it did not run out of gas or if out-of-gas receipts are not enabled
+func (context *runtimeContext) GetOutOfGasReceipt() *arwen.OutOfGasReceipt {
+	return context.outOfGasReceipt
+}
+
+// EnableOutOfGasReceipts makes the Runtime context capture an
+// OutOfGasReceipt when an execution runs out of gas, which is then also
+// recorded in its VMOutput
+func (context *runtimeContext) EnableOutOfGasReceipts() {
+	context.outOfGasReceiptsEnabled = true
+}
+
+// CaptureCallStackTrace records the chain of nested executions which led to
+// the current execution failing with the given message, if call stack traces
+// are enabled. A trace captured by a nested execution of the current one is
+// kept, since the failure originated there and propagated to the current
+// execution; a trace captured by a previous execution on the same or on an
+// outer level is replaced.
+func (context *runtimeContext) CaptureCallStackTrace(message string) {
+	if !context.callStackTracesEnabled {
+		return
+	}
+
+	depth := len(context.stateStack)
+	if context.callStackTrace != nil && context.callStackTraceDepth > depth {
+		return
+	}
+
+	metering := context.host.Metering()
+	callersGasLeft := metering.GetGasLeftOfCallers()
+
+	frames := make([]arwen.CallStackFrame, 0, len(context.stateStack)+1)
+	for i, state := range context.stateStack {
+		if len(state.scAddress) == 0 {
+			continue
+		}
+
+		frame := arwen.CallStackFrame{
+			ContractAddress: state.scAddress,
+			Function:        state.callFunction,
+		}
+		if i < len(callersGasLeft) {
+			frame.GasRemaining = callersGasLeft[i]
+		}
+		frames = append(frames, frame)
+	}
+	frames = append(frames, arwen.CallStackFrame{
+		ContractAddress: context.scAddress,
+		Function:        context.callFunction,
+		GasRemaining:    metering.GasLeft(),
+	})
+
+	context.callStackTrace = &arwen.CallStackTrace{
+		Frames:  frames,
+		Message: message,
+	}
+	context.callStackTraceDepth = depth
+}
+
+// DiscardHandledCallStackTrace discards the call stack trace captured by the
+// current execution or by the executions nested in it, because the current
+// execution completed successfully, so it handled their failure
+func (context *runtimeContext) DiscardHandledCallStackTrace() {
+	if context.callStackTrace != nil && context.callStackTraceDepth >= len(context.stateStack) {
+		context.callStackTrace = nil
+	}
+}
+
+// GetCallStackTrace returns the call stack trace of the current execution,
+// or nil if it did not fail or if call stack traces are not enabled
+func (context *runtimeContext) GetCallStackTrace() *arwen.CallStackTrace {
+	return context.callStackTrace
+}
+
+// EnableCallStackTraces makes the Runtime context capture a CallStackTrace
+// when an execution fails, which is then also recorded in its VMOutput
+func (context *runtimeContext) EnableCallStackTraces() {
+	context.callStackTracesEnabled = true
+}
+
 // SetRuntimeBreakpointValue sets the given value as a breakpoint value.
 func (context *runtimeContext) SetRuntimeBreakpointValue(value arwen.BreakpointValue) {
 	context.instance.SetBreakpointValue(uint64(value))
@@ -690,16 +1639,48 @@ func (context *runtimeContext) GetInstanceExports() wasmer.ExportsMap {
 
 // CleanWasmerInstance cleans the current wasmer instance.
 func (context *runtimeContext) CleanWasmerInstance() {
+	context.mutInstances.Lock()
+	defer context.mutInstances.Unlock()
+
+	context.cleanWasmerInstance()
+}
+
+func (context *runtimeContext) cleanWasmerInstance() {
 	if context.instance == nil || context.IsWarmInstance() {
 		return
 	}
 
+	if context.putInstanceInPool() {
+		context.instance = nil
+		logRuntime.Trace("instance returned to the pool")
+		return
+	}
+
+	context.releaseInstanceMemory(context.instance)
 	context.instance.Clean()
 	context.instance = nil
 
 	logRuntime.Trace("instance cleaned")
 }
 
+// InterruptExecution stops the running instances on the execution timeout
+// breakpoint and refuses to start new instances until the next execution; it
+// is meant to be called from outside the goroutine running the execution
+func (context *runtimeContext) InterruptExecution() {
+	context.mutInstances.Lock()
+	defer context.mutInstances.Unlock()
+
+	context.interrupted = true
+	runningInstances := append([]wasmer.InstanceHandler{context.instance}, context.instanceStack...)
+	for _, instance := range runningInstances {
+		if instance != nil {
+			instance.SetBreakpointValue(uint64(arwen.BreakpointExecutionTimeout))
+		}
+	}
+
+	logRuntime.Trace("execution interrupted")
+}
+
 // IsContractOnTheStack iterates over the state stack to find whether the
 // provided SC address is already in execution, below the current instance.
 func (context *runtimeContext) IsContractOnTheStack(address []byte) bool {
@@ -746,14 +1727,9 @@ func (context *runtimeContext) ExecuteAsyncCall(address []byte, data []byte, val
 		return err
 	}
 
-	gasToLock := uint64(0)
-	shouldLockGas := context.HasCallbackMethod() || !context.host.IsDynamicGasLockingEnabled()
-	if shouldLockGas {
-		gasToLock = metering.ComputeGasLockedForAsync()
-		err = metering.UseGasBounded(gasToLock)
-		if err != nil {
-			return err
-		}
+	gasToLock, err := metering.EscrowGasForCallback(context.HasCallbackMethod())
+	if err != nil {
+		return err
 	}
 
 	context.SetAsyncCallInfo(&arwen.AsyncCallInfo{
@@ -780,6 +1756,17 @@ func (context *runtimeContext) SetAsyncCallInfo(asyncCallInfo *arwen.AsyncCallIn
 
 // AddAsyncContextCall adds the given async call to the asyncContextMap at the given identifier.
 func (context *runtimeContext) AddAsyncContextCall(contextIdentifier []byte, asyncCall *arwen.AsyncGeneratedCall) error {
+	if !context.host.IsMultiAsyncCallGroupsEnabled() {
+		return arwen.ErrMultiAsyncCallGroupsNotEnabled
+	}
+
+	if context.host.IsCallbackValidationEnabled() {
+		err := context.verifyAsyncCallbacks(asyncCall)
+		if err != nil {
+			return err
+		}
+	}
+
 	_, ok := context.asyncContextInfo.AsyncContextMap[string(contextIdentifier)]
 	currentContextMap := context.asyncContextInfo.AsyncContextMap
 	if !ok {
@@ -794,6 +1781,26 @@ func (context *runtimeContext) AddAsyncContextCall(contextIdentifier []byte, asy
 	return nil
 }
 
+// verifyAsyncCallbacks checks that the callbacks of the given async call can
+// be executed by the current contract, so that the registration fails early,
+// instead of the callback failing after the destination was executed, which
+// might be in another shard; a missing callback is allowed
+func (context *runtimeContext) verifyAsyncCallbacks(asyncCall *arwen.AsyncGeneratedCall) error {
+	for _, callbackName := range []string{asyncCall.SuccessCallback, asyncCall.ErrorCallback} {
+		if len(callbackName) == 0 {
+			continue
+		}
+
+		err := context.validator.verifyValidCallbackName(context.instance, callbackName)
+		if err != nil {
+			logRuntime.Trace("async call registration", "error", err)
+			return err
+		}
+	}
+
+	return nil
+}
+
 // GetAsyncContextInfo returns the async context info for the current context.
 func (context *runtimeContext) GetAsyncContextInfo() *arwen.AsyncContextInfo {
 	return context.asyncContextInfo
@@ -2,9 +2,12 @@ package contexts
 
 import (
 	"bytes"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"math/big"
+	"os"
 	"testing"
 
 	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
@@ -26,8 +29,12 @@ const counterWasmCode = "./../../test/contracts/counter/output/counter.wasm"
 func MakeAPIImports() *wasmer.Imports {
 	imports, _ := elrondapi.ElrondEIImports()
 	imports, _ = elrondapi.BigIntImports(imports)
+	imports, _ = elrondapi.BigFloatImports(imports)
+	imports, _ = elrondapi.ManagedMapImports(imports)
+	imports, _ = elrondapi.ManagedBufferImports(imports)
 	imports, _ = elrondapi.SmallIntImports(imports)
 	imports, _ = cryptoapi.CryptoImports(imports)
+	imports, _ = cryptoapi.EllipticCurveImports(imports)
 	return imports
 }
 
@@ -202,6 +209,25 @@ func TestRuntimeContext_StateSettersAndGetters(t *testing.T) {
 	require.Equal(t, []byte("smartcontract"), runtimeContext.GetSCAddress())
 }
 
+func TestRuntimeContext_NextRandomCounter(t *testing.T) {
+	imports := MakeAPIImports()
+	host := &contextmock.VMHostMock{}
+	host.SCAPIMethods = imports
+
+	runtimeContext, _ := NewRuntimeContext(host, []byte("type"), false)
+	require.Equal(t, uint64(0), runtimeContext.NextRandomCounter())
+	require.Equal(t, uint64(1), runtimeContext.NextRandomCounter())
+
+	// Nested executions within the transaction keep counting
+	runtimeContext.PushState()
+	require.Equal(t, uint64(2), runtimeContext.NextRandomCounter())
+	runtimeContext.PopSetActiveState()
+	require.Equal(t, uint64(3), runtimeContext.NextRandomCounter())
+
+	runtimeContext.InitState()
+	require.Equal(t, uint64(0), runtimeContext.NextRandomCounter())
+}
+
 func TestRuntimeContext_PushPopInstance(t *testing.T) {
 	host := InitializeArwenAndWasmer()
 
@@ -289,6 +315,42 @@ func TestRuntimeContext_PushPopState(t *testing.T) {
 	require.Equal(t, 0, len(runtimeContext.stateStack))
 }
 
+func TestRuntimeContext_ESDTTransfers(t *testing.T) {
+	host := &contextmock.VMHostMock{}
+	host.SCAPIMethods = MakeAPIImports()
+	runtimeContext, _ := NewRuntimeContext(host, []byte("type"), false)
+
+	input := &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallValue:      big.NewInt(0),
+			ESDTValue:      big.NewInt(5),
+			ESDTTokenName:  []byte("TOKEN-abcdef"),
+			ESDTTokenNonce: 2,
+		},
+	}
+	runtimeContext.InitStateFromContractCallInput(input)
+	singleTransfer := []*arwen.ESDTTransfer{
+		{TokenIdentifier: []byte("TOKEN-abcdef"), Nonce: 2, Value: big.NewInt(5)},
+	}
+	require.Equal(t, singleTransfer, runtimeContext.GetESDTTransfers())
+
+	transfers := []*arwen.ESDTTransfer{
+		{TokenIdentifier: []byte("FIRST-abcdef"), Value: big.NewInt(1)},
+		{TokenIdentifier: []byte("SECOND-abcdef"), Value: big.NewInt(2)},
+	}
+	runtimeContext.SetESDTTransfers(transfers)
+	require.Equal(t, transfers, runtimeContext.GetESDTTransfers())
+
+	runtimeContext.PushState()
+	runtimeContext.InitStateFromContractCallInput(&vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{CallValue: big.NewInt(0)},
+	})
+	require.Nil(t, runtimeContext.GetESDTTransfers())
+
+	runtimeContext.PopSetActiveState()
+	require.Equal(t, transfers, runtimeContext.GetESDTTransfers())
+}
+
 func TestRuntimeContext_Instance(t *testing.T) {
 	host := InitializeArwenAndWasmer()
 
@@ -680,3 +742,167 @@ func TestRuntimeContext_PopInstanceIfStackIsEmptyShouldNotPanic(t *testing.T) {
 
 	require.Equal(t, 0, len(runtimeContext.stateStack))
 }
+
+func TestRuntimeContext_InstancePool(t *testing.T) {
+	pool := newInstancePool(2, arwen.NewMemoryAccountant())
+
+	first := contextmock.NewInstanceMock([]byte("first"))
+	second := contextmock.NewInstanceMock([]byte("second"))
+	third := contextmock.NewInstanceMock([]byte("third"))
+	duplicate := contextmock.NewInstanceMock([]byte("first"))
+	untracked := contextmock.NewInstanceMock([]byte("untracked"))
+
+	pool.track([]byte("first"), first)
+	pool.track([]byte("second"), second)
+	pool.track([]byte("third"), third)
+	pool.track([]byte("first"), duplicate)
+
+	// the memory of a pooled instance is restored when it is taken
+	first.GetMemory().Data()[0] = 42
+	pooled, evicted := pool.put(first)
+	require.True(t, pooled)
+	require.Empty(t, evicted)
+
+	instance, ok := pool.take([]byte("first"))
+	require.True(t, ok)
+	require.Equal(t, first, instance)
+	require.Equal(t, byte(0), first.GetMemory().Data()[0])
+	_, ok = pool.take([]byte("first"))
+	require.False(t, ok)
+
+	// at most one instance is kept per code hash
+	pooled, _ = pool.put(first)
+	require.True(t, pooled)
+	pooled, _ = pool.put(duplicate)
+	require.False(t, pooled)
+
+	pooled, _ = pool.put(untracked)
+	require.False(t, pooled)
+
+	// the least recently used instance is evicted when the pool is full
+	pooled, _ = pool.put(second)
+	require.True(t, pooled)
+	pooled, evicted = pool.put(third)
+	require.True(t, pooled)
+	require.Equal(t, []wasmer.InstanceHandler{first}, evicted)
+	require.Equal(t, 2, pool.numIdle())
+
+	// an instance whose memory has grown is not kept
+	instance, _ = pool.take([]byte("second"))
+	_ = instance.GetMemory().Grow(1)
+	pooled, _ = pool.put(instance)
+	require.False(t, pooled)
+
+	require.Equal(t, []wasmer.InstanceHandler{third}, pool.clear())
+	require.Equal(t, 0, pool.numIdle())
+	pooled, _ = pool.put(third)
+	require.False(t, pooled)
+}
+
+func TestRuntimeContext_CompiledCodeDiskCache(t *testing.T) {
+	directory, err := ioutil.TempDir("", "compiledCode")
+	require.Nil(t, err)
+	defer func() {
+		_ = os.RemoveAll(directory)
+	}()
+
+	cache, err := newCompiledCodeDiskCache(directory, 2)
+	require.Nil(t, err)
+
+	found, _ := cache.get([]byte("first"))
+	require.False(t, found)
+
+	cache.put([]byte("first"), []byte("first compiled code"))
+	cache.put([]byte("second"), []byte("second compiled code"))
+	found, compiledCode := cache.get([]byte("first"))
+	require.True(t, found)
+	require.Equal(t, []byte("first compiled code"), compiledCode)
+
+	// the least recently used code is evicted
+	cache.put([]byte("third"), []byte("third compiled code"))
+	found, _ = cache.get([]byte("second"))
+	require.False(t, found)
+
+	// the cached code survives restarts
+	cache, err = newCompiledCodeDiskCache(directory, 2)
+	require.Nil(t, err)
+	found, compiledCode = cache.get([]byte("third"))
+	require.True(t, found)
+	require.Equal(t, []byte("third compiled code"), compiledCode)
+
+	// corrupted files are removed
+	path := cache.path(hex.EncodeToString([]byte("first")))
+	contents, err := ioutil.ReadFile(path)
+	require.Nil(t, err)
+	contents[len(contents)-1]++
+	err = ioutil.WriteFile(path, contents, 0640)
+	require.Nil(t, err)
+
+	found, _ = cache.get([]byte("first"))
+	require.False(t, found)
+	_, err = os.Stat(path)
+	require.True(t, os.IsNotExist(err))
+}
+
+func TestRuntimeContext_ReentrantCallGetsOwnInstance(t *testing.T) {
+	host := InitializeArwenAndWasmer()
+
+	vmType := []byte("type")
+	runtimeContext, _ := NewRuntimeContext(host, vmType, true)
+	runtimeContext.SetMaxInstanceCount(2)
+	runtimeContext.SetSCAddress([]byte("smartcontract"))
+	defer runtimeContext.ResetWarmInstance()
+
+	gasLimit := uint64(100000000)
+	contractCode := arwen.GetSCCode(counterWasmCode)
+	err := runtimeContext.StartWasmerInstance(contractCode, gasLimit, false)
+	require.Nil(t, err)
+	outerInstance := runtimeContext.instance
+	require.True(t, runtimeContext.IsWarmInstance())
+
+	// the warm instance is suspended, so the same contract gets a new instance
+	runtimeContext.pushInstance()
+	err = runtimeContext.StartWasmerInstance(contractCode, gasLimit, false)
+	require.Nil(t, err)
+	require.NotEqual(t, outerInstance, runtimeContext.instance)
+	require.False(t, runtimeContext.IsWarmInstance())
+
+	runtimeContext.popInstance()
+	require.Equal(t, outerInstance, runtimeContext.instance)
+	require.True(t, runtimeContext.IsWarmInstance())
+
+	// once no longer suspended, the warm instance is reused
+	runtimeContext.CleanWasmerInstance()
+	err = runtimeContext.StartWasmerInstance(contractCode, gasLimit, false)
+	require.Nil(t, err)
+	require.Equal(t, outerInstance, runtimeContext.instance)
+}
+
+func TestRuntimeContext_CompiledCodeKey(t *testing.T) {
+	host := InitializeArwenAndWasmer()
+	runtimeContext, _ := NewRuntimeContext(host, []byte("type"), false)
+
+	key := runtimeContext.getCompiledCodeKey([]byte("codeHash"))
+	require.Equal(t, key, runtimeContext.getCompiledCodeKey([]byte("codeHash")))
+	require.NotEqual(t, key, runtimeContext.getCompiledCodeKey([]byte("otherCodeHash")))
+
+	runtimeContext.SetExecutor(contextmock.NewInstanceBuilderRecorderMock())
+	recorderKey := runtimeContext.getCompiledCodeKey([]byte("codeHash"))
+	require.NotEqual(t, key, recorderKey)
+
+	// another version of the same executor compiles the code under another key
+	runtimeContext.SetExecutor(&versionedExecutor{
+		Executor: contextmock.NewInstanceBuilderRecorderMock(),
+		version:  "next",
+	})
+	require.NotEqual(t, recorderKey, runtimeContext.getCompiledCodeKey([]byte("codeHash")))
+}
+
+type versionedExecutor struct {
+	arwen.Executor
+	version string
+}
+
+func (executor *versionedExecutor) Version() string {
+	return executor.version
+}
@@ -3,6 +3,8 @@ package contexts
 import (
 	"bytes"
 	"errors"
+	"math/big"
+	"sort"
 
 	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
 	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/math"
@@ -18,10 +20,31 @@ type storageContext struct {
 	blockChainHook                vmcommon.BlockchainHook
 	address                       []byte
 	stateStack                    [][]byte
-	elrondProtectedKeyPrefix      []byte
+	snapshots                     []*storageSnapshot
+	batch                         *storageBatch
+	transient                     *storageBatch
+	loadCache                     *storageLoadCache
+	transactions                  []*storageTransaction
+	limits                        arwen.StorageLimits
+	storageSizes                  map[string]int64
+	namespaces                    *arwen.StorageNamespaces
 	arwenStorageProtectionEnabled bool
 }
 
+type storageSnapshot struct {
+	key             string
+	address         []byte
+	batchLength     int
+	transientLength int
+	loadCacheLength int
+}
+
+type storageTransaction struct {
+	batchLength     int
+	transientLength int
+	loadCacheLength int
+}
+
 // NewStorageContext creates a new storageContext
 func NewStorageContext(
 	host arwen.VMHost,
@@ -35,15 +58,33 @@ func NewStorageContext(
 		host:                          host,
 		blockChainHook:                blockChainHook,
 		stateStack:                    make([][]byte, 0),
-		elrondProtectedKeyPrefix:      elrondProtectedKeyPrefix,
+		snapshots:                     make([]*storageSnapshot, 0),
+		batch:                         newStorageBatch(),
+		transient:                     newStorageBatch(),
+		loadCache:                     newStorageLoadCache(),
+		transactions:                  make([]*storageTransaction, 0),
+		storageSizes:                  make(map[string]int64),
+		namespaces:                    arwen.NewStorageNamespaces(elrondProtectedKeyPrefix),
 		arwenStorageProtectionEnabled: true,
 	}
 
 	return context, nil
 }
 
-// InitState does nothing
+// SetStorageLimits sets the bounds of the storage which contracts may write
+func (context *storageContext) SetStorageLimits(limits arwen.StorageLimits) {
+	context.limits = limits
+}
+
+// InitState discards the storage writes not yet committed, the transient
+// storage, the keys loaded and the sizes of the storage of the contracts
+// retrieved from the node
 func (context *storageContext) InitState() {
+	context.batch = newStorageBatch()
+	context.transient = newStorageBatch()
+	context.loadCache = newStorageLoadCache()
+	context.transactions = make([]*storageTransaction, 0)
+	context.storageSizes = make(map[string]int64)
 }
 
 // PushState appends the current address to the state stack.
@@ -74,9 +115,119 @@ func (context *storageContext) PopDiscard() {
 	context.stateStack = context.stateStack[:stateStackLen-1]
 }
 
-// ClearStateStack clears the state stack from the current context.
+// ClearStateStack clears the state stack, the snapshots and the open transactions from the current context.
 func (context *storageContext) ClearStateStack() {
 	context.stateStack = make([][]byte, 0)
+	context.snapshots = make([]*storageSnapshot, 0)
+	context.transactions = make([]*storageTransaction, 0)
+}
+
+// BeginTransaction opens a transaction nested in the currently open one, if
+// any; the storage and transient storage writes made from now on can be
+// reverted together, along with the keys loaded meanwhile.
+func (context *storageContext) BeginTransaction() {
+	context.transactions = append(context.transactions, &storageTransaction{
+		batchLength:     context.batch.length(),
+		transientLength: context.transient.length(),
+		loadCacheLength: context.loadCache.length(),
+	})
+}
+
+// Commit closes the innermost open transaction, whose storage writes become
+// part of the enclosing transaction. If no transaction is open, the storage
+// writes held so far are materialized into the StorageUpdates of the output
+// accounts, in the order in which they were made; the transient storage
+// writes are never materialized.
+func (context *storageContext) Commit() {
+	numTransactions := len(context.transactions)
+	if numTransactions > 0 {
+		context.transactions = context.transactions[:numTransactions-1]
+		return
+	}
+
+	output := context.host.Output()
+	for _, write := range context.batch.writes {
+		account, _ := output.GetOutputAccount(write.address)
+		account.StorageUpdates[string(write.key)] = &vmcommon.StorageUpdate{
+			Offset: write.key,
+			Data:   write.data,
+		}
+	}
+
+	if context.limits.HasStorageQuota() {
+		context.commitStorageSizes()
+	}
+
+	logStorage.Trace("storage writes committed", "numWrites", context.batch.length())
+	context.batch = newStorageBatch()
+}
+
+// Revert closes the innermost open transaction and discards its storage and
+// transient storage writes, restoring the keys loaded as they were when it
+// was opened. If no transaction is open, all the storage writes not yet
+// committed are discarded, along with the transient storage.
+func (context *storageContext) Revert() {
+	numTransactions := len(context.transactions)
+	if numTransactions == 0 {
+		context.batch = newStorageBatch()
+		context.transient = newStorageBatch()
+		context.loadCache = newStorageLoadCache()
+		return
+	}
+
+	transaction := context.transactions[numTransactions-1]
+	context.transactions = context.transactions[:numTransactions-1]
+	context.batch.truncate(transaction.batchLength)
+	context.transient.truncate(transaction.transientLength)
+	context.loadCache.truncate(transaction.loadCacheLength)
+}
+
+// TakeSnapshot saves the current address and the storage writes made so far under the given key
+func (context *storageContext) TakeSnapshot(key string) {
+	context.snapshots = append(context.snapshots, &storageSnapshot{
+		key:             key,
+		address:         context.address,
+		batchLength:     context.batch.length(),
+		transientLength: context.transient.length(),
+		loadCacheLength: context.loadCache.length(),
+	})
+}
+
+// RevertToSnapshot restores the storage state saved under the given key and removes the snapshots taken since
+func (context *storageContext) RevertToSnapshot(key string) error {
+	index := context.findSnapshot(key)
+	if index < 0 {
+		return arwen.ErrStorageSnapshotNotFound
+	}
+
+	snapshot := context.snapshots[index]
+	context.address = snapshot.address
+	context.batch.truncate(snapshot.batchLength)
+	context.transient.truncate(snapshot.transientLength)
+	context.loadCache.truncate(snapshot.loadCacheLength)
+	context.snapshots = context.snapshots[:index]
+
+	return nil
+}
+
+// DiscardSnapshot removes the snapshot saved under the given key and the snapshots taken since
+func (context *storageContext) DiscardSnapshot(key string) {
+	index := context.findSnapshot(key)
+	if index < 0 {
+		return
+	}
+
+	context.snapshots = context.snapshots[:index]
+}
+
+func (context *storageContext) findSnapshot(key string) int {
+	for index := len(context.snapshots) - 1; index >= 0; index-- {
+		if context.snapshots[index].key == key {
+			return index
+		}
+	}
+
+	return -1
 }
 
 // SetAddress sets the given address as the address for the current context.
@@ -85,10 +236,58 @@ func (context *storageContext) SetAddress(address []byte) {
 	logStorage.Trace("storage under address set", "address", address)
 }
 
-// GetStorageUpdates returns the storage updates for the account mapped to the given address.
+// GetStorageUpdates returns the storage updates for the account mapped to the
+// given address: those of its output account, overridden by the storage
+// writes not yet committed. The returned map is a copy.
 func (context *storageContext) GetStorageUpdates(address []byte) map[string]*vmcommon.StorageUpdate {
 	account, _ := context.host.Output().GetOutputAccount(address)
-	return account.StorageUpdates
+	writes := context.batch.latestWrites(address)
+
+	storageUpdates := make(map[string]*vmcommon.StorageUpdate, len(account.StorageUpdates)+len(writes))
+	for key, update := range account.StorageUpdates {
+		storageUpdates[key] = update
+	}
+	for key, data := range writes {
+		storageUpdates[key] = &vmcommon.StorageUpdate{
+			Offset: []byte(key),
+			Data:   data,
+		}
+	}
+
+	return storageUpdates
+}
+
+// UseGasForStorageLoad uses the given load cost for loading the given key of
+// the storage of the current address, unless the key was already loaded
+// during the execution and not written since, in which case the cheaper
+// CachedStorageLoad cost is used.
+func (context *storageContext) UseGasForStorageLoad(key []byte, loadCost uint64) {
+	context.UseGasForStorageLoadFromAddress(context.address, key, loadCost)
+}
+
+// UseGasForStorageLoadFromAddress uses the given load cost for loading the
+// given key of the storage of the given address, unless the key was already
+// loaded during the execution and not written since, in which case the
+// cheaper CachedStorageLoad cost is used.
+func (context *storageContext) UseGasForStorageLoadFromAddress(address []byte, key []byte, loadCost uint64) {
+	metering := context.host.Metering()
+	if !context.host.IsStorageLoadCacheEnabled() {
+		metering.UseGas(loadCost)
+		return
+	}
+
+	if context.loadCache.isLoaded(address, key) {
+		cachedCost := metering.GasSchedule().ElrondAPICost.CachedStorageLoad
+		if cachedCost < loadCost {
+			loadCost = cachedCost
+		}
+		metering.UseGas(loadCost)
+		logStorage.Trace("cached load", "address", address, "key", key, "gas", loadCost)
+		return
+	}
+
+	metering.UseGas(loadCost)
+	context.loadCache.markLoaded(address, key)
 }
 
 // GetStorage returns the storage data mapped to the given key.
@@ -101,6 +300,12 @@ func (context *storageContext) GetStorage(key []byte) []byte {
 		metering.UseGas(gasToUse)
 	}
 
+	namespace := context.namespaces.Find(key)
+	if !namespace.CanRead(context.accessor()) {
+		logStorage.Trace("get", "error", "read denied", "namespace", namespace.Name, "key", key)
+		return nil
+	}
+
 	value := context.GetStorageUnmetered(key)
 
 	gasToUse := math.MulUint64(metering.GasSchedule().BaseOperationCost.DataCopyPerByte, uint64(len(value)))
@@ -133,13 +338,19 @@ func (context *storageContext) GetStorageFromAddress(address []byte, key []byte)
 		}
 	}
 
-	// If the requested key is protected by the Elrond node, the stored value
+	namespace := context.namespaces.Find(key)
+	if !namespace.CanRead(context.accessor()) {
+		logStorage.Trace("get from address", "error", "read denied", "namespace", namespace.Name, "key", key)
+		return nil
+	}
+
+	// If the requested key is managed by the Elrond node, the stored value
 	// could have been changed by a built-in function in the meantime, even if
 	// contracts themselves cannot change protected values. Values stored under
-	// protected keys must always be retrieved from the node, not from the cached
+	// such keys must always be retrieved from the node, not from the cached
 	// StorageUpdates.
 	var value []byte
-	if context.isElrondReservedKey(key) {
+	if namespace.NodeManaged {
 		value, _ = context.blockChainHook.GetStorageData(address, key)
 	} else {
 		value = context.getStorageFromAddressUnmetered(address, key)
@@ -153,18 +364,38 @@ func (context *storageContext) GetStorageFromAddress(address []byte, key []byte)
 	return value
 }
 
+// GetStorageFromContract returns the data under the given key from the
+// storage of the contract at the given address, as GetStorageFromAddress
+// does, but only if that contract is in the same shard as the current one;
+// the storage of a contract in another shard cannot be read consistently.
+func (context *storageContext) GetStorageFromContract(address []byte, key []byte) ([]byte, error) {
+	if !context.host.AreInSameShard(context.address, address) {
+		logStorage.Trace("get from contract", "error", "not in same shard", "address", address, "key", key)
+		return nil, arwen.ErrStorageReadNotInSameShard
+	}
+
+	return context.GetStorageFromAddress(address, key), nil
+}
+
+// getStorageFromAddressUnmetered returns the latest value written under the
+// given key, if not yet committed; otherwise, the value is read from the
+// StorageUpdates of the output account, which cache the values retrieved
+// from the node.
 func (context *storageContext) getStorageFromAddressUnmetered(address []byte, key []byte) []byte {
-	var value []byte
+	value, ok := context.batch.get(address, key)
+	if ok {
+		return value
+	}
 
-	storageUpdates := context.GetStorageUpdates(address)
-	if storageUpdate, ok := storageUpdates[string(key)]; ok {
-		value = storageUpdate.Data
-	} else {
-		value, _ = context.blockChainHook.GetStorageData(address, key)
-		storageUpdates[string(key)] = &vmcommon.StorageUpdate{
-			Offset: key,
-			Data:   value,
-		}
+	account, _ := context.host.Output().GetOutputAccount(address)
+	if storageUpdate, ok := account.StorageUpdates[string(key)]; ok {
+		return storageUpdate.Data
+	}
+
+	value, _ = context.blockChainHook.GetStorageData(address, key)
+	account.StorageUpdates[string(key)] = &vmcommon.StorageUpdate{
+		Offset: key,
+		Data:   value,
 	}
 
 	return value
@@ -175,6 +406,71 @@ func (context *storageContext) GetStorageUnmetered(key []byte) []byte {
 	return context.getStorageFromAddressUnmetered(context.address, key)
 }
 
+// GetKeysWithPrefix returns, in lexicographic order, at most maxResults keys
+// of the storage of the current address which start with the given prefix
+// and follow the key startAfter, if given; the keys written during the
+// execution are included, and the deleted ones excluded. The keys reserved
+// by the Elrond node are never returned. Gas is used for each key scanned,
+// stored or written during the execution, before the scan, then for each key
+// returned.
+func (context *storageContext) GetKeysWithPrefix(prefix []byte, startAfter []byte, maxResults uint64) ([][]byte, error) {
+	storedState, err := context.blockChainHook.GetAllState(context.address)
+	if err != nil {
+		return nil, err
+	}
+
+	metering := context.host.Metering()
+	storageUpdates := context.GetStorageUpdates(context.address)
+	numScannedKeys := uint64(len(storedState) + len(storageUpdates))
+	gasToUse := math.MulUint64(metering.GasSchedule().ElrondAPICost.StorageKeysScanPerKey, numScannedKeys)
+	err = metering.UseGasBounded(gasToUse)
+	if err != nil {
+		return nil, err
+	}
+
+	keySet := make(map[string]struct{}, len(storedState))
+	for key, value := range storedState {
+		if len(value) > 0 {
+			keySet[key] = struct{}{}
+		}
+	}
+	for key, update := range storageUpdates {
+		if len(update.Data) == 0 {
+			delete(keySet, key)
+			continue
+		}
+		keySet[key] = struct{}{}
+	}
+
+	keys := make([][]byte, 0, len(keySet))
+	for key := range keySet {
+		keyBytes := []byte(key)
+		if !bytes.HasPrefix(keyBytes, prefix) || context.namespaces.Find(keyBytes).NodeManaged {
+			continue
+		}
+		if len(startAfter) > 0 && bytes.Compare(keyBytes, startAfter) <= 0 {
+			continue
+		}
+		keys = append(keys, keyBytes)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		return bytes.Compare(keys[i], keys[j]) < 0
+	})
+	if uint64(len(keys)) > maxResults {
+		keys = keys[:maxResults]
+	}
+
+	for _, key := range keys {
+		gasToUse := math.MulUint64(metering.GasSchedule().BaseOperationCost.DataCopyPerByte, uint64(len(key)))
+		gasToUse = math.AddUint64(gasToUse, metering.GasSchedule().ElrondAPICost.StorageKeysPerKey)
+		metering.UseGas(gasToUse)
+	}
+
+	logStorage.Trace("get keys with prefix", "prefix", prefix, "startAfter", startAfter, "numKeys", len(keys))
+	return keys, nil
+}
+
 // enableStorageProtection will prevent writing to protected keys
 func (context *storageContext) enableStorageProtection() {
 	context.arwenStorageProtectionEnabled = true
@@ -185,12 +481,19 @@ func (context *storageContext) disableStorageProtection() {
 	context.arwenStorageProtectionEnabled = false
 }
 
-func (context *storageContext) isArwenProtectedKey(key []byte) bool {
-	return bytes.HasPrefix(key, []byte(arwen.ProtectedStoragePrefix))
-}
+// accessor returns the StorageAccessor of the code currently accessing the
+// storage; while the storage protection is disabled, it is the VM itself
+func (context *storageContext) accessor() arwen.StorageAccessor {
+	if !context.arwenStorageProtectionEnabled {
+		return arwen.StorageAccessByBuiltin
+	}
+
+	vmInput := context.host.Runtime().GetVMInput()
+	if vmInput != nil && vmInput.CallType == vmcommon.AsynchronousCallBack {
+		return arwen.StorageAccessByCallback
+	}
 
-func (context *storageContext) isElrondReservedKey(key []byte) bool {
-	return bytes.HasPrefix(key, context.elrondProtectedKeyPrefix)
+	return arwen.StorageAccessByContract
 }
 
 func (context *storageContext) SetProtectedStorage(key []byte, value []byte) (arwen.StorageStatus, error) {
@@ -200,19 +503,60 @@ func (context *storageContext) SetProtectedStorage(key []byte, value []byte) (ar
 	return context.SetStorage(key, value)
 }
 
+// SetTransientStorage writes the given value under the given key of the
+// transient storage of the current address, which lasts until the end of
+// the outermost execution and is shared by all its nested executions of the
+// same contract; it never reaches the VMOutput. An empty value deletes the key.
+func (context *storageContext) SetTransientStorage(key []byte, value []byte) error {
+	if context.host.Runtime().ReadOnly() {
+		logStorage.Trace("transient set", "error", "cannot set transient storage in readonly mode")
+		return arwen.ErrInvalidCallOnReadOnlyMode
+	}
+
+	err := context.limits.CheckEntry(key, value)
+	if err != nil {
+		logStorage.Trace("transient set", "error", err, "key", key, "length", len(value))
+		return err
+	}
+
+	newValue := make([]byte, len(value))
+	copy(newValue, value)
+	context.transient.put(context.address, key, newValue, 0)
+
+	logStorage.Trace("transient set", "key", key, "value", value)
+	return nil
+}
+
+// GetTransientStorage returns the value under the given key of the transient
+// storage of the current address, or nil if the key was not written
+func (context *storageContext) GetTransientStorage(key []byte) []byte {
+	value, _ := context.transient.get(context.address, key)
+
+	logStorage.Trace("transient get", "key", key, "value", value)
+	return value
+}
+
 // SetStorage sets the given value at the given key.
 func (context *storageContext) SetStorage(key []byte, value []byte) (arwen.StorageStatus, error) {
 	if context.host.Runtime().ReadOnly() {
 		logStorage.Trace("storage set", "error", "cannot set storage in readonly mode")
-		return arwen.StorageUnchanged, nil
+		if !context.host.IsReadOnlyEnforcementEnabled() {
+			return arwen.StorageUnchanged, nil
+		}
+		return arwen.StorageUnchanged, arwen.ErrInvalidCallOnReadOnlyMode
 	}
-	if context.isElrondReservedKey(key) {
-		logStorage.Trace("storage set", "error", arwen.ErrStoreElrondReservedKey, "key", key)
-		return arwen.StorageUnchanged, arwen.ErrStoreElrondReservedKey
+	accessor := context.accessor()
+	namespace := context.namespaces.Find(key)
+	if !namespace.CanWrite(accessor) {
+		logStorage.Trace("storage set", "error", namespace.WriteDeniedError, "namespace", namespace.Name, "accessor", accessor.String(), "key", key)
+		return arwen.StorageUnchanged, namespace.WriteDeniedError
 	}
-	if context.isArwenProtectedKey(key) && context.arwenStorageProtectionEnabled {
-		logStorage.Trace("storage set", "error", arwen.ErrCannotWriteProtectedKey, "key", key)
-		return arwen.StorageUnchanged, arwen.ErrCannotWriteProtectedKey
+	if accessor != arwen.StorageAccessByBuiltin {
+		err := context.limits.CheckEntry(key, value)
+		if err != nil {
+			logStorage.Trace("storage set", "error", err, "key", key, "length", len(value))
+			return arwen.StorageUnchanged, err
+		}
 	}
 
 	metering := context.host.Metering()
@@ -224,21 +568,9 @@ func (context *storageContext) SetStorage(key []byte, value []byte) (arwen.Stora
 	}
 
 	var zero []byte
-	strKey := string(key)
 	length := len(value)
 
-	var oldValue []byte
-	storageUpdates := context.GetStorageUpdates(context.address)
-	if update, ok := storageUpdates[strKey]; !ok {
-		oldValue = context.GetStorageUnmetered(key)
-		storageUpdates[strKey] = &vmcommon.StorageUpdate{
-			Offset: key,
-			Data:   oldValue,
-		}
-	} else {
-		oldValue = update.Data
-	}
-
+	oldValue := context.GetStorageUnmetered(key)
 	lengthOldValue := len(oldValue)
 	if bytes.Equal(oldValue, value) {
 		useGas := math.MulUint64(metering.GasSchedule().BaseOperationCost.DataCopyPerByte, uint64(length))
@@ -247,12 +579,19 @@ func (context *storageContext) SetStorage(key []byte, value []byte) (arwen.Stora
 		return arwen.StorageUnchanged, nil
 	}
 
-	newUpdate := &vmcommon.StorageUpdate{
-		Offset: key,
-		Data:   make([]byte, length),
+	sizeDelta := int64(arwen.StorageEntrySize(key, value)) - int64(arwen.StorageEntrySize(key, oldValue))
+	if accessor != arwen.StorageAccessByBuiltin {
+		err := context.checkStorageQuota(sizeDelta)
+		if err != nil {
+			logStorage.Trace("storage set", "error", err, "key", key, "sizeDelta", sizeDelta)
+			return arwen.StorageUnchanged, err
+		}
 	}
-	copy(newUpdate.Data[:length], value[:length])
-	storageUpdates[strKey] = newUpdate
+
+	newValue := make([]byte, length)
+	copy(newValue[:length], value[:length])
+	context.batch.put(context.address, key, newValue, sizeDelta)
+	context.loadCache.invalidate(context.address, key)
 
 	if bytes.Equal(oldValue, zero) {
 		useGas := math.MulUint64(metering.GasSchedule().BaseOperationCost.StorePerByte, uint64(length))
@@ -290,3 +629,82 @@ func (context *storageContext) SetStorage(key []byte, value []byte) (arwen.Stora
 	logStorage.Trace("storage modified", "key", key, "value", value, "lengthDelta", newValueExtraLength)
 	return arwen.StorageModified, nil
 }
+
+// checkStorageQuota returns ErrStorageQuotaExceeded if growing the storage of
+// the current address by sizeDelta bytes would exceed its quota
+func (context *storageContext) checkStorageQuota(sizeDelta int64) error {
+	if !context.limits.HasStorageQuota() || sizeDelta <= 0 {
+		return nil
+	}
+
+	size := context.getStorageSize(context.address)
+	if size+sizeDelta > int64(context.limits.MaxContractStorageSize) {
+		return arwen.ErrStorageQuotaExceeded
+	}
+
+	return nil
+}
+
+// getStorageSize returns the size of the storage of the given address,
+// including the storage writes not yet committed. The size is kept under
+// arwen.StorageSizeKey and updated by the size change of each committed
+// write; while it is not kept yet, it is computed from the storage held by
+// the node.
+func (context *storageContext) getStorageSize(address []byte) int64 {
+	size, ok := context.storageSizes[string(address)]
+	if !ok {
+		value, _ := context.blockChainHook.GetStorageData(address, []byte(arwen.StorageSizeKey))
+		size = big.NewInt(0).SetBytes(value).Int64()
+		if len(value) == 0 {
+			size = context.computeStorageSize(address)
+		}
+		context.storageSizes[string(address)] = size
+	}
+
+	return size + context.batch.sizeDelta(address)
+}
+
+// computeStorageSize adds up the sizes of the entries held by the node for
+// the given address, except those managed by the node itself, which are not
+// written through the storage context
+func (context *storageContext) computeStorageSize(address []byte) int64 {
+	storedState, err := context.blockChainHook.GetAllState(address)
+	if err != nil {
+		logStorage.Trace("compute storage size", "error", err)
+		return 0
+	}
+
+	size := uint64(0)
+	for key, value := range storedState {
+		if key == arwen.StorageSizeKey || context.namespaces.Find([]byte(key)).NodeManaged {
+			continue
+		}
+		size += arwen.StorageEntrySize([]byte(key), value)
+	}
+
+	return int64(size)
+}
+
+// commitStorageSizes writes the size of the storage of each address changed
+// by the storage writes about to be committed under arwen.StorageSizeKey
+func (context *storageContext) commitStorageSizes() {
+	output := context.host.Output()
+	for address, sizeDelta := range context.batch.sizeDeltas {
+		if sizeDelta == 0 {
+			continue
+		}
+
+		size := context.getStorageSize([]byte(address))
+		if size < 0 {
+			size = 0
+		}
+		context.storageSizes[address] = size
+
+		key := []byte(arwen.StorageSizeKey)
+		account, _ := output.GetOutputAccount([]byte(address))
+		account.StorageUpdates[string(key)] = &vmcommon.StorageUpdate{
+			Offset: key,
+			Data:   big.NewInt(size).Bytes(),
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package contexts
+
+// storageWrite is a value written by a contract under a key of the storage
+// of an account; previous is the position in the batch of the preceding
+// write of the same key of the same account, or -1 if there is none, and
+// sizeDelta is the change of the size of the storage of the account.
+type storageWrite struct {
+	address   []byte
+	key       []byte
+	data      []byte
+	previous  int
+	sizeDelta int64
+}
+
+// storageBatch holds the storage writes of an execution in the order in
+// which they were made, along with the position of the latest write of each
+// key, so that reverting to an earlier length of the batch only requires
+// walking back over the writes it discards.
+type storageBatch struct {
+	writes     []*storageWrite
+	latest     map[string]map[string]int
+	sizeDeltas map[string]int64
+}
+
+func newStorageBatch() *storageBatch {
+	return &storageBatch{
+		writes:     make([]*storageWrite, 0),
+		latest:     make(map[string]map[string]int),
+		sizeDeltas: make(map[string]int64),
+	}
+}
+
+// length returns the number of writes held by the batch
+func (batch *storageBatch) length() int {
+	return len(batch.writes)
+}
+
+// put appends a write of the given data under the given key of the storage
+// of the given account, which changes the size of that storage by sizeDelta
+func (batch *storageBatch) put(address []byte, key []byte, data []byte, sizeDelta int64) {
+	keys, ok := batch.latest[string(address)]
+	if !ok {
+		keys = make(map[string]int)
+		batch.latest[string(address)] = keys
+	}
+
+	previous, ok := keys[string(key)]
+	if !ok {
+		previous = -1
+	}
+
+	keys[string(key)] = len(batch.writes)
+	batch.sizeDeltas[string(address)] += sizeDelta
+	batch.writes = append(batch.writes, &storageWrite{
+		address:   address,
+		key:       key,
+		data:      data,
+		previous:  previous,
+		sizeDelta: sizeDelta,
+	})
+}
+
+// get returns the data of the latest write of the given key of the storage
+// of the given account, and false if the batch holds no such write
+func (batch *storageBatch) get(address []byte, key []byte) ([]byte, bool) {
+	index, ok := batch.latest[string(address)][string(key)]
+	if !ok {
+		return nil, false
+	}
+
+	return batch.writes[index].data, true
+}
+
+// latestWrites returns the data of the latest write of each key of the
+// storage of the given account
+func (batch *storageBatch) latestWrites(address []byte) map[string][]byte {
+	keys := batch.latest[string(address)]
+	writes := make(map[string][]byte, len(keys))
+	for key, index := range keys {
+		writes[key] = batch.writes[index].data
+	}
+
+	return writes
+}
+
+// sizeDelta returns the change of the size of the storage of the given
+// account made by the writes held by the batch
+func (batch *storageBatch) sizeDelta(address []byte) int64 {
+	return batch.sizeDeltas[string(address)]
+}
+
+// truncate discards the writes made after the batch had the given length
+func (batch *storageBatch) truncate(length int) {
+	if length < 0 {
+		length = 0
+	}
+
+	for index := len(batch.writes) - 1; index >= length; index-- {
+		write := batch.writes[index]
+		keys := batch.latest[string(write.address)]
+		if write.previous < 0 {
+			delete(keys, string(write.key))
+		} else {
+			keys[string(write.key)] = write.previous
+		}
+		if len(keys) == 0 {
+			delete(batch.latest, string(write.address))
+		}
+		batch.sizeDeltas[string(write.address)] -= write.sizeDelta
+	}
+
+	if length < len(batch.writes) {
+		batch.writes = batch.writes[:length]
+	}
+}
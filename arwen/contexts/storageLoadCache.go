@@ -0,0 +1,96 @@
+package contexts
+
+// storageLoadChange records whether a key of the storage of an account was
+// loaded before the change made to the storageLoadCache
+type storageLoadChange struct {
+	address   []byte
+	key       []byte
+	wasLoaded bool
+}
+
+// storageLoadCache holds the keys whose load was charged in full during an
+// execution, whose later loads are charged the cached cost, until the key is
+// written. The changes made to the cache are journaled, so that reverting to
+// an earlier length of the journal restores the cache as it was.
+type storageLoadCache struct {
+	loaded  map[string]map[string]struct{}
+	journal []*storageLoadChange
+}
+
+func newStorageLoadCache() *storageLoadCache {
+	return &storageLoadCache{
+		loaded:  make(map[string]map[string]struct{}),
+		journal: make([]*storageLoadChange, 0),
+	}
+}
+
+// length returns the number of changes held by the journal of the cache
+func (cache *storageLoadCache) length() int {
+	return len(cache.journal)
+}
+
+// isLoaded returns true if the given key of the storage of the given account is cached
+func (cache *storageLoadCache) isLoaded(address []byte, key []byte) bool {
+	_, ok := cache.loaded[string(address)][string(key)]
+	return ok
+}
+
+// markLoaded caches the given key of the storage of the given account
+func (cache *storageLoadCache) markLoaded(address []byte, key []byte) {
+	if cache.isLoaded(address, key) {
+		return
+	}
+
+	keys, ok := cache.loaded[string(address)]
+	if !ok {
+		keys = make(map[string]struct{})
+		cache.loaded[string(address)] = keys
+	}
+
+	keys[string(key)] = struct{}{}
+	cache.journal = append(cache.journal, &storageLoadChange{address: address, key: key, wasLoaded: false})
+}
+
+// invalidate removes the given key of the storage of the given account from the cache
+func (cache *storageLoadCache) invalidate(address []byte, key []byte) {
+	if !cache.isLoaded(address, key) {
+		return
+	}
+
+	cache.remove(address, key)
+	cache.journal = append(cache.journal, &storageLoadChange{address: address, key: key, wasLoaded: true})
+}
+
+func (cache *storageLoadCache) remove(address []byte, key []byte) {
+	keys := cache.loaded[string(address)]
+	delete(keys, string(key))
+	if len(keys) == 0 {
+		delete(cache.loaded, string(address))
+	}
+}
+
+// truncate undoes the changes made after the journal had the given length
+func (cache *storageLoadCache) truncate(length int) {
+	if length < 0 {
+		length = 0
+	}
+
+	for index := len(cache.journal) - 1; index >= length; index-- {
+		change := cache.journal[index]
+		if !change.wasLoaded {
+			cache.remove(change.address, change.key)
+			continue
+		}
+
+		keys, ok := cache.loaded[string(change.address)]
+		if !ok {
+			keys = make(map[string]struct{})
+			cache.loaded[string(change.address)] = keys
+		}
+		keys[string(change.key)] = struct{}{}
+	}
+
+	if length < len(cache.journal) {
+		cache.journal = cache.journal[:length]
+	}
+}
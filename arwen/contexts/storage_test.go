@@ -177,10 +177,19 @@ func TestStorageContext_SetStorage(t *testing.T) {
 	mockRuntime.SetReadOnly(true)
 	value = []byte("newValue")
 	storageStatus, err = storageContext.SetStorage(key, value)
+	require.Equal(t, arwen.ErrInvalidCallOnReadOnlyMode, err)
+	require.Equal(t, arwen.StorageUnchanged, storageStatus)
+	require.Equal(t, []byte{}, storageContext.GetStorage(key))
+	require.Len(t, storageContext.GetStorageUpdates(address), 1)
+
+	// before the read-only enforcement, the value is ignored without an error
+	host.ReadOnlyEnforcementDisabled = true
+	storageStatus, err = storageContext.SetStorage(key, value)
 	require.Nil(t, err)
 	require.Equal(t, arwen.StorageUnchanged, storageStatus)
 	require.Equal(t, []byte{}, storageContext.GetStorage(key))
 	require.Len(t, storageContext.GetStorageUpdates(address), 1)
+	host.ReadOnlyEnforcementDisabled = false
 
 	mockRuntime.SetReadOnly(false)
 	key = []byte("other_key")
@@ -243,6 +252,12 @@ func TestStorageContext_StorageProtection(t *testing.T) {
 	require.Equal(t, arwen.StorageUnchanged, storageStatus)
 	require.True(t, errors.Is(err, arwen.ErrCannotWriteProtectedKey))
 	require.Len(t, storageContext.GetStorageUpdates(address), 1)
+
+	mockRuntime.SetVMInput(&vmcommon.VMInput{CallType: vmcommon.AsynchronousCallBack})
+	storageStatus, err = storageContext.SetStorage([]byte(arwen.AsyncDataPrefix+"something"), value)
+	require.Equal(t, arwen.StorageUnchanged, storageStatus)
+	require.True(t, errors.Is(err, arwen.ErrCannotWriteProtectedKey))
+	require.Equal(t, value, storageContext.GetStorage(key))
 }
 
 func TestStorageContext_GetStorageFromAddress(t *testing.T) {
@@ -298,6 +313,71 @@ func TestStorageContext_GetStorageFromAddress(t *testing.T) {
 	require.Nil(t, data)
 }
 
+func TestStorageContext_GetKeysWithPrefix(t *testing.T) {
+	t.Parallel()
+
+	scAddress := []byte("account")
+	mockOutput := &contextmock.OutputContextMock{}
+	account := mockOutput.NewVMOutputAccount(scAddress)
+	mockOutput.OutputAccountMock = account
+	mockOutput.OutputAccountIsNew = false
+
+	mockMetering := &contextmock.MeteringContextMock{}
+	mockMetering.SetGasSchedule(config.MakeGasMapForTests())
+
+	host := &contextmock.VMHostMock{
+		OutputContext:   mockOutput,
+		MeteringContext: mockMetering,
+		RuntimeContext:  &contextmock.RuntimeContextMock{},
+	}
+
+	bcHook := &contextmock.BlockchainHookStub{
+		GetAllStateCalled: func(address []byte) (map[string][]byte, error) {
+			require.Equal(t, scAddress, address)
+			return map[string][]byte{
+				"item.c":            []byte("c"),
+				"item.a":            []byte("a"),
+				"item.deleted":      []byte("deleted"),
+				"other":             []byte("other"),
+				"RESERVEDitem.esdt": []byte("esdt"),
+			}, nil
+		},
+	}
+
+	storageContext, _ := NewStorageContext(host, bcHook, elrondReservedTestPrefix)
+	storageContext.SetAddress(scAddress)
+
+	// the keys written and deleted during the execution are taken into account
+	account.StorageUpdates["item.b"] = &vmcommon.StorageUpdate{Offset: []byte("item.b"), Data: []byte("b")}
+	account.StorageUpdates["item.deleted"] = &vmcommon.StorageUpdate{Offset: []byte("item.deleted"), Data: []byte{}}
+
+	keys, err := storageContext.GetKeysWithPrefix([]byte("item."), nil, 10)
+	require.Nil(t, err)
+	require.Equal(t, [][]byte{[]byte("item.a"), []byte("item.b"), []byte("item.c")}, keys)
+
+	keys, err = storageContext.GetKeysWithPrefix([]byte("item."), nil, 2)
+	require.Nil(t, err)
+	require.Equal(t, [][]byte{[]byte("item.a"), []byte("item.b")}, keys)
+
+	keys, err = storageContext.GetKeysWithPrefix([]byte("item."), []byte("item.b"), 2)
+	require.Nil(t, err)
+	require.Equal(t, [][]byte{[]byte("item.c")}, keys)
+
+	// the scan of all the keys is paid for before it starts
+	mockMetering.Err = arwen.ErrNotEnoughGas
+	keys, err = storageContext.GetKeysWithPrefix([]byte("item."), nil, 10)
+	require.Equal(t, arwen.ErrNotEnoughGas, err)
+	require.Nil(t, keys)
+	mockMetering.Err = nil
+
+	bcHook.GetAllStateCalled = func(address []byte) (map[string][]byte, error) {
+		return nil, errors.New("account not found")
+	}
+	keys, err = storageContext.GetKeysWithPrefix([]byte("item."), nil, 10)
+	require.NotNil(t, err)
+	require.Nil(t, keys)
+}
+
 func TestStorageContext_LoadGasStoreGasPerKey(t *testing.T) {
 	// TODO
 }
@@ -306,6 +386,364 @@ func TestStorageContext_StoreGasPerKey(t *testing.T) {
 	// TODO
 }
 
+func TestStorageContext_Snapshots(t *testing.T) {
+	t.Parallel()
+
+	host := &contextmock.VMHostMock{}
+	mockBlockchain := worldmock.NewMockWorld()
+	storageContext, _ := NewStorageContext(host, mockBlockchain, elrondReservedTestPrefix)
+
+	addressA := []byte("accountA")
+	addressB := []byte("accountB")
+
+	storageContext.SetAddress(addressA)
+	storageContext.TakeSnapshot("outer")
+	storageContext.SetAddress(addressB)
+	storageContext.TakeSnapshot("inner")
+
+	storageContext.DiscardSnapshot("inner")
+	require.Equal(t, 1, len(storageContext.snapshots))
+	require.Equal(t, addressB, storageContext.address)
+
+	storageContext.TakeSnapshot("inner")
+	err := storageContext.RevertToSnapshot("outer")
+	require.Nil(t, err)
+	require.Equal(t, 0, len(storageContext.snapshots))
+	require.Equal(t, addressA, storageContext.address)
+
+	err = storageContext.RevertToSnapshot("inner")
+	require.Equal(t, arwen.ErrStorageSnapshotNotFound, err)
+}
+
+func TestStorageContext_Transactions(t *testing.T) {
+	t.Parallel()
+
+	address := []byte("account")
+	mockOutput := &contextmock.OutputContextMock{}
+	account := mockOutput.NewVMOutputAccount(address)
+	mockOutput.OutputAccountMock = account
+	mockOutput.OutputAccountIsNew = false
+
+	mockMetering := &contextmock.MeteringContextMock{}
+	mockMetering.SetGasSchedule(config.MakeGasMapForTests())
+	mockMetering.BlockGasLimitMock = uint64(15000)
+
+	host := &contextmock.VMHostMock{
+		OutputContext:   mockOutput,
+		MeteringContext: mockMetering,
+		RuntimeContext:  &contextmock.RuntimeContextMock{},
+	}
+	bcHook := &contextmock.BlockchainHookStub{}
+
+	storageContext, _ := NewStorageContext(host, bcHook, elrondReservedTestPrefix)
+	storageContext.SetAddress(address)
+
+	keyA := []byte("keyA")
+	keyB := []byte("keyB")
+	_, _ = storageContext.SetStorage(keyA, []byte("valueA"))
+
+	storageContext.BeginTransaction()
+	_, _ = storageContext.SetStorage(keyA, []byte("nestedValueA"))
+	_, _ = storageContext.SetStorage(keyB, []byte("nestedValueB"))
+	require.Equal(t, []byte("nestedValueA"), storageContext.GetStorage(keyA))
+	require.Equal(t, []byte("nestedValueB"), storageContext.GetStorage(keyB))
+	require.Len(t, storageContext.GetStorageUpdates(address), 2)
+
+	storageContext.Revert()
+	require.Equal(t, []byte("valueA"), storageContext.GetStorage(keyA))
+	require.Equal(t, []byte(nil), storageContext.GetStorage(keyB))
+
+	storageContext.BeginTransaction()
+	_, _ = storageContext.SetStorage(keyB, []byte("valueB"))
+	storageContext.TakeSnapshot("snapshot")
+	_, _ = storageContext.SetStorage(keyB, []byte("discardedValueB"))
+	err := storageContext.RevertToSnapshot("snapshot")
+	require.Nil(t, err)
+	storageContext.Commit()
+	require.Equal(t, []byte("valueB"), storageContext.GetStorage(keyB))
+
+	// the writes only reach the output account when committed at the top level,
+	// which holds until then the values cached from the node
+	require.Equal(t, []byte(nil), account.StorageUpdates[string(keyA)].Data)
+	require.Equal(t, []byte(nil), account.StorageUpdates[string(keyB)].Data)
+
+	storageContext.Commit()
+	require.Equal(t, []byte("valueA"), account.StorageUpdates[string(keyA)].Data)
+	require.Equal(t, []byte("valueB"), account.StorageUpdates[string(keyB)].Data)
+	require.Equal(t, 0, storageContext.batch.length())
+}
+
+func TestStorageContext_StorageLoadCache(t *testing.T) {
+	t.Parallel()
+
+	address := []byte("account")
+	mockOutput := &contextmock.OutputContextMock{}
+	account := mockOutput.NewVMOutputAccount(address)
+	mockOutput.OutputAccountMock = account
+	mockOutput.OutputAccountIsNew = false
+
+	mockMetering := &contextmock.MeteringContextMock{}
+	mockMetering.SetGasSchedule(config.MakeGasMapForTests())
+	mockMetering.BlockGasLimitMock = uint64(15000)
+
+	host := &contextmock.VMHostMock{
+		OutputContext:   mockOutput,
+		MeteringContext: mockMetering,
+		RuntimeContext:  &contextmock.RuntimeContextMock{},
+	}
+	bcHook := &contextmock.BlockchainHookStub{}
+
+	storageContext, _ := NewStorageContext(host, bcHook, elrondReservedTestPrefix)
+	storageContext.SetAddress(address)
+
+	keyA := []byte("keyA")
+	keyB := []byte("keyB")
+	otherAddress := []byte("otherAccount")
+	require.False(t, storageContext.loadCache.isLoaded(address, keyA))
+
+	storageContext.UseGasForStorageLoad(keyA, 100)
+	require.True(t, storageContext.loadCache.isLoaded(address, keyA))
+	require.False(t, storageContext.loadCache.isLoaded(otherAddress, keyA))
+
+	storageContext.UseGasForStorageLoadFromAddress(otherAddress, keyA, 100)
+	require.True(t, storageContext.loadCache.isLoaded(otherAddress, keyA))
+
+	// a write makes the next load of the key charged in full
+	_, _ = storageContext.SetStorage(keyA, []byte("valueA"))
+	require.False(t, storageContext.loadCache.isLoaded(address, keyA))
+	require.True(t, storageContext.loadCache.isLoaded(otherAddress, keyA))
+
+	storageContext.UseGasForStorageLoad(keyA, 100)
+	require.True(t, storageContext.loadCache.isLoaded(address, keyA))
+
+	// the loads and the invalidations of a reverted transaction are undone
+	storageContext.BeginTransaction()
+	storageContext.UseGasForStorageLoad(keyB, 100)
+	_, _ = storageContext.SetStorage(keyA, []byte("nestedValueA"))
+	require.True(t, storageContext.loadCache.isLoaded(address, keyB))
+	require.False(t, storageContext.loadCache.isLoaded(address, keyA))
+
+	storageContext.Revert()
+	require.False(t, storageContext.loadCache.isLoaded(address, keyB))
+	require.True(t, storageContext.loadCache.isLoaded(address, keyA))
+
+	// as are those made after a snapshot which is reverted to
+	storageContext.TakeSnapshot("snapshot")
+	storageContext.UseGasForStorageLoad(keyB, 100)
+	_, _ = storageContext.SetStorage(keyA, []byte("discardedValueA"))
+	err := storageContext.RevertToSnapshot("snapshot")
+	require.Nil(t, err)
+	require.False(t, storageContext.loadCache.isLoaded(address, keyB))
+	require.True(t, storageContext.loadCache.isLoaded(address, keyA))
+
+	storageContext.InitState()
+	require.False(t, storageContext.loadCache.isLoaded(address, keyA))
+	require.False(t, storageContext.loadCache.isLoaded(otherAddress, keyA))
+}
+
+func TestStorageContext_TransientStorage(t *testing.T) {
+	t.Parallel()
+
+	address := []byte("account")
+	otherAddress := []byte("otherAccount")
+	mockOutput := &contextmock.OutputContextMock{}
+	account := mockOutput.NewVMOutputAccount(address)
+	mockOutput.OutputAccountMock = account
+	mockOutput.OutputAccountIsNew = false
+
+	mockMetering := &contextmock.MeteringContextMock{}
+	mockMetering.SetGasSchedule(config.MakeGasMapForTests())
+	mockMetering.BlockGasLimitMock = uint64(15000)
+
+	mockRuntime := &contextmock.RuntimeContextMock{}
+	host := &contextmock.VMHostMock{
+		OutputContext:   mockOutput,
+		MeteringContext: mockMetering,
+		RuntimeContext:  mockRuntime,
+	}
+	bcHook := &contextmock.BlockchainHookStub{}
+
+	storageContext, _ := NewStorageContext(host, bcHook, elrondReservedTestPrefix)
+	storageContext.SetAddress(address)
+
+	key := []byte("lock")
+	require.Nil(t, storageContext.GetTransientStorage(key))
+
+	err := storageContext.SetTransientStorage(key, []byte("locked"))
+	require.Nil(t, err)
+	require.Equal(t, []byte("locked"), storageContext.GetTransientStorage(key))
+
+	// the transient storage of each contract is separate
+	storageContext.SetAddress(otherAddress)
+	require.Nil(t, storageContext.GetTransientStorage(key))
+	storageContext.SetAddress(address)
+
+	// the writes of a reverted transaction are discarded
+	storageContext.BeginTransaction()
+	err = storageContext.SetTransientStorage(key, []byte("nested"))
+	require.Nil(t, err)
+	require.Equal(t, []byte("nested"), storageContext.GetTransientStorage(key))
+	storageContext.Revert()
+	require.Equal(t, []byte("locked"), storageContext.GetTransientStorage(key))
+
+	storageContext.BeginTransaction()
+	err = storageContext.SetTransientStorage(key, nil)
+	require.Nil(t, err)
+	storageContext.Commit()
+	require.Equal(t, []byte{}, storageContext.GetTransientStorage(key))
+
+	mockRuntime.SetReadOnly(true)
+	err = storageContext.SetTransientStorage(key, []byte("locked"))
+	require.Equal(t, arwen.ErrInvalidCallOnReadOnlyMode, err)
+	mockRuntime.SetReadOnly(false)
+
+	// the transient storage never reaches the output accounts
+	storageContext.Commit()
+	require.Len(t, account.StorageUpdates, 0)
+
+	err = storageContext.SetTransientStorage(key, []byte("locked"))
+	require.Nil(t, err)
+	storageContext.InitState()
+	require.Nil(t, storageContext.GetTransientStorage(key))
+}
+
+func TestStorageContext_StorageLimits(t *testing.T) {
+	t.Parallel()
+
+	address := []byte("account")
+	mockOutput := &contextmock.OutputContextMock{}
+	account := mockOutput.NewVMOutputAccount(address)
+	mockOutput.OutputAccountMock = account
+	mockOutput.OutputAccountIsNew = false
+
+	mockMetering := &contextmock.MeteringContextMock{}
+	mockMetering.SetGasSchedule(config.MakeGasMapForTests())
+	mockMetering.BlockGasLimitMock = uint64(15000)
+
+	host := &contextmock.VMHostMock{
+		OutputContext:   mockOutput,
+		MeteringContext: mockMetering,
+		RuntimeContext:  &contextmock.RuntimeContextMock{},
+	}
+
+	storedState := map[string][]byte{
+		"existing":           []byte("0123456789"),
+		arwen.StorageSizeKey: big.NewInt(18).Bytes(),
+	}
+	bcHook := &contextmock.BlockchainHookStub{
+		GetStorageDataCalled: func(_ []byte, key []byte) ([]byte, error) {
+			return storedState[string(key)], nil
+		},
+	}
+
+	storageContext, _ := NewStorageContext(host, bcHook, elrondReservedTestPrefix)
+	storageContext.SetStorageLimits(arwen.StorageLimits{
+		MaxKeyLength:           8,
+		MaxValueLength:         16,
+		MaxContractStorageSize: 40,
+	})
+	storageContext.SetAddress(address)
+
+	storageStatus, err := storageContext.SetStorage([]byte("longerKey"), []byte("value"))
+	require.Equal(t, arwen.ErrStorageKeyTooLong, err)
+	require.Equal(t, arwen.StorageUnchanged, storageStatus)
+
+	_, err = storageContext.SetStorage([]byte("key"), []byte("0123456789abcdefg"))
+	require.Equal(t, arwen.ErrStorageValueTooLong, err)
+
+	// the storage holds 18 bytes, to which keyA adds 16 and keyB would add 9
+	_, err = storageContext.SetStorage([]byte("keyA"), []byte("0123456789ab"))
+	require.Nil(t, err)
+	_, err = storageContext.SetStorage([]byte("keyB"), []byte("01234"))
+	require.Equal(t, arwen.ErrStorageQuotaExceeded, err)
+	require.Equal(t, []byte(nil), storageContext.GetStorage([]byte("keyB")))
+
+	storageStatus, err = storageContext.SetStorage([]byte("existing"), nil)
+	require.Nil(t, err)
+	require.Equal(t, arwen.StorageDeleted, storageStatus)
+	_, err = storageContext.SetStorage([]byte("keyB"), []byte("01234"))
+	require.Nil(t, err)
+
+	// the space taken by reverted writes is released
+	storageContext.BeginTransaction()
+	_, err = storageContext.SetStorage([]byte("keyC"), []byte("0123456789"))
+	require.Nil(t, err)
+	storageContext.Revert()
+	_, err = storageContext.SetStorage([]byte("keyD"), []byte("0123456789a"))
+	require.Nil(t, err)
+	_, err = storageContext.SetStorage([]byte("keyE"), []byte("0"))
+	require.Equal(t, arwen.ErrStorageQuotaExceeded, err)
+
+	// shrinking a value is always allowed, and the VM itself is not bounded
+	_, err = storageContext.SetStorage([]byte("keyD"), []byte("0"))
+	require.Nil(t, err)
+	_, err = storageContext.SetProtectedStorage([]byte(arwen.ProtectedStoragePrefix+"async"), []byte("0123456789abcdefghijklmnopqrstuvwxyz"))
+	require.Nil(t, err)
+
+	// the committed writes update the size kept in the storage
+	storageContext.Commit()
+	require.Equal(t, big.NewInt(77).Bytes(), account.StorageUpdates[arwen.StorageSizeKey].Data)
+	_, err = storageContext.SetStorage([]byte("keyB"), nil)
+	require.Nil(t, err)
+	storageContext.Commit()
+	require.Equal(t, big.NewInt(68).Bytes(), account.StorageUpdates[arwen.StorageSizeKey].Data)
+
+	// without a quota, the size is not kept
+	delete(account.StorageUpdates, arwen.StorageSizeKey)
+	storageContext.SetStorageLimits(arwen.StorageLimits{})
+	_, err = storageContext.SetStorage([]byte("keyF"), []byte("0123456789"))
+	require.Nil(t, err)
+	storageContext.Commit()
+	require.NotContains(t, account.StorageUpdates, arwen.StorageSizeKey)
+}
+
+func TestStorageContext_StorageSizeComputedWhenNotKept(t *testing.T) {
+	t.Parallel()
+
+	address := []byte("account")
+	mockOutput := &contextmock.OutputContextMock{}
+	account := mockOutput.NewVMOutputAccount(address)
+	mockOutput.OutputAccountMock = account
+	mockOutput.OutputAccountIsNew = false
+
+	mockMetering := &contextmock.MeteringContextMock{}
+	mockMetering.SetGasSchedule(config.MakeGasMapForTests())
+	mockMetering.BlockGasLimitMock = uint64(15000)
+
+	host := &contextmock.VMHostMock{
+		OutputContext:   mockOutput,
+		MeteringContext: mockMetering,
+		RuntimeContext:  &contextmock.RuntimeContextMock{},
+	}
+
+	// written before the quota was set; the key managed by the node is not accounted
+	storedState := map[string][]byte{
+		"existing":                               []byte("0123456789"),
+		string(elrondReservedTestPrefix) + "key": []byte("0123456789abcdefghij"),
+	}
+	bcHook := &contextmock.BlockchainHookStub{
+		GetStorageDataCalled: func(_ []byte, key []byte) ([]byte, error) {
+			return storedState[string(key)], nil
+		},
+		GetAllStateCalled: func(_ []byte) (map[string][]byte, error) {
+			return storedState, nil
+		},
+	}
+
+	storageContext, _ := NewStorageContext(host, bcHook, elrondReservedTestPrefix)
+	storageContext.SetStorageLimits(arwen.StorageLimits{MaxContractStorageSize: 40})
+	storageContext.SetAddress(address)
+
+	// the storage holds 18 bytes, to which keyA adds 16 and keyB would add 9
+	_, err := storageContext.SetStorage([]byte("keyA"), []byte("0123456789ab"))
+	require.Nil(t, err)
+	_, err = storageContext.SetStorage([]byte("keyB"), []byte("01234"))
+	require.Equal(t, arwen.ErrStorageQuotaExceeded, err)
+
+	storageContext.Commit()
+	require.Equal(t, big.NewInt(34).Bytes(), account.StorageUpdates[arwen.StorageSizeKey].Data)
+}
+
 func TestStorageContext_PopSetActiveStateIfStackIsEmptyShouldNotPanic(t *testing.T) {
 	t.Parallel()
 
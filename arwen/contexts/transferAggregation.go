@@ -0,0 +1,97 @@
+package contexts
+
+import (
+	"math/big"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
+	"github.com/ElrondNetwork/elrond-go/core"
+	"github.com/ElrondNetwork/elrond-go/core/vmcommon"
+)
+
+// aggregationKey identifies the OutputTransfers to the same destination which
+// may be merged into a single one: plain transfers of the same token, from the
+// same sender, with the same call type; the empty token stands for EGLD
+type aggregationKey struct {
+	sender   string
+	callType vmcommon.CallType
+	token    string
+}
+
+// getAggregationKey returns the aggregationKey and the transferred value of
+// the given OutputTransfer, if it may be aggregated; only the transfers which
+// carry no gas and either no data, or only a fungible ESDT transfer, qualify
+func getAggregationKey(transfer vmcommon.OutputTransfer) (aggregationKey, *big.Int, bool) {
+	if transfer.GasLimit > 0 || transfer.GasLocked > 0 {
+		return aggregationKey{}, nil, false
+	}
+
+	key := aggregationKey{
+		sender:   string(transfer.SenderAddress),
+		callType: transfer.CallType,
+	}
+	if len(transfer.Data) == 0 {
+		if transfer.Value == nil {
+			return aggregationKey{}, nil, false
+		}
+		return key, transfer.Value, true
+	}
+
+	hasValue := transfer.Value != nil && transfer.Value.Sign() != 0
+	if hasValue || transfer.CallType != vmcommon.DirectCall {
+		return aggregationKey{}, nil, false
+	}
+
+	function, args, err := arwen.DecodeCallData(transfer.Data)
+	if err != nil || function != core.BuiltInFunctionESDTTransfer || len(args) != 2 || len(args[0]) == 0 {
+		return aggregationKey{}, nil, false
+	}
+
+	key.token = string(args[0])
+	return key, big.NewInt(0).SetBytes(args[1]), true
+}
+
+// aggregateTransfers merges each run of adjacent OutputTransfers which may be
+// aggregated and share the same aggregationKey into the first one of the run,
+// so that the transfers keep their order relative to the ones in between. It
+// returns the resulting OutputTransfers, along with the new position of each
+// of the given ones.
+func aggregateTransfers(transfers []vmcommon.OutputTransfer) ([]vmcommon.OutputTransfer, []int) {
+	aggregated := make([]vmcommon.OutputTransfer, 0, len(transfers))
+	positions := make([]int, len(transfers))
+	values := make(map[int]*big.Int)
+
+	runStart := -1
+	var runKey aggregationKey
+	for i, transfer := range transfers {
+		key, value, ok := getAggregationKey(transfer)
+		if !ok {
+			runStart = -1
+			positions[i] = len(aggregated)
+			aggregated = append(aggregated, transfer)
+			continue
+		}
+
+		if runStart < 0 || key != runKey {
+			runStart = len(aggregated)
+			runKey = key
+			values[runStart] = big.NewInt(0)
+			aggregated = append(aggregated, transfer)
+		}
+
+		positions[i] = runStart
+		values[runStart].Add(values[runStart], value)
+	}
+
+	for position, value := range values {
+		key, _, _ := getAggregationKey(aggregated[position])
+		transfer := aggregated[position]
+		if len(key.token) == 0 {
+			transfer.Value = value
+		} else {
+			transfer.Data = arwen.EncodeCallData(core.BuiltInFunctionESDTTransfer, [][]byte{[]byte(key.token), value.Bytes()})
+		}
+		aggregated[position] = transfer
+	}
+
+	return aggregated, positions
+}
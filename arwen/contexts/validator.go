@@ -102,6 +102,46 @@ func (validator *wasmValidator) verifyValidFunctionName(functionName string) err
 	return nil
 }
 
+// verifyValidCallbackName checks that a callback of an async call registered
+// by the given instance can be executed: it must be a valid identifier, not
+// reserved, and exported by the module of the instance
+func (validator *wasmValidator) verifyValidCallbackName(instance wasmer.InstanceHandler, callbackName string) error {
+	errInvalidName := fmt.Errorf("%w: %s", arwen.ErrInvalidCallbackName, callbackName)
+
+	if !isIdentifier(callbackName) {
+		return errInvalidName
+	}
+	if validator.reserved.IsReserved(callbackName) {
+		return errInvalidName
+	}
+	if _, ok := instance.GetExports()[callbackName]; !ok {
+		return errInvalidName
+	}
+
+	return nil
+}
+
+// isIdentifier returns true if the input starts with a letter or an
+// underscore, followed by letters, digits or underscores only
+func isIdentifier(input string) bool {
+	const maxLengthOfIdentifier = 256
+
+	if len(input) == 0 || len(input) >= maxLengthOfIdentifier {
+		return false
+	}
+
+	for i := 0; i < len(input); i++ {
+		c := input[i]
+		isLetter := (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || c == '_'
+		isDigit := c >= '0' && c <= '9'
+		if !isLetter && !(isDigit && i > 0) {
+			return false
+		}
+	}
+
+	return true
+}
+
 // TODO: Add more constraints (too loose currently)
 func isASCIIString(input string) bool {
 	for i := 0; i < len(input); i++ {
@@ -1,10 +1,12 @@
 package contexts
 
 import (
+	"errors"
 	"strings"
 	"testing"
 
 	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
+	contextmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/context"
 	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/wasmer"
 	"github.com/ElrondNetwork/elrond-go/core/vmcommon"
 	"github.com/stretchr/testify/require"
@@ -95,3 +97,33 @@ func TestFunctionsGuard_Arity(t *testing.T) {
 	err = validator.verifyVoidFunction(instance, "wrongParamsAndReturn")
 	require.NotNil(t, err)
 }
+
+func TestFunctionsGuard_isValidCallbackName(t *testing.T) {
+	imports := MakeAPIImports()
+
+	protocolBuiltinFunctions := vmcommon.FunctionNames{
+		"fromProtocolFoo": {},
+	}
+
+	validator := newWASMValidator(imports.Names(), protocolBuiltinFunctions)
+	instance := &contextmock.InstanceMock{
+		Exports: wasmer.ExportsMap{
+			"callBack":        nil,
+			"_callback_2":     nil,
+			"fromProtocolFoo": nil,
+			"getArgument":     nil,
+			"2callback":       nil,
+			"call-back":       nil,
+		},
+	}
+
+	require.Nil(t, validator.verifyValidCallbackName(instance, "callBack"))
+	require.Nil(t, validator.verifyValidCallbackName(instance, "_callback_2"))
+
+	require.True(t, errors.Is(validator.verifyValidCallbackName(instance, "notExported"), arwen.ErrInvalidCallbackName))
+	require.NotNil(t, validator.verifyValidCallbackName(instance, ""))
+	require.NotNil(t, validator.verifyValidCallbackName(instance, "fromProtocolFoo"))
+	require.NotNil(t, validator.verifyValidCallbackName(instance, "getArgument"))
+	require.NotNil(t, validator.verifyValidCallbackName(instance, "2callback"))
+	require.NotNil(t, validator.verifyValidCallbackName(instance, "call-back"))
+}
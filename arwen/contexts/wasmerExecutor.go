@@ -0,0 +1,57 @@
+package contexts
+
+import (
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/wasmer"
+)
+
+// WasmerExecutorName is the name of the default executor, backed by Wasmer 1.x
+const WasmerExecutorName = "wasmer1"
+
+var _ arwen.Executor = (*wasmerExecutor)(nil)
+
+type wasmerExecutor struct {
+}
+
+// NewWasmerExecutor creates the default executor, backed by Wasmer 1.x
+func NewWasmerExecutor() (arwen.Executor, error) {
+	return &wasmerExecutor{}, nil
+}
+
+// Name returns the name of the executor
+func (executor *wasmerExecutor) Name() string {
+	return WasmerExecutorName
+}
+
+// Version returns the version of the Wasmer library backing the executor
+func (executor *wasmerExecutor) Version() string {
+	return wasmer.LibraryVersion
+}
+
+// NewInstanceWithOptions creates a new Wasmer instance from WASM bytecode,
+// respecting the provided options
+func (executor *wasmerExecutor) NewInstanceWithOptions(
+	contractCode []byte,
+	options arwen.CompilationOptions,
+) (arwen.Instance, error) {
+	return wasmer.NewInstanceWithOptions(contractCode, toWasmerCompilationOptions(options))
+}
+
+// NewInstanceFromCompiledCodeWithOptions creates a new Wasmer instance from
+// precompiled machine code, respecting the provided options
+func (executor *wasmerExecutor) NewInstanceFromCompiledCodeWithOptions(
+	compiledCode []byte,
+	options arwen.CompilationOptions,
+) (arwen.Instance, error) {
+	return wasmer.NewInstanceFromCompiledCodeWithOptions(compiledCode, toWasmerCompilationOptions(options))
+}
+
+func toWasmerCompilationOptions(options arwen.CompilationOptions) wasmer.CompilationOptions {
+	return wasmer.CompilationOptions{
+		GasLimit:           options.GasLimit,
+		UnmeteredLocals:    options.UnmeteredLocals,
+		OpcodeTrace:        options.OpcodeTrace,
+		Metering:           options.Metering,
+		RuntimeBreakpoints: options.RuntimeBreakpoints,
+	}
+}
@@ -9,15 +9,23 @@ package cryptoapi
 // extern int32_t v1_3_sha256(void* context, int32_t dataOffset, int32_t length, int32_t resultOffset);
 // extern int32_t v1_3_keccak256(void *context, int32_t dataOffset, int32_t length, int32_t resultOffset);
 // extern int32_t v1_3_ripemd160(void *context, int32_t dataOffset, int32_t length, int32_t resultOffset);
+// extern int32_t v1_3_poseidon(void *context, int32_t inputsOffset, int32_t numInputs, int32_t resultOffset);
+// extern int32_t v1_3_mimc(void *context, int32_t inputsOffset, int32_t numInputs, int32_t resultOffset);
 // extern int32_t v1_3_verifyBLS(void *context, int32_t keyOffset, int32_t messageOffset, int32_t messageLength, int32_t sigOffset);
+// extern int32_t v1_3_verifyBLSAggregated(void *context, int32_t keysOffset, int32_t numKeys, int32_t messageOffset, int32_t messageLength, int32_t sigOffset);
 // extern int32_t v1_3_verifyEd25519(void *context, int32_t keyOffset, int32_t messageOffset, int32_t messageLength, int32_t sigOffset);
+// extern int32_t v1_3_verifyEd25519Batch(void *context, int32_t numSignatures, int32_t keysOffset, int32_t messageLengthsOffset, int32_t messagesOffset, int32_t sigsOffset);
 // extern int32_t v1_3_verifySecp256k1(void *context, int32_t keyOffset, int32_t keyLength, int32_t messageOffset, int32_t messageLength, int32_t sigOffset);
+// extern int32_t v1_3_ecrecover(void *context, int32_t hashOffset, int32_t recoveryID, int32_t rOffset, int32_t sOffset, int32_t resultOffset);
 import "C"
 
 import (
+	"encoding/binary"
+	builtinMath "math"
 	"unsafe"
 
 	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/crypto/hashing"
 	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/math"
 	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/wasmer"
 )
@@ -26,9 +34,12 @@ const blsPublicKeyLength = 96
 const blsSignatureLength = 48
 const ed25519PublicKeyLength = 32
 const ed25519SignatureLength = 64
+const fieldElementLength = 32
 const secp256k1CompressedPublicKeyLength = 33
 const secp256k1UncompressedPublicKeyLength = 65
 const secp256k1SignatureLength = 64
+const ecrecoverHashLength = 32
+const ecrecoverScalarLength = 32
 
 // CryptoImports adds some crypto imports to the Wasmer Imports map
 func CryptoImports(imports *wasmer.Imports) (*wasmer.Imports, error) {
@@ -48,21 +59,46 @@ func CryptoImports(imports *wasmer.Imports) (*wasmer.Imports, error) {
 		return nil, err
 	}
 
+	imports, err = imports.Append("poseidon", v1_3_poseidon, C.v1_3_poseidon)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("mimc", v1_3_mimc, C.v1_3_mimc)
+	if err != nil {
+		return nil, err
+	}
+
 	imports, err = imports.Append("verifyBLS", v1_3_verifyBLS, C.v1_3_verifyBLS)
 	if err != nil {
 		return nil, err
 	}
 
+	imports, err = imports.Append("verifyBLSAggregated", v1_3_verifyBLSAggregated, C.v1_3_verifyBLSAggregated)
+	if err != nil {
+		return nil, err
+	}
+
 	imports, err = imports.Append("verifyEd25519", v1_3_verifyEd25519, C.v1_3_verifyEd25519)
 	if err != nil {
 		return nil, err
 	}
 
+	imports, err = imports.Append("verifyEd25519Batch", v1_3_verifyEd25519Batch, C.v1_3_verifyEd25519Batch)
+	if err != nil {
+		return nil, err
+	}
+
 	imports, err = imports.Append("verifySecp256k1", v1_3_verifySecp256k1, C.v1_3_verifySecp256k1)
 	if err != nil {
 		return nil, err
 	}
 
+	imports, err = imports.Append("ecrecover", v1_3_ecrecover, C.v1_3_ecrecover)
+	if err != nil {
+		return nil, err
+	}
+
 	return imports, nil
 }
 
@@ -150,6 +186,92 @@ func v1_3_ripemd160(context unsafe.Pointer, dataOffset int32, length int32, resu
 	return 0
 }
 
+// loadFieldElements reads the given number of concatenated 32-byte
+// big-endian field elements, which the ZK-friendly hashes take as inputs
+func loadFieldElements(context unsafe.Pointer, inputsOffset int32, numInputs int32, maxInputs int) ([][]byte, bool) {
+	runtime := arwen.GetRuntimeContext(context)
+
+	if numInputs < 0 {
+		arwen.WithFault(arwen.ErrNegativeLength, context, runtime.CryptoAPIErrorShouldFailExecution())
+		return nil, false
+	}
+	if numInputs == 0 || int(numInputs) > maxInputs {
+		arwen.WithFault(hashing.ErrInvalidNumberOfInputs, context, runtime.CryptoAPIErrorShouldFailExecution())
+		return nil, false
+	}
+
+	data, err := runtime.MemLoad(inputsOffset, numInputs*fieldElementLength)
+	if arwen.WithFault(err, context, runtime.CryptoAPIErrorShouldFailExecution()) {
+		return nil, false
+	}
+
+	inputs := make([][]byte, numInputs)
+	for i := range inputs {
+		inputs[i] = data[i*fieldElementLength : (i+1)*fieldElementLength]
+	}
+	return inputs, true
+}
+
+//export v1_3_poseidon
+func v1_3_poseidon(context unsafe.Pointer, inputsOffset int32, numInputs int32, resultOffset int32) int32 {
+	runtime := arwen.GetRuntimeContext(context)
+	crypto := arwen.GetCryptoContext(context)
+	metering := arwen.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().CryptoAPICost.Poseidon
+	metering.UseGas(gasToUse)
+
+	inputs, ok := loadFieldElements(context, inputsOffset, numInputs, hashing.MaxPoseidonInputs)
+	if !ok {
+		return 1
+	}
+
+	gasToUse = math.MulUint64(metering.GasSchedule().CryptoAPICost.PoseidonPerInput, uint64(numInputs))
+	metering.UseGas(gasToUse)
+
+	result, err := crypto.Poseidon(inputs)
+	if arwen.WithFault(err, context, runtime.CryptoAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	err = runtime.MemStore(resultOffset, result)
+	if arwen.WithFault(err, context, runtime.CryptoAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	return 0
+}
+
+//export v1_3_mimc
+func v1_3_mimc(context unsafe.Pointer, inputsOffset int32, numInputs int32, resultOffset int32) int32 {
+	runtime := arwen.GetRuntimeContext(context)
+	crypto := arwen.GetCryptoContext(context)
+	metering := arwen.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().CryptoAPICost.MiMC
+	metering.UseGas(gasToUse)
+
+	inputs, ok := loadFieldElements(context, inputsOffset, numInputs, hashing.MaxMiMCInputs)
+	if !ok {
+		return 1
+	}
+
+	gasToUse = math.MulUint64(metering.GasSchedule().CryptoAPICost.MiMCPerInput, uint64(numInputs))
+	metering.UseGas(gasToUse)
+
+	result, err := crypto.MiMC(inputs)
+	if arwen.WithFault(err, context, runtime.CryptoAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	err = runtime.MemStore(resultOffset, result)
+	if arwen.WithFault(err, context, runtime.CryptoAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	return 0
+}
+
 //export v1_3_verifyBLS
 func v1_3_verifyBLS(
 	context unsafe.Pointer,
@@ -191,6 +313,65 @@ func v1_3_verifyBLS(
 	return 0
 }
 
+//export v1_3_verifyBLSAggregated
+func v1_3_verifyBLSAggregated(
+	context unsafe.Pointer,
+	keysOffset int32,
+	numKeys int32,
+	messageOffset int32,
+	messageLength int32,
+	sigOffset int32,
+) int32 {
+	runtime := arwen.GetRuntimeContext(context)
+	crypto := arwen.GetCryptoContext(context)
+	metering := arwen.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().CryptoAPICost.VerifyBLSAggregated
+	metering.UseGas(gasToUse)
+
+	if numKeys < 0 {
+		arwen.WithFault(arwen.ErrNegativeLength, context, runtime.CryptoAPIErrorShouldFailExecution())
+		return 1
+	}
+	if numKeys > builtinMath.MaxInt32/blsPublicKeyLength {
+		arwen.WithFault(arwen.ErrArgOutOfRange, context, runtime.CryptoAPIErrorShouldFailExecution())
+		return 1
+	}
+
+	gasToUse = math.MulUint64(metering.GasSchedule().CryptoAPICost.VerifyBLSAggregatedPerKey, uint64(numKeys))
+	metering.UseGas(gasToUse)
+
+	keysData, err := runtime.MemLoad(keysOffset, numKeys*blsPublicKeyLength)
+	if arwen.WithFault(err, context, runtime.CryptoAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	keys := make([][]byte, numKeys)
+	for i := range keys {
+		keys[i] = keysData[i*blsPublicKeyLength : (i+1)*blsPublicKeyLength]
+	}
+
+	gasToUse = math.MulUint64(metering.GasSchedule().BaseOperationCost.DataCopyPerByte, uint64(messageLength))
+	metering.UseGas(gasToUse)
+
+	message, err := runtime.MemLoad(messageOffset, messageLength)
+	if arwen.WithFault(err, context, runtime.CryptoAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	sig, err := runtime.MemLoad(sigOffset, blsSignatureLength)
+	if arwen.WithFault(err, context, runtime.CryptoAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	invalidSigErr := crypto.VerifyBLSAggregated(keys, message, sig)
+	if invalidSigErr != nil {
+		return -1
+	}
+
+	return 0
+}
+
 //export v1_3_verifyEd25519
 func v1_3_verifyEd25519(
 	context unsafe.Pointer,
@@ -232,6 +413,100 @@ func v1_3_verifyEd25519(
 	return 0
 }
 
+//export v1_3_verifyEd25519Batch
+func v1_3_verifyEd25519Batch(
+	context unsafe.Pointer,
+	numSignatures int32,
+	keysOffset int32,
+	messageLengthsOffset int32,
+	messagesOffset int32,
+	sigsOffset int32,
+) int32 {
+	runtime := arwen.GetRuntimeContext(context)
+	crypto := arwen.GetCryptoContext(context)
+	metering := arwen.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().CryptoAPICost.VerifyEd25519Batch
+	metering.UseGas(gasToUse)
+
+	if numSignatures < 0 {
+		arwen.WithFault(arwen.ErrNegativeLength, context, runtime.CryptoAPIErrorShouldFailExecution())
+		return 1
+	}
+	if numSignatures > builtinMath.MaxInt32/ed25519SignatureLength {
+		arwen.WithFault(arwen.ErrArgOutOfRange, context, runtime.CryptoAPIErrorShouldFailExecution())
+		return 1
+	}
+
+	// the signatures are verified one by one, so none of them may cost less
+	// than a single verification
+	gasPerSignature := metering.GasSchedule().CryptoAPICost.VerifyEd25519BatchPerSignature
+	if gasPerSignature < metering.GasSchedule().CryptoAPICost.VerifyEd25519 {
+		gasPerSignature = metering.GasSchedule().CryptoAPICost.VerifyEd25519
+	}
+	gasToUse = math.MulUint64(gasPerSignature, uint64(numSignatures))
+	metering.UseGas(gasToUse)
+
+	// the keys and the signatures have fixed sizes and are simply concatenated,
+	// while the lengths of the concatenated messages are 4-byte big-endian integers
+	keysData, err := runtime.MemLoad(keysOffset, numSignatures*ed25519PublicKeyLength)
+	if arwen.WithFault(err, context, runtime.CryptoAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	messageLengthsData, err := runtime.MemLoad(messageLengthsOffset, numSignatures*4)
+	if arwen.WithFault(err, context, runtime.CryptoAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	messagesLength := int64(0)
+	for i := int32(0); i < numSignatures; i++ {
+		messageLength := int32(binary.BigEndian.Uint32(messageLengthsData[i*4:]))
+		if messageLength < 0 {
+			arwen.WithFault(arwen.ErrNegativeLength, context, runtime.CryptoAPIErrorShouldFailExecution())
+			return 1
+		}
+		messagesLength += int64(messageLength)
+	}
+	if messagesLength > builtinMath.MaxInt32 {
+		arwen.WithFault(arwen.ErrArgOutOfRange, context, runtime.CryptoAPIErrorShouldFailExecution())
+		return 1
+	}
+
+	gasToUse = math.MulUint64(metering.GasSchedule().BaseOperationCost.DataCopyPerByte, uint64(messagesLength))
+	metering.UseGas(gasToUse)
+
+	messagesData, err := runtime.MemLoad(messagesOffset, int32(messagesLength))
+	if arwen.WithFault(err, context, runtime.CryptoAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	sigsData, err := runtime.MemLoad(sigsOffset, numSignatures*ed25519SignatureLength)
+	if arwen.WithFault(err, context, runtime.CryptoAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	keys := make([][]byte, numSignatures)
+	messages := make([][]byte, numSignatures)
+	sigs := make([][]byte, numSignatures)
+	messageStart := int32(0)
+	for i := int32(0); i < numSignatures; i++ {
+		keys[i] = keysData[i*ed25519PublicKeyLength : (i+1)*ed25519PublicKeyLength]
+		sigs[i] = sigsData[i*ed25519SignatureLength : (i+1)*ed25519SignatureLength]
+
+		messageEnd := messageStart + int32(binary.BigEndian.Uint32(messageLengthsData[i*4:]))
+		messages[i] = messagesData[messageStart:messageEnd]
+		messageStart = messageEnd
+	}
+
+	invalidSigErr := crypto.VerifyEd25519Batch(keys, messages, sigs)
+	if invalidSigErr != nil {
+		return -1
+	}
+
+	return 0
+}
+
 //export v1_3_verifySecp256k1
 func v1_3_verifySecp256k1(
 	context unsafe.Pointer,
@@ -287,3 +562,52 @@ func v1_3_verifySecp256k1(
 
 	return 0
 }
+
+//export v1_3_ecrecover
+func v1_3_ecrecover(
+	context unsafe.Pointer,
+	hashOffset int32,
+	recoveryID int32,
+	rOffset int32,
+	sOffset int32,
+	resultOffset int32,
+) int32 {
+	runtime := arwen.GetRuntimeContext(context)
+	crypto := arwen.GetCryptoContext(context)
+	metering := arwen.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().CryptoAPICost.Ecrecover
+	metering.UseGas(gasToUse)
+
+	hash, err := runtime.MemLoad(hashOffset, ecrecoverHashLength)
+	if arwen.WithFault(err, context, runtime.CryptoAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	r, err := runtime.MemLoad(rOffset, ecrecoverScalarLength)
+	if arwen.WithFault(err, context, runtime.CryptoAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	s, err := runtime.MemLoad(sOffset, ecrecoverScalarLength)
+	if arwen.WithFault(err, context, runtime.CryptoAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	// a malformed signature recovers no address, the same as an invalid one
+	if recoveryID < 0 || recoveryID > builtinMath.MaxUint8 {
+		return -1
+	}
+
+	address, invalidSigErr := crypto.Ecrecover(hash, []byte{byte(recoveryID)}, r, s)
+	if invalidSigErr != nil {
+		return -1
+	}
+
+	err = runtime.MemStore(resultOffset, address)
+	if arwen.WithFault(err, context, runtime.CryptoAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	return 0
+}
@@ -0,0 +1,217 @@
+package cryptoapi
+
+// // Declare the function signatures (see [cgo](https://golang.org/cmd/cgo/)).
+//
+// #include <stdlib.h>
+// typedef unsigned char uint8_t;
+// typedef int int32_t;
+//
+// extern int32_t		v1_3_curvePointFromBytes(void* context, int32_t curveID, int32_t dataOffset);
+// extern int32_t		v1_3_curvePointToBytes(void* context, int32_t pointHandle, int32_t resultOffset);
+// extern int32_t		v1_3_curvePointAdd(void* context, int32_t pointHandle1, int32_t pointHandle2);
+// extern int32_t		v1_3_curvePointScalarMult(void* context, int32_t pointHandle, int32_t scalarOffset);
+// extern int32_t		v1_3_curvePointScalarBaseMult(void* context, int32_t curveID, int32_t scalarOffset);
+// extern int32_t		v1_3_curvePointEqual(void* context, int32_t pointHandle1, int32_t pointHandle2);
+import "C"
+
+import (
+	"bytes"
+	"unsafe"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/crypto/curves"
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/wasmer"
+)
+
+// EllipticCurveImports populates imports with the elliptic curve API methods
+func EllipticCurveImports(imports *wasmer.Imports) (*wasmer.Imports, error) {
+	imports = imports.Namespace("env")
+
+	imports, err := imports.Append("curvePointFromBytes", v1_3_curvePointFromBytes, C.v1_3_curvePointFromBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("curvePointToBytes", v1_3_curvePointToBytes, C.v1_3_curvePointToBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("curvePointAdd", v1_3_curvePointAdd, C.v1_3_curvePointAdd)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("curvePointScalarMult", v1_3_curvePointScalarMult, C.v1_3_curvePointScalarMult)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("curvePointScalarBaseMult", v1_3_curvePointScalarBaseMult, C.v1_3_curvePointScalarBaseMult)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("curvePointEqual", v1_3_curvePointEqual, C.v1_3_curvePointEqual)
+	if err != nil {
+		return nil, err
+	}
+
+	return imports, nil
+}
+
+// getCurvePoint returns the curve point under the given handle, failing the
+// execution if it is missing
+func getCurvePoint(context unsafe.Pointer, pointHandle int32) (*arwen.CurvePoint, bool) {
+	runtime := arwen.GetRuntimeContext(context)
+	point, err := runtime.ManagedTypes().GetCurvePoint(pointHandle)
+	if arwen.WithFault(err, context, runtime.CryptoAPIErrorShouldFailExecution()) {
+		return nil, false
+	}
+
+	return point, true
+}
+
+//export v1_3_curvePointFromBytes
+func v1_3_curvePointFromBytes(context unsafe.Pointer, curveID int32, dataOffset int32) int32 {
+	runtime := arwen.GetRuntimeContext(context)
+	crypto := arwen.GetCryptoContext(context)
+	metering := arwen.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().EllipticCurveAPICost.CurvePointFromBytes
+	metering.UseGas(gasToUse)
+
+	encoding, err := runtime.MemLoad(dataOffset, curves.PointLength)
+	if arwen.WithFault(err, context, runtime.CryptoAPIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	err = crypto.ValidateCurvePoint(curveID, encoding)
+	if arwen.WithFault(err, context, runtime.CryptoAPIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	return runtime.ManagedTypes().PutCurvePoint(curveID, encoding)
+}
+
+//export v1_3_curvePointToBytes
+func v1_3_curvePointToBytes(context unsafe.Pointer, pointHandle int32, resultOffset int32) int32 {
+	runtime := arwen.GetRuntimeContext(context)
+	metering := arwen.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().EllipticCurveAPICost.CurvePointToBytes
+	metering.UseGas(gasToUse)
+
+	point, ok := getCurvePoint(context, pointHandle)
+	if !ok {
+		return -1
+	}
+
+	err := runtime.MemStore(resultOffset, point.Encoding)
+	if arwen.WithFault(err, context, runtime.CryptoAPIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	return int32(len(point.Encoding))
+}
+
+//export v1_3_curvePointAdd
+func v1_3_curvePointAdd(context unsafe.Pointer, pointHandle1 int32, pointHandle2 int32) int32 {
+	runtime := arwen.GetRuntimeContext(context)
+	crypto := arwen.GetCryptoContext(context)
+	metering := arwen.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().EllipticCurveAPICost.CurvePointAdd
+	metering.UseGas(gasToUse)
+
+	point1, ok := getCurvePoint(context, pointHandle1)
+	if !ok {
+		return -1
+	}
+	point2, ok := getCurvePoint(context, pointHandle2)
+	if !ok {
+		return -1
+	}
+	if point1.CurveID != point2.CurveID {
+		arwen.WithFault(curves.ErrCurveMismatch, context, runtime.CryptoAPIErrorShouldFailExecution())
+		return -1
+	}
+
+	result, err := crypto.CurvePointAdd(point1.CurveID, point1.Encoding, point2.Encoding)
+	if arwen.WithFault(err, context, runtime.CryptoAPIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	return runtime.ManagedTypes().PutCurvePoint(point1.CurveID, result)
+}
+
+//export v1_3_curvePointScalarMult
+func v1_3_curvePointScalarMult(context unsafe.Pointer, pointHandle int32, scalarOffset int32) int32 {
+	runtime := arwen.GetRuntimeContext(context)
+	crypto := arwen.GetCryptoContext(context)
+	metering := arwen.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().EllipticCurveAPICost.CurvePointScalarMult
+	metering.UseGas(gasToUse)
+
+	point, ok := getCurvePoint(context, pointHandle)
+	if !ok {
+		return -1
+	}
+
+	scalar, err := runtime.MemLoad(scalarOffset, curves.ScalarLength)
+	if arwen.WithFault(err, context, runtime.CryptoAPIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	result, err := crypto.CurveScalarMult(point.CurveID, point.Encoding, scalar)
+	if arwen.WithFault(err, context, runtime.CryptoAPIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	return runtime.ManagedTypes().PutCurvePoint(point.CurveID, result)
+}
+
+//export v1_3_curvePointScalarBaseMult
+func v1_3_curvePointScalarBaseMult(context unsafe.Pointer, curveID int32, scalarOffset int32) int32 {
+	runtime := arwen.GetRuntimeContext(context)
+	crypto := arwen.GetCryptoContext(context)
+	metering := arwen.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().EllipticCurveAPICost.CurvePointScalarBaseMult
+	metering.UseGas(gasToUse)
+
+	scalar, err := runtime.MemLoad(scalarOffset, curves.ScalarLength)
+	if arwen.WithFault(err, context, runtime.CryptoAPIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	result, err := crypto.CurveScalarBaseMult(curveID, scalar)
+	if arwen.WithFault(err, context, runtime.CryptoAPIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	return runtime.ManagedTypes().PutCurvePoint(curveID, result)
+}
+
+//export v1_3_curvePointEqual
+func v1_3_curvePointEqual(context unsafe.Pointer, pointHandle1 int32, pointHandle2 int32) int32 {
+	metering := arwen.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().EllipticCurveAPICost.CurvePointEqual
+	metering.UseGas(gasToUse)
+
+	point1, ok := getCurvePoint(context, pointHandle1)
+	if !ok {
+		return -1
+	}
+	point2, ok := getCurvePoint(context, pointHandle2)
+	if !ok {
+		return -1
+	}
+
+	if point1.CurveID == point2.CurveID && bytes.Equal(point1.Encoding, point2.Encoding) {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,9 @@
+package arwen
+
+// CurvePoint is a point of one of the elliptic curves of the Crypto API, which
+// contracts use through a handle. It is kept compressed and never modified,
+// the operations on points producing new ones.
+type CurvePoint struct {
+	CurveID  int32
+	Encoding []byte
+}
@@ -0,0 +1,58 @@
+package arwen
+
+import (
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/wasmer"
+)
+
+// DebugStop identifies where the single-step debugging mode of the runtime
+// stops an execution: in the Instance-th instance started by the transaction,
+// counted from 1, at the first metering check at which the instance has used
+// at least Points points. Wasmer cannot resume an instance stopped by its
+// metering, so the consecutive steps of an execution are taken by running it
+// again with a later DebugStop.
+type DebugStop struct {
+	Instance uint64
+	Points   uint64
+}
+
+// IsSet returns true if the DebugStop designates an instance
+func (stop DebugStop) IsSet() bool {
+	return stop.Instance > 0
+}
+
+// DebugStep describes an execution stopped by the single-step debugging mode
+// of the runtime: the instance, contract and function being executed, the gas
+// left to the execution, the points used by the instance so far and a copy of
+// the memory of the instance, which is empty if the instance has no memory
+type DebugStep struct {
+	Instance   uint64
+	Address    []byte
+	Function   string
+	GasLeft    uint64
+	PointsUsed uint64
+	Memory     []byte
+}
+
+// NewDebugStep creates a DebugStep, copying the memory of the stopped
+// instance, which is cleaned after the execution ends
+func NewDebugStep(instance uint64, address []byte, function string, gasLeft uint64, pointsUsed uint64, memory wasmer.MemoryHandler) DebugStep {
+	step := DebugStep{
+		Instance:   instance,
+		Address:    address,
+		Function:   function,
+		GasLeft:    gasLeft,
+		PointsUsed: pointsUsed,
+	}
+
+	if memory != nil {
+		data := memory.Data()
+		step.Memory = make([]byte, len(data))
+		copy(step.Memory, data)
+	}
+
+	return step
+}
+
+// DebugStepCallback is invoked by the runtime when the single-step debugging
+// mode stops an execution
+type DebugStepCallback func(step DebugStep)
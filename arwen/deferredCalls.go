@@ -0,0 +1,26 @@
+package arwen
+
+// DeferredCallsKey is the storage key of the pending deferred calls of a contract
+const DeferredCallsKey = ProtectedStoragePrefix + "DEFERRED"
+
+// DeferredCallLogIdentifier is the identifier of the log entry written when a deferred call is registered
+const DeferredCallLogIdentifier = "deferredCall"
+
+// DeferredCall is a call of a contract to itself, dispatched once its target round and epoch are reached
+type DeferredCall struct {
+	Function    string
+	Arguments   [][]byte
+	GasLimit    uint64
+	TargetRound uint64
+	TargetEpoch uint32
+}
+
+// IsDue returns true if the deferred call must be dispatched in the block with the given round and epoch
+func (call *DeferredCall) IsDue(round uint64, epoch uint32) bool {
+	return round >= call.TargetRound && epoch >= call.TargetEpoch
+}
+
+// DeferredCallsInfo contains the deferred calls registered by a contract, in the order of their registration
+type DeferredCallsInfo struct {
+	Calls []*DeferredCall
+}
@@ -0,0 +1,110 @@
+package arwen
+
+import (
+	"fmt"
+)
+
+// DeploymentLimits bounds the code of the contracts admitted for deployment
+// or upgrade: its size in bytes, and the number of exports, imports and data
+// segments of the module. A value of 0 leaves the respective limit unset.
+type DeploymentLimits struct {
+	MaxCodeSize     uint64
+	MaxExports      uint32
+	MaxImports      uint32
+	MaxDataSegments uint32
+}
+
+// IsEnabled returns true if at least one of the limits is set
+func (limits DeploymentLimits) IsEnabled() bool {
+	return limits.MaxCodeSize > 0 ||
+		limits.MaxExports > 0 ||
+		limits.MaxImports > 0 ||
+		limits.MaxDataSegments > 0
+}
+
+// CheckCode returns the error of the first limit exceeded by the given
+// contract code, or nil if the code may be deployed
+func (limits DeploymentLimits) CheckCode(code []byte) error {
+	if !limits.IsEnabled() {
+		return nil
+	}
+
+	if limits.MaxCodeSize > 0 && uint64(len(code)) > limits.MaxCodeSize {
+		return fmt.Errorf("%w: %d bytes, at most %d allowed", ErrContractCodeTooLarge, len(code), limits.MaxCodeSize)
+	}
+
+	needsModule := limits.MaxExports > 0 || limits.MaxImports > 0 || limits.MaxDataSegments > 0
+	if !needsModule {
+		return nil
+	}
+
+	counts, err := countModuleEntries(code)
+	if err != nil {
+		return err
+	}
+
+	if limits.MaxExports > 0 && counts.numExports > limits.MaxExports {
+		return fmt.Errorf("%w: %d declared, at most %d allowed", ErrTooManyExports, counts.numExports, limits.MaxExports)
+	}
+	if limits.MaxImports > 0 && counts.numImports > limits.MaxImports {
+		return fmt.Errorf("%w: %d declared, at most %d allowed", ErrTooManyImports, counts.numImports, limits.MaxImports)
+	}
+	if limits.MaxDataSegments > 0 && counts.numDataSegments > limits.MaxDataSegments {
+		return fmt.Errorf("%w: %d declared, at most %d allowed", ErrTooManyDataSegments, counts.numDataSegments, limits.MaxDataSegments)
+	}
+
+	return nil
+}
+
+type wasmModuleCounts struct {
+	numExports      uint32
+	numImports      uint32
+	numDataSegments uint32
+}
+
+// countModuleEntries reads the number of entries declared at the start of the
+// export, import and data sections of the given Wasm module, skipping over
+// the entries themselves and over all the other sections
+func countModuleEntries(code []byte) (*wasmModuleCounts, error) {
+	if len(code) < wasmHeaderLength {
+		return nil, fmt.Errorf("%w: missing header", ErrModuleMalformed)
+	}
+
+	counts := &wasmModuleCounts{}
+	reader := &wasmReader{data: code, offset: wasmHeaderLength}
+	for reader.offset < len(reader.data) {
+		sectionID, ok := reader.readByte()
+		if !ok {
+			return nil, fmt.Errorf("%w: truncated section", ErrModuleMalformed)
+		}
+		sectionSize, ok := reader.readUint32()
+		if !ok {
+			return nil, fmt.Errorf("%w: truncated section", ErrModuleMalformed)
+		}
+
+		sectionEnd := reader.offset + int(sectionSize)
+		if sectionEnd > len(reader.data) {
+			return nil, fmt.Errorf("%w: section %d exceeds the module", ErrModuleMalformed, sectionID)
+		}
+
+		var count *uint32
+		switch sectionID {
+		case wasmExportSectionID:
+			count = &counts.numExports
+		case wasmImportSectionID:
+			count = &counts.numImports
+		case wasmDataSectionID:
+			count = &counts.numDataSegments
+		}
+		if count != nil {
+			section := &wasmReader{data: reader.data[:sectionEnd], offset: reader.offset}
+			*count, ok = section.readUint32()
+			if !ok {
+				return nil, fmt.Errorf("%w: cannot decode section %d", ErrModuleMalformed, sectionID)
+			}
+		}
+		reader.offset = sectionEnd
+	}
+
+	return counts, nil
+}
@@ -0,0 +1,71 @@
+package arwen
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeploymentLimits_Disabled(t *testing.T) {
+	limits := DeploymentLimits{}
+	require.False(t, limits.IsEnabled())
+	require.Nil(t, limits.CheckCode([]byte("not a module")))
+}
+
+func TestDeploymentLimits_CodeSize(t *testing.T) {
+	code := makeTestModule([][]byte{emptyBody})
+
+	limits := DeploymentLimits{MaxCodeSize: uint64(len(code))}
+	require.Nil(t, limits.CheckCode(code))
+
+	limits.MaxCodeSize--
+	err := limits.CheckCode(code)
+	require.True(t, errors.Is(err, ErrContractCodeTooLarge))
+	require.True(t, errors.Is(err, ErrContractInvalid))
+}
+
+func TestDeploymentLimits_ModuleCounts(t *testing.T) {
+	// data section: two active segments in memory 0
+	dataSegments := []byte{0x0b, 0x0d, 0x02,
+		0x00, 0x41, 0x00, 0x0b, 0x01, 'x',
+		0x00, 0x41, 0x08, 0x0b, 0x01, 'y',
+	}
+	code := makeTestModule([][]byte{emptyBody}, dataSegments)
+
+	require.Nil(t, DeploymentLimits{MaxExports: 1, MaxImports: 1, MaxDataSegments: 2}.CheckCode(code))
+
+	err := DeploymentLimits{MaxDataSegments: 1}.CheckCode(code)
+	require.True(t, errors.Is(err, ErrTooManyDataSegments))
+	require.Contains(t, err.Error(), "2 declared, at most 1 allowed")
+
+	// two imported functions of type 0 and two exports, without the
+	// sections which would make it a complete module
+	imports := append(append([]byte{}, wasmHeader...), 0x02, 0x0d, 0x02,
+		0x01, 'm', 0x01, 'a', 0x00, 0x00,
+		0x01, 'm', 0x01, 'b', 0x00, 0x00,
+	)
+	exports := append(imports, 0x07, 0x09, 0x02,
+		0x01, 'a', 0x00, 0x00,
+		0x01, 'b', 0x00, 0x01,
+	)
+
+	err = DeploymentLimits{MaxImports: 1}.CheckCode(exports)
+	require.True(t, errors.Is(err, ErrTooManyImports))
+
+	err = DeploymentLimits{MaxExports: 1}.CheckCode(exports)
+	require.True(t, errors.Is(err, ErrTooManyExports))
+
+	err = DeploymentLimits{MaxExports: 1}.CheckCode(exports[:len(exports)-1])
+	require.True(t, errors.Is(err, ErrModuleMalformed))
+}
+
+func TestDeploymentLimits_CountsWithoutDecodingEntries(t *testing.T) {
+	// a body with an unknown opcode, which the module validation refuses
+	code := makeTestModule([][]byte{{0x00, 0xff, 0x0b}})
+	_, err := decodeWasmModule(code)
+	require.True(t, errors.Is(err, ErrModuleMalformed))
+
+	// only the number of entries of the counted sections is read
+	require.Nil(t, DeploymentLimits{MaxExports: 1, MaxImports: 1, MaxDataSegments: 1}.CheckCode(code))
+}
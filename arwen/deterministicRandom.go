@@ -0,0 +1,52 @@
+package arwen
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+)
+
+// DeterministicRandom derives length pseudo-random bytes, which all the nodes
+// compute identically, from the random seed of the current block, the hash of
+// the current transaction, a seed given by the contract and a counter which
+// the Runtime context increments on each call within the transaction, so that
+// repeated calls with the same seed never return the same bytes.
+//
+// The bytes are the concatenation of as many SHA256 hashes as needed, the i-th
+// hash being taken over
+//
+//	len(blockRandomSeed) || blockRandomSeed || len(txHash) || txHash ||
+//	len(seed) || seed || counter || i
+//
+// with the lengths and i as 4-byte and the counter as 8-byte big-endian integers.
+func DeterministicRandom(blockRandomSeed []byte, txHash []byte, seed []byte, counter uint64, length int) []byte {
+	prefix := make([]byte, 0, 12+len(blockRandomSeed)+len(txHash)+len(seed)+8)
+	prefix = appendWithLength(prefix, blockRandomSeed)
+	prefix = appendWithLength(prefix, txHash)
+	prefix = appendWithLength(prefix, seed)
+	prefix = appendUint64(prefix, counter)
+
+	result := make([]byte, 0, length+sha256.Size)
+	for i := uint32(0); len(result) < length; i++ {
+		block := sha256.Sum256(appendUint32(prefix, i))
+		result = append(result, block[:]...)
+	}
+
+	return result[:length]
+}
+
+func appendWithLength(data []byte, value []byte) []byte {
+	data = appendUint32(data, uint32(len(value)))
+	return append(data, value...)
+}
+
+func appendUint32(data []byte, value uint32) []byte {
+	encoded := make([]byte, 4)
+	binary.BigEndian.PutUint32(encoded, value)
+	return append(data, encoded...)
+}
+
+func appendUint64(data []byte, value uint64) []byte {
+	encoded := make([]byte, 8)
+	binary.BigEndian.PutUint64(encoded, value)
+	return append(data, encoded...)
+}
@@ -0,0 +1,37 @@
+package arwen
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeterministicRandom_Derivation(t *testing.T) {
+	blockRandomSeed := []byte("block")
+	txHash := []byte("tx")
+	seed := []byte("seed")
+
+	input := []byte{0, 0, 0, 5, 'b', 'l', 'o', 'c', 'k', 0, 0, 0, 2, 't', 'x', 0, 0, 0, 4, 's', 'e', 'e', 'd'}
+	input = append(input, 0, 0, 0, 0, 0, 0, 0, 3)
+	firstBlock := sha256.Sum256(append(append([]byte{}, input...), 0, 0, 0, 0))
+	secondBlock := sha256.Sum256(append(append([]byte{}, input...), 0, 0, 0, 1))
+
+	result := DeterministicRandom(blockRandomSeed, txHash, seed, 3, 40)
+	require.Equal(t, append(firstBlock[:], secondBlock[:8]...), result)
+
+	require.Equal(t, firstBlock[:10], DeterministicRandom(blockRandomSeed, txHash, seed, 3, 10))
+	require.Empty(t, DeterministicRandom(blockRandomSeed, txHash, seed, 3, 0))
+}
+
+func TestDeterministicRandom_DependsOnAllInputs(t *testing.T) {
+	result := DeterministicRandom([]byte("block"), []byte("tx"), []byte("seed"), 0, 32)
+
+	require.NotEqual(t, result, DeterministicRandom([]byte("block"), []byte("tx"), []byte("seed"), 1, 32))
+	require.NotEqual(t, result, DeterministicRandom([]byte("other"), []byte("tx"), []byte("seed"), 0, 32))
+	require.NotEqual(t, result, DeterministicRandom([]byte("block"), []byte("other"), []byte("seed"), 0, 32))
+	require.NotEqual(t, result, DeterministicRandom([]byte("block"), []byte("tx"), []byte("other"), 0, 32))
+
+	// the lengths keep the boundaries between the inputs unambiguous
+	require.NotEqual(t, result, DeterministicRandom([]byte("bloc"), []byte("ktx"), []byte("seed"), 0, 32))
+}
@@ -0,0 +1,263 @@
+package elrondapi
+
+// // Declare the function signatures (see [cgo](https://golang.org/cmd/cgo/)).
+//
+// #include <stdlib.h>
+// typedef unsigned char uint8_t;
+// typedef int int32_t;
+//
+// extern int32_t		v1_3_bigFloatNewFromParts(void* context, int32_t integralPart, int32_t fractionalPart, int32_t exponent);
+//
+// extern void			v1_3_bigFloatAdd(void* context, int32_t destination, int32_t op1, int32_t op2);
+// extern void			v1_3_bigFloatSub(void* context, int32_t destination, int32_t op1, int32_t op2);
+// extern void			v1_3_bigFloatMul(void* context, int32_t destination, int32_t op1, int32_t op2);
+// extern void			v1_3_bigFloatDiv(void* context, int32_t destination, int32_t op1, int32_t op2);
+//
+// extern void			v1_3_bigFloatSqrt(void* context, int32_t destination, int32_t op);
+// extern void			v1_3_bigFloatPow(void* context, int32_t destination, int32_t op, int32_t exponent);
+// extern int32_t		v1_3_bigFloatCmp(void* context, int32_t op1, int32_t op2);
+// extern void			v1_3_bigFloatTruncate(void* context, int32_t op, int32_t bigIntDestination);
+import "C"
+
+import (
+	"math/big"
+	"unsafe"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/math"
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/wasmer"
+)
+
+// BigFloatImports populates imports with the BigFloat API methods
+func BigFloatImports(imports *wasmer.Imports) (*wasmer.Imports, error) {
+	imports = imports.Namespace("env")
+
+	imports, err := imports.Append("bigFloatNewFromParts", v1_3_bigFloatNewFromParts, C.v1_3_bigFloatNewFromParts)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("bigFloatAdd", v1_3_bigFloatAdd, C.v1_3_bigFloatAdd)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("bigFloatSub", v1_3_bigFloatSub, C.v1_3_bigFloatSub)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("bigFloatMul", v1_3_bigFloatMul, C.v1_3_bigFloatMul)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("bigFloatDiv", v1_3_bigFloatDiv, C.v1_3_bigFloatDiv)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("bigFloatSqrt", v1_3_bigFloatSqrt, C.v1_3_bigFloatSqrt)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("bigFloatPow", v1_3_bigFloatPow, C.v1_3_bigFloatPow)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("bigFloatCmp", v1_3_bigFloatCmp, C.v1_3_bigFloatCmp)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("bigFloatTruncate", v1_3_bigFloatTruncate, C.v1_3_bigFloatTruncate)
+	if err != nil {
+		return nil, err
+	}
+
+	return imports, nil
+}
+
+// getBigFloats returns the big floats under the given handles, failing the
+// execution if any of them is missing
+func getBigFloats(context unsafe.Pointer, handles ...int32) ([]*big.Float, bool) {
+	runtime := arwen.GetRuntimeContext(context)
+	managedTypes := runtime.ManagedTypes()
+
+	values := make([]*big.Float, len(handles))
+	for i, handle := range handles {
+		value, err := managedTypes.GetBigFloat(handle)
+		if arwen.WithFault(err, context, runtime.BigIntAPIErrorShouldFailExecution()) {
+			return nil, false
+		}
+		values[i] = value
+	}
+
+	return values, true
+}
+
+// setBigFloatResult stores the given result under the destination, unless it
+// is not finite, in which case the execution fails
+func setBigFloatResult(context unsafe.Pointer, dest *big.Float, result *big.Float) {
+	runtime := arwen.GetRuntimeContext(context)
+	err := arwen.CheckBigFloat(result)
+	if arwen.WithFault(err, context, runtime.BigIntAPIErrorShouldFailExecution()) {
+		return
+	}
+
+	dest.Set(result)
+}
+
+//export v1_3_bigFloatNewFromParts
+func v1_3_bigFloatNewFromParts(context unsafe.Pointer, integralPart, fractionalPart, exponent int32) int32 {
+	runtime := arwen.GetRuntimeContext(context)
+	metering := arwen.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().BigFloatAPICost.BigFloatNewFromParts
+	metering.UseGas(gasToUse)
+
+	value, err := arwen.BigFloatFromParts(integralPart, fractionalPart, exponent)
+	if arwen.WithFault(err, context, runtime.BigIntAPIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	return runtime.ManagedTypes().PutBigFloat(value)
+}
+
+//export v1_3_bigFloatAdd
+func v1_3_bigFloatAdd(context unsafe.Pointer, destination, op1, op2 int32) {
+	metering := arwen.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().BigFloatAPICost.BigFloatAdd
+	metering.UseGas(gasToUse)
+
+	values, ok := getBigFloats(context, destination, op1, op2)
+	if !ok {
+		return
+	}
+	setBigFloatResult(context, values[0], arwen.NewBigFloat().Add(values[1], values[2]))
+}
+
+//export v1_3_bigFloatSub
+func v1_3_bigFloatSub(context unsafe.Pointer, destination, op1, op2 int32) {
+	metering := arwen.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().BigFloatAPICost.BigFloatSub
+	metering.UseGas(gasToUse)
+
+	values, ok := getBigFloats(context, destination, op1, op2)
+	if !ok {
+		return
+	}
+	setBigFloatResult(context, values[0], arwen.NewBigFloat().Sub(values[1], values[2]))
+}
+
+//export v1_3_bigFloatMul
+func v1_3_bigFloatMul(context unsafe.Pointer, destination, op1, op2 int32) {
+	metering := arwen.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().BigFloatAPICost.BigFloatMul
+	metering.UseGas(gasToUse)
+
+	values, ok := getBigFloats(context, destination, op1, op2)
+	if !ok {
+		return
+	}
+	setBigFloatResult(context, values[0], arwen.NewBigFloat().Mul(values[1], values[2]))
+}
+
+//export v1_3_bigFloatDiv
+func v1_3_bigFloatDiv(context unsafe.Pointer, destination, op1, op2 int32) {
+	metering := arwen.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().BigFloatAPICost.BigFloatDiv
+	metering.UseGas(gasToUse)
+
+	values, ok := getBigFloats(context, destination, op1, op2)
+	if !ok {
+		return
+	}
+	if values[2].Sign() == 0 {
+		runtime := arwen.GetRuntimeContext(context)
+		arwen.WithFault(arwen.ErrDivZero, context, runtime.BigIntAPIErrorShouldFailExecution())
+		return
+	}
+	setBigFloatResult(context, values[0], arwen.NewBigFloat().Quo(values[1], values[2]))
+}
+
+//export v1_3_bigFloatSqrt
+func v1_3_bigFloatSqrt(context unsafe.Pointer, destination, op int32) {
+	metering := arwen.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().BigFloatAPICost.BigFloatSqrt
+	metering.UseGas(gasToUse)
+
+	values, ok := getBigFloats(context, destination, op)
+	if !ok {
+		return
+	}
+	if values[1].Sign() < 0 {
+		runtime := arwen.GetRuntimeContext(context)
+		arwen.WithFault(arwen.ErrInvalidBigFloatOperation, context, runtime.BigIntAPIErrorShouldFailExecution())
+		return
+	}
+	setBigFloatResult(context, values[0], arwen.NewBigFloat().Sqrt(values[1]))
+}
+
+//export v1_3_bigFloatPow
+func v1_3_bigFloatPow(context unsafe.Pointer, destination, op, exponent int32) {
+	runtime := arwen.GetRuntimeContext(context)
+	metering := arwen.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().BigFloatAPICost.BigFloatPow
+	metering.UseGas(gasToUse)
+
+	values, ok := getBigFloats(context, destination, op)
+	if !ok {
+		return
+	}
+
+	result, multiplications, err := arwen.BigFloatPow(values[1], exponent)
+	gasToUse = math.MulUint64(metering.GasSchedule().BigFloatAPICost.BigFloatMul, multiplications)
+	metering.UseGas(gasToUse)
+	if arwen.WithFault(err, context, runtime.BigIntAPIErrorShouldFailExecution()) {
+		return
+	}
+
+	values[0].Set(result)
+}
+
+//export v1_3_bigFloatCmp
+func v1_3_bigFloatCmp(context unsafe.Pointer, op1, op2 int32) int32 {
+	metering := arwen.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().BigFloatAPICost.BigFloatCmp
+	metering.UseGas(gasToUse)
+
+	values, ok := getBigFloats(context, op1, op2)
+	if !ok {
+		return -2
+	}
+	return int32(values[0].Cmp(values[1]))
+}
+
+//export v1_3_bigFloatTruncate
+func v1_3_bigFloatTruncate(context unsafe.Pointer, op, bigIntDestination int32) {
+	bigInt := arwen.GetBigIntContext(context)
+	metering := arwen.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().BigFloatAPICost.BigFloatTruncate
+	metering.UseGas(gasToUse)
+
+	values, ok := getBigFloats(context, op)
+	if !ok {
+		return
+	}
+	err := arwen.CheckBigFloat(values[0])
+	if arwen.WithFault(err, context, arwen.GetRuntimeContext(context).BigIntAPIErrorShouldFailExecution()) {
+		return
+	}
+	values[0].Int(bigInt.GetOne(bigIntDestination))
+}
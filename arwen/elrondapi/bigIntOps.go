@@ -39,6 +39,10 @@ package elrondapi
 // extern void			v1_3_bigIntShr(void* context, int32_t destination, int32_t op, int32_t bits);
 // extern void			v1_3_bigIntShl(void* context, int32_t destination, int32_t op, int32_t bits);
 //
+// extern void			v1_3_bigIntPowMod(void* context, int32_t destination, int32_t base, int32_t exponent, int32_t modulus);
+// extern int32_t		v1_3_bigIntIsPrime(void* context, int32_t op);
+// extern void			v1_3_bigIntGcd(void* context, int32_t destination, int32_t op1, int32_t op2);
+//
 // extern void			v1_3_bigIntFinishUnsigned(void* context, int32_t reference);
 // extern void			v1_3_bigIntFinishSigned(void* context, int32_t reference);
 // extern int32_t		v1_3_bigIntStorageStoreUnsigned(void *context, int32_t keyOffset, int32_t keyLength, int32_t source);
@@ -200,6 +204,21 @@ func BigIntImports(imports *wasmer.Imports) (*wasmer.Imports, error) {
 		return nil, err
 	}
 
+	imports, err = imports.Append("bigIntPowMod", v1_3_bigIntPowMod, C.v1_3_bigIntPowMod)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("bigIntIsPrime", v1_3_bigIntIsPrime, C.v1_3_bigIntIsPrime)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("bigIntGcd", v1_3_bigIntGcd, C.v1_3_bigIntGcd)
+	if err != nil {
+		return nil, err
+	}
+
 	imports, err = imports.Append("bigIntFinishUnsigned", v1_3_bigIntFinishUnsigned, C.v1_3_bigIntFinishUnsigned)
 	if err != nil {
 		return nil, err
@@ -264,6 +283,23 @@ func useExtraGasForOperations(metering arwen.MeteringContext, values []*big.Int)
 	}
 }
 
+// useGasBeforeExpensiveOperation charges the given gas and returns true if
+// the contract could afford it; otherwise it fails the execution, sparing
+// the computation which the gas would have paid for
+func useGasBeforeExpensiveOperation(context unsafe.Pointer, gasToUse uint64) bool {
+	runtime := arwen.GetRuntimeContext(context)
+	metering := arwen.GetMeteringContext(context)
+
+	gasLeft := metering.GasLeft()
+	metering.UseGas(gasToUse)
+	if gasToUse > gasLeft {
+		_ = arwen.WithFault(arwen.ErrNotEnoughGas, context, runtime.BigIntAPIErrorShouldFailExecution())
+		return false
+	}
+
+	return true
+}
+
 //export v1_3_bigIntGetUnsignedArgument
 func v1_3_bigIntGetUnsignedArgument(context unsafe.Pointer, id int32, destination int32) {
 	bigInt := arwen.GetBigIntContext(context)
@@ -335,14 +371,14 @@ func v1_3_bigIntStorageLoadUnsigned(context unsafe.Pointer, keyOffset int32, key
 	storage := arwen.GetStorageContext(context)
 	metering := arwen.GetMeteringContext(context)
 
-	gasToUse := metering.GasSchedule().BigIntAPICost.BigIntStorageLoadUnsigned
-	metering.UseGas(gasToUse)
-
 	key, err := runtime.MemLoad(keyOffset, keyLength)
 	if arwen.WithFault(err, context, runtime.BigIntAPIErrorShouldFailExecution()) {
 		return 0
 	}
 
+	gasToUse := metering.GasSchedule().BigIntAPICost.BigIntStorageLoadUnsigned
+	storage.UseGasForStorageLoad(key, gasToUse)
+
 	bytes := storage.GetStorage(key)
 
 	value := bigInt.GetOne(destination)
@@ -862,9 +898,67 @@ func v1_3_bigIntShl(context unsafe.Pointer, destination, op, bits int32) {
 	useExtraGasForOperations(metering, []*big.Int{dest})
 }
 
+//export v1_3_bigIntPowMod
+func v1_3_bigIntPowMod(context unsafe.Pointer, destination, base, exponent, modulus int32) {
+	bigInt := arwen.GetBigIntContext(context)
+	runtime := arwen.GetRuntimeContext(context)
+	metering := arwen.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().BigIntAPICost.BigIntPowMod
+	metering.UseGas(gasToUse)
+
+	dest := bigInt.GetOne(destination)
+	a, e, m := bigInt.GetThree(base, exponent, modulus)
+	gasToUse = math.MulUint64(metering.GasSchedule().BigIntAPICost.BigIntPowModPerUnit, arwen.PowModGasUnits(e, m))
+	if !useGasBeforeExpensiveOperation(context, gasToUse) {
+		return
+	}
+
+	err := arwen.BigIntPowMod(dest, a, e, m)
+	arwen.WithFault(err, context, runtime.BigIntAPIErrorShouldFailExecution())
+}
+
+//export v1_3_bigIntIsPrime
+func v1_3_bigIntIsPrime(context unsafe.Pointer, op int32) int32 {
+	bigInt := arwen.GetBigIntContext(context)
+	metering := arwen.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().BigIntAPICost.BigIntIsPrime
+	metering.UseGas(gasToUse)
+
+	a := bigInt.GetOne(op)
+	gasToUse = math.MulUint64(metering.GasSchedule().BigIntAPICost.BigIntIsPrimePerUnit, arwen.IsPrimeGasUnits(a))
+	if !useGasBeforeExpensiveOperation(context, gasToUse) {
+		return 0
+	}
+
+	if arwen.BigIntIsPrime(a) {
+		return 1
+	}
+	return 0
+}
+
+//export v1_3_bigIntGcd
+func v1_3_bigIntGcd(context unsafe.Pointer, destination, op1, op2 int32) {
+	bigInt := arwen.GetBigIntContext(context)
+	metering := arwen.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().BigIntAPICost.BigIntGcd
+	metering.UseGas(gasToUse)
+
+	dest, a, b := bigInt.GetThree(destination, op1, op2)
+	gasToUse = math.MulUint64(metering.GasSchedule().BigIntAPICost.BigIntGcdPerUnit, arwen.GcdGasUnits(a, b))
+	if !useGasBeforeExpensiveOperation(context, gasToUse) {
+		return
+	}
+
+	arwen.BigIntGcd(dest, a, b)
+}
+
 //export v1_3_bigIntFinishUnsigned
 func v1_3_bigIntFinishUnsigned(context unsafe.Pointer, reference int32) {
 	bigInt := arwen.GetBigIntContext(context)
+	runtime := arwen.GetRuntimeContext(context)
 	output := arwen.GetOutputContext(context)
 	metering := arwen.GetMeteringContext(context)
 
@@ -873,7 +967,10 @@ func v1_3_bigIntFinishUnsigned(context unsafe.Pointer, reference int32) {
 
 	value := bigInt.GetOne(reference)
 	bigIntBytes := value.Bytes()
-	output.Finish(bigIntBytes)
+	err := output.Finish(bigIntBytes)
+	if arwen.WithFault(err, context, runtime.BigIntAPIErrorShouldFailExecution()) {
+		return
+	}
 
 	gasToUse = math.MulUint64(metering.GasSchedule().BaseOperationCost.PersistPerByte, uint64(len(value.Bytes())))
 	metering.UseGas(gasToUse)
@@ -882,6 +979,7 @@ func v1_3_bigIntFinishUnsigned(context unsafe.Pointer, reference int32) {
 //export v1_3_bigIntFinishSigned
 func v1_3_bigIntFinishSigned(context unsafe.Pointer, reference int32) {
 	bigInt := arwen.GetBigIntContext(context)
+	runtime := arwen.GetRuntimeContext(context)
 	output := arwen.GetOutputContext(context)
 	metering := arwen.GetMeteringContext(context)
 
@@ -890,7 +988,10 @@ func v1_3_bigIntFinishSigned(context unsafe.Pointer, reference int32) {
 
 	value := bigInt.GetOne(reference)
 	bigInt2cBytes := twos.ToBytes(value)
-	output.Finish(bigInt2cBytes)
+	err := output.Finish(bigInt2cBytes)
+	if arwen.WithFault(err, context, runtime.BigIntAPIErrorShouldFailExecution()) {
+		return
+	}
 
 	gasToUse = math.MulUint64(metering.GasSchedule().BaseOperationCost.PersistPerByte, uint64(len(bigInt2cBytes)))
 	metering.UseGas(gasToUse)
@@ -25,6 +25,11 @@ package elrondapi
 // extern int32_t		v1_3_storageLoadLength(void *context, int32_t keyOffset, int32_t keyLength );
 // extern int32_t		v1_3_storageLoad(void *context, int32_t keyOffset, int32_t keyLength , int32_t dataOffset);
 // extern int32_t		v1_3_storageLoadFromAddress(void *context, int32_t addressOffset, int32_t keyOffset, int32_t keyLength , int32_t dataOffset);
+// extern int32_t		v1_3_storageLoadFromContract(void *context, int32_t addressOffset, int32_t keyOffset, int32_t keyLength, int32_t dataOffset);
+// extern int32_t		v1_3_storageLoadLengthFromContract(void *context, int32_t addressOffset, int32_t keyOffset, int32_t keyLength);
+// extern int32_t		v1_3_transientStore(void *context, int32_t keyOffset, int32_t keyLength, int32_t dataOffset, int32_t dataLength);
+// extern int32_t		v1_3_transientLoad(void *context, int32_t keyOffset, int32_t keyLength, int32_t dataOffset);
+// extern int32_t		v1_3_storageKeysWithPrefix(void *context, int32_t prefixOffset, int32_t prefixLength, int32_t startAfterOffset, int32_t startAfterLength, int32_t maxResults, int32_t resultOffset, int32_t resultLength);
 // extern void			v1_3_getCaller(void *context, int32_t resultOffset);
 // extern void			v1_3_checkNoPayment(void *context);
 // extern int32_t		v1_3_callValue(void *context, int32_t resultOffset);
@@ -32,6 +37,10 @@ package elrondapi
 // extern int32_t		v1_3_getESDTTokenName(void *context, int32_t resultOffset);
 // extern long long	v1_3_getESDTTokenNonce(void *context);
 // extern int32_t		v1_3_getESDTTokenType(void *context);
+// extern int32_t		v1_3_getNumESDTTransfers(void *context);
+// extern int32_t		v1_3_getESDTValueByIndex(void *context, int32_t resultOffset, int32_t index);
+// extern int32_t		v1_3_getESDTTokenNameByIndex(void *context, int32_t resultOffset, int32_t index);
+// extern long long	v1_3_getESDTTokenNonceByIndex(void *context, int32_t index);
 // extern long long v1_3_getCurrentESDTNFTNonce(void *context, int32_t addressOffset, int32_t tokenIDOffset, int32_t tokenIDLen);
 // extern int32_t		v1_3_getCallValueTokenName(void *context, int32_t callValueOffset, int32_t tokenNameOffset);
 // extern void			v1_3_writeLog(void *context, int32_t pointer, int32_t length, int32_t topicPtr, int32_t numTopics);
@@ -43,6 +52,11 @@ package elrondapi
 // extern int32_t		v1_3_getESDTNFTNameLength(void *context, int32_t addressOffset, int32_t tokenIDOffset, int32_t tokenIDLen, long long nonce);
 // extern int32_t		v1_3_getESDTNFTAttributeLength(void *context, int32_t addressOffset, int32_t tokenIDOffset, int32_t tokenIDLen, long long nonce);
 // extern int32_t		v1_3_getESDTNFTURILength(void *context, int32_t addressOffset, int32_t tokenIDOffset, int32_t tokenIDLen, long long nonce);
+// extern int32_t		v1_3_transferESDTAndExecute(void *context, int32_t dstOffset, int32_t tokenIDOffset, int32_t tokenIDLen, int32_t valueOffset, long long nonce, long long gasLimit, int32_t functionOffset, int32_t functionLength, int32_t numArguments, int32_t argumentsLengthOffset, int32_t dataOffset);
+// extern int32_t		v1_3_multiTransferESDTNFTExecute(void *context, int32_t dstOffset, int32_t numTokenTransfers, int32_t tokenTransfersArgsLengthOffset, int32_t tokenTransferDataOffset, long long gasLimit, int32_t functionOffset, int32_t functionLength, int32_t numArguments, int32_t argumentsLengthOffset, int32_t dataOffset);
+// extern int32_t		v1_3_getESDTNFTAttributes(void *context, int32_t addressOffset, int32_t tokenIDOffset, int32_t tokenIDLen, long long nonce, int32_t resultHandle);
+// extern int32_t		v1_3_getESDTNFTURIs(void *context, int32_t addressOffset, int32_t tokenIDOffset, int32_t tokenIDLen, long long nonce, int32_t resultHandle);
+// extern int32_t		v1_3_getESDTRoyalties(void *context, int32_t addressOffset, int32_t tokenIDOffset, int32_t tokenIDLen, long long nonce, int32_t resultHandle);
 // extern int32_t		v1_3_getESDTTokenData(void *context, int32_t addressOffset, int32_t tokenIDOffset, int32_t tokenIDLen, long long nonce, int32_t valueOffset, int32_t propertiesOffset, int32_t hashOffset, int32_t nameOffset, int32_t attributesOffset, int32_t creatorOffset, int32_t royaltiesOffset, int32_t urisOffset);
 //
 // extern int32_t		v1_3_executeOnDestContext(void *context, long long gas, int32_t addressOffset, int32_t valueOffset, int32_t functionOffset, int32_t functionLength, int32_t numArguments, int32_t argumentsLengthOffset, int32_t dataOffset);
@@ -52,12 +66,16 @@ package elrondapi
 // extern int32_t		v1_3_executeReadOnly(void *context, long long gas, int32_t addressOffset, int32_t functionOffset, int32_t functionLength, int32_t numArguments, int32_t argumentsLengthOffset, int32_t dataOffset);
 // extern int32_t		v1_3_createContract(void *context, long long gas, int32_t valueOffset, int32_t codeOffset, int32_t codeMetadataOffset, int32_t length, int32_t resultOffset, int32_t numArguments, int32_t argumentsLengthOffset, int32_t dataOffset);
 // extern void			v1_3_upgradeContract(void *context, int32_t dstOffset, long long gas, int32_t valueOffset, int32_t codeOffset, int32_t codeMetadataOffset, int32_t length, int32_t numArguments, int32_t argumentsLengthOffset, int32_t dataOffset);
+// extern int32_t		v1_3_upgradeAndCall(void *context, int32_t dstOffset, long long gas, int32_t valueOffset, int32_t codeOffset, int32_t codeMetadataOffset, int32_t length, int32_t functionOffset, int32_t functionLength, int32_t numArguments, int32_t argumentsLengthOffset, int32_t dataOffset);
 // extern void			v1_3_asyncCall(void *context, int32_t dstOffset, int32_t valueOffset, int32_t dataOffset, int32_t length);
 // extern void			v1_3_createAsyncCall(void *context, int32_t identifierOffset, int32_t identifierLength, int32_t dstOffset, int32_t valueOffset, int32_t dataOffset, int32_t length, int32_t successCallback, int32_t successLength, int32_t errorCallback, int32_t errorLength, long long gas);
 // extern int32_t		v1_3_setAsyncContextCallback(void *context, int32_t identifierOffset, int32_t identifierLength, int32_t callback, int32_t callbackLength);
+// extern int32_t		v1_3_registerDeferredCall(void *context, int32_t functionOffset, int32_t functionLength, long long gas, long long targetRound, int32_t targetEpoch, int32_t numArguments, int32_t argumentsLengthOffset, int32_t dataOffset);
+// extern long long		v1_3_estimateAsyncCallGas(void *context, int32_t dstOffset, int32_t valueOffset, int32_t dataOffset, int32_t length);
 //
 // extern int32_t		v1_3_getNumReturnData(void *context);
 // extern int32_t		v1_3_getReturnDataSize(void *context, int32_t resultID);
+// extern long long	v1_3_getReturnDataBudget(void *context);
 // extern int32_t		v1_3_getReturnData(void *context, int32_t resultID, int32_t dataOffset);
 //
 // extern int32_t		v1_3_setStorageLock(void *context, int32_t keyOffset, int32_t keyLength, long long lockTimestamp);
@@ -70,6 +88,7 @@ package elrondapi
 // extern long long v1_3_getBlockRound(void *context);
 // extern long long v1_3_getBlockEpoch(void *context);
 // extern void			v1_3_getBlockRandomSeed(void *context, int32_t resultOffset);
+// extern int32_t		v1_3_getBlockInfo(void *context, int32_t resultHandle);
 // extern void			v1_3_getStateRootHash(void *context, int32_t resultOffset);
 //
 // extern long long v1_3_getPrevBlockTimestamp(void *context);
@@ -78,6 +97,7 @@ package elrondapi
 // extern long long v1_3_getPrevBlockEpoch(void *context);
 // extern void			v1_3_getPrevBlockRandomSeed(void *context, int32_t resultOffset);
 // extern void			v1_3_getOriginalTxHash(void *context, int32_t resultOffset);
+// extern int32_t		v1_3_getDeterministicRandom(void *context, int32_t seedOffset, int32_t seedLength, int32_t length, int32_t resultOffset);
 import "C"
 
 import (
@@ -93,7 +113,6 @@ import (
 	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/wasmer"
 	logger "github.com/ElrondNetwork/elrond-go-logger"
 	"github.com/ElrondNetwork/elrond-go/core"
-	"github.com/ElrondNetwork/elrond-go/core/parsers"
 	"github.com/ElrondNetwork/elrond-go/core/vmcommon"
 	"github.com/ElrondNetwork/elrond-go/data/esdt"
 )
@@ -175,6 +194,16 @@ func ElrondEIImports() (*wasmer.Imports, error) {
 	// 	return nil, err
 	// }
 
+	imports, err = imports.Append("registerDeferredCall", v1_3_registerDeferredCall, C.v1_3_registerDeferredCall)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("estimateAsyncCallGas", v1_3_estimateAsyncCallGas, C.v1_3_estimateAsyncCallGas)
+	if err != nil {
+		return nil, err
+	}
+
 	imports, err = imports.Append("getArgumentLength", v1_3_getArgumentLength, C.v1_3_getArgumentLength)
 	if err != nil {
 		return nil, err
@@ -215,6 +244,31 @@ func ElrondEIImports() (*wasmer.Imports, error) {
 		return nil, err
 	}
 
+	imports, err = imports.Append("storageLoadFromContract", v1_3_storageLoadFromContract, C.v1_3_storageLoadFromContract)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("storageLoadLengthFromContract", v1_3_storageLoadLengthFromContract, C.v1_3_storageLoadLengthFromContract)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("transientStore", v1_3_transientStore, C.v1_3_transientStore)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("transientLoad", v1_3_transientLoad, C.v1_3_transientLoad)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("storageKeysWithPrefix", v1_3_storageKeysWithPrefix, C.v1_3_storageKeysWithPrefix)
+	if err != nil {
+		return nil, err
+	}
+
 	imports, err = imports.Append("getStorageLock", v1_3_getStorageLock, C.v1_3_getStorageLock)
 	if err != nil {
 		return nil, err
@@ -270,6 +324,26 @@ func ElrondEIImports() (*wasmer.Imports, error) {
 		return nil, err
 	}
 
+	imports, err = imports.Append("getNumESDTTransfers", v1_3_getNumESDTTransfers, C.v1_3_getNumESDTTransfers)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("getESDTValueByIndex", v1_3_getESDTValueByIndex, C.v1_3_getESDTValueByIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("getESDTTokenNameByIndex", v1_3_getESDTTokenNameByIndex, C.v1_3_getESDTTokenNameByIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("getESDTTokenNonceByIndex", v1_3_getESDTTokenNonceByIndex, C.v1_3_getESDTTokenNonceByIndex)
+	if err != nil {
+		return nil, err
+	}
+
 	imports, err = imports.Append("getCurrentESDTNFTNonce", v1_3_getCurrentESDTNFTNonce, C.v1_3_getCurrentESDTNFTNonce)
 	if err != nil {
 		return nil, err
@@ -325,6 +399,11 @@ func ElrondEIImports() (*wasmer.Imports, error) {
 		return nil, err
 	}
 
+	imports, err = imports.Append("getBlockInfo", v1_3_getBlockInfo, C.v1_3_getBlockInfo)
+	if err != nil {
+		return nil, err
+	}
+
 	imports, err = imports.Append("getStateRootHash", v1_3_getStateRootHash, C.v1_3_getStateRootHash)
 	if err != nil {
 		return nil, err
@@ -360,6 +439,11 @@ func ElrondEIImports() (*wasmer.Imports, error) {
 		return nil, err
 	}
 
+	imports, err = imports.Append("getDeterministicRandom", v1_3_getDeterministicRandom, C.v1_3_getDeterministicRandom)
+	if err != nil {
+		return nil, err
+	}
+
 	imports, err = imports.Append("getGasLeft", v1_3_getGasLeft, C.v1_3_getGasLeft)
 	if err != nil {
 		return nil, err
@@ -395,6 +479,11 @@ func ElrondEIImports() (*wasmer.Imports, error) {
 		return nil, err
 	}
 
+	imports, err = imports.Append("upgradeAndCall", v1_3_upgradeAndCall, C.v1_3_upgradeAndCall)
+	if err != nil {
+		return nil, err
+	}
+
 	imports, err = imports.Append("executeReadOnly", v1_3_executeReadOnly, C.v1_3_executeReadOnly)
 	if err != nil {
 		return nil, err
@@ -410,6 +499,11 @@ func ElrondEIImports() (*wasmer.Imports, error) {
 		return nil, err
 	}
 
+	imports, err = imports.Append("getReturnDataBudget", v1_3_getReturnDataBudget, C.v1_3_getReturnDataBudget)
+	if err != nil {
+		return nil, err
+	}
+
 	imports, err = imports.Append("getReturnData", v1_3_getReturnData, C.v1_3_getReturnData)
 	if err != nil {
 		return nil, err
@@ -440,6 +534,31 @@ func ElrondEIImports() (*wasmer.Imports, error) {
 		return nil, err
 	}
 
+	imports, err = imports.Append("transferESDTAndExecute", v1_3_transferESDTAndExecute, C.v1_3_transferESDTAndExecute)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("multiTransferESDTNFTExecute", v1_3_multiTransferESDTNFTExecute, C.v1_3_multiTransferESDTNFTExecute)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("getESDTNFTAttributes", v1_3_getESDTNFTAttributes, C.v1_3_getESDTNFTAttributes)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("getESDTNFTURIs", v1_3_getESDTNFTURIs, C.v1_3_getESDTNFTURIs)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("getESDTRoyalties", v1_3_getESDTRoyalties, C.v1_3_getESDTRoyalties)
+	if err != nil {
+		return nil, err
+	}
+
 	return imports, nil
 }
 
@@ -579,8 +698,20 @@ func v1_3_blockHash(context unsafe.Pointer, nonce int64, resultOffset int32) int
 	return 0
 }
 
+// failIfReadOnly fails the execution with ErrInvalidCallOnReadOnlyMode when
+// it is read-only, i.e. when it is an SC query or it was started through
+// executeReadOnly; it guards the functions which would modify the state, once
+// the read-only enforcement is active
+func failIfReadOnly(host arwen.VMHost) bool {
+	if !host.IsReadOnlyEnforcementEnabled() || !host.Runtime().ReadOnly() {
+		return false
+	}
+
+	return arwen.WithFaultAndHost(host, arwen.ErrInvalidCallOnReadOnlyMode, true)
+}
+
 func isBuiltInCall(data string, host arwen.VMHost) bool {
-	argParser := parsers.NewCallArgsParser()
+	argParser := arwen.NewCallDataParser()
 	functionName, _, _ := argParser.ParseData(data)
 	return host.IsBuiltinFunctionName(functionName)
 }
@@ -702,6 +833,78 @@ func v1_3_getESDTNFTURILength(
 	return int32(len(esdtData.TokenMetaData.URIs[0]))
 }
 
+// setESDTNFTDataBuffer replaces the contents of the managed buffer under the
+// given handle with the given metadata of an NFT or SFT, charging the gas for
+// each of its bytes
+func setESDTNFTDataBuffer(context unsafe.Pointer, data []byte, resultHandle int32) int32 {
+	runtime := arwen.GetRuntimeContext(context)
+	metering := arwen.GetMeteringContext(context)
+
+	gasToUse := math.MulUint64(metering.GasSchedule().ElrondAPICost.ESDTNFTDataPerByte, uint64(len(data)))
+	metering.UseGas(gasToUse)
+
+	err := runtime.ManagedTypes().SetManagedBuffer(resultHandle, data)
+	if arwen.WithFault(err, context, runtime.ElrondAPIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	return 0
+}
+
+//export v1_3_getESDTNFTAttributes
+func v1_3_getESDTNFTAttributes(
+	context unsafe.Pointer,
+	addressOffset int32,
+	tokenIDOffset int32,
+	tokenIDLen int32,
+	nonce int64,
+	resultHandle int32,
+) int32 {
+	runtime := arwen.GetRuntimeContext(context)
+	esdtData, err := getESDTDataFromBlockchainHook(context, addressOffset, tokenIDOffset, tokenIDLen, nonce)
+	if arwen.WithFault(err, context, runtime.ElrondAPIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	return setESDTNFTDataBuffer(context, arwen.NFTAttributes(esdtData), resultHandle)
+}
+
+//export v1_3_getESDTNFTURIs
+func v1_3_getESDTNFTURIs(
+	context unsafe.Pointer,
+	addressOffset int32,
+	tokenIDOffset int32,
+	tokenIDLen int32,
+	nonce int64,
+	resultHandle int32,
+) int32 {
+	runtime := arwen.GetRuntimeContext(context)
+	esdtData, err := getESDTDataFromBlockchainHook(context, addressOffset, tokenIDOffset, tokenIDLen, nonce)
+	if arwen.WithFault(err, context, runtime.ElrondAPIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	return setESDTNFTDataBuffer(context, arwen.NFTURIs(esdtData), resultHandle)
+}
+
+//export v1_3_getESDTRoyalties
+func v1_3_getESDTRoyalties(
+	context unsafe.Pointer,
+	addressOffset int32,
+	tokenIDOffset int32,
+	tokenIDLen int32,
+	nonce int64,
+	resultHandle int32,
+) int32 {
+	runtime := arwen.GetRuntimeContext(context)
+	esdtData, err := getESDTDataFromBlockchainHook(context, addressOffset, tokenIDOffset, tokenIDLen, nonce)
+	if arwen.WithFault(err, context, runtime.ElrondAPIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	return setESDTNFTDataBuffer(context, arwen.EncodeNFTRoyalties(esdtData), resultHandle)
+}
+
 //export v1_3_getESDTTokenData
 func v1_3_getESDTTokenData(
 	context unsafe.Pointer,
@@ -771,6 +974,10 @@ func v1_3_transferValue(context unsafe.Pointer, destOffset int32, valueOffset in
 	metering := host.Metering()
 	output := host.Output()
 
+	if failIfReadOnly(host) {
+		return 1
+	}
+
 	gasToUse := metering.GasSchedule().ElrondAPICost.TransferValue
 	metering.UseGas(gasToUse)
 
@@ -984,6 +1191,10 @@ func TransferValueExecuteWithTypedArgs(
 	metering := host.Metering()
 	output := host.Output()
 
+	if failIfReadOnly(host) {
+		return 1
+	}
+
 	gasToUse := metering.GasSchedule().ElrondAPICost.TransferValue
 	metering.UseGas(gasToUse)
 
@@ -1040,12 +1251,7 @@ func makeCrossShardCallFromInput(vmInput *vmcommon.ContractCallInput) string {
 		return ""
 	}
 
-	txData := vmInput.Function
-	for _, arg := range vmInput.Arguments {
-		txData += "@" + hex.EncodeToString(arg)
-	}
-
-	return txData
+	return string(arwen.EncodeCallData(vmInput.Function, vmInput.Arguments))
 }
 
 //export v1_3_transferESDT
@@ -1121,6 +1327,38 @@ func v1_3_transferESDTNFTExecute(
 		dataOffset)
 }
 
+//export v1_3_transferESDTAndExecute
+func v1_3_transferESDTAndExecute(
+	context unsafe.Pointer,
+	destOffset int32,
+	tokenIDOffset int32,
+	tokenIDLen int32,
+	valueOffset int32,
+	nonce int64,
+	gasLimit int64,
+	functionOffset int32,
+	functionLength int32,
+	numArguments int32,
+	argumentsLengthOffset int32,
+	dataOffset int32,
+) int32 {
+	host := arwen.GetVMHost(context)
+	return transferESDTNFTExecuteFromMemory(
+		host,
+		destOffset,
+		tokenIDOffset,
+		tokenIDLen,
+		valueOffset,
+		nonce,
+		gasLimit,
+		functionOffset,
+		functionLength,
+		numArguments,
+		argumentsLengthOffset,
+		dataOffset,
+		true)
+}
+
 // TransferESDTNFTExecuteWithHost contains only memory reading of arguments
 func TransferESDTNFTExecuteWithHost(
 	host arwen.VMHost,
@@ -1135,6 +1373,37 @@ func TransferESDTNFTExecuteWithHost(
 	numArguments int32,
 	argumentsLengthOffset int32,
 	dataOffset int32,
+) int32 {
+	return transferESDTNFTExecuteFromMemory(
+		host,
+		destOffset,
+		tokenIDOffset,
+		tokenIDLen,
+		valueOffset,
+		nonce,
+		gasLimit,
+		functionOffset,
+		functionLength,
+		numArguments,
+		argumentsLengthOffset,
+		dataOffset,
+		false)
+}
+
+func transferESDTNFTExecuteFromMemory(
+	host arwen.VMHost,
+	destOffset int32,
+	tokenIDOffset int32,
+	tokenIDLen int32,
+	valueOffset int32,
+	nonce int64,
+	gasLimit int64,
+	functionOffset int32,
+	functionLength int32,
+	numArguments int32,
+	argumentsLengthOffset int32,
+	dataOffset int32,
+	syncExecutionRequired bool,
 ) int32 {
 	runtime := host.Runtime()
 	metering := host.Metering()
@@ -1153,7 +1422,7 @@ func TransferESDTNFTExecuteWithHost(
 	gasToUse := math.MulUint64(metering.GasSchedule().BaseOperationCost.DataCopyPerByte, uint64(callArgs.actualLen))
 	metering.UseGas(gasToUse)
 
-	return TransferESDTNFTExecuteWithTypedArgs(
+	return transferESDTNFTExecute(
 		host,
 		callArgs.value,
 		tokenIdentifier,
@@ -1162,6 +1431,7 @@ func TransferESDTNFTExecuteWithHost(
 		gasLimit,
 		callArgs.function,
 		callArgs.args,
+		syncExecutionRequired,
 	)
 }
 
@@ -1176,12 +1446,58 @@ func TransferESDTNFTExecuteWithTypedArgs(
 	function []byte,
 	data [][]byte,
 ) int32 {
+	return transferESDTNFTExecute(host, esdtValue, esdtTokenName, dest, nonce, gasLimit, function, data, false)
+}
+
+// TransferESDTAndExecuteWithTypedArgs transfers the ESDT tokens to a contract
+// in the same shard, then executes the given function of the contract in the
+// same step, with the tokens as its payment; the call fails if the destination
+// is not such a contract, instead of falling back to an asynchronous transfer
+func TransferESDTAndExecuteWithTypedArgs(
+	host arwen.VMHost,
+	esdtValue *big.Int,
+	esdtTokenName []byte,
+	dest []byte,
+	nonce int64,
+	gasLimit int64,
+	function []byte,
+	data [][]byte,
+) int32 {
+	return transferESDTNFTExecute(host, esdtValue, esdtTokenName, dest, nonce, gasLimit, function, data, true)
+}
+
+func transferESDTNFTExecute(
+	host arwen.VMHost,
+	esdtValue *big.Int,
+	esdtTokenName []byte,
+	dest []byte,
+	nonce int64,
+	gasLimit int64,
+	function []byte,
+	data [][]byte,
+	syncExecutionRequired bool,
+) int32 {
 
 	var executeErr error
 
 	runtime := host.Runtime()
 	metering := host.Metering()
 
+	if failIfReadOnly(host) {
+		return 1
+	}
+
+	if syncExecutionRequired {
+		if len(function) == 0 {
+			_ = arwen.WithFaultAndHost(host, arwen.ErrInvalidFunctionName, runtime.ElrondSyncExecAPIErrorShouldFailExecution())
+			return 1
+		}
+		if !host.Blockchain().IsSmartContract(dest) {
+			_ = arwen.WithFaultAndHost(host, arwen.ErrContractNotFound, runtime.ElrondSyncExecAPIErrorShouldFailExecution())
+			return 1
+		}
+	}
+
 	output := host.Output()
 
 	gasToUse := metering.GasSchedule().ElrondAPICost.TransferValue
@@ -1200,7 +1516,7 @@ func TransferESDTNFTExecuteWithTypedArgs(
 			function,
 			data,
 			gasToUse,
-			false,
+			syncExecutionRequired,
 		)
 		if arwen.WithFaultAndHost(host, executeErr, runtime.ElrondSyncExecAPIErrorShouldFailExecution()) {
 			return 1
@@ -1237,24 +1553,166 @@ func TransferESDTNFTExecuteWithTypedArgs(
 	return 0
 }
 
-//export v1_3_createAsyncCall
-func v1_3_createAsyncCall(context unsafe.Pointer,
-	asyncContextIdentifier int32,
-	identifierLength int32,
+//export v1_3_multiTransferESDTNFTExecute
+func v1_3_multiTransferESDTNFTExecute(
+	context unsafe.Pointer,
 	destOffset int32,
-	valueOffset int32,
+	numTokenTransfers int32,
+	tokenTransfersArgsLengthOffset int32,
+	tokenTransferDataOffset int32,
+	gasLimit int64,
+	functionOffset int32,
+	functionLength int32,
+	numArguments int32,
+	argumentsLengthOffset int32,
 	dataOffset int32,
-	length int32,
-	successOffset int32,
-	successLength int32,
-	errorOffset int32,
-	errorLength int32,
-	gas int64,
-) {
+) int32 {
 	host := arwen.GetVMHost(context)
 	runtime := host.Runtime()
+	metering := host.Metering()
 
-	// TODO consume gas
+	if numTokenTransfers <= 0 || numTokenTransfers > arwen.MaxMultiESDTTransfers {
+		_ = arwen.WithFaultAndHost(host, arwen.ErrInvalidMultiESDTTransfer, runtime.ElrondAPIErrorShouldFailExecution())
+		return 1
+	}
+
+	callArgs, err := extractIndirectContractCallArgumentsWithoutValue(
+		host, destOffset, functionOffset, functionLength, numArguments, argumentsLengthOffset, dataOffset)
+	if arwen.WithFaultAndHost(host, err, runtime.ElrondAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	numTransferArgs := int64(numTokenTransfers) * 3
+	transferArgs, actualLen, err := getArgumentsFromMemory(
+		host, int32(numTransferArgs), tokenTransfersArgsLengthOffset, tokenTransferDataOffset)
+	if arwen.WithFaultAndHost(host, err, runtime.ElrondAPIErrorShouldFailExecution()) {
+		return 1
+	}
+	if int64(len(transferArgs)) != numTransferArgs {
+		_ = arwen.WithFaultAndHost(host, arwen.ErrInvalidMultiESDTTransfer, runtime.ElrondAPIErrorShouldFailExecution())
+		return 1
+	}
+
+	gasToUse := math.MulUint64(metering.GasSchedule().BaseOperationCost.DataCopyPerByte, uint64(callArgs.actualLen+actualLen))
+	metering.UseGas(gasToUse)
+
+	transfers := make([]*arwen.ESDTTransfer, numTokenTransfers)
+	for i := range transfers {
+		transfers[i] = &arwen.ESDTTransfer{
+			TokenIdentifier: transferArgs[i*3],
+			Nonce:           big.NewInt(0).SetBytes(transferArgs[i*3+1]).Uint64(),
+			Value:           big.NewInt(0).SetBytes(transferArgs[i*3+2]),
+		}
+	}
+
+	return MultiTransferESDTNFTExecuteWithTypedArgs(
+		host,
+		callArgs.dest,
+		transfers,
+		gasLimit,
+		callArgs.function,
+		callArgs.args,
+	)
+}
+
+// MultiTransferESDTNFTExecuteWithTypedArgs defines the actual logic of
+// transferring several ESDT tokens at once, then executing a function of the
+// destination, if given
+func MultiTransferESDTNFTExecuteWithTypedArgs(
+	host arwen.VMHost,
+	dest []byte,
+	transfers []*arwen.ESDTTransfer,
+	gasLimit int64,
+	function []byte,
+	data [][]byte,
+) int32 {
+	var executeErr error
+
+	runtime := host.Runtime()
+	metering := host.Metering()
+
+	if failIfReadOnly(host) {
+		return 1
+	}
+
+	output := host.Output()
+
+	gasToUse := math.MulUint64(metering.GasSchedule().ElrondAPICost.TransferValue, uint64(len(transfers)))
+	metering.UseGas(gasToUse)
+
+	sender := runtime.GetSCAddress()
+
+	var contractCallInput *vmcommon.ContractCallInput
+	if function != nil {
+		contractCallInput, executeErr = prepareIndirectContractCallInput(
+			host,
+			sender,
+			big.NewInt(0),
+			gasLimit,
+			dest,
+			function,
+			data,
+			gasToUse,
+			false,
+		)
+		if arwen.WithFaultAndHost(host, executeErr, runtime.ElrondSyncExecAPIErrorShouldFailExecution()) {
+			return 1
+		}
+
+		// the ContractCallInput can only describe a single ESDT payment
+		if len(transfers) == 1 {
+			contractCallInput.ESDTValue = transfers[0].Value
+			contractCallInput.ESDTTokenName = transfers[0].TokenIdentifier
+			contractCallInput.ESDTTokenNonce = transfers[0].Nonce
+			if transfers[0].Nonce > 0 {
+				contractCallInput.ESDTTokenType = uint32(core.NonFungible)
+			}
+		}
+	}
+
+	snapshotBeforeTransfer := host.Blockchain().GetSnapshot()
+
+	gasLimitForExec, executeErr := output.TransferMultiESDT(dest, sender, transfers, contractCallInput)
+	if arwen.WithFaultAndHost(host, executeErr, runtime.ElrondAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	if host.AreInSameShard(sender, dest) && contractCallInput != nil && host.Blockchain().IsSmartContract(dest) {
+		contractCallInput.GasProvided = gasLimitForExec
+		logEEI.Trace("multi ESDT post-transfer execution begin")
+		_, _, executeErr = host.ExecuteOnDestContextWithESDTTransfers(contractCallInput, transfers)
+		if executeErr != nil {
+			logEEI.Trace("multi ESDT post-transfer execution failed", "error", executeErr)
+			host.Blockchain().RevertToSnapshot(snapshotBeforeTransfer)
+			return 1
+		}
+	}
+
+	return 0
+}
+
+//export v1_3_createAsyncCall
+func v1_3_createAsyncCall(context unsafe.Pointer,
+	asyncContextIdentifier int32,
+	identifierLength int32,
+	destOffset int32,
+	valueOffset int32,
+	dataOffset int32,
+	length int32,
+	successOffset int32,
+	successLength int32,
+	errorOffset int32,
+	errorLength int32,
+	gas int64,
+) {
+	host := arwen.GetVMHost(context)
+	runtime := host.Runtime()
+
+	if failIfReadOnly(host) {
+		return
+	}
+
+	// TODO consume gas
 
 	acIdentifier, err := runtime.MemLoad(asyncContextIdentifier, identifierLength)
 	if arwen.WithFault(err, context, runtime.ElrondAPIErrorShouldFailExecution()) {
@@ -1286,6 +1744,17 @@ func v1_3_createAsyncCall(context unsafe.Pointer,
 		return
 	}
 
+	// the gas for the callback is held apart from now on, so that the
+	// execution following this call cannot consume it
+	gasLocked := uint64(0)
+	if host.IsCallbackGasEscrowEnabled() {
+		hasCallback := len(successFunc) > 0 || len(errorFunc) > 0
+		gasLocked, err = host.Metering().EscrowGasForCallback(hasCallback)
+		if arwen.WithFault(err, context, runtime.ElrondAPIErrorShouldFailExecution()) {
+			return
+		}
+	}
+
 	err = runtime.AddAsyncContextCall(acIdentifier, &arwen.AsyncGeneratedCall{
 		Destination:     calledSCAddress,
 		Data:            data,
@@ -1293,8 +1762,11 @@ func v1_3_createAsyncCall(context unsafe.Pointer,
 		SuccessCallback: string(successFunc),
 		ErrorCallback:   string(errorFunc),
 		ProvidedGas:     uint64(gas),
+		GasLocked:       gasLocked,
 	})
-	if arwen.WithFault(err, context, runtime.ElrondAPIErrorShouldFailExecution()) {
+	if err != nil {
+		host.Metering().RestoreLockedGas(gasLocked)
+		arwen.WithFault(err, context, runtime.ElrondAPIErrorShouldFailExecution())
 		return
 	}
 }
@@ -1331,6 +1803,102 @@ func v1_3_setAsyncContextCallback(context unsafe.Pointer,
 	return 0
 }
 
+//export v1_3_registerDeferredCall
+func v1_3_registerDeferredCall(
+	context unsafe.Pointer,
+	functionOffset int32,
+	functionLength int32,
+	gasLimit int64,
+	targetRound int64,
+	targetEpoch int32,
+	numArguments int32,
+	argumentsLengthOffset int32,
+	dataOffset int32,
+) int32 {
+	host := arwen.GetVMHost(context)
+	runtime := host.Runtime()
+	metering := host.Metering()
+
+	if failIfReadOnly(host) {
+		return 1
+	}
+
+	gasToUse := metering.GasSchedule().ElrondAPICost.RegisterDeferredCall
+	metering.UseGas(gasToUse)
+
+	if gasLimit < 0 || targetRound < 0 || targetEpoch < 0 {
+		arwen.WithFault(arwen.ErrArgOutOfRange, context, runtime.ElrondAPIErrorShouldFailExecution())
+		return 1
+	}
+
+	function, err := runtime.MemLoad(functionOffset, functionLength)
+	if arwen.WithFault(err, context, runtime.ElrondAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	args, actualLen, err := getArgumentsFromMemory(
+		host,
+		numArguments,
+		argumentsLengthOffset,
+		dataOffset,
+	)
+	if arwen.WithFault(err, context, runtime.ElrondAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	gasToUse = math.MulUint64(metering.GasSchedule().BaseOperationCost.DataCopyPerByte, uint64(actualLen))
+	metering.UseGas(gasToUse)
+
+	err = host.RegisterDeferredCall(&arwen.DeferredCall{
+		Function:    string(function),
+		Arguments:   args,
+		GasLimit:    uint64(gasLimit),
+		TargetRound: uint64(targetRound),
+		TargetEpoch: uint32(targetEpoch),
+	})
+	if arwen.WithFault(err, context, runtime.ElrondAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	return 0
+}
+
+//export v1_3_estimateAsyncCallGas
+func v1_3_estimateAsyncCallGas(context unsafe.Pointer, destOffset int32, valueOffset int32, dataOffset int32, length int32) int64 {
+	host := arwen.GetVMHost(context)
+	runtime := host.Runtime()
+	metering := host.Metering()
+
+	gasToUse := metering.GasSchedule().ElrondAPICost.EstimateAsyncCallGas
+	metering.UseGas(gasToUse)
+
+	destination, err := runtime.MemLoad(destOffset, arwen.AddressLen)
+	if arwen.WithFault(err, context, runtime.ElrondAPIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	_, err = runtime.MemLoad(valueOffset, arwen.BalanceLen)
+	if arwen.WithFault(err, context, runtime.ElrondAPIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	gasToUse = math.MulUint64(metering.GasSchedule().BaseOperationCost.DataCopyPerByte, uint64(length))
+	metering.UseGas(gasToUse)
+
+	data, err := runtime.MemLoad(dataOffset, length)
+	if arwen.WithFault(err, context, runtime.ElrondAPIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	destinationCodeSize, err := host.Blockchain().GetCodeSize(destination)
+	if err != nil {
+		destinationCodeSize = 0
+	}
+
+	estimate := metering.EstimateAsyncCallGas(runtime.GetSCCodeSize(), uint64(destinationCodeSize), uint64(len(data)))
+	return int64(estimate.Total)
+}
+
 //export v1_3_upgradeContract
 func v1_3_upgradeContract(
 	context unsafe.Pointer,
@@ -1348,6 +1916,10 @@ func v1_3_upgradeContract(
 	runtime := host.Runtime()
 	metering := host.Metering()
 
+	if failIfReadOnly(host) {
+		return
+	}
+
 	gasToUse := metering.GasSchedule().ElrondAPICost.CreateContract
 	metering.UseGas(gasToUse)
 
@@ -1421,12 +1993,181 @@ func v1_3_upgradeContract(
 	runtime.SetRuntimeBreakpointValue(arwen.BreakpointAsyncCall)
 }
 
+//export v1_3_upgradeAndCall
+func v1_3_upgradeAndCall(
+	context unsafe.Pointer,
+	destOffset int32,
+	gasLimit int64,
+	valueOffset int32,
+	codeOffset int32,
+	codeMetadataOffset int32,
+	length int32,
+	functionOffset int32,
+	functionLength int32,
+	numArguments int32,
+	argumentsLengthOffset int32,
+	dataOffset int32,
+) int32 {
+	host := arwen.GetVMHost(context)
+	return UpgradeAndCallWithHost(
+		host,
+		destOffset,
+		gasLimit,
+		valueOffset,
+		codeOffset,
+		codeMetadataOffset,
+		length,
+		functionOffset,
+		functionLength,
+		numArguments,
+		argumentsLengthOffset,
+		dataOffset,
+	)
+}
+
+// UpgradeAndCallWithHost - upgradeAndCall with host instead of pointer context
+func UpgradeAndCallWithHost(
+	host arwen.VMHost,
+	destOffset int32,
+	gasLimit int64,
+	valueOffset int32,
+	codeOffset int32,
+	codeMetadataOffset int32,
+	length int32,
+	functionOffset int32,
+	functionLength int32,
+	numArguments int32,
+	argumentsLengthOffset int32,
+	dataOffset int32,
+) int32 {
+	runtime := host.Runtime()
+	metering := host.Metering()
+
+	callArgs, err := extractIndirectContractCallArgumentsWithValue(
+		host, destOffset, valueOffset, functionOffset, functionLength, numArguments, argumentsLengthOffset, dataOffset)
+	if arwen.WithFaultAndHost(host, err, runtime.ElrondAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	gasToUse := math.MulUint64(metering.GasSchedule().BaseOperationCost.DataCopyPerByte, uint64(length))
+	metering.UseGas(gasToUse)
+
+	code, err := runtime.MemLoad(codeOffset, length)
+	if arwen.WithFaultAndHost(host, err, runtime.ElrondAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	codeMetadata, err := runtime.MemLoad(codeMetadataOffset, arwen.CodeMetadataLen)
+	if arwen.WithFaultAndHost(host, err, runtime.ElrondAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	return UpgradeAndCallWithTypedArgs(
+		host,
+		gasLimit,
+		callArgs.dest,
+		code,
+		codeMetadata,
+		callArgs.value,
+		callArgs.function,
+		callArgs.args,
+	)
+}
+
+// UpgradeAndCallWithTypedArgs - upgradeAndCall with args already read from
+// memory; the destination contract is first upgraded synchronously to the
+// given code, its init function being called without arguments, then the
+// given function of the new code is called with the given arguments and value.
+// The gas limit covers both steps: the call is given the gas not consumed by
+// the upgrade.
+func UpgradeAndCallWithTypedArgs(
+	host arwen.VMHost,
+	gasLimit int64,
+	dest []byte,
+	code []byte,
+	codeMetadata []byte,
+	value *big.Int,
+	function []byte,
+	args [][]byte,
+) int32 {
+	runtime := host.Runtime()
+	metering := host.Metering()
+
+	if failIfReadOnly(host) {
+		return 1
+	}
+
+	gasSchedule := metering.GasSchedule()
+	gasToUse := math.AddUint64(gasSchedule.ElrondAPICost.CreateContract, gasSchedule.ElrondAPICost.ExecuteOnDestContext)
+	metering.UseGas(gasToUse)
+
+	// refused before the upgrade, which would otherwise stay in place
+	if isBuiltInCall(string(function), host) {
+		_ = arwen.WithFaultAndHost(host, arwen.ErrBuiltinCallAfterUpgradeDisallowed, runtime.ElrondAPIErrorShouldFailExecution())
+		return 1
+	}
+
+	sender := runtime.GetSCAddress()
+	upgradeInput, err := prepareIndirectContractCallInput(
+		host,
+		sender,
+		big.NewInt(0),
+		gasLimit,
+		dest,
+		[]byte(arwen.UpgradeFunctionName),
+		[][]byte{code, codeMetadata},
+		gasToUse,
+		true,
+	)
+	if arwen.WithFaultAndHost(host, err, runtime.ElrondAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	gasLeftBeforeUpgrade := metering.GasLeft()
+	_, _, err = host.ExecuteOnDestContext(upgradeInput)
+	if arwen.WithFaultAndHost(host, err, runtime.ElrondAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	gasUsedByUpgrade := gasLeftBeforeUpgrade - metering.GasLeft()
+	if gasUsedByUpgrade >= upgradeInput.GasProvided {
+		_ = arwen.WithFaultAndHost(host, arwen.ErrNotEnoughGas, runtime.ElrondAPIErrorShouldFailExecution())
+		return 1
+	}
+
+	callInput, err := prepareIndirectContractCallInput(
+		host,
+		sender,
+		value,
+		int64(upgradeInput.GasProvided-gasUsedByUpgrade),
+		dest,
+		function,
+		args,
+		gasToUse,
+		true,
+	)
+	if arwen.WithFaultAndHost(host, err, runtime.ElrondAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	_, _, err = host.ExecuteOnDestContext(callInput)
+	if arwen.WithFaultAndHost(host, err, runtime.ElrondAPIErrorShouldFailExecution()) {
+		return 1
+	}
+
+	return 0
+}
+
 //export v1_3_asyncCall
 func v1_3_asyncCall(context unsafe.Pointer, destOffset int32, valueOffset int32, dataOffset int32, length int32) {
 	host := arwen.GetVMHost(context)
 	runtime := host.Runtime()
 	metering := host.Metering()
 
+	if failIfReadOnly(host) {
+		return
+	}
+
 	gasSchedule := metering.GasSchedule()
 	gasToUse := gasSchedule.ElrondAPICost.AsyncCallStep
 	metering.UseGas(gasToUse)
@@ -1455,85 +2196,216 @@ func v1_3_asyncCall(context unsafe.Pointer, destOffset int32, valueOffset int32,
 		return
 	}
 	if arwen.WithFault(err, context, runtime.ElrondAPIErrorShouldFailExecution()) {
-		return
+		return
+	}
+}
+
+//export v1_3_getArgumentLength
+func v1_3_getArgumentLength(context unsafe.Pointer, id int32) int32 {
+	runtime := arwen.GetRuntimeContext(context)
+	metering := arwen.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().ElrondAPICost.GetArgument
+	metering.UseGas(gasToUse)
+
+	args := runtime.Arguments()
+	if id < 0 || int32(len(args)) <= id {
+		return -1
+	}
+
+	return int32(len(args[id]))
+}
+
+//export v1_3_getArgument
+func v1_3_getArgument(context unsafe.Pointer, id int32, argOffset int32) int32 {
+	runtime := arwen.GetRuntimeContext(context)
+	metering := arwen.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().ElrondAPICost.GetArgument
+	metering.UseGas(gasToUse)
+
+	args := runtime.Arguments()
+	if id < 0 || int32(len(args)) <= id {
+		return -1
+	}
+
+	err := runtime.MemStore(argOffset, args[id])
+	if arwen.WithFault(err, context, runtime.ElrondAPIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	return int32(len(args[id]))
+}
+
+//export v1_3_getFunction
+func v1_3_getFunction(context unsafe.Pointer, functionOffset int32) int32 {
+	runtime := arwen.GetRuntimeContext(context)
+	metering := arwen.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().ElrondAPICost.GetFunction
+	metering.UseGas(gasToUse)
+
+	function := runtime.Function()
+	err := runtime.MemStore(functionOffset, []byte(function))
+	if arwen.WithFault(err, context, runtime.ElrondAPIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	return int32(len(function))
+}
+
+//export v1_3_getNumArguments
+func v1_3_getNumArguments(context unsafe.Pointer) int32 {
+	runtime := arwen.GetRuntimeContext(context)
+	metering := arwen.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().ElrondAPICost.GetNumArguments
+	metering.UseGas(gasToUse)
+
+	args := runtime.Arguments()
+	return int32(len(args))
+}
+
+//export v1_3_storageStore
+func v1_3_storageStore(context unsafe.Pointer, keyOffset int32, keyLength int32, dataOffset int32, dataLength int32) int32 {
+	runtime := arwen.GetRuntimeContext(context)
+	storage := arwen.GetStorageContext(context)
+	metering := arwen.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().ElrondAPICost.StorageStore
+	metering.UseGas(gasToUse)
+
+	key, err := runtime.MemLoad(keyOffset, keyLength)
+	if arwen.WithFault(err, context, runtime.ElrondAPIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	data, err := runtime.MemLoad(dataOffset, dataLength)
+	if arwen.WithFault(err, context, runtime.ElrondAPIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	storageStatus, err := storage.SetStorage(key, data)
+	if arwen.WithFault(err, context, runtime.ElrondAPIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	return int32(storageStatus)
+}
+
+//export v1_3_storageLoadLength
+func v1_3_storageLoadLength(context unsafe.Pointer, keyOffset int32, keyLength int32) int32 {
+	runtime := arwen.GetRuntimeContext(context)
+	storage := arwen.GetStorageContext(context)
+	metering := arwen.GetMeteringContext(context)
+
+	key, err := runtime.MemLoad(keyOffset, keyLength)
+	if arwen.WithFault(err, context, runtime.ElrondAPIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	gasToUse := metering.GasSchedule().ElrondAPICost.StorageLoad
+	storage.UseGasForStorageLoad(key, gasToUse)
+
+	data := storage.GetStorageUnmetered(key)
+
+	return int32(len(data))
+}
+
+//export v1_3_storageLoadFromAddress
+func v1_3_storageLoadFromAddress(context unsafe.Pointer, addressOffset int32, keyOffset int32, keyLength int32, dataOffset int32) int32 {
+	runtime := arwen.GetRuntimeContext(context)
+	storage := arwen.GetStorageContext(context)
+	metering := arwen.GetMeteringContext(context)
+
+	key, err := runtime.MemLoad(keyOffset, keyLength)
+	if arwen.WithFault(err, context, runtime.ElrondAPIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	address, err := runtime.MemLoad(addressOffset, arwen.AddressLen)
+	if arwen.WithFault(err, context, runtime.ElrondAPIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	gasToUse := metering.GasSchedule().ElrondAPICost.StorageLoad
+	storage.UseGasForStorageLoadFromAddress(address, key, gasToUse)
+
+	data := storage.GetStorageFromAddress(address, key)
+
+	err = runtime.MemStore(dataOffset, data)
+	if arwen.WithFault(err, context, runtime.ElrondAPIErrorShouldFailExecution()) {
+		return -1
 	}
+
+	return int32(len(data))
 }
 
-//export v1_3_getArgumentLength
-func v1_3_getArgumentLength(context unsafe.Pointer, id int32) int32 {
+//export v1_3_storageLoadFromContract
+func v1_3_storageLoadFromContract(context unsafe.Pointer, addressOffset int32, keyOffset int32, keyLength int32, dataOffset int32) int32 {
 	runtime := arwen.GetRuntimeContext(context)
+	storage := arwen.GetStorageContext(context)
 	metering := arwen.GetMeteringContext(context)
 
-	gasToUse := metering.GasSchedule().ElrondAPICost.GetArgument
-	metering.UseGas(gasToUse)
-
-	args := runtime.Arguments()
-	if id < 0 || int32(len(args)) <= id {
+	address, err := runtime.MemLoad(addressOffset, arwen.AddressLen)
+	if arwen.WithFault(err, context, runtime.ElrondAPIErrorShouldFailExecution()) {
 		return -1
 	}
 
-	return int32(len(args[id]))
-}
-
-//export v1_3_getArgument
-func v1_3_getArgument(context unsafe.Pointer, id int32, argOffset int32) int32 {
-	runtime := arwen.GetRuntimeContext(context)
-	metering := arwen.GetMeteringContext(context)
+	key, err := runtime.MemLoad(keyOffset, keyLength)
+	if arwen.WithFault(err, context, runtime.ElrondAPIErrorShouldFailExecution()) {
+		return -1
+	}
 
-	gasToUse := metering.GasSchedule().ElrondAPICost.GetArgument
-	metering.UseGas(gasToUse)
+	gasToUse := metering.GasSchedule().ElrondAPICost.StorageLoadFromContract
+	storage.UseGasForStorageLoadFromAddress(address, key, gasToUse)
 
-	args := runtime.Arguments()
-	if id < 0 || int32(len(args)) <= id {
+	data, err := storage.GetStorageFromContract(address, key)
+	if arwen.WithFault(err, context, runtime.ElrondAPIErrorShouldFailExecution()) {
 		return -1
 	}
 
-	err := runtime.MemStore(argOffset, args[id])
+	err = runtime.MemStore(dataOffset, data)
 	if arwen.WithFault(err, context, runtime.ElrondAPIErrorShouldFailExecution()) {
 		return -1
 	}
 
-	return int32(len(args[id]))
+	return int32(len(data))
 }
 
-//export v1_3_getFunction
-func v1_3_getFunction(context unsafe.Pointer, functionOffset int32) int32 {
+//export v1_3_storageLoadLengthFromContract
+func v1_3_storageLoadLengthFromContract(context unsafe.Pointer, addressOffset int32, keyOffset int32, keyLength int32) int32 {
 	runtime := arwen.GetRuntimeContext(context)
+	storage := arwen.GetStorageContext(context)
 	metering := arwen.GetMeteringContext(context)
 
-	gasToUse := metering.GasSchedule().ElrondAPICost.GetFunction
-	metering.UseGas(gasToUse)
-
-	function := runtime.Function()
-	err := runtime.MemStore(functionOffset, []byte(function))
+	address, err := runtime.MemLoad(addressOffset, arwen.AddressLen)
 	if arwen.WithFault(err, context, runtime.ElrondAPIErrorShouldFailExecution()) {
 		return -1
 	}
 
-	return int32(len(function))
-}
+	key, err := runtime.MemLoad(keyOffset, keyLength)
+	if arwen.WithFault(err, context, runtime.ElrondAPIErrorShouldFailExecution()) {
+		return -1
+	}
 
-//export v1_3_getNumArguments
-func v1_3_getNumArguments(context unsafe.Pointer) int32 {
-	runtime := arwen.GetRuntimeContext(context)
-	metering := arwen.GetMeteringContext(context)
+	gasToUse := metering.GasSchedule().ElrondAPICost.StorageLoadFromContract
+	storage.UseGasForStorageLoadFromAddress(address, key, gasToUse)
 
-	gasToUse := metering.GasSchedule().ElrondAPICost.GetNumArguments
-	metering.UseGas(gasToUse)
+	data, err := storage.GetStorageFromContract(address, key)
+	if arwen.WithFault(err, context, runtime.ElrondAPIErrorShouldFailExecution()) {
+		return -1
+	}
 
-	args := runtime.Arguments()
-	return int32(len(args))
+	return int32(len(data))
 }
 
-//export v1_3_storageStore
-func v1_3_storageStore(context unsafe.Pointer, keyOffset int32, keyLength int32, dataOffset int32, dataLength int32) int32 {
+//export v1_3_transientStore
+func v1_3_transientStore(context unsafe.Pointer, keyOffset int32, keyLength int32, dataOffset int32, dataLength int32) int32 {
 	runtime := arwen.GetRuntimeContext(context)
 	storage := arwen.GetStorageContext(context)
 	metering := arwen.GetMeteringContext(context)
 
-	gasToUse := metering.GasSchedule().ElrondAPICost.StorageStore
-	metering.UseGas(gasToUse)
-
 	key, err := runtime.MemLoad(keyOffset, keyLength)
 	if arwen.WithFault(err, context, runtime.ElrondAPIErrorShouldFailExecution()) {
 		return -1
@@ -1544,53 +2416,58 @@ func v1_3_storageStore(context unsafe.Pointer, keyOffset int32, keyLength int32,
 		return -1
 	}
 
-	storageStatus, err := storage.SetStorage(key, data)
+	gasToUse := metering.GasSchedule().ElrondAPICost.TransientStore
+	gasToUse = math.AddUint64(gasToUse, math.MulUint64(metering.GasSchedule().BaseOperationCost.DataCopyPerByte, uint64(len(data))))
+	metering.UseGas(gasToUse)
+
+	err = storage.SetTransientStorage(key, data)
 	if arwen.WithFault(err, context, runtime.ElrondAPIErrorShouldFailExecution()) {
 		return -1
 	}
 
-	return int32(storageStatus)
+	return 0
 }
 
-//export v1_3_storageLoadLength
-func v1_3_storageLoadLength(context unsafe.Pointer, keyOffset int32, keyLength int32) int32 {
+//export v1_3_transientLoad
+func v1_3_transientLoad(context unsafe.Pointer, keyOffset int32, keyLength int32, dataOffset int32) int32 {
 	runtime := arwen.GetRuntimeContext(context)
 	storage := arwen.GetStorageContext(context)
 	metering := arwen.GetMeteringContext(context)
 
-	gasToUse := metering.GasSchedule().ElrondAPICost.StorageLoad
-	metering.UseGas(gasToUse)
-
 	key, err := runtime.MemLoad(keyOffset, keyLength)
 	if arwen.WithFault(err, context, runtime.ElrondAPIErrorShouldFailExecution()) {
 		return -1
 	}
 
-	data := storage.GetStorageUnmetered(key)
+	data := storage.GetTransientStorage(key)
+
+	gasToUse := metering.GasSchedule().ElrondAPICost.TransientLoad
+	gasToUse = math.AddUint64(gasToUse, math.MulUint64(metering.GasSchedule().BaseOperationCost.DataCopyPerByte, uint64(len(data))))
+	metering.UseGas(gasToUse)
+
+	err = runtime.MemStore(dataOffset, data)
+	if arwen.WithFault(err, context, runtime.ElrondAPIErrorShouldFailExecution()) {
+		return -1
+	}
 
 	return int32(len(data))
 }
 
-//export v1_3_storageLoadFromAddress
-func v1_3_storageLoadFromAddress(context unsafe.Pointer, addressOffset int32, keyOffset int32, keyLength int32, dataOffset int32) int32 {
+//export v1_3_storageLoad
+func v1_3_storageLoad(context unsafe.Pointer, keyOffset int32, keyLength int32, dataOffset int32) int32 {
 	runtime := arwen.GetRuntimeContext(context)
 	storage := arwen.GetStorageContext(context)
 	metering := arwen.GetMeteringContext(context)
 
-	gasToUse := metering.GasSchedule().ElrondAPICost.StorageLoad
-	metering.UseGas(gasToUse)
-
 	key, err := runtime.MemLoad(keyOffset, keyLength)
 	if arwen.WithFault(err, context, runtime.ElrondAPIErrorShouldFailExecution()) {
 		return -1
 	}
 
-	address, err := runtime.MemLoad(addressOffset, arwen.AddressLen)
-	if arwen.WithFault(err, context, runtime.ElrondAPIErrorShouldFailExecution()) {
-		return -1
-	}
+	gasToUse := metering.GasSchedule().ElrondAPICost.StorageLoad
+	storage.UseGasForStorageLoad(key, gasToUse)
 
-	data := storage.GetStorageFromAddress(address, key)
+	data := storage.GetStorage(key)
 
 	err = runtime.MemStore(dataOffset, data)
 	if arwen.WithFault(err, context, runtime.ElrondAPIErrorShouldFailExecution()) {
@@ -1600,28 +2477,67 @@ func v1_3_storageLoadFromAddress(context unsafe.Pointer, addressOffset int32, ke
 	return int32(len(data))
 }
 
-//export v1_3_storageLoad
-func v1_3_storageLoad(context unsafe.Pointer, keyOffset int32, keyLength int32, dataOffset int32) int32 {
+//export v1_3_storageKeysWithPrefix
+func v1_3_storageKeysWithPrefix(
+	context unsafe.Pointer,
+	prefixOffset int32,
+	prefixLength int32,
+	startAfterOffset int32,
+	startAfterLength int32,
+	maxResults int32,
+	resultOffset int32,
+	resultLength int32,
+) int32 {
 	runtime := arwen.GetRuntimeContext(context)
 	storage := arwen.GetStorageContext(context)
 	metering := arwen.GetMeteringContext(context)
 
-	gasToUse := metering.GasSchedule().ElrondAPICost.StorageLoad
+	gasToUse := metering.GasSchedule().ElrondAPICost.StorageKeysWithPrefix
 	metering.UseGas(gasToUse)
 
-	key, err := runtime.MemLoad(keyOffset, keyLength)
+	if maxResults < 0 || resultLength < 0 {
+		arwen.WithFault(arwen.ErrNegativeLength, context, runtime.ElrondAPIErrorShouldFailExecution())
+		return -1
+	}
+
+	prefix, err := runtime.MemLoad(prefixOffset, prefixLength)
 	if arwen.WithFault(err, context, runtime.ElrondAPIErrorShouldFailExecution()) {
 		return -1
 	}
 
-	data := storage.GetStorage(key)
+	startAfter, err := runtime.MemLoad(startAfterOffset, startAfterLength)
+	if arwen.WithFault(err, context, runtime.ElrondAPIErrorShouldFailExecution()) {
+		return -1
+	}
 
-	err = runtime.MemStore(dataOffset, data)
+	keys, err := storage.GetKeysWithPrefix(prefix, startAfter, uint64(maxResults))
 	if arwen.WithFault(err, context, runtime.ElrondAPIErrorShouldFailExecution()) {
 		return -1
 	}
 
-	return int32(len(data))
+	// each key is preceded by its length, as 4 bytes big endian; only the keys
+	// which fit in resultLength bytes are written, the last of them being the
+	// startAfter of the next page
+	result := make([]byte, 0)
+	numKeys := int32(0)
+	for _, key := range keys {
+		if len(result)+4+len(key) > int(resultLength) {
+			break
+		}
+
+		keyLength := make([]byte, 4)
+		binary.BigEndian.PutUint32(keyLength, uint32(len(key)))
+		result = append(result, keyLength...)
+		result = append(result, key...)
+		numKeys++
+	}
+
+	err = runtime.MemStore(resultOffset, result)
+	if arwen.WithFault(err, context, runtime.ElrondAPIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	return numKeys
 }
 
 //export v1_3_setStorageLock
@@ -1797,6 +2713,89 @@ func v1_3_getESDTTokenNonce(context unsafe.Pointer) int64 {
 	return int64(runtime.GetVMInput().ESDTTokenNonce)
 }
 
+//export v1_3_getNumESDTTransfers
+func v1_3_getNumESDTTransfers(context unsafe.Pointer) int32 {
+	runtime := arwen.GetRuntimeContext(context)
+	metering := arwen.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().ElrondAPICost.GetCallValue
+	metering.UseGas(gasToUse)
+
+	return int32(len(runtime.GetESDTTransfers()))
+}
+
+//export v1_3_getESDTValueByIndex
+func v1_3_getESDTValueByIndex(context unsafe.Pointer, resultOffset int32, index int32) int32 {
+	runtime := arwen.GetRuntimeContext(context)
+	metering := arwen.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().ElrondAPICost.GetCallValue
+	metering.UseGas(gasToUse)
+
+	transfer, err := getESDTTransferByIndex(runtime, index)
+	if arwen.WithFault(err, context, runtime.ElrondAPIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	var value []byte
+	if transfer.Value != nil {
+		value = arwen.PadBytesLeft(transfer.Value.Bytes(), arwen.BalanceLen)
+	}
+
+	err = runtime.MemStore(resultOffset, value)
+	if arwen.WithFault(err, context, runtime.ElrondAPIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	return int32(len(value))
+}
+
+//export v1_3_getESDTTokenNameByIndex
+func v1_3_getESDTTokenNameByIndex(context unsafe.Pointer, resultOffset int32, index int32) int32 {
+	runtime := arwen.GetRuntimeContext(context)
+	metering := arwen.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().ElrondAPICost.GetCallValue
+	metering.UseGas(gasToUse)
+
+	transfer, err := getESDTTransferByIndex(runtime, index)
+	if arwen.WithFault(err, context, runtime.ElrondAPIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	err = runtime.MemStore(resultOffset, transfer.TokenIdentifier)
+	if arwen.WithFault(err, context, runtime.ElrondAPIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	return int32(len(transfer.TokenIdentifier))
+}
+
+//export v1_3_getESDTTokenNonceByIndex
+func v1_3_getESDTTokenNonceByIndex(context unsafe.Pointer, index int32) int64 {
+	runtime := arwen.GetRuntimeContext(context)
+	metering := arwen.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().ElrondAPICost.GetCallValue
+	metering.UseGas(gasToUse)
+
+	transfer, err := getESDTTransferByIndex(runtime, index)
+	if arwen.WithFault(err, context, runtime.ElrondAPIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	return int64(transfer.Nonce)
+}
+
+func getESDTTransferByIndex(runtime arwen.RuntimeContext, index int32) (*arwen.ESDTTransfer, error) {
+	transfers := runtime.GetESDTTransfers()
+	if index < 0 || int(index) >= len(transfers) {
+		return nil, arwen.ErrInvalidTokenIndex
+	}
+
+	return transfers[index], nil
+}
+
 //export v1_3_getCurrentESDTNFTNonce
 func v1_3_getCurrentESDTNFTNonce(context unsafe.Pointer, addressOffset int32, tokenIDOffset int32, tokenIDLen int32) int64 {
 	runtime := arwen.GetRuntimeContext(context)
@@ -1867,6 +2866,7 @@ func v1_3_getCallValueTokenName(context unsafe.Pointer, callValueOffset int32, t
 //export v1_3_writeLog
 func v1_3_writeLog(context unsafe.Pointer, dataPointer int32, dataLength int32, topicPtr int32, numTopics int32) {
 	// note: deprecated
+	host := arwen.GetVMHost(context)
 	runtime := arwen.GetRuntimeContext(context)
 	output := arwen.GetOutputContext(context)
 	metering := arwen.GetMeteringContext(context)
@@ -1874,6 +2874,7 @@ func v1_3_writeLog(context unsafe.Pointer, dataPointer int32, dataLength int32,
 	gasToUse := metering.GasSchedule().ElrondAPICost.Log
 	gas := math.MulUint64(metering.GasSchedule().BaseOperationCost.PersistPerByte, uint64(numTopics*arwen.HashLen+dataLength))
 	gasToUse = math.AddUint64(gasToUse, gas)
+	gasToUse = math.AddUint64(gasToUse, computeGasForLogEntry(host, numTopics, numTopics*arwen.HashLen, dataLength))
 	metering.UseGas(gasToUse)
 
 	log, err := runtime.MemLoad(dataPointer, dataLength)
@@ -1893,7 +2894,10 @@ func v1_3_writeLog(context unsafe.Pointer, dataPointer int32, dataLength int32,
 		}
 	}
 
-	output.WriteLog(runtime.GetSCAddress(), topics, log)
+	err = output.WriteEventLog(runtime.GetSCAddress(), arwen.NewEventLogFromTopics(topics, log))
+	if arwen.WithFault(err, context, runtime.ElrondAPIErrorShouldFailExecution()) {
+		return
+	}
 }
 
 //export v1_3_writeEventLog
@@ -1930,9 +2934,28 @@ func v1_3_writeEventLog(
 		metering.GasSchedule().BaseOperationCost.DataCopyPerByte,
 		uint64(topicDataTotalLen+dataLength))
 	gasToUse = math.AddUint64(gasToUse, gasForData)
+	gasToUse = math.AddUint64(gasToUse, computeGasForLogEntry(host, numTopics, topicDataTotalLen, dataLength))
 	metering.UseGas(gasToUse)
 
-	output.WriteLog(runtime.GetSCAddress(), topics, data)
+	err = output.WriteEventLog(runtime.GetSCAddress(), arwen.NewEventLogFromTopics(topics, data))
+	if arwen.WithFault(err, context, runtime.ElrondAPIErrorShouldFailExecution()) {
+		return
+	}
+}
+
+// computeGasForLogEntry returns the gas charged for the topics, for the bytes
+// of the topics and for the data of a log entry, in addition to the cost of
+// copying them from memory; nothing is charged before LogEntryGasEnableEpoch
+func computeGasForLogEntry(host arwen.VMHost, numTopics int32, topicDataLength int32, dataLength int32) uint64 {
+	if !host.IsLogEntryGasEnabled() {
+		return 0
+	}
+
+	metering := host.Metering()
+	gasForTopics := math.MulUint64(metering.GasSchedule().ElrondAPICost.LogPerTopic, uint64(numTopics))
+	gasForTopicData := math.MulUint64(metering.GasSchedule().ElrondAPICost.LogPerTopicByte, uint64(topicDataLength))
+	gasForData := math.MulUint64(metering.GasSchedule().ElrondAPICost.LogPerDataByte, uint64(dataLength))
+	return math.AddUint64(math.AddUint64(gasForTopics, gasForTopicData), gasForData)
 }
 
 //export v1_3_getBlockTimestamp
@@ -1993,6 +3016,24 @@ func v1_3_getBlockRandomSeed(context unsafe.Pointer, pointer int32) {
 	arwen.WithFault(err, context, runtime.ElrondAPIErrorShouldFailExecution())
 }
 
+//export v1_3_getBlockInfo
+func v1_3_getBlockInfo(context unsafe.Pointer, resultHandle int32) int32 {
+	runtime := arwen.GetRuntimeContext(context)
+	blockchain := arwen.GetBlockchainContext(context)
+	metering := arwen.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().ElrondAPICost.GetBlockInfo
+	metering.UseGas(gasToUse)
+
+	blockInfo := blockchain.CurrentBlockInfo()
+	err := runtime.ManagedTypes().SetManagedBuffer(resultHandle, blockInfo.Encode())
+	if arwen.WithFault(err, context, runtime.ElrondAPIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	return 0
+}
+
 //export v1_3_getStateRootHash
 func v1_3_getStateRootHash(context unsafe.Pointer, pointer int32) {
 	runtime := arwen.GetRuntimeContext(context)
@@ -2081,7 +3122,10 @@ func v1_3_returnData(context unsafe.Pointer, pointer int32, length int32) {
 		return
 	}
 
-	output.Finish(data)
+	err = output.Finish(data)
+	if arwen.WithFault(err, context, runtime.ElrondAPIErrorShouldFailExecution()) {
+		return
+	}
 }
 
 //export v1_3_executeOnSameContext
@@ -2155,6 +3199,11 @@ func ExecuteOnSameContextWithTypedArgs(
 	gasToUse := metering.GasSchedule().ElrondAPICost.ExecuteOnSameContext
 	metering.UseGas(gasToUse)
 
+	// a read-only execution may call other contracts, but not transfer value to them
+	if value.Sign() > 0 && failIfReadOnly(host) {
+		return 1
+	}
+
 	sender := runtime.GetSCAddress()
 	contractCallInput, err := prepareIndirectContractCallInput(
 		host,
@@ -2254,6 +3303,11 @@ func ExecuteOnDestContextWithTypedArgs(
 	gasToUse := metering.GasSchedule().ElrondAPICost.ExecuteOnDestContext
 	metering.UseGas(gasToUse)
 
+	// a read-only execution may call other contracts, but not transfer value to them
+	if value.Sign() > 0 && failIfReadOnly(host) {
+		return 1
+	}
+
 	sender := runtime.GetSCAddress()
 	contractCallInput, err := prepareIndirectContractCallInput(
 		host,
@@ -2349,6 +3403,11 @@ func ExecuteOnDestContextByCallerWithTypedArgs(
 	gasToUse := metering.GasSchedule().ElrondAPICost.ExecuteOnDestContext
 	metering.UseGas(gasToUse)
 
+	// a read-only execution may call other contracts, but not transfer value to them
+	if value.Sign() > 0 && failIfReadOnly(host) {
+		return 1
+	}
+
 	send := runtime.GetVMInput().CallerAddr
 	contractCallInput, err := prepareIndirectContractCallInput(
 		host,
@@ -2559,9 +3618,11 @@ func ExecuteReadOnlyWithTypedArguments(
 		return 1
 	}
 
+	// a read-only execution stays read-only after executeReadOnly returns
+	wasReadOnly := runtime.ReadOnly() && host.IsReadOnlyEnforcementEnabled()
 	runtime.SetReadOnly(true)
 	_, err = host.ExecuteOnSameContext(contractCallInput)
-	runtime.SetReadOnly(false)
+	runtime.SetReadOnly(wasReadOnly)
 	if arwen.WithFaultAndHost(host, err, runtime.ElrondAPIErrorShouldFailExecution()) {
 		return 1
 	}
@@ -2586,6 +3647,10 @@ func v1_3_createContract(
 	runtime := host.Runtime()
 	metering := host.Metering()
 
+	if failIfReadOnly(host) {
+		return 1
+	}
+
 	gasToUse := metering.GasSchedule().ElrondAPICost.CreateContract
 	metering.UseGas(gasToUse)
 
@@ -2672,6 +3737,17 @@ func v1_3_getReturnDataSize(context unsafe.Pointer, resultID int32) int32 {
 	return int32(len(returnData[resultID]))
 }
 
+//export v1_3_getReturnDataBudget
+func v1_3_getReturnDataBudget(context unsafe.Pointer) int64 {
+	output := arwen.GetOutputContext(context)
+	metering := arwen.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().ElrondAPICost.GetReturnDataBudget
+	metering.UseGas(gasToUse)
+
+	return output.GetReturnDataBudget()
+}
+
 //export v1_3_getReturnData
 func v1_3_getReturnData(context unsafe.Pointer, resultID int32, dataOffset int32) int32 {
 	runtime := arwen.GetRuntimeContext(context)
@@ -2706,6 +3782,50 @@ func v1_3_getOriginalTxHash(context unsafe.Pointer, dataOffset int32) {
 	_ = arwen.WithFault(err, context, runtime.ElrondAPIErrorShouldFailExecution())
 }
 
+//export v1_3_getDeterministicRandom
+func v1_3_getDeterministicRandom(context unsafe.Pointer, seedOffset int32, seedLength int32, length int32, resultOffset int32) int32 {
+	runtime := arwen.GetRuntimeContext(context)
+	blockchain := arwen.GetBlockchainContext(context)
+	metering := arwen.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().ElrondAPICost.GetDeterministicRandom
+	metering.UseGas(gasToUse)
+
+	if length < 0 {
+		_ = arwen.WithFault(arwen.ErrNegativeLength, context, runtime.ElrondAPIErrorShouldFailExecution())
+		return -1
+	}
+
+	seed, err := runtime.MemLoad(seedOffset, seedLength)
+	if arwen.WithFault(err, context, runtime.ElrondAPIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	// the random bytes are only generated if there is enough gas left to copy them
+	gasToUse = math.MulUint64(metering.GasSchedule().BaseOperationCost.DataCopyPerByte, uint64(len(seed))+uint64(length))
+	gasLeft := metering.GasLeft()
+	metering.UseGas(gasToUse)
+	if gasToUse > gasLeft {
+		_ = arwen.WithFault(arwen.ErrNotEnoughGas, context, runtime.ElrondAPIErrorShouldFailExecution())
+		return -1
+	}
+
+	random := arwen.DeterministicRandom(
+		blockchain.CurrentRandomSeed(),
+		runtime.GetCurrentTxHash(),
+		seed,
+		runtime.NextRandomCounter(),
+		int(length),
+	)
+
+	err = runtime.MemStore(resultOffset, random)
+	if arwen.WithFault(err, context, runtime.ElrondAPIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	return 0
+}
+
 func prepareIndirectContractCallInput(
 	host arwen.VMHost,
 	sender []byte,
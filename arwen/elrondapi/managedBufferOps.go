@@ -0,0 +1,346 @@
+package elrondapi
+
+// // Declare the function signatures (see [cgo](https://golang.org/cmd/cgo/)).
+//
+// #include <stdlib.h>
+// typedef unsigned char uint8_t;
+// typedef int int32_t;
+//
+// extern int32_t		v1_3_mBufferNew(void* context);
+// extern int32_t		v1_3_mBufferNewFromBytes(void* context, int32_t dataOffset, int32_t dataLength);
+// extern int32_t		v1_3_mBufferGetLength(void* context, int32_t mBufferHandle);
+// extern int32_t		v1_3_mBufferGetBytes(void* context, int32_t mBufferHandle, int32_t resultOffset);
+// extern int32_t		v1_3_mBufferSetBytes(void* context, int32_t mBufferHandle, int32_t dataOffset, int32_t dataLength);
+// extern int32_t		v1_3_mBufferAppend(void* context, int32_t accumulatorHandle, int32_t dataHandle);
+//
+// extern int32_t		v1_3_mBufferCopySlice(void* context, int32_t sourceHandle, int32_t startingPosition, int32_t sliceLength, int32_t destinationHandle);
+// extern int32_t		v1_3_mBufferFind(void* context, int32_t sourceHandle, int32_t patternHandle, int32_t startingPosition);
+// extern int32_t		v1_3_mBufferSplit(void* context, int32_t sourceHandle, int32_t separatorHandle, int32_t maxPieces, int32_t resultOffset);
+// extern int32_t		v1_3_mBufferCompareSlice(void* context, int32_t handle1, int32_t startingPosition1, int32_t handle2, int32_t startingPosition2, int32_t sliceLength);
+import "C"
+
+import (
+	"bytes"
+	"encoding/binary"
+	"unsafe"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/math"
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/wasmer"
+)
+
+// ManagedBufferImports populates imports with the ManagedBuffer API methods
+func ManagedBufferImports(imports *wasmer.Imports) (*wasmer.Imports, error) {
+	imports = imports.Namespace("env")
+
+	imports, err := imports.Append("mBufferNew", v1_3_mBufferNew, C.v1_3_mBufferNew)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("mBufferNewFromBytes", v1_3_mBufferNewFromBytes, C.v1_3_mBufferNewFromBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("mBufferGetLength", v1_3_mBufferGetLength, C.v1_3_mBufferGetLength)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("mBufferGetBytes", v1_3_mBufferGetBytes, C.v1_3_mBufferGetBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("mBufferSetBytes", v1_3_mBufferSetBytes, C.v1_3_mBufferSetBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("mBufferAppend", v1_3_mBufferAppend, C.v1_3_mBufferAppend)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("mBufferCopySlice", v1_3_mBufferCopySlice, C.v1_3_mBufferCopySlice)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("mBufferFind", v1_3_mBufferFind, C.v1_3_mBufferFind)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("mBufferSplit", v1_3_mBufferSplit, C.v1_3_mBufferSplit)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("mBufferCompareSlice", v1_3_mBufferCompareSlice, C.v1_3_mBufferCompareSlice)
+	if err != nil {
+		return nil, err
+	}
+
+	return imports, nil
+}
+
+// getManagedBuffer returns the data of the managed buffer under the given
+// handle, failing the execution if it is missing
+func getManagedBuffer(context unsafe.Pointer, handle int32) ([]byte, bool) {
+	runtime := arwen.GetRuntimeContext(context)
+	data, err := runtime.ManagedTypes().GetManagedBuffer(handle)
+	if arwen.WithFault(err, context, runtime.ElrondAPIErrorShouldFailExecution()) {
+		return nil, false
+	}
+
+	return data, true
+}
+
+// useGasForManagedBufferBytes charges the copy of the given number of bytes
+func useGasForManagedBufferBytes(context unsafe.Pointer, numBytes int) {
+	metering := arwen.GetMeteringContext(context)
+	gasToUse := math.MulUint64(metering.GasSchedule().BaseOperationCost.DataCopyPerByte, uint64(numBytes))
+	metering.UseGas(gasToUse)
+}
+
+//export v1_3_mBufferNew
+func v1_3_mBufferNew(context unsafe.Pointer) int32 {
+	runtime := arwen.GetRuntimeContext(context)
+	metering := arwen.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().ManagedBufferAPICost.MBufferNew
+	metering.UseGas(gasToUse)
+
+	return runtime.ManagedTypes().NewManagedBuffer(nil)
+}
+
+//export v1_3_mBufferNewFromBytes
+func v1_3_mBufferNewFromBytes(context unsafe.Pointer, dataOffset int32, dataLength int32) int32 {
+	runtime := arwen.GetRuntimeContext(context)
+	metering := arwen.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().ManagedBufferAPICost.MBufferNewFromBytes
+	metering.UseGas(gasToUse)
+
+	data, err := runtime.MemLoad(dataOffset, dataLength)
+	if arwen.WithFault(err, context, runtime.ElrondAPIErrorShouldFailExecution()) {
+		return -1
+	}
+	useGasForManagedBufferBytes(context, len(data))
+
+	return runtime.ManagedTypes().NewManagedBuffer(data)
+}
+
+//export v1_3_mBufferGetLength
+func v1_3_mBufferGetLength(context unsafe.Pointer, mBufferHandle int32) int32 {
+	metering := arwen.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().ManagedBufferAPICost.MBufferGetLength
+	metering.UseGas(gasToUse)
+
+	data, ok := getManagedBuffer(context, mBufferHandle)
+	if !ok {
+		return -1
+	}
+
+	return int32(len(data))
+}
+
+//export v1_3_mBufferGetBytes
+func v1_3_mBufferGetBytes(context unsafe.Pointer, mBufferHandle int32, resultOffset int32) int32 {
+	runtime := arwen.GetRuntimeContext(context)
+	metering := arwen.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().ManagedBufferAPICost.MBufferGetBytes
+	metering.UseGas(gasToUse)
+
+	data, ok := getManagedBuffer(context, mBufferHandle)
+	if !ok {
+		return -1
+	}
+	useGasForManagedBufferBytes(context, len(data))
+
+	err := runtime.MemStore(resultOffset, data)
+	if arwen.WithFault(err, context, runtime.ElrondAPIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	return int32(len(data))
+}
+
+//export v1_3_mBufferSetBytes
+func v1_3_mBufferSetBytes(context unsafe.Pointer, mBufferHandle int32, dataOffset int32, dataLength int32) int32 {
+	runtime := arwen.GetRuntimeContext(context)
+	metering := arwen.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().ManagedBufferAPICost.MBufferSetBytes
+	metering.UseGas(gasToUse)
+
+	data, err := runtime.MemLoad(dataOffset, dataLength)
+	if arwen.WithFault(err, context, runtime.ElrondAPIErrorShouldFailExecution()) {
+		return -1
+	}
+	useGasForManagedBufferBytes(context, len(data))
+
+	err = runtime.ManagedTypes().SetManagedBuffer(mBufferHandle, data)
+	if arwen.WithFault(err, context, runtime.ElrondAPIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	return 0
+}
+
+//export v1_3_mBufferAppend
+func v1_3_mBufferAppend(context unsafe.Pointer, accumulatorHandle int32, dataHandle int32) int32 {
+	runtime := arwen.GetRuntimeContext(context)
+	metering := arwen.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().ManagedBufferAPICost.MBufferAppend
+	metering.UseGas(gasToUse)
+
+	accumulator, ok := getManagedBuffer(context, accumulatorHandle)
+	if !ok {
+		return -1
+	}
+	data, ok := getManagedBuffer(context, dataHandle)
+	if !ok {
+		return -1
+	}
+	useGasForManagedBufferBytes(context, len(data))
+
+	result := make([]byte, 0, len(accumulator)+len(data))
+	result = append(result, accumulator...)
+	result = append(result, data...)
+	err := runtime.ManagedTypes().SetManagedBuffer(accumulatorHandle, result)
+	if arwen.WithFault(err, context, runtime.ElrondAPIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	return 0
+}
+
+//export v1_3_mBufferCopySlice
+func v1_3_mBufferCopySlice(context unsafe.Pointer, sourceHandle int32, startingPosition int32, sliceLength int32, destinationHandle int32) int32 {
+	runtime := arwen.GetRuntimeContext(context)
+	metering := arwen.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().ManagedBufferAPICost.MBufferCopySlice
+	metering.UseGas(gasToUse)
+
+	source, ok := getManagedBuffer(context, sourceHandle)
+	if !ok {
+		return -1
+	}
+
+	slice, err := arwen.ManagedBufferSlice(source, startingPosition, sliceLength)
+	if arwen.WithFault(err, context, runtime.ElrondAPIErrorShouldFailExecution()) {
+		return -1
+	}
+	useGasForManagedBufferBytes(context, len(slice))
+
+	err = runtime.ManagedTypes().SetManagedBuffer(destinationHandle, slice)
+	if arwen.WithFault(err, context, runtime.ElrondAPIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	return 0
+}
+
+//export v1_3_mBufferFind
+func v1_3_mBufferFind(context unsafe.Pointer, sourceHandle int32, patternHandle int32, startingPosition int32) int32 {
+	runtime := arwen.GetRuntimeContext(context)
+	metering := arwen.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().ManagedBufferAPICost.MBufferFind
+	metering.UseGas(gasToUse)
+
+	source, ok := getManagedBuffer(context, sourceHandle)
+	if !ok {
+		return -2
+	}
+	pattern, ok := getManagedBuffer(context, patternHandle)
+	if !ok {
+		return -2
+	}
+	useGasForManagedBufferBytes(context, len(source)+len(pattern))
+
+	index, err := arwen.ManagedBufferFind(source, pattern, startingPosition)
+	if arwen.WithFault(err, context, runtime.ElrondAPIErrorShouldFailExecution()) {
+		return -2
+	}
+
+	return index
+}
+
+//export v1_3_mBufferSplit
+func v1_3_mBufferSplit(context unsafe.Pointer, sourceHandle int32, separatorHandle int32, maxPieces int32, resultOffset int32) int32 {
+	runtime := arwen.GetRuntimeContext(context)
+	metering := arwen.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().ManagedBufferAPICost.MBufferSplit
+	metering.UseGas(gasToUse)
+
+	source, ok := getManagedBuffer(context, sourceHandle)
+	if !ok {
+		return -1
+	}
+	separator, ok := getManagedBuffer(context, separatorHandle)
+	if !ok {
+		return -1
+	}
+
+	pieces, err := arwen.ManagedBufferSplit(source, separator, maxPieces)
+	if arwen.WithFault(err, context, runtime.ElrondAPIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	// Each piece costs a new managed buffer, besides the copy of its bytes
+	gasToUse = math.MulUint64(metering.GasSchedule().ManagedBufferAPICost.MBufferNew, uint64(len(pieces)))
+	metering.UseGas(gasToUse)
+	useGasForManagedBufferBytes(context, len(source))
+
+	handles := make([]byte, 4*len(pieces))
+	for i, piece := range pieces {
+		handle := runtime.ManagedTypes().NewManagedBuffer(piece)
+		binary.BigEndian.PutUint32(handles[4*i:], uint32(handle))
+	}
+
+	err = runtime.MemStore(resultOffset, handles)
+	if arwen.WithFault(err, context, runtime.ElrondAPIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	return int32(len(pieces))
+}
+
+//export v1_3_mBufferCompareSlice
+func v1_3_mBufferCompareSlice(context unsafe.Pointer, handle1 int32, startingPosition1 int32, handle2 int32, startingPosition2 int32, sliceLength int32) int32 {
+	runtime := arwen.GetRuntimeContext(context)
+	metering := arwen.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().ManagedBufferAPICost.MBufferCompareSlice
+	metering.UseGas(gasToUse)
+
+	data1, ok := getManagedBuffer(context, handle1)
+	if !ok {
+		return -2
+	}
+	data2, ok := getManagedBuffer(context, handle2)
+	if !ok {
+		return -2
+	}
+
+	slice1, err := arwen.ManagedBufferSlice(data1, startingPosition1, sliceLength)
+	if arwen.WithFault(err, context, runtime.ElrondAPIErrorShouldFailExecution()) {
+		return -2
+	}
+	slice2, err := arwen.ManagedBufferSlice(data2, startingPosition2, sliceLength)
+	if arwen.WithFault(err, context, runtime.ElrondAPIErrorShouldFailExecution()) {
+		return -2
+	}
+	useGasForManagedBufferBytes(context, 2*int(sliceLength))
+
+	return int32(bytes.Compare(slice1, slice2))
+}
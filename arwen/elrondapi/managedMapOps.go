@@ -0,0 +1,292 @@
+package elrondapi
+
+// // Declare the function signatures (see [cgo](https://golang.org/cmd/cgo/)).
+//
+// #include <stdlib.h>
+// typedef unsigned char uint8_t;
+// typedef int int32_t;
+//
+// extern int32_t		v1_3_managedMapNew(void* context);
+//
+// extern int32_t		v1_3_managedMapPut(void* context, int32_t mapHandle, int32_t keyOffset, int32_t keyLength, int32_t valueOffset, int32_t valueLength);
+// extern int32_t		v1_3_managedMapGetLength(void* context, int32_t mapHandle, int32_t keyOffset, int32_t keyLength);
+// extern int32_t		v1_3_managedMapGet(void* context, int32_t mapHandle, int32_t keyOffset, int32_t keyLength, int32_t resultOffset);
+// extern int32_t		v1_3_managedMapRemove(void* context, int32_t mapHandle, int32_t keyOffset, int32_t keyLength);
+// extern int32_t		v1_3_managedMapContains(void* context, int32_t mapHandle, int32_t keyOffset, int32_t keyLength);
+//
+// extern int32_t		v1_3_managedMapLength(void* context, int32_t mapHandle);
+// extern int32_t		v1_3_managedMapKeyLengthAtIndex(void* context, int32_t mapHandle, int32_t index);
+// extern int32_t		v1_3_managedMapKeyAtIndex(void* context, int32_t mapHandle, int32_t index, int32_t resultOffset);
+import "C"
+
+import (
+	"unsafe"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/math"
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/wasmer"
+)
+
+// ManagedMapImports populates imports with the ManagedMap API methods
+func ManagedMapImports(imports *wasmer.Imports) (*wasmer.Imports, error) {
+	imports = imports.Namespace("env")
+
+	imports, err := imports.Append("managedMapNew", v1_3_managedMapNew, C.v1_3_managedMapNew)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("managedMapPut", v1_3_managedMapPut, C.v1_3_managedMapPut)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("managedMapGetLength", v1_3_managedMapGetLength, C.v1_3_managedMapGetLength)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("managedMapGet", v1_3_managedMapGet, C.v1_3_managedMapGet)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("managedMapRemove", v1_3_managedMapRemove, C.v1_3_managedMapRemove)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("managedMapContains", v1_3_managedMapContains, C.v1_3_managedMapContains)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("managedMapLength", v1_3_managedMapLength, C.v1_3_managedMapLength)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("managedMapKeyLengthAtIndex", v1_3_managedMapKeyLengthAtIndex, C.v1_3_managedMapKeyLengthAtIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = imports.Append("managedMapKeyAtIndex", v1_3_managedMapKeyAtIndex, C.v1_3_managedMapKeyAtIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	return imports, nil
+}
+
+// getManagedMap returns the managed map under the given handle, failing the
+// execution if it is missing
+func getManagedMap(context unsafe.Pointer, mapHandle int32) (*arwen.ManagedMap, bool) {
+	runtime := arwen.GetRuntimeContext(context)
+	managedMap, err := runtime.ManagedTypes().GetManagedMap(mapHandle)
+	if arwen.WithFault(err, context, runtime.ElrondAPIErrorShouldFailExecution()) {
+		return nil, false
+	}
+
+	return managedMap, true
+}
+
+// getManagedMapAndKey returns the managed map under the given handle and the
+// key read from the memory of the contract, charging the copy of the key
+func getManagedMapAndKey(context unsafe.Pointer, mapHandle int32, keyOffset int32, keyLength int32) (*arwen.ManagedMap, []byte, bool) {
+	runtime := arwen.GetRuntimeContext(context)
+	metering := arwen.GetMeteringContext(context)
+
+	managedMap, ok := getManagedMap(context, mapHandle)
+	if !ok {
+		return nil, nil, false
+	}
+
+	key, err := runtime.MemLoad(keyOffset, keyLength)
+	if arwen.WithFault(err, context, runtime.ElrondAPIErrorShouldFailExecution()) {
+		return nil, nil, false
+	}
+
+	gasToUse := math.MulUint64(metering.GasSchedule().BaseOperationCost.DataCopyPerByte, uint64(len(key)))
+	metering.UseGas(gasToUse)
+
+	return managedMap, key, true
+}
+
+// storeManagedMapData writes the given data in the memory of the contract,
+// charging its copy, and returns its length
+func storeManagedMapData(context unsafe.Pointer, data []byte, resultOffset int32) int32 {
+	runtime := arwen.GetRuntimeContext(context)
+	metering := arwen.GetMeteringContext(context)
+
+	gasToUse := math.MulUint64(metering.GasSchedule().BaseOperationCost.DataCopyPerByte, uint64(len(data)))
+	metering.UseGas(gasToUse)
+
+	err := runtime.MemStore(resultOffset, data)
+	if arwen.WithFault(err, context, runtime.ElrondAPIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	return int32(len(data))
+}
+
+//export v1_3_managedMapNew
+func v1_3_managedMapNew(context unsafe.Pointer) int32 {
+	runtime := arwen.GetRuntimeContext(context)
+	metering := arwen.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().ManagedMapAPICost.ManagedMapNew
+	metering.UseGas(gasToUse)
+
+	return runtime.ManagedTypes().NewManagedMap()
+}
+
+//export v1_3_managedMapPut
+func v1_3_managedMapPut(context unsafe.Pointer, mapHandle int32, keyOffset int32, keyLength int32, valueOffset int32, valueLength int32) int32 {
+	runtime := arwen.GetRuntimeContext(context)
+	metering := arwen.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().ManagedMapAPICost.ManagedMapPut
+	metering.UseGas(gasToUse)
+
+	managedMap, key, ok := getManagedMapAndKey(context, mapHandle, keyOffset, keyLength)
+	if !ok {
+		return -1
+	}
+
+	value, err := runtime.MemLoad(valueOffset, valueLength)
+	if arwen.WithFault(err, context, runtime.ElrondAPIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	gasToUse = math.MulUint64(metering.GasSchedule().BaseOperationCost.DataCopyPerByte, uint64(len(value)))
+	metering.UseGas(gasToUse)
+
+	managedMap.Put(key, value)
+	return 0
+}
+
+//export v1_3_managedMapGetLength
+func v1_3_managedMapGetLength(context unsafe.Pointer, mapHandle int32, keyOffset int32, keyLength int32) int32 {
+	metering := arwen.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().ManagedMapAPICost.ManagedMapGet
+	metering.UseGas(gasToUse)
+
+	managedMap, key, ok := getManagedMapAndKey(context, mapHandle, keyOffset, keyLength)
+	if !ok {
+		return -1
+	}
+
+	return int32(len(managedMap.Get(key)))
+}
+
+//export v1_3_managedMapGet
+func v1_3_managedMapGet(context unsafe.Pointer, mapHandle int32, keyOffset int32, keyLength int32, resultOffset int32) int32 {
+	metering := arwen.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().ManagedMapAPICost.ManagedMapGet
+	metering.UseGas(gasToUse)
+
+	managedMap, key, ok := getManagedMapAndKey(context, mapHandle, keyOffset, keyLength)
+	if !ok {
+		return -1
+	}
+
+	return storeManagedMapData(context, managedMap.Get(key), resultOffset)
+}
+
+//export v1_3_managedMapRemove
+func v1_3_managedMapRemove(context unsafe.Pointer, mapHandle int32, keyOffset int32, keyLength int32) int32 {
+	metering := arwen.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().ManagedMapAPICost.ManagedMapRemove
+	metering.UseGas(gasToUse)
+
+	managedMap, key, ok := getManagedMapAndKey(context, mapHandle, keyOffset, keyLength)
+	if !ok {
+		return -1
+	}
+
+	if !managedMap.Contains(key) {
+		return 0
+	}
+
+	managedMap.Remove(key)
+	return 1
+}
+
+//export v1_3_managedMapContains
+func v1_3_managedMapContains(context unsafe.Pointer, mapHandle int32, keyOffset int32, keyLength int32) int32 {
+	metering := arwen.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().ManagedMapAPICost.ManagedMapContains
+	metering.UseGas(gasToUse)
+
+	managedMap, key, ok := getManagedMapAndKey(context, mapHandle, keyOffset, keyLength)
+	if !ok {
+		return -1
+	}
+
+	if managedMap.Contains(key) {
+		return 1
+	}
+	return 0
+}
+
+//export v1_3_managedMapLength
+func v1_3_managedMapLength(context unsafe.Pointer, mapHandle int32) int32 {
+	metering := arwen.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().ManagedMapAPICost.ManagedMapLength
+	metering.UseGas(gasToUse)
+
+	managedMap, ok := getManagedMap(context, mapHandle)
+	if !ok {
+		return -1
+	}
+
+	return int32(managedMap.Len())
+}
+
+//export v1_3_managedMapKeyLengthAtIndex
+func v1_3_managedMapKeyLengthAtIndex(context unsafe.Pointer, mapHandle int32, index int32) int32 {
+	runtime := arwen.GetRuntimeContext(context)
+	metering := arwen.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().ManagedMapAPICost.ManagedMapKeyAtIndex
+	metering.UseGas(gasToUse)
+
+	managedMap, ok := getManagedMap(context, mapHandle)
+	if !ok {
+		return -1
+	}
+
+	key, err := managedMap.KeyAtIndex(index)
+	if arwen.WithFault(err, context, runtime.ElrondAPIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	return int32(len(key))
+}
+
+//export v1_3_managedMapKeyAtIndex
+func v1_3_managedMapKeyAtIndex(context unsafe.Pointer, mapHandle int32, index int32, resultOffset int32) int32 {
+	runtime := arwen.GetRuntimeContext(context)
+	metering := arwen.GetMeteringContext(context)
+
+	gasToUse := metering.GasSchedule().ManagedMapAPICost.ManagedMapKeyAtIndex
+	metering.UseGas(gasToUse)
+
+	managedMap, ok := getManagedMap(context, mapHandle)
+	if !ok {
+		return -1
+	}
+
+	key, err := managedMap.KeyAtIndex(index)
+	if arwen.WithFault(err, context, runtime.ElrondAPIErrorShouldFailExecution()) {
+		return -1
+	}
+
+	return storeManagedMapData(context, key, resultOffset)
+}
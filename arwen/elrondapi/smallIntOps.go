@@ -150,6 +150,7 @@ func v1_3_smallIntGetSignedArgument(context unsafe.Pointer, id int32) int64 {
 
 //export v1_3_smallIntFinishUnsigned
 func v1_3_smallIntFinishUnsigned(context unsafe.Pointer, value int64) {
+	runtime := arwen.GetRuntimeContext(context)
 	output := arwen.GetOutputContext(context)
 	metering := arwen.GetMeteringContext(context)
 
@@ -157,11 +158,13 @@ func v1_3_smallIntFinishUnsigned(context unsafe.Pointer, value int64) {
 	metering.UseGas(gasToUse)
 
 	valueBytes := big.NewInt(0).SetUint64(uint64(value)).Bytes()
-	output.Finish(valueBytes)
+	err := output.Finish(valueBytes)
+	arwen.WithFault(err, context, runtime.ElrondAPIErrorShouldFailExecution())
 }
 
 //export v1_3_smallIntFinishSigned
 func v1_3_smallIntFinishSigned(context unsafe.Pointer, value int64) {
+	runtime := arwen.GetRuntimeContext(context)
 	output := arwen.GetOutputContext(context)
 	metering := arwen.GetMeteringContext(context)
 
@@ -169,7 +172,8 @@ func v1_3_smallIntFinishSigned(context unsafe.Pointer, value int64) {
 	metering.UseGas(gasToUse)
 
 	valueBytes := twos.ToBytes(big.NewInt(value))
-	output.Finish(valueBytes)
+	err := output.Finish(valueBytes)
+	arwen.WithFault(err, context, runtime.ElrondAPIErrorShouldFailExecution())
 }
 
 //export v1_3_smallIntStorageStoreUnsigned
@@ -224,14 +228,14 @@ func v1_3_smallIntStorageLoadUnsigned(context unsafe.Pointer, keyOffset int32, k
 	storage := arwen.GetStorageContext(context)
 	metering := arwen.GetMeteringContext(context)
 
-	gasToUse := metering.GasSchedule().ElrondAPICost.Int64StorageLoad
-	metering.UseGas(gasToUse)
-
 	key, err := runtime.MemLoad(keyOffset, keyLength)
 	if arwen.WithFault(err, context, runtime.ElrondAPIErrorShouldFailExecution()) {
 		return 0
 	}
 
+	gasToUse := metering.GasSchedule().ElrondAPICost.Int64StorageLoad
+	storage.UseGasForStorageLoad(key, gasToUse)
+
 	data := storage.GetStorage(key)
 	valueBigInt := big.NewInt(0).SetBytes(data)
 	if !valueBigInt.IsUint64() {
@@ -248,14 +252,14 @@ func v1_3_smallIntStorageLoadSigned(context unsafe.Pointer, keyOffset int32, key
 	storage := arwen.GetStorageContext(context)
 	metering := arwen.GetMeteringContext(context)
 
-	gasToUse := metering.GasSchedule().ElrondAPICost.Int64StorageLoad
-	metering.UseGas(gasToUse)
-
 	key, err := runtime.MemLoad(keyOffset, keyLength)
 	if arwen.WithFault(err, context, runtime.ElrondAPIErrorShouldFailExecution()) {
 		return 0
 	}
 
+	gasToUse := metering.GasSchedule().ElrondAPICost.Int64StorageLoad
+	storage.UseGasForStorageLoad(key, gasToUse)
+
 	data := storage.GetStorage(key)
 	valueBigInt := twos.SetBytes(big.NewInt(0), data)
 	if !valueBigInt.IsInt64() {
@@ -0,0 +1,12 @@
+package arwen
+
+// EnableEpochsHandler is an optional component supplied by the node, which
+// tells the VMHost whether the features that can be activated at a given
+// epoch are active in the current epoch
+type EnableEpochsHandler interface {
+	// IsMultiAsyncCallGroupsEnabledInEpoch returns true if contracts may register
+	// async calls in multiple groups (AsyncContexts) in the given epoch; otherwise
+	// only the legacy single async call is available
+	IsMultiAsyncCallGroupsEnabledInEpoch(epoch uint32) bool
+	IsInterfaceNil() bool
+}
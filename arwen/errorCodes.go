@@ -0,0 +1,156 @@
+package arwen
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrorCode is a stable numeric identifier of an Arwen error, which does not
+// change when the human-readable message of the error changes
+type ErrorCode uint32
+
+// ErrorCodeUnknown is the code reported for errors which do not carry a code
+const ErrorCodeUnknown ErrorCode = 0
+
+// Subsystem identifies the part of Arwen in which an error originated
+type Subsystem string
+
+const (
+	// SubsystemUnknown is reported for errors which do not carry a code
+	SubsystemUnknown Subsystem = "unknown"
+
+	// SubsystemRuntime groups the errors of the runtime context and of contract execution
+	SubsystemRuntime Subsystem = "runtime"
+
+	// SubsystemStorage groups the errors of the storage context
+	SubsystemStorage Subsystem = "storage"
+
+	// SubsystemOutput groups the errors of the output context, including transfers
+	SubsystemOutput Subsystem = "output"
+
+	// SubsystemAsync groups the errors of asynchronous calls and callbacks
+	SubsystemAsync Subsystem = "async"
+
+	// SubsystemMetering groups the errors of the metering context
+	SubsystemMetering Subsystem = "metering"
+
+	// SubsystemEEI groups the errors raised while validating arguments of the Elrond Environment Interface
+	SubsystemEEI Subsystem = "eei"
+)
+
+// CodedError is an error carrying a stable numeric code and the subsystem in
+// which it originated; it optionally wraps a more generic cause
+type CodedError struct {
+	Code      ErrorCode
+	Subsystem Subsystem
+	message   string
+	cause     error
+}
+
+// NewCodedError creates a new CodedError with the provided code, subsystem and message
+func NewCodedError(code ErrorCode, subsystem Subsystem, message string) *CodedError {
+	return &CodedError{
+		Code:      code,
+		Subsystem: subsystem,
+		message:   message,
+	}
+}
+
+// newDerivedCodedError creates a CodedError which specializes the provided cause,
+// keeping the message format "<cause> (<detail>)"
+func newDerivedCodedError(code ErrorCode, subsystem Subsystem, cause error, detail string) *CodedError {
+	return &CodedError{
+		Code:      code,
+		Subsystem: subsystem,
+		message:   fmt.Sprintf("%s (%s)", cause.Error(), detail),
+		cause:     cause,
+	}
+}
+
+// Error returns the human-readable message of the error
+func (err *CodedError) Error() string {
+	return err.message
+}
+
+// Unwrap returns the more generic error which this error specializes, if any
+func (err *CodedError) Unwrap() error {
+	return err.cause
+}
+
+// WithCause returns a copy of the error which wraps the provided cause, keeping the code and subsystem
+func (err *CodedError) WithCause(cause error) *CodedError {
+	if cause == nil {
+		return err
+	}
+
+	return &CodedError{
+		Code:      err.Code,
+		Subsystem: err.Subsystem,
+		message:   fmt.Sprintf("%s: %s", err.message, cause.Error()),
+		cause:     &chainedError{outer: err, inner: cause},
+	}
+}
+
+// chainedError allows errors.Is and errors.As to match both the original coded
+// error and the cause it was combined with through WithCause
+type chainedError struct {
+	outer error
+	inner error
+}
+
+func (ce *chainedError) Error() string {
+	return ce.outer.Error()
+}
+
+func (ce *chainedError) Is(target error) bool {
+	return errors.Is(ce.outer, target) || errors.Is(ce.inner, target)
+}
+
+func (ce *chainedError) Unwrap() error {
+	return ce.inner
+}
+
+// GetErrorCode returns the code and subsystem of the most specific CodedError
+// found in the chain of the provided error, or ErrorCodeUnknown and SubsystemUnknown
+func GetErrorCode(err error) (ErrorCode, Subsystem) {
+	var codedError *CodedError
+	if errors.As(err, &codedError) {
+		return codedError.Code, codedError.Subsystem
+	}
+
+	return ErrorCodeUnknown, SubsystemUnknown
+}
+
+// FormatReturnMessage prefixes the message with the code and subsystem of the
+// provided error, in the form "[<code>:<subsystem>] <message>"
+func FormatReturnMessage(err error, message string) string {
+	code, subsystem := GetErrorCode(err)
+	return fmt.Sprintf("[%d:%s] %s", code, subsystem, message)
+}
+
+// ParseReturnMessage extracts the code and subsystem from a message previously
+// produced by FormatReturnMessage; ok is false if the message has no such prefix
+func ParseReturnMessage(returnMessage string) (code ErrorCode, subsystem Subsystem, message string, ok bool) {
+	if !strings.HasPrefix(returnMessage, "[") {
+		return ErrorCodeUnknown, SubsystemUnknown, returnMessage, false
+	}
+
+	end := strings.Index(returnMessage, "] ")
+	if end < 0 {
+		return ErrorCodeUnknown, SubsystemUnknown, returnMessage, false
+	}
+
+	parts := strings.SplitN(returnMessage[1:end], ":", 2)
+	if len(parts) != 2 || len(parts[1]) == 0 {
+		return ErrorCodeUnknown, SubsystemUnknown, returnMessage, false
+	}
+
+	parsedCode, err := strconv.ParseUint(parts[0], 10, 32)
+	if err != nil {
+		return ErrorCodeUnknown, SubsystemUnknown, returnMessage, false
+	}
+
+	return ErrorCode(parsedCode), Subsystem(parts[1]), returnMessage[end+2:], true
+}
@@ -0,0 +1,73 @@
+package arwen
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCodedError_KeepsMessagesAndHierarchy(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, "invalid function (not found)", ErrFuncNotFound.Error())
+	require.True(t, errors.Is(ErrFuncNotFound, ErrInvalidFunction))
+	require.False(t, errors.Is(ErrInvalidFunction, ErrFuncNotFound))
+
+	wrapped := fmt.Errorf("%w: %s", ErrFuncNotFound, "missingFunction")
+	require.True(t, errors.Is(wrapped, ErrInvalidFunction))
+
+	code, subsystem := GetErrorCode(wrapped)
+	require.Equal(t, ErrFuncNotFound.Code, code)
+	require.Equal(t, SubsystemRuntime, subsystem)
+}
+
+func TestCodedError_WithCause(t *testing.T) {
+	t.Parallel()
+
+	cause := errors.New("disk on fire")
+	err := ErrStorageValueOutOfRange.WithCause(cause)
+
+	require.Equal(t, "storage value out of range: disk on fire", err.Error())
+	require.True(t, errors.Is(err, cause))
+	require.True(t, errors.Is(err, ErrStorageValueOutOfRange))
+	require.Equal(t, ErrStorageValueOutOfRange, ErrStorageValueOutOfRange.WithCause(nil))
+
+	code, subsystem := GetErrorCode(err)
+	require.Equal(t, ErrorCode(2003), code)
+	require.Equal(t, SubsystemStorage, subsystem)
+}
+
+func TestGetErrorCode_UncodedError(t *testing.T) {
+	t.Parallel()
+
+	code, subsystem := GetErrorCode(errors.New("plain"))
+	require.Equal(t, ErrorCodeUnknown, code)
+	require.Equal(t, SubsystemUnknown, subsystem)
+
+	code, _ = GetErrorCode(WrapError(ErrNotEnoughGas).WrapWithMessage("context"))
+	require.Equal(t, ErrNotEnoughGas.Code, code)
+}
+
+func TestFormatAndParseReturnMessage(t *testing.T) {
+	t.Parallel()
+
+	formatted := FormatReturnMessage(ErrSyncExecutionNotInSameShard, "custom message")
+	require.Equal(t, "[4005:async] custom message", formatted)
+
+	code, subsystem, message, ok := ParseReturnMessage(formatted)
+	require.True(t, ok)
+	require.Equal(t, ErrorCode(4005), code)
+	require.Equal(t, SubsystemAsync, subsystem)
+	require.Equal(t, "custom message", message)
+
+	formatted = FormatReturnMessage(errors.New("plain"), "plain")
+	require.Equal(t, "[0:unknown] plain", formatted)
+
+	for _, invalid := range []string{"", "plain", "[abc:runtime] x", "[12] x", "[12:runtime]x", "[12:] x"} {
+		_, _, message, ok = ParseReturnMessage(invalid)
+		require.False(t, ok, invalid)
+		require.Equal(t, invalid, message)
+	}
+}
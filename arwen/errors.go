@@ -1,151 +1,287 @@
 package arwen
 
-import (
-	"errors"
-	"fmt"
-)
-
 // ErrReturnCodeNotOk signals that the returned code is different than vmcommon.Ok
-var ErrReturnCodeNotOk = errors.New("return code is not ok")
+var ErrReturnCodeNotOk = NewCodedError(3001, SubsystemOutput, "return code is not ok")
 
 // ErrInvalidCallOnReadOnlyMode signals that an operation is not permitted due to read only mode
-var ErrInvalidCallOnReadOnlyMode = errors.New("operation not permitted in read only mode")
+var ErrInvalidCallOnReadOnlyMode = NewCodedError(1001, SubsystemRuntime, "operation not permitted in read only mode")
 
 // ErrNotEnoughGas signals that there is not enough gas for the operation
-var ErrNotEnoughGas = errors.New("not enough gas")
+var ErrNotEnoughGas = NewCodedError(5001, SubsystemMetering, "not enough gas")
 
 // ErrUnhandledRuntimeBreakpoint signals that the runtime breakpoint is unhandled
-var ErrUnhandledRuntimeBreakpoint = errors.New("unhandled runtime breakpoint")
+var ErrUnhandledRuntimeBreakpoint = NewCodedError(1002, SubsystemRuntime, "unhandled runtime breakpoint")
 
 // ErrSignalError is given when the smart contract signals an error
-var ErrSignalError = errors.New("error signalled by smartcontract")
+var ErrSignalError = NewCodedError(1003, SubsystemRuntime, "error signalled by smartcontract")
 
 // ErrExecutionFailed signals that the execution failed
-var ErrExecutionFailed = errors.New("execution failed")
+var ErrExecutionFailed = NewCodedError(1004, SubsystemRuntime, "execution failed")
 
 // ErrBadBounds signals that a certain variable is out of bounds
-var ErrBadBounds = errors.New("bad bounds")
+var ErrBadBounds = NewCodedError(6001, SubsystemEEI, "bad bounds")
 
 // ErrBadLowerBounds signals that a certain variable is lower than allowed
-var ErrBadLowerBounds = fmt.Errorf("%w (lower)", ErrBadBounds)
+var ErrBadLowerBounds = newDerivedCodedError(6002, SubsystemEEI, ErrBadBounds, "lower")
 
 // ErrBadUpperBounds signals that a certain variable is higher than allowed
-var ErrBadUpperBounds = fmt.Errorf("%w (upper)", ErrBadBounds)
+var ErrBadUpperBounds = newDerivedCodedError(6003, SubsystemEEI, ErrBadBounds, "upper")
 
 // ErrNegativeLength signals that the given length is less than 0
-var ErrNegativeLength = errors.New("negative length")
+var ErrNegativeLength = NewCodedError(6004, SubsystemEEI, "negative length")
 
 // ErrFailedTransfer signals that the transfer operation has failed
-var ErrFailedTransfer = errors.New("failed transfer")
+var ErrFailedTransfer = NewCodedError(3002, SubsystemOutput, "failed transfer")
 
 // ErrTransferInsufficientFunds signals that the transfer has failed due to insufficient funds
-var ErrTransferInsufficientFunds = fmt.Errorf("%w (insufficient funds)", ErrFailedTransfer)
+var ErrTransferInsufficientFunds = newDerivedCodedError(3003, SubsystemOutput, ErrFailedTransfer, "insufficient funds")
 
 // ErrTransferNegativeValue signals that the transfer has failed due to the fact that the value is less than 0
-var ErrTransferNegativeValue = fmt.Errorf("%w (negative value)", ErrFailedTransfer)
+var ErrTransferNegativeValue = newDerivedCodedError(3004, SubsystemOutput, ErrFailedTransfer, "negative value")
 
 // ErrUpgradeFailed signals that the upgrade encountered an error
-var ErrUpgradeFailed = errors.New("upgrade failed")
+var ErrUpgradeFailed = NewCodedError(1005, SubsystemRuntime, "upgrade failed")
 
 // ErrInvalidUpgradeArguments signals that the upgrade process failed due to invalid arguments
-var ErrInvalidUpgradeArguments = fmt.Errorf("%w (invalid arguments)", ErrUpgradeFailed)
+var ErrInvalidUpgradeArguments = newDerivedCodedError(1006, SubsystemRuntime, ErrUpgradeFailed, "invalid arguments")
 
 // ErrInvalidFunction signals that the function is invalid
-var ErrInvalidFunction = errors.New("invalid function")
+var ErrInvalidFunction = NewCodedError(1007, SubsystemRuntime, "invalid function")
 
 // ErrInitFuncCalledInRun signals that the init func was called directly, which is forbidden
-var ErrInitFuncCalledInRun = fmt.Errorf("%w (calling init() directly is forbidden)", ErrInvalidFunction)
+var ErrInitFuncCalledInRun = newDerivedCodedError(1008, SubsystemRuntime, ErrInvalidFunction, "calling init() directly is forbidden")
 
 // ErrCallBackFuncCalledInRun signals that a callback func was called directly, which is forbidden
-var ErrCallBackFuncCalledInRun = fmt.Errorf("%w (calling callBack() directly is forbidden)", ErrInvalidFunction)
+var ErrCallBackFuncCalledInRun = newDerivedCodedError(4001, SubsystemAsync, ErrInvalidFunction, "calling callBack() directly is forbidden")
 
 // ErrCallBackFuncNotExpected signals that an unexpected callback was received
-var ErrCallBackFuncNotExpected = fmt.Errorf("%w (unexpected callback was received)", ErrInvalidFunction)
+var ErrCallBackFuncNotExpected = newDerivedCodedError(4002, SubsystemAsync, ErrInvalidFunction, "unexpected callback was received")
 
 // ErrFuncNotFound signals that the the function does not exist
-var ErrFuncNotFound = fmt.Errorf("%w (not found)", ErrInvalidFunction)
+var ErrFuncNotFound = newDerivedCodedError(1009, SubsystemRuntime, ErrInvalidFunction, "not found")
 
 // ErrInvalidFunctionName signals that the function name is invalid
-var ErrInvalidFunctionName = fmt.Errorf("%w (invalid name)", ErrInvalidFunction)
+var ErrInvalidFunctionName = newDerivedCodedError(1010, SubsystemRuntime, ErrInvalidFunction, "invalid name")
 
 // ErrFunctionNonvoidSignature signals that the signature for the function is invalid
-var ErrFunctionNonvoidSignature = fmt.Errorf("%w (nonvoid signature)", ErrInvalidFunction)
+var ErrFunctionNonvoidSignature = newDerivedCodedError(1011, SubsystemRuntime, ErrInvalidFunction, "nonvoid signature")
 
 // ErrContractInvalid signals that the contract code is invalid
-var ErrContractInvalid = fmt.Errorf("invalid contract code")
+var ErrContractInvalid = NewCodedError(1012, SubsystemRuntime, "invalid contract code")
 
 // ErrContractNotFound signals that the contract was not found
-var ErrContractNotFound = fmt.Errorf("%w (not found)", ErrContractInvalid)
+var ErrContractNotFound = newDerivedCodedError(1013, SubsystemRuntime, ErrContractInvalid, "not found")
 
 // ErrMemoryDeclarationMissing signals that a memory declaration is missing
-var ErrMemoryDeclarationMissing = fmt.Errorf("%w (missing memory declaration)", ErrContractInvalid)
+var ErrMemoryDeclarationMissing = newDerivedCodedError(1014, SubsystemRuntime, ErrContractInvalid, "missing memory declaration")
 
 // ErrMaxInstancesReached signals that the max number of Wasmer instances has been reached.
-var ErrMaxInstancesReached = fmt.Errorf("%w (max instances reached)", ErrExecutionFailed)
+var ErrMaxInstancesReached = newDerivedCodedError(1015, SubsystemRuntime, ErrExecutionFailed, "max instances reached")
 
 // ErrStoreElrondReservedKey signals that an attempt to write under an reserved key has been made
-var ErrStoreElrondReservedKey = errors.New("cannot write to storage under Elrond reserved key")
+var ErrStoreElrondReservedKey = NewCodedError(2001, SubsystemStorage, "cannot write to storage under Elrond reserved key")
 
 // ErrCannotWriteProtectedKey signals an attempt to write to a protected key, while storage protection is enforced
-var ErrCannotWriteProtectedKey = errors.New("cannot write to protected key")
+var ErrCannotWriteProtectedKey = NewCodedError(2002, SubsystemStorage, "cannot write to protected key")
 
 // ErrNonPayableFunctionEgld signals that a non-payable function received non-zero call value
-var ErrNonPayableFunctionEgld = errors.New("function does not accept EGLD payment")
+var ErrNonPayableFunctionEgld = NewCodedError(1016, SubsystemRuntime, "function does not accept EGLD payment")
 
 // ErrNonPayableFunctionEsdt signals that a non-payable function received non-zero ESDT call value
-var ErrNonPayableFunctionEsdt = errors.New("function does not accept ESDT payment")
+var ErrNonPayableFunctionEsdt = NewCodedError(1017, SubsystemRuntime, "function does not accept ESDT payment")
 
 // ErrArgIndexOutOfRange signals that the argument index is out of range
-var ErrArgIndexOutOfRange = errors.New("argument index out of range")
+var ErrArgIndexOutOfRange = NewCodedError(6005, SubsystemEEI, "argument index out of range")
 
 // ErrArgOutOfRange signals that the argument is out of range
-var ErrArgOutOfRange = errors.New("argument out of range")
+var ErrArgOutOfRange = NewCodedError(6006, SubsystemEEI, "argument out of range")
 
 // ErrStorageValueOutOfRange signals that the storage value is out of range
-var ErrStorageValueOutOfRange = errors.New("storage value out of range")
+var ErrStorageValueOutOfRange = NewCodedError(2003, SubsystemStorage, "storage value out of range")
 
 // ErrDivZero signals that an attempt to divide by 0 has been made
-var ErrDivZero = errors.New("division by 0")
+var ErrDivZero = NewCodedError(6007, SubsystemEEI, "division by 0")
 
 // ErrBitwiseNegative signals that an attempt to apply a bitwise operation on negative numbers has been made
-var ErrBitwiseNegative = errors.New("bitwise operations only allowed on positive integers")
+var ErrBitwiseNegative = NewCodedError(6008, SubsystemEEI, "bitwise operations only allowed on positive integers")
 
 // ErrShiftNegative signals that an attempt to apply a bitwise shift operation on negative numbers has been made
-var ErrShiftNegative = errors.New("bitwise shift operations only allowed on positive integers and by a positive amount")
+var ErrShiftNegative = NewCodedError(6009, SubsystemEEI, "bitwise shift operations only allowed on positive integers and by a positive amount")
 
 // ErrAsyncContextDoesNotExist signals that the async context does not exist
-var ErrAsyncContextDoesNotExist = errors.New("async context does not exist")
+var ErrAsyncContextDoesNotExist = NewCodedError(4003, SubsystemAsync, "async context does not exist")
 
 // ErrInvalidAccount signals that a certain account does not exist
-var ErrInvalidAccount = errors.New("account does not exist")
+var ErrInvalidAccount = NewCodedError(3005, SubsystemOutput, "account does not exist")
 
 // ErrDeploymentOverExistingAccount signals that an attempt to deploy a new SC over an already existing account has been made
-var ErrDeploymentOverExistingAccount = errors.New("cannot deploy over existing account")
+var ErrDeploymentOverExistingAccount = NewCodedError(1018, SubsystemRuntime, "cannot deploy over existing account")
 
 // ErrAccountNotPayable signals that the value transfer to a non payable contract is not possible
-var ErrAccountNotPayable = errors.New("sending value to non payable contract")
+var ErrAccountNotPayable = NewCodedError(3006, SubsystemOutput, "sending value to non payable contract")
 
 // ErrInvalidPublicKeySize signals that the public key size is invalid
-var ErrInvalidPublicKeySize = errors.New("invalid public key size")
+var ErrInvalidPublicKeySize = NewCodedError(6010, SubsystemEEI, "invalid public key size")
 
 // ErrNilCallbackFunction signals that a nil callback function has been provided
-var ErrNilCallbackFunction = errors.New("nil callback function")
+var ErrNilCallbackFunction = NewCodedError(4004, SubsystemAsync, "nil callback function")
 
 // ErrUpgradeNotAllowed signals that an upgrade is not allowed
-var ErrUpgradeNotAllowed = errors.New("upgrade not allowed")
+var ErrUpgradeNotAllowed = NewCodedError(1019, SubsystemRuntime, "upgrade not allowed")
 
 // ErrNilContract signals that the contract is nil
-var ErrNilContract = errors.New("nil contract")
+var ErrNilContract = NewCodedError(1020, SubsystemRuntime, "nil contract")
 
 // ErrBuiltinCallOnSameContextDisallowed signals that calling a built-in function on the same context is not allowed
-var ErrBuiltinCallOnSameContextDisallowed = errors.New("calling built-in function on the same context is disallowed")
+var ErrBuiltinCallOnSameContextDisallowed = NewCodedError(1021, SubsystemRuntime, "calling built-in function on the same context is disallowed")
 
 // ErrSyncExecutionNotInSameShard signals that the sync execution request is not in the same shard
-var ErrSyncExecutionNotInSameShard = errors.New("sync execution request is not in the same shard")
+var ErrSyncExecutionNotInSameShard = NewCodedError(4005, SubsystemAsync, "sync execution request is not in the same shard")
 
 // ErrInputAndOutputGasDoesNotMatch is raised when the output gas (gas used + gas locked + gas remaining)
 // is not equal to the input gas
-var ErrInputAndOutputGasDoesNotMatch = errors.New("input and output gas does not match")
+var ErrInputAndOutputGasDoesNotMatch = NewCodedError(5002, SubsystemMetering, "input and output gas does not match")
 
 // ErrTransferValueOnESDTCall signals that balance transfer was given in esdt call
-var ErrTransferValueOnESDTCall = errors.New("transfer value on esdt call")
+var ErrTransferValueOnESDTCall = NewCodedError(3007, SubsystemOutput, "transfer value on esdt call")
+
+// ErrExecutionRejectedByPolicy signals that the execution policy of the embedder rejected the call
+var ErrExecutionRejectedByPolicy = NewCodedError(1022, SubsystemRuntime, "execution rejected by policy")
+
+// ErrExecutionDeprioritized signals that the execution policy of the embedder postponed the call
+var ErrExecutionDeprioritized = NewCodedError(1023, SubsystemRuntime, "execution deprioritized by policy")
+
+// ErrAsyncMigrationMissingDestination signals that a legacy async data entry has no destination and cannot be migrated
+var ErrAsyncMigrationMissingDestination = NewCodedError(4006, SubsystemAsync, "legacy async data has no destination")
+
+// ErrDeferredCallTargetReached signals that a deferred call was registered for a round and epoch which were already reached
+var ErrDeferredCallTargetReached = NewCodedError(4007, SubsystemAsync, "deferred call target already reached")
+
+// ErrInvalidDeferredCallFunction signals that a deferred call was registered for a function which cannot be called this way
+var ErrInvalidDeferredCallFunction = NewCodedError(4008, SubsystemAsync, "invalid deferred call function")
+
+// ErrStorageSnapshotNotFound signals that no storage snapshot was taken under the requested key
+var ErrStorageSnapshotNotFound = NewCodedError(2004, SubsystemStorage, "storage snapshot not found")
+
+// ErrOutputSnapshotNotFound signals that no output snapshot was taken under the requested key
+var ErrOutputSnapshotNotFound = NewCodedError(3008, SubsystemOutput, "output snapshot not found")
+
+// ErrMultiAsyncCallGroupsNotEnabled signals that a contract registered an async call in a group before the multiple async call groups were enabled
+var ErrMultiAsyncCallGroupsNotEnabled = NewCodedError(4009, SubsystemAsync, "multiple async call groups are not enabled")
+
+// ErrInvalidAsyncCallbackOrder signals that an AsyncContext requested an unknown order of execution for its callbacks
+var ErrInvalidAsyncCallbackOrder = NewCodedError(4010, SubsystemAsync, "invalid async callback order")
+
+// ErrInvalidCallbackName signals that a contract registered an async call whose callback is not a valid, non-reserved function exported by the contract
+var ErrInvalidCallbackName = NewCodedError(4011, SubsystemAsync, "invalid callback name")
+
+// ErrMemoryLimitReached signals that the memory of the process is near its ceiling; the execution may be retried later
+var ErrMemoryLimitReached = NewCodedError(1024, SubsystemRuntime, "memory limit reached")
+
+// ErrStorageKeyTooLong signals that a contract attempted to write under a storage key longer than allowed
+var ErrStorageKeyTooLong = NewCodedError(2005, SubsystemStorage, "storage key too long")
+
+// ErrStorageValueTooLong signals that a contract attempted to write a storage value longer than allowed
+var ErrStorageValueTooLong = NewCodedError(2006, SubsystemStorage, "storage value too long")
+
+// ErrStorageQuotaExceeded signals that a storage write would grow the storage of the contract beyond its quota
+var ErrStorageQuotaExceeded = NewCodedError(2007, SubsystemStorage, "storage quota exceeded")
+
+// ErrStorageReadNotInSameShard signals that a contract attempted to read the storage of a contract in another shard
+var ErrStorageReadNotInSameShard = NewCodedError(2008, SubsystemStorage, "cannot read the storage of a contract in another shard")
+
+// ErrMemoryPagesLimitExceeded signals that the memory of a contract holds more pages than allowed
+var ErrMemoryPagesLimitExceeded = NewCodedError(1025, SubsystemRuntime, "memory pages limit exceeded")
+
+// ErrMemoryGrowLimitExceeded signals that a contract grew its memory by more pages than allowed during a call
+var ErrMemoryGrowLimitExceeded = NewCodedError(1026, SubsystemRuntime, "memory grow limit exceeded")
+
+// ErrExecutionTimeout signals that an execution was interrupted because it lasted longer than allowed; the execution may be retried later
+var ErrExecutionTimeout = NewCodedError(1027, SubsystemRuntime, "execution timeout")
+
+// ErrModuleValidationFailed signals that the code of a contract violates one of the rules checked before its instantiation
+var ErrModuleValidationFailed = newDerivedCodedError(1028, SubsystemRuntime, ErrContractInvalid, "module validation failed")
+
+// ErrModuleMalformed signals that the code of a contract could not be decoded for validation
+var ErrModuleMalformed = newDerivedCodedError(1029, SubsystemRuntime, ErrModuleValidationFailed, "malformed module")
+
+// ErrFloatingPointNotAllowed signals that the code of a contract uses floating-point values or instructions, while disallowed
+var ErrFloatingPointNotAllowed = newDerivedCodedError(1030, SubsystemRuntime, ErrModuleValidationFailed, "floating point not allowed")
+
+// ErrTooManyFunctions signals that the code of a contract defines more functions than allowed
+var ErrTooManyFunctions = newDerivedCodedError(1031, SubsystemRuntime, ErrModuleValidationFailed, "too many functions")
+
+// ErrTooManyGlobals signals that the code of a contract defines more globals than allowed
+var ErrTooManyGlobals = newDerivedCodedError(1032, SubsystemRuntime, ErrModuleValidationFailed, "too many globals")
+
+// ErrCallbackNotExported signals that the code of a contract performs legacy async calls without exporting the default callback
+var ErrCallbackNotExported = newDerivedCodedError(1033, SubsystemRuntime, ErrModuleValidationFailed, "callback not exported")
+
+// ErrStartSectionNotAllowed signals that the code of a contract has a start section, while disallowed
+var ErrStartSectionNotAllowed = newDerivedCodedError(1034, SubsystemRuntime, ErrModuleValidationFailed, "start section not allowed")
+
+// ErrExecutionStackOverflow signals that a nested execution was refused because the execution stack reached its maximum depth
+var ErrExecutionStackOverflow = NewCodedError(1035, SubsystemRuntime, "execution stack overflow")
+
+// ErrContractCodeTooLarge signals that the code of a contract is larger than allowed for deployment
+var ErrContractCodeTooLarge = newDerivedCodedError(1036, SubsystemRuntime, ErrContractInvalid, "code too large")
+
+// ErrTooManyExports signals that the code of a contract declares more exports than allowed for deployment
+var ErrTooManyExports = newDerivedCodedError(1037, SubsystemRuntime, ErrContractInvalid, "too many exports")
+
+// ErrTooManyImports signals that the code of a contract declares more imports than allowed for deployment
+var ErrTooManyImports = newDerivedCodedError(1038, SubsystemRuntime, ErrContractInvalid, "too many imports")
+
+// ErrTooManyDataSegments signals that the code of a contract declares more data segments than allowed for deployment
+var ErrTooManyDataSegments = newDerivedCodedError(1039, SubsystemRuntime, ErrContractInvalid, "too many data segments")
+
+// ErrExecutionStoppedByDebugger signals that an execution was stopped by the single-step debugging mode
+var ErrExecutionStoppedByDebugger = NewCodedError(1040, SubsystemRuntime, "execution stopped by debugger")
+
+// ErrInvalidBuiltinOutputProcessor signals that a built-in output processor was registered without a function name or without an implementation
+var ErrInvalidBuiltinOutputProcessor = NewCodedError(1041, SubsystemRuntime, "invalid built-in output processor")
+
+// ErrFunctionNotActivated signals that a contract imports an EEI function which is not active in the current epoch
+var ErrFunctionNotActivated = newDerivedCodedError(1042, SubsystemRuntime, ErrContractInvalid, "imported function not activated")
+
+// ErrValueNotConserved signals that the balance deltas of the output accounts do not add up to the value which entered them from outside the VM
+var ErrValueNotConserved = NewCodedError(3009, SubsystemOutput, "value not conserved by the output accounts")
+
+// ErrEventLogMissingIdentifier signals that a contract attempted to write an event log without an identifier
+var ErrEventLogMissingIdentifier = NewCodedError(3010, SubsystemOutput, "event log without identifier")
+
+// ErrEventLogTooManyTopics signals that a contract attempted to write an event log with more topics than allowed
+var ErrEventLogTooManyTopics = NewCodedError(3011, SubsystemOutput, "too many event log topics")
+
+// ErrEventLogTopicTooLong signals that a contract attempted to write an event log with a topic longer than allowed
+var ErrEventLogTopicTooLong = NewCodedError(3012, SubsystemOutput, "event log topic too long")
+
+// ErrReturnDataTooLarge signals that a contract attempted to finish more return data than allowed for a transaction
+var ErrReturnDataTooLarge = NewCodedError(3013, SubsystemOutput, "return data too large")
+
+// ErrInvalidMultiESDTTransfer signals that a multiple ESDT transfer has no tokens or malformed arguments
+var ErrInvalidMultiESDTTransfer = NewCodedError(3014, SubsystemOutput, "invalid multiple ESDT transfer")
+
+// ErrNoBigFloatUnderThisHandle signals that there is no big float under the handle given to the BigFloat API
+var ErrNoBigFloatUnderThisHandle = NewCodedError(6011, SubsystemEEI, "no big float under the given handle")
+
+// ErrInvalidBigFloatOperation signals that a BigFloat API operation has no finite result, or invalid operands
+var ErrInvalidBigFloatOperation = NewCodedError(6012, SubsystemEEI, "invalid big float operation")
+
+// ErrNoManagedMapUnderThisHandle signals that there is no managed map under the handle given to the ManagedMap API
+var ErrNoManagedMapUnderThisHandle = NewCodedError(6013, SubsystemEEI, "no managed map under the given handle")
+
+// ErrPowModNegative signals that a modular exponentiation has been attempted with a negative exponent or modulus
+var ErrPowModNegative = NewCodedError(6014, SubsystemEEI, "modular exponentiation only allowed with a non-negative exponent and modulus")
+
+// ErrNoManagedBufferUnderThisHandle signals that there is no managed buffer under the handle given to the ManagedBuffer API
+var ErrNoManagedBufferUnderThisHandle = NewCodedError(6015, SubsystemEEI, "no managed buffer under the given handle")
+
+// ErrNoCurvePointUnderThisHandle signals that there is no curve point under the handle given to the elliptic curve API
+var ErrNoCurvePointUnderThisHandle = NewCodedError(6016, SubsystemEEI, "no curve point under the given handle")
+
+// ErrInvalidTokenIndex signals that a contract requested a token it did not receive, by an index out of range
+var ErrInvalidTokenIndex = NewCodedError(6017, SubsystemEEI, "invalid token index")
+
+// ErrBuiltinCallAfterUpgradeDisallowed signals that upgradeAndCall was asked to call a built-in function on the upgraded contract
+var ErrBuiltinCallAfterUpgradeDisallowed = NewCodedError(6018, SubsystemEEI, "calling a built-in function after an upgrade is disallowed")
@@ -0,0 +1,89 @@
+package arwen
+
+import (
+	"fmt"
+)
+
+// EventLog is a log entry written by a contract as an event: an identifier,
+// followed by its indexed topics, and its unindexed data
+type EventLog struct {
+	Identifier []byte
+	Topics     [][]byte
+	Data       []byte
+}
+
+// NewEventLog creates an EventLog with the given identifier, without topics
+// or data
+func NewEventLog(identifier []byte) *EventLog {
+	return &EventLog{
+		Identifier: identifier,
+		Topics:     make([][]byte, 0),
+	}
+}
+
+// NewEventLogFromTopics creates an EventLog out of the topics passed by a
+// contract to the EEI, the first of which is the identifier of the event
+func NewEventLogFromTopics(topics [][]byte, data []byte) *EventLog {
+	if len(topics) == 0 {
+		return NewEventLog(nil).WithData(data)
+	}
+
+	event := NewEventLog(topics[0]).WithData(data)
+	for _, topic := range topics[1:] {
+		event.AddTopic(topic)
+	}
+	return event
+}
+
+// AddTopic appends the given indexed topic to the EventLog
+func (event *EventLog) AddTopic(topic []byte) *EventLog {
+	event.Topics = append(event.Topics, topic)
+	return event
+}
+
+// WithData sets the unindexed data of the EventLog
+func (event *EventLog) WithData(data []byte) *EventLog {
+	event.Data = data
+	return event
+}
+
+// LogTopics returns the identifier and the topics of the EventLog, in the
+// form in which they are written to the logs
+func (event *EventLog) LogTopics() [][]byte {
+	topics := make([][]byte, 0, len(event.Topics)+1)
+	topics = append(topics, event.Identifier)
+	return append(topics, event.Topics...)
+}
+
+// EventLogLimits constrains the shape of the EventLogs written by contracts,
+// so that the indexer can rely on them. A value of 0 leaves the respective
+// limit unlimited; the EventLogs are not validated at all when both are 0.
+type EventLogLimits struct {
+	MaxTopics      uint64
+	MaxTopicLength uint64
+}
+
+// Validate returns an error if the given EventLog has no identifier, has
+// more topics than allowed, or has an identifier or a topic longer than
+// allowed; any EventLog is valid when there are no limits
+func (limits EventLogLimits) Validate(event *EventLog) error {
+	if limits.MaxTopics == 0 && limits.MaxTopicLength == 0 {
+		return nil
+	}
+	if len(event.Identifier) == 0 {
+		return ErrEventLogMissingIdentifier
+	}
+	if limits.MaxTopics > 0 && uint64(len(event.Topics)) > limits.MaxTopics {
+		return fmt.Errorf("%w: %d topics, at most %d allowed", ErrEventLogTooManyTopics, len(event.Topics), limits.MaxTopics)
+	}
+	if limits.MaxTopicLength == 0 {
+		return nil
+	}
+
+	for _, topic := range event.LogTopics() {
+		if uint64(len(topic)) > limits.MaxTopicLength {
+			return fmt.Errorf("%w: %d bytes, at most %d allowed", ErrEventLogTopicTooLong, len(topic), limits.MaxTopicLength)
+		}
+	}
+	return nil
+}
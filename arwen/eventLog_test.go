@@ -0,0 +1,53 @@
+package arwen
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventLog_Builder(t *testing.T) {
+	t.Parallel()
+
+	event := NewEventLog([]byte("transfer")).
+		AddTopic([]byte("sender")).
+		AddTopic([]byte("receiver")).
+		WithData([]byte("value"))
+
+	require.Equal(t, [][]byte{[]byte("transfer"), []byte("sender"), []byte("receiver")}, event.LogTopics())
+	require.Equal(t, []byte("value"), event.Data)
+
+	fromTopics := NewEventLogFromTopics(event.LogTopics(), []byte("value"))
+	require.Equal(t, event, fromTopics)
+
+	empty := NewEventLogFromTopics(nil, []byte("value"))
+	require.Empty(t, empty.Identifier)
+	require.Empty(t, empty.Topics)
+}
+
+func TestEventLogLimits_Validate(t *testing.T) {
+	t.Parallel()
+
+	event := NewEventLog([]byte("transfer")).
+		AddTopic([]byte("sender")).
+		AddTopic([]byte("receiver"))
+
+	require.Nil(t, EventLogLimits{}.Validate(event))
+	require.Nil(t, EventLogLimits{MaxTopics: 2, MaxTopicLength: 8}.Validate(event))
+
+	require.Nil(t, EventLogLimits{}.Validate(NewEventLog(nil).AddTopic([]byte("sender"))))
+
+	err := EventLogLimits{MaxTopics: 2}.Validate(NewEventLog(nil).AddTopic([]byte("sender")))
+	require.Equal(t, ErrEventLogMissingIdentifier, err)
+
+	err = EventLogLimits{MaxTopics: 1}.Validate(event)
+	require.True(t, errors.Is(err, ErrEventLogTooManyTopics))
+
+	// the identifier is subject to the same length limit as the topics
+	err = EventLogLimits{MaxTopicLength: 7}.Validate(event)
+	require.True(t, errors.Is(err, ErrEventLogTopicTooLong))
+
+	err = EventLogLimits{MaxTopicLength: 8}.Validate(NewEventLog([]byte("long identifier")))
+	require.True(t, errors.Is(err, ErrEventLogTopicTooLong))
+}
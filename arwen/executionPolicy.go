@@ -0,0 +1,29 @@
+package arwen
+
+// ExecutionPolicyDecision is the verdict of an ExecutionPolicy on a call
+type ExecutionPolicyDecision uint8
+
+const (
+	// ExecutionAllowed lets the call execute normally
+	ExecutionAllowed ExecutionPolicyDecision = iota
+
+	// ExecutionDeprioritized postpones the call, without consuming its gas
+	ExecutionDeprioritized
+
+	// ExecutionRejected fails the call, consuming its gas
+	ExecutionRejected
+)
+
+// ExecutionPolicyRequest contains the details of a call, as submitted to the ExecutionPolicy
+type ExecutionPolicyRequest struct {
+	Caller      []byte
+	Contract    []byte
+	Function    string
+	GasProvided uint64
+}
+
+// ExecutionPolicy is an optional hook of the embedder, consulted before each call or deployment
+type ExecutionPolicy interface {
+	Evaluate(request ExecutionPolicyRequest) ExecutionPolicyDecision
+	IsInterfaceNil() bool
+}
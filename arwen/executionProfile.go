@@ -0,0 +1,43 @@
+package arwen
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"time"
+)
+
+// ExecutionProfileSampleInterval is the interval at which the call stack of
+// a profiled execution is sampled
+const ExecutionProfileSampleInterval = 100 * time.Microsecond
+
+// ExecutionProfile holds the call stacks sampled during an execution, in the
+// folded format of flame graph tools: the frames of a stack are separated by
+// ";", from the outermost to the innermost. The Go frames are those of the
+// VM, while a frame of the form "wasm:function@address" stands for the WASM
+// code of a contract, as wasmer does not expose the index of the function
+// it executes.
+type ExecutionProfile struct {
+	SampleInterval time.Duration
+	NumSamples     uint64
+	Stacks         map[string]uint64
+}
+
+// WriteFolded writes the ExecutionProfile in the folded format, one stack
+// per line, followed by the number of samples in which it was found
+func (profile *ExecutionProfile) WriteFolded(writer io.Writer) error {
+	stacks := make([]string, 0, len(profile.Stacks))
+	for stack := range profile.Stacks {
+		stacks = append(stacks, stack)
+	}
+	sort.Strings(stacks)
+
+	for _, stack := range stacks {
+		_, err := fmt.Fprintf(writer, "%s %d\n", stack, profile.Stacks[stack])
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
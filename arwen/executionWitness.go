@@ -0,0 +1,34 @@
+package arwen
+
+import (
+	"math/big"
+)
+
+// WitnessStorageEntry is a storage key read during an execution, together
+// with the value it held before the execution
+type WitnessStorageEntry struct {
+	Key   []byte
+	Value []byte
+}
+
+// WitnessAccount holds the state of an account as read during an execution;
+// only the fields actually requested from the BlockchainHook are filled in
+type WitnessAccount struct {
+	Address      []byte
+	Exists       bool
+	Nonce        uint64
+	Balance      *big.Int
+	CodeMetadata []byte
+	CodeHash     []byte
+	OwnerAddress []byte
+	Code         []byte
+	Storage      []WitnessStorageEntry
+}
+
+// ExecutionWitness is the compact record of all the accounts and storage keys
+// read by the VMHost during an execution, with their values, sufficient to
+// re-execute the transaction without access to the full state; the accounts
+// are sorted by address and their storage entries by key
+type ExecutionWitness struct {
+	Accounts []*WitnessAccount
+}
@@ -0,0 +1,121 @@
+package arwen
+
+import (
+	"fmt"
+	"sort"
+)
+
+// FunctionActivationMap gives the epoch from which contracts may import each
+// of the EEI functions it lists; the functions missing from the map are
+// always active, which lets a single VM binary serve the protocol epochs
+// before and after the introduction of a function
+type FunctionActivationMap map[string]uint32
+
+// eeiExtensionFunctions are the EEI functions added on top of the original
+// API of the VM, which contracts may import only from EEIExtensionsEnableEpoch
+var eeiExtensionFunctions = []string{
+	"bigFloatAdd",
+	"bigFloatCmp",
+	"bigFloatDiv",
+	"bigFloatMul",
+	"bigFloatNewFromParts",
+	"bigFloatPow",
+	"bigFloatSqrt",
+	"bigFloatSub",
+	"bigFloatTruncate",
+	"bigIntGcd",
+	"bigIntIsPrime",
+	"bigIntPowMod",
+	"curvePointAdd",
+	"curvePointEqual",
+	"curvePointFromBytes",
+	"curvePointScalarBaseMult",
+	"curvePointScalarMult",
+	"curvePointToBytes",
+	"ecrecover",
+	"estimateAsyncCallGas",
+	"getBlockInfo",
+	"getDeterministicRandom",
+	"getESDTNFTAttributes",
+	"getESDTNFTURIs",
+	"getESDTRoyalties",
+	"getESDTTokenNameByIndex",
+	"getESDTTokenNonceByIndex",
+	"getESDTValueByIndex",
+	"getNumESDTTransfers",
+	"getReturnDataBudget",
+	"mBufferAppend",
+	"mBufferCompareSlice",
+	"mBufferCopySlice",
+	"mBufferFind",
+	"mBufferGetBytes",
+	"mBufferGetLength",
+	"mBufferNew",
+	"mBufferNewFromBytes",
+	"mBufferSetBytes",
+	"mBufferSplit",
+	"managedMapContains",
+	"managedMapGet",
+	"managedMapGetLength",
+	"managedMapKeyAtIndex",
+	"managedMapKeyLengthAtIndex",
+	"managedMapLength",
+	"managedMapNew",
+	"managedMapPut",
+	"managedMapRemove",
+	"mimc",
+	"multiTransferESDTNFTExecute",
+	"poseidon",
+	"registerDeferredCall",
+	"storageKeysWithPrefix",
+	"storageLoadFromContract",
+	"storageLoadLengthFromContract",
+	"transferESDTAndExecute",
+	"transientLoad",
+	"transientStore",
+	"upgradeAndCall",
+	"verifyBLSAggregated",
+	"verifyEd25519Batch",
+}
+
+// NewFunctionActivationMap creates a FunctionActivationMap in which every EEI
+// extension function is active from the given epoch, then applies the given
+// overrides, which may also list functions of the original API
+func NewFunctionActivationMap(eeiExtensionsEnableEpoch uint32, overrides FunctionActivationMap) FunctionActivationMap {
+	activationMap := make(FunctionActivationMap, len(eeiExtensionFunctions)+len(overrides))
+	for _, functionName := range eeiExtensionFunctions {
+		activationMap[functionName] = eeiExtensionsEnableEpoch
+	}
+	for functionName, activationEpoch := range overrides {
+		activationMap[functionName] = activationEpoch
+	}
+
+	return activationMap
+}
+
+// IsActive returns true if the given function may be imported in the given epoch
+func (activationMap FunctionActivationMap) IsActive(functionName string, epoch uint32) bool {
+	activationEpoch, ok := activationMap[functionName]
+	return !ok || epoch >= activationEpoch
+}
+
+// CheckImports returns ErrFunctionNotActivated for the first function, in
+// alphabetical order, which is imported according to isImported but is not
+// active in the given epoch
+func (activationMap FunctionActivationMap) CheckImports(epoch uint32, isImported func(functionName string) bool) error {
+	inactiveFunctions := make([]string, 0)
+	for functionName := range activationMap {
+		if !activationMap.IsActive(functionName, epoch) {
+			inactiveFunctions = append(inactiveFunctions, functionName)
+		}
+	}
+	sort.Strings(inactiveFunctions)
+
+	for _, functionName := range inactiveFunctions {
+		if isImported(functionName) {
+			return fmt.Errorf("%w: %s, active from epoch %d", ErrFunctionNotActivated, functionName, activationMap[functionName])
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,47 @@
+package arwen
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFunctionActivationMap_IsActive(t *testing.T) {
+	activationMap := FunctionActivationMap{"newFunction": 5}
+
+	require.True(t, activationMap.IsActive("oldFunction", 0))
+	require.False(t, activationMap.IsActive("newFunction", 4))
+	require.True(t, activationMap.IsActive("newFunction", 5))
+	require.True(t, FunctionActivationMap(nil).IsActive("newFunction", 0))
+}
+
+func TestNewFunctionActivationMap(t *testing.T) {
+	activationMap := NewFunctionActivationMap(5, FunctionActivationMap{"int64storageStore": 3, "mBufferNew": 7})
+
+	require.True(t, activationMap.IsActive("getSCAddress", 0))
+	require.False(t, activationMap.IsActive("mBufferAppend", 4))
+	require.True(t, activationMap.IsActive("mBufferAppend", 5))
+	require.False(t, activationMap.IsActive("int64storageStore", 2))
+	require.False(t, activationMap.IsActive("mBufferNew", 6))
+	require.True(t, activationMap.IsActive("mBufferNew", 7))
+}
+
+func TestFunctionActivationMap_CheckImports(t *testing.T) {
+	activationMap := FunctionActivationMap{"first": 5, "second": 10, "third": 10}
+	imported := map[string]bool{"second": true, "third": true}
+	isImported := func(functionName string) bool {
+		return imported[functionName]
+	}
+
+	err := activationMap.CheckImports(4, isImported)
+	require.True(t, errors.Is(err, ErrFunctionNotActivated))
+	require.Contains(t, err.Error(), "second, active from epoch 10")
+
+	err = activationMap.CheckImports(10, isImported)
+	require.Nil(t, err)
+
+	imported = map[string]bool{"first": true}
+	err = activationMap.CheckImports(7, isImported)
+	require.Nil(t, err)
+}
@@ -0,0 +1,59 @@
+package arwen
+
+import (
+	"math/big"
+
+	"github.com/ElrondNetwork/elrond-go/core/vmcommon"
+)
+
+// GasModifierLogIdentifier is the identifier of the log entry which records
+// in the VMOutput the GasModifier applied to the gas used by a contract
+const GasModifierLogIdentifier = "gasModifier"
+
+// GasModifier scales the gas used by a contract by Numerator / Denominator,
+// making the contract cheaper (a discount) or more expensive (a premium)
+type GasModifier struct {
+	Numerator   uint64
+	Denominator uint64
+}
+
+// IsValid returns true if the GasModifier can be applied
+func (modifier GasModifier) IsValid() bool {
+	return modifier.Denominator > 0
+}
+
+// Apply returns the given gas scaled by the GasModifier, saturating at the
+// maximum uint64 value
+func (modifier GasModifier) Apply(gas uint64) uint64 {
+	scaled := big.NewInt(0).SetUint64(gas)
+	scaled.Mul(scaled, big.NewInt(0).SetUint64(modifier.Numerator))
+	scaled.Div(scaled, big.NewInt(0).SetUint64(modifier.Denominator))
+	if !scaled.IsUint64() {
+		return ^uint64(0)
+	}
+
+	return scaled.Uint64()
+}
+
+// LogEntry encodes the application of the GasModifier to the gas used by the
+// contract at the given address as a VMOutput log entry; its topics are the
+// numerator, the denominator, the gas used before and the gas used after
+func (modifier GasModifier) LogEntry(address []byte, gasUsed uint64, modifiedGasUsed uint64) *vmcommon.LogEntry {
+	return &vmcommon.LogEntry{
+		Identifier: []byte(GasModifierLogIdentifier),
+		Address:    address,
+		Topics: [][]byte{
+			big.NewInt(0).SetUint64(modifier.Numerator).Bytes(),
+			big.NewInt(0).SetUint64(modifier.Denominator).Bytes(),
+			big.NewInt(0).SetUint64(gasUsed).Bytes(),
+			big.NewInt(0).SetUint64(modifiedGasUsed).Bytes(),
+		},
+	}
+}
+
+// GasModifierProvider can optionally be implemented by the BlockchainHook
+// given to the VMHost, in order to define a GasModifier for the contracts
+// at certain addresses, such as the system smart contracts
+type GasModifierProvider interface {
+	GetGasModifier(address []byte) (GasModifier, bool)
+}
@@ -0,0 +1,30 @@
+package arwen
+
+// HostFunctionGas is the gas charged by a host function, over all its calls
+type HostFunctionGas struct {
+	Name string
+	Gas  uint64
+}
+
+// GasProfile reports where the gas used by an execution was spent. Wasmer
+// only exposes the total gas of the executed WASM instructions, therefore
+// WASMOpcodes is the gas which remains after subtracting all the other
+// entries from GasUsed; it also covers the gas consumed by failed nested
+// executions and by protocol operations outside the host functions, such as
+// async call steps. The host functions are sorted by gas, descending.
+type GasProfile struct {
+	GasUsed          uint64
+	InitialCost      uint64
+	BuiltinFunctions uint64
+	HostFunctions    []HostFunctionGas
+	WASMOpcodes      uint64
+}
+
+// HostFunctionsGas returns the total gas charged by the host functions
+func (profile *GasProfile) HostFunctionsGas() uint64 {
+	total := uint64(0)
+	for _, hostFunction := range profile.HostFunctions {
+		total += hostFunction.Gas
+	}
+	return total
+}
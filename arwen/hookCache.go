@@ -0,0 +1,19 @@
+package arwen
+
+// HookCacheStats counts the BlockchainHook calls of an execution which were
+// answered by the hook cache of the VMHost, and those forwarded to the node
+type HookCacheStats struct {
+	Hits   uint64
+	Misses uint64
+}
+
+// HitRatio returns the fraction of the cacheable BlockchainHook calls which
+// were answered by the hook cache, or 0 if there were none
+func (stats *HookCacheStats) HitRatio() float64 {
+	total := stats.Hits + stats.Misses
+	if total == 0 {
+		return 0
+	}
+
+	return float64(stats.Hits) / float64(total)
+}
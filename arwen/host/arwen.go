@@ -1,6 +1,7 @@
 package host
 
 import (
+	"errors"
 	"fmt"
 	"sync"
 
@@ -12,6 +13,7 @@ import (
 	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/crypto"
 	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/wasmer"
 	logger "github.com/ElrondNetwork/elrond-go-logger"
+	"github.com/ElrondNetwork/elrond-go-logger/check"
 	"github.com/ElrondNetwork/elrond-go/core/atomic"
 	"github.com/ElrondNetwork/elrond-go/core/vmcommon"
 )
@@ -44,6 +46,33 @@ type vmHost struct {
 	gasSchedule              config.GasScheduleMap
 	scAPIMethods             *wasmer.Imports
 	protocolBuiltinFunctions vmcommon.FunctionNames
+	executionPolicy          arwen.ExecutionPolicy
+	asyncTracer              arwen.AsyncTracer
+	witnessRecorder          *witnessRecorder
+	executionProfiler        *executionProfiler
+	executionWatchdog        *executionWatchdog
+	deploymentLimits         arwen.DeploymentLimits
+	accessRecorder           *accessRecorder
+	hookCache                *hookCache
+	enableEpochsHandler      arwen.EnableEpochsHandler
+	builtinOutputProcessors  map[string]arwen.BuiltinOutputProcessor
+
+	asyncCallTree     *arwen.AsyncCallTree
+	asyncCallNodes    []*arwen.AsyncCallNode
+	numAsyncCallbacks int
+
+	paymentNotificationGasLimit uint64
+	paymentNotifications        []*arwen.PaymentNotification
+
+	touchedAccounts    *arwen.TouchedAccounts
+	storageDiffEnabled bool
+	storageDiff        *arwen.StorageDiff
+
+	storageKeyRecordingEnabled bool
+	touchedStorageKeys         *arwen.TouchedStorageKeys
+
+	maxQueryGasLimit uint64
+	receiptsEnabled  bool
 
 	arwenV2EnableEpoch uint32
 	flagArwenV2        atomic.Flag
@@ -59,6 +88,44 @@ type vmHost struct {
 
 	eSDTFunctionsEnableEpoch uint32
 	flagESDTFunctions        atomic.Flag
+
+	callbackValidationEnableEpoch uint32
+	flagCallbackValidation        atomic.Flag
+
+	refundReceiptsEnableEpoch uint32
+	flagRefundReceipts        atomic.Flag
+
+	storageLoadCacheEnableEpoch uint32
+	flagStorageLoadCache        atomic.Flag
+
+	flagMultiAsyncCallGroups atomic.Flag
+
+	asyncCallsFixEnableEpoch uint32
+	flagAsyncCallsFix        atomic.Flag
+
+	failedExecutionGasEnableEpoch uint32
+	flagFailedExecutionGas        atomic.Flag
+
+	eventLogValidationEnableEpoch uint32
+	flagEventLogValidation        atomic.Flag
+
+	readOnlyEnforcementEnableEpoch uint32
+	flagReadOnlyEnforcement        atomic.Flag
+
+	asyncCallGasCapEnableEpoch uint32
+	flagAsyncCallGasCap        atomic.Flag
+
+	logEntryGasEnableEpoch uint32
+	flagLogEntryGas        atomic.Flag
+
+	groupCallbacksEnableEpoch uint32
+	flagGroupCallbacks        atomic.Flag
+
+	asyncValueTransferEnableEpoch uint32
+	flagAsyncValueTransfer        atomic.Flag
+
+	callbackGasEscrowEnableEpoch uint32
+	flagCallbackGasEscrow        atomic.Flag
 }
 
 // NewArwenVM creates a new Arwen vmHost
@@ -83,6 +150,44 @@ func NewArwenVM(
 		arwenV3EnableEpoch:       hostParameters.ArwenV3EnableEpoch,
 		dynGasLockEnableEpoch:    hostParameters.DynGasLockEnableEpoch,
 		eSDTFunctionsEnableEpoch: hostParameters.ArwenESDTFunctionsEnableEpoch,
+		executionPolicy:          hostParameters.ExecutionPolicy,
+		asyncTracer:              hostParameters.AsyncTracer,
+		enableEpochsHandler:      hostParameters.EnableEpochsHandler,
+		deploymentLimits:         hostParameters.DeploymentLimits,
+		asyncCallTree:            arwen.NewAsyncCallTree(),
+		touchedAccounts:          &arwen.TouchedAccounts{},
+		builtinOutputProcessors:  newBuiltinOutputProcessors(),
+
+		paymentNotificationGasLimit:    hostParameters.PaymentNotificationGasLimit,
+		callbackValidationEnableEpoch:  hostParameters.CallbackValidationEnableEpoch,
+		refundReceiptsEnableEpoch:      hostParameters.RefundReceiptsEnableEpoch,
+		storageLoadCacheEnableEpoch:    hostParameters.StorageLoadCacheEnableEpoch,
+		maxQueryGasLimit:               hostParameters.MaxQueryGasLimit,
+		receiptsEnabled:                hostParameters.ReceiptsEnabled,
+		storageKeyRecordingEnabled:     hostParameters.StorageKeyRecordingEnabled,
+		storageDiffEnabled:             hostParameters.StorageDiffEnabled,
+		asyncCallsFixEnableEpoch:       hostParameters.AsyncCallsFixEnableEpoch,
+		failedExecutionGasEnableEpoch:  hostParameters.FailedExecutionGasEnableEpoch,
+		eventLogValidationEnableEpoch:  hostParameters.EventLogValidationEnableEpoch,
+		readOnlyEnforcementEnableEpoch: hostParameters.ReadOnlyEnforcementEnableEpoch,
+		asyncCallGasCapEnableEpoch:     hostParameters.AsyncCallGasCapEnableEpoch,
+		logEntryGasEnableEpoch:         hostParameters.LogEntryGasEnableEpoch,
+		groupCallbacksEnableEpoch:      hostParameters.GroupCallbacksEnableEpoch,
+		asyncValueTransferEnableEpoch:  hostParameters.AsyncValueTransferEnableEpoch,
+		callbackGasEscrowEnableEpoch:   hostParameters.CallbackGasEscrowEnableEpoch,
+	}
+
+	host.accessRecorder = newAccessRecorder(blockChainHook)
+	blockChainHook = host.accessRecorder
+
+	if hostParameters.ExecutionWitnessEnabled {
+		host.witnessRecorder = newWitnessRecorder(blockChainHook)
+		blockChainHook = host.witnessRecorder
+	}
+
+	if hostParameters.HookCacheEnabled {
+		host.hookCache = newHookCache(blockChainHook)
+		blockChainHook = host.hookCache
 	}
 
 	var err error
@@ -97,6 +202,21 @@ func NewArwenVM(
 		return nil, err
 	}
 
+	imports, err = elrondapi.BigFloatImports(imports)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = elrondapi.ManagedMapImports(imports)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err = elrondapi.ManagedBufferImports(imports)
+	if err != nil {
+		return nil, err
+	}
+
 	imports, err = elrondapi.SmallIntImports(imports)
 	if err != nil {
 		return nil, err
@@ -107,6 +227,11 @@ func NewArwenVM(
 		return nil, err
 	}
 
+	imports, err = cryptoapi.EllipticCurveImports(imports)
+	if err != nil {
+		return nil, err
+	}
+
 	err = wasmer.SetImports(imports)
 	if err != nil {
 		return nil, err
@@ -127,21 +252,96 @@ func NewArwenVM(
 	if err != nil {
 		return nil, err
 	}
+	if hostParameters.ExecutorFactory != nil {
+		executor, err := hostParameters.ExecutorFactory()
+		if err != nil {
+			return nil, err
+		}
+		host.runtimeContext.SetExecutor(executor)
+	}
+	if hostParameters.ExecutionTimeout > 0 {
+		host.executionWatchdog = newExecutionWatchdog(hostParameters.ExecutionTimeout, host.runtimeContext)
+	}
+	if hostParameters.InstancePoolSize > 0 {
+		host.runtimeContext.EnableInstancePool(hostParameters.InstancePoolSize)
+	}
+	host.runtimeContext.MemoryAccountant().SetCeiling(hostParameters.MemoryCeiling)
+	host.runtimeContext.SetInstanceMemoryLimits(arwen.InstanceMemoryLimits{
+		InitialMemoryPages: hostParameters.InitialMemoryPages,
+		MaxMemoryPages:     hostParameters.MaxMemoryPages,
+		MaxMemoryGrow:      hostParameters.MaxMemoryGrow,
+	})
+	host.runtimeContext.SetValidationConfig(hostParameters.ValidationConfig)
+	host.runtimeContext.SetFunctionActivationMap(arwen.NewFunctionActivationMap(
+		hostParameters.EEIExtensionsEnableEpoch,
+		hostParameters.FunctionActivationMap,
+	))
+	if hostParameters.DebugStop.IsSet() && hostParameters.DebugStepCallback != nil {
+		host.runtimeContext.SetDebugStop(hostParameters.DebugStop, hostParameters.DebugStepCallback)
+	}
+	if len(hostParameters.CompiledCodeCacheDirectory) > 0 && hostParameters.CompiledCodeCacheSize > 0 {
+		err = host.runtimeContext.EnableCompiledCodeDiskCache(
+			hostParameters.CompiledCodeCacheDirectory,
+			hostParameters.CompiledCodeCacheSize,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	host.meteringContext, err = contexts.NewMeteringContext(host, hostParameters.GasSchedule, hostParameters.BlockGasLimit)
 	if err != nil {
 		return nil, err
 	}
+	if hostParameters.GasProfilingEnabled {
+		host.meteringContext.EnableGasProfiling()
+	}
+	if hostParameters.ExecutionProfilingEnabled {
+		host.executionProfiler = newExecutionProfiler(arwen.ExecutionProfileSampleInterval)
+	}
+	if hostParameters.MeteringAuditEnabled {
+		host.meteringContext.EnableAudit()
+	}
+	if hostParameters.UserErrorDebugEnabled {
+		host.runtimeContext.EnableUserErrorDebug()
+	}
+	host.updateUserErrorCapture()
+	if hostParameters.OutOfGasReceiptsEnabled {
+		host.runtimeContext.EnableOutOfGasReceipts()
+		host.meteringContext.EnableHostFunctionTracking()
+	}
+	if hostParameters.CallStackTracesEnabled {
+		host.runtimeContext.EnableCallStackTraces()
+	}
 
-	host.outputContext, err = contexts.NewOutputContext(host)
+	outputContext, err := contexts.NewOutputContext(host)
 	if err != nil {
 		return nil, err
 	}
-
-	host.storageContext, err = contexts.NewStorageContext(host, blockChainHook, hostParameters.ElrondProtectedKeyPrefix)
+	outputContext.SetErrorCodesInReturnMessage(hostParameters.ErrorCodesInReturnMessage)
+	outputContext.SetItemizedRefundsInVMOutput(hostParameters.ItemizedRefundsInVMOutput)
+	outputContext.SetLogRetentionPolicy(arwen.LogRetentionPolicy{
+		MaxEntries: hostParameters.MaxLogEntries,
+		MaxBytes:   hostParameters.MaxLogBytes,
+	})
+	outputContext.SetMaxReturnDataSize(hostParameters.MaxReturnDataSize)
+	outputContext.SetTransferAggregation(hostParameters.TransferAggregationEnabled)
+	outputContext.SetEventLogLimits(arwen.EventLogLimits{
+		MaxTopics:      hostParameters.MaxLogTopics,
+		MaxTopicLength: hostParameters.MaxLogTopicLength,
+	})
+	host.outputContext = outputContext
+
+	storageContext, err := contexts.NewStorageContext(host, blockChainHook, hostParameters.ElrondProtectedKeyPrefix)
 	if err != nil {
 		return nil, err
 	}
+	storageContext.SetStorageLimits(arwen.StorageLimits{
+		MaxKeyLength:           hostParameters.MaxStorageKeyLength,
+		MaxValueLength:         hostParameters.MaxStorageValueLength,
+		MaxContractStorageSize: hostParameters.MaxContractStorageSize,
+	})
+	host.storageContext = storageContext
 
 	host.bigIntContext, err = contexts.NewBigIntContext()
 	if err != nil {
@@ -154,6 +354,7 @@ func NewArwenVM(
 	}
 
 	host.runtimeContext.SetMaxInstanceCount(MaximumWasmerInstanceCount)
+	host.runtimeContext.SetMaxExecutionStackDepth(hostParameters.MaxExecutionStackDepth)
 
 	opcodeCosts := gasCostConfig.WASMOpcodeCost.ToOpcodeCostsArray()
 	wasmer.SetOpcodeCosts(&opcodeCosts)
@@ -228,6 +429,71 @@ func (host *vmHost) IsESDTFunctionsEnabled() bool {
 	return host.flagESDTFunctions.IsSet()
 }
 
+// IsCallbackValidationEnabled returns whether the callbacks of async calls are validated when registered
+func (host *vmHost) IsCallbackValidationEnabled() bool {
+	return host.flagCallbackValidation.IsSet()
+}
+
+// IsRefundReceiptsEnabled returns whether the VMOutput of top-level executions records their refund receipts
+func (host *vmHost) IsRefundReceiptsEnabled() bool {
+	return host.flagRefundReceipts.IsSet()
+}
+
+// IsStorageLoadCacheEnabled returns whether the repeated loads of a storage key are charged the cached cost
+func (host *vmHost) IsStorageLoadCacheEnabled() bool {
+	return host.flagStorageLoadCache.IsSet()
+}
+
+// IsMultiAsyncCallGroupsEnabled returns whether async calls may be registered in multiple groups
+func (host *vmHost) IsMultiAsyncCallGroupsEnabled() bool {
+	return host.flagMultiAsyncCallGroups.IsSet()
+}
+
+// IsAsyncCallsFixEnabled returns whether the fixes to the gas and to the pending calls of async calls are active
+func (host *vmHost) IsAsyncCallsFixEnabled() bool {
+	return host.flagAsyncCallsFix.IsSet()
+}
+
+// IsFailedExecutionGasEnabled returns whether a nested execution consumes all the gas provided to it even when it fails before the contract runs
+func (host *vmHost) IsFailedExecutionGasEnabled() bool {
+	return host.flagFailedExecutionGas.IsSet()
+}
+
+// IsEventLogValidationEnabled returns whether the event logs written by contracts are validated against the EventLogLimits
+func (host *vmHost) IsEventLogValidationEnabled() bool {
+	return host.flagEventLogValidation.IsSet()
+}
+
+// IsReadOnlyEnforcementEnabled returns whether the EEI functions modifying the state fail in read-only executions, which SC queries are as well
+func (host *vmHost) IsReadOnlyEnforcementEnabled() bool {
+	return host.flagReadOnlyEnforcement.IsSet()
+}
+
+// IsAsyncCallGasCapEnabled returns whether the gas provided to the destination of an async call executed synchronously is capped to the gas limit of the call
+func (host *vmHost) IsAsyncCallGasCapEnabled() bool {
+	return host.flagAsyncCallGasCap.IsSet()
+}
+
+// IsLogEntryGasEnabled returns whether the writing of logs is charged per topic, per topic byte and per data byte
+func (host *vmHost) IsLogEntryGasEnabled() bool {
+	return host.flagLogEntryGas.IsSet()
+}
+
+// IsGroupCallbacksEnabled returns whether the callbacks of completed AsyncContexts are executed, rolling back only their own changes on failure
+func (host *vmHost) IsGroupCallbacksEnabled() bool {
+	return host.flagGroupCallbacks.IsSet()
+}
+
+// IsAsyncValueTransferEnabled returns whether the async calls without Data are forwarded to their destination as plain value transfers
+func (host *vmHost) IsAsyncValueTransferEnabled() bool {
+	return host.flagAsyncValueTransfer.IsSet()
+}
+
+// IsCallbackGasEscrowEnabled returns whether the gas of the callback of an async context call is escrowed when the call is registered
+func (host *vmHost) IsCallbackGasEscrowEnabled() bool {
+	return host.flagCallbackGasEscrow.IsSet()
+}
+
 // GetContexts returns the main contexts of the host
 func (host *vmHost) GetContexts() (
 	arwen.BigIntContext,
@@ -248,6 +514,15 @@ func (host *vmHost) GetContexts() (
 // InitState resets the contexts of the host and reconfigures its flags
 func (host *vmHost) InitState() {
 	host.initContexts()
+	if host.witnessRecorder != nil {
+		host.witnessRecorder.reset()
+	}
+	host.accessRecorder.reset()
+	if host.hookCache != nil {
+		host.hookCache.reset()
+	}
+	host.meteringContext.ResetGasProfile()
+	host.resetAsyncCallTree()
 	currentEpoch := host.Blockchain().CurrentEpoch()
 	host.flagArwenV2.Toggle(currentEpoch >= host.arwenV2EnableEpoch)
 	log.Trace("arwenV2", "enabled", host.flagArwenV2.IsSet())
@@ -263,6 +538,48 @@ func (host *vmHost) InitState() {
 
 	host.flagESDTFunctions.Toggle(currentEpoch >= host.eSDTFunctionsEnableEpoch)
 	log.Trace("esdt functions", "enabled", host.flagESDTFunctions.IsSet())
+
+	host.flagCallbackValidation.Toggle(currentEpoch >= host.callbackValidationEnableEpoch)
+	log.Trace("callback validation", "enabled", host.flagCallbackValidation.IsSet())
+
+	host.flagRefundReceipts.Toggle(currentEpoch >= host.refundReceiptsEnableEpoch)
+	log.Trace("refund receipts", "enabled", host.flagRefundReceipts.IsSet())
+
+	host.flagStorageLoadCache.Toggle(currentEpoch >= host.storageLoadCacheEnableEpoch)
+	log.Trace("storage load cache", "enabled", host.flagStorageLoadCache.IsSet())
+
+	// without an EnableEpochsHandler, the multiple async call groups are always enabled
+	multiAsyncCallGroupsEnabled := check.IfNil(host.enableEpochsHandler) ||
+		host.enableEpochsHandler.IsMultiAsyncCallGroupsEnabledInEpoch(currentEpoch)
+	host.flagMultiAsyncCallGroups.Toggle(multiAsyncCallGroupsEnabled)
+	log.Trace("multiple async call groups", "enabled", host.flagMultiAsyncCallGroups.IsSet())
+
+	host.flagAsyncCallsFix.Toggle(currentEpoch >= host.asyncCallsFixEnableEpoch)
+	log.Trace("async calls fix", "enabled", host.flagAsyncCallsFix.IsSet())
+
+	host.flagFailedExecutionGas.Toggle(currentEpoch >= host.failedExecutionGasEnableEpoch)
+	log.Trace("failed execution gas", "enabled", host.flagFailedExecutionGas.IsSet())
+
+	host.flagEventLogValidation.Toggle(currentEpoch >= host.eventLogValidationEnableEpoch)
+	log.Trace("event log validation", "enabled", host.flagEventLogValidation.IsSet())
+
+	host.flagReadOnlyEnforcement.Toggle(currentEpoch >= host.readOnlyEnforcementEnableEpoch)
+	log.Trace("read-only enforcement", "enabled", host.flagReadOnlyEnforcement.IsSet())
+
+	host.flagAsyncCallGasCap.Toggle(currentEpoch >= host.asyncCallGasCapEnableEpoch)
+	log.Trace("async call gas cap", "enabled", host.flagAsyncCallGasCap.IsSet())
+
+	host.flagLogEntryGas.Toggle(currentEpoch >= host.logEntryGasEnableEpoch)
+	log.Trace("log entry gas", "enabled", host.flagLogEntryGas.IsSet())
+
+	host.flagGroupCallbacks.Toggle(currentEpoch >= host.groupCallbacksEnableEpoch)
+	log.Trace("group callbacks", "enabled", host.flagGroupCallbacks.IsSet())
+
+	host.flagAsyncValueTransfer.Toggle(currentEpoch >= host.asyncValueTransferEnableEpoch)
+	log.Trace("async value transfers", "enabled", host.flagAsyncValueTransfer.IsSet())
+
+	host.flagCallbackGasEscrow.Toggle(currentEpoch >= host.callbackGasEscrowEnableEpoch)
+	log.Trace("callback gas escrow", "enabled", host.flagCallbackGasEscrow.IsSet())
 }
 
 func (host *vmHost) initContexts() {
@@ -273,6 +590,7 @@ func (host *vmHost) initContexts() {
 	host.runtimeContext.InitState()
 	host.storageContext.InitState()
 	host.ethInput = nil
+	host.paymentNotifications = nil
 }
 
 // ClearContextStateStack cleans the state stacks of all the contexts of the host
@@ -309,20 +627,122 @@ func (host *vmHost) SetProtocolBuiltinFunctions(functionNames vmcommon.FunctionN
 
 // GasScheduleChange applies a new gas schedule to the host
 func (host *vmHost) GasScheduleChange(newGasSchedule config.GasScheduleMap) {
-	host.mutExecution.Lock()
-	defer host.mutExecution.Unlock()
+	err := host.SetGasSchedule(newGasSchedule)
+	if err != nil {
+		log.Error("cannot apply new gas config remained with old one", "error", err)
+	}
+}
 
-	host.gasSchedule = newGasSchedule
+// SetGasSchedule validates the new gas schedule, then replaces the gas
+// schedule of the Metering context and the WASM opcode costs at once, in
+// between executions; an invalid gas schedule leaves the current one in place
+func (host *vmHost) SetGasSchedule(newGasSchedule config.GasScheduleMap) error {
 	gasCostConfig, err := config.CreateGasConfig(newGasSchedule)
 	if err != nil {
-		log.Error("cannot apply new gas config remained with old one")
-		return
+		return err
 	}
 
+	host.mutExecution.Lock()
+	defer host.mutExecution.Unlock()
+
+	host.gasSchedule = newGasSchedule
 	opcodeCosts := gasCostConfig.WASMOpcodeCost.ToOpcodeCostsArray()
 	wasmer.SetOpcodeCosts(&opcodeCosts)
 
 	host.meteringContext.SetGasSchedule(newGasSchedule)
+
+	// the warm instance and the cached compiled code were compiled with the
+	// previous opcode costs
+	host.runtimeContext.ResetWarmInstance()
+	host.runtimeContext.ClearCompiledCode()
+
+	return nil
+}
+
+// GetGasProfile returns where the gas of the last execution was spent; it
+// returns nil if the VMHost was not created with GasProfilingEnabled
+func (host *vmHost) GetGasProfile() *arwen.GasProfile {
+	host.mutExecution.RLock()
+	defer host.mutExecution.RUnlock()
+
+	return host.meteringContext.GetGasProfile()
+}
+
+// GetExecutionProfile returns the call stacks sampled during the last
+// RunSmartContractCall; it returns nil if the VMHost was not created with
+// ExecutionProfilingEnabled
+func (host *vmHost) GetExecutionProfile() *arwen.ExecutionProfile {
+	host.mutExecution.RLock()
+	defer host.mutExecution.RUnlock()
+
+	if host.executionProfiler == nil {
+		return nil
+	}
+
+	return host.executionProfiler.profile()
+}
+
+func (host *vmHost) startExecutionProfile() {
+	if host.executionProfiler != nil {
+		host.executionProfiler.start()
+	}
+}
+
+func (host *vmHost) stopExecutionProfile() {
+	if host.executionProfiler != nil {
+		host.executionProfiler.stop()
+	}
+}
+
+func (host *vmHost) startExecutionWatchdog() {
+	if host.executionWatchdog != nil {
+		host.executionWatchdog.start()
+	}
+}
+
+func (host *vmHost) stopExecutionWatchdog() {
+	if host.executionWatchdog != nil {
+		host.executionWatchdog.stop()
+	}
+}
+
+// callWasmFunction calls the given function exported by the current contract,
+// labeling its WASM code in the execution profile, if any, then checks the
+// memory limits of the current instance
+func (host *vmHost) callWasmFunction(function wasmer.ExportedFunctionCallback) error {
+	runtime := host.Runtime()
+	if host.executionProfiler != nil {
+		host.executionProfiler.enterWasm(runtime.GetSCAddress(), runtime.Function())
+	}
+
+	_, err := function()
+
+	if host.executionProfiler != nil {
+		host.executionProfiler.exitWasm()
+	}
+	if err != nil {
+		return err
+	}
+
+	return runtime.CheckInstanceMemoryLimits()
+}
+
+// GetDeploymentLimits returns the limits which the code of the contracts must
+// respect to be admitted for deployment or upgrade, allowing deployment tools
+// to check the code beforehand
+func (host *vmHost) GetDeploymentLimits() arwen.DeploymentLimits {
+	return host.deploymentLimits
+}
+
+// GetRuntimeConfig returns the configuration applied to the Wasmer instances of the contracts
+func (host *vmHost) GetRuntimeConfig() arwen.RuntimeConfig {
+	host.mutExecution.RLock()
+	defer host.mutExecution.RUnlock()
+
+	return arwen.RuntimeConfig{
+		MemoryLimits: host.runtimeContext.GetInstanceMemoryLimits(),
+		Validation:   host.runtimeContext.GetValidationConfig(),
+	}
 }
 
 // GetGasScheduleMap returns the currently stored gas schedule
@@ -337,7 +757,16 @@ func (host *vmHost) RunSmartContractCreate(input *vmcommon.ContractCreateInput)
 
 	log.Trace("RunSmartContractCreate begin", "len(code)", len(input.ContractCode), "metadata", input.ContractCodeMetadata)
 
+	decision := host.evaluateExecutionPolicy(&input.VMInput, nil, arwen.InitFunctionName)
+	if decision == arwen.ExecutionDeprioritized {
+		return nil, arwen.ErrExecutionDeprioritized
+	}
+
 	try := func() {
+		if decision == arwen.ExecutionRejected {
+			vmOutput = host.doRejectExecution(&input.VMInput)
+			return
+		}
 		vmOutput = host.doRunSmartContractCreate(input)
 	}
 
@@ -346,7 +775,18 @@ func (host *vmHost) RunSmartContractCreate(input *vmcommon.ContractCreateInput)
 		log.Error("RunSmartContractCreate", "error", err)
 	}
 
+	host.startExecutionWatchdog()
 	TryCatch(try, catch, "arwen.RunSmartContractCreate")
+	host.stopExecutionWatchdog()
+	host.recordTouchedAccounts(vmOutput)
+	host.recordStorageDiff(vmOutput)
+	host.recordTouchedStorageKeys()
+	if host.isMemoryLimitReached() {
+		return nil, arwen.ErrMemoryLimitReached
+	}
+	if host.isExecutionTimedOut() {
+		return nil, arwen.ErrExecutionTimeout
+	}
 	if vmOutput != nil {
 		log.Trace("RunSmartContractCreate end", "returnCode", vmOutput.ReturnCode, "returnMessage", vmOutput.ReturnMessage)
 	}
@@ -356,17 +796,37 @@ func (host *vmHost) RunSmartContractCreate(input *vmcommon.ContractCreateInput)
 
 // RunSmartContractCall executes the call of an existing contract
 func (host *vmHost) RunSmartContractCall(input *vmcommon.ContractCallInput) (vmOutput *vmcommon.VMOutput, err error) {
+	return host.runSmartContractCall(input, false)
+}
+
+// RunSmartContractQuery executes the call of an existing contract received
+// from the SC query service, in read-only mode and with its gas bounded by the
+// MaxQueryGasLimit
+func (host *vmHost) RunSmartContractQuery(input *vmcommon.ContractCallInput) (vmOutput *vmcommon.VMOutput, err error) {
+	return host.runSmartContractCall(host.prepareQueryInput(input), true)
+}
+
+func (host *vmHost) runSmartContractCall(input *vmcommon.ContractCallInput, readOnly bool) (vmOutput *vmcommon.VMOutput, err error) {
 	host.mutExecution.RLock()
 	defer host.mutExecution.RUnlock()
 
-	log.Trace("RunSmartContractCall begin", "function", input.Function)
+	log.Trace("RunSmartContractCall begin", "function", input.Function, "query", readOnly)
+
+	decision := host.evaluateExecutionPolicy(&input.VMInput, input.RecipientAddr, input.Function)
+	if decision == arwen.ExecutionDeprioritized {
+		return nil, arwen.ErrExecutionDeprioritized
+	}
+
+	tryReject := func() {
+		vmOutput = host.doRejectExecution(&input.VMInput)
+	}
 
 	tryUpgrade := func() {
-		vmOutput = host.doRunSmartContractUpgrade(input)
+		vmOutput = host.doRunSmartContractUpgrade(input, readOnly)
 	}
 
 	tryCall := func() {
-		vmOutput = host.doRunSmartContractCall(input)
+		vmOutput = host.doRunSmartContractCall(input, readOnly)
 
 		if host.hasRetriableExecutionError(vmOutput) {
 			log.Error("Retriable execution error detected. Will reset warm Wasmer instance.")
@@ -379,12 +839,28 @@ func (host *vmHost) RunSmartContractCall(input *vmcommon.ContractCallInput) (vmO
 		log.Error("RunSmartContractCall", "error", err)
 	}
 
+	host.startExecutionProfile()
+	host.startExecutionWatchdog()
 	isUpgrade := input.Function == arwen.UpgradeFunctionName
-	if isUpgrade {
+	if decision == arwen.ExecutionRejected {
+		TryCatch(tryReject, catch, "arwen.RunSmartContractCall")
+	} else if isUpgrade {
 		TryCatch(tryUpgrade, catch, "arwen.RunSmartContractUpgrade")
 	} else {
 		TryCatch(tryCall, catch, "arwen.RunSmartContractCall")
 	}
+	host.stopExecutionWatchdog()
+	host.stopExecutionProfile()
+	host.recordTouchedAccounts(vmOutput)
+	host.recordStorageDiff(vmOutput)
+	host.recordTouchedStorageKeys()
+
+	if host.isMemoryLimitReached() {
+		return nil, arwen.ErrMemoryLimitReached
+	}
+	if host.isExecutionTimedOut() {
+		return nil, arwen.ErrExecutionTimeout
+	}
 
 	return
 }
@@ -405,6 +881,20 @@ func TryCatch(try TryFunction, catch CatchFunction, catchFallbackMessage string)
 	try()
 }
 
+// isMemoryLimitReached returns true if the last execution was interrupted
+// because the memory of the process was near its ceiling; its output is then
+// discarded, so that the node can retry the execution later
+func (host *vmHost) isMemoryLimitReached() bool {
+	return errors.Is(host.GetRuntimeErrors(), arwen.ErrMemoryLimitReached)
+}
+
+// isExecutionTimedOut returns true if the last execution was interrupted
+// because it lasted longer than allowed; its output is then discarded, as
+// wall-clock time does not yield the same outcome on every node
+func (host *vmHost) isExecutionTimedOut() bool {
+	return host.executionWatchdog != nil && host.executionWatchdog.hasExpired()
+}
+
 func (host *vmHost) hasRetriableExecutionError(vmOutput *vmcommon.VMOutput) bool {
 	if !host.runtimeContext.IsWarmInstance() {
 		return false
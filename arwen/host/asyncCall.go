@@ -2,15 +2,14 @@ package host
 
 import (
 	"bytes"
-	"encoding/hex"
 	"encoding/json"
 	"math/big"
+	"sort"
 
 	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
 	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/math"
 	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/wasmer"
 	"github.com/ElrondNetwork/elrond-go/core"
-	"github.com/ElrondNetwork/elrond-go/core/parsers"
 	"github.com/ElrondNetwork/elrond-go/core/vmcommon"
 )
 
@@ -28,6 +27,10 @@ func (host *vmHost) handleAsyncCallBreakpoint() error {
 
 	log.Trace("async call", "execMode", execMode)
 
+	isCrossShard := execMode == arwen.AsyncUnknown || execMode == arwen.AsyncBuiltinFuncCrossShard
+	host.traceAsyncCallRegistered(arwen.LegacyAsyncContextIdentifier, asyncCallInfo, isCrossShard)
+	node := host.addAsyncCallNode(arwen.LegacyAsyncContextIdentifier, asyncCallInfo, isCrossShard || execMode == arwen.ESDTTransferOnCallBack)
+
 	if execMode == arwen.AsyncUnknown {
 		err = host.sendAsyncCallToDestination(asyncCallInfo)
 		if err != nil {
@@ -57,14 +60,29 @@ func (host *vmHost) handleAsyncCallBreakpoint() error {
 		// via a reversed async call. The reversed async call will not have a
 		// callback, therefore the gas locked for callback execution must be
 		// restored.
-		host.Metering().RestoreGas(asyncCallInfo.GetGasLocked())
+		host.Metering().RestoreLockedGas(asyncCallInfo.GetGasLocked())
+		host.Output().AddRefund(arwen.RefundUnusedLockedGas, asyncCallInfo.GetGasLocked())
 		return nil
 	}
 
 	// Start calling the destination SC, synchronously.
+	host.pushAsyncCallNode(node)
 	destinationVMOutput, destinationErr := host.executeSyncDestinationCall(asyncCallInfo)
+	host.popAsyncCallNode()
+	host.traceAsyncCallExecuted(arwen.LegacyAsyncContextIdentifier, asyncCallInfo, destinationVMOutput)
+	completeAsyncCallNode(node, destinationVMOutput, false)
 
+	host.recordAsyncCallNodeCallback(node, arwen.CallbackFunctionName)
 	callbackVMOutput, callBackErr := host.executeSyncCallbackCall(asyncCallInfo, destinationVMOutput, destinationErr)
+	host.addAsyncCallRefunds(asyncCallInfo.GetGasLocked(), callbackVMOutput, callBackErr)
+	if host.isAsyncTracingEnabled() && destinationVMOutput != nil {
+		status := arwen.AsyncCallResolved
+		if destinationVMOutput.ReturnCode != vmcommon.Ok {
+			status = arwen.AsyncCallRejected
+		}
+		host.traceCallbackExecuted(arwen.LegacyAsyncContextIdentifier, asyncCallInfo, arwen.CallbackFunctionName, status, callbackVMOutput)
+		host.traceGroupCompleted(arwen.LegacyAsyncContextIdentifier, arwen.CallbackFunctionName)
+	}
 
 	err = host.processCallbackVMOutput(callbackVMOutput, callBackErr)
 	if err != nil {
@@ -79,7 +97,7 @@ func isESDTTransferOnReturnDataWithNoAdditionalData(destinationVMOutput *vmcommo
 		return false, "", nil
 	}
 
-	argParser := parsers.NewCallArgsParser()
+	argParser := arwen.NewCallDataParser()
 	functionName, args, err := argParser.ParseData(string(destinationVMOutput.ReturnData[0]))
 	if err != nil {
 		return false, "", nil
@@ -105,9 +123,16 @@ func (host *vmHost) determineAsyncCallExecutionMode(asyncCallInfo *arwen.AsyncCa
 	runtime := host.Runtime()
 	blockchain := host.Blockchain()
 
+	// An async call without Data is a pure value transfer; it is forwarded to
+	// the destination as a simple transfer, and the callback will be executed
+	// when the destination responds.
+	if host.isAsyncValueTransfer(asyncCallInfo.Data) {
+		return arwen.AsyncUnknown, nil
+	}
+
 	// If ArgParser cannot read the Data field, then this is neither a SC call,
 	// nor a built-in function call.
-	argParser := parsers.NewCallArgsParser()
+	argParser := arwen.NewCallDataParser()
 	functionName, args, err := argParser.ParseData(string(asyncCallInfo.Data))
 	if err != nil {
 		return arwen.AsyncUnknown, err
@@ -160,6 +185,11 @@ func (host *vmHost) executeSyncDestinationCall(asyncCallInfo arwen.AsyncCallInfo
 	return destinationVMOutput, err
 }
 
+// executeSyncCallbackCall runs the callback on the caller's context. Its
+// output is merged after the output of the caller and of the destination:
+// logs and return data are appended, while storage updates override the
+// earlier ones. When the callback fails, only the error recorded by
+// processCallbackVMOutput is kept.
 func (host *vmHost) executeSyncCallbackCall(
 	asyncCallInfo arwen.AsyncCallInfoHandler,
 	destinationVMOutput *vmcommon.VMOutput,
@@ -185,9 +215,10 @@ func (host *vmHost) executeSyncCallbackCall(
 
 	// Restore gas locked while still on the caller instance; otherwise, the
 	// locked gas will appear to have been used twice by the caller instance.
-	host.Metering().RestoreGas(asyncCallInfo.GetGasLocked())
+	host.Metering().RestoreLockedGas(asyncCallInfo.GetGasLocked())
 
 	callbackVMOutput, _, callBackErr := host.ExecuteOnDestContext(callbackCallInput)
+	host.addCallbackReceipt(callbackCallInput, callbackVMOutput)
 	if callbackVMOutput != nil {
 		log.Trace("async call: sync callback call",
 			"retCode", callbackVMOutput.ReturnCode,
@@ -199,6 +230,41 @@ func (host *vmHost) executeSyncCallbackCall(
 	return callbackVMOutput, callBackErr
 }
 
+// addAsyncCallRefunds records the gas given back to the caller by the
+// callback of an async call executed on this host. The gas returned by the
+// callback is first attributed to the gas locked for it, then to the gas left
+// unused by the destination.
+func (host *vmHost) addAsyncCallRefunds(gasLocked uint64, callbackVMOutput *vmcommon.VMOutput, callBackErr error) {
+	if callBackErr != nil || callbackVMOutput == nil {
+		return
+	}
+
+	gasReturned := callbackVMOutput.GasRemaining
+	unusedLockedGas := gasReturned
+	if unusedLockedGas > gasLocked {
+		unusedLockedGas = gasLocked
+	}
+
+	output := host.Output()
+	output.AddRefund(arwen.RefundUnusedLockedGas, unusedLockedGas)
+	output.AddRefund(arwen.RefundAsyncCall, gasReturned-unusedLockedGas)
+}
+
+func (host *vmHost) isAsyncValueTransfer(data []byte) bool {
+	return host.IsAsyncValueTransferEnabled() && len(data) == 0
+}
+
+// canExecuteAsyncCallSynchronously returns whether the generated async call
+// can be executed on this host; value transfers without Data are always
+// forwarded to the destination as simple transfers
+func (host *vmHost) canExecuteAsyncCallSynchronously(asyncCall *arwen.AsyncGeneratedCall) bool {
+	if host.isAsyncValueTransfer(asyncCall.Data) {
+		return false
+	}
+
+	return host.canExecuteSynchronously(asyncCall.Destination, asyncCall.Data)
+}
+
 func (host *vmHost) canExecuteSynchronously(destination []byte, _ []byte) bool {
 	// TODO replace this function in promise-related code below.
 	blockchain := host.Blockchain()
@@ -240,10 +306,7 @@ func (host *vmHost) sendCallbackToCurrentCaller() error {
 	metering := host.Metering()
 	currentCall := runtime.GetVMInput()
 
-	retData := []byte("@" + hex.EncodeToString([]byte(output.ReturnCode().String())))
-	for _, data := range output.ReturnData() {
-		retData = append(retData, []byte("@"+hex.EncodeToString(data))...)
-	}
+	retData := arwen.EncodeCallArguments(append([][]byte{[]byte(output.ReturnCode().String())}, output.ReturnData()...))
 
 	err := output.Transfer(
 		currentCall.CallerAddr,
@@ -286,6 +349,11 @@ func (host *vmHost) sendStorageCallbackToDestination(callerAddress, returnData [
 		return err
 	}
 
+	if host.IsAsyncCallsFixEnabled() {
+		// the gas left was passed on to the callback
+		gasLeft := metering.GasLeft()
+		metering.UseGas(gasLeft)
+	}
 	return nil
 }
 
@@ -294,12 +362,19 @@ func (host *vmHost) createDestinationContractCallInput(asyncCallInfo arwen.Async
 	sender := runtime.GetSCAddress()
 	metering := host.Metering()
 
-	argParser := parsers.NewCallArgsParser()
+	argParser := arwen.NewCallDataParser()
 	function, arguments, err := argParser.ParseData(string(asyncCallInfo.GetData()))
 	if err != nil {
 		return nil, err
 	}
 
+	// the destination is limited to the gas of the async call, which also
+	// bounds the async calls it registers in turn
+	gasProvided := metering.GasLeft()
+	if host.IsAsyncCallGasCapEnabled() && asyncCallInfo.GetGasLimit() < gasProvided {
+		gasProvided = asyncCallInfo.GetGasLimit()
+	}
+
 	contractCallInput := &vmcommon.ContractCallInput{
 		VMInput: vmcommon.VMInput{
 			CallerAddr:     sender,
@@ -307,7 +382,7 @@ func (host *vmHost) createDestinationContractCallInput(asyncCallInfo arwen.Async
 			CallValue:      big.NewInt(0).SetBytes(asyncCallInfo.GetValueBytes()),
 			CallType:       vmcommon.AsynchronousCall,
 			GasPrice:       runtime.GetVMInput().GasPrice,
-			GasProvided:    metering.GasLeft(),
+			GasProvided:    gasProvided,
 			GasLocked:      asyncCallInfo.GetGasLocked(),
 			CurrentTxHash:  runtime.GetCurrentTxHash(),
 			OriginalTxHash: runtime.GetOriginalTxHash(),
@@ -375,7 +450,10 @@ func (host *vmHost) createCallbackContractCallInput(
 		arguments = append(arguments, []byte(destinationVMOutput.ReturnMessage))
 	}
 
-	gasLimit := destinationVMOutput.GasRemaining + asyncCallInfo.GetGasLocked()
+	gasLimit := destinationVMOutput.GasRemaining
+	if asyncCallInfo != nil {
+		gasLimit += asyncCallInfo.GetGasLocked()
+	}
 	dataLength := host.computeDataLengthFromArguments(callbackFunction, arguments)
 
 	gasToUse := gasSchedule.ElrondAPICost.AsyncCallStep
@@ -434,18 +512,22 @@ func (host *vmHost) processCallbackVMOutput(callbackVMOutput *vmcommon.VMOutput,
 	}
 
 	output.SetReturnMessage(callbackVMOutput.ReturnMessage)
-	output.Finish([]byte(callbackVMOutput.ReturnCode.String()))
-	output.Finish(runtime.GetCurrentTxHash())
+	err := output.Finish([]byte(callbackVMOutput.ReturnCode.String()))
+	if err != nil {
+		return err
+	}
 
-	return nil
+	return output.Finish(runtime.GetCurrentTxHash())
 }
 
 func (host *vmHost) computeDataLengthFromArguments(function string, arguments [][]byte) int {
 	// Calculate what length would the Data field have, were it of the
 	// form "callback@arg1@arg4...
 
-	// TODO this needs tests, especially for the case when the arguments slice
-	// contains an empty []byte
+	// The length is computed on the raw arguments, not on their hex encoding
+	// produced by arwen.EncodeCallData, because the gas charged for callbacks
+	// depends on it; an empty argument contributes only its separator, as in
+	// the encoded call data.
 	numSeparators := len(arguments)
 	dataLength := math.AddUint64(uint64(len(function)), uint64(numSeparators))
 	for _, element := range arguments {
@@ -474,16 +556,19 @@ func (host *vmHost) processAsyncInfo(asyncInfo *arwen.AsyncContextInfo) (*arwen.
 		return nil, err
 	}
 
-	for _, asyncContext := range asyncInfo.AsyncContextMap {
-		for _, asyncCall := range asyncContext.AsyncCalls {
-			if !host.canExecuteSynchronously(asyncCall.Destination, asyncCall.Data) {
-				continue
-			}
+	for _, contextIdentifier := range sortedAsyncContextIdentifiers(asyncInfo) {
+		procErr := host.processAsyncContext(contextIdentifier, asyncInfo.AsyncContextMap[contextIdentifier])
+		if procErr != nil {
+			return nil, procErr
+		}
+	}
 
-			procErr := host.processAsyncCall(asyncCall)
-			if procErr != nil {
-				return nil, procErr
-			}
+	host.traceCompletedAsyncContexts(asyncInfo)
+
+	if host.IsGroupCallbacksEnabled() {
+		err = host.executeCompletedGroupCallbacks(asyncInfo)
+		if err != nil {
+			return nil, err
 		}
 	}
 
@@ -497,14 +582,22 @@ func (host *vmHost) processAsyncInfo(asyncInfo *arwen.AsyncContextInfo) (*arwen.
 		return nil, err
 	}
 
-	err = host.setupAsyncCallsGas(pendingMapInfo)
+	// the pending calls executed on this host already received their gas,
+	// only the calls that leave this shard share the gas left
+	callsToSend := pendingMapInfo
+	if host.IsAsyncCallsFixEnabled() {
+		callsToSend = host.getCrossShardCalls(pendingMapInfo)
+	}
+	err = host.setupAsyncCallsGas(callsToSend)
 	if err != nil {
 		return nil, err
 	}
 
-	for _, asyncContext := range pendingMapInfo.AsyncContextMap {
-		for _, asyncCall := range asyncContext.AsyncCalls {
-			if !host.canExecuteSynchronously(asyncCall.Destination, asyncCall.Data) {
+	for _, contextIdentifier := range sortedAsyncContextIdentifiers(callsToSend) {
+		for _, asyncCall := range callsToSend.AsyncContextMap[contextIdentifier].AsyncCalls {
+			if !host.canExecuteAsyncCallSynchronously(asyncCall) {
+				host.traceAsyncCallRegistered(contextIdentifier, asyncCall, true)
+				host.addAsyncCallNode(contextIdentifier, asyncCall, true)
 				sendErr := host.sendAsyncCallToDestination(asyncCall)
 				if sendErr != nil {
 					return nil, sendErr
@@ -516,25 +609,113 @@ func (host *vmHost) processAsyncInfo(asyncInfo *arwen.AsyncContextInfo) (*arwen.
 	return pendingMapInfo, nil
 }
 
+func sortedAsyncContextIdentifiers(asyncInfo *arwen.AsyncContextInfo) []string {
+	contextIdentifiers := make([]string, 0, len(asyncInfo.AsyncContextMap))
+	for contextIdentifier := range asyncInfo.AsyncContextMap {
+		contextIdentifiers = append(contextIdentifiers, contextIdentifier)
+	}
+	sort.Strings(contextIdentifiers)
+
+	return contextIdentifiers
+}
+
+// completedAsyncCall is an async call executed synchronously, whose callback awaits execution
+type completedAsyncCall struct {
+	asyncCall      *arwen.AsyncGeneratedCall
+	node           *arwen.AsyncCallNode
+	vmOutput       *vmcommon.VMOutput
+	executionError error
+}
+
+// processAsyncContext executes the local async calls of an AsyncContext and their callbacks, in its CallbackOrder
+func (host *vmHost) processAsyncContext(contextIdentifier string, asyncContext *arwen.AsyncContext) error {
+	var deferredCallbacks []*completedAsyncCall
+	switch asyncContext.CallbackOrder {
+	case arwen.AsyncCallbackOrderCompletion:
+	case arwen.AsyncCallbackOrderRegistration:
+		deferredCallbacks = make([]*completedAsyncCall, 0, len(asyncContext.AsyncCalls))
+	default:
+		return arwen.ErrInvalidAsyncCallbackOrder
+	}
+
+	for _, asyncCall := range asyncContext.AsyncCalls {
+		if !host.canExecuteAsyncCallSynchronously(asyncCall) {
+			continue
+		}
+
+		host.traceAsyncCallRegistered(contextIdentifier, asyncCall, false)
+		node := host.addAsyncCallNode(contextIdentifier, asyncCall, false)
+		completed, err := host.processAsyncCall(contextIdentifier, asyncCall, node)
+		if err != nil {
+			return err
+		}
+		if completed == nil {
+			continue
+		}
+
+		if deferredCallbacks != nil {
+			deferredCallbacks = append(deferredCallbacks, completed)
+			continue
+		}
+
+		err = host.callbackAsync(contextIdentifier, completed)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, completed := range deferredCallbacks {
+		err := host.callbackAsync(contextIdentifier, completed)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 /**
- * processAsyncCall executes an async call and processes the callback if no extra calls are pending
+ * processAsyncCall executes an async call and returns it as completed, awaiting its callback, if no extra
+ *  calls are pending
  */
-func (host *vmHost) processAsyncCall(asyncCall *arwen.AsyncGeneratedCall) error {
+func (host *vmHost) processAsyncCall(
+	contextIdentifier string,
+	asyncCall *arwen.AsyncGeneratedCall,
+	node *arwen.AsyncCallNode,
+) (*completedAsyncCall, error) {
 	input, _ := host.createDestinationContractCallInput(asyncCall)
+	host.pushAsyncCallNode(node)
 	output, asyncMap, executionError := host.ExecuteOnDestContext(input)
-
-	pendingMap := host.getPendingAsyncCalls(asyncMap)
-	if len(pendingMap.AsyncContextMap) == 0 {
-		return host.callbackAsync(asyncCall, output, executionError)
-	}
-
-	return executionError
+	host.popAsyncCallNode()
+	host.traceAsyncCallExecuted(contextIdentifier, asyncCall, output)
+
+	// a destination which failed before completing has no async info, so no pending calls
+	isPending := false
+	if asyncMap != nil {
+		pendingMap := host.getPendingAsyncCalls(asyncMap)
+		isPending = len(pendingMap.AsyncContextMap) > 0
+	}
+	completeAsyncCallNode(node, output, isPending)
+	if !isPending {
+		return &completedAsyncCall{
+			asyncCall:      asyncCall,
+			node:           node,
+			vmOutput:       output,
+			executionError: executionError,
+		}, nil
+	}
+
+	return nil, executionError
 }
 
 /**
  * callbackAsync will execute a callback from an async call that was ran on this host and set it's status to resolved or rejected
  */
-func (host *vmHost) callbackAsync(asyncCall *arwen.AsyncGeneratedCall, vmOutput *vmcommon.VMOutput, executionError error) error {
+func (host *vmHost) callbackAsync(contextIdentifier string, completed *completedAsyncCall) error {
+	asyncCall := completed.asyncCall
+	vmOutput := completed.vmOutput
+	executionError := completed.executionError
+
 	asyncCall.Status = arwen.AsyncCallResolved
 	callbackFunction := asyncCall.SuccessCallback
 	if vmOutput.ReturnCode != vmcommon.Ok {
@@ -553,8 +734,17 @@ func (host *vmHost) callbackAsync(asyncCall *arwen.AsyncGeneratedCall, vmOutput
 		return err
 	}
 
+	// The gas escrowed for the callback is released into the caller instance
+	// right before being passed on to the callback, as in executeSyncCallbackCall
+	host.Metering().RestoreLockedGas(asyncCall.GetGasLocked())
+
+	host.recordAsyncCallNodeCallback(completed.node, callbackFunction)
+
 	// Callback omits for now any async call - TODO: take into consideration async calls generated from callbacks
 	callbackVMOutput, _, callBackErr := host.ExecuteOnDestContext(callbackCallInput)
+	host.addCallbackReceipt(callbackCallInput, callbackVMOutput)
+	host.traceCallbackExecuted(contextIdentifier, asyncCall, callbackFunction, asyncCall.Status, callbackVMOutput)
+	host.addAsyncCallRefunds(asyncCall.GetGasLocked(), callbackVMOutput, callBackErr)
 	err = host.processCallbackVMOutput(callbackVMOutput, callBackErr)
 	if err != nil {
 		return err
@@ -563,6 +753,107 @@ func (host *vmHost) callbackAsync(asyncCall *arwen.AsyncGeneratedCall, vmOutput
 	return nil
 }
 
+/**
+ * traceCompletedAsyncContexts notifies the AsyncTracer of the AsyncContexts which have no more pending calls
+ */
+func (host *vmHost) traceCompletedAsyncContexts(asyncInfo *arwen.AsyncContextInfo) {
+	if !host.isAsyncTracingEnabled() {
+		return
+	}
+
+	for contextIdentifier, asyncContext := range asyncInfo.AsyncContextMap {
+		if isAsyncContextCompleted(asyncContext) {
+			host.traceGroupCompleted(contextIdentifier, asyncContext.Callback)
+		}
+	}
+}
+
+func isAsyncContextCompleted(asyncContext *arwen.AsyncContext) bool {
+	for _, asyncCall := range asyncContext.AsyncCalls {
+		if asyncCall.Status == arwen.AsyncCallPending {
+			return false
+		}
+	}
+
+	return true
+}
+
+/**
+ * executeCompletedGroupCallbacks calls the callbacks of the completed AsyncContexts, in the order of their identifiers
+ */
+func (host *vmHost) executeCompletedGroupCallbacks(asyncInfo *arwen.AsyncContextInfo) error {
+	contextIdentifiers := make([]string, 0, len(asyncInfo.AsyncContextMap))
+	for contextIdentifier, asyncContext := range asyncInfo.AsyncContextMap {
+		if isAsyncContextCompleted(asyncContext) {
+			contextIdentifiers = append(contextIdentifiers, contextIdentifier)
+		}
+	}
+	sort.Strings(contextIdentifiers)
+
+	for _, contextIdentifier := range contextIdentifiers {
+		err := host.executeGroupCallback(contextIdentifier, asyncInfo.AsyncContextMap[contextIdentifier])
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+/**
+ * executeGroupCallback calls the callback of a completed AsyncContext, reverting only its own changes if it fails
+ */
+func (host *vmHost) executeGroupCallback(contextIdentifier string, asyncContext *arwen.AsyncContext) error {
+	// the legacy callBack is executed for each async call and cannot be called directly
+	if len(asyncContext.Callback) == 0 || asyncContext.Callback == arwen.CallbackFunctionName {
+		return nil
+	}
+
+	output := host.Output()
+	runtime := host.Runtime()
+	storage := host.Storage()
+
+	scAddress := runtime.GetSCAddress()
+	snapshotKey := groupCallbackSnapshotKey(scAddress, contextIdentifier)
+	output.TakeSnapshot(snapshotKey)
+	storage.TakeSnapshot(snapshotKey)
+
+	callbackInput := &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr:     scAddress,
+			Arguments:      [][]byte{[]byte(contextIdentifier)},
+			CallValue:      big.NewInt(0),
+			CallType:       vmcommon.DirectCall,
+			GasPrice:       runtime.GetVMInput().GasPrice,
+			GasProvided:    host.Metering().GasLeft(),
+			CurrentTxHash:  runtime.GetCurrentTxHash(),
+			OriginalTxHash: runtime.GetOriginalTxHash(),
+		},
+		RecipientAddr: scAddress,
+		Function:      asyncContext.Callback,
+	}
+
+	callbackVMOutput, _, callbackErr := host.ExecuteOnDestContext(callbackInput)
+	if callbackErr == nil && callbackVMOutput.ReturnCode == vmcommon.Ok {
+		output.DiscardSnapshot(snapshotKey)
+		storage.DiscardSnapshot(snapshotKey)
+		return nil
+	}
+
+	log.Trace("group callback failed", "group", contextIdentifier, "callback", asyncContext.Callback, "error", callbackErr)
+
+	err := output.RevertToSnapshot(snapshotKey)
+	if err != nil {
+		return err
+	}
+
+	return storage.RevertToSnapshot(snapshotKey)
+}
+
+func groupCallbackSnapshotKey(scAddress []byte, contextIdentifier string) string {
+	return string(scAddress) + "@" + contextIdentifier
+}
+
 /**
  * savePendingAsyncCalls takes a list of pending async calls and save them to storage so the info will be available on callback
  */
@@ -592,6 +883,14 @@ func (host *vmHost) savePendingAsyncCalls(pendingAsyncMap *arwen.AsyncContextInf
  * saveCrossShardCalls goes through the list of async calls and saves the ones that are cross shard
  */
 func (host *vmHost) saveCrossShardCalls(asyncInfo *arwen.AsyncContextInfo) error {
+	return host.savePendingAsyncCalls(host.getCrossShardCalls(asyncInfo))
+}
+
+/**
+ * getCrossShardCalls returns only the async calls that leave this shard from a list that can also contain
+ *  calls executed on this host
+ */
+func (host *vmHost) getCrossShardCalls(asyncInfo *arwen.AsyncContextInfo) *arwen.AsyncContextInfo {
 	crossMap := &arwen.AsyncContextInfo{
 		CallerAddr:      asyncInfo.CallerAddr,
 		ReturnData:      asyncInfo.ReturnData,
@@ -600,7 +899,7 @@ func (host *vmHost) saveCrossShardCalls(asyncInfo *arwen.AsyncContextInfo) error
 
 	for contextIdentifier, asyncContext := range asyncInfo.AsyncContextMap {
 		for _, asyncCall := range asyncContext.AsyncCalls {
-			if !host.canExecuteSynchronously(asyncCall.Destination, asyncCall.Data) {
+			if !host.canExecuteAsyncCallSynchronously(asyncCall) {
 				_, ok := crossMap.AsyncContextMap[contextIdentifier]
 				if !ok {
 					crossMap.AsyncContextMap[contextIdentifier] = &arwen.AsyncContext{
@@ -616,7 +915,7 @@ func (host *vmHost) saveCrossShardCalls(asyncInfo *arwen.AsyncContextInfo) error
 		}
 	}
 
-	return host.savePendingAsyncCalls(crossMap)
+	return crossMap
 }
 
 /**
@@ -659,6 +958,10 @@ func (host *vmHost) getPendingAsyncCalls(asyncInfo *arwen.AsyncContextInfo) *arw
  *   again since it was executed in the callSCMethod step
  */
 func (host *vmHost) processCallbackStack() error {
+	if !host.IsMultiAsyncCallGroupsEnabled() {
+		return nil
+	}
+
 	runtime := host.Runtime()
 	storage := host.Storage()
 
@@ -674,44 +977,73 @@ func (host *vmHost) processCallbackStack() error {
 		return err
 	}
 
+	// the identifier may be empty, as is LegacyAsyncContextIdentifier
 	vmInput := runtime.GetVMInput()
 	var asyncCallPosition int
 	var currentContextIdentifier string
-	for contextIdentifier, asyncContext := range asyncInfo.AsyncContextMap {
+	found := false
+	for _, contextIdentifier := range sortedAsyncContextIdentifiers(asyncInfo) {
+		asyncContext := asyncInfo.AsyncContextMap[contextIdentifier]
 		for position, asyncCall := range asyncContext.AsyncCalls {
 			if bytes.Equal(vmInput.CallerAddr, asyncCall.Destination) {
 				asyncCallPosition = position
 				currentContextIdentifier = contextIdentifier
+				found = true
 				break
 			}
 		}
 
-		if len(currentContextIdentifier) > 0 {
+		if found {
 			break
 		}
 	}
 
-	if len(currentContextIdentifier) == 0 {
+	if !found {
 		return arwen.ErrCallBackFuncNotExpected
 	}
 
+	currentContext := asyncInfo.AsyncContextMap[currentContextIdentifier]
+	if host.isAsyncTracingEnabled() {
+		asyncCall := currentContext.AsyncCalls[asyncCallPosition]
+		// the first argument of a callback is the return code of the async call
+		status := arwen.AsyncCallResolved
+		if len(vmInput.Arguments) > 0 && big.NewInt(0).SetBytes(vmInput.Arguments[0]).Sign() != 0 {
+			status = arwen.AsyncCallRejected
+		}
+		host.traceCallbackExecuted(currentContextIdentifier, asyncCall, runtime.Function(), status, host.Output().GetVMOutput())
+	}
+
 	// Remove current async call from the pending list
-	currentContextCalls := asyncInfo.AsyncContextMap[currentContextIdentifier].AsyncCalls
+	currentContextCalls := currentContext.AsyncCalls
 	contextCallId := len(currentContextCalls) - 1
 	if contextCallId >= 0 {
 		currentContextCalls[asyncCallPosition] = currentContextCalls[contextCallId]
 		currentContextCalls[contextCallId] = nil
 		currentContextCalls = currentContextCalls[:contextCallId]
 	}
+	if host.IsAsyncCallsFixEnabled() {
+		currentContext.AsyncCalls = currentContextCalls
+	}
 
 	if len(currentContextCalls) == 0 {
 		// call OUR callback for resolving a full context
 		delete(asyncInfo.AsyncContextMap, currentContextIdentifier)
+		host.traceGroupCompleted(currentContextIdentifier, currentContext.Callback)
+
+		if host.IsGroupCallbacksEnabled() {
+			err = host.executeGroupCallback(currentContextIdentifier, currentContext)
+			if err != nil {
+				return err
+			}
+		}
 	}
 
-	// If we are still waiting for callbacks we return
+	// If we are still waiting for callbacks we save the calls left and return
 	if len(asyncInfo.AsyncContextMap) > 0 {
-		return nil
+		if !host.IsAsyncCallsFixEnabled() {
+			return nil
+		}
+		return host.savePendingAsyncCalls(asyncInfo)
 	}
 
 	_, err = storage.SetProtectedStorage(storageKey, nil)
@@ -719,6 +1051,11 @@ func (host *vmHost) processCallbackStack() error {
 		return err
 	}
 
+	// the async calls migrated from the legacy format have no caller to notify
+	if len(asyncInfo.CallerAddr) == 0 {
+		return nil
+	}
+
 	// Now figure out if we can execute the callback here or different shard
 	if !host.canExecuteSynchronously(asyncInfo.CallerAddr, asyncInfo.ReturnData) {
 		err = host.sendStorageCallbackToDestination(asyncInfo.CallerAddr, asyncInfo.ReturnData)
@@ -730,7 +1067,6 @@ func (host *vmHost) processCallbackStack() error {
 	}
 
 	// The caller is in the same shard, execute it's callback
-	// TODO nil pointer exception warning: must refactor
 	callbackCallInput, err := host.createCallbackContractCallInput(
 		nil,
 		host.Output().GetVMOutput(),
@@ -743,6 +1079,7 @@ func (host *vmHost) processCallbackStack() error {
 	}
 
 	callbackVMOutput, _, callBackErr := host.ExecuteOnDestContext(callbackCallInput)
+	host.addCallbackReceipt(callbackCallInput, callbackVMOutput)
 	err = host.processCallbackVMOutput(callbackVMOutput, callBackErr)
 	if err != nil {
 		return err
@@ -817,7 +1154,8 @@ func (host *vmHost) getFunctionByCallType(callType vmcommon.CallType) (wasmer.Ex
 	vmInput := runtime.GetVMInput()
 
 	customCallback := false
-	for _, asyncContext := range asyncInfo.AsyncContextMap {
+	for _, contextIdentifier := range sortedAsyncContextIdentifiers(asyncInfo) {
+		asyncContext := asyncInfo.AsyncContextMap[contextIdentifier]
 		for _, asyncCall := range asyncContext.AsyncCalls {
 			if bytes.Equal(vmInput.CallerAddr, asyncCall.Destination) {
 				customCallback = true
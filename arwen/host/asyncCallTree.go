@@ -0,0 +1,82 @@
+package host
+
+import (
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
+	"github.com/ElrondNetwork/elrond-go/core/vmcommon"
+)
+
+// GetAsyncCallTree returns the async calls of the last execution, composed
+// through the nested executions as described by arwen.AsyncCallNode
+func (host *vmHost) GetAsyncCallTree() *arwen.AsyncCallTree {
+	host.mutExecution.RLock()
+	defer host.mutExecution.RUnlock()
+
+	return host.asyncCallTree
+}
+
+func (host *vmHost) resetAsyncCallTree() {
+	host.asyncCallTree = arwen.NewAsyncCallTree()
+	host.asyncCallNodes = nil
+	host.numAsyncCallbacks = 0
+}
+
+// addAsyncCallNode adds an async call registered by the contract currently
+// executing to the AsyncCallTree, as a child of the async call whose
+// destination is being executed, if any
+func (host *vmHost) addAsyncCallNode(groupID string, asyncCall arwen.AsyncCallInfoHandler, crossShard bool) *arwen.AsyncCallNode {
+	node := &arwen.AsyncCallNode{
+		Caller:      host.Runtime().GetSCAddress(),
+		GroupID:     groupID,
+		Destination: asyncCall.GetDestination(),
+		Data:        asyncCall.GetData(),
+		GasLimit:    asyncCall.GetGasLimit(),
+		GasLocked:   asyncCall.GetGasLocked(),
+		Status:      arwen.AsyncCallPending,
+		CrossShard:  crossShard,
+		Children:    make([]*arwen.AsyncCallNode, 0),
+	}
+
+	numNodes := len(host.asyncCallNodes)
+	if numNodes == 0 {
+		host.asyncCallTree.Calls = append(host.asyncCallTree.Calls, node)
+		return node
+	}
+
+	parent := host.asyncCallNodes[numNodes-1]
+	parent.Children = append(parent.Children, node)
+	return node
+}
+
+// pushAsyncCallNode marks the async call whose destination is about to be
+// executed on this host, so that the async calls registered meanwhile become
+// its children; it must be followed by popAsyncCallNode
+func (host *vmHost) pushAsyncCallNode(node *arwen.AsyncCallNode) {
+	host.asyncCallNodes = append(host.asyncCallNodes, node)
+}
+
+func (host *vmHost) popAsyncCallNode() {
+	host.asyncCallNodes = host.asyncCallNodes[:len(host.asyncCallNodes)-1]
+}
+
+// completeAsyncCallNode sets the outcome of an async call executed on this
+// host; it remains pending while the destination awaits its own async calls
+func completeAsyncCallNode(node *arwen.AsyncCallNode, destinationVMOutput *vmcommon.VMOutput, pending bool) {
+	if destinationVMOutput != nil {
+		node.GasRemaining = destinationVMOutput.GasRemaining
+	}
+
+	if pending {
+		return
+	}
+
+	node.Status = arwen.AsyncCallResolved
+	if destinationVMOutput == nil || destinationVMOutput.ReturnCode != vmcommon.Ok {
+		node.Status = arwen.AsyncCallRejected
+	}
+}
+
+func (host *vmHost) recordAsyncCallNodeCallback(node *arwen.AsyncCallNode, callbackFunction string) {
+	host.numAsyncCallbacks++
+	node.Callback = callbackFunction
+	node.CallbackPosition = host.numAsyncCallbacks
+}
@@ -0,0 +1,40 @@
+package host
+
+import (
+	"math/big"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
+)
+
+// EstimateAsyncCallGas computes the minimum gas which the caller contract
+// needs in order to register an async call to the destination, carrying the
+// given data and value, to dispatch it and to receive its callback, according
+// to the current gas schedule and to the flags of the current epoch. The value
+// is only validated, as it does not influence the gas cost.
+func (host *vmHost) EstimateAsyncCallGas(
+	caller []byte,
+	destination []byte,
+	data []byte,
+	value *big.Int,
+) (*arwen.AsyncCallGasEstimate, error) {
+	host.mutExecution.RLock()
+	defer host.mutExecution.RUnlock()
+
+	if value != nil && value.Sign() < 0 {
+		return nil, arwen.ErrTransferNegativeValue
+	}
+
+	callerCodeSize, err := host.Blockchain().GetCodeSize(caller)
+	if err != nil || callerCodeSize == 0 {
+		return nil, arwen.ErrContractNotFound
+	}
+
+	// the code of a destination which is not available, such as one in
+	// another shard, does not contribute to the estimate
+	destinationCodeSize, err := host.Blockchain().GetCodeSize(destination)
+	if err != nil {
+		destinationCodeSize = 0
+	}
+
+	return host.Metering().EstimateAsyncCallGas(uint64(callerCodeSize), uint64(destinationCodeSize), uint64(len(data))), nil
+}
@@ -0,0 +1,111 @@
+package host
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
+)
+
+// MigrateLegacyAsyncData scans the storage of the given contract for async
+// data entries in the legacy single-call format and converts them to the
+// AsyncContextInfo format. The storage itself is left untouched: the new
+// values are returned in the result, to be written by the caller, together
+// with the entries that could not be migrated.
+func (host *vmHost) MigrateLegacyAsyncData(address []byte) (*arwen.AsyncMigrationResult, error) {
+	host.mutExecution.RLock()
+	defer host.mutExecution.RUnlock()
+
+	state, err := host.Blockchain().GetAllState(address)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &arwen.AsyncMigrationResult{
+		Address:        address,
+		Migrated:       make([]arwen.AsyncMigrationEntry, 0),
+		NotMigrated:    make([]arwen.AsyncMigrationEntry, 0),
+		StorageUpdates: make(map[string][]byte),
+	}
+
+	keys := make([]string, 0, len(state))
+	for key := range state {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	asyncDataSuffix := []byte(arwen.AsyncDataPrefix)
+	for _, key := range keys {
+		value := state[key]
+		if !bytes.HasSuffix([]byte(key), asyncDataSuffix) || len(value) == 0 {
+			continue
+		}
+
+		if isAsyncContextInfo(value) {
+			result.UpToDate++
+			continue
+		}
+
+		migrated, err := convertLegacyAsyncData(value)
+		if err != nil {
+			result.NotMigrated = append(result.NotMigrated, arwen.AsyncMigrationEntry{Key: []byte(key), Reason: err.Error()})
+			continue
+		}
+
+		result.StorageUpdates[key] = migrated
+		result.Migrated = append(result.Migrated, arwen.AsyncMigrationEntry{Key: []byte(key)})
+	}
+
+	return result, nil
+}
+
+func isAsyncContextInfo(data []byte) bool {
+	asyncInfo := &arwen.AsyncContextInfo{}
+	err := strictUnmarshal(data, asyncInfo)
+	return err == nil && asyncInfo.AsyncContextMap != nil
+}
+
+// convertLegacyAsyncData converts a legacy AsyncCallInfo into an
+// AsyncContextInfo holding a single pending call, which resolves to the
+// default callback; the gas locked for the callback is added to the gas
+// limit of the call, because the new format does not track it separately
+func convertLegacyAsyncData(data []byte) ([]byte, error) {
+	legacyInfo := &arwen.AsyncCallInfo{}
+	err := strictUnmarshal(data, legacyInfo)
+	if err != nil {
+		return nil, err
+	}
+	if len(legacyInfo.Destination) == 0 {
+		return nil, arwen.ErrAsyncMigrationMissingDestination
+	}
+
+	gasLimit := legacyInfo.GasLimit + legacyInfo.GasLocked
+	asyncInfo := &arwen.AsyncContextInfo{
+		AsyncContextMap: map[string]*arwen.AsyncContext{
+			arwen.LegacyAsyncContextIdentifier: {
+				Callback: arwen.CallbackFunctionName,
+				AsyncCalls: []*arwen.AsyncGeneratedCall{
+					{
+						Status:          arwen.AsyncCallPending,
+						Destination:     legacyInfo.Destination,
+						Data:            legacyInfo.Data,
+						GasLimit:        gasLimit,
+						ValueBytes:      legacyInfo.ValueBytes,
+						SuccessCallback: arwen.CallbackFunctionName,
+						ErrorCallback:   arwen.CallbackFunctionName,
+						ProvidedGas:     gasLimit,
+					},
+				},
+			},
+		},
+	}
+
+	return json.Marshal(asyncInfo)
+}
+
+func strictUnmarshal(data []byte, target interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	return decoder.Decode(target)
+}
@@ -0,0 +1,128 @@
+package host
+
+import (
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
+	"github.com/ElrondNetwork/elrond-go-logger/check"
+	"github.com/ElrondNetwork/elrond-go/core/vmcommon"
+)
+
+// SetAsyncTracer sets the AsyncTracer to be notified of the async calls
+// processed by the VMHost; a nil tracer disables the notifications
+func (host *vmHost) SetAsyncTracer(tracer arwen.AsyncTracer) {
+	host.mutExecution.Lock()
+	defer host.mutExecution.Unlock()
+
+	host.asyncTracer = tracer
+	host.updateUserErrorCapture()
+}
+
+// updateUserErrorCapture makes the Runtime context describe the errors
+// signalled by contracts only while the tracer is notified of them
+func (host *vmHost) updateUserErrorCapture() {
+	_, ok := host.asyncTracer.(arwen.UserErrorTracer)
+	host.runtimeContext.SetUserErrorCaptureEnabled(ok && host.isAsyncTracingEnabled())
+}
+
+func (host *vmHost) isAsyncTracingEnabled() bool {
+	return !check.IfNil(host.asyncTracer)
+}
+
+func (host *vmHost) traceAsyncCallRegistered(groupID string, asyncCall arwen.AsyncCallInfoHandler, crossShard bool) {
+	if !host.isAsyncTracingEnabled() {
+		return
+	}
+
+	event := host.newAsyncTraceEvent(groupID, asyncCall)
+	event.Status = arwen.AsyncCallPending
+	event.CrossShard = crossShard
+	host.asyncTracer.OnAsyncCallRegistered(event)
+}
+
+func (host *vmHost) traceAsyncCallExecuted(groupID string, asyncCall arwen.AsyncCallInfoHandler, destinationVMOutput *vmcommon.VMOutput) {
+	if !host.isAsyncTracingEnabled() {
+		return
+	}
+
+	event := host.newAsyncTraceEvent(groupID, asyncCall)
+	setAsyncTraceEventOutcome(&event, destinationVMOutput)
+	event.Status = arwen.AsyncCallResolved
+	if event.ReturnCode != vmcommon.Ok {
+		event.Status = arwen.AsyncCallRejected
+	}
+	host.asyncTracer.OnAsyncCallExecuted(event)
+}
+
+func (host *vmHost) traceCallbackExecuted(
+	groupID string,
+	asyncCall arwen.AsyncCallInfoHandler,
+	callbackFunction string,
+	status arwen.AsyncCallStatus,
+	callbackVMOutput *vmcommon.VMOutput,
+) {
+	if !host.isAsyncTracingEnabled() {
+		return
+	}
+
+	event := host.newAsyncTraceEvent(groupID, asyncCall)
+	setAsyncTraceEventOutcome(&event, callbackVMOutput)
+	event.Function = callbackFunction
+	event.Status = status
+	host.asyncTracer.OnCallbackExecuted(event)
+}
+
+func (host *vmHost) traceGroupCompleted(groupID string, callbackFunction string) {
+	if !host.isAsyncTracingEnabled() {
+		return
+	}
+
+	event := host.newAsyncTraceEvent(groupID, nil)
+	event.Function = callbackFunction
+	event.Status = arwen.AsyncCallResolved
+	host.asyncTracer.OnGroupCompleted(event)
+}
+
+func (host *vmHost) newAsyncTraceEvent(groupID string, asyncCall arwen.AsyncCallInfoHandler) arwen.AsyncTraceEvent {
+	runtime := host.Runtime()
+	event := arwen.AsyncTraceEvent{
+		OriginalTxHash: runtime.GetOriginalTxHash(),
+		CurrentTxHash:  runtime.GetCurrentTxHash(),
+		Caller:         runtime.GetSCAddress(),
+		GroupID:        groupID,
+	}
+
+	if asyncCall != nil {
+		event.Destination = asyncCall.GetDestination()
+		event.Data = asyncCall.GetData()
+		event.GasLimit = asyncCall.GetGasLimit()
+		event.GasLocked = asyncCall.GetGasLocked()
+	}
+
+	return event
+}
+
+func setAsyncTraceEventOutcome(event *arwen.AsyncTraceEvent, vmOutput *vmcommon.VMOutput) {
+	if vmOutput == nil {
+		event.ReturnCode = vmcommon.ExecutionFailed
+		return
+	}
+
+	event.ReturnCode = vmOutput.ReturnCode
+	event.GasRemaining = vmOutput.GasRemaining
+}
+
+func (host *vmHost) traceUserError() {
+	if !host.isAsyncTracingEnabled() {
+		return
+	}
+
+	tracer, ok := host.asyncTracer.(arwen.UserErrorTracer)
+	if !ok {
+		return
+	}
+
+	reason := host.Runtime().GetUserErrorReason()
+	if reason == nil {
+		return
+	}
+	tracer.OnUserError(*reason)
+}
@@ -30,11 +30,19 @@ func (host *vmHost) handleBreakpoint(breakpointValue arwen.BreakpointValue) erro
 		return arwen.ErrExecutionFailed
 	}
 	if breakpointValue == arwen.BreakpointSignalError {
+		host.traceUserError()
 		return arwen.ErrSignalError
 	}
 	if breakpointValue == arwen.BreakpointOutOfGas {
+		if host.Runtime().TakeDebugStep() {
+			return arwen.ErrExecutionStoppedByDebugger
+		}
+		host.Runtime().CaptureOutOfGasReceipt()
 		return arwen.ErrNotEnoughGas
 	}
+	if breakpointValue == arwen.BreakpointExecutionTimeout {
+		return arwen.ErrExecutionTimeout
+	}
 
 	return arwen.ErrUnhandledRuntimeBreakpoint
 }
@@ -0,0 +1,216 @@
+package host
+
+import (
+	"bytes"
+	"math/big"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
+	"github.com/ElrondNetwork/elrond-go/core"
+	"github.com/ElrondNetwork/elrond-go/core/vmcommon"
+)
+
+// RegisterBuiltinOutputProcessor sets the processor which interprets the calls
+// to the given built-in function, replacing the existing one, if any
+func (host *vmHost) RegisterBuiltinOutputProcessor(functionName string, processor arwen.BuiltinOutputProcessor) error {
+	if len(functionName) == 0 || processor == nil {
+		return arwen.ErrInvalidBuiltinOutputProcessor
+	}
+
+	host.mutExecution.Lock()
+	defer host.mutExecution.Unlock()
+
+	host.builtinOutputProcessors[functionName] = processor
+	return nil
+}
+
+// getBuiltinOutputProcessor returns the processor registered for the given
+// built-in function, or a processor which leaves its output as it is
+func (host *vmHost) getBuiltinOutputProcessor(functionName string) arwen.BuiltinOutputProcessor {
+	processor, ok := host.builtinOutputProcessors[functionName]
+	if !ok {
+		return &defaultOutputProcessor{}
+	}
+
+	return processor
+}
+
+func newBuiltinOutputProcessors() map[string]arwen.BuiltinOutputProcessor {
+	return map[string]arwen.BuiltinOutputProcessor{
+		core.BuiltInFunctionESDTTransfer:          &esdtTransferOutputProcessor{},
+		core.BuiltInFunctionESDTNFTTransfer:       &esdtNFTTransferOutputProcessor{},
+		arwen.BuiltInFunctionMultiESDTNFTTransfer: &multiESDTNFTTransferOutputProcessor{},
+	}
+}
+
+// defaultOutputProcessor handles the built-in functions without a registered
+// processor, which transfer nothing apart from what their VMOutput contains
+type defaultOutputProcessor struct {
+}
+
+// Recipient returns the recipient of the call
+func (processor *defaultOutputProcessor) Recipient(input *vmcommon.ContractCallInput) []byte {
+	return input.RecipientAddr
+}
+
+// FillPostBuiltinInput does nothing
+func (processor *defaultOutputProcessor) FillPostBuiltinInput(_ *vmcommon.ContractCallInput, _ *vmcommon.ContractCallInput) {
+}
+
+// ProcessOutput does nothing
+func (processor *defaultOutputProcessor) ProcessOutput(_ *vmcommon.ContractCallInput, _ *vmcommon.VMOutput) {
+}
+
+// PaymentNotifications returns nil
+func (processor *defaultOutputProcessor) PaymentNotifications(_ *vmcommon.ContractCallInput) []*arwen.PaymentNotification {
+	return nil
+}
+
+// esdtTransferOutputProcessor handles ESDTTransfer@token@value, sent directly
+// to the recipient
+type esdtTransferOutputProcessor struct {
+	defaultOutputProcessor
+}
+
+// FillPostBuiltinInput sets the transferred token as the ESDT payment
+func (processor *esdtTransferOutputProcessor) FillPostBuiltinInput(input *vmcommon.ContractCallInput, postBuiltinInput *vmcommon.ContractCallInput) {
+	if len(input.Arguments) < 2 {
+		return
+	}
+
+	postBuiltinInput.ESDTTokenName = input.Arguments[0]
+	postBuiltinInput.ESDTValue = big.NewInt(0).SetBytes(input.Arguments[1])
+}
+
+// ProcessOutput adds the transfer to the VMOutput, to log it
+func (processor *esdtTransferOutputProcessor) ProcessOutput(input *vmcommon.ContractCallInput, vmOutput *vmcommon.VMOutput) {
+	addOutputTransferToVMOutput(input.Function, input.Arguments, input.CallerAddr, input.RecipientAddr, input.CallType, vmOutput)
+}
+
+// PaymentNotifications returns the transferred token
+func (processor *esdtTransferOutputProcessor) PaymentNotifications(input *vmcommon.ContractCallInput) []*arwen.PaymentNotification {
+	if len(input.Arguments) < 2 {
+		return nil
+	}
+
+	return []*arwen.PaymentNotification{{
+		Sender:          input.CallerAddr,
+		Recipient:       input.RecipientAddr,
+		TokenIdentifier: input.Arguments[0],
+		Value:           big.NewInt(0).SetBytes(input.Arguments[1]),
+	}}
+}
+
+// esdtNFTTransferOutputProcessor handles ESDTNFTTransfer@token@nonce@value@destination,
+// sent by the owner of the token to itself
+type esdtNFTTransferOutputProcessor struct {
+	defaultOutputProcessor
+}
+
+// Recipient returns the destination given in the arguments
+func (processor *esdtNFTTransferOutputProcessor) Recipient(input *vmcommon.ContractCallInput) []byte {
+	if len(input.Arguments) >= 4 && bytes.Equal(input.CallerAddr, input.RecipientAddr) {
+		return input.Arguments[3]
+	}
+
+	return input.RecipientAddr
+}
+
+// FillPostBuiltinInput sets the transferred token as the ESDT payment
+func (processor *esdtNFTTransferOutputProcessor) FillPostBuiltinInput(input *vmcommon.ContractCallInput, postBuiltinInput *vmcommon.ContractCallInput) {
+	if len(input.Arguments) < 3 {
+		return
+	}
+
+	postBuiltinInput.ESDTTokenName = input.Arguments[0]
+	postBuiltinInput.ESDTTokenNonce = big.NewInt(0).SetBytes(input.Arguments[1]).Uint64()
+	postBuiltinInput.ESDTValue = big.NewInt(0).SetBytes(input.Arguments[2])
+	postBuiltinInput.ESDTTokenType = uint32(core.NonFungible)
+}
+
+// ProcessOutput adds the transfer to the VMOutput of the destination, to log it
+func (processor *esdtNFTTransferOutputProcessor) ProcessOutput(input *vmcommon.ContractCallInput, vmOutput *vmcommon.VMOutput) {
+	if len(input.Arguments) != 4 {
+		return
+	}
+
+	addOutputTransferToVMOutput(input.Function, input.Arguments, input.CallerAddr, input.Arguments[3], input.CallType, vmOutput)
+}
+
+// PaymentNotifications returns the transferred token
+func (processor *esdtNFTTransferOutputProcessor) PaymentNotifications(input *vmcommon.ContractCallInput) []*arwen.PaymentNotification {
+	if len(input.Arguments) < 4 {
+		return nil
+	}
+
+	return []*arwen.PaymentNotification{{
+		Sender:          input.CallerAddr,
+		Recipient:       input.Arguments[3],
+		TokenIdentifier: input.Arguments[0],
+		Nonce:           big.NewInt(0).SetBytes(input.Arguments[1]).Uint64(),
+		Value:           big.NewInt(0).SetBytes(input.Arguments[2]),
+	}}
+}
+
+// multiESDTNFTTransferOutputProcessor handles
+// MultiESDTNFTTransfer@destination@numTokens@token@nonce@value..., sent by
+// the owner of the tokens to itself
+type multiESDTNFTTransferOutputProcessor struct {
+	defaultOutputProcessor
+}
+
+// Recipient returns the destination given in the arguments
+func (processor *multiESDTNFTTransferOutputProcessor) Recipient(input *vmcommon.ContractCallInput) []byte {
+	destination, _, _, err := arwen.ParseMultiESDTNFTTransferArguments(input.Arguments)
+	if err != nil {
+		return input.RecipientAddr
+	}
+
+	return destination
+}
+
+// FillPostBuiltinInput sets the ESDT payment only for a single token, which is
+// all that a ContractCallInput can describe
+func (processor *multiESDTNFTTransferOutputProcessor) FillPostBuiltinInput(input *vmcommon.ContractCallInput, postBuiltinInput *vmcommon.ContractCallInput) {
+	_, transfers, _, err := arwen.ParseMultiESDTNFTTransferArguments(input.Arguments)
+	if err != nil || len(transfers) != 1 {
+		return
+	}
+
+	postBuiltinInput.ESDTTokenName = transfers[0].TokenIdentifier
+	postBuiltinInput.ESDTTokenNonce = transfers[0].Nonce
+	postBuiltinInput.ESDTValue = transfers[0].Value
+	if transfers[0].Nonce > 0 {
+		postBuiltinInput.ESDTTokenType = uint32(core.NonFungible)
+	}
+}
+
+// ProcessOutput adds the transfer to the VMOutput of the destination, to log it
+func (processor *multiESDTNFTTransferOutputProcessor) ProcessOutput(input *vmcommon.ContractCallInput, vmOutput *vmcommon.VMOutput) {
+	destination, _, _, err := arwen.ParseMultiESDTNFTTransferArguments(input.Arguments)
+	if err != nil {
+		return
+	}
+
+	addOutputTransferToVMOutput(input.Function, input.Arguments, input.CallerAddr, destination, input.CallType, vmOutput)
+}
+
+// PaymentNotifications returns each of the transferred tokens
+func (processor *multiESDTNFTTransferOutputProcessor) PaymentNotifications(input *vmcommon.ContractCallInput) []*arwen.PaymentNotification {
+	destination, transfers, _, err := arwen.ParseMultiESDTNFTTransferArguments(input.Arguments)
+	if err != nil {
+		return nil
+	}
+
+	notifications := make([]*arwen.PaymentNotification, len(transfers))
+	for i, transfer := range transfers {
+		notifications[i] = &arwen.PaymentNotification{
+			Sender:          input.CallerAddr,
+			Recipient:       destination,
+			TokenIdentifier: transfer.TokenIdentifier,
+			Nonce:           transfer.Nonce,
+			Value:           transfer.Value,
+		}
+	}
+
+	return notifications
+}
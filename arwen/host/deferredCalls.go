@@ -0,0 +1,159 @@
+package host
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"math/big"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
+	"github.com/ElrondNetwork/elrond-go/core/vmcommon"
+)
+
+// RegisterDeferredCall records a deferred call of the running contract to itself and consumes its gas limit
+func (host *vmHost) RegisterDeferredCall(call *arwen.DeferredCall) error {
+	blockchain := host.Blockchain()
+	metering := host.Metering()
+	runtime := host.Runtime()
+
+	if !host.isValidDeferredCallFunction(call.Function) {
+		return arwen.ErrInvalidDeferredCallFunction
+	}
+
+	if call.IsDue(blockchain.CurrentRound(), blockchain.CurrentEpoch()) {
+		return arwen.ErrDeferredCallTargetReached
+	}
+
+	err := metering.UseGasBounded(call.GasLimit)
+	if err != nil {
+		return err
+	}
+
+	deferredCalls, err := host.loadDeferredCalls()
+	if err != nil {
+		return err
+	}
+
+	deferredCalls.Calls = append(deferredCalls.Calls, call)
+	err = host.saveDeferredCalls(deferredCalls)
+	if err != nil {
+		return err
+	}
+
+	targetRound := make([]byte, 8)
+	binary.BigEndian.PutUint64(targetRound, call.TargetRound)
+	targetEpoch := make([]byte, 4)
+	binary.BigEndian.PutUint32(targetEpoch, call.TargetEpoch)
+	topics := [][]byte{[]byte(arwen.DeferredCallLogIdentifier), targetRound, targetEpoch}
+	host.Output().WriteLog(runtime.GetSCAddress(), topics, []byte(call.Function))
+
+	return nil
+}
+
+// RunDeferredCalls dispatches the due deferred calls of the given contract as transfers to itself
+func (host *vmHost) RunDeferredCalls(address []byte) (*vmcommon.VMOutput, error) {
+	host.mutExecution.RLock()
+	defer host.mutExecution.RUnlock()
+
+	host.InitState()
+	defer host.Clean()
+
+	blockchain := host.Blockchain()
+	storage := host.Storage()
+	storage.SetAddress(address)
+
+	deferredCalls, err := host.loadDeferredCalls()
+	if err != nil {
+		return nil, err
+	}
+
+	round := blockchain.CurrentRound()
+	epoch := blockchain.CurrentEpoch()
+
+	pendingCalls := &arwen.DeferredCallsInfo{Calls: make([]*arwen.DeferredCall, 0)}
+	transfers := make([]vmcommon.OutputTransfer, 0)
+	for _, call := range deferredCalls.Calls {
+		if !call.IsDue(round, epoch) {
+			pendingCalls.Calls = append(pendingCalls.Calls, call)
+			continue
+		}
+
+		log.Trace("dispatch deferred call", "address", address, "function", call.Function, "gas", call.GasLimit)
+		transfers = append(transfers, vmcommon.OutputTransfer{
+			Value:         big.NewInt(0),
+			GasLimit:      call.GasLimit,
+			Data:          encodeDeferredCallData(call),
+			CallType:      vmcommon.DirectCall,
+			SenderAddress: address,
+		})
+	}
+
+	vmOutput := &vmcommon.VMOutput{
+		ReturnCode:      vmcommon.Ok,
+		ReturnData:      make([][]byte, 0),
+		OutputAccounts:  make(map[string]*vmcommon.OutputAccount),
+		DeletedAccounts: make([][]byte, 0),
+		TouchedAccounts: make([][]byte, 0),
+		Logs:            make([]*vmcommon.LogEntry, 0),
+	}
+	if len(transfers) == 0 {
+		return vmOutput, nil
+	}
+
+	var registry []byte
+	if len(pendingCalls.Calls) > 0 {
+		registry, err = json.Marshal(pendingCalls)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	key := []byte(arwen.DeferredCallsKey)
+	vmOutput.OutputAccounts[string(address)] = &vmcommon.OutputAccount{
+		Address:      address,
+		BalanceDelta: big.NewInt(0),
+		StorageUpdates: map[string]*vmcommon.StorageUpdate{
+			string(key): {Offset: key, Data: registry},
+		},
+		OutputTransfers: transfers,
+	}
+
+	return vmOutput, nil
+}
+
+func (host *vmHost) isValidDeferredCallFunction(function string) bool {
+	if len(function) == 0 || host.IsBuiltinFunctionName(function) {
+		return false
+	}
+
+	return function != arwen.InitFunctionName && function != arwen.CallbackFunctionName
+}
+
+func (host *vmHost) loadDeferredCalls() (*arwen.DeferredCallsInfo, error) {
+	deferredCalls := &arwen.DeferredCallsInfo{Calls: make([]*arwen.DeferredCall, 0)}
+
+	buff := host.Storage().GetStorageUnmetered([]byte(arwen.DeferredCallsKey))
+	if len(buff) == 0 {
+		return deferredCalls, nil
+	}
+
+	err := json.Unmarshal(buff, deferredCalls)
+	if err != nil {
+		return nil, err
+	}
+
+	return deferredCalls, nil
+}
+
+func (host *vmHost) saveDeferredCalls(deferredCalls *arwen.DeferredCallsInfo) error {
+	data, err := json.Marshal(deferredCalls)
+	if err != nil {
+		return err
+	}
+
+	_, err = host.Storage().SetProtectedStorage([]byte(arwen.DeferredCallsKey), data)
+	return err
+}
+
+func encodeDeferredCallData(call *arwen.DeferredCall) []byte {
+	return arwen.EncodeCallData(call.Function, call.Arguments)
+}
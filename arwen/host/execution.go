@@ -2,7 +2,6 @@ package host
 
 import (
 	"bytes"
-	"encoding/hex"
 	"errors"
 	"fmt"
 	"math/big"
@@ -11,7 +10,6 @@ import (
 	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/math"
 	"github.com/ElrondNetwork/elrond-go-logger/check"
 	"github.com/ElrondNetwork/elrond-go/core"
-	"github.com/ElrondNetwork/elrond-go/core/parsers"
 	"github.com/ElrondNetwork/elrond-go/core/vmcommon"
 )
 
@@ -63,9 +61,15 @@ func (host *vmHost) doRunSmartContractCreate(input *vmcommon.ContractCreateInput
 func (host *vmHost) performCodeDeployment(input arwen.CodeDeployInput) (*vmcommon.VMOutput, error) {
 	log.Trace("performCodeDeployment", "address", input.ContractAddress, "len(code)", len(input.ContractCode), "metadata", input.ContractCodeMetadata)
 
-	_, _, metering, output, runtime, _ := host.GetContexts()
+	_, _, metering, output, runtime, storage := host.GetContexts()
 
-	err := metering.DeductInitialGasForDirectDeployment(input)
+	err := host.deploymentLimits.CheckCode(input.ContractCode)
+	if err != nil {
+		log.Trace("performCodeDeployment", "error", err)
+		return nil, err
+	}
+
+	err = metering.DeductInitialGasForDirectDeployment(input)
 	if err != nil {
 		output.SetReturnCode(vmcommon.OutOfGas)
 		return nil, err
@@ -76,7 +80,7 @@ func (host *vmHost) performCodeDeployment(input arwen.CodeDeployInput) (*vmcommo
 	err = runtime.StartWasmerInstance(input.ContractCode, metering.GetGasForExecution(), true)
 	if err != nil {
 		log.Debug("performCodeDeployment/StartWasmerInstance", "err", err)
-		return nil, arwen.ErrContractInvalid
+		return nil, contractInvalidError(err)
 	}
 
 	err = host.callInitFunction()
@@ -85,13 +89,26 @@ func (host *vmHost) performCodeDeployment(input arwen.CodeDeployInput) (*vmcommo
 	}
 
 	output.DeployCode(input)
+	storage.Commit()
 	vmOutput := output.GetVMOutput()
 	runtime.CleanWasmerInstance()
 	return vmOutput, nil
 }
 
-// doRunSmartContractUpgrade upgrades a contract directly
-func (host *vmHost) doRunSmartContractUpgrade(input *vmcommon.ContractCallInput) *vmcommon.VMOutput {
+// contractInvalidError returns the error reported for a contract code which
+// could not be instantiated; only the violations of the validation rules are
+// reported in detail, the other errors are reported as ErrContractInvalid
+func contractInvalidError(err error) error {
+	if errors.Is(err, arwen.ErrModuleValidationFailed) {
+		return err
+	}
+
+	return arwen.ErrContractInvalid
+}
+
+// doRunSmartContractUpgrade upgrades a contract directly; a read-only
+// upgrade, i.e. one received as an SC query, is refused
+func (host *vmHost) doRunSmartContractUpgrade(input *vmcommon.ContractCallInput, readOnly bool) *vmcommon.VMOutput {
 	host.InitState()
 	defer func() {
 		errors := host.GetRuntimeErrors()
@@ -105,6 +122,10 @@ func (host *vmHost) doRunSmartContractUpgrade(input *vmcommon.ContractCallInput)
 
 	runtime.InitStateFromContractCallInput(input)
 	metering.InitStateFromContractCallInput(&input.VMInput)
+	if readOnly && host.IsReadOnlyEnforcementEnabled() {
+		return output.CreateVMOutputInCaseOfError(arwen.ErrInvalidCallOnReadOnlyMode)
+	}
+
 	output.AddTxValueToAccount(input.RecipientAddr, input.CallValue)
 	storage.SetAddress(runtime.GetSCAddress())
 
@@ -142,19 +163,23 @@ func (host *vmHost) checkGasForGetCode(input *vmcommon.ContractCallInput, meteri
 	return nil
 }
 
-func (host *vmHost) doRunSmartContractCall(input *vmcommon.ContractCallInput) (vmOutput *vmcommon.VMOutput) {
+// doRunSmartContractCall executes a contract directly; a read-only execution,
+// i.e. an SC query, is refused every modification of the state by the EEI
+func (host *vmHost) doRunSmartContractCall(input *vmcommon.ContractCallInput, readOnly bool) (vmOutput *vmcommon.VMOutput) {
 	host.InitState()
 	defer func() {
 		errors := host.GetRuntimeErrors()
 		if errors != nil {
 			log.Trace(fmt.Sprintf("doRunSmartContractCall full error list for %s", input.Function), "error", errors)
 		}
+		host.attachTransactionReceipts(input, vmOutput)
 		host.Clean()
 	}()
 
 	_, _, metering, output, runtime, storage := host.GetContexts()
 
 	runtime.InitStateFromContractCallInput(input)
+	runtime.SetReadOnly(readOnly && host.IsReadOnlyEnforcementEnabled())
 	metering.InitStateFromContractCallInput(&input.VMInput)
 	output.AddTxValueToAccount(input.RecipientAddr, input.CallValue)
 	storage.SetAddress(runtime.GetSCAddress())
@@ -188,6 +213,9 @@ func (host *vmHost) doRunSmartContractCall(input *vmcommon.ContractCallInput) (v
 		return output.CreateVMOutputInCaseOfError(err)
 	}
 
+	host.notifyReceivedPayments()
+
+	storage.Commit()
 	vmOutput = output.GetVMOutput()
 
 	log.Trace("doRunSmartContractCall finished",
@@ -218,11 +246,29 @@ func copyTxHashesFromContext(copyEnabled bool, runtime arwen.RuntimeContext, inp
 
 // ExecuteOnDestContext pushes each context to the corresponding stack
 // and initializes new contexts for executing the contract call with the given input
-func (host *vmHost) ExecuteOnDestContext(input *vmcommon.ContractCallInput) (vmOutput *vmcommon.VMOutput, asyncInfo *arwen.AsyncContextInfo, err error) {
+func (host *vmHost) ExecuteOnDestContext(input *vmcommon.ContractCallInput) (*vmcommon.VMOutput, *arwen.AsyncContextInfo, error) {
+	return host.executeOnDestContext(input, nil)
+}
+
+// ExecuteOnDestContextWithESDTTransfers executes the contract call with the
+// given input like ExecuteOnDestContext, letting the called contract see all
+// the given tokens as received, which the input can describe only one of
+func (host *vmHost) ExecuteOnDestContextWithESDTTransfers(input *vmcommon.ContractCallInput, transfers []*arwen.ESDTTransfer) (*vmcommon.VMOutput, *arwen.AsyncContextInfo, error) {
+	return host.executeOnDestContext(input, transfers)
+}
+
+func (host *vmHost) executeOnDestContext(input *vmcommon.ContractCallInput, transfers []*arwen.ESDTTransfer) (vmOutput *vmcommon.VMOutput, asyncInfo *arwen.AsyncContextInfo, err error) {
 	log.Trace("ExecuteOnDestContext", "caller", input.CallerAddr, "dest", input.RecipientAddr, "function", input.Function)
 
 	scExecutionInput := input
 
+	err = host.Runtime().CheckExecutionStackDepth()
+	if err != nil {
+		host.Runtime().AddError(err, input.Function)
+		vmOutput = host.Output().CreateVMOutputInCaseOfError(err)
+		return
+	}
+
 	blockchain := host.Blockchain()
 	blockchain.PushState()
 
@@ -237,7 +283,7 @@ func (host *vmHost) ExecuteOnDestContext(input *vmcommon.ContractCallInput) (vmO
 	}
 
 	if scExecutionInput != nil {
-		vmOutput, asyncInfo, err = host.executeOnDestContextNoBuiltinFunction(scExecutionInput)
+		vmOutput, asyncInfo, err = host.executeOnDestContextNoBuiltinFunction(scExecutionInput, transfers)
 	}
 
 	if err != nil {
@@ -262,10 +308,14 @@ func (host *vmHost) handleBuiltinFunctionCall(input *vmcommon.ContractCallInput)
 	return postBuiltinInput, builtinOutput, nil
 }
 
-func (host *vmHost) executeOnDestContextNoBuiltinFunction(input *vmcommon.ContractCallInput) (vmOutput *vmcommon.VMOutput, asyncInfo *arwen.AsyncContextInfo, err error) {
+func (host *vmHost) executeOnDestContextNoBuiltinFunction(input *vmcommon.ContractCallInput, transfers []*arwen.ESDTTransfer) (vmOutput *vmcommon.VMOutput, asyncInfo *arwen.AsyncContextInfo, err error) {
 	bigInt, _, metering, output, runtime, storage := host.GetContexts()
+	gasSnapshot := metering.Snapshot()
+
 	bigInt.PushState()
 	bigInt.InitState()
+	runtime.ManagedTypes().PushState()
+	runtime.ManagedTypes().InitState()
 
 	output.PushState()
 	output.CensorVMOutput()
@@ -273,19 +323,28 @@ func (host *vmHost) executeOnDestContextNoBuiltinFunction(input *vmcommon.Contra
 	copyTxHashesFromContext(host.IsESDTFunctionsEnabled(), runtime, input)
 	runtime.PushState()
 	runtime.InitStateFromContractCallInput(input)
+	if len(transfers) > 1 {
+		runtime.SetESDTTransfers(transfers)
+	}
 
 	metering.PushState()
 	metering.InitStateFromContractCallInput(&input.VMInput)
 
 	storage.PushState()
 	storage.SetAddress(runtime.GetSCAddress())
+	storage.BeginTransaction()
+
+	numPaymentNotifications := len(host.paymentNotifications)
 
 	defer func() {
-		vmOutput = host.finishExecuteOnDestContext(err)
+		vmOutput = host.finishExecuteOnDestContext(input, gasSnapshot, err)
 
 		if err == nil && vmOutput.ReturnCode != vmcommon.Ok {
 			err = arwen.ErrExecutionFailed
 		}
+		if err != nil {
+			host.discardPaymentNotificationsAfter(numPaymentNotifications)
+		}
 	}()
 
 	// Perform a value transfer to the called SC. If the execution fails, this
@@ -309,7 +368,11 @@ func (host *vmHost) executeOnDestContextNoBuiltinFunction(input *vmcommon.Contra
 	return
 }
 
-func (host *vmHost) finishExecuteOnDestContext(executeErr error) *vmcommon.VMOutput {
+func (host *vmHost) finishExecuteOnDestContext(
+	input *vmcommon.ContractCallInput,
+	gasSnapshot int,
+	executeErr error,
+) *vmcommon.VMOutput {
 	bigInt, _, metering, output, runtime, storage := host.GetContexts()
 
 	var vmOutput *vmcommon.VMOutput
@@ -327,21 +390,34 @@ func (host *vmHost) finishExecuteOnDestContext(executeErr error) *vmcommon.VMOut
 
 	// Restore the previous context states
 	bigInt.PopSetActiveState()
+	runtime.ManagedTypes().PopSetActiveState()
 	storage.PopSetActiveState()
 
-	if vmOutput.ReturnCode == vmcommon.Ok {
+	isSuccess := vmOutput.ReturnCode == vmcommon.Ok
+	if isSuccess {
+		runtime.DiscardHandledCallStackTrace()
 		metering.PopMergeActiveState()
 		output.PopMergeActiveState()
+		storage.Commit()
 	} else {
-		metering.PopSetActiveState()
 		output.PopSetActiveState()
+		storage.Revert()
+		host.addFailedCallReceipt(input)
 	}
 
 	// Return to the caller context completely
 	runtime.PopSetActiveState()
 
-	// Restore remaining gas to the caller Wasmer instance
-	metering.RestoreGas(vmOutput.GasRemaining)
+	if isSuccess || !host.IsFailedExecutionGasEnabled() {
+		if !isSuccess {
+			metering.PopSetActiveState()
+		}
+		// Restore remaining gas to the caller Wasmer instance
+		metering.DiscardSnapshot(gasSnapshot)
+		metering.RestoreGas(vmOutput.GasRemaining)
+	} else {
+		host.restoreGasAfterFailedExecution(input, gasSnapshot)
+	}
 
 	log.Trace("ExecuteOnDestContext finished", "gas spent", gasSpentByChildContract)
 
@@ -357,12 +433,20 @@ func (host *vmHost) ExecuteOnSameContext(input *vmcommon.ContractCallInput) (asy
 		return nil, arwen.ErrBuiltinCallOnSameContextDisallowed
 	}
 
-	bigInt, blockchain, metering, output, runtime, _ := host.GetContexts()
+	err = host.Runtime().CheckExecutionStackDepth()
+	if err != nil {
+		return nil, err
+	}
+
+	bigInt, blockchain, metering, output, runtime, storage := host.GetContexts()
+	gasSnapshot := metering.Snapshot()
 
-	// Back up the states of the contexts (except Storage, which isn't affected
-	// by ExecuteOnSameContext())
+	// Back up the states of the contexts (except Storage, whose address isn't
+	// affected by ExecuteOnSameContext(), but whose writes are reverted on failure)
 	bigInt.PushState()
+	runtime.ManagedTypes().PushState()
 	output.PushState()
+	storage.BeginTransaction()
 
 	copyTxHashesFromContext(host.IsESDTFunctionsEnabled(), runtime, input)
 	runtime.PushState()
@@ -373,9 +457,19 @@ func (host *vmHost) ExecuteOnSameContext(input *vmcommon.ContractCallInput) (asy
 
 	blockchain.PushState()
 
+	numPaymentNotifications := len(host.paymentNotifications)
+
 	defer func() {
+		// The value moved by this execution and by the executions nested in
+		// it must be conserved by the output accounts before it is kept
+		if err == nil && output.ReturnCode() == vmcommon.Ok {
+			err = output.CheckValueConservation()
+		}
 		runtime.AddError(err, input.Function)
-		host.finishExecuteOnSameContext(err)
+		if err != nil || output.ReturnCode() != vmcommon.Ok {
+			host.discardPaymentNotificationsAfter(numPaymentNotifications)
+		}
+		host.finishExecuteOnSameContext(input, gasSnapshot, err)
 	}()
 
 	// Perform a value transfer to the called SC. If the execution fails, this
@@ -394,16 +488,27 @@ func (host *vmHost) ExecuteOnSameContext(input *vmcommon.ContractCallInput) (asy
 	return
 }
 
-func (host *vmHost) finishExecuteOnSameContext(executeErr error) {
-	bigInt, blockchain, metering, output, runtime, _ := host.GetContexts()
+func (host *vmHost) finishExecuteOnSameContext(
+	input *vmcommon.ContractCallInput,
+	gasSnapshot int,
+	executeErr error,
+) {
+	bigInt, blockchain, metering, output, runtime, storage := host.GetContexts()
 
 	if output.ReturnCode() != vmcommon.Ok || executeErr != nil {
 		// Execution failed: restore contexts as if the execution didn't happen.
 		bigInt.PopSetActiveState()
-		metering.PopSetActiveState()
+		runtime.ManagedTypes().PopSetActiveState()
 		output.PopSetActiveState()
+		storage.Revert()
 		runtime.PopSetActiveState()
 		blockchain.PopSetActiveState()
+		if host.IsFailedExecutionGasEnabled() {
+			host.restoreGasAfterFailedExecution(input, gasSnapshot)
+		} else {
+			metering.PopSetActiveState()
+			metering.DiscardSnapshot(gasSnapshot)
+		}
 		return
 	}
 
@@ -411,17 +516,33 @@ func (host *vmHost) finishExecuteOnSameContext(executeErr error) {
 	// state and the previous instance, to ensure accurate GasRemaining and
 	// GasUsed for all accounts.
 	vmOutput := output.GetVMOutput()
+	if vmOutput.ReturnCode == vmcommon.Ok {
+		runtime.DiscardHandledCallStackTrace()
+	}
 
 	metering.PopMergeActiveState()
 	output.PopDiscard()
+	storage.Commit()
 	bigInt.PopDiscard()
+	runtime.ManagedTypes().PopDiscard()
 	blockchain.PopDiscard()
 	runtime.PopSetActiveState()
 
 	// Restore remaining gas to the caller (parent) Wasmer instance
+	metering.DiscardSnapshot(gasSnapshot)
 	metering.RestoreGas(vmOutput.GasRemaining)
 }
 
+// restoreGasAfterFailedExecution restores the gas state of the caller from
+// before the failed nested execution, then charges the caller with all the
+// gas provided to the nested execution, which a failed execution consumes.
+// The Wasmer instance of the caller must already be the current one.
+func (host *vmHost) restoreGasAfterFailedExecution(input *vmcommon.ContractCallInput, gasSnapshot int) {
+	metering := host.Metering()
+	metering.RestoreToSnapshot(gasSnapshot)
+	metering.UseGas(input.GasProvided)
+}
+
 func (host *vmHost) isInitFunctionBeingCalled() bool {
 	functionName := host.Runtime().Function()
 	return functionName == arwen.InitFunctionName || functionName == arwen.InitFunctionNameEth
@@ -467,6 +588,11 @@ func (host *vmHost) CreateNewContract(input *vmcommon.ContractCreateInput) (newC
 		return
 	}
 
+	err = host.deploymentLimits.CheckCode(input.ContractCode)
+	if err != nil {
+		return
+	}
+
 	newContractAddress, err = blockchain.NewAddress(input.CallerAddr)
 	if err != nil {
 		return
@@ -548,6 +674,11 @@ func (host *vmHost) executeUpgrade(input *vmcommon.ContractCallInput) error {
 		CodeDeployerAddress:  input.CallerAddr,
 	}
 
+	err = host.deploymentLimits.CheckCode(code)
+	if err != nil {
+		return err
+	}
+
 	err = metering.DeductInitialGasForDirectDeployment(codeDeployInput)
 	if err != nil {
 		output.SetReturnCode(vmcommon.OutOfGas)
@@ -559,7 +690,7 @@ func (host *vmHost) executeUpgrade(input *vmcommon.ContractCallInput) error {
 	err = runtime.StartWasmerInstance(codeDeployInput.ContractCode, metering.GetGasForExecution(), true)
 	if err != nil {
 		log.Debug("performCodeDeployment/StartWasmerInstance", "err", err)
-		return arwen.ErrContractInvalid
+		return contractInvalidError(err)
 	}
 
 	err = host.callInitFunction()
@@ -572,6 +703,8 @@ func (host *vmHost) executeUpgrade(input *vmcommon.ContractCallInput) error {
 		return arwen.ErrReturnCodeNotOk
 	}
 
+	runtime.RegisterCodeUpgrade(input.RecipientAddr, code)
+
 	return nil
 }
 
@@ -644,7 +777,7 @@ func (host *vmHost) callSCMethodIndirect() error {
 		return err
 	}
 
-	_, err = function()
+	err = host.callWasmFunction(function)
 	if err != nil {
 		err = host.handleBreakpointIfAny(err)
 	}
@@ -681,9 +814,46 @@ func (host *vmHost) ExecuteESDTTransfer(destination []byte, sender []byte, token
 		esdtTransferInput.Arguments = append(esdtTransferInput.Arguments, tokenIdentifier, value.Bytes())
 	}
 
-	vmOutput, err := host.Blockchain().ProcessBuiltInFunction(esdtTransferInput)
 	log.Trace("ESDT transfer", "sender", sender, "dest", destination)
 	log.Trace("ESDT transfer", "token", tokenIdentifier, "value", value)
+	return host.processESDTTransfer(esdtTransferInput)
+}
+
+// ExecuteMultiESDTTransfer calls the MultiESDTNFTTransfer built-in function,
+// transferring all the given tokens to the destination at once
+func (host *vmHost) ExecuteMultiESDTTransfer(destination []byte, sender []byte, transfers []*arwen.ESDTTransfer, callType vmcommon.CallType) (*vmcommon.VMOutput, uint64, error) {
+	_, _, metering, _, runtime, _ := host.GetContexts()
+
+	if len(transfers) == 0 {
+		return nil, 0, arwen.ErrInvalidMultiESDTTransfer
+	}
+
+	multiTransferInput := &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr:  sender,
+			Arguments:   arwen.MultiESDTNFTTransferArguments(destination, transfers),
+			CallValue:   big.NewInt(0),
+			CallType:    callType,
+			GasPrice:    runtime.GetVMInput().GasPrice,
+			GasProvided: metering.GasLeft(),
+			GasLocked:   0,
+		},
+		RecipientAddr:     sender,
+		Function:          arwen.BuiltInFunctionMultiESDTNFTTransfer,
+		AllowInitFunction: false,
+	}
+
+	log.Trace("multi ESDT transfer", "sender", sender, "dest", destination, "num tokens", len(transfers))
+	return host.processESDTTransfer(multiTransferInput)
+}
+
+// processESDTTransfer calls the built-in function of an ESDT transfer and
+// charges the gas it consumed, apart from the gas forwarded to its output transfers
+func (host *vmHost) processESDTTransfer(esdtTransferInput *vmcommon.ContractCallInput) (*vmcommon.VMOutput, uint64, error) {
+	metering := host.Metering()
+	callType := esdtTransferInput.CallType
+
+	vmOutput, err := host.Blockchain().ProcessBuiltInFunction(esdtTransferInput)
 	if err != nil {
 		log.Trace("ESDT transfer", "error", err)
 		return vmOutput, esdtTransferInput.GasProvided, err
@@ -707,6 +877,10 @@ func (host *vmHost) ExecuteESDTTransfer(destination []byte, sender []byte, token
 		metering.UseGas(gasConsumed)
 	}
 
+	if callType == vmcommon.DirectCall {
+		host.queuePaymentNotificationForBuiltinFunction(esdtTransferInput, vmOutput)
+	}
+
 	return vmOutput, gasConsumed, nil
 }
 
@@ -729,6 +903,8 @@ func (host *vmHost) callBuiltinFunction(input *vmcommon.ContractCallInput) (*vmc
 		for _, outAcc := range vmOutput.OutputAccounts {
 			outAcc.OutputTransfers = make([]vmcommon.OutputTransfer, 0)
 		}
+	} else {
+		host.queuePaymentNotificationForBuiltinFunction(input, vmOutput)
 	}
 
 	metering.TrackGasUsedByBuiltinFunction(input, vmOutput, newVMInput)
@@ -749,19 +925,8 @@ func (host *vmHost) addESDTTransferToVMOutputSCIntraShardCall(
 	if !host.AreInSameShard(input.RecipientAddr, input.CallerAddr) {
 		return
 	}
-	isESDTTransfer := input.Function == core.BuiltInFunctionESDTTransfer || input.Function == core.BuiltInFunctionESDTNFTTransfer
-	if !isESDTTransfer {
-		return
-	}
 
-	recipientAddr := input.RecipientAddr
-	if input.Function == core.BuiltInFunctionESDTNFTTransfer {
-		if len(input.Arguments) != 4 {
-			return
-		}
-		recipientAddr = input.Arguments[3]
-	}
-	addOutputTransferToVMOutput(input.Function, input.Arguments, input.CallerAddr, recipientAddr, input.CallType, output)
+	host.getBuiltinOutputProcessor(input.Function).ProcessOutput(input, output)
 }
 
 func addOutputTransferToVMOutput(
@@ -772,10 +937,7 @@ func addOutputTransferToVMOutput(
 	callType vmcommon.CallType,
 	vmOutput *vmcommon.VMOutput,
 ) {
-	esdtTransferTxData := function
-	for _, arg := range arguments {
-		esdtTransferTxData += "@" + hex.EncodeToString(arg)
-	}
+	esdtTransferTxData := string(arwen.EncodeCallData(function, arguments))
 	outTransfer := vmcommon.OutputTransfer{
 		Value:         big.NewInt(0),
 		Data:          []byte(esdtTransferTxData),
@@ -817,7 +979,7 @@ func (host *vmHost) callInitFunction() error {
 		return nil
 	}
 
-	_, err := init()
+	err := host.callWasmFunction(init)
 	if err != nil {
 		err = host.handleBreakpointIfAny(err)
 	}
@@ -847,7 +1009,8 @@ func (host *vmHost) callSCMethod() error {
 	callType := runtime.GetVMInput().CallType
 	function, err := host.getFunctionByCallType(callType)
 	if err != nil {
-		if callType == vmcommon.AsynchronousCallBack && errors.Is(err, arwen.ErrNilCallbackFunction) {
+		isCallbackForAsyncCallGroup := host.IsMultiAsyncCallGroupsEnabled() && errors.Is(err, arwen.ErrNilCallbackFunction)
+		if callType == vmcommon.AsynchronousCallBack && isCallbackForAsyncCallGroup {
 			err = host.processCallbackStack()
 			if err != nil {
 				log.Trace("call SC method failed", "error", err)
@@ -859,7 +1022,7 @@ func (host *vmHost) callSCMethod() error {
 		return err
 	}
 
-	_, err = function()
+	err = host.callWasmFunction(function)
 	if err != nil {
 		err = host.handleBreakpointIfAny(err)
 	}
@@ -919,10 +1082,8 @@ func (host *vmHost) isSCExecutionAfterBuiltInFunc(
 	if vmOutput.ReturnCode != vmcommon.Ok {
 		return nil, nil
 	}
-	recipient := vmInput.RecipientAddr
-	if vmInput.Function == core.BuiltInFunctionESDTNFTTransfer && bytes.Equal(vmInput.CallerAddr, vmInput.RecipientAddr) {
-		recipient = vmInput.Arguments[3]
-	}
+	processor := host.getBuiltinOutputProcessor(vmInput.Function)
+	recipient := processor.Recipient(vmInput)
 	if !host.AreInSameShard(vmInput.CallerAddr, recipient) {
 		return nil, nil
 	}
@@ -941,7 +1102,7 @@ func (host *vmHost) isSCExecutionAfterBuiltInFunc(
 	callType := vmInput.CallType
 	scCallOutTransfer := outAcc.OutputTransfers[0]
 
-	argParser := parsers.NewCallArgsParser()
+	argParser := arwen.NewCallDataParser()
 	function, arguments, err := argParser.ParseData(string(scCallOutTransfer.Data))
 	if err != nil {
 		return nil, err
@@ -964,23 +1125,7 @@ func (host *vmHost) isSCExecutionAfterBuiltInFunc(
 		AllowInitFunction: false,
 	}
 
-	fillWithESDTValue(vmInput, newVMInput)
+	processor.FillPostBuiltinInput(vmInput, newVMInput)
 
 	return newVMInput, nil
 }
-
-func fillWithESDTValue(fullVMInput *vmcommon.ContractCallInput, newVMInput *vmcommon.ContractCallInput) {
-	isESDTTransfer := fullVMInput.Function == core.BuiltInFunctionESDTTransfer || fullVMInput.Function == core.BuiltInFunctionESDTNFTTransfer
-	if !isESDTTransfer {
-		return
-	}
-
-	newVMInput.ESDTTokenName = fullVMInput.Arguments[0]
-	newVMInput.ESDTValue = big.NewInt(0).SetBytes(fullVMInput.Arguments[1])
-
-	if fullVMInput.Function == core.BuiltInFunctionESDTNFTTransfer {
-		newVMInput.ESDTTokenNonce = big.NewInt(0).SetBytes(fullVMInput.Arguments[1]).Uint64()
-		newVMInput.ESDTValue = big.NewInt(0).SetBytes(fullVMInput.Arguments[2])
-		newVMInput.ESDTTokenType = uint32(core.NonFungible)
-	}
-}
@@ -0,0 +1,35 @@
+package host
+
+import (
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
+	"github.com/ElrondNetwork/elrond-go-logger/check"
+	"github.com/ElrondNetwork/elrond-go/core/vmcommon"
+)
+
+// evaluateExecutionPolicy consults the ExecutionPolicy of the embedder, allowing all calls in its absence
+func (host *vmHost) evaluateExecutionPolicy(input *vmcommon.VMInput, contract []byte, function string) arwen.ExecutionPolicyDecision {
+	if check.IfNil(host.executionPolicy) {
+		return arwen.ExecutionAllowed
+	}
+
+	decision := host.executionPolicy.Evaluate(arwen.ExecutionPolicyRequest{
+		Caller:      input.CallerAddr,
+		Contract:    contract,
+		Function:    function,
+		GasProvided: input.GasProvided,
+	})
+	if decision != arwen.ExecutionAllowed {
+		log.Trace("execution policy", "caller", input.CallerAddr, "contract", contract, "function", function, "decision", decision)
+	}
+
+	return decision
+}
+
+// doRejectExecution produces a failed VMOutput which consumes all the provided gas
+func (host *vmHost) doRejectExecution(input *vmcommon.VMInput) *vmcommon.VMOutput {
+	host.InitState()
+	defer host.Clean()
+
+	host.Metering().InitStateFromContractCallInput(input)
+	return host.Output().CreateVMOutputInCaseOfError(arwen.ErrExecutionRejectedByPolicy)
+}
@@ -0,0 +1,222 @@
+package host
+
+import (
+	"bytes"
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
+)
+
+const profiledPackagePrefix = "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/"
+const wasmCallFrame = "wasmer._Cfunc_wasmer_instance_call"
+
+// executionProfiler samples the call stack of the goroutine running an
+// execution, from a separate goroutine. The stacks of all goroutines are
+// captured at once, which stops the world, therefore the profiler only
+// exists while execution profiling is enabled. The WASM code executed by
+// wasmer is seen as a single cgo frame, labeled with the contract function
+// called, as recorded by enterWasm() and exitWasm().
+type executionProfiler struct {
+	mutProfile     sync.Mutex
+	sampleInterval time.Duration
+	goroutine      []byte
+	wasmLabels     []string
+	stacks         map[string]uint64
+	numSamples     uint64
+
+	stopSampling chan struct{}
+	samplingDone chan struct{}
+}
+
+func newExecutionProfiler(sampleInterval time.Duration) *executionProfiler {
+	return &executionProfiler{
+		sampleInterval: sampleInterval,
+		stacks:         make(map[string]uint64),
+	}
+}
+
+// start resets the profiler and starts sampling the calling goroutine
+func (profiler *executionProfiler) start() {
+	profiler.mutProfile.Lock()
+	profiler.goroutine = currentGoroutineHeader()
+	profiler.wasmLabels = make([]string, 0)
+	profiler.stacks = make(map[string]uint64)
+	profiler.numSamples = 0
+	profiler.mutProfile.Unlock()
+
+	profiler.stopSampling = make(chan struct{})
+	profiler.samplingDone = make(chan struct{})
+	go profiler.sampleUntilStopped()
+}
+
+// stop stops sampling and waits for the sampling goroutine to end
+func (profiler *executionProfiler) stop() {
+	if profiler.stopSampling == nil {
+		return
+	}
+
+	close(profiler.stopSampling)
+	<-profiler.samplingDone
+	profiler.stopSampling = nil
+}
+
+func (profiler *executionProfiler) sampleUntilStopped() {
+	defer close(profiler.samplingDone)
+
+	ticker := time.NewTicker(profiler.sampleInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-profiler.stopSampling:
+			return
+		case <-ticker.C:
+			profiler.sample()
+		}
+	}
+}
+
+func (profiler *executionProfiler) enterWasm(address []byte, function string) {
+	profiler.mutProfile.Lock()
+	defer profiler.mutProfile.Unlock()
+
+	label := fmt.Sprintf("wasm:%s@%x", function, address)
+	profiler.wasmLabels = append(profiler.wasmLabels, label)
+}
+
+func (profiler *executionProfiler) exitWasm() {
+	profiler.mutProfile.Lock()
+	defer profiler.mutProfile.Unlock()
+
+	if len(profiler.wasmLabels) > 0 {
+		profiler.wasmLabels = profiler.wasmLabels[:len(profiler.wasmLabels)-1]
+	}
+}
+
+func (profiler *executionProfiler) sample() {
+	profiler.mutProfile.Lock()
+	defer profiler.mutProfile.Unlock()
+
+	frames := findGoroutineFrames(allGoroutineStacks(), profiler.goroutine)
+	if len(frames) == 0 {
+		return
+	}
+
+	stack := profiler.foldFrames(frames)
+	if len(stack) == 0 {
+		return
+	}
+
+	profiler.stacks[stack]++
+	profiler.numSamples++
+}
+
+// foldFrames joins the given frames, innermost first, into a folded stack of
+// the frames of the VM, outermost first
+func (profiler *executionProfiler) foldFrames(frames []string) string {
+	folded := make([]string, 0, len(frames))
+	numWasmFrames := 0
+	for i := len(frames) - 1; i >= 0; i-- {
+		if !strings.HasPrefix(frames[i], profiledPackagePrefix) {
+			continue
+		}
+
+		frame := strings.TrimPrefix(frames[i], profiledPackagePrefix)
+		if frame == wasmCallFrame {
+			frame = "wasm"
+			if numWasmFrames < len(profiler.wasmLabels) {
+				frame = profiler.wasmLabels[numWasmFrames]
+			}
+			numWasmFrames++
+		}
+		folded = append(folded, frame)
+	}
+
+	return strings.Join(folded, ";")
+}
+
+func (profiler *executionProfiler) profile() *arwen.ExecutionProfile {
+	profiler.mutProfile.Lock()
+	defer profiler.mutProfile.Unlock()
+
+	stacks := make(map[string]uint64, len(profiler.stacks))
+	for stack, count := range profiler.stacks {
+		stacks[stack] = count
+	}
+
+	return &arwen.ExecutionProfile{
+		SampleInterval: profiler.sampleInterval,
+		NumSamples:     profiler.numSamples,
+		Stacks:         stacks,
+	}
+}
+
+// currentGoroutineHeader returns the first line of the stack trace of the
+// calling goroutine, up to its state, e.g. "goroutine 18 ["
+func currentGoroutineHeader() []byte {
+	buffer := make([]byte, 64)
+	buffer = buffer[:runtime.Stack(buffer, false)]
+
+	end := bytes.IndexByte(buffer, '[')
+	if end < 0 {
+		return nil
+	}
+
+	return buffer[:end+1]
+}
+
+func allGoroutineStacks() []byte {
+	buffer := make([]byte, 64*1024)
+	for {
+		length := runtime.Stack(buffer, true)
+		if length < len(buffer) {
+			return buffer[:length]
+		}
+		buffer = make([]byte, 2*len(buffer))
+	}
+}
+
+// findGoroutineFrames returns the names of the functions on the stack of the
+// goroutine with the given header, innermost first, from the stack traces
+// of all goroutines
+func findGoroutineFrames(stacks []byte, goroutine []byte) []string {
+	if len(goroutine) == 0 {
+		return nil
+	}
+
+	start := bytes.Index(stacks, append([]byte("\n\n"), goroutine...))
+	if start >= 0 {
+		start += 2
+	} else if bytes.HasPrefix(stacks, goroutine) {
+		start = 0
+	} else {
+		return nil
+	}
+
+	trace := stacks[start:]
+	end := bytes.Index(trace, []byte("\n\n"))
+	if end >= 0 {
+		trace = trace[:end]
+	}
+
+	frames := make([]string, 0)
+	lines := strings.Split(string(trace), "\n")
+	for _, line := range lines[1:] {
+		if len(line) == 0 || line[0] == '\t' || strings.HasPrefix(line, "created by ") {
+			continue
+		}
+
+		// remove the arguments of the function
+		argumentsStart := strings.LastIndex(line, "(")
+		if argumentsStart > 0 {
+			line = line[:argumentsStart]
+		}
+		frames = append(frames, line)
+	}
+
+	return frames
+}
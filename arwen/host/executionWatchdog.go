@@ -0,0 +1,70 @@
+package host
+
+import (
+	"time"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
+	"github.com/ElrondNetwork/elrond-go/core/atomic"
+)
+
+// executionWatchdog interrupts an execution which lasts longer than its
+// budget of wall-clock time, from a separate goroutine, by stopping the
+// running Wasmer instances on the execution timeout breakpoint. It guards the
+// goroutine running the execution against contracts, or Wasmer bugs, which
+// would otherwise hang it regardless of the gas provided.
+type executionWatchdog struct {
+	budget  time.Duration
+	runtime arwen.RuntimeContext
+	expired atomic.Flag
+
+	stopWatching chan struct{}
+	watchingDone chan struct{}
+}
+
+func newExecutionWatchdog(budget time.Duration, runtime arwen.RuntimeContext) *executionWatchdog {
+	return &executionWatchdog{
+		budget:  budget,
+		runtime: runtime,
+	}
+}
+
+// start resets the watchdog and starts measuring the execution
+func (watchdog *executionWatchdog) start() {
+	watchdog.expired.Unset()
+
+	watchdog.stopWatching = make(chan struct{})
+	watchdog.watchingDone = make(chan struct{})
+	go watchdog.watchUntilStopped()
+}
+
+// stop stops measuring the execution and waits for the watching goroutine to end
+func (watchdog *executionWatchdog) stop() {
+	if watchdog.stopWatching == nil {
+		return
+	}
+
+	close(watchdog.stopWatching)
+	<-watchdog.watchingDone
+	watchdog.stopWatching = nil
+}
+
+// hasExpired returns true if the last execution was interrupted
+func (watchdog *executionWatchdog) hasExpired() bool {
+	return watchdog.expired.IsSet()
+}
+
+func (watchdog *executionWatchdog) watchUntilStopped() {
+	defer close(watchdog.watchingDone)
+
+	timer := time.NewTimer(watchdog.budget)
+	defer timer.Stop()
+
+	select {
+	case <-watchdog.stopWatching:
+		return
+	case <-timer.C:
+		watchdog.expired.Set()
+		log.Warn("execution interrupted", "error", arwen.ErrExecutionTimeout, "budget", watchdog.budget)
+		watchdog.runtime.InterruptExecution()
+	}
+}
@@ -0,0 +1,196 @@
+package host
+
+import (
+	"bytes"
+	"sort"
+	"sync"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
+	"github.com/ElrondNetwork/elrond-go/core/vmcommon"
+)
+
+// GetExecutionWitness returns the accounts and storage keys read during the
+// last execution, with the values they held before it; it returns nil if the
+// VMHost was not created with ExecutionWitnessEnabled
+func (host *vmHost) GetExecutionWitness() *arwen.ExecutionWitness {
+	host.mutExecution.RLock()
+	defer host.mutExecution.RUnlock()
+
+	if host.witnessRecorder == nil {
+		return nil
+	}
+
+	return host.witnessRecorder.buildWitness()
+}
+
+// witnessRecorder decorates the BlockchainHook of the VMHost, recording the
+// first value returned for each account and storage key. Later reads return
+// the same values from the hook, since the changes made by an execution are
+// only kept in its output until committed by the node. The reads performed
+// by the BlockchainHook itself, while processing built-in functions, are not
+// recorded.
+type witnessRecorder struct {
+	vmcommon.BlockchainHook
+	mutRecords sync.Mutex
+	accounts   map[string]*arwen.WitnessAccount
+	storage    map[string]map[string][]byte
+}
+
+func newWitnessRecorder(blockChainHook vmcommon.BlockchainHook) *witnessRecorder {
+	recorder := &witnessRecorder{
+		BlockchainHook: blockChainHook,
+	}
+	recorder.reset()
+
+	return recorder
+}
+
+func (recorder *witnessRecorder) reset() {
+	recorder.mutRecords.Lock()
+	defer recorder.mutRecords.Unlock()
+
+	recorder.accounts = make(map[string]*arwen.WitnessAccount)
+	recorder.storage = make(map[string]map[string][]byte)
+}
+
+// GetStorageData returns the value under the given key from the storage of
+// the given account, recording it on the first read
+func (recorder *witnessRecorder) GetStorageData(accountAddress []byte, index []byte) ([]byte, error) {
+	value, err := recorder.BlockchainHook.GetStorageData(accountAddress, index)
+	if err != nil {
+		return value, err
+	}
+
+	recorder.recordStorage(accountAddress, index, value)
+	return value, nil
+}
+
+// GetAllState returns the whole storage of the given account, recording all its entries
+func (recorder *witnessRecorder) GetAllState(address []byte) (map[string][]byte, error) {
+	state, err := recorder.BlockchainHook.GetAllState(address)
+	if err != nil {
+		return state, err
+	}
+
+	for key, value := range state {
+		recorder.recordStorage(address, []byte(key), value)
+	}
+	return state, nil
+}
+
+// GetUserAccount returns the given account, recording its state on the first read
+func (recorder *witnessRecorder) GetUserAccount(address []byte) (vmcommon.UserAccountHandler, error) {
+	account, err := recorder.BlockchainHook.GetUserAccount(address)
+
+	recorder.mutRecords.Lock()
+	defer recorder.mutRecords.Unlock()
+
+	witnessAccount := recorder.getOrCreateAccount(address)
+	if err != nil || account == nil || account.IsInterfaceNil() {
+		return account, err
+	}
+
+	if !witnessAccount.Exists {
+		witnessAccount.Exists = true
+		witnessAccount.Nonce = account.GetNonce()
+		witnessAccount.Balance = account.GetBalance()
+		witnessAccount.CodeMetadata = account.GetCodeMetadata()
+		witnessAccount.CodeHash = account.GetCodeHash()
+		witnessAccount.OwnerAddress = account.GetOwnerAddress()
+	}
+
+	return account, nil
+}
+
+// GetCode returns the code of the given account, recording it on the first read
+func (recorder *witnessRecorder) GetCode(account vmcommon.UserAccountHandler) []byte {
+	code := recorder.BlockchainHook.GetCode(account)
+	if account == nil || account.IsInterfaceNil() {
+		return code
+	}
+
+	recorder.mutRecords.Lock()
+	defer recorder.mutRecords.Unlock()
+
+	witnessAccount := recorder.getOrCreateAccount(account.AddressBytes())
+	if witnessAccount.Code == nil {
+		witnessAccount.Code = code
+	}
+
+	return code
+}
+
+// GetGasModifier forwards to the decorated BlockchainHook, if it is an arwen.GasModifierProvider
+func (recorder *witnessRecorder) GetGasModifier(address []byte) (arwen.GasModifier, bool) {
+	return getGasModifier(recorder.BlockchainHook, address)
+}
+
+func (recorder *witnessRecorder) recordStorage(address []byte, key []byte, value []byte) {
+	recorder.mutRecords.Lock()
+	defer recorder.mutRecords.Unlock()
+
+	recorder.getOrCreateAccount(address)
+
+	accountStorage, ok := recorder.storage[string(address)]
+	if !ok {
+		accountStorage = make(map[string][]byte)
+		recorder.storage[string(address)] = accountStorage
+	}
+
+	_, alreadyRead := accountStorage[string(key)]
+	if !alreadyRead {
+		accountStorage[string(key)] = value
+	}
+}
+
+func (recorder *witnessRecorder) getOrCreateAccount(address []byte) *arwen.WitnessAccount {
+	witnessAccount, ok := recorder.accounts[string(address)]
+	if !ok {
+		witnessAccount = &arwen.WitnessAccount{Address: address}
+		recorder.accounts[string(address)] = witnessAccount
+	}
+
+	return witnessAccount
+}
+
+func (recorder *witnessRecorder) buildWitness() *arwen.ExecutionWitness {
+	recorder.mutRecords.Lock()
+	defer recorder.mutRecords.Unlock()
+
+	witness := &arwen.ExecutionWitness{
+		Accounts: make([]*arwen.WitnessAccount, 0, len(recorder.accounts)),
+	}
+
+	for address, recordedAccount := range recorder.accounts {
+		witnessAccount := *recordedAccount
+		witnessAccount.Storage = make([]arwen.WitnessStorageEntry, 0, len(recorder.storage[address]))
+		for key, value := range recorder.storage[address] {
+			witnessAccount.Storage = append(witnessAccount.Storage, arwen.WitnessStorageEntry{
+				Key:   []byte(key),
+				Value: value,
+			})
+		}
+		sort.Slice(witnessAccount.Storage, func(i, j int) bool {
+			return bytes.Compare(witnessAccount.Storage[i].Key, witnessAccount.Storage[j].Key) < 0
+		})
+
+		witness.Accounts = append(witness.Accounts, &witnessAccount)
+	}
+	sort.Slice(witness.Accounts, func(i, j int) bool {
+		return bytes.Compare(witness.Accounts[i].Address, witness.Accounts[j].Address) < 0
+	})
+
+	return witness
+}
+
+// getGasModifier returns the GasModifier of the given contract, if the given
+// BlockchainHook is an arwen.GasModifierProvider; it lets the decorators of
+// the BlockchainHook preserve its gas modifiers
+func getGasModifier(blockChainHook vmcommon.BlockchainHook, address []byte) (arwen.GasModifier, bool) {
+	provider, ok := blockChainHook.(arwen.GasModifierProvider)
+	if !ok {
+		return arwen.GasModifier{}, false
+	}
+
+	return provider.GetGasModifier(address)
+}
@@ -0,0 +1,176 @@
+package host
+
+import (
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
+	"github.com/ElrondNetwork/elrond-go/core/vmcommon"
+)
+
+// GetHookCacheStats returns the hits and misses of the hook cache during the
+// last execution; it returns nil if the VMHost was not created with
+// HookCacheEnabled
+func (host *vmHost) GetHookCacheStats() *arwen.HookCacheStats {
+	host.mutExecution.RLock()
+	defer host.mutExecution.RUnlock()
+
+	if host.hookCache == nil {
+		return nil
+	}
+
+	stats := host.hookCache.stats
+	return &stats
+}
+
+// cachedAccount holds the result of a GetUserAccount call, along with the
+// code of the account, once requested
+type cachedAccount struct {
+	account vmcommon.UserAccountHandler
+	code    []byte
+	hasCode bool
+}
+
+// hookCache decorates the BlockchainHook of the VMHost, memoizing the
+// accounts, their code, their shard and whether they are contracts, for the
+// duration of a single execution. The state of the node only changes during
+// an execution when a built-in function is processed, so the accounts
+// involved in a built-in function call are invalidated, as are all of them
+// when the node reverts to a snapshot. The cache is reset before every
+// execution.
+type hookCache struct {
+	vmcommon.BlockchainHook
+	accounts       map[string]*cachedAccount
+	shards         map[string]uint32
+	smartContracts map[string]bool
+	stats          arwen.HookCacheStats
+}
+
+func newHookCache(blockChainHook vmcommon.BlockchainHook) *hookCache {
+	cache := &hookCache{
+		BlockchainHook: blockChainHook,
+	}
+	cache.reset()
+
+	return cache
+}
+
+func (cache *hookCache) reset() {
+	cache.clear()
+	cache.shards = make(map[string]uint32)
+	cache.stats = arwen.HookCacheStats{}
+}
+
+// clear drops the cached accounts, keeping the shards, which do not depend
+// on the state of the node
+func (cache *hookCache) clear() {
+	cache.accounts = make(map[string]*cachedAccount)
+	cache.smartContracts = make(map[string]bool)
+}
+
+func (cache *hookCache) invalidate(address []byte) {
+	delete(cache.accounts, string(address))
+	delete(cache.smartContracts, string(address))
+}
+
+func (cache *hookCache) recordLookup(hit bool) {
+	if hit {
+		cache.stats.Hits++
+		return
+	}
+	cache.stats.Misses++
+}
+
+// GetUserAccount returns the given account, from the cache if it was already
+// read during the current execution; failed reads are not cached
+func (cache *hookCache) GetUserAccount(address []byte) (vmcommon.UserAccountHandler, error) {
+	cached, ok := cache.accounts[string(address)]
+	cache.recordLookup(ok)
+	if ok {
+		return cached.account, nil
+	}
+
+	account, err := cache.BlockchainHook.GetUserAccount(address)
+	if err != nil {
+		return account, err
+	}
+
+	cache.accounts[string(address)] = &cachedAccount{account: account}
+	return account, nil
+}
+
+// GetCode returns the code of the given account, from the cache if it was
+// already read during the current execution
+func (cache *hookCache) GetCode(account vmcommon.UserAccountHandler) []byte {
+	if arwen.IfNil(account) {
+		return cache.BlockchainHook.GetCode(account)
+	}
+
+	cached, ok := cache.accounts[string(account.AddressBytes())]
+	if ok && cached.hasCode {
+		cache.recordLookup(true)
+		return cached.code
+	}
+
+	cache.recordLookup(false)
+	code := cache.BlockchainHook.GetCode(account)
+	if ok {
+		cached.code = code
+		cached.hasCode = true
+	}
+	return code
+}
+
+// GetShardOfAddress returns the shard of the given address, from the cache
+// if it was already requested during the current execution
+func (cache *hookCache) GetShardOfAddress(address []byte) uint32 {
+	shard, ok := cache.shards[string(address)]
+	cache.recordLookup(ok)
+	if ok {
+		return shard
+	}
+
+	shard = cache.BlockchainHook.GetShardOfAddress(address)
+	cache.shards[string(address)] = shard
+	return shard
+}
+
+// IsSmartContract returns whether the given address belongs to a contract,
+// from the cache if it was already requested during the current execution
+func (cache *hookCache) IsSmartContract(address []byte) bool {
+	isSmartContract, ok := cache.smartContracts[string(address)]
+	cache.recordLookup(ok)
+	if ok {
+		return isSmartContract
+	}
+
+	isSmartContract = cache.BlockchainHook.IsSmartContract(address)
+	cache.smartContracts[string(address)] = isSmartContract
+	return isSmartContract
+}
+
+// ProcessBuiltInFunction processes the given built-in function call, then
+// invalidates its caller, its recipient and the accounts changed by it
+func (cache *hookCache) ProcessBuiltInFunction(input *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+	vmOutput, err := cache.BlockchainHook.ProcessBuiltInFunction(input)
+
+	cache.invalidate(input.CallerAddr)
+	cache.invalidate(input.RecipientAddr)
+	if vmOutput != nil {
+		for _, account := range vmOutput.OutputAccounts {
+			cache.invalidate(account.Address)
+		}
+	}
+
+	return vmOutput, err
+}
+
+// RevertToSnapshot reverts the state of the node to the given snapshot, then
+// drops the cached accounts
+func (cache *hookCache) RevertToSnapshot(snapshot int) error {
+	err := cache.BlockchainHook.RevertToSnapshot(snapshot)
+	cache.clear()
+	return err
+}
+
+// GetGasModifier forwards to the decorated BlockchainHook, if it is an arwen.GasModifierProvider
+func (cache *hookCache) GetGasModifier(address []byte) (arwen.GasModifier, bool) {
+	return getGasModifier(cache.BlockchainHook, address)
+}
@@ -0,0 +1,107 @@
+package host
+
+import (
+	"bytes"
+	"math/big"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
+	"github.com/ElrondNetwork/elrond-go/core"
+	"github.com/ElrondNetwork/elrond-go/core/vmcommon"
+)
+
+func (host *vmHost) isPaymentNotificationEnabled() bool {
+	return host.paymentNotificationGasLimit > 0
+}
+
+// queuePaymentNotification records an ESDT transfer to be notified to the
+// recipient when the current execution ends, if the recipient is a contract
+// in the same shard as the sender
+func (host *vmHost) queuePaymentNotification(notification *arwen.PaymentNotification) {
+	if !host.isPaymentNotificationEnabled() {
+		return
+	}
+	if bytes.Equal(notification.Sender, notification.Recipient) {
+		return
+	}
+	if !host.AreInSameShard(notification.Sender, notification.Recipient) {
+		return
+	}
+	if !host.Blockchain().IsSmartContract(notification.Recipient) {
+		return
+	}
+
+	host.paymentNotifications = append(host.paymentNotifications, notification)
+}
+
+// queuePaymentNotificationForBuiltinFunction records the ESDT transfer
+// performed by a built-in function, which did not execute the recipient
+func (host *vmHost) queuePaymentNotificationForBuiltinFunction(input *vmcommon.ContractCallInput, vmOutput *vmcommon.VMOutput) {
+	if vmOutput.ReturnCode != vmcommon.Ok {
+		return
+	}
+
+	notifications := host.getBuiltinOutputProcessor(input.Function).PaymentNotifications(input)
+	for _, notification := range notifications {
+		host.queuePaymentNotification(notification)
+	}
+}
+
+// notifyReceivedPayments calls PaymentReceivedFunctionName on the recipient of
+// each queued notification, including the ones queued by the notifications
+// themselves, with at most paymentNotificationGasLimit gas for each call; a
+// failed notification only reverts its own changes
+func (host *vmHost) notifyReceivedPayments() {
+	for len(host.paymentNotifications) > 0 {
+		notification := host.paymentNotifications[0]
+		host.paymentNotifications = host.paymentNotifications[1:]
+
+		err := host.notifyReceivedPayment(notification)
+		if err != nil {
+			log.Trace("payment notification failed", "recipient", notification.Recipient, "error", err)
+		}
+	}
+}
+
+func (host *vmHost) notifyReceivedPayment(notification *arwen.PaymentNotification) error {
+	runtime := host.Runtime()
+
+	gasLimit := host.Metering().GasLeft()
+	if gasLimit > host.paymentNotificationGasLimit {
+		gasLimit = host.paymentNotificationGasLimit
+	}
+	if gasLimit == 0 {
+		return arwen.ErrNotEnoughGas
+	}
+
+	notificationInput := &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr:     notification.Sender,
+			Arguments:      notification.Arguments(),
+			CallValue:      big.NewInt(0),
+			CallType:       vmcommon.DirectCall,
+			GasPrice:       runtime.GetVMInput().GasPrice,
+			GasProvided:    gasLimit,
+			CurrentTxHash:  runtime.GetCurrentTxHash(),
+			OriginalTxHash: runtime.GetOriginalTxHash(),
+			ESDTValue:      notification.Value,
+			ESDTTokenName:  notification.TokenIdentifier,
+			ESDTTokenNonce: notification.Nonce,
+		},
+		RecipientAddr: notification.Recipient,
+		Function:      arwen.PaymentReceivedFunctionName,
+	}
+	if notification.Nonce > 0 {
+		notificationInput.ESDTTokenType = uint32(core.NonFungible)
+	}
+
+	_, _, err := host.ExecuteOnDestContext(notificationInput)
+	return err
+}
+
+// discardPaymentNotificationsAfter drops the notifications queued by a failed
+// execution, since the transfers they refer to have been reverted
+func (host *vmHost) discardPaymentNotificationsAfter(numNotifications int) {
+	if len(host.paymentNotifications) > numNotifications {
+		host.paymentNotifications = host.paymentNotifications[:numNotifications]
+	}
+}
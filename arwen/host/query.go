@@ -0,0 +1,18 @@
+package host
+
+import (
+	"github.com/ElrondNetwork/elrond-go/core/vmcommon"
+)
+
+// prepareQueryInput returns a copy of the input of a call received from the
+// SC query service, with its gas bounded by the MaxQueryGasLimit. The given
+// input is never modified, because it belongs to the caller.
+func (host *vmHost) prepareQueryInput(input *vmcommon.ContractCallInput) *vmcommon.ContractCallInput {
+	queryInput := *input
+	if host.maxQueryGasLimit > 0 && queryInput.GasProvided > host.maxQueryGasLimit {
+		log.Trace("query gas limited", "function", input.Function, "gas provided", input.GasProvided, "limit", host.maxQueryGasLimit)
+		queryInput.GasProvided = host.maxQueryGasLimit
+	}
+
+	return &queryInput
+}
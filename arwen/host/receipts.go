@@ -0,0 +1,76 @@
+package host
+
+import (
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
+	"github.com/ElrondNetwork/elrond-go/core/vmcommon"
+)
+
+// addFailedCallReceipt records the Receipt of the value given back to the
+// caller of a nested execution which failed
+func (host *vmHost) addFailedCallReceipt(input *vmcommon.ContractCallInput) {
+	if !host.receiptsEnabled {
+		return
+	}
+
+	receipt := arwen.NewFailedCallReceipt(
+		input.CallerAddr,
+		input.RecipientAddr,
+		input.CallValue,
+		input.CallType,
+	)
+	if receipt != nil {
+		host.Output().AddReceipt(receipt)
+	}
+}
+
+// addCallbackReceipt records the Receipt of a callback executed on this host,
+// for the contract which made the async call
+func (host *vmHost) addCallbackReceipt(callbackInput *vmcommon.ContractCallInput, callbackVMOutput *vmcommon.VMOutput) {
+	if !host.receiptsEnabled || callbackVMOutput == nil {
+		return
+	}
+
+	host.Output().AddReceipt(arwen.NewCallbackReceipt(
+		callbackInput.RecipientAddr,
+		callbackInput.CallerAddr,
+		callbackInput.Function,
+		callbackInput.CallValue,
+		callbackVMOutput.ReturnCode,
+	))
+}
+
+// attachTransactionReceipts appends to the VMOutput of a transaction the
+// Receipts of its own outcome, which is not part of its output state: the
+// execution of a callback sent by the node, or the value given back to the
+// sender of a failed transaction.
+func (host *vmHost) attachTransactionReceipts(input *vmcommon.ContractCallInput, vmOutput *vmcommon.VMOutput) {
+	if !host.receiptsEnabled || vmOutput == nil {
+		return
+	}
+
+	output := host.Output()
+	if input.CallType == vmcommon.AsynchronousCallBack {
+		output.AttachReceipt(vmOutput, arwen.NewCallbackReceipt(
+			input.RecipientAddr,
+			input.CallerAddr,
+			input.Function,
+			input.CallValue,
+			vmOutput.ReturnCode,
+		))
+		return
+	}
+
+	if vmOutput.ReturnCode == vmcommon.Ok {
+		return
+	}
+
+	receipt := arwen.NewFailedCallReceipt(
+		input.CallerAddr,
+		input.RecipientAddr,
+		input.CallValue,
+		input.CallType,
+	)
+	if receipt != nil {
+		output.AttachReceipt(vmOutput, receipt)
+	}
+}
@@ -0,0 +1,81 @@
+package host
+
+import (
+	"bytes"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
+	"github.com/ElrondNetwork/elrond-go/core/vmcommon"
+)
+
+// GetStorageDiff returns the storage keys changed by the last contract call
+// or deployment, with the values they held before and after it; it returns
+// nil if the VMHost was not created with StorageDiffEnabled
+func (host *vmHost) GetStorageDiff() *arwen.StorageDiff {
+	host.mutExecution.RLock()
+	defer host.mutExecution.RUnlock()
+
+	return host.storageDiff
+}
+
+func (host *vmHost) recordStorageDiff(vmOutput *vmcommon.VMOutput) {
+	if host.storageDiffEnabled {
+		host.storageDiff = host.accessRecorder.storageDiff(vmOutput)
+	}
+}
+
+// storageDiff returns the storage changes made by the given VMOutput, if
+// any. The value held by a key before the execution is the one first read
+// from the BlockchainHook, or, if the execution wrote the key without
+// reading it, the one still held by the BlockchainHook, which the VMOutput
+// has not yet been applied to.
+func (recorder *accessRecorder) storageDiff(vmOutput *vmcommon.VMOutput) *arwen.StorageDiff {
+	changes := make([]*arwen.StorageChange, 0)
+	if vmOutput == nil {
+		return arwen.NewStorageDiff(changes)
+	}
+
+	for _, outputAccount := range vmOutput.OutputAccounts {
+		for _, storageUpdate := range outputAccount.StorageUpdates {
+			before := recorder.valueBefore(outputAccount.Address, storageUpdate.Offset)
+			if bytes.Equal(before, storageUpdate.Data) {
+				continue
+			}
+
+			changes = append(changes, &arwen.StorageChange{
+				Address: outputAccount.Address,
+				Key:     storageUpdate.Offset,
+				Before:  before,
+				After:   storageUpdate.Data,
+			})
+		}
+	}
+
+	return arwen.NewStorageDiff(changes)
+}
+
+func (recorder *accessRecorder) valueBefore(address []byte, key []byte) []byte {
+	value, wasRead := recorder.recordedStorage(address, key)
+	if wasRead {
+		return value
+	}
+
+	value, err := recorder.BlockchainHook.GetStorageData(address, key)
+	if err != nil {
+		return nil
+	}
+
+	return value
+}
+
+func (recorder *accessRecorder) recordedStorage(address []byte, key []byte) ([]byte, bool) {
+	recorder.mutRecords.Lock()
+	defer recorder.mutRecords.Unlock()
+
+	accessed, wasRead := recorder.accounts[string(address)]
+	if !wasRead {
+		return nil, false
+	}
+
+	value, wasReadKey := accessed.storage[string(key)]
+	return value, wasReadKey
+}
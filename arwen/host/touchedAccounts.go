@@ -0,0 +1,241 @@
+package host
+
+import (
+	"bytes"
+	"sort"
+	"sync"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
+	"github.com/ElrondNetwork/elrond-go/core/vmcommon"
+	"github.com/ElrondNetwork/elrond-go/data/esdt"
+)
+
+// GetTouchedAccounts returns the accounts read and written during the last
+// contract call or deployment
+func (host *vmHost) GetTouchedAccounts() *arwen.TouchedAccounts {
+	host.mutExecution.RLock()
+	defer host.mutExecution.RUnlock()
+
+	return host.touchedAccounts
+}
+
+func (host *vmHost) recordTouchedAccounts(vmOutput *vmcommon.VMOutput) {
+	host.touchedAccounts = host.accessRecorder.touchedAccounts(vmOutput)
+}
+
+// accessedAccount holds what the accessRecorder needs to tell whether an
+// account read from the BlockchainHook was also written by the VMOutput
+type accessedAccount struct {
+	nonce   uint64
+	storage map[string][]byte
+}
+
+// accessRecorder decorates the BlockchainHook of the VMHost, recording the
+// accounts read from it. Unlike the witnessRecorder, it is always in place,
+// so it only keeps the nonce and the storage values first read from each
+// account, which are needed to tell the accounts actually written by the
+// VMOutput apart from those merely cached in it.
+type accessRecorder struct {
+	vmcommon.BlockchainHook
+	mutRecords sync.Mutex
+	accounts   map[string]*accessedAccount
+}
+
+func newAccessRecorder(blockChainHook vmcommon.BlockchainHook) *accessRecorder {
+	recorder := &accessRecorder{
+		BlockchainHook: blockChainHook,
+	}
+	recorder.reset()
+
+	return recorder
+}
+
+func (recorder *accessRecorder) reset() {
+	recorder.mutRecords.Lock()
+	defer recorder.mutRecords.Unlock()
+
+	recorder.accounts = make(map[string]*accessedAccount)
+}
+
+// GetStorageData returns the value under the given key from the storage of
+// the given account, recording it on the first read
+func (recorder *accessRecorder) GetStorageData(accountAddress []byte, index []byte) ([]byte, error) {
+	value, err := recorder.BlockchainHook.GetStorageData(accountAddress, index)
+	recorder.recordStorage(accountAddress, index, value)
+	return value, err
+}
+
+// GetAllState returns the whole storage of the given account, recording all its entries
+func (recorder *accessRecorder) GetAllState(address []byte) (map[string][]byte, error) {
+	state, err := recorder.BlockchainHook.GetAllState(address)
+	recorder.recordAccount(address)
+	for key, value := range state {
+		recorder.recordStorage(address, []byte(key), value)
+	}
+	return state, err
+}
+
+// GetUserAccount returns the given account, recording its nonce on the first read
+func (recorder *accessRecorder) GetUserAccount(address []byte) (vmcommon.UserAccountHandler, error) {
+	account, err := recorder.BlockchainHook.GetUserAccount(address)
+
+	recorder.mutRecords.Lock()
+	defer recorder.mutRecords.Unlock()
+
+	_, alreadyRead := recorder.accounts[string(address)]
+	accessed := recorder.getOrCreateAccount(address)
+	if !alreadyRead && err == nil && !arwen.IfNil(account) {
+		accessed.nonce = account.GetNonce()
+	}
+
+	return account, err
+}
+
+// GetCode returns the code of the given account, recording the account
+func (recorder *accessRecorder) GetCode(account vmcommon.UserAccountHandler) []byte {
+	if !arwen.IfNil(account) {
+		recorder.recordAccount(account.AddressBytes())
+	}
+	return recorder.BlockchainHook.GetCode(account)
+}
+
+// IsSmartContract returns whether the given address belongs to a contract, recording the account
+func (recorder *accessRecorder) IsSmartContract(address []byte) bool {
+	recorder.recordAccount(address)
+	return recorder.BlockchainHook.IsSmartContract(address)
+}
+
+// IsPayable returns whether the given account accepts payments, recording the account
+func (recorder *accessRecorder) IsPayable(address []byte) (bool, error) {
+	recorder.recordAccount(address)
+	return recorder.BlockchainHook.IsPayable(address)
+}
+
+// GetESDTToken returns the given ESDT token of the given account, recording the account
+func (recorder *accessRecorder) GetESDTToken(address []byte, tokenID []byte, nonce uint64) (*esdt.ESDigitalToken, error) {
+	recorder.recordAccount(address)
+	return recorder.BlockchainHook.GetESDTToken(address, tokenID, nonce)
+}
+
+// NewAddress returns the address of a new contract, recording its creator
+func (recorder *accessRecorder) NewAddress(creatorAddress []byte, creatorNonce uint64, vmType []byte) ([]byte, error) {
+	recorder.recordAccount(creatorAddress)
+	return recorder.BlockchainHook.NewAddress(creatorAddress, creatorNonce, vmType)
+}
+
+// ProcessBuiltInFunction processes the given built-in function call,
+// recording its caller and its recipient; the accounts it changes are
+// recorded from its output, once merged into the VMOutput
+func (recorder *accessRecorder) ProcessBuiltInFunction(input *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+	recorder.recordAccount(input.CallerAddr)
+	recorder.recordAccount(input.RecipientAddr)
+	return recorder.BlockchainHook.ProcessBuiltInFunction(input)
+}
+
+// GetGasModifier forwards to the decorated BlockchainHook, if it is an arwen.GasModifierProvider
+func (recorder *accessRecorder) GetGasModifier(address []byte) (arwen.GasModifier, bool) {
+	return getGasModifier(recorder.BlockchainHook, address)
+}
+
+func (recorder *accessRecorder) recordAccount(address []byte) {
+	recorder.mutRecords.Lock()
+	defer recorder.mutRecords.Unlock()
+
+	recorder.getOrCreateAccount(address)
+}
+
+func (recorder *accessRecorder) recordStorage(address []byte, key []byte, value []byte) {
+	recorder.mutRecords.Lock()
+	defer recorder.mutRecords.Unlock()
+
+	accessed := recorder.getOrCreateAccount(address)
+	_, alreadyRead := accessed.storage[string(key)]
+	if !alreadyRead {
+		accessed.storage[string(key)] = value
+	}
+}
+
+func (recorder *accessRecorder) getOrCreateAccount(address []byte) *accessedAccount {
+	accessed, ok := recorder.accounts[string(address)]
+	if !ok {
+		accessed = &accessedAccount{storage: make(map[string][]byte)}
+		recorder.accounts[string(address)] = accessed
+	}
+
+	return accessed
+}
+
+// touchedAccounts returns the accounts read until now, and the accounts
+// actually written by the given VMOutput, if any
+func (recorder *accessRecorder) touchedAccounts(vmOutput *vmcommon.VMOutput) *arwen.TouchedAccounts {
+	recorder.mutRecords.Lock()
+	defer recorder.mutRecords.Unlock()
+
+	touched := &arwen.TouchedAccounts{
+		Read:    make([][]byte, 0, len(recorder.accounts)),
+		Written: make([][]byte, 0),
+	}
+	for address := range recorder.accounts {
+		touched.Read = append(touched.Read, []byte(address))
+	}
+
+	if vmOutput != nil {
+		for _, outputAccount := range vmOutput.OutputAccounts {
+			if recorder.isWritten(outputAccount) {
+				touched.Written = append(touched.Written, outputAccount.Address)
+			}
+		}
+		for _, address := range vmOutput.DeletedAccounts {
+			if !containsAddress(touched.Written, address) {
+				touched.Written = append(touched.Written, address)
+			}
+		}
+	}
+
+	sortAddresses(touched.Read)
+	sortAddresses(touched.Written)
+
+	return touched
+}
+
+// isWritten returns true if the given OutputAccount changes the state of the
+// account, rather than only caching what was read from it
+func (recorder *accessRecorder) isWritten(outputAccount *vmcommon.OutputAccount) bool {
+	if outputAccount.BalanceDelta != nil && outputAccount.BalanceDelta.Sign() != 0 {
+		return true
+	}
+	if len(outputAccount.Code) > 0 || len(outputAccount.CodeMetadata) > 0 || len(outputAccount.OutputTransfers) > 0 {
+		return true
+	}
+
+	accessed, wasRead := recorder.accounts[string(outputAccount.Address)]
+	if !wasRead {
+		return outputAccount.Nonce > 0 || len(outputAccount.StorageUpdates) > 0
+	}
+	if outputAccount.Nonce > accessed.nonce {
+		return true
+	}
+	for key, storageUpdate := range outputAccount.StorageUpdates {
+		value, wasReadKey := accessed.storage[key]
+		if !wasReadKey || !bytes.Equal(value, storageUpdate.Data) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func containsAddress(addresses [][]byte, address []byte) bool {
+	for _, existing := range addresses {
+		if bytes.Equal(existing, address) {
+			return true
+		}
+	}
+	return false
+}
+
+func sortAddresses(addresses [][]byte) {
+	sort.Slice(addresses, func(i, j int) bool {
+		return bytes.Compare(addresses[i], addresses[j]) < 0
+	})
+}
@@ -0,0 +1,43 @@
+package host
+
+import (
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
+)
+
+// GetTouchedStorageKeys returns the storage keys read during the last
+// contract call or deployment; it returns nil if the VMHost was not created
+// with StorageKeyRecordingEnabled
+func (host *vmHost) GetTouchedStorageKeys() *arwen.TouchedStorageKeys {
+	host.mutExecution.RLock()
+	defer host.mutExecution.RUnlock()
+
+	return host.touchedStorageKeys
+}
+
+func (host *vmHost) recordTouchedStorageKeys() {
+	if host.storageKeyRecordingEnabled {
+		host.touchedStorageKeys = host.accessRecorder.touchedStorageKeys()
+	}
+}
+
+// touchedStorageKeys returns the storage keys read until now. The Storage
+// context retrieves each key from the BlockchainHook the first time it is
+// loaded or written during the transaction, so these are all the keys
+// touched by the execution, including by the nested executions which were
+// reverted.
+func (recorder *accessRecorder) touchedStorageKeys() *arwen.TouchedStorageKeys {
+	recorder.mutRecords.Lock()
+	defer recorder.mutRecords.Unlock()
+
+	touched := make([]*arwen.TouchedStorageKey, 0)
+	for address, accessed := range recorder.accounts {
+		for key := range accessed.storage {
+			touched = append(touched, &arwen.TouchedStorageKey{
+				Address: []byte(address),
+				Key:     []byte(key),
+			})
+		}
+	}
+
+	return arwen.NewTouchedStorageKeys(touched)
+}
@@ -0,0 +1,151 @@
+package hosttest
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
+	contextmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/context"
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/contracts"
+	worldmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/world"
+	test "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/testcommon"
+	"github.com/stretchr/testify/require"
+)
+
+const asyncCallGasCapActive = uint32(0)
+const asyncCallGasCapInactive = uint32(math.MaxUint32)
+
+var nephewAddress = test.MakeTestSCAddress("nephewSC")
+
+var asyncCallTreeTestConfig = contracts.AsyncCallBaseTestConfig{
+	GasProvided:       10000,
+	GasUsedByParent:   100,
+	GasUsedByChild:    20,
+	GasUsedByCallback: 10,
+	ParentBalance:     1000,
+	ChildBalance:      1000,
+}
+
+func TestAsyncCallTree_NestedAsyncCalls(t *testing.T) {
+	testConfig := asyncCallTreeTestConfig
+
+	var vmHost arwen.VMHost
+	test.BuildMockInstanceCallTest(t).
+		WithContracts(
+			test.CreateMockContract(test.ParentAddress).
+				WithBalance(testConfig.ParentBalance).
+				WithConfig(&testConfig).
+				WithMethods(contracts.NestAsyncCallMock, contracts.NestedCallBackMock),
+			test.CreateMockContract(test.ChildAddress).
+				WithBalance(testConfig.ChildBalance).
+				WithConfig(&testConfig).
+				WithMethods(contracts.NestAsyncCallMock, contracts.NestedCallBackMock),
+			test.CreateMockContract(nephewAddress).
+				WithBalance(testConfig.ChildBalance).
+				WithConfig(&testConfig).
+				WithMethods(contracts.NestAsyncCallMock, contracts.NestedCallBackMock),
+		).
+		WithInput(test.CreateTestContractCallInputBuilder().
+			WithCallerAddr(test.UserAddress).
+			WithRecipientAddr(test.ParentAddress).
+			WithGasProvided(testConfig.GasProvided).
+			WithFunction("nestAsyncCall").
+			WithArguments(test.ChildAddress, nephewAddress).
+			Build()).
+		WithSetup(func(host arwen.VMHost, world *worldmock.MockWorld) {
+			setZeroCodeCosts(host)
+			setAsyncCosts(host, testConfig.GasLockCost)
+			vmHost = host
+		}).
+		AndAssertResults(func(world *worldmock.MockWorld, verify *test.VMOutputVerifier) {
+			verify.Ok()
+		})
+
+	asyncCallTree := vmHost.GetAsyncCallTree()
+	require.NotNil(t, asyncCallTree)
+	require.Equal(t, 2, asyncCallTree.Depth())
+	require.Len(t, asyncCallTree.Calls, 1)
+
+	parentCall := asyncCallTree.Calls[0]
+	require.Equal(t, test.ParentAddress, parentCall.Caller)
+	require.Equal(t, test.ChildAddress, parentCall.Destination)
+	require.Equal(t, contracts.NestedAsyncCallGroup, parentCall.GroupID)
+	require.Equal(t, arwen.AsyncCallResolved, parentCall.Status)
+	require.False(t, parentCall.CrossShard)
+	require.Len(t, parentCall.Children, 1)
+
+	// the nested call is registered by the child, in a group of its own, and
+	// is paid from the gas provided to the call of the parent
+	childCall := parentCall.Children[0]
+	require.Equal(t, test.ChildAddress, childCall.Caller)
+	require.Equal(t, nephewAddress, childCall.Destination)
+	require.Equal(t, contracts.NestedAsyncCallGroup, childCall.GroupID)
+	require.Equal(t, arwen.AsyncCallResolved, childCall.Status)
+	require.Empty(t, childCall.Children)
+	require.Less(t, childCall.GasLimit+childCall.GasLocked, parentCall.GasLimit)
+	require.Equal(t, childCall.GasLimit-testConfig.GasUsedByChild, childCall.GasRemaining)
+
+	// the callback of the nested call is executed before the callback of the parent
+	callbacks := asyncCallTree.Callbacks()
+	require.Equal(t, []*arwen.AsyncCallNode{childCall, parentCall}, callbacks)
+	require.Equal(t, "nestedCallBack", parentCall.Callback)
+	require.Equal(t, "nestedCallBack", childCall.Callback)
+}
+
+func TestAsyncCallTree_ResetByExecution(t *testing.T) {
+	host, _ := test.DefaultTestArwenForCall(t, test.GetTestSCCode("counter", "../../"), nil)
+	require.Empty(t, host.GetAsyncCallTree().Calls)
+
+	input := test.DefaultTestContractCallInput()
+	input.Function = "increment"
+	input.GasProvided = 1000000
+	vmOutput, err := host.RunSmartContractCall(input)
+	require.Nil(t, err)
+	require.NotNil(t, vmOutput)
+
+	require.Empty(t, host.GetAsyncCallTree().Calls)
+	require.Equal(t, 0, host.GetAsyncCallTree().Depth())
+}
+
+func TestAsyncCallTree_GasCappedToAsyncCall(t *testing.T) {
+	testConfig := asyncCallTreeTestConfig
+
+	for _, enableEpoch := range []uint32{asyncCallGasCapActive, asyncCallGasCapInactive} {
+		world := worldmock.NewMockWorld()
+		parameters := test.DefaultTestVMHostParameters()
+		parameters.AsyncCallGasCapEnableEpoch = enableEpoch
+		host := test.DefaultTestArwenWithParameters(t, world, parameters)
+
+		instanceBuilderMock := contextmock.NewInstanceBuilderMock(world)
+		host.Runtime().ReplaceInstanceBuilder(instanceBuilderMock)
+		setZeroCodeCosts(host)
+		setAsyncCosts(host, testConfig.GasLockCost)
+
+		for _, address := range [][]byte{test.ParentAddress, test.ChildAddress, nephewAddress} {
+			instance := instanceBuilderMock.CreateAndStoreInstanceMock(t, host, address, 0, testConfig.ParentBalance)
+			contracts.NestAsyncCallMock(instance, &testConfig)
+			contracts.NestedCallBackMock(instance, &testConfig)
+		}
+		world.CreateStateBackup()
+
+		vmOutput, err := host.RunSmartContractCall(test.CreateTestContractCallInputBuilder().
+			WithCallerAddr(test.UserAddress).
+			WithRecipientAddr(test.ParentAddress).
+			WithGasProvided(testConfig.GasProvided).
+			WithFunction("nestAsyncCall").
+			WithArguments(test.ChildAddress, nephewAddress).
+			Build())
+		test.NewVMOutputVerifier(t, vmOutput, err).Ok()
+
+		parentCall := host.GetAsyncCallTree().Calls[0]
+		childCall := parentCall.Children[0]
+		require.Equal(t, uint64(4990), parentCall.GasLimit)
+		if enableEpoch == asyncCallGasCapInactive {
+			// the child is provided all the gas left to the parent, half of
+			// which it passes on to its own async call
+			require.Equal(t, uint64(4980), childCall.GasLimit)
+			continue
+		}
+		require.Equal(t, uint64(2485), childCall.GasLimit)
+	}
+}
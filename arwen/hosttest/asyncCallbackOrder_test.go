@@ -0,0 +1,103 @@
+package hosttest
+
+import (
+	"testing"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/contracts"
+	worldmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/world"
+	test "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/testcommon"
+	"github.com/ElrondNetwork/elrond-go/core/vmcommon"
+)
+
+var asyncCallbackOrderTestConfig = contracts.AsyncCallbackOrderTestConfig{
+	AsyncCallBaseTestConfig: asyncBaseTestConfig,
+	GasProvidedToChild:      500,
+}
+
+func TestAsyncCallbackOrder(t *testing.T) {
+	testCases := []struct {
+		order  arwen.AsyncCallbackOrder
+		seenBy [][]byte
+	}{
+		// each async call sees the callbacks of the calls completed before it
+		{arwen.AsyncCallbackOrderCompletion, [][]byte{{}, []byte("f1;"), []byte("f1;f2;"), []byte("f1;f2;f3;")}},
+		// all the async calls of a group are executed before its callbacks
+		{arwen.AsyncCallbackOrderRegistration, [][]byte{{}, {}, {}, []byte("f1;f2;f3;")}},
+	}
+
+	for _, testCase := range testCases {
+		testConfig := asyncCallbackOrderTestConfig
+		testConfig.GasProvided = 10000
+		testConfig.CallbackOrder = testCase.order
+
+		// the groups are processed in the order of their identifiers, whatever the order of the map
+		for i := 0; i < 10; i++ {
+			test.BuildMockInstanceCallTest(t).
+				WithContracts(
+					test.CreateMockContract(test.ParentAddress).
+						WithBalance(testConfig.ParentBalance).
+						WithConfig(&testConfig).
+						WithMethods(contracts.PerformAsyncCallbackOrderParentMock, contracts.AppendToLogParentMock),
+					test.CreateMockContract(test.ChildAddress).
+						WithBalance(testConfig.ChildBalance).
+						WithConfig(&testConfig).
+						WithMethods(contracts.RecordLogChildMock),
+				).
+				WithInput(test.CreateTestContractCallInputBuilder().
+					WithRecipientAddr(test.ParentAddress).
+					WithGasProvided(testConfig.GasProvided).
+					WithFunction("performAsyncCallbackOrder").
+					Build()).
+				WithSetup(func(host arwen.VMHost, world *worldmock.MockWorld) {
+					setZeroCodeCosts(host)
+					setAsyncCosts(host, testConfig.GasLockCost)
+					// the child reads the log of the parent
+					world.AcctMap.GetAccount(test.ParentAddress).CodeMetadata = []byte{vmcommon.MetadataReadable, 0}
+				}).
+				AndAssertResults(func(world *worldmock.MockWorld, verify *test.VMOutputVerifier) {
+					verify.
+						Ok().
+						Storage(
+							test.CreateStoreEntry(test.ChildAddress).WithKey([]byte("seenBy_f1")).WithValue(testCase.seenBy[0]),
+							test.CreateStoreEntry(test.ChildAddress).WithKey([]byte("seenBy_f2")).WithValue(testCase.seenBy[1]),
+							test.CreateStoreEntry(test.ChildAddress).WithKey([]byte("seenBy_f3")).WithValue(testCase.seenBy[2]),
+							test.CreateStoreEntry(test.ChildAddress).WithKey([]byte("seenBy_s1")).WithValue(testCase.seenBy[3]),
+							test.CreateStoreEntry(test.ParentAddress).WithKey([]byte("log")).WithValue([]byte("f1;f2;f3;s1;")),
+						)
+				})
+		}
+	}
+}
+
+func TestAsyncCallbackOrder_Invalid(t *testing.T) {
+	testConfig := asyncCallbackOrderTestConfig
+	testConfig.GasProvided = 10000
+	testConfig.CallbackOrder = arwen.AsyncCallbackOrderRegistration + 1
+
+	test.BuildMockInstanceCallTest(t).
+		WithContracts(
+			test.CreateMockContract(test.ParentAddress).
+				WithBalance(testConfig.ParentBalance).
+				WithConfig(&testConfig).
+				WithMethods(contracts.PerformAsyncCallbackOrderParentMock, contracts.AppendToLogParentMock),
+			test.CreateMockContract(test.ChildAddress).
+				WithBalance(testConfig.ChildBalance).
+				WithConfig(&testConfig).
+				WithMethods(contracts.RecordLogChildMock),
+		).
+		WithInput(test.CreateTestContractCallInputBuilder().
+			WithRecipientAddr(test.ParentAddress).
+			WithGasProvided(testConfig.GasProvided).
+			WithFunction("performAsyncCallbackOrder").
+			Build()).
+		WithSetup(func(host arwen.VMHost, world *worldmock.MockWorld) {
+			setZeroCodeCosts(host)
+			setAsyncCosts(host, testConfig.GasLockCost)
+		}).
+		AndAssertResults(func(world *worldmock.MockWorld, verify *test.VMOutputVerifier) {
+			verify.
+				ReturnCode(vmcommon.ExecutionFailed).
+				ReturnMessage(arwen.ErrInvalidAsyncCallbackOrder.Error())
+		})
+}
@@ -0,0 +1,114 @@
+package hosttest
+
+import (
+	"testing"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/contracts"
+	worldmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/world"
+	test "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/testcommon"
+	"github.com/ElrondNetwork/elrond-go/core/vmcommon"
+	"github.com/stretchr/testify/require"
+)
+
+var asyncCallbackOutputTestConfig = contracts.AsyncCallbackOutputTestConfig{
+	AsyncCallBaseTestConfig: asyncBaseTestConfig,
+}
+
+func runAsyncCallbackOutputTest(t *testing.T, testConfig *contracts.AsyncCallbackOutputTestConfig) *vmcommon.VMOutput {
+	var vmOutput *vmcommon.VMOutput
+	test.BuildMockInstanceCallTest(t).
+		WithContracts(
+			test.CreateMockContract(test.ParentAddress).
+				WithBalance(testConfig.ParentBalance).
+				WithConfig(testConfig).
+				WithMethods(
+					contracts.PerformAsyncWithOutputParentMock,
+					contracts.CallBackWithOutputParentMock,
+				),
+			test.CreateMockContract(test.ChildAddress).
+				WithBalance(testConfig.ChildBalance).
+				WithConfig(testConfig).
+				WithMethods(contracts.ProduceOutputChildMock),
+		).
+		WithInput(test.CreateTestContractCallInputBuilder().
+			WithCallerAddr(test.UserAddress).
+			WithRecipientAddr(test.ParentAddress).
+			WithGasProvided(testConfig.GasProvided).
+			WithFunction("performAsyncWithOutput").
+			WithCurrentTxHash([]byte("txHash")).
+			Build()).
+		WithSetup(func(host arwen.VMHost, world *worldmock.MockWorld) {
+			setZeroCodeCosts(host)
+			setAsyncCosts(host, testConfig.GasLockCost)
+		}).
+		AndAssertResults(func(world *worldmock.MockWorld, verify *test.VMOutputVerifier) {
+			vmOutput = verify.VmOutput
+		})
+
+	return vmOutput
+}
+
+func logIdentifiers(vmOutput *vmcommon.VMOutput) []string {
+	identifiers := make([]string, 0, len(vmOutput.Logs))
+	for _, logEntry := range vmOutput.Logs {
+		identifiers = append(identifiers, string(logEntry.Identifier))
+	}
+
+	return identifiers
+}
+
+func TestAsyncCallbackOutput_MergedInOrder(t *testing.T) {
+	testConfig := asyncCallbackOutputTestConfig
+
+	vmOutput := runAsyncCallbackOutputTest(t, &testConfig)
+
+	// the output of the caller comes first, followed by the output of the
+	// destination and then by the output of the callback; the storage updates
+	// of the callback override the earlier ones of the caller
+	verify := test.NewVMOutputVerifier(t, vmOutput, nil)
+	verify.
+		Ok().
+		ReturnData([]byte("parent"), []byte("child"), []byte("callback")).
+		Storage(
+			test.CreateStoreEntry(test.ParentAddress).WithKey([]byte("writtenBy_parent")).WithValue([]byte("yes")),
+			test.CreateStoreEntry(test.ParentAddress).WithKey([]byte("writtenBy_callback")).WithValue([]byte("yes")),
+			test.CreateStoreEntry(test.ParentAddress).WithKey([]byte("lastWriter")).WithValue([]byte("callback")),
+			test.CreateStoreEntry(test.ChildAddress).WithKey([]byte("writtenBy_child")).WithValue([]byte("yes")),
+			test.CreateStoreEntry(test.ChildAddress).WithKey([]byte("lastWriter")).WithValue([]byte("child")),
+		)
+	require.Equal(t, []string{"parent", "child", "callback"}, logIdentifiers(vmOutput))
+}
+
+func TestAsyncCallbackOutput_FailedCallbackDiscarded(t *testing.T) {
+	testConfig := asyncCallbackOutputTestConfig
+	testConfig.FailCallback = true
+
+	vmOutput := runAsyncCallbackOutputTest(t, &testConfig)
+
+	// only the error of the failed callback is recorded, after the output of the destination
+	verify := test.NewVMOutputVerifier(t, vmOutput, nil)
+	verify.
+		Ok().
+		ReturnMessage("callback failed").
+		ReturnData([]byte("parent"), []byte("child"), []byte(vmcommon.UserError.String()), []byte("txHash")).
+		Storage(
+			test.CreateStoreEntry(test.ParentAddress).WithKey([]byte("writtenBy_parent")).WithValue([]byte("yes")),
+			test.CreateStoreEntry(test.ParentAddress).WithKey([]byte("lastWriter")).WithValue([]byte("parent")),
+			test.CreateStoreEntry(test.ChildAddress).WithKey([]byte("writtenBy_child")).WithValue([]byte("yes")),
+			test.CreateStoreEntry(test.ChildAddress).WithKey([]byte("lastWriter")).WithValue([]byte("child")),
+		)
+	require.Equal(t, []string{"parent", "child"}, logIdentifiers(vmOutput))
+}
+
+func TestAsyncCallbackOutput_Deterministic(t *testing.T) {
+	for _, failCallback := range []bool{false, true} {
+		testConfig := asyncCallbackOutputTestConfig
+		testConfig.FailCallback = failCallback
+
+		expectedVMOutput := runAsyncCallbackOutputTest(t, &testConfig)
+		for i := 0; i < 10; i++ {
+			require.Equal(t, expectedVMOutput, runAsyncCallbackOutputTest(t, &testConfig))
+		}
+	}
+}
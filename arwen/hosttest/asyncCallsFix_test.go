@@ -0,0 +1,294 @@
+package hosttest
+
+import (
+	"encoding/json"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
+	contextmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/context"
+	worldmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/world"
+	test "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/testcommon"
+	"github.com/ElrondNetwork/elrond-go/core/vmcommon"
+	"github.com/stretchr/testify/require"
+)
+
+const asyncCallsFixActive = uint32(0)
+const asyncCallsFixInactive = uint32(math.MaxUint32)
+
+var asyncCallsFixTxHash = []byte("asyncCallsFixTx")
+
+var asyncCallsFixFirstCallee = test.MakeTestSCAddress("firstCallee")
+var asyncCallsFixSecondCallee = test.MakeTestSCAddress("secondCallee")
+
+func createTestArwenWithAsyncCallsFix(t *testing.T, world *worldmock.MockWorld, enableEpoch uint32) (arwen.VMHost, *contextmock.InstanceBuilderMock) {
+	parameters := test.DefaultTestVMHostParameters()
+	parameters.AsyncCallsFixEnableEpoch = enableEpoch
+	host := test.DefaultTestArwenWithParameters(t, world, parameters)
+
+	instanceBuilderMock := contextmock.NewInstanceBuilderMock(world)
+	host.Runtime().ReplaceInstanceBuilder(instanceBuilderMock)
+	setZeroCodeCosts(host)
+	setAsyncCosts(host, 0)
+
+	return host, instanceBuilderMock
+}
+
+func createAccountOnShard(world *worldmock.MockWorld, address []byte, shardID uint32) {
+	account := world.AcctMap.CreateAccount(address)
+	account.ShardID = shardID
+}
+
+func asyncCallsFixStorageKey() string {
+	return string(arwen.CustomStorageKey(arwen.AsyncDataPrefix, asyncCallsFixTxHash))
+}
+
+// storePendingAsyncCalls stores in the given contract the async calls it left
+// pending in the group "group", as if they were sent to other shards
+func storePendingAsyncCalls(t *testing.T, world *worldmock.MockWorld, address []byte, callerAddr []byte, destinations ...[]byte) {
+	asyncContext := &arwen.AsyncContext{}
+	for _, destination := range destinations {
+		asyncContext.AsyncCalls = append(asyncContext.AsyncCalls, &arwen.AsyncGeneratedCall{
+			Status:          arwen.AsyncCallPending,
+			Destination:     destination,
+			SuccessCallback: "calleeCallback",
+			ErrorCallback:   "calleeCallback",
+			GasLimit:        100,
+		})
+	}
+
+	data, err := json.Marshal(&arwen.AsyncContextInfo{
+		CallerAddr:      callerAddr,
+		AsyncContextMap: map[string]*arwen.AsyncContext{"group": asyncContext},
+	})
+	require.Nil(t, err)
+
+	world.AcctMap.GetAccount(address).Storage[asyncCallsFixStorageKey()] = data
+}
+
+// runCalleeCallback delivers to the child the callback of one of the async
+// calls it left pending, as if it came from another shard
+func runCalleeCallback(t *testing.T, host arwen.VMHost, callee []byte) (*vmcommon.VMOutput, error) {
+	input := test.CreateTestContractCallInputBuilder().
+		WithCallerAddr(callee).
+		WithRecipientAddr(test.ChildAddress).
+		WithFunction(arwen.CallbackFunctionName).
+		WithArguments([]byte{0}).
+		WithCallType(vmcommon.AsynchronousCallBack).
+		WithGasProvided(1000).
+		Build()
+	input.OriginalTxHash = asyncCallsFixTxHash
+
+	return host.RunSmartContractCall(input)
+}
+
+func addCalleeCallback(host arwen.VMHost, instance *contextmock.InstanceMock) {
+	instance.AddMockMethod("calleeCallback", func() *contextmock.InstanceMock {
+		host.Metering().UseGas(10)
+		return contextmock.GetMockInstance(host)
+	})
+}
+
+func getStoredAsyncCalls(t *testing.T, world *worldmock.MockWorld, vmOutput *vmcommon.VMOutput, address []byte) []*arwen.AsyncGeneratedCall {
+	data := world.AcctMap.GetAccount(address).Storage[asyncCallsFixStorageKey()]
+	outputAccount, ok := vmOutput.OutputAccounts[string(address)]
+	if ok {
+		storageUpdate, ok := outputAccount.StorageUpdates[asyncCallsFixStorageKey()]
+		if ok {
+			data = storageUpdate.Data
+		}
+	}
+	if len(data) == 0 {
+		return nil
+	}
+
+	asyncInfo := &arwen.AsyncContextInfo{}
+	err := json.Unmarshal(data, asyncInfo)
+	require.Nil(t, err)
+
+	return asyncInfo.AsyncContextMap["group"].AsyncCalls
+}
+
+func TestAsyncCallsFix_StorageCallbackUsesGasLeft(t *testing.T) {
+	for _, enableEpoch := range []uint32{asyncCallsFixActive, asyncCallsFixInactive} {
+		world := worldmock.NewMockWorld()
+		host, instanceBuilderMock := createTestArwenWithAsyncCallsFix(t, world, enableEpoch)
+
+		childInstance := instanceBuilderMock.CreateAndStoreInstanceMock(t, host, test.ChildAddress, 0, 1000)
+		addCalleeCallback(host, childInstance)
+		createAccountOnShard(world, test.ParentAddress, 1)
+		createAccountOnShard(world, asyncCallsFixFirstCallee, 1)
+		storePendingAsyncCalls(t, world, test.ChildAddress, test.ParentAddress, asyncCallsFixFirstCallee)
+
+		vmOutput, err := runCalleeCallback(t, host, asyncCallsFixFirstCallee)
+		verify := test.NewVMOutputVerifier(t, vmOutput, err)
+		if enableEpoch == asyncCallsFixInactive {
+			// the gas given to the callback is also counted as remaining
+			verify.ReturnCode(vmcommon.ExecutionFailed)
+			continue
+		}
+
+		// the last pending call was answered, so the callback of the child goes to its caller
+		verify.Ok()
+		transfers := vmOutput.OutputAccounts[string(test.ParentAddress)].OutputTransfers
+		require.Len(t, transfers, 1)
+		require.Equal(t, vmcommon.AsynchronousCallBack, transfers[0].CallType)
+		require.Equal(t, uint64(1000-10), transfers[0].GasLimit)
+		require.Equal(t, uint64(0), vmOutput.GasRemaining)
+	}
+}
+
+func TestAsyncCallsFix_GasLeftSharedOnlyByCrossShardCalls(t *testing.T) {
+	crossShardGasLimits := make(map[uint32]uint64)
+	for _, enableEpoch := range []uint32{asyncCallsFixActive, asyncCallsFixInactive} {
+		world := worldmock.NewMockWorld()
+		host, instanceBuilderMock := createTestArwenWithAsyncCallsFix(t, world, enableEpoch)
+
+		// the parent calls the child on its shard, and the first callee on
+		// another shard; the child remains pending, on its call to the second callee
+		parentInstance := instanceBuilderMock.CreateAndStoreInstanceMock(t, host, test.ParentAddress, 0, 1000)
+		parentInstance.AddMockMethod("callBoth", func() *contextmock.InstanceMock {
+			for _, destination := range [][]byte{test.ChildAddress, asyncCallsFixFirstCallee} {
+				err := host.Runtime().AddAsyncContextCall([]byte("group"), &arwen.AsyncGeneratedCall{
+					Destination:     destination,
+					Data:            []byte("callCrossShard"),
+					SuccessCallback: "calleeCallback",
+					ErrorCallback:   "calleeCallback",
+				})
+				require.Nil(t, err)
+			}
+			return contextmock.GetMockInstance(host)
+		})
+		addCalleeCallback(host, parentInstance)
+
+		childInstance := instanceBuilderMock.CreateAndStoreInstanceMock(t, host, test.ChildAddress, 0, 1000)
+		childInstance.AddMockMethod("callCrossShard", func() *contextmock.InstanceMock {
+			err := host.Runtime().AddAsyncContextCall([]byte("group"), &arwen.AsyncGeneratedCall{
+				Destination:     asyncCallsFixSecondCallee,
+				Data:            []byte("anything"),
+				SuccessCallback: "calleeCallback",
+				ErrorCallback:   "calleeCallback",
+			})
+			require.Nil(t, err)
+			return contextmock.GetMockInstance(host)
+		})
+		addCalleeCallback(host, childInstance)
+
+		createAccountOnShard(world, asyncCallsFixFirstCallee, 1)
+		createAccountOnShard(world, asyncCallsFixSecondCallee, 1)
+
+		input := test.CreateTestContractCallInputBuilder().
+			WithCallerAddr(test.UserAddress).
+			WithRecipientAddr(test.ParentAddress).
+			WithFunction("callBoth").
+			WithGasProvided(1000).
+			Build()
+		input.OriginalTxHash = asyncCallsFixTxHash
+
+		vmOutput, err := host.RunSmartContractCall(input)
+		verify := test.NewVMOutputVerifier(t, vmOutput, err)
+		verify.Ok()
+
+		transfers := vmOutput.OutputAccounts[string(asyncCallsFixFirstCallee)].OutputTransfers
+		require.Len(t, transfers, 1)
+		crossShardGasLimits[enableEpoch] = transfers[0].GasLimit
+	}
+
+	// the child already received its share, so the first callee gets all the gas left,
+	// instead of sharing it again with the child
+	require.Equal(t, crossShardGasLimits[asyncCallsFixActive]/2, crossShardGasLimits[asyncCallsFixInactive])
+}
+
+func TestAsyncCallsFix_PendingCallsSavedAfterCallback(t *testing.T) {
+	for _, enableEpoch := range []uint32{asyncCallsFixActive, asyncCallsFixInactive} {
+		world := worldmock.NewMockWorld()
+		host, instanceBuilderMock := createTestArwenWithAsyncCallsFix(t, world, enableEpoch)
+
+		childInstance := instanceBuilderMock.CreateAndStoreInstanceMock(t, host, test.ChildAddress, 0, 1000)
+		addCalleeCallback(host, childInstance)
+		createAccountOnShard(world, test.ParentAddress, 1)
+		createAccountOnShard(world, asyncCallsFixFirstCallee, 1)
+		createAccountOnShard(world, asyncCallsFixSecondCallee, 1)
+		storePendingAsyncCalls(t, world, test.ChildAddress, test.ParentAddress, asyncCallsFixFirstCallee, asyncCallsFixSecondCallee)
+
+		vmOutput, err := runCalleeCallback(t, host, asyncCallsFixFirstCallee)
+		verify := test.NewVMOutputVerifier(t, vmOutput, err)
+		verify.Ok()
+
+		pendingCalls := getStoredAsyncCalls(t, world, vmOutput, test.ChildAddress)
+		if enableEpoch == asyncCallsFixActive {
+			require.Len(t, pendingCalls, 1)
+			require.Equal(t, asyncCallsFixSecondCallee, pendingCalls[0].Destination)
+		} else {
+			// the answered call stays pending, and would be answered again
+			require.Len(t, pendingCalls, 2)
+		}
+	}
+}
+
+func TestAsyncCallsFix_LastCallbackWithSameShardCaller(t *testing.T) {
+	world := worldmock.NewMockWorld()
+	host, instanceBuilderMock := createTestArwenWithAsyncCallsFix(t, world, asyncCallsFixActive)
+
+	childInstance := instanceBuilderMock.CreateAndStoreInstanceMock(t, host, test.ChildAddress, 0, 1000)
+	addCalleeCallback(host, childInstance)
+	_ = instanceBuilderMock.CreateAndStoreInstanceMock(t, host, test.ParentAddress, 0, 1000)
+	createAccountOnShard(world, asyncCallsFixFirstCallee, 1)
+	storePendingAsyncCalls(t, world, test.ChildAddress, test.ParentAddress, asyncCallsFixFirstCallee)
+
+	// the callback for the caller is created without an async call info
+	vmOutput, err := runCalleeCallback(t, host, asyncCallsFixFirstCallee)
+	verify := test.NewVMOutputVerifier(t, vmOutput, err)
+	verify.Ok()
+	require.Nil(t, getStoredAsyncCalls(t, world, vmOutput, test.ChildAddress))
+}
+
+func TestAsyncCallsFix_FailedSameShardCallee(t *testing.T) {
+	world := worldmock.NewMockWorld()
+	host, instanceBuilderMock := createTestArwenWithAsyncCallsFix(t, world, asyncCallsFixActive)
+	setAsyncCosts(host, 100)
+
+	parentInstance := instanceBuilderMock.CreateAndStoreInstanceMock(t, host, test.ParentAddress, 0, 1000)
+	parentInstance.AddMockMethod("callFailing", func() *contextmock.InstanceMock {
+		// the gas locked for the callback, since the failed callee uses all its gas
+		gasLocked, err := host.Metering().EscrowGasForCallback(true)
+		require.Nil(t, err)
+		err = host.Runtime().AddAsyncContextCall([]byte("group"), &arwen.AsyncGeneratedCall{
+			Destination:     test.ChildAddress,
+			Data:            []byte("fail"),
+			ValueBytes:      big.NewInt(0).Bytes(),
+			SuccessCallback: "calleeCallback",
+			ErrorCallback:   "calleeCallback",
+			ProvidedGas:     300,
+			GasLocked:       gasLocked,
+		})
+		require.Nil(t, err)
+		return contextmock.GetMockInstance(host)
+	})
+	parentInstance.AddMockMethod("calleeCallback", func() *contextmock.InstanceMock {
+		_, _ = host.Storage().SetStorage([]byte("answered"), host.Runtime().Arguments()[0])
+		return contextmock.GetMockInstance(host)
+	})
+	childInstance := instanceBuilderMock.CreateAndStoreInstanceMock(t, host, test.ChildAddress, 0, 1000)
+	childInstance.AddMockMethod("fail", func() *contextmock.InstanceMock {
+		host.Runtime().SignalUserError("callee failed")
+		return contextmock.GetMockInstance(host)
+	})
+
+	input := test.CreateTestContractCallInputBuilder().
+		WithCallerAddr(test.UserAddress).
+		WithRecipientAddr(test.ParentAddress).
+		WithFunction("callFailing").
+		WithGasProvided(1000).
+		Build()
+
+	// the failed callee has no async info
+	vmOutput, err := host.RunSmartContractCall(input)
+	verify := test.NewVMOutputVerifier(t, vmOutput, err)
+	verify.
+		Ok().
+		Storage(
+			test.CreateStoreEntry(test.ParentAddress).WithKey([]byte("answered")).WithValue(big.NewInt(int64(vmcommon.UserError)).Bytes()),
+		)
+}
@@ -0,0 +1,78 @@
+package hosttest
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
+	contextmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/context"
+	worldmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/world"
+	test "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/testcommon"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestArwenForAsyncGasEstimate(t *testing.T) arwen.VMHost {
+	world := worldmock.NewMockWorld()
+	parameters := test.DefaultTestVMHostParameters()
+	host := test.DefaultTestArwenWithParameters(t, world, parameters)
+
+	instanceBuilderMock := contextmock.NewInstanceBuilderMock(world)
+	host.Runtime().ReplaceInstanceBuilder(instanceBuilderMock)
+	_ = instanceBuilderMock.CreateAndStoreInstanceMock(t, host, test.ParentAddress, 0, 1000)
+	_ = instanceBuilderMock.CreateAndStoreInstanceMock(t, host, test.ChildAddress, 0, 1000)
+	host.InitState()
+
+	return host
+}
+
+func TestEstimateAsyncCallGas(t *testing.T) {
+	host := createTestArwenForAsyncGasEstimate(t)
+	gasSchedule := host.Metering().GasSchedule()
+	data := []byte("transferToThirdParty@03")
+
+	estimate, err := host.EstimateAsyncCallGas(test.ParentAddress, test.ChildAddress, data, big.NewInt(10))
+	require.Nil(t, err)
+
+	// the mock contracts have their own address as code
+	codeSize := uint64(len(test.ChildAddress))
+	costPerByte := gasSchedule.BaseOperationCost.CompilePerByte
+	baseCost := uint64(0)
+	if host.IsAheadOfTimeCompileEnabled() {
+		costPerByte = gasSchedule.BaseOperationCost.AoTPreparePerByte
+		baseCost = gasSchedule.BaseOperationCost.GetCode
+	}
+	compilationGasLock := uint64(0)
+	if host.IsDynamicGasLockingEnabled() {
+		compilationGasLock = codeSize * costPerByte
+	}
+
+	expectedRegistration := 2*gasSchedule.ElrondAPICost.AsyncCallStep + uint64(len(data))*gasSchedule.BaseOperationCost.DataCopyPerByte
+	expectedDispatch := baseCost + codeSize*costPerByte
+	expectedCallback := compilationGasLock + gasSchedule.ElrondAPICost.AsyncCallStep + gasSchedule.ElrondAPICost.AsyncCallbackGasLock
+
+	require.Equal(t, expectedRegistration, estimate.Registration)
+	require.Equal(t, expectedDispatch, estimate.Dispatch)
+	require.Equal(t, expectedCallback, estimate.Callback)
+	require.Equal(t, expectedRegistration+expectedDispatch+expectedCallback, estimate.Total)
+}
+
+func TestEstimateAsyncCallGas_DestinationNotAvailable(t *testing.T) {
+	host := createTestArwenForAsyncGasEstimate(t)
+
+	estimate, err := host.EstimateAsyncCallGas(test.ParentAddress, test.UserAddress, []byte("function"), nil)
+	require.Nil(t, err)
+	require.Zero(t, estimate.Dispatch)
+	require.Equal(t, estimate.Registration+estimate.Callback, estimate.Total)
+}
+
+func TestEstimateAsyncCallGas_InvalidInput(t *testing.T) {
+	host := createTestArwenForAsyncGasEstimate(t)
+
+	estimate, err := host.EstimateAsyncCallGas(test.ParentAddress, test.ChildAddress, []byte("function"), big.NewInt(-1))
+	require.Nil(t, estimate)
+	require.Equal(t, arwen.ErrTransferNegativeValue, err)
+
+	estimate, err = host.EstimateAsyncCallGas(test.UserAddress, test.ChildAddress, []byte("function"), big.NewInt(0))
+	require.Nil(t, estimate)
+	require.Equal(t, arwen.ErrContractNotFound, err)
+}
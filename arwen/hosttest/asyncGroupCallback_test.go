@@ -0,0 +1,134 @@
+package hosttest
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/contracts"
+	worldmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/world"
+	test "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/testcommon"
+	"github.com/ElrondNetwork/elrond-go/core/vmcommon"
+)
+
+var asyncGroupCallbackTestConfig = contracts.AsyncGroupCallbackTestConfig{
+	AsyncCallBaseTestConfig:   asyncBaseTestConfig,
+	GasProvidedToChild:        300,
+	TransferFromGroupCallback: 5,
+}
+
+func TestAsyncGroupCallback_Success(t *testing.T) {
+	testConfig := asyncGroupCallbackTestConfig
+	testConfig.GasLockCost = 0
+
+	test.BuildMockInstanceCallTest(t).
+		WithContracts(
+			test.CreateMockContract(test.ParentAddress).
+				WithBalance(testConfig.ParentBalance).
+				WithConfig(&testConfig).
+				WithMethods(contracts.PerformAsyncGroupParentMock, contracts.GroupCallCompletedParentMock, contracts.GroupCallbackParentMock),
+			test.CreateMockContract(test.ChildAddress).
+				WithBalance(testConfig.ChildBalance).
+				WithConfig(&testConfig).
+				WithMethods(contracts.StoreInGroupChildMock),
+		).
+		WithInput(test.CreateTestContractCallInputBuilder().
+			WithRecipientAddr(test.ParentAddress).
+			WithGasProvided(testConfig.GasProvided).
+			WithFunction("performAsyncGroup").
+			Build()).
+		WithSetup(func(host arwen.VMHost, world *worldmock.MockWorld) {
+			setZeroCodeCosts(host)
+			setAsyncCosts(host, testConfig.GasLockCost)
+		}).
+		AndAssertResults(func(world *worldmock.MockWorld, verify *test.VMOutputVerifier) {
+			verify.
+				Ok().
+				Storage(
+					test.CreateStoreEntry(test.ChildAddress).WithKey([]byte("storedByChild")).WithValue([]byte("yes")),
+					test.CreateStoreEntry(test.ParentAddress).WithKey([]byte("callCompleted")).WithValue([]byte("yes")),
+					test.CreateStoreEntry(test.ParentAddress).WithKey([]byte("groupCallback")).WithValue([]byte("group")),
+				).
+				Transfers(
+					test.CreateTransferEntry(test.ParentAddress, test.ThirdPartyAddress).
+						WithCallType(vmcommon.DirectCall).
+						WithValue(big.NewInt(testConfig.TransferFromGroupCallback)),
+				)
+		})
+}
+
+func TestAsyncGroupCallback_FailureRevertsOnlyGroupCallback(t *testing.T) {
+	testConfig := asyncGroupCallbackTestConfig
+	testConfig.GasLockCost = 0
+	testConfig.FailGroupCallback = true
+
+	test.BuildMockInstanceCallTest(t).
+		WithContracts(
+			test.CreateMockContract(test.ParentAddress).
+				WithBalance(testConfig.ParentBalance).
+				WithConfig(&testConfig).
+				WithMethods(contracts.PerformAsyncGroupParentMock, contracts.GroupCallCompletedParentMock, contracts.GroupCallbackParentMock),
+			test.CreateMockContract(test.ChildAddress).
+				WithBalance(testConfig.ChildBalance).
+				WithConfig(&testConfig).
+				WithMethods(contracts.StoreInGroupChildMock),
+		).
+		WithInput(test.CreateTestContractCallInputBuilder().
+			WithRecipientAddr(test.ParentAddress).
+			WithGasProvided(testConfig.GasProvided).
+			WithFunction("performAsyncGroup").
+			Build()).
+		WithSetup(func(host arwen.VMHost, world *worldmock.MockWorld) {
+			setZeroCodeCosts(host)
+			setAsyncCosts(host, testConfig.GasLockCost)
+		}).
+		AndAssertResults(func(world *worldmock.MockWorld, verify *test.VMOutputVerifier) {
+			// the async call and its callback are kept, the group callback is reverted
+			verify.
+				Ok().
+				Storage(
+					test.CreateStoreEntry(test.ChildAddress).WithKey([]byte("storedByChild")).WithValue([]byte("yes")),
+					test.CreateStoreEntry(test.ParentAddress).WithKey([]byte("callCompleted")).WithValue([]byte("yes")),
+				).
+				Transfers()
+		})
+}
+
+func TestAsyncGroupCallback_NotExecutedBeforeEnableEpoch(t *testing.T) {
+	testConfig := asyncGroupCallbackTestConfig
+	testConfig.GasLockCost = 0
+
+	parameters := test.DefaultTestVMHostParameters()
+	parameters.GroupCallbacksEnableEpoch = 1
+
+	test.BuildMockInstanceCallTest(t).
+		WithParameters(parameters).
+		WithContracts(
+			test.CreateMockContract(test.ParentAddress).
+				WithBalance(testConfig.ParentBalance).
+				WithConfig(&testConfig).
+				WithMethods(contracts.PerformAsyncGroupParentMock, contracts.GroupCallCompletedParentMock, contracts.GroupCallbackParentMock),
+			test.CreateMockContract(test.ChildAddress).
+				WithBalance(testConfig.ChildBalance).
+				WithConfig(&testConfig).
+				WithMethods(contracts.StoreInGroupChildMock),
+		).
+		WithInput(test.CreateTestContractCallInputBuilder().
+			WithRecipientAddr(test.ParentAddress).
+			WithGasProvided(testConfig.GasProvided).
+			WithFunction("performAsyncGroup").
+			Build()).
+		WithSetup(func(host arwen.VMHost, world *worldmock.MockWorld) {
+			setZeroCodeCosts(host)
+			setAsyncCosts(host, testConfig.GasLockCost)
+		}).
+		AndAssertResults(func(world *worldmock.MockWorld, verify *test.VMOutputVerifier) {
+			verify.
+				Ok().
+				Storage(
+					test.CreateStoreEntry(test.ChildAddress).WithKey([]byte("storedByChild")).WithValue([]byte("yes")),
+					test.CreateStoreEntry(test.ParentAddress).WithKey([]byte("callCompleted")).WithValue([]byte("yes")),
+				).
+				Transfers()
+		})
+}
@@ -0,0 +1,125 @@
+package hosttest
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
+	contextmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/context"
+	worldmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/world"
+	test "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/testcommon"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrateLegacyAsyncData(t *testing.T) {
+	legacyKey := string(arwen.CustomStorageKey(arwen.AsyncDataPrefix, []byte("legacyTx")))
+	currentKey := string(arwen.CustomStorageKey(arwen.AsyncDataPrefix, []byte("currentTx")))
+	invalidKey := string(arwen.CustomStorageKey(arwen.AsyncDataPrefix, []byte("invalidTx")))
+	noDestinationKey := string(arwen.CustomStorageKey(arwen.AsyncDataPrefix, []byte("noDestinationTx")))
+
+	legacyData, _ := json.Marshal(&arwen.AsyncCallInfo{
+		Destination: test.ChildAddress,
+		Data:        []byte("transferToThirdParty"),
+		GasLimit:    100,
+		GasLocked:   50,
+		ValueBytes:  []byte{7},
+	})
+	currentData, _ := json.Marshal(&arwen.AsyncContextInfo{
+		AsyncContextMap: map[string]*arwen.AsyncContext{},
+	})
+	noDestinationData, _ := json.Marshal(&arwen.AsyncCallInfo{GasLimit: 100})
+
+	world := worldmock.NewMockWorld()
+	world.AcctMap.PutAccount(&worldmock.Account{
+		Address: test.ParentAddress,
+		Storage: map[string][]byte{
+			"someKey":        []byte("someValue"),
+			legacyKey:        legacyData,
+			currentKey:       currentData,
+			invalidKey:       []byte("not json"),
+			noDestinationKey: noDestinationData,
+		},
+	})
+
+	parameters := test.DefaultTestVMHostParameters()
+	host := test.DefaultTestArwenWithParameters(t, world, parameters)
+
+	result, err := host.MigrateLegacyAsyncData(test.ParentAddress)
+	require.Nil(t, err)
+	require.Equal(t, 1, result.UpToDate)
+	require.Equal(t, []arwen.AsyncMigrationEntry{{Key: []byte(legacyKey)}}, result.Migrated)
+	require.Len(t, result.NotMigrated, 2)
+	require.Equal(t, []byte(invalidKey), result.NotMigrated[0].Key)
+	require.Equal(t, []byte(noDestinationKey), result.NotMigrated[1].Key)
+	require.Equal(t, arwen.ErrAsyncMigrationMissingDestination.Error(), result.NotMigrated[1].Reason)
+
+	require.Len(t, result.StorageUpdates, 1)
+	asyncInfo := &arwen.AsyncContextInfo{}
+	err = json.Unmarshal(result.StorageUpdates[legacyKey], asyncInfo)
+	require.Nil(t, err)
+
+	asyncContext := asyncInfo.AsyncContextMap[arwen.LegacyAsyncContextIdentifier]
+	require.NotNil(t, asyncContext)
+	require.Equal(t, arwen.CallbackFunctionName, asyncContext.Callback)
+	require.Equal(t, []*arwen.AsyncGeneratedCall{
+		{
+			Status:          arwen.AsyncCallPending,
+			Destination:     test.ChildAddress,
+			Data:            []byte("transferToThirdParty"),
+			GasLimit:        150,
+			ValueBytes:      []byte{7},
+			SuccessCallback: arwen.CallbackFunctionName,
+			ErrorCallback:   arwen.CallbackFunctionName,
+			ProvidedGas:     150,
+		},
+	}, asyncContext.AsyncCalls)
+
+	require.Equal(t, legacyData, world.AcctMap.GetAccount(test.ParentAddress).Storage[legacyKey])
+}
+
+func TestMigrateLegacyAsyncData_AccountNotFound(t *testing.T) {
+	parameters := test.DefaultTestVMHostParameters()
+	host := test.DefaultTestArwenWithParameters(t, worldmock.NewMockWorld(), parameters)
+
+	result, err := host.MigrateLegacyAsyncData(test.ParentAddress)
+	require.NotNil(t, err)
+	require.Nil(t, result)
+}
+
+func TestMigrateLegacyAsyncData_CallbackResolvesMigratedCall(t *testing.T) {
+	world := worldmock.NewMockWorld()
+	host, instanceBuilderMock := createTestArwenWithAsyncCallsFix(t, world, asyncCallsFixActive)
+
+	callbackExecuted := false
+	childInstance := instanceBuilderMock.CreateAndStoreInstanceMock(t, host, test.ChildAddress, 0, 1000)
+	childInstance.AddMockMethod(arwen.CallbackFunctionName, func() *contextmock.InstanceMock {
+		callbackExecuted = true
+		return contextmock.GetMockInstance(host)
+	})
+	createAccountOnShard(world, asyncCallsFixFirstCallee, 1)
+
+	legacyData, _ := json.Marshal(&arwen.AsyncCallInfo{
+		Destination: asyncCallsFixFirstCallee,
+		Data:        []byte("doSomething"),
+		GasLimit:    100,
+	})
+	childAccount := world.AcctMap.GetAccount(test.ChildAddress)
+	childAccount.Storage[asyncCallsFixStorageKey()] = legacyData
+
+	result, err := host.MigrateLegacyAsyncData(test.ChildAddress)
+	require.Nil(t, err)
+	require.Len(t, result.Migrated, 1)
+	for key, value := range result.StorageUpdates {
+		childAccount.Storage[key] = value
+	}
+
+	// the callback from the destination finds the migrated call in the
+	// legacy group, resolves it and clears the async data
+	vmOutput, err := runCalleeCallback(t, host, asyncCallsFixFirstCallee)
+	verify := test.NewVMOutputVerifier(t, vmOutput, err)
+	verify.Ok()
+	require.True(t, callbackExecuted)
+	require.Empty(t, getStoredAsyncCalls(t, world, vmOutput, test.ChildAddress))
+	require.Empty(t, vmOutput.OutputAccounts[string(test.ChildAddress)].StorageUpdates[asyncCallsFixStorageKey()].Data)
+	require.Len(t, vmOutput.OutputAccounts, 1)
+}
@@ -0,0 +1,143 @@
+package hosttest
+
+import (
+	"testing"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/contracts"
+	worldmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/world"
+	test "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/testcommon"
+	"github.com/ElrondNetwork/elrond-go/core/vmcommon"
+	"github.com/stretchr/testify/require"
+)
+
+type tracedAsyncEvent struct {
+	kind  string
+	event arwen.AsyncTraceEvent
+}
+
+type asyncTracerStub struct {
+	events []tracedAsyncEvent
+}
+
+func (tracer *asyncTracerStub) OnAsyncCallRegistered(event arwen.AsyncTraceEvent) {
+	tracer.events = append(tracer.events, tracedAsyncEvent{kind: "registered", event: event})
+}
+
+func (tracer *asyncTracerStub) OnAsyncCallExecuted(event arwen.AsyncTraceEvent) {
+	tracer.events = append(tracer.events, tracedAsyncEvent{kind: "executed", event: event})
+}
+
+func (tracer *asyncTracerStub) OnCallbackExecuted(event arwen.AsyncTraceEvent) {
+	tracer.events = append(tracer.events, tracedAsyncEvent{kind: "callback", event: event})
+}
+
+func (tracer *asyncTracerStub) OnGroupCompleted(event arwen.AsyncTraceEvent) {
+	tracer.events = append(tracer.events, tracedAsyncEvent{kind: "completed", event: event})
+}
+
+func (tracer *asyncTracerStub) IsInterfaceNil() bool {
+	return tracer == nil
+}
+
+func (tracer *asyncTracerStub) kinds() []string {
+	kinds := make([]string, 0, len(tracer.events))
+	for _, traced := range tracer.events {
+		kinds = append(kinds, traced.kind)
+	}
+	return kinds
+}
+
+func TestAsyncTracer_SameShard(t *testing.T) {
+	testConfig := asyncTestConfig
+	testConfig.GasProvided = 1000
+	tracer := &asyncTracerStub{}
+
+	test.BuildMockInstanceCallTest(t).
+		WithContracts(
+			test.CreateMockContract(test.ParentAddress).
+				WithBalance(testConfig.ParentBalance).
+				WithConfig(testConfig).
+				WithMethods(contracts.PerformAsyncCallParentMock, contracts.CallBackParentMock),
+			test.CreateMockContract(test.ChildAddress).
+				WithBalance(testConfig.ChildBalance).
+				WithConfig(testConfig).
+				WithMethods(contracts.TransferToThirdPartyAsyncChildMock),
+		).
+		WithInput(test.CreateTestContractCallInputBuilder().
+			WithRecipientAddr(test.ParentAddress).
+			WithGasProvided(testConfig.GasProvided).
+			WithFunction("performAsyncCall").
+			WithArguments([]byte{0}).
+			WithCurrentTxHash([]byte("txHash")).
+			Build()).
+		WithSetup(func(host arwen.VMHost, world *worldmock.MockWorld) {
+			setZeroCodeCosts(host)
+			setAsyncCosts(host, testConfig.GasLockCost)
+			host.SetAsyncTracer(tracer)
+		}).
+		AndAssertResults(func(world *worldmock.MockWorld, verify *test.VMOutputVerifier) {
+			verify.Ok()
+		})
+
+	require.Equal(t, []string{"registered", "executed", "callback", "completed"}, tracer.kinds())
+
+	registered := tracer.events[0].event
+	require.Equal(t, test.ParentAddress, registered.Caller)
+	require.Equal(t, test.ChildAddress, registered.Destination)
+	require.Equal(t, []byte("txHash"), registered.CurrentTxHash)
+	require.Equal(t, testConfig.GasLockCost, registered.GasLocked)
+	require.Equal(t, arwen.AsyncCallPending, registered.Status)
+	require.False(t, registered.CrossShard)
+
+	executed := tracer.events[1].event
+	require.Equal(t, vmcommon.Ok, executed.ReturnCode)
+	require.Equal(t, arwen.AsyncCallResolved, executed.Status)
+	require.Equal(t, registered.GasLimit-testConfig.GasUsedByChild, executed.GasRemaining)
+
+	callback := tracer.events[2].event
+	require.Equal(t, arwen.CallbackFunctionName, callback.Function)
+	require.Equal(t, arwen.AsyncCallResolved, callback.Status)
+	require.Equal(t, vmcommon.Ok, callback.ReturnCode)
+
+	completed := tracer.events[3].event
+	require.Equal(t, arwen.LegacyAsyncContextIdentifier, completed.GroupID)
+	require.Equal(t, test.ParentAddress, completed.Caller)
+}
+
+func TestAsyncTracer_CrossShard(t *testing.T) {
+	testConfig := asyncTestConfig
+	testConfig.GasProvided = 1000
+	tracer := &asyncTracerStub{}
+
+	test.BuildMockInstanceCallTest(t).
+		WithContracts(
+			test.CreateMockContract(test.ParentAddress).
+				WithBalance(testConfig.ParentBalance).
+				WithConfig(testConfig).
+				WithMethods(contracts.CreateEscrowedAsyncCallParentMock, contracts.CallBackParentMock),
+		).
+		WithInput(test.CreateTestContractCallInputBuilder().
+			WithCallerAddr(test.UserAddress).
+			WithRecipientAddr(test.ParentAddress).
+			WithGasProvided(testConfig.GasProvided).
+			WithFunction("createEscrowedAsyncCall").
+			Build()).
+		WithSetup(func(host arwen.VMHost, world *worldmock.MockWorld) {
+			setZeroCodeCosts(host)
+			setAsyncCosts(host, testConfig.GasLockCost)
+			host.SetAsyncTracer(tracer)
+		}).
+		AndAssertResults(func(world *worldmock.MockWorld, verify *test.VMOutputVerifier) {
+			verify.Ok()
+		})
+
+	require.Equal(t, []string{"registered"}, tracer.kinds())
+
+	registered := tracer.events[0].event
+	require.Equal(t, "group", registered.GroupID)
+	require.Equal(t, test.ChildAddress, registered.Destination)
+	require.Equal(t, testConfig.GasProvided-testConfig.GasUsedByParent-testConfig.GasLockCost, registered.GasLimit)
+	require.Equal(t, testConfig.GasLockCost, registered.GasLocked)
+	require.True(t, registered.CrossShard)
+}
@@ -0,0 +1,78 @@
+package hosttest
+
+import (
+	"testing"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/contracts"
+	worldmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/world"
+	test "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/testcommon"
+	"github.com/ElrondNetwork/elrond-go/core/vmcommon"
+	"github.com/stretchr/testify/require"
+)
+
+// stripCallerOutputProcessor removes the account of the caller from the
+// VMOutput of the built-in function, recording the calls it receives
+type stripCallerOutputProcessor struct {
+	processedInputs []*vmcommon.ContractCallInput
+}
+
+func (processor *stripCallerOutputProcessor) Recipient(input *vmcommon.ContractCallInput) []byte {
+	return input.RecipientAddr
+}
+
+func (processor *stripCallerOutputProcessor) FillPostBuiltinInput(_ *vmcommon.ContractCallInput, _ *vmcommon.ContractCallInput) {
+}
+
+func (processor *stripCallerOutputProcessor) ProcessOutput(input *vmcommon.ContractCallInput, vmOutput *vmcommon.VMOutput) {
+	processor.processedInputs = append(processor.processedInputs, input)
+	delete(vmOutput.OutputAccounts, string(input.CallerAddr))
+}
+
+func (processor *stripCallerOutputProcessor) PaymentNotifications(_ *vmcommon.ContractCallInput) []*arwen.PaymentNotification {
+	return nil
+}
+
+func TestBuiltinOutputProcessors_Registration(t *testing.T) {
+	host, _ := test.DefaultTestArwenWithWorldMock(t)
+
+	err := host.RegisterBuiltinOutputProcessor("", &stripCallerOutputProcessor{})
+	require.Equal(t, arwen.ErrInvalidBuiltinOutputProcessor, err)
+
+	err = host.RegisterBuiltinOutputProcessor(customClaimBuiltin.Name, nil)
+	require.Equal(t, arwen.ErrInvalidBuiltinOutputProcessor, err)
+
+	err = host.RegisterBuiltinOutputProcessor(customClaimBuiltin.Name, &stripCallerOutputProcessor{})
+	require.Nil(t, err)
+}
+
+func TestBuiltinOutputProcessors_ProcessOutput(t *testing.T) {
+	processor := &stripCallerOutputProcessor{}
+
+	test.BuildMockInstanceCallTest(t).
+		WithContracts(
+			test.CreateMockContract(test.ParentAddress).
+				WithBalance(simpleGasTestConfig.ParentBalance).
+				WithConfig(simpleGasTestConfig).
+				WithMethods(contracts.ExecOnDestCtxParentMock)).
+		WithInput(test.CreateTestContractCallInputBuilder().
+			WithRecipientAddr(test.ParentAddress).
+			WithGasProvided(simpleGasTestConfig.GasProvided).
+			WithFunction("execOnDestCtx").
+			WithArguments(test.ParentAddress, []byte(customClaimBuiltin.Name), arwen.One.Bytes()).
+			Build()).
+		WithSetup(func(host arwen.VMHost, world *worldmock.MockWorld) {
+			test.RegisterCustomBuiltinFunctions(t, host, world, customClaimBuiltin)
+			setZeroCodeCosts(host)
+			err := host.RegisterBuiltinOutputProcessor(customClaimBuiltin.Name, processor)
+			require.Nil(t, err)
+		}).
+		AndAssertResults(func(world *worldmock.MockWorld, verify *test.VMOutputVerifier) {
+			verify.
+				Ok().
+				BalanceDelta(test.ParentAddress, 0)
+		})
+
+	require.Len(t, processor.processedInputs, 1)
+	require.Equal(t, customClaimBuiltin.Name, processor.processedInputs[0].Function)
+}
@@ -0,0 +1,202 @@
+package hosttest
+
+import (
+	"encoding/binary"
+	"errors"
+	"testing"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
+	contextmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/context"
+	worldmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/world"
+	test "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/testcommon"
+	"github.com/ElrondNetwork/elrond-go/core/vmcommon"
+	"github.com/stretchr/testify/require"
+)
+
+var errChildFailed = errors.New("child failed")
+var errOtherChildFailed = errors.New("other child failed")
+var errParentFailed = errors.New("parent failed")
+
+type callStackTraceTest struct {
+	host          arwen.VMHost
+	parentGasLeft uint64
+	childGasLeft  uint64
+}
+
+// createCallStackTraceTest sets up a parent contract which calls a child
+// contract failing with errChildFailed, then fails with the error of the
+// child; the parent can also ignore the failure of the child and continue
+func createCallStackTraceTest(t *testing.T, callStackTracesEnabled bool) *callStackTraceTest {
+	world := worldmock.NewMockWorld()
+	parameters := test.DefaultTestVMHostParameters()
+	parameters.CallStackTracesEnabled = callStackTracesEnabled
+	host := test.DefaultTestArwenWithParameters(t, world, parameters)
+
+	instanceBuilderMock := contextmock.NewInstanceBuilderMock(world)
+	host.Runtime().ReplaceInstanceBuilder(instanceBuilderMock)
+
+	traceTest := &callStackTraceTest{host: host}
+
+	parentInstance := instanceBuilderMock.CreateAndStoreInstanceMock(t, host, test.ParentAddress, 0, 1000)
+	parentInstance.AddMockMethod("callChild", func() *contextmock.InstanceMock {
+		instance := contextmock.GetMockInstance(host)
+		traceTest.parentGasLeft = host.Metering().GasLeft()
+
+		_, _, err := host.ExecuteOnDestContext(createChildInput("fail"))
+		if err != nil {
+			host.Runtime().FailExecution(err)
+		}
+
+		return instance
+	})
+
+	parentInstance.AddMockMethod("recoverThenFail", func() *contextmock.InstanceMock {
+		instance := contextmock.GetMockInstance(host)
+		_, _, _ = host.ExecuteOnDestContext(createChildInput("fail"))
+		_, _, err := host.ExecuteOnDestContext(createChildInput("succeed"))
+		require.Nil(t, err)
+		host.Runtime().FailExecution(errParentFailed)
+		return instance
+	})
+
+	parentInstance.AddMockMethod("recoverThenCallFailingChild", func() *contextmock.InstanceMock {
+		instance := contextmock.GetMockInstance(host)
+		_, _, _ = host.ExecuteOnDestContext(createChildInput("fail"))
+		_, _, err := host.ExecuteOnDestContext(createChildInput("failOther"))
+		if err != nil {
+			host.Runtime().FailExecution(err)
+		}
+		return instance
+	})
+
+	childInstance := instanceBuilderMock.CreateAndStoreInstanceMock(t, host, test.ChildAddress, 0, 1000)
+	childInstance.AddMockMethod("fail", func() *contextmock.InstanceMock {
+		instance := contextmock.GetMockInstance(host)
+		traceTest.childGasLeft = host.Metering().GasLeft()
+		host.Runtime().FailExecution(errChildFailed)
+		return instance
+	})
+
+	childInstance.AddMockMethod("failOther", func() *contextmock.InstanceMock {
+		instance := contextmock.GetMockInstance(host)
+		host.Runtime().FailExecution(errOtherChildFailed)
+		return instance
+	})
+
+	childInstance.AddMockMethod("succeed", func() *contextmock.InstanceMock {
+		return contextmock.GetMockInstance(host)
+	})
+
+	return traceTest
+}
+
+func createChildInput(function string) *vmcommon.ContractCallInput {
+	return test.CreateTestContractCallInputBuilder().
+		WithCallerAddr(test.ParentAddress).
+		WithRecipientAddr(test.ChildAddress).
+		WithFunction(function).
+		WithGasProvided(2000).
+		Build()
+}
+
+func (traceTest *callStackTraceTest) run(function string) (*vmcommon.VMOutput, error) {
+	input := test.CreateTestContractCallInputBuilder().
+		WithCallerAddr(test.UserAddress).
+		WithRecipientAddr(test.ParentAddress).
+		WithFunction(function).
+		WithGasProvided(10000).
+		Build()
+
+	return traceTest.host.RunSmartContractCall(input)
+}
+
+func gasTopic(gas uint64) []byte {
+	topic := make([]byte, 8)
+	binary.BigEndian.PutUint64(topic, gas)
+	return topic
+}
+
+func TestCallStackTrace_NestedFailure(t *testing.T) {
+	traceTest := createCallStackTraceTest(t, true)
+
+	vmOutput, err := traceTest.run("callChild")
+	verify := test.NewVMOutputVerifier(t, vmOutput, err)
+	verify.ReturnCode(vmcommon.ExecutionFailed)
+
+	trace := traceTest.host.Runtime().GetCallStackTrace()
+	require.NotNil(t, trace)
+	require.Equal(t, []arwen.CallStackFrame{
+		{ContractAddress: test.ParentAddress, Function: "callChild", GasRemaining: traceTest.parentGasLeft},
+		{ContractAddress: test.ChildAddress, Function: "fail", GasRemaining: traceTest.childGasLeft},
+	}, trace.Frames)
+	require.Equal(t, errChildFailed.Error(), trace.Message)
+	require.Greater(t, traceTest.parentGasLeft, traceTest.childGasLeft)
+
+	require.Len(t, vmOutput.Logs, 1)
+	logEntry := vmOutput.Logs[0]
+	require.Equal(t, trace.LogEntry(), logEntry)
+	require.Equal(t, []byte(arwen.CallStackTraceLogIdentifier), logEntry.Identifier)
+	require.Equal(t, test.ChildAddress, logEntry.Address)
+	require.Equal(t, [][]byte{
+		[]byte(trace.Frames[0].String()),
+		gasTopic(traceTest.parentGasLeft),
+		[]byte(trace.Frames[1].String()),
+		gasTopic(traceTest.childGasLeft),
+	}, logEntry.Topics)
+	require.Equal(t, []byte(trace.Message), logEntry.Data)
+}
+
+func TestCallStackTrace_HandledFailureDiscarded(t *testing.T) {
+	traceTest := createCallStackTraceTest(t, true)
+
+	vmOutput, err := traceTest.run("recoverThenFail")
+	verify := test.NewVMOutputVerifier(t, vmOutput, err)
+	verify.ReturnCode(vmcommon.ExecutionFailed)
+
+	trace := traceTest.host.Runtime().GetCallStackTrace()
+	require.NotNil(t, trace)
+	require.Len(t, trace.Frames, 1)
+	require.Equal(t, "recoverThenFail", trace.Frames[0].Function)
+	require.Equal(t, errParentFailed.Error(), trace.Message)
+}
+
+func TestCallStackTrace_LaterFailureReplacesEarlierOne(t *testing.T) {
+	traceTest := createCallStackTraceTest(t, true)
+
+	vmOutput, err := traceTest.run("recoverThenCallFailingChild")
+	verify := test.NewVMOutputVerifier(t, vmOutput, err)
+	verify.ReturnCode(vmcommon.ExecutionFailed)
+
+	trace := traceTest.host.Runtime().GetCallStackTrace()
+	require.NotNil(t, trace)
+	require.Len(t, trace.Frames, 2)
+	require.Equal(t, "failOther", trace.Frames[1].Function)
+	require.Equal(t, errOtherChildFailed.Error(), trace.Message)
+}
+
+func TestCallStackTrace_TopLevelFailure(t *testing.T) {
+	traceTest := createCallStackTraceTest(t, true)
+
+	vmOutput, err := traceTest.run("missingFunction")
+	verify := test.NewVMOutputVerifier(t, vmOutput, err)
+	verify.ReturnCode(vmcommon.FunctionNotFound)
+
+	trace := traceTest.host.Runtime().GetCallStackTrace()
+	require.NotNil(t, trace)
+	require.Len(t, trace.Frames, 1)
+	require.Equal(t, test.ParentAddress, trace.Frames[0].ContractAddress)
+	require.Equal(t, "missingFunction", trace.Frames[0].Function)
+	require.Len(t, vmOutput.Logs, 1)
+	require.Equal(t, trace.LogEntry(), vmOutput.Logs[0])
+}
+
+func TestCallStackTrace_Disabled(t *testing.T) {
+	traceTest := createCallStackTraceTest(t, false)
+
+	vmOutput, err := traceTest.run("callChild")
+	verify := test.NewVMOutputVerifier(t, vmOutput, err)
+	verify.ReturnCode(vmcommon.ExecutionFailed)
+
+	require.Nil(t, traceTest.host.Runtime().GetCallStackTrace())
+	require.Empty(t, vmOutput.Logs)
+}
@@ -0,0 +1,98 @@
+package hosttest
+
+import (
+	"testing"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
+	contextmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/context"
+	worldmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/world"
+	test "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/testcommon"
+	"github.com/ElrondNetwork/elrond-go/core/vmcommon"
+	"github.com/stretchr/testify/require"
+)
+
+const callbackValidationEnableEpoch = uint32(2)
+
+func runRegisterWithCallbacks(t *testing.T, currentEpoch uint32, successCallback string, errorCallback string) *vmcommon.VMOutput {
+	world := worldmock.NewMockWorld()
+	world.CurrentBlockInfo.BlockEpoch = currentEpoch
+
+	parameters := test.DefaultTestVMHostParameters()
+	parameters.ProtocolBuiltinFunctions = vmcommon.FunctionNames{"ESDTTransfer": {}}
+	parameters.CallbackValidationEnableEpoch = callbackValidationEnableEpoch
+	host := test.DefaultTestArwenWithParameters(t, world, parameters)
+
+	instanceBuilderMock := contextmock.NewInstanceBuilderMock(world)
+	host.Runtime().ReplaceInstanceBuilder(instanceBuilderMock)
+
+	parentInstance := instanceBuilderMock.CreateAndStoreInstanceMock(t, host, test.ParentAddress, 0, 1000)
+	parentInstance.AddMockMethod("registerWithCallback", func() *contextmock.InstanceMock {
+		instance := contextmock.GetMockInstance(host)
+
+		// the destination is in another shard, so that the call is only registered
+		err := host.Runtime().AddAsyncContextCall([]byte("group"), &arwen.AsyncGeneratedCall{
+			Destination:     test.ChildAddress,
+			Data:            []byte("function"),
+			SuccessCallback: successCallback,
+			ErrorCallback:   errorCallback,
+		})
+		if err != nil {
+			host.Runtime().FailExecution(err)
+		}
+
+		return instance
+	})
+	parentInstance.AddMockMethod("exportedCallback", test.SimpleWasteGasMockMethod(parentInstance, 0))
+	parentInstance.AddMockMethod("ESDTTransfer", test.SimpleWasteGasMockMethod(parentInstance, 0))
+	world.AcctMap.CreateAccount(test.ChildAddress).ShardID = 1
+
+	input := test.CreateTestContractCallInputBuilder().
+		WithCallerAddr(test.UserAddress).
+		WithRecipientAddr(test.ParentAddress).
+		WithFunction("registerWithCallback").
+		WithGasProvided(1000).
+		Build()
+
+	vmOutput, err := host.RunSmartContractCall(input)
+	require.Nil(t, err)
+
+	return vmOutput
+}
+
+func TestCallbackValidation_ValidCallback(t *testing.T) {
+	vmOutput := runRegisterWithCallbacks(t, callbackValidationEnableEpoch, "exportedCallback", "exportedCallback")
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+
+	vmOutput = runRegisterWithCallbacks(t, callbackValidationEnableEpoch, "", "exportedCallback")
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+}
+
+func TestCallbackValidation_NoCallbacks(t *testing.T) {
+	// the parent exports no callback, which is not needed when none is named
+	vmOutput := runRegisterWithCallbacks(t, callbackValidationEnableEpoch, "", "")
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+}
+
+func TestCallbackValidation_InvalidCallbacks(t *testing.T) {
+	invalidCallbacks := []string{
+		"notExported",
+		"ESDTTransfer",
+		"1callback",
+		"call back",
+		"callBack!",
+	}
+
+	for _, callbackName := range invalidCallbacks {
+		vmOutput := runRegisterWithCallbacks(t, callbackValidationEnableEpoch, callbackName, "exportedCallback")
+		require.Equal(t, vmcommon.ExecutionFailed, vmOutput.ReturnCode, callbackName)
+		require.Contains(t, vmOutput.ReturnMessage, arwen.ErrInvalidCallbackName.Error(), callbackName)
+	}
+
+	vmOutput := runRegisterWithCallbacks(t, callbackValidationEnableEpoch, "", "notExported")
+	require.Equal(t, vmcommon.ExecutionFailed, vmOutput.ReturnCode)
+}
+
+func TestCallbackValidation_NotYetEnabled(t *testing.T) {
+	vmOutput := runRegisterWithCallbacks(t, callbackValidationEnableEpoch-1, "notExported", "exportedCallback")
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+}
@@ -0,0 +1,117 @@
+package hosttest
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
+	contextmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/context"
+	worldmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/world"
+	test "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/testcommon"
+	"github.com/ElrondNetwork/elrond-go/core/vmcommon"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChainSimulator_BlockProgression(t *testing.T) {
+	simulator := worldmock.NewChainSimulator(1)
+	simulator.RoundsPerEpoch = 3
+
+	genesis := *simulator.CurrentBlockInfo
+	simulator.NextBlock()
+	require.Equal(t, &genesis, simulator.PreviousBlockInfo)
+	require.Equal(t, uint64(1), simulator.CurrentNonce())
+	require.Equal(t, uint64(1), simulator.CurrentRound())
+	require.Equal(t, worldmock.DefaultRoundDuration, simulator.CurrentTimeStamp())
+	require.NotEqual(t, genesis.RandomSeed[:], simulator.CurrentRandomSeed())
+
+	simulator.SkipRounds(2)
+	require.Equal(t, uint64(2), simulator.CurrentNonce())
+	require.Equal(t, uint64(3), simulator.CurrentRound())
+	require.Equal(t, uint32(1), simulator.CurrentEpoch())
+	require.Equal(t, uint32(0), simulator.LastEpoch())
+
+	simulator.AdvanceBlocks(3)
+	require.Equal(t, uint64(5), simulator.CurrentNonce())
+	require.Equal(t, uint32(2), simulator.CurrentEpoch())
+
+	currentHash, err := simulator.GetBlockhash(5)
+	require.Nil(t, err)
+	genesisHash, err := simulator.GetBlockhash(0)
+	require.Nil(t, err)
+	require.NotEqual(t, currentHash, genesisHash)
+}
+
+func TestChainSimulator_WithExistingWorld(t *testing.T) {
+	world := worldmock.NewMockWorld()
+	account := world.AcctMap.CreateAccount([]byte("existing_account________________"))
+
+	simulator := worldmock.NewChainSimulatorWithWorld(world, 1)
+	require.Same(t, world, simulator.MockWorld)
+	require.Same(t, account, simulator.AcctMap.GetAccount(account.Address))
+	require.Len(t, simulator.Blockhashes, 1)
+
+	simulator.NextBlock()
+	require.Equal(t, uint64(1), world.CurrentNonce())
+
+	// a world already on a block is not moved back to genesis
+	simulator = worldmock.NewChainSimulatorWithWorld(world, 1)
+	require.Equal(t, uint64(1), simulator.CurrentNonce())
+}
+
+func TestChainSimulator_ShardsAndESDT(t *testing.T) {
+	simulator := worldmock.NewChainSimulator(3)
+
+	address := []byte("address_________________________")
+	address[len(address)-1] = 5
+	require.Equal(t, uint32(2), simulator.GetShardOfAddress(address))
+
+	account := simulator.CreateUserAccount(address, big.NewInt(100))
+	require.Equal(t, uint32(2), account.ShardID)
+	require.False(t, simulator.SameShard(address, test.UserAddress))
+
+	token := []byte("TOKEN-123456")
+	err := simulator.SetESDTBalance(test.UserAddress, token, big.NewInt(42))
+	require.Nil(t, err)
+	balance, err := simulator.GetESDTBalance(test.UserAddress, token)
+	require.Nil(t, err)
+	require.Equal(t, big.NewInt(42), balance)
+}
+
+func TestChainSimulator_ExecuteAndCommit(t *testing.T) {
+	simulator := worldmock.NewChainSimulator(1)
+	simulator.AdvanceBlocks(7)
+
+	parameters := test.DefaultTestVMHostParameters()
+	host := test.DefaultTestArwenWithParameters(t, simulator, parameters)
+
+	instanceBuilderMock := contextmock.NewInstanceBuilderMock(simulator.MockWorld)
+	host.Runtime().ReplaceInstanceBuilder(instanceBuilderMock)
+
+	var blockInfo *arwen.BlockInfo
+	parentInstance := instanceBuilderMock.CreateAndStoreInstanceMock(t, host, test.ParentAddress, 0, 1000)
+	parentInstance.AddMockMethod("pay", func() *contextmock.InstanceMock {
+		instance := contextmock.GetMockInstance(host)
+		blockInfo = host.Blockchain().CurrentBlockInfo()
+		err := host.Output().Transfer(test.UserAddress, test.ParentAddress, 0, 0, big.NewInt(10), nil, vmcommon.DirectCall)
+		require.Nil(t, err)
+		return instance
+	})
+
+	input := test.CreateTestContractCallInputBuilder().
+		WithCallerAddr(test.UserAddress).
+		WithRecipientAddr(test.ParentAddress).
+		WithFunction("pay").
+		WithGasProvided(100000).
+		Build()
+
+	vmOutput, err := host.RunSmartContractCall(input)
+	verify := test.NewVMOutputVerifier(t, vmOutput, err)
+	verify.Ok()
+	require.Equal(t, uint64(7), blockInfo.Nonce)
+	require.Equal(t, simulator.CurrentRandomSeed(), blockInfo.RandomSeed)
+
+	err = simulator.Commit(vmOutput)
+	require.Nil(t, err)
+	require.Equal(t, big.NewInt(990), simulator.AcctMap.GetAccount(test.ParentAddress).Balance)
+	require.Equal(t, big.NewInt(10), simulator.AcctMap.GetAccount(test.UserAddress).Balance)
+}
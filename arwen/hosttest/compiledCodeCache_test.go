@@ -0,0 +1,108 @@
+package hosttest
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/config"
+	contextmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/context"
+	test "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/testcommon"
+	"github.com/ElrondNetwork/elrond-go/core/vmcommon"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestArwenWithCompiledCodeCache(t *testing.T, code []byte, directory string) (arwen.VMHost, *contextmock.InstanceBuilderRecorderMock) {
+	blockchainHook := &contextmock.BlockchainHookStub{}
+	blockchainHook.GetUserAccountCalled = func(address []byte) (vmcommon.UserAccountHandler, error) {
+		return &contextmock.StubAccount{CodeHash: []byte("counterCodeHash")}, nil
+	}
+	blockchainHook.GetCodeCalled = func(account vmcommon.UserAccountHandler) []byte {
+		return code
+	}
+
+	parameters := test.DefaultTestVMHostParameters()
+	parameters.CompiledCodeCacheDirectory = directory
+	parameters.CompiledCodeCacheSize = 10
+	host := test.DefaultTestArwenWithParameters(t, blockchainHook, parameters)
+
+	instanceRecorder := contextmock.NewInstanceBuilderRecorderMock()
+	host.Runtime().ReplaceInstanceBuilder(instanceRecorder)
+
+	return host, instanceRecorder
+}
+
+func TestCompiledCodeCache_SkipsCompilationAfterRestart(t *testing.T) {
+	directory, err := ioutil.TempDir("", "compiledCode")
+	require.Nil(t, err)
+	defer func() {
+		_ = os.RemoveAll(directory)
+	}()
+
+	code := test.GetTestSCCode("counter", "../../")
+	input := test.DefaultTestContractCallInput()
+	input.GasProvided = 1000000
+	input.Function = get
+
+	host, instanceRecorder := createTestArwenWithCompiledCodeCache(t, code, directory)
+	vmOutput, err := host.RunSmartContractCall(input)
+	verify := test.NewVMOutputVerifier(t, vmOutput, err)
+	verify.Ok()
+	require.Len(t, instanceRecorder.GetContractInstances(code), 1)
+
+	files, err := ioutil.ReadDir(directory)
+	require.Nil(t, err)
+	require.Len(t, files, 1)
+
+	// a new host finds the compiled code on disk, although the blockchain hook lost it
+	restartedHost, instanceRecorder := createTestArwenWithCompiledCodeCache(t, code, directory)
+	vmOutput, err = restartedHost.RunSmartContractCall(input)
+	verify = test.NewVMOutputVerifier(t, vmOutput, err)
+	verify.Ok()
+	require.Len(t, instanceRecorder.GetContractInstances(code), 0)
+	require.Len(t, instanceRecorder.InstanceMap, 1)
+}
+
+func TestCompiledCodeCache_ClearedByNewGasSchedule(t *testing.T) {
+	directory, err := ioutil.TempDir("", "compiledCode")
+	require.Nil(t, err)
+	defer func() {
+		_ = os.RemoveAll(directory)
+	}()
+
+	code := test.GetTestSCCode("counter", "../../")
+	input := test.DefaultTestContractCallInput()
+	input.GasProvided = 1000000
+	input.Function = get
+
+	host, instanceRecorder := createTestArwenWithCompiledCodeCache(t, code, directory)
+	defer func() {
+		// the opcode costs are shared by all the hosts
+		_ = host.SetGasSchedule(config.MakeGasMapForTests())
+	}()
+
+	vmOutput, err := host.RunSmartContractCall(input)
+	verify := test.NewVMOutputVerifier(t, vmOutput, err)
+	verify.Ok()
+	filesBefore, err := ioutil.ReadDir(directory)
+	require.Nil(t, err)
+	require.Len(t, filesBefore, 1)
+
+	err = host.SetGasSchedule(makeGasMapWithOpcodeCost(2 * config.GasValueForTests))
+	require.Nil(t, err)
+	files, err := ioutil.ReadDir(directory)
+	require.Nil(t, err)
+	require.Len(t, files, 0)
+
+	// the code is compiled again with the new opcode costs, and kept under another key
+	vmOutput, err = host.RunSmartContractCall(input)
+	verify = test.NewVMOutputVerifier(t, vmOutput, err)
+	verify.Ok()
+	require.Len(t, instanceRecorder.GetContractInstances(code), 2)
+
+	files, err = ioutil.ReadDir(directory)
+	require.Nil(t, err)
+	require.Len(t, files, 1)
+	require.NotEqual(t, filesBefore[0].Name(), files[0].Name())
+}
@@ -0,0 +1,38 @@
+package hosttest
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/config"
+	test "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/testcommon"
+	"github.com/stretchr/testify/require"
+)
+
+func runVerifyEd25519Batch(t *testing.T, numSignatures int64) uint64 {
+	host, _ := test.DefaultTestArwenForCall(t, test.GetTestSCCode("ed25519-batch", "../../"), nil)
+
+	gasMap := config.MakeGasMapForTests()
+	gasMap["CryptoAPICost"]["VerifyEd25519"] = 1000
+	gasMap["CryptoAPICost"]["VerifyEd25519BatchPerSignature"] = 1
+	host.SetGasSchedule(gasMap)
+
+	input := test.DefaultTestContractCallInput()
+	input.Function = "verifyBatch"
+	input.Arguments = [][]byte{big.NewInt(numSignatures).Bytes()}
+	input.GasProvided = 100000
+
+	vmOutput, err := host.RunSmartContractCall(input)
+	test.NewVMOutputVerifier(t, vmOutput, err).Ok()
+
+	return input.GasProvided - vmOutput.GasRemaining
+}
+
+func TestCryptoEI_VerifyEd25519BatchChargesEachSignature(t *testing.T) {
+	gasUsedForOne := runVerifyEd25519Batch(t, 1)
+	gasUsedForTwo := runVerifyEd25519Batch(t, 2)
+
+	// each signature costs no less than a single verification, regardless of
+	// the lower VerifyEd25519BatchPerSignature
+	require.Equal(t, uint64(1000), gasUsedForTwo-gasUsedForOne)
+}
@@ -0,0 +1,146 @@
+package hosttest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/contracts"
+	worldmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/world"
+	test "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/testcommon"
+	"github.com/ElrondNetwork/elrond-go/core"
+	"github.com/ElrondNetwork/elrond-go/core/vmcommon"
+	"github.com/stretchr/testify/require"
+)
+
+const gasUsedByCustomClaim = uint64(17)
+
+var customClaimBuiltin = worldmock.CustomBuiltinFunction{
+	Name:    "customClaim",
+	GasCost: gasUsedByCustomClaim,
+	Handler: func(world *worldmock.MockWorld, input *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+		vmOutput := test.MakeVMOutput()
+		test.AddNewOutputAccount(vmOutput, nil, input.CallerAddr, 42, nil)
+		return vmOutput, nil
+	},
+}
+
+var customFailBuiltin = worldmock.CustomBuiltinFunction{
+	Name:    "customFail",
+	GasCost: gasUsedByCustomClaim,
+	Handler: func(world *worldmock.MockWorld, input *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+		return nil, errors.New("custom failure")
+	},
+}
+
+func TestCustomBuiltinFunctions_Registration(t *testing.T) {
+	host, world := test.DefaultTestArwenWithWorldMock(t)
+
+	err := world.RegisterBuiltinFunction(worldmock.CustomBuiltinFunction{Name: "noHandler"})
+	require.Equal(t, worldmock.ErrInvalidCustomBuiltinFunction, err)
+
+	test.RegisterCustomBuiltinFunctions(t, host, world.MockWorld, customClaimBuiltin)
+	require.True(t, host.IsBuiltinFunctionName(customClaimBuiltin.Name))
+	require.True(t, host.IsBuiltinFunctionName(core.BuiltInFunctionESDTTransfer))
+
+	err = world.RegisterBuiltinFunction(customClaimBuiltin)
+	require.NotNil(t, err)
+
+	emptyWorld := worldmock.NewMockWorld()
+	err = emptyWorld.RegisterBuiltinFunction(customClaimBuiltin)
+	require.Equal(t, worldmock.ErrBuiltinFuncWrapperNotInitialized, err)
+}
+
+func TestCustomBuiltinFunctions_ExecuteOnDestContext(t *testing.T) {
+	test.BuildMockInstanceCallTest(t).
+		WithContracts(
+			test.CreateMockContract(test.ParentAddress).
+				WithBalance(simpleGasTestConfig.ParentBalance).
+				WithConfig(simpleGasTestConfig).
+				WithMethods(contracts.ExecOnDestCtxParentMock)).
+		WithInput(test.CreateTestContractCallInputBuilder().
+			WithRecipientAddr(test.ParentAddress).
+			WithGasProvided(simpleGasTestConfig.GasProvided).
+			WithFunction("execOnDestCtx").
+			WithArguments(test.ParentAddress, []byte(customClaimBuiltin.Name), arwen.One.Bytes()).
+			Build()).
+		WithSetup(func(host arwen.VMHost, world *worldmock.MockWorld) {
+			test.RegisterCustomBuiltinFunctions(t, host, world, customClaimBuiltin)
+			setZeroCodeCosts(host)
+		}).
+		AndAssertResults(func(world *worldmock.MockWorld, verify *test.VMOutputVerifier) {
+			verify.
+				Ok().
+				BalanceDelta(test.ParentAddress, 42).
+				GasRemaining(simpleGasTestConfig.GasProvided-simpleGasTestConfig.GasUsedByParent-gasUsedByCustomClaim).
+				GasUsed(test.ParentAddress, simpleGasTestConfig.GasUsedByParent+gasUsedByCustomClaim)
+		})
+}
+
+func TestCustomBuiltinFunctions_AsyncCall(t *testing.T) {
+	testConfig := asyncBaseTestConfig
+	testConfig.GasProvided = 1000
+
+	expectedGasUsedByParent := testConfig.GasUsedByParent + testConfig.GasUsedByCallback + gasUsedByCustomClaim
+
+	test.BuildMockInstanceCallTest(t).
+		WithContracts(
+			test.CreateMockContract(test.ParentAddress).
+				WithBalance(testConfig.ParentBalance).
+				WithConfig(&testConfig).
+				WithMethods(contracts.ForwardAsyncCallParentBuiltinMock, contracts.CallBackParentBuiltinMock),
+		).
+		WithInput(test.CreateTestContractCallInputBuilder().
+			WithRecipientAddr(test.ParentAddress).
+			WithGasProvided(testConfig.GasProvided).
+			WithFunction("forwardAsyncCall").
+			WithArguments(test.UserAddress, []byte(customClaimBuiltin.Name)).
+			Build()).
+		WithSetup(func(host arwen.VMHost, world *worldmock.MockWorld) {
+			world.AcctMap.CreateAccount(test.UserAddress)
+			test.RegisterCustomBuiltinFunctions(t, host, world, customClaimBuiltin)
+			setZeroCodeCosts(host)
+			setAsyncCosts(host, testConfig.GasLockCost)
+		}).
+		AndAssertResults(func(world *worldmock.MockWorld, verify *test.VMOutputVerifier) {
+			verify.
+				Ok().
+				GasUsed(test.ParentAddress, expectedGasUsedByParent).
+				GasUsed(test.UserAddress, 0).
+				GasRemaining(testConfig.GasProvided - expectedGasUsedByParent)
+		})
+}
+
+func TestCustomBuiltinFunctions_AsyncCallFail(t *testing.T) {
+	testConfig := asyncBaseTestConfig
+	testConfig.GasProvided = 1000
+
+	gasProvidedForBuiltinCall := testConfig.GasProvided - testConfig.GasUsedByParent - testConfig.GasLockCost
+	expectedGasUsedByParent := testConfig.GasUsedByParent + gasProvidedForBuiltinCall + testConfig.GasUsedByCallback
+
+	test.BuildMockInstanceCallTest(t).
+		WithContracts(
+			test.CreateMockContract(test.ParentAddress).
+				WithBalance(testConfig.ParentBalance).
+				WithConfig(&testConfig).
+				WithMethods(contracts.ForwardAsyncCallParentBuiltinMock, contracts.CallBackParentBuiltinMock),
+		).
+		WithInput(test.CreateTestContractCallInputBuilder().
+			WithRecipientAddr(test.ParentAddress).
+			WithGasProvided(testConfig.GasProvided).
+			WithFunction("forwardAsyncCall").
+			WithArguments(test.UserAddress, []byte(customFailBuiltin.Name)).
+			Build()).
+		WithSetup(func(host arwen.VMHost, world *worldmock.MockWorld) {
+			world.AcctMap.CreateAccount(test.UserAddress)
+			test.RegisterCustomBuiltinFunctions(t, host, world, customFailBuiltin)
+			setZeroCodeCosts(host)
+			setAsyncCosts(host, testConfig.GasLockCost)
+		}).
+		AndAssertResults(func(world *worldmock.MockWorld, verify *test.VMOutputVerifier) {
+			verify.
+				Ok().
+				GasUsed(test.ParentAddress, expectedGasUsedByParent).
+				GasRemaining(testConfig.GasProvided - expectedGasUsedByParent)
+		})
+}
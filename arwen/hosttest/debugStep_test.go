@@ -0,0 +1,83 @@
+package hosttest
+
+import (
+	"testing"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
+	contextmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/context"
+	test "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/testcommon"
+	"github.com/ElrondNetwork/elrond-go/core/vmcommon"
+	"github.com/stretchr/testify/require"
+)
+
+func runCounterWithDebugStop(t *testing.T, stop arwen.DebugStop) (*vmcommon.VMOutput, []arwen.DebugStep) {
+	code := test.GetTestSCCode("counter", "../../")
+	blockchainHook := &contextmock.BlockchainHookStub{}
+	blockchainHook.GetUserAccountCalled = func(address []byte) (vmcommon.UserAccountHandler, error) {
+		return &contextmock.StubAccount{CodeHash: []byte("counterCodeHash")}, nil
+	}
+	blockchainHook.GetCodeCalled = func(account vmcommon.UserAccountHandler) []byte {
+		return code
+	}
+
+	steps := make([]arwen.DebugStep, 0)
+	parameters := test.DefaultTestVMHostParameters()
+	parameters.DebugStop = stop
+	parameters.DebugStepCallback = func(step arwen.DebugStep) {
+		steps = append(steps, step)
+	}
+	host := test.DefaultTestArwenWithParameters(t, blockchainHook, parameters)
+	defer host.Runtime().ResetWarmInstance()
+
+	input := test.DefaultTestContractCallInput()
+	input.GasProvided = 1000000
+	input.Function = increment
+
+	vmOutput, err := host.RunSmartContractCall(input)
+	require.Nil(t, err)
+	return vmOutput, steps
+}
+
+func TestDebugStep_StopsAtPoints(t *testing.T) {
+	vmOutput, steps := runCounterWithDebugStop(t, arwen.DebugStop{Instance: 1, Points: 2})
+	require.Equal(t, vmcommon.ExecutionFailed, vmOutput.ReturnCode)
+	require.Equal(t, arwen.ErrExecutionStoppedByDebugger.Error(), vmOutput.ReturnMessage)
+
+	require.Len(t, steps, 1)
+	step := steps[0]
+	require.Equal(t, uint64(1), step.Instance)
+	require.Equal(t, increment, step.Function)
+	require.Equal(t, test.ParentAddress, step.Address)
+	require.GreaterOrEqual(t, step.PointsUsed, uint64(2))
+
+	require.NotZero(t, len(step.Memory))
+	require.Equal(t, 0, len(step.Memory)%arwen.WasmPageSize)
+}
+
+func TestDebugStep_StepsEveryInterval(t *testing.T) {
+	interval := uint64(3)
+	lastPoints := uint64(0)
+	numSteps := 0
+	for points := uint64(0); ; points += interval {
+		vmOutput, steps := runCounterWithDebugStop(t, arwen.DebugStop{Instance: 1, Points: points})
+		if len(steps) == 0 {
+			require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+			break
+		}
+
+		require.Len(t, steps, 1)
+		require.GreaterOrEqual(t, steps[0].PointsUsed, points)
+		require.GreaterOrEqual(t, steps[0].PointsUsed, lastPoints)
+		lastPoints = steps[0].PointsUsed
+		numSteps++
+		require.Less(t, numSteps, 1000)
+	}
+
+	require.Greater(t, numSteps, 1)
+}
+
+func TestDebugStep_NoStopForMissingInstance(t *testing.T) {
+	vmOutput, steps := runCounterWithDebugStop(t, arwen.DebugStop{Instance: 2, Points: 0})
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+	require.Empty(t, steps)
+}
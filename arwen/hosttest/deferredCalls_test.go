@@ -0,0 +1,177 @@
+package hosttest
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/contracts"
+	worldmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/world"
+	test "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/testcommon"
+	"github.com/ElrondNetwork/elrond-go/core/vmcommon"
+	"github.com/stretchr/testify/require"
+)
+
+var deferredCallTestConfig = contracts.DeferredCallTestConfig{
+	GasProvided: 1000,
+	GasLimit:    400,
+	TargetRound: 10,
+	TargetEpoch: 2,
+}
+
+func TestDeferredCall_Register(t *testing.T) {
+	testConfig := deferredCallTestConfig
+
+	test.BuildMockInstanceCallTest(t).
+		WithContracts(
+			test.CreateMockContract(test.ParentAddress).
+				WithBalance(0).
+				WithConfig(&testConfig).
+				WithMethods(contracts.RegisterDeferredCallMock),
+		).
+		WithInput(test.CreateTestContractCallInputBuilder().
+			WithRecipientAddr(test.ParentAddress).
+			WithGasProvided(testConfig.GasProvided).
+			WithFunction("registerDeferredCall").
+			WithArguments([]byte("arg")).
+			Build()).
+		WithSetup(func(host arwen.VMHost, world *worldmock.MockWorld) {
+			setZeroCodeCosts(host)
+			world.CurrentBlockInfo.BlockRound = 5
+			world.CurrentBlockInfo.BlockEpoch = 2
+		}).
+		AndAssertResults(func(world *worldmock.MockWorld, verify *test.VMOutputVerifier) {
+			verify.Ok()
+			vmOutput := verify.VmOutput
+			require.LessOrEqual(t, vmOutput.GasRemaining, testConfig.GasProvided-testConfig.GasLimit)
+
+			storageUpdate := vmOutput.OutputAccounts[string(test.ParentAddress)].StorageUpdates[arwen.DeferredCallsKey]
+			require.NotNil(t, storageUpdate)
+			deferredCalls := &arwen.DeferredCallsInfo{}
+			err := json.Unmarshal(storageUpdate.Data, deferredCalls)
+			require.Nil(t, err)
+			require.Equal(t, []*arwen.DeferredCall{
+				{
+					Function:    "deferredFunction",
+					Arguments:   [][]byte{[]byte("arg")},
+					GasLimit:    testConfig.GasLimit,
+					TargetRound: testConfig.TargetRound,
+					TargetEpoch: testConfig.TargetEpoch,
+				},
+			}, deferredCalls.Calls)
+
+			require.Len(t, vmOutput.Logs, 1)
+			require.Equal(t, []byte(arwen.DeferredCallLogIdentifier), vmOutput.Logs[0].Identifier)
+			require.Equal(t, []byte("deferredFunction"), vmOutput.Logs[0].Data)
+		})
+}
+
+func TestDeferredCall_Register_TargetReached(t *testing.T) {
+	testConfig := deferredCallTestConfig
+
+	test.BuildMockInstanceCallTest(t).
+		WithContracts(
+			test.CreateMockContract(test.ParentAddress).
+				WithBalance(0).
+				WithConfig(&testConfig).
+				WithMethods(contracts.RegisterDeferredCallMock),
+		).
+		WithInput(test.CreateTestContractCallInputBuilder().
+			WithRecipientAddr(test.ParentAddress).
+			WithGasProvided(testConfig.GasProvided).
+			WithFunction("registerDeferredCall").
+			Build()).
+		WithSetup(func(host arwen.VMHost, world *worldmock.MockWorld) {
+			setZeroCodeCosts(host)
+			world.CurrentBlockInfo.BlockRound = 10
+			world.CurrentBlockInfo.BlockEpoch = 2
+		}).
+		AndAssertResults(func(world *worldmock.MockWorld, verify *test.VMOutputVerifier) {
+			verify.
+				ReturnCode(vmcommon.ExecutionFailed).
+				ReturnMessage(arwen.ErrDeferredCallTargetReached.Error())
+		})
+}
+
+func TestDeferredCall_Register_NotEnoughGas(t *testing.T) {
+	testConfig := deferredCallTestConfig
+	testConfig.GasLimit = testConfig.GasProvided
+
+	test.BuildMockInstanceCallTest(t).
+		WithContracts(
+			test.CreateMockContract(test.ParentAddress).
+				WithBalance(0).
+				WithConfig(&testConfig).
+				WithMethods(contracts.RegisterDeferredCallMock),
+		).
+		WithInput(test.CreateTestContractCallInputBuilder().
+			WithRecipientAddr(test.ParentAddress).
+			WithGasProvided(testConfig.GasProvided).
+			WithFunction("registerDeferredCall").
+			Build()).
+		WithSetup(func(host arwen.VMHost, world *worldmock.MockWorld) {
+			setZeroCodeCosts(host)
+			world.CurrentBlockInfo.BlockRound = 5
+			world.CurrentBlockInfo.BlockEpoch = 2
+		}).
+		AndAssertResults(func(world *worldmock.MockWorld, verify *test.VMOutputVerifier) {
+			verify.
+				ReturnCode(vmcommon.ExecutionFailed).
+				ReturnMessage(arwen.ErrNotEnoughGas.Error())
+		})
+}
+
+func TestDeferredCall_Run(t *testing.T) {
+	testConfig := deferredCallTestConfig
+	var host arwen.VMHost
+
+	test.BuildMockInstanceCallTest(t).
+		WithContracts(
+			test.CreateMockContract(test.ParentAddress).
+				WithBalance(0).
+				WithConfig(&testConfig).
+				WithMethods(contracts.RegisterDeferredCallMock),
+		).
+		WithInput(test.CreateTestContractCallInputBuilder().
+			WithRecipientAddr(test.ParentAddress).
+			WithGasProvided(testConfig.GasProvided).
+			WithFunction("registerDeferredCall").
+			WithArguments([]byte("arg")).
+			Build()).
+		WithSetup(func(setupHost arwen.VMHost, world *worldmock.MockWorld) {
+			setZeroCodeCosts(setupHost)
+			world.CurrentBlockInfo.BlockRound = 5
+			world.CurrentBlockInfo.BlockEpoch = 2
+			host = setupHost
+		}).
+		AndAssertResults(func(world *worldmock.MockWorld, verify *test.VMOutputVerifier) {
+			verify.Ok()
+			_ = world.UpdateAccounts(verify.VmOutput.OutputAccounts, nil)
+
+			// the target round is not reached yet
+			world.CurrentBlockInfo.BlockRound = 9
+			vmOutput, err := host.RunDeferredCalls(test.ParentAddress)
+			require.Nil(t, err)
+			require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+			require.Len(t, vmOutput.OutputAccounts, 0)
+
+			world.CurrentBlockInfo.BlockRound = 10
+			vmOutput, err = host.RunDeferredCalls(test.ParentAddress)
+			require.Nil(t, err)
+
+			test.NewVMOutputVerifier(t, vmOutput, nil).
+				Ok().
+				Transfers(
+					test.CreateTransferEntry(test.ParentAddress, test.ParentAddress).
+						WithData([]byte("deferredFunction@617267")).
+						WithGasLimit(testConfig.GasLimit).
+						WithCallType(vmcommon.DirectCall).
+						WithValue(big.NewInt(0)),
+				)
+
+			storageUpdate := vmOutput.OutputAccounts[string(test.ParentAddress)].StorageUpdates[arwen.DeferredCallsKey]
+			require.NotNil(t, storageUpdate)
+			require.Len(t, storageUpdate.Data, 0)
+		})
+}
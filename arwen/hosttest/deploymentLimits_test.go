@@ -0,0 +1,55 @@
+package hosttest
+
+import (
+	"testing"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
+	contextmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/context"
+	test "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/testcommon"
+	"github.com/ElrondNetwork/elrond-go/core/vmcommon"
+	"github.com/stretchr/testify/require"
+)
+
+func deployWithDeploymentLimits(t *testing.T, limits arwen.DeploymentLimits) *test.VMOutputVerifier {
+	blockchainHook := &contextmock.BlockchainHookStub{}
+	blockchainHook.GetUserAccountCalled = func(address []byte) (vmcommon.UserAccountHandler, error) {
+		return &contextmock.StubAccount{Nonce: 24}, nil
+	}
+	blockchainHook.NewAddressCalled = func(creatorAddress []byte, nonce uint64, vmType []byte) ([]byte, error) {
+		return test.ParentAddress, nil
+	}
+
+	parameters := test.DefaultTestVMHostParameters()
+	parameters.DeploymentLimits = limits
+	host := test.DefaultTestArwenWithParameters(t, blockchainHook, parameters)
+	require.Equal(t, limits, host.GetDeploymentLimits())
+
+	input := test.CreateTestContractCreateInputBuilder().
+		WithGasProvided(100000).
+		WithContractCode(test.GetTestSCCode("counter", "../../")).
+		Build()
+
+	vmOutput, err := host.RunSmartContractCreate(input)
+	return test.NewVMOutputVerifier(t, vmOutput, err)
+}
+
+func TestDeploymentLimits_CodeAdmitted(t *testing.T) {
+	deployWithDeploymentLimits(t, arwen.DeploymentLimits{
+		MaxCodeSize:     100000,
+		MaxExports:      10,
+		MaxImports:      10,
+		MaxDataSegments: 10,
+	}).Ok()
+}
+
+func TestDeploymentLimits_CodeTooLarge(t *testing.T) {
+	deployWithDeploymentLimits(t, arwen.DeploymentLimits{MaxCodeSize: 100}).
+		ReturnCode(vmcommon.ContractInvalid).
+		ReturnMessageContains(arwen.ErrContractCodeTooLarge.Error())
+}
+
+func TestDeploymentLimits_TooManyImports(t *testing.T) {
+	deployWithDeploymentLimits(t, arwen.DeploymentLimits{MaxImports: 1}).
+		ReturnCode(vmcommon.ContractInvalid).
+		ReturnMessageContains(arwen.ErrTooManyImports.Error())
+}
@@ -130,3 +130,28 @@ func TestElrondEI_int64getArgument(t *testing.T) {
 	i64val12345 = big.NewInt(0).SetBytes(data[2])
 	assert.Equal(t, big.NewInt(12345), i64val12345)
 }
+
+func TestElrondEI_getBlockInfo(t *testing.T) {
+	code := testcommon.GetTestSCCode("block-info", "../../")
+	host, blockchainHook := testcommon.DefaultTestArwenForCall(t, code, nil)
+	blockchainHook.CurrentNonceCalled = func() uint64 { return 98 }
+	blockchainHook.CurrentRoundCalled = func() uint64 { return 99 }
+	blockchainHook.CurrentEpochCalled = func() uint32 { return 4 }
+	blockchainHook.CurrentTimeStampCalled = func() uint64 { return 6800 }
+	blockchainHook.CurrentRandomSeedCalled = func() []byte { return []byte("random seed") }
+
+	input := testcommon.DefaultTestContractCallInput()
+	input.GasProvided = 100000
+	input.Function = "getBlockInfo"
+
+	vmOutput, err := host.RunSmartContractCall(input)
+	require.Nil(t, err)
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+	require.Equal(t, [][]byte{{
+		0, 0, 0, 0, 0, 0, 0, 98,
+		0, 0, 0, 0, 0, 0, 0, 99,
+		0, 0, 0, 4,
+		0, 0, 0, 0, 0, 0, 0x1a, 0x90,
+		'r', 'a', 'n', 'd', 'o', 'm', ' ', 's', 'e', 'e', 'd',
+	}}, vmOutput.ReturnData)
+}
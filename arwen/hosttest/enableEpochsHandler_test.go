@@ -0,0 +1,79 @@
+package hosttest
+
+import (
+	"testing"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
+	contextmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/context"
+	worldmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/world"
+	test "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/testcommon"
+	"github.com/ElrondNetwork/elrond-go/core/vmcommon"
+	"github.com/stretchr/testify/require"
+)
+
+type enableEpochsHandlerStub struct {
+	multiAsyncCallGroupsEnableEpoch uint32
+}
+
+func (handler *enableEpochsHandlerStub) IsMultiAsyncCallGroupsEnabledInEpoch(epoch uint32) bool {
+	return epoch >= handler.multiAsyncCallGroupsEnableEpoch
+}
+
+func (handler *enableEpochsHandlerStub) IsInterfaceNil() bool {
+	return handler == nil
+}
+
+func runRegisterInGroupWithEnableEpochs(t *testing.T, currentEpoch uint32) *vmcommon.VMOutput {
+	world := worldmock.NewMockWorld()
+	world.CurrentBlockInfo.BlockEpoch = currentEpoch
+
+	parameters := test.DefaultTestVMHostParameters()
+	parameters.EnableEpochsHandler = &enableEpochsHandlerStub{multiAsyncCallGroupsEnableEpoch: 2}
+	host := test.DefaultTestArwenWithParameters(t, world, parameters)
+
+	instanceBuilderMock := contextmock.NewInstanceBuilderMock(world)
+	host.Runtime().ReplaceInstanceBuilder(instanceBuilderMock)
+
+	parentInstance := instanceBuilderMock.CreateAndStoreInstanceMock(t, host, test.ParentAddress, 0, 1000)
+	parentInstance.AddMockMethod("registerInGroup", func() *contextmock.InstanceMock {
+		instance := contextmock.GetMockInstance(host)
+
+		// the destination is in another shard, so that the call is only registered
+		err := host.Runtime().AddAsyncContextCall([]byte("group"), &arwen.AsyncGeneratedCall{
+			Destination:     test.ChildAddress,
+			Data:            []byte("function"),
+			SuccessCallback: "callBack",
+			ErrorCallback:   "callBack",
+		})
+		if err != nil {
+			host.Runtime().FailExecution(err)
+		}
+
+		return instance
+	})
+	parentInstance.AddMockMethod("callBack", test.SimpleWasteGasMockMethod(parentInstance, 0))
+	world.AcctMap.CreateAccount(test.ChildAddress).ShardID = 1
+
+	input := test.CreateTestContractCallInputBuilder().
+		WithCallerAddr(test.UserAddress).
+		WithRecipientAddr(test.ParentAddress).
+		WithFunction("registerInGroup").
+		WithGasProvided(1000).
+		Build()
+
+	vmOutput, err := host.RunSmartContractCall(input)
+	require.Nil(t, err)
+
+	return vmOutput
+}
+
+func TestEnableEpochsHandler_MultiAsyncCallGroupsNotYetEnabled(t *testing.T) {
+	vmOutput := runRegisterInGroupWithEnableEpochs(t, 1)
+	require.Equal(t, vmcommon.ExecutionFailed, vmOutput.ReturnCode)
+	require.Equal(t, arwen.ErrMultiAsyncCallGroupsNotEnabled.Error(), vmOutput.ReturnMessage)
+}
+
+func TestEnableEpochsHandler_MultiAsyncCallGroupsEnabled(t *testing.T) {
+	vmOutput := runRegisterInGroupWithEnableEpochs(t, 2)
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+}
@@ -0,0 +1,153 @@
+package hosttest
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/config"
+	worldmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/world"
+	test "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/testcommon"
+	"github.com/ElrondNetwork/elrond-go/core/vmcommon"
+	"github.com/stretchr/testify/require"
+)
+
+const eventLogValidationActive = uint32(0)
+const eventLogValidationInactive = uint32(math.MaxUint32)
+
+const logEntryGasActive = uint32(0)
+const logEntryGasInactive = uint32(math.MaxUint32)
+
+// runERC20TransferWithEventLogLimits deploys the ERC20 contract on a new
+// host and transfers a token, which writes an event log with an identifier
+// and two topics, of 32 bytes each
+func runERC20TransferWithEventLogLimits(t *testing.T, limits arwen.EventLogLimits, logPerTopicByte uint64) *vmcommon.VMOutput {
+	return runERC20TransferWithEventLogValidation(t, limits, logPerTopicByte, eventLogValidationActive)
+}
+
+func runERC20TransferWithEventLogValidation(t *testing.T, limits arwen.EventLogLimits, logPerTopicByte uint64, enableEpoch uint32) *vmcommon.VMOutput {
+	return runERC20TransferWithEnableEpochs(t, limits, logPerTopicByte, enableEpoch, logEntryGasActive)
+}
+
+func runERC20TransferWithEnableEpochs(
+	t *testing.T,
+	limits arwen.EventLogLimits,
+	logPerTopicByte uint64,
+	eventLogValidationEnableEpoch uint32,
+	logEntryGasEnableEpoch uint32,
+) *vmcommon.VMOutput {
+	mockWorld := worldmock.NewMockWorld()
+	ownerAccount := &worldmock.Account{
+		Address: owner,
+		Nonce:   1024,
+		Balance: big.NewInt(0),
+	}
+	mockWorld.AcctMap.PutAccount(ownerAccount)
+	mockWorld.NewAddressMocks = append(mockWorld.NewAddressMocks, &worldmock.NewAddressMock{
+		CreatorAddress: owner,
+		CreatorNonce:   ownerAccount.Nonce,
+		NewAddress:     scAddress,
+	})
+
+	gasMap := config.MakeGasMapForTests()
+	gasMap["ElrondAPICost"]["LogPerTopicByte"] = logPerTopicByte
+
+	parameters := test.DefaultTestVMHostParameters()
+	parameters.GasSchedule = gasMap
+	parameters.MaxLogTopics = limits.MaxTopics
+	parameters.MaxLogTopicLength = limits.MaxTopicLength
+	parameters.EventLogValidationEnableEpoch = eventLogValidationEnableEpoch
+	parameters.LogEntryGasEnableEpoch = logEntryGasEnableEpoch
+	host := test.DefaultTestArwenWithParameters(t, mockWorld, parameters)
+
+	deployInput := test.CreateTestContractCreateInputBuilder().
+		WithCallerAddr(owner).
+		WithArguments(big.NewInt(100).Bytes()).
+		WithGasProvided(0xFFFFFFFFFFFFFFFF).
+		WithContractCode(test.GetTestSCCode("erc20", "../../")).
+		Build()
+
+	ownerAccount.Nonce++
+	vmOutput, err := host.RunSmartContractCreate(deployInput)
+	verify := test.NewVMOutputVerifier(t, vmOutput, err)
+	verify.Ok()
+	_ = mockWorld.UpdateAccounts(vmOutput.OutputAccounts, nil)
+
+	transferInput := test.CreateTestContractCallInputBuilder().
+		WithCallerAddr(owner).
+		WithRecipientAddr(scAddress).
+		WithFunction("transferToken").
+		WithArguments(receiver, big.NewInt(1).Bytes()).
+		WithGasProvided(100000).
+		Build()
+
+	vmOutput, err = host.RunSmartContractCall(transferInput)
+	require.Nil(t, err)
+
+	return vmOutput
+}
+
+func TestEventLog_WithinLimits(t *testing.T) {
+	limits := arwen.EventLogLimits{MaxTopics: 2, MaxTopicLength: 32}
+	vmOutput := runERC20TransferWithEventLogLimits(t, limits, 1)
+
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+	require.NotEmpty(t, vmOutput.Logs)
+	require.Equal(t, scAddress, vmOutput.Logs[0].Address)
+	require.Len(t, vmOutput.Logs[0].Identifier, 32)
+	require.Len(t, vmOutput.Logs[0].Topics, 2)
+}
+
+func TestEventLog_TooManyTopics(t *testing.T) {
+	limits := arwen.EventLogLimits{MaxTopics: 1}
+	vmOutput := runERC20TransferWithEventLogLimits(t, limits, 1)
+
+	require.Equal(t, vmcommon.ExecutionFailed, vmOutput.ReturnCode)
+	require.Contains(t, vmOutput.ReturnMessage, arwen.ErrEventLogTooManyTopics.Error())
+	require.Empty(t, vmOutput.Logs)
+}
+
+func TestEventLog_NotValidatedBeforeActivation(t *testing.T) {
+	limits := arwen.EventLogLimits{MaxTopics: 1, MaxTopicLength: 31}
+	vmOutput := runERC20TransferWithEventLogValidation(t, limits, 1, eventLogValidationInactive)
+
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+	require.NotEmpty(t, vmOutput.Logs)
+	require.Len(t, vmOutput.Logs[0].Topics, 2)
+}
+
+func TestEventLog_TopicTooLong(t *testing.T) {
+	limits := arwen.EventLogLimits{MaxTopicLength: 31}
+	vmOutput := runERC20TransferWithEventLogLimits(t, limits, 1)
+
+	require.Equal(t, vmcommon.ExecutionFailed, vmOutput.ReturnCode)
+	require.Contains(t, vmOutput.ReturnMessage, arwen.ErrEventLogTopicTooLong.Error())
+	require.Empty(t, vmOutput.Logs)
+}
+
+func TestEventLog_GasPerTopicByte(t *testing.T) {
+	vmOutput := runERC20TransferWithEventLogLimits(t, arwen.EventLogLimits{}, 1)
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+	gasRemaining := vmOutput.GasRemaining
+
+	vmOutput = runERC20TransferWithEventLogLimits(t, arwen.EventLogLimits{}, 2)
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+
+	// the identifier and the two topics written by the ERC20 contract
+	require.Equal(t, uint64(3*32), gasRemaining-vmOutput.GasRemaining)
+}
+
+func TestEventLog_NoGasPerTopicByteBeforeActivation(t *testing.T) {
+	vmOutput := runERC20TransferWithEnableEpochs(t, arwen.EventLogLimits{}, 1, eventLogValidationActive, logEntryGasInactive)
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+	gasRemaining := vmOutput.GasRemaining
+
+	vmOutput = runERC20TransferWithEnableEpochs(t, arwen.EventLogLimits{}, 2, eventLogValidationActive, logEntryGasInactive)
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+	require.Equal(t, gasRemaining, vmOutput.GasRemaining)
+
+	vmOutput = runERC20TransferWithEnableEpochs(t, arwen.EventLogLimits{}, 1, eventLogValidationActive, logEntryGasActive)
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+	require.Less(t, vmOutput.GasRemaining, gasRemaining)
+}
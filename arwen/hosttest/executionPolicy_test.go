@@ -0,0 +1,96 @@
+package hosttest
+
+import (
+	"testing"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
+	worldmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/world"
+	test "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/testcommon"
+	"github.com/ElrondNetwork/elrond-go/core/vmcommon"
+	"github.com/stretchr/testify/require"
+)
+
+type executionPolicyStub struct {
+	decision arwen.ExecutionPolicyDecision
+	requests []arwen.ExecutionPolicyRequest
+}
+
+func (policy *executionPolicyStub) Evaluate(request arwen.ExecutionPolicyRequest) arwen.ExecutionPolicyDecision {
+	policy.requests = append(policy.requests, request)
+	return policy.decision
+}
+
+func (policy *executionPolicyStub) IsInterfaceNil() bool {
+	return policy == nil
+}
+
+func createTestArwenWithExecutionPolicy(t *testing.T, policy arwen.ExecutionPolicy) arwen.VMHost {
+	parameters := test.DefaultTestVMHostParameters()
+	parameters.ExecutionPolicy = policy
+	return test.DefaultTestArwenWithParameters(t, worldmock.NewMockWorld(), parameters)
+}
+
+func TestExecutionPolicy_Allowed(t *testing.T) {
+	policy := &executionPolicyStub{decision: arwen.ExecutionAllowed}
+	host := createTestArwenWithExecutionPolicy(t, policy)
+
+	input := test.CreateTestContractCallInputBuilder().
+		WithCallerAddr(test.UserAddress).
+		WithRecipientAddr(test.ParentAddress).
+		WithFunction("someFunction").
+		WithGasProvided(1000).
+		Build()
+
+	vmOutput, err := host.RunSmartContractCall(input)
+	require.Nil(t, err)
+	require.Equal(t, vmcommon.ContractNotFound, vmOutput.ReturnCode)
+
+	require.Len(t, policy.requests, 1)
+	require.Equal(t, arwen.ExecutionPolicyRequest{
+		Caller:      test.UserAddress,
+		Contract:    test.ParentAddress,
+		Function:    "someFunction",
+		GasProvided: 1000,
+	}, policy.requests[0])
+}
+
+func TestExecutionPolicy_Rejected(t *testing.T) {
+	policy := &executionPolicyStub{decision: arwen.ExecutionRejected}
+	host := createTestArwenWithExecutionPolicy(t, policy)
+
+	input := test.CreateTestContractCallInputBuilder().
+		WithRecipientAddr(test.ParentAddress).
+		WithGasProvided(1000).
+		Build()
+
+	vmOutput, err := host.RunSmartContractCall(input)
+	require.Nil(t, err)
+	require.Equal(t, vmcommon.UserError, vmOutput.ReturnCode)
+	require.Equal(t, arwen.ErrExecutionRejectedByPolicy.Error(), vmOutput.ReturnMessage)
+	require.Equal(t, uint64(0), vmOutput.GasRemaining)
+
+	createInput := test.CreateTestContractCreateInputBuilder().
+		WithGasProvided(1000).
+		WithContractCode([]byte("contract")).
+		Build()
+
+	vmOutput, err = host.RunSmartContractCreate(createInput)
+	require.Nil(t, err)
+	require.Equal(t, vmcommon.UserError, vmOutput.ReturnCode)
+	require.Equal(t, arwen.InitFunctionName, policy.requests[1].Function)
+	require.Nil(t, policy.requests[1].Contract)
+}
+
+func TestExecutionPolicy_Deprioritized(t *testing.T) {
+	policy := &executionPolicyStub{decision: arwen.ExecutionDeprioritized}
+	host := createTestArwenWithExecutionPolicy(t, policy)
+
+	input := test.CreateTestContractCallInputBuilder().
+		WithRecipientAddr(test.ParentAddress).
+		WithGasProvided(1000).
+		Build()
+
+	vmOutput, err := host.RunSmartContractCall(input)
+	require.Equal(t, arwen.ErrExecutionDeprioritized, err)
+	require.Nil(t, vmOutput)
+}
@@ -0,0 +1,82 @@
+package hosttest
+
+import (
+	"bytes"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
+	contextmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/context"
+	test "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/testcommon"
+	"github.com/ElrondNetwork/elrond-go/core/vmcommon"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestArwenWithExecutionProfiling(t *testing.T, code []byte) arwen.VMHost {
+	blockchainHook := &contextmock.BlockchainHookStub{}
+	blockchainHook.GetUserAccountCalled = func(address []byte) (vmcommon.UserAccountHandler, error) {
+		return &contextmock.StubAccount{}, nil
+	}
+	blockchainHook.GetCodeCalled = func(account vmcommon.UserAccountHandler) []byte {
+		return code
+	}
+
+	parameters := test.DefaultTestVMHostParameters()
+	parameters.ExecutionProfilingEnabled = true
+	host := test.DefaultTestArwenWithParameters(t, blockchainHook, parameters)
+
+	return host
+}
+
+func TestExecutionProfile_DisabledByDefault(t *testing.T) {
+	code := test.GetTestSCCode("counter", "../../")
+	host, _ := test.DefaultTestArwenForCall(t, code, nil)
+
+	input := test.DefaultTestContractCallInput()
+	input.GasProvided = 1000000
+	input.Function = get
+
+	_, err := host.RunSmartContractCall(input)
+	require.Nil(t, err)
+	require.Nil(t, host.GetExecutionProfile())
+}
+
+func TestExecutionProfile_SamplesWasmAndHostFunctions(t *testing.T) {
+	code := test.GetTestSCCode("exec-same-ctx-child", "../../")
+	host := createTestArwenWithExecutionProfiling(t, code)
+
+	// the child loops on finish() until it runs out of gas
+	input := test.DefaultTestContractCallInput()
+	input.GasProvided = 5000000
+	input.Function = "childFunction_OutOfGas"
+	input.CallValue = big.NewInt(99)
+
+	vmOutput, err := host.RunSmartContractCall(input)
+	require.Nil(t, err)
+	require.Equal(t, vmcommon.OutOfGas, vmOutput.ReturnCode)
+
+	profile := host.GetExecutionProfile()
+	require.NotNil(t, profile)
+	require.Equal(t, arwen.ExecutionProfileSampleInterval, profile.SampleInterval)
+	require.NotZero(t, profile.NumSamples)
+
+	// the execution spends most of its time in the WASM code and in finish()
+	numSamples := uint64(0)
+	numWasmSamples := uint64(0)
+	for stack, count := range profile.Stacks {
+		numSamples += count
+		require.True(t, strings.HasPrefix(stack, "arwen/hosttest."), stack)
+		require.Contains(t, stack, "arwen/host.(*vmHost).RunSmartContractCall;")
+		if strings.Contains(stack, "wasm:childFunction_OutOfGas@") {
+			numWasmSamples += count
+		}
+	}
+	require.Equal(t, profile.NumSamples, numSamples)
+	require.Greater(t, numWasmSamples, numSamples/2)
+
+	folded := &bytes.Buffer{}
+	require.Nil(t, profile.WriteFolded(folded))
+	require.Contains(t, folded.String(), "elrondapi.v1_3_returnData")
+	require.Len(t, strings.Split(strings.TrimSpace(folded.String()), "\n"), len(profile.Stacks))
+}
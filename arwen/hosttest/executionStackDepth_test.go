@@ -0,0 +1,84 @@
+package hosttest
+
+import (
+	"testing"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
+	contextmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/context"
+	worldmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/world"
+	test "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/testcommon"
+	"github.com/ElrondNetwork/elrond-go/core/vmcommon"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestArwenWithMaxExecutionStackDepth(t *testing.T, world *worldmock.MockWorld, maxDepth uint64) (arwen.VMHost, *contextmock.InstanceBuilderMock) {
+	parameters := test.DefaultTestVMHostParameters()
+	parameters.MaxExecutionStackDepth = maxDepth
+	host := test.DefaultTestArwenWithParameters(t, world, parameters)
+
+	instanceBuilderMock := contextmock.NewInstanceBuilderMock(world)
+	host.Runtime().ReplaceInstanceBuilder(instanceBuilderMock)
+
+	return host, instanceBuilderMock
+}
+
+// runRecursiveExecution runs a contract calling itself, through the given
+// kind of nested execution, until a nested execution fails; it returns the
+// deepest execution stack depth reached and the error of the failed execution
+func runRecursiveExecution(t *testing.T, maxDepth uint64, sameContext bool) (uint64, error) {
+	world := worldmock.NewMockWorld()
+	host, instanceBuilderMock := createTestArwenWithMaxExecutionStackDepth(t, world, maxDepth)
+
+	deepestDepth := uint64(0)
+	var nestedErr error
+
+	parentInstance := instanceBuilderMock.CreateAndStoreInstanceMock(t, host, test.ParentAddress, 0, 1000)
+	parentInstance.AddMockMethod("recurse", func() *contextmock.InstanceMock {
+		instance := contextmock.GetMockInstance(host)
+		deepestDepth = host.Runtime().GetExecutionStackDepth()
+
+		nestedInput := test.CreateTestContractCallInputBuilder().
+			WithCallerAddr(test.ParentAddress).
+			WithRecipientAddr(test.ParentAddress).
+			WithFunction("recurse").
+			WithGasProvided(host.Metering().GasLeft() / 2).
+			Build()
+
+		var err error
+		if sameContext {
+			_, err = host.ExecuteOnSameContext(nestedInput)
+		} else {
+			_, _, err = host.ExecuteOnDestContext(nestedInput)
+		}
+		if err != nil && nestedErr == nil {
+			nestedErr = err
+		}
+
+		return instance
+	})
+
+	input := test.CreateTestContractCallInputBuilder().
+		WithCallerAddr(test.UserAddress).
+		WithRecipientAddr(test.ParentAddress).
+		WithFunction("recurse").
+		WithGasProvided(100000).
+		Build()
+
+	vmOutput, err := host.RunSmartContractCall(input)
+	require.Nil(t, err)
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+
+	return deepestDepth, nestedErr
+}
+
+func TestExecutionStackDepth_DestContextLimited(t *testing.T) {
+	deepestDepth, err := runRecursiveExecution(t, 3, false)
+	require.Equal(t, arwen.ErrExecutionStackOverflow, err)
+	require.Equal(t, uint64(3), deepestDepth)
+}
+
+func TestExecutionStackDepth_SameContextLimited(t *testing.T) {
+	deepestDepth, err := runRecursiveExecution(t, 2, true)
+	require.Equal(t, arwen.ErrExecutionStackOverflow, err)
+	require.Equal(t, uint64(2), deepestDepth)
+}
@@ -0,0 +1,109 @@
+package hosttest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
+	contextmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/context"
+	worldmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/world"
+	test "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/testcommon"
+	"github.com/ElrondNetwork/elrond-go/core/vmcommon"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestArwenWithExecutionTimeout(t *testing.T, world *worldmock.MockWorld, timeout time.Duration) (arwen.VMHost, *contextmock.InstanceBuilderMock) {
+	parameters := test.DefaultTestVMHostParameters()
+	parameters.ExecutionTimeout = timeout
+	host := test.DefaultTestArwenWithParameters(t, world, parameters)
+
+	instanceBuilderMock := contextmock.NewInstanceBuilderMock(world)
+	host.Runtime().ReplaceInstanceBuilder(instanceBuilderMock)
+
+	return host, instanceBuilderMock
+}
+
+// waitForBreakpoint stands for WASM code running until Wasmer stops it on a
+// breakpoint, giving up after a while
+func waitForBreakpoint(instance *contextmock.InstanceMock) {
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if arwen.BreakpointValue(instance.GetBreakpointValue()) != arwen.BreakpointNone {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestExecutionWatchdog_InterruptsLongExecution(t *testing.T) {
+	world := worldmock.NewMockWorld()
+	host, instanceBuilderMock := createTestArwenWithExecutionTimeout(t, world, 50*time.Millisecond)
+
+	instance := instanceBuilderMock.CreateAndStoreInstanceMock(t, host, test.ParentAddress, 0, 1000)
+	instance.AddMockMethod("hang", func() *contextmock.InstanceMock {
+		instance := contextmock.GetMockInstance(host)
+		waitForBreakpoint(instance)
+		return instance
+	})
+	instance.AddMockMethod("return", func() *contextmock.InstanceMock {
+		return contextmock.GetMockInstance(host)
+	})
+
+	input := test.CreateTestContractCallInputBuilder().
+		WithCallerAddr(test.UserAddress).
+		WithRecipientAddr(test.ParentAddress).
+		WithFunction("hang").
+		WithGasProvided(1000).
+		Build()
+
+	vmOutput, err := host.RunSmartContractCall(input)
+	require.Nil(t, vmOutput)
+	require.Equal(t, arwen.ErrExecutionTimeout, err)
+
+	// the next execution is not affected
+	input.Function = "return"
+	vmOutput, err = host.RunSmartContractCall(input)
+	require.Nil(t, err)
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+}
+
+func TestExecutionWatchdog_InterruptsCallerOfLongExecution(t *testing.T) {
+	world := worldmock.NewMockWorld()
+	host, instanceBuilderMock := createTestArwenWithExecutionTimeout(t, world, 50*time.Millisecond)
+
+	callerInterrupted := false
+	parentInstance := instanceBuilderMock.CreateAndStoreInstanceMock(t, host, test.ParentAddress, 0, 1000)
+	parentInstance.AddMockMethod("callChild", func() *contextmock.InstanceMock {
+		instance := contextmock.GetMockInstance(host)
+		childInput := test.CreateTestContractCallInputBuilder().
+			WithCallerAddr(test.ParentAddress).
+			WithRecipientAddr(test.ChildAddress).
+			WithFunction("hang").
+			WithGasProvided(500).
+			Build()
+		_, _, _ = host.ExecuteOnDestContext(childInput)
+
+		waitForBreakpoint(instance)
+		callerInterrupted = arwen.BreakpointValue(instance.GetBreakpointValue()) == arwen.BreakpointExecutionTimeout
+		return instance
+	})
+
+	childInstance := instanceBuilderMock.CreateAndStoreInstanceMock(t, host, test.ChildAddress, 0, 1000)
+	childInstance.AddMockMethod("hang", func() *contextmock.InstanceMock {
+		instance := contextmock.GetMockInstance(host)
+		waitForBreakpoint(instance)
+		return instance
+	})
+
+	input := test.CreateTestContractCallInputBuilder().
+		WithCallerAddr(test.UserAddress).
+		WithRecipientAddr(test.ParentAddress).
+		WithFunction("callChild").
+		WithGasProvided(1000).
+		Build()
+
+	vmOutput, err := host.RunSmartContractCall(input)
+	require.Nil(t, vmOutput)
+	require.Equal(t, arwen.ErrExecutionTimeout, err)
+	require.True(t, callerInterrupted)
+}
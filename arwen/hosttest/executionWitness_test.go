@@ -0,0 +1,90 @@
+package hosttest
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
+	contextmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/context"
+	worldmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/world"
+	test "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/testcommon"
+	"github.com/ElrondNetwork/elrond-go/core/vmcommon"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestArwenWithExecutionWitness(t *testing.T, world *worldmock.MockWorld) (arwen.VMHost, *contextmock.InstanceBuilderMock) {
+	parameters := test.DefaultTestVMHostParameters()
+	parameters.ExecutionWitnessEnabled = true
+	host := test.DefaultTestArwenWithParameters(t, world, parameters)
+
+	instanceBuilderMock := contextmock.NewInstanceBuilderMock(world)
+	host.Runtime().ReplaceInstanceBuilder(instanceBuilderMock)
+
+	return host, instanceBuilderMock
+}
+
+func TestExecutionWitness_RecordsReadsBeforeExecution(t *testing.T) {
+	world := worldmock.NewMockWorld()
+	host, instanceBuilderMock := createTestArwenWithExecutionWitness(t, world)
+
+	parentInstance := instanceBuilderMock.CreateAndStoreInstanceMock(t, host, test.ParentAddress, 0, 1000)
+	parentInstance.AddMockMethod("readAndWrite", func() *contextmock.InstanceMock {
+		instance := contextmock.GetMockInstance(host)
+		storage := host.Storage()
+
+		storage.GetStorage([]byte("a"))
+		storage.GetStorageFromAddress(test.ChildAddress, []byte("b"))
+		_, _ = storage.SetStorage([]byte("a"), []byte("new"))
+		storage.GetStorage([]byte("a"))
+
+		return instance
+	})
+	world.AcctMap.GetAccount(test.ParentAddress).Storage["a"] = []byte("old")
+
+	input := test.CreateTestContractCallInputBuilder().
+		WithCallerAddr(test.UserAddress).
+		WithRecipientAddr(test.ParentAddress).
+		WithFunction("readAndWrite").
+		WithGasProvided(1000).
+		Build()
+
+	vmOutput, err := host.RunSmartContractCall(input)
+	require.Nil(t, err)
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+
+	witness := host.GetExecutionWitness()
+	require.NotNil(t, witness)
+
+	accounts := make(map[string]*arwen.WitnessAccount)
+	for _, account := range witness.Accounts {
+		accounts[string(account.Address)] = account
+	}
+
+	// the value of "a" is the one before the execution, even if it was read again after being written
+	parentAccount := accounts[string(test.ParentAddress)]
+	require.NotNil(t, parentAccount)
+	require.True(t, parentAccount.Exists)
+	require.Equal(t, big.NewInt(1000), parentAccount.Balance)
+	require.Equal(t, []arwen.WitnessStorageEntry{{Key: []byte("a"), Value: []byte("old")}}, parentAccount.Storage)
+
+	// the child does not exist, which is enough to know that its storage cannot be read
+	childAccount := accounts[string(test.ChildAddress)]
+	require.NotNil(t, childAccount)
+	require.False(t, childAccount.Exists)
+	require.Empty(t, childAccount.Storage)
+
+	// a new execution starts a new witness
+	input.Function = "missingFunction"
+	_, err = host.RunSmartContractCall(input)
+	require.Nil(t, err)
+
+	witness = host.GetExecutionWitness()
+	for _, account := range witness.Accounts {
+		require.Empty(t, account.Storage)
+	}
+}
+
+func TestExecutionWitness_Disabled(t *testing.T) {
+	host := test.DefaultTestArwen(t, worldmock.NewMockWorld())
+	require.Nil(t, host.GetExecutionWitness())
+}
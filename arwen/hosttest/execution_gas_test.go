@@ -549,6 +549,165 @@ func TestGasUsed_AsyncCall_CrossShard_InitCall(t *testing.T) {
 		})
 }
 
+func TestGasUsed_AsyncCall_ValueTransferWithoutData(t *testing.T) {
+	testConfig := asyncTestConfig
+	testConfig.GasProvided = 1000
+
+	gasUsedByParent := testConfig.GasUsedByParent
+	gasForAsyncCall := testConfig.GasProvided - gasUsedByParent - testConfig.GasLockCost
+
+	test.BuildMockInstanceCallTest(t).
+		WithContracts(
+			test.CreateMockContract(test.ParentAddress).
+				WithBalance(testConfig.ParentBalance).
+				WithConfig(testConfig).
+				WithMethods(contracts.PerformValueOnlyAsyncCallParentMock, contracts.CallBackParentMock),
+			test.CreateMockContract(test.ChildAddress).
+				WithBalance(testConfig.ChildBalance).
+				WithConfig(testConfig).
+				WithMethods(contracts.TransferToThirdPartyAsyncChildMock),
+		).
+		WithInput(test.CreateTestContractCallInputBuilder().
+			WithCallerAddr(test.UserAddress).
+			WithRecipientAddr(test.ParentAddress).
+			WithGasProvided(testConfig.GasProvided).
+			WithFunction("performValueOnlyAsyncCall").
+			Build()).
+		WithSetup(func(host arwen.VMHost, world *worldmock.MockWorld) {
+			setZeroCodeCosts(host)
+			setAsyncCosts(host, testConfig.GasLockCost)
+		}).
+		AndAssertResults(func(world *worldmock.MockWorld, verify *test.VMOutputVerifier) {
+			verify.
+				Ok().
+				GasUsed(test.ParentAddress, gasUsedByParent).
+				GasRemaining(0).
+				Transfers(
+					test.CreateTransferEntry(test.ParentAddress, test.ChildAddress).
+						WithGasLimit(gasForAsyncCall).
+						WithGasLocked(testConfig.GasLockCost).
+						WithCallType(vmcommon.AsynchronousCall).
+						WithValue(big.NewInt(testConfig.TransferFromParentToChild)),
+				)
+		})
+}
+
+func TestGasUsed_AsyncContextCall_CallbackGasEscrowed(t *testing.T) {
+	testConfig := asyncTestConfig
+	testConfig.GasProvided = 1000
+	gasForAsyncCall := testConfig.GasProvided - testConfig.GasUsedByParent - testConfig.GasLockCost
+
+	// the destination is not in this shard; the gas escrowed at registration
+	// is not available to the parent afterwards and leaves with the call
+	test.BuildMockInstanceCallTest(t).
+		WithContracts(
+			test.CreateMockContract(test.ParentAddress).
+				WithBalance(testConfig.ParentBalance).
+				WithConfig(testConfig).
+				WithMethods(contracts.CreateEscrowedAsyncCallParentMock, contracts.CallBackParentMock),
+		).
+		WithInput(test.CreateTestContractCallInputBuilder().
+			WithCallerAddr(test.UserAddress).
+			WithRecipientAddr(test.ParentAddress).
+			WithGasProvided(testConfig.GasProvided).
+			WithFunction("createEscrowedAsyncCall").
+			Build()).
+		WithSetup(func(host arwen.VMHost, world *worldmock.MockWorld) {
+			setZeroCodeCosts(host)
+			setAsyncCosts(host, testConfig.GasLockCost)
+		}).
+		AndAssertResults(func(world *worldmock.MockWorld, verify *test.VMOutputVerifier) {
+			verify.
+				Ok().
+				GasRemaining(0).
+				Transfers(
+					test.CreateTransferEntry(test.ParentAddress, test.ChildAddress).
+						WithData([]byte(contracts.AsyncChildFunction)).
+						WithGasLimit(gasForAsyncCall).
+						WithGasLocked(testConfig.GasLockCost).
+						WithCallType(vmcommon.AsynchronousCall).
+						WithValue(big.NewInt(testConfig.TransferFromParentToChild)),
+				)
+		})
+}
+
+func TestGasUsed_AsyncContextCall_CallbackGasEscrowed_BeforeEnableEpoch(t *testing.T) {
+	testConfig := asyncTestConfig
+	testConfig.GasProvided = 1000
+
+	parameters := test.DefaultTestVMHostParameters()
+	parameters.CallbackGasEscrowEnableEpoch = 1
+
+	test.BuildMockInstanceCallTest(t).
+		WithParameters(parameters).
+		WithContracts(
+			test.CreateMockContract(test.ParentAddress).
+				WithBalance(testConfig.ParentBalance).
+				WithConfig(testConfig).
+				WithMethods(contracts.CreateEscrowedAsyncCallParentMock, contracts.CallBackParentMock),
+		).
+		WithInput(test.CreateTestContractCallInputBuilder().
+			WithCallerAddr(test.UserAddress).
+			WithRecipientAddr(test.ParentAddress).
+			WithGasProvided(testConfig.GasProvided).
+			WithFunction("createEscrowedAsyncCall").
+			Build()).
+		WithSetup(func(host arwen.VMHost, world *worldmock.MockWorld) {
+			setZeroCodeCosts(host)
+			setAsyncCosts(host, testConfig.GasLockCost)
+		}).
+		AndAssertResults(func(world *worldmock.MockWorld, verify *test.VMOutputVerifier) {
+			// no gas is held for the callback, the call receives all the gas left
+			verify.
+				Ok().
+				GasRemaining(0).
+				Transfers(
+					test.CreateTransferEntry(test.ParentAddress, test.ChildAddress).
+						WithData([]byte(contracts.AsyncChildFunction)).
+						WithGasLimit(testConfig.GasProvided - testConfig.GasUsedByParent).
+						WithGasLocked(0).
+						WithCallType(vmcommon.AsynchronousCall).
+						WithValue(big.NewInt(testConfig.TransferFromParentToChild)),
+				)
+		})
+}
+
+func TestGasUsed_AsyncCall_ValueTransferWithoutData_BeforeEnableEpoch(t *testing.T) {
+	testConfig := asyncTestConfig
+	testConfig.GasProvided = 1000
+
+	parameters := test.DefaultTestVMHostParameters()
+	parameters.AsyncValueTransferEnableEpoch = 1
+
+	test.BuildMockInstanceCallTest(t).
+		WithParameters(parameters).
+		WithContracts(
+			test.CreateMockContract(test.ParentAddress).
+				WithBalance(testConfig.ParentBalance).
+				WithConfig(testConfig).
+				WithMethods(contracts.PerformValueOnlyAsyncCallParentMock, contracts.CallBackParentMock),
+			test.CreateMockContract(test.ChildAddress).
+				WithBalance(testConfig.ChildBalance).
+				WithConfig(testConfig).
+				WithMethods(contracts.TransferToThirdPartyAsyncChildMock),
+		).
+		WithInput(test.CreateTestContractCallInputBuilder().
+			WithCallerAddr(test.UserAddress).
+			WithRecipientAddr(test.ParentAddress).
+			WithGasProvided(testConfig.GasProvided).
+			WithFunction("performValueOnlyAsyncCall").
+			Build()).
+		WithSetup(func(host arwen.VMHost, world *worldmock.MockWorld) {
+			setZeroCodeCosts(host)
+			setAsyncCosts(host, testConfig.GasLockCost)
+		}).
+		AndAssertResults(func(world *worldmock.MockWorld, verify *test.VMOutputVerifier) {
+			verify.
+				ReturnCode(vmcommon.ExecutionFailed).
+				HasRuntimeErrors("tokenize failed")
+		})
+}
+
 func TestGasUsed_AsyncCall_CrossShard_ExecuteCall(t *testing.T) {
 	testConfig := asyncTestConfig
 	gasUsedByChild := testConfig.GasUsedByChild
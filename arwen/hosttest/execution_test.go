@@ -1540,6 +1540,7 @@ func TestExecution_ExecuteOnDestContext_GasRemaining(t *testing.T) {
 
 	_, _, metering, output, runtime, storage := host.GetContexts()
 	runtime.InitStateFromContractCallInput(input)
+	metering.InitStateFromContractCallInput(&input.VMInput)
 	output.AddTxValueToAccount(input.RecipientAddr, input.CallValue)
 	storage.SetAddress(runtime.GetSCAddress())
 	_ = metering.DeductInitialGasForExecution([]byte{})
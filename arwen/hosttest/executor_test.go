@@ -0,0 +1,65 @@
+package hosttest
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen/contexts"
+	arwenHost "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen/host"
+	contextmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/context"
+	worldmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/world"
+	test "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/testcommon"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestArwenWithExecutorFactory(world *worldmock.MockWorld, executorFactory arwen.ExecutorFactory) (arwen.VMHost, error) {
+	parameters := test.DefaultTestVMHostParameters()
+	parameters.ExecutorFactory = executorFactory
+	return arwenHost.NewArwenVM(world, parameters)
+}
+
+func TestExecutor_DefaultIsWasmer(t *testing.T) {
+	host, err := createTestArwenWithExecutorFactory(worldmock.NewMockWorld(), nil)
+	require.Nil(t, err)
+	require.Equal(t, contexts.WasmerExecutorName, host.Runtime().GetExecutorName())
+}
+
+func TestExecutor_PluggedInThroughHostParameters(t *testing.T) {
+	world := worldmock.NewMockWorld()
+	executor := contextmock.NewInstanceBuilderMock(world)
+
+	host, err := createTestArwenWithExecutorFactory(world, func() (arwen.Executor, error) {
+		return executor, nil
+	})
+	require.Nil(t, err)
+	require.Equal(t, contextmock.InstanceBuilderMockName, host.Runtime().GetExecutorName())
+
+	parentInstance := executor.CreateAndStoreInstanceMock(t, host, test.ParentAddress, 0, 1000)
+	parentInstance.AddMockMethod("answer", func() *contextmock.InstanceMock {
+		instance := contextmock.GetMockInstance(host)
+		host.Output().Finish(big.NewInt(42).Bytes())
+		return instance
+	})
+
+	input := test.CreateTestContractCallInputBuilder().
+		WithRecipientAddr(test.ParentAddress).
+		WithFunction("answer").
+		WithGasProvided(1000).
+		Build()
+
+	vmOutput, err := host.RunSmartContractCall(input)
+	verify := test.NewVMOutputVerifier(t, vmOutput, err)
+	verify.Ok().ReturnData(big.NewInt(42).Bytes())
+}
+
+func TestExecutor_FactoryErrorFailsHostCreation(t *testing.T) {
+	errExecutor := errors.New("executor unavailable")
+
+	host, err := createTestArwenWithExecutorFactory(worldmock.NewMockWorld(), func() (arwen.Executor, error) {
+		return nil, errExecutor
+	})
+	require.Nil(t, host)
+	require.Equal(t, errExecutor, err)
+}
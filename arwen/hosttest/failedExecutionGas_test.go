@@ -0,0 +1,111 @@
+package hosttest
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
+	contextmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/context"
+	worldmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/world"
+	test "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/testcommon"
+	"github.com/ElrondNetwork/elrond-go/core/vmcommon"
+	"github.com/stretchr/testify/require"
+)
+
+const failedExecutionGasActive = uint32(0)
+const failedExecutionGasInactive = uint32(math.MaxUint32)
+
+const failedExecutionGasProvided = uint64(1000)
+const failedExecutionGasUsedByParent = uint64(100)
+const failedExecutionGasProvidedToChild = uint64(300)
+const failedExecutionGasUsedByChild = uint64(50)
+
+// runFailedNestedExecution runs a parent which uses some gas, then calls a
+// child which uses some gas and fails, with the given execution function
+func runFailedNestedExecution(t *testing.T, enableEpoch uint32, execute func(host arwen.VMHost, input *vmcommon.ContractCallInput) error) *vmcommon.VMOutput {
+	world := worldmock.NewMockWorld()
+	parameters := test.DefaultTestVMHostParameters()
+	parameters.FailedExecutionGasEnableEpoch = enableEpoch
+	host := test.DefaultTestArwenWithParameters(t, world, parameters)
+
+	instanceBuilderMock := contextmock.NewInstanceBuilderMock(world)
+	host.Runtime().ReplaceInstanceBuilder(instanceBuilderMock)
+	setZeroCodeCosts(host)
+
+	parentInstance := instanceBuilderMock.CreateAndStoreInstanceMock(t, host, test.ParentAddress, 0, 1000)
+	parentInstance.AddMockMethod("callFailing", func() *contextmock.InstanceMock {
+		host.Metering().UseGas(failedExecutionGasUsedByParent)
+		childInput := test.CreateTestContractCallInputBuilder().
+			WithCallerAddr(test.ParentAddress).
+			WithRecipientAddr(test.ChildAddress).
+			WithFunction("fail").
+			WithGasProvided(failedExecutionGasProvidedToChild).
+			Build()
+		err := execute(host, childInput)
+		require.NotNil(t, err)
+		return contextmock.GetMockInstance(host)
+	})
+	childInstance := instanceBuilderMock.CreateAndStoreInstanceMock(t, host, test.ChildAddress, 0, 1000)
+	childInstance.AddMockMethod("fail", func() *contextmock.InstanceMock {
+		host.Metering().UseGas(failedExecutionGasUsedByChild)
+		host.Runtime().SignalUserError("child failed")
+		return contextmock.GetMockInstance(host)
+	})
+
+	input := test.CreateTestContractCallInputBuilder().
+		WithCallerAddr(test.UserAddress).
+		WithRecipientAddr(test.ParentAddress).
+		WithFunction("callFailing").
+		WithGasProvided(failedExecutionGasProvided).
+		Build()
+
+	vmOutput, err := host.RunSmartContractCall(input)
+	require.Nil(t, err)
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode, vmOutput.ReturnMessage)
+	return vmOutput
+}
+
+func executeOnDestContext(host arwen.VMHost, input *vmcommon.ContractCallInput) error {
+	_, _, err := host.ExecuteOnDestContext(input)
+	return err
+}
+
+func executeOnSameContext(host arwen.VMHost, input *vmcommon.ContractCallInput) error {
+	_, err := host.ExecuteOnSameContext(input)
+	return err
+}
+
+// withExcessiveValue makes the nested execution fail before the child runs,
+// on the transfer of a value the parent does not have
+func withExcessiveValue(execute func(host arwen.VMHost, input *vmcommon.ContractCallInput) error) func(host arwen.VMHost, input *vmcommon.ContractCallInput) error {
+	return func(host arwen.VMHost, input *vmcommon.ContractCallInput) error {
+		input.CallValue = big.NewInt(1000000)
+		return execute(host, input)
+	}
+}
+
+func TestFailedExecutionGas_ChildFails(t *testing.T) {
+	expectedGasRemaining := failedExecutionGasProvided - failedExecutionGasUsedByParent - failedExecutionGasProvidedToChild
+	for _, enableEpoch := range []uint32{failedExecutionGasActive, failedExecutionGasInactive} {
+		vmOutput := runFailedNestedExecution(t, enableEpoch, executeOnDestContext)
+		require.Equal(t, expectedGasRemaining, vmOutput.GasRemaining)
+
+		vmOutput = runFailedNestedExecution(t, enableEpoch, executeOnSameContext)
+		require.Equal(t, expectedGasRemaining, vmOutput.GasRemaining)
+	}
+}
+
+func TestFailedExecutionGas_FailureBeforeChildRuns(t *testing.T) {
+	vmOutput := runFailedNestedExecution(t, failedExecutionGasActive, withExcessiveValue(executeOnDestContext))
+	require.Equal(t, failedExecutionGasProvided-failedExecutionGasUsedByParent-failedExecutionGasProvidedToChild, vmOutput.GasRemaining)
+
+	vmOutput = runFailedNestedExecution(t, failedExecutionGasActive, withExcessiveValue(executeOnSameContext))
+	require.Equal(t, failedExecutionGasProvided-failedExecutionGasUsedByParent-failedExecutionGasProvidedToChild, vmOutput.GasRemaining)
+
+	vmOutput = runFailedNestedExecution(t, failedExecutionGasInactive, withExcessiveValue(executeOnDestContext))
+	require.Equal(t, failedExecutionGasProvided-failedExecutionGasUsedByParent, vmOutput.GasRemaining)
+
+	vmOutput = runFailedNestedExecution(t, failedExecutionGasInactive, withExcessiveValue(executeOnSameContext))
+	require.Equal(t, failedExecutionGasProvided-failedExecutionGasUsedByParent, vmOutput.GasRemaining)
+}
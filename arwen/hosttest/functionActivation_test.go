@@ -0,0 +1,87 @@
+package hosttest
+
+import (
+	"testing"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
+	contextmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/context"
+	test "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/testcommon"
+	"github.com/ElrondNetwork/elrond-go/core/vmcommon"
+	"github.com/stretchr/testify/require"
+)
+
+// the counter contract imports int64storageStore
+var counterActivationMap = arwen.FunctionActivationMap{"int64storageStore": 5}
+
+func deployCounterInEpoch(t *testing.T, epoch uint32) *test.VMOutputVerifier {
+	parameters := test.DefaultTestVMHostParameters()
+	parameters.FunctionActivationMap = counterActivationMap
+	return deployInEpoch(t, "counter", epoch, parameters)
+}
+
+func deployInEpoch(t *testing.T, contract string, epoch uint32, parameters *arwen.VMHostParameters) *test.VMOutputVerifier {
+	blockchainHook := &contextmock.BlockchainHookStub{}
+	blockchainHook.GetUserAccountCalled = func(address []byte) (vmcommon.UserAccountHandler, error) {
+		return &contextmock.StubAccount{Nonce: 24}, nil
+	}
+	blockchainHook.NewAddressCalled = func(creatorAddress []byte, nonce uint64, vmType []byte) ([]byte, error) {
+		return test.ParentAddress, nil
+	}
+	blockchainHook.CurrentEpochCalled = func() uint32 {
+		return epoch
+	}
+
+	host := test.DefaultTestArwenWithParameters(t, blockchainHook, parameters)
+	input := test.CreateTestContractCreateInputBuilder().
+		WithGasProvided(100000).
+		WithContractCode(test.GetTestSCCode(contract, "../../")).
+		Build()
+
+	vmOutput, err := host.RunSmartContractCreate(input)
+	return test.NewVMOutputVerifier(t, vmOutput, err)
+}
+
+func TestFunctionActivation_ImportNotActive(t *testing.T) {
+	deployCounterInEpoch(t, 4).
+		ReturnCode(vmcommon.ContractInvalid)
+}
+
+func TestFunctionActivation_ImportActive(t *testing.T) {
+	deployCounterInEpoch(t, 5).Ok()
+}
+
+func TestFunctionActivation_EEIExtensionNotActive(t *testing.T) {
+	// the managed-buffers contract imports mBufferAppend, among other EEI extensions
+	parameters := test.DefaultTestVMHostParameters()
+	parameters.EEIExtensionsEnableEpoch = 5
+
+	deployInEpoch(t, "managed-buffers", 4, parameters).
+		ReturnCode(vmcommon.ContractInvalid)
+
+	deployInEpoch(t, "managed-buffers", 5, parameters).Ok()
+}
+
+func TestFunctionActivation_CallBeforeActivation(t *testing.T) {
+	code := test.GetTestSCCode("counter", "../../")
+	host, blockchainHook := test.DefaultTestArwenForCall(t, code, nil)
+	host.Runtime().SetFunctionActivationMap(counterActivationMap)
+
+	input := test.CreateTestContractCallInputBuilder().
+		WithGasProvided(100000).
+		WithFunction("increment").
+		Build()
+
+	blockchainHook.CurrentEpochCalled = func() uint32 {
+		return 4
+	}
+	vmOutput, err := host.RunSmartContractCall(input)
+	test.NewVMOutputVerifier(t, vmOutput, err).
+		ReturnCode(vmcommon.ContractInvalid)
+
+	blockchainHook.CurrentEpochCalled = func() uint32 {
+		return 5
+	}
+	vmOutput, err = host.RunSmartContractCall(input)
+	require.Nil(t, err)
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+}
@@ -0,0 +1,138 @@
+package hosttest
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
+	contextmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/context"
+	test "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/testcommon"
+	"github.com/ElrondNetwork/elrond-go/core/vmcommon"
+	"github.com/stretchr/testify/require"
+)
+
+func runCounterIncrement(t *testing.T, modifier *arwen.GasModifier, gasProvided uint64) *vmcommon.VMOutput {
+	code := test.GetTestSCCode("counter", "../../")
+	host, stubBlockchainHook := test.DefaultTestArwenForCall(t, code, nil)
+	if modifier != nil {
+		stubBlockchainHook.GetGasModifierCalled = func(address []byte) (arwen.GasModifier, bool) {
+			return *modifier, bytes.Equal(address, test.ParentAddress)
+		}
+	}
+
+	input := test.DefaultTestContractCallInput()
+	input.GasProvided = gasProvided
+	input.Function = increment
+
+	vmOutput, err := host.RunSmartContractCall(input)
+	verify := test.NewVMOutputVerifier(t, vmOutput, err)
+	verify.Ok()
+
+	return vmOutput
+}
+
+func TestGasModifier_Discount(t *testing.T) {
+	gasProvided := uint64(1000000)
+	baseline := runCounterIncrement(t, nil, gasProvided)
+	baselineGasUsed := gasProvided - baseline.GasRemaining
+	require.Empty(t, baseline.Logs)
+
+	modifier := arwen.GasModifier{Numerator: 1, Denominator: 2}
+	vmOutput := runCounterIncrement(t, &modifier, gasProvided)
+
+	gasUsed := gasProvided - vmOutput.GasRemaining
+	require.Equal(t, baselineGasUsed/2, gasUsed)
+	require.Equal(t, gasUsed, vmOutput.OutputAccounts[string(test.ParentAddress)].GasUsed)
+
+	require.Len(t, vmOutput.Logs, 1)
+	require.Equal(t, modifier.LogEntry(test.ParentAddress, baselineGasUsed, gasUsed), vmOutput.Logs[0])
+	require.Equal(t, []byte(arwen.GasModifierLogIdentifier), vmOutput.Logs[0].Identifier)
+}
+
+func TestGasModifier_Premium(t *testing.T) {
+	gasProvided := uint64(1000000)
+	baseline := runCounterIncrement(t, nil, gasProvided)
+	baselineGasUsed := gasProvided - baseline.GasRemaining
+
+	modifier := arwen.GasModifier{Numerator: 3, Denominator: 2}
+	vmOutput := runCounterIncrement(t, &modifier, gasProvided)
+	require.Equal(t, baselineGasUsed*3/2, gasProvided-vmOutput.GasRemaining)
+
+	// the premium cannot exceed the gas remaining
+	modifier = arwen.GasModifier{Numerator: 1000000, Denominator: 1}
+	vmOutput = runCounterIncrement(t, &modifier, gasProvided)
+	require.Equal(t, uint64(0), vmOutput.GasRemaining)
+	require.Equal(t, gasProvided, vmOutput.OutputAccounts[string(test.ParentAddress)].GasUsed)
+}
+
+func TestGasModifier_InvalidModifierIgnored(t *testing.T) {
+	gasProvided := uint64(1000000)
+	baseline := runCounterIncrement(t, nil, gasProvided)
+
+	modifier := arwen.GasModifier{Numerator: 1, Denominator: 0}
+	vmOutput := runCounterIncrement(t, &modifier, gasProvided)
+	require.Equal(t, baseline.GasRemaining, vmOutput.GasRemaining)
+	require.Empty(t, vmOutput.Logs)
+}
+
+func TestGasModifier_NestedCall(t *testing.T) {
+	parentCode := test.GetTestSCCode("exec-dest-ctx-parent", "../../")
+	childCode := test.GetTestSCCode("exec-dest-ctx-child", "../../")
+
+	runParent := func(modifier *arwen.GasModifier) *vmcommon.VMOutput {
+		host, stubBlockchainHook := test.DefaultTestArwenForTwoSCs(t, parentCode, childCode, big.NewInt(1000), nil)
+		if modifier != nil {
+			stubBlockchainHook.GetGasModifierCalled = func(address []byte) (arwen.GasModifier, bool) {
+				return *modifier, bytes.Equal(address, test.ChildAddress)
+			}
+		}
+
+		input := test.DefaultTestContractCallInput()
+		input.RecipientAddr = test.ParentAddress
+		input.Function = "parentFunctionChildCall"
+		input.GasProvided = test.GasProvided
+
+		vmOutput, err := host.RunSmartContractCall(input)
+		verify := test.NewVMOutputVerifier(t, vmOutput, err)
+		verify.Ok()
+
+		return vmOutput
+	}
+
+	baseline := runParent(nil)
+	baselineChildGasUsed := baseline.OutputAccounts[string(test.ChildAddress)].GasUsed
+
+	modifier := arwen.GasModifier{Numerator: 1, Denominator: 4}
+	vmOutput := runParent(&modifier)
+
+	childGasUsed := vmOutput.OutputAccounts[string(test.ChildAddress)].GasUsed
+	require.Equal(t, baselineChildGasUsed/4, childGasUsed)
+	require.Equal(t,
+		baseline.OutputAccounts[string(test.ParentAddress)].GasUsed,
+		vmOutput.OutputAccounts[string(test.ParentAddress)].GasUsed,
+	)
+	require.Equal(t, baseline.GasRemaining+baselineChildGasUsed-childGasUsed, vmOutput.GasRemaining)
+
+	require.Len(t, vmOutput.Logs, len(baseline.Logs)+1)
+	require.Equal(t, modifier.LogEntry(test.ChildAddress, baselineChildGasUsed, childGasUsed), vmOutput.Logs[len(vmOutput.Logs)-1])
+}
+
+func TestGasModifier_WithExecutionWitness(t *testing.T) {
+	stubBlockchainHook := &contextmock.BlockchainHookStub{}
+	stubBlockchainHook.GetGasModifierCalled = func(address []byte) (arwen.GasModifier, bool) {
+		return arwen.GasModifier{Numerator: 1, Denominator: 2}, bytes.Equal(address, test.ParentAddress)
+	}
+
+	parameters := test.DefaultTestVMHostParameters()
+	parameters.ExecutionWitnessEnabled = true
+	host := test.DefaultTestArwenWithParameters(t, stubBlockchainHook, parameters)
+
+	// the witness recorder decorates the BlockchainHook, and must not hide its gas modifiers
+	modifier, ok := host.Blockchain().GetGasModifier(test.ParentAddress)
+	require.True(t, ok)
+	require.Equal(t, arwen.GasModifier{Numerator: 1, Denominator: 2}, modifier)
+
+	_, ok = host.Blockchain().GetGasModifier(test.ChildAddress)
+	require.False(t, ok)
+}
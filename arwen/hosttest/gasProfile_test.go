@@ -0,0 +1,90 @@
+package hosttest
+
+import (
+	"testing"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
+	contextmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/context"
+	test "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/testcommon"
+	"github.com/ElrondNetwork/elrond-go/core/vmcommon"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestArwenWithGasProfiling(t *testing.T, code []byte) arwen.VMHost {
+	blockchainHook := &contextmock.BlockchainHookStub{}
+	blockchainHook.GetUserAccountCalled = func(address []byte) (vmcommon.UserAccountHandler, error) {
+		return &contextmock.StubAccount{}, nil
+	}
+	blockchainHook.GetCodeCalled = func(account vmcommon.UserAccountHandler) []byte {
+		return code
+	}
+
+	parameters := test.DefaultTestVMHostParameters()
+	parameters.GasProfilingEnabled = true
+	host := test.DefaultTestArwenWithParameters(t, blockchainHook, parameters)
+
+	return host
+}
+
+func TestGasProfile_DisabledByDefault(t *testing.T) {
+	code := test.GetTestSCCode("counter", "../../")
+	host, _ := test.DefaultTestArwenForCall(t, code, nil)
+
+	input := test.DefaultTestContractCallInput()
+	input.GasProvided = 1000000
+	input.Function = get
+
+	_, err := host.RunSmartContractCall(input)
+	require.Nil(t, err)
+	require.Nil(t, host.GetGasProfile())
+}
+
+func TestGasProfile_AccountsForAllGasUsed(t *testing.T) {
+	code := test.GetTestSCCode("counter", "../../")
+	host := createTestArwenWithGasProfiling(t, code)
+
+	input := test.DefaultTestContractCallInput()
+	input.GasProvided = 1000000
+	input.Function = increment
+
+	vmOutput, err := host.RunSmartContractCall(input)
+	verify := test.NewVMOutputVerifier(t, vmOutput, err)
+	verify.Ok()
+
+	profile := host.GetGasProfile()
+	require.NotNil(t, profile)
+	require.Equal(t, input.GasProvided-vmOutput.GasRemaining, profile.GasUsed)
+	require.Equal(t, host.Metering().GetSCPrepareInitialCost(), profile.InitialCost)
+	require.Greater(t, profile.WASMOpcodes, uint64(0))
+
+	hostFunctions := make(map[string]uint64)
+	for _, hostFunction := range profile.HostFunctions {
+		hostFunctions[hostFunction.Name] = hostFunction.Gas
+	}
+	gasSchedule := host.Metering().GasSchedule()
+	require.Equal(t, gasSchedule.ElrondAPICost.Int64StorageLoad, hostFunctions["int64storageLoad"])
+	require.Contains(t, hostFunctions, "int64storageStore")
+
+	gasAccounted := profile.InitialCost + profile.BuiltinFunctions + profile.HostFunctionsGas() + profile.WASMOpcodes
+	require.Equal(t, profile.GasUsed, gasAccounted)
+}
+
+func TestGasProfile_ResetBetweenExecutions(t *testing.T) {
+	code := test.GetTestSCCode("counter", "../../")
+	host := createTestArwenWithGasProfiling(t, code)
+
+	input := test.DefaultTestContractCallInput()
+	input.GasProvided = 1000000
+	input.Function = increment
+
+	_, err := host.RunSmartContractCall(input)
+	require.Nil(t, err)
+	firstProfile := host.GetGasProfile()
+
+	_, err = host.RunSmartContractCall(input)
+	require.Nil(t, err)
+	secondProfile := host.GetGasProfile()
+
+	require.Equal(t, firstProfile.HostFunctionsGas(), secondProfile.HostFunctionsGas())
+	require.Equal(t, firstProfile.InitialCost, secondProfile.InitialCost)
+}
@@ -0,0 +1,65 @@
+package hosttest
+
+import (
+	"testing"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/config"
+	test "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/testcommon"
+	"github.com/stretchr/testify/require"
+)
+
+func makeGasMapWithOpcodeCost(opcodeCost uint64) config.GasScheduleMap {
+	gasMap := config.MakeGasMapForTests()
+	for opcode := range gasMap["WASMOpcodeCost"] {
+		gasMap["WASMOpcodeCost"][opcode] = opcodeCost
+	}
+	return gasMap
+}
+
+func TestGasSchedule_SetGasSchedule_AppliesToNextExecution(t *testing.T) {
+	code := test.GetTestSCCode("counter", "../../")
+	host, _ := test.DefaultTestArwenForCall(t, code, nil)
+	defer func() {
+		err := host.SetGasSchedule(config.MakeGasMapForTests())
+		require.Nil(t, err)
+	}()
+
+	input := test.DefaultTestContractCallInput()
+	input.GasProvided = 1000000
+	input.Function = get
+
+	vmOutput, err := host.RunSmartContractCall(input)
+	verify := test.NewVMOutputVerifier(t, vmOutput, err)
+	verify.Ok()
+	gasUsedBefore := input.GasProvided - vmOutput.GasRemaining
+
+	newGasMap := makeGasMapWithOpcodeCost(2 * config.GasValueForTests)
+	newGasMap["ElrondAPICost"]["StorageLoad"] = 2 * config.GasValueForTests
+	err = host.SetGasSchedule(newGasMap)
+	require.Nil(t, err)
+	require.Equal(t, newGasMap, host.GetGasScheduleMap())
+	require.Equal(t, uint64(2*config.GasValueForTests), host.Metering().GasSchedule().ElrondAPICost.StorageLoad)
+
+	vmOutput, err = host.RunSmartContractCall(input)
+	verify = test.NewVMOutputVerifier(t, vmOutput, err)
+	verify.Ok()
+	gasUsedAfter := input.GasProvided - vmOutput.GasRemaining
+
+	require.Greater(t, gasUsedAfter, gasUsedBefore)
+}
+
+func TestGasSchedule_SetGasSchedule_InvalidKeepsCurrent(t *testing.T) {
+	code := test.GetTestSCCode("counter", "../../")
+	host, _ := test.DefaultTestArwenForCall(t, code, nil)
+
+	currentGasMap := host.GetGasScheduleMap()
+	currentGasSchedule := host.Metering().GasSchedule()
+
+	invalidGasMap := config.MakeGasMapForTests()
+	invalidGasMap["ElrondAPICost"]["StorageLoad"] = 0
+	err := host.SetGasSchedule(invalidGasMap)
+	require.NotNil(t, err)
+
+	require.Equal(t, currentGasMap, host.GetGasScheduleMap())
+	require.Equal(t, currentGasSchedule, host.Metering().GasSchedule())
+}
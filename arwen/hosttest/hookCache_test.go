@@ -0,0 +1,81 @@
+package hosttest
+
+import (
+	"testing"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
+	contextmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/context"
+	worldmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/world"
+	test "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/testcommon"
+	"github.com/ElrondNetwork/elrond-go/core/vmcommon"
+	"github.com/stretchr/testify/require"
+)
+
+// countingBlockchainHook counts the accounts read from the decorated BlockchainHook
+type countingBlockchainHook struct {
+	vmcommon.BlockchainHook
+	numAccountReads int
+}
+
+func (hook *countingBlockchainHook) GetUserAccount(address []byte) (vmcommon.UserAccountHandler, error) {
+	hook.numAccountReads++
+	return hook.BlockchainHook.GetUserAccount(address)
+}
+
+// runHookCacheTest executes a contract which queries the same accounts
+// repeatedly, and returns the number of accounts read from the node
+func runHookCacheTest(t *testing.T, hookCacheEnabled bool) (int, arwen.VMHost) {
+	world := worldmock.NewMockWorld()
+	hook := &countingBlockchainHook{BlockchainHook: world}
+	parameters := test.DefaultTestVMHostParameters()
+	parameters.HookCacheEnabled = hookCacheEnabled
+	host := test.DefaultTestArwenWithParameters(t, hook, parameters)
+
+	instanceBuilderMock := contextmock.NewInstanceBuilderMock(world)
+	host.Runtime().ReplaceInstanceBuilder(instanceBuilderMock)
+
+	instanceBuilderMock.CreateAndStoreInstanceMock(t, host, test.ChildAddress, 0, 1000)
+	parentInstance := instanceBuilderMock.CreateAndStoreInstanceMock(t, host, test.ParentAddress, 0, 1000)
+	parentInstance.AddMockMethod("queryAccounts", func() *contextmock.InstanceMock {
+		instance := contextmock.GetMockInstance(host)
+		blockchain := host.Blockchain()
+		for i := 0; i < 5; i++ {
+			blockchain.GetCodeHash(test.ChildAddress)
+			require.True(t, blockchain.AccountExists(test.ChildAddress))
+			require.True(t, blockchain.IsSmartContract(test.ChildAddress))
+			blockchain.GetShardOfAddress(test.ChildAddress)
+		}
+		return instance
+	})
+
+	input := test.CreateTestContractCallInputBuilder().
+		WithCallerAddr(test.UserAddress).
+		WithRecipientAddr(test.ParentAddress).
+		WithFunction("queryAccounts").
+		WithGasProvided(100000).
+		Build()
+
+	hook.numAccountReads = 0
+	vmOutput, err := host.RunSmartContractCall(input)
+	verify := test.NewVMOutputVerifier(t, vmOutput, err)
+	verify.Ok()
+
+	return hook.numAccountReads, host
+}
+
+func TestHookCache_Disabled(t *testing.T) {
+	numAccountReads, host := runHookCacheTest(t, false)
+	require.Nil(t, host.GetHookCacheStats())
+	require.GreaterOrEqual(t, numAccountReads, 10)
+}
+
+func TestHookCache_Enabled(t *testing.T) {
+	numUncachedReads, _ := runHookCacheTest(t, false)
+	numAccountReads, host := runHookCacheTest(t, true)
+	require.Less(t, numAccountReads, numUncachedReads)
+
+	stats := host.GetHookCacheStats()
+	require.NotNil(t, stats)
+	require.Greater(t, stats.Hits, stats.Misses)
+	require.Greater(t, stats.HitRatio(), 0.5)
+}
@@ -0,0 +1,133 @@
+package hosttest
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
+	contextmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/context"
+	worldmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/world"
+	test "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/testcommon"
+	"github.com/ElrondNetwork/elrond-go/core/vmcommon"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestArwenWithMemoryLimits(t *testing.T, world *worldmock.MockWorld, limits arwen.InstanceMemoryLimits) (arwen.VMHost, *contextmock.InstanceBuilderMock) {
+	parameters := test.DefaultTestVMHostParameters()
+	parameters.InitialMemoryPages = limits.InitialMemoryPages
+	parameters.MaxMemoryPages = limits.MaxMemoryPages
+	parameters.MaxMemoryGrow = limits.MaxMemoryGrow
+	host := test.DefaultTestArwenWithParameters(t, world, parameters)
+
+	instanceBuilderMock := contextmock.NewInstanceBuilderMock(world)
+	host.Runtime().ReplaceInstanceBuilder(instanceBuilderMock)
+
+	return host, instanceBuilderMock
+}
+
+func addMemoryGrowingMethod(host arwen.VMHost, instance *contextmock.InstanceMock, name string, pages uint32) {
+	instance.AddMockMethod(name, func() *contextmock.InstanceMock {
+		instance := contextmock.GetMockInstance(host)
+		_ = instance.GetMemory().Grow(pages)
+		return instance
+	})
+}
+
+func TestInstanceMemoryLimits_Config(t *testing.T) {
+	limits := arwen.InstanceMemoryLimits{
+		InitialMemoryPages: 4,
+		MaxMemoryPages:     10,
+		MaxMemoryGrow:      2,
+	}
+	host, _ := createTestArwenWithMemoryLimits(t, worldmock.NewMockWorld(), limits)
+
+	require.Equal(t, limits, host.GetRuntimeConfig().MemoryLimits)
+}
+
+func TestInstanceMemoryLimits_GrowWithinLimits(t *testing.T) {
+	world := worldmock.NewMockWorld()
+	host, instanceBuilderMock := createTestArwenWithMemoryLimits(t, world, arwen.InstanceMemoryLimits{
+		InitialMemoryPages: 4,
+		MaxMemoryPages:     10,
+		MaxMemoryGrow:      2,
+	})
+
+	instance := instanceBuilderMock.CreateAndStoreInstanceMock(t, host, test.ParentAddress, 0, 1000)
+	addMemoryGrowingMethod(host, instance, "grow", 2)
+
+	input := test.CreateTestContractCallInputBuilder().
+		WithCallerAddr(test.UserAddress).
+		WithRecipientAddr(test.ParentAddress).
+		WithFunction("grow").
+		WithGasProvided(1000).
+		Build()
+
+	vmOutput, err := host.RunSmartContractCall(input)
+	require.Nil(t, err)
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+
+	// the instance was grown to the initial pages before the call
+	require.Equal(t, uint32(6*arwen.WasmPageSize), instance.GetMemory().Length())
+}
+
+func TestInstanceMemoryLimits_GrowPastLimits(t *testing.T) {
+	world := worldmock.NewMockWorld()
+	host, instanceBuilderMock := createTestArwenWithMemoryLimits(t, world, arwen.InstanceMemoryLimits{
+		MaxMemoryPages: 6,
+		MaxMemoryGrow:  2,
+	})
+
+	instance := instanceBuilderMock.CreateAndStoreInstanceMock(t, host, test.ParentAddress, 0, 1000)
+	addMemoryGrowingMethod(host, instance, "growTooMuch", 3)
+	addMemoryGrowingMethod(host, instance, "grow", 2)
+
+	input := test.CreateTestContractCallInputBuilder().
+		WithCallerAddr(test.UserAddress).
+		WithRecipientAddr(test.ParentAddress).
+		WithFunction("growTooMuch").
+		WithGasProvided(1000).
+		Build()
+
+	vmOutput, err := host.RunSmartContractCall(input)
+	require.Nil(t, err)
+	require.Equal(t, vmcommon.ExecutionFailed, vmOutput.ReturnCode)
+	require.Equal(t, arwen.ErrMemoryGrowLimitExceeded.Error(), vmOutput.ReturnMessage)
+
+	// the memory now holds 5 pages, which may grow by 2 pages, but not past 6 pages
+	input.Function = "grow"
+	vmOutput, err = host.RunSmartContractCall(input)
+	require.Nil(t, err)
+	require.Equal(t, vmcommon.ExecutionFailed, vmOutput.ReturnCode)
+	require.Equal(t, arwen.ErrMemoryPagesLimitExceeded.Error(), vmOutput.ReturnMessage)
+}
+
+func runMemoryGrow(t *testing.T, limits arwen.InstanceMemoryLimits, pages int64) *vmcommon.VMOutput {
+	host, _ := test.DefaultTestArwenForCall(t, test.GetTestSCCode("memory-grow", "../../"), nil)
+	host.Runtime().SetInstanceMemoryLimits(limits)
+
+	input := test.DefaultTestContractCallInput()
+	input.Function = "grow"
+	input.Arguments = [][]byte{big.NewInt(pages).Bytes()}
+	input.GasProvided = 100000
+
+	vmOutput, err := host.RunSmartContractCall(input)
+	require.Nil(t, err)
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+
+	return vmOutput
+}
+
+func TestInstanceMemoryLimits_MemoryGrowFailsPastMaxPages(t *testing.T) {
+	limits := arwen.InstanceMemoryLimits{MaxMemoryPages: 3}
+
+	// memory.grow returns the previous number of pages, 1, when it succeeds
+	vmOutput := runMemoryGrow(t, limits, 2)
+	require.Equal(t, [][]byte{{1}}, vmOutput.ReturnData)
+
+	// and -1 when the memory would exceed the limit, leaving the contract to handle it
+	vmOutput = runMemoryGrow(t, limits, 3)
+	require.Equal(t, [][]byte{{0xff}}, vmOutput.ReturnData)
+
+	vmOutput = runMemoryGrow(t, arwen.InstanceMemoryLimits{}, 3)
+	require.Equal(t, [][]byte{{1}}, vmOutput.ReturnData)
+}
@@ -0,0 +1,129 @@
+package hosttest
+
+import (
+	"testing"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
+	contextmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/context"
+	test "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/testcommon"
+	"github.com/ElrondNetwork/elrond-go/core/vmcommon"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestArwenWithInstancePool(t *testing.T, code []byte) (arwen.VMHost, *contextmock.InstanceBuilderRecorderMock) {
+	blockchainHook := &contextmock.BlockchainHookStub{}
+	blockchainHook.GetUserAccountCalled = func(address []byte) (vmcommon.UserAccountHandler, error) {
+		return &contextmock.StubAccount{CodeHash: []byte("counterCodeHash")}, nil
+	}
+	blockchainHook.GetCodeCalled = func(account vmcommon.UserAccountHandler) []byte {
+		return code
+	}
+
+	parameters := test.DefaultTestVMHostParameters()
+	parameters.InstancePoolSize = 1
+	host := test.DefaultTestArwenWithParameters(t, blockchainHook, parameters)
+
+	instanceRecorder := contextmock.NewInstanceBuilderRecorderMock()
+	host.Runtime().ReplaceInstanceBuilder(instanceRecorder)
+
+	return host, instanceRecorder
+}
+
+func TestInstancePool_ReusesInstanceOfSameCode(t *testing.T) {
+	code := test.GetTestSCCode("storage-counter", "../../")
+	host, instanceRecorder := createTestArwenWithInstancePool(t, code)
+	defer host.Runtime().ResetWarmInstance()
+
+	input := test.DefaultTestContractCallInput()
+	input.GasProvided = 1000000
+	input.Function = increment
+
+	firstOutput, err := host.RunSmartContractCall(input)
+	verify := test.NewVMOutputVerifier(t, firstOutput, err)
+	verify.Ok()
+
+	input.RecipientAddr = test.ChildAddress
+	secondOutput, err := host.RunSmartContractCall(input)
+	verify = test.NewVMOutputVerifier(t, secondOutput, err)
+	verify.Ok()
+
+	require.Len(t, instanceRecorder.GetContractInstances(code), 1)
+	require.Equal(t, firstOutput.GasRemaining, secondOutput.GasRemaining)
+	require.Equal(t, firstOutput.ReturnData, secondOutput.ReturnData)
+}
+
+func TestInstancePool_CodeWithMutableGlobalsNotReused(t *testing.T) {
+	code := test.GetTestSCCode("global-counter", "../../")
+	host, instanceRecorder := createTestArwenWithInstancePool(t, code)
+	defer host.Runtime().ResetWarmInstance()
+
+	input := test.DefaultTestContractCallInput()
+	input.GasProvided = 1000000
+	input.Function = increment
+
+	firstOutput, err := host.RunSmartContractCall(input)
+	verify := test.NewVMOutputVerifier(t, firstOutput, err)
+	verify.Ok().ReturnData([]byte{1})
+
+	input.RecipientAddr = test.ChildAddress
+	secondOutput, err := host.RunSmartContractCall(input)
+	verify = test.NewVMOutputVerifier(t, secondOutput, err)
+	verify.Ok().ReturnData([]byte{1})
+
+	require.Len(t, instanceRecorder.GetContractInstances(code), 2)
+}
+
+func TestInstancePool_NotReusedWithOtherCompilationSettings(t *testing.T) {
+	code := test.GetTestSCCode("storage-counter", "../../")
+	host, instanceRecorder := createTestArwenWithInstancePool(t, code)
+	defer host.Runtime().ResetWarmInstance()
+
+	input := test.DefaultTestContractCallInput()
+	input.GasProvided = 1000000
+	input.Function = increment
+
+	vmOutput, err := host.RunSmartContractCall(input)
+	verify := test.NewVMOutputVerifier(t, vmOutput, err)
+	verify.Ok()
+
+	// the pooled instance was created for another maximum memory
+	host.Runtime().SetInstanceMemoryLimits(arwen.InstanceMemoryLimits{MaxMemoryPages: 20})
+
+	input.RecipientAddr = test.ChildAddress
+	vmOutput, err = host.RunSmartContractCall(input)
+	verify = test.NewVMOutputVerifier(t, vmOutput, err)
+	verify.Ok()
+
+	// the code of the second instance was capped to the maximum memory
+	require.Len(t, instanceRecorder.GetContractInstances(code), 1)
+	require.Len(t, instanceRecorder.InstanceMap, 2)
+}
+
+func TestInstancePool_ClearedWithWarmInstance(t *testing.T) {
+	code := test.GetTestSCCode("storage-counter", "../../")
+	host, instanceRecorder := createTestArwenWithInstancePool(t, code)
+
+	memory := host.Runtime().MemoryAccountant()
+	instancesMemoryBefore := memory.InUseBy(arwen.MemoryWasmerInstances)
+
+	input := test.DefaultTestContractCallInput()
+	input.GasProvided = 1000000
+	input.Function = increment
+
+	vmOutput, err := host.RunSmartContractCall(input)
+	verify := test.NewVMOutputVerifier(t, vmOutput, err)
+	verify.Ok()
+	require.Greater(t, memory.InUseBy(arwen.MemoryWasmerInstances), instancesMemoryBefore)
+	require.Greater(t, memory.InUseBy(arwen.MemoryInstancePool), uint64(0))
+
+	host.Runtime().ResetWarmInstance()
+	require.Equal(t, instancesMemoryBefore, memory.InUseBy(arwen.MemoryWasmerInstances))
+	require.Zero(t, memory.InUseBy(arwen.MemoryInstancePool))
+
+	vmOutput, err = host.RunSmartContractCall(input)
+	verify = test.NewVMOutputVerifier(t, vmOutput, err)
+	verify.Ok()
+	require.Len(t, instanceRecorder.GetContractInstances(code), 2)
+
+	host.Runtime().ResetWarmInstance()
+}
@@ -0,0 +1,126 @@
+package hosttest
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/config"
+	contextmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/context"
+	worldmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/world"
+	test "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/testcommon"
+	"github.com/ElrondNetwork/elrond-go/core/vmcommon"
+	"github.com/stretchr/testify/require"
+)
+
+// TestManagedTypes_NestedExecutions checks that a big float of the parent is
+// hidden from a child on the destination context, and shared with a child on
+// the same context, whose changes are kept only if it succeeds
+func TestManagedTypes_NestedExecutions(t *testing.T) {
+	world := worldmock.NewMockWorld()
+	parameters := test.DefaultTestVMHostParameters()
+	host := test.DefaultTestArwenWithParameters(t, world, parameters)
+
+	instanceBuilderMock := contextmock.NewInstanceBuilderMock(world)
+	host.Runtime().ReplaceInstanceBuilder(instanceBuilderMock)
+
+	handle := int32(0)
+	getValue := func() *big.Float {
+		value, err := host.Runtime().ManagedTypes().GetBigFloat(handle)
+		require.Nil(t, err)
+		return value
+	}
+	childInput := func(function string) *vmcommon.ContractCallInput {
+		return test.CreateTestContractCallInputBuilder().
+			WithCallerAddr(test.ParentAddress).
+			WithRecipientAddr(test.ChildAddress).
+			WithFunction(function).
+			WithGasProvided(2000).
+			Build()
+	}
+
+	parentInstance := instanceBuilderMock.CreateAndStoreInstanceMock(t, host, test.ParentAddress, 0, 1000)
+	parentInstance.AddMockMethod("callChildren", func() *contextmock.InstanceMock {
+		instance := contextmock.GetMockInstance(host)
+		handle = host.Runtime().ManagedTypes().PutBigFloat(big.NewFloat(1.5))
+
+		_, _, err := host.ExecuteOnDestContext(childInput("hidden"))
+		require.Nil(t, err)
+		require.Equal(t, 0, getValue().Cmp(big.NewFloat(1.5)))
+
+		_, err = host.ExecuteOnSameContext(childInput("double"))
+		require.Nil(t, err)
+		require.Equal(t, 0, getValue().Cmp(big.NewFloat(3)))
+
+		_, err = host.ExecuteOnSameContext(childInput("doubleAndFail"))
+		require.NotNil(t, err)
+		require.Equal(t, 0, getValue().Cmp(big.NewFloat(3)))
+
+		return instance
+	})
+
+	childInstance := instanceBuilderMock.CreateAndStoreInstanceMock(t, host, test.ChildAddress, 0, 1000)
+	childInstance.AddMockMethod("hidden", func() *contextmock.InstanceMock {
+		instance := contextmock.GetMockInstance(host)
+		_, err := host.Runtime().ManagedTypes().GetBigFloat(handle)
+		require.Equal(t, arwen.ErrNoBigFloatUnderThisHandle, err)
+		host.Runtime().ManagedTypes().PutBigFloat(big.NewFloat(100))
+		return instance
+	})
+	childInstance.AddMockMethod("double", func() *contextmock.InstanceMock {
+		instance := contextmock.GetMockInstance(host)
+		value := getValue()
+		value.Add(value, value)
+		return instance
+	})
+	childInstance.AddMockMethod("doubleAndFail", func() *contextmock.InstanceMock {
+		instance := contextmock.GetMockInstance(host)
+		value := getValue()
+		value.Add(value, value)
+		host.Runtime().FailExecution(errChildFailed)
+		return instance
+	})
+
+	input := test.CreateTestContractCallInputBuilder().
+		WithCallerAddr(test.UserAddress).
+		WithRecipientAddr(test.ParentAddress).
+		WithFunction("callChildren").
+		WithGasProvided(10000).
+		Build()
+
+	vmOutput, err := host.RunSmartContractCall(input)
+	test.NewVMOutputVerifier(t, vmOutput, err).Ok()
+}
+
+func runManagedBufferFunction(t *testing.T, function string, numBytes int64) uint64 {
+	host, _ := test.DefaultTestArwenForCall(t, test.GetTestSCCode("managed-buffers", "../../"), nil)
+
+	gasMap := config.MakeGasMapForTests()
+	gasMap["BaseOperationCost"]["DataCopyPerByte"] = 3
+	host.SetGasSchedule(gasMap)
+
+	input := test.DefaultTestContractCallInput()
+	input.Function = function
+	input.Arguments = [][]byte{big.NewInt(numBytes).Bytes()}
+	input.GasProvided = 100000
+
+	vmOutput, err := host.RunSmartContractCall(input)
+	test.NewVMOutputVerifier(t, vmOutput, err).Ok()
+
+	return input.GasProvided - vmOutput.GasRemaining
+}
+
+func TestManagedTypes_ManagedBufferBytesCharged(t *testing.T) {
+	for _, function := range []string{"copySlice", "append", "compareSlice"} {
+		gasUsedForShort := runManagedBufferFunction(t, function, 10)
+		gasUsedForLong := runManagedBufferFunction(t, function, 20)
+
+		// copySlice copies the slice once; append copies the data into a new
+		// buffer and then onto the accumulator; compareSlice reads both slices
+		bytesPerUnit := uint64(2)
+		if function == "copySlice" {
+			bytesPerUnit = 1
+		}
+		require.Equal(t, 10*bytesPerUnit*3, gasUsedForLong-gasUsedForShort, function)
+	}
+}
@@ -0,0 +1,124 @@
+package hosttest
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
+	contextmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/context"
+	test "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/testcommon"
+	"github.com/ElrondNetwork/elrond-go/core/vmcommon"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestArwenWithWarmInstance(t *testing.T, code []byte) arwen.VMHost {
+	blockchainHook := &contextmock.BlockchainHookStub{}
+	blockchainHook.GetUserAccountCalled = func(address []byte) (vmcommon.UserAccountHandler, error) {
+		return &contextmock.StubAccount{}, nil
+	}
+	blockchainHook.GetCodeCalled = func(account vmcommon.UserAccountHandler) []byte {
+		return code
+	}
+
+	parameters := test.DefaultTestVMHostParameters()
+	parameters.UseWarmInstance = true
+	host := test.DefaultTestArwenWithParameters(t, blockchainHook, parameters)
+
+	return host
+}
+
+func TestMemoryLimits_RejectsNewInstanceNearCeiling(t *testing.T) {
+	code := test.GetTestSCCode("counter", "../../")
+	host, _ := test.DefaultTestArwenForCall(t, code, nil)
+
+	memory := host.Runtime().MemoryAccountant()
+	memory.Reserve(arwen.MemoryWasmerInstances, 1000)
+	memory.SetCeiling(memory.InUse())
+
+	input := test.DefaultTestContractCallInput()
+	input.GasProvided = 1000000
+	input.Function = get
+
+	vmOutput, err := host.RunSmartContractCall(input)
+	require.Nil(t, vmOutput)
+	require.Equal(t, arwen.ErrMemoryLimitReached, err)
+
+	memory.SetCeiling(0)
+	vmOutput, err = host.RunSmartContractCall(input)
+	verify := test.NewVMOutputVerifier(t, vmOutput, err)
+	verify.Ok()
+}
+
+func TestMemoryLimits_EvictsWarmInstanceNearCeiling(t *testing.T) {
+	code := test.GetTestSCCode("counter", "../../")
+	host := createTestArwenWithWarmInstance(t, code)
+	defer host.Runtime().ResetWarmInstance()
+
+	memory := host.Runtime().MemoryAccountant()
+	instancesMemoryBefore := memory.InUseBy(arwen.MemoryWasmerInstances)
+
+	input := test.DefaultTestContractCallInput()
+	input.GasProvided = 1000000
+	input.Function = get
+
+	vmOutput, err := host.RunSmartContractCall(input)
+	verify := test.NewVMOutputVerifier(t, vmOutput, err)
+	verify.Ok()
+
+	warmInstanceMemory := memory.InUseBy(arwen.MemoryWasmerInstances) - instancesMemoryBefore
+	require.Greater(t, warmInstanceMemory, uint64(0))
+
+	// the ceiling is reached only while the warm instance is kept
+	threshold := memory.InUse() - warmInstanceMemory/2
+	memory.SetCeiling((threshold/arwen.MemoryEvictionPercent + 1) * 100)
+	require.True(t, memory.IsNearCeiling())
+
+	input.RecipientAddr = test.ChildAddress
+	vmOutput, err = host.RunSmartContractCall(input)
+	verify = test.NewVMOutputVerifier(t, vmOutput, err)
+	verify.Ok()
+
+	// only the new warm instance is kept
+	require.Equal(t, warmInstanceMemory, memory.InUseBy(arwen.MemoryWasmerInstances)-instancesMemoryBefore)
+}
+
+func TestMemoryLimits_AccountsMemoryGrowth(t *testing.T) {
+	host := createTestArwenWithWarmInstance(t, test.GetTestSCCode("memory-grow", "../../"))
+	defer host.Runtime().ResetWarmInstance()
+	memory := host.Runtime().MemoryAccountant()
+
+	input := test.DefaultTestContractCallInput()
+	input.GasProvided = 100000
+	input.Function = "grow"
+	input.Arguments = [][]byte{big.NewInt(2).Bytes()}
+
+	vmOutput, err := host.RunSmartContractCall(input)
+	verify := test.NewVMOutputVerifier(t, vmOutput, err)
+	verify.Ok()
+
+	// the warm instance is kept with the memory it grew to, along with its compiled code
+	require.Equal(t, uint64(3*arwen.WasmPageSize), memory.InUseBy(arwen.MemoryWasmerInstances))
+	require.Greater(t, memory.InUseBy(arwen.MemoryCompiledCode), uint64(0))
+
+	host.Runtime().ResetWarmInstance()
+	require.Zero(t, memory.InUseBy(arwen.MemoryWasmerInstances))
+	require.Zero(t, memory.InUseBy(arwen.MemoryCompiledCode))
+}
+
+func TestMemoryLimits_AccountedPerHost(t *testing.T) {
+	code := test.GetTestSCCode("counter", "../../")
+	host := createTestArwenWithWarmInstance(t, code)
+	defer host.Runtime().ResetWarmInstance()
+	otherHost, _ := test.DefaultTestArwenForCall(t, code, nil)
+
+	input := test.DefaultTestContractCallInput()
+	input.GasProvided = 1000000
+	input.Function = get
+
+	vmOutput, err := host.RunSmartContractCall(input)
+	verify := test.NewVMOutputVerifier(t, vmOutput, err)
+	verify.Ok()
+
+	require.Greater(t, host.Runtime().MemoryAccountant().InUse(), uint64(0))
+	require.Zero(t, otherHost.Runtime().MemoryAccountant().InUse())
+}
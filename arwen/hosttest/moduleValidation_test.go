@@ -0,0 +1,96 @@
+package hosttest
+
+import (
+	"testing"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
+	contextmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/context"
+	test "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/testcommon"
+	"github.com/ElrondNetwork/elrond-go/core/vmcommon"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestArwenWithValidationConfig(t *testing.T, validationConfig arwen.RuntimeValidationConfig) arwen.VMHost {
+	blockchainHook := &contextmock.BlockchainHookStub{}
+	blockchainHook.GetUserAccountCalled = func(address []byte) (vmcommon.UserAccountHandler, error) {
+		return &contextmock.StubAccount{Nonce: 24}, nil
+	}
+	blockchainHook.NewAddressCalled = func(creatorAddress []byte, nonce uint64, vmType []byte) ([]byte, error) {
+		return test.ParentAddress, nil
+	}
+
+	parameters := test.DefaultTestVMHostParameters()
+	parameters.ValidationConfig = validationConfig
+	host := test.DefaultTestArwenWithParameters(t, blockchainHook, parameters)
+	require.Equal(t, validationConfig, host.GetRuntimeConfig().Validation)
+
+	return host
+}
+
+func deployWithValidationConfig(t *testing.T, contract string, validationConfig arwen.RuntimeValidationConfig) *test.VMOutputVerifier {
+	return deployCodeWithValidationConfig(t, test.GetTestSCCode(contract, "../../"), validationConfig)
+}
+
+func deployCodeWithValidationConfig(t *testing.T, code []byte, validationConfig arwen.RuntimeValidationConfig) *test.VMOutputVerifier {
+	host := createTestArwenWithValidationConfig(t, validationConfig)
+	input := test.CreateTestContractCreateInputBuilder().
+		WithGasProvided(100000).
+		WithContractCode(code).
+		Build()
+
+	vmOutput, err := host.RunSmartContractCreate(input)
+	return test.NewVMOutputVerifier(t, vmOutput, err)
+}
+
+func TestModuleValidation_DeployAllowed(t *testing.T) {
+	deployWithValidationConfig(t, "counter", arwen.RuntimeValidationConfig{
+		DisallowFloatingPoint: true,
+		DisallowStartSection:  true,
+		RequireCallbackExport: true,
+		MaxFunctions:          100,
+		MaxGlobals:            10,
+	}).Ok()
+}
+
+func TestModuleValidation_FloatingPointReported(t *testing.T) {
+	deployWithValidationConfig(t, "num-with-fp", arwen.RuntimeValidationConfig{
+		DisallowFloatingPoint: true,
+	}).
+		ReturnCode(vmcommon.ContractInvalid).
+		ReturnMessageContains(arwen.ErrFloatingPointNotAllowed.Error())
+}
+
+func TestModuleValidation_TooManyFunctionsReported(t *testing.T) {
+	deployWithValidationConfig(t, "counter", arwen.RuntimeValidationConfig{
+		MaxFunctions: 1,
+	}).
+		ReturnCode(vmcommon.ContractInvalid).
+		ReturnMessageContains(arwen.ErrTooManyFunctions.Error())
+}
+
+func TestModuleValidation_CallbackNotExportedReported(t *testing.T) {
+	// a module importing the legacy asyncCall and exporting only "init"
+	code := []byte{
+		0x00, 'a', 's', 'm', 0x01, 0x00, 0x00, 0x00,
+		0x01, 0x04, 0x01, 0x60, 0x00, 0x00,
+		0x02, 0x11, 0x01,
+		0x03, 'e', 'n', 'v',
+		0x09, 'a', 's', 'y', 'n', 'c', 'C', 'a', 'l', 'l',
+		0x00, 0x00,
+		0x03, 0x02, 0x01, 0x00,
+		0x07, 0x08, 0x01, 0x04, 'i', 'n', 'i', 't', 0x00, 0x01,
+		0x0a, 0x04, 0x01, 0x02, 0x00, 0x0b,
+	}
+
+	deployCodeWithValidationConfig(t, code, arwen.RuntimeValidationConfig{
+		RequireCallbackExport: true,
+	}).
+		ReturnCode(vmcommon.ContractInvalid).
+		ReturnMessageContains(arwen.ErrCallbackNotExported.Error())
+}
+
+func TestModuleValidation_CallbackExportedAllowed(t *testing.T) {
+	deployWithValidationConfig(t, "async-call-parent", arwen.RuntimeValidationConfig{
+		RequireCallbackExport: true,
+	}).Ok()
+}
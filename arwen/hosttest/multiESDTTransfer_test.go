@@ -0,0 +1,133 @@
+package hosttest
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen/elrondapi"
+	contextmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/context"
+	worldmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/world"
+	test "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/testcommon"
+	"github.com/ElrondNetwork/elrond-go/core"
+	"github.com/ElrondNetwork/elrond-go/core/vmcommon"
+	"github.com/ElrondNetwork/elrond-go/data/esdt"
+	"github.com/stretchr/testify/require"
+)
+
+var multiTransferSecondToken = []byte("SECOND-abcdef")
+
+func runMultiESDTTransferTest(
+	t *testing.T,
+	transfers []*arwen.ESDTTransfer,
+	function []byte,
+) (*vmcommon.VMOutput, *worldmock.MockWorld) {
+	world := worldmock.NewMockWorld()
+	parameters := test.DefaultTestVMHostParameters()
+	host := test.DefaultTestArwenWithParameters(t, world, parameters)
+
+	err := world.InitBuiltinFunctions(host.GetGasScheduleMap())
+	require.Nil(t, err)
+	host.SetProtocolBuiltinFunctions(world.BuiltinFuncs.GetBuiltinFunctionNames())
+
+	instanceBuilderMock := contextmock.NewInstanceBuilderMock(world)
+	host.Runtime().ReplaceInstanceBuilder(instanceBuilderMock)
+
+	parentInstance := instanceBuilderMock.CreateAndStoreInstanceMock(t, host, test.ParentAddress, 0, 1000)
+	parentInstance.AddMockMethod("multiTransfer", func() *contextmock.InstanceMock {
+		instance := contextmock.GetMockInstance(host)
+		result := elrondapi.MultiTransferESDTNFTExecuteWithTypedArgs(host, test.ChildAddress, transfers, 1000, function, nil)
+		if result != 0 {
+			host.Runtime().FailExecution(errChildFailed)
+		}
+		return instance
+	})
+
+	childInstance := instanceBuilderMock.CreateAndStoreInstanceMock(t, host, test.ChildAddress, 0, 1000)
+	childInstance.AddMockMethod("receive", func() *contextmock.InstanceMock {
+		instance := contextmock.GetMockInstance(host)
+		for _, transfer := range host.Runtime().GetESDTTransfers() {
+			_, err := host.Storage().SetStorage(transfer.TokenIdentifier, transfer.Value.Bytes())
+			require.Nil(t, err)
+		}
+		return instance
+	})
+
+	for _, token := range [][]byte{test.ESDTTestTokenName, multiTransferSecondToken} {
+		err = world.BuiltinFuncs.SetTokenData(test.ParentAddress, worldmock.MakeTokenKey(token, 0), &esdt.ESDigitalToken{
+			Value: big.NewInt(100),
+			Type:  uint32(core.Fungible),
+		})
+		require.Nil(t, err)
+	}
+
+	input := test.CreateTestContractCallInputBuilder().
+		WithCallerAddr(test.UserAddress).
+		WithRecipientAddr(test.ParentAddress).
+		WithFunction("multiTransfer").
+		WithGasProvided(test.GasProvided).
+		Build()
+
+	vmOutput, err := host.RunSmartContractCall(input)
+	require.Nil(t, err)
+	require.NotNil(t, vmOutput)
+
+	return vmOutput, world
+}
+
+func requireTokenBalance(t *testing.T, world *worldmock.MockWorld, address []byte, token []byte, expectedBalance int64) {
+	balance, err := world.BuiltinFuncs.GetTokenBalance(address, worldmock.MakeTokenKey(token, 0))
+	require.Nil(t, err)
+	require.Equal(t, big.NewInt(expectedBalance), balance)
+}
+
+func TestMultiESDTTransfer_TransfersAllTokens(t *testing.T) {
+	transfers := []*arwen.ESDTTransfer{
+		{TokenIdentifier: test.ESDTTestTokenName, Value: big.NewInt(10)},
+		{TokenIdentifier: multiTransferSecondToken, Value: big.NewInt(20)},
+	}
+	vmOutput, world := runMultiESDTTransferTest(t, transfers, nil)
+
+	verify := test.NewVMOutputVerifier(t, vmOutput, nil)
+	verify.Ok()
+	requireTokenBalance(t, world, test.ChildAddress, test.ESDTTestTokenName, 10)
+	requireTokenBalance(t, world, test.ChildAddress, multiTransferSecondToken, 20)
+	requireTokenBalance(t, world, test.ParentAddress, test.ESDTTestTokenName, 90)
+	requireTokenBalance(t, world, test.ParentAddress, multiTransferSecondToken, 80)
+
+	childOutput := vmOutput.OutputAccounts[string(test.ChildAddress)]
+	require.NotNil(t, childOutput)
+	require.Len(t, childOutput.OutputTransfers, 1)
+	expectedData := arwen.EncodeCallData(arwen.BuiltInFunctionMultiESDTNFTTransfer, arwen.MultiESDTNFTTransferArguments(test.ChildAddress, transfers))
+	require.Equal(t, expectedData, childOutput.OutputTransfers[0].Data)
+}
+
+func TestMultiESDTTransfer_ExecutesDestination(t *testing.T) {
+	transfers := []*arwen.ESDTTransfer{
+		{TokenIdentifier: test.ESDTTestTokenName, Value: big.NewInt(10)},
+		{TokenIdentifier: multiTransferSecondToken, Value: big.NewInt(20)},
+	}
+	vmOutput, world := runMultiESDTTransferTest(t, transfers, []byte("receive"))
+
+	verify := test.NewVMOutputVerifier(t, vmOutput, nil)
+	verify.
+		Ok().
+		Storage(
+			test.CreateStoreEntry(test.ChildAddress).WithKey(test.ESDTTestTokenName).WithValue([]byte{10}),
+			test.CreateStoreEntry(test.ChildAddress).WithKey(multiTransferSecondToken).WithValue([]byte{20}),
+		)
+	requireTokenBalance(t, world, test.ChildAddress, test.ESDTTestTokenName, 10)
+	requireTokenBalance(t, world, test.ChildAddress, multiTransferSecondToken, 20)
+}
+
+func TestMultiESDTTransfer_InsufficientBalance(t *testing.T) {
+	transfers := []*arwen.ESDTTransfer{
+		{TokenIdentifier: test.ESDTTestTokenName, Value: big.NewInt(10)},
+		{TokenIdentifier: multiTransferSecondToken, Value: big.NewInt(200)},
+	}
+	vmOutput, world := runMultiESDTTransferTest(t, transfers, nil)
+
+	require.NotEqual(t, vmcommon.Ok, vmOutput.ReturnCode)
+	requireTokenBalance(t, world, test.ParentAddress, test.ESDTTestTokenName, 100)
+	requireTokenBalance(t, world, test.ParentAddress, multiTransferSecondToken, 100)
+}
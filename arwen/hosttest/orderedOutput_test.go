@@ -0,0 +1,66 @@
+package hosttest
+
+import (
+	"math/big"
+	"testing"
+
+	contextmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/context"
+	worldmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/world"
+	test "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/testcommon"
+	"github.com/ElrondNetwork/elrond-go/core/vmcommon"
+	"github.com/stretchr/testify/require"
+)
+
+// runOrderedOutputTest executes, on a new host, a parent contract which
+// makes transfers to several accounts, in an order unrelated to their
+// addresses, and returns the encoding of its ordered VMOutput
+func runOrderedOutputTest(t *testing.T) []byte {
+	world := worldmock.NewMockWorld()
+	parameters := test.DefaultTestVMHostParameters()
+	host := test.DefaultTestArwenWithParameters(t, world, parameters)
+
+	instanceBuilderMock := contextmock.NewInstanceBuilderMock(world)
+	host.Runtime().ReplaceInstanceBuilder(instanceBuilderMock)
+
+	destinations := [][]byte{test.ThirdPartyAddress, test.ChildAddress, test.UserAddress, test.ChildAddress}
+	parentInstance := instanceBuilderMock.CreateAndStoreInstanceMock(t, host, test.ParentAddress, 0, 1000)
+	parentInstance.AddMockMethod("transfer", func() *contextmock.InstanceMock {
+		instance := contextmock.GetMockInstance(host)
+		for i, destination := range destinations {
+			err := host.Output().Transfer(destination, test.ParentAddress, 0, 0, big.NewInt(int64(i+1)), []byte{byte(i)}, vmcommon.DirectCall)
+			require.Nil(t, err)
+		}
+		return instance
+	})
+
+	input := test.CreateTestContractCallInputBuilder().
+		WithCallerAddr(test.UserAddress).
+		WithRecipientAddr(test.ParentAddress).
+		WithFunction("transfer").
+		WithGasProvided(100000).
+		Build()
+
+	vmOutput, err := host.RunSmartContractCall(input)
+	verify := test.NewVMOutputVerifier(t, vmOutput, err)
+	verify.Ok()
+
+	orderedOutput := host.Output().GetOrderedVMOutput(vmOutput)
+	require.Len(t, orderedOutput.OutputTransfers, len(destinations))
+	for i, transfer := range orderedOutput.OutputTransfers {
+		require.Equal(t, i, transfer.Index)
+		require.Equal(t, destinations[i], transfer.Destination)
+		require.Equal(t, []byte{byte(i)}, transfer.Transfer.Data)
+	}
+
+	encoded, err := orderedOutput.Encode()
+	require.Nil(t, err)
+
+	return encoded
+}
+
+func TestOrderedOutput_SameTransactionSameEncoding(t *testing.T) {
+	encoded := runOrderedOutputTest(t)
+	for i := 0; i < 5; i++ {
+		require.Equal(t, encoded, runOrderedOutputTest(t))
+	}
+}
@@ -0,0 +1,97 @@
+package hosttest
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
+	contextmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/context"
+	test "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/testcommon"
+	"github.com/ElrondNetwork/elrond-go/core/vmcommon"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestArwenWithOutOfGasReceipts(t *testing.T, code []byte) arwen.VMHost {
+	blockchainHook := &contextmock.BlockchainHookStub{}
+	blockchainHook.GetUserAccountCalled = func(address []byte) (vmcommon.UserAccountHandler, error) {
+		return &contextmock.StubAccount{}, nil
+	}
+	blockchainHook.GetCodeCalled = func(account vmcommon.UserAccountHandler) []byte {
+		return code
+	}
+
+	parameters := test.DefaultTestVMHostParameters()
+	parameters.BlockGasLimit = uint64(1000000)
+	parameters.OutOfGasReceiptsEnabled = true
+	host := test.DefaultTestArwenWithParameters(t, blockchainHook, parameters)
+
+	return host
+}
+
+func createOutOfGasReceiptTestInput() *vmcommon.ContractCallInput {
+	input := test.DefaultTestContractCallInput()
+	input.GasProvided = 100000
+	input.CallValue = big.NewInt(99)
+	input.Function = "childFunction_OutOfGas"
+	return input
+}
+
+func TestOutOfGasReceipt_Captured(t *testing.T) {
+	code := test.GetTestSCCode("exec-same-ctx-child", "../../")
+	host := createTestArwenWithOutOfGasReceipts(t, code)
+	input := createOutOfGasReceiptTestInput()
+
+	vmOutput, err := host.RunSmartContractCall(input)
+	verify := test.NewVMOutputVerifier(t, vmOutput, err)
+	verify.ReturnCode(vmcommon.OutOfGas)
+
+	receipt := host.Runtime().GetOutOfGasReceipt()
+	require.NotNil(t, receipt)
+	require.Equal(t, input.RecipientAddr, receipt.ContractAddress)
+	require.Equal(t, "childFunction_OutOfGas", receipt.Function)
+	require.Equal(t, "returnData", receipt.LastHostFunction)
+	require.Equal(t, [][]byte{[]byte("childKey........................")}, receipt.StorageKeys)
+
+	require.Len(t, vmOutput.Logs, 1)
+	require.Equal(t, receipt.LogEntry(), vmOutput.Logs[0])
+	require.Equal(t, []byte(arwen.OutOfGasLogIdentifier), vmOutput.Logs[0].Identifier)
+	require.Equal(t, input.RecipientAddr, vmOutput.Logs[0].Address)
+	expectedTopics := [][]byte{
+		[]byte("childFunction_OutOfGas"),
+		[]byte("returnData"),
+		[]byte("childKey........................"),
+	}
+	require.Equal(t, expectedTopics, vmOutput.Logs[0].Topics)
+}
+
+func TestOutOfGasReceipt_InitialCost(t *testing.T) {
+	code := test.GetTestSCCode("exec-same-ctx-child", "../../")
+	host := createTestArwenWithOutOfGasReceipts(t, code)
+	input := createOutOfGasReceiptTestInput()
+	input.GasProvided = 0
+
+	vmOutput, err := host.RunSmartContractCall(input)
+	verify := test.NewVMOutputVerifier(t, vmOutput, err)
+	verify.ReturnCode(vmcommon.OutOfGas)
+
+	receipt := host.Runtime().GetOutOfGasReceipt()
+	require.NotNil(t, receipt)
+	require.Equal(t, "childFunction_OutOfGas", receipt.Function)
+	require.Empty(t, receipt.LastHostFunction)
+	require.Empty(t, receipt.StorageKeys)
+	require.Len(t, vmOutput.Logs, 1)
+	require.Equal(t, receipt.LogEntry(), vmOutput.Logs[0])
+}
+
+func TestOutOfGasReceipt_Disabled(t *testing.T) {
+	code := test.GetTestSCCode("exec-same-ctx-child", "../../")
+	host, _ := test.DefaultTestArwenForCall(t, code, nil)
+	input := createOutOfGasReceiptTestInput()
+
+	vmOutput, err := host.RunSmartContractCall(input)
+	verify := test.NewVMOutputVerifier(t, vmOutput, err)
+	verify.ReturnCode(vmcommon.OutOfGas)
+
+	require.Nil(t, host.Runtime().GetOutOfGasReceipt())
+	require.Empty(t, vmOutput.Logs)
+}
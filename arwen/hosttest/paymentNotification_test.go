@@ -0,0 +1,123 @@
+package hosttest
+
+import (
+	"math/big"
+	"testing"
+
+	contextmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/context"
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/contracts"
+	worldmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/world"
+	test "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/testcommon"
+	"github.com/ElrondNetwork/elrond-go/core"
+	"github.com/ElrondNetwork/elrond-go/core/vmcommon"
+	"github.com/ElrondNetwork/elrond-go/data/esdt"
+	"github.com/stretchr/testify/require"
+)
+
+var paymentNotificationTestConfig = &contracts.PaymentNotificationTestConfig{
+	GasUsedByParent:      100,
+	GasUsedByHook:        50,
+	ESDTTokensToTransfer: 16,
+}
+
+func runPaymentNotificationTest(
+	t *testing.T,
+	notificationGasLimit uint64,
+	testConfig *contracts.PaymentNotificationTestConfig,
+	childHasHook bool,
+) (*vmcommon.VMOutput, *worldmock.MockWorld) {
+	world := worldmock.NewMockWorld()
+	parameters := test.DefaultTestVMHostParameters()
+	parameters.PaymentNotificationGasLimit = notificationGasLimit
+	host := test.DefaultTestArwenWithParameters(t, world, parameters)
+
+	err := world.InitBuiltinFunctions(host.GetGasScheduleMap())
+	require.Nil(t, err)
+	host.SetProtocolBuiltinFunctions(world.BuiltinFuncs.GetBuiltinFunctionNames())
+
+	instanceBuilderMock := contextmock.NewInstanceBuilderMock(world)
+	host.Runtime().ReplaceInstanceBuilder(instanceBuilderMock)
+
+	parentInstance := instanceBuilderMock.CreateAndStoreInstanceMock(t, host, test.ParentAddress, 0, 1000)
+	contracts.TransferESDTToChildParentMock(parentInstance, testConfig)
+
+	childInstance := instanceBuilderMock.CreateAndStoreInstanceMock(t, host, test.ChildAddress, 0, 1000)
+	if childHasHook {
+		contracts.OnPaymentReceivedChildMock(childInstance, testConfig)
+	}
+
+	tokenKey := worldmock.MakeTokenKey(test.ESDTTestTokenName, 0)
+	err = world.BuiltinFuncs.SetTokenData(test.ParentAddress, tokenKey, &esdt.ESDigitalToken{
+		Value: big.NewInt(100),
+		Type:  uint32(core.Fungible),
+	})
+	require.Nil(t, err)
+
+	input := test.CreateTestContractCallInputBuilder().
+		WithCallerAddr(test.UserAddress).
+		WithRecipientAddr(test.ParentAddress).
+		WithFunction("transferESDTToChild").
+		WithGasProvided(test.GasProvided).
+		Build()
+
+	vmOutput, err := host.RunSmartContractCall(input)
+	require.Nil(t, err)
+	require.NotNil(t, vmOutput)
+
+	return vmOutput, world
+}
+
+func requireChildTokenBalance(t *testing.T, world *worldmock.MockWorld, expectedBalance int64) {
+	tokenKey := worldmock.MakeTokenKey(test.ESDTTestTokenName, 0)
+	balance, err := world.BuiltinFuncs.GetTokenBalance(test.ChildAddress, tokenKey)
+	require.Nil(t, err)
+	require.Equal(t, big.NewInt(expectedBalance), balance)
+}
+
+func TestPaymentNotification_HookCalled(t *testing.T) {
+	vmOutput, world := runPaymentNotificationTest(t, 1000, paymentNotificationTestConfig, true)
+
+	verify := test.NewVMOutputVerifier(t, vmOutput, nil)
+	verify.
+		Ok().
+		Storage(
+			test.CreateStoreEntry(test.ChildAddress).WithKey([]byte("paymentSender")).WithValue(test.ParentAddress),
+			test.CreateStoreEntry(test.ChildAddress).WithKey([]byte("paymentToken")).WithValue(test.ESDTTestTokenName),
+			test.CreateStoreEntry(test.ChildAddress).WithKey([]byte("paymentValue")).WithValue(big.NewInt(16).Bytes()),
+		)
+	requireChildTokenBalance(t, world, 16)
+}
+
+func TestPaymentNotification_Disabled(t *testing.T) {
+	vmOutput, world := runPaymentNotificationTest(t, 0, paymentNotificationTestConfig, true)
+
+	verify := test.NewVMOutputVerifier(t, vmOutput, nil)
+	verify.
+		Ok().
+		Storage()
+	requireChildTokenBalance(t, world, 16)
+}
+
+func TestPaymentNotification_NoHook(t *testing.T) {
+	vmOutput, world := runPaymentNotificationTest(t, 1000, paymentNotificationTestConfig, false)
+
+	verify := test.NewVMOutputVerifier(t, vmOutput, nil)
+	verify.
+		Ok().
+		Storage()
+	requireChildTokenBalance(t, world, 16)
+}
+
+func TestPaymentNotification_HookOutOfGas(t *testing.T) {
+	testConfig := *paymentNotificationTestConfig
+	testConfig.GasUsedByHook = 2000
+
+	vmOutput, world := runPaymentNotificationTest(t, 1000, &testConfig, true)
+
+	// the failed notification does not revert the transfer
+	verify := test.NewVMOutputVerifier(t, vmOutput, nil)
+	verify.
+		Ok().
+		Storage()
+	requireChildTokenBalance(t, world, 16)
+}
@@ -0,0 +1,94 @@
+package hosttest
+
+import (
+	"testing"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
+	contextmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/context"
+	test "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/testcommon"
+	"github.com/ElrondNetwork/elrond-go/core/vmcommon"
+	"github.com/stretchr/testify/require"
+)
+
+const testMaxQueryGasLimit = uint64(100000)
+
+func createTestArwenWithMaxQueryGasLimit(t *testing.T, code []byte) arwen.VMHost {
+	blockchainHook := &contextmock.BlockchainHookStub{}
+	blockchainHook.GetUserAccountCalled = func(address []byte) (vmcommon.UserAccountHandler, error) {
+		return &contextmock.StubAccount{}, nil
+	}
+	blockchainHook.GetCodeCalled = func(account vmcommon.UserAccountHandler) []byte {
+		return code
+	}
+
+	parameters := test.DefaultTestVMHostParameters()
+	parameters.BlockGasLimit = uint64(10000000)
+	parameters.MaxQueryGasLimit = testMaxQueryGasLimit
+	host := test.DefaultTestArwenWithParameters(t, blockchainHook, parameters)
+
+	return host
+}
+
+func TestQuery_GasBoundedByMaxQueryGasLimit(t *testing.T) {
+	code := test.GetTestSCCode("counter", "../../")
+	host := createTestArwenWithMaxQueryGasLimit(t, code)
+
+	input := test.DefaultTestContractCallInput()
+	input.Function = "get"
+	input.GasProvided = 1000000
+
+	vmOutput, err := host.RunSmartContractQuery(input)
+	verify := test.NewVMOutputVerifier(t, vmOutput, err)
+	verify.Ok()
+	require.Equal(t, testMaxQueryGasLimit, host.Metering().GetGasProvided())
+	require.Less(t, vmOutput.GasRemaining, testMaxQueryGasLimit)
+
+	// the input belongs to the caller and is left unchanged
+	require.Equal(t, uint64(1000000), input.GasProvided)
+}
+
+func TestQuery_OutOfGasAtMaxQueryGasLimit(t *testing.T) {
+	code := test.GetTestSCCode("async-call-child", "../../")
+	host := createTestArwenWithMaxQueryGasLimit(t, code)
+
+	// the third argument makes the child loop before modifying the state,
+	// which a query is not allowed to
+	input := test.DefaultTestContractCallInput()
+	input.Function = "transferToThirdParty"
+	input.Arguments = [][]byte{{3}, []byte("data"), {2}}
+	input.GasProvided = 1000000
+
+	vmOutput, err := host.RunSmartContractQuery(input)
+	verify := test.NewVMOutputVerifier(t, vmOutput, err)
+	verify.ReturnCode(vmcommon.OutOfGas)
+	require.Equal(t, testMaxQueryGasLimit, host.Metering().GetGasProvided())
+}
+
+func TestQuery_TransactionNotBounded(t *testing.T) {
+	code := test.GetTestSCCode("counter", "../../")
+	host := createTestArwenWithMaxQueryGasLimit(t, code)
+
+	input := test.DefaultTestContractCallInput()
+	input.Function = "get"
+	input.GasProvided = 1000000
+
+	vmOutput, err := host.RunSmartContractCall(input)
+	verify := test.NewVMOutputVerifier(t, vmOutput, err)
+	verify.Ok()
+	require.Equal(t, input.GasProvided, host.Metering().GetGasProvided())
+	require.Greater(t, vmOutput.GasRemaining, testMaxQueryGasLimit)
+}
+
+func TestQuery_Unbounded(t *testing.T) {
+	code := test.GetTestSCCode("counter", "../../")
+	host, _ := test.DefaultTestArwenForCall(t, code, nil)
+
+	input := test.DefaultTestContractCallInput()
+	input.Function = "get"
+	input.GasProvided = 1000000
+
+	vmOutput, err := host.RunSmartContractQuery(input)
+	verify := test.NewVMOutputVerifier(t, vmOutput, err)
+	verify.Ok()
+	require.Equal(t, input.GasProvided, host.Metering().GetGasProvided())
+}
@@ -0,0 +1,164 @@
+package hosttest
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen/elrondapi"
+	contextmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/context"
+	worldmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/world"
+	test "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/testcommon"
+	"github.com/ElrondNetwork/elrond-go/core/vmcommon"
+	"github.com/stretchr/testify/require"
+)
+
+type readOnlyTest struct {
+	host              arwen.VMHost
+	readOnlyAfterCall bool
+}
+
+const readOnlyEnforcementActive = uint32(0)
+const readOnlyEnforcementInactive = uint32(math.MaxUint32)
+
+// createReadOnlyTest sets up a parent contract which transfers value to a
+// child contract, and which calls the child through executeReadOnly
+func createReadOnlyTest(t *testing.T) *readOnlyTest {
+	return createReadOnlyTestWithEnableEpoch(t, readOnlyEnforcementActive)
+}
+
+func createReadOnlyTestWithEnableEpoch(t *testing.T, enableEpoch uint32) *readOnlyTest {
+	world := worldmock.NewMockWorld()
+	parameters := test.DefaultTestVMHostParameters()
+	parameters.ReadOnlyEnforcementEnableEpoch = enableEpoch
+	host := test.DefaultTestArwenWithParameters(t, world, parameters)
+
+	instanceBuilderMock := contextmock.NewInstanceBuilderMock(world)
+	host.Runtime().ReplaceInstanceBuilder(instanceBuilderMock)
+
+	readOnly := &readOnlyTest{host: host}
+
+	parentInstance := instanceBuilderMock.CreateAndStoreInstanceMock(t, host, test.ParentAddress, 0, 1000)
+	parentInstance.AddMockMethod("transferToChild", func() *contextmock.InstanceMock {
+		instance := contextmock.GetMockInstance(host)
+		elrondapi.TransferValueExecuteWithTypedArgs(host, test.ChildAddress, big.NewInt(10), 0, nil, nil)
+		return instance
+	})
+	parentInstance.AddMockMethod("payChildOnSameContext", func() *contextmock.InstanceMock {
+		instance := contextmock.GetMockInstance(host)
+		elrondapi.ExecuteOnSameContextWithTypedArgs(host, 1000, big.NewInt(10), []byte("get"), test.ChildAddress, nil)
+		return instance
+	})
+	parentInstance.AddMockMethod("readChild", func() *contextmock.InstanceMock {
+		instance := contextmock.GetMockInstance(host)
+		result := elrondapi.ExecuteReadOnlyWithTypedArguments(host, 1000, []byte("get"), test.ChildAddress, nil)
+		require.Equal(t, int32(0), result)
+		readOnly.readOnlyAfterCall = host.Runtime().ReadOnly()
+		return instance
+	})
+
+	childInstance := instanceBuilderMock.CreateAndStoreInstanceMock(t, host, test.ChildAddress, 0, 0)
+	childInstance.AddMockMethod("get", test.SimpleWasteGasMockMethod(childInstance, 10))
+
+	return readOnly
+}
+
+func (readOnly *readOnlyTest) run(function string, isQuery bool) (*vmcommon.VMOutput, error) {
+	input := test.CreateTestContractCallInputBuilder().
+		WithCallerAddr(test.UserAddress).
+		WithRecipientAddr(test.ParentAddress).
+		WithFunction(function).
+		WithGasProvided(10000).
+		Build()
+
+	if isQuery {
+		return readOnly.host.RunSmartContractQuery(input)
+	}
+
+	return readOnly.host.RunSmartContractCall(input)
+}
+
+func TestReadOnly_QueryStorageWriteRefused(t *testing.T) {
+	code := test.GetTestSCCode("counter", "../../")
+	host, _ := test.DefaultTestArwenForCall(t, code, nil)
+
+	input := test.DefaultTestContractCallInput()
+	input.Function = "increment"
+	input.GasProvided = 1000000
+
+	vmOutput, err := host.RunSmartContractQuery(input)
+	verify := test.NewVMOutputVerifier(t, vmOutput, err)
+	verify.ReturnCode(vmcommon.ExecutionFailed).
+		ReturnMessageContains(arwen.ErrInvalidCallOnReadOnlyMode.Error())
+
+	vmOutput, err = host.RunSmartContractCall(input)
+	verify = test.NewVMOutputVerifier(t, vmOutput, err)
+	verify.Ok()
+}
+
+func TestReadOnly_QueryTransferRefused(t *testing.T) {
+	readOnly := createReadOnlyTest(t)
+
+	vmOutput, err := readOnly.run("transferToChild", true)
+	verify := test.NewVMOutputVerifier(t, vmOutput, err)
+	verify.ReturnCode(vmcommon.ExecutionFailed).
+		ReturnMessageContains(arwen.ErrInvalidCallOnReadOnlyMode.Error())
+
+	readOnly = createReadOnlyTest(t)
+	vmOutput, err = readOnly.run("transferToChild", false)
+	verify = test.NewVMOutputVerifier(t, vmOutput, err)
+	verify.Ok()
+	require.Equal(t, big.NewInt(10), vmOutput.OutputAccounts[string(test.ChildAddress)].BalanceDelta)
+}
+
+func TestReadOnly_QueryUpgradeRefused(t *testing.T) {
+	readOnly := createReadOnlyTest(t)
+
+	vmOutput, err := readOnly.run(arwen.UpgradeFunctionName, true)
+	verify := test.NewVMOutputVerifier(t, vmOutput, err)
+	verify.ReturnCode(vmcommon.ExecutionFailed).
+		ReturnMessageContains(arwen.ErrInvalidCallOnReadOnlyMode.Error())
+}
+
+func TestReadOnly_ExecuteReadOnlyRestoresMode(t *testing.T) {
+	readOnly := createReadOnlyTest(t)
+
+	vmOutput, err := readOnly.run("readChild", false)
+	verify := test.NewVMOutputVerifier(t, vmOutput, err)
+	verify.Ok()
+	require.False(t, readOnly.readOnlyAfterCall)
+
+	vmOutput, err = readOnly.run("readChild", true)
+	verify = test.NewVMOutputVerifier(t, vmOutput, err)
+	verify.Ok()
+	require.True(t, readOnly.readOnlyAfterCall)
+}
+
+func TestReadOnly_QuerySameContextValueRefused(t *testing.T) {
+	readOnly := createReadOnlyTest(t)
+
+	vmOutput, err := readOnly.run("payChildOnSameContext", true)
+	verify := test.NewVMOutputVerifier(t, vmOutput, err)
+	verify.ReturnCode(vmcommon.ExecutionFailed).
+		ReturnMessageContains(arwen.ErrInvalidCallOnReadOnlyMode.Error())
+
+	readOnly = createReadOnlyTest(t)
+	vmOutput, err = readOnly.run("payChildOnSameContext", false)
+	verify = test.NewVMOutputVerifier(t, vmOutput, err)
+	verify.Ok()
+}
+
+func TestReadOnly_NotEnforcedBeforeActivation(t *testing.T) {
+	readOnly := createReadOnlyTestWithEnableEpoch(t, readOnlyEnforcementInactive)
+	vmOutput, err := readOnly.run("transferToChild", true)
+	verify := test.NewVMOutputVerifier(t, vmOutput, err)
+	verify.Ok()
+	require.Equal(t, big.NewInt(10), vmOutput.OutputAccounts[string(test.ChildAddress)].BalanceDelta)
+
+	readOnly = createReadOnlyTestWithEnableEpoch(t, readOnlyEnforcementInactive)
+	vmOutput, err = readOnly.run("readChild", false)
+	verify = test.NewVMOutputVerifier(t, vmOutput, err)
+	verify.Ok()
+	require.False(t, readOnly.readOnlyAfterCall)
+}
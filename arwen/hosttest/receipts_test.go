@@ -0,0 +1,124 @@
+package hosttest
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
+	contextmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/context"
+	worldmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/world"
+	test "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/testcommon"
+	"github.com/ElrondNetwork/elrond-go/core/vmcommon"
+	"github.com/stretchr/testify/require"
+)
+
+// createReceiptsTestHost sets up a parent contract which either fails, or
+// calls a failing child contract with value and then finishes successfully
+func createReceiptsTestHost(t *testing.T, receiptsEnabled bool) arwen.VMHost {
+	world := worldmock.NewMockWorld()
+	parameters := test.DefaultTestVMHostParameters()
+	parameters.ReceiptsEnabled = receiptsEnabled
+	host := test.DefaultTestArwenWithParameters(t, world, parameters)
+
+	instanceBuilderMock := contextmock.NewInstanceBuilderMock(world)
+	host.Runtime().ReplaceInstanceBuilder(instanceBuilderMock)
+
+	parentInstance := instanceBuilderMock.CreateAndStoreInstanceMock(t, host, test.ParentAddress, 0, 1000)
+	parentInstance.AddMockMethod("callChild", func() *contextmock.InstanceMock {
+		instance := contextmock.GetMockInstance(host)
+		childInput := test.CreateTestContractCallInputBuilder().
+			WithCallerAddr(test.ParentAddress).
+			WithRecipientAddr(test.ChildAddress).
+			WithFunction("fail").
+			WithGasProvided(2000).
+			Build()
+		childInput.CallValue = big.NewInt(7)
+		_, _, _ = host.ExecuteOnDestContext(childInput)
+		return instance
+	})
+	parentInstance.AddMockMethod("fail", func() *contextmock.InstanceMock {
+		instance := contextmock.GetMockInstance(host)
+		host.Runtime().FailExecution(errChildFailed)
+		return instance
+	})
+
+	childInstance := instanceBuilderMock.CreateAndStoreInstanceMock(t, host, test.ChildAddress, 0, 1000)
+	childInstance.AddMockMethod("fail", func() *contextmock.InstanceMock {
+		instance := contextmock.GetMockInstance(host)
+		host.Runtime().FailExecution(errChildFailed)
+		return instance
+	})
+
+	return host
+}
+
+func runReceiptsTest(host arwen.VMHost, function string, value int64) (*vmcommon.VMOutput, error) {
+	input := test.CreateTestContractCallInputBuilder().
+		WithCallerAddr(test.UserAddress).
+		WithRecipientAddr(test.ParentAddress).
+		WithFunction(function).
+		WithGasProvided(10000).
+		Build()
+	input.CallValue = big.NewInt(value)
+
+	return host.RunSmartContractCall(input)
+}
+
+func receiptLogs(vmOutput *vmcommon.VMOutput) []*vmcommon.LogEntry {
+	logs := make([]*vmcommon.LogEntry, 0)
+	for _, logEntry := range vmOutput.Logs {
+		if string(logEntry.Identifier) == arwen.ReceiptLogIdentifier {
+			logs = append(logs, logEntry)
+		}
+	}
+	return logs
+}
+
+func TestReceipts_NestedCallFailed(t *testing.T) {
+	host := createReceiptsTestHost(t, true)
+
+	vmOutput, err := runReceiptsTest(host, "callChild", 0)
+	verify := test.NewVMOutputVerifier(t, vmOutput, err)
+	verify.Ok()
+
+	receipt := arwen.NewFailedCallReceipt(test.ParentAddress, test.ChildAddress, big.NewInt(7), vmcommon.DirectCall)
+	require.Equal(t, []*vmcommon.LogEntry{receipt.LogEntry()}, receiptLogs(vmOutput))
+}
+
+func TestReceipts_TransactionFailed(t *testing.T) {
+	host := createReceiptsTestHost(t, true)
+
+	vmOutput, err := runReceiptsTest(host, "fail", 5)
+	verify := test.NewVMOutputVerifier(t, vmOutput, err)
+	verify.ReturnCode(vmcommon.ExecutionFailed)
+
+	logs := receiptLogs(vmOutput)
+	require.Len(t, logs, 1)
+	require.Equal(t, test.UserAddress, logs[0].Address)
+	require.Equal(t, []byte(arwen.ReceiptValueRefunded.String()), logs[0].Topics[0])
+	require.Equal(t, test.ParentAddress, logs[0].Topics[1])
+	require.Equal(t, big.NewInt(5).Bytes(), logs[0].Topics[2])
+}
+
+func TestReceipts_TransactionFailedWithoutValue(t *testing.T) {
+	host := createReceiptsTestHost(t, true)
+
+	vmOutput, err := runReceiptsTest(host, "fail", 0)
+	verify := test.NewVMOutputVerifier(t, vmOutput, err)
+	verify.ReturnCode(vmcommon.ExecutionFailed)
+	require.Empty(t, receiptLogs(vmOutput))
+}
+
+func TestReceipts_Disabled(t *testing.T) {
+	host := createReceiptsTestHost(t, false)
+
+	vmOutput, err := runReceiptsTest(host, "callChild", 0)
+	verify := test.NewVMOutputVerifier(t, vmOutput, err)
+	verify.Ok()
+	require.Empty(t, receiptLogs(vmOutput))
+
+	vmOutput, err = runReceiptsTest(host, "fail", 5)
+	verify = test.NewVMOutputVerifier(t, vmOutput, err)
+	verify.ReturnCode(vmcommon.ExecutionFailed)
+	require.Empty(t, receiptLogs(vmOutput))
+}
@@ -0,0 +1,68 @@
+package hosttest
+
+import (
+	"testing"
+
+	worldmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/world"
+	test "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/testcommon"
+	"github.com/ElrondNetwork/elrond-go/core/vmcommon"
+	"github.com/stretchr/testify/require"
+)
+
+// runReentrantCalls deploys the given contracts as the parent and the child,
+// then calls the given function of the parent numRuns times
+func runReentrantCalls(t *testing.T, useWarmInstance bool, contracts []string, function string, numRuns int) []*vmcommon.VMOutput {
+	world := worldmock.NewMockWorld()
+	addresses := [][]byte{test.ParentAddress, test.ChildAddress}
+	for i, contract := range contracts {
+		code := test.GetTestSCCode(contract, "../../")
+		world.AcctMap.CreateSmartContractAccount(test.UserAddress, addresses[i], code).Balance.SetInt64(1000)
+	}
+
+	parameters := test.DefaultTestVMHostParameters()
+	parameters.UseWarmInstance = useWarmInstance
+	host := test.DefaultTestArwenWithParameters(t, world, parameters)
+	defer host.Runtime().ResetWarmInstance()
+
+	vmOutputs := make([]*vmcommon.VMOutput, 0, numRuns)
+	for i := 0; i < numRuns; i++ {
+		input := test.CreateTestContractCallInputBuilder().
+			WithCallerAddr(test.UserAddress).
+			WithRecipientAddr(test.ParentAddress).
+			WithFunction(function).
+			WithGasProvided(test.GasProvided).
+			WithArguments([]byte{6}).
+			Build()
+
+		vmOutput, err := host.RunSmartContractCall(input)
+		require.Nil(t, err)
+		require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode, vmOutput.ReturnMessage)
+		vmOutputs = append(vmOutputs, vmOutput)
+	}
+
+	return vmOutputs
+}
+
+func requireSameOutputWithWarmInstance(t *testing.T, contracts []string, function string) {
+	coldOutputs := runReentrantCalls(t, false, contracts, function, 2)
+	warmOutputs := runReentrantCalls(t, true, contracts, function, 2)
+
+	for i := range coldOutputs {
+		require.Equal(t, coldOutputs[i].ReturnData, warmOutputs[i].ReturnData)
+		require.Equal(t, coldOutputs[i].GasRemaining, warmOutputs[i].GasRemaining)
+		require.Equal(t, coldOutputs[i].OutputAccounts, warmOutputs[i].OutputAccounts)
+	}
+}
+
+func TestReentrantCalls_DestContext_Direct(t *testing.T) {
+	requireSameOutputWithWarmInstance(t, []string{"exec-dest-ctx-recursive"}, callRecursive)
+}
+
+func TestReentrantCalls_SameContext_Direct(t *testing.T) {
+	requireSameOutputWithWarmInstance(t, []string{"exec-same-ctx-recursive"}, callRecursive)
+}
+
+func TestReentrantCalls_DestContext_Mutual(t *testing.T) {
+	contracts := []string{"exec-dest-ctx-recursive-parent", "exec-dest-ctx-recursive-child"}
+	requireSameOutputWithWarmInstance(t, contracts, parentCallsChild)
+}
@@ -0,0 +1,94 @@
+package hosttest
+
+import (
+	"testing"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
+	contextmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/context"
+	worldmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/world"
+	test "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/testcommon"
+	"github.com/ElrondNetwork/elrond-go/core/vmcommon"
+	"github.com/stretchr/testify/require"
+)
+
+const refundReceiptsTestGasPrice = uint64(2)
+
+func createTestArwenWithRefundReceipts(t *testing.T, world *worldmock.MockWorld, enableEpoch uint32) (arwen.VMHost, *contextmock.InstanceBuilderMock) {
+	parameters := test.DefaultTestVMHostParameters()
+	parameters.RefundReceiptsEnableEpoch = enableEpoch
+	host := test.DefaultTestArwenWithParameters(t, world, parameters)
+
+	instanceBuilderMock := contextmock.NewInstanceBuilderMock(world)
+	host.Runtime().ReplaceInstanceBuilder(instanceBuilderMock)
+
+	parentInstance := instanceBuilderMock.CreateAndStoreInstanceMock(t, host, test.ParentAddress, 0, 1000)
+	parentInstance.AddMockMethod("releaseStorage", func() *contextmock.InstanceMock {
+		host.Metering().UseGas(100)
+		host.Output().AddRefund(arwen.RefundStorageRelease, 30)
+		return contextmock.GetMockInstance(host)
+	})
+
+	return host, instanceBuilderMock
+}
+
+func runRefundReceiptsTestCall(t *testing.T, host arwen.VMHost, callType vmcommon.CallType) *vmcommon.VMOutput {
+	input := test.CreateTestContractCallInputBuilder().
+		WithCallerAddr(test.UserAddress).
+		WithRecipientAddr(test.ParentAddress).
+		WithFunction("releaseStorage").
+		WithGasProvided(1000).
+		WithCallType(callType).
+		Build()
+	input.GasPrice = refundReceiptsTestGasPrice
+
+	vmOutput, err := host.RunSmartContractCall(input)
+	require.Nil(t, err)
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+
+	return vmOutput
+}
+
+func getRefundReceiptLogs(vmOutput *vmcommon.VMOutput) []*vmcommon.LogEntry {
+	receiptLogs := make([]*vmcommon.LogEntry, 0)
+	for _, logEntry := range vmOutput.Logs {
+		if string(logEntry.Identifier) == arwen.RefundReceiptLogIdentifier {
+			receiptLogs = append(receiptLogs, logEntry)
+		}
+	}
+	return receiptLogs
+}
+
+func TestRefundReceipts_DirectCall(t *testing.T) {
+	host, _ := createTestArwenWithRefundReceipts(t, worldmock.NewMockWorld(), 0)
+	vmOutput := runRefundReceiptsTestCall(t, host, vmcommon.DirectCall)
+
+	receiptLogs := getRefundReceiptLogs(vmOutput)
+	require.Len(t, receiptLogs, 2)
+
+	expectedUnusedGas := arwen.NewRefundReceipt(test.UserAddress, vmOutput.GasRemaining, refundReceiptsTestGasPrice, arwen.RefundReasonUnusedGas)
+	require.Equal(t, expectedUnusedGas.LogEntry(), receiptLogs[0])
+
+	expectedStorageRelease := arwen.NewRefundReceipt(test.UserAddress, 30, refundReceiptsTestGasPrice, arwen.RefundReasonStorageRelease)
+	require.Equal(t, expectedStorageRelease.LogEntry(), receiptLogs[1])
+}
+
+func TestRefundReceipts_NotForAsyncCalls(t *testing.T) {
+	host, _ := createTestArwenWithRefundReceipts(t, worldmock.NewMockWorld(), 0)
+	vmOutput := runRefundReceiptsTestCall(t, host, vmcommon.AsynchronousCall)
+
+	// the refunds of async calls are settled by the node with the original caller
+	require.Empty(t, getRefundReceiptLogs(vmOutput))
+}
+
+func TestRefundReceipts_BeforeEnableEpoch(t *testing.T) {
+	world := worldmock.NewMockWorld()
+	host, _ := createTestArwenWithRefundReceipts(t, world, 2)
+
+	world.CurrentBlockInfo.BlockEpoch = 1
+	vmOutput := runRefundReceiptsTestCall(t, host, vmcommon.DirectCall)
+	require.Empty(t, getRefundReceiptLogs(vmOutput))
+
+	world.CurrentBlockInfo.BlockEpoch = 2
+	vmOutput = runRefundReceiptsTestCall(t, host, vmcommon.DirectCall)
+	require.Len(t, getRefundReceiptLogs(vmOutput), 2)
+}
@@ -0,0 +1,55 @@
+package hosttest
+
+import (
+	"testing"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/contracts"
+	worldmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/world"
+	test "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/testcommon"
+	"github.com/stretchr/testify/require"
+)
+
+func TestItemizedRefunds_AsyncCall(t *testing.T) {
+	testConfig := *asyncTestConfig
+	testConfig.GasProvided = 1000
+
+	gasUsedByParent := testConfig.GasUsedByParent + testConfig.GasUsedByCallback
+	gasRemaining := testConfig.GasProvided - gasUsedByParent - testConfig.GasUsedByChild
+
+	var vmHost arwen.VMHost
+	test.BuildMockInstanceCallTest(t).
+		WithContracts(
+			test.CreateMockContract(test.ParentAddress).
+				WithBalance(testConfig.ParentBalance).
+				WithConfig(&testConfig).
+				WithMethods(contracts.PerformAsyncCallParentMock, contracts.CallBackParentMock),
+			test.CreateMockContract(test.ChildAddress).
+				WithBalance(testConfig.ChildBalance).
+				WithConfig(&testConfig).
+				WithMethods(contracts.TransferToThirdPartyAsyncChildMock),
+		).
+		WithInput(test.CreateTestContractCallInputBuilder().
+			WithRecipientAddr(test.ParentAddress).
+			WithGasProvided(testConfig.GasProvided).
+			WithFunction("performAsyncCall").
+			WithArguments([]byte{0}).
+			Build()).
+		WithSetup(func(host arwen.VMHost, world *worldmock.MockWorld) {
+			vmHost = host
+			setZeroCodeCosts(host)
+			setAsyncCosts(host, testConfig.GasLockCost)
+		}).
+		AndAssertResults(func(world *worldmock.MockWorld, verify *test.VMOutputVerifier) {
+			verify.
+				Ok().
+				GasRemaining(gasRemaining)
+
+			// the gas returned by the callback covers the gas locked for it
+			// first, then the gas left unused by the child
+			require.Equal(t, arwen.ItemizedRefunds{
+				UnusedLockedGas: testConfig.GasLockCost,
+				AsyncCall:       gasRemaining - testConfig.GasLockCost,
+			}, vmHost.Output().GetItemizedRefunds())
+		})
+}
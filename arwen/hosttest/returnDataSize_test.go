@@ -0,0 +1,124 @@
+package hosttest
+
+import (
+	"testing"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
+	contextmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/context"
+	worldmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/world"
+	test "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/testcommon"
+	"github.com/ElrondNetwork/elrond-go/core/vmcommon"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestArwenWithMaxReturnDataSize(t *testing.T, code []byte, maxReturnDataSize uint64) arwen.VMHost {
+	blockchainHook := &contextmock.BlockchainHookStub{}
+	blockchainHook.GetUserAccountCalled = func(address []byte) (vmcommon.UserAccountHandler, error) {
+		return &contextmock.StubAccount{}, nil
+	}
+	blockchainHook.GetCodeCalled = func(account vmcommon.UserAccountHandler) []byte {
+		return code
+	}
+
+	parameters := test.DefaultTestVMHostParameters()
+	parameters.BlockGasLimit = uint64(10000000)
+	parameters.MaxReturnDataSize = maxReturnDataSize
+	host := test.DefaultTestArwenWithParameters(t, blockchainHook, parameters)
+
+	return host
+}
+
+// createFinishLoopInput calls the child contract with the behavior which
+// makes it finish the same data in an endless loop
+func createFinishLoopInput() *vmcommon.ContractCallInput {
+	input := test.DefaultTestContractCallInput()
+	input.Function = "transferToThirdParty"
+	input.Arguments = [][]byte{{3}, []byte("data"), {2}}
+	input.GasProvided = 1000000
+	return input
+}
+
+func TestReturnDataSize_Unlimited(t *testing.T) {
+	code := test.GetTestSCCode("async-call-child", "../../")
+	host := createTestArwenWithMaxReturnDataSize(t, code, 0)
+
+	vmOutput, err := host.RunSmartContractCall(createFinishLoopInput())
+	verify := test.NewVMOutputVerifier(t, vmOutput, err)
+	verify.ReturnCode(vmcommon.OutOfGas)
+}
+
+func TestReturnDataSize_Exceeded(t *testing.T) {
+	code := test.GetTestSCCode("async-call-child", "../../")
+	host := createTestArwenWithMaxReturnDataSize(t, code, 20)
+
+	vmOutput, err := host.RunSmartContractCall(createFinishLoopInput())
+	verify := test.NewVMOutputVerifier(t, vmOutput, err)
+	verify.ReturnCode(vmcommon.ExecutionFailed).
+		ReturnMessage(arwen.ErrReturnDataTooLarge.Error())
+
+	// the budget was exhausted by five "loop" chunks
+	require.Equal(t, int64(0), host.Output().GetReturnDataBudget())
+}
+
+func TestReturnDataSize_WithinLimit(t *testing.T) {
+	code := test.GetTestSCCode("counter", "../../")
+	host := createTestArwenWithMaxReturnDataSize(t, code, 20)
+
+	input := test.DefaultTestContractCallInput()
+	input.Function = "get"
+	input.GasProvided = 1000000
+
+	vmOutput, err := host.RunSmartContractCall(input)
+	verify := test.NewVMOutputVerifier(t, vmOutput, err)
+	verify.Ok()
+	require.Len(t, vmOutput.ReturnData, 1)
+}
+
+func TestReturnDataSize_FailedNestedCallNotCounted(t *testing.T) {
+	world := worldmock.NewMockWorld()
+	parameters := test.DefaultTestVMHostParameters()
+	parameters.BlockGasLimit = uint64(10000)
+	parameters.MaxReturnDataSize = 10
+	host := test.DefaultTestArwenWithParameters(t, world, parameters)
+
+	instanceBuilderMock := contextmock.NewInstanceBuilderMock(world)
+	host.Runtime().ReplaceInstanceBuilder(instanceBuilderMock)
+
+	parentInstance := instanceBuilderMock.CreateAndStoreInstanceMock(t, host, test.ParentAddress, 0, 1000)
+	parentInstance.AddMockMethod("callChild", func() *contextmock.InstanceMock {
+		instance := contextmock.GetMockInstance(host)
+		childInput := test.CreateTestContractCallInputBuilder().
+			WithCallerAddr(test.ParentAddress).
+			WithRecipientAddr(test.ChildAddress).
+			WithFunction("finishThenFail").
+			WithGasProvided(2000).
+			Build()
+		_, _, _ = host.ExecuteOnDestContext(childInput)
+
+		err := host.Output().Finish([]byte("parentdata"))
+		require.Nil(t, err)
+		return instance
+	})
+
+	childInstance := instanceBuilderMock.CreateAndStoreInstanceMock(t, host, test.ChildAddress, 0, 1000)
+	childInstance.AddMockMethod("finishThenFail", func() *contextmock.InstanceMock {
+		instance := contextmock.GetMockInstance(host)
+		err := host.Output().Finish([]byte("childdata"))
+		require.Nil(t, err)
+		host.Runtime().FailExecution(arwen.ErrSignalError)
+		return instance
+	})
+
+	input := test.CreateTestContractCallInputBuilder().
+		WithCallerAddr(test.UserAddress).
+		WithRecipientAddr(test.ParentAddress).
+		WithFunction("callChild").
+		WithGasProvided(5000).
+		Build()
+
+	vmOutput, err := host.RunSmartContractCall(input)
+	verify := test.NewVMOutputVerifier(t, vmOutput, err)
+	verify.Ok().
+		ReturnData([]byte("parentdata"))
+	require.Equal(t, int64(0), host.Output().GetReturnDataBudget())
+}
@@ -0,0 +1,121 @@
+package hosttest
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
+	contextmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/context"
+	worldmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/world"
+	test "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/testcommon"
+	"github.com/stretchr/testify/require"
+)
+
+var errGrandchildFailed = errors.New("grandchild failed")
+
+// createSameContextValueTest sets up a parent contract which calls a child
+// contract on the same context, with value, several times; on each call, the
+// child calls a grandchild and then itself on the same context, with value
+func createSameContextValueTest(t *testing.T, numCalls int) arwen.VMHost {
+	world := worldmock.NewMockWorld()
+	parameters := test.DefaultTestVMHostParameters()
+	host := test.DefaultTestArwenWithParameters(t, world, parameters)
+
+	instanceBuilderMock := contextmock.NewInstanceBuilderMock(world)
+	host.Runtime().ReplaceInstanceBuilder(instanceBuilderMock)
+
+	executeOnSameContext := func(caller []byte, recipient []byte, function string, value int64) error {
+		input := test.CreateTestContractCallInputBuilder().
+			WithCallerAddr(caller).
+			WithRecipientAddr(recipient).
+			WithFunction(function).
+			WithGasProvided(1000).
+			Build()
+		input.CallValue = big.NewInt(value)
+
+		_, err := host.ExecuteOnSameContext(input)
+		return err
+	}
+
+	parentInstance := instanceBuilderMock.CreateAndStoreInstanceMock(t, host, test.ParentAddress, 0, 1000)
+	parentInstance.AddMockMethod("callChild", func() *contextmock.InstanceMock {
+		instance := contextmock.GetMockInstance(host)
+		for i := 0; i < numCalls; i++ {
+			err := executeOnSameContext(test.ParentAddress, test.ChildAddress, "callGrandchild", 10)
+			require.Nil(t, err)
+		}
+
+		err := executeOnSameContext(test.ParentAddress, test.ChildAddress, "callFailingGrandchild", 10)
+		require.True(t, errors.Is(err, arwen.ErrExecutionFailed))
+		return instance
+	})
+
+	childInstance := instanceBuilderMock.CreateAndStoreInstanceMock(t, host, test.ChildAddress, 0, 0)
+	childInstance.AddMockMethod("callGrandchild", func() *contextmock.InstanceMock {
+		instance := contextmock.GetMockInstance(host)
+		err := executeOnSameContext(test.ChildAddress, test.ThirdPartyAddress, "receive", 4)
+		if err == nil {
+			err = executeOnSameContext(test.ChildAddress, test.ChildAddress, "receive", 3)
+		}
+		if err != nil {
+			host.Runtime().FailExecution(err)
+		}
+		return instance
+	})
+	childInstance.AddMockMethod("callFailingGrandchild", func() *contextmock.InstanceMock {
+		instance := contextmock.GetMockInstance(host)
+		err := executeOnSameContext(test.ChildAddress, test.ThirdPartyAddress, "fail", 4)
+		if err != nil {
+			host.Runtime().FailExecution(err)
+		}
+		return instance
+	})
+	childInstance.AddMockMethod("receive", test.SimpleWasteGasMockMethod(childInstance, 10))
+
+	grandchildInstance := instanceBuilderMock.CreateAndStoreInstanceMock(t, host, test.ThirdPartyAddress, 0, 0)
+	grandchildInstance.AddMockMethod("receive", test.SimpleWasteGasMockMethod(grandchildInstance, 10))
+	grandchildInstance.AddMockMethod("fail", func() *contextmock.InstanceMock {
+		instance := contextmock.GetMockInstance(host)
+		host.Runtime().FailExecution(errGrandchildFailed)
+		return instance
+	})
+
+	return host
+}
+
+func runSameContextValueTest(t *testing.T, numCalls int) {
+	host := createSameContextValueTest(t, numCalls)
+
+	input := test.CreateTestContractCallInputBuilder().
+		WithCallerAddr(test.UserAddress).
+		WithRecipientAddr(test.ParentAddress).
+		WithFunction("callChild").
+		WithGasProvided(100000).
+		Build()
+
+	vmOutput, err := host.RunSmartContractCall(input)
+	verify := test.NewVMOutputVerifier(t, vmOutput, err)
+	verify.Ok()
+
+	balanceDelta := func(address []byte) *big.Int {
+		account, ok := vmOutput.OutputAccounts[string(address)]
+		if !ok {
+			return big.NewInt(0)
+		}
+		return account.BalanceDelta
+	}
+
+	calls := int64(numCalls)
+	require.Equal(t, big.NewInt(-10*calls), balanceDelta(test.ParentAddress))
+	require.Equal(t, big.NewInt(6*calls), balanceDelta(test.ChildAddress))
+	require.Equal(t, big.NewInt(4*calls), balanceDelta(test.ThirdPartyAddress))
+}
+
+func TestSameContextValue_ExecuteOnSameContext_Once(t *testing.T) {
+	runSameContextValueTest(t, 1)
+}
+
+func TestSameContextValue_ExecuteOnSameContext_Repeated(t *testing.T) {
+	runSameContextValueTest(t, 5)
+}
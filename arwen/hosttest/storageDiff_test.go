@@ -0,0 +1,129 @@
+package hosttest
+
+import (
+	"bytes"
+	"testing"
+
+	contextmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/context"
+	worldmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/world"
+	test "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/testcommon"
+	"github.com/ElrondNetwork/elrond-go/core/vmcommon"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStorageDiff_ChangedKeys(t *testing.T) {
+	world := worldmock.NewMockWorld()
+	host, instanceBuilderMock := createTestArwenWithMockWorld(t, world)
+
+	parentInstance := instanceBuilderMock.CreateAndStoreInstanceMock(t, host, test.ParentAddress, 0, 1000)
+	parentInstance.AddMockMethod("write", func() *contextmock.InstanceMock {
+		instance := contextmock.GetMockInstance(host)
+		storage := host.Storage()
+
+		// "a" is read before being changed, "b" is changed without being read,
+		// "c" is rewritten with its value and "d" is deleted
+		storage.GetStorage([]byte("a"))
+		_, _ = storage.SetStorage([]byte("a"), []byte("newA"))
+		_, _ = storage.SetStorage([]byte("b"), []byte("newB"))
+		_, _ = storage.SetStorage([]byte("c"), []byte("oldC"))
+		_, _ = storage.SetStorage([]byte("d"), nil)
+		_, _ = storage.SetStorage([]byte("e"), []byte("newE"))
+
+		return instance
+	})
+	parentStorage := world.AcctMap.GetAccount(test.ParentAddress).Storage
+	parentStorage["a"] = []byte("oldA")
+	parentStorage["b"] = []byte("oldB")
+	parentStorage["c"] = []byte("oldC")
+	parentStorage["d"] = []byte("oldD")
+
+	input := test.CreateTestContractCallInputBuilder().
+		WithCallerAddr(test.UserAddress).
+		WithRecipientAddr(test.ParentAddress).
+		WithFunction("write").
+		WithGasProvided(1000).
+		Build()
+
+	vmOutput, err := host.RunSmartContractCall(input)
+	require.Nil(t, err)
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+
+	diff := host.GetStorageDiff()
+	require.Len(t, diff.Changes, 4)
+	require.Len(t, diff.ChangesOf(test.ParentAddress), 4)
+	require.Len(t, diff.ChangesOf(test.ChildAddress), 0)
+
+	expectedChanges := []struct {
+		key    string
+		before []byte
+		after  []byte
+	}{
+		{"a", []byte("oldA"), []byte("newA")},
+		{"b", []byte("oldB"), []byte("newB")},
+		{"d", []byte("oldD"), nil},
+		{"e", nil, []byte("newE")},
+	}
+	for i, expected := range expectedChanges {
+		change := diff.Changes[i]
+		require.Equal(t, test.ParentAddress, change.Address)
+		require.Equal(t, []byte(expected.key), change.Key)
+		require.True(t, bytes.Equal(expected.before, change.Before))
+		require.True(t, bytes.Equal(expected.after, change.After))
+	}
+
+	_, changed := diff.Get(test.ParentAddress, []byte("c"))
+	require.False(t, changed)
+}
+
+func TestStorageDiff_FailedExecution(t *testing.T) {
+	world := worldmock.NewMockWorld()
+	host, instanceBuilderMock := createTestArwenWithMockWorld(t, world)
+
+	parentInstance := instanceBuilderMock.CreateAndStoreInstanceMock(t, host, test.ParentAddress, 0, 1000)
+	parentInstance.AddMockMethod("writeAndFail", func() *contextmock.InstanceMock {
+		instance := contextmock.GetMockInstance(host)
+		_, _ = host.Storage().SetStorage([]byte("a"), []byte("new"))
+		host.Runtime().SignalUserError("failed")
+		return instance
+	})
+
+	input := test.CreateTestContractCallInputBuilder().
+		WithCallerAddr(test.UserAddress).
+		WithRecipientAddr(test.ParentAddress).
+		WithFunction("writeAndFail").
+		WithGasProvided(1000).
+		Build()
+
+	vmOutput, err := host.RunSmartContractCall(input)
+	require.Nil(t, err)
+	require.Equal(t, vmcommon.UserError, vmOutput.ReturnCode)
+	require.True(t, host.GetStorageDiff().IsEmpty())
+}
+
+func TestStorageDiff_Disabled(t *testing.T) {
+	world := worldmock.NewMockWorld()
+	parameters := test.DefaultTestVMHostParameters()
+	host := test.DefaultTestArwenWithParameters(t, world, parameters)
+
+	instanceBuilderMock := contextmock.NewInstanceBuilderMock(world)
+	host.Runtime().ReplaceInstanceBuilder(instanceBuilderMock)
+
+	parentInstance := instanceBuilderMock.CreateAndStoreInstanceMock(t, host, test.ParentAddress, 0, 1000)
+	parentInstance.AddMockMethod("write", func() *contextmock.InstanceMock {
+		instance := contextmock.GetMockInstance(host)
+		_, _ = host.Storage().SetStorage([]byte("a"), []byte("new"))
+		return instance
+	})
+
+	input := test.CreateTestContractCallInputBuilder().
+		WithCallerAddr(test.UserAddress).
+		WithRecipientAddr(test.ParentAddress).
+		WithFunction("write").
+		WithGasProvided(1000).
+		Build()
+
+	vmOutput, err := host.RunSmartContractCall(input)
+	verify := test.NewVMOutputVerifier(t, vmOutput, err)
+	verify.Ok()
+	require.Nil(t, host.GetStorageDiff())
+}
@@ -0,0 +1,138 @@
+package hosttest
+
+import (
+	"testing"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
+	contextmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/context"
+	worldmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/world"
+	test "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/testcommon"
+	"github.com/ElrondNetwork/elrond-go/core/vmcommon"
+	"github.com/stretchr/testify/require"
+)
+
+var crossShardAddress = test.MakeTestSCAddress("crossShardSC")
+
+func TestStorageFromContract_SameShardAndCrossShard(t *testing.T) {
+	world := worldmock.NewMockWorld()
+	host, instanceBuilderMock := createTestArwenWithMockWorld(t, world)
+
+	parentInstance := instanceBuilderMock.CreateAndStoreInstanceMock(t, host, test.ParentAddress, 0, 1000)
+	parentInstance.AddMockMethod("readContracts", func() *contextmock.InstanceMock {
+		instance := contextmock.GetMockInstance(host)
+		storage := host.Storage()
+
+		value, err := storage.GetStorageFromContract(test.ChildAddress, []byte("a"))
+		require.Nil(t, err)
+		require.Equal(t, []byte("childValue"), value)
+
+		// the storage of a contract in another shard is never read, whatever it holds
+		value, err = storage.GetStorageFromContract(crossShardAddress, []byte("a"))
+		require.Equal(t, arwen.ErrStorageReadNotInSameShard, err)
+		require.Nil(t, value)
+
+		return instance
+	})
+
+	instanceBuilderMock.CreateAndStoreInstanceMock(t, host, test.ChildAddress, 0, 1000)
+	child := world.AcctMap.GetAccount(test.ChildAddress)
+	child.CodeMetadata = []byte{vmcommon.MetadataReadable, 0}
+	child.Storage["a"] = []byte("childValue")
+
+	instanceBuilderMock.CreateAndStoreInstanceMock(t, host, crossShardAddress, 1, 1000)
+	crossShard := world.AcctMap.GetAccount(crossShardAddress)
+	crossShard.CodeMetadata = []byte{vmcommon.MetadataReadable, 0}
+	crossShard.Storage["a"] = []byte("crossShardValue")
+
+	input := test.CreateTestContractCallInputBuilder().
+		WithCallerAddr(test.UserAddress).
+		WithRecipientAddr(test.ParentAddress).
+		WithFunction("readContracts").
+		WithGasProvided(1000).
+		Build()
+
+	vmOutput, err := host.RunSmartContractCall(input)
+	require.Nil(t, err)
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+
+	// reading another contract does not write its storage
+	require.True(t, host.GetStorageDiff().IsEmpty())
+}
+
+// createStorageFromContractTest sets up the storage-from-contract contract
+// as the parent, reading the storage of a child in the same shard and of a
+// contract in another shard, both of which hold a value under the key "a"
+func createStorageFromContractTest(t *testing.T) arwen.VMHost {
+	world := worldmock.NewMockWorld()
+	parameters := test.DefaultTestVMHostParameters()
+	parameters.BlockGasLimit = uint64(100000)
+	host := test.DefaultTestArwenWithParameters(t, world, parameters)
+
+	parent := world.AcctMap.CreateAccount(test.ParentAddress)
+	parent.IsSmartContract = true
+	parent.Code = test.GetTestSCCode("storage-from-contract", "../../")
+	parent.MockWorld = world
+
+	child := world.AcctMap.CreateAccount(test.ChildAddress)
+	child.IsSmartContract = true
+	child.CodeMetadata = []byte{vmcommon.MetadataReadable, 0}
+	child.Storage["a"] = []byte("childValue")
+	child.MockWorld = world
+
+	crossShard := world.AcctMap.CreateAccount(crossShardAddress)
+	crossShard.IsSmartContract = true
+	crossShard.CodeMetadata = []byte{vmcommon.MetadataReadable, 0}
+	crossShard.Storage["a"] = []byte("crossShardValue")
+	crossShard.ShardID = 1
+	crossShard.MockWorld = world
+
+	return host
+}
+
+func runStorageFromContract(host arwen.VMHost, function string, address []byte) (*vmcommon.VMOutput, error) {
+	input := test.CreateTestContractCallInputBuilder().
+		WithCallerAddr(test.UserAddress).
+		WithRecipientAddr(test.ParentAddress).
+		WithFunction(function).
+		WithArguments(address, []byte("a")).
+		WithGasProvided(10000).
+		Build()
+
+	return host.RunSmartContractCall(input)
+}
+
+func TestStorageFromContract_LoadSameShard(t *testing.T) {
+	host := createStorageFromContractTest(t)
+
+	vmOutput, err := runStorageFromContract(host, "loadFromContract", test.ChildAddress)
+	verify := test.NewVMOutputVerifier(t, vmOutput, err)
+	verify.Ok().
+		ReturnData([]byte("childValue"))
+}
+
+func TestStorageFromContract_LoadWrongShard(t *testing.T) {
+	host := createStorageFromContractTest(t)
+
+	vmOutput, err := runStorageFromContract(host, "loadFromContract", crossShardAddress)
+	verify := test.NewVMOutputVerifier(t, vmOutput, err)
+	verify.ReturnCode(vmcommon.ExecutionFailed).
+		ReturnMessage(arwen.ErrStorageReadNotInSameShard.Error())
+}
+
+func TestStorageFromContract_LoadLengthSameShard(t *testing.T) {
+	host := createStorageFromContractTest(t)
+
+	vmOutput, err := runStorageFromContract(host, "loadLengthFromContract", test.ChildAddress)
+	verify := test.NewVMOutputVerifier(t, vmOutput, err)
+	verify.Ok().
+		ReturnData([]byte{byte(len("childValue"))})
+}
+
+func TestStorageFromContract_LoadLengthWrongShard(t *testing.T) {
+	host := createStorageFromContractTest(t)
+
+	vmOutput, err := runStorageFromContract(host, "loadLengthFromContract", crossShardAddress)
+	verify := test.NewVMOutputVerifier(t, vmOutput, err)
+	verify.ReturnCode(vmcommon.ExecutionFailed).
+		ReturnMessage(arwen.ErrStorageReadNotInSameShard.Error())
+}
@@ -0,0 +1,102 @@
+package hosttest
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
+	contextmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/context"
+	worldmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/world"
+	test "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/testcommon"
+	"github.com/ElrondNetwork/elrond-go/core/vmcommon"
+	"github.com/stretchr/testify/require"
+)
+
+var recipientAddress = test.MakeTestSCAddress("recipientSC")
+
+func createTestArwenWithMockWorld(t *testing.T, world *worldmock.MockWorld) (arwen.VMHost, *contextmock.InstanceBuilderMock) {
+	parameters := test.DefaultTestVMHostParameters()
+	parameters.StorageDiffEnabled = true
+	host := test.DefaultTestArwenWithParameters(t, world, parameters)
+
+	instanceBuilderMock := contextmock.NewInstanceBuilderMock(world)
+	host.Runtime().ReplaceInstanceBuilder(instanceBuilderMock)
+
+	return host, instanceBuilderMock
+}
+
+func TestTouchedAccounts_ReadAndWritten(t *testing.T) {
+	world := worldmock.NewMockWorld()
+	host, instanceBuilderMock := createTestArwenWithMockWorld(t, world)
+
+	parentInstance := instanceBuilderMock.CreateAndStoreInstanceMock(t, host, test.ParentAddress, 0, 1000)
+	parentInstance.AddMockMethod("touchAccounts", func() *contextmock.InstanceMock {
+		instance := contextmock.GetMockInstance(host)
+		storage := host.Storage()
+
+		// the child is only read, and rewriting a value leaves the storage unchanged
+		storage.GetStorageFromAddress(test.ChildAddress, []byte("b"))
+		_, _ = storage.SetStorage([]byte("a"), []byte("old"))
+
+		err := host.Output().TransferValueOnly(recipientAddress, test.ParentAddress, big.NewInt(10), false)
+		require.Nil(t, err)
+
+		return instance
+	})
+	world.AcctMap.GetAccount(test.ParentAddress).Storage["a"] = []byte("old")
+	instanceBuilderMock.CreateAndStoreInstanceMock(t, host, test.ChildAddress, 0, 1000)
+
+	input := test.CreateTestContractCallInputBuilder().
+		WithCallerAddr(test.UserAddress).
+		WithRecipientAddr(test.ParentAddress).
+		WithFunction("touchAccounts").
+		WithGasProvided(1000).
+		Build()
+
+	vmOutput, err := host.RunSmartContractCall(input)
+	require.Nil(t, err)
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+
+	// the child was only read, so it does not appear in the OutputAccounts
+	require.NotContains(t, vmOutput.OutputAccounts, string(test.ChildAddress))
+
+	touched := host.GetTouchedAccounts()
+	require.True(t, touched.IsRead(test.ParentAddress))
+	require.True(t, touched.IsRead(test.ChildAddress))
+	require.True(t, touched.IsWritten(test.ParentAddress))
+	require.True(t, touched.IsWritten(recipientAddress))
+	require.False(t, touched.IsWritten(test.ChildAddress))
+
+	// an execution which reads the recipient cannot be reordered with this one
+	other := &arwen.TouchedAccounts{Read: [][]byte{recipientAddress}, Written: [][]byte{}}
+	require.True(t, touched.ConflictsWith(other))
+	require.True(t, other.ConflictsWith(touched))
+}
+
+func TestTouchedAccounts_StorageWritten(t *testing.T) {
+	world := worldmock.NewMockWorld()
+	host, instanceBuilderMock := createTestArwenWithMockWorld(t, world)
+
+	parentInstance := instanceBuilderMock.CreateAndStoreInstanceMock(t, host, test.ParentAddress, 0, 1000)
+	parentInstance.AddMockMethod("write", func() *contextmock.InstanceMock {
+		instance := contextmock.GetMockInstance(host)
+		_, _ = host.Storage().SetStorage([]byte("a"), []byte("new"))
+		return instance
+	})
+	world.AcctMap.GetAccount(test.ParentAddress).Storage["a"] = []byte("old")
+
+	input := test.CreateTestContractCallInputBuilder().
+		WithCallerAddr(test.UserAddress).
+		WithRecipientAddr(test.ParentAddress).
+		WithFunction("write").
+		WithGasProvided(1000).
+		Build()
+
+	vmOutput, err := host.RunSmartContractCall(input)
+	require.Nil(t, err)
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+
+	touched := host.GetTouchedAccounts()
+	require.Equal(t, [][]byte{test.ParentAddress}, touched.Written)
+	require.True(t, touched.IsRead(test.ParentAddress))
+}
@@ -0,0 +1,77 @@
+package hosttest
+
+import (
+	"testing"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
+	contextmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/context"
+	worldmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/world"
+	test "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/testcommon"
+	"github.com/ElrondNetwork/elrond-go/core/vmcommon"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTouchedStorageKeys_Disabled(t *testing.T) {
+	world := worldmock.NewMockWorld()
+	host, _ := createTestArwenWithMockWorld(t, world)
+
+	require.Nil(t, host.GetTouchedStorageKeys())
+}
+
+func TestTouchedStorageKeys_ReadAcrossContexts(t *testing.T) {
+	world := worldmock.NewMockWorld()
+	parameters := test.DefaultTestVMHostParameters()
+	parameters.StorageKeyRecordingEnabled = true
+	host := test.DefaultTestArwenWithParameters(t, world, parameters)
+
+	instanceBuilderMock := contextmock.NewInstanceBuilderMock(world)
+	host.Runtime().ReplaceInstanceBuilder(instanceBuilderMock)
+
+	parentInstance := instanceBuilderMock.CreateAndStoreInstanceMock(t, host, test.ParentAddress, 0, 1000)
+	parentInstance.AddMockMethod("read", func() *contextmock.InstanceMock {
+		instance := contextmock.GetMockInstance(host)
+		storage := host.Storage()
+
+		storage.GetStorage([]byte("a"))
+		_, _ = storage.SetStorage([]byte("b"), []byte("new"))
+		storage.GetStorageFromAddress(test.ChildAddress, []byte("c"))
+
+		childInput := test.CreateTestContractCallInputBuilder().
+			WithCallerAddr(test.ParentAddress).
+			WithRecipientAddr(test.ChildAddress).
+			WithFunction("readThenFail").
+			WithGasProvided(100).
+			Build()
+		_, _, _ = host.ExecuteOnDestContext(childInput)
+
+		return instance
+	})
+
+	// the keys read by a reverted execution are still touched
+	childInstance := instanceBuilderMock.CreateAndStoreInstanceMock(t, host, test.ChildAddress, 0, 1000)
+	childInstance.AddMockMethod("readThenFail", func() *contextmock.InstanceMock {
+		instance := contextmock.GetMockInstance(host)
+		host.Storage().GetStorage([]byte("d"))
+		host.Runtime().FailExecution(arwen.ErrSignalError)
+		return instance
+	})
+	world.AcctMap.GetAccount(test.ChildAddress).CodeMetadata = []byte{vmcommon.MetadataReadable, 0}
+
+	input := test.CreateTestContractCallInputBuilder().
+		WithCallerAddr(test.UserAddress).
+		WithRecipientAddr(test.ParentAddress).
+		WithFunction("read").
+		WithGasProvided(1000).
+		Build()
+
+	vmOutput, err := host.RunSmartContractCall(input)
+	require.Nil(t, err)
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+
+	touched := host.GetTouchedStorageKeys()
+	require.True(t, touched.Contains(test.ParentAddress, []byte("a")))
+	require.True(t, touched.Contains(test.ParentAddress, []byte("b")))
+	require.True(t, touched.Contains(test.ChildAddress, []byte("c")))
+	require.True(t, touched.Contains(test.ChildAddress, []byte("d")))
+	require.False(t, touched.Contains(test.ChildAddress, []byte("a")))
+}
@@ -0,0 +1,60 @@
+package hosttest
+
+import (
+	"math/big"
+	"testing"
+
+	contextmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/context"
+	worldmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/world"
+	test "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/testcommon"
+	"github.com/ElrondNetwork/elrond-go/core/vmcommon"
+	"github.com/stretchr/testify/require"
+)
+
+// runTransferAggregationTest executes a parent contract which transfers value
+// to the same destination several times, and returns the transfers received
+// by the destination
+func runTransferAggregationTest(t *testing.T, aggregationEnabled bool) []vmcommon.OutputTransfer {
+	world := worldmock.NewMockWorld()
+	parameters := test.DefaultTestVMHostParameters()
+	parameters.TransferAggregationEnabled = aggregationEnabled
+	host := test.DefaultTestArwenWithParameters(t, world, parameters)
+
+	instanceBuilderMock := contextmock.NewInstanceBuilderMock(world)
+	host.Runtime().ReplaceInstanceBuilder(instanceBuilderMock)
+
+	parentInstance := instanceBuilderMock.CreateAndStoreInstanceMock(t, host, test.ParentAddress, 0, 1000)
+	parentInstance.AddMockMethod("transfer", func() *contextmock.InstanceMock {
+		instance := contextmock.GetMockInstance(host)
+		for i := int64(1); i <= 3; i++ {
+			err := host.Output().Transfer(test.UserAddress, test.ParentAddress, 0, 0, big.NewInt(i), nil, vmcommon.DirectCall)
+			require.Nil(t, err)
+		}
+		return instance
+	})
+
+	input := test.CreateTestContractCallInputBuilder().
+		WithCallerAddr(test.UserAddress).
+		WithRecipientAddr(test.ParentAddress).
+		WithFunction("transfer").
+		WithGasProvided(100000).
+		Build()
+
+	vmOutput, err := host.RunSmartContractCall(input)
+	verify := test.NewVMOutputVerifier(t, vmOutput, err)
+	verify.Ok()
+
+	return vmOutput.OutputAccounts[string(test.UserAddress)].OutputTransfers
+}
+
+func TestTransferAggregation_Disabled(t *testing.T) {
+	transfers := runTransferAggregationTest(t, false)
+	require.Len(t, transfers, 3)
+}
+
+func TestTransferAggregation_Enabled(t *testing.T) {
+	transfers := runTransferAggregationTest(t, true)
+	require.Len(t, transfers, 1)
+	require.Equal(t, big.NewInt(6), transfers[0].Value)
+	require.Equal(t, test.ParentAddress, transfers[0].SenderAddress)
+}
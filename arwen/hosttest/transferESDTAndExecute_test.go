@@ -0,0 +1,110 @@
+package hosttest
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen/elrondapi"
+	contextmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/context"
+	worldmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/world"
+	test "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/testcommon"
+	"github.com/ElrondNetwork/elrond-go/core"
+	"github.com/ElrondNetwork/elrond-go/core/vmcommon"
+	"github.com/ElrondNetwork/elrond-go/data/esdt"
+	"github.com/stretchr/testify/require"
+)
+
+var transferAndExecuteGrandchildAddress = test.MakeTestSCAddress("grandchildSC")
+
+// runTransferESDTAndExecuteTest makes the parent transfer 30 tokens to the
+// given destination and call "receive" on it; the child forwards 10 of them
+// to the grandchild, in the same way
+func runTransferESDTAndExecuteTest(t *testing.T, destination []byte) (*vmcommon.VMOutput, *worldmock.MockWorld) {
+	world := worldmock.NewMockWorld()
+	parameters := test.DefaultTestVMHostParameters()
+	host := test.DefaultTestArwenWithParameters(t, world, parameters)
+
+	err := world.InitBuiltinFunctions(host.GetGasScheduleMap())
+	require.Nil(t, err)
+	host.SetProtocolBuiltinFunctions(world.BuiltinFuncs.GetBuiltinFunctionNames())
+
+	instanceBuilderMock := contextmock.NewInstanceBuilderMock(world)
+	host.Runtime().ReplaceInstanceBuilder(instanceBuilderMock)
+
+	recordPayment := func() {
+		vmInput := host.Runtime().GetVMInput()
+		_, err := host.Storage().SetStorage([]byte("paymentToken"), vmInput.ESDTTokenName)
+		require.Nil(t, err)
+		_, err = host.Storage().SetStorage([]byte("paymentValue"), vmInput.ESDTValue.Bytes())
+		require.Nil(t, err)
+	}
+
+	parentInstance := instanceBuilderMock.CreateAndStoreInstanceMock(t, host, test.ParentAddress, 0, 1000)
+	parentInstance.AddMockMethod("pay", func() *contextmock.InstanceMock {
+		instance := contextmock.GetMockInstance(host)
+		result := elrondapi.TransferESDTAndExecuteWithTypedArgs(host, big.NewInt(30), test.ESDTTestTokenName, destination, 0, 100000, []byte("receive"), nil)
+		if result != 0 {
+			host.Runtime().FailExecution(errChildFailed)
+		}
+		return instance
+	})
+
+	childInstance := instanceBuilderMock.CreateAndStoreInstanceMock(t, host, test.ChildAddress, 0, 1000)
+	childInstance.AddMockMethod("receive", func() *contextmock.InstanceMock {
+		instance := contextmock.GetMockInstance(host)
+		recordPayment()
+		result := elrondapi.TransferESDTAndExecuteWithTypedArgs(host, big.NewInt(10), test.ESDTTestTokenName, transferAndExecuteGrandchildAddress, 0, 50000, []byte("receive"), nil)
+		require.Equal(t, int32(0), result)
+		return instance
+	})
+
+	grandchildInstance := instanceBuilderMock.CreateAndStoreInstanceMock(t, host, transferAndExecuteGrandchildAddress, 0, 1000)
+	grandchildInstance.AddMockMethod("receive", func() *contextmock.InstanceMock {
+		instance := contextmock.GetMockInstance(host)
+		recordPayment()
+		return instance
+	})
+
+	err = world.BuiltinFuncs.SetTokenData(test.ParentAddress, worldmock.MakeTokenKey(test.ESDTTestTokenName, 0), &esdt.ESDigitalToken{
+		Value: big.NewInt(100),
+		Type:  uint32(core.Fungible),
+	})
+	require.Nil(t, err)
+
+	input := test.CreateTestContractCallInputBuilder().
+		WithCallerAddr(test.UserAddress).
+		WithRecipientAddr(test.ParentAddress).
+		WithFunction("pay").
+		WithGasProvided(test.GasProvided).
+		Build()
+
+	vmOutput, err := host.RunSmartContractCall(input)
+	require.Nil(t, err)
+	require.NotNil(t, vmOutput)
+
+	return vmOutput, world
+}
+
+func TestTransferESDTAndExecute_Chain(t *testing.T) {
+	vmOutput, world := runTransferESDTAndExecuteTest(t, test.ChildAddress)
+
+	verify := test.NewVMOutputVerifier(t, vmOutput, nil)
+	verify.
+		Ok().
+		Storage(
+			test.CreateStoreEntry(test.ChildAddress).WithKey([]byte("paymentToken")).WithValue(test.ESDTTestTokenName),
+			test.CreateStoreEntry(test.ChildAddress).WithKey([]byte("paymentValue")).WithValue(big.NewInt(30).Bytes()),
+			test.CreateStoreEntry(transferAndExecuteGrandchildAddress).WithKey([]byte("paymentToken")).WithValue(test.ESDTTestTokenName),
+			test.CreateStoreEntry(transferAndExecuteGrandchildAddress).WithKey([]byte("paymentValue")).WithValue(big.NewInt(10).Bytes()),
+		)
+	requireTokenBalance(t, world, test.ParentAddress, test.ESDTTestTokenName, 70)
+	requireTokenBalance(t, world, test.ChildAddress, test.ESDTTestTokenName, 20)
+	requireTokenBalance(t, world, transferAndExecuteGrandchildAddress, test.ESDTTestTokenName, 10)
+}
+
+func TestTransferESDTAndExecute_DestinationNotContract(t *testing.T) {
+	vmOutput, world := runTransferESDTAndExecuteTest(t, test.UserAddress)
+
+	require.Equal(t, vmcommon.ExecutionFailed, vmOutput.ReturnCode)
+	requireTokenBalance(t, world, test.ParentAddress, test.ESDTTestTokenName, 100)
+}
@@ -0,0 +1,162 @@
+package hosttest
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen/elrondapi"
+	contextmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/context"
+	worldmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/world"
+	test "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/testcommon"
+	"github.com/ElrondNetwork/elrond-go/core/vmcommon"
+	"github.com/stretchr/testify/require"
+)
+
+var newChildCode = []byte("newChildCode....................")
+
+const upgradeAndCallGas = 10000
+
+type upgradeAndCallTest struct {
+	host              arwen.VMHost
+	parentInstance    *contextmock.InstanceMock
+	childAccount      *worldmock.Account
+	function          []byte
+	upgraded          bool
+	gasProvidedToCall uint64
+}
+
+// createUpgradeAndCallTest sets up a parent contract owning an upgradeable
+// child contract which answers with version 1, along with a new code for the
+// child which answers with version 2; the child account has a code hash, so
+// that the instances of its code are kept in the instance pool
+func createUpgradeAndCallTest(t *testing.T) *upgradeAndCallTest {
+	world := worldmock.NewMockWorld()
+	parameters := test.DefaultTestVMHostParameters()
+	parameters.InstancePoolSize = 2
+	host := test.DefaultTestArwenWithParameters(t, world, parameters)
+
+	instanceBuilderMock := contextmock.NewInstanceBuilderMock(world)
+	host.Runtime().ReplaceInstanceBuilder(instanceBuilderMock)
+
+	upgradeTest := &upgradeAndCallTest{
+		host:     host,
+		function: []byte("answer"),
+	}
+	answerWithVersion := func(version int64) func() *contextmock.InstanceMock {
+		return func() *contextmock.InstanceMock {
+			instance := contextmock.GetMockInstance(host)
+			upgradeTest.gasProvidedToCall = host.Runtime().GetVMInput().GasProvided
+			host.Output().Finish(big.NewInt(version).Bytes())
+			return instance
+		}
+	}
+
+	upgradeTest.parentInstance = instanceBuilderMock.CreateAndStoreInstanceMock(t, host, test.ParentAddress, 0, 1000)
+
+	childInstance := instanceBuilderMock.CreateAndStoreInstanceMock(t, host, test.ChildAddress, 0, 1000)
+	childInstance.AddMockMethod("answer", answerWithVersion(1))
+
+	upgradeTest.childAccount = world.AcctMap.GetAccount(test.ChildAddress)
+	upgradeTest.childAccount.CodeHash = []byte("childCodeHash")
+	upgradeTest.childAccount.OwnerAddress = test.ParentAddress
+	upgradeTest.childAccount.CodeMetadata = []byte{vmcommon.MetadataUpgradeable, vmcommon.MetadataPayable}
+
+	newChildInstance := instanceBuilderMock.CreateAndStoreInstanceMock(t, host, newChildCode, 0, 0)
+	newChildInstance.AddMockMethod("init", func() *contextmock.InstanceMock {
+		upgradeTest.upgraded = true
+		host.Metering().UseGas(100)
+		return contextmock.GetMockInstance(host)
+	})
+	newChildInstance.AddMockMethod("answer", answerWithVersion(2))
+
+	return upgradeTest
+}
+
+// addUpgradeChildMethod makes the parent call the child, then upgrade the
+// child and call it again, within the same transaction
+func (upgradeTest *upgradeAndCallTest) addUpgradeChildMethod(t *testing.T, expectedResult int32) {
+	host := upgradeTest.host
+	upgradeTest.parentInstance.AddMockMethod("upgradeChild", func() *contextmock.InstanceMock {
+		instance := contextmock.GetMockInstance(host)
+
+		answerInput := test.CreateTestContractCallInputBuilder().
+			WithCallerAddr(test.ParentAddress).
+			WithRecipientAddr(test.ChildAddress).
+			WithFunction("answer").
+			WithGasProvided(1000).
+			Build()
+		_, _, err := host.ExecuteOnDestContext(answerInput)
+		require.Nil(t, err)
+
+		result := elrondapi.UpgradeAndCallWithTypedArgs(
+			host,
+			upgradeAndCallGas,
+			test.ChildAddress,
+			newChildCode,
+			[]byte{vmcommon.MetadataUpgradeable, 0},
+			big.NewInt(0),
+			upgradeTest.function,
+			nil,
+		)
+		require.Equal(t, expectedResult, result)
+
+		return instance
+	})
+}
+
+func (upgradeTest *upgradeAndCallTest) run() (*vmcommon.VMOutput, error) {
+	input := test.CreateTestContractCallInputBuilder().
+		WithCallerAddr(test.UserAddress).
+		WithRecipientAddr(test.ParentAddress).
+		WithFunction("upgradeChild").
+		WithGasProvided(100000).
+		Build()
+
+	return upgradeTest.host.RunSmartContractCall(input)
+}
+
+func TestUpgradeAndCall_CallsNewCode(t *testing.T) {
+	upgradeTest := createUpgradeAndCallTest(t)
+	defer upgradeTest.host.Runtime().ResetWarmInstance()
+	upgradeTest.addUpgradeChildMethod(t, 0)
+
+	vmOutput, err := upgradeTest.run()
+	verify := test.NewVMOutputVerifier(t, vmOutput, err)
+	verify.Ok().ReturnData(big.NewInt(1).Bytes(), big.NewInt(2).Bytes())
+
+	childOutputAccount := vmOutput.OutputAccounts[string(test.ChildAddress)]
+	require.Equal(t, newChildCode, childOutputAccount.Code)
+	require.Equal(t, test.ParentAddress, childOutputAccount.CodeDeployerAddress)
+
+	// the call is given the gas left after the upgrade, which includes the
+	// 100 gas used by the init function of the new code
+	require.Equal(t, uint64(upgradeAndCallGas-133), upgradeTest.gasProvidedToCall)
+}
+
+func TestUpgradeAndCall_UpgradeNotAllowed(t *testing.T) {
+	upgradeTest := createUpgradeAndCallTest(t)
+	defer upgradeTest.host.Runtime().ResetWarmInstance()
+	upgradeTest.addUpgradeChildMethod(t, 1)
+	upgradeTest.childAccount.CodeMetadata = []byte{0, vmcommon.MetadataPayable}
+
+	vmOutput, err := upgradeTest.run()
+	verify := test.NewVMOutputVerifier(t, vmOutput, err)
+	verify.ReturnCode(vmcommon.ExecutionFailed).
+		ReturnMessageContains(arwen.ErrUpgradeNotAllowed.Error())
+}
+
+func TestUpgradeAndCall_BuiltinFunctionRefusedBeforeUpgrade(t *testing.T) {
+	upgradeTest := createUpgradeAndCallTest(t)
+	defer upgradeTest.host.Runtime().ResetWarmInstance()
+	upgradeTest.host.SetProtocolBuiltinFunctions(vmcommon.FunctionNames{"builtin": {}})
+	upgradeTest.function = []byte("builtin")
+	upgradeTest.addUpgradeChildMethod(t, 1)
+
+	vmOutput, err := upgradeTest.run()
+	verify := test.NewVMOutputVerifier(t, vmOutput, err)
+	verify.ReturnCode(vmcommon.ExecutionFailed).
+		ReturnMessageContains(arwen.ErrBuiltinCallAfterUpgradeDisallowed.Error())
+
+	require.False(t, upgradeTest.upgraded)
+}
@@ -0,0 +1,150 @@
+package hosttest
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
+	contextmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/context"
+	test "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/testcommon"
+	"github.com/ElrondNetwork/elrond-go/core/vmcommon"
+	"github.com/stretchr/testify/require"
+)
+
+type userErrorTracerStub struct {
+	asyncTracerStub
+	reasons []arwen.UserErrorReason
+}
+
+func (tracer *userErrorTracerStub) OnUserError(reason arwen.UserErrorReason) {
+	tracer.reasons = append(tracer.reasons, reason)
+}
+
+func createTestArwenWithUserErrorDebug(t *testing.T, code []byte) arwen.VMHost {
+	blockchainHook := &contextmock.BlockchainHookStub{}
+	blockchainHook.GetUserAccountCalled = func(address []byte) (vmcommon.UserAccountHandler, error) {
+		return &contextmock.StubAccount{}, nil
+	}
+	blockchainHook.GetCodeCalled = func(account vmcommon.UserAccountHandler) []byte {
+		return code
+	}
+
+	parameters := test.DefaultTestVMHostParameters()
+	parameters.UserErrorDebugEnabled = true
+	host := test.DefaultTestArwenWithParameters(t, blockchainHook, parameters)
+
+	return host
+}
+
+func TestUserError_ReasonCaptured(t *testing.T) {
+	code := test.GetTestSCCode("breakpoint", "../../")
+	host, _ := test.DefaultTestArwenForCall(t, code, nil)
+	tracer := &userErrorTracerStub{}
+	host.SetAsyncTracer(tracer)
+
+	input := test.DefaultTestContractCallInput()
+	input.GasProvided = 100000
+	input.Function = "testFunc"
+	input.Arguments = [][]byte{{1}}
+
+	vmOutput, err := host.RunSmartContractCall(input)
+	verify := test.NewVMOutputVerifier(t, vmOutput, err)
+	verify.
+		ReturnCode(vmcommon.UserError).
+		ReturnMessage("exit here")
+
+	reason := host.Runtime().GetUserErrorReason()
+	require.NotNil(t, reason)
+	require.Equal(t, "exit here", reason.Message)
+	require.Equal(t, input.RecipientAddr, reason.ContractAddress)
+	require.Equal(t, "testFunc", reason.Function)
+	require.Equal(t, int32(3), reason.FunctionIndex)
+	require.Empty(t, reason.CallStack)
+	require.Empty(t, vmOutput.Logs)
+	require.Equal(t, []arwen.UserErrorReason{*reason}, tracer.reasons)
+}
+
+func TestUserError_NotCapturedWithoutTracer(t *testing.T) {
+	code := test.GetTestSCCode("breakpoint", "../../")
+	host, _ := test.DefaultTestArwenForCall(t, code, nil)
+
+	input := test.DefaultTestContractCallInput()
+	input.GasProvided = 100000
+	input.Function = "testFunc"
+	input.Arguments = [][]byte{{1}}
+
+	vmOutput, err := host.RunSmartContractCall(input)
+	verify := test.NewVMOutputVerifier(t, vmOutput, err)
+	verify.
+		ReturnCode(vmcommon.UserError).
+		ReturnMessage("exit here")
+	require.Nil(t, host.Runtime().GetUserErrorReason())
+
+	// a tracer which is not notified of user errors does not need them either
+	host.SetAsyncTracer(&asyncTracerStub{})
+	_, err = host.RunSmartContractCall(input)
+	require.Nil(t, err)
+	require.Nil(t, host.Runtime().GetUserErrorReason())
+}
+
+func TestUserError_NoReasonOnSuccess(t *testing.T) {
+	code := test.GetTestSCCode("breakpoint", "../../")
+	host, _ := test.DefaultTestArwenForCall(t, code, nil)
+	host.SetAsyncTracer(&userErrorTracerStub{})
+
+	input := test.DefaultTestContractCallInput()
+	input.GasProvided = 100000
+	input.Function = "testFunc"
+	input.Arguments = [][]byte{{1}}
+
+	_, err := host.RunSmartContractCall(input)
+	require.Nil(t, err)
+	require.NotNil(t, host.Runtime().GetUserErrorReason())
+
+	input.Arguments = [][]byte{{15}}
+	vmOutput, err := host.RunSmartContractCall(input)
+	verify := test.NewVMOutputVerifier(t, vmOutput, err)
+	verify.Ok()
+	require.Nil(t, host.Runtime().GetUserErrorReason())
+	require.Empty(t, vmOutput.Logs)
+}
+
+func TestUserError_DebugMode(t *testing.T) {
+	code := test.GetTestSCCode("breakpoint", "../../")
+	host := createTestArwenWithUserErrorDebug(t, code)
+	tracer := &userErrorTracerStub{}
+	host.SetAsyncTracer(tracer)
+
+	input := test.DefaultTestContractCallInput()
+	input.GasProvided = 100000
+	input.Function = "testFunc"
+	input.Arguments = [][]byte{{1}}
+
+	vmOutput, err := host.RunSmartContractCall(input)
+	verify := test.NewVMOutputVerifier(t, vmOutput, err)
+	verify.ReturnCode(vmcommon.UserError)
+
+	expectedCallStack := []arwen.UserErrorFrame{
+		{ContractAddress: input.RecipientAddr, Function: "testFunc"},
+		{Function: "signalError", IsHostFunction: true},
+	}
+	reason := host.Runtime().GetUserErrorReason()
+	require.Equal(t, expectedCallStack, reason.CallStack)
+
+	require.Len(t, vmOutput.Logs, 1)
+	require.Equal(t, reason.LogEntry(), vmOutput.Logs[0])
+	require.Equal(t, []byte(arwen.SignalErrorLogIdentifier), vmOutput.Logs[0].Identifier)
+	require.Equal(t, input.RecipientAddr, vmOutput.Logs[0].Address)
+	require.Equal(t, []byte("exit here"), vmOutput.Logs[0].Data)
+	expectedTopics := [][]byte{
+		[]byte("testFunc"),
+		{0, 0, 0, 3},
+		[]byte(hex.EncodeToString(input.RecipientAddr) + ".testFunc"),
+		[]byte("host.signalError"),
+	}
+	require.Equal(t, expectedTopics, vmOutput.Logs[0].Topics)
+
+	require.Len(t, tracer.reasons, 1)
+	require.Equal(t, *reason, tracer.reasons[0])
+	require.Empty(t, tracer.events)
+}
@@ -0,0 +1,129 @@
+package arwen
+
+// WasmPageSize is the size of a page of the linear memory of a Wasmer instance
+const WasmPageSize = 65536
+
+// wasmMemorySectionID is the id of the section declaring the linear memory
+const wasmMemorySectionID = 5
+
+// InstanceMemoryLimits bounds the linear memory of the Wasmer instances. An
+// instance declaring fewer than InitialMemoryPages pages is grown to that
+// many when created. An instance may never hold more than MaxMemoryPages
+// pages, nor grow by more than MaxMemoryGrow pages during a contract call;
+// memory.grow fails in the contract past MaxMemoryPages, while the growth is
+// checked when the call returns. A value of 0 leaves the respective limit
+// unset.
+type InstanceMemoryLimits struct {
+	InitialMemoryPages uint32
+	MaxMemoryPages     uint32
+	MaxMemoryGrow      uint32
+}
+
+// RuntimeConfig is the configuration applied by the runtime to the Wasmer
+// instances of the contracts
+type RuntimeConfig struct {
+	MemoryLimits InstanceMemoryLimits
+	Validation   RuntimeValidationConfig
+}
+
+// CheckPages returns the error of an instance holding the given number of
+// pages, having started the contract call with pagesAtStart pages
+func (limits InstanceMemoryLimits) CheckPages(pagesAtStart uint32, pages uint32) error {
+	if limits.MaxMemoryPages > 0 && pages > limits.MaxMemoryPages {
+		return ErrMemoryPagesLimitExceeded
+	}
+	if limits.MaxMemoryGrow > 0 && pages > pagesAtStart && pages-pagesAtStart > limits.MaxMemoryGrow {
+		return ErrMemoryGrowLimitExceeded
+	}
+	return nil
+}
+
+// CapModuleMemory returns the given Wasm module with the maximum of its memory
+// lowered to MaxMemoryPages, so that memory.grow fails in the contract as soon
+// as it would exceed the limit. A memory whose minimum already exceeds the
+// limit keeps its minimum as maximum, to be refused once instantiated.
+// Malformed modules are returned unchanged, for the compiler to reject.
+func (limits InstanceMemoryLimits) CapModuleMemory(code []byte) []byte {
+	if limits.MaxMemoryPages == 0 || len(code) < wasmHeaderLength {
+		return code
+	}
+
+	reader := &wasmReader{data: code, offset: wasmHeaderLength}
+	for reader.offset < len(reader.data) {
+		sectionStart := reader.offset
+		sectionID, ok := reader.readByte()
+		if !ok {
+			return code
+		}
+		sectionSize, ok := reader.readUint32()
+		if !ok {
+			return code
+		}
+
+		sectionEnd := reader.offset + int(sectionSize)
+		if sectionEnd > len(reader.data) {
+			return code
+		}
+		if sectionID == wasmMemorySectionID {
+			section := &wasmReader{data: reader.data[:sectionEnd], offset: reader.offset}
+			content, ok := limits.capMemorySection(section)
+			if !ok {
+				return code
+			}
+
+			capped := make([]byte, 0, len(code)+len(content))
+			capped = append(capped, code[:sectionStart]...)
+			capped = append(capped, wasmMemorySectionID)
+			capped = append(capped, U64ToLEB128(uint64(len(content)))...)
+			capped = append(capped, content...)
+			return append(capped, code[sectionEnd:]...)
+		}
+		reader.offset = sectionEnd
+	}
+
+	return code
+}
+
+// capMemorySection encodes anew the content of the memory section, with the
+// maximum of each memory capped to MaxMemoryPages
+func (limits InstanceMemoryLimits) capMemorySection(section *wasmReader) ([]byte, bool) {
+	numMemories, ok := section.readUint32()
+	if !ok {
+		return nil, false
+	}
+
+	content := U64ToLEB128(uint64(numMemories))
+	for i := uint32(0); i < numMemories; i++ {
+		flags, ok := section.readByte()
+		if !ok {
+			return nil, false
+		}
+		minPages, ok := section.readUint32()
+		if !ok {
+			return nil, false
+		}
+		maxPages := limits.MaxMemoryPages
+		if flags&0x01 != 0 {
+			declaredMaxPages, ok := section.readUint32()
+			if !ok {
+				return nil, false
+			}
+			if declaredMaxPages < maxPages {
+				maxPages = declaredMaxPages
+			}
+		}
+		if minPages > maxPages {
+			maxPages = minPages
+		}
+
+		content = append(content, flags|0x01)
+		content = append(content, U64ToLEB128(uint64(minPages))...)
+		content = append(content, U64ToLEB128(uint64(maxPages))...)
+	}
+
+	if section.offset != len(section.data) {
+		return nil, false
+	}
+
+	return content, true
+}
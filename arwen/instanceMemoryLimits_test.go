@@ -0,0 +1,43 @@
+package arwen
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func makeModuleWithMemory(limits ...byte) []byte {
+	code := append([]byte{}, wasmHeader...)
+	// type section: () -> ()
+	code = append(code, 0x01, 0x04, 0x01, 0x60, 0x00, 0x00)
+	code = append(code, wasmMemorySectionID, byte(len(limits)+1), 0x01)
+	return append(code, limits...)
+}
+
+func TestInstanceMemoryLimits_CapModuleMemory(t *testing.T) {
+	limits := InstanceMemoryLimits{MaxMemoryPages: 8}
+
+	// no maximum declared
+	capped := limits.CapModuleMemory(makeModuleWithMemory(0x00, 0x02))
+	require.Equal(t, makeModuleWithMemory(0x01, 0x02, 0x08), capped)
+
+	// a larger maximum declared
+	capped = limits.CapModuleMemory(makeModuleWithMemory(0x01, 0x02, 0x10))
+	require.Equal(t, makeModuleWithMemory(0x01, 0x02, 0x08), capped)
+
+	// a smaller maximum declared is kept
+	capped = limits.CapModuleMemory(makeModuleWithMemory(0x01, 0x02, 0x04))
+	require.Equal(t, makeModuleWithMemory(0x01, 0x02, 0x04), capped)
+
+	// a minimum past the limit is left to be refused on instantiation
+	capped = limits.CapModuleMemory(makeModuleWithMemory(0x00, 0x0a))
+	require.Equal(t, makeModuleWithMemory(0x01, 0x0a, 0x0a), capped)
+}
+
+func TestInstanceMemoryLimits_CapModuleMemory_Unchanged(t *testing.T) {
+	code := makeModuleWithMemory(0x00, 0x02)
+	require.Equal(t, code, InstanceMemoryLimits{}.CapModuleMemory(code))
+
+	malformed := makeModuleWithMemory(0x01, 0x02)
+	require.Equal(t, malformed, InstanceMemoryLimits{MaxMemoryPages: 8}.CapModuleMemory(malformed))
+}
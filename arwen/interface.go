@@ -19,6 +19,13 @@ type StateStack interface {
 	ClearStateStack()
 }
 
+// KeyedSnapshots defines the functionality for working with context snapshots identified by a key
+type KeyedSnapshots interface {
+	TakeSnapshot(key string)
+	RevertToSnapshot(key string) error
+	DiscardSnapshot(key string)
+}
+
 // CallArgsParser defines the functionality to parse transaction data for a smart contract call
 type CallArgsParser interface {
 	ParseData(data string) (string, [][]byte, error)
@@ -28,6 +35,7 @@ type CallArgsParser interface {
 // VMHost defines the functionality for working with the VM
 type VMHost interface {
 	vmcommon.VMExecutionHandler
+	RunSmartContractQuery(input *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error)
 	Crypto() crypto.VMCrypto
 	Blockchain() BlockchainContext
 	Runtime() RuntimeContext
@@ -40,11 +48,26 @@ type VMHost interface {
 	IsDynamicGasLockingEnabled() bool
 	IsArwenV3Enabled() bool
 	IsESDTFunctionsEnabled() bool
+	IsMultiAsyncCallGroupsEnabled() bool
+	IsCallbackValidationEnabled() bool
+	IsRefundReceiptsEnabled() bool
+	IsStorageLoadCacheEnabled() bool
+	IsAsyncCallsFixEnabled() bool
+	IsFailedExecutionGasEnabled() bool
+	IsEventLogValidationEnabled() bool
+	IsReadOnlyEnforcementEnabled() bool
+	IsAsyncCallGasCapEnabled() bool
+	IsLogEntryGasEnabled() bool
+	IsGroupCallbacksEnabled() bool
+	IsAsyncValueTransferEnabled() bool
+	IsCallbackGasEscrowEnabled() bool
 
 	ExecuteESDTTransfer(destination []byte, sender []byte, tokenIdentifier []byte, nonce uint64, value *big.Int, callType vmcommon.CallType) (*vmcommon.VMOutput, uint64, error)
+	ExecuteMultiESDTTransfer(destination []byte, sender []byte, transfers []*ESDTTransfer, callType vmcommon.CallType) (*vmcommon.VMOutput, uint64, error)
 	CreateNewContract(input *vmcommon.ContractCreateInput) ([]byte, error)
 	ExecuteOnSameContext(input *vmcommon.ContractCallInput) (*AsyncContextInfo, error)
 	ExecuteOnDestContext(input *vmcommon.ContractCallInput) (*vmcommon.VMOutput, *AsyncContextInfo, error)
+	ExecuteOnDestContextWithESDTTransfers(input *vmcommon.ContractCallInput, transfers []*ESDTTransfer) (*vmcommon.VMOutput, *AsyncContextInfo, error)
 	GetAPIMethods() *wasmer.Imports
 	GetProtocolBuiltinFunctions() vmcommon.FunctionNames
 	SetProtocolBuiltinFunctions(vmcommon.FunctionNames)
@@ -52,12 +75,40 @@ type VMHost interface {
 	AreInSameShard(leftAddress []byte, rightAddress []byte) bool
 
 	GetGasScheduleMap() config.GasScheduleMap
+	SetGasSchedule(newGasSchedule config.GasScheduleMap) error
 	GetContexts() (BigIntContext, BlockchainContext, MeteringContext, OutputContext, RuntimeContext, StorageContext)
 	SetRuntimeContext(runtime RuntimeContext)
+	MigrateLegacyAsyncData(address []byte) (*AsyncMigrationResult, error)
+	SetAsyncTracer(tracer AsyncTracer)
+	RegisterDeferredCall(call *DeferredCall) error
+	RunDeferredCalls(address []byte) (*vmcommon.VMOutput, error)
+	GetExecutionWitness() *ExecutionWitness
+	GetHookCacheStats() *HookCacheStats
+	GetGasProfile() *GasProfile
+	GetExecutionProfile() *ExecutionProfile
+	GetAsyncCallTree() *AsyncCallTree
+	GetTouchedAccounts() *TouchedAccounts
+	GetStorageDiff() *StorageDiff
+	GetRuntimeConfig() RuntimeConfig
+	GetDeploymentLimits() DeploymentLimits
+	GetTouchedStorageKeys() *TouchedStorageKeys
+	EstimateAsyncCallGas(caller []byte, destination []byte, data []byte, value *big.Int) (*AsyncCallGasEstimate, error)
+	RegisterBuiltinOutputProcessor(functionName string, processor BuiltinOutputProcessor) error
 
 	InitState()
 }
 
+// BuiltinOutputProcessor defines how the host interprets a call to a built-in
+// function: the account receiving what the built-in function transfers, the
+// ESDT payment of the contract executed after it, the normalization of its
+// VMOutput on intra-shard calls and the payments to notify to the recipients
+type BuiltinOutputProcessor interface {
+	Recipient(input *vmcommon.ContractCallInput) []byte
+	FillPostBuiltinInput(input *vmcommon.ContractCallInput, postBuiltinInput *vmcommon.ContractCallInput)
+	ProcessOutput(input *vmcommon.ContractCallInput, vmOutput *vmcommon.VMOutput)
+	PaymentNotifications(input *vmcommon.ContractCallInput) []*PaymentNotification
+}
+
 // BlockchainContext defines the functionality needed for interacting with the blockchain context
 type BlockchainContext interface {
 	StateStack
@@ -78,6 +129,7 @@ type BlockchainContext interface {
 	CurrentTimeStamp() uint64
 	CurrentRandomSeed() []byte
 	LastRandomSeed() []byte
+	CurrentBlockInfo() *BlockInfo
 	IncreaseNonce(addr []byte)
 	GetCodeHash(addr []byte) []byte
 	GetCode(addr []byte) ([]byte, error)
@@ -91,7 +143,9 @@ type BlockchainContext interface {
 	GetCompiledCode(codeHash []byte) (bool, []byte)
 	GetESDTToken(address []byte, tokenID []byte, nonce uint64) (*esdt.ESDigitalToken, error)
 	GetUserAccount(address []byte) (vmcommon.UserAccountHandler, error)
+	GetAllState(address []byte) (map[string][]byte, error)
 	ProcessBuiltInFunction(input *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error)
+	GetGasModifier(address []byte) (GasModifier, bool)
 	GetSnapshot() int
 	RevertToSnapshot(snapshot int)
 }
@@ -104,6 +158,8 @@ type RuntimeContext interface {
 	SetCustomCallFunction(callFunction string)
 	GetVMInput() *vmcommon.VMInput
 	SetVMInput(vmInput *vmcommon.VMInput)
+	GetESDTTransfers() []*ESDTTransfer
+	SetESDTTransfers(transfers []*ESDTTransfer)
 	GetSCAddress() []byte
 	SetSCAddress(scAddress []byte)
 	GetSCCode() ([]byte, error)
@@ -129,11 +185,30 @@ type RuntimeContext interface {
 	IsFunctionImported(name string) bool
 	IsWarmInstance() bool
 	ResetWarmInstance()
+	ClearCompiledCode()
+	RegisterCodeUpgrade(address []byte, code []byte)
+	EnableInstancePool(size uint64)
+	EnableCompiledCodeDiskCache(directory string, size uint64) error
+	SetInstanceMemoryLimits(limits InstanceMemoryLimits)
+	GetInstanceMemoryLimits() InstanceMemoryLimits
+	CheckInstanceMemoryLimits() error
+	MemoryAccountant() *MemoryAccountant
+	SetValidationConfig(config RuntimeValidationConfig)
+	GetValidationConfig() RuntimeValidationConfig
+	SetFunctionActivationMap(activationMap FunctionActivationMap)
+	ManagedTypes() ManagedTypesContainer
+	NextRandomCounter() uint64
+	InterruptExecution()
 	ReadOnly() bool
 	SetReadOnly(readOnly bool)
 	StartWasmerInstance(contract []byte, gasLimit uint64, newCode bool) error
 	CleanWasmerInstance()
 	SetMaxInstanceCount(uint64)
+	SetMaxExecutionStackDepth(maxDepth uint64)
+	GetExecutionStackDepth() uint64
+	CheckExecutionStackDepth() error
+	SetDebugStop(stop DebugStop, callback DebugStepCallback)
+	TakeDebugStep() bool
 	VerifyContractCode() error
 	GetInstance() wasmer.InstanceHandler
 	GetInstanceExports() wasmer.ExportsMap
@@ -152,10 +227,22 @@ type RuntimeContext interface {
 
 	AddError(err error, otherInfo ...string)
 	GetAllErrors() error
+	GetUserErrorReason() *UserErrorReason
+	SetUserErrorCaptureEnabled(enabled bool)
+	EnableUserErrorDebug()
+	CaptureOutOfGasReceipt()
+	GetOutOfGasReceipt() *OutOfGasReceipt
+	EnableOutOfGasReceipts()
+	CaptureCallStackTrace(message string)
+	GetCallStackTrace() *CallStackTrace
+	DiscardHandledCallStackTrace()
+	EnableCallStackTraces()
 
 	// TODO remove after implementing proper mocking of Wasmer instances; this is
 	// used for tests only
 	ReplaceInstanceBuilder(builder InstanceBuilder)
+	SetExecutor(executor Executor)
+	GetExecutorName() string
 }
 
 // BigIntContext defines the functionality needed for interacting with the big int context
@@ -166,11 +253,30 @@ type BigIntContext interface {
 	GetOne(id int32) *big.Int
 	GetTwo(id1, id2 int32) (*big.Int, *big.Int)
 	GetThree(id1, id2, id3 int32) (*big.Int, *big.Int, *big.Int)
+	MemoryUsage() uint64
+}
+
+// ManagedTypesContainer defines the functionality needed for interacting with
+// the values held under handles by the runtime context, other than the big ints
+type ManagedTypesContainer interface {
+	StateStack
+
+	PutBigFloat(value *big.Float) int32
+	GetBigFloat(handle int32) (*big.Float, error)
+	NewManagedMap() int32
+	GetManagedMap(handle int32) (*ManagedMap, error)
+	NewManagedBuffer(data []byte) int32
+	GetManagedBuffer(handle int32) ([]byte, error)
+	SetManagedBuffer(handle int32, data []byte) error
+	PutCurvePoint(curveID int32, encoding []byte) int32
+	GetCurvePoint(handle int32) (*CurvePoint, error)
+	MemoryUsage() uint64
 }
 
 // OutputContext defines the functionality needed for interacting with the output context
 type OutputContext interface {
 	StateStack
+	KeyedSnapshots
 	PopMergeActiveState()
 	CensorVMOutput()
 	AddToActiveState(rightOutput *vmcommon.VMOutput)
@@ -179,22 +285,32 @@ type OutputContext interface {
 	GetOutputAccounts() map[string]*vmcommon.OutputAccount
 	DeleteOutputAccount(address []byte)
 	WriteLog(address []byte, topics [][]byte, data []byte)
+	WriteContractLog(address []byte, topics [][]byte, data []byte)
+	WriteEventLog(address []byte, event *EventLog) error
 	TransferValueOnly(destination []byte, sender []byte, value *big.Int, checkPayable bool) error
 	Transfer(destination []byte, sender []byte, gasLimit uint64, gasLocked uint64, value *big.Int, input []byte, callType vmcommon.CallType) error
 	TransferESDT(destination []byte, sender []byte, tokenIdentifier []byte, nonce uint64, value *big.Int, callInput *vmcommon.ContractCallInput) (uint64, error)
+	TransferMultiESDT(destination []byte, sender []byte, transfers []*ESDTTransfer, callInput *vmcommon.ContractCallInput) (uint64, error)
 	SelfDestruct(address []byte, beneficiary []byte)
 	GetRefund() uint64
 	SetRefund(refund uint64)
+	AddRefund(category RefundCategory, gas uint64)
+	GetItemizedRefunds() ItemizedRefunds
 	ReturnCode() vmcommon.ReturnCode
 	SetReturnCode(returnCode vmcommon.ReturnCode)
 	ReturnMessage() string
 	SetReturnMessage(message string)
 	ReturnData() [][]byte
 	ClearReturnData()
-	Finish(data []byte)
+	Finish(data []byte) error
 	PrependFinish(data []byte)
+	GetReturnDataBudget() int64
 	GetVMOutput() *vmcommon.VMOutput
 	AddTxValueToAccount(address []byte, value *big.Int)
+	CheckValueConservation() error
+	GetOrderedVMOutput(vmOutput *vmcommon.VMOutput) *OrderedVMOutput
+	AddReceipt(receipt LogReceipt)
+	AttachReceipt(vmOutput *vmcommon.VMOutput, receipt LogReceipt)
 	DeployCode(input CodeDeployInput)
 	CreateVMOutputInCaseOfError(err error) *vmcommon.VMOutput
 }
@@ -210,7 +326,12 @@ type MeteringContext interface {
 	UseGas(gas uint64)
 	FreeGas(gas uint64)
 	RestoreGas(gas uint64)
+	RestoreLockedGas(gas uint64)
+	Snapshot() int
+	RestoreToSnapshot(snapshot int)
+	DiscardSnapshot(snapshot int)
 	GasLeft() uint64
+	GetGasLeftOfCallers() []uint64
 	GasUsedForExecution() uint64
 	GasSpentByContract() uint64
 	GetGasForExecution() uint64
@@ -222,12 +343,20 @@ type MeteringContext interface {
 	DeductInitialGasForDirectDeployment(input CodeDeployInput) error
 	DeductInitialGasForIndirectDeployment(input CodeDeployInput) error
 	ComputeGasLockedForAsync() uint64
+	EstimateAsyncCallGas(callerCodeSize uint64, destinationCodeSize uint64, dataLength uint64) *AsyncCallGasEstimate
 	UseGasForAsyncStep() error
 	UseGasBounded(gasToUse uint64) error
+	EscrowGasForCallback(hasCallback bool) (uint64, error)
 	GetGasLocked() uint64
 	UpdateGasStateOnSuccess(vmOutput *vmcommon.VMOutput) error
 	UpdateGasStateOnFailure(vmOutput *vmcommon.VMOutput)
 	TrackGasUsedByBuiltinFunction(builtinInput *vmcommon.ContractCallInput, builtinOutput *vmcommon.VMOutput, postBuiltinInput *vmcommon.ContractCallInput)
+	EnableGasProfiling()
+	EnableAudit()
+	EnableHostFunctionTracking()
+	GetLastHostFunction() string
+	ResetGasProfile()
+	GetGasProfile() *GasProfile
 }
 
 // StorageStatus defines the states the storage can be in
@@ -250,14 +379,24 @@ const (
 // StorageContext defines the functionality needed for interacting with the storage context
 type StorageContext interface {
 	StateStack
+	KeyedSnapshots
 
+	BeginTransaction()
+	Commit()
+	Revert()
 	SetAddress(address []byte)
 	GetStorageUpdates(address []byte) map[string]*vmcommon.StorageUpdate
 	GetStorageFromAddress(address []byte, key []byte) []byte
 	GetStorage(key []byte) []byte
+	GetStorageFromContract(address []byte, key []byte) ([]byte, error)
+	SetTransientStorage(key []byte, value []byte) error
+	GetTransientStorage(key []byte) []byte
+	UseGasForStorageLoad(key []byte, loadCost uint64)
+	UseGasForStorageLoadFromAddress(address []byte, key []byte, loadCost uint64)
 	GetStorageUnmetered(key []byte) []byte
 	SetStorage(key []byte, value []byte) (StorageStatus, error)
 	SetProtectedStorage(key []byte, value []byte) (StorageStatus, error)
+	GetKeysWithPrefix(prefix []byte, startAfter []byte, maxResults uint64) ([][]byte, error)
 }
 
 // AsyncCallInfoHandler defines the functionality for working with AsyncCallInfo
@@ -269,8 +408,24 @@ type AsyncCallInfoHandler interface {
 	GetValueBytes() []byte
 }
 
-// InstanceBuilder defines the functionality needed to create Wasmer instances
+// InstanceBuilder defines the functionality needed to create the instances of the contracts
 type InstanceBuilder interface {
-	NewInstanceWithOptions(contractCode []byte, options wasmer.CompilationOptions) (wasmer.InstanceHandler, error)
-	NewInstanceFromCompiledCodeWithOptions(compiledCode []byte, options wasmer.CompilationOptions) (wasmer.InstanceHandler, error)
+	NewInstanceWithOptions(contractCode []byte, options CompilationOptions) (Instance, error)
+	NewInstanceFromCompiledCodeWithOptions(compiledCode []byte, options CompilationOptions) (Instance, error)
+}
+
+// Instance defines the functionality of an instance of a contract, as created
+// by an Executor; the Wasmer 1.x instances are the default implementation
+type Instance = wasmer.InstanceHandler
+
+// Executor abstracts the engine executing the contracts, which creates their
+// instances from bytecode or from code it compiled previously; compiled code
+// is specific to the engine and to the version which produced it
+type Executor interface {
+	InstanceBuilder
+	Name() string
+	Version() string
 }
+
+// ExecutorFactory creates the Executor used by a VMHost
+type ExecutorFactory func() (Executor, error)
@@ -0,0 +1,62 @@
+package arwen
+
+import (
+	"math/big"
+
+	"github.com/ElrondNetwork/elrond-go/core/vmcommon"
+)
+
+// LogsTruncatedLogIdentifier is the identifier of the log entry which marks
+// the VMOutput of an execution whose contracts wrote more logs than retained
+const LogsTruncatedLogIdentifier = "logsTruncated"
+
+// LogRetentionPolicy caps the logs written by contracts during a transaction,
+// across all its nested executions. A log entry which would exceed either cap
+// is dropped, as are all the following ones, regardless of their size, so
+// that the retained logs are always a prefix of the logs written. A value of
+// 0 leaves the respective cap unlimited.
+type LogRetentionPolicy struct {
+	MaxEntries uint64
+	MaxBytes   uint64
+}
+
+// IsUnlimited returns true if the LogRetentionPolicy retains all the logs
+func (policy LogRetentionPolicy) IsUnlimited() bool {
+	return policy.MaxEntries == 0 && policy.MaxBytes == 0
+}
+
+// Retains returns true if a transaction which already retained the given
+// number of log entries and bytes may also retain a log entry of the given size
+func (policy LogRetentionPolicy) Retains(numEntries uint64, numBytes uint64, entrySize uint64) bool {
+	if policy.MaxEntries > 0 && numEntries+1 > policy.MaxEntries {
+		return false
+	}
+	if policy.MaxBytes > 0 && numBytes+entrySize > policy.MaxBytes {
+		return false
+	}
+	return true
+}
+
+// LogEntrySize returns the number of bytes of a log entry accounted by the
+// LogRetentionPolicy, which are the bytes of its topics and of its data
+func LogEntrySize(topics [][]byte, data []byte) uint64 {
+	size := uint64(len(data))
+	for _, topic := range topics {
+		size += uint64(len(topic))
+	}
+	return size
+}
+
+// LogsTruncatedLogEntry returns the log entry which marks the VMOutput of an
+// execution of the contract at the given address whose logs were truncated;
+// its topics are the number of log entries and the number of bytes dropped
+func LogsTruncatedLogEntry(address []byte, droppedEntries uint64, droppedBytes uint64) *vmcommon.LogEntry {
+	return &vmcommon.LogEntry{
+		Identifier: []byte(LogsTruncatedLogIdentifier),
+		Address:    address,
+		Topics: [][]byte{
+			big.NewInt(0).SetUint64(droppedEntries).Bytes(),
+			big.NewInt(0).SetUint64(droppedBytes).Bytes(),
+		},
+	}
+}
@@ -0,0 +1,42 @@
+package arwen
+
+import (
+	"bytes"
+)
+
+// ManagedBufferSlice returns the given number of bytes of the managed buffer
+// data, starting at the given position, or ErrBadBounds if they exceed it
+func ManagedBufferSlice(data []byte, start int32, length int32) ([]byte, error) {
+	if start < 0 || length < 0 || int64(start)+int64(length) > int64(len(data)) {
+		return nil, ErrBadBounds
+	}
+
+	return data[start : start+length], nil
+}
+
+// ManagedBufferFind returns the position of the first occurrence of the
+// pattern in the managed buffer data, searching from the given position, or
+// -1 if there is none
+func ManagedBufferFind(data []byte, pattern []byte, start int32) (int32, error) {
+	if start < 0 || int(start) > len(data) {
+		return 0, ErrBadBounds
+	}
+
+	index := bytes.Index(data[start:], pattern)
+	if index < 0 {
+		return -1, nil
+	}
+
+	return start + int32(index), nil
+}
+
+// ManagedBufferSplit splits the managed buffer data around the given
+// separator, which must not be empty, into at most maxPieces pieces; the last
+// piece holds the unsplit remainder
+func ManagedBufferSplit(data []byte, separator []byte, maxPieces int32) ([][]byte, error) {
+	if len(separator) == 0 || maxPieces <= 0 {
+		return nil, ErrArgOutOfRange
+	}
+
+	return bytes.SplitN(data, separator, int(maxPieces)), nil
+}
@@ -0,0 +1,60 @@
+package arwen
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestManagedBuffer_Slice(t *testing.T) {
+	data := []byte("abcdef")
+
+	slice, err := ManagedBufferSlice(data, 2, 3)
+	require.Nil(t, err)
+	require.Equal(t, []byte("cde"), slice)
+
+	slice, err = ManagedBufferSlice(data, 6, 0)
+	require.Nil(t, err)
+	require.Empty(t, slice)
+
+	_, err = ManagedBufferSlice(data, 4, 3)
+	require.Equal(t, ErrBadBounds, err)
+	_, err = ManagedBufferSlice(data, -1, 1)
+	require.Equal(t, ErrBadBounds, err)
+	_, err = ManagedBufferSlice(data, 1, -1)
+	require.Equal(t, ErrBadBounds, err)
+}
+
+func TestManagedBuffer_Find(t *testing.T) {
+	data := []byte("abcabc")
+
+	index, err := ManagedBufferFind(data, []byte("bc"), 0)
+	require.Nil(t, err)
+	require.Equal(t, int32(1), index)
+
+	index, err = ManagedBufferFind(data, []byte("bc"), 2)
+	require.Nil(t, err)
+	require.Equal(t, int32(4), index)
+
+	index, err = ManagedBufferFind(data, []byte("x"), 0)
+	require.Nil(t, err)
+	require.Equal(t, int32(-1), index)
+
+	_, err = ManagedBufferFind(data, []byte("a"), 7)
+	require.Equal(t, ErrBadBounds, err)
+}
+
+func TestManagedBuffer_Split(t *testing.T) {
+	pieces, err := ManagedBufferSplit([]byte("a,b,,c"), []byte(","), 10)
+	require.Nil(t, err)
+	require.Equal(t, [][]byte{[]byte("a"), []byte("b"), []byte(""), []byte("c")}, pieces)
+
+	pieces, err = ManagedBufferSplit([]byte("a,b,c"), []byte(","), 2)
+	require.Nil(t, err)
+	require.Equal(t, [][]byte{[]byte("a"), []byte("b,c")}, pieces)
+
+	_, err = ManagedBufferSplit([]byte("abc"), nil, 2)
+	require.Equal(t, ErrArgOutOfRange, err)
+	_, err = ManagedBufferSplit([]byte("abc"), []byte(","), 0)
+	require.Equal(t, ErrArgOutOfRange, err)
+}
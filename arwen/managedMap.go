@@ -0,0 +1,102 @@
+package arwen
+
+import (
+	"sort"
+)
+
+// managedMapEntryOverhead approximates the memory taken by an entry of a
+// ManagedMap, besides its key and value
+const managedMapEntryOverhead = 64
+
+// ManagedMap is an associative array of bytes to bytes, which contracts use
+// through a handle to hold temporary data. Its keys are sorted when first
+// iterated by index after a change, which makes the iteration deterministic.
+type ManagedMap struct {
+	values     map[string][]byte
+	sortedKeys []string
+}
+
+// NewManagedMap creates an empty ManagedMap
+func NewManagedMap() *ManagedMap {
+	return &ManagedMap{
+		values: make(map[string][]byte),
+	}
+}
+
+// Put sets the value of the given key, adding the key if it is missing
+func (managedMap *ManagedMap) Put(key []byte, value []byte) {
+	_, exists := managedMap.values[string(key)]
+	managedMap.values[string(key)] = append([]byte{}, value...)
+	if !exists {
+		managedMap.sortedKeys = nil
+	}
+}
+
+// Get returns the value of the given key, which is empty if the key is missing
+func (managedMap *ManagedMap) Get(key []byte) []byte {
+	return managedMap.values[string(key)]
+}
+
+// Contains returns true if the given key is in the ManagedMap
+func (managedMap *ManagedMap) Contains(key []byte) bool {
+	_, exists := managedMap.values[string(key)]
+	return exists
+}
+
+// Remove deletes the given key and returns its value, which is empty if the
+// key is missing
+func (managedMap *ManagedMap) Remove(key []byte) []byte {
+	value, exists := managedMap.values[string(key)]
+	if !exists {
+		return nil
+	}
+
+	delete(managedMap.values, string(key))
+	managedMap.sortedKeys = nil
+
+	return value
+}
+
+// Len returns the number of keys in the ManagedMap
+func (managedMap *ManagedMap) Len() int {
+	return len(managedMap.values)
+}
+
+// KeyAtIndex returns the key at the given index, in the ascending order of the keys
+func (managedMap *ManagedMap) KeyAtIndex(index int32) ([]byte, error) {
+	if index < 0 || int(index) >= len(managedMap.values) {
+		return nil, ErrArgOutOfRange
+	}
+
+	if managedMap.sortedKeys == nil {
+		managedMap.sortedKeys = make([]string, 0, len(managedMap.values))
+		for key := range managedMap.values {
+			managedMap.sortedKeys = append(managedMap.sortedKeys, key)
+		}
+		sort.Strings(managedMap.sortedKeys)
+	}
+
+	return []byte(managedMap.sortedKeys[index]), nil
+}
+
+// Clone returns a copy of the ManagedMap, which shares no data with it
+func (managedMap *ManagedMap) Clone() *ManagedMap {
+	clone := &ManagedMap{
+		values:     make(map[string][]byte, len(managedMap.values)),
+		sortedKeys: managedMap.sortedKeys,
+	}
+	for key, value := range managedMap.values {
+		clone.values[key] = append([]byte{}, value...)
+	}
+
+	return clone
+}
+
+// MemoryUsage approximates the memory held by the keys and values of the ManagedMap
+func (managedMap *ManagedMap) MemoryUsage() uint64 {
+	usage := uint64(0)
+	for key, value := range managedMap.values {
+		usage += managedMapEntryOverhead + 2*uint64(len(key)) + uint64(len(value))
+	}
+	return usage
+}
@@ -0,0 +1,61 @@
+package arwen
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestManagedMap_PutGetRemove(t *testing.T) {
+	managedMap := NewManagedMap()
+	require.Equal(t, 0, managedMap.Len())
+	require.Empty(t, managedMap.Get([]byte("key")))
+	require.False(t, managedMap.Contains([]byte("key")))
+
+	managedMap.Put([]byte("key"), []byte("value"))
+	managedMap.Put([]byte("key"), []byte("other"))
+	require.Equal(t, 1, managedMap.Len())
+	require.True(t, managedMap.Contains([]byte("key")))
+	require.Equal(t, []byte("other"), managedMap.Get([]byte("key")))
+
+	require.Equal(t, []byte("other"), managedMap.Remove([]byte("key")))
+	require.Nil(t, managedMap.Remove([]byte("key")))
+	require.Equal(t, 0, managedMap.Len())
+	require.False(t, managedMap.Contains([]byte("key")))
+}
+
+func TestManagedMap_KeysAreSorted(t *testing.T) {
+	managedMap := NewManagedMap()
+	managedMap.Put([]byte("c"), nil)
+	managedMap.Put([]byte("a"), nil)
+	managedMap.Put([]byte("b"), nil)
+	managedMap.Remove([]byte("a"))
+	managedMap.Put([]byte("ab"), nil)
+
+	keys := make([]string, 0)
+	for i := 0; i < managedMap.Len(); i++ {
+		key, err := managedMap.KeyAtIndex(int32(i))
+		require.Nil(t, err)
+		keys = append(keys, string(key))
+	}
+	require.Equal(t, []string{"ab", "b", "c"}, keys)
+
+	_, err := managedMap.KeyAtIndex(3)
+	require.Equal(t, ErrArgOutOfRange, err)
+	_, err = managedMap.KeyAtIndex(-1)
+	require.Equal(t, ErrArgOutOfRange, err)
+}
+
+func TestManagedMap_Clone(t *testing.T) {
+	managedMap := NewManagedMap()
+	managedMap.Put([]byte("key"), []byte("value"))
+
+	clone := managedMap.Clone()
+	clone.Put([]byte("key"), []byte("changed"))
+	clone.Put([]byte("new"), []byte("value"))
+
+	require.Equal(t, []byte("value"), managedMap.Get([]byte("key")))
+	require.Equal(t, 1, managedMap.Len())
+	require.Equal(t, 2, clone.Len())
+	require.Equal(t, uint64(managedMapEntryOverhead+2*3+5), managedMap.MemoryUsage())
+}
@@ -0,0 +1,114 @@
+package arwen
+
+import (
+	"sync"
+)
+
+// MemoryCategory identifies what the memory accounted by a MemoryAccountant
+// is used for
+type MemoryCategory string
+
+const (
+	// MemoryWasmerInstances is the linear memory of the Wasmer instances
+	MemoryWasmerInstances MemoryCategory = "wasmerInstances"
+
+	// MemoryManagedObjects is the memory held by the values of the BigInt context
+	MemoryManagedObjects MemoryCategory = "managedObjects"
+
+	// MemoryCompiledCode is the compiled code held by the Wasmer instances
+	MemoryCompiledCode MemoryCategory = "compiledCode"
+
+	// MemoryInstancePool is the copies of the initial memory of the instances
+	// tracked by the instance pool, kept to restore them before reuse
+	MemoryInstancePool MemoryCategory = "instancePool"
+)
+
+// MemoryEvictionPercent is the percentage of the memory ceiling above which
+// the VMHost evicts its caches and refuses to create new Wasmer instances
+const MemoryEvictionPercent = 90
+
+// MemoryAccountant keeps track of the memory used by a VMHost, by category,
+// and compares it against a ceiling. A ceiling of 0 means that the memory is
+// accounted, but not limited.
+type MemoryAccountant struct {
+	mutMemory sync.RWMutex
+	ceiling   uint64
+	inUse     map[MemoryCategory]uint64
+}
+
+// NewMemoryAccountant creates a MemoryAccountant without a ceiling
+func NewMemoryAccountant() *MemoryAccountant {
+	return &MemoryAccountant{
+		inUse: make(map[MemoryCategory]uint64),
+	}
+}
+
+// SetCeiling sets the maximum memory the VMHost may use; 0 removes the limit
+func (accountant *MemoryAccountant) SetCeiling(ceiling uint64) {
+	accountant.mutMemory.Lock()
+	accountant.ceiling = ceiling
+	accountant.mutMemory.Unlock()
+}
+
+// Ceiling returns the maximum memory the VMHost may use
+func (accountant *MemoryAccountant) Ceiling() uint64 {
+	accountant.mutMemory.RLock()
+	defer accountant.mutMemory.RUnlock()
+
+	return accountant.ceiling
+}
+
+// Reserve accounts the given amount of memory as used by the given category
+func (accountant *MemoryAccountant) Reserve(category MemoryCategory, size uint64) {
+	accountant.mutMemory.Lock()
+	accountant.inUse[category] += size
+	accountant.mutMemory.Unlock()
+}
+
+// Release accounts the given amount of memory of the given category as freed
+func (accountant *MemoryAccountant) Release(category MemoryCategory, size uint64) {
+	accountant.mutMemory.Lock()
+	defer accountant.mutMemory.Unlock()
+
+	if size > accountant.inUse[category] {
+		size = accountant.inUse[category]
+	}
+	accountant.inUse[category] -= size
+}
+
+// InUse returns the total memory accounted, over all categories
+func (accountant *MemoryAccountant) InUse() uint64 {
+	accountant.mutMemory.RLock()
+	defer accountant.mutMemory.RUnlock()
+
+	return accountant.inUseUnprotected()
+}
+
+// InUseBy returns the memory accounted for the given category
+func (accountant *MemoryAccountant) InUseBy(category MemoryCategory) uint64 {
+	accountant.mutMemory.RLock()
+	defer accountant.mutMemory.RUnlock()
+
+	return accountant.inUse[category]
+}
+
+// IsNearCeiling returns true if the memory in use exceeds MemoryEvictionPercent of the ceiling
+func (accountant *MemoryAccountant) IsNearCeiling() bool {
+	accountant.mutMemory.RLock()
+	defer accountant.mutMemory.RUnlock()
+
+	if accountant.ceiling == 0 {
+		return false
+	}
+
+	threshold := accountant.ceiling / 100 * MemoryEvictionPercent
+	return accountant.inUseUnprotected() > threshold
+}
+
+func (accountant *MemoryAccountant) inUseUnprotected() uint64 {
+	total := uint64(0)
+	for _, size := range accountant.inUse {
+		total += size
+	}
+	return total
+}
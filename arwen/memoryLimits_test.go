@@ -0,0 +1,35 @@
+package arwen
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryAccountant_ReserveAndRelease(t *testing.T) {
+	accountant := NewMemoryAccountant()
+
+	accountant.Reserve(MemoryWasmerInstances, 1000)
+	accountant.Reserve(MemoryManagedObjects, 200)
+	require.Equal(t, uint64(1200), accountant.InUse())
+	require.Equal(t, uint64(200), accountant.InUseBy(MemoryManagedObjects))
+
+	accountant.Release(MemoryWasmerInstances, 400)
+	require.Equal(t, uint64(600), accountant.InUseBy(MemoryWasmerInstances))
+
+	accountant.Release(MemoryManagedObjects, 500)
+	require.Equal(t, uint64(0), accountant.InUseBy(MemoryManagedObjects))
+	require.Equal(t, uint64(600), accountant.InUse())
+}
+
+func TestMemoryAccountant_IsNearCeiling(t *testing.T) {
+	accountant := NewMemoryAccountant()
+	accountant.Reserve(MemoryWasmerInstances, 950)
+	require.False(t, accountant.IsNearCeiling())
+
+	accountant.SetCeiling(1000)
+	require.True(t, accountant.IsNearCeiling())
+
+	accountant.Release(MemoryWasmerInstances, 50)
+	require.False(t, accountant.IsNearCeiling())
+}
@@ -0,0 +1,67 @@
+package arwen
+
+import (
+	"math/big"
+)
+
+// BuiltInFunctionMultiESDTNFTTransfer is the built-in function which transfers
+// several ESDT tokens, fungible or not, from a sender to a single destination
+const BuiltInFunctionMultiESDTNFTTransfer = "MultiESDTNFTTransfer"
+
+// MaxMultiESDTTransfers is the largest number of tokens a contract may
+// transfer with a single multiple ESDT transfer
+const MaxMultiESDTTransfers = 100
+
+// ESDTTransfer is one of the tokens transferred by a multiple ESDT transfer;
+// a zero nonce designates a fungible token
+type ESDTTransfer struct {
+	TokenIdentifier []byte
+	Nonce           uint64
+	Value           *big.Int
+}
+
+// MultiESDTNFTTransferArguments builds the arguments of a call to
+// BuiltInFunctionMultiESDTNFTTransfer: the destination, the number of tokens,
+// then the identifier, the nonce and the value of each token
+func MultiESDTNFTTransferArguments(destination []byte, transfers []*ESDTTransfer) [][]byte {
+	arguments := make([][]byte, 0, 2+3*len(transfers))
+	arguments = append(arguments, destination, big.NewInt(int64(len(transfers))).Bytes())
+	for _, transfer := range transfers {
+		arguments = append(arguments,
+			transfer.TokenIdentifier,
+			big.NewInt(0).SetUint64(transfer.Nonce).Bytes(),
+			transfer.Value.Bytes(),
+		)
+	}
+
+	return arguments
+}
+
+// ParseMultiESDTNFTTransferArguments extracts the destination and the tokens
+// from the arguments of a call to BuiltInFunctionMultiESDTNFTTransfer; the
+// arguments following the tokens, if any, are returned as well
+func ParseMultiESDTNFTTransferArguments(arguments [][]byte) ([]byte, []*ESDTTransfer, [][]byte, error) {
+	if len(arguments) < 2 {
+		return nil, nil, nil, ErrInvalidMultiESDTTransfer
+	}
+
+	numTransfers := big.NewInt(0).SetBytes(arguments[1])
+	if numTransfers.Sign() == 0 || !numTransfers.IsUint64() {
+		return nil, nil, nil, ErrInvalidMultiESDTTransfer
+	}
+	if numTransfers.Uint64() > uint64(len(arguments)-2)/3 {
+		return nil, nil, nil, ErrInvalidMultiESDTTransfer
+	}
+
+	transfers := make([]*ESDTTransfer, numTransfers.Uint64())
+	for i := range transfers {
+		index := 2 + 3*i
+		transfers[i] = &ESDTTransfer{
+			TokenIdentifier: arguments[index],
+			Nonce:           big.NewInt(0).SetBytes(arguments[index+1]).Uint64(),
+			Value:           big.NewInt(0).SetBytes(arguments[index+2]),
+		}
+	}
+
+	return arguments[0], transfers, arguments[2+3*len(transfers):], nil
+}
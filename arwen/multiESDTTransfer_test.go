@@ -0,0 +1,41 @@
+package arwen
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMultiESDTTransfer_Arguments(t *testing.T) {
+	destination := []byte("destination")
+	transfers := []*ESDTTransfer{
+		{TokenIdentifier: []byte("FIRST-123456"), Nonce: 0, Value: big.NewInt(10)},
+		{TokenIdentifier: []byte("NFT-123456"), Nonce: 3, Value: big.NewInt(1)},
+	}
+
+	arguments := MultiESDTNFTTransferArguments(destination, transfers)
+	require.Equal(t, [][]byte{
+		destination, {2},
+		[]byte("FIRST-123456"), {}, {10},
+		[]byte("NFT-123456"), {3}, {1},
+	}, arguments)
+
+	arguments = append(arguments, []byte("function"), []byte("argument"))
+	parsedDestination, parsedTransfers, remaining, err := ParseMultiESDTNFTTransferArguments(arguments)
+	require.Nil(t, err)
+	require.Equal(t, destination, parsedDestination)
+	require.Equal(t, transfers, parsedTransfers)
+	require.Equal(t, [][]byte{[]byte("function"), []byte("argument")}, remaining)
+}
+
+func TestMultiESDTTransfer_InvalidArguments(t *testing.T) {
+	_, _, _, err := ParseMultiESDTNFTTransferArguments([][]byte{[]byte("destination")})
+	require.Equal(t, ErrInvalidMultiESDTTransfer, err)
+
+	_, _, _, err = ParseMultiESDTNFTTransferArguments([][]byte{[]byte("destination"), {}})
+	require.Equal(t, ErrInvalidMultiESDTTransfer, err)
+
+	_, _, _, err = ParseMultiESDTNFTTransferArguments([][]byte{[]byte("destination"), {2}, []byte("TOKEN"), {}, {1}})
+	require.Equal(t, ErrInvalidMultiESDTTransfer, err)
+}
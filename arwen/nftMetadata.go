@@ -0,0 +1,64 @@
+package arwen
+
+import (
+	"encoding/binary"
+	"math/big"
+
+	"github.com/ElrondNetwork/elrond-go/data/esdt"
+)
+
+// EncodeNFTURIs serializes the given URIs of an NFT or SFT as a sequence of
+// entries, each made of the length of the URI, as 4 big-endian bytes,
+// followed by the URI itself
+func EncodeNFTURIs(uris [][]byte) []byte {
+	length := 0
+	for _, uri := range uris {
+		length += 4 + len(uri)
+	}
+
+	encoded := make([]byte, 0, length)
+	for _, uri := range uris {
+		uriLength := make([]byte, 4)
+		binary.BigEndian.PutUint32(uriLength, uint32(len(uri)))
+		encoded = append(encoded, uriLength...)
+		encoded = append(encoded, uri...)
+	}
+
+	return encoded
+}
+
+// NFTAttributes returns the attributes of the given ESDT token, or nil if it
+// has no metadata
+func NFTAttributes(token *esdt.ESDigitalToken) []byte {
+	if token == nil || token.TokenMetaData == nil {
+		return nil
+	}
+
+	return token.TokenMetaData.Attributes
+}
+
+// NFTURIs returns the URIs of the given ESDT token, encoded by EncodeNFTURIs,
+// or nil if it has no metadata
+func NFTURIs(token *esdt.ESDigitalToken) []byte {
+	if token == nil || token.TokenMetaData == nil {
+		return nil
+	}
+
+	return EncodeNFTURIs(token.TokenMetaData.URIs)
+}
+
+// EncodeNFTRoyalties returns the royalties of the given ESDT token as minimal
+// big-endian bytes, which are empty if it has no royalties
+func EncodeNFTRoyalties(token *esdt.ESDigitalToken) []byte {
+	return big.NewInt(int64(NFTRoyalties(token))).Bytes()
+}
+
+// NFTRoyalties returns the royalties of the given ESDT token, or 0 if it has
+// no metadata
+func NFTRoyalties(token *esdt.ESDigitalToken) uint32 {
+	if token == nil || token.TokenMetaData == nil {
+		return 0
+	}
+
+	return token.TokenMetaData.Royalties
+}
@@ -0,0 +1,35 @@
+package arwen
+
+import (
+	"testing"
+
+	"github.com/ElrondNetwork/elrond-go/data/esdt"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNFTMetadata_EncodeURIs(t *testing.T) {
+	require.Empty(t, EncodeNFTURIs(nil))
+
+	encoded := EncodeNFTURIs([][]byte{[]byte("ab"), []byte(""), []byte("xyz")})
+	expected := []byte{0, 0, 0, 2, 'a', 'b', 0, 0, 0, 0, 0, 0, 0, 3, 'x', 'y', 'z'}
+	require.Equal(t, expected, encoded)
+}
+
+func TestNFTMetadata_Getters(t *testing.T) {
+	require.Nil(t, NFTAttributes(nil))
+	require.Nil(t, NFTURIs(&esdt.ESDigitalToken{}))
+	require.Equal(t, uint32(0), NFTRoyalties(&esdt.ESDigitalToken{}))
+	require.Empty(t, EncodeNFTRoyalties(&esdt.ESDigitalToken{}))
+
+	token := &esdt.ESDigitalToken{
+		TokenMetaData: &esdt.MetaData{
+			Royalties:  250,
+			Attributes: []byte("attributes"),
+			URIs:       [][]byte{[]byte("uri")},
+		},
+	}
+	require.Equal(t, []byte("attributes"), NFTAttributes(token))
+	require.Equal(t, []byte{0, 0, 0, 3, 'u', 'r', 'i'}, NFTURIs(token))
+	require.Equal(t, uint32(250), NFTRoyalties(token))
+	require.Equal(t, []byte{250}, EncodeNFTRoyalties(token))
+}
@@ -0,0 +1,42 @@
+package arwen
+
+import (
+	"encoding/json"
+	"math/big"
+
+	"github.com/ElrondNetwork/elrond-go/core/vmcommon"
+)
+
+// IndexedOutputTransfer is an OutputTransfer along with the address of the
+// account it was made to and its index in the order in which the transfers
+// of an execution were made, across all its OutputAccounts
+type IndexedOutputTransfer struct {
+	Index       int
+	Destination []byte
+	Transfer    vmcommon.OutputTransfer
+}
+
+// OrderedVMOutput is a view of a VMOutput whose OutputAccounts are listed in
+// the order in which the execution created them, and whose OutputTransfers
+// are also listed in the order in which the execution made them, across all
+// the accounts. Unlike the VMOutput, which holds the accounts in a map, it has
+// a single encoding, so two runs of the same transaction can be compared byte
+// by byte.
+type OrderedVMOutput struct {
+	ReturnData      [][]byte
+	ReturnCode      vmcommon.ReturnCode
+	ReturnMessage   string
+	GasRemaining    uint64
+	GasRefund       *big.Int
+	OutputAccounts  []*vmcommon.OutputAccount
+	OutputTransfers []IndexedOutputTransfer
+	DeletedAccounts [][]byte
+	TouchedAccounts [][]byte
+	Logs            []*vmcommon.LogEntry
+}
+
+// Encode returns the JSON encoding of the OrderedVMOutput; the storage
+// updates of each account are encoded in the order of their keys
+func (output *OrderedVMOutput) Encode() ([]byte, error) {
+	return json.Marshal(output)
+}
@@ -0,0 +1,29 @@
+package arwen
+
+import "math/big"
+
+// PaymentReceivedFunctionName is the function which the host calls on a
+// contract to notify it about the ESDT tokens it has received through a
+// transfer which did not call any of its functions
+const PaymentReceivedFunctionName = "onPaymentReceived"
+
+// PaymentNotification is an ESDT transfer to a contract, waiting for the host to
+// call PaymentReceivedFunctionName on the contract when the current execution ends
+type PaymentNotification struct {
+	Sender          []byte
+	Recipient       []byte
+	TokenIdentifier []byte
+	Nonce           uint64
+	Value           *big.Int
+}
+
+// Arguments returns the arguments passed to PaymentReceivedFunctionName, namely
+// the sender, the token identifier, the nonce and the value of the transfer
+func (notification *PaymentNotification) Arguments() [][]byte {
+	return [][]byte{
+		notification.Sender,
+		notification.TokenIdentifier,
+		big.NewInt(0).SetUint64(notification.Nonce).Bytes(),
+		notification.Value.Bytes(),
+	}
+}
@@ -0,0 +1,259 @@
+package arwen
+
+import (
+	"math/big"
+
+	"github.com/ElrondNetwork/elrond-go/core/vmcommon"
+)
+
+// LogReceipt is a receipt of an outcome of an execution, recorded in the
+// VMOutput as a log entry. All the receipts are collected by the Output
+// context, which drops them along with the output state when it is reverted.
+type LogReceipt interface {
+	LogEntry() *vmcommon.LogEntry
+}
+
+// ReceiptLogIdentifier is the identifier of the log entries which record the
+// Receipts of an execution in its VMOutput
+const ReceiptLogIdentifier = "receipt"
+
+// ReceiptKind identifies the outcome of an execution described by a Receipt
+type ReceiptKind int
+
+const (
+	// ReceiptValueRefunded is the value of a failed call given back to its caller
+	ReceiptValueRefunded ReceiptKind = iota
+
+	// ReceiptAsyncCallbackExecuted is the execution of the callback of an
+	// async call, successful or not
+	ReceiptAsyncCallbackExecuted
+
+	// ReceiptErrorCompensation is the value of a failed async call given back
+	// to the contract which made it, along with the error passed to its callback
+	ReceiptErrorCompensation
+)
+
+// String returns the name of the ReceiptKind
+func (kind ReceiptKind) String() string {
+	switch kind {
+	case ReceiptValueRefunded:
+		return "valueRefunded"
+	case ReceiptAsyncCallbackExecuted:
+		return "asyncCallbackExecuted"
+	case ReceiptErrorCompensation:
+		return "errorCompensation"
+	}
+	return "unknown"
+}
+
+// Receipt describes an outcome of an execution which is relevant to the users
+// and developers of contracts, so that the node can persist it without
+// deriving it from the rest of the VMOutput. The Address is the account the
+// Receipt concerns, while the Origin is the contract whose execution
+// produced it.
+type Receipt struct {
+	Kind    ReceiptKind
+	Address []byte
+	Origin  []byte
+	Value   *big.Int
+	Details [][]byte
+}
+
+// NewFailedCallReceipt creates the Receipt of the value of a failed call given
+// back to its caller; the calls without value have no Receipt, nor do the
+// callbacks, whose value is not transferred by the VM
+func NewFailedCallReceipt(caller []byte, recipient []byte, value *big.Int, callType vmcommon.CallType) *Receipt {
+	if value == nil || value.Sign() <= 0 || callType == vmcommon.AsynchronousCallBack {
+		return nil
+	}
+
+	kind := ReceiptValueRefunded
+	if callType == vmcommon.AsynchronousCall {
+		kind = ReceiptErrorCompensation
+	}
+
+	return &Receipt{
+		Kind:    kind,
+		Address: caller,
+		Origin:  recipient,
+		Value:   big.NewInt(0).Set(value),
+		Details: make([][]byte, 0),
+	}
+}
+
+// NewCallbackReceipt creates the Receipt of the execution of the given
+// callback by the contract which made an async call to the given destination
+func NewCallbackReceipt(
+	caller []byte,
+	destination []byte,
+	callback string,
+	value *big.Int,
+	returnCode vmcommon.ReturnCode,
+) *Receipt {
+	receiptValue := big.NewInt(0)
+	if value != nil {
+		receiptValue.Set(value)
+	}
+
+	return &Receipt{
+		Kind:    ReceiptAsyncCallbackExecuted,
+		Address: caller,
+		Origin:  destination,
+		Value:   receiptValue,
+		Details: [][]byte{[]byte(callback), []byte(returnCode.String())},
+	}
+}
+
+// LogEntry encodes the Receipt as a VMOutput log entry, at its Address; its
+// topics are the kind, the origin and the value of the Receipt, followed by
+// its details
+func (receipt *Receipt) LogEntry() *vmcommon.LogEntry {
+	topics := make([][]byte, 0, 3+len(receipt.Details))
+	topics = append(topics, []byte(receipt.Kind.String()), receipt.Origin, receipt.Value.Bytes())
+	topics = append(topics, receipt.Details...)
+
+	return &vmcommon.LogEntry{
+		Identifier: []byte(ReceiptLogIdentifier),
+		Address:    receipt.Address,
+		Topics:     topics,
+	}
+}
+
+// RefundReceiptLogIdentifier is the identifier of the log entries which
+// record the RefundReceipts of an execution in its VMOutput
+const RefundReceiptLogIdentifier = "refundReceipt"
+
+// RefundReason identifies why gas paid for an execution is given back to its payer
+type RefundReason int
+
+const (
+	// RefundReasonUnusedGas is the gas provided to the execution and not
+	// consumed, apart from the gas of RefundReasonAsyncUnusedLock
+	RefundReasonUnusedGas RefundReason = iota
+
+	// RefundReasonAsyncUnusedLock is the gas locked for the callbacks of async
+	// calls, which was not consumed by the callbacks
+	RefundReasonAsyncUnusedLock
+
+	// RefundReasonStorageRelease is the gas refunded for releasing storage,
+	// which is the GasRefund of the VMOutput
+	RefundReasonStorageRelease
+)
+
+// String returns the name of the RefundReason
+func (reason RefundReason) String() string {
+	switch reason {
+	case RefundReasonUnusedGas:
+		return "unusedGas"
+	case RefundReasonAsyncUnusedLock:
+		return "asyncUnusedLock"
+	case RefundReasonStorageRelease:
+		return "storageRelease"
+	}
+	return "unknown"
+}
+
+// RefundReceipt describes an amount of gas given back to the payer of an
+// execution, and its value at the gas price of the execution. The discounts
+// which the node applies to the gas price, if any, are not known to the VM
+// and are not accounted in the value.
+type RefundReceipt struct {
+	Payer  []byte
+	Gas    uint64
+	Amount *big.Int
+	Reason RefundReason
+}
+
+// NewRefundReceipt creates a RefundReceipt of the given gas, valued at the given gas price
+func NewRefundReceipt(payer []byte, gas uint64, gasPrice uint64, reason RefundReason) *RefundReceipt {
+	amount := big.NewInt(0).SetUint64(gas)
+	amount.Mul(amount, big.NewInt(0).SetUint64(gasPrice))
+
+	return &RefundReceipt{
+		Payer:  payer,
+		Gas:    gas,
+		Amount: amount,
+		Reason: reason,
+	}
+}
+
+// LogEntry encodes the RefundReceipt as a VMOutput log entry, at the address
+// of its payer; its topics are the reason, the gas and the amount refunded
+func (receipt *RefundReceipt) LogEntry() *vmcommon.LogEntry {
+	return &vmcommon.LogEntry{
+		Identifier: []byte(RefundReceiptLogIdentifier),
+		Address:    receipt.Payer,
+		Topics: [][]byte{
+			[]byte(receipt.Reason.String()),
+			big.NewInt(0).SetUint64(receipt.Gas).Bytes(),
+			receipt.Amount.Bytes(),
+		},
+	}
+}
+
+// RefundReceipts returns the RefundReceipts of an execution paid by the given
+// payer, which ended with the given gas remaining and gas refunded for storage
+// release. The gas locked for callbacks and given back unused is part of the
+// gas remaining, and is receipted separately. Zero refunds have no receipt.
+func RefundReceipts(
+	payer []byte,
+	gasRemaining uint64,
+	storageRelease uint64,
+	refunds ItemizedRefunds,
+	gasPrice uint64,
+) []*RefundReceipt {
+	asyncUnusedLock := refunds.UnusedLockedGas
+	if asyncUnusedLock > gasRemaining {
+		asyncUnusedLock = gasRemaining
+	}
+
+	receipts := make([]*RefundReceipt, 0)
+	refundsByReason := []struct {
+		gas    uint64
+		reason RefundReason
+	}{
+		{gasRemaining - asyncUnusedLock, RefundReasonUnusedGas},
+		{asyncUnusedLock, RefundReasonAsyncUnusedLock},
+		{storageRelease, RefundReasonStorageRelease},
+	}
+	for _, refund := range refundsByReason {
+		if refund.gas == 0 {
+			continue
+		}
+		receipts = append(receipts, NewRefundReceipt(payer, refund.gas, gasPrice, refund.reason))
+	}
+
+	return receipts
+}
+
+// OutOfGasLogIdentifier is the identifier of the log entry which records the
+// OutOfGasReceipt in the VMOutput of an execution which ran out of gas
+const OutOfGasLogIdentifier = "outOfGas"
+
+// OutOfGasReceipt describes how far an execution got before running out of
+// gas: the contract and the function being executed, the last host function
+// invoked during the execution, by any contract, and the storage keys of the
+// contract read or written until then, sorted. The receipts are only
+// captured in debug mode, enabled through the VMHostParameters, because
+// tracking the host functions is too slow for production use.
+type OutOfGasReceipt struct {
+	ContractAddress  []byte
+	Function         string
+	LastHostFunction string
+	StorageKeys      [][]byte
+}
+
+// LogEntry encodes the OutOfGasReceipt as a VMOutput log entry; its topics
+// are the function name and the last host function (empty if none was
+// invoked), followed by one topic per storage key
+func (receipt *OutOfGasReceipt) LogEntry() *vmcommon.LogEntry {
+	topics := make([][]byte, 0, 2+len(receipt.StorageKeys))
+	topics = append(topics, []byte(receipt.Function), []byte(receipt.LastHostFunction))
+	topics = append(topics, receipt.StorageKeys...)
+
+	return &vmcommon.LogEntry{
+		Identifier: []byte(OutOfGasLogIdentifier),
+		Address:    receipt.ContractAddress,
+		Topics:     topics,
+	}
+}
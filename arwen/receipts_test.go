@@ -0,0 +1,96 @@
+package arwen
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ElrondNetwork/elrond-go/core/vmcommon"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReceipts_FailedCall(t *testing.T) {
+	t.Parallel()
+
+	caller := []byte("caller")
+	recipient := []byte("recipient")
+
+	receipt := NewFailedCallReceipt(caller, recipient, big.NewInt(42), vmcommon.DirectCall)
+	require.NotNil(t, receipt)
+	require.Equal(t, ReceiptValueRefunded, receipt.Kind)
+	require.Equal(t, caller, receipt.Address)
+	require.Equal(t, recipient, receipt.Origin)
+	require.Equal(t, big.NewInt(42), receipt.Value)
+
+	receipt = NewFailedCallReceipt(caller, recipient, big.NewInt(42), vmcommon.AsynchronousCall)
+	require.Equal(t, ReceiptErrorCompensation, receipt.Kind)
+
+	// no value, or the value of a callback, has nothing to refund
+	require.Nil(t, NewFailedCallReceipt(caller, recipient, nil, vmcommon.DirectCall))
+	require.Nil(t, NewFailedCallReceipt(caller, recipient, big.NewInt(0), vmcommon.DirectCall))
+	require.Nil(t, NewFailedCallReceipt(caller, recipient, big.NewInt(42), vmcommon.AsynchronousCallBack))
+}
+
+func TestReceipt_LogEntry(t *testing.T) {
+	t.Parallel()
+
+	receipt := NewCallbackReceipt([]byte("caller"), []byte("destination"), "callBack", nil, vmcommon.UserError)
+	require.Equal(t, &vmcommon.LogEntry{
+		Identifier: []byte(ReceiptLogIdentifier),
+		Address:    []byte("caller"),
+		Topics: [][]byte{
+			[]byte("asyncCallbackExecuted"),
+			[]byte("destination"),
+			{},
+			[]byte("callBack"),
+			[]byte(vmcommon.UserError.String()),
+		},
+	}, receipt.LogEntry())
+}
+
+func TestRefundReceipts_ByReason(t *testing.T) {
+	t.Parallel()
+
+	payer := []byte("payer")
+	refunds := ItemizedRefunds{}.Add(RefundUnusedLockedGas, 30)
+
+	receipts := RefundReceipts(payer, 100, 5, refunds, 2)
+	require.Len(t, receipts, 3)
+
+	// the unused locked gas is part of the gas remaining, but receipted separately
+	require.Equal(t, RefundReasonUnusedGas, receipts[0].Reason)
+	require.Equal(t, uint64(70), receipts[0].Gas)
+	require.Equal(t, big.NewInt(140), receipts[0].Amount)
+	require.Equal(t, RefundReasonAsyncUnusedLock, receipts[1].Reason)
+	require.Equal(t, uint64(30), receipts[1].Gas)
+	require.Equal(t, RefundReasonStorageRelease, receipts[2].Reason)
+	require.Equal(t, uint64(5), receipts[2].Gas)
+	require.Equal(t, big.NewInt(10), receipts[2].Amount)
+
+	for _, receipt := range receipts {
+		require.Equal(t, payer, receipt.Payer)
+	}
+}
+
+func TestRefundReceipts_ZeroRefundsOmitted(t *testing.T) {
+	t.Parallel()
+
+	require.Empty(t, RefundReceipts([]byte("payer"), 0, 0, ItemizedRefunds{}, 1))
+
+	// more unused locked gas than gas remaining is capped to the gas remaining
+	refunds := ItemizedRefunds{}.Add(RefundUnusedLockedGas, 50)
+	receipts := RefundReceipts([]byte("payer"), 20, 0, refunds, 1)
+	require.Len(t, receipts, 1)
+	require.Equal(t, RefundReasonAsyncUnusedLock, receipts[0].Reason)
+	require.Equal(t, uint64(20), receipts[0].Gas)
+}
+
+func TestRefundReceipt_LogEntry(t *testing.T) {
+	t.Parallel()
+
+	receipt := NewRefundReceipt([]byte("payer"), 7, 3, RefundReasonStorageRelease)
+	logEntry := receipt.LogEntry()
+
+	require.Equal(t, []byte(RefundReceiptLogIdentifier), logEntry.Identifier)
+	require.Equal(t, []byte("payer"), logEntry.Address)
+	require.Equal(t, [][]byte{[]byte("storageRelease"), {7}, {21}}, logEntry.Topics)
+}
@@ -0,0 +1,82 @@
+package arwen
+
+import (
+	"math/big"
+
+	"github.com/ElrondNetwork/elrond-go/core/vmcommon"
+)
+
+// RefundsLogIdentifier is the identifier of the log entry which records the
+// ItemizedRefunds of an execution in its VMOutput
+const RefundsLogIdentifier = "gasRefunds"
+
+// RefundCategory identifies the origin of gas refunded during an execution
+type RefundCategory int
+
+const (
+	// RefundStorageRelease is the gas refunded for releasing storage; it is
+	// the only category also accounted in the GasRefund of the VMOutput
+	RefundStorageRelease RefundCategory = iota
+
+	// RefundUnusedLockedGas is the gas locked for a callback which was given
+	// back to the caller without being consumed
+	RefundUnusedLockedGas
+
+	// RefundAsyncCall is the gas provided to an async call which was given
+	// back to the caller without being consumed by the destination
+	RefundAsyncCall
+)
+
+// String returns the name of the RefundCategory
+func (category RefundCategory) String() string {
+	switch category {
+	case RefundStorageRelease:
+		return "storageRelease"
+	case RefundUnusedLockedGas:
+		return "unusedLockedGas"
+	case RefundAsyncCall:
+		return "asyncCall"
+	}
+	return "unknown"
+}
+
+// ItemizedRefunds holds the gas refunded during an execution, separately
+// for each RefundCategory
+type ItemizedRefunds struct {
+	StorageRelease  uint64
+	UnusedLockedGas uint64
+	AsyncCall       uint64
+}
+
+// Add returns the ItemizedRefunds with the given gas added to a category
+func (refunds ItemizedRefunds) Add(category RefundCategory, gas uint64) ItemizedRefunds {
+	switch category {
+	case RefundStorageRelease:
+		refunds.StorageRelease += gas
+	case RefundUnusedLockedGas:
+		refunds.UnusedLockedGas += gas
+	case RefundAsyncCall:
+		refunds.AsyncCall += gas
+	}
+	return refunds
+}
+
+// IsZero returns true if no gas was refunded in any category
+func (refunds ItemizedRefunds) IsZero() bool {
+	return refunds == ItemizedRefunds{}
+}
+
+// LogEntry encodes the ItemizedRefunds of the execution of the contract at
+// the given address as a VMOutput log entry; its topics are the gas refunded
+// for storage release, as unused locked gas and by async calls, in this order
+func (refunds ItemizedRefunds) LogEntry(address []byte) *vmcommon.LogEntry {
+	return &vmcommon.LogEntry{
+		Identifier: []byte(RefundsLogIdentifier),
+		Address:    address,
+		Topics: [][]byte{
+			big.NewInt(0).SetUint64(refunds.StorageRelease).Bytes(),
+			big.NewInt(0).SetUint64(refunds.UnusedLockedGas).Bytes(),
+			big.NewInt(0).SetUint64(refunds.AsyncCall).Bytes(),
+		},
+	}
+}
@@ -0,0 +1,65 @@
+package arwen
+
+import (
+	"bytes"
+	"sort"
+)
+
+// StorageChange is a key of the storage of an account whose value was
+// changed by an execution, with its values before and after the execution;
+// an empty value stands for a key which is not set
+type StorageChange struct {
+	Address []byte
+	Key     []byte
+	Before  []byte
+	After   []byte
+}
+
+// StorageDiff holds the storage changes made by an execution, sorted by
+// address, then by key. The keys written with the value they already held
+// are not changes.
+type StorageDiff struct {
+	Changes []*StorageChange
+}
+
+// NewStorageDiff creates a StorageDiff holding the given changes, which it sorts
+func NewStorageDiff(changes []*StorageChange) *StorageDiff {
+	sort.Slice(changes, func(i, j int) bool {
+		addressOrder := bytes.Compare(changes[i].Address, changes[j].Address)
+		if addressOrder != 0 {
+			return addressOrder < 0
+		}
+		return bytes.Compare(changes[i].Key, changes[j].Key) < 0
+	})
+
+	return &StorageDiff{Changes: changes}
+}
+
+// Get returns the change of the given key of the storage of the given
+// account, and false if the key was not changed
+func (diff *StorageDiff) Get(address []byte, key []byte) (*StorageChange, bool) {
+	for _, change := range diff.Changes {
+		if bytes.Equal(change.Address, address) && bytes.Equal(change.Key, key) {
+			return change, true
+		}
+	}
+
+	return nil, false
+}
+
+// ChangesOf returns the changes of the storage of the given account
+func (diff *StorageDiff) ChangesOf(address []byte) []*StorageChange {
+	changes := make([]*StorageChange, 0)
+	for _, change := range diff.Changes {
+		if bytes.Equal(change.Address, address) {
+			changes = append(changes, change)
+		}
+	}
+
+	return changes
+}
+
+// IsEmpty returns true if the execution changed no storage
+func (diff *StorageDiff) IsEmpty() bool {
+	return len(diff.Changes) == 0
+}
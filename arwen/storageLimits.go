@@ -0,0 +1,45 @@
+package arwen
+
+// StorageLimits bounds the storage which contracts may write. A write under a
+// key longer than MaxKeyLength, or of a value longer than MaxValueLength, is
+// rejected, as is a write which would grow the total size of the storage of
+// the contract beyond MaxContractStorageSize. A value of 0 leaves the
+// respective limit unlimited. The keys written by the VM itself on behalf of
+// the contract, such as those of its async calls, are not bounded, although
+// they take up the storage quota. The size of the storage is kept by the VM
+// under StorageSizeKey while the quota is set, starting from the size of the
+// storage held by the node when the quota first applies to the contract; the
+// writes made while the quota is unset again are not accounted.
+type StorageLimits struct {
+	MaxKeyLength           uint64
+	MaxValueLength         uint64
+	MaxContractStorageSize uint64
+}
+
+// HasStorageQuota returns true if the StorageLimits bound the total size of
+// the storage of each contract
+func (limits StorageLimits) HasStorageQuota() bool {
+	return limits.MaxContractStorageSize > 0
+}
+
+// CheckEntry returns the error of a write of the given value under the given
+// key, if either of them is too long
+func (limits StorageLimits) CheckEntry(key []byte, value []byte) error {
+	if limits.MaxKeyLength > 0 && uint64(len(key)) > limits.MaxKeyLength {
+		return ErrStorageKeyTooLong
+	}
+	if limits.MaxValueLength > 0 && uint64(len(value)) > limits.MaxValueLength {
+		return ErrStorageValueTooLong
+	}
+	return nil
+}
+
+// StorageEntrySize returns the number of bytes of a storage entry accounted
+// towards the storage quota of a contract, which are the bytes of its key and
+// of its value; an empty value deletes the entry, which then takes no space.
+func StorageEntrySize(key []byte, value []byte) uint64 {
+	if len(value) == 0 {
+		return 0
+	}
+	return uint64(len(key) + len(value))
+}
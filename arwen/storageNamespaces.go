@@ -0,0 +1,142 @@
+package arwen
+
+import "bytes"
+
+// StorageAccessor identifies the kind of code accessing the storage of a contract
+type StorageAccessor int
+
+const (
+	// StorageAccessByContract is the code of the contract itself
+	StorageAccessByContract StorageAccessor = iota
+
+	// StorageAccessByCallback is the code of the contract, executed as the callback of an async call
+	StorageAccessByCallback
+
+	// StorageAccessByBuiltin is the VM itself, acting on behalf of the contract, as do the built-in functions
+	StorageAccessByBuiltin
+)
+
+// String returns the name of the StorageAccessor
+func (accessor StorageAccessor) String() string {
+	switch accessor {
+	case StorageAccessByContract:
+		return "contract"
+	case StorageAccessByCallback:
+		return "callback"
+	case StorageAccessByBuiltin:
+		return "builtin"
+	}
+	return "unknown"
+}
+
+// StorageRights holds the kinds of access to a StorageNamespace
+type StorageRights uint8
+
+const (
+	// StorageRead allows reading the keys of a StorageNamespace
+	StorageRead StorageRights = 1 << iota
+
+	// StorageWrite allows writing the keys of a StorageNamespace
+	StorageWrite
+)
+
+// Allows returns true if the StorageRights include all the given rights
+func (rights StorageRights) Allows(required StorageRights) bool {
+	return rights&required == required
+}
+
+// StorageNamespace is the set of storage keys starting with a prefix, along
+// with the policy deciding the rights of each StorageAccessor over them. A
+// write denied by the policy fails with WriteDeniedError. The values of a
+// NodeManaged namespace are changed by the node alone, so they are always
+// retrieved from the node, and are neither enumerated nor accounted towards
+// the storage quota of the contract.
+type StorageNamespace struct {
+	Name             string
+	Prefix           []byte
+	Rights           map[StorageAccessor]StorageRights
+	WriteDeniedError error
+	NodeManaged      bool
+}
+
+// CanRead returns true if the given StorageAccessor may read the keys of the StorageNamespace
+func (namespace *StorageNamespace) CanRead(accessor StorageAccessor) bool {
+	return namespace.Rights[accessor].Allows(StorageRead)
+}
+
+// CanWrite returns true if the given StorageAccessor may write the keys of the StorageNamespace
+func (namespace *StorageNamespace) CanWrite(accessor StorageAccessor) bool {
+	return namespace.Rights[accessor].Allows(StorageWrite)
+}
+
+// StorageNamespaces partitions the storage of a contract into StorageNamespaces
+type StorageNamespaces struct {
+	namespaces []*StorageNamespace
+}
+
+// NewStorageNamespaces creates the StorageNamespaces of the storage of
+// contracts: the keys reserved by the Elrond node, which only the node writes;
+// the keys protected by Arwen, and among them the async data, which only the
+// VM writes; and the user area, holding all the other keys.
+func NewStorageNamespaces(elrondProtectedKeyPrefix []byte) *StorageNamespaces {
+	readOnly := map[StorageAccessor]StorageRights{
+		StorageAccessByContract: StorageRead,
+		StorageAccessByCallback: StorageRead,
+		StorageAccessByBuiltin:  StorageRead,
+	}
+	writtenByVM := map[StorageAccessor]StorageRights{
+		StorageAccessByContract: StorageRead,
+		StorageAccessByCallback: StorageRead,
+		StorageAccessByBuiltin:  StorageRead | StorageWrite,
+	}
+	readWrite := map[StorageAccessor]StorageRights{
+		StorageAccessByContract: StorageRead | StorageWrite,
+		StorageAccessByCallback: StorageRead | StorageWrite,
+		StorageAccessByBuiltin:  StorageRead | StorageWrite,
+	}
+
+	return &StorageNamespaces{
+		namespaces: []*StorageNamespace{
+			{
+				Name:             "elrond",
+				Prefix:           elrondProtectedKeyPrefix,
+				Rights:           readOnly,
+				WriteDeniedError: ErrStoreElrondReservedKey,
+				NodeManaged:      true,
+			},
+			{
+				Name:             "async",
+				Prefix:           []byte(AsyncDataPrefix),
+				Rights:           writtenByVM,
+				WriteDeniedError: ErrCannotWriteProtectedKey,
+			},
+			{
+				Name:             "arwen",
+				Prefix:           []byte(ProtectedStoragePrefix),
+				Rights:           writtenByVM,
+				WriteDeniedError: ErrCannotWriteProtectedKey,
+			},
+			{
+				Name:   "user",
+				Prefix: []byte{},
+				Rights: readWrite,
+			},
+		},
+	}
+}
+
+// Find returns the StorageNamespace of the given key, which is the one with
+// the longest prefix of the key
+func (namespaces *StorageNamespaces) Find(key []byte) *StorageNamespace {
+	var found *StorageNamespace
+	for _, namespace := range namespaces.namespaces {
+		if !bytes.HasPrefix(key, namespace.Prefix) {
+			continue
+		}
+		if found == nil || len(namespace.Prefix) > len(found.Prefix) {
+			found = namespace
+		}
+	}
+
+	return found
+}
@@ -0,0 +1,48 @@
+package arwen
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStorageNamespaces_Find(t *testing.T) {
+	t.Parallel()
+
+	namespaces := NewStorageNamespaces([]byte("ELROND"))
+
+	require.Equal(t, "elrond", namespaces.Find([]byte("ELRONDesdt")).Name)
+	require.Equal(t, "async", namespaces.Find([]byte(AsyncDataPrefix+"txHash")).Name)
+	require.Equal(t, "arwen", namespaces.Find([]byte(TimeLockKeyPrefix+"key")).Name)
+	require.Equal(t, "arwen", namespaces.Find([]byte(DeferredCallsKey)).Name)
+	require.Equal(t, "user", namespaces.Find([]byte("counter")).Name)
+	require.Equal(t, "user", namespaces.Find([]byte{}).Name)
+}
+
+func TestStorageNamespaces_Rights(t *testing.T) {
+	t.Parallel()
+
+	namespaces := NewStorageNamespaces([]byte("ELROND"))
+	accessors := []StorageAccessor{StorageAccessByContract, StorageAccessByCallback, StorageAccessByBuiltin}
+
+	elrond := namespaces.Find([]byte("ELRONDesdt"))
+	user := namespaces.Find([]byte("counter"))
+	for _, accessor := range accessors {
+		require.True(t, elrond.CanRead(accessor), accessor.String())
+		require.False(t, elrond.CanWrite(accessor), accessor.String())
+		require.True(t, user.CanRead(accessor), accessor.String())
+		require.True(t, user.CanWrite(accessor), accessor.String())
+	}
+	require.True(t, elrond.NodeManaged)
+	require.Equal(t, ErrStoreElrondReservedKey, elrond.WriteDeniedError)
+
+	async := namespaces.Find([]byte(AsyncDataPrefix + "txHash"))
+	require.True(t, async.CanRead(StorageAccessByCallback))
+	require.False(t, async.CanWrite(StorageAccessByContract))
+	require.False(t, async.CanWrite(StorageAccessByCallback))
+	require.True(t, async.CanWrite(StorageAccessByBuiltin))
+	require.Equal(t, ErrCannotWriteProtectedKey, async.WriteDeniedError)
+
+	require.True(t, (StorageRead | StorageWrite).Allows(StorageRead))
+	require.False(t, StorageRead.Allows(StorageRead|StorageWrite))
+}
@@ -0,0 +1,51 @@
+package arwen
+
+import (
+	"bytes"
+	"sort"
+)
+
+// TouchedAccounts holds the accounts accessed during an execution: the
+// accounts read from the BlockchainHook, and the accounts whose state is
+// changed by the VMOutput, which does not hold the accounts merely read. An
+// account may be both read and written. The addresses are sorted.
+type TouchedAccounts struct {
+	Read    [][]byte
+	Written [][]byte
+}
+
+// IsRead returns true if the account at the given address was read
+func (touched *TouchedAccounts) IsRead(address []byte) bool {
+	return containsAddress(touched.Read, address)
+}
+
+// IsWritten returns true if the account at the given address was written
+func (touched *TouchedAccounts) IsWritten(address []byte) bool {
+	return containsAddress(touched.Written, address)
+}
+
+// ConflictsWith returns true if the executions which touched the given
+// accounts cannot be reordered, because one of them writes an account which
+// the other one reads or writes
+func (touched *TouchedAccounts) ConflictsWith(other *TouchedAccounts) bool {
+	for _, address := range touched.Written {
+		if other.IsRead(address) || other.IsWritten(address) {
+			return true
+		}
+	}
+	for _, address := range other.Written {
+		if touched.IsRead(address) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func containsAddress(sortedAddresses [][]byte, address []byte) bool {
+	index := sort.Search(len(sortedAddresses), func(i int) bool {
+		return bytes.Compare(sortedAddresses[i], address) >= 0
+	})
+
+	return index < len(sortedAddresses) && bytes.Equal(sortedAddresses[index], address)
+}
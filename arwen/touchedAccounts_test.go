@@ -0,0 +1,29 @@
+package arwen
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTouchedAccounts_ConflictsWith(t *testing.T) {
+	t.Parallel()
+
+	a, b, c := []byte("a"), []byte("b"), []byte("c")
+	readOnly := &TouchedAccounts{Read: [][]byte{a, b}, Written: [][]byte{}}
+	otherReadOnly := &TouchedAccounts{Read: [][]byte{a, c}, Written: [][]byte{}}
+	writesB := &TouchedAccounts{Read: [][]byte{b}, Written: [][]byte{b}}
+	writesC := &TouchedAccounts{Read: [][]byte{}, Written: [][]byte{c}}
+
+	// reading the same accounts is not a conflict
+	require.False(t, readOnly.ConflictsWith(otherReadOnly))
+
+	// writing an account read or written by the other execution is a conflict, in both directions
+	require.True(t, readOnly.ConflictsWith(writesB))
+	require.True(t, writesB.ConflictsWith(readOnly))
+	require.True(t, writesC.ConflictsWith(otherReadOnly))
+	require.True(t, writesC.ConflictsWith(writesC))
+
+	require.False(t, writesB.ConflictsWith(writesC))
+	require.False(t, readOnly.ConflictsWith(writesC))
+}
@@ -0,0 +1,50 @@
+package arwen
+
+import (
+	"bytes"
+	"sort"
+)
+
+// TouchedStorageKey is a key of the storage of an account
+type TouchedStorageKey struct {
+	Address []byte
+	Key     []byte
+}
+
+// TouchedStorageKeys holds the storage keys read during an execution, sorted
+// by address, then by key. A key is read whether its value is retrieved from
+// the node or was already loaded or written during the execution, and also
+// when a contract writes it, since the cost of the write depends on the value
+// it replaces. Proving the values of these keys before the execution is
+// enough to re-execute it without access to the rest of the storage.
+type TouchedStorageKeys struct {
+	Keys []*TouchedStorageKey
+}
+
+// NewTouchedStorageKeys creates a TouchedStorageKeys holding the given keys, which it sorts
+func NewTouchedStorageKeys(keys []*TouchedStorageKey) *TouchedStorageKeys {
+	sort.Slice(keys, func(i, j int) bool {
+		addressOrder := bytes.Compare(keys[i].Address, keys[j].Address)
+		if addressOrder != 0 {
+			return addressOrder < 0
+		}
+		return bytes.Compare(keys[i].Key, keys[j].Key) < 0
+	})
+
+	return &TouchedStorageKeys{Keys: keys}
+}
+
+// Contains returns true if the given key of the storage of the given account was read
+func (touched *TouchedStorageKeys) Contains(address []byte, key []byte) bool {
+	index := sort.Search(len(touched.Keys), func(i int) bool {
+		addressOrder := bytes.Compare(touched.Keys[i].Address, address)
+		if addressOrder != 0 {
+			return addressOrder > 0
+		}
+		return bytes.Compare(touched.Keys[i].Key, key) >= 0
+	})
+
+	return index < len(touched.Keys) &&
+		bytes.Equal(touched.Keys[index].Address, address) &&
+		bytes.Equal(touched.Keys[index].Key, key)
+}
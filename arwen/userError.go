@@ -0,0 +1,79 @@
+package arwen
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+
+	"github.com/ElrondNetwork/elrond-go/core/vmcommon"
+)
+
+// SignalErrorLogIdentifier is the identifier of the log entry which records
+// the UserErrorReason in the VMOutput of a failed execution
+const SignalErrorLogIdentifier = "signalError"
+
+// UnknownFunctionIndex is the FunctionIndex of a UserErrorReason when the
+// function which signalled the error is not exported by the contract code
+const UnknownFunctionIndex = int32(-1)
+
+// UserErrorFrame is a frame of the call stack reconstructed when a contract
+// signals an error: either the exported function of a contract in the chain
+// of nested executions, or the host function which signalled the error
+type UserErrorFrame struct {
+	ContractAddress []byte
+	Function        string
+	IsHostFunction  bool
+}
+
+// UserErrorReason is the structured description of an error signalled by a
+// contract, captured by the Runtime context. FunctionIndex is the index of
+// the exported function in the function index space of the Wasm module. The
+// CallStack, from the outermost frame to the innermost one, is only
+// reconstructed in debug mode, enabled through the VMHostParameters; only
+// then is the reason also recorded in the VMOutput, as a log entry.
+type UserErrorReason struct {
+	Message         string
+	ContractAddress []byte
+	Function        string
+	FunctionIndex   int32
+	CallStack       []UserErrorFrame
+}
+
+// LogEntry encodes the UserErrorReason as a VMOutput log entry; its topics
+// are the function name, the function index as 4 big-endian bytes (empty if
+// unknown), followed by one topic per frame of the call stack
+func (reason *UserErrorReason) LogEntry() *vmcommon.LogEntry {
+	functionIndex := make([]byte, 0, 4)
+	if reason.FunctionIndex != UnknownFunctionIndex {
+		functionIndex = functionIndex[:4]
+		binary.BigEndian.PutUint32(functionIndex, uint32(reason.FunctionIndex))
+	}
+
+	topics := make([][]byte, 0, 2+len(reason.CallStack))
+	topics = append(topics, []byte(reason.Function), functionIndex)
+	for _, frame := range reason.CallStack {
+		topics = append(topics, []byte(frame.String()))
+	}
+
+	return &vmcommon.LogEntry{
+		Identifier: []byte(SignalErrorLogIdentifier),
+		Address:    reason.ContractAddress,
+		Topics:     topics,
+		Data:       []byte(reason.Message),
+	}
+}
+
+// String formats the frame as "<hex address>.<function>" for contract
+// functions and as "host.<function>" for host functions
+func (frame UserErrorFrame) String() string {
+	if frame.IsHostFunction {
+		return "host." + frame.Function
+	}
+	return hex.EncodeToString(frame.ContractAddress) + "." + frame.Function
+}
+
+// UserErrorTracer can optionally be implemented by the AsyncTracer injected
+// into the VMHost, in order to also be notified of the errors signalled by
+// contracts, including those of nested executions
+type UserErrorTracer interface {
+	OnUserError(reason UserErrorReason)
+}
@@ -0,0 +1,116 @@
+package arwen
+
+const wasmHeaderLength = 8
+const wasmExportSectionID = 7
+const wasmExportKindFunction = 0
+
+// ExportedFunctionIndex returns the index, in the function index space of
+// the given Wasm module, of the function exported under the given name; it
+// only decodes the export section, skipping all the others, and returns
+// false if the module is malformed or does not export such a function
+func ExportedFunctionIndex(code []byte, name string) (uint32, bool) {
+	if len(code) < wasmHeaderLength {
+		return 0, false
+	}
+
+	reader := &wasmReader{data: code, offset: wasmHeaderLength}
+	for reader.offset < len(reader.data) {
+		sectionID, ok := reader.readByte()
+		if !ok {
+			return 0, false
+		}
+		sectionSize, ok := reader.readUint32()
+		if !ok {
+			return 0, false
+		}
+
+		sectionEnd := reader.offset + int(sectionSize)
+		if sectionEnd > len(reader.data) {
+			return 0, false
+		}
+		if sectionID == wasmExportSectionID {
+			section := &wasmReader{data: reader.data[:sectionEnd], offset: reader.offset}
+			return section.findExportedFunction(name)
+		}
+		reader.offset = sectionEnd
+	}
+
+	return 0, false
+}
+
+type wasmReader struct {
+	data   []byte
+	offset int
+}
+
+func (reader *wasmReader) findExportedFunction(name string) (uint32, bool) {
+	numExports, ok := reader.readUint32()
+	if !ok {
+		return 0, false
+	}
+
+	for i := uint32(0); i < numExports; i++ {
+		exportName, ok := reader.readName()
+		if !ok {
+			return 0, false
+		}
+		kind, ok := reader.readByte()
+		if !ok {
+			return 0, false
+		}
+		index, ok := reader.readUint32()
+		if !ok {
+			return 0, false
+		}
+
+		if kind == wasmExportKindFunction && exportName == name {
+			return index, true
+		}
+	}
+
+	return 0, false
+}
+
+func (reader *wasmReader) readByte() (byte, bool) {
+	if reader.offset >= len(reader.data) {
+		return 0, false
+	}
+
+	value := reader.data[reader.offset]
+	reader.offset++
+	return value, true
+}
+
+// readUint32 decodes an unsigned LEB128 integer of at most 32 bits
+func (reader *wasmReader) readUint32() (uint32, bool) {
+	result := uint32(0)
+	for shift := uint(0); shift < 35; shift += 7 {
+		value, ok := reader.readByte()
+		if !ok {
+			return 0, false
+		}
+
+		result |= uint32(value&0x7f) << shift
+		if value&0x80 == 0 {
+			return result, true
+		}
+	}
+
+	return 0, false
+}
+
+func (reader *wasmReader) readName() (string, bool) {
+	length, ok := reader.readUint32()
+	if !ok {
+		return "", false
+	}
+
+	end := reader.offset + int(length)
+	if end > len(reader.data) {
+		return "", false
+	}
+
+	name := string(reader.data[reader.offset:end])
+	reader.offset = end
+	return name, true
+}
@@ -0,0 +1,49 @@
+package arwen
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var wasmHeader = []byte{0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00}
+
+func TestExportedFunctionIndex(t *testing.T) {
+	code := append([]byte{}, wasmHeader...)
+	// an unrelated custom section, which must be skipped
+	code = append(code, 0x00, 0x03, 0x01, 'x', 0xff)
+	// export section: memory "memory" at 0, function "init" at 2, function "big" at 200
+	code = append(code, 0x07, 0x18, 0x03,
+		0x06, 'm', 'e', 'm', 'o', 'r', 'y', 0x02, 0x00,
+		0x04, 'i', 'n', 'i', 't', 0x00, 0x02,
+		0x03, 'b', 'i', 'g', 0x00, 0xc8, 0x01,
+	)
+
+	index, ok := ExportedFunctionIndex(code, "init")
+	require.True(t, ok)
+	require.Equal(t, uint32(2), index)
+
+	index, ok = ExportedFunctionIndex(code, "big")
+	require.True(t, ok)
+	require.Equal(t, uint32(200), index)
+
+	_, ok = ExportedFunctionIndex(code, "memory")
+	require.False(t, ok)
+
+	_, ok = ExportedFunctionIndex(code, "missing")
+	require.False(t, ok)
+}
+
+func TestExportedFunctionIndex_Malformed(t *testing.T) {
+	_, ok := ExportedFunctionIndex(nil, "init")
+	require.False(t, ok)
+
+	truncated := append(append([]byte{}, wasmHeader...), 0x07, 0x10, 0x01, 0x04, 'i')
+	_, ok = ExportedFunctionIndex(truncated, "init")
+	require.False(t, ok)
+
+	overlongIndex := append(append([]byte{}, wasmHeader...), 0x07, 0x0b, 0x01,
+		0x04, 'i', 'n', 'i', 't', 0x00, 0xff, 0xff, 0xff, 0xff, 0xff)
+	_, ok = ExportedFunctionIndex(overlongIndex, "init")
+	require.False(t, ok)
+}
@@ -0,0 +1,560 @@
+package arwen
+
+import (
+	"bytes"
+	"fmt"
+)
+
+const wasmTypeSectionID = 1
+const wasmImportSectionID = 2
+const wasmFunctionSectionID = 3
+const wasmGlobalSectionID = 6
+const wasmStartSectionID = 8
+const wasmCodeSectionID = 10
+const wasmDataSectionID = 11
+
+const wasmImportKindFunction = 0
+const wasmImportKindTable = 1
+const wasmImportKindMemory = 2
+const wasmImportKindGlobal = 3
+
+const wasmFunctionTypeForm = 0x60
+const wasmValueTypeF32 = 0x7d
+const wasmValueTypeF64 = 0x7c
+const wasmEmptyBlockType = 0x40
+
+const wasmOpcodeEnd = 0x0b
+const wasmOpcodePrefixMisc = 0xfc
+
+var wasmMagic = []byte("\x00asm")
+
+// legacyAsyncCallImportName is the EEI function performing an asynchronous
+// call whose result is always delivered to the default callback
+const legacyAsyncCallImportName = "asyncCall"
+
+// RuntimeValidationConfig holds the rules checked on the code of a contract
+// before it is instantiated on deployment or upgrade. Modules using
+// floating-point values or instructions are refused when
+// DisallowFloatingPoint is set, and modules with a start section when
+// DisallowStartSection is set. Modules importing the legacy asyncCall are
+// refused when RequireCallbackExport is set, unless they export the default
+// callback. A module may define at most MaxFunctions functions and
+// MaxGlobals globals; a value of 0 leaves the respective limit unset.
+type RuntimeValidationConfig struct {
+	DisallowFloatingPoint bool
+	DisallowStartSection  bool
+	RequireCallbackExport bool
+	MaxFunctions          uint32
+	MaxGlobals            uint32
+}
+
+// IsEnabled returns true if at least one of the rules is set
+func (config RuntimeValidationConfig) IsEnabled() bool {
+	return config.DisallowFloatingPoint ||
+		config.DisallowStartSection ||
+		config.RequireCallbackExport ||
+		config.MaxFunctions > 0 ||
+		config.MaxGlobals > 0
+}
+
+// ValidateModule decodes the given Wasm module and checks it against the
+// rules, returning the error of the first rule it violates, with the details
+// of the violation
+func (config RuntimeValidationConfig) ValidateModule(code []byte) error {
+	if !config.IsEnabled() {
+		return nil
+	}
+
+	module, err := decodeWasmModule(code)
+	if err != nil {
+		return err
+	}
+
+	if config.DisallowFloatingPoint && len(module.floatingPointUse) > 0 {
+		return fmt.Errorf("%w: %s", ErrFloatingPointNotAllowed, module.floatingPointUse)
+	}
+	if config.MaxFunctions > 0 && module.numFunctions > config.MaxFunctions {
+		return fmt.Errorf("%w: %d defined, at most %d allowed", ErrTooManyFunctions, module.numFunctions, config.MaxFunctions)
+	}
+	if config.MaxGlobals > 0 && module.numGlobals > config.MaxGlobals {
+		return fmt.Errorf("%w: %d defined, at most %d allowed", ErrTooManyGlobals, module.numGlobals, config.MaxGlobals)
+	}
+	if config.RequireCallbackExport && module.importsLegacyAsyncCall {
+		_, exported := ExportedFunctionIndex(code, CallbackFunctionName)
+		if !exported {
+			return fmt.Errorf("%w: %s imported without exporting %s", ErrCallbackNotExported, legacyAsyncCallImportName, CallbackFunctionName)
+		}
+	}
+	if config.DisallowStartSection && module.hasStartSection {
+		return ErrStartSectionNotAllowed
+	}
+
+	return nil
+}
+
+// wasmModuleSummary holds what the validation rules need to know about a
+// Wasm module; floatingPointUse describes the first use of floating-point
+// values or instructions found in the module, if any
+type wasmModuleSummary struct {
+	numFunctions           uint32
+	numGlobals             uint32
+	hasStartSection        bool
+	hasMutableGlobals      bool
+	importsLegacyAsyncCall bool
+	floatingPointUse       string
+}
+
+// HasMutableGlobals returns true if the given Wasm module defines or imports
+// mutable globals, or if it cannot be decoded; only its import and global
+// sections are decoded, all the others are skipped
+func HasMutableGlobals(code []byte) bool {
+	if !bytes.HasPrefix(code, wasmMagic) {
+		return true
+	}
+
+	module, err := decodeWasmSections(code, func(sectionID byte) bool {
+		return sectionID == wasmImportSectionID || sectionID == wasmGlobalSectionID
+	})
+	return err != nil || module.hasMutableGlobals
+}
+
+func decodeWasmModule(code []byte) (*wasmModuleSummary, error) {
+	return decodeWasmSections(code, func(_ byte) bool {
+		return true
+	})
+}
+
+// decodeWasmSections decodes the sections of the given Wasm module for which
+// shouldDecode returns true, skipping all the others
+func decodeWasmSections(code []byte, shouldDecode func(sectionID byte) bool) (*wasmModuleSummary, error) {
+	if len(code) < wasmHeaderLength {
+		return nil, fmt.Errorf("%w: missing header", ErrModuleMalformed)
+	}
+
+	module := &wasmModuleSummary{}
+
+	reader := &wasmReader{data: code, offset: wasmHeaderLength}
+	for reader.offset < len(reader.data) {
+		sectionID, ok := reader.readByte()
+		if !ok {
+			return nil, fmt.Errorf("%w: truncated section", ErrModuleMalformed)
+		}
+		sectionSize, ok := reader.readUint32()
+		if !ok {
+			return nil, fmt.Errorf("%w: truncated section", ErrModuleMalformed)
+		}
+
+		sectionEnd := reader.offset + int(sectionSize)
+		if sectionEnd > len(reader.data) {
+			return nil, fmt.Errorf("%w: section %d exceeds the module", ErrModuleMalformed, sectionID)
+		}
+
+		if shouldDecode(sectionID) {
+			section := &wasmReader{data: reader.data[:sectionEnd], offset: reader.offset}
+			ok = module.decodeSection(sectionID, section)
+			if !ok {
+				return nil, fmt.Errorf("%w: cannot decode section %d", ErrModuleMalformed, sectionID)
+			}
+		}
+		reader.offset = sectionEnd
+	}
+
+	return module, nil
+}
+
+func (module *wasmModuleSummary) decodeSection(sectionID byte, section *wasmReader) bool {
+	switch sectionID {
+	case wasmTypeSectionID:
+		return module.decodeTypes(section)
+	case wasmImportSectionID:
+		return module.decodeImports(section)
+	case wasmFunctionSectionID:
+		var ok bool
+		module.numFunctions, ok = section.readUint32()
+		return ok
+	case wasmGlobalSectionID:
+		return module.decodeGlobals(section)
+	case wasmStartSectionID:
+		module.hasStartSection = true
+		return true
+	case wasmCodeSectionID:
+		return module.decodeCode(section)
+	}
+
+	return true
+}
+
+func (module *wasmModuleSummary) decodeTypes(section *wasmReader) bool {
+	numTypes, ok := section.readUint32()
+	if !ok {
+		return false
+	}
+
+	for i := uint32(0); i < numTypes; i++ {
+		form, ok := section.readByte()
+		if !ok || form != wasmFunctionTypeForm {
+			return false
+		}
+
+		location := fmt.Sprintf("type %d", i)
+		ok = module.decodeValueTypes(section, location) && module.decodeValueTypes(section, location)
+		if !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (module *wasmModuleSummary) decodeImports(section *wasmReader) bool {
+	numImports, ok := section.readUint32()
+	if !ok {
+		return false
+	}
+
+	for i := uint32(0); i < numImports; i++ {
+		_, ok = section.readName()
+		if !ok {
+			return false
+		}
+		name, ok := section.readName()
+		if !ok {
+			return false
+		}
+		kind, ok := section.readByte()
+		if !ok {
+			return false
+		}
+
+		switch kind {
+		case wasmImportKindFunction:
+			module.importsLegacyAsyncCall = module.importsLegacyAsyncCall || name == legacyAsyncCallImportName
+			_, ok = section.readUint32()
+		case wasmImportKindTable:
+			_, ok = section.readByte()
+			ok = ok && section.skipLimits()
+		case wasmImportKindMemory:
+			ok = section.skipLimits()
+		case wasmImportKindGlobal:
+			var valueType byte
+			valueType, ok = section.readByte()
+			module.checkValueType(valueType, fmt.Sprintf("imported global %s", name))
+			ok = ok && module.decodeMutability(section)
+		default:
+			ok = false
+		}
+		if !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (module *wasmModuleSummary) decodeGlobals(section *wasmReader) bool {
+	numGlobals, ok := section.readUint32()
+	if !ok {
+		return false
+	}
+	module.numGlobals = numGlobals
+
+	for i := uint32(0); i < numGlobals; i++ {
+		location := fmt.Sprintf("global %d", i)
+		valueType, ok := section.readByte()
+		if !ok {
+			return false
+		}
+		module.checkValueType(valueType, location)
+
+		ok = module.decodeMutability(section)
+		if !ok {
+			return false
+		}
+
+		// the initializer is a constant expression, which does not nest blocks
+		for {
+			opcode, ok := module.decodeInstruction(section, location)
+			if !ok {
+				return false
+			}
+			if opcode == wasmOpcodeEnd {
+				break
+			}
+		}
+	}
+
+	return true
+}
+
+func (module *wasmModuleSummary) decodeMutability(section *wasmReader) bool {
+	mutability, ok := section.readByte()
+	if mutability != 0 {
+		module.hasMutableGlobals = true
+	}
+
+	return ok
+}
+
+func (module *wasmModuleSummary) decodeCode(section *wasmReader) bool {
+	numBodies, ok := section.readUint32()
+	if !ok {
+		return false
+	}
+
+	for i := uint32(0); i < numBodies; i++ {
+		location := fmt.Sprintf("function body %d", i)
+		bodySize, ok := section.readUint32()
+		if !ok {
+			return false
+		}
+		bodyEnd := section.offset + int(bodySize)
+		if bodyEnd > len(section.data) {
+			return false
+		}
+		body := &wasmReader{data: section.data[:bodyEnd], offset: section.offset}
+
+		numLocalGroups, ok := body.readUint32()
+		if !ok {
+			return false
+		}
+		for j := uint32(0); j < numLocalGroups; j++ {
+			_, ok = body.readUint32()
+			if !ok {
+				return false
+			}
+			valueType, ok := body.readByte()
+			if !ok {
+				return false
+			}
+			module.checkValueType(valueType, location)
+		}
+
+		for body.offset < bodyEnd {
+			_, ok = module.decodeInstruction(body, location)
+			if !ok {
+				return false
+			}
+		}
+		section.offset = bodyEnd
+	}
+
+	return true
+}
+
+// decodeInstruction skips over the next instruction, recording whether it
+// operates on floating-point values, and returns its opcode
+func (module *wasmModuleSummary) decodeInstruction(reader *wasmReader, location string) (byte, bool) {
+	opcode, ok := reader.readByte()
+	if !ok {
+		return 0, false
+	}
+
+	switch {
+	case opcode <= 0x01 || opcode == 0x05 || opcode == wasmOpcodeEnd || opcode == 0x0f ||
+		opcode == 0x1a || opcode == 0x1b || opcode == 0xd1:
+		// no immediates
+	case opcode >= 0x02 && opcode <= 0x04:
+		ok = module.skipBlockType(reader, location)
+	case opcode == 0x0c || opcode == 0x0d || opcode == 0x10 || opcode == 0xd2 ||
+		(opcode >= 0x20 && opcode <= 0x26):
+		_, ok = reader.readUint32()
+	case opcode == 0x0e:
+		ok = reader.skipIndices()
+		if ok {
+			_, ok = reader.readUint32()
+		}
+	case opcode == 0x11:
+		_, ok = reader.readUint32()
+		if ok {
+			_, ok = reader.readUint32()
+		}
+	case opcode == 0x1c:
+		ok = module.decodeValueTypes(reader, location)
+	case opcode >= 0x28 && opcode <= 0x3e:
+		// memory access: alignment and offset
+		_, ok = reader.readUint32()
+		if ok {
+			_, ok = reader.readUint32()
+		}
+	case opcode == 0x3f || opcode == 0x40 || opcode == 0xd0:
+		_, ok = reader.readByte()
+	case opcode == 0x41:
+		ok = reader.skipSignedInteger(5)
+	case opcode == 0x42:
+		ok = reader.skipSignedInteger(10)
+	case opcode == 0x43:
+		ok = reader.skip(4)
+	case opcode == 0x44:
+		ok = reader.skip(8)
+	case opcode >= 0x45 && opcode <= 0xc4:
+		// numeric instructions, without immediates
+	case opcode == wasmOpcodePrefixMisc:
+		return opcode, module.decodeMiscInstruction(reader, location)
+	default:
+		return 0, false
+	}
+
+	if ok && isFloatingPointOpcode(opcode) {
+		module.recordFloatingPoint(fmt.Sprintf("opcode 0x%02x in %s", opcode, location))
+	}
+
+	return opcode, ok
+}
+
+// decodeMiscInstruction skips over the immediates of an instruction having
+// the 0xfc prefix, of which the first eight are saturating truncations of
+// floating-point values
+func (module *wasmModuleSummary) decodeMiscInstruction(reader *wasmReader, location string) bool {
+	subOpcode, ok := reader.readUint32()
+	if !ok {
+		return false
+	}
+
+	switch {
+	case subOpcode <= 7:
+		module.recordFloatingPoint(fmt.Sprintf("opcode 0xfc %d in %s", subOpcode, location))
+		return true
+	case subOpcode == 8:
+		_, ok = reader.readUint32()
+		return ok && reader.skip(1)
+	case subOpcode == 10:
+		return reader.skip(2)
+	case subOpcode == 11:
+		return reader.skip(1)
+	case subOpcode == 12 || subOpcode == 14:
+		_, ok = reader.readUint32()
+		if ok {
+			_, ok = reader.readUint32()
+		}
+		return ok
+	case subOpcode <= 17:
+		_, ok = reader.readUint32()
+		return ok
+	}
+
+	return false
+}
+
+func (module *wasmModuleSummary) skipBlockType(reader *wasmReader, location string) bool {
+	blockType, ok := reader.readByte()
+	if !ok {
+		return false
+	}
+	if blockType == wasmEmptyBlockType {
+		return true
+	}
+	if blockType&0x80 == 0 && blockType >= 0x40 {
+		// a single value type, encoded as a negative number
+		module.checkValueType(blockType, location)
+		return true
+	}
+
+	// the index of a function type, as a signed integer of 33 bits
+	reader.offset--
+	return reader.skipSignedInteger(5)
+}
+
+func (module *wasmModuleSummary) decodeValueTypes(reader *wasmReader, location string) bool {
+	numValues, ok := reader.readUint32()
+	if !ok {
+		return false
+	}
+
+	for i := uint32(0); i < numValues; i++ {
+		valueType, ok := reader.readByte()
+		if !ok {
+			return false
+		}
+		module.checkValueType(valueType, location)
+	}
+
+	return true
+}
+
+func (module *wasmModuleSummary) checkValueType(valueType byte, location string) {
+	if valueType == wasmValueTypeF32 || valueType == wasmValueTypeF64 {
+		module.recordFloatingPoint(fmt.Sprintf("value type 0x%02x in %s", valueType, location))
+	}
+}
+
+func (module *wasmModuleSummary) recordFloatingPoint(use string) {
+	if len(module.floatingPointUse) == 0 {
+		module.floatingPointUse = use
+	}
+}
+
+// isFloatingPointOpcode returns true for the loads, stores, constants,
+// comparisons, arithmetic and conversions involving floating-point values
+func isFloatingPointOpcode(opcode byte) bool {
+	switch {
+	case opcode == 0x2a || opcode == 0x2b || opcode == 0x38 || opcode == 0x39:
+		return true
+	case opcode == 0x43 || opcode == 0x44:
+		return true
+	case opcode >= 0x5b && opcode <= 0x66:
+		return true
+	case opcode >= 0x8b && opcode <= 0xa6:
+		return true
+	case opcode >= 0xa8 && opcode <= 0xab:
+		return true
+	case opcode >= 0xae && opcode <= 0xbf:
+		return true
+	}
+
+	return false
+}
+
+func (reader *wasmReader) skip(length int) bool {
+	end := reader.offset + length
+	if end > len(reader.data) {
+		return false
+	}
+
+	reader.offset = end
+	return true
+}
+
+// skipIndices skips a vector of indices
+func (reader *wasmReader) skipIndices() bool {
+	length, ok := reader.readUint32()
+	if !ok {
+		return false
+	}
+
+	for i := uint32(0); i < length; i++ {
+		_, ok = reader.readUint32()
+		if !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// skipSignedInteger skips a signed LEB128 integer encoded on at most maxBytes bytes
+func (reader *wasmReader) skipSignedInteger(maxBytes int) bool {
+	for i := 0; i < maxBytes; i++ {
+		value, ok := reader.readByte()
+		if !ok {
+			return false
+		}
+		if value&0x80 == 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (reader *wasmReader) skipLimits() bool {
+	flags, ok := reader.readByte()
+	if !ok {
+		return false
+	}
+
+	_, ok = reader.readUint32()
+	if ok && flags&0x01 != 0 {
+		_, ok = reader.readUint32()
+	}
+	return ok
+}
@@ -0,0 +1,143 @@
+package arwen
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// makeTestModule builds a module defining the functions with the given
+// bodies, all of type () -> (), exporting the first one as "init", along
+// with the given extra sections
+func makeTestModule(bodies [][]byte, extraSections ...[]byte) []byte {
+	code := append([]byte{}, wasmHeader...)
+	// type section: () -> ()
+	code = append(code, 0x01, 0x04, 0x01, 0x60, 0x00, 0x00)
+	// import section: "env" "asyncCall" of type 0
+	code = append(code, 0x02, 0x11, 0x01,
+		0x03, 'e', 'n', 'v',
+		0x09, 'a', 's', 'y', 'n', 'c', 'C', 'a', 'l', 'l',
+		0x00, 0x00,
+	)
+
+	functions := []byte{byte(len(bodies))}
+	for range bodies {
+		functions = append(functions, 0x00)
+	}
+	code = append(code, 0x03, byte(len(functions)))
+	code = append(code, functions...)
+
+	for _, section := range extraSections {
+		code = append(code, section...)
+	}
+
+	// export section: function "init" at 1, after the imported one
+	code = append(code, 0x07, 0x08, 0x01, 0x04, 'i', 'n', 'i', 't', 0x00, 0x01)
+
+	bodiesSection := []byte{byte(len(bodies))}
+	for _, body := range bodies {
+		bodiesSection = append(bodiesSection, byte(len(body)))
+		bodiesSection = append(bodiesSection, body...)
+	}
+	code = append(code, 0x0a, byte(len(bodiesSection)))
+	code = append(code, bodiesSection...)
+
+	return code
+}
+
+var emptyBody = []byte{0x00, 0x0b}
+
+func TestValidateModule_Disabled(t *testing.T) {
+	config := RuntimeValidationConfig{}
+	require.False(t, config.IsEnabled())
+	require.Nil(t, config.ValidateModule([]byte("not a module")))
+}
+
+func TestValidateModule_FloatingPoint(t *testing.T) {
+	config := RuntimeValidationConfig{DisallowFloatingPoint: true}
+
+	integerBody := []byte{0x00, 0x41, 0x2a, 0x41, 0x01, 0x6a, 0x1a, 0x0b}
+	require.Nil(t, config.ValidateModule(makeTestModule([][]byte{integerBody})))
+
+	floatConstBody := []byte{0x00, 0x43, 0x00, 0x00, 0x80, 0x3f, 0x1a, 0x0b}
+	err := config.ValidateModule(makeTestModule([][]byte{emptyBody, floatConstBody}))
+	require.True(t, errors.Is(err, ErrFloatingPointNotAllowed))
+	require.Contains(t, err.Error(), "opcode 0x43 in function body 1")
+
+	floatLocalBody := []byte{0x01, 0x01, 0x7c, 0x0b}
+	err = config.ValidateModule(makeTestModule([][]byte{floatLocalBody}))
+	require.True(t, errors.Is(err, ErrFloatingPointNotAllowed))
+	require.Contains(t, err.Error(), "value type 0x7c")
+
+	truncationBody := []byte{0x00, 0x41, 0x00, 0xfc, 0x02, 0x1a, 0x0b}
+	err = config.ValidateModule(makeTestModule([][]byte{truncationBody}))
+	require.True(t, errors.Is(err, ErrFloatingPointNotAllowed))
+
+	floatGlobal := []byte{0x06, 0x09, 0x01, 0x7d, 0x00, 0x43, 0x00, 0x00, 0x00, 0x00, 0x0b}
+	err = config.ValidateModule(makeTestModule([][]byte{emptyBody}, floatGlobal))
+	require.True(t, errors.Is(err, ErrFloatingPointNotAllowed))
+	require.Contains(t, err.Error(), "global 0")
+}
+
+func TestValidateModule_Limits(t *testing.T) {
+	config := RuntimeValidationConfig{MaxFunctions: 2, MaxGlobals: 1}
+	globals := []byte{0x06, 0x0b, 0x02, 0x7f, 0x01, 0x41, 0x00, 0x0b, 0x7e, 0x00, 0x42, 0x7f, 0x0b}
+
+	require.Nil(t, config.ValidateModule(makeTestModule([][]byte{emptyBody, emptyBody})))
+
+	err := config.ValidateModule(makeTestModule([][]byte{emptyBody, emptyBody, emptyBody}))
+	require.True(t, errors.Is(err, ErrTooManyFunctions))
+	require.True(t, errors.Is(err, ErrContractInvalid))
+	require.Contains(t, err.Error(), "3 defined, at most 2 allowed")
+
+	err = config.ValidateModule(makeTestModule([][]byte{emptyBody}, globals))
+	require.True(t, errors.Is(err, ErrTooManyGlobals))
+}
+
+func TestValidateModule_CallbackAndStartSection(t *testing.T) {
+	config := RuntimeValidationConfig{RequireCallbackExport: true}
+	err := config.ValidateModule(makeTestModule([][]byte{emptyBody}))
+	require.True(t, errors.Is(err, ErrCallbackNotExported))
+
+	config = RuntimeValidationConfig{DisallowStartSection: true}
+	require.Nil(t, config.ValidateModule(makeTestModule([][]byte{emptyBody})))
+
+	start := []byte{0x08, 0x01, 0x01}
+	err = config.ValidateModule(makeTestModule([][]byte{emptyBody}, start))
+	require.True(t, errors.Is(err, ErrStartSectionNotAllowed))
+}
+
+func TestValidateModule_Malformed(t *testing.T) {
+	config := RuntimeValidationConfig{DisallowFloatingPoint: true}
+
+	err := config.ValidateModule(wasmHeader[:4])
+	require.True(t, errors.Is(err, ErrModuleMalformed))
+
+	unknownOpcodeBody := []byte{0x00, 0xff, 0x0b}
+	err = config.ValidateModule(makeTestModule([][]byte{unknownOpcodeBody}))
+	require.True(t, errors.Is(err, ErrModuleMalformed))
+
+	truncated := makeTestModule([][]byte{emptyBody})
+	err = config.ValidateModule(truncated[:len(truncated)-1])
+	require.True(t, errors.Is(err, ErrModuleMalformed))
+}
+
+func TestHasMutableGlobals(t *testing.T) {
+	contractHasMutableGlobals := func(contract string) bool {
+		code, err := ioutil.ReadFile(fmt.Sprintf("../test/contracts/%s/output/%s.wasm", contract, contract))
+		require.Nil(t, err)
+		return HasMutableGlobals(code)
+	}
+
+	require.True(t, contractHasMutableGlobals("counter"))
+	require.True(t, contractHasMutableGlobals("global-counter"))
+	require.False(t, contractHasMutableGlobals("storage-counter"))
+	require.False(t, contractHasMutableGlobals("memoryless"))
+
+	// codes which cannot be decoded are treated as having mutable globals
+	require.True(t, HasMutableGlobals([]byte("not wasm")))
+	require.True(t, HasMutableGlobals(append(append([]byte{}, wasmHeader...), 0x06, 0x05)))
+}
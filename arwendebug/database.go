@@ -51,6 +51,10 @@ func (db *database) loadWorld(worldID string) (*world, error) {
 	return world, nil
 }
 
+func (db *database) hasWorld(worldID string) bool {
+	return fileExists(db.getWorldFile(worldID))
+}
+
 func (db *database) getWorldFile(worldID string) string {
 	return path.Join(db.rootPath, "worlds", fmt.Sprintf("%s.json", worldID))
 }
@@ -0,0 +1,65 @@
+package arwendebug
+
+import (
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen/host"
+	"github.com/ElrondNetwork/elrond-go/core/vmcommon"
+)
+
+// maxDebugSteps bounds the number of steps taken for a call, since each step
+// runs the call again
+const maxDebugSteps = 1000
+
+// takeDebugSteps runs the given call in the single-step debugging mode, once
+// for each step, without committing its output: the instances started by the
+// call are stopped in turn, each first when it starts and then every interval
+// points, until it finishes before reaching the next stop. No steps are taken
+// if the interval is 0.
+func (w *world) takeDebugSteps(input *vmcommon.ContractCallInput, interval uint64, isQuery bool) ([]arwen.DebugStep, error) {
+	if interval == 0 {
+		return nil, nil
+	}
+
+	vm, err := host.NewArwenVM(w.blockchainHook, getHostParameters())
+	if err != nil {
+		return nil, err
+	}
+
+	steps := make([]arwen.DebugStep, 0)
+	for instance := uint64(1); len(steps) < maxDebugSteps; instance++ {
+		numStepsBefore := len(steps)
+		for points := uint64(0); len(steps) < maxDebugSteps; points += interval {
+			step, err := runToDebugStop(vm, input, arwen.DebugStop{Instance: instance, Points: points}, isQuery)
+			if err != nil {
+				return nil, err
+			}
+			if step == nil {
+				break
+			}
+
+			steps = append(steps, *step)
+		}
+
+		// the call started fewer instances
+		if len(steps) == numStepsBefore {
+			break
+		}
+	}
+
+	return steps, nil
+}
+
+func runToDebugStop(vm arwen.VMHost, input *vmcommon.ContractCallInput, stop arwen.DebugStop, isQuery bool) (*arwen.DebugStep, error) {
+	var stoppedStep *arwen.DebugStep
+	vm.Runtime().SetDebugStop(stop, func(step arwen.DebugStep) {
+		stoppedStep = &step
+	})
+
+	stepInput := *input
+	_, err := runCallOrQuery(vm, &stepInput, isQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	return stoppedStep, nil
+}
@@ -44,3 +44,9 @@ var ErrInvalidArgumentEncoding = errors.New("invalid contract argument encoding"
 
 // ErrAccountDoesntExist signals an error
 var ErrAccountDoesntExist = errors.New("account does not exist")
+
+// ErrWorldAlreadyExists signals an error
+var ErrWorldAlreadyExists = errors.New("world already exists")
+
+// ErrUnsupportedScenarioStep signals an error
+var ErrUnsupportedScenarioStep = errors.New("unsupported scenario step")
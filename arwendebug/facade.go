@@ -3,7 +3,9 @@ package arwendebug
 import (
 	"encoding/json"
 	"fmt"
+	"io/ioutil"
 
+	mjwrite "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mandos-go/json/write"
 	logger "github.com/ElrondNetwork/elrond-go-logger"
 )
 
@@ -173,6 +175,112 @@ func (f *DebugFacade) CreateAccount(request CreateAccountRequest) (*CreateAccoun
 	return response, err
 }
 
+// MigrateAsyncData converts the legacy async data found in the storage of a contract to the AsyncContext format
+func (f *DebugFacade) MigrateAsyncData(request MigrateAsyncDataRequest) (*MigrateAsyncDataResponse, error) {
+	log.Debug("Debugf.MigrateAsyncData()")
+
+	err := request.digest()
+	if err != nil {
+		return nil, err
+	}
+
+	database := f.loadDatabase(request.DatabasePath)
+	world, err := database.loadWorld(request.World)
+	if err != nil {
+		return nil, err
+	}
+
+	response := world.migrateAsyncData(request)
+
+	err = database.storeWorld(world)
+	if err != nil {
+		return nil, err
+	}
+
+	err = database.storeOutcome(request.Outcome, response)
+	if err != nil {
+		return nil, err
+	}
+
+	dumpOutcome(&response)
+	return response, err
+}
+
+// ExportScenario converts the history of a world to a Mandos scenario
+func (f *DebugFacade) ExportScenario(request ExportScenarioRequest) (*ExportScenarioResponse, error) {
+	log.Debug("Debugf.ExportScenario()")
+
+	err := request.digest()
+	if err != nil {
+		return nil, err
+	}
+
+	database := f.loadDatabase(request.DatabasePath)
+	world, err := database.loadWorld(request.World)
+	if err != nil {
+		return nil, err
+	}
+
+	scenario := world.exportScenario(request.Name)
+	response := &ExportScenarioResponse{Scenario: mjwrite.ScenarioToJSONString(scenario)}
+
+	if len(request.ScenarioPath) > 0 {
+		err = ioutil.WriteFile(request.ScenarioPath, []byte(response.Scenario), 0644)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = database.storeOutcome(request.Outcome, response)
+	if err != nil {
+		return nil, err
+	}
+
+	dumpOutcome(&response)
+	return response, err
+}
+
+// ImportScenario loads a Mandos scenario into a new world
+func (f *DebugFacade) ImportScenario(request ImportScenarioRequest) (*ImportScenarioResponse, error) {
+	log.Debug("Debugf.ImportScenario()")
+
+	err := request.digest()
+	if err != nil {
+		return nil, err
+	}
+
+	database := f.loadDatabase(request.DatabasePath)
+	if database.hasWorld(request.World) {
+		return nil, ErrWorldAlreadyExists
+	}
+
+	scenario, err := readScenario(request.ScenarioPath)
+	if err != nil {
+		return nil, err
+	}
+
+	world, err := database.loadWorld(request.World)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ImportScenarioResponse{}
+	response.NumTransactions, response.Error = world.importScenario(scenario)
+
+	err = database.storeWorld(world)
+	if err != nil {
+		return nil, err
+	}
+
+	err = database.storeOutcome(request.Outcome, response)
+	if err != nil {
+		return nil, err
+	}
+
+	dumpOutcome(&response)
+	return response, err
+}
+
 func dumpOutcome(outcome interface{}) {
 	data, err := json.MarshalIndent(outcome, "", "\t")
 	if err != nil {
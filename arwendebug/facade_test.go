@@ -2,9 +2,14 @@ package arwendebug
 
 import (
 	"os"
+	"path"
 	"testing"
 
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwenmandos"
+	mc "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mandos-go/controller"
+	fr "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mandos-go/fileresolver"
 	worldmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/world"
+	"github.com/ElrondNetwork/elrond-go/core/vmcommon"
 	"github.com/stretchr/testify/require"
 )
 
@@ -34,7 +39,12 @@ func TestFacade_RunContract_Counter(t *testing.T) {
 	require.Equal(t, worldmock.GenerateMockAddress(alice.raw, 0), contractAddress)
 	require.True(t, context.accountExists([]byte(contractAddress)))
 
-	context.runContract(contractAddressHex, alice.hex, "increment")
+	runResponse := context.runContract(contractAddressHex, alice.hex, "increment")
+	change, changed := runResponse.StorageDiff.Get([]byte(contractAddress), []byte("COUNTER"))
+	require.True(t, changed)
+	require.Equal(t, []byte{1}, change.Before)
+	require.Equal(t, []byte{2}, change.After)
+
 	counterValue := context.queryContract(contractAddressHex, alice.hex, "get").getFirstResultAsInt64()
 	require.Equal(t, int64(2), counterValue)
 
@@ -45,6 +55,40 @@ func TestFacade_RunContract_Counter(t *testing.T) {
 	require.Equal(t, []byte{2}, state["COUNTER"])
 }
 
+func TestFacade_RunContract_DebugSteps(t *testing.T) {
+	context := newTestContext(t)
+
+	alice := newDummyAddress("alice")
+	context.createAccount(alice.hex, "42")
+	deployResponse := context.deployContract(wasmCounterPath, alice.hex)
+
+	response, err := context.facade.RunSmartContract(RunRequest{
+		ContractRequestBase: ContractRequestBase{
+			RequestBase:     context.createRequestBase(),
+			ImpersonatedHex: alice.hex,
+			GasLimit:        gasLimit,
+		},
+		ContractAddressHex: deployResponse.ContractAddressHex,
+		Function:           "increment",
+		DebugStepInterval:  3,
+	})
+	require.Nil(t, err)
+	require.Nil(t, response.Error)
+	require.Equal(t, vmcommon.Ok, response.Output.ReturnCode)
+
+	require.Greater(t, len(response.DebugSteps), 1)
+	for i, step := range response.DebugSteps {
+		require.Equal(t, uint64(1), step.Instance)
+		require.Equal(t, "increment", step.Function)
+		require.GreaterOrEqual(t, step.PointsUsed, uint64(i*3))
+		require.NotEmpty(t, step.Memory)
+	}
+
+	// the steps are not committed, only the call itself
+	counterValue := context.queryContract(deployResponse.ContractAddressHex, alice.hex, "get").getFirstResultAsInt64()
+	require.Equal(t, int64(2), counterValue)
+}
+
 func TestFacade_RunContract_ERC20(t *testing.T) {
 	context := newTestContext(t)
 
@@ -104,3 +148,45 @@ func TestFacade_UpgradeContract_CounterToERC20(t *testing.T) {
 	require.Equal(t, int64(90), balanceOfAlice)
 	require.Equal(t, int64(10), balanceOfBob)
 }
+
+func TestFacade_ScenarioRoundTrip(t *testing.T) {
+	context := newTestContext(t)
+
+	alice := newDummyAddress("alice")
+	bob := newDummyAddress("bob")
+	context.createAccount(alice.hex, "42")
+	deployResponse := context.deployContract(wasmCounterPath, alice.hex)
+	contractAddressHex := deployResponse.ContractAddressHex
+	context.runContract(contractAddressHex, alice.hex, "increment")
+	context.createAccount(bob.hex, "7")
+	context.runContract(contractAddressHex, bob.hex, "increment")
+
+	scenarioPath := path.Join(databasePath, context.worldID+".scen.json")
+	exported := context.exportScenario("roundTrip", scenarioPath)
+
+	// The exported scenario passes when run by Mandos
+	executor, err := arwenmandos.NewArwenTestExecutor()
+	require.Nil(t, err)
+	runner := mc.NewScenarioRunner(executor, fr.NewDefaultFileResolver())
+	err = runner.RunSingleJSONScenario(scenarioPath)
+	require.Nil(t, err)
+
+	// The scenario recreates the contract in a new world
+	imported := newTestContext(t)
+	imported.worldID = context.worldID + "_imported"
+	importResponse := imported.importScenario(scenarioPath)
+	require.Equal(t, 3, importResponse.NumTransactions)
+
+	counterValue := imported.queryContract(contractAddressHex, alice.hex, "get").getFirstResultAsInt64()
+	require.Equal(t, int64(3), counterValue)
+
+	reexported := imported.exportScenario("roundTrip", "")
+	require.Equal(t, exported.Scenario, reexported.Scenario)
+
+	// Scenarios are only imported into new worlds
+	_, err = imported.facade.ImportScenario(ImportScenarioRequest{
+		RequestBase:  imported.createRequestBase(),
+		ScenarioPath: scenarioPath,
+	})
+	require.Equal(t, ErrWorldAlreadyExists, err)
+}
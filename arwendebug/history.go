@@ -0,0 +1,105 @@
+package arwendebug
+
+import (
+	"math/big"
+
+	worldmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/world"
+	"github.com/ElrondNetwork/elrond-go/core/vmcommon"
+)
+
+type historyEntryKind string
+
+const (
+	historySetState historyEntryKind = "setState"
+	historyDeploy   historyEntryKind = "deploy"
+	historyUpgrade  historyEntryKind = "upgrade"
+	historyRun      historyEntryKind = "run"
+)
+
+// historyEntry records a change of the world, either an account set
+// explicitly or a transaction executed by the VM, so that the world can be
+// exported as a Mandos scenario
+type historyEntry struct {
+	Kind            historyEntryKind
+	Accounts        []*worldmock.Account
+	Sender          []byte
+	ContractAddress []byte
+	Value           *big.Int
+	Code            []byte
+	CodeMetadata    []byte
+	Function        string
+	Arguments       [][]byte
+	GasLimit        uint64
+	ReturnCode      vmcommon.ReturnCode
+	ReturnMessage   string
+	ReturnData      [][]byte
+}
+
+func (w *world) recordSetState(accounts ...*worldmock.Account) {
+	snapshots := make([]*worldmock.Account, len(accounts))
+	for i, account := range accounts {
+		snapshots[i] = snapshotAccount(account)
+	}
+
+	w.history = append(w.history, &historyEntry{
+		Kind:     historySetState,
+		Accounts: snapshots,
+	})
+}
+
+func (w *world) recordTransaction(kind historyEntryKind, input *vmcommon.VMInput, contractAddress []byte, vmOutput *vmcommon.VMOutput) {
+	entry := &historyEntry{
+		Kind:            kind,
+		Sender:          input.CallerAddr,
+		ContractAddress: contractAddress,
+		Value:           input.CallValue,
+		Arguments:       input.Arguments,
+		GasLimit:        input.GasProvided,
+		ReturnCode:      vmOutput.ReturnCode,
+		ReturnMessage:   vmOutput.ReturnMessage,
+		ReturnData:      vmOutput.ReturnData,
+	}
+
+	w.history = append(w.history, entry)
+}
+
+func (w *world) recordDeploy(input *vmcommon.ContractCreateInput, contractAddress []byte, vmOutput *vmcommon.VMOutput) {
+	w.recordTransaction(historyDeploy, &input.VMInput, contractAddress, vmOutput)
+
+	entry := w.history[len(w.history)-1]
+	entry.Code = input.ContractCode
+	entry.CodeMetadata = input.ContractCodeMetadata
+}
+
+func (w *world) recordCall(kind historyEntryKind, input *vmcommon.ContractCallInput, vmOutput *vmcommon.VMOutput) {
+	w.recordTransaction(kind, &input.VMInput, input.RecipientAddr, vmOutput)
+
+	entry := w.history[len(w.history)-1]
+	entry.Function = input.Function
+}
+
+// snapshotAccount copies the fields of an account which can be expressed in
+// the setState step of a Mandos scenario
+func snapshotAccount(account *worldmock.Account) *worldmock.Account {
+	balance := big.NewInt(0)
+	if account.Balance != nil {
+		balance.Set(account.Balance)
+	}
+
+	storage := make(map[string][]byte, len(account.Storage))
+	for key, value := range account.Storage {
+		storage[key] = append([]byte{}, value...)
+	}
+
+	return &worldmock.Account{
+		Address:       account.Address,
+		Nonce:         account.Nonce,
+		Balance:       balance,
+		Storage:       storage,
+		Code:          account.Code,
+		CodeMetadata:  account.CodeMetadata,
+		OwnerAddress:  account.OwnerAddress,
+		AsyncCallData: account.AsyncCallData,
+		Username:      account.Username,
+	}
+}
@@ -3,6 +3,7 @@ package arwendebug
 import (
 	"math/big"
 
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
 	"github.com/ElrondNetwork/elrond-go/core/vmcommon"
 )
 
@@ -78,12 +79,14 @@ type ContractResponseBase struct {
 	Input            *vmcommon.VMInput
 	Output           *vmcommon.VMOutput
 	ReturnCodeString string
+	StorageDiff      *arwen.StorageDiff
 }
 
-func createContractResponseBase(input *vmcommon.VMInput, output *vmcommon.VMOutput) ContractResponseBase {
+func createContractResponseBase(input *vmcommon.VMInput, output *vmcommon.VMOutput, storageDiff *arwen.StorageDiff) ContractResponseBase {
 	response := ContractResponseBase{
-		Input:  input,
-		Output: output,
+		Input:       input,
+		Output:      output,
+		StorageDiff: storageDiff,
 	}
 
 	if output != nil {
@@ -0,0 +1,37 @@
+package arwendebug
+
+import (
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
+)
+
+// MigrateAsyncDataRequest is a CLI / REST request message
+type MigrateAsyncDataRequest struct {
+	RequestBase
+	ContractAddressHex string
+	ContractAddress    []byte
+	DryRun             bool
+}
+
+func (request *MigrateAsyncDataRequest) digest() error {
+	err := request.RequestBase.digest()
+	if err != nil {
+		return err
+	}
+
+	if len(request.ContractAddressHex) == 0 {
+		return NewRequestError("empty contract address")
+	}
+
+	request.ContractAddress, err = fromHex(request.ContractAddressHex)
+	if err != nil {
+		return NewRequestErrorMessageInner("invalid contract address", err)
+	}
+
+	return nil
+}
+
+// MigrateAsyncDataResponse is a CLI / REST response message
+type MigrateAsyncDataResponse struct {
+	Result *arwen.AsyncMigrationResult
+	Error  error
+}
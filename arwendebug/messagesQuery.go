@@ -1,5 +1,9 @@
 package arwendebug
 
+import (
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
+)
+
 // QueryRequest is a CLI / REST request message
 type QueryRequest struct {
 	RunRequest
@@ -8,4 +12,5 @@ type QueryRequest struct {
 // QueryResponse is a CLI / REST response message
 type QueryResponse struct {
 	ContractResponseBase
+	DebugSteps []arwen.DebugStep
 }
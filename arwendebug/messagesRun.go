@@ -1,5 +1,9 @@
 package arwendebug
 
+import (
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
+)
+
 // RunRequest is a CLI / REST request message
 type RunRequest struct {
 	ContractRequestBase
@@ -8,6 +12,8 @@ type RunRequest struct {
 	Function           string
 	ArgumentsHex       []string
 	Arguments          [][]byte
+	ProfilePath        string
+	DebugStepInterval  uint64
 }
 
 func (request *RunRequest) digest() error {
@@ -32,4 +38,5 @@ func (request *RunRequest) digest() error {
 // RunResponse is a CLI / REST response message
 type RunResponse struct {
 	ContractResponseBase
+	DebugSteps []arwen.DebugStep
 }
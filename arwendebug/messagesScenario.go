@@ -0,0 +1,52 @@
+package arwendebug
+
+// ExportScenarioRequest is a CLI / REST request message
+type ExportScenarioRequest struct {
+	RequestBase
+	Name         string
+	ScenarioPath string
+}
+
+func (request *ExportScenarioRequest) digest() error {
+	err := request.RequestBase.digest()
+	if err != nil {
+		return err
+	}
+
+	if request.Name == "" {
+		request.Name = request.World
+	}
+
+	return nil
+}
+
+// ExportScenarioResponse is a CLI / REST response message
+type ExportScenarioResponse struct {
+	ResponseBase
+	Scenario string
+}
+
+// ImportScenarioRequest is a CLI / REST request message
+type ImportScenarioRequest struct {
+	RequestBase
+	ScenarioPath string
+}
+
+func (request *ImportScenarioRequest) digest() error {
+	err := request.RequestBase.digest()
+	if err != nil {
+		return err
+	}
+
+	if request.ScenarioPath == "" {
+		return NewRequestError("empty scenario path")
+	}
+
+	return nil
+}
+
+// ImportScenarioResponse is a CLI / REST response message
+type ImportScenarioResponse struct {
+	ResponseBase
+	NumTransactions int
+}
@@ -0,0 +1,364 @@
+package arwendebug
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"path/filepath"
+	"sort"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
+	fr "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mandos-go/fileresolver"
+	mj "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mandos-go/json/model"
+	mjparse "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mandos-go/json/parse"
+	oj "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mandos-go/orderedjson"
+	worldmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/world"
+	"github.com/ElrondNetwork/elrond-go/core/vmcommon"
+)
+
+// exportScenario converts the history of the world into a Mandos scenario.
+// Mandos increments the nonce of the sender before each transaction, while a
+// debugging world does not, so each deployment is preceded by a "newAddresses"
+// mock which gives the contract the address it has in the world.
+func (w *world) exportScenario(name string) *mj.Scenario {
+	scenario := &mj.Scenario{
+		Name:        name,
+		CheckGas:    false,
+		GasSchedule: mj.GasScheduleDummy,
+	}
+
+	nonces := make(map[string]uint64)
+	txIndex := 0
+	for _, entry := range w.history {
+		if entry.Kind == historySetState {
+			step := &mj.SetStateStep{}
+			for _, account := range entry.Accounts {
+				nonces[string(account.Address)] = account.Nonce
+				step.Accounts = append(step.Accounts, accountToMandos(account))
+			}
+			scenario.Steps = append(scenario.Steps, step)
+			continue
+		}
+
+		creatorNonce := nonces[string(entry.Sender)]
+		nonces[string(entry.Sender)]++
+
+		if entry.Kind == historyDeploy && entry.ReturnCode == vmcommon.Ok {
+			scenario.Steps = append(scenario.Steps, &mj.SetStateStep{
+				NewAddressMocks: []*mj.NewAddressMock{{
+					CreatorAddress: bytesToMandos(entry.Sender),
+					CreatorNonce:   uint64ToMandos(creatorNonce),
+					NewAddress:     bytesToMandos(entry.ContractAddress),
+				}},
+			})
+		}
+
+		txIndex++
+		scenario.Steps = append(scenario.Steps, &mj.TxStep{
+			TxIdent:        fmt.Sprintf("%s-%d", entry.Kind, txIndex),
+			Tx:             transactionToMandos(entry),
+			ExpectedResult: transactionResultToMandos(entry),
+		})
+	}
+
+	return scenario
+}
+
+func accountToMandos(account *worldmock.Account) *mj.Account {
+	keys := make([]string, 0, len(account.Storage))
+	for key, value := range account.Storage {
+		if len(value) > 0 {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	storage := make([]*mj.StorageKeyValuePair, len(keys))
+	for i, key := range keys {
+		storage[i] = &mj.StorageKeyValuePair{
+			Key:   bytesToMandos([]byte(key)),
+			Value: bytesToMandosTree(account.Storage[key]),
+		}
+	}
+
+	return &mj.Account{
+		Address:       bytesToMandos(account.Address),
+		Nonce:         uint64ToMandos(account.Nonce),
+		Balance:       bigIntToMandos(account.Balance),
+		Storage:       storage,
+		Code:          bytesToMandos(account.Code),
+		Owner:         bytesToMandos(account.OwnerAddress),
+		Username:      bytesToMandos(account.Username),
+		AsyncCallData: account.AsyncCallData,
+	}
+}
+
+func transactionToMandos(entry *historyEntry) *mj.Transaction {
+	tx := &mj.Transaction{
+		Type:     mj.ScCall,
+		From:     bytesToMandos(entry.Sender),
+		To:       bytesToMandos(entry.ContractAddress),
+		Value:    bigIntToMandos(entry.Value),
+		Function: entry.Function,
+		GasLimit: uint64ToMandos(entry.GasLimit),
+		GasPrice: uint64ToMandos(0),
+	}
+
+	if entry.Kind == historyDeploy {
+		tx.Type = mj.ScDeploy
+		tx.To = mj.JSONBytesFromString{}
+		tx.Code = bytesToMandos(entry.Code)
+	}
+
+	for _, argument := range entry.Arguments {
+		tx.Arguments = append(tx.Arguments, bytesToMandosTree(argument))
+	}
+
+	return tx
+}
+
+func transactionResultToMandos(entry *historyEntry) *mj.TransactionResult {
+	result := &mj.TransactionResult{
+		Status: mj.JSONCheckBigInt{
+			Value:    big.NewInt(int64(entry.ReturnCode)),
+			Original: fmt.Sprintf("%d", entry.ReturnCode),
+		},
+		Message:  mj.JSONCheckBytesReconstructed([]byte(entry.ReturnMessage), stringToMandosOriginal(entry.ReturnMessage)),
+		Gas:      mj.JSONCheckUint64{IsStar: true, Original: "*"},
+		Refund:   mj.JSONCheckBigInt{Value: big.NewInt(0), IsStar: true, Original: "*"},
+		LogsStar: true,
+	}
+
+	for _, data := range entry.ReturnData {
+		result.Out = append(result.Out, mj.JSONCheckBytesReconstructed(data, bytesToMandosOriginal(data)))
+	}
+
+	return result
+}
+
+func bytesToMandos(value []byte) mj.JSONBytesFromString {
+	return mj.NewJSONBytesFromString(value, bytesToMandosOriginal(value))
+}
+
+func bytesToMandosTree(value []byte) mj.JSONBytesFromTree {
+	return mj.JSONBytesFromTree{
+		Value:    value,
+		Original: &oj.OJsonString{Value: bytesToMandosOriginal(value)},
+	}
+}
+
+func bytesToMandosOriginal(value []byte) string {
+	if len(value) == 0 {
+		return ""
+	}
+
+	return "0x" + hex.EncodeToString(value)
+}
+
+func stringToMandosOriginal(value string) string {
+	if len(value) == 0 {
+		return ""
+	}
+
+	return "str:" + value
+}
+
+func bigIntToMandos(value *big.Int) mj.JSONBigInt {
+	if value == nil {
+		value = big.NewInt(0)
+	}
+
+	return mj.JSONBigInt{
+		Value:    value,
+		Original: value.String(),
+	}
+}
+
+func uint64ToMandos(value uint64) mj.JSONUint64 {
+	return mj.JSONUint64{
+		Value:    value,
+		Original: fmt.Sprintf("%d", value),
+	}
+}
+
+func readScenario(scenarioPath string) (*mj.Scenario, error) {
+	scenarioPath, err := filepath.Abs(scenarioPath)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := ioutil.ReadFile(scenarioPath)
+	if err != nil {
+		return nil, err
+	}
+
+	fileResolver := fr.NewDefaultFileResolver()
+	fileResolver.SetContext(scenarioPath)
+	parser := mjparse.NewParser(fileResolver)
+
+	return parser.ParseScenarioFile(data)
+}
+
+// importScenario applies the steps of a Mandos scenario to the world. The
+// "setState" steps set the accounts and the "newAddresses" mocks, while the
+// transactions are executed like any other debugging request, incrementing
+// the nonce of their sender beforehand, as Mandos does. It returns the
+// number of transactions executed.
+func (w *world) importScenario(scenario *mj.Scenario) (int, error) {
+	numTransactions := 0
+
+	for _, generalStep := range scenario.Steps {
+		var err error
+
+		switch step := generalStep.(type) {
+		case *mj.SetStateStep:
+			err = w.importSetState(step)
+		case *mj.TxStep:
+			if step.Tx.Type == mj.ScQuery {
+				continue
+			}
+			err = w.importTransaction(step)
+			numTransactions++
+		case *mj.CheckStateStep, *mj.DumpStateStep:
+			continue
+		default:
+			err = NewRequestErrorMessageInner(generalStep.StepTypeName(), ErrUnsupportedScenarioStep)
+		}
+
+		if err != nil {
+			return numTransactions, err
+		}
+	}
+
+	return numTransactions, nil
+}
+
+func (w *world) importSetState(step *mj.SetStateStep) error {
+	accounts := make([]*worldmock.Account, 0, len(step.Accounts))
+	for _, mandosAccount := range step.Accounts {
+		account, err := accountFromMandos(mandosAccount)
+		if err != nil {
+			return err
+		}
+
+		w.blockchainHook.AcctMap.PutAccount(account)
+		accounts = append(accounts, account)
+	}
+
+	if len(accounts) > 0 {
+		w.recordSetState(accounts...)
+	}
+
+	for _, newAddressMock := range step.NewAddressMocks {
+		w.blockchainHook.NewAddressMocks = append(w.blockchainHook.NewAddressMocks, &worldmock.NewAddressMock{
+			CreatorAddress: newAddressMock.CreatorAddress.Value,
+			CreatorNonce:   newAddressMock.CreatorNonce.Value,
+			NewAddress:     newAddressMock.NewAddress.Value,
+		})
+	}
+
+	return nil
+}
+
+func accountFromMandos(mandosAccount *mj.Account) (*worldmock.Account, error) {
+	if len(mandosAccount.ESDTData) > 0 {
+		return nil, NewRequestErrorMessageInner("ESDT tokens of account "+mandosAccount.Address.Original, ErrUnsupportedScenarioStep)
+	}
+
+	storage := make(map[string][]byte, len(mandosAccount.Storage))
+	for _, keyValuePair := range mandosAccount.Storage {
+		storage[string(keyValuePair.Key.Value)] = keyValuePair.Value.Value
+	}
+
+	account := &worldmock.Account{
+		Address:         mandosAccount.Address.Value,
+		Nonce:           mandosAccount.Nonce.Value,
+		Balance:         big.NewInt(0).Set(mandosAccount.Balance.Value),
+		BalanceDelta:    big.NewInt(0),
+		DeveloperReward: big.NewInt(0),
+		Storage:         storage,
+		Code:            mandosAccount.Code.Value,
+		OwnerAddress:    mandosAccount.Owner.Value,
+		Username:        mandosAccount.Username.Value,
+		AsyncCallData:   mandosAccount.AsyncCallData,
+		IsSmartContract: len(mandosAccount.Code.Value) > 0,
+	}
+
+	if account.IsSmartContract {
+		account.CodeMetadata = (&vmcommon.CodeMetadata{
+			Payable:     true,
+			Upgradeable: true,
+			Readable:    true,
+		}).ToBytes()
+	}
+
+	return account, nil
+}
+
+func (w *world) importTransaction(step *mj.TxStep) error {
+	tx := step.Tx
+	if tx.ESDTValue != nil || (tx.Type != mj.ScDeploy && tx.Type != mj.ScCall) {
+		return NewRequestErrorMessageInner("transaction "+step.TxIdent, ErrUnsupportedScenarioStep)
+	}
+
+	sender := w.blockchainHook.AcctMap.GetAccount(tx.From.Value)
+	if sender == nil {
+		return NewRequestErrorMessageInner("sender of transaction "+step.TxIdent, ErrAccountDoesntExist)
+	}
+	sender.Nonce++
+
+	requestBase := ContractRequestBase{
+		Impersonated:  tx.From.Value,
+		ValueAsBigInt: tx.Value.Value,
+		GasPrice:      tx.GasPrice.Value,
+		GasLimit:      tx.GasLimit.Value,
+	}
+	arguments := mj.JSONBytesFromTreeValues(tx.Arguments)
+
+	if tx.Type == mj.ScDeploy {
+		response := w.deploySmartContract(DeployRequest{
+			ContractRequestBase: requestBase,
+			Code:                tx.Code.Value,
+			CodeMetadataBytes:   (&vmcommon.CodeMetadata{Upgradeable: true}).ToBytes(),
+			Arguments:           arguments,
+		})
+		return response.Error
+	}
+
+	if tx.Function == arwen.UpgradeFunctionName && len(arguments) >= 2 {
+		response := w.upgradeSmartContract(UpgradeRequest{
+			DeployRequest: DeployRequest{
+				ContractRequestBase: requestBase,
+				Code:                arguments[0],
+				CodeMetadataBytes:   arguments[1],
+				Arguments:           arguments[2:],
+			},
+			ContractAddress: tx.To.Value,
+		})
+		return response.Error
+	}
+
+	response := w.runSmartContract(RunRequest{
+		ContractRequestBase: requestBase,
+		ContractAddress:     tx.To.Value,
+		Function:            tx.Function,
+		Arguments:           arguments,
+	})
+	return response.Error
+}
+
+func sortedAccounts(accounts worldmock.AccountMap) []*worldmock.Account {
+	addresses := make([]string, 0, len(accounts))
+	for address := range accounts {
+		addresses = append(addresses, address)
+	}
+	sort.Strings(addresses)
+
+	result := make([]*worldmock.Account, len(addresses))
+	for i, address := range addresses {
+		result[i] = accounts[address]
+	}
+
+	return result
+}
@@ -32,6 +32,9 @@ func (server *DebugServer) Start() error {
 	router.POST("/upgrade", server.handleUpgrade)
 	router.POST("/run", server.handleRun)
 	router.POST("/query", server.handleQuery)
+	router.POST("/migrate-async", server.handleMigrateAsyncData)
+	router.POST("/export-scenario", server.handleExportScenario)
+	router.POST("/import-scenario", server.handleImportScenario)
 
 	return router.Run(server.address)
 }
@@ -126,6 +129,60 @@ func (server *DebugServer) handleQuery(ginContext *gin.Context) {
 	returnOkResponse(ginContext, response)
 }
 
+func (server *DebugServer) handleMigrateAsyncData(ginContext *gin.Context) {
+	request := MigrateAsyncDataRequest{}
+
+	err := ginContext.ShouldBindJSON(&request)
+	if err != nil {
+		returnBadRequest(ginContext, "handleMigrateAsyncData.ShouldBindJSON", err)
+		return
+	}
+
+	response, err := server.facade.MigrateAsyncData(request)
+	if err != nil {
+		returnBadRequest(ginContext, "handleMigrateAsyncData.MigrateAsyncData", err)
+		return
+	}
+
+	returnOkResponse(ginContext, response)
+}
+
+func (server *DebugServer) handleExportScenario(ginContext *gin.Context) {
+	request := ExportScenarioRequest{}
+
+	err := ginContext.ShouldBindJSON(&request)
+	if err != nil {
+		returnBadRequest(ginContext, "handleExportScenario.ShouldBindJSON", err)
+		return
+	}
+
+	response, err := server.facade.ExportScenario(request)
+	if err != nil {
+		returnBadRequest(ginContext, "handleExportScenario.ExportScenario", err)
+		return
+	}
+
+	returnOkResponse(ginContext, response)
+}
+
+func (server *DebugServer) handleImportScenario(ginContext *gin.Context) {
+	request := ImportScenarioRequest{}
+
+	err := ginContext.ShouldBindJSON(&request)
+	if err != nil {
+		returnBadRequest(ginContext, "handleImportScenario.ShouldBindJSON", err)
+		return
+	}
+
+	response, err := server.facade.ImportScenario(request)
+	if err != nil {
+		returnBadRequest(ginContext, "handleImportScenario.ImportScenario", err)
+		return
+	}
+
+	returnOkResponse(ginContext, response)
+}
+
 func returnBadRequest(context *gin.Context, errScope string, err error) {
 	context.JSON(http.StatusBadRequest, gin.H{
 		"error":        fmt.Sprintf("%T", err),
@@ -190,3 +190,35 @@ func newDummyAddress(address string) *dummyAddress {
 		raw: []byte(rawString),
 	}
 }
+
+func (context *testContext) exportScenario(name string, scenarioPath string) *ExportScenarioResponse {
+	request := ExportScenarioRequest{
+		RequestBase:  context.createRequestBase(),
+		Name:         name,
+		ScenarioPath: scenarioPath,
+	}
+
+	response, err := context.facade.ExportScenario(request)
+
+	t := context.t
+	require.Nil(t, err)
+	require.NotNil(t, response)
+
+	return response
+}
+
+func (context *testContext) importScenario(scenarioPath string) *ImportScenarioResponse {
+	request := ImportScenarioRequest{
+		RequestBase:  context.createRequestBase(),
+		ScenarioPath: scenarioPath,
+	}
+
+	response, err := context.facade.ImportScenario(request)
+
+	t := context.t
+	require.Nil(t, err)
+	require.NotNil(t, response)
+	require.Nil(t, response.Error)
+
+	return response
+}
@@ -1,6 +1,8 @@
 package arwendebug
 
 import (
+	"os"
+
 	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
 	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen/host"
 	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/config"
@@ -11,12 +13,14 @@ import (
 type worldDataModel struct {
 	ID       string
 	Accounts worldmock.AccountMap
+	History  []*historyEntry
 }
 
 type world struct {
 	id             string
-	blockchainHook *worldmock.MockWorld
-	vm             vmcommon.VMExecutionHandler
+	blockchainHook *worldmock.ChainSimulator
+	vm             arwen.VMHost
+	history        []*historyEntry
 }
 
 func newWorldDataModel(worldID string) *worldDataModel {
@@ -28,7 +32,7 @@ func newWorldDataModel(worldID string) *worldDataModel {
 
 // newWorld creates a new debugging world
 func newWorld(dataModel *worldDataModel) (*world, error) {
-	blockchainHook := worldmock.NewMockWorld()
+	blockchainHook := worldmock.NewChainSimulator(1)
 	blockchainHook.AcctMap = dataModel.Accounts
 
 	vm, err := host.NewArwenVM(
@@ -39,19 +43,32 @@ func newWorld(dataModel *worldDataModel) (*world, error) {
 		return nil, err
 	}
 
-	return &world{
+	world := &world{
 		id:             dataModel.ID,
 		blockchainHook: blockchainHook,
 		vm:             vm,
-	}, nil
+		history:        dataModel.History,
+	}
+
+	// worlds stored before the history was recorded start from their accounts
+	if world.history == nil && len(dataModel.Accounts) > 0 {
+		world.recordSetState(sortedAccounts(dataModel.Accounts)...)
+	}
+
+	return world, nil
 }
 
 func getHostParameters() *arwen.VMHostParameters {
 	return &arwen.VMHostParameters{
-		VMType:                   []byte{5, 0},
-		BlockGasLimit:            uint64(10000000),
-		GasSchedule:              config.MakeGasMap(1, 1),
-		ElrondProtectedKeyPrefix: []byte("ELROND"),
+		VMType:                    []byte{5, 0},
+		BlockGasLimit:             uint64(10000000),
+		GasSchedule:               config.MakeGasMap(1, 1),
+		ElrondProtectedKeyPrefix:  []byte("ELROND"),
+		MeteringAuditEnabled:      true,
+		UserErrorDebugEnabled:     true,
+		ItemizedRefundsInVMOutput: true,
+		OutOfGasReceiptsEnabled:   true,
+		StorageDiffEnabled:        true,
 	}
 }
 
@@ -61,11 +78,14 @@ func (w *world) deploySmartContract(request DeployRequest) *DeployResponse {
 
 	vmOutput, err := w.vm.RunSmartContractCreate(input)
 	if err == nil {
-		w.blockchainHook.UpdateAccounts(vmOutput.OutputAccounts, nil)
+		err = w.blockchainHook.Commit(vmOutput)
+	}
+	if err == nil {
+		w.recordDeploy(input, w.blockchainHook.LastCreatedContractAddress, vmOutput)
 	}
 
 	response := &DeployResponse{}
-	response.ContractResponseBase = createContractResponseBase(&input.VMInput, vmOutput)
+	response.ContractResponseBase = createContractResponseBase(&input.VMInput, vmOutput, w.vm.GetStorageDiff())
 	response.Error = err
 	response.ContractAddress = w.blockchainHook.LastCreatedContractAddress
 	response.ContractAddressHex = toHex(response.ContractAddress)
@@ -78,11 +98,14 @@ func (w *world) upgradeSmartContract(request UpgradeRequest) *UpgradeResponse {
 
 	vmOutput, err := w.vm.RunSmartContractCall(input)
 	if err == nil {
-		w.blockchainHook.UpdateAccounts(vmOutput.OutputAccounts, nil)
+		err = w.blockchainHook.Commit(vmOutput)
+	}
+	if err == nil {
+		w.recordCall(historyUpgrade, input, vmOutput)
 	}
 
 	response := &UpgradeResponse{}
-	response.ContractResponseBase = createContractResponseBase(&input.VMInput, vmOutput)
+	response.ContractResponseBase = createContractResponseBase(&input.VMInput, vmOutput, w.vm.GetStorageDiff())
 	response.Error = err
 
 	return response
@@ -92,13 +115,24 @@ func (w *world) runSmartContract(request RunRequest) *RunResponse {
 	input := w.prepareCallInput(request)
 	log.Trace("w.runSmartContract()", "input", prettyJson(input))
 
-	vmOutput, err := w.vm.RunSmartContractCall(input)
+	debugSteps, err := w.takeDebugSteps(input, request.DebugStepInterval, false)
+	if err != nil {
+		response := &RunResponse{}
+		response.Error = err
+		return response
+	}
+
+	vmOutput, storageDiff, err := w.runSmartContractCall(input, request.ProfilePath, false)
 	if err == nil {
-		w.blockchainHook.UpdateAccounts(vmOutput.OutputAccounts, nil)
+		err = w.blockchainHook.Commit(vmOutput)
+	}
+	if err == nil {
+		w.recordCall(historyRun, input, vmOutput)
 	}
 
 	response := &RunResponse{}
-	response.ContractResponseBase = createContractResponseBase(&input.VMInput, vmOutput)
+	response.ContractResponseBase = createContractResponseBase(&input.VMInput, vmOutput, storageDiff)
+	response.DebugSteps = debugSteps
 	response.Error = err
 
 	return response
@@ -108,15 +142,76 @@ func (w *world) querySmartContract(request QueryRequest) *QueryResponse {
 	input := w.prepareCallInput(request.RunRequest)
 	log.Trace("w.querySmartContract()", "input", prettyJson(input))
 
-	vmOutput, err := w.vm.RunSmartContractCall(input)
+	debugSteps, err := w.takeDebugSteps(input, request.DebugStepInterval, true)
+	if err != nil {
+		response := &QueryResponse{}
+		response.Error = err
+		return response
+	}
+
+	vmOutput, storageDiff, err := w.runSmartContractCall(input, request.ProfilePath, true)
 
 	response := &QueryResponse{}
-	response.ContractResponseBase = createContractResponseBase(&input.VMInput, vmOutput)
+	response.ContractResponseBase = createContractResponseBase(&input.VMInput, vmOutput, storageDiff)
+	response.DebugSteps = debugSteps
 	response.Error = err
 
 	return response
 }
 
+// runSmartContractCall runs the given call, or query, returning its output
+// along with the storage it changed; if a profile path is given, the call runs
+// on a VM which samples its execution, and the samples are written to the
+// profile path in the folded format of flame graph tools
+func (w *world) runSmartContractCall(input *vmcommon.ContractCallInput, profilePath string, isQuery bool) (*vmcommon.VMOutput, *arwen.StorageDiff, error) {
+	if profilePath == "" {
+		vmOutput, err := runCallOrQuery(w.vm, input, isQuery)
+		return vmOutput, w.vm.GetStorageDiff(), err
+	}
+
+	hostParameters := getHostParameters()
+	hostParameters.ExecutionProfilingEnabled = true
+	vm, err := host.NewArwenVM(w.blockchainHook, hostParameters)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	vmOutput, err := runCallOrQuery(vm, input, isQuery)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = writeExecutionProfile(profilePath, vm.GetExecutionProfile())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return vmOutput, vm.GetStorageDiff(), nil
+}
+
+func runCallOrQuery(vm arwen.VMHost, input *vmcommon.ContractCallInput, isQuery bool) (*vmcommon.VMOutput, error) {
+	if isQuery {
+		return vm.RunSmartContractQuery(input)
+	}
+
+	return vm.RunSmartContractCall(input)
+}
+
+func writeExecutionProfile(profilePath string, profile *arwen.ExecutionProfile) error {
+	file, err := os.Create(profilePath)
+	if err != nil {
+		return err
+	}
+
+	err = profile.WriteFolded(file)
+	if err != nil {
+		_ = file.Close()
+		return err
+	}
+
+	return file.Close()
+}
+
 func (w *world) createAccount(request CreateAccountRequest) *CreateAccountResponse {
 	log.Trace("w.createAccount()", "request", prettyJson(request))
 
@@ -126,12 +221,29 @@ func (w *world) createAccount(request CreateAccountRequest) *CreateAccountRespon
 		Balance: request.BalanceAsBigInt,
 	}
 	w.blockchainHook.AcctMap.PutAccount(&account)
+	w.recordSetState(&account)
 	return &CreateAccountResponse{Account: &account}
 }
 
+func (w *world) migrateAsyncData(request MigrateAsyncDataRequest) *MigrateAsyncDataResponse {
+	log.Trace("w.migrateAsyncData()", "request", prettyJson(request))
+
+	result, err := w.vm.MigrateLegacyAsyncData(request.ContractAddress)
+	if err == nil && !request.DryRun {
+		account := w.blockchainHook.AcctMap.GetAccount(request.ContractAddress)
+		for key, value := range result.StorageUpdates {
+			account.Storage[key] = value
+		}
+		w.recordSetState(account)
+	}
+
+	return &MigrateAsyncDataResponse{Result: result, Error: err}
+}
+
 func (w *world) toDataModel() *worldDataModel {
 	return &worldDataModel{
 		ID:       w.id,
 		Accounts: w.blockchainHook.AcctMap,
+		History:  w.history,
 	}
 }
@@ -2,6 +2,7 @@ package arwenmandos
 
 import (
 	"fmt"
+	"math"
 
 	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
 	arwenHost "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen/host"
@@ -29,6 +30,11 @@ type ArwenTestExecutor struct {
 	mandosGasScheduleLoaded bool
 	fileResolver            fr.FileResolver
 	exprReconstructor       er.ExprReconstructor
+	savedStates             map[string]*savedState
+	pendingCrossShardCalls  []*crossShardCall
+	gasGolden               *gasGolden
+	beforeStepHooks         []StepHook
+	afterStepHooks          []StepHook
 }
 
 var _ mc.TestExecutor = (*ArwenTestExecutor)(nil)
@@ -51,6 +57,10 @@ func NewArwenTestExecutor() (*ArwenTestExecutor, error) {
 		GasSchedule:              gasScheduleMap,
 		ProtocolBuiltinFunctions: world.GetBuiltinFunctionNames(),
 		ElrondProtectedKeyPrefix: []byte(ElrondProtectedKeyPrefix),
+		// the logs of the scenarios are those written by the contracts
+		RefundReceiptsEnableEpoch: math.MaxUint32,
+		// the step hooks get to see the storage keys read by each tx
+		StorageKeyRecordingEnabled: true,
 	})
 	if err != nil {
 		return nil, err
@@ -63,6 +73,11 @@ func NewArwenTestExecutor() (*ArwenTestExecutor, error) {
 		mandosGasScheduleLoaded: false,
 		fileResolver:            nil,
 		exprReconstructor:       er.ExprReconstructor{},
+		savedStates:             make(map[string]*savedState),
+		pendingCrossShardCalls:  nil,
+		gasGolden:               nil,
+		beforeStepHooks:         nil,
+		afterStepHooks:          nil,
 	}, nil
 }
 
@@ -0,0 +1,40 @@
+package arwenmandos
+
+import (
+	mc "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mandos-go/controller"
+	mj "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mandos-go/json/model"
+	worldhook "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/world"
+)
+
+// NewParallelTestExecutors prepares executors to be used by
+// RunAllJSONScenariosInDirectoryParallel, one for each worker.
+// The Wasmer opcode costs are global and are baked into the compiled code,
+// so all executors are created here, one after the other, and are locked to the same gas schedule.
+// The executors share a single cache of compiled code, so each contract is only compiled once.
+func NewParallelTestExecutors(count int, gasSchedule mj.GasSchedule) ([]mc.ScenarioExecutor, *worldhook.CompiledCodeCache, error) {
+	compiledCode := worldhook.NewCompiledCodeCache()
+	executors := make([]mc.ScenarioExecutor, 0, count)
+	for i := 0; i < count; i++ {
+		executor, err := NewArwenTestExecutor()
+		if err != nil {
+			return nil, nil, err
+		}
+		err = executor.SetMandosGasSchedule(gasSchedule)
+		if err != nil {
+			return nil, nil, err
+		}
+		executor.World.SharedCompiledCode = compiledCode
+		executors = append(executors, executor)
+	}
+
+	return executors, compiledCode, nil
+}
+
+// ParallelTestExecutorsFactory returns the factory of the executors used by
+// RunAllJSONScenariosInDirectoryParallel, creating count executors for each gas schedule.
+func ParallelTestExecutorsFactory(count int) mc.ParallelExecutorsFactory {
+	return func(gasSchedule mj.GasSchedule) ([]mc.ScenarioExecutor, error) {
+		executors, _, err := NewParallelTestExecutors(count, gasSchedule)
+		return executors, err
+	}
+}
@@ -1,9 +1,12 @@
 package arwenmandos
 
 import (
+	"fmt"
+
 	mc "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mandos-go/controller"
 	fr "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mandos-go/fileresolver"
 	mj "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mandos-go/json/model"
+	worldhook "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/world"
 	vmi "github.com/ElrondNetwork/elrond-go/core/vmcommon"
 )
 
@@ -11,6 +14,14 @@ import (
 // Is called in RunAllJSONScenariosInDirectory, but not in RunSingleJSONScenario.
 func (ae *ArwenTestExecutor) Reset() {
 	ae.World.Clear()
+	ae.savedStates = make(map[string]*savedState)
+	ae.pendingCrossShardCalls = nil
+}
+
+// savedState is a snapshot taken by a SaveStateStep
+type savedState struct {
+	world                  *worldhook.WorldState
+	pendingCrossShardCalls []*crossShardCall
 }
 
 // ExecuteScenario executes an individual test.
@@ -37,8 +48,12 @@ func (ae *ArwenTestExecutor) ExecuteScenario(scenario *mj.Scenario, fileResolver
 
 // ExecuteStep executes an individual step from a scenario.
 func (ae *ArwenTestExecutor) ExecuteStep(generalStep mj.Step) error {
-	err := error(nil)
+	err := ae.runStepHooks(ae.beforeStepHooks, generalStep, nil)
+	if err != nil {
+		return err
+	}
 
+	var output *vmi.VMOutput
 	switch step := generalStep.(type) {
 	case *mj.ExternalStepsStep:
 		err = ae.ExecuteExternalStep(step)
@@ -47,12 +62,27 @@ func (ae *ArwenTestExecutor) ExecuteStep(generalStep mj.Step) error {
 	case *mj.CheckStateStep:
 		err = ae.ExecuteCheckStateStep(step)
 	case *mj.TxStep:
-		_, err = ae.ExecuteTxStep(step)
+		output, err = ae.ExecuteTxStep(step)
+	case *mj.CrossShardCallbackStep:
+		output, err = ae.ExecuteCrossShardCallbackStep(step)
 	case *mj.DumpStateStep:
 		err = ae.DumpWorld()
+	case *mj.LetStep:
+		// variables are resolved when parsing, nothing left to do
+	case *mj.SaveStateStep:
+		ae.ExecuteSaveStateStep(step)
+	case *mj.RestoreStateStep:
+		err = ae.ExecuteRestoreStateStep(step)
+	case *mj.AdvanceRoundsStep:
+		err = ae.ExecuteAdvanceRoundsStep(step)
+	case *mj.AdvanceEpochStep:
+		err = ae.ExecuteAdvanceEpochStep(step)
+	}
+	if err != nil {
+		return err
 	}
 
-	return err
+	return ae.runStepHooks(ae.afterStepHooks, generalStep, output)
 }
 
 // ExecuteExternalStep executes an external step referenced by the scenario.
@@ -65,6 +95,12 @@ func (ae *ArwenTestExecutor) ExecuteExternalStep(step *mj.ExternalStepsStep) err
 	fileResolverBackup := ae.fileResolver
 	clonedFileResolver := ae.fileResolver.Clone()
 	externalStepsRunner := mc.NewScenarioRunner(ae, clonedFileResolver)
+	for _, parameter := range step.Parameters {
+		err := externalStepsRunner.Parser.ExprInterpreter.SetVariable(parameter.Name, parameter.Value.Value)
+		if err != nil {
+			return fmt.Errorf("invalid externalSteps parameter: %w", err)
+		}
+	}
 
 	extAbsPth := ae.fileResolver.ResolveAbsolutePath(step.Path)
 	err := externalStepsRunner.RunSingleJSONScenario(extAbsPth)
@@ -113,6 +149,38 @@ func (ae *ArwenTestExecutor) ExecuteSetStateStep(step *mj.SetStateStep) error {
 	return nil
 }
 
+// ExecuteSaveStateStep executes a SaveStateStep.
+// A state saved under an existing name replaces the previous one.
+func (ae *ArwenTestExecutor) ExecuteSaveStateStep(step *mj.SaveStateStep) {
+	log.Trace("SaveStateStep", "name", step.Name)
+	if len(step.Comment) > 0 {
+		log.Trace("SaveStateStep", "comment", step.Comment)
+	}
+
+	ae.savedStates[step.Name] = &savedState{
+		world:                  ae.World.SaveState(),
+		pendingCrossShardCalls: append([]*crossShardCall{}, ae.pendingCrossShardCalls...),
+	}
+}
+
+// ExecuteRestoreStateStep executes a RestoreStateStep.
+// The saved state is kept, so it can be restored again by later steps.
+func (ae *ArwenTestExecutor) ExecuteRestoreStateStep(step *mj.RestoreStateStep) error {
+	log.Trace("RestoreStateStep", "name", step.Name)
+	if len(step.Comment) > 0 {
+		log.Trace("RestoreStateStep", "comment", step.Comment)
+	}
+
+	saved, found := ae.savedStates[step.Name]
+	if !found {
+		return fmt.Errorf("no state saved under the name \"%s\"", step.Name)
+	}
+
+	ae.World.RestoreState(saved.world)
+	ae.pendingCrossShardCalls = append([]*crossShardCall{}, saved.pendingCrossShardCalls...)
+	return nil
+}
+
 // ExecuteTxStep executes a TxStep.
 func (ae *ArwenTestExecutor) ExecuteTxStep(step *mj.TxStep) (*vmi.VMOutput, error) {
 	log.Trace("ExecuteTxStep", "id", step.TxIdent)
@@ -133,5 +201,10 @@ func (ae *ArwenTestExecutor) ExecuteTxStep(step *mj.TxStep) (*vmi.VMOutput, erro
 		}
 	}
 
+	err = ae.processGasGolden(step, output)
+	if err != nil {
+		return nil, err
+	}
+
 	return output, nil
 }
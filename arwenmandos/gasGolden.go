@@ -0,0 +1,160 @@
+package arwenmandos
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	mj "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mandos-go/json/model"
+	vmi "github.com/ElrondNetwork/elrond-go/core/vmcommon"
+)
+
+// GasGoldenMode specifies what the executor does with the golden gas file.
+type GasGoldenMode int
+
+const (
+	// GasGoldenOff means no golden gas file is used.
+	GasGoldenOff GasGoldenMode = iota
+
+	// GasGoldenWrite means the gas used by each tx is recorded, to be written to the golden gas file.
+	GasGoldenWrite
+
+	// GasGoldenCompare means the gas used by each tx is compared against the golden gas file.
+	GasGoldenCompare
+)
+
+// gasGolden holds the gas used by the txs of each scenario,
+// the scenarios being identified by their path relative to the golden gas file.
+type gasGolden struct {
+	path   string
+	mode   GasGoldenMode
+	values map[string]map[string]uint64
+}
+
+// SetGasGoldenFile configures the golden gas file.
+// In compare mode, the file is loaded and each subsequent tx with an entry in it
+// must use the same amount of gas, or at most "gasUsedDelta" more or less.
+// In write mode, the gas used is recorded and only written by SaveGasGoldenFile.
+func (ae *ArwenTestExecutor) SetGasGoldenFile(path string, mode GasGoldenMode) error {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+
+	golden := &gasGolden{
+		path:   path,
+		mode:   mode,
+		values: make(map[string]map[string]uint64),
+	}
+
+	if mode == GasGoldenCompare {
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		var serialized map[string]map[string]string
+		err = json.Unmarshal(content, &serialized)
+		if err != nil {
+			return fmt.Errorf("invalid golden gas file %s: %w", path, err)
+		}
+
+		for scenarioName, txs := range serialized {
+			golden.values[scenarioName] = make(map[string]uint64)
+			for txID, gasStr := range txs {
+				gasUsed, err := strconv.ParseUint(gasStr, 10, 64)
+				if err != nil {
+					return fmt.Errorf("invalid gas in golden gas file %s, scenario %s, tx %s: %w",
+						path, scenarioName, txID, err)
+				}
+				golden.values[scenarioName][txID] = gasUsed
+			}
+		}
+	}
+
+	ae.gasGolden = golden
+	return nil
+}
+
+// SaveGasGoldenFile writes the gas recorded in write mode to the golden gas file.
+func (ae *ArwenTestExecutor) SaveGasGoldenFile() error {
+	if ae.gasGolden == nil || ae.gasGolden.mode != GasGoldenWrite {
+		return nil
+	}
+
+	serialized := make(map[string]map[string]string)
+	for scenarioName, txs := range ae.gasGolden.values {
+		serialized[scenarioName] = make(map[string]string)
+		for txID, gasUsed := range txs {
+			serialized[scenarioName][txID] = strconv.FormatUint(gasUsed, 10)
+		}
+	}
+
+	// map keys are sorted, so the file only changes when the gas does
+	content, err := json.MarshalIndent(serialized, "", "    ")
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(ae.gasGolden.path), os.ModePerm)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(ae.gasGolden.path, append(content, '\n'), 0644)
+}
+
+// processGasGolden records or checks the gas used by a tx step.
+func (ae *ArwenTestExecutor) processGasGolden(step *mj.TxStep, output *vmi.VMOutput) error {
+	if ae.gasGolden == nil || ae.gasGolden.mode == GasGoldenOff || !ae.checkGas {
+		return nil
+	}
+	if len(step.TxIdent) == 0 || step.Tx.GasLimit.Value < output.GasRemaining {
+		return nil
+	}
+
+	scenarioName := ae.gasGoldenScenarioName()
+	gasUsed := step.Tx.GasLimit.Value - output.GasRemaining
+
+	if ae.gasGolden.mode == GasGoldenWrite {
+		if ae.gasGolden.values[scenarioName] == nil {
+			ae.gasGolden.values[scenarioName] = make(map[string]uint64)
+		}
+		ae.gasGolden.values[scenarioName][step.TxIdent] = gasUsed
+		return nil
+	}
+
+	expectedGasUsed, found := ae.gasGolden.values[scenarioName][step.TxIdent]
+	if !found {
+		// txs added since the golden gas file was written are not checked
+		return nil
+	}
+
+	var delta uint64
+	if step.ExpectedResult != nil {
+		delta = step.ExpectedResult.GasUsedDelta.Value
+	}
+	if absDiff(expectedGasUsed, gasUsed) > delta {
+		return fmt.Errorf("gas used mismatch with golden gas file. Scenario: %s. Tx %s. Want: %d%s. Have: %d",
+			scenarioName,
+			step.TxIdent,
+			expectedGasUsed,
+			gasDeltaPretty(delta),
+			gasUsed)
+	}
+
+	return nil
+}
+
+// gasGoldenScenarioName identifies the scenario currently running,
+// by its path relative to the golden gas file.
+func (ae *ArwenTestExecutor) gasGoldenScenarioName() string {
+	scenarioPath := ae.fileResolver.ContextPath()
+	relativePath, err := filepath.Rel(filepath.Dir(ae.gasGolden.path), scenarioPath)
+	if err != nil {
+		return filepath.Base(scenarioPath)
+	}
+	return filepath.ToSlash(relativePath)
+}
@@ -69,6 +69,8 @@ gasScheduleV1 = `[BuiltInCost]
     GetBlockEpoch      = 1000
     GetBlockRound      = 1000
     GetBlockRandomSeed = 1000
+    GetBlockInfo       = 1000
+    GetDeterministicRandom = 1000
     ExecuteOnSameContext = 160000
     ExecuteOnDestContext = 160000
     DelegateExecution    = 160000
@@ -79,6 +81,20 @@ gasScheduleV1 = `[BuiltInCost]
     GetReturnData        = 100
     GetNumReturnData     = 100
     GetReturnDataSize    = 100
+    GetReturnDataBudget  = 100
+    RegisterDeferredCall = 200000
+    EstimateAsyncCallGas = 100
+    LogPerTopic          = 100
+    LogPerDataByte       = 1
+    LogPerTopicByte      = 1
+    ESDTNFTDataPerByte   = 1
+    StorageKeysWithPrefix = 100
+    StorageKeysPerKey    = 50
+    StorageKeysScanPerKey = 20
+    CachedStorageLoad    = 10000
+    StorageLoadFromContract = 100000
+    TransientStore       = 5000
+    TransientLoad        = 5000
 
 [EthAPICost]
     UseGas              = 100
@@ -155,14 +171,69 @@ gasScheduleV1 = `[BuiltInCost]
     BigIntGetSignedArgument     = 100
     BigIntGetCallValue          = 100
     BigIntGetExternalBalance    = 500
+    BigIntPowMod                = 600
+    BigIntPowModPerUnit         = 1
+    BigIntIsPrime               = 600
+    BigIntIsPrimePerUnit        = 1
+    BigIntGcd                   = 100
+    BigIntGcdPerUnit            = 1
+
+[BigFloatAPICost]
+    BigFloatNewFromParts = 100
+    BigFloatAdd          = 100
+    BigFloatSub          = 100
+    BigFloatMul          = 600
+    BigFloatDiv          = 600
+    BigFloatSqrt         = 600
+    BigFloatPow          = 600
+    BigFloatCmp          = 100
+    BigFloatTruncate     = 100
+
+[ManagedMapAPICost]
+    ManagedMapNew        = 100
+    ManagedMapPut        = 100
+    ManagedMapGet        = 100
+    ManagedMapRemove     = 100
+    ManagedMapContains   = 100
+    ManagedMapLength     = 100
+    ManagedMapKeyAtIndex = 100
+
+[ManagedBufferAPICost]
+    MBufferNew          = 100
+    MBufferNewFromBytes = 100
+    MBufferGetLength    = 100
+    MBufferGetBytes     = 100
+    MBufferSetBytes     = 100
+    MBufferAppend       = 100
+    MBufferCopySlice    = 100
+    MBufferFind         = 100
+    MBufferSplit        = 100
+    MBufferCompareSlice = 100
 
 [CryptoAPICost]
-    SHA256          = 600
-    Keccak256       = 600
-    Ripemd160       = 600
-    VerifyBLS       = 1000
-    VerifyEd25519   = 1000
-    VerifySecp256k1 = 1000
+    SHA256                         = 600
+    Keccak256                      = 600
+    Ripemd160                      = 600
+    Poseidon                       = 1000
+    PoseidonPerInput               = 100
+    MiMC                           = 1000
+    MiMCPerInput                   = 100
+    VerifyBLS                      = 1000
+    VerifyBLSAggregated            = 1000
+    VerifyBLSAggregatedPerKey      = 100
+    VerifyEd25519                  = 1000
+    VerifyEd25519Batch             = 1000
+    VerifyEd25519BatchPerSignature = 1000
+    VerifySecp256k1                = 1000
+    Ecrecover                      = 1000
+
+[EllipticCurveAPICost]
+    CurvePointFromBytes      = 100
+    CurvePointToBytes        = 100
+    CurvePointAdd            = 1000
+    CurvePointScalarMult     = 1000
+    CurvePointScalarBaseMult = 1000
+    CurvePointEqual          = 100
 
 [WASMOpcodeCost]
     Unreachable = 1
@@ -681,6 +752,8 @@ gasScheduleV2 = `[BuiltInCost]
     GetBlockEpoch      = 10000
     GetBlockRound      = 10000
     GetBlockRandomSeed = 10000
+    GetBlockInfo       = 10000
+    GetDeterministicRandom = 10000
     ExecuteOnSameContext = 160000
     ExecuteOnDestContext = 160000
     DelegateExecution    = 160000
@@ -691,6 +764,20 @@ gasScheduleV2 = `[BuiltInCost]
     GetReturnData        = 100
     GetNumReturnData     = 100
     GetReturnDataSize    = 100
+    GetReturnDataBudget  = 100
+    RegisterDeferredCall = 200000
+    EstimateAsyncCallGas = 100
+    LogPerTopic          = 100
+    LogPerDataByte       = 1
+    LogPerTopicByte      = 1
+    ESDTNFTDataPerByte   = 1
+    StorageKeysWithPrefix = 100
+    StorageKeysPerKey    = 50
+    StorageKeysScanPerKey = 20
+    CachedStorageLoad    = 10000
+    StorageLoadFromContract = 100000
+    TransientStore       = 5000
+    TransientLoad        = 5000
 
 [EthAPICost]
     UseGas              = 100
@@ -767,14 +854,69 @@ gasScheduleV2 = `[BuiltInCost]
     BigIntGetSignedArgument     = 1000
     BigIntGetCallValue          = 1000
     BigIntGetExternalBalance    = 10000
+    BigIntPowMod                = 6000
+    BigIntPowModPerUnit         = 10
+    BigIntIsPrime               = 6000
+    BigIntIsPrimePerUnit        = 10
+    BigIntGcd                   = 2000
+    BigIntGcdPerUnit            = 10
+
+[BigFloatAPICost]
+    BigFloatNewFromParts = 2000
+    BigFloatAdd          = 2000
+    BigFloatSub          = 2000
+    BigFloatMul          = 6000
+    BigFloatDiv          = 6000
+    BigFloatSqrt         = 6000
+    BigFloatPow          = 6000
+    BigFloatCmp          = 2000
+    BigFloatTruncate     = 2000
+
+[ManagedMapAPICost]
+    ManagedMapNew        = 2000
+    ManagedMapPut        = 2000
+    ManagedMapGet        = 2000
+    ManagedMapRemove     = 2000
+    ManagedMapContains   = 2000
+    ManagedMapLength     = 2000
+    ManagedMapKeyAtIndex = 2000
+
+[ManagedBufferAPICost]
+    MBufferNew          = 2000
+    MBufferNewFromBytes = 2000
+    MBufferGetLength    = 2000
+    MBufferGetBytes     = 2000
+    MBufferSetBytes     = 2000
+    MBufferAppend       = 2000
+    MBufferCopySlice    = 2000
+    MBufferFind         = 2000
+    MBufferSplit        = 2000
+    MBufferCompareSlice = 2000
 
 [CryptoAPICost]
-    SHA256          = 1000000
-    Keccak256       = 1000000
-    Ripemd160       = 1000000
-    VerifyBLS       = 5000000
-    VerifyEd25519   = 2000000
-    VerifySecp256k1 = 2000000
+    SHA256                         = 1000000
+    Keccak256                      = 1000000
+    Ripemd160                      = 1000000
+    Poseidon                       = 1000000
+    PoseidonPerInput               = 200000
+    MiMC                           = 1000000
+    MiMCPerInput                   = 300000
+    VerifyBLS                      = 5000000
+    VerifyBLSAggregated            = 5000000
+    VerifyBLSAggregatedPerKey      = 1000000
+    VerifyEd25519                  = 2000000
+    VerifyEd25519Batch             = 2000000
+    VerifyEd25519BatchPerSignature = 2000000
+    VerifySecp256k1                = 2000000
+    Ecrecover                      = 2000000
+
+[EllipticCurveAPICost]
+    CurvePointFromBytes      = 100000
+    CurvePointToBytes        = 2000
+    CurvePointAdd            = 300000
+    CurvePointScalarMult     = 1000000
+    CurvePointScalarBaseMult = 600000
+    CurvePointEqual          = 2000
 
 [WASMOpcodeCost]
     Unreachable = 1
@@ -1295,6 +1437,8 @@ gasScheduleV3 = `[BuiltInCost]
     GetBlockEpoch      = 10000
     GetBlockRound      = 10000
     GetBlockRandomSeed = 10000
+    GetBlockInfo       = 10000
+    GetDeterministicRandom = 10000
     ExecuteOnSameContext = 160000
     ExecuteOnDestContext = 160000
     DelegateExecution    = 160000
@@ -1305,6 +1449,20 @@ gasScheduleV3 = `[BuiltInCost]
     GetReturnData        = 100
     GetNumReturnData     = 100
     GetReturnDataSize    = 100
+    GetReturnDataBudget  = 100
+    RegisterDeferredCall = 200000
+    EstimateAsyncCallGas = 100
+    LogPerTopic          = 100
+    LogPerDataByte       = 1
+    LogPerTopicByte      = 1
+    ESDTNFTDataPerByte   = 1
+    StorageKeysWithPrefix = 100
+    StorageKeysPerKey    = 50
+    StorageKeysScanPerKey = 20
+    CachedStorageLoad    = 10000
+    StorageLoadFromContract = 100000
+    TransientStore       = 5000
+    TransientLoad        = 5000
 
 [EthAPICost]
     UseGas              = 100
@@ -1381,14 +1539,69 @@ gasScheduleV3 = `[BuiltInCost]
     BigIntGetSignedArgument     = 1000
     BigIntGetCallValue          = 1000
     BigIntGetExternalBalance    = 10000
+    BigIntPowMod                = 6000
+    BigIntPowModPerUnit         = 10
+    BigIntIsPrime               = 6000
+    BigIntIsPrimePerUnit        = 10
+    BigIntGcd                   = 2000
+    BigIntGcdPerUnit            = 10
+
+[BigFloatAPICost]
+    BigFloatNewFromParts = 2000
+    BigFloatAdd          = 2000
+    BigFloatSub          = 2000
+    BigFloatMul          = 6000
+    BigFloatDiv          = 6000
+    BigFloatSqrt         = 6000
+    BigFloatPow          = 6000
+    BigFloatCmp          = 2000
+    BigFloatTruncate     = 2000
+
+[ManagedMapAPICost]
+    ManagedMapNew        = 2000
+    ManagedMapPut        = 2000
+    ManagedMapGet        = 2000
+    ManagedMapRemove     = 2000
+    ManagedMapContains   = 2000
+    ManagedMapLength     = 2000
+    ManagedMapKeyAtIndex = 2000
+
+[ManagedBufferAPICost]
+    MBufferNew          = 2000
+    MBufferNewFromBytes = 2000
+    MBufferGetLength    = 2000
+    MBufferGetBytes     = 2000
+    MBufferSetBytes     = 2000
+    MBufferAppend       = 2000
+    MBufferCopySlice    = 2000
+    MBufferFind         = 2000
+    MBufferSplit        = 2000
+    MBufferCompareSlice = 2000
 
 [CryptoAPICost]
-    SHA256          = 1000000
-    Keccak256       = 1000000
-    Ripemd160       = 1000000
-    VerifyBLS       = 5000000
-    VerifyEd25519   = 2000000
-    VerifySecp256k1 = 2000000
+    SHA256                         = 1000000
+    Keccak256                      = 1000000
+    Ripemd160                      = 1000000
+    Poseidon                       = 1000000
+    PoseidonPerInput               = 200000
+    MiMC                           = 1000000
+    MiMCPerInput                   = 300000
+    VerifyBLS                      = 5000000
+    VerifyBLSAggregated            = 5000000
+    VerifyBLSAggregatedPerKey      = 1000000
+    VerifyEd25519                  = 2000000
+    VerifyEd25519Batch             = 2000000
+    VerifyEd25519BatchPerSignature = 2000000
+    VerifySecp256k1                = 2000000
+    Ecrecover                      = 2000000
+
+[EllipticCurveAPICost]
+    CurvePointFromBytes      = 100000
+    CurvePointToBytes        = 2000
+    CurvePointAdd            = 300000
+    CurvePointScalarMult     = 1000000
+    CurvePointScalarBaseMult = 600000
+    CurvePointEqual          = 2000
 
 [WASMOpcodeCost]
     Unreachable = 1
@@ -0,0 +1,395 @@
+package arwenmandos
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	mc "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mandos-go/controller"
+	ei "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mandos-go/expression/interpreter"
+	er "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mandos-go/expression/reconstructor"
+	fr "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mandos-go/fileresolver"
+	mj "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mandos-go/json/model"
+	mjwrite "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mandos-go/json/write"
+	worldmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/world"
+	"github.com/ElrondNetwork/elrond-go/core"
+	vmi "github.com/ElrondNetwork/elrond-go/core/vmcommon"
+	"github.com/ElrondNetwork/elrond-go/data/esdt"
+	"github.com/ElrondNetwork/elrond-go/process/smartContract/builtInFunctions"
+)
+
+// ScenarioRecorder executes scenarios with an ArwenTestExecutor and records the
+// executed steps into a new, fully explicit scenario: the expected results of
+// the txs are replaced with their actual results and the full state is checked
+// after each step that changes it, without any "*".
+// It is meant to turn fuzz runs or debug sessions into regression scenarios.
+type ScenarioRecorder struct {
+	executor      *ArwenTestExecutor
+	recorded      *mj.Scenario
+	codeOriginals map[string]string
+}
+
+var _ mc.ScenarioExecutor = (*ScenarioRecorder)(nil)
+
+// NewScenarioRecorder creates a ScenarioRecorder that executes with the given executor.
+func NewScenarioRecorder(executor *ArwenTestExecutor) *ScenarioRecorder {
+	return &ScenarioRecorder{
+		executor:      executor,
+		recorded:      nil,
+		codeOriginals: make(map[string]string),
+	}
+}
+
+// Reset clears the state of the executor and the recorded scenario.
+func (sr *ScenarioRecorder) Reset() {
+	sr.executor.Reset()
+	sr.recorded = nil
+	sr.codeOriginals = make(map[string]string)
+}
+
+// ExecuteScenario executes and records a scenario.
+// Subsequent scenarios are appended to the same recording, until Reset is called.
+func (sr *ScenarioRecorder) ExecuteScenario(scenario *mj.Scenario, fileResolver fr.FileResolver) error {
+	if sr.recorded == nil {
+		sr.recorded = &mj.Scenario{
+			Name:        scenario.Name,
+			Comment:     scenario.Comment,
+			CheckGas:    scenario.CheckGas,
+			GasSchedule: scenario.GasSchedule,
+		}
+	}
+
+	ae := sr.executor
+	ae.fileResolver = fileResolver
+	ae.checkGas = scenario.CheckGas
+	err := ae.SetMandosGasSchedule(scenario.GasSchedule)
+	if err != nil {
+		return err
+	}
+
+	for _, generalStep := range scenario.Steps {
+		err = sr.executeStep(generalStep)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RecordedScenario yields the scenario recorded so far.
+func (sr *ScenarioRecorder) RecordedScenario() *mj.Scenario {
+	return sr.recorded
+}
+
+// WriteRecordedScenario saves the scenario recorded so far as a .scen.json file.
+// The "file:" paths are kept as in the original, relative to its directory.
+func (sr *ScenarioRecorder) WriteRecordedScenario(toPath string) error {
+	if sr.recorded == nil {
+		return fmt.Errorf("no scenario recorded")
+	}
+
+	err := os.MkdirAll(filepath.Dir(toPath), os.ModePerm)
+	if err != nil {
+		return err
+	}
+	resultJSON := mjwrite.ScenarioToJSONString(sr.recorded)
+	return ioutil.WriteFile(toPath, []byte(resultJSON), 0644)
+}
+
+func (sr *ScenarioRecorder) executeStep(generalStep mj.Step) error {
+	ae := sr.executor
+
+	switch step := generalStep.(type) {
+	case *mj.TxStep:
+		output, err := ae.ExecuteTxStep(step)
+		if err != nil {
+			return err
+		}
+		if step.Tx.Type == mj.ScDeploy {
+			sr.codeOriginals[string(step.Tx.Code.Value)] = step.Tx.Code.Original
+		}
+		sr.recordStep(&mj.TxStep{
+			TxIdent:        step.TxIdent,
+			Comment:        step.Comment,
+			Tx:             step.Tx,
+			ExpectedResult: sr.recordedTxResult(output),
+		})
+		return sr.recordState(fmt.Sprintf("state after tx %s", step.TxIdent))
+	case *mj.CrossShardCallbackStep:
+		output, err := ae.ExecuteCrossShardCallbackStep(step)
+		if err != nil {
+			return err
+		}
+		sr.recordStep(&mj.CrossShardCallbackStep{
+			TxIdent:        step.TxIdent,
+			Comment:        step.Comment,
+			ExpectedResult: sr.recordedTxResult(output),
+		})
+		return sr.recordState(fmt.Sprintf("state after cross-shard callback %s", step.TxIdent))
+	case *mj.SetStateStep:
+		for _, account := range step.Accounts {
+			sr.codeOriginals[string(account.Code.Value)] = account.Code.Original
+		}
+	case *mj.CheckStateStep:
+		// the full state is already recorded after each step that changes it
+		return ae.ExecuteStep(step)
+	}
+
+	err := ae.ExecuteStep(generalStep)
+	if err != nil {
+		return err
+	}
+	sr.recordStep(generalStep)
+
+	switch generalStep.(type) {
+	case *mj.ExternalStepsStep, *mj.RestoreStateStep:
+		return sr.recordState(fmt.Sprintf("state after %s", generalStep.StepTypeName()))
+	}
+	return nil
+}
+
+func (sr *ScenarioRecorder) recordStep(step mj.Step) {
+	sr.recorded.Steps = append(sr.recorded.Steps, step)
+}
+
+func (sr *ScenarioRecorder) recordState(comment string) error {
+	var addresses []string
+	for address := range sr.executor.World.AcctMap {
+		addresses = append(addresses, address)
+	}
+	sort.Strings(addresses)
+
+	checkAccounts := &mj.CheckAccounts{}
+	for _, address := range addresses {
+		checkAccount, err := sr.recordedAccount(sr.executor.World.AcctMap[address])
+		if err != nil {
+			return err
+		}
+		checkAccounts.Accounts = append(checkAccounts.Accounts, checkAccount)
+	}
+
+	sr.recordStep(&mj.CheckStateStep{
+		Comment:       comment,
+		CheckAccounts: checkAccounts,
+	})
+	return nil
+}
+
+func (sr *ScenarioRecorder) recordedTxResult(output *vmi.VMOutput) *mj.TransactionResult {
+	result := &mj.TransactionResult{
+		Status: mj.JSONCheckBigInt{
+			Value:    big.NewInt(int64(output.ReturnCode)),
+			Original: fmt.Sprintf("%d", int(output.ReturnCode)),
+		},
+		Message:         sr.checkBytes([]byte(output.ReturnMessage), er.StrHint),
+		Gas:             sr.checkUint64(output.GasRemaining),
+		Refund:          sr.checkBigInt(output.GasRefund),
+		LogsStar:        false,
+		LogsUnspecified: false,
+	}
+
+	result.Out = make([]mj.JSONCheckBytes, 0, len(output.ReturnData))
+	for _, data := range output.ReturnData {
+		result.Out = append(result.Out, sr.checkBytes(data, er.NoHint))
+	}
+
+	result.Logs = make([]*mj.LogEntry, 0, len(output.Logs))
+	for _, outputLog := range output.Logs {
+		logEntry := &mj.LogEntry{
+			Address:    sr.checkBytes(outputLog.Address, er.AddressHint),
+			Identifier: sr.checkBytes(outputLog.Identifier, er.StrHint),
+			Data:       sr.checkBytes(outputLog.Data, er.NoHint),
+		}
+		for _, topic := range outputLog.Topics {
+			logEntry.Topics = append(logEntry.Topics, sr.checkBytes(topic, er.NoHint))
+		}
+		result.Logs = append(result.Logs, logEntry)
+	}
+
+	return result
+}
+
+func (sr *ScenarioRecorder) recordedAccount(account *worldmock.Account) (*mj.CheckAccount, error) {
+	checkAccount := &mj.CheckAccount{
+		Address:       mj.NewJSONBytesFromString(account.Address, sr.expression(account.Address, er.AddressHint)),
+		Nonce:         sr.checkUint64(account.Nonce),
+		Balance:       sr.checkBigInt(account.Balance),
+		Username:      sr.checkBytes(account.Username, er.StrHint),
+		Code:          sr.checkCode(account.Code),
+		Owner:         sr.checkBytes(account.OwnerAddress, er.AddressHint),
+		AsyncCallData: mj.JSONCheckBytesUnspecified(),
+	}
+
+	var storageKeys []string
+	for storageKey, storageValue := range account.Storage {
+		// the reserved "ELROND..." keys are not checked, the ESDT tokens are checked separately
+		if len(storageValue) > 0 && !strings.HasPrefix(storageKey, core.ElrondProtectedKeyPrefix) {
+			storageKeys = append(storageKeys, storageKey)
+		}
+	}
+	sort.Strings(storageKeys)
+	for _, storageKey := range storageKeys {
+		checkAccount.CheckStorage = append(checkAccount.CheckStorage, &mj.CheckStorageKeyValuePair{
+			Key:        mj.NewJSONBytesFromString([]byte(storageKey), sr.expression([]byte(storageKey), er.StrHint)),
+			CheckValue: sr.checkBytes(account.Storage[storageKey], er.NoHint),
+		})
+	}
+
+	tokens, err := account.GetFullMockESDTData()
+	if err != nil {
+		return nil, err
+	}
+	var tokenNames []string
+	for tokenName := range tokens {
+		tokenNames = append(tokenNames, tokenName)
+	}
+	sort.Strings(tokenNames)
+	for _, tokenName := range tokenNames {
+		checkAccount.CheckESDTData = append(checkAccount.CheckESDTData, sr.recordedToken(tokens[tokenName]))
+	}
+
+	return checkAccount, nil
+}
+
+func (sr *ScenarioRecorder) recordedToken(token *worldmock.MockESDTData) *mj.CheckESDTData {
+	checkToken := &mj.CheckESDTData{
+		TokenIdentifier: mj.NewJSONBytesFromString(token.TokenIdentifier, sr.expression(token.TokenIdentifier, er.StrHint)),
+		LastNonce:       sr.checkUint64(token.LastNonce),
+		Frozen:          sr.checkTokenFrozen(token),
+	}
+
+	for _, role := range token.Roles {
+		checkToken.Roles = append(checkToken.Roles, string(role))
+	}
+
+	instances := append(token.Instances[:0:0], token.Instances...)
+	sort.Slice(instances, func(i, j int) bool {
+		return instances[i].TokenMetaData.Nonce < instances[j].TokenMetaData.Nonce
+	})
+	for _, instance := range instances {
+		metaData := instance.TokenMetaData
+		checkInstance := mj.NewCheckESDTInstance()
+		checkInstance.Nonce = sr.checkUint64(metaData.Nonce)
+		checkInstance.Balance = sr.checkBigInt(instance.Value)
+		checkInstance.Creator = sr.checkBytes(metaData.Creator, er.AddressHint)
+		checkInstance.Royalties = sr.checkUint64(uint64(metaData.Royalties))
+		checkInstance.Hash = sr.checkBytes(metaData.Hash, er.NoHint)
+		checkInstance.Attributes = sr.checkBytes(metaData.Attributes, er.StrHint)
+		checkInstance.UrisUnspecified = false
+		checkInstance.Uris = make([]mj.JSONCheckBytes, 0, len(metaData.URIs))
+		for _, uri := range metaData.URIs {
+			checkInstance.Uris = append(checkInstance.Uris, sr.checkBytes(uri, er.StrHint))
+		}
+		checkInstance.Frozen = sr.checkBool(isInstanceFrozen(instance))
+		checkToken.Instances = append(checkToken.Instances, checkInstance)
+	}
+
+	return checkToken
+}
+
+// checkTokenFrozen checks the frozen flag of the token, which must match the
+// flag of each of its instances; it is left unspecified if only some of the
+// instances are frozen, whose flags are checked individually
+func (sr *ScenarioRecorder) checkTokenFrozen(token *worldmock.MockESDTData) mj.JSONCheckUint64 {
+	numFrozen := 0
+	for _, instance := range token.Instances {
+		if isInstanceFrozen(instance) {
+			numFrozen++
+		}
+	}
+
+	if numFrozen > 0 && numFrozen < len(token.Instances) {
+		return mj.JSONCheckUint64Unspecified()
+	}
+	return sr.checkBool(numFrozen > 0)
+}
+
+func isInstanceFrozen(instance *esdt.ESDigitalToken) bool {
+	return builtInFunctions.ESDTUserMetadataFromBytes(instance.Properties).Frozen
+}
+
+func (sr *ScenarioRecorder) checkCode(code []byte) mj.JSONCheckBytes {
+	if original, found := sr.codeOriginals[string(code)]; found {
+		return mj.JSONCheckBytesReconstructed(code, original)
+	}
+	return sr.checkBytes(code, er.NoHint)
+}
+
+func (sr *ScenarioRecorder) checkBytes(value []byte, hint er.ExprReconstructorHint) mj.JSONCheckBytes {
+	return mj.JSONCheckBytesReconstructed(value, sr.expression(value, hint))
+}
+
+func (sr *ScenarioRecorder) checkBigInt(value *big.Int) mj.JSONCheckBigInt {
+	if value == nil {
+		value = big.NewInt(0)
+	}
+	return mj.JSONCheckBigInt{
+		Value:    value,
+		Original: value.String(),
+	}
+}
+
+func (sr *ScenarioRecorder) checkBool(value bool) mj.JSONCheckUint64 {
+	return mj.JSONCheckUint64{
+		Value:    boolToUint64(value),
+		Original: fmt.Sprintf("%t", value),
+	}
+}
+
+func (sr *ScenarioRecorder) checkUint64(value uint64) mj.JSONCheckUint64 {
+	return mj.JSONCheckUint64{
+		Value:    value,
+		Original: fmt.Sprintf("%d", value),
+	}
+}
+
+// expression yields a mandos expression for the value, as readable as possible.
+// The reconstructed expressions are only used if they evaluate back to the
+// same value, otherwise the value is written in hex.
+func (sr *ScenarioRecorder) expression(value []byte, hint er.ExprReconstructorHint) string {
+	if len(value) == 0 {
+		return ""
+	}
+
+	candidates := []string{
+		sr.executor.exprReconstructor.Reconstruct(value, hint),
+		"str:" + string(value),
+	}
+	interpreter := ei.ExprInterpreter{}
+	for _, candidate := range candidates {
+		if !isPlainString([]byte(candidate)) {
+			continue
+		}
+		interpreted, err := interpreter.InterpretString(candidate)
+		if err == nil && bytes.Equal(interpreted, value) {
+			return candidate
+		}
+	}
+
+	return "0x" + hex.EncodeToString(value)
+}
+
+func boolToUint64(value bool) uint64 {
+	if value {
+		return 1
+	}
+	return 0
+}
+
+// isPlainString checks that the value can be written as a JSON string without
+// escaping, since the JSON writer does not escape strings.
+func isPlainString(value []byte) bool {
+	for _, b := range value {
+		if b < 32 || b > 126 || b == '"' || b == '\\' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,84 @@
+package arwenmandos
+
+import (
+	"errors"
+	"math"
+
+	mj "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mandos-go/json/model"
+	worldmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/world"
+)
+
+// ExecuteAdvanceRoundsStep executes an AdvanceRoundsStep.
+func (ae *ArwenTestExecutor) ExecuteAdvanceRoundsStep(step *mj.AdvanceRoundsStep) error {
+	log.Trace("AdvanceRoundsStep", "rounds", step.Rounds.Value)
+	if len(step.Comment) > 0 {
+		log.Trace("AdvanceRoundsStep", "comment", step.Comment)
+	}
+
+	current := ae.currentBlockInfoOrGenesis()
+	rounds := step.Rounds.Value
+	roundDuration := roundDurationOrDefault(step.RoundDuration)
+	if rounds > math.MaxUint64-current.BlockRound ||
+		rounds > math.MaxUint64-current.BlockNonce ||
+		(roundDuration > 0 && rounds > (math.MaxUint64-current.BlockTimestamp)/roundDuration) {
+		return errors.New("advance rounds step overflows the block info")
+	}
+
+	next := *current
+	next.BlockNonce += rounds
+	next.BlockRound += rounds
+	next.BlockTimestamp += rounds * roundDuration
+	ae.moveToBlock(current, &next)
+	return nil
+}
+
+// ExecuteAdvanceEpochStep executes an AdvanceEpochStep.
+// The host toggles its epoch-activated features from the current epoch of the world,
+// before each execution, so the following txs run with the flags of the new epoch.
+func (ae *ArwenTestExecutor) ExecuteAdvanceEpochStep(step *mj.AdvanceEpochStep) error {
+	log.Trace("AdvanceEpochStep", "epochs", step.Epochs.Value)
+	if len(step.Comment) > 0 {
+		log.Trace("AdvanceEpochStep", "comment", step.Comment)
+	}
+
+	current := ae.currentBlockInfoOrGenesis()
+	roundDuration := roundDurationOrDefault(step.RoundDuration)
+	if step.Epochs.Value > uint64(math.MaxUint32-current.BlockEpoch) {
+		return errors.New("advance epoch step overflows the block epoch")
+	}
+	if current.BlockRound == math.MaxUint64 ||
+		current.BlockNonce == math.MaxUint64 ||
+		current.BlockTimestamp > math.MaxUint64-roundDuration {
+		return errors.New("advance epoch step overflows the block info")
+	}
+
+	next := *current
+	next.BlockNonce++
+	next.BlockRound++
+	next.BlockTimestamp += roundDuration
+	next.BlockEpoch += uint32(step.Epochs.Value)
+	ae.moveToBlock(current, &next)
+	return nil
+}
+
+// currentBlockInfoOrGenesis yields the current block info,
+// which is all zero if no block info was set yet.
+func (ae *ArwenTestExecutor) currentBlockInfoOrGenesis() *worldmock.BlockInfo {
+	if ae.World.CurrentBlockInfo == nil {
+		return &worldmock.BlockInfo{}
+	}
+	return ae.World.CurrentBlockInfo
+}
+
+func (ae *ArwenTestExecutor) moveToBlock(current *worldmock.BlockInfo, next *worldmock.BlockInfo) {
+	previous := *current
+	ae.World.PreviousBlockInfo = &previous
+	ae.World.CurrentBlockInfo = next
+}
+
+func roundDurationOrDefault(roundDuration mj.JSONUint64) uint64 {
+	if len(roundDuration.Original) == 0 {
+		return worldmock.DefaultRoundDuration
+	}
+	return roundDuration.Value
+}
@@ -12,6 +12,7 @@ import (
 	worldmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/world"
 	"github.com/ElrondNetwork/elrond-go/core"
 	"github.com/ElrondNetwork/elrond-go/data/esdt"
+	"github.com/ElrondNetwork/elrond-go/process/smartContract/builtInFunctions"
 )
 
 // ExecuteCheckStateStep executes a CheckStateStep defined by the current scenario.
@@ -238,6 +239,20 @@ func (ae *ArwenTestExecutor) checkTokenState(
 			accountToken.LastNonce))
 	}
 
+	if !expectedToken.Frozen.IsUnspecified() {
+		for _, accountInstance := range accountToken.Instances {
+			isFrozen := builtInFunctions.ESDTUserMetadataFromBytes(accountInstance.Properties).Frozen
+			if !expectedToken.Frozen.CheckBool(isFrozen) {
+				errors = append(errors, fmt.Errorf("bad account ESDT frozen flag. Account: %s. Token: %s. Nonce: %d. Want: \"%s\". Have: %t",
+					accountAddress,
+					tokenName,
+					accountInstance.TokenMetaData.Nonce,
+					expectedToken.Frozen.Original,
+					isFrozen))
+			}
+		}
+	}
+
 	errors = append(errors, checkTokenRoles(accountAddress, tokenName, expectedToken, accountToken)...)
 
 	return errors
@@ -323,27 +338,11 @@ func (ae *ArwenTestExecutor) checkTokenInstances(
 						accountInstance.TokenMetaData.Hash,
 						er.NoHint)))
 			}
-			if len(accountInstance.TokenMetaData.URIs) > 1 {
-				errors = append(errors, fmt.Errorf(
-					"for token: %s, nonce: %d: More than one URI currently not supported",
-					tokenName,
-					nonce))
-			}
-			var actualUri []byte
-			if len(accountInstance.TokenMetaData.URIs) == 1 {
-				actualUri = accountInstance.TokenMetaData.URIs[0]
-			}
-			if !expectedInstance.Uri.IsUnspecified() &&
-				!expectedInstance.Uri.Check(actualUri) {
-				errors = append(errors, fmt.Errorf(
-					"for token: %s, nonce: %d: Bad URI. Want: %s. Have: \"%s\"",
-					tokenName,
-					nonce,
-					oj.JSONString(expectedInstance.Uri.Original),
-					ae.exprReconstructor.Reconstruct(
-						actualUri,
-						er.StrHint)))
-			}
+			errors = append(errors, ae.checkTokenInstanceURIs(
+				tokenName,
+				nonce,
+				expectedInstance,
+				accountInstance.TokenMetaData.URIs)...)
 			if !expectedInstance.Attributes.IsUnspecified() &&
 				!expectedInstance.Attributes.Check(accountInstance.TokenMetaData.Attributes) {
 				errors = append(errors, fmt.Errorf(
@@ -355,6 +354,81 @@ func (ae *ArwenTestExecutor) checkTokenInstances(
 						accountInstance.TokenMetaData.Attributes,
 						er.StrHint)))
 			}
+			if !expectedInstance.Frozen.IsUnspecified() {
+				isFrozen := builtInFunctions.ESDTUserMetadataFromBytes(accountInstance.Properties).Frozen
+				if !expectedInstance.Frozen.CheckBool(isFrozen) {
+					errors = append(errors, fmt.Errorf(
+						"for token: %s, nonce: %d: Bad frozen flag. Want: \"%s\". Have: %t",
+						tokenName,
+						nonce,
+						expectedInstance.Frozen.Original,
+						isFrozen))
+				}
+			}
+		}
+	}
+
+	return errors
+}
+
+// checkTokenInstanceURIs checks the URIs of an NFT instance, either the single
+// URI or the full list, in which case each differing URI is reported.
+func (ae *ArwenTestExecutor) checkTokenInstanceURIs(
+	tokenName string,
+	nonce uint64,
+	expectedInstance *mj.CheckESDTInstance,
+	accountURIs [][]byte) []error {
+
+	var errors []error
+
+	if !expectedInstance.Uri.IsUnspecified() {
+		var actualUri []byte
+		if len(accountURIs) > 0 {
+			actualUri = accountURIs[0]
+		}
+		if len(accountURIs) > 1 {
+			errors = append(errors, fmt.Errorf(
+				"for token: %s, nonce: %d: Found %d URIs, use \"uris\" to check them all",
+				tokenName,
+				nonce,
+				len(accountURIs)))
+		} else if !expectedInstance.Uri.Check(actualUri) {
+			errors = append(errors, fmt.Errorf(
+				"for token: %s, nonce: %d: Bad URI. Want: %s. Have: \"%s\"",
+				tokenName,
+				nonce,
+				oj.JSONString(expectedInstance.Uri.Original),
+				ae.exprReconstructor.Reconstruct(
+					actualUri,
+					er.StrHint)))
+		}
+	}
+
+	if expectedInstance.UrisUnspecified {
+		return errors
+	}
+	if len(expectedInstance.Uris) != len(accountURIs) {
+		errors = append(errors, fmt.Errorf(
+			"for token: %s, nonce: %d: Bad number of URIs. Want: %d. Have: %d",
+			tokenName,
+			nonce,
+			len(expectedInstance.Uris),
+			len(accountURIs)))
+	}
+	for i, expectedUri := range expectedInstance.Uris {
+		if i >= len(accountURIs) {
+			break
+		}
+		if !expectedUri.Check(accountURIs[i]) {
+			errors = append(errors, fmt.Errorf(
+				"for token: %s, nonce: %d: Bad URI #%d. Want: %s. Have: \"%s\"",
+				tokenName,
+				nonce,
+				i,
+				oj.JSONString(expectedUri.Original),
+				ae.exprReconstructor.Reconstruct(
+					accountURIs[i],
+					er.StrHint)))
 		}
 	}
 
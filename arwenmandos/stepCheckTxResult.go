@@ -51,10 +51,12 @@ func (ae *ArwenTestExecutor) checkTxResults(
 
 	// check gas
 	// unlike other checks, if unspecified the remaining gas check is ignored
-	if checkGas && !blResult.Gas.IsUnspecified() && !blResult.Gas.Check(output.GasRemaining) {
-		return fmt.Errorf("result gas mismatch. Tx %s. Want: %s. Got: %d (0x%x)",
+	if checkGas && !blResult.Gas.IsUnspecified() &&
+		!checkGasWithinDelta(blResult.Gas, blResult.GasUsedDelta.Value, output.GasRemaining) {
+		return fmt.Errorf("result gas mismatch. Tx %s. Want: %s%s. Got: %d (0x%x)",
 			txIndex,
 			blResult.Gas.Original,
+			gasDeltaPretty(blResult.GasUsedDelta.Value),
 			output.GasRemaining,
 			output.GasRemaining)
 	}
@@ -85,19 +87,22 @@ func (ae *ArwenTestExecutor) checkTxResults(
 				mjwrite.LogToString(testLog),
 				mjwrite.LogToString(ae.convertLogToTestFormat(outLog)))
 		}
-		if len(outLog.Topics) != len(testLog.Topics) {
-			return fmt.Errorf("wrong number of log topics. Tx %s. Want:\n%s\nGot:\n%s",
-				txIndex,
-				mjwrite.LogToString(testLog),
-				mjwrite.LogToString(ae.convertLogToTestFormat(outLog)))
-		}
-		for ti := range outLog.Topics {
-			if !testLog.Topics[ti].Check(outLog.Topics[ti]) {
-				return fmt.Errorf("bad log topic. Tx %s. Want:\n%s\nGot:\n%s",
+		// "topics": "*" accepts any topics
+		if !testLog.TopicsStar {
+			if len(outLog.Topics) != len(testLog.Topics) {
+				return fmt.Errorf("wrong number of log topics. Tx %s. Want:\n%s\nGot:\n%s",
 					txIndex,
 					mjwrite.LogToString(testLog),
 					mjwrite.LogToString(ae.convertLogToTestFormat(outLog)))
 			}
+			for ti := range outLog.Topics {
+				if !testLog.Topics[ti].Check(outLog.Topics[ti]) {
+					return fmt.Errorf("bad log topic. Tx %s. Want:\n%s\nGot:\n%s",
+						txIndex,
+						mjwrite.LogToString(testLog),
+						mjwrite.LogToString(ae.convertLogToTestFormat(outLog)))
+				}
+			}
 		}
 		if !testLog.Data.Check(outLog.Data) {
 			return fmt.Errorf("bad log data. Tx %s. Want:\n%s\nGot:\n%s",
@@ -123,3 +128,26 @@ func checkBytesListPretty(jcbs []mj.JSONCheckBytes) string {
 	}
 	return str + "]"
 }
+
+// checkGasWithinDelta checks the remaining gas, allowing it to differ from the
+// expected value by at most delta, since the gas used differs by the same amount.
+func checkGasWithinDelta(expected mj.JSONCheckUint64, delta uint64, have uint64) bool {
+	if expected.IsStar || delta == 0 {
+		return expected.Check(have)
+	}
+	return absDiff(expected.Value, have) <= delta
+}
+
+func absDiff(a uint64, b uint64) uint64 {
+	if a > b {
+		return a - b
+	}
+	return b - a
+}
+
+func gasDeltaPretty(delta uint64) string {
+	if delta == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" (+/- %d)", delta)
+}
@@ -0,0 +1,317 @@
+package arwenmandos
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
+	er "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mandos-go/expression/reconstructor"
+	mj "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mandos-go/json/model"
+	"github.com/ElrondNetwork/elrond-go/core/vmcommon"
+)
+
+// crossShardCall is an asynchronous call or callback sent to an account of
+// another shard, which has not yet been executed at its destination
+type crossShardCall struct {
+	destination    []byte
+	transfer       *vmcommon.OutputTransfer
+	originalTxHash []byte
+}
+
+// ExecuteCrossShardCallbackStep executes a CrossShardCallbackStep: the oldest
+// pending cross-shard async call is executed at its destination, then its
+// callback is executed at the original caller. The expected result is checked
+// against the output of the callback.
+func (ae *ArwenTestExecutor) ExecuteCrossShardCallbackStep(step *mj.CrossShardCallbackStep) (*vmcommon.VMOutput, error) {
+	log.Trace("CrossShardCallbackStep", "id", step.TxIdent)
+	if len(step.Comment) > 0 {
+		log.Trace("CrossShardCallbackStep", "comment", step.Comment)
+	}
+
+	asyncCall := ae.popPendingCrossShardCall(vmcommon.AsynchronousCall)
+	if asyncCall == nil {
+		return nil, errors.New("no pending cross-shard async call")
+	}
+
+	callback, err := ae.executeCrossShardAsyncCall(asyncCall)
+	if err != nil {
+		return nil, err
+	}
+
+	// the gas locked by the caller is only available to the callback
+	output, err := ae.executeCrossShardCall(callback, asyncCall.transfer.GasLocked)
+	if err != nil {
+		return nil, err
+	}
+
+	if step.ExpectedResult != nil {
+		err = ae.checkTxResults(step.TxIdent, step.ExpectedResult, ae.checkGas, output)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return output, nil
+}
+
+// executeCrossShardAsyncCall executes the given async call at its destination
+// and returns the callback to be sent back to the caller
+func (ae *ArwenTestExecutor) executeCrossShardAsyncCall(asyncCall *crossShardCall) (*crossShardCall, error) {
+	caller := asyncCall.transfer.SenderAddress
+	if !ae.isContract(asyncCall.destination) || len(asyncCall.transfer.Data) == 0 {
+		// not a contract call, the protocol only delivers the value
+		ae.World.UpdateAccountFromOutputAccount(&vmcommon.OutputAccount{
+			Address:      asyncCall.destination,
+			BalanceDelta: asyncCall.transfer.Value,
+		})
+		return newCrossShardCallback(asyncCall, big.NewInt(0), asyncCall.transfer.GasLimit, vmcommon.Ok, ""), nil
+	}
+
+	output, err := ae.executeCrossShardCall(asyncCall, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	if output.ReturnCode != vmcommon.Ok {
+		// the value is returned to the caller along with the error
+		return newCrossShardCallback(asyncCall, asyncCall.transfer.Value, output.GasRemaining, output.ReturnCode, output.ReturnMessage), nil
+	}
+
+	// a destination which made cross-shard async calls of its own only sends
+	// the callback once their callbacks were executed
+	for {
+		callback := ae.popPendingCrossShardCallback(caller, asyncCall.destination)
+		if callback != nil {
+			return callback, nil
+		}
+
+		nestedCall := ae.popPendingCrossShardCallFrom(asyncCall.destination)
+		if nestedCall == nil {
+			return nil, fmt.Errorf("cross-shard async call to %s produced no callback", ae.exprReconstructor.Reconstruct(asyncCall.destination, er.AddressHint))
+		}
+
+		nestedCallback, err := ae.executeCrossShardAsyncCall(nestedCall)
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = ae.executeCrossShardCall(nestedCallback, nestedCall.transfer.GasLocked)
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// popPendingCrossShardCallback removes and returns the most recent pending
+// callback sent by the given sender to the given caller, or nil if there is none
+func (ae *ArwenTestExecutor) popPendingCrossShardCallback(caller []byte, sender []byte) *crossShardCall {
+	for i := len(ae.pendingCrossShardCalls) - 1; i >= 0; i-- {
+		callback := ae.pendingCrossShardCalls[i]
+		isCallback := callback.transfer.CallType == vmcommon.AsynchronousCallBack
+		if isCallback && string(callback.destination) == string(caller) && string(callback.transfer.SenderAddress) == string(sender) {
+			ae.pendingCrossShardCalls = append(ae.pendingCrossShardCalls[:i], ae.pendingCrossShardCalls[i+1:]...)
+			return callback
+		}
+	}
+
+	return nil
+}
+
+// popPendingCrossShardCallFrom removes and returns the oldest pending
+// cross-shard async call made by the given sender, or nil if there is none
+func (ae *ArwenTestExecutor) popPendingCrossShardCallFrom(sender []byte) *crossShardCall {
+	for i, call := range ae.pendingCrossShardCalls {
+		isAsyncCall := call.transfer.CallType == vmcommon.AsynchronousCall
+		if isAsyncCall && string(call.transfer.SenderAddress) == string(sender) {
+			ae.pendingCrossShardCalls = append(ae.pendingCrossShardCalls[:i], ae.pendingCrossShardCalls[i+1:]...)
+			return call
+		}
+	}
+
+	return nil
+}
+
+// executeCrossShardCall runs the VM for the given call at its destination, as
+// the protocol would in the shard of the destination. The gas and the value
+// have already been paid in the shard of the sender.
+func (ae *ArwenTestExecutor) executeCrossShardCall(call *crossShardCall, extraGas uint64) (*vmcommon.VMOutput, error) {
+	function, arguments, err := decodeCrossShardCallData(call.transfer)
+	if err != nil {
+		return nil, err
+	}
+
+	ae.World.CreateStateBackup()
+	ae.World.SelfShardID = ae.World.GetShardOfAddress(call.destination)
+
+	input := &vmcommon.ContractCallInput{
+		RecipientAddr: call.destination,
+		Function:      function,
+		VMInput: vmcommon.VMInput{
+			CallerAddr:     call.transfer.SenderAddress,
+			Arguments:      arguments,
+			CallValue:      call.transfer.Value,
+			CallType:       call.transfer.CallType,
+			GasProvided:    call.transfer.GasLimit + extraGas,
+			GasLocked:      call.transfer.GasLocked,
+			OriginalTxHash: call.originalTxHash,
+			CurrentTxHash:  call.originalTxHash,
+			ESDTValue:      big.NewInt(0),
+		},
+	}
+
+	output, err := ae.vm.RunSmartContractCall(input)
+	if err != nil {
+		_ = ae.World.RollbackChanges()
+		return nil, err
+	}
+
+	if output.ReturnCode != vmcommon.Ok {
+		err = ae.World.RollbackChanges()
+		if err != nil {
+			return nil, err
+		}
+		if call.transfer.CallType == vmcommon.AsynchronousCallBack && call.transfer.Value != nil {
+			// the value of a failed callback cannot be sent back, so the
+			// protocol keeps it at the destination
+			ae.World.UpdateAccountFromOutputAccount(&vmcommon.OutputAccount{
+				Address:      call.destination,
+				BalanceDelta: call.transfer.Value,
+			})
+		}
+		return output, nil
+	}
+
+	outputAccounts := ae.deferCrossShardCalls(output.OutputAccounts, call.originalTxHash)
+	err = ae.World.UpdateAccounts(outputAccounts, output.DeletedAccounts)
+	if err != nil {
+		return nil, err
+	}
+
+	return output, ae.World.CommitChanges()
+}
+
+// deferCrossShardCalls queues the async calls and callbacks which the given
+// output accounts send to other shards, and returns the output accounts
+// without the value carried by them, which only reaches the destination when
+// they are executed
+func (ae *ArwenTestExecutor) deferCrossShardCalls(
+	outputAccounts map[string]*vmcommon.OutputAccount,
+	originalTxHash []byte,
+) map[string]*vmcommon.OutputAccount {
+	addresses := make([]string, 0, len(outputAccounts))
+	for address := range outputAccounts {
+		addresses = append(addresses, address)
+	}
+	sort.Strings(addresses)
+
+	updatedAccounts := make(map[string]*vmcommon.OutputAccount, len(outputAccounts))
+	for _, address := range addresses {
+		outputAccount := outputAccounts[address]
+		updatedAccounts[address] = outputAccount
+		if ae.World.GetShardOfAddress(outputAccount.Address) == ae.World.SelfShardID {
+			continue
+		}
+		if !ae.isContract(outputAccount.Address) {
+			// nothing is executed at the destination, the protocol only delivers the value
+			continue
+		}
+
+		deferredValue := big.NewInt(0)
+		for i := range outputAccount.OutputTransfers {
+			transfer := &outputAccount.OutputTransfers[i]
+			isAsync := transfer.CallType == vmcommon.AsynchronousCall || transfer.CallType == vmcommon.AsynchronousCallBack
+			if !isAsync {
+				continue
+			}
+
+			ae.pendingCrossShardCalls = append(ae.pendingCrossShardCalls, &crossShardCall{
+				destination:    outputAccount.Address,
+				transfer:       transfer,
+				originalTxHash: originalTxHash,
+			})
+			if transfer.Value != nil {
+				deferredValue.Add(deferredValue, transfer.Value)
+			}
+		}
+
+		if deferredValue.Sign() == 0 || outputAccount.BalanceDelta == nil {
+			continue
+		}
+		updatedAccount := *outputAccount
+		updatedAccount.BalanceDelta = big.NewInt(0).Sub(outputAccount.BalanceDelta, deferredValue)
+		updatedAccounts[address] = &updatedAccount
+	}
+
+	return updatedAccounts
+}
+
+func (ae *ArwenTestExecutor) isContract(address []byte) bool {
+	account := ae.World.AcctMap.GetAccount(address)
+	return account != nil && len(account.Code) > 0
+}
+
+// popPendingCrossShardCall removes and returns the oldest pending cross-shard
+// call of the given call type, or nil if there is none
+func (ae *ArwenTestExecutor) popPendingCrossShardCall(callType vmcommon.CallType) *crossShardCall {
+	for i, call := range ae.pendingCrossShardCalls {
+		if call.transfer.CallType == callType {
+			ae.pendingCrossShardCalls = append(ae.pendingCrossShardCalls[:i], ae.pendingCrossShardCalls[i+1:]...)
+			return call
+		}
+	}
+
+	return nil
+}
+
+// NumPendingCrossShardCalls returns the number of cross-shard calls of the
+// given call type which have not yet been executed at their destination
+func (ae *ArwenTestExecutor) NumPendingCrossShardCalls(callType vmcommon.CallType) int {
+	numCalls := 0
+	for _, call := range ae.pendingCrossShardCalls {
+		if call.transfer.CallType == callType {
+			numCalls++
+		}
+	}
+
+	return numCalls
+}
+
+// newCrossShardCallback creates the callback which the protocol sends to the
+// caller of the given async call, when the destination does not send it itself
+func newCrossShardCallback(
+	asyncCall *crossShardCall,
+	value *big.Int,
+	gasLimit uint64,
+	returnCode vmcommon.ReturnCode,
+	returnMessage string,
+) *crossShardCall {
+	arguments := [][]byte{[]byte(returnCode.String())}
+	if returnCode != vmcommon.Ok {
+		arguments = append(arguments, []byte(returnMessage))
+	}
+
+	return &crossShardCall{
+		destination: asyncCall.transfer.SenderAddress,
+		transfer: &vmcommon.OutputTransfer{
+			Value:         value,
+			GasLimit:      gasLimit,
+			Data:          arwen.EncodeCallArguments(arguments),
+			CallType:      vmcommon.AsynchronousCallBack,
+			SenderAddress: asyncCall.destination,
+		},
+		originalTxHash: asyncCall.originalTxHash,
+	}
+}
+
+// decodeCrossShardCallData returns the function and the arguments of the
+// given transfer; the data of a callback only holds arguments
+func decodeCrossShardCallData(transfer *vmcommon.OutputTransfer) (string, [][]byte, error) {
+	if transfer.CallType == vmcommon.AsynchronousCallBack {
+		data := append([]byte(arwen.CallbackFunctionName), transfer.Data...)
+		return arwen.DecodeCallData(data)
+	}
+
+	return arwen.DecodeCallData(transfer.Data)
+}
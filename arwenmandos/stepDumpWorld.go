@@ -85,12 +85,21 @@ func (ae *ArwenTestExecutor) convertMockAccountToMandosFormat(account *worldmock
 			}
 
 			var uri mj.JSONBytesFromTree
-			if len(mockInstance.TokenMetaData.URIs) > 0 {
+			var uris []mj.JSONBytesFromTree
+			if len(mockInstance.TokenMetaData.URIs) == 1 {
 				uri = mj.JSONBytesFromTree{
 					Value:    mockInstance.TokenMetaData.URIs[0],
 					Original: &oj.OJsonString{Value: ae.exprReconstructor.Reconstruct(mockInstance.TokenMetaData.URIs[0], er.NoHint)},
 				}
 			}
+			if len(mockInstance.TokenMetaData.URIs) > 1 {
+				for _, mockUri := range mockInstance.TokenMetaData.URIs {
+					uris = append(uris, mj.JSONBytesFromTree{
+						Value:    mockUri,
+						Original: &oj.OJsonString{Value: ae.exprReconstructor.Reconstruct(mockUri, er.NoHint)},
+					})
+				}
+			}
 
 			var attributes mj.JSONBytesFromString
 			if len(mockInstance.TokenMetaData.Attributes) > 0 {
@@ -113,6 +122,7 @@ func (ae *ArwenTestExecutor) convertMockAccountToMandosFormat(account *worldmock
 				Royalties:  royalties,
 				Hash:       hash,
 				Uri:        uri,
+				Uris:       uris,
 				Attributes: attributes,
 			})
 		}
@@ -0,0 +1,75 @@
+package arwenmandos
+
+import (
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
+	mj "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mandos-go/json/model"
+	worldhook "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/world"
+	vmi "github.com/ElrondNetwork/elrond-go/core/vmcommon"
+)
+
+// StepHookContext is what a StepHook gets to see of the step being executed.
+type StepHookContext struct {
+	Step  mj.Step
+	World *worldhook.MockWorld
+
+	// Output is the VMOutput of tx and crossShardCallback steps.
+	// It is only available to the hooks called after the step.
+	Output *vmi.VMOutput
+
+	// TouchedStorageKeys are the storage keys read during the last execution of the step,
+	// available together with the Output.
+	TouchedStorageKeys *arwen.TouchedStorageKeys
+}
+
+// StepHook is a Go callback, called around the steps of a scenario,
+// to mix scenario-driven execution with programmatic assertions.
+// Returning an error stops the scenario with that error.
+type StepHook func(ctx *StepHookContext) error
+
+// AddBeforeStepHook registers a hook to be called before each step.
+// The steps of external scenarios are also reported, after the externalSteps step itself.
+func (ae *ArwenTestExecutor) AddBeforeStepHook(hook StepHook) {
+	ae.beforeStepHooks = append(ae.beforeStepHooks, hook)
+}
+
+// AddAfterStepHook registers a hook to be called after each step that executed successfully.
+func (ae *ArwenTestExecutor) AddAfterStepHook(hook StepHook) {
+	ae.afterStepHooks = append(ae.afterStepHooks, hook)
+}
+
+// NewStepHookContext creates the context the hooks get after a step, for the callers
+// that execute the steps directly, e.g. with ExecuteTxStep, and notify their own hooks.
+func (ae *ArwenTestExecutor) NewStepHookContext(step mj.Step, output *vmi.VMOutput) *StepHookContext {
+	ctx := &StepHookContext{
+		Step:   step,
+		World:  ae.World,
+		Output: output,
+	}
+	if output != nil {
+		ctx.TouchedStorageKeys = ae.getTouchedStorageKeys()
+	}
+	return ctx
+}
+
+func (ae *ArwenTestExecutor) runStepHooks(hooks []StepHook, step mj.Step, output *vmi.VMOutput) error {
+	if len(hooks) == 0 {
+		return nil
+	}
+
+	ctx := ae.NewStepHookContext(step, output)
+	for _, hook := range hooks {
+		err := hook(ctx)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (ae *ArwenTestExecutor) getTouchedStorageKeys() *arwen.TouchedStorageKeys {
+	host, isHost := ae.vm.(arwen.VMHost)
+	if !isHost {
+		return nil
+	}
+	return host.GetTouchedStorageKeys()
+}
@@ -29,6 +29,8 @@ func (ae *ArwenTestExecutor) executeTx(txIndex string, tx *mj.Transaction) (*vmc
 		}
 	}()
 
+	ae.World.SelfShardID = ae.executionShard(tx)
+
 	gasForExecution := uint64(0)
 
 	if tx.Type.HasSender() {
@@ -91,7 +93,7 @@ func (ae *ArwenTestExecutor) executeTx(txIndex string, tx *mj.Transaction) (*vmc
 	}
 
 	if output.ReturnCode == vmcommon.Ok {
-		err := ae.updateStateAfterTx(tx, output)
+		err := ae.updateStateAfterTx(tx, output, generateTxHash(txIndex))
 		if err != nil {
 			return nil, err
 		}
@@ -104,6 +106,16 @@ func (ae *ArwenTestExecutor) executeTx(txIndex string, tx *mj.Transaction) (*vmc
 	return output, nil
 }
 
+// executionShard is the shard in which the transaction is executed: that of
+// the called contract, or else that of the sender
+func (ae *ArwenTestExecutor) executionShard(tx *mj.Transaction) uint32 {
+	if tx.Type == mj.ScCall || tx.Type == mj.ScQuery || !tx.Type.HasSender() {
+		return ae.World.GetShardOfAddress(tx.To.Value)
+	}
+
+	return ae.World.GetShardOfAddress(tx.From.Value)
+}
+
 func (ae *ArwenTestExecutor) senderHasEnoughBalance(tx *mj.Transaction) bool {
 	if !tx.Type.HasSender() {
 		return true
@@ -251,7 +263,8 @@ func (ae *ArwenTestExecutor) directESDTTransferFromTx(tx *mj.Transaction) (uint6
 
 func (ae *ArwenTestExecutor) updateStateAfterTx(
 	tx *mj.Transaction,
-	output *vmcommon.VMOutput) error {
+	output *vmcommon.VMOutput,
+	originalTxHash []byte) error {
 
 	// subtract call value from sender (this is not reflected in the delta)
 	// except for validatorReward, there is no sender there
@@ -260,7 +273,9 @@ func (ae *ArwenTestExecutor) updateStateAfterTx(
 	}
 
 	// update accounts based on deltas
-	updErr := ae.World.UpdateAccounts(output.OutputAccounts, output.DeletedAccounts)
+	// except for the asynchronous calls sent to other shards, which wait for their crossShardCallback step
+	outputAccounts := ae.deferCrossShardCalls(output.OutputAccounts, originalTxHash)
+	updErr := ae.World.UpdateAccounts(outputAccounts, output.DeletedAccounts)
 	if updErr != nil {
 		return updErr
 	}
@@ -52,6 +52,13 @@ func convertAccount(testAcct *mj.Account) (*worldmock.Account, error) {
 			tokenNonce := instance.Nonce.Value
 			tokenKey := worldmock.MakeTokenKey(tokenName, tokenNonce)
 			tokenBalance := instance.Balance.Value
+			uris := [][]byte{instance.Uri.Value}
+			if len(instance.Uris) > 0 {
+				uris = make([][]byte, 0, len(instance.Uris))
+				for _, uri := range instance.Uris {
+					uris = append(uris, uri.Value)
+				}
+			}
 			tokenData := &esdt.ESDigitalToken{
 				Value:      tokenBalance,
 				Type:       uint32(core.Fungible),
@@ -62,7 +69,7 @@ func convertAccount(testAcct *mj.Account) (*worldmock.Account, error) {
 					Creator:    instance.Creator.Value,
 					Royalties:  uint32(instance.Royalties.Value),
 					Hash:       instance.Hash.Value,
-					URIs:       [][]byte{instance.Uri.Value},
+					URIs:       uris,
 					Attributes: instance.Attributes.Value,
 				},
 			}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/config/gasvalidator"
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/ipc/common"
+)
+
+const gasScheduleCommand = "gas-schedule"
+
+const gasScheduleUsage = `usage:
+  arwen gas-schedule validate <tomlDir>
+  arwen gas-schedule diff <oldSchedule.toml> <newSchedule.toml>`
+
+// doGasScheduleCommand runs the "gas-schedule" subcommand, which validates the
+// gas schedules of a directory or prints the differences between two gas
+// schedule files; it returns (error code, error message)
+func doGasScheduleCommand(args []string) (int, string) {
+	if len(args) == 0 {
+		return common.ErrCodeInit, gasScheduleUsage
+	}
+
+	switch {
+	case args[0] == "validate" && len(args) == 2:
+		return validateGasSchedules(args[1])
+	case args[0] == "diff" && len(args) == 3:
+		return diffGasSchedules(args[1], args[2])
+	}
+
+	return common.ErrCodeInit, gasScheduleUsage
+}
+
+func validateGasSchedules(tomlDir string) (int, string) {
+	gasSchedules, err := gasvalidator.LoadAndValidate(tomlDir)
+
+	var validationError *gasvalidator.ValidationError
+	if err != nil && !errors.As(err, &validationError) {
+		return common.ErrCodeInit, fmt.Sprintf("Cannot load gas schedules: %v", err)
+	}
+
+	names := make([]string, 0, len(gasSchedules))
+	for name := range gasSchedules {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	fmt.Printf("validated gas schedules: %s\n", strings.Join(names, ", "))
+
+	if validationError != nil {
+		return common.ErrCodeInit, validationError.Error()
+	}
+
+	return common.ErrCodeSuccess, ""
+}
+
+func diffGasSchedules(oldPath string, newPath string) (int, string) {
+	oldSchedule, err := gasvalidator.LoadGasSchedule(oldPath)
+	if err != nil {
+		return common.ErrCodeInit, fmt.Sprintf("Cannot load gas schedule %s: %v", oldPath, err)
+	}
+
+	newSchedule, err := gasvalidator.LoadGasSchedule(newPath)
+	if err != nil {
+		return common.ErrCodeInit, fmt.Sprintf("Cannot load gas schedule %s: %v", newPath, err)
+	}
+
+	for _, change := range gasvalidator.Diff(oldSchedule, newSchedule) {
+		fmt.Println(change)
+	}
+
+	return common.ErrCodeSuccess, ""
+}
@@ -21,7 +21,14 @@ const (
 var appVersion = "undefined"
 
 func main() {
-	errCode, errMessage := doMain()
+	var errCode int
+	var errMessage string
+	if len(os.Args) > 1 && os.Args[1] == gasScheduleCommand {
+		errCode, errMessage = doGasScheduleCommand(os.Args[2:])
+	} else {
+		errCode, errMessage = doMain()
+	}
+
 	if errCode != common.ErrCodeSuccess {
 		fmt.Fprintln(os.Stderr, errMessage)
 		os.Exit(errCode)
@@ -80,6 +87,7 @@ func doMain() (int, string) {
 		nodeToArwenFile,
 		arwenToNodeFile,
 		&arwenArguments.VMHostParameters,
+		arwenArguments.HasExecutionPolicy,
 		messagesMarshalizer,
 	)
 	if err != nil {
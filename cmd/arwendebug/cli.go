@@ -93,6 +93,19 @@ func initializeCLI(facade *arwendebug.DebugFacade) *cli.App {
 		Destination: &args.CodeMetadata,
 	}
 
+	// For run / query
+	flagProfile := cli.StringFlag{
+		Name:        "profile",
+		Usage:       "the path of a flame graph profile (folded stacks) of the execution to write",
+		Destination: &args.ProfilePath,
+	}
+
+	flagDebugStep := cli.Uint64Flag{
+		Name:        "debug-step",
+		Usage:       "the interval, in points, at which the steps of the execution are taken in single-step debugging mode",
+		Destination: &args.DebugStepInterval,
+	}
+
 	// For create-account
 	flagAccountAddress := cli.StringFlag{
 		Required:    true,
@@ -114,6 +127,33 @@ func initializeCLI(facade *arwendebug.DebugFacade) *cli.App {
 		Destination: &args.AccountNonce,
 	}
 
+	// For migrate-async
+	flagDryRun := cli.BoolFlag{
+		Name:        "dry-run",
+		Usage:       "only report the entries to migrate, without changing the storage",
+		Destination: &args.DryRun,
+	}
+
+	// For export-scenario / import-scenario
+	flagScenarioName := cli.StringFlag{
+		Name:        "name",
+		Usage:       "the name of the exported scenario, by default the name of the world",
+		Destination: &args.ScenarioName,
+	}
+
+	flagScenarioOutput := cli.StringFlag{
+		Name:        "scenario",
+		Usage:       "the path of the Mandos scenario file to write",
+		Destination: &args.ScenarioPath,
+	}
+
+	flagScenarioInput := cli.StringFlag{
+		Required:    true,
+		Name:        "scenario",
+		Usage:       "the path of the Mandos scenario file to load",
+		Destination: &args.ScenarioPath,
+	}
+
 	app.Flags = []cli.Flag{}
 
 	app.Authors = []cli.Author{
@@ -196,6 +236,8 @@ func initializeCLI(facade *arwendebug.DebugFacade) *cli.App {
 				flagValue,
 				flagGasLimit,
 				flagGasPrice,
+				flagProfile,
+				flagDebugStep,
 			},
 		},
 		{
@@ -214,6 +256,8 @@ func initializeCLI(facade *arwendebug.DebugFacade) *cli.App {
 				flagFunction,
 				flagArguments,
 				flagGasLimit,
+				flagProfile,
+				flagDebugStep,
 			},
 		},
 		{
@@ -232,6 +276,50 @@ func initializeCLI(facade *arwendebug.DebugFacade) *cli.App {
 				flagAccountNonce,
 			},
 		},
+		{
+			Name:        "migrate-async",
+			Description: "migrate legacy async data of a smart contract",
+			Action: func(context *cli.Context) error {
+				_, err := facade.MigrateAsyncData(args.toMigrateAsyncDataRequest())
+				return err
+			},
+			Flags: []cli.Flag{
+				flagOutcome,
+				flagWorld,
+				flagDatabase,
+				flagContract,
+				flagDryRun,
+			},
+		},
+		{
+			Name:        "export-scenario",
+			Description: "export the history of a world as a Mandos scenario",
+			Action: func(context *cli.Context) error {
+				_, err := facade.ExportScenario(args.toExportScenarioRequest())
+				return err
+			},
+			Flags: []cli.Flag{
+				flagOutcome,
+				flagWorld,
+				flagDatabase,
+				flagScenarioName,
+				flagScenarioOutput,
+			},
+		},
+		{
+			Name:        "import-scenario",
+			Description: "load a Mandos scenario into a new world",
+			Action: func(context *cli.Context) error {
+				_, err := facade.ImportScenario(args.toImportScenarioRequest())
+				return err
+			},
+			Flags: []cli.Flag{
+				flagOutcome,
+				flagWorld,
+				flagDatabase,
+				flagScenarioInput,
+			},
+		},
 	}
 
 	return app
@@ -23,10 +23,18 @@ type cliArguments struct {
 	Value           string
 	GasLimit        uint64
 	GasPrice        uint64
+	// For run / query
+	ProfilePath       string
+	DebugStepInterval uint64
 	// For blockchain-related action
 	AccountAddress string
 	AccountBalance string
 	AccountNonce   uint64
+	// For migrate-async
+	DryRun bool
+	// For export-scenario / import-scenario
+	ScenarioName string
+	ScenarioPath string
 }
 
 func (args *cliArguments) toDeployRequest() arwendebug.DeployRequest {
@@ -81,6 +89,8 @@ func (args *cliArguments) populateRunRequest(request *arwendebug.RunRequest) {
 	request.ContractAddressHex = args.ContractAddress
 	request.Function = args.Function
 	request.ArgumentsHex = args.Arguments
+	request.ProfilePath = args.ProfilePath
+	request.DebugStepInterval = args.DebugStepInterval
 }
 
 func (args *cliArguments) toQueryRequest() arwendebug.QueryRequest {
@@ -99,3 +109,29 @@ func (args *cliArguments) toCreateAccountRequest() arwendebug.CreateAccountReque
 	request.Nonce = args.AccountNonce
 	return *request
 }
+
+func (args *cliArguments) toMigrateAsyncDataRequest() arwendebug.MigrateAsyncDataRequest {
+	request := &arwendebug.MigrateAsyncDataRequest{}
+	args.populateRequestBase(&request.RequestBase)
+
+	request.ContractAddressHex = args.ContractAddress
+	request.DryRun = args.DryRun
+	return *request
+}
+
+func (args *cliArguments) toExportScenarioRequest() arwendebug.ExportScenarioRequest {
+	request := &arwendebug.ExportScenarioRequest{}
+	args.populateRequestBase(&request.RequestBase)
+
+	request.Name = args.ScenarioName
+	request.ScenarioPath = args.ScenarioPath
+	return *request
+}
+
+func (args *cliArguments) toImportScenarioRequest() arwendebug.ImportScenarioRequest {
+	request := &arwendebug.ImportScenarioRequest{}
+	args.populateRequestBase(&request.RequestBase)
+
+	request.ScenarioPath = args.ScenarioPath
+	return *request
+}
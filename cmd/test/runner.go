@@ -1,6 +1,7 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -31,11 +32,16 @@ func main() {
 		os.Exit(1)
 	}
 
-	// argument
-	if len(os.Args) != 2 {
+	// arguments
+	gasGoldenPath := flag.String("gas-golden", "", "golden file holding the gas used by each tx, to compare against")
+	updateGasGolden := flag.Bool("update-gas-golden", false, "write the gas used by each tx to the golden file instead")
+	recordPath := flag.String("record", "", "write the executed steps of a .scen.json to a new, fully explicit scenario")
+	parallel := flag.Int("parallel", 1, "number of scenarios of a directory to run concurrently, grouped by gas schedule")
+	flag.Parse()
+	if flag.NArg() != 1 {
 		panic("One argument expected - the path to the json test.")
 	}
-	jsonFilePath, isDir, err := resolveArgument(exeDir, os.Args[1])
+	jsonFilePath, isDir, err := resolveArgument(exeDir, flag.Arg(0))
 	if err != nil {
 		fmt.Println(err)
 		os.Exit(1)
@@ -46,9 +52,44 @@ func main() {
 	if err != nil {
 		panic("Could not instantiate Arwen VM")
 	}
+	if len(*gasGoldenPath) > 0 {
+		gasGoldenMode := am.GasGoldenCompare
+		if *updateGasGolden {
+			gasGoldenMode = am.GasGoldenWrite
+		}
+		err = executor.SetGasGoldenFile(*gasGoldenPath, gasGoldenMode)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	}
 
 	// execute
 	switch {
+	case len(*recordPath) > 0:
+		if isDir || !strings.HasSuffix(jsonFilePath, ".scen.json") {
+			panic("Only a single .scen.json can be recorded.")
+		}
+		recorder := am.NewScenarioRecorder(executor)
+		runner := mc.NewScenarioRunner(
+			recorder,
+			mc.NewDefaultFileResolver(),
+		)
+		err = runner.RunSingleJSONScenario(jsonFilePath)
+		if err == nil {
+			err = recorder.WriteRecordedScenario(*recordPath)
+		}
+	case isDir && *parallel > 1:
+		if len(*gasGoldenPath) > 0 {
+			panic("The golden gas file cannot be used when running in parallel.")
+		}
+		err = mc.RunAllJSONScenariosInDirectoryParallel(
+			am.ParallelTestExecutorsFactory(*parallel),
+			mc.NewDefaultFileResolver(),
+			jsonFilePath,
+			"",
+			".scen.json",
+			[]string{})
 	case isDir:
 		runner := mc.NewScenarioRunner(
 			executor,
@@ -72,6 +113,9 @@ func main() {
 		)
 		err = runner.RunSingleJSONTest(jsonFilePath)
 	}
+	if err == nil {
+		err = executor.SaveGasGoldenFile()
+	}
 
 	// print result
 	if err == nil {
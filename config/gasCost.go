@@ -3,12 +3,16 @@ package config
 import "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/wasmer"
 
 type GasCost struct {
-	BaseOperationCost BaseOperationCost
-	BigIntAPICost     BigIntAPICost
-	EthAPICost        EthAPICost
-	ElrondAPICost     ElrondAPICost
-	CryptoAPICost     CryptoAPICost
-	WASMOpcodeCost    WASMOpcodeCost
+	BaseOperationCost    BaseOperationCost
+	BigIntAPICost        BigIntAPICost
+	BigFloatAPICost      BigFloatAPICost
+	ManagedMapAPICost    ManagedMapAPICost
+	ManagedBufferAPICost ManagedBufferAPICost
+	EthAPICost           EthAPICost
+	ElrondAPICost        ElrondAPICost
+	CryptoAPICost        CryptoAPICost
+	EllipticCurveAPICost EllipticCurveAPICost
+	WASMOpcodeCost       WASMOpcodeCost
 }
 
 type BaseOperationCost struct {
@@ -22,44 +26,60 @@ type BaseOperationCost struct {
 }
 
 type ElrondAPICost struct {
-	GetSCAddress         uint64
-	GetOwnerAddress      uint64
-	IsSmartContract      uint64
-	GetShardOfAddress    uint64
-	GetExternalBalance   uint64
-	GetBlockHash         uint64
-	TransferValue        uint64
-	GetArgument          uint64
-	GetFunction          uint64
-	GetNumArguments      uint64
-	StorageStore         uint64
-	StorageLoad          uint64
-	GetCaller            uint64
-	GetCallValue         uint64
-	Log                  uint64
-	Finish               uint64
-	SignalError          uint64
-	GetBlockTimeStamp    uint64
-	GetGasLeft           uint64
-	Int64GetArgument     uint64
-	Int64StorageStore    uint64
-	Int64StorageLoad     uint64
-	Int64Finish          uint64
-	GetStateRootHash     uint64
-	GetBlockNonce        uint64
-	GetBlockEpoch        uint64
-	GetBlockRound        uint64
-	GetBlockRandomSeed   uint64
-	ExecuteOnSameContext uint64
-	ExecuteOnDestContext uint64
-	DelegateExecution    uint64
-	ExecuteReadOnly      uint64
-	AsyncCallStep        uint64
-	AsyncCallbackGasLock uint64
-	CreateContract       uint64
-	GetReturnData        uint64
-	GetNumReturnData     uint64
-	GetReturnDataSize    uint64
+	GetSCAddress            uint64
+	GetOwnerAddress         uint64
+	IsSmartContract         uint64
+	GetShardOfAddress       uint64
+	GetExternalBalance      uint64
+	GetBlockHash            uint64
+	TransferValue           uint64
+	GetArgument             uint64
+	GetFunction             uint64
+	GetNumArguments         uint64
+	StorageStore            uint64
+	StorageLoad             uint64
+	GetCaller               uint64
+	GetCallValue            uint64
+	Log                     uint64
+	Finish                  uint64
+	SignalError             uint64
+	GetBlockTimeStamp       uint64
+	GetGasLeft              uint64
+	Int64GetArgument        uint64
+	Int64StorageStore       uint64
+	Int64StorageLoad        uint64
+	Int64Finish             uint64
+	GetStateRootHash        uint64
+	GetBlockNonce           uint64
+	GetBlockEpoch           uint64
+	GetBlockRound           uint64
+	GetBlockRandomSeed      uint64
+	GetBlockInfo            uint64
+	GetDeterministicRandom  uint64
+	ExecuteOnSameContext    uint64
+	ExecuteOnDestContext    uint64
+	DelegateExecution       uint64
+	ExecuteReadOnly         uint64
+	AsyncCallStep           uint64
+	AsyncCallbackGasLock    uint64
+	CreateContract          uint64
+	GetReturnData           uint64
+	GetNumReturnData        uint64
+	GetReturnDataSize       uint64
+	GetReturnDataBudget     uint64
+	RegisterDeferredCall    uint64
+	EstimateAsyncCallGas    uint64
+	LogPerTopic             uint64
+	LogPerDataByte          uint64
+	LogPerTopicByte         uint64
+	ESDTNFTDataPerByte      uint64
+	StorageKeysWithPrefix   uint64
+	StorageKeysPerKey       uint64
+	StorageKeysScanPerKey   uint64
+	CachedStorageLoad       uint64
+	StorageLoadFromContract uint64
+	TransientStore          uint64
+	TransientLoad           uint64
 }
 
 type EthAPICost struct {
@@ -134,15 +154,74 @@ type BigIntAPICost struct {
 	BigIntGetSignedArgument    uint64
 	BigIntGetCallValue         uint64
 	BigIntGetExternalBalance   uint64
+	BigIntPowMod               uint64
+	BigIntPowModPerUnit        uint64
+	BigIntIsPrime              uint64
+	BigIntIsPrimePerUnit       uint64
+	BigIntGcd                  uint64
+	BigIntGcdPerUnit           uint64
+}
+
+type BigFloatAPICost struct {
+	BigFloatNewFromParts uint64
+	BigFloatAdd          uint64
+	BigFloatSub          uint64
+	BigFloatMul          uint64
+	BigFloatDiv          uint64
+	BigFloatSqrt         uint64
+	BigFloatPow          uint64
+	BigFloatCmp          uint64
+	BigFloatTruncate     uint64
+}
+
+type ManagedMapAPICost struct {
+	ManagedMapNew        uint64
+	ManagedMapPut        uint64
+	ManagedMapGet        uint64
+	ManagedMapRemove     uint64
+	ManagedMapContains   uint64
+	ManagedMapLength     uint64
+	ManagedMapKeyAtIndex uint64
+}
+
+type ManagedBufferAPICost struct {
+	MBufferNew          uint64
+	MBufferNewFromBytes uint64
+	MBufferGetLength    uint64
+	MBufferGetBytes     uint64
+	MBufferSetBytes     uint64
+	MBufferAppend       uint64
+	MBufferCopySlice    uint64
+	MBufferFind         uint64
+	MBufferSplit        uint64
+	MBufferCompareSlice uint64
 }
 
 type CryptoAPICost struct {
-	SHA256          uint64
-	Keccak256       uint64
-	Ripemd160       uint64
-	VerifyBLS       uint64
-	VerifyEd25519   uint64
-	VerifySecp256k1 uint64
+	SHA256                         uint64
+	Keccak256                      uint64
+	Ripemd160                      uint64
+	Poseidon                       uint64
+	PoseidonPerInput               uint64
+	MiMC                           uint64
+	MiMCPerInput                   uint64
+	VerifyBLS                      uint64
+	VerifyBLSAggregated            uint64
+	VerifyBLSAggregatedPerKey      uint64
+	VerifyEd25519                  uint64
+	VerifyEd25519Batch             uint64
+	VerifyEd25519BatchPerSignature uint64
+	VerifySecp256k1                uint64
+	Ecrecover                      uint64
+}
+
+type EllipticCurveAPICost struct {
+	CurvePointFromBytes      uint64
+	CurvePointToBytes        uint64
+	CurvePointAdd            uint64
+	CurvePointScalarMult     uint64
+	CurvePointScalarBaseMult uint64
+	CurvePointEqual          uint64
 }
 
 type WASMOpcodeCost struct {
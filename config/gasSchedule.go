@@ -48,6 +48,39 @@ func CreateGasConfig(gasMap GasScheduleMap) (*GasCost, error) {
 		return nil, err
 	}
 
+	bigFloatOps := &BigFloatAPICost{}
+	err = mapstructure.Decode(gasMap["BigFloatAPICost"], bigFloatOps)
+	if err != nil {
+		return nil, err
+	}
+
+	err = checkForZeroUint64Fields(*bigFloatOps)
+	if err != nil {
+		return nil, err
+	}
+
+	managedMapOps := &ManagedMapAPICost{}
+	err = mapstructure.Decode(gasMap["ManagedMapAPICost"], managedMapOps)
+	if err != nil {
+		return nil, err
+	}
+
+	err = checkForZeroUint64Fields(*managedMapOps)
+	if err != nil {
+		return nil, err
+	}
+
+	managedBufferOps := &ManagedBufferAPICost{}
+	err = mapstructure.Decode(gasMap["ManagedBufferAPICost"], managedBufferOps)
+	if err != nil {
+		return nil, err
+	}
+
+	err = checkForZeroUint64Fields(*managedBufferOps)
+	if err != nil {
+		return nil, err
+	}
+
 	ethOps := &EthAPICost{}
 	err = mapstructure.Decode(gasMap["EthAPICost"], ethOps)
 	if err != nil {
@@ -70,6 +103,17 @@ func CreateGasConfig(gasMap GasScheduleMap) (*GasCost, error) {
 		return nil, err
 	}
 
+	ellipticCurveOps := &EllipticCurveAPICost{}
+	err = mapstructure.Decode(gasMap["EllipticCurveAPICost"], ellipticCurveOps)
+	if err != nil {
+		return nil, err
+	}
+
+	err = checkForZeroUint64Fields(*ellipticCurveOps)
+	if err != nil {
+		return nil, err
+	}
+
 	opcodeCosts := &WASMOpcodeCost{}
 	err = mapstructure.Decode(gasMap["WASMOpcodeCost"], opcodeCosts)
 	if err != nil {
@@ -82,12 +126,16 @@ func CreateGasConfig(gasMap GasScheduleMap) (*GasCost, error) {
 	}
 
 	gasCost := &GasCost{
-		BaseOperationCost: *baseOps,
-		BigIntAPICost:     *bigIntOps,
-		EthAPICost:        *ethOps,
-		ElrondAPICost:     *elrondOps,
-		CryptoAPICost:     *cryptOps,
-		WASMOpcodeCost:    *opcodeCosts,
+		BaseOperationCost:    *baseOps,
+		BigIntAPICost:        *bigIntOps,
+		BigFloatAPICost:      *bigFloatOps,
+		ManagedMapAPICost:    *managedMapOps,
+		ManagedBufferAPICost: *managedBufferOps,
+		EthAPICost:           *ethOps,
+		ElrondAPICost:        *elrondOps,
+		CryptoAPICost:        *cryptOps,
+		EllipticCurveAPICost: *ellipticCurveOps,
+		WASMOpcodeCost:       *opcodeCosts,
 	}
 
 	return gasCost, nil
@@ -121,7 +169,11 @@ func FillGasMap(gasMap GasScheduleMap, value, asyncCallbackGasLock uint64) GasSc
 	gasMap["ElrondAPICost"] = FillGasMap_ElrondAPICosts(value, asyncCallbackGasLock)
 	gasMap["EthAPICost"] = FillGasMap_EthereumAPICosts(value)
 	gasMap["BigIntAPICost"] = FillGasMap_BigIntAPICosts(value)
+	gasMap["BigFloatAPICost"] = FillGasMap_BigFloatAPICosts(value)
+	gasMap["ManagedMapAPICost"] = FillGasMap_ManagedMapAPICosts(value)
+	gasMap["ManagedBufferAPICost"] = FillGasMap_ManagedBufferAPICosts(value)
 	gasMap["CryptoAPICost"] = FillGasMap_CryptoAPICosts(value)
+	gasMap["EllipticCurveAPICost"] = FillGasMap_EllipticCurveAPICosts(value)
 	gasMap["WASMOpcodeCost"] = FillGasMap_WASMOpcodeValues(value)
 
 	return gasMap
@@ -189,6 +241,8 @@ func FillGasMap_ElrondAPICosts(value, asyncCallbackGasLock uint64) map[string]ui
 	gasMap["GetBlockEpoch"] = value
 	gasMap["GetBlockRound"] = value
 	gasMap["GetBlockRandomSeed"] = value
+	gasMap["GetBlockInfo"] = value
+	gasMap["GetDeterministicRandom"] = value
 	gasMap["ExecuteOnSameContext"] = value
 	gasMap["ExecuteOnDestContext"] = value
 	gasMap["DelegateExecution"] = value
@@ -199,6 +253,20 @@ func FillGasMap_ElrondAPICosts(value, asyncCallbackGasLock uint64) map[string]ui
 	gasMap["GetReturnData"] = value
 	gasMap["GetNumReturnData"] = value
 	gasMap["GetReturnDataSize"] = value
+	gasMap["GetReturnDataBudget"] = value
+	gasMap["RegisterDeferredCall"] = value
+	gasMap["EstimateAsyncCallGas"] = value
+	gasMap["LogPerTopic"] = value
+	gasMap["LogPerDataByte"] = value
+	gasMap["LogPerTopicByte"] = value
+	gasMap["ESDTNFTDataPerByte"] = value
+	gasMap["StorageKeysWithPrefix"] = value
+	gasMap["StorageKeysPerKey"] = value
+	gasMap["StorageKeysScanPerKey"] = value
+	gasMap["CachedStorageLoad"] = value
+	gasMap["StorageLoadFromContract"] = value
+	gasMap["TransientStore"] = value
+	gasMap["TransientLoad"] = value
 
 	return gasMap
 }
@@ -279,6 +347,56 @@ func FillGasMap_BigIntAPICosts(value uint64) map[string]uint64 {
 	gasMap["BigIntGetSignedArgument"] = value
 	gasMap["BigIntGetCallValue"] = value
 	gasMap["BigIntGetExternalBalance"] = value
+	gasMap["BigIntPowMod"] = value
+	gasMap["BigIntPowModPerUnit"] = value
+	gasMap["BigIntIsPrime"] = value
+	gasMap["BigIntIsPrimePerUnit"] = value
+	gasMap["BigIntGcd"] = value
+	gasMap["BigIntGcdPerUnit"] = value
+
+	return gasMap
+}
+
+func FillGasMap_BigFloatAPICosts(value uint64) map[string]uint64 {
+	gasMap := make(map[string]uint64)
+	gasMap["BigFloatNewFromParts"] = value
+	gasMap["BigFloatAdd"] = value
+	gasMap["BigFloatSub"] = value
+	gasMap["BigFloatMul"] = value
+	gasMap["BigFloatDiv"] = value
+	gasMap["BigFloatSqrt"] = value
+	gasMap["BigFloatPow"] = value
+	gasMap["BigFloatCmp"] = value
+	gasMap["BigFloatTruncate"] = value
+
+	return gasMap
+}
+
+func FillGasMap_ManagedMapAPICosts(value uint64) map[string]uint64 {
+	gasMap := make(map[string]uint64)
+	gasMap["ManagedMapNew"] = value
+	gasMap["ManagedMapPut"] = value
+	gasMap["ManagedMapGet"] = value
+	gasMap["ManagedMapRemove"] = value
+	gasMap["ManagedMapContains"] = value
+	gasMap["ManagedMapLength"] = value
+	gasMap["ManagedMapKeyAtIndex"] = value
+
+	return gasMap
+}
+
+func FillGasMap_ManagedBufferAPICosts(value uint64) map[string]uint64 {
+	gasMap := make(map[string]uint64)
+	gasMap["MBufferNew"] = value
+	gasMap["MBufferNewFromBytes"] = value
+	gasMap["MBufferGetLength"] = value
+	gasMap["MBufferGetBytes"] = value
+	gasMap["MBufferSetBytes"] = value
+	gasMap["MBufferAppend"] = value
+	gasMap["MBufferCopySlice"] = value
+	gasMap["MBufferFind"] = value
+	gasMap["MBufferSplit"] = value
+	gasMap["MBufferCompareSlice"] = value
 
 	return gasMap
 }
@@ -288,9 +406,30 @@ func FillGasMap_CryptoAPICosts(value uint64) map[string]uint64 {
 	gasMap["SHA256"] = value
 	gasMap["Keccak256"] = value
 	gasMap["Ripemd160"] = value
+	gasMap["Poseidon"] = value
+	gasMap["PoseidonPerInput"] = value
+	gasMap["MiMC"] = value
+	gasMap["MiMCPerInput"] = value
 	gasMap["VerifyBLS"] = value
+	gasMap["VerifyBLSAggregated"] = value
+	gasMap["VerifyBLSAggregatedPerKey"] = value
 	gasMap["VerifyEd25519"] = value
+	gasMap["VerifyEd25519Batch"] = value
+	gasMap["VerifyEd25519BatchPerSignature"] = value
 	gasMap["VerifySecp256k1"] = value
+	gasMap["Ecrecover"] = value
+
+	return gasMap
+}
+
+func FillGasMap_EllipticCurveAPICosts(value uint64) map[string]uint64 {
+	gasMap := make(map[string]uint64)
+	gasMap["CurvePointFromBytes"] = value
+	gasMap["CurvePointToBytes"] = value
+	gasMap["CurvePointAdd"] = value
+	gasMap["CurvePointScalarMult"] = value
+	gasMap["CurvePointScalarBaseMult"] = value
+	gasMap["CurvePointEqual"] = value
 
 	return gasMap
 }
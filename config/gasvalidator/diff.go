@@ -0,0 +1,122 @@
+package gasvalidator
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/config"
+)
+
+// ChangeKind identifies how a cost differs between two gas schedules
+type ChangeKind int
+
+const (
+	// CostAdded is the change of a cost defined only by the new gas schedule
+	CostAdded ChangeKind = iota
+
+	// CostRemoved is the change of a cost defined only by the old gas schedule
+	CostRemoved
+
+	// CostModified is the change of a cost defined with different values by the gas schedules
+	CostModified
+)
+
+// String returns the name of the ChangeKind
+func (kind ChangeKind) String() string {
+	switch kind {
+	case CostAdded:
+		return "added"
+	case CostRemoved:
+		return "removed"
+	case CostModified:
+		return "modified"
+	}
+	return "unknown"
+}
+
+// Change is a cost which differs between two gas schedules; OldValue is 0
+// for an added cost, and NewValue is 0 for a removed one
+type Change struct {
+	Section  string
+	Key      string
+	Kind     ChangeKind
+	OldValue uint64
+	NewValue uint64
+}
+
+// String returns the description of the Change
+func (change *Change) String() string {
+	switch change.Kind {
+	case CostAdded:
+		return fmt.Sprintf("+ %s.%s = %d", change.Section, change.Key, change.NewValue)
+	case CostRemoved:
+		return fmt.Sprintf("- %s.%s = %d", change.Section, change.Key, change.OldValue)
+	}
+	return fmt.Sprintf("~ %s.%s = %d -> %d", change.Section, change.Key, change.OldValue, change.NewValue)
+}
+
+// Diff returns the costs which differ between the old and the new gas
+// schedule, ordered by section and key
+func Diff(oldSchedule config.GasScheduleMap, newSchedule config.GasScheduleMap) []*Change {
+	changes := make([]*Change, 0)
+	for _, section := range sortedSections(oldSchedule, newSchedule) {
+		oldCosts := oldSchedule[section]
+		newCosts := newSchedule[section]
+		for _, key := range sortedKeys(oldCosts, newCosts) {
+			oldValue, inOld := oldCosts[key]
+			newValue, inNew := newCosts[key]
+
+			change := &Change{
+				Section:  section,
+				Key:      key,
+				OldValue: oldValue,
+				NewValue: newValue,
+			}
+			switch {
+			case !inOld:
+				change.Kind = CostAdded
+			case !inNew:
+				change.Kind = CostRemoved
+			case oldValue != newValue:
+				change.Kind = CostModified
+			default:
+				continue
+			}
+			changes = append(changes, change)
+		}
+	}
+
+	return changes
+}
+
+func sortedSections(gasSchedules ...config.GasScheduleMap) []string {
+	sectionSet := make(map[string]struct{})
+	for _, gasSchedule := range gasSchedules {
+		for section := range gasSchedule {
+			sectionSet[section] = struct{}{}
+		}
+	}
+
+	return sortedSet(sectionSet)
+}
+
+func sortedKeys(costMaps ...map[string]uint64) []string {
+	keySet := make(map[string]struct{})
+	for _, costs := range costMaps {
+		for key := range costs {
+			keySet[key] = struct{}{}
+		}
+	}
+
+	return sortedSet(keySet)
+}
+
+func sortedSet(set map[string]struct{}) []string {
+	result := make([]string, 0, len(set))
+	for value := range set {
+		result = append(result, value)
+	}
+	sort.Strings(result)
+
+	return result
+}
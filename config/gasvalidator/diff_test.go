@@ -0,0 +1,34 @@
+package gasvalidator
+
+import (
+	"testing"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff(t *testing.T) {
+	oldSchedule := config.GasScheduleMap{
+		"BaseOperationCost": {"StorePerByte": 10, "GetCode": 5, "ReleasePerByte": 2},
+		"EthAPICost":        {"UseGas": 1},
+	}
+	newSchedule := config.GasScheduleMap{
+		"BaseOperationCost": {"StorePerByte": 12, "GetCode": 5, "CompilePerByte": 3},
+		"CryptoAPICost":     {"SHA256": 7},
+	}
+
+	changes := Diff(oldSchedule, newSchedule)
+	assert.Equal(t, []*Change{
+		{Section: "BaseOperationCost", Key: "CompilePerByte", Kind: CostAdded, NewValue: 3},
+		{Section: "BaseOperationCost", Key: "ReleasePerByte", Kind: CostRemoved, OldValue: 2},
+		{Section: "BaseOperationCost", Key: "StorePerByte", Kind: CostModified, OldValue: 10, NewValue: 12},
+		{Section: "CryptoAPICost", Key: "SHA256", Kind: CostAdded, NewValue: 7},
+		{Section: "EthAPICost", Key: "UseGas", Kind: CostRemoved, OldValue: 1},
+	}, changes)
+
+	assert.Equal(t, "+ BaseOperationCost.CompilePerByte = 3", changes[0].String())
+	assert.Equal(t, "- BaseOperationCost.ReleasePerByte = 2", changes[1].String())
+	assert.Equal(t, "~ BaseOperationCost.StorePerByte = 10 -> 12", changes[2].String())
+
+	assert.Empty(t, Diff(oldSchedule, oldSchedule))
+}
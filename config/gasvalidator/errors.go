@@ -0,0 +1,12 @@
+package gasvalidator
+
+import "errors"
+
+// ErrNoGasSchedules signals that the directory holds no gas schedule files
+var ErrNoGasSchedules = errors.New("no gas schedule files found")
+
+// ErrInvalidGasScheduleSection signals that a section of a gas schedule is not a table of costs
+var ErrInvalidGasScheduleSection = errors.New("invalid gas schedule section")
+
+// ErrInvalidGasCost signals that a cost of a gas schedule is not a non-negative integer
+var ErrInvalidGasCost = errors.New("invalid gas cost")
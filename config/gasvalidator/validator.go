@@ -0,0 +1,184 @@
+package gasvalidator
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/config"
+	"github.com/pelletier/go-toml"
+)
+
+// GasScheduleFileSuffix is the extension of the gas schedule files loaded by LoadAndValidate
+const GasScheduleFileSuffix = ".toml"
+
+// IssueKind identifies the problems which Validate finds in a gas schedule
+type IssueKind int
+
+const (
+	// MissingCost is the issue of a cost required by the VM, which the gas schedule does not define
+	MissingCost IssueKind = iota
+
+	// ZeroCost is the issue of a cost required by the VM, which the gas schedule sets to 0
+	ZeroCost
+)
+
+// String returns the description of the IssueKind
+func (kind IssueKind) String() string {
+	switch kind {
+	case MissingCost:
+		return "missing"
+	case ZeroCost:
+		return "set to 0"
+	}
+	return "unknown"
+}
+
+// Issue is a problem with a cost of a gas schedule
+type Issue struct {
+	Schedule string
+	Section  string
+	Key      string
+	Kind     IssueKind
+}
+
+// String returns the description of the Issue
+func (issue *Issue) String() string {
+	return fmt.Sprintf("%s: %s.%s is %s", issue.Schedule, issue.Section, issue.Key, issue.Kind)
+}
+
+// ValidationError holds the issues found in the gas schedules by LoadAndValidate
+type ValidationError struct {
+	Issues []*Issue
+}
+
+// Error returns the description of all the issues, one per line
+func (err *ValidationError) Error() string {
+	lines := make([]string, len(err.Issues))
+	for i, issue := range err.Issues {
+		lines[i] = issue.String()
+	}
+
+	return fmt.Sprintf("invalid gas schedules (%d issues):\n%s", len(err.Issues), strings.Join(lines, "\n"))
+}
+
+// LoadAndValidate loads all the gas schedules of the given directory, keyed
+// by their file name without extension, and validates each of them. The
+// schedules are returned even if invalid, along with a *ValidationError
+// which holds the issues found in all of them.
+func LoadAndValidate(tomlDir string) (map[string]config.GasScheduleMap, error) {
+	files, err := ioutil.ReadDir(tomlDir)
+	if err != nil {
+		return nil, err
+	}
+
+	gasSchedules := make(map[string]config.GasScheduleMap)
+	issues := make([]*Issue, 0)
+	for _, file := range files {
+		if file.IsDir() || filepath.Ext(file.Name()) != GasScheduleFileSuffix {
+			continue
+		}
+
+		name := strings.TrimSuffix(file.Name(), GasScheduleFileSuffix)
+		gasSchedule, err := LoadGasSchedule(filepath.Join(tomlDir, file.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", file.Name(), err)
+		}
+
+		gasSchedules[name] = gasSchedule
+		issues = append(issues, Validate(name, gasSchedule)...)
+	}
+
+	if len(gasSchedules) == 0 {
+		return nil, ErrNoGasSchedules
+	}
+
+	if len(issues) > 0 {
+		return gasSchedules, &ValidationError{Issues: issues}
+	}
+
+	return gasSchedules, nil
+}
+
+// LoadGasSchedule reads a gas schedule from a TOML file, whose tables are the
+// sections of the gas schedule
+func LoadGasSchedule(path string) (config.GasScheduleMap, error) {
+	tree, err := toml.LoadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	gasSchedule := make(config.GasScheduleMap)
+	for section, costs := range tree.ToMap() {
+		costsMap, ok := costs.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidGasScheduleSection, section)
+		}
+
+		gasSchedule[section] = make(map[string]uint64, len(costsMap))
+		for key, cost := range costsMap {
+			value, ok := cost.(int64)
+			if !ok || value < 0 {
+				return nil, fmt.Errorf("%w: %s.%s", ErrInvalidGasCost, section, key)
+			}
+			gasSchedule[section][key] = uint64(value)
+		}
+	}
+
+	return gasSchedule, nil
+}
+
+// Validate returns the issues of the given gas schedule: the costs which the
+// VM decodes into config.GasCost must all be defined, and none can be 0, as
+// required by config.CreateGasConfig. The other sections, such as the costs
+// of the built-in functions, belong to the node and are not validated.
+func Validate(name string, gasSchedule config.GasScheduleMap) []*Issue {
+	issues := make([]*Issue, 0)
+	for _, section := range RequiredCosts() {
+		for _, key := range section.Keys {
+			value, ok := gasSchedule[section.Name][key]
+			switch {
+			case !ok:
+				issues = append(issues, &Issue{Schedule: name, Section: section.Name, Key: key, Kind: MissingCost})
+			case value == 0:
+				issues = append(issues, &Issue{Schedule: name, Section: section.Name, Key: key, Kind: ZeroCost})
+			}
+		}
+	}
+
+	return issues
+}
+
+// RequiredSection holds the keys of a gas schedule section required by the VM
+type RequiredSection struct {
+	Name string
+	Keys []string
+}
+
+// RequiredCosts returns the sections and keys of the costs which the VM
+// decodes into config.GasCost, in the order of their declaration
+func RequiredCosts() []RequiredSection {
+	gasCostType := reflect.TypeOf(config.GasCost{})
+	sections := make([]RequiredSection, 0, gasCostType.NumField())
+	for i := 0; i < gasCostType.NumField(); i++ {
+		sectionField := gasCostType.Field(i)
+		if sectionField.Type.Kind() != reflect.Struct {
+			continue
+		}
+
+		section := RequiredSection{Name: sectionField.Name}
+		for j := 0; j < sectionField.Type.NumField(); j++ {
+			costField := sectionField.Type.Field(j)
+			kind := costField.Type.Kind()
+			if kind != reflect.Uint64 && kind != reflect.Uint32 {
+				continue
+			}
+			section.Keys = append(section.Keys, costField.Name)
+		}
+		sections = append(sections, section)
+	}
+
+	return sections
+}
@@ -0,0 +1,87 @@
+package gasvalidator
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const gasSchedulesDir = "../../arwenmandos/gasSchedules"
+
+func TestLoadAndValidate_RepositorySchedules(t *testing.T) {
+	gasSchedules, err := LoadAndValidate(gasSchedulesDir)
+	require.Nil(t, err)
+	require.Len(t, gasSchedules, 3)
+
+	for name, gasSchedule := range gasSchedules {
+		_, err = config.CreateGasConfig(gasSchedule)
+		assert.Nil(t, err, name)
+	}
+}
+
+func TestLoadAndValidate_Issues(t *testing.T) {
+	tomlDir, err := ioutil.TempDir("", "gasSchedules")
+	require.Nil(t, err)
+	defer os.RemoveAll(tomlDir)
+
+	valid, err := ioutil.ReadFile(filepath.Join(gasSchedulesDir, "gasScheduleV3.toml"))
+	require.Nil(t, err)
+	writeFile(t, tomlDir, "valid.toml", string(valid))
+	writeFile(t, tomlDir, "invalid.toml", "[BaseOperationCost]\nStorePerByte = 0\n")
+	writeFile(t, tomlDir, "ignored.txt", "not a gas schedule")
+
+	gasSchedules, err := LoadAndValidate(tomlDir)
+	require.Len(t, gasSchedules, 2)
+	require.Equal(t, uint64(0), gasSchedules["invalid"]["BaseOperationCost"]["StorePerByte"])
+
+	var validationError *ValidationError
+	require.True(t, errors.As(err, &validationError))
+
+	numRequiredCosts := 0
+	for _, section := range RequiredCosts() {
+		numRequiredCosts += len(section.Keys)
+	}
+	require.Len(t, validationError.Issues, numRequiredCosts)
+
+	first := validationError.Issues[0]
+	assert.Equal(t, &Issue{Schedule: "invalid", Section: "BaseOperationCost", Key: "StorePerByte", Kind: ZeroCost}, first)
+	assert.Equal(t, "invalid: BaseOperationCost.StorePerByte is set to 0", first.String())
+	assert.Equal(t, MissingCost, validationError.Issues[1].Kind)
+}
+
+func TestLoadAndValidate_Errors(t *testing.T) {
+	tomlDir, err := ioutil.TempDir("", "gasSchedules")
+	require.Nil(t, err)
+	defer os.RemoveAll(tomlDir)
+
+	_, err = LoadAndValidate(tomlDir)
+	require.Equal(t, ErrNoGasSchedules, err)
+
+	writeFile(t, tomlDir, "negative.toml", "[BaseOperationCost]\nStorePerByte = -1\n")
+	_, err = LoadAndValidate(tomlDir)
+	require.True(t, errors.Is(err, ErrInvalidGasCost))
+
+	_, err = LoadAndValidate(filepath.Join(tomlDir, "missing"))
+	require.NotNil(t, err)
+}
+
+func TestLoadGasSchedule_InvalidSection(t *testing.T) {
+	tomlDir, err := ioutil.TempDir("", "gasSchedules")
+	require.Nil(t, err)
+	defer os.RemoveAll(tomlDir)
+
+	writeFile(t, tomlDir, "flat.toml", "StorePerByte = 1\n")
+	_, err = LoadGasSchedule(filepath.Join(tomlDir, "flat.toml"))
+	require.True(t, errors.Is(err, ErrInvalidGasScheduleSection))
+}
+
+func writeFile(t *testing.T, dir string, name string, contents string) {
+	err := ioutil.WriteFile(filepath.Join(dir, name), []byte(contents), 0644)
+	require.Nil(t, err)
+}
@@ -1,6 +1,7 @@
 package crypto
 
 import (
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/crypto/curves"
 	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/crypto/hashing"
 	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/crypto/signing/bls"
 	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/crypto/signing/ed25519"
@@ -14,10 +15,12 @@ func NewVMCrypto() VMCrypto {
 		Ed25519
 		BLS
 		Secp256k1
+		EllipticCurves
 	}{
-		Hasher:    hashing.NewHasher(),
-		Ed25519:   ed25519.NewEd25519Signer(),
-		BLS:       bls.NewBLS(),
-		Secp256k1: secp256k1.NewSecp256k1(),
+		Hasher:         hashing.NewHasher(),
+		Ed25519:        ed25519.NewEd25519Signer(),
+		BLS:            bls.NewBLS(),
+		Secp256k1:      secp256k1.NewSecp256k1(),
+		EllipticCurves: curves.NewCurves(),
 	}
 }
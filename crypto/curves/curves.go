@@ -0,0 +1,150 @@
+package curves
+
+import (
+	"github.com/gtank/ristretto255"
+	"golang.org/x/crypto/curve25519"
+)
+
+// Ristretto255 identifies the prime-order group built over Curve25519, whose
+// points support addition and scalar multiplication
+const Ristretto255 = int32(0)
+
+// Curve25519 identifies the Montgomery form of Curve25519, whose points are
+// only their u-coordinates, supporting scalar multiplication (X25519) alone
+const Curve25519 = int32(1)
+
+// PointLength is the length of the compressed points of all the supported curves
+const PointLength = 32
+
+// ScalarLength is the length of the scalars of all the supported curves
+const ScalarLength = 32
+
+type curves struct {
+}
+
+// NewCurves returns the elliptic curve arithmetic of the VM
+func NewCurves() *curves {
+	return &curves{}
+}
+
+// ValidateCurvePoint checks that the given bytes are a compressed point of
+// the given curve
+func (c *curves) ValidateCurvePoint(curveID int32, point []byte) error {
+	switch curveID {
+	case Ristretto255:
+		_, err := decodeRistretto255Point(point)
+		return err
+	case Curve25519:
+		if len(point) != PointLength {
+			return ErrInvalidCurvePoint
+		}
+		return nil
+	default:
+		return ErrUnsupportedCurve
+	}
+}
+
+// CurvePointAdd adds two compressed points of the given curve
+func (c *curves) CurvePointAdd(curveID int32, point1 []byte, point2 []byte) ([]byte, error) {
+	switch curveID {
+	case Ristretto255:
+		p1, err := decodeRistretto255Point(point1)
+		if err != nil {
+			return nil, err
+		}
+		p2, err := decodeRistretto255Point(point2)
+		if err != nil {
+			return nil, err
+		}
+		return ristretto255.NewElement().Add(p1, p2).Encode(nil), nil
+	case Curve25519:
+		return nil, ErrUnsupportedCurveOperation
+	default:
+		return nil, ErrUnsupportedCurve
+	}
+}
+
+// CurveScalarMult multiplies a compressed point of the given curve by a
+// 32-byte little-endian scalar
+func (c *curves) CurveScalarMult(curveID int32, point []byte, scalar []byte) ([]byte, error) {
+	switch curveID {
+	case Ristretto255:
+		p, err := decodeRistretto255Point(point)
+		if err != nil {
+			return nil, err
+		}
+		s, err := decodeRistretto255Scalar(scalar)
+		if err != nil {
+			return nil, err
+		}
+		return ristretto255.NewElement().ScalarMult(s, p).Encode(nil), nil
+	case Curve25519:
+		return x25519(scalar, point)
+	default:
+		return nil, ErrUnsupportedCurve
+	}
+}
+
+// CurveScalarBaseMult multiplies the base point of the given curve by a
+// 32-byte little-endian scalar
+func (c *curves) CurveScalarBaseMult(curveID int32, scalar []byte) ([]byte, error) {
+	switch curveID {
+	case Ristretto255:
+		s, err := decodeRistretto255Scalar(scalar)
+		if err != nil {
+			return nil, err
+		}
+		return ristretto255.NewElement().ScalarBaseMult(s).Encode(nil), nil
+	case Curve25519:
+		return x25519(scalar, curve25519.Basepoint)
+	default:
+		return nil, ErrUnsupportedCurve
+	}
+}
+
+func decodeRistretto255Point(point []byte) (*ristretto255.Element, error) {
+	if len(point) != PointLength {
+		return nil, ErrInvalidCurvePoint
+	}
+
+	p := ristretto255.NewElement()
+	err := p.Decode(point)
+	if err != nil {
+		return nil, ErrInvalidCurvePoint
+	}
+
+	return p, nil
+}
+
+// decodeRistretto255Scalar only accepts canonical scalars, lower than the
+// order of the group
+func decodeRistretto255Scalar(scalar []byte) (*ristretto255.Scalar, error) {
+	if len(scalar) != ScalarLength {
+		return nil, ErrInvalidScalar
+	}
+
+	s := ristretto255.NewScalar()
+	err := s.Decode(scalar)
+	if err != nil {
+		return nil, ErrInvalidScalar
+	}
+
+	return s, nil
+}
+
+// x25519 rejects the points of low order, for which the result would be zero
+func x25519(scalar []byte, point []byte) ([]byte, error) {
+	if len(scalar) != ScalarLength {
+		return nil, ErrInvalidScalar
+	}
+	if len(point) != PointLength {
+		return nil, ErrInvalidCurvePoint
+	}
+
+	result, err := curve25519.X25519(scalar, point)
+	if err != nil {
+		return nil, ErrInvalidCurvePoint
+	}
+
+	return result, nil
+}
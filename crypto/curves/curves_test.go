@@ -0,0 +1,85 @@
+package curves
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func scalarOf(value byte) []byte {
+	scalar := make([]byte, ScalarLength)
+	scalar[0] = value
+	return scalar
+}
+
+func TestCurves_Ristretto255(t *testing.T) {
+	t.Parallel()
+
+	c := NewCurves()
+	base, err := c.CurveScalarBaseMult(Ristretto255, scalarOf(1))
+	require.Nil(t, err)
+	require.Nil(t, c.ValidateCurvePoint(Ristretto255, base))
+
+	doubled, err := c.CurvePointAdd(Ristretto255, base, base)
+	require.Nil(t, err)
+	timesTwo, err := c.CurveScalarMult(Ristretto255, base, scalarOf(2))
+	require.Nil(t, err)
+	twoTimesBase, err := c.CurveScalarBaseMult(Ristretto255, scalarOf(2))
+	require.Nil(t, err)
+	require.Equal(t, doubled, timesTwo)
+	require.Equal(t, doubled, twoTimesBase)
+
+	invalidPoint := bytes.Repeat([]byte{0xff}, PointLength)
+	require.Equal(t, ErrInvalidCurvePoint, c.ValidateCurvePoint(Ristretto255, invalidPoint))
+	_, err = c.CurvePointAdd(Ristretto255, base, invalidPoint)
+	require.Equal(t, ErrInvalidCurvePoint, err)
+	_, err = c.CurvePointAdd(Ristretto255, base, base[1:])
+	require.Equal(t, ErrInvalidCurvePoint, err)
+
+	nonCanonicalScalar := bytes.Repeat([]byte{0xff}, ScalarLength)
+	_, err = c.CurveScalarMult(Ristretto255, base, nonCanonicalScalar)
+	require.Equal(t, ErrInvalidScalar, err)
+	_, err = c.CurveScalarBaseMult(Ristretto255, scalarOf(1)[1:])
+	require.Equal(t, ErrInvalidScalar, err)
+}
+
+func TestCurves_Curve25519(t *testing.T) {
+	t.Parallel()
+
+	c := NewCurves()
+	scalarA := bytes.Repeat([]byte{3}, ScalarLength)
+	scalarB := bytes.Repeat([]byte{5}, ScalarLength)
+
+	pointA, err := c.CurveScalarBaseMult(Curve25519, scalarA)
+	require.Nil(t, err)
+	pointB, err := c.CurveScalarBaseMult(Curve25519, scalarB)
+	require.Nil(t, err)
+
+	sharedA, err := c.CurveScalarMult(Curve25519, pointB, scalarA)
+	require.Nil(t, err)
+	sharedB, err := c.CurveScalarMult(Curve25519, pointA, scalarB)
+	require.Nil(t, err)
+	require.Equal(t, sharedA, sharedB)
+
+	_, err = c.CurvePointAdd(Curve25519, pointA, pointB)
+	require.Equal(t, ErrUnsupportedCurveOperation, err)
+
+	lowOrderPoint := make([]byte, PointLength)
+	_, err = c.CurveScalarMult(Curve25519, lowOrderPoint, scalarA)
+	require.Equal(t, ErrInvalidCurvePoint, err)
+}
+
+func TestCurves_UnsupportedCurve(t *testing.T) {
+	t.Parallel()
+
+	c := NewCurves()
+	point := make([]byte, PointLength)
+	require.Equal(t, ErrUnsupportedCurve, c.ValidateCurvePoint(2, point))
+	_, err := c.CurvePointAdd(2, point, point)
+	require.Equal(t, ErrUnsupportedCurve, err)
+	_, err = c.CurveScalarMult(-1, point, scalarOf(1))
+	require.Equal(t, ErrUnsupportedCurve, err)
+	_, err = c.CurveScalarBaseMult(2, scalarOf(1))
+	require.Equal(t, ErrUnsupportedCurve, err)
+}
@@ -0,0 +1,20 @@
+package curves
+
+import (
+	"errors"
+)
+
+// ErrUnsupportedCurve signals that the requested elliptic curve is not supported
+var ErrUnsupportedCurve = errors.New("unsupported elliptic curve")
+
+// ErrUnsupportedCurveOperation signals that the requested operation is not defined on the given curve
+var ErrUnsupportedCurveOperation = errors.New("operation not supported on this elliptic curve")
+
+// ErrInvalidCurvePoint signals that an encoding is not a valid point of the curve
+var ErrInvalidCurvePoint = errors.New("invalid curve point")
+
+// ErrInvalidScalar signals that a scalar is not a valid scalar of the curve
+var ErrInvalidScalar = errors.New("invalid scalar")
+
+// ErrCurveMismatch signals that an operation received points of different curves
+var ErrCurveMismatch = errors.New("points of different elliptic curves")
@@ -0,0 +1,84 @@
+package hashing
+
+import (
+	"math/big"
+	"sync"
+
+	"golang.org/x/crypto/sha3"
+)
+
+const mimcRounds = 91
+
+const mimcSeed = "mimc"
+
+// MaxMiMCInputs is the largest number of inputs which MiMC accepts
+const MaxMiMCInputs = 256
+
+var mimcConstantsOnce sync.Once
+var mimcConstants []*big.Int
+
+// MiMC returns the MiMC-7 hash of the given BN254 scalar field elements, with
+// a zero key and the parameters of circomlib: 91 rounds, the first constant
+// being zero and the others a chain of Keccak256 hashes seeded with "mimc"
+func (h *hasher) MiMC(inputs [][]byte) ([]byte, error) {
+	if len(inputs) == 0 || len(inputs) > MaxMiMCInputs {
+		return nil, ErrInvalidNumberOfInputs
+	}
+
+	elements := make([]*big.Int, len(inputs))
+	for i, input := range inputs {
+		element, err := decodeFieldElement(input)
+		if err != nil {
+			return nil, err
+		}
+		elements[i] = element
+	}
+
+	mimcConstantsOnce.Do(generateMiMCConstants)
+
+	result := big.NewInt(0)
+	for _, element := range elements {
+		encrypted := mimcEncrypt(element, result)
+		result.Add(result, element)
+		result.Add(result, encrypted)
+		result.Mod(result, bn254ScalarField)
+	}
+
+	return encodeFieldElement(result), nil
+}
+
+// mimcEncrypt applies the x^7 rounds of MiMC to the given element under the given key
+func mimcEncrypt(element *big.Int, key *big.Int) *big.Int {
+	state := new(big.Int)
+	exponent := big.NewInt(7)
+	for round := 0; round < mimcRounds; round++ {
+		if round == 0 {
+			state.Add(element, key)
+		} else {
+			state.Add(state, key)
+			state.Add(state, mimcConstants[round])
+		}
+		state.Exp(state, exponent, bn254ScalarField)
+	}
+
+	state.Add(state, key)
+	return state.Mod(state, bn254ScalarField)
+}
+
+func generateMiMCConstants() {
+	mimcConstants = make([]*big.Int, mimcRounds)
+	mimcConstants[0] = big.NewInt(0)
+
+	hash := keccak256([]byte(mimcSeed))
+	for i := 1; i < mimcRounds; i++ {
+		hash = keccak256(hash)
+		mimcConstants[i] = new(big.Int).SetBytes(hash)
+		mimcConstants[i].Mod(mimcConstants[i], bn254ScalarField)
+	}
+}
+
+func keccak256(data []byte) []byte {
+	hash := sha3.NewLegacyKeccak256()
+	_, _ = hash.Write(data)
+	return hash.Sum(nil)
+}
@@ -0,0 +1,42 @@
+package hashing
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHasher_MiMC(t *testing.T) {
+	t.Parallel()
+
+	h := NewHasher()
+
+	result, err := h.MiMC(fieldElements(1, 2))
+	require.Nil(t, err)
+	require.Len(t, result, fieldElementLength)
+
+	again, err := h.MiMC(fieldElements(1, 2))
+	require.Nil(t, err)
+	require.Equal(t, result, again)
+
+	swapped, err := h.MiMC(fieldElements(2, 1))
+	require.Nil(t, err)
+	require.NotEqual(t, result, swapped)
+
+	prefix, err := h.MiMC(fieldElements(1))
+	require.Nil(t, err)
+	require.NotEqual(t, result, prefix)
+}
+
+func TestHasher_MiMCInvalidInputs(t *testing.T) {
+	t.Parallel()
+
+	h := NewHasher()
+
+	_, err := h.MiMC(nil)
+	require.Equal(t, ErrInvalidNumberOfInputs, err)
+	_, err = h.MiMC(make([][]byte, MaxMiMCInputs+1))
+	require.Equal(t, ErrInvalidNumberOfInputs, err)
+	_, err = h.MiMC([][]byte{encodeFieldElement(bn254ScalarField)})
+	require.Equal(t, ErrInvalidFieldElement, err)
+}
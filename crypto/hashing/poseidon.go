@@ -0,0 +1,237 @@
+package hashing
+
+import (
+	"errors"
+	"math/big"
+	"sync"
+)
+
+// bn254ScalarField is the order of the scalar field of the BN254 curve, the
+// field of the circuits of most zk-SNARK toolchains
+var bn254ScalarField, _ = new(big.Int).SetString("21888242871839275222246405745257275088548364400416034343698204186575808495617", 10)
+
+// fieldElementLength is the length of the big-endian encoding of an element of the BN254 scalar field
+const fieldElementLength = 32
+
+const poseidonFullRounds = 8
+
+// poseidonPartialRounds holds the number of partial rounds of Poseidon for
+// 1 up to 16 inputs, the same as circomlib
+var poseidonPartialRounds = []int{56, 57, 56, 60, 60, 63, 64, 63, 60, 66, 60, 65, 70, 60, 64, 68}
+
+// MaxPoseidonInputs is the largest number of inputs which Poseidon accepts
+var MaxPoseidonInputs = len(poseidonPartialRounds)
+
+// ErrInvalidFieldElement signals that an input is not the encoding of an element of the BN254 scalar field
+var ErrInvalidFieldElement = errors.New("invalid field element")
+
+// ErrInvalidNumberOfInputs signals that a hash does not accept the given number of inputs
+var ErrInvalidNumberOfInputs = errors.New("invalid number of inputs")
+
+type poseidonParameters struct {
+	roundConstants []*big.Int
+	mdsMatrix      [][]*big.Int
+}
+
+var poseidonParametersMutex sync.Mutex
+var poseidonParametersByWidth = make(map[int]*poseidonParameters)
+
+// Poseidon returns the Poseidon hash of the given BN254 scalar field elements,
+// with the x^5 S-box and the parameters of circomlib
+func (h *hasher) Poseidon(inputs [][]byte) ([]byte, error) {
+	if len(inputs) == 0 || len(inputs) > MaxPoseidonInputs {
+		return nil, ErrInvalidNumberOfInputs
+	}
+
+	width := len(inputs) + 1
+	state := make([]*big.Int, width)
+	state[0] = big.NewInt(0)
+	for i, input := range inputs {
+		element, err := decodeFieldElement(input)
+		if err != nil {
+			return nil, err
+		}
+		state[i+1] = element
+	}
+
+	partialRounds := poseidonPartialRounds[len(inputs)-1]
+	params := getPoseidonParameters(width, partialRounds)
+	halfFullRounds := poseidonFullRounds / 2
+	for round := 0; round < poseidonFullRounds+partialRounds; round++ {
+		for i := range state {
+			state[i].Add(state[i], params.roundConstants[round*width+i])
+			state[i].Mod(state[i], bn254ScalarField)
+		}
+
+		isFullRound := round < halfFullRounds || round >= halfFullRounds+partialRounds
+		if isFullRound {
+			for i := range state {
+				sbox(state[i])
+			}
+		} else {
+			sbox(state[0])
+		}
+
+		state = mix(params.mdsMatrix, state)
+	}
+
+	return encodeFieldElement(state[0]), nil
+}
+
+func sbox(element *big.Int) {
+	square := new(big.Int).Mul(element, element)
+	square.Mod(square, bn254ScalarField)
+	fourth := square.Mul(square, square)
+	fourth.Mod(fourth, bn254ScalarField)
+	element.Mul(element, fourth)
+	element.Mod(element, bn254ScalarField)
+}
+
+func mix(matrix [][]*big.Int, state []*big.Int) []*big.Int {
+	product := new(big.Int)
+	newState := make([]*big.Int, len(state))
+	for i := range state {
+		newState[i] = new(big.Int)
+		for j := range state {
+			product.Mul(matrix[i][j], state[j])
+			newState[i].Add(newState[i], product)
+		}
+		newState[i].Mod(newState[i], bn254ScalarField)
+	}
+	return newState
+}
+
+// getPoseidonParameters returns the parameters of the given width, computing
+// them on first use
+func getPoseidonParameters(width int, partialRounds int) *poseidonParameters {
+	poseidonParametersMutex.Lock()
+	defer poseidonParametersMutex.Unlock()
+
+	params, ok := poseidonParametersByWidth[width]
+	if !ok {
+		params = generatePoseidonParameters(width, partialRounds)
+		poseidonParametersByWidth[width] = params
+	}
+	return params
+}
+
+// generatePoseidonParameters derives the round constants and the Cauchy MDS
+// matrix from the Grain LFSR, as the reference implementation of Poseidon does
+func generatePoseidonParameters(width int, partialRounds int) *poseidonParameters {
+	lfsr := newGrainLFSR(width, poseidonFullRounds, partialRounds)
+	fieldBits := bn254ScalarField.BitLen()
+
+	numConstants := (poseidonFullRounds + partialRounds) * width
+	roundConstants := make([]*big.Int, numConstants)
+	for i := range roundConstants {
+		constant := lfsr.nextInt(fieldBits)
+		for constant.Cmp(bn254ScalarField) >= 0 {
+			constant = lfsr.nextInt(fieldBits)
+		}
+		roundConstants[i] = constant
+	}
+
+	xs := make([]*big.Int, 2*width)
+	for i := range xs {
+		xs[i] = lfsr.nextInt(fieldBits)
+		xs[i].Mod(xs[i], bn254ScalarField)
+	}
+
+	mdsMatrix := make([][]*big.Int, width)
+	for i := range mdsMatrix {
+		mdsMatrix[i] = make([]*big.Int, width)
+		for j := range mdsMatrix[i] {
+			sum := new(big.Int).Add(xs[i], xs[width+j])
+			sum.Mod(sum, bn254ScalarField)
+			mdsMatrix[i][j] = sum.ModInverse(sum, bn254ScalarField)
+		}
+	}
+
+	return &poseidonParameters{
+		roundConstants: roundConstants,
+		mdsMatrix:      mdsMatrix,
+	}
+}
+
+const grainStateLength = 80
+
+// grainLFSR is the self-shrinking Grain LFSR with which the reference
+// implementation of Poseidon generates its parameters
+type grainLFSR struct {
+	state    [grainStateLength]byte
+	position int
+}
+
+func newGrainLFSR(width int, fullRounds int, partialRounds int) *grainLFSR {
+	lfsr := &grainLFSR{}
+	initBits := make([]byte, 0, grainStateLength)
+	appendBits := func(value int, numBits int) {
+		for i := numBits - 1; i >= 0; i-- {
+			initBits = append(initBits, byte((value>>uint(i))&1))
+		}
+	}
+
+	// prime field, x^alpha S-box, field size, width, rounds and a padding of ones
+	appendBits(1, 2)
+	appendBits(0, 4)
+	appendBits(bn254ScalarField.BitLen(), 12)
+	appendBits(width, 12)
+	appendBits(fullRounds, 10)
+	appendBits(partialRounds, 10)
+	appendBits(1<<30-1, 30)
+	copy(lfsr.state[:], initBits)
+
+	for i := 0; i < 160; i++ {
+		lfsr.step()
+	}
+	return lfsr
+}
+
+func (lfsr *grainLFSR) bit(index int) byte {
+	return lfsr.state[(lfsr.position+index)%grainStateLength]
+}
+
+func (lfsr *grainLFSR) step() byte {
+	newBit := lfsr.bit(62) ^ lfsr.bit(51) ^ lfsr.bit(38) ^ lfsr.bit(23) ^ lfsr.bit(13) ^ lfsr.bit(0)
+	lfsr.state[lfsr.position] = newBit
+	lfsr.position = (lfsr.position + 1) % grainStateLength
+	return newBit
+}
+
+// nextBit keeps the second bit of the first pair starting with a one
+func (lfsr *grainLFSR) nextBit() byte {
+	for {
+		first := lfsr.step()
+		second := lfsr.step()
+		if first == 1 {
+			return second
+		}
+	}
+}
+
+func (lfsr *grainLFSR) nextInt(numBits int) *big.Int {
+	result := new(big.Int)
+	for i := 0; i < numBits; i++ {
+		result.Lsh(result, 1)
+		result.SetBit(result, 0, uint(lfsr.nextBit()))
+	}
+	return result
+}
+
+func decodeFieldElement(data []byte) (*big.Int, error) {
+	if len(data) != fieldElementLength {
+		return nil, ErrInvalidFieldElement
+	}
+
+	element := new(big.Int).SetBytes(data)
+	if element.Cmp(bn254ScalarField) >= 0 {
+		return nil, ErrInvalidFieldElement
+	}
+	return element, nil
+}
+
+func encodeFieldElement(element *big.Int) []byte {
+	result := make([]byte, fieldElementLength)
+	element.FillBytes(result)
+	return result
+}
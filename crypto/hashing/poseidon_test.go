@@ -0,0 +1,51 @@
+package hashing
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func fieldElements(values ...int64) [][]byte {
+	elements := make([][]byte, len(values))
+	for i, value := range values {
+		elements[i] = encodeFieldElement(big.NewInt(value))
+	}
+	return elements
+}
+
+func TestHasher_PoseidonMatchesCircomlib(t *testing.T) {
+	t.Parallel()
+
+	h := NewHasher()
+
+	result, err := h.Poseidon(fieldElements(1))
+	require.Nil(t, err)
+	require.Equal(t, "18586133768512220936620570745912940619677854269274689475585506675881198879027", new(big.Int).SetBytes(result).String())
+
+	result, err = h.Poseidon(fieldElements(1, 2))
+	require.Nil(t, err)
+	require.Equal(t, "115cc0f5e7d690413df64c6b9662e9cf2a3617f2743245519e19607a4417189a", hex.EncodeToString(result))
+
+	result, err = h.Poseidon(fieldElements(1, 2, 3, 4))
+	require.Nil(t, err)
+	require.Equal(t, "299c867db6c1fdd79dcefa40e4510b9837e60ebb1ce0663dbaa525df65250465", hex.EncodeToString(result))
+}
+
+func TestHasher_PoseidonInvalidInputs(t *testing.T) {
+	t.Parallel()
+
+	h := NewHasher()
+
+	_, err := h.Poseidon(nil)
+	require.Equal(t, ErrInvalidNumberOfInputs, err)
+	_, err = h.Poseidon(make([][]byte, MaxPoseidonInputs+1))
+	require.Equal(t, ErrInvalidNumberOfInputs, err)
+
+	_, err = h.Poseidon([][]byte{{1}})
+	require.Equal(t, ErrInvalidFieldElement, err)
+	_, err = h.Poseidon([][]byte{encodeFieldElement(bn254ScalarField)})
+	require.Equal(t, ErrInvalidFieldElement, err)
+}
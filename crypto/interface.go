@@ -9,20 +9,35 @@ type Hasher interface {
 
 	// Ripemd160 cryptographic function
 	Ripemd160(data []byte) ([]byte, error)
+
+	// Poseidon hash over the BN254 scalar field
+	Poseidon(inputs [][]byte) ([]byte, error)
+
+	// MiMC hash over the BN254 scalar field
+	MiMC(inputs [][]byte) ([]byte, error)
 }
 
 type BLS interface {
 	VerifyBLS(key []byte,  msg []byte, sig []byte) error
+	VerifyBLSAggregated(keys [][]byte, msg []byte, aggregatedSig []byte) error
 }
 
 type Ed25519 interface {
 	VerifyEd25519(key []byte,  msg []byte, sig []byte) error
+	VerifyEd25519Batch(keys [][]byte, msgs [][]byte, sigs [][]byte) error
 }
 
 type Secp256k1 interface {
 	VerifySecp256k1(key []byte,  msg []byte, sig []byte) error
+	Ecrecover(hash []byte, recoveryID []byte, r []byte, s []byte) ([]byte, error)
 }
 
+type EllipticCurves interface {
+	ValidateCurvePoint(curveID int32, point []byte) error
+	CurvePointAdd(curveID int32, point1 []byte, point2 []byte) ([]byte, error)
+	CurveScalarMult(curveID int32, point []byte, scalar []byte) ([]byte, error)
+	CurveScalarBaseMult(curveID int32, scalar []byte) ([]byte, error)
+}
 
 // VMCrypto will provide the interface to the main crypto functionalities of the vm
 type VMCrypto interface {
@@ -30,4 +45,5 @@ type VMCrypto interface {
 	Ed25519
 	BLS
 	Secp256k1
+	EllipticCurves
 }
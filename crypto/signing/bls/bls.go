@@ -4,19 +4,30 @@ import (
 	"github.com/ElrondNetwork/elrond-go/crypto"
 	"github.com/ElrondNetwork/elrond-go/crypto/signing"
 	"github.com/ElrondNetwork/elrond-go/crypto/signing/mcl"
+	"github.com/ElrondNetwork/elrond-go/crypto/signing/mcl/multisig"
 	"github.com/ElrondNetwork/elrond-go/crypto/signing/mcl/singlesig"
+	"github.com/ElrondNetwork/elrond-go/hashing/blake2b"
 )
 
+// multiSigHasherSize is the size of the hashes of the public keys used by the
+// node when aggregating BLS signatures
+const multiSigHasherSize = 16
+
 type bls struct {
+	suite        crypto.Suite
 	keyGenerator crypto.KeyGenerator
 	signer       crypto.SingleSigner
+	multiSigner  crypto.LowLevelSignerBLS
 }
 
 func NewBLS() *bls {
 	b := &bls{}
-	suite := mcl.NewSuiteBLS12()
-	b.keyGenerator = signing.NewKeyGenerator(suite)
+	b.suite = mcl.NewSuiteBLS12()
+	b.keyGenerator = signing.NewKeyGenerator(b.suite)
 	b.signer = singlesig.NewBlsSigner()
+	b.multiSigner = &multisig.BlsMultiSigner{
+		Hasher: &blake2b.Blake2b{HashSize: multiSigHasherSize},
+	}
 
 	return b
 }
@@ -29,3 +40,18 @@ func (b *bls) VerifyBLS(key []byte, msg []byte, sig []byte) error {
 
 	return b.signer.Verify(publicKey, msg, sig)
 }
+
+// VerifyBLSAggregated verifies a signature aggregated by the node from the
+// signatures of the given keys over the same message
+func (b *bls) VerifyBLSAggregated(keys [][]byte, msg []byte, aggregatedSig []byte) error {
+	publicKeys := make([]crypto.PublicKey, len(keys))
+	for i, key := range keys {
+		publicKey, err := b.keyGenerator.PublicKeyFromByteArray(key)
+		if err != nil {
+			return err
+		}
+		publicKeys[i] = publicKey
+	}
+
+	return b.multiSigner.VerifyAggregatedSig(b.suite, publicKeys, aggregatedSig, msg)
+}
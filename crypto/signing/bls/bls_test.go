@@ -5,6 +5,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/ElrondNetwork/elrond-go/crypto"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -20,6 +21,42 @@ func TestBls_VerifyBLS(t *testing.T) {
 	assert.NotNil(t, b.VerifyBLS(splitString(t, checkNOK)))
 }
 
+func TestBls_VerifyBLSAggregated(t *testing.T) {
+	t.Parallel()
+
+	b := NewBLS()
+	message := []byte("message")
+	keys, sigShares, publicKeys := generateSigShares(t, b, 3, message)
+
+	aggregatedSig, err := b.multiSigner.AggregateSignatures(b.suite, sigShares, publicKeys)
+	require.Nil(t, err)
+
+	assert.Nil(t, b.VerifyBLSAggregated(keys, message, aggregatedSig))
+	assert.NotNil(t, b.VerifyBLSAggregated(keys[:2], message, aggregatedSig))
+	assert.NotNil(t, b.VerifyBLSAggregated(keys, []byte("other message"), aggregatedSig))
+	assert.NotNil(t, b.VerifyBLSAggregated(keys, message, sigShares[0]))
+	assert.NotNil(t, b.VerifyBLSAggregated([][]byte{[]byte("invalid key")}, message, aggregatedSig))
+}
+
+func generateSigShares(t testing.TB, b *bls, numSigners int, message []byte) ([][]byte, [][]byte, []crypto.PublicKey) {
+	keys := make([][]byte, numSigners)
+	sigShares := make([][]byte, numSigners)
+	publicKeys := make([]crypto.PublicKey, numSigners)
+	for i := 0; i < numSigners; i++ {
+		privateKey, publicKey := b.keyGenerator.GeneratePair()
+		key, err := publicKey.ToByteArray()
+		require.Nil(t, err)
+
+		sigShares[i], err = b.multiSigner.SignShare(privateKey, message)
+		require.Nil(t, err)
+
+		keys[i] = key
+		publicKeys[i] = publicKey
+	}
+
+	return keys, sigShares, publicKeys
+}
+
 func splitString(t testing.TB, str string) ([]byte, []byte, []byte) {
 	split := strings.Split(str, "@")
 	pkBuff, err := hex.DecodeString(split[0])
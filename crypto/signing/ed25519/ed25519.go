@@ -25,3 +25,20 @@ func (e *ed25519) VerifyEd25519(key []byte, msg []byte, sig []byte) error {
 
 	return nil
 }
+
+// VerifyEd25519Batch verifies that each signature is valid for the message
+// and the key at the same position, failing on the first invalid one
+func (e *ed25519) VerifyEd25519Batch(keys [][]byte, msgs [][]byte, sigs [][]byte) error {
+	if len(keys) != len(msgs) || len(keys) != len(sigs) {
+		return signing.ErrBatchSizeMismatch
+	}
+
+	for i := range keys {
+		err := e.VerifyEd25519(keys[i], msgs[i], sigs[i])
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,42 @@
+package ed25519
+
+import (
+	libed25519 "crypto/ed25519"
+	"testing"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/crypto/signing"
+	"github.com/stretchr/testify/require"
+)
+
+func generateBatch(t *testing.T, size int) ([][]byte, [][]byte, [][]byte) {
+	keys := make([][]byte, size)
+	msgs := make([][]byte, size)
+	sigs := make([][]byte, size)
+	for i := 0; i < size; i++ {
+		publicKey, privateKey, err := libed25519.GenerateKey(nil)
+		require.Nil(t, err)
+
+		keys[i] = publicKey
+		msgs[i] = []byte{byte(i), 1, 2, 3}
+		sigs[i] = libed25519.Sign(privateKey, msgs[i])
+	}
+
+	return keys, msgs, sigs
+}
+
+func TestEd25519_VerifyEd25519Batch(t *testing.T) {
+	t.Parallel()
+
+	e := NewEd25519Signer()
+	keys, msgs, sigs := generateBatch(t, 4)
+	require.Nil(t, e.VerifyEd25519Batch(keys, msgs, sigs))
+	require.Nil(t, e.VerifyEd25519Batch(nil, nil, nil))
+
+	sigs[2], sigs[3] = sigs[3], sigs[2]
+	require.Equal(t, signing.ErrInvalidSignature, e.VerifyEd25519Batch(keys, msgs, sigs))
+
+	require.Equal(t, signing.ErrBatchSizeMismatch, e.VerifyEd25519Batch(keys, msgs[:3], sigs))
+
+	keys[0] = keys[0][:16]
+	require.Equal(t, signing.ErrInvalidPublicKey, e.VerifyEd25519Batch(keys, msgs, sigs))
+}
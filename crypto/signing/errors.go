@@ -9,3 +9,9 @@ var ErrInvalidPublicKey = errors.New("public key is invalid")
 
 // ErrInvalidSignature will be returned when ed25519 signature verification fails
 var ErrInvalidSignature = errors.New("invalid signature")
+
+// ErrInvalidRecoveryID will be returned when the recovery ID of a secp256k1 signature is neither 0 nor 1
+var ErrInvalidRecoveryID = errors.New("invalid recovery ID")
+
+// ErrBatchSizeMismatch will be returned when a batch verification receives different numbers of keys, messages and signatures
+var ErrBatchSizeMismatch = errors.New("batch size mismatch")
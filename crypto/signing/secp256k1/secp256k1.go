@@ -1,11 +1,28 @@
 package secp256k1
 
 import (
+	"math/big"
+
 	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/crypto/signing"
 	"github.com/btcsuite/btcd/btcec"
 	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"golang.org/x/crypto/sha3"
 )
 
+// hashLength is the length of the message hashes accepted by Ecrecover
+const hashLength = 32
+
+// scalarLength is the length of the r and s values of a signature
+const scalarLength = 32
+
+// ethereumAddressLength is the length of an Ethereum address, which is the
+// tail of the Keccak256 hash of the uncompressed public key
+const ethereumAddressLength = 20
+
+// compactSignatureMagic is the offset which btcec expects to be added to the
+// recovery ID in the header of a compact signature
+const compactSignatureMagic = 27
+
 type secp256k1 struct {
 }
 
@@ -33,3 +50,50 @@ func (sec *secp256k1) VerifySecp256k1(key []byte, msg []byte, sig []byte) error
 
 	return nil
 }
+
+// Ecrecover returns the Ethereum address of the key which produced the given
+// signature over the given hash, like the ecrecover precompile of Ethereum.
+// The recovery ID is a single byte, either 0 and 1 or 27 and 28.
+func (sec *secp256k1) Ecrecover(hash []byte, recoveryID []byte, r []byte, s []byte) ([]byte, error) {
+	if len(hash) != hashLength || len(r) != scalarLength || len(s) != scalarLength {
+		return nil, signing.ErrInvalidSignature
+	}
+	if len(recoveryID) != 1 {
+		return nil, signing.ErrInvalidRecoveryID
+	}
+
+	v := recoveryID[0]
+	if v >= compactSignatureMagic {
+		v -= compactSignatureMagic
+	}
+	if v > 1 {
+		return nil, signing.ErrInvalidRecoveryID
+	}
+
+	curveOrder := btcec.S256().Params().N
+	if !isValidScalar(r, curveOrder) || !isValidScalar(s, curveOrder) {
+		return nil, signing.ErrInvalidSignature
+	}
+
+	compactSig := make([]byte, 0, 1+2*scalarLength)
+	compactSig = append(compactSig, compactSignatureMagic+v)
+	compactSig = append(compactSig, r...)
+	compactSig = append(compactSig, s...)
+
+	pubKey, _, err := btcec.RecoverCompact(btcec.S256(), compactSig, hash)
+	if err != nil {
+		return nil, signing.ErrInvalidSignature
+	}
+
+	// the leading byte of the uncompressed key only marks its format
+	keccak := sha3.NewLegacyKeccak256()
+	_, _ = keccak.Write(pubKey.SerializeUncompressed()[1:])
+	keyHash := keccak.Sum(nil)
+
+	return keyHash[len(keyHash)-ethereumAddressLength:], nil
+}
+
+func isValidScalar(scalar []byte, curveOrder *big.Int) bool {
+	value := new(big.Int).SetBytes(scalar)
+	return value.Sign() > 0 && value.Cmp(curveOrder) < 0
+}
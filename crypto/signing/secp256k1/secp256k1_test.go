@@ -0,0 +1,67 @@
+package secp256k1
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/crypto/signing"
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/stretchr/testify/require"
+)
+
+// the Ethereum address of the private key 1
+const addressOfPrivateKeyOne = "7e5f4552091a69125d5dfcb7b8c2659029395bdf"
+
+func signCompact(t *testing.T, hash []byte) ([]byte, []byte, []byte) {
+	privateKey, _ := btcec.PrivKeyFromBytes(btcec.S256(), []byte{1})
+	sig, err := btcec.SignCompact(btcec.S256(), privateKey, hash, false)
+	require.Nil(t, err)
+
+	return []byte{sig[0]}, sig[1:33], sig[33:]
+}
+
+func TestSecp256k1_Ecrecover(t *testing.T) {
+	t.Parallel()
+
+	sec := NewSecp256k1()
+	hash := make([]byte, 32)
+	hash[31] = 42
+	v, r, s := signCompact(t, hash)
+
+	address, err := sec.Ecrecover(hash, v, r, s)
+	require.Nil(t, err)
+	require.Equal(t, addressOfPrivateKeyOne, hex.EncodeToString(address))
+
+	address, err = sec.Ecrecover(hash, []byte{v[0] - 27}, r, s)
+	require.Nil(t, err)
+	require.Equal(t, addressOfPrivateKeyOne, hex.EncodeToString(address))
+
+	otherHash := make([]byte, 32)
+	address, err = sec.Ecrecover(otherHash, v, r, s)
+	require.Nil(t, err)
+	require.NotEqual(t, addressOfPrivateKeyOne, hex.EncodeToString(address))
+}
+
+func TestSecp256k1_EcrecoverMalformed(t *testing.T) {
+	t.Parallel()
+
+	sec := NewSecp256k1()
+	hash := make([]byte, 32)
+	v, r, s := signCompact(t, hash)
+
+	_, err := sec.Ecrecover(hash, []byte{2}, r, s)
+	require.Equal(t, signing.ErrInvalidRecoveryID, err)
+	_, err = sec.Ecrecover(hash, []byte{29}, r, s)
+	require.Equal(t, signing.ErrInvalidRecoveryID, err)
+	_, err = sec.Ecrecover(hash, nil, r, s)
+	require.Equal(t, signing.ErrInvalidRecoveryID, err)
+
+	_, err = sec.Ecrecover(hash[1:], v, r, s)
+	require.Equal(t, signing.ErrInvalidSignature, err)
+	_, err = sec.Ecrecover(hash, v, make([]byte, 32), s)
+	require.Equal(t, signing.ErrInvalidSignature, err)
+
+	curveOrder := btcec.S256().Params().N.Bytes()
+	_, err = sec.Ecrecover(hash, v, r, curveOrder)
+	require.Equal(t, signing.ErrInvalidSignature, err)
+}
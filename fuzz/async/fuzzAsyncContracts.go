@@ -0,0 +1,127 @@
+package async
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
+	mock "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/context"
+)
+
+// fuzzAsyncFunction is the function of the mock contracts which executes a
+// call of a topology, given as its only argument
+const fuzzAsyncFunction = "fuzzAsync"
+
+func callbackName(callID int) string {
+	return fmt.Sprintf("fuzzCallBack%d", callID)
+}
+
+// completedCallKey is the storage key which marks the successful completion
+// of a call, in the storage of its destination
+func completedCallKey(callID int) []byte {
+	return []byte(fmt.Sprintf("completed%d", callID))
+}
+
+// addFuzzAsyncMethod adds to the mock contract the function which executes a
+// call of a topology: it uses gas, registers the async calls of the node and
+// fails, as planned
+func (pfe *fuzzAsyncExecutor) addFuzzAsyncMethod(instanceMock *mock.InstanceMock) {
+	instanceMock.AddMockMethod(fuzzAsyncFunction, func() *mock.InstanceMock {
+		host := instanceMock.Host
+		instance := mock.GetMockInstance(host)
+
+		arguments := host.Runtime().Arguments()
+		if len(arguments) != 1 {
+			host.Runtime().SignalUserError("wrong number of arguments")
+			return instance
+		}
+		node := &asyncCallNode{}
+		err := json.Unmarshal(arguments[0], node)
+		if err != nil {
+			host.Runtime().SignalUserError(err.Error())
+			return instance
+		}
+
+		pfe.calls[node.ID]++
+		if !useGas(host, node.GasUsed) {
+			return instance
+		}
+
+		for _, call := range node.Calls {
+			callData, err := json.Marshal(call)
+			if err != nil {
+				host.Runtime().SignalUserError(err.Error())
+				return instance
+			}
+
+			// like the async call API, the gas of the callback is locked first
+			gasLocked, err := host.Metering().EscrowGasForCallback(true)
+			if err != nil {
+				host.Runtime().FailExecution(err)
+				return instance
+			}
+
+			err = host.Runtime().AddAsyncContextCall([]byte(call.Group), &arwen.AsyncGeneratedCall{
+				Destination:     call.Destination,
+				Data:            arwen.EncodeCallData(fuzzAsyncFunction, [][]byte{callData}),
+				ValueBytes:      big.NewInt(call.Value).Bytes(),
+				SuccessCallback: callbackName(call.ID),
+				ErrorCallback:   callbackName(call.ID),
+				ProvidedGas:     call.ProvidedGas,
+				GasLocked:       gasLocked,
+			})
+			if err != nil {
+				host.Metering().RestoreLockedGas(gasLocked)
+				host.Runtime().FailExecution(err)
+				return instance
+			}
+		}
+
+		if node.Fail {
+			host.Runtime().SignalUserError(fmt.Sprintf("call %d failed", node.ID))
+			return instance
+		}
+
+		// the marker is rolled back along with the call, if it fails later
+		_, err = host.Storage().SetStorage(completedCallKey(node.ID), []byte{1})
+		if err != nil {
+			host.Runtime().FailExecution(err)
+			return instance
+		}
+
+		host.Output().Finish(big.NewInt(int64(node.ID)).Bytes())
+		return instance
+	})
+}
+
+// addCallbackMethod adds to the mock contract of the caller the callback of
+// the given call, which uses gas and fails, as planned
+func (pfe *fuzzAsyncExecutor) addCallbackMethod(instanceMock *mock.InstanceMock, node *asyncCallNode) {
+	instanceMock.AddMockMethod(callbackName(node.ID), func() *mock.InstanceMock {
+		host := instanceMock.Host
+		instance := mock.GetMockInstance(host)
+
+		pfe.callbacks[node.ID]++
+		if !useGas(host, node.GasUsedByCallback) {
+			return instance
+		}
+
+		if node.FailCallback {
+			host.Runtime().SignalUserError(fmt.Sprintf("callback of call %d failed", node.ID))
+		}
+		return instance
+	})
+}
+
+// useGas charges the gas used by a mock contract, failing the execution
+// instead if there is not enough gas left
+func useGas(host arwen.VMHost, gas uint64) bool {
+	if gas > host.Metering().GasLeft() {
+		host.Runtime().FailExecution(arwen.ErrNotEnoughGas)
+		return false
+	}
+
+	host.Metering().UseGas(gas)
+	return true
+}
@@ -0,0 +1,232 @@
+package async
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"math/rand"
+	"strconv"
+	"testing"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
+	am "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwenmandos"
+	mj "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mandos-go/json/model"
+	mjparse "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mandos-go/json/parse"
+	scenario "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mandos-go/json/scenario"
+	mock "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/context"
+	worldhook "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/world"
+	test "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/testcommon"
+	vmi "github.com/ElrondNetwork/elrond-go/core/vmcommon"
+)
+
+const numShards = 2
+
+const initialBalance = 1000000
+
+const minTopologyGas = 10000
+
+const maxTopologyGas = 3000000
+
+// fuzzContract is a mock contract called by the generated topologies
+type fuzzContract struct {
+	address  []byte
+	shardID  uint32
+	instance *mock.InstanceMock
+}
+
+type fuzzAsyncExecutor struct {
+	arwenTestExecutor *am.ArwenTestExecutor
+	world             *worldhook.ChainSimulator
+	host              arwen.VMHost
+	mandosParser      mjparse.Parser
+	txIndex           int
+	callIndex         int
+	userAddress       []byte
+	contracts         []*fuzzContract
+	totalBalance      *big.Int
+	txHooks           []am.StepHook
+
+	// the last executed topology and what happened to it
+	topology    *asyncCallNode
+	gasLimit    uint64
+	rootOutput  *vmi.VMOutput
+	gasRefunded uint64
+	calls       map[int]int
+	callbacks   map[int]int
+}
+
+func newFuzzAsyncExecutor(t testing.TB, numContractsPerShard int) (*fuzzAsyncExecutor, error) {
+	arwenTestExecutor, err := am.NewArwenTestExecutor()
+	if err != nil {
+		return nil, err
+	}
+
+	host := arwenTestExecutor.GetVM().(arwen.VMHost)
+	host.Metering().EnableAudit()
+	instanceBuilder := mock.NewInstanceBuilderMock(arwenTestExecutor.World)
+	host.Runtime().ReplaceInstanceBuilder(instanceBuilder)
+
+	pfe := &fuzzAsyncExecutor{
+		arwenTestExecutor: arwenTestExecutor,
+		world:             worldhook.NewChainSimulatorWithWorld(arwenTestExecutor.World, numShards),
+		host:              host,
+		mandosParser:      mjparse.NewParser(nil),
+		userAddress:       test.MakeTestSCAddress("asyncFuzzUser"),
+		totalBalance:      big.NewInt(0),
+		calls:             make(map[int]int),
+		callbacks:         make(map[int]int),
+	}
+
+	user := pfe.world.CreateUserAccount(pfe.userAddress, big.NewInt(initialBalance))
+	user.ShardID = 0
+
+	for shardID := uint32(0); shardID < numShards; shardID++ {
+		for i := 0; i < numContractsPerShard; i++ {
+			address := test.MakeTestSCAddress(fmt.Sprintf("asyncFuzz%d_%d", shardID, i))
+			instance := instanceBuilder.CreateAndStoreInstanceMock(t, host, address, shardID, initialBalance)
+			pfe.addFuzzAsyncMethod(instance)
+			pfe.contracts = append(pfe.contracts, &fuzzContract{
+				address:  address,
+				shardID:  shardID,
+				instance: instance,
+			})
+		}
+	}
+
+	pfe.totalBalance = pfe.getTotalBalance()
+	return pfe, nil
+}
+
+// executeTopology generates a random topology of async calls and executes it,
+// delivering all the async calls and callbacks sent across shards
+func (pfe *fuzzAsyncExecutor) executeTopology(r *rand.Rand, config *topologyConfig) error {
+	root := pfe.contracts[r.Intn(len(pfe.contracts))]
+	gasLimit := uint64(r.Int63n(maxTopologyGas-minTopologyGas) + minTopologyGas)
+	return pfe.executeCalls(pfe.generateTopology(r, config, root, gasLimit))
+}
+
+// executeCalls executes the given topology, starting with the call of the
+// root contract by the user
+func (pfe *fuzzAsyncExecutor) executeCalls(topology *asyncCallNode) error {
+	pfe.topology = topology
+	pfe.gasLimit = topology.ProvidedGas
+	pfe.calls = make(map[int]int)
+	pfe.callbacks = make(map[int]int)
+	pfe.gasRefunded = 0
+
+	// the callbacks are functions of the callers
+	walkTopology(pfe.topology, nil, func(node *asyncCallNode, caller *asyncCallNode) {
+		if caller != nil {
+			pfe.addCallbackMethod(pfe.getContract(caller.Destination).instance, node)
+		}
+	})
+
+	rootArgument, err := json.Marshal(pfe.topology)
+	if err != nil {
+		return err
+	}
+
+	output, err := pfe.executeTx(scenario.NewSCCall().
+		TxID(strconv.Itoa(pfe.nextTxIndex())).
+		From(addressExpr(pfe.userAddress)).
+		To(addressExpr(topology.Destination)).
+		Value(strconv.FormatInt(pfe.topology.Value, 10)).
+		Function(fuzzAsyncFunction).
+		Arguments("0x" + hex.EncodeToString(rootArgument)).
+		GasLimit(pfe.gasLimit).
+		GasPrice(0))
+	if err != nil {
+		return err
+	}
+	pfe.rootOutput = output
+	pfe.gasRefunded = output.GasRemaining
+
+	for pfe.arwenTestExecutor.NumPendingCrossShardCalls(vmi.AsynchronousCall) > 0 {
+		callbackStep := &mj.CrossShardCallbackStep{
+			TxIdent: strconv.Itoa(pfe.nextTxIndex()),
+		}
+		output, err = pfe.arwenTestExecutor.ExecuteCrossShardCallbackStep(callbackStep)
+		if err != nil {
+			return err
+		}
+		pfe.gasRefunded += output.GasRemaining
+
+		err = pfe.runTxHooks(callbackStep, output)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (pfe *fuzzAsyncExecutor) executeTx(txBuilder *scenario.TxBuilder) (*vmi.VMOutput, error) {
+	txStep, err := txBuilder.Build(pfe.mandosParser.ExprInterpreter)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := pfe.arwenTestExecutor.ExecuteTxStep(txStep)
+	if err != nil {
+		return nil, err
+	}
+
+	return output, pfe.runTxHooks(txStep, output)
+}
+
+func (pfe *fuzzAsyncExecutor) runTxHooks(step mj.Step, output *vmi.VMOutput) error {
+	for _, hook := range pfe.txHooks {
+		err := hook(pfe.arwenTestExecutor.NewStepHookContext(step, output))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (pfe *fuzzAsyncExecutor) addTxHook(hook am.StepHook) {
+	pfe.txHooks = append(pfe.txHooks, hook)
+}
+
+func (pfe *fuzzAsyncExecutor) getContract(address []byte) *fuzzContract {
+	for _, contract := range pfe.contracts {
+		if string(contract.address) == string(address) {
+			return contract
+		}
+	}
+	return nil
+}
+
+// isCallCompleted returns true if the given call completed successfully,
+// and was not rolled back afterwards
+func (pfe *fuzzAsyncExecutor) isCallCompleted(node *asyncCallNode) bool {
+	account := pfe.world.AcctMap.GetAccount(node.Destination)
+	return len(account.Storage[string(completedCallKey(node.ID))]) > 0
+}
+
+func (pfe *fuzzAsyncExecutor) getTotalBalance() *big.Int {
+	totalBalance := big.NewInt(0)
+	for _, account := range pfe.world.AcctMap {
+		totalBalance.Add(totalBalance, account.Balance)
+	}
+	return totalBalance
+}
+
+func (pfe *fuzzAsyncExecutor) nextTxIndex() int {
+	pfe.txIndex++
+	return pfe.txIndex
+}
+
+func (pfe *fuzzAsyncExecutor) nextCallID() int {
+	pfe.callIndex++
+	return pfe.callIndex
+}
+
+func (pfe *fuzzAsyncExecutor) log(info string, args ...interface{}) {
+	fmt.Printf(info+"\n", args...)
+}
+
+func addressExpr(address []byte) string {
+	return "0x" + hex.EncodeToString(address)
+}
@@ -0,0 +1,83 @@
+package async
+
+import (
+	"fmt"
+
+	vmi "github.com/ElrondNetwork/elrond-go/core/vmcommon"
+)
+
+// checkGasConservation verifies that the async calls neither create gas nor
+// spend it twice: the gas given to the calls sent across shards and the gas
+// refunded by the root call and the callbacks never exceed the gas limit
+func (pfe *fuzzAsyncExecutor) checkGasConservation() error {
+	gasSent := pfe.rootOutput.GasRemaining
+	for _, outputAccount := range pfe.rootOutput.OutputAccounts {
+		for _, transfer := range outputAccount.OutputTransfers {
+			gasSent += transfer.GasLimit + transfer.GasLocked
+		}
+	}
+	if gasSent > pfe.gasLimit {
+		return fmt.Errorf("root call of topology %d passed on %d gas out of a gas limit of %d",
+			pfe.topology.ID, gasSent, pfe.gasLimit)
+	}
+
+	if pfe.gasRefunded > pfe.gasLimit {
+		return fmt.Errorf("topology %d refunded %d gas out of a gas limit of %d",
+			pfe.topology.ID, pfe.gasRefunded, pfe.gasLimit)
+	}
+
+	return nil
+}
+
+// checkCallbacksExactlyOnce verifies that each async call and each callback
+// is executed at most once, and that every async call registered by a call
+// which completed successfully gets exactly one callback, whether it failed
+// or not. The executions rolled back along with a failed caller are only
+// counted towards the former.
+func (pfe *fuzzAsyncExecutor) checkCallbacksExactlyOnce() error {
+	var err error
+	walkTopology(pfe.topology, nil, func(node *asyncCallNode, caller *asyncCallNode) {
+		if err != nil {
+			return
+		}
+
+		numCalls := pfe.calls[node.ID]
+		if numCalls > 1 {
+			err = fmt.Errorf("call %d executed %d times", node.ID, numCalls)
+			return
+		}
+		if caller == nil {
+			return
+		}
+
+		numCallbacks := pfe.callbacks[node.ID]
+		switch {
+		case numCallbacks > 1:
+			err = fmt.Errorf("callback of call %d executed %d times", node.ID, numCallbacks)
+		case numCallbacks == 1 && pfe.calls[caller.ID] == 0:
+			err = fmt.Errorf("callback of call %d executed, but its caller %d was not", node.ID, caller.ID)
+		case numCallbacks == 0 && pfe.isCallCompleted(caller):
+			err = fmt.Errorf("callback of call %d not executed, but its caller %d completed", node.ID, caller.ID)
+		}
+	})
+
+	return err
+}
+
+// checkNoLostValue verifies that the value sent by the async calls reaches
+// its destination or returns to the caller, once all of them were delivered
+func (pfe *fuzzAsyncExecutor) checkNoLostValue() error {
+	numPendingCallbacks := pfe.arwenTestExecutor.NumPendingCrossShardCalls(vmi.AsynchronousCallBack)
+	if numPendingCallbacks > 0 {
+		return fmt.Errorf("%d cross-shard callbacks of topology %d never delivered",
+			numPendingCallbacks, pfe.topology.ID)
+	}
+
+	totalBalance := pfe.getTotalBalance()
+	if totalBalance.Cmp(pfe.totalBalance) != 0 {
+		return fmt.Errorf("total balance changed from %d to %d by topology %d",
+			pfe.totalBalance, totalBalance, pfe.topology.ID)
+	}
+
+	return nil
+}
@@ -0,0 +1,150 @@
+package async
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// asyncCallNode is a call of a random async call topology: the behaviour of
+// the mock contract at its destination, the async calls it registers in turn,
+// and the behaviour of the callback executed back at its caller
+type asyncCallNode struct {
+	ID                int              `json:"id"`
+	Destination       []byte           `json:"destination"`
+	Value             int64            `json:"value"`
+	ProvidedGas       uint64           `json:"providedGas"`
+	Group             string           `json:"group"`
+	GasUsed           uint64           `json:"gasUsed"`
+	Fail              bool             `json:"fail"`
+	GasUsedByCallback uint64           `json:"gasUsedByCallback"`
+	FailCallback      bool             `json:"failCallback"`
+	Calls             []*asyncCallNode `json:"calls"`
+}
+
+// topologyConfig holds the odds of the features of the generated topologies
+type topologyConfig struct {
+	maxDepth                int
+	maxCallsPerContract     int
+	crossShardProbability   float32
+	zeroGasProbability      float32
+	failProbability         float32
+	failCallbackProbability float32
+
+	// knownIssues allows the topologies which reach the known defects of the
+	// async calls, which the fuzzer would otherwise report on every campaign:
+	// - a failing cross-shard callback rolls back the update of the pending
+	//   calls, so a caller that was itself called asynchronously never sends
+	//   its callback;
+	// - a same-shard call left pending by its own cross-shard calls is
+	//   answered through the generic callBack, not through the callback
+	//   registered by its caller, and can make the root spend more gas than
+	//   it received;
+	// - a contract which makes async calls and is called again by the same
+	//   transaction loses the calls it left pending
+	knownIssues bool
+}
+
+const maxGasUsedByContract = 5000
+
+const maxCallValue = 100
+
+// generateTopology creates a random tree of async calls, rooted in the call
+// of the given contract by the user
+func (pfe *fuzzAsyncExecutor) generateTopology(r *rand.Rand, config *topologyConfig, root *fuzzContract, gasLimit uint64) *asyncCallNode {
+	rootNode := &asyncCallNode{
+		ID:          pfe.nextCallID(),
+		Destination: root.address,
+		Value:       r.Int63n(maxCallValue),
+		ProvidedGas: gasLimit,
+		GasUsed:     uint64(r.Int63n(maxGasUsedByContract)),
+		Fail:        r.Float32() < config.failProbability,
+	}
+	reserved := map[*fuzzContract]bool{root: true}
+	pfe.generateCalls(r, config, rootNode, root, 1, false, reserved)
+	return rootNode
+}
+
+// generateCalls adds random async calls to the given node. Unless the known
+// issues are allowed, the calls of a node reached through a same-shard async
+// call stay in its shard, and the reserved contracts, which make async calls
+// of their own, are called only once.
+func (pfe *fuzzAsyncExecutor) generateCalls(
+	r *rand.Rand,
+	config *topologyConfig,
+	node *asyncCallNode,
+	caller *fuzzContract,
+	depth int,
+	calledInShard bool,
+	reserved map[*fuzzContract]bool,
+) {
+	if depth > config.maxDepth {
+		return
+	}
+
+	numCalls := r.Intn(config.maxCallsPerContract + 1)
+	for i := 0; i < numCalls; i++ {
+		crossShard := r.Float32() < config.crossShardProbability
+		if calledInShard && !config.knownIssues {
+			crossShard = false
+		}
+		destination := pfe.randomContract(r, caller, crossShard, func(contract *fuzzContract) bool {
+			return contract != caller && (config.knownIssues || !reserved[contract])
+		})
+		if destination == nil {
+			continue
+		}
+
+		providedGas := uint64(0)
+		if r.Float32() >= config.zeroGasProbability {
+			providedGas = node.ProvidedGas / uint64(2*numCalls+2)
+		}
+
+		call := &asyncCallNode{
+			ID:                pfe.nextCallID(),
+			Destination:       destination.address,
+			Value:             r.Int63n(maxCallValue),
+			ProvidedGas:       providedGas,
+			Group:             fmt.Sprintf("group%d", r.Intn(2)),
+			GasUsed:           uint64(r.Int63n(maxGasUsedByContract)),
+			Fail:              r.Float32() < config.failProbability,
+			GasUsedByCallback: uint64(r.Int63n(maxGasUsedByContract)),
+			FailCallback:      r.Float32() < config.failCallbackProbability,
+		}
+		if crossShard && depth > 1 && !config.knownIssues {
+			call.FailCallback = false
+		}
+		if !reserved[destination] {
+			reserved[destination] = true
+			pfe.generateCalls(r, config, call, destination, depth+1, !crossShard, reserved)
+		}
+		node.Calls = append(node.Calls, call)
+	}
+}
+
+// randomContract picks an allowed contract from the shard of the caller or
+// from another shard, or returns nil if there is none
+func (pfe *fuzzAsyncExecutor) randomContract(r *rand.Rand, caller *fuzzContract, crossShard bool, allowed func(*fuzzContract) bool) *fuzzContract {
+	candidates := make([]*fuzzContract, 0, len(pfe.contracts))
+	for _, contract := range pfe.contracts {
+		if !allowed(contract) {
+			continue
+		}
+		if (contract.shardID != caller.shardID) == crossShard {
+			candidates = append(candidates, contract)
+		}
+	}
+
+	if len(candidates) == 0 {
+		return nil
+	}
+	return candidates[r.Intn(len(candidates))]
+}
+
+// walkTopology calls visit for each call of the topology, along with its caller,
+// which is nil for the root
+func walkTopology(node *asyncCallNode, caller *asyncCallNode, visit func(node *asyncCallNode, caller *asyncCallNode)) {
+	visit(node, caller)
+	for _, call := range node.Calls {
+		walkTopology(call, node, visit)
+	}
+}
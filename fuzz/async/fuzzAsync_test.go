@@ -0,0 +1,166 @@
+package async
+
+import (
+	"flag"
+	"math/rand"
+	"path/filepath"
+	"testing"
+	"time"
+
+	fuzzcore "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/fuzz/core"
+	fuzzutil "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/fuzz/util"
+	"github.com/stretchr/testify/require"
+)
+
+var fuzz = flag.Bool("fuzz", false, "Enable fuzz test")
+
+var seedFlag = flag.Int64("seed", 0, "Random seed, use it to replay fuzz scenarios")
+
+var iterationsFlag = flag.Int("iterations", 1000, "Number of iterations")
+
+var coverageFlag = flag.Bool("coverage", false, "Favour the events that reach new contract behaviour, instead of fixed probabilities")
+
+var replayFileFlag = flag.String("replayFile", "fuzz_failure.replay.json", "File to save the events to, if the fuzz campaign fails")
+
+var replayFlag = flag.String("replay", "", "Replay file to execute again, instead of the ones in the replays folder")
+
+var knownIssuesFlag = flag.Bool("knownIssues", false, "Also generate the async call topologies which reach the known defects")
+
+// replaysPattern matches the replays of past failures, which are executed as regression tests.
+const replaysPattern = "replays/*.replay.json"
+
+const numContractsPerShard = 3
+
+func TestFuzzAsync(t *testing.T) {
+	if !*fuzz {
+		t.Skip("skipping test; only run with --fuzz argument")
+	}
+
+	pfe, err := newFuzzAsyncExecutor(t, numContractsPerShard)
+	require.Nil(t, err)
+
+	var seed int64
+	if *seedFlag == 0 {
+		seed = time.Now().UnixNano()
+	} else {
+		seed = *seedFlag
+	}
+	pfe.log("Random seed: %d\n", seed)
+	campaign := fuzzutil.NewCampaign(seed)
+	defer func() {
+		pfe.log(campaign.Report())
+	}()
+
+	harness := newFuzzHarness(t, pfe, campaign)
+	harness.SetReplayPath(*replayFileFlag)
+	if *coverageFlag {
+		coverage := fuzzcore.NewCoverageTracker()
+		pfe.addTxHook(fuzzcore.NewVMFeedbackHook(coverage))
+		harness.SetCoverageGuidance(coverage)
+		defer func() {
+			pfe.log("Coverage: %d distinct features reached\n", coverage.Len())
+		}()
+	}
+
+	err = harness.Run(*iterationsFlag)
+	require.Nil(t, err)
+}
+
+// TestFuzzAsync_Replays executes again the events of failed fuzz campaigns,
+// saved in the replays folder, or the replay given with the -replay argument.
+func TestFuzzAsync_Replays(t *testing.T) {
+	replayPaths, err := filepath.Glob(replaysPattern)
+	require.Nil(t, err)
+	if *replayFlag != "" {
+		replayPaths = []string{*replayFlag}
+	}
+	if len(replayPaths) == 0 {
+		t.Skip("no fuzz replays")
+	}
+
+	for _, replayPath := range replayPaths {
+		replayPath := replayPath
+		t.Run(filepath.Base(replayPath), func(t *testing.T) {
+			replay, err := fuzzcore.LoadReplay(replayPath)
+			require.Nil(t, err)
+
+			pfe, err := newFuzzAsyncExecutor(t, numContractsPerShard)
+			require.Nil(t, err)
+			harness := newFuzzHarness(t, pfe, fuzzutil.NewCampaign(replay.Seed))
+			err = harness.Replay(replay)
+			require.Nil(t, err, "replayed failure: %s", replay.Failure)
+		})
+	}
+}
+
+func newFuzzHarness(t *testing.T, pfe *fuzzAsyncExecutor, campaign *fuzzutil.Campaign) *fuzzcore.Harness {
+	harness := fuzzcore.NewHarness(campaign)
+	err := registerFuzzEvents(harness, pfe)
+	require.Nil(t, err)
+	harness.AddInvariant(fuzzcore.NewInvariant("gasConservation", pfe.checkGasConservation))
+	harness.AddInvariant(fuzzcore.NewInvariant("callbacksExactlyOnce", pfe.checkCallbacksExactlyOnce))
+	harness.AddInvariant(fuzzcore.NewInvariant("noLostValue", pfe.checkNoLostValue))
+	return harness
+}
+
+// registerFuzzEvents adds the async call topologies to the harness.
+// The order of the events matters, since it determines the events generated from a seed.
+func registerFuzzEvents(harness *fuzzcore.Harness, pfe *fuzzAsyncExecutor) error {
+	topologyEvent := func(name string, probability float32, config *topologyConfig) fuzzcore.EventGenerator {
+		config.knownIssues = *knownIssuesFlag
+		return fuzzcore.NewEvent(name, probability, func(r *rand.Rand) (bool, error) {
+			return true, pfe.executeTopology(r, config)
+		})
+	}
+
+	events := []fuzzcore.EventGenerator{
+		topologyEvent("sameShardCalls", 0.2, &topologyConfig{
+			maxDepth:                2,
+			maxCallsPerContract:     3,
+			crossShardProbability:   0,
+			zeroGasProbability:      0.1,
+			failProbability:         0.1,
+			failCallbackProbability: 0.1,
+		}),
+		topologyEvent("crossShardCalls", 0.2, &topologyConfig{
+			maxDepth:                2,
+			maxCallsPerContract:     3,
+			crossShardProbability:   1,
+			zeroGasProbability:      0.1,
+			failProbability:         0.1,
+			failCallbackProbability: 0.1,
+		}),
+		topologyEvent("nestedCalls", 0.2, &topologyConfig{
+			maxDepth:                4,
+			maxCallsPerContract:     2,
+			crossShardProbability:   0.5,
+			zeroGasProbability:      0.1,
+			failProbability:         0.1,
+			failCallbackProbability: 0.1,
+		}),
+		topologyEvent("failingCallbacks", 0.15, &topologyConfig{
+			maxDepth:                3,
+			maxCallsPerContract:     2,
+			crossShardProbability:   0.5,
+			zeroGasProbability:      0.1,
+			failProbability:         0.4,
+			failCallbackProbability: 0.6,
+		}),
+		topologyEvent("zeroGasCalls", 0.15, &topologyConfig{
+			maxDepth:                3,
+			maxCallsPerContract:     3,
+			crossShardProbability:   0.5,
+			zeroGasProbability:      0.8,
+			failProbability:         0.1,
+			failCallbackProbability: 0.1,
+		}),
+	}
+
+	for _, event := range events {
+		err := harness.AddEvent(event)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package fuzzcore
+
+import (
+	"math/rand"
+)
+
+// EventFunc executes a fuzz event, see EventGenerator.Generate.
+type EventFunc func(r *rand.Rand) (checkInvariants bool, err error)
+
+type funcEvent struct {
+	name        string
+	probability float32
+	generate    EventFunc
+}
+
+// NewEvent creates an EventGenerator from a function.
+func NewEvent(name string, probability float32, generate EventFunc) EventGenerator {
+	return &funcEvent{
+		name:        name,
+		probability: probability,
+		generate:    generate,
+	}
+}
+
+func (fe *funcEvent) EventName() string {
+	return fe.name
+}
+
+func (fe *funcEvent) Probability() float32 {
+	return fe.probability
+}
+
+func (fe *funcEvent) Generate(r *rand.Rand) (bool, error) {
+	return fe.generate(r)
+}
+
+type funcInvariant struct {
+	name  string
+	check func() error
+}
+
+// NewInvariant creates an Invariant from a function.
+func NewInvariant(name string, check func() error) Invariant {
+	return &funcInvariant{
+		name:  name,
+		check: check,
+	}
+}
+
+func (fi *funcInvariant) InvariantName() string {
+	return fi.name
+}
+
+func (fi *funcInvariant) Check() error {
+	return fi.check()
+}
+
+// StateObserverFunc adapts a function to the StateObserver interface.
+type StateObserverFunc func(stepIndex int, eventName string) error
+
+// ObserveState calls the function.
+func (f StateObserverFunc) ObserveState(stepIndex int, eventName string) error {
+	return f(stepIndex, eventName)
+}
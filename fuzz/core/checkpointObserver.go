@@ -0,0 +1,41 @@
+package fuzzcore
+
+import (
+	fuzzutil "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/fuzz/util"
+)
+
+// ExecutorStateProvider yields the state of the fuzz executor and the scenario generated so far,
+// to be saved in a checkpoint.
+type ExecutorStateProvider func() (executorState interface{}, scenario string)
+
+type checkpointObserver struct {
+	campaign      *fuzzutil.Campaign
+	path          string
+	interval      int
+	stateProvider ExecutorStateProvider
+}
+
+// NewCheckpointObserver creates a StateObserver that saves a checkpoint of the campaign
+// at the given path, every interval steps.
+func NewCheckpointObserver(
+	campaign *fuzzutil.Campaign,
+	path string,
+	interval int,
+	stateProvider ExecutorStateProvider,
+) StateObserver {
+	return &checkpointObserver{
+		campaign:      campaign,
+		path:          path,
+		interval:      interval,
+		stateProvider: stateProvider,
+	}
+}
+
+func (co *checkpointObserver) ObserveState(stepIndex int, _ string) error {
+	if co.interval <= 0 || stepIndex%co.interval != 0 {
+		return nil
+	}
+
+	executorState, scenario := co.stateProvider()
+	return co.campaign.SaveCheckpoint(co.path, executorState, scenario)
+}
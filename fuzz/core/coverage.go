@@ -0,0 +1,47 @@
+package fuzzcore
+
+// CoverageTracker collects the distinct behaviours reached by the fuzzed contracts,
+// as feature strings, e.g. the functions called or the return codes seen.
+type CoverageTracker struct {
+	features    map[string]struct{}
+	newFeatures int
+}
+
+// NewCoverageTracker creates an empty CoverageTracker.
+func NewCoverageTracker() *CoverageTracker {
+	return &CoverageTracker{
+		features:    make(map[string]struct{}),
+		newFeatures: 0,
+	}
+}
+
+// AddFeature records a feature, returning true if it was not reached before.
+func (ct *CoverageTracker) AddFeature(feature string) bool {
+	_, found := ct.features[feature]
+	if found {
+		return false
+	}
+
+	ct.features[feature] = struct{}{}
+	ct.newFeatures++
+	return true
+}
+
+// HasFeature returns true if the feature was reached.
+func (ct *CoverageTracker) HasFeature(feature string) bool {
+	_, found := ct.features[feature]
+	return found
+}
+
+// Len returns the number of distinct features reached.
+func (ct *CoverageTracker) Len() int {
+	return len(ct.features)
+}
+
+// TakeNewFeatures returns the number of features reached for the first time
+// since the previous call.
+func (ct *CoverageTracker) TakeNewFeatures() int {
+	newFeatures := ct.newFeatures
+	ct.newFeatures = 0
+	return newFeatures
+}
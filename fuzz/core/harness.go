@@ -0,0 +1,297 @@
+package fuzzcore
+
+import (
+	"errors"
+	"fmt"
+
+	fuzzutil "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/fuzz/util"
+)
+
+// NoEventName is counted when none of the events was picked at a step.
+const NoEventName = "none"
+
+// ErrProbabilitiesExceedOne signals that the probabilities of the events of a harness add up to more than 1.
+var ErrProbabilitiesExceedOne = errors.New("event probabilities exceed 1")
+
+// Harness runs a property-based fuzz campaign: at each step it picks a random event,
+// executes it, checks the invariants and notifies the observers.
+// The events are picked in the order they were added, each with its own probability,
+// so a seed always replays the same sequence of events.
+// The executed events are recorded, to be saved as a replay if the campaign fails.
+//
+// With coverage guidance, the probabilities are weighted by the energy of each event,
+// which grows every time the event reaches new behaviour, and slowly decays otherwise.
+// The probability of no event being picked stays the same.
+type Harness struct {
+	campaign         *fuzzutil.Campaign
+	events           []EventGenerator
+	totalProbability float32
+	invariants       []Invariant
+	observers        []StateObserver
+	replaySteps      []ReplayStep
+	replayPath       string
+	coverage         *CoverageTracker
+	energies         []float64
+}
+
+const (
+	// coverageBoost multiplies the energy of an event that reached new behaviour.
+	coverageBoost = 2.0
+
+	// coverageDecay multiplies the energy of an event that did not reach new behaviour.
+	coverageDecay = 0.95
+
+	// maxEnergy caps the energy, so no event can take over the campaign.
+	maxEnergy = 16.0
+
+	// minEnergy is the initial energy, and the lowest one.
+	minEnergy = 1.0
+)
+
+// NewHarness creates a harness running the given campaign.
+func NewHarness(campaign *fuzzutil.Campaign) *Harness {
+	return &Harness{
+		campaign: campaign,
+	}
+}
+
+// Campaign returns the campaign run by the harness.
+func (h *Harness) Campaign() *fuzzutil.Campaign {
+	return h.campaign
+}
+
+// AddEvent registers an event generator.
+func (h *Harness) AddEvent(event EventGenerator) error {
+	totalProbability := h.totalProbability + event.Probability()
+	if totalProbability > 1 {
+		return ErrProbabilitiesExceedOne
+	}
+
+	h.totalProbability = totalProbability
+	h.events = append(h.events, event)
+	h.energies = append(h.energies, minEnergy)
+	return nil
+}
+
+// SetCoverageGuidance makes the harness favour the events that reach new behaviour,
+// as recorded in the tracker while they execute, e.g. by a hook created with NewVMFeedbackHook.
+func (h *Harness) SetCoverageGuidance(coverage *CoverageTracker) {
+	h.coverage = coverage
+}
+
+// Coverage returns the tracker guiding the harness, nil without coverage guidance.
+func (h *Harness) Coverage() *CoverageTracker {
+	return h.coverage
+}
+
+// EventEnergy returns the current energy of an event, minEnergy for unknown events.
+func (h *Harness) EventEnergy(eventName string) float64 {
+	for i, event := range h.events {
+		if event.EventName() == eventName {
+			return h.energies[i]
+		}
+	}
+	return minEnergy
+}
+
+// SetReplayPath sets the file the replay is saved to, if an event fails or breaks an invariant.
+func (h *Harness) SetReplayPath(path string) {
+	h.replayPath = path
+}
+
+// AddInvariant registers an invariant, checked after every event that can break it.
+func (h *Harness) AddInvariant(invariant Invariant) {
+	h.invariants = append(h.invariants, invariant)
+}
+
+// AddObserver registers a state observer, notified after every step.
+func (h *Harness) AddObserver(observer StateObserver) {
+	h.observers = append(h.observers, observer)
+}
+
+// Run executes steps until the campaign reaches the given number of steps.
+// A resumed campaign continues from the step it was interrupted at.
+func (h *Harness) Run(numSteps int) error {
+	for h.campaign.StepIndex() < numSteps {
+		_, err := h.Step()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Step picks and executes a single random event, returning its name.
+func (h *Harness) Step() (string, error) {
+	stepIndex := h.campaign.StepIndex()
+	eventName, err := h.generateEvent()
+	if err != nil {
+		err = fmt.Errorf("fuzz event %s at step %d: %w", eventName, stepIndex, err)
+		return eventName, h.saveReplay(err)
+	}
+
+	h.campaign.RecordEvent(eventName)
+	for _, observer := range h.observers {
+		err = observer.ObserveState(h.campaign.StepIndex(), eventName)
+		if err != nil {
+			return eventName, err
+		}
+	}
+
+	return eventName, nil
+}
+
+func (h *Harness) generateEvent() (string, error) {
+	eventIndex := h.pickEvent()
+	if eventIndex < 0 {
+		return NoEventName, nil
+	}
+
+	event := h.events[eventIndex]
+	h.replaySteps = append(h.replaySteps, ReplayStep{
+		StepIndex: h.campaign.StepIndex(),
+		EventName: event.EventName(),
+		Draws:     h.campaign.Draws(),
+	})
+	if h.coverage == nil {
+		return event.EventName(), h.executeEvent(event)
+	}
+
+	h.coverage.TakeNewFeatures()
+	checkInvariants, err := event.Generate(h.campaign.Rand())
+	h.updateEnergy(eventIndex, h.coverage.TakeNewFeatures())
+	if err == nil && checkInvariants {
+		err = h.CheckInvariants()
+	}
+	return event.EventName(), err
+}
+
+// pickEvent yields the index of a random event, or -1 if no event was picked.
+func (h *Harness) pickEvent() int {
+	if h.coverage == nil {
+		re := h.campaign.EventProvider()
+		re.Reset()
+		for i, event := range h.events {
+			if re.WithProbability(event.Probability()) {
+				return i
+			}
+		}
+		return -1
+	}
+
+	totalWeight := 0.0
+	for i, event := range h.events {
+		totalWeight += float64(event.Probability()) * h.energies[i]
+	}
+	if totalWeight == 0 {
+		return -1
+	}
+
+	// the weights are scaled to add up to the total probability of the events
+	scale := float64(h.totalProbability) / totalWeight
+	threshold := h.campaign.Rand().Float64()
+	cumulated := 0.0
+	for i, event := range h.events {
+		cumulated += float64(event.Probability()) * h.energies[i] * scale
+		if threshold < cumulated {
+			return i
+		}
+	}
+	return -1
+}
+
+func (h *Harness) updateEnergy(eventIndex int, newFeatures int) {
+	energy := h.energies[eventIndex]
+	if newFeatures > 0 {
+		energy *= coverageBoost
+	} else {
+		energy *= coverageDecay
+	}
+	if energy > maxEnergy {
+		energy = maxEnergy
+	}
+	if energy < minEnergy {
+		energy = minEnergy
+	}
+	h.energies[eventIndex] = energy
+}
+
+func (h *Harness) executeEvent(event EventGenerator) error {
+	checkInvariants, err := event.Generate(h.campaign.Rand())
+	if err != nil {
+		return err
+	}
+	if checkInvariants {
+		return h.CheckInvariants()
+	}
+	return nil
+}
+
+// saveReplay saves the events executed so far, if a replay path was set,
+// and yields the failure that caused it.
+func (h *Harness) saveReplay(failure error) error {
+	if len(h.replayPath) == 0 {
+		return failure
+	}
+
+	replay := &Replay{
+		Seed:    h.campaign.Seed(),
+		Failure: failure.Error(),
+		Steps:   h.replaySteps,
+	}
+	if h.campaign.IsResumed() {
+		replay.ExecutorState = h.campaign.ExecutorState()
+		replay.Scenario = h.campaign.Scenario()
+	}
+
+	err := SaveReplay(h.replayPath, replay)
+	if err != nil {
+		return fmt.Errorf("%w, and the replay could not be saved: %v", failure, err)
+	}
+	return failure
+}
+
+// Replay executes again the events of a replay, with the same random parameters.
+// The campaign of the harness has to be started from the seed of the replay,
+// and the executor brought to the same initial state as in the replayed campaign.
+// It yields the same error as the replayed campaign, or nil if the failure no longer occurs.
+func (h *Harness) Replay(replay *Replay) error {
+	if replay.Seed != h.campaign.Seed() {
+		return fmt.Errorf("replay seed %d does not match the campaign seed %d", replay.Seed, h.campaign.Seed())
+	}
+
+	eventsByName := make(map[string]EventGenerator, len(h.events))
+	for _, event := range h.events {
+		eventsByName[event.EventName()] = event
+	}
+
+	for _, step := range replay.Steps {
+		event, found := eventsByName[step.EventName]
+		if !found {
+			return fmt.Errorf("unknown replay event: %s", step.EventName)
+		}
+
+		err := h.campaign.FastForward(step.Draws)
+		if err != nil {
+			return err
+		}
+
+		err = h.executeEvent(event)
+		if err != nil {
+			return fmt.Errorf("fuzz event %s at step %d: %w", step.EventName, step.StepIndex, err)
+		}
+	}
+
+	return nil
+}
+
+// CheckInvariants checks all the invariants, in the order they were added.
+func (h *Harness) CheckInvariants() error {
+	for _, invariant := range h.invariants {
+		err := invariant.Check()
+		if err != nil {
+			return fmt.Errorf("invariant %s broken: %w", invariant.InvariantName(), err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,175 @@
+package fuzzcore
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	fuzzutil "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/fuzz/util"
+	"github.com/stretchr/testify/require"
+)
+
+func newCountingHarness(t *testing.T, seed int64, counts map[string]int) *Harness {
+	harness := NewHarness(fuzzutil.NewCampaign(seed))
+	addCountingEvent := func(name string, probability float32, checkInvariants bool) {
+		err := harness.AddEvent(NewEvent(name, probability, func(r *rand.Rand) (bool, error) {
+			counts[name]++
+			_ = r.Intn(100)
+			return checkInvariants, nil
+		}))
+		require.Nil(t, err)
+	}
+	addCountingEvent("a", 0.3, true)
+	addCountingEvent("b", 0.5, false)
+	return harness
+}
+
+func TestHarness_Run(t *testing.T) {
+	counts := make(map[string]int)
+	harness := newCountingHarness(t, 42, counts)
+
+	invariantChecks := 0
+	harness.AddInvariant(NewInvariant("counted", func() error {
+		invariantChecks++
+		return nil
+	}))
+	var observedSteps []int
+	harness.AddObserver(StateObserverFunc(func(stepIndex int, eventName string) error {
+		observedSteps = append(observedSteps, stepIndex)
+		return nil
+	}))
+
+	err := harness.Run(100)
+	require.Nil(t, err)
+
+	statistics := harness.Campaign().Segments()[0].EventCounts
+	require.Equal(t, 100, statistics.Total())
+	require.Equal(t, counts["a"], statistics["a"])
+	require.Equal(t, counts["b"], statistics["b"])
+	require.Equal(t, 100-counts["a"]-counts["b"], statistics[NoEventName])
+	require.Equal(t, counts["a"], invariantChecks)
+	require.Len(t, observedSteps, 100)
+	require.Equal(t, 1, observedSteps[0])
+	require.Equal(t, 100, observedSteps[99])
+}
+
+func TestHarness_SameSeedSameEvents(t *testing.T) {
+	generateEvents := func() []string {
+		harness := newCountingHarness(t, 7, make(map[string]int))
+		var eventNames []string
+		for i := 0; i < 50; i++ {
+			eventName, err := harness.Step()
+			require.Nil(t, err)
+			eventNames = append(eventNames, eventName)
+		}
+		return eventNames
+	}
+
+	require.Equal(t, generateEvents(), generateEvents())
+}
+
+func TestHarness_ProbabilitiesExceedOne(t *testing.T) {
+	harness := newCountingHarness(t, 1, make(map[string]int))
+	err := harness.AddEvent(NewEvent("c", 0.3, func(r *rand.Rand) (bool, error) {
+		return false, nil
+	}))
+	require.Equal(t, ErrProbabilitiesExceedOne, err)
+}
+
+func TestHarness_Errors(t *testing.T) {
+	harness := NewHarness(fuzzutil.NewCampaign(1))
+	err := harness.AddEvent(NewEvent("fail", 1, func(r *rand.Rand) (bool, error) {
+		return false, errors.New("tx failed")
+	}))
+	require.Nil(t, err)
+	_, err = harness.Step()
+	require.EqualError(t, err, "fuzz event fail at step 0: tx failed")
+	require.Equal(t, 0, harness.Campaign().StepIndex())
+
+	harness = NewHarness(fuzzutil.NewCampaign(1))
+	err = harness.AddEvent(NewEvent("ok", 1, func(r *rand.Rand) (bool, error) {
+		return true, nil
+	}))
+	require.Nil(t, err)
+	harness.AddInvariant(NewInvariant("balance", func() error {
+		return errors.New("balance mismatch")
+	}))
+	err = harness.Run(10)
+	require.EqualError(t, err, "fuzz event ok at step 0: invariant balance broken: balance mismatch")
+}
+
+func newSummingHarness(t *testing.T, seed int64) *Harness {
+	sum := 0
+	harness := NewHarness(fuzzutil.NewCampaign(seed))
+	err := harness.AddEvent(NewEvent("add", 0.5, func(r *rand.Rand) (bool, error) {
+		sum += r.Intn(10)
+		return true, nil
+	}))
+	require.Nil(t, err)
+	harness.AddInvariant(NewInvariant("sum", func() error {
+		if sum >= 100 {
+			return fmt.Errorf("sum too large: %d", sum)
+		}
+		return nil
+	}))
+	return harness
+}
+
+func TestHarness_Replay(t *testing.T) {
+	replayDir, err := ioutil.TempDir("", "fuzz-replay")
+	require.Nil(t, err)
+	defer os.RemoveAll(replayDir)
+	replayPath := filepath.Join(replayDir, "fuzz.replay.json")
+
+	harness := newSummingHarness(t, 3)
+	harness.SetReplayPath(replayPath)
+	failure := harness.Run(1000)
+	require.NotNil(t, failure)
+
+	replay, err := LoadReplay(replayPath)
+	require.Nil(t, err)
+	require.Equal(t, int64(3), replay.Seed)
+	require.Equal(t, failure.Error(), replay.Failure)
+	require.False(t, replay.IsResumed())
+	require.NotEmpty(t, replay.Steps)
+
+	err = newSummingHarness(t, 3).Replay(replay)
+	require.Equal(t, failure.Error(), err.Error())
+
+	err = newSummingHarness(t, 4).Replay(replay)
+	require.EqualError(t, err, "replay seed 3 does not match the campaign seed 4")
+}
+
+func TestHarness_CoverageGuidance(t *testing.T) {
+	coverage := NewCoverageTracker()
+	harness := NewHarness(fuzzutil.NewCampaign(5))
+	harness.SetCoverageGuidance(coverage)
+
+	explored := 0
+	err := harness.AddEvent(NewEvent("explore", 0.4, func(r *rand.Rand) (bool, error) {
+		explored++
+		coverage.AddFeature(fmt.Sprintf("feature%d", explored))
+		return false, nil
+	}))
+	require.Nil(t, err)
+	err = harness.AddEvent(NewEvent("repeat", 0.4, func(r *rand.Rand) (bool, error) {
+		coverage.AddFeature("repeated")
+		return false, nil
+	}))
+	require.Nil(t, err)
+
+	err = harness.Run(1000)
+	require.Nil(t, err)
+
+	require.Equal(t, maxEnergy, harness.EventEnergy("explore"))
+	require.Equal(t, minEnergy, harness.EventEnergy("repeat"))
+
+	statistics := harness.Campaign().Segments()[0].EventCounts
+	require.Greater(t, statistics["explore"], 5*statistics["repeat"])
+	require.InDelta(t, 200, statistics[NoEventName], 50)
+	require.Equal(t, statistics["explore"]+1, coverage.Len())
+}
@@ -0,0 +1,32 @@
+package fuzzcore
+
+import (
+	"math/rand"
+)
+
+// EventGenerator generates and executes one kind of random fuzz event.
+type EventGenerator interface {
+	// EventName is the name under which the event is counted in the statistics.
+	EventName() string
+
+	// Probability is the chance of the event being picked at each step.
+	Probability() float32
+
+	// Generate executes the event, with random parameters drawn from r.
+	// It returns false if the event cannot break the invariants, so checking them can be skipped.
+	Generate(r *rand.Rand) (checkInvariants bool, err error)
+}
+
+// Invariant is a property of the fuzzed contracts that has to hold after every event.
+type Invariant interface {
+	// InvariantName identifies the invariant in error messages.
+	InvariantName() string
+
+	// Check returns an error if the invariant does not hold.
+	Check() error
+}
+
+// StateObserver is notified after every step of the harness, e.g. to print or persist the state.
+type StateObserver interface {
+	ObserveState(stepIndex int, eventName string) error
+}
@@ -0,0 +1,56 @@
+package fuzzcore
+
+import (
+	"encoding/json"
+	"io/ioutil"
+)
+
+// ReplayStep is an event executed by the harness, together with the number of
+// random values drawn before it, from which its random parameters are reproduced.
+type ReplayStep struct {
+	StepIndex int    `json:"stepIndex"`
+	EventName string `json:"eventName"`
+	Draws     uint64 `json:"draws"`
+}
+
+// Replay is the exact sequence of events of a failed fuzz campaign.
+// For a resumed campaign, it also holds the state the campaign was resumed from.
+type Replay struct {
+	Seed          int64           `json:"seed"`
+	Failure       string          `json:"failure"`
+	ExecutorState json.RawMessage `json:"executorState,omitempty"`
+	Scenario      string          `json:"scenario,omitempty"`
+	Steps         []ReplayStep    `json:"steps"`
+}
+
+// IsResumed returns true if the replayed campaign was resumed from a checkpoint,
+// so the executor has to be restored from the saved state instead of being initialized.
+func (r *Replay) IsResumed() bool {
+	return len(r.ExecutorState) > 0
+}
+
+// SaveReplay writes a replay to the given path.
+func SaveReplay(path string, replay *Replay) error {
+	serialized, err := json.MarshalIndent(replay, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(path, serialized, 0644)
+}
+
+// LoadReplay reads a replay from the given path.
+func LoadReplay(path string) (*Replay, error) {
+	serialized, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	replay := &Replay{}
+	err = json.Unmarshal(serialized, replay)
+	if err != nil {
+		return nil, err
+	}
+
+	return replay, nil
+}
@@ -0,0 +1,63 @@
+package fuzzcore
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+
+	am "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwenmandos"
+	mj "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mandos-go/json/model"
+)
+
+// NewVMFeedbackHook creates a step hook that records in the tracker the behaviour of each tx executed by the VM:
+// the functions reached, including those called by the contracts, the return codes seen,
+// the events logged and the storage keys read.
+func NewVMFeedbackHook(tracker *CoverageTracker) am.StepHook {
+	return func(ctx *am.StepHookContext) error {
+		if ctx.Output == nil {
+			return nil
+		}
+
+		function := ""
+		if txStep, isTx := ctx.Step.(*mj.TxStep); isTx {
+			function = txStep.Tx.Function
+			if txStep.Tx.Type == mj.ScDeploy {
+				function = "init"
+			}
+		}
+		if len(function) > 0 {
+			tracker.AddFeature("function:" + function)
+		}
+		tracker.AddFeature(fmt.Sprintf("returnCode:%s:%s", function, ctx.Output.ReturnCode))
+
+		for _, outputAccount := range ctx.Output.OutputAccounts {
+			for _, transfer := range outputAccount.OutputTransfers {
+				calledFunction := calledFunctionName(transfer.Data)
+				if len(calledFunction) > 0 {
+					tracker.AddFeature("function:" + calledFunction)
+				}
+			}
+		}
+		for _, logEntry := range ctx.Output.Logs {
+			tracker.AddFeature("event:" + string(logEntry.Identifier))
+		}
+		if ctx.TouchedStorageKeys != nil {
+			for _, touched := range ctx.TouchedStorageKeys.Keys {
+				tracker.AddFeature(fmt.Sprintf("storage:%s:%s",
+					hex.EncodeToString(touched.Address),
+					hex.EncodeToString(touched.Key)))
+			}
+		}
+
+		return nil
+	}
+}
+
+// calledFunctionName extracts the function from the data of a transfer, "function@arg1@arg2...".
+func calledFunctionName(data []byte) string {
+	separatorIndex := bytes.IndexByte(data, '@')
+	if separatorIndex < 0 {
+		return string(data)
+	}
+	return string(data[:separatorIndex])
+}
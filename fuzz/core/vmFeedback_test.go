@@ -0,0 +1,48 @@
+package fuzzcore
+
+import (
+	"testing"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
+	am "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwenmandos"
+	mj "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mandos-go/json/model"
+	vmi "github.com/ElrondNetwork/elrond-go/core/vmcommon"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVMFeedbackHook(t *testing.T) {
+	coverage := NewCoverageTracker()
+	hook := NewVMFeedbackHook(coverage)
+
+	err := hook(&am.StepHookContext{
+		Step: &mj.TxStep{Tx: &mj.Transaction{Type: mj.ScCall, Function: "stake"}},
+		Output: &vmi.VMOutput{
+			ReturnCode: vmi.UserError,
+			OutputAccounts: map[string]*vmi.OutputAccount{
+				"auction": {
+					OutputTransfers: []vmi.OutputTransfer{
+						{Data: []byte("stakeNodes@01@02")},
+						{Data: []byte{}},
+					},
+				},
+			},
+			Logs: []*vmi.LogEntry{{Identifier: []byte("stake_event")}},
+		},
+		TouchedStorageKeys: arwen.NewTouchedStorageKeys([]*arwen.TouchedStorageKey{
+			{Address: []byte{0xab}, Key: []byte{0x01, 0x02}},
+		}),
+	})
+	require.Nil(t, err)
+
+	require.True(t, coverage.HasFeature("function:stake"))
+	require.True(t, coverage.HasFeature("function:stakeNodes"))
+	require.True(t, coverage.HasFeature("returnCode:stake:user error"))
+	require.True(t, coverage.HasFeature("event:stake_event"))
+	require.True(t, coverage.HasFeature("storage:ab:0102"))
+	require.Equal(t, 5, coverage.Len())
+	require.Equal(t, 5, coverage.TakeNewFeatures())
+
+	err = hook(&am.StepHookContext{Step: &mj.SetStateStep{}})
+	require.Nil(t, err)
+	require.Equal(t, 0, coverage.TakeNewFeatures())
+}
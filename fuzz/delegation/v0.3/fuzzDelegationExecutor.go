@@ -18,7 +18,7 @@ import (
 
 type fuzzDelegationExecutor struct {
 	arwenTestExecutor *am.ArwenTestExecutor
-	world             *worldhook.MockWorld
+	world             *worldhook.ChainSimulator
 	vm                vmi.VMExecutionHandler
 	mandosParser      mjparse.Parser
 	txIndex           int
@@ -49,7 +49,7 @@ func newFuzzDelegationExecutor(fileResolver fr.FileResolver) (*fuzzDelegationExe
 	parser := mjparse.NewParser(fileResolver)
 	return &fuzzDelegationExecutor{
 		arwenTestExecutor:   arwenTestExecutor,
-		world:               arwenTestExecutor.World,
+		world:               worldhook.NewChainSimulatorWithWorld(arwenTestExecutor.World, 1),
 		vm:                  arwenTestExecutor.GetVM(),
 		mandosParser:        parser,
 		txIndex:             0,
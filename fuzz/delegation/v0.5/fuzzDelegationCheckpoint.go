@@ -0,0 +1,97 @@
+package delegation
+
+import (
+	"encoding/json"
+	"math/big"
+
+	mjwrite "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mandos-go/json/write"
+)
+
+// fuzzDelegationExecutorState holds the fields of the executor which are not
+// part of the world state, so that they can be saved in a checkpoint.
+type fuzzDelegationExecutorState struct {
+	TxIndex                     int      `json:"txIndex"`
+	ServiceFee                  int      `json:"serviceFee"`
+	NumBlocksBeforeForceUnstake int      `json:"numBlocksBeforeForceUnstake"`
+	NumBlocksBeforeUnbond       int      `json:"numBlocksBeforeUnbond"`
+	NumDelegators               int      `json:"numDelegators"`
+	StakePerNode                *big.Int `json:"stakePerNode"`
+	OwnerAddress                string   `json:"ownerAddress"`
+	DelegationContractAddress   string   `json:"delegationContractAddress"`
+	AuctionMockAddress          string   `json:"auctionMockAddress"`
+	FaucetAddress               string   `json:"faucetAddress"`
+	WithdrawTargetAddress       string   `json:"withdrawTargetAddress"`
+	StakePurchaseForwardAddress string   `json:"stakePurchaseForwardAddress"`
+	NumNodes                    int      `json:"numNodes"`
+	TotalStakeAdded             *big.Int `json:"totalStakeAdded"`
+	TotalStakeWithdrawn         *big.Int `json:"totalStakeWithdrawn"`
+	TotalRewards                *big.Int `json:"totalRewards"`
+}
+
+func (pfe *fuzzDelegationExecutor) getState() *fuzzDelegationExecutorState {
+	return &fuzzDelegationExecutorState{
+		TxIndex:                     pfe.txIndex,
+		ServiceFee:                  pfe.serviceFee,
+		NumBlocksBeforeForceUnstake: pfe.numBlocksBeforeForceUnstake,
+		NumBlocksBeforeUnbond:       pfe.numBlocksBeforeUnbond,
+		NumDelegators:               pfe.numDelegators,
+		StakePerNode:                pfe.stakePerNode,
+		OwnerAddress:                pfe.ownerAddress,
+		DelegationContractAddress:   pfe.delegationContractAddress,
+		AuctionMockAddress:          pfe.auctionMockAddress,
+		FaucetAddress:               pfe.faucetAddress,
+		WithdrawTargetAddress:       pfe.withdrawTargetAddress,
+		StakePurchaseForwardAddress: pfe.stakePurchaseForwardAddress,
+		NumNodes:                    pfe.numNodes,
+		TotalStakeAdded:             pfe.totalStakeAdded,
+		TotalStakeWithdrawn:         pfe.totalStakeWithdrawn,
+		TotalRewards:                pfe.totalRewards,
+	}
+}
+
+func (pfe *fuzzDelegationExecutor) getGeneratedScenario() string {
+	return mjwrite.ScenarioToJSONString(pfe.generatedScenario)
+}
+
+// restore brings a fresh executor to the state saved in a checkpoint, by
+// executing again the steps generated before the checkpoint.
+func (pfe *fuzzDelegationExecutor) restore(serializedState json.RawMessage, serializedScenario string) error {
+	state := &fuzzDelegationExecutorState{}
+	err := json.Unmarshal(serializedState, state)
+	if err != nil {
+		return err
+	}
+
+	scenario, err := pfe.mandosParser.ParseScenarioFile([]byte(serializedScenario))
+	if err != nil {
+		return err
+	}
+
+	pfe.world.Clear()
+	for _, step := range scenario.Steps {
+		pfe.addStep(step)
+		err = pfe.arwenTestExecutor.ExecuteStep(step)
+		if err != nil {
+			return err
+		}
+	}
+
+	pfe.txIndex = state.TxIndex
+	pfe.serviceFee = state.ServiceFee
+	pfe.numBlocksBeforeForceUnstake = state.NumBlocksBeforeForceUnstake
+	pfe.numBlocksBeforeUnbond = state.NumBlocksBeforeUnbond
+	pfe.numDelegators = state.NumDelegators
+	pfe.stakePerNode = state.StakePerNode
+	pfe.ownerAddress = state.OwnerAddress
+	pfe.delegationContractAddress = state.DelegationContractAddress
+	pfe.auctionMockAddress = state.AuctionMockAddress
+	pfe.faucetAddress = state.FaucetAddress
+	pfe.withdrawTargetAddress = state.WithdrawTargetAddress
+	pfe.stakePurchaseForwardAddress = state.StakePurchaseForwardAddress
+	pfe.numNodes = state.NumNodes
+	pfe.totalStakeAdded = state.TotalStakeAdded
+	pfe.totalStakeWithdrawn = state.TotalStakeWithdrawn
+	pfe.totalRewards = state.TotalRewards
+
+	return nil
+}
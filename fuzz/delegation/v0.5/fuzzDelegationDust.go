@@ -1,8 +1,10 @@
 package delegation
 
 import (
-	"fmt"
 	"math/big"
+	"strconv"
+
+	scenario "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mandos-go/json/scenario"
 )
 
 func (pfe *fuzzDelegationExecutor) dustCleanup(dustLimit *big.Int) error {
@@ -24,7 +26,7 @@ func (pfe *fuzzDelegationExecutor) dustCleanup(dustLimit *big.Int) error {
 }
 
 func (pfe *fuzzDelegationExecutor) hasDustItemsWaitingList(dustLimit *big.Int) bool {
-	count, err := pfe.querySingleResult("countDustItemsWaitingList", fmt.Sprintf(`"%d"`, dustLimit))
+	count, err := pfe.querySingleResult("countDustItemsWaitingList", dustLimit.String())
 	if err != nil {
 		panic(err)
 	}
@@ -33,7 +35,7 @@ func (pfe *fuzzDelegationExecutor) hasDustItemsWaitingList(dustLimit *big.Int) b
 }
 
 func (pfe *fuzzDelegationExecutor) hasDustItemsActive(dustLimit *big.Int) bool {
-	count, err := pfe.querySingleResult("countDustItemsActive", fmt.Sprintf(`"%d"`, dustLimit))
+	count, err := pfe.querySingleResult("countDustItemsActive", dustLimit.String())
 	if err != nil {
 		panic(err)
 	}
@@ -42,61 +44,35 @@ func (pfe *fuzzDelegationExecutor) hasDustItemsActive(dustLimit *big.Int) bool {
 }
 
 func (pfe *fuzzDelegationExecutor) dustCleanupWaitingList(dustLimit *big.Int) error {
-	_, err := pfe.executeTxStep(fmt.Sprintf(`
-		{
-			"step": "scCall",
-			"txId": "%d",
-			"tx": {
-				"from": "%s",
-				"to": "%s",
-				"value": "0",
-				"function": "dustCleanupWaitingList",
-				"arguments": [
-					"%d"
-				],
-				"gasLimit": "300,000,000",
-				"gasPrice": "0"
-			},
-			"expect": {
-				"out": [],
-				"status": "",
-				"refund": "*"
-			}
-		}`,
-		pfe.nextTxIndex(),
-		pfe.ownerAddress,
-		pfe.delegationContractAddress,
-		dustLimit,
-	))
+	_, err := pfe.executeTx(scenario.NewSCCall().
+		TxID(strconv.Itoa(pfe.nextTxIndex())).
+		From(pfe.ownerAddress).
+		To(pfe.delegationContractAddress).
+		Value("0").
+		Function("dustCleanupWaitingList").
+		Arguments(dustLimit.String()).
+		GasLimit(300000000).
+		GasPrice(0).
+		Expect(scenario.NewExpect().
+			Out().
+			Status("").
+			Refund("*")))
 	return err
 }
 
 func (pfe *fuzzDelegationExecutor) dustCleanupActive(dustLimit *big.Int) error {
-	_, err := pfe.executeTxStep(fmt.Sprintf(`
-		{
-			"step": "scCall",
-			"txId": "%d",
-			"tx": {
-				"from": "%s",
-				"to": "%s",
-				"value": "0",
-				"function": "dustCleanupActive",
-				"arguments": [
-					"%d"
-				],
-				"gasLimit": "300,000,000",
-				"gasPrice": "0"
-			},
-			"expect": {
-				"out": [],
-				"status": "",
-				"refund": "*"
-			}
-		}`,
-		pfe.nextTxIndex(),
-		pfe.ownerAddress,
-		pfe.delegationContractAddress,
-		dustLimit,
-	))
+	_, err := pfe.executeTx(scenario.NewSCCall().
+		TxID(strconv.Itoa(pfe.nextTxIndex())).
+		From(pfe.ownerAddress).
+		To(pfe.delegationContractAddress).
+		Value("0").
+		Function("dustCleanupActive").
+		Arguments(dustLimit.String()).
+		GasLimit(300000000).
+		GasPrice(0).
+		Expect(scenario.NewExpect().
+			Out().
+			Status("").
+			Refund("*")))
 	return err
 }
@@ -2,22 +2,20 @@ package delegation
 
 import (
 	"bytes"
-	"errors"
 	"fmt"
 	"io/ioutil"
 	"math/big"
 	"math/rand"
-	"strings"
-	"testing"
+	"strconv"
 
 	am "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwenmandos"
 	fr "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mandos-go/fileresolver"
 	mj "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mandos-go/json/model"
 	mjparse "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mandos-go/json/parse"
+	scenario "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mandos-go/json/scenario"
 	mjwrite "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mandos-go/json/write"
 	worldhook "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/world"
 	vmi "github.com/ElrondNetwork/elrond-go/core/vmcommon"
-	"github.com/stretchr/testify/require"
 )
 
 const (
@@ -42,7 +40,7 @@ type fuzzDelegationExecutorInitArgs struct {
 
 type fuzzDelegationExecutor struct {
 	arwenTestExecutor *am.ArwenTestExecutor
-	world             *worldhook.MockWorld
+	world             *worldhook.ChainSimulator
 	vm                vmi.VMExecutionHandler
 	mandosParser      mjparse.Parser
 	txIndex           int
@@ -63,6 +61,7 @@ type fuzzDelegationExecutor struct {
 	totalStakeWithdrawn         *big.Int
 	totalRewards                *big.Int
 	generatedScenario           *mj.Scenario
+	txHooks                     []am.StepHook
 }
 
 func newFuzzDelegationExecutor(fileResolver fr.FileResolver) (*fuzzDelegationExecutor, error) {
@@ -77,7 +76,7 @@ func newFuzzDelegationExecutor(fileResolver fr.FileResolver) (*fuzzDelegationExe
 
 	return &fuzzDelegationExecutor{
 		arwenTestExecutor:   arwenTestExecutor,
-		world:               arwenTestExecutor.World,
+		world:               worldhook.NewChainSimulatorWithWorld(arwenTestExecutor.World, 1),
 		vm:                  arwenTestExecutor.GetVM(),
 		mandosParser:        parser,
 		txIndex:             0,
@@ -115,20 +114,36 @@ func (pfe *fuzzDelegationExecutor) saveGeneratedScenario() {
 	}
 }
 
-func (pfe *fuzzDelegationExecutor) executeTxStep(stepSnippet string) (*vmi.VMOutput, error) {
-	step, err := pfe.mandosParser.ParseScenarioStep(stepSnippet)
+func (pfe *fuzzDelegationExecutor) executeTx(txBuilder *scenario.TxBuilder) (*vmi.VMOutput, error) {
+	txStep, err := txBuilder.Build(pfe.mandosParser.ExprInterpreter)
 	if err != nil {
 		return nil, err
 	}
 
-	txStep, isTx := step.(*mj.TxStep)
-	if !isTx {
-		return nil, errors.New("tx step expected")
+	return pfe.executeTxStep(txStep)
+}
+
+func (pfe *fuzzDelegationExecutor) executeTxStep(txStep *mj.TxStep) (*vmi.VMOutput, error) {
+	pfe.addStep(txStep)
+
+	output, err := pfe.arwenTestExecutor.ExecuteTxStep(txStep)
+	if err != nil {
+		return nil, err
 	}
 
-	pfe.addStep(step)
+	for _, hook := range pfe.txHooks {
+		err = hook(pfe.arwenTestExecutor.NewStepHookContext(txStep, output))
+		if err != nil {
+			return nil, err
+		}
+	}
+	return output, nil
+}
 
-	return pfe.arwenTestExecutor.ExecuteTxStep(txStep)
+// addTxHook registers a hook called after each tx executed successfully,
+// since the txs are executed directly, without the step hooks of the executor.
+func (pfe *fuzzDelegationExecutor) addTxHook(hook am.StepHook) {
+	pfe.txHooks = append(pfe.txHooks, hook)
 }
 
 func (pfe *fuzzDelegationExecutor) log(info string, args ...interface{}) {
@@ -138,62 +153,37 @@ func (pfe *fuzzDelegationExecutor) log(info string, args ...interface{}) {
 func (pfe *fuzzDelegationExecutor) addNodes(numNodesToAdd int) error {
 	pfe.log("addNodes %d -> %d", numNodesToAdd, pfe.numNodes+numNodesToAdd)
 
-	_, err := pfe.executeTxStep(fmt.Sprintf(`
-	{
-		"step": "scCall",
-		"txId": "%d",
-		"tx": {
-			"from": "%s",
-			"to": "%s",
-			"value": "0",
-			"function": "addNodes",
-			"arguments": [
-				%s
-			],
-			"gasLimit": "1,000,000,000",
-			"gasPrice": "0"
-		},
-		"expect": {
-			"out": [],
-			"status": "",
-			"logs": [],
-			"gas": "*",
-			"refund": "*"
-		}
-	}`,
-		pfe.nextTxIndex(),
-		pfe.ownerAddress,
-		pfe.delegationContractAddress,
-		blsKeySignatureArgsString(pfe.numNodes, numNodesToAdd),
-	))
+	_, err := pfe.executeTx(scenario.NewSCCall().
+		TxID(strconv.Itoa(pfe.nextTxIndex())).
+		From(pfe.ownerAddress).
+		To(pfe.delegationContractAddress).
+		Value("0").
+		Function("addNodes").
+		Arguments(blsKeySignatureArgs(pfe.numNodes, numNodesToAdd)...).
+		GasLimit(1000000000).
+		GasPrice(0).
+		Expect(scenario.NewExpect().
+			Out().
+			Status("").
+			NoLogs().
+			Gas("*").
+			Refund("*")))
 	pfe.numNodes += numNodesToAdd
 	return err
 }
 
-func (pfe *fuzzDelegationExecutor) removeNodes(numNodesToRemove int) error {
+func (pfe *fuzzDelegationExecutor) removeNodes(r *rand.Rand, numNodesToRemove int) error {
 	pfe.log("removeNodes %d -> %d", numNodesToRemove, pfe.numNodes-numNodesToRemove)
 
-	output, err := pfe.executeTxStep(fmt.Sprintf(`
-	{
-		"step": "scCall",
-		"txId": "%d",
-		"tx": {
-			"from": "%s",
-			"to": "%s",
-			"value": "0",
-			"function": "removeNodes",
-			"arguments": [
-				%s
-			],
-			"gasLimit": "1,000,000,000",
-			"gasPrice": "0"
-		}
-	}`,
-		pfe.nextTxIndex(),
-		pfe.ownerAddress,
-		pfe.delegationContractAddress,
-		blsKeysToBeRemoved(pfe.numNodes, numNodesToRemove),
-	))
+	output, err := pfe.executeTx(scenario.NewSCCall().
+		TxID(strconv.Itoa(pfe.nextTxIndex())).
+		From(pfe.ownerAddress).
+		To(pfe.delegationContractAddress).
+		Value("0").
+		Function("removeNodes").
+		Arguments(blsKeysToBeRemoved(r, pfe.numNodes, numNodesToRemove)...).
+		GasLimit(1000000000).
+		GasPrice(0))
 	if err != nil {
 		return err
 	}
@@ -211,22 +201,22 @@ func (pfe *fuzzDelegationExecutor) nextTxIndex() int {
 	return pfe.txIndex
 }
 
-func blsKeysToBeRemoved(totalNumNodes, numKeysToBeRemoved int) string {
+func blsKeysToBeRemoved(r *rand.Rand, totalNumNodes, numKeysToBeRemoved int) []string {
 	var blsKeys []string
 	for i := 0; i < numKeysToBeRemoved; i++ {
-		keyIndex := rand.Intn(totalNumNodes + 1)
-		blsKeys = append(blsKeys, "\"str:"+blsKey(keyIndex)+"\"")
+		keyIndex := r.Intn(totalNumNodes + 1)
+		blsKeys = append(blsKeys, "str:"+blsKey(keyIndex))
 	}
-	return strings.Join(blsKeys, ",")
+	return blsKeys
 }
 
-func blsKeySignatureArgsString(startIndex, numNodes int) string {
+func blsKeySignatureArgs(startIndex, numNodes int) []string {
 	var blsKeyArgs []string
 	for i := startIndex; i < startIndex+numNodes; i++ {
-		blsKeyArgs = append(blsKeyArgs, "\"str:"+blsKey(i)+"\"")
-		blsKeyArgs = append(blsKeyArgs, "\"str:"+blsSignature(i)+"\"")
+		blsKeyArgs = append(blsKeyArgs, "str:"+blsKey(i))
+		blsKeyArgs = append(blsKeyArgs, "str:"+blsSignature(i))
 	}
-	return strings.Join(blsKeyArgs, ",")
+	return blsKeyArgs
 }
 
 func blsKey(index int) string {
@@ -274,28 +264,15 @@ func (pfe *fuzzDelegationExecutor) increaseBlockNonce(nonceDelta int) error {
 	return pfe.setBlockNonce(curentBlockNonce, curentBlockNonce+uint64(nonceDelta))
 }
 
-func (pfe *fuzzDelegationExecutor) querySingleResult(funcName string, args string) (*big.Int, error) {
-	output, err := pfe.executeTxStep(fmt.Sprintf(`
-	{
-		"step": "scQuery",
-		"txId": "%d",
-		"tx": {
-			"to": "%s",
-			"function": "%s",
-			"arguments": [
-				%s
-			]
-		},
-		"expect": {
-			"out": [ "*" ],
-			"status": ""
-		}
-	}`,
-		pfe.nextTxIndex(),
-		pfe.delegationContractAddress,
-		funcName,
-		args,
-	))
+func (pfe *fuzzDelegationExecutor) querySingleResult(funcName string, args ...string) (*big.Int, error) {
+	output, err := pfe.executeTx(scenario.NewSCQuery().
+		TxID(strconv.Itoa(pfe.nextTxIndex())).
+		To(pfe.delegationContractAddress).
+		Function(funcName).
+		Arguments(args...).
+		Expect(scenario.NewExpect().
+			Out("*").
+			Status("")))
 	if err != nil {
 		return nil, err
 	}
@@ -306,8 +283,7 @@ func (pfe *fuzzDelegationExecutor) querySingleResult(funcName string, args strin
 }
 
 func (pfe *fuzzDelegationExecutor) delegatorQuery(funcName string, delegatorIndex int) (*big.Int, error) {
-	delegatorAddr := fmt.Sprintf(`"str:%s"`, pfe.delegatorAddress(delegatorIndex))
-	return pfe.querySingleResult(funcName, delegatorAddr)
+	return pfe.querySingleResult(funcName, "str:"+pfe.delegatorAddress(delegatorIndex))
 }
 
 func (pfe *fuzzDelegationExecutor) getAllDelegatorsBalance() *big.Int {
@@ -328,24 +304,15 @@ func (pfe *fuzzDelegationExecutor) getDelegatorBalance(delegatorIndex int) *big.
 }
 
 func (pfe *fuzzDelegationExecutor) modifyDelegationCap(newCap *big.Int) error {
-	output, err := pfe.executeTxStep(fmt.Sprintf(`
-	{
-		"step": "scCall",
-		"txId": "-modify-delegation-cap-",
-		"tx": {
-			"from": "%s",
-			"to": "%s",
-			"value": "0",
-			"function": "modifyTotalDelegationCap",
-			"arguments": ["%d"],
-			"gasLimit": "100,000,000",
-			"gasPrice": "0"
-		}
-	}`,
-		pfe.ownerAddress,
-		pfe.delegationContractAddress,
-		newCap,
-	))
+	output, err := pfe.executeTx(scenario.NewSCCall().
+		TxID("-modify-delegation-cap-").
+		From(pfe.ownerAddress).
+		To(pfe.delegationContractAddress).
+		Value("0").
+		Function("modifyTotalDelegationCap").
+		Arguments(newCap.String()).
+		GasLimit(100000000).
+		GasPrice(0))
 	if err != nil {
 		return err
 	}
@@ -356,24 +323,15 @@ func (pfe *fuzzDelegationExecutor) modifyDelegationCap(newCap *big.Int) error {
 }
 
 func (pfe *fuzzDelegationExecutor) setServiceFee(newServiceFee int) error {
-	output, err := pfe.executeTxStep(fmt.Sprintf(`
-	{
-		"step": "scCall",
-		"txId": "-set-service-fee-",
-		"tx": {
-			"from": "%s",
-			"to": "%s",
-			"value": "0",
-			"function": "setServiceFee",
-			"arguments": ["%d"],
-			"gasLimit": "100,000,000",
-			"gasPrice": "0"
-		}
-	}`,
-		pfe.ownerAddress,
-		pfe.delegationContractAddress,
-		newServiceFee,
-	))
+	output, err := pfe.executeTx(scenario.NewSCCall().
+		TxID("-set-service-fee-").
+		From(pfe.ownerAddress).
+		To(pfe.delegationContractAddress).
+		Value("0").
+		Function("setServiceFee").
+		Arguments(strconv.Itoa(newServiceFee)).
+		GasLimit(100000000).
+		GasPrice(0))
 	if err != nil {
 		return err
 	}
@@ -386,27 +344,17 @@ func (pfe *fuzzDelegationExecutor) setServiceFee(newServiceFee int) error {
 func (pfe *fuzzDelegationExecutor) continueGlobalOperation() error {
 	completed := false
 	for !completed {
-		output, err := pfe.executeTxStep(fmt.Sprintf(`
-		{
-			"step": "scCall",
-			"txId": "-continue-global-operation-",
-			"tx": {
-				"from": "%s",
-				"to": "%s",
-				"value": "0",
-				"function": "continueGlobalOperation",
-				"arguments": [],
-				"gasLimit": "200,000,000",
-				"gasPrice": "0"
-			},
-			"expect": {
-				"out": [ "*" ],
-				"refund": "*"
-			}
-		}`,
-			pfe.ownerAddress,
-			pfe.delegationContractAddress,
-		))
+		output, err := pfe.executeTx(scenario.NewSCCall().
+			TxID("-continue-global-operation-").
+			From(pfe.ownerAddress).
+			To(pfe.delegationContractAddress).
+			Value("0").
+			Function("continueGlobalOperation").
+			GasLimit(200000000).
+			GasPrice(0).
+			Expect(scenario.NewExpect().
+				Out("*").
+				Refund("*")))
 		if err != nil {
 			return err
 		}
@@ -425,23 +373,14 @@ func (pfe *fuzzDelegationExecutor) continueGlobalOperation() error {
 }
 
 func (pfe *fuzzDelegationExecutor) isBootstrapMode() (bool, error) {
-	output, err := pfe.executeTxStep(fmt.Sprintf(`
-	{
-		"step": "scCall",
-		"txId": "-is-bootstrap-mode-",
-		"tx": {
-			"from": "%s",
-			"to": "%s",
-			"value": "0",
-			"function": "isBootstrapMode",
-			"arguments": [],
-			"gasLimit": "50,000,000",
-			"gasPrice": "0"
-		}
-	}`,
-		pfe.ownerAddress,
-		pfe.delegationContractAddress,
-	))
+	output, err := pfe.executeTx(scenario.NewSCCall().
+		TxID("-is-bootstrap-mode-").
+		From(pfe.ownerAddress).
+		To(pfe.delegationContractAddress).
+		Value("0").
+		Function("isBootstrapMode").
+		GasLimit(50000000).
+		GasPrice(0))
 	if err != nil {
 		return false, err
 	}
@@ -453,10 +392,12 @@ func (pfe *fuzzDelegationExecutor) isBootstrapMode() (bool, error) {
 	}
 }
 
-func (pfe *fuzzDelegationExecutor) printServiceFeeAndDelegationCap(t *testing.T) {
-	_, err := pfe.querySingleResult("getTotalDelegationCap", "")
-	require.Nil(t, err)
+func (pfe *fuzzDelegationExecutor) printServiceFeeAndDelegationCap() error {
+	_, err := pfe.querySingleResult("getTotalDelegationCap")
+	if err != nil {
+		return err
+	}
 
-	_, err = pfe.querySingleResult("getServiceFee", "")
-	require.Nil(t, err)
+	_, err = pfe.querySingleResult("getServiceFee")
+	return err
 }
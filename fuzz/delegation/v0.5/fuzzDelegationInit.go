@@ -2,6 +2,9 @@ package delegation
 
 import (
 	"fmt"
+	"strconv"
+
+	scenario "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mandos-go/json/scenario"
 )
 
 func (pfe *fuzzDelegationExecutor) init(args *fuzzDelegationExecutorInitArgs) error {
@@ -100,41 +103,27 @@ func (pfe *fuzzDelegationExecutor) init(args *fuzzDelegationExecutorInitArgs) er
 	}
 
 	// deploy delegation
-	_, err = pfe.executeTxStep(fmt.Sprintf(`
-	{
-		"step": "scDeploy",
-		"txId": "-deploy-",
-		"tx": {
-			"from": "%s",
-			"value": "0",
-			"contractCode": "file:delegation.wasm",
-			"arguments": [
-				"%s",
-				"%d",
-				"%d",
-				"%d",
-				"%d",
-				"%d"
-			],
-			"gasLimit": "50,000,000",
-			"gasPrice": "0"
-		},
-		"expect": {
-			"out": [],
-			"status": "",
-			"logs": [],
-			"gas": "*",
-			"refund": "*"
-		}
-	}`,
-		pfe.ownerAddress,
-		pfe.auctionMockAddress,
-		args.serviceFee,
-		args.ownerMinStake,
-		args.numBlocksBeforeUnbond,
-		args.minStake,
-		args.totalDelegationCap,
-	))
+	_, err = pfe.executeTx(scenario.NewSCDeploy().
+		TxID("-deploy-").
+		From(pfe.ownerAddress).
+		Value("0").
+		Code("file:delegation.wasm").
+		Arguments(
+			pfe.auctionMockAddress,
+			strconv.Itoa(args.serviceFee),
+			strconv.Itoa(args.ownerMinStake),
+			strconv.Itoa(args.numBlocksBeforeUnbond),
+			strconv.Itoa(args.minStake),
+			args.totalDelegationCap.String(),
+		).
+		GasLimit(50000000).
+		GasPrice(0).
+		Expect(scenario.NewExpect().
+			Out().
+			Status("").
+			NoLogs().
+			Gas("*").
+			Refund("*")))
 	if err != nil {
 		return err
 	}
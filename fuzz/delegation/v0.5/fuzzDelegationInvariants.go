@@ -1,35 +1,26 @@
 package delegation
 
 import (
-	"fmt"
+	"strconv"
+
+	scenario "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mandos-go/json/scenario"
 )
 
 func (pfe *fuzzDelegationExecutor) validateOwnerStakeShare() error {
-	_, err := pfe.executeTxStep(fmt.Sprintf(`
-	{
-		"step": "scCall",
-		"txId": "%d",
-		"tx": {
-			"from": "%s",
-			"to": "%s",
-			"value": "0",
-			"function": "validateOwnerStakeShare",
-			"arguments": [],
-			"gasLimit": "50,000,000",
-			"gasPrice": "0"
-		},
-		"expect": {
-			"out": [],
-			"status": "",
-			"logs": [],
-			"gas": "*",
-			"refund": "*"
-		}
-	}`,
-		pfe.nextTxIndex(),
-		pfe.ownerAddress,
-		pfe.delegationContractAddress,
-	))
+	_, err := pfe.executeTx(scenario.NewSCCall().
+		TxID(strconv.Itoa(pfe.nextTxIndex())).
+		From(pfe.ownerAddress).
+		To(pfe.delegationContractAddress).
+		Value("0").
+		Function("validateOwnerStakeShare").
+		GasLimit(50000000).
+		GasPrice(0).
+		Expect(scenario.NewExpect().
+			Out().
+			Status("").
+			NoLogs().
+			Gas("*").
+			Refund("*")))
 	if err != nil {
 		return err
 	}
@@ -38,31 +29,20 @@ func (pfe *fuzzDelegationExecutor) validateOwnerStakeShare() error {
 }
 
 func (pfe *fuzzDelegationExecutor) validateDelegationCapInvariant() error {
-	_, err := pfe.executeTxStep(fmt.Sprintf(`
-	{
-		"step": "scCall",
-		"txId": "%d",
-		"tx": {
-			"from": "%s",
-			"to": "%s",
-			"value": "0",
-			"function": "validateDelegationCapInvariant",
-			"arguments": [],
-			"gasLimit": "50,000,000",
-			"gasPrice": "0"
-		},
-		"expect": {
-			"out": [],
-			"status": "",
-			"logs": [],
-			"gas": "*",
-			"refund": "*"
-		}
-	}`,
-		pfe.nextTxIndex(),
-		pfe.ownerAddress,
-		pfe.delegationContractAddress,
-	))
+	_, err := pfe.executeTx(scenario.NewSCCall().
+		TxID(strconv.Itoa(pfe.nextTxIndex())).
+		From(pfe.ownerAddress).
+		To(pfe.delegationContractAddress).
+		Value("0").
+		Function("validateDelegationCapInvariant").
+		GasLimit(50000000).
+		GasPrice(0).
+		Expect(scenario.NewExpect().
+			Out().
+			Status("").
+			NoLogs().
+			Gas("*").
+			Refund("*")))
 	if err != nil {
 		return err
 	}
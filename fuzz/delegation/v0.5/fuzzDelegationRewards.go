@@ -2,8 +2,10 @@ package delegation
 
 import (
 	"errors"
-	"fmt"
 	"math/big"
+	"strconv"
+
+	scenario "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mandos-go/json/scenario"
 )
 
 func (pfe *fuzzDelegationExecutor) addRewards(amount *big.Int) error {
@@ -11,19 +13,10 @@ func (pfe *fuzzDelegationExecutor) addRewards(amount *big.Int) error {
 	pfe.totalRewards.Add(pfe.totalRewards, amount)
 
 	// simulate system rewards to delegation contract
-	_, err := pfe.executeTxStep(fmt.Sprintf(`
-	{
-		"step": "validatorReward",
-		"txId": "%d",
-		"tx": {
-			"to": "%s",
-			"value": "%d"
-		}
-	}`,
-		pfe.nextTxIndex(),
-		pfe.delegationContractAddress,
-		amount,
-	))
+	_, err := pfe.executeTx(scenario.NewValidatorReward().
+		TxID(strconv.Itoa(pfe.nextTxIndex())).
+		To(pfe.delegationContractAddress).
+		Value(amount.String()))
 	pfe.log("reward: %d", amount)
 	return err
 }
@@ -38,31 +31,20 @@ func (pfe *fuzzDelegationExecutor) claimRewards(delegatorIndex int) error {
 		return nil
 	}
 
-	_, err = pfe.executeTxStep(fmt.Sprintf(`
-	{
-		"step": "scCall",
-		"txId": "%d",
-		"tx": {
-			"from": "%s",
-			"to": "%s",
-			"value": "0",
-			"function": "claimRewards",
-			"arguments": [],
-			"gasLimit": "500,000,000",
-			"gasPrice": "0"
-		},
-		"expect": {
-			"out": [],
-			"status": "",
-			"logs": "*",
-			"gas": "*",
-			"refund": "*"
-		}
-	}`,
-		pfe.nextTxIndex(),
-		pfe.delegatorAddress(delegatorIndex),
-		pfe.delegationContractAddress,
-	))
+	_, err = pfe.executeTx(scenario.NewSCCall().
+		TxID(strconv.Itoa(pfe.nextTxIndex())).
+		From(pfe.delegatorAddress(delegatorIndex)).
+		To(pfe.delegationContractAddress).
+		Value("0").
+		Function("claimRewards").
+		GasLimit(500000000).
+		GasPrice(0).
+		Expect(scenario.NewExpect().
+			Out().
+			Status("").
+			AnyLogs().
+			Gas("*").
+			Refund("*")))
 	if err != nil {
 		return err
 	}
@@ -80,26 +62,14 @@ func (pfe *fuzzDelegationExecutor) claimRewards(delegatorIndex int) error {
 }
 
 func (pfe *fuzzDelegationExecutor) getClaimableRewards(delegatorIndex int) (*big.Int, error) {
-	output, err := pfe.executeTxStep(fmt.Sprintf(`
-	{
-		"step": "scQuery",
-		"txId": "%d",
-		"tx": {
-			"to": "%s",
-			"function": "getClaimableRewards",
-			"arguments": [
-				"%s"
-			]
-		},
-		"expect": {
-			"out": [ "*" ],
-			"status": ""
-		}
-	}`,
-		pfe.nextTxIndex(),
-		pfe.delegationContractAddress,
-		pfe.delegatorAddress(delegatorIndex),
-	))
+	output, err := pfe.executeTx(scenario.NewSCQuery().
+		TxID(strconv.Itoa(pfe.nextTxIndex())).
+		To(pfe.delegationContractAddress).
+		Function("getClaimableRewards").
+		Arguments(pfe.delegatorAddress(delegatorIndex)).
+		Expect(scenario.NewExpect().
+			Out("*").
+			Status("")))
 	if err != nil {
 		return nil, err
 	}
@@ -3,7 +3,9 @@ package delegation
 import (
 	"fmt"
 	"math/big"
+	"strconv"
 
+	scenario "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mandos-go/json/scenario"
 	vmi "github.com/ElrondNetwork/elrond-go/core/vmcommon"
 )
 
@@ -12,70 +14,39 @@ func (pfe *fuzzDelegationExecutor) stake(delegIndex int, amount *big.Int) error
 	pfe.totalStakeAdded.Add(pfe.totalStakeAdded, amount)
 
 	// get the stake from the big sack
-	_, err := pfe.executeTxStep(fmt.Sprintf(`
-	{
-		"step": "transfer",
-		"txId": "%d",
-		"tx": {
-			"from": "%s",
-			"to": "%s",
-			"value": "%d"
-		}
-	}`,
-		pfe.nextTxIndex(),
-		pfe.faucetAddress,
-		pfe.delegatorAddress(delegIndex),
-		amount,
-	))
+	_, err := pfe.executeTx(scenario.NewTransfer().
+		TxID(strconv.Itoa(pfe.nextTxIndex())).
+		From(pfe.faucetAddress).
+		To(pfe.delegatorAddress(delegIndex)).
+		Value(amount.String()))
 	if err != nil {
 		return err
 	}
 
 	// actual staking
-	_, err = pfe.executeTxStep(fmt.Sprintf(`
-	{
-		"step": "scCall",
-		"txId": "%d",
-		"tx": {
-			"from": "%s",
-			"to": "%s",
-			"value": "%d",
-			"function": "stake",
-			"arguments": [],
-			"gasLimit": "100,000,000",
-			"gasPrice": "0"
-		}
-	}`,
-		pfe.nextTxIndex(),
-		pfe.delegatorAddress(delegIndex),
-		pfe.delegationContractAddress,
-		amount,
-	))
+	_, err = pfe.executeTx(scenario.NewSCCall().
+		TxID(strconv.Itoa(pfe.nextTxIndex())).
+		From(pfe.delegatorAddress(delegIndex)).
+		To(pfe.delegationContractAddress).
+		Value(amount.String()).
+		Function("stake").
+		GasLimit(100000000).
+		GasPrice(0))
 	pfe.log("stake, delegator: %d, amount: %d", delegIndex, amount)
 	pfe.printUserStakeByType(delegIndex)
 	return err
 }
 
 func (pfe *fuzzDelegationExecutor) unStake(delegatorIndex int, stake *big.Int) error {
-	output, err := pfe.executeTxStep(fmt.Sprintf(`
-	{
-		"step": "scCall",
-		"txId": "%d",
-		"tx": {
-			"from": "%s",
-			"to": "%s",
-			"value": "0",
-			"function": "unStake",
-			"arguments": ["%d"],
-			"gasLimit": "500,000,000",
-			"gasPrice": "0"
-		}
-	}`,
-		pfe.nextTxIndex(),
-		pfe.delegatorAddress(delegatorIndex),
-		pfe.delegationContractAddress,
-		stake,
-	))
+	output, err := pfe.executeTx(scenario.NewSCCall().
+		TxID(strconv.Itoa(pfe.nextTxIndex())).
+		From(pfe.delegatorAddress(delegatorIndex)).
+		To(pfe.delegationContractAddress).
+		Value("0").
+		Function("unStake").
+		Arguments(stake.String()).
+		GasLimit(500000000).
+		GasPrice(0))
 	if err != nil {
 		return err
 	}
@@ -101,29 +72,18 @@ func (pfe *fuzzDelegationExecutor) unBond(delegatorIndex int) error {
 	callIndex := 0
 	for unbondable.Sign() > 0 {
 		callIndex++
-		output, err := pfe.executeTxStep(fmt.Sprintf(`
-		{
-			"step": "scCall",
-			"txId": "%d",
-			"tx": {
-				"from": "%s",
-				"to": "%s",
-				"value": "0",
-				"function": "unBond",
-				"arguments": [],
-				"gasLimit": "90,000,000",
-				"gasPrice": "0"
-			},
-			"expect": {
-				"out": [ "*" ],
-				"status": "",
-				"refund": "*"
-			}
-		}`,
-			pfe.nextTxIndex(),
-			pfe.delegatorAddress(delegatorIndex),
-			pfe.delegationContractAddress,
-		))
+		output, err := pfe.executeTx(scenario.NewSCCall().
+			TxID(strconv.Itoa(pfe.nextTxIndex())).
+			From(pfe.delegatorAddress(delegatorIndex)).
+			To(pfe.delegationContractAddress).
+			Value("0").
+			Function("unBond").
+			GasLimit(90000000).
+			GasPrice(0).
+			Expect(scenario.NewExpect().
+				Out("*").
+				Status("").
+				Refund("*")))
 		if err != nil {
 			return err
 		}
@@ -149,21 +109,11 @@ func (pfe *fuzzDelegationExecutor) unBond(delegatorIndex int) error {
 	pfe.totalStakeWithdrawn.Add(pfe.totalStakeWithdrawn, unbondedSum)
 
 	if unbondedSum.Cmp(big.NewInt(0)) > 0 {
-		_, err = pfe.executeTxStep(fmt.Sprintf(`
-		{
-			"step": "transfer",
-			"txId": "%d",
-			"tx": {
-				"from": "%s",
-				"to": "%s",
-				"value": "%d"
-			}
-		}`,
-			pfe.nextTxIndex(),
-			pfe.delegatorAddress(delegatorIndex),
-			pfe.withdrawTargetAddress,
-			unbondedSum,
-		))
+		_, err = pfe.executeTx(scenario.NewTransfer().
+			TxID(strconv.Itoa(pfe.nextTxIndex())).
+			From(pfe.delegatorAddress(delegatorIndex)).
+			To(pfe.withdrawTargetAddress).
+			Value(unbondedSum.String()))
 		if err != nil {
 			return err
 		}
@@ -174,26 +124,15 @@ func (pfe *fuzzDelegationExecutor) unBond(delegatorIndex int) error {
 }
 
 func (pfe *fuzzDelegationExecutor) getUserStakeOfType(delegatorIndex int, fundType string) (*big.Int, error) {
-	output, err := pfe.executeTxStep(fmt.Sprintf(`
-	{
-		"step": "scCall",
-		"txId": "%d",
-		"tx": {
-			"from": "%s",
-			"to": "%s",
-			"value": "0",
-			"function": "%s",
-			"arguments": ["%s"],
-			"gasLimit": "100,000,000",
-			"gasPrice": "0"
-		}
-	}`,
-		pfe.nextTxIndex(),
-		pfe.delegatorAddress(delegatorIndex),
-		pfe.delegationContractAddress,
-		fundType,
-		pfe.delegatorAddress(delegatorIndex),
-	))
+	output, err := pfe.executeTx(scenario.NewSCCall().
+		TxID(strconv.Itoa(pfe.nextTxIndex())).
+		From(pfe.delegatorAddress(delegatorIndex)).
+		To(pfe.delegationContractAddress).
+		Value("0").
+		Function(fundType).
+		Arguments(pfe.delegatorAddress(delegatorIndex)).
+		GasLimit(100000000).
+		GasPrice(0))
 	if err != nil {
 		return nil, err
 	}
@@ -207,25 +146,15 @@ func (pfe *fuzzDelegationExecutor) getUserStakeOfType(delegatorIndex int, fundTy
 }
 
 func (pfe *fuzzDelegationExecutor) printUserStakeByType(delegatorIndex int) {
-	output, err := pfe.executeTxStep(fmt.Sprintf(`
-	{
-		"step": "scCall",
-		"txId": "%d",
-		"tx": {
-			"from": "%s",
-			"to": "%s",
-			"value": "0",
-			"function": "getUserStakeByType",
-			"arguments": ["%s"],
-			"gasLimit": "100,000,000",
-			"gasPrice": "0"
-		}
-	}`,
-		pfe.nextTxIndex(),
-		pfe.ownerAddress,
-		pfe.delegationContractAddress,
-		pfe.delegatorAddress(delegatorIndex),
-	))
+	output, err := pfe.executeTx(scenario.NewSCCall().
+		TxID(strconv.Itoa(pfe.nextTxIndex())).
+		From(pfe.ownerAddress).
+		To(pfe.delegationContractAddress).
+		Value("0").
+		Function("getUserStakeByType").
+		Arguments(pfe.delegatorAddress(delegatorIndex)).
+		GasLimit(100000000).
+		GasPrice(0))
 	if err != nil {
 		pfe.log("getUserStakeByType error")
 		return
@@ -236,24 +165,14 @@ func (pfe *fuzzDelegationExecutor) printUserStakeByType(delegatorIndex int) {
 }
 
 func (pfe *fuzzDelegationExecutor) printTotalStakeByType() {
-	output, err := pfe.executeTxStep(fmt.Sprintf(`
-	{
-		"step": "scCall",
-		"txId": "%d",
-		"tx": {
-			"from": "%s",
-			"to": "%s",
-			"value": "0",
-			"function": "getTotalStakeByType",
-			"arguments": [],
-			"gasLimit": "100,000,000",
-			"gasPrice": "0"
-		}
-	}`,
-		pfe.nextTxIndex(),
-		pfe.ownerAddress,
-		pfe.delegationContractAddress,
-	))
+	output, err := pfe.executeTx(scenario.NewSCCall().
+		TxID(strconv.Itoa(pfe.nextTxIndex())).
+		From(pfe.ownerAddress).
+		To(pfe.delegationContractAddress).
+		Value("0").
+		Function("getTotalStakeByType").
+		GasLimit(100000000).
+		GasPrice(0))
 	if err != nil {
 		pfe.log("getTotalStakeByType error")
 		return
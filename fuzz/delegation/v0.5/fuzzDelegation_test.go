@@ -9,6 +9,7 @@ import (
 	"testing"
 	"time"
 
+	fuzzcore "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/fuzz/core"
 	fuzzutil "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/fuzz/util"
 	mc "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mandos-go/controller"
 	"github.com/stretchr/testify/assert"
@@ -21,6 +22,25 @@ var seedFlag = flag.Int64("seed", 0, "Random seed, use it to replay fuzz scenari
 
 var iterationsFlag = flag.Int("iterations", 1000, "Number of iterations")
 
+var checkpointFlag = flag.String("checkpoint", "", "File to periodically save the state of the fuzz campaign to")
+
+var checkpointIntervalFlag = flag.Int("checkpointInterval", 100, "Number of iterations between checkpoints")
+
+var resumeFlag = flag.Bool("resume", false, "Resume the fuzz campaign from the checkpoint file")
+
+var coverageFlag = flag.Bool("coverage", false, "Favour the events that reach new contract behaviour, instead of fixed probabilities")
+
+var replayFileFlag = flag.String("replayFile", "fuzz_failure.replay.json", "File to save the events to, if the fuzz campaign fails")
+
+var replayFlag = flag.String("replay", "", "Replay file to execute again, instead of the ones in the replays folder")
+
+// replaysPattern matches the replays of past failures, which are executed as regression tests.
+const replaysPattern = "replays/*.replay.json"
+
+var stakePerNode = big.NewInt(1000000000)
+
+var maxDelegationCap = big.NewInt(0).Mul(stakePerNode, big.NewInt(4))
+
 func getTestRoot() string {
 	exePath, err := os.Getwd()
 	if err != nil {
@@ -54,42 +74,35 @@ func TestFuzzDelegation_v0_5(t *testing.T) {
 	pfe := newExecutorWithPaths()
 	defer pfe.saveGeneratedScenario()
 
-	var seed int64
-	if *seedFlag == 0 {
-		seed = time.Now().UnixNano()
-	} else {
-		seed = *seedFlag
+	campaign := startFuzzCampaign(t, pfe)
+	defer func() {
+		pfe.log(campaign.Report())
+	}()
+
+	r := campaign.Rand()
+	harness := newFuzzHarness(t, pfe, campaign)
+	harness.SetReplayPath(*replayFileFlag)
+	if *coverageFlag {
+		coverage := fuzzcore.NewCoverageTracker()
+		pfe.addTxHook(fuzzcore.NewVMFeedbackHook(coverage))
+		harness.SetCoverageGuidance(coverage)
+		defer func() {
+			pfe.log("Coverage: %d distinct features reached\n", coverage.Len())
+		}()
+	}
+	if *checkpointFlag != "" {
+		harness.AddObserver(fuzzcore.NewCheckpointObserver(
+			campaign,
+			*checkpointFlag,
+			*checkpointIntervalFlag,
+			func() (interface{}, string) {
+				return pfe.getState(), pfe.getGeneratedScenario()
+			}))
 	}
-	pfe.log("Random seed: %d\n", seed)
-	r := rand.New(rand.NewSource(seed))
-	r.Seed(seed)
-
-	stakePerNode := big.NewInt(1000000000)
-	numDelegators := 10
-	maxDelegationCap := big.NewInt(0).Mul(stakePerNode, big.NewInt(int64(4)))
-
-	err := pfe.init(
-		&fuzzDelegationExecutorInitArgs{
-			serviceFee:                  r.Intn(10000),
-			ownerMinStake:               0,
-			minStake:                    r.Intn(1000000),
-			numBlocksBeforeForceUnstake: r.Intn(1000),
-			numBlocksBeforeUnbond:       r.Intn(1000),
-			numDelegators:               numDelegators,
-			stakePerNode:                stakePerNode,
-			totalDelegationCap:          big.NewInt(0).Rand(r, maxDelegationCap),
-		},
-	)
-	require.Nil(t, err)
 
-	err = pfe.increaseBlockNonce(r.Intn(10000))
+	err := harness.Run(*iterationsFlag)
 	require.Nil(t, err)
 
-	re := fuzzutil.NewRandomEventProvider(r)
-	for stepIndex := 0; stepIndex < *iterationsFlag; stepIndex++ {
-		generateRandomEvent(t, pfe, r, re, maxDelegationCap)
-	}
-
 	err = pfe.increaseBlockNonce(r.Intn(pfe.numBlocksBeforeUnbond + 1))
 	require.Nil(t, err)
 
@@ -157,117 +170,192 @@ func TestFuzzDelegation_v0_5(t *testing.T) {
 		big.NewInt(0).Sub(pfe.totalStakeAdded, activeAndWithdrawn))
 }
 
-func generateRandomEvent(
-	t *testing.T,
-	pfe *fuzzDelegationExecutor,
-	r *rand.Rand,
-	re *fuzzutil.RandomEventProvider,
-	maxDelegationCap *big.Int,
-) {
-	maxStake := big.NewInt(0).Mul(pfe.stakePerNode, big.NewInt(2))
-	maxSystemReward := big.NewInt(1000000000)
-	maxDust := big.NewInt(0).Div(pfe.stakePerNode, big.NewInt(4))
-	maxServiceFee := 10000
-	re.Reset()
-
-	switch {
-	case re.WithProbability(0.05):
-		// increment block nonce
-		err := pfe.increaseBlockNonce(r.Intn(1000))
-		require.Nil(t, err)
+// TestFuzzDelegation_v0_5_Replays executes again the events of failed fuzz campaigns,
+// saved in the replays folder, or the replay given with the -replay argument.
+func TestFuzzDelegation_v0_5_Replays(t *testing.T) {
+	replayPaths, err := filepath.Glob(replaysPattern)
+	require.Nil(t, err)
+	if *replayFlag != "" {
+		replayPaths = []string{*replayFlag}
+	}
+	if len(replayPaths) == 0 {
+		t.Skip("no fuzz replays")
+	}
 
-		pfe.checkInvariants(t)
-	case re.WithProbability(0.05):
-		// add nodes
-		err := pfe.addNodes(r.Intn(3))
-		require.Nil(t, err)
+	for _, replayPath := range replayPaths {
+		replayPath := replayPath
+		t.Run(filepath.Base(replayPath), func(t *testing.T) {
+			replay, err := fuzzcore.LoadReplay(replayPath)
+			require.Nil(t, err)
 
-		pfe.checkInvariants(t)
-	case re.WithProbability(0.05):
-		// add nodes
-		err := pfe.removeNodes(r.Intn(2))
-		require.Nil(t, err)
-	case re.WithProbability(0.05):
-		// stake
-		delegatorIdx := r.Intn(pfe.numDelegators + 1)
-		stake := big.NewInt(0).Rand(r, maxStake)
+			pfe := newExecutorWithPaths()
+			campaign := startReplayCampaign(t, pfe, replay)
+			harness := newFuzzHarness(t, pfe, campaign)
+			err = harness.Replay(replay)
+			require.Nil(t, err, "replayed failure: %s", replay.Failure)
+		})
+	}
+}
 
-		err := pfe.stake(delegatorIdx, stake)
+// startFuzzCampaign either resumes the campaign saved in the checkpoint file
+// or starts a new one, from the given seed or from a random one.
+func startFuzzCampaign(t *testing.T, pfe *fuzzDelegationExecutor) *fuzzutil.Campaign {
+	if *resumeFlag {
+		campaign, err := fuzzutil.ResumeCampaign(*checkpointFlag)
 		require.Nil(t, err)
 
-		pfe.checkInvariants(t)
-	case re.WithProbability(0.05):
-		// rewards
-		ok, err := pfe.isBootstrapMode()
+		err = pfe.restore(campaign.ExecutorState(), campaign.Scenario())
 		require.Nil(t, err)
 
-		if !ok {
-			// add system rewards
-			rewards := big.NewInt(0).Rand(r, maxSystemReward)
-
-			err := pfe.addRewards(rewards)
-			require.Nil(t, err)
+		pfe.log("Resumed random seed: %d, at step %d\n", campaign.Seed(), campaign.StepIndex())
+		return campaign
+	}
 
-			pfe.checkInvariants(t)
-		}
-	case re.WithProbability(0.2):
-		// claim rewards
-		delegatorIdx := r.Intn(pfe.numDelegators + 1)
+	var seed int64
+	if *seedFlag == 0 {
+		seed = time.Now().UnixNano()
+	} else {
+		seed = *seedFlag
+	}
+	pfe.log("Random seed: %d\n", seed)
+	campaign := fuzzutil.NewCampaign(seed)
+	initFuzzCampaign(t, pfe, campaign)
+	return campaign
+}
 
-		err := pfe.claimRewards(delegatorIdx)
+// startReplayCampaign brings the executor to the state the replayed campaign started from.
+func startReplayCampaign(t *testing.T, pfe *fuzzDelegationExecutor, replay *fuzzcore.Replay) *fuzzutil.Campaign {
+	campaign := fuzzutil.NewCampaign(replay.Seed)
+	if replay.IsResumed() {
+		err := pfe.restore(replay.ExecutorState, replay.Scenario)
 		require.Nil(t, err)
-	case re.WithProbability(0.05):
-		// unStake
-		delegatorIdx := r.Intn(pfe.numDelegators + 1)
-		stake := big.NewInt(0).Rand(r, maxStake)
+		return campaign
+	}
 
-		err := pfe.unStake(delegatorIdx, stake)
-		require.Nil(t, err)
+	initFuzzCampaign(t, pfe, campaign)
+	return campaign
+}
 
-		pfe.checkInvariants(t)
-	case re.WithProbability(0.05):
-		// unBond
-		delegatorIdx := r.Intn(pfe.numDelegators + 1)
-		err := pfe.unBond(delegatorIdx)
-		require.Nil(t, err)
-	case re.WithProbability(0.05):
-		// delegation cap
-		err := pfe.modifyDelegationCap(big.NewInt(0).Rand(r, maxDelegationCap))
-		require.Nil(t, err)
+func initFuzzCampaign(t *testing.T, pfe *fuzzDelegationExecutor, campaign *fuzzutil.Campaign) {
+	r := campaign.Rand()
+	numDelegators := 10
 
-		err = pfe.continueGlobalOperation()
-		require.Nil(t, err)
+	err := pfe.init(
+		&fuzzDelegationExecutorInitArgs{
+			serviceFee:                  r.Intn(10000),
+			ownerMinStake:               0,
+			minStake:                    r.Intn(1000000),
+			numBlocksBeforeForceUnstake: r.Intn(1000),
+			numBlocksBeforeUnbond:       r.Intn(1000),
+			numDelegators:               numDelegators,
+			stakePerNode:                stakePerNode,
+			totalDelegationCap:          big.NewInt(0).Rand(r, maxDelegationCap),
+		},
+	)
+	require.Nil(t, err)
 
-		pfe.printServiceFeeAndDelegationCap(t)
-		pfe.printTotalStakeByType()
+	err = pfe.increaseBlockNonce(r.Intn(10000))
+	require.Nil(t, err)
+}
 
-		pfe.checkInvariants(t)
-	case re.WithProbability(0.05):
-		// service fee
-		err := pfe.setServiceFee(r.Intn(maxServiceFee))
-		require.Nil(t, err)
+func newFuzzHarness(t *testing.T, pfe *fuzzDelegationExecutor, campaign *fuzzutil.Campaign) *fuzzcore.Harness {
+	harness := fuzzcore.NewHarness(campaign)
+	err := registerFuzzEvents(harness, pfe)
+	require.Nil(t, err)
+	harness.AddInvariant(fuzzcore.NewInvariant("validateOwnerStakeShare", pfe.validateOwnerStakeShare))
+	harness.AddInvariant(fuzzcore.NewInvariant("validateDelegationCapInvariant", pfe.validateDelegationCapInvariant))
+	return harness
+}
 
-		err = pfe.continueGlobalOperation()
-		require.Nil(t, err)
+// registerFuzzEvents adds the delegation events to the harness.
+// The order of the events matters, since it determines the events generated from a seed.
+func registerFuzzEvents(harness *fuzzcore.Harness, pfe *fuzzDelegationExecutor) error {
+	maxServiceFee := 10000
+	maxSystemReward := big.NewInt(1000000000)
+	maxStake := func() *big.Int {
+		return big.NewInt(0).Mul(pfe.stakePerNode, big.NewInt(2))
+	}
+	maxDust := func() *big.Int {
+		return big.NewInt(0).Div(pfe.stakePerNode, big.NewInt(4))
+	}
 
-		pfe.printServiceFeeAndDelegationCap(t)
-		pfe.printTotalStakeByType()
+	events := []fuzzcore.EventGenerator{
+		fuzzcore.NewEvent("increaseBlockNonce", 0.05, func(r *rand.Rand) (bool, error) {
+			return true, pfe.increaseBlockNonce(r.Intn(1000))
+		}),
+		fuzzcore.NewEvent("addNodes", 0.05, func(r *rand.Rand) (bool, error) {
+			return true, pfe.addNodes(r.Intn(3))
+		}),
+		fuzzcore.NewEvent("removeNodes", 0.05, func(r *rand.Rand) (bool, error) {
+			return false, pfe.removeNodes(r, r.Intn(2))
+		}),
+		fuzzcore.NewEvent("stake", 0.05, func(r *rand.Rand) (bool, error) {
+			delegatorIdx := r.Intn(pfe.numDelegators + 1)
+			stake := big.NewInt(0).Rand(r, maxStake())
+			return true, pfe.stake(delegatorIdx, stake)
+		}),
+		fuzzcore.NewEvent("addRewards", 0.05, func(r *rand.Rand) (bool, error) {
+			isBootstrapMode, err := pfe.isBootstrapMode()
+			if err != nil || isBootstrapMode {
+				return false, err
+			}
 
-		pfe.checkInvariants(t)
-	case re.WithProbability(0.01):
-		// dust
-		dustLimit := big.NewInt(0).Rand(r, maxDust)
-		err := pfe.dustCleanup(dustLimit)
-		require.Nil(t, err)
-		pfe.checkInvariants(t)
-	default:
+			// add system rewards
+			rewards := big.NewInt(0).Rand(r, maxSystemReward)
+			return true, pfe.addRewards(rewards)
+		}),
+		fuzzcore.NewEvent("claimRewards", 0.2, func(r *rand.Rand) (bool, error) {
+			delegatorIdx := r.Intn(pfe.numDelegators + 1)
+			return false, pfe.claimRewards(delegatorIdx)
+		}),
+		fuzzcore.NewEvent("unStake", 0.05, func(r *rand.Rand) (bool, error) {
+			delegatorIdx := r.Intn(pfe.numDelegators + 1)
+			stake := big.NewInt(0).Rand(r, maxStake())
+			return true, pfe.unStake(delegatorIdx, stake)
+		}),
+		fuzzcore.NewEvent("unBond", 0.05, func(r *rand.Rand) (bool, error) {
+			delegatorIdx := r.Intn(pfe.numDelegators + 1)
+			return false, pfe.unBond(delegatorIdx)
+		}),
+		fuzzcore.NewEvent("modifyDelegationCap", 0.05, func(r *rand.Rand) (bool, error) {
+			err := pfe.modifyDelegationCap(big.NewInt(0).Rand(r, maxDelegationCap))
+			if err != nil {
+				return false, err
+			}
+			return true, pfe.continueGlobalOperationAndPrint()
+		}),
+		fuzzcore.NewEvent("setServiceFee", 0.05, func(r *rand.Rand) (bool, error) {
+			err := pfe.setServiceFee(r.Intn(maxServiceFee))
+			if err != nil {
+				return false, err
+			}
+			return true, pfe.continueGlobalOperationAndPrint()
+		}),
+		fuzzcore.NewEvent("dustCleanup", 0.01, func(r *rand.Rand) (bool, error) {
+			dustLimit := big.NewInt(0).Rand(r, maxDust())
+			return true, pfe.dustCleanup(dustLimit)
+		}),
 	}
+
+	for _, event := range events {
+		err := harness.AddEvent(event)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
-func (pfe *fuzzDelegationExecutor) checkInvariants(t *testing.T) {
-	err := pfe.validateOwnerStakeShare()
-	require.Nil(t, err)
+func (pfe *fuzzDelegationExecutor) continueGlobalOperationAndPrint() error {
+	err := pfe.continueGlobalOperation()
+	if err != nil {
+		return err
+	}
 
-	err = pfe.validateDelegationCapInvariant()
-	require.Nil(t, err)
+	err = pfe.printServiceFeeAndDelegationCap()
+	if err != nil {
+		return err
+	}
+	pfe.printTotalStakeByType()
+	return nil
 }
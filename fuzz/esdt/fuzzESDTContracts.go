@@ -0,0 +1,47 @@
+package esdt
+
+import (
+	"math/big"
+
+	mock "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/context"
+	vmi "github.com/ElrondNetwork/elrond-go/core/vmcommon"
+)
+
+// fuzzESDTFunction is the function of the holders which calls a built-in
+// function: its arguments are the recipient of the built-in function call,
+// the name of the built-in function and its arguments
+const fuzzESDTFunction = "fuzzESDT"
+
+// addFuzzESDTMethod adds to the mock contract the function which calls a
+// built-in function on its own behalf, like a contract does through the
+// executeOnDestContext API, failing if the built-in function fails
+func addFuzzESDTMethod(instanceMock *mock.InstanceMock) {
+	instanceMock.AddMockMethod(fuzzESDTFunction, func() *mock.InstanceMock {
+		host := instanceMock.Host
+		instance := mock.GetMockInstance(host)
+
+		arguments := host.Runtime().Arguments()
+		if len(arguments) < 2 {
+			host.Runtime().SignalUserError("wrong number of arguments")
+			return instance
+		}
+
+		input := &vmi.ContractCallInput{
+			VMInput: vmi.VMInput{
+				CallerAddr:  host.Runtime().GetSCAddress(),
+				Arguments:   arguments[2:],
+				CallValue:   big.NewInt(0),
+				GasPrice:    0,
+				GasProvided: host.Metering().GasLeft(),
+			},
+			RecipientAddr: arguments[0],
+			Function:      string(arguments[1]),
+		}
+
+		_, _, err := host.ExecuteOnDestContext(input)
+		if err != nil {
+			host.Runtime().FailExecution(err)
+		}
+		return instance
+	})
+}
@@ -0,0 +1,217 @@
+package esdt
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strconv"
+	"testing"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
+	am "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwenmandos"
+	mj "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mandos-go/json/model"
+	mjparse "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mandos-go/json/parse"
+	scenario "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mandos-go/json/scenario"
+	mock "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/context"
+	worldhook "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/world"
+	test "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/testcommon"
+	"github.com/ElrondNetwork/elrond-go/core"
+	vmi "github.com/ElrondNetwork/elrond-go/core/vmcommon"
+	"github.com/ElrondNetwork/elrond-go/vm"
+)
+
+const initialBalance = 1000000
+
+const txGasLimit = 10000000
+
+// fuzzHolder is a mock contract holding tokens, which calls the ESDT
+// built-in functions on behalf of the fuzzer
+type fuzzHolder struct {
+	index    int
+	address  []byte
+	instance *mock.InstanceMock
+}
+
+// fuzzToken is a token issued during the fuzz campaign, along with its
+// expected supply, by nonce; the supply of a fungible token is at nonce 0
+type fuzzToken struct {
+	identifier []byte
+	fungible   bool
+	creator    *fuzzHolder
+	supply     map[uint64]*big.Int
+}
+
+type fuzzESDTExecutor struct {
+	arwenTestExecutor *am.ArwenTestExecutor
+	world             *worldhook.ChainSimulator
+	mandosParser      mjparse.Parser
+	txIndex           int
+	userAddress       []byte
+	holders           []*fuzzHolder
+	tokens            []*fuzzToken
+	totalBalance      *big.Int
+	txHooks           []am.StepHook
+
+	// the last built-in function called by a holder, nil after the
+	// operations of the ESDT system SC
+	lastOperation *esdtOperation
+}
+
+func newFuzzESDTExecutor(t testing.TB, numHolders int) (*fuzzESDTExecutor, error) {
+	arwenTestExecutor, err := am.NewArwenTestExecutor()
+	if err != nil {
+		return nil, err
+	}
+
+	host := arwenTestExecutor.GetVM().(arwen.VMHost)
+	instanceBuilder := mock.NewInstanceBuilderMock(arwenTestExecutor.World)
+	host.Runtime().ReplaceInstanceBuilder(instanceBuilder)
+
+	pfe := &fuzzESDTExecutor{
+		arwenTestExecutor: arwenTestExecutor,
+		world:             worldhook.NewChainSimulatorWithWorld(arwenTestExecutor.World, 1),
+		mandosParser:      mjparse.NewParser(nil),
+		userAddress:       test.MakeTestSCAddress("esdtFuzzUser"),
+		totalBalance:      big.NewInt(0),
+	}
+
+	pfe.world.CreateUserAccount(pfe.userAddress, big.NewInt(initialBalance))
+
+	// the ESDT system SC lives in the metachain, so the built-in functions
+	// only ever see it as the sender of cross-shard calls
+	esdtSystemSC := pfe.world.CreateUserAccount(vm.ESDTSCAddress, big.NewInt(0))
+	esdtSystemSC.ShardID = core.MetachainShardId
+
+	for i := 0; i < numHolders; i++ {
+		address := test.MakeTestSCAddress(fmt.Sprintf("esdtFuzzHolder%d", i))
+		instance := instanceBuilder.CreateAndStoreInstanceMock(t, host, address, 0, initialBalance)
+		addFuzzESDTMethod(instance)
+		pfe.holders = append(pfe.holders, &fuzzHolder{
+			index:    i,
+			address:  address,
+			instance: instance,
+		})
+	}
+
+	pfe.totalBalance = pfe.getTotalBalance()
+	return pfe, nil
+}
+
+// callBuiltinFunction makes the given holder call a built-in function, from
+// within its contract, through a transaction of the user
+func (pfe *fuzzESDTExecutor) callBuiltinFunction(
+	holder *fuzzHolder,
+	recipient []byte,
+	function string,
+	arguments ...[]byte,
+) (*vmi.VMOutput, error) {
+	txArguments := []string{addressExpr(recipient), "str:" + function}
+	for _, argument := range arguments {
+		txArguments = append(txArguments, "0x"+hex.EncodeToString(argument))
+	}
+
+	txStep, err := scenario.NewSCCall().
+		TxID(strconv.Itoa(pfe.nextTxIndex())).
+		From(addressExpr(pfe.userAddress)).
+		To(addressExpr(holder.address)).
+		Function(fuzzESDTFunction).
+		Arguments(txArguments...).
+		GasLimit(txGasLimit).
+		GasPrice(0).
+		Build(pfe.mandosParser.ExprInterpreter)
+	if err != nil {
+		return nil, err
+	}
+
+	// a failed call is an expected outcome, the invariants judge it
+	output, _ := pfe.arwenTestExecutor.ExecuteTxStep(txStep)
+	if output == nil {
+		return nil, fmt.Errorf("no output for %s called by holder %d", function, holder.index)
+	}
+
+	return output, pfe.runTxHooks(txStep, output)
+}
+
+// callAsSystemSC executes a built-in function as the ESDT system SC does
+// when it manages the tokens: from the metachain, bypassing the VM
+func (pfe *fuzzESDTExecutor) callAsSystemSC(recipient []byte, function string, arguments ...[]byte) error {
+	pfe.lastOperation = nil
+	pfe.world.SelfShardID = 0
+	pfe.world.CreateStateBackup()
+
+	output, err := pfe.world.BuiltinFuncs.ProcessBuiltInFunction(&vmi.ContractCallInput{
+		VMInput: vmi.VMInput{
+			CallerAddr:  vm.ESDTSCAddress,
+			Arguments:   arguments,
+			CallValue:   big.NewInt(0),
+			GasProvided: txGasLimit,
+		},
+		RecipientAddr: recipient,
+		Function:      function,
+	})
+	if err != nil {
+		_ = pfe.world.RollbackChanges()
+		return fmt.Errorf("%s by the ESDT system SC: %w", function, err)
+	}
+	if output.ReturnCode != vmi.Ok {
+		_ = pfe.world.RollbackChanges()
+		return fmt.Errorf("%s by the ESDT system SC: %s", function, output.ReturnMessage)
+	}
+
+	return pfe.world.CommitChanges()
+}
+
+func (pfe *fuzzESDTExecutor) runTxHooks(step mj.Step, output *vmi.VMOutput) error {
+	for _, hook := range pfe.txHooks {
+		err := hook(pfe.arwenTestExecutor.NewStepHookContext(step, output))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (pfe *fuzzESDTExecutor) addTxHook(hook am.StepHook) {
+	pfe.txHooks = append(pfe.txHooks, hook)
+}
+
+// getTokenData returns the data of the given token instance held by the
+// given holder; a token never held has no value
+func (pfe *fuzzESDTExecutor) getTokenData(holder *fuzzHolder, tokenKey []byte) (*tokenData, error) {
+	account := pfe.world.AcctMap.GetAccount(holder.address)
+	esdtData, err := account.GetTokenData(tokenKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tokenData{
+		value:  esdtData.Value,
+		frozen: isFrozen(esdtData.Properties),
+	}, nil
+}
+
+func (pfe *fuzzESDTExecutor) getRoles(holder *fuzzHolder, token *fuzzToken) ([][]byte, error) {
+	account := pfe.world.AcctMap.GetAccount(holder.address)
+	return account.GetTokenRoles(token.identifier)
+}
+
+func (pfe *fuzzESDTExecutor) getTotalBalance() *big.Int {
+	totalBalance := big.NewInt(0)
+	for _, account := range pfe.world.AcctMap {
+		totalBalance.Add(totalBalance, account.Balance)
+	}
+	return totalBalance
+}
+
+func (pfe *fuzzESDTExecutor) nextTxIndex() int {
+	pfe.txIndex++
+	return pfe.txIndex
+}
+
+func (pfe *fuzzESDTExecutor) log(info string, args ...interface{}) {
+	fmt.Printf(info+"\n", args...)
+}
+
+func addressExpr(address []byte) string {
+	return "0x" + hex.EncodeToString(address)
+}
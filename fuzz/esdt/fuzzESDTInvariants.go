@@ -0,0 +1,129 @@
+package esdt
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ElrondNetwork/elrond-go/process"
+)
+
+// checkTotalSupply verifies that the balances of each token instance add up
+// to its supply: issued, minted or created, less burned
+func (pfe *fuzzESDTExecutor) checkTotalSupply() error {
+	state, err := pfe.getTokenState()
+	if err != nil {
+		return err
+	}
+
+	for _, token := range pfe.tokens {
+		tokenKeys, err := pfe.getTokenKeys(token)
+		if err != nil {
+			return err
+		}
+
+		for nonce, tokenKey := range tokenKeys {
+			if !token.fungible {
+				nonce++
+			}
+
+			totalBalance := big.NewInt(0)
+			for _, holder := range pfe.holders {
+				totalBalance.Add(totalBalance, state[tokenStateKey(holder, tokenKey)].value)
+			}
+
+			supply := token.supply[uint64(nonce)]
+			if supply == nil {
+				supply = big.NewInt(0)
+			}
+			if totalBalance.Cmp(supply) != 0 {
+				return fmt.Errorf("the balances of %s, nonce %d, add up to %d, instead of a supply of %d",
+					token.identifier, nonce, totalBalance, supply)
+			}
+		}
+	}
+
+	return nil
+}
+
+// checkRolesEnforced verifies that the last operation succeeded only if the
+// holder had the roles it requires, and that it was not refused for lack of
+// a role the holder had
+func (pfe *fuzzESDTExecutor) checkRolesEnforced() error {
+	op := pfe.lastOperation
+	if op == nil {
+		return nil
+	}
+
+	hasRoles := true
+	for _, role := range op.requiredRoles {
+		hasRoles = hasRoles && hasRole(op.rolesBefore, role)
+	}
+
+	if op.succeeded && !hasRoles {
+		return fmt.Errorf("%s by holder %d succeeded without the roles %v, having %s",
+			op.function, op.holder.index, op.requiredRoles, op.rolesBefore)
+	}
+	if !op.succeeded && hasRoles && strings.Contains(op.returnMessage, process.ErrActionNotAllowed.Error()) {
+		return fmt.Errorf("%s by holder %d refused, having the roles %v",
+			op.function, op.holder.index, op.requiredRoles)
+	}
+
+	return nil
+}
+
+// checkFreezeEnforced verifies that the last operation changed no frozen
+// balance, whether as sender, as receiver, or by minting or burning
+func (pfe *fuzzESDTExecutor) checkFreezeEnforced() error {
+	op := pfe.lastOperation
+	if op == nil {
+		return nil
+	}
+
+	for key, before := range op.stateBefore {
+		if !before.frozen {
+			continue
+		}
+
+		after := op.stateAfter[key]
+		if after.value.Cmp(before.value) != 0 {
+			return fmt.Errorf("%s by holder %d changed the frozen balance of %s from %d to %d",
+				op.function, op.holder.index, key, before.value, after.value)
+		}
+	}
+
+	return nil
+}
+
+// checkBalanceConservation verifies that a failed operation left the tokens
+// as they were, and that no operation moved EGLD, since the gas is free
+func (pfe *fuzzESDTExecutor) checkBalanceConservation() error {
+	totalBalance := pfe.getTotalBalance()
+	if totalBalance.Cmp(pfe.totalBalance) != 0 {
+		return fmt.Errorf("total EGLD balance changed from %d to %d", pfe.totalBalance, totalBalance)
+	}
+
+	op := pfe.lastOperation
+	if op == nil || op.succeeded {
+		return nil
+	}
+
+	for key, before := range op.stateBefore {
+		after := op.stateAfter[key]
+		if after.value.Cmp(before.value) != 0 || after.frozen != before.frozen {
+			return fmt.Errorf("%s by holder %d failed with \"%s\", but changed %s from %d to %d",
+				op.function, op.holder.index, op.returnMessage, key, before.value, after.value)
+		}
+	}
+
+	return nil
+}
+
+func hasRole(roles [][]byte, role string) bool {
+	for _, heldRole := range roles {
+		if string(heldRole) == role {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,418 @@
+package esdt
+
+import (
+	"fmt"
+	"math/big"
+	"math/rand"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
+	worldhook "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/world"
+	"github.com/ElrondNetwork/elrond-go/core"
+	vmi "github.com/ElrondNetwork/elrond-go/core/vmcommon"
+	"github.com/ElrondNetwork/elrond-go/process/smartContract/builtInFunctions"
+)
+
+const maxAmount = 1000
+
+const maxNFTQuantity = 10
+
+const maxTokensInMultiTransfer = 3
+
+// tokenData is the part of the ESDT data of a token instance which the
+// invariants look at
+type tokenData struct {
+	value  *big.Int
+	frozen bool
+}
+
+// esdtOperation is a built-in function called by a holder, along with the
+// state of the tokens before and after it, which the invariants judge
+type esdtOperation struct {
+	function      string
+	holder        *fuzzHolder
+	requiredRoles []string
+	rolesBefore   [][]byte
+	succeeded     bool
+	returnMessage string
+	stateBefore   map[string]*tokenData
+	stateAfter    map[string]*tokenData
+}
+
+// executeOperation makes the holder call the built-in function and records
+// the outcome as the last operation; the roles are those of the given token,
+// if the built-in function requires any
+func (pfe *fuzzESDTExecutor) executeOperation(
+	holder *fuzzHolder,
+	token *fuzzToken,
+	requiredRoles []string,
+	recipient []byte,
+	function string,
+	arguments ...[]byte,
+) (*esdtOperation, error) {
+	stateBefore, err := pfe.getTokenState()
+	if err != nil {
+		return nil, err
+	}
+	var rolesBefore [][]byte
+	if token != nil {
+		rolesBefore, err = pfe.getRoles(holder, token)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	output, err := pfe.callBuiltinFunction(holder, recipient, function, arguments...)
+	if err != nil {
+		return nil, err
+	}
+
+	stateAfter, err := pfe.getTokenState()
+	if err != nil {
+		return nil, err
+	}
+
+	pfe.lastOperation = &esdtOperation{
+		function:      function,
+		holder:        holder,
+		requiredRoles: requiredRoles,
+		rolesBefore:   rolesBefore,
+		succeeded:     output.ReturnCode == vmi.Ok,
+		returnMessage: output.ReturnMessage,
+		stateBefore:   stateBefore,
+		stateAfter:    stateAfter,
+	}
+	return pfe.lastOperation, nil
+}
+
+func (pfe *fuzzESDTExecutor) localMint(r *rand.Rand) error {
+	token := pfe.randomToken(r, true)
+	if token == nil {
+		return nil
+	}
+
+	holder := pfe.randomHolder(r)
+	amount := randomAmount(r, maxAmount)
+	op, err := pfe.executeOperation(holder, token, []string{core.ESDTRoleLocalMint},
+		holder.address, core.BuiltInFunctionESDTLocalMint, token.identifier, amount.Bytes())
+	if err != nil || !op.succeeded {
+		return err
+	}
+
+	token.supply[0].Add(token.supply[0], amount)
+	return nil
+}
+
+func (pfe *fuzzESDTExecutor) localBurn(r *rand.Rand) error {
+	token := pfe.randomToken(r, true)
+	if token == nil {
+		return nil
+	}
+
+	holder, err := pfe.randomOwner(r, worldhook.MakeTokenKey(token.identifier, 0))
+	if err != nil {
+		return err
+	}
+	amount := randomAmount(r, maxAmount)
+	op, err := pfe.executeOperation(holder, token, []string{core.ESDTRoleLocalBurn},
+		holder.address, core.BuiltInFunctionESDTLocalBurn, token.identifier, amount.Bytes())
+	if err != nil || !op.succeeded {
+		return err
+	}
+
+	token.supply[0].Sub(token.supply[0], amount)
+	return nil
+}
+
+func (pfe *fuzzESDTExecutor) transfer(r *rand.Rand) error {
+	token := pfe.randomToken(r, true)
+	if token == nil {
+		return nil
+	}
+
+	holder, err := pfe.randomOwner(r, worldhook.MakeTokenKey(token.identifier, 0))
+	if err != nil {
+		return err
+	}
+	destination := pfe.randomOtherHolder(r, holder)
+	amount := randomAmount(r, maxAmount)
+	_, err = pfe.executeOperation(holder, token, nil,
+		destination.address, core.BuiltInFunctionESDTTransfer, token.identifier, amount.Bytes())
+	return err
+}
+
+func (pfe *fuzzESDTExecutor) nftCreate(r *rand.Rand) error {
+	token := pfe.randomToken(r, false)
+	if token == nil {
+		return nil
+	}
+
+	// mostly the creator, the only holder allowed to create
+	holder := token.creator
+	if r.Intn(4) == 0 {
+		holder = pfe.randomHolder(r)
+	}
+
+	quantity := big.NewInt(1)
+	if r.Intn(2) == 0 {
+		quantity = randomAmount(r, maxNFTQuantity)
+	}
+	requiredRoles := []string{core.ESDTRoleNFTCreate}
+	if quantity.Cmp(big.NewInt(1)) > 0 {
+		requiredRoles = append(requiredRoles, core.ESDTRoleNFTAddQuantity)
+	}
+
+	royalties := big.NewInt(int64(r.Intn(int(core.MaxRoyalty) + 1)))
+	op, err := pfe.executeOperation(holder, token, requiredRoles,
+		holder.address, core.BuiltInFunctionESDTNFTCreate,
+		token.identifier,
+		quantity.Bytes(),
+		[]byte(fmt.Sprintf("nft%d", pfe.txIndex)),
+		royalties.Bytes(),
+		[]byte("hash"),
+		[]byte("attributes"),
+		[]byte("uri"))
+	if err != nil || !op.succeeded {
+		return err
+	}
+
+	nonce, err := pfe.getLastNonce(token)
+	if err != nil {
+		return err
+	}
+	token.supply[nonce] = quantity
+	return nil
+}
+
+func (pfe *fuzzESDTExecutor) nftAddQuantity(r *rand.Rand) error {
+	token, nonce, err := pfe.randomNFT(r)
+	if err != nil || token == nil {
+		return err
+	}
+
+	holder, err := pfe.randomOwner(r, worldhook.MakeTokenKey(token.identifier, nonce))
+	if err != nil {
+		return err
+	}
+	quantity := randomAmount(r, maxNFTQuantity)
+	op, err := pfe.executeOperation(holder, token, []string{core.ESDTRoleNFTAddQuantity},
+		holder.address, core.BuiltInFunctionESDTNFTAddQuantity, token.identifier, nonceBytes(nonce), quantity.Bytes())
+	if err != nil || !op.succeeded {
+		return err
+	}
+
+	token.supply[nonce].Add(token.supply[nonce], quantity)
+	return nil
+}
+
+func (pfe *fuzzESDTExecutor) nftBurn(r *rand.Rand) error {
+	token, nonce, err := pfe.randomNFT(r)
+	if err != nil || token == nil {
+		return err
+	}
+
+	holder, err := pfe.randomOwner(r, worldhook.MakeTokenKey(token.identifier, nonce))
+	if err != nil {
+		return err
+	}
+	quantity := randomAmount(r, maxNFTQuantity)
+	op, err := pfe.executeOperation(holder, token, []string{core.ESDTRoleNFTBurn},
+		holder.address, core.BuiltInFunctionESDTNFTBurn, token.identifier, nonceBytes(nonce), quantity.Bytes())
+	if err != nil || !op.succeeded {
+		return err
+	}
+
+	token.supply[nonce].Sub(token.supply[nonce], quantity)
+	return nil
+}
+
+func (pfe *fuzzESDTExecutor) nftTransfer(r *rand.Rand) error {
+	token, nonce, err := pfe.randomNFT(r)
+	if err != nil || token == nil {
+		return err
+	}
+
+	holder, err := pfe.randomOwner(r, worldhook.MakeTokenKey(token.identifier, nonce))
+	if err != nil {
+		return err
+	}
+	destination := pfe.randomOtherHolder(r, holder)
+	quantity := randomAmount(r, maxNFTQuantity)
+	_, err = pfe.executeOperation(holder, token, nil,
+		holder.address, core.BuiltInFunctionESDTNFTTransfer,
+		token.identifier, nonceBytes(nonce), quantity.Bytes(), destination.address)
+	return err
+}
+
+func (pfe *fuzzESDTExecutor) multiTransfer(r *rand.Rand) error {
+	if len(pfe.tokens) == 0 {
+		return nil
+	}
+
+	transfers := make([]*arwen.ESDTTransfer, 0, maxTokensInMultiTransfer)
+	numTransfers := r.Intn(maxTokensInMultiTransfer) + 1
+	for i := 0; i < numTransfers; i++ {
+		token := pfe.tokens[r.Intn(len(pfe.tokens))]
+		transfer := &arwen.ESDTTransfer{
+			TokenIdentifier: token.identifier,
+			Value:           randomAmount(r, maxAmount),
+		}
+		if !token.fungible {
+			lastNonce, err := pfe.getLastNonce(token)
+			if err != nil {
+				return err
+			}
+			if lastNonce == 0 {
+				continue
+			}
+			transfer.Nonce = uint64(r.Int63n(int64(lastNonce))) + 1
+			transfer.Value = randomAmount(r, maxNFTQuantity)
+		}
+		transfers = append(transfers, transfer)
+	}
+	if len(transfers) == 0 {
+		return nil
+	}
+
+	holder, err := pfe.randomOwner(r, worldhook.MakeTokenKey(transfers[0].TokenIdentifier, transfers[0].Nonce))
+	if err != nil {
+		return err
+	}
+	destination := pfe.randomOtherHolder(r, holder)
+	arguments := arwen.MultiESDTNFTTransferArguments(destination.address, transfers)
+	_, err = pfe.executeOperation(holder, nil, nil,
+		holder.address, arwen.BuiltInFunctionMultiESDTNFTTransfer, arguments...)
+	return err
+}
+
+// getTokenState returns the data of every token instance held by every
+// holder, by holder and token key
+func (pfe *fuzzESDTExecutor) getTokenState() (map[string]*tokenData, error) {
+	state := make(map[string]*tokenData)
+	for _, token := range pfe.tokens {
+		tokenKeys, err := pfe.getTokenKeys(token)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, holder := range pfe.holders {
+			for _, tokenKey := range tokenKeys {
+				data, err := pfe.getTokenData(holder, tokenKey)
+				if err != nil {
+					return nil, err
+				}
+				state[tokenStateKey(holder, tokenKey)] = data
+			}
+		}
+	}
+
+	return state, nil
+}
+
+// getTokenKeys returns the keys of all the instances of the given token:
+// the only one of a fungible token, or one by created nonce
+func (pfe *fuzzESDTExecutor) getTokenKeys(token *fuzzToken) ([][]byte, error) {
+	if token.fungible {
+		return [][]byte{worldhook.MakeTokenKey(token.identifier, 0)}, nil
+	}
+
+	lastNonce, err := pfe.getLastNonce(token)
+	if err != nil {
+		return nil, err
+	}
+
+	tokenKeys := make([][]byte, 0, lastNonce)
+	for nonce := uint64(1); nonce <= lastNonce; nonce++ {
+		tokenKeys = append(tokenKeys, worldhook.MakeTokenKey(token.identifier, nonce))
+	}
+	return tokenKeys, nil
+}
+
+// getLastNonce returns the nonce of the last instance of the given token,
+// as recorded by ESDTNFTCreate in the storage of the creator
+func (pfe *fuzzESDTExecutor) getLastNonce(token *fuzzToken) (uint64, error) {
+	account := pfe.world.AcctMap.GetAccount(token.creator.address)
+	lastNonce, err := account.DataTrieTracker().RetrieveValue(worldhook.MakeLastNonceKey(token.identifier))
+	if err != nil {
+		return 0, err
+	}
+
+	return big.NewInt(0).SetBytes(lastNonce).Uint64(), nil
+}
+
+// randomToken picks one of the fungible or non-fungible tokens, or returns
+// nil if none was issued yet
+func (pfe *fuzzESDTExecutor) randomToken(r *rand.Rand, fungible bool) *fuzzToken {
+	candidates := make([]*fuzzToken, 0, len(pfe.tokens))
+	for _, token := range pfe.tokens {
+		if token.fungible == fungible {
+			candidates = append(candidates, token)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	return candidates[r.Intn(len(candidates))]
+}
+
+// randomNFT picks a created instance of a non-fungible token, or returns a
+// nil token if none was created yet
+func (pfe *fuzzESDTExecutor) randomNFT(r *rand.Rand) (*fuzzToken, uint64, error) {
+	token := pfe.randomToken(r, false)
+	if token == nil {
+		return nil, 0, nil
+	}
+
+	lastNonce, err := pfe.getLastNonce(token)
+	if err != nil || lastNonce == 0 {
+		return nil, 0, err
+	}
+
+	return token, uint64(r.Int63n(int64(lastNonce))) + 1, nil
+}
+
+func (pfe *fuzzESDTExecutor) randomHolder(r *rand.Rand) *fuzzHolder {
+	return pfe.holders[r.Intn(len(pfe.holders))]
+}
+
+// randomOtherHolder picks a holder other than the given one
+func (pfe *fuzzESDTExecutor) randomOtherHolder(r *rand.Rand, holder *fuzzHolder) *fuzzHolder {
+	otherIndex := (holder.index + 1 + r.Intn(len(pfe.holders)-1)) % len(pfe.holders)
+	return pfe.holders[otherIndex]
+}
+
+// randomOwner mostly picks one of the holders of the given token instance,
+// so that spending it has a chance to succeed, and otherwise any holder
+func (pfe *fuzzESDTExecutor) randomOwner(r *rand.Rand, tokenKey []byte) (*fuzzHolder, error) {
+	owners := make([]*fuzzHolder, 0, len(pfe.holders))
+	for _, holder := range pfe.holders {
+		data, err := pfe.getTokenData(holder, tokenKey)
+		if err != nil {
+			return nil, err
+		}
+		if data.value.Sign() > 0 {
+			owners = append(owners, holder)
+		}
+	}
+
+	if len(owners) == 0 || r.Intn(4) == 0 {
+		return pfe.randomHolder(r), nil
+	}
+	return owners[r.Intn(len(owners))], nil
+}
+
+func randomAmount(r *rand.Rand, max int64) *big.Int {
+	return big.NewInt(r.Int63n(max) + 1)
+}
+
+func nonceBytes(nonce uint64) []byte {
+	return big.NewInt(0).SetUint64(nonce).Bytes()
+}
+
+func tokenStateKey(holder *fuzzHolder, tokenKey []byte) string {
+	return fmt.Sprintf("holder %d, token key %s", holder.index, tokenKey)
+}
+
+func isFrozen(properties []byte) bool {
+	return builtInFunctions.ESDTUserMetadataFromBytes(properties).Frozen
+}
@@ -0,0 +1,127 @@
+package esdt
+
+import (
+	"fmt"
+	"math/big"
+	"math/rand"
+
+	"github.com/ElrondNetwork/elrond-go/core"
+)
+
+const maxTokensOfEachKind = 4
+
+const maxInitialSupply = 100000
+
+var fungibleRoles = []string{core.ESDTRoleLocalMint, core.ESDTRoleLocalBurn}
+
+// the role to create instances stays with the creator, since the last nonce
+// of a token is kept in the storage of the creator
+var nonFungibleRoles = []string{core.ESDTRoleNFTAddQuantity, core.ESDTRoleNFTBurn}
+
+// issueFungible issues a new fungible token, sending its initial supply to
+// a random holder, as the ESDT system SC does
+func (pfe *fuzzESDTExecutor) issueFungible(r *rand.Rand) (bool, error) {
+	if pfe.countTokens(true) >= maxTokensOfEachKind {
+		return false, nil
+	}
+
+	issuer := pfe.randomHolder(r)
+	token := &fuzzToken{
+		identifier: pfe.newTokenIdentifier(r, "FUNG"),
+		fungible:   true,
+		supply:     map[uint64]*big.Int{0: randomAmount(r, maxInitialSupply)},
+	}
+	err := pfe.callAsSystemSC(issuer.address, core.BuiltInFunctionESDTTransfer, token.identifier, token.supply[0].Bytes())
+	if err != nil {
+		return false, err
+	}
+
+	pfe.tokens = append(pfe.tokens, token)
+	pfe.log("issued %s, supply %d, to holder %d", token.identifier, token.supply[0], issuer.index)
+	return true, nil
+}
+
+// issueNonFungible issues a new non-fungible token, giving the role to
+// create its instances to a random holder, as the ESDT system SC does
+func (pfe *fuzzESDTExecutor) issueNonFungible(r *rand.Rand) (bool, error) {
+	if pfe.countTokens(false) >= maxTokensOfEachKind {
+		return false, nil
+	}
+
+	token := &fuzzToken{
+		identifier: pfe.newTokenIdentifier(r, "NFT"),
+		fungible:   false,
+		creator:    pfe.randomHolder(r),
+		supply:     make(map[uint64]*big.Int),
+	}
+	err := pfe.callAsSystemSC(token.creator.address, core.BuiltInFunctionSetESDTRole, token.identifier, []byte(core.ESDTRoleNFTCreate))
+	if err != nil {
+		return false, err
+	}
+
+	pfe.tokens = append(pfe.tokens, token)
+	pfe.log("issued %s, created by holder %d", token.identifier, token.creator.index)
+	return true, nil
+}
+
+// setRole gives a random role of a random token to a random holder
+func (pfe *fuzzESDTExecutor) setRole(r *rand.Rand) error {
+	return pfe.changeRole(r, core.BuiltInFunctionSetESDTRole)
+}
+
+// unsetRole takes a random role of a random token from a random holder
+func (pfe *fuzzESDTExecutor) unsetRole(r *rand.Rand) error {
+	return pfe.changeRole(r, core.BuiltInFunctionUnSetESDTRole)
+}
+
+func (pfe *fuzzESDTExecutor) changeRole(r *rand.Rand, function string) error {
+	if len(pfe.tokens) == 0 {
+		return nil
+	}
+
+	token := pfe.tokens[r.Intn(len(pfe.tokens))]
+	roles := nonFungibleRoles
+	if token.fungible {
+		roles = fungibleRoles
+	}
+	role := roles[r.Intn(len(roles))]
+
+	holder := pfe.randomHolder(r)
+	return pfe.callAsSystemSC(holder.address, function, token.identifier, []byte(role))
+}
+
+// freeze freezes the balance of a random holder, in a random fungible token
+func (pfe *fuzzESDTExecutor) freeze(r *rand.Rand) error {
+	return pfe.toggleFreeze(r, core.BuiltInFunctionESDTFreeze)
+}
+
+// unFreeze unfreezes the balance of a random holder, in a random fungible token
+func (pfe *fuzzESDTExecutor) unFreeze(r *rand.Rand) error {
+	return pfe.toggleFreeze(r, core.BuiltInFunctionESDTUnFreeze)
+}
+
+func (pfe *fuzzESDTExecutor) toggleFreeze(r *rand.Rand, function string) error {
+	token := pfe.randomToken(r, true)
+	if token == nil {
+		return nil
+	}
+
+	holder := pfe.randomHolder(r)
+	return pfe.callAsSystemSC(holder.address, function, token.identifier)
+}
+
+func (pfe *fuzzESDTExecutor) countTokens(fungible bool) int {
+	numTokens := 0
+	for _, token := range pfe.tokens {
+		if token.fungible == fungible {
+			numTokens++
+		}
+	}
+	return numTokens
+}
+
+// newTokenIdentifier creates an identifier like those of the ESDT system SC:
+// a ticker and a random suffix
+func (pfe *fuzzESDTExecutor) newTokenIdentifier(r *rand.Rand, ticker string) []byte {
+	return []byte(fmt.Sprintf("%s%d-%06x", ticker, len(pfe.tokens), r.Intn(1<<24)))
+}
@@ -0,0 +1,138 @@
+package esdt
+
+import (
+	"flag"
+	"math/rand"
+	"path/filepath"
+	"testing"
+	"time"
+
+	fuzzcore "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/fuzz/core"
+	fuzzutil "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/fuzz/util"
+	"github.com/stretchr/testify/require"
+)
+
+var fuzz = flag.Bool("fuzz", false, "Enable fuzz test")
+
+var seedFlag = flag.Int64("seed", 0, "Random seed, use it to replay fuzz scenarios")
+
+var iterationsFlag = flag.Int("iterations", 1000, "Number of iterations")
+
+var coverageFlag = flag.Bool("coverage", false, "Favour the events that reach new contract behaviour, instead of fixed probabilities")
+
+var replayFileFlag = flag.String("replayFile", "fuzz_failure.replay.json", "File to save the events to, if the fuzz campaign fails")
+
+var replayFlag = flag.String("replay", "", "Replay file to execute again, instead of the ones in the replays folder")
+
+// replaysPattern matches the replays of past failures, which are executed as regression tests.
+const replaysPattern = "replays/*.replay.json"
+
+const numHolders = 5
+
+func TestFuzzESDT(t *testing.T) {
+	if !*fuzz {
+		t.Skip("skipping test; only run with --fuzz argument")
+	}
+
+	pfe, err := newFuzzESDTExecutor(t, numHolders)
+	require.Nil(t, err)
+
+	var seed int64
+	if *seedFlag == 0 {
+		seed = time.Now().UnixNano()
+	} else {
+		seed = *seedFlag
+	}
+	pfe.log("Random seed: %d\n", seed)
+	campaign := fuzzutil.NewCampaign(seed)
+	defer func() {
+		pfe.log(campaign.Report())
+	}()
+
+	harness := newFuzzHarness(t, pfe, campaign)
+	harness.SetReplayPath(*replayFileFlag)
+	if *coverageFlag {
+		coverage := fuzzcore.NewCoverageTracker()
+		pfe.addTxHook(fuzzcore.NewVMFeedbackHook(coverage))
+		harness.SetCoverageGuidance(coverage)
+		defer func() {
+			pfe.log("Coverage: %d distinct features reached\n", coverage.Len())
+		}()
+	}
+
+	err = harness.Run(*iterationsFlag)
+	require.Nil(t, err)
+}
+
+// TestFuzzESDT_Replays executes again the events of failed fuzz campaigns,
+// saved in the replays folder, or the replay given with the -replay argument.
+func TestFuzzESDT_Replays(t *testing.T) {
+	replayPaths, err := filepath.Glob(replaysPattern)
+	require.Nil(t, err)
+	if *replayFlag != "" {
+		replayPaths = []string{*replayFlag}
+	}
+	if len(replayPaths) == 0 {
+		t.Skip("no fuzz replays")
+	}
+
+	for _, replayPath := range replayPaths {
+		replayPath := replayPath
+		t.Run(filepath.Base(replayPath), func(t *testing.T) {
+			replay, err := fuzzcore.LoadReplay(replayPath)
+			require.Nil(t, err)
+
+			pfe, err := newFuzzESDTExecutor(t, numHolders)
+			require.Nil(t, err)
+			harness := newFuzzHarness(t, pfe, fuzzutil.NewCampaign(replay.Seed))
+			err = harness.Replay(replay)
+			require.Nil(t, err, "replayed failure: %s", replay.Failure)
+		})
+	}
+}
+
+func newFuzzHarness(t *testing.T, pfe *fuzzESDTExecutor, campaign *fuzzutil.Campaign) *fuzzcore.Harness {
+	harness := fuzzcore.NewHarness(campaign)
+	err := registerFuzzEvents(harness, pfe)
+	require.Nil(t, err)
+	harness.AddInvariant(fuzzcore.NewInvariant("totalSupply", pfe.checkTotalSupply))
+	harness.AddInvariant(fuzzcore.NewInvariant("rolesEnforced", pfe.checkRolesEnforced))
+	harness.AddInvariant(fuzzcore.NewInvariant("freezeEnforced", pfe.checkFreezeEnforced))
+	harness.AddInvariant(fuzzcore.NewInvariant("balanceConservation", pfe.checkBalanceConservation))
+	return harness
+}
+
+// registerFuzzEvents adds the ESDT events to the harness.
+// The order of the events matters, since it determines the events generated from a seed.
+func registerFuzzEvents(harness *fuzzcore.Harness, pfe *fuzzESDTExecutor) error {
+	operationEvent := func(name string, probability float32, operation func(r *rand.Rand) error) fuzzcore.EventGenerator {
+		return fuzzcore.NewEvent(name, probability, func(r *rand.Rand) (bool, error) {
+			return true, operation(r)
+		})
+	}
+
+	events := []fuzzcore.EventGenerator{
+		fuzzcore.NewEvent("issueFungible", 0.03, pfe.issueFungible),
+		fuzzcore.NewEvent("issueNonFungible", 0.03, pfe.issueNonFungible),
+		operationEvent("setRole", 0.08, pfe.setRole),
+		operationEvent("unsetRole", 0.04, pfe.unsetRole),
+		operationEvent("freeze", 0.04, pfe.freeze),
+		operationEvent("unFreeze", 0.04, pfe.unFreeze),
+		operationEvent("localMint", 0.1, pfe.localMint),
+		operationEvent("localBurn", 0.08, pfe.localBurn),
+		operationEvent("transfer", 0.12, pfe.transfer),
+		operationEvent("nftCreate", 0.1, pfe.nftCreate),
+		operationEvent("nftAddQuantity", 0.06, pfe.nftAddQuantity),
+		operationEvent("nftBurn", 0.06, pfe.nftBurn),
+		operationEvent("nftTransfer", 0.1, pfe.nftTransfer),
+		operationEvent("multiTransfer", 0.1, pfe.multiTransfer),
+	}
+
+	for _, event := range events {
+		err := harness.AddEvent(event)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
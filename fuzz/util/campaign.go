@@ -0,0 +1,253 @@
+package fuzzutil
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// EventStatistics counts the fuzz events generated, by event name.
+type EventStatistics map[string]int
+
+// Total returns the number of events counted.
+func (es EventStatistics) Total() int {
+	total := 0
+	for _, count := range es {
+		total += count
+	}
+	return total
+}
+
+// String lists the counts, sorted by event name.
+func (es EventStatistics) String() string {
+	names := make([]string, 0, len(es))
+	for name := range es {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var sb strings.Builder
+	for _, name := range names {
+		sb.WriteString(fmt.Sprintf("  %-30s %d\n", name, es[name]))
+	}
+	return sb.String()
+}
+
+// SegmentReport describes an uninterrupted run of a fuzz campaign.
+type SegmentReport struct {
+	FirstStep   int             `json:"firstStep"`
+	LastStep    int             `json:"lastStep"`
+	Duration    time.Duration   `json:"duration"`
+	EventCounts EventStatistics `json:"eventCounts"`
+}
+
+// Checkpoint is the persisted state of a fuzz campaign, from which an
+// interrupted campaign can be resumed.
+type Checkpoint struct {
+	Seed          int64           `json:"seed"`
+	Draws         uint64          `json:"draws"`
+	StepIndex     int             `json:"stepIndex"`
+	EventCounts   EventStatistics `json:"eventCounts"`
+	Segments      []SegmentReport `json:"segments"`
+	ExecutorState json.RawMessage `json:"executorState"`
+	Scenario      string          `json:"scenario"`
+}
+
+// Campaign keeps track of the progress of a fuzz campaign, across the
+// segments it was resumed in.
+type Campaign struct {
+	source       *CountingSource
+	rand         *rand.Rand
+	events       *RandomEventProvider
+	checkpoint   *Checkpoint
+	segment      SegmentReport
+	segmentStart time.Time
+}
+
+// NewCampaign starts a fuzz campaign from the given seed.
+func NewCampaign(seed int64) *Campaign {
+	return newCampaign(&Checkpoint{
+		Seed:        seed,
+		EventCounts: make(EventStatistics),
+	})
+}
+
+// ResumeCampaign continues a fuzz campaign from the checkpoint saved at the given path.
+func ResumeCampaign(path string) (*Campaign, error) {
+	checkpoint, err := LoadCheckpoint(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return newCampaign(checkpoint), nil
+}
+
+func newCampaign(checkpoint *Checkpoint) *Campaign {
+	if checkpoint.EventCounts == nil {
+		checkpoint.EventCounts = make(EventStatistics)
+	}
+
+	// the event provider draws a random value when created, so it is created
+	// before the source reaches the state saved in the checkpoint
+	source := NewCountingSource(checkpoint.Seed)
+	r := rand.New(source)
+	events := NewRandomEventProvider(r)
+	source.FastForward(checkpoint.Draws)
+
+	return &Campaign{
+		source:     source,
+		rand:       r,
+		events:     events,
+		checkpoint: checkpoint,
+		segment: SegmentReport{
+			FirstStep:   checkpoint.StepIndex,
+			LastStep:    checkpoint.StepIndex,
+			EventCounts: make(EventStatistics),
+		},
+		segmentStart: time.Now(),
+	}
+}
+
+// Rand returns the random number generator of the campaign.
+func (c *Campaign) Rand() *rand.Rand {
+	return c.rand
+}
+
+// EventProvider returns the random event provider of the campaign.
+func (c *Campaign) EventProvider() *RandomEventProvider {
+	return c.events
+}
+
+// Seed returns the seed the campaign was started with.
+func (c *Campaign) Seed() int64 {
+	return c.checkpoint.Seed
+}
+
+// StepIndex returns the number of events generated so far in the campaign.
+func (c *Campaign) StepIndex() int {
+	return c.checkpoint.StepIndex
+}
+
+// Draws returns the number of random values drawn so far, since the campaign started from its seed.
+func (c *Campaign) Draws() uint64 {
+	return c.source.Draws()
+}
+
+// FastForward draws random values until the given number of draws is reached,
+// bringing the random number generator to the state it had after those draws.
+func (c *Campaign) FastForward(draws uint64) error {
+	if draws < c.source.Draws() {
+		return fmt.Errorf("cannot rewind the random number generator from %d to %d draws", c.source.Draws(), draws)
+	}
+	c.source.FastForward(draws)
+	return nil
+}
+
+// IsResumed returns true if the campaign was resumed from a checkpoint.
+func (c *Campaign) IsResumed() bool {
+	return len(c.checkpoint.Segments) > 0
+}
+
+// ExecutorState returns the executor state saved in the last checkpoint.
+func (c *Campaign) ExecutorState() json.RawMessage {
+	return c.checkpoint.ExecutorState
+}
+
+// Scenario returns the generated scenario saved in the last checkpoint.
+func (c *Campaign) Scenario() string {
+	return c.checkpoint.Scenario
+}
+
+// RecordEvent counts an event and advances the campaign by one step.
+func (c *Campaign) RecordEvent(name string) {
+	c.checkpoint.EventCounts[name]++
+	c.segment.EventCounts[name]++
+	c.checkpoint.StepIndex++
+	c.segment.LastStep = c.checkpoint.StepIndex
+}
+
+// SaveCheckpoint persists the state of the campaign at the given path,
+// together with the state of the executor and the scenario generated so far.
+func (c *Campaign) SaveCheckpoint(path string, executorState interface{}, scenario string) error {
+	serializedState, err := json.Marshal(executorState)
+	if err != nil {
+		return err
+	}
+
+	checkpoint := *c.checkpoint
+	checkpoint.Draws = c.source.Draws()
+	checkpoint.Segments = c.Segments()
+	checkpoint.ExecutorState = serializedState
+	checkpoint.Scenario = scenario
+
+	return SaveCheckpoint(path, &checkpoint)
+}
+
+// Segments returns the reports of the segments of the campaign, including
+// the one currently running.
+func (c *Campaign) Segments() []SegmentReport {
+	currentSegment := c.segment
+	currentSegment.Duration = time.Since(c.segmentStart)
+
+	segments := make([]SegmentReport, 0, len(c.checkpoint.Segments)+1)
+	segments = append(segments, c.checkpoint.Segments...)
+	return append(segments, currentSegment)
+}
+
+// Report aggregates the statistics of all the segments of the campaign.
+func (c *Campaign) Report() string {
+	segments := c.Segments()
+
+	var totalDuration time.Duration
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Fuzz campaign with seed %d: %d steps in %d segment(s)\n",
+		c.checkpoint.Seed, c.checkpoint.StepIndex, len(segments)))
+	for i, segment := range segments {
+		totalDuration += segment.Duration
+		sb.WriteString(fmt.Sprintf("  segment %d: steps %d-%d, %d events in %s\n",
+			i, segment.FirstStep, segment.LastStep, segment.EventCounts.Total(), segment.Duration))
+	}
+	sb.WriteString(fmt.Sprintf("Total duration: %s\n", totalDuration))
+	sb.WriteString("Events:\n")
+	sb.WriteString(c.checkpoint.EventCounts.String())
+
+	return sb.String()
+}
+
+// SaveCheckpoint writes a checkpoint to the given path, replacing the
+// previous one only once the new one was completely written.
+func SaveCheckpoint(path string, checkpoint *Checkpoint) error {
+	serialized, err := json.MarshalIndent(checkpoint, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tempPath := path + ".tmp"
+	err = ioutil.WriteFile(tempPath, serialized, 0644)
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tempPath, path)
+}
+
+// LoadCheckpoint reads a checkpoint from the given path.
+func LoadCheckpoint(path string) (*Checkpoint, error) {
+	serialized, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	checkpoint := &Checkpoint{}
+	err = json.Unmarshal(serialized, checkpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	return checkpoint, nil
+}
@@ -0,0 +1,58 @@
+package fuzzutil
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCountingSource_ResumesSequence(t *testing.T) {
+	source := NewCountingSource(42)
+	for i := 0; i < 10; i++ {
+		_ = source.Int63()
+		_ = source.Uint64()
+	}
+	require.Equal(t, uint64(20), source.Draws())
+
+	resumedSource := NewCountingSource(42)
+	resumedSource.FastForward(source.Draws())
+	for i := 0; i < 10; i++ {
+		require.Equal(t, source.Int63(), resumedSource.Int63())
+		require.Equal(t, source.Uint64(), resumedSource.Uint64())
+	}
+}
+
+func TestCampaign_SaveAndResume(t *testing.T) {
+	checkpointPath := filepath.Join(t.TempDir(), "fuzz.checkpoint.json")
+
+	campaign := NewCampaign(7)
+	_ = campaign.Rand().Intn(1000)
+	campaign.EventProvider().Reset()
+	campaign.RecordEvent("stake")
+	campaign.RecordEvent("stake")
+	campaign.RecordEvent("claimRewards")
+
+	err := campaign.SaveCheckpoint(checkpointPath, map[string]int{"txIndex": 3}, "{}")
+	require.Nil(t, err)
+	expectedNext := campaign.Rand().Int63()
+
+	resumedCampaign, err := ResumeCampaign(checkpointPath)
+	require.Nil(t, err)
+	require.True(t, resumedCampaign.IsResumed())
+	require.Equal(t, int64(7), resumedCampaign.Seed())
+	require.Equal(t, 3, resumedCampaign.StepIndex())
+	require.JSONEq(t, `{"txIndex":3}`, string(resumedCampaign.ExecutorState()))
+	require.Equal(t, "{}", resumedCampaign.Scenario())
+	require.Equal(t, expectedNext, resumedCampaign.Rand().Int63())
+	require.NotSame(t, campaign.EventProvider(), resumedCampaign.EventProvider())
+
+	resumedCampaign.RecordEvent("stake")
+	segments := resumedCampaign.Segments()
+	require.Len(t, segments, 2)
+	require.Equal(t, EventStatistics{"stake": 2, "claimRewards": 1}, segments[0].EventCounts)
+	require.Equal(t, EventStatistics{"stake": 1}, segments[1].EventCounts)
+	require.Equal(t, 3, segments[1].FirstStep)
+	require.Equal(t, 4, segments[1].LastStep)
+	require.Contains(t, resumedCampaign.Report(), "4 steps in 2 segment(s)")
+}
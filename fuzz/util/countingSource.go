@@ -0,0 +1,59 @@
+package fuzzutil
+
+import (
+	"math/rand"
+)
+
+// CountingSource is a rand.Source64 which counts the random values drawn
+// from it, so that its state can be persisted as a seed and a draw count.
+type CountingSource struct {
+	source rand.Source64
+	seed   int64
+	draws  uint64
+}
+
+// NewCountingSource is a CountingSource constructor.
+func NewCountingSource(seed int64) *CountingSource {
+	return &CountingSource{
+		source: rand.NewSource(seed).(rand.Source64),
+		seed:   seed,
+		draws:  0,
+	}
+}
+
+// FastForward draws random values until the given number of draws is
+// reached, bringing the source to the state it had after those draws.
+func (cs *CountingSource) FastForward(draws uint64) {
+	for cs.draws < draws {
+		cs.Uint64()
+	}
+}
+
+// Int63 returns a non-negative pseudo-random 63-bit integer.
+func (cs *CountingSource) Int63() int64 {
+	cs.draws++
+	return cs.source.Int63()
+}
+
+// Uint64 returns a pseudo-random 64-bit integer.
+func (cs *CountingSource) Uint64() uint64 {
+	cs.draws++
+	return cs.source.Uint64()
+}
+
+// Seed reinitializes the source and clears the draw count.
+func (cs *CountingSource) Seed(seed int64) {
+	cs.source.Seed(seed)
+	cs.seed = seed
+	cs.draws = 0
+}
+
+// GetSeed returns the seed the source was last initialized with.
+func (cs *CountingSource) GetSeed() int64 {
+	return cs.seed
+}
+
+// Draws returns the number of random values drawn since the source was seeded.
+func (cs *CountingSource) Draws() uint64 {
+	return cs.draws
+}
@@ -8,6 +8,7 @@ require (
 	github.com/ElrondNetwork/elrond-go-logger v1.0.4
 	github.com/btcsuite/btcd v0.21.0-beta
 	github.com/gin-gonic/gin v1.7.1
+	github.com/gtank/ristretto255 v0.1.2
 	github.com/mitchellh/mapstructure v1.4.1
 	github.com/pelletier/go-toml v1.9.0
 	github.com/stretchr/testify v1.7.0
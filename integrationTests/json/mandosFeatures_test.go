@@ -50,6 +50,10 @@ func TestTimelocks(t *testing.T) {
 	runAllTestsInFolder(t, "timelocks")
 }
 
+func TestNFTMetadata(t *testing.T) {
+	runAllTestsInFolder(t, "contracts/nft-metadata/mandos")
+}
+
 // func TestPromises(t *testing.T) {
 // 	executor, err := am.NewArwenTestExecutor()
 // 	require.Nil(t, err)
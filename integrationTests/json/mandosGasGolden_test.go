@@ -0,0 +1,67 @@
+package vmjsonintegrationtest
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"testing"
+
+	am "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwenmandos"
+	mc "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mandos-go/controller"
+	"github.com/stretchr/testify/require"
+)
+
+func runSingleTestWithGasGolden(t *testing.T, goldenPath string, mode am.GasGoldenMode) error {
+	executor, err := am.NewArwenTestExecutor()
+	require.Nil(t, err)
+	err = executor.SetGasGoldenFile(goldenPath, mode)
+	require.Nil(t, err)
+	runner := mc.NewScenarioRunner(
+		executor,
+		mc.NewDefaultFileResolver(),
+	)
+
+	err = runner.RunSingleJSONScenario(path.Join(getTestRoot(), "mandos-self-test/gas/gas-used-delta.scen.json"))
+	if err != nil {
+		return err
+	}
+	return executor.SaveGasGoldenFile()
+}
+
+func TestMandosGasGolden(t *testing.T) {
+	goldenDir, err := ioutil.TempDir("", "gas-golden")
+	require.Nil(t, err)
+	defer os.RemoveAll(goldenDir)
+	goldenPath := filepath.Join(goldenDir, "gas.json")
+
+	err = runSingleTestWithGasGolden(t, goldenPath, am.GasGoldenWrite)
+	require.Nil(t, err)
+
+	golden, err := ioutil.ReadFile(goldenPath)
+	require.Nil(t, err)
+	scenarioName, err := filepath.Rel(goldenDir, filepath.Join(getTestRoot(), "mandos-self-test/gas/gas-used-delta.scen.json"))
+	require.Nil(t, err)
+	scenarioName = filepath.ToSlash(scenarioName)
+	require.Equal(t, `{
+    "`+scenarioName+`": {
+        "echo": "35376468"
+    }
+}
+`, string(golden))
+
+	err = runSingleTestWithGasGolden(t, goldenPath, am.GasGoldenCompare)
+	require.Nil(t, err)
+
+	// within the "gasUsedDelta" of the tx
+	err = ioutil.WriteFile(goldenPath, []byte(`{"`+scenarioName+`": {"echo": "35376400"}}`), 0644)
+	require.Nil(t, err)
+	err = runSingleTestWithGasGolden(t, goldenPath, am.GasGoldenCompare)
+	require.Nil(t, err)
+
+	err = ioutil.WriteFile(goldenPath, []byte(`{"`+scenarioName+`": {"echo": "35376000"}}`), 0644)
+	require.Nil(t, err)
+	err = runSingleTestWithGasGolden(t, goldenPath, am.GasGoldenCompare)
+	require.EqualError(t, err,
+		"gas used mismatch with golden gas file. Scenario: "+scenarioName+". Tx echo. Want: 35376000 (+/- 100). Have: 35376468")
+}
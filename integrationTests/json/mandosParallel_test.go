@@ -0,0 +1,38 @@
+package vmjsonintegrationtest
+
+import (
+	"testing"
+
+	am "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwenmandos"
+	mc "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mandos-go/controller"
+	mj "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mandos-go/json/model"
+	worldhook "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/world"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMandosParallel(t *testing.T) {
+	gasSchedules := make(map[mj.GasSchedule]*worldhook.CompiledCodeCache)
+	newExecutors := func(gasSchedule mj.GasSchedule) ([]mc.ScenarioExecutor, error) {
+		_, ok := gasSchedules[gasSchedule]
+		require.False(t, ok, "executors created twice for the same gas schedule")
+
+		executors, compiledCode, err := am.NewParallelTestExecutors(4, gasSchedule)
+		gasSchedules[gasSchedule] = compiledCode
+		return executors, err
+	}
+
+	err := mc.RunAllJSONScenariosInDirectoryParallel(
+		newExecutors,
+		mc.NewDefaultFileResolver(),
+		getTestRoot(),
+		"features/basic-features/mandos",
+		".scen.json",
+		[]string{})
+	require.Nil(t, err)
+
+	// the directory holds scenarios with different gas schedules
+	require.True(t, len(gasSchedules) > 1)
+	for _, compiledCode := range gasSchedules {
+		require.True(t, compiledCode.Len() > 0)
+	}
+}
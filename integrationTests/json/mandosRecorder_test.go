@@ -0,0 +1,53 @@
+package vmjsonintegrationtest
+
+import (
+	"path"
+	"testing"
+
+	am "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwenmandos"
+	mc "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mandos-go/controller"
+	mjwrite "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mandos-go/json/write"
+	"github.com/stretchr/testify/require"
+)
+
+// records the scenario, then runs the recorded scenario, which must pass
+func runRecordedScenario(t *testing.T, scenarioPath string) string {
+	fullPath := path.Join(getTestRoot(), scenarioPath)
+
+	executor, err := am.NewArwenTestExecutor()
+	require.Nil(t, err)
+	recorder := am.NewScenarioRecorder(executor)
+	err = mc.NewScenarioRunner(recorder, mc.NewDefaultFileResolver()).RunSingleJSONScenario(fullPath)
+	require.Nil(t, err)
+
+	recordedJSON := mjwrite.ScenarioToJSONString(recorder.RecordedScenario())
+	require.NotContains(t, recordedJSON, `"*"`)
+
+	replayExecutor, err := am.NewArwenTestExecutor()
+	require.Nil(t, err)
+	replayRunner := mc.NewScenarioRunner(replayExecutor, mc.NewDefaultFileResolver())
+	// the recorded scenario refers to the same files as the original
+	replayRunner.Parser.ExprInterpreter.FileResolver.SetContext(fullPath)
+	recordedScenario, err := replayRunner.Parser.ParseScenarioFile([]byte(recordedJSON))
+	require.Nil(t, err)
+	err = replayExecutor.ExecuteScenario(recordedScenario, replayRunner.Parser.ExprInterpreter.FileResolver)
+	require.Nil(t, err)
+
+	return recordedJSON
+}
+
+func TestMandosRecorderEvents(t *testing.T) {
+	recordedJSON := runRecordedScenario(t, "features/basic-features/mandos/events.scen.json")
+	require.Contains(t, recordedJSON, `"gas"`)
+	require.Contains(t, recordedJSON, `"owner"`)
+}
+
+func TestMandosRecorderStorage(t *testing.T) {
+	runRecordedScenario(t, "features/basic-features/mandos/storage_mapper_map.scen.json")
+}
+
+func TestMandosRecorderESDT(t *testing.T) {
+	runRecordedScenario(t, "mandos-self-test/transfer-esdt.scen.json")
+	recordedJSON := runRecordedScenario(t, "mandos-self-test/set-check/set-check-esdt-nft.scen.json")
+	require.Contains(t, recordedJSON, `"frozen"`)
+}
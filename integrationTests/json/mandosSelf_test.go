@@ -115,3 +115,59 @@ func TestMandosCheckESDTErr1(t *testing.T) {
 ]. Have: "str:www.cool_nft.com/my_nft.jpg"
   for token: NFT-123456, nonce: 1: Bad attributes. Want: "str:other_attributes". Have: "str:serialized_attributes"`)
 }
+
+func TestMandosRestoreStateErr(t *testing.T) {
+	err := runSingleTest(t, "mandos-self-test/set-check", "restore-state.err.json")
+	require.EqualError(t, err, "no state saved under the name \"other\"")
+}
+
+func TestMandosCrossShardCallbackErr(t *testing.T) {
+	err := runSingleTest(t, "mandos-self-test/set-check", "cross-shard-callback.err.json")
+	require.EqualError(t, err, "no pending cross-shard async call")
+}
+
+func TestMandosCheckESDTNFTErr(t *testing.T) {
+	err := runSingleTest(t, "mandos-self-test/set-check", "set-check-esdt-nft.err.json")
+	require.EqualError(t, err,
+		`mismatch for account "address:the-address":
+  for token: NFT-123456, nonce: 1: Found 2 URIs, use "uris" to check them all
+  for token: NFT-123456, nonce: 1: Bad number of URIs. Want: 3. Have: 2
+  for token: NFT-123456, nonce: 1: Bad URI #1. Want: "str:www.cool_nft.com/another_nft.json". Have: "str:www.cool_nft.com/my_nft.json"
+  for token: NFT-123456, nonce: 1: Bad frozen flag. Want: "false". Have: true
+  bad account ESDT frozen flag. Account: address:the-address. Token: NFT-123456. Nonce: 1. Want: "false". Have: true`)
+}
+
+func TestMandosGasUsedDeltaErr(t *testing.T) {
+	err := runSingleTest(t, "mandos-self-test/set-check", "gas-used-delta.err.json")
+	require.EqualError(t, err, "result gas mismatch. Tx echo. Want: 14,623,000 (+/- 100). Got: 14623532 (0xdf232c)")
+}
+
+func TestMandosExternalStepsMissingParameterErr(t *testing.T) {
+	err := runSingleTest(t, "mandos-self-test/external_steps/params", "external-steps-missing-param.err.json")
+	require.EqualError(t, err,
+		"error processing steps: cannot parse tx step transaction: invalid transaction value: unknown variable: amount")
+}
+
+func TestMandosAdvanceRoundsZeroErr(t *testing.T) {
+	err := runSingleTest(t, "mandos-self-test/set-check", "advance-rounds-zero.err.json")
+	require.EqualError(t, err,
+		"error processing steps: advance rounds step requires a positive number of rounds")
+}
+
+func TestMandosAdvanceEpochOverflowErr(t *testing.T) {
+	err := runSingleTest(t, "mandos-self-test/set-check", "advance-epoch-overflow.err.json")
+	require.EqualError(t, err,
+		"advance epoch step overflows the block epoch")
+}
+
+func TestMandosLogTopicPrefixErr(t *testing.T) {
+	err := runSingleTest(t, "mandos-self-test/set-check", "log-topic-prefix.err.json")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "bad log topic. Tx topic-prefix-mismatch.")
+}
+
+func TestMandosLogTopicPrefixEmptyErr(t *testing.T) {
+	err := runSingleTest(t, "mandos-self-test/set-check", "log-topic-prefix-empty.err.json")
+	require.EqualError(t, err,
+		"error processing steps: cannot parse tx expected result: invalid log entry topics: empty prefix matcher, use \"*\" to accept any value")
+}
@@ -0,0 +1,59 @@
+package vmjsonintegrationtest
+
+import (
+	"errors"
+	"path"
+	"testing"
+
+	am "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwenmandos"
+	mc "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mandos-go/controller"
+	mj "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mandos-go/json/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMandosStepHooks(t *testing.T) {
+	executor, err := am.NewArwenTestExecutor()
+	require.Nil(t, err)
+
+	nrStepsBefore := 0
+	executor.AddBeforeStepHook(func(ctx *am.StepHookContext) error {
+		require.Nil(t, ctx.Output)
+		nrStepsBefore++
+		return nil
+	})
+	var txIDs []string
+	executor.AddAfterStepHook(func(ctx *am.StepHookContext) error {
+		txStep, isTx := ctx.Step.(*mj.TxStep)
+		if !isTx {
+			require.Nil(t, ctx.Output)
+			return nil
+		}
+		require.NotNil(t, ctx.Output)
+		require.Len(t, ctx.Output.Logs, 1)
+		require.NotNil(t, ctx.World.AcctMap.GetAccount(ctx.Output.Logs[0].Address))
+		txIDs = append(txIDs, txStep.TxIdent)
+		return nil
+	})
+
+	runner := mc.NewScenarioRunner(executor, mc.NewDefaultFileResolver())
+	err = runner.RunSingleJSONScenario(path.Join(getTestRoot(), "features/basic-features/mandos/events.scen.json"))
+	require.Nil(t, err)
+	require.Equal(t, 4, nrStepsBefore)
+	require.Equal(t, []string{"A1", "A2", "B1"}, txIDs)
+}
+
+func TestMandosStepHookErr(t *testing.T) {
+	executor, err := am.NewArwenTestExecutor()
+	require.Nil(t, err)
+
+	executor.AddAfterStepHook(func(ctx *am.StepHookContext) error {
+		if txStep, isTx := ctx.Step.(*mj.TxStep); isTx && txStep.TxIdent == "A2" {
+			return errors.New("hook failed on A2")
+		}
+		return nil
+	})
+
+	runner := mc.NewScenarioRunner(executor, mc.NewDefaultFileResolver())
+	err = runner.RunSingleJSONScenario(path.Join(getTestRoot(), "features/basic-features/mandos/events.scen.json"))
+	require.EqualError(t, err, "hook failed on A2")
+}
@@ -0,0 +1,41 @@
+package arwenpart
+
+import (
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/ipc/common"
+)
+
+var _ arwen.ExecutionPolicy = (*ExecutionPolicyGateway)(nil)
+
+// ExecutionPolicyGateway forwards execution policy requests to the policy held by the Node
+type ExecutionPolicyGateway struct {
+	messenger *ArwenMessenger
+}
+
+// NewExecutionPolicyGateway creates a new gateway
+func NewExecutionPolicyGateway(messenger *ArwenMessenger) *ExecutionPolicyGateway {
+	return &ExecutionPolicyGateway{messenger: messenger}
+}
+
+// Evaluate forwards a message to the actual policy, rejecting the call on any error
+func (gateway *ExecutionPolicyGateway) Evaluate(request arwen.ExecutionPolicyRequest) arwen.ExecutionPolicyDecision {
+	message := common.NewMessageExecutionPolicyRequest(request)
+	rawResponse, err := gateway.messenger.SendHookCallRequest(message)
+	if err != nil {
+		log.Error("ExecutionPolicyGateway.Evaluate", "err", err)
+		return arwen.ExecutionRejected
+	}
+
+	if rawResponse.GetKind() != common.ExecutionPolicyResponse {
+		log.Error("ExecutionPolicyGateway.Evaluate", "err", common.ErrBadHookResponseFromNode)
+		return arwen.ExecutionRejected
+	}
+
+	response := rawResponse.(*common.MessageExecutionPolicyResponse)
+	return response.Decision
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (gateway *ExecutionPolicyGateway) IsInterfaceNil() bool {
+	return gateway == nil
+}
@@ -9,7 +9,6 @@ import (
 	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/ipc/common"
 	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/ipc/marshaling"
 	logger "github.com/ElrondNetwork/elrond-go-logger"
-	"github.com/ElrondNetwork/elrond-go/core/vmcommon"
 )
 
 var log = logger.GetOrCreate("arwen/part")
@@ -17,7 +16,7 @@ var log = logger.GetOrCreate("arwen/part")
 // ArwenPart is the endpoint that implements the message loop on Arwen's side
 type ArwenPart struct {
 	Messenger *ArwenMessenger
-	VMHost    vmcommon.VMExecutionHandler
+	VMHost    arwen.VMHost
 	Repliers  []common.MessageReplier
 	Version   string
 }
@@ -28,11 +27,16 @@ func NewArwenPart(
 	input *os.File,
 	output *os.File,
 	vmHostParameters *arwen.VMHostParameters,
+	hasExecutionPolicy bool,
 	marshalizer marshaling.Marshalizer,
 ) (*ArwenPart, error) {
 	messenger := NewArwenMessenger(input, output, marshalizer)
 	blockchain := NewBlockchainHookGateway(messenger)
 
+	if hasExecutionPolicy {
+		vmHostParameters.ExecutionPolicy = NewExecutionPolicyGateway(messenger)
+	}
+
 	newArwenHost, err := host.NewArwenVM(
 		blockchain,
 		vmHostParameters,
@@ -50,6 +54,7 @@ func NewArwenPart(
 	part.Repliers = common.CreateReplySlots(part.noopReplier)
 	part.Repliers[common.ContractDeployRequest] = part.replyToRunSmartContractCreate
 	part.Repliers[common.ContractCallRequest] = part.replyToRunSmartContractCall
+	part.Repliers[common.ContractQueryRequest] = part.replyToRunSmartContractQuery
 	part.Repliers[common.DiagnoseWaitRequest] = part.replyToDiagnoseWait
 	part.Repliers[common.VersionRequest] = part.replyToVersionRequest
 	part.Repliers[common.GasScheduleChangeRequest] = part.replyToGasScheduleChange
@@ -111,6 +116,12 @@ func (part *ArwenPart) replyToRunSmartContractCall(request common.MessageHandler
 	return common.NewMessageContractResponse(vmOutput, err)
 }
 
+func (part *ArwenPart) replyToRunSmartContractQuery(request common.MessageHandler) common.MessageHandler {
+	typedRequest := request.(*common.MessageContractQueryRequest)
+	vmOutput, err := part.VMHost.RunSmartContractQuery(typedRequest.CallInput)
+	return common.NewMessageContractResponse(vmOutput, err)
+}
+
 func (part *ArwenPart) replyToDiagnoseWait(request common.MessageHandler) common.MessageHandler {
 	typedRequest := request.(*common.MessageDiagnoseWaitRequest)
 	duration := time.Duration(int64(typedRequest.Milliseconds) * int64(time.Millisecond))
@@ -12,6 +12,7 @@ type ArwenArguments struct {
 	arwen.VMHostParameters
 	LogsMarshalizer     marshaling.MarshalizerKind
 	MessagesMarshalizer marshaling.MarshalizerKind
+	HasExecutionPolicy  bool
 }
 
 // SendArwenArguments sends initialization arguments through a pipe
@@ -81,6 +81,9 @@ const (
 	BlockchainRevertToSnapshotResponse
 	BlockchainProcessBuiltInFunctionRequest
 	BlockchainProcessBuiltInFunctionResponse
+	ExecutionPolicyRequest
+	ExecutionPolicyResponse
+	ContractQueryRequest
 	UndefinedRequestOrResponse
 	LastKind
 )
@@ -160,6 +163,9 @@ func init() {
 	messageKindNameByID[BlockchainRevertToSnapshotResponse] = "BlockchainRevertToSnapshotResponse"
 	messageKindNameByID[BlockchainProcessBuiltInFunctionRequest] = "BlockchainProcessBuiltInFunctionRequest"
 	messageKindNameByID[BlockchainProcessBuiltInFunctionResponse] = "BlockchainProcessBuiltInFunctionResponse	"
+	messageKindNameByID[ExecutionPolicyRequest] = "ExecutionPolicyRequest"
+	messageKindNameByID[ExecutionPolicyResponse] = "ExecutionPolicyResponse"
+	messageKindNameByID[ContractQueryRequest] = "ContractQueryRequest"
 	messageKindNameByID[UndefinedRequestOrResponse] = "UndefinedRequestOrResponse"
 	messageKindNameByID[LastKind] = "LastKind"
 }
@@ -286,7 +292,9 @@ func CreateReplySlots(noopReplier MessageReplier) []MessageReplier {
 // IsHookCall returns whether a message is a hook call
 func IsHookCall(message MessageHandler) bool {
 	kind := message.GetKind()
-	return kind >= BlockchainNewAddressRequest && kind <= BlockchainGetCompiledCodeResponse
+	isBlockchainHookCall := kind >= BlockchainNewAddressRequest && kind <= BlockchainGetCompiledCodeResponse
+	isExecutionPolicyCall := kind == ExecutionPolicyRequest
+	return isBlockchainHookCall || isExecutionPolicyCall
 }
 
 // IsStopRequest returns whether a message is a stop request
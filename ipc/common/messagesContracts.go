@@ -32,6 +32,20 @@ func NewMessageContractCallRequest(input *vmcommon.ContractCallInput) *MessageCo
 	return message
 }
 
+// MessageContractQueryRequest is a query request message (from the Node)
+type MessageContractQueryRequest struct {
+	Message
+	CallInput *vmcommon.ContractCallInput
+}
+
+// NewMessageContractQueryRequest creates a MessageContractQueryRequest
+func NewMessageContractQueryRequest(input *vmcommon.ContractCallInput) *MessageContractQueryRequest {
+	message := &MessageContractQueryRequest{}
+	message.Kind = ContractQueryRequest
+	message.CallInput = input
+	return message
+}
+
 // MessageContractResponse is a contract response message (from Arwen)
 type MessageContractResponse struct {
 	Message
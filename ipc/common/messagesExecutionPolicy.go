@@ -0,0 +1,33 @@
+package common
+
+import (
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
+)
+
+// MessageExecutionPolicyRequest is a request message (from Arwen) to evaluate the execution policy of the Node
+type MessageExecutionPolicyRequest struct {
+	Message
+	Request arwen.ExecutionPolicyRequest
+}
+
+// NewMessageExecutionPolicyRequest creates a MessageExecutionPolicyRequest
+func NewMessageExecutionPolicyRequest(request arwen.ExecutionPolicyRequest) *MessageExecutionPolicyRequest {
+	message := &MessageExecutionPolicyRequest{}
+	message.Kind = ExecutionPolicyRequest
+	message.Request = request
+	return message
+}
+
+// MessageExecutionPolicyResponse is a response message (from the Node) carrying the decision of the execution policy
+type MessageExecutionPolicyResponse struct {
+	Message
+	Decision arwen.ExecutionPolicyDecision
+}
+
+// NewMessageExecutionPolicyResponse creates a MessageExecutionPolicyResponse
+func NewMessageExecutionPolicyResponse(decision arwen.ExecutionPolicyDecision) *MessageExecutionPolicyResponse {
+	message := &MessageExecutionPolicyResponse{}
+	message.Kind = ExecutionPolicyResponse
+	message.Decision = decision
+	return message
+}
@@ -26,6 +26,7 @@ func init() {
 	messageCreators[Stop] = createMessageStop
 	messageCreators[ContractDeployRequest] = createMessageContractDeployRequest
 	messageCreators[ContractCallRequest] = createMessageContractCallRequest
+	messageCreators[ContractQueryRequest] = createMessageContractQueryRequest
 	messageCreators[ContractResponse] = createMessageContractResponse
 	messageCreators[DiagnoseWaitRequest] = createMessageDiagnoseWaitRequest
 	messageCreators[DiagnoseWaitResponse] = createMessageDiagnoseWaitResponse
@@ -90,6 +91,8 @@ func init() {
 	messageCreators[BlockchainGetSnapshotResponse] = createMessageBlockchainGetSnapshotResponse
 	messageCreators[BlockchainRevertToSnapshotRequest] = createMessageBlockchainRevertToSnapshotRequest
 	messageCreators[BlockchainRevertToSnapshotResponse] = createMessageBlockchainRevertToSnapshotResponse
+	messageCreators[ExecutionPolicyRequest] = createMessageExecutionPolicyRequest
+	messageCreators[ExecutionPolicyResponse] = createMessageExecutionPolicyResponse
 
 }
 
@@ -109,6 +112,10 @@ func createMessageContractCallRequest() MessageHandler {
 	return &MessageContractCallRequest{}
 }
 
+func createMessageContractQueryRequest() MessageHandler {
+	return &MessageContractQueryRequest{}
+}
+
 func createMessageContractResponse() MessageHandler {
 	return &MessageContractResponse{}
 }
@@ -364,3 +371,11 @@ func createMessageBlockchainRevertToSnapshotRequest() MessageHandler {
 func createMessageBlockchainRevertToSnapshotResponse() MessageHandler {
 	return &MessageBlockchainRevertToSnapshotResponse{}
 }
+
+func createMessageExecutionPolicyRequest() MessageHandler {
+	return &MessageExecutionPolicyRequest{}
+}
+
+func createMessageExecutionPolicyResponse() MessageHandler {
+	return &MessageExecutionPolicyResponse{}
+}
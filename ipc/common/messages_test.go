@@ -4,6 +4,7 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
 	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/ipc/marshaling"
 	"github.com/ElrondNetwork/elrond-go/core/vmcommon"
 	"github.com/stretchr/testify/require"
@@ -70,6 +71,58 @@ func TestMessageBlockchainGetAllStateResponse_IsConsistentlySerializable(t *test
 	requireSerializationConsistency(t, message, &MessageBlockchainGetAllStateResponse{})
 }
 
+func TestMessageContractQueryRequest_IsConsistentlySerializable(t *testing.T) {
+	input := &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr:  []byte("caller"),
+			Arguments:   [][]byte{{0, 128}},
+			GasProvided: 42,
+		},
+		RecipientAddr: []byte("contract"),
+		Function:      "getValue",
+	}
+	message := NewMessageContractQueryRequest(input)
+	requireSerializationConsistency(t, message, &MessageContractQueryRequest{})
+}
+
+func TestMessageExecutionPolicy_IsConsistentlySerializable(t *testing.T) {
+	request := NewMessageExecutionPolicyRequest(arwen.ExecutionPolicyRequest{
+		Caller:      []byte("caller"),
+		Contract:    []byte{0, 128},
+		Function:    "doSomething",
+		GasProvided: 42,
+	})
+	requireSerializationConsistency(t, request, &MessageExecutionPolicyRequest{})
+
+	response := NewMessageExecutionPolicyResponse(arwen.ExecutionDeprioritized)
+	requireSerializationConsistency(t, response, &MessageExecutionPolicyResponse{})
+}
+
+func TestArwenArguments_ExecutionPolicyIsNotSerialized(t *testing.T) {
+	arguments := ArwenArguments{HasExecutionPolicy: true}
+	arguments.ExecutionPolicy = &executionPolicyStub{}
+
+	serialized, err := createArgumentsMarshalizer().Marshal(arguments)
+	require.Nil(t, err)
+
+	deserialized := &ArwenArguments{}
+	err = createArgumentsMarshalizer().Unmarshal(deserialized, serialized)
+	require.Nil(t, err)
+	require.True(t, deserialized.HasExecutionPolicy)
+	require.Nil(t, deserialized.ExecutionPolicy)
+}
+
+type executionPolicyStub struct {
+}
+
+func (policy *executionPolicyStub) Evaluate(_ arwen.ExecutionPolicyRequest) arwen.ExecutionPolicyDecision {
+	return arwen.ExecutionAllowed
+}
+
+func (policy *executionPolicyStub) IsInterfaceNil() bool {
+	return policy == nil
+}
+
 func requireSerializationConsistency(t *testing.T, message interface{}, intoMessage interface{}) {
 	marshalizer := marshaling.CreateMarshalizer(marshaling.JSON)
 
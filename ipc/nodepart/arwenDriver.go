@@ -10,6 +10,7 @@ import (
 	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/ipc/common"
 	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/ipc/marshaling"
 	logger "github.com/ElrondNetwork/elrond-go-logger"
+	"github.com/ElrondNetwork/elrond-go-logger/check"
 	"github.com/ElrondNetwork/elrond-go-logger/pipes"
 	"github.com/ElrondNetwork/elrond-go/core/vmcommon"
 )
@@ -64,6 +65,8 @@ func NewArwenDriver(
 		messagesMarshalizer: marshaling.CreateMarshalizer(arwenArguments.MessagesMarshalizer),
 	}
 
+	driver.arwenArguments.HasExecutionPolicy = !check.IfNil(arwenArguments.ExecutionPolicy)
+
 	err := driver.startArwen()
 	if err != nil {
 		return nil, err
@@ -132,6 +135,8 @@ func (driver *ArwenDriver) startArwen() error {
 		return err
 	}
 
+	driver.part.SetExecutionPolicy(driver.arwenArguments.ExecutionPolicy)
+
 	err = driver.logsPart.StartLoop(arwenStdout, arwenStderr)
 	if err != nil {
 		return err
@@ -301,15 +306,29 @@ func (driver *ArwenDriver) RunSmartContractCall(input *vmcommon.ContractCallInpu
 	driver.counterCall++
 	log.Trace("RunSmartContractCall", "counter", driver.counterCall, "func", input.Function, "sc", input.RecipientAddr)
 
+	return driver.runContractRequest("RunSmartContractCall", common.NewMessageContractCallRequest(input))
+}
+
+// RunSmartContractQuery sends a query request to Arwen and waits for the output
+func (driver *ArwenDriver) RunSmartContractQuery(input *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+	driver.operationsMutex.Lock()
+	defer driver.operationsMutex.Unlock()
+
+	driver.counterCall++
+	log.Trace("RunSmartContractQuery", "counter", driver.counterCall, "func", input.Function, "sc", input.RecipientAddr)
+
+	return driver.runContractRequest("RunSmartContractQuery", common.NewMessageContractQueryRequest(input))
+}
+
+func (driver *ArwenDriver) runContractRequest(operation string, request common.MessageHandler) (*vmcommon.VMOutput, error) {
 	err := driver.RestartArwenIfNecessary()
 	if err != nil {
 		return nil, common.WrapCriticalError(err)
 	}
 
-	request := common.NewMessageContractCallRequest(input)
 	response, err := driver.part.StartLoop(request)
 	if err != nil {
-		log.Warn("RunSmartContractCall", "err", err)
+		log.Warn(operation, "err", err)
 		_ = driver.Close()
 		return nil, common.WrapCriticalError(err)
 	}
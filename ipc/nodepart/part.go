@@ -5,17 +5,20 @@ import (
 	"os"
 	"time"
 
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
 	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/ipc/common"
 	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/ipc/marshaling"
+	"github.com/ElrondNetwork/elrond-go-logger/check"
 	"github.com/ElrondNetwork/elrond-go/core/vmcommon"
 )
 
 // NodePart is the endpoint that implements the message loop on Node's side
 type NodePart struct {
-	Messenger  *NodeMessenger
-	blockchain vmcommon.BlockchainHook
-	Repliers   []common.MessageReplier
-	config     Config
+	Messenger       *NodeMessenger
+	blockchain      vmcommon.BlockchainHook
+	executionPolicy arwen.ExecutionPolicy
+	Repliers        []common.MessageReplier
+	config          Config
 }
 
 // NewNodePart creates the Node part
@@ -64,10 +67,26 @@ func NewNodePart(
 	part.Repliers[common.BlockchainIsInterfaceNilRequest] = part.replyToBlockchainIsInterfaceNil
 	part.Repliers[common.BlockchainGetSnapshotRequest] = part.replyToBlockchainGetSnapshot
 	part.Repliers[common.BlockchainRevertToSnapshotRequest] = part.replyToBlockchainRevertToSnapshot
+	part.Repliers[common.ExecutionPolicyRequest] = part.replyToExecutionPolicy
 
 	return part, nil
 }
 
+// SetExecutionPolicy sets the policy used to reply to the execution policy requests of Arwen
+func (part *NodePart) SetExecutionPolicy(executionPolicy arwen.ExecutionPolicy) {
+	part.executionPolicy = executionPolicy
+}
+
+func (part *NodePart) replyToExecutionPolicy(request common.MessageHandler) common.MessageHandler {
+	typedRequest := request.(*common.MessageExecutionPolicyRequest)
+	if check.IfNil(part.executionPolicy) {
+		return common.NewMessageExecutionPolicyResponse(arwen.ExecutionAllowed)
+	}
+
+	decision := part.executionPolicy.Evaluate(typedRequest.Request)
+	return common.NewMessageExecutionPolicyResponse(decision)
+}
+
 func (part *NodePart) noopReplier(_ common.MessageHandler) common.MessageHandler {
 	log.Error("noopReplier called")
 	return common.CreateMessage(common.UndefinedRequestOrResponse)
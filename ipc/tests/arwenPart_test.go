@@ -79,6 +79,7 @@ func doContractRequest(
 			files.inputOfArwen,
 			files.outputOfArwen,
 			vmHostParameters,
+			false,
 			marshaling.CreateMarshalizer(marshaling.JSON),
 		)
 		assert.Nil(t, err)
@@ -0,0 +1,135 @@
+package mandoscontroller
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	fr "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mandos-go/fileresolver"
+	mj "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mandos-go/json/model"
+)
+
+// ParallelExecutorsFactory creates the executors which run concurrently the
+// scenarios declaring the given gas schedule, one for each worker.
+type ParallelExecutorsFactory func(gasSchedule mj.GasSchedule) ([]ScenarioExecutor, error)
+
+// scenarioFileResult is the outcome of a scenario file run by a worker
+type scenarioFileResult struct {
+	skipped bool
+	err     error
+}
+
+// RunAllJSONScenariosInDirectoryParallel works like RunAllJSONScenariosInDirectory,
+// but the scenario files are run concurrently, on a pool of workers, one for each executor.
+// The scenario files must be independent of each other, each of them is run after a Reset.
+// The executors of a VM may share global state which depends on the gas schedule,
+// so the scenario files are grouped by the gas schedule they declare and the groups
+// are run one after the other, each on new executors created for its gas schedule.
+// The results are printed in the order of the files, once all of them have run.
+func RunAllJSONScenariosInDirectoryParallel(
+	newExecutors ParallelExecutorsFactory,
+	fileResolver fr.FileResolver,
+	generalTestPath string,
+	specificTestPath string,
+	allowedSuffix string,
+	excludedFilePatterns []string) error {
+
+	mainDirPath := path.Join(generalTestPath, specificTestPath)
+	var testFilePaths []string
+	err := filepath.Walk(mainDirPath, func(testFilePath string, info os.FileInfo, err error) error {
+		if strings.HasSuffix(testFilePath, allowedSuffix) {
+			testFilePaths = append(testFilePaths, testFilePath)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	results := make([]scenarioFileResult, len(testFilePaths))
+	parser := NewScenarioRunner(nil, fileResolver.Clone())
+	var gasSchedules []mj.GasSchedule
+	filesByGasSchedule := make(map[mj.GasSchedule][]int)
+	for i, testFilePath := range testFilePaths {
+		if isExcluded(excludedFilePatterns, testFilePath, generalTestPath) {
+			results[i].skipped = true
+			continue
+		}
+		scenario, parseErr := parser.ParseJSONScenario(testFilePath)
+		if parseErr != nil {
+			results[i].err = parseErr
+			continue
+		}
+		if _, ok := filesByGasSchedule[scenario.GasSchedule]; !ok {
+			gasSchedules = append(gasSchedules, scenario.GasSchedule)
+		}
+		filesByGasSchedule[scenario.GasSchedule] = append(filesByGasSchedule[scenario.GasSchedule], i)
+	}
+
+	for _, gasSchedule := range gasSchedules {
+		executors, err := newExecutors(gasSchedule)
+		if err != nil {
+			return err
+		}
+		if len(executors) == 0 {
+			return errors.New("no executors provided")
+		}
+
+		runScenarioFilesParallel(executors, fileResolver, testFilePaths, filesByGasSchedule[gasSchedule], results)
+	}
+
+	var nrPassed, nrFailed, nrSkipped int
+	for i, testFilePath := range testFilePaths {
+		fmt.Printf("Scenario: %s ... ", shortenTestPath(testFilePath, generalTestPath))
+		switch {
+		case results[i].skipped:
+			nrSkipped++
+			fmt.Print("  skip\n")
+		case results[i].err == nil:
+			nrPassed++
+			fmt.Print("  ok\n")
+		default:
+			nrFailed++
+			fmt.Printf("  FAIL: %s\n", results[i].err.Error())
+		}
+	}
+	fmt.Printf("Done. Passed: %d. Failed: %d. Skipped: %d.\n", nrPassed, nrFailed, nrSkipped)
+	if nrFailed > 0 {
+		return errors.New("Some tests failed")
+	}
+
+	return nil
+}
+
+// runScenarioFilesParallel runs the scenario files with the given indexes on
+// the given executors, and records their results at the same indexes
+func runScenarioFilesParallel(
+	executors []ScenarioExecutor,
+	fileResolver fr.FileResolver,
+	testFilePaths []string,
+	fileIndexes []int,
+	results []scenarioFileResult) {
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for _, executor := range executors {
+		runner := NewScenarioRunner(executor, fileResolver.Clone())
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				runner.Executor.Reset()
+				results[i].err = runner.RunSingleJSONScenario(testFilePaths[i])
+			}
+		}()
+	}
+	for _, i := range fileIndexes {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+}
@@ -11,10 +11,20 @@ import (
 
 // RunSingleJSONScenario parses and prepares test, then calls testCallback.
 func (r *ScenarioRunner) RunSingleJSONScenario(contextPath string) error {
+	scenario, err := r.ParseJSONScenario(contextPath)
+	if err != nil {
+		return err
+	}
+
+	return r.Executor.ExecuteScenario(scenario, r.Parser.ExprInterpreter.FileResolver)
+}
+
+// ParseJSONScenario parses the scenario file at the given path, without executing it.
+func (r *ScenarioRunner) ParseJSONScenario(contextPath string) (*mj.Scenario, error) {
 	var err error
 	contextPath, err = filepath.Abs(contextPath)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// Open our jsonFile
@@ -22,7 +32,7 @@ func (r *ScenarioRunner) RunSingleJSONScenario(contextPath string) error {
 	jsonFile, err = os.Open(contextPath)
 	// if we os.Open returns an error then handle it
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// defer the closing of our jsonFile so that we can parse it later on
@@ -30,16 +40,11 @@ func (r *ScenarioRunner) RunSingleJSONScenario(contextPath string) error {
 
 	byteValue, err := ioutil.ReadAll(jsonFile)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	r.Parser.ExprInterpreter.FileResolver.SetContext(contextPath)
-	scenario, parseErr := r.Parser.ParseScenarioFile(byteValue)
-	if parseErr != nil {
-		return parseErr
-	}
-
-	return r.Executor.ExecuteScenario(scenario, r.Parser.ExprInterpreter.FileResolver)
+	return r.Parser.ParseScenarioFile(byteValue)
 }
 
 // tool to modify scenarios
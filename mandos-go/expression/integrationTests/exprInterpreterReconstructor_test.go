@@ -2,6 +2,7 @@ package mandosjsontest
 
 import (
 	"encoding/hex"
+	"math/big"
 	"testing"
 
 	mei "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mandos-go/expression/interpreter"
@@ -564,3 +565,84 @@ func TestInterpretSubTree2(t *testing.T) {
 	expected = append(expected, []byte("field2elem3b")...)
 	require.Equal(t, expected, result)
 }
+
+func TestVariables(t *testing.T) {
+	ei := mei.ExprInterpreter{}
+	err := ei.SetVariable("initial", []byte{0x03, 0xe8})
+	require.Nil(t, err)
+	err = ei.SetVariable("token_id-2", []byte("TOKEN"))
+	require.Nil(t, err)
+
+	result, err := ei.InterpretString("${initial}")
+	require.Nil(t, err)
+	require.Equal(t, []byte{0x03, 0xe8}, result)
+
+	result, err = ei.InterpretString("${token_id-2}|u8:1")
+	require.Nil(t, err)
+	require.Equal(t, []byte("TOKEN\x01"), result)
+
+	result, err = ei.InterpretString("str:${initial}")
+	require.Nil(t, err)
+	require.Equal(t, []byte("${initial}"), result)
+
+	_, err = ei.InterpretString("${missing}")
+	require.EqualError(t, err, "unknown variable: missing")
+
+	err = ei.SetVariable("bad name", []byte{})
+	require.EqualError(t, err, "invalid variable name: bad name")
+	err = ei.SetVariable("", []byte{})
+	require.NotNil(t, err)
+}
+
+func TestArithmetic(t *testing.T) {
+	ei := mei.ExprInterpreter{}
+
+	result, err := ei.InterpretString("1,000 - 0x64")
+	require.Nil(t, err)
+	require.Equal(t, big.NewInt(900).Bytes(), result)
+
+	result, err = ei.InterpretString("2 + 3 * 4")
+	require.Nil(t, err)
+	require.Equal(t, []byte{14}, result)
+
+	result, err = ei.InterpretString("100 - 10 - 5")
+	require.Nil(t, err)
+	require.Equal(t, []byte{85}, result)
+
+	result, err = ei.InterpretString("17 / 5 + 17 % 5")
+	require.Nil(t, err)
+	require.Equal(t, []byte{5}, result)
+
+	result, err = ei.InterpretString("1 - 2")
+	require.Nil(t, err)
+	require.Equal(t, []byte{0xff}, result)
+
+	result, err = ei.InterpretString("5 - 5")
+	require.Nil(t, err)
+	require.Equal(t, []byte{}, result)
+
+	_, err = ei.InterpretString("5 / 0")
+	require.EqualError(t, err, "division by zero")
+
+	_, err = ei.InterpretString("5 + abc")
+	require.NotNil(t, err)
+
+	// not an arithmetic expression, operators need the spaces
+	_, err = ei.InterpretString("5+3")
+	require.NotNil(t, err)
+}
+
+func TestArithmeticWithVariables(t *testing.T) {
+	ei := mei.ExprInterpreter{}
+	require.Nil(t, ei.SetVariable("initial", big.NewInt(1000000).Bytes()))
+	require.Nil(t, ei.SetVariable("fee", big.NewInt(1500).Bytes()))
+
+	result, err := ei.InterpretString("${initial} - ${fee} * 2")
+	require.Nil(t, err)
+	require.Equal(t, big.NewInt(997000).Bytes(), result)
+
+	// concatenation binds looser than arithmetic
+	result, err = ei.InterpretString("${fee} + 1|u16:2")
+	require.Nil(t, err)
+	require.Equal(t, []byte{0x05, 0xdd, 0x00, 0x02}, result)
+}
@@ -0,0 +1,151 @@
+package mandosexpressioninterpreter
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	twos "github.com/ElrondNetwork/big-int-util/twos-complement"
+)
+
+const variablePrefix = "${"
+const variableSuffix = "}"
+
+var arithmeticOperators = map[string]int{
+	"+": 1,
+	"-": 1,
+	"*": 2,
+	"/": 2,
+	"%": 2,
+}
+
+// SetVariable defines a variable, which the following expressions can
+// reference as "${name}".
+func (ei *ExprInterpreter) SetVariable(name string, value []byte) error {
+	if !isValidVariableName(name) {
+		return fmt.Errorf("invalid variable name: %s", name)
+	}
+	if ei.Variables == nil {
+		ei.Variables = make(map[string][]byte)
+	}
+	ei.Variables[name] = append([]byte{}, value...)
+	return nil
+}
+
+func isValidVariableName(name string) bool {
+	if len(name) == 0 {
+		return false
+	}
+	for _, c := range name {
+		isLetter := (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+		isDigit := c >= '0' && c <= '9'
+		if !isLetter && !isDigit && c != '_' && c != '-' {
+			return false
+		}
+	}
+	return true
+}
+
+func isVariableReference(strRaw string) bool {
+	return strings.HasPrefix(strRaw, variablePrefix) && strings.HasSuffix(strRaw, variableSuffix)
+}
+
+func (ei *ExprInterpreter) interpretVariable(strRaw string) ([]byte, error) {
+	name := strRaw[len(variablePrefix) : len(strRaw)-len(variableSuffix)]
+	value, found := ei.Variables[name]
+	if !found {
+		return []byte{}, fmt.Errorf("unknown variable: %s", name)
+	}
+	return value, nil
+}
+
+// isArithmeticExpression checks whether the string is of the form
+// "operand operator operand ...", the operators being surrounded by spaces.
+func isArithmeticExpression(tokens []string) bool {
+	if len(tokens) < 3 || len(tokens)%2 == 0 {
+		return false
+	}
+	for i := 1; i < len(tokens); i += 2 {
+		if _, isOperator := arithmeticOperators[tokens[i]]; !isOperator {
+			return false
+		}
+	}
+	return true
+}
+
+// interpretArithmetic evaluates an arithmetic expression, with the usual
+// precedence of the operators, left to right. The operands are interpreted as
+// unsigned numbers. A negative result is represented in two's complement,
+// the same as a negative number literal.
+func (ei *ExprInterpreter) interpretArithmetic(tokens []string) ([]byte, error) {
+	// the terms of the sum, each computed from its factors
+	terms := make([]*big.Int, 0)
+	termOperators := make([]string, 0)
+
+	current, err := ei.interpretOperand(tokens[0])
+	if err != nil {
+		return []byte{}, err
+	}
+	for i := 1; i < len(tokens); i += 2 {
+		operator := tokens[i]
+		operand, err := ei.interpretOperand(tokens[i+1])
+		if err != nil {
+			return []byte{}, err
+		}
+
+		if arithmeticOperators[operator] == 1 {
+			terms = append(terms, current)
+			termOperators = append(termOperators, operator)
+			current = operand
+			continue
+		}
+
+		current, err = applyArithmeticOperator(current, operator, operand)
+		if err != nil {
+			return []byte{}, err
+		}
+	}
+
+	terms = append(terms, current)
+	result := terms[0]
+	for i, operator := range termOperators {
+		result, _ = applyArithmeticOperator(result, operator, terms[i+1])
+	}
+
+	if result.Sign() < 0 {
+		return twos.ToBytes(result), nil
+	}
+	return result.Bytes(), nil
+}
+
+func (ei *ExprInterpreter) interpretOperand(strRaw string) (*big.Int, error) {
+	operandBytes, err := ei.InterpretString(strRaw)
+	if err != nil {
+		return nil, fmt.Errorf("cannot interpret operand %s: %w", strRaw, err)
+	}
+	return big.NewInt(0).SetBytes(operandBytes), nil
+}
+
+func applyArithmeticOperator(left *big.Int, operator string, right *big.Int) (*big.Int, error) {
+	switch operator {
+	case "+":
+		return big.NewInt(0).Add(left, right), nil
+	case "-":
+		return big.NewInt(0).Sub(left, right), nil
+	case "*":
+		return big.NewInt(0).Mul(left, right), nil
+	case "/":
+		if right.Sign() == 0 {
+			return nil, errors.New("division by zero")
+		}
+		return big.NewInt(0).Quo(left, right), nil
+	case "%":
+		if right.Sign() == 0 {
+			return nil, errors.New("division by zero")
+		}
+		return big.NewInt(0).Rem(left, right), nil
+	default:
+		return nil, fmt.Errorf("unknown operator: %s", operator)
+	}
+}
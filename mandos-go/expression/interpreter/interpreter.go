@@ -35,6 +35,7 @@ const nestedPrefix = "nested:"
 // ExprInterpreter provides context for computing Mandos values.
 type ExprInterpreter struct {
 	FileResolver fr.FileResolver
+	Variables    map[string][]byte
 }
 
 // InterpretSubTree attempts to produce a value based on a JSON subtree.
@@ -90,6 +91,8 @@ func (ei *ExprInterpreter) InterpretSubTree(obj oj.OJsonObject) ([]byte, error)
 // - "sc:..." (also an address)
 // - "file:..."
 // - "keccak256:..."
+// - variables, as "${name}"
+// - arithmetic using +, -, *, / and %, surrounded by spaces: "${initial} - ${fee}"
 // - concatenation using |
 //
 func (ei *ExprInterpreter) InterpretString(strRaw string) ([]byte, error) {
@@ -167,6 +170,16 @@ func (ei *ExprInterpreter) InterpretString(strRaw string) ([]byte, error) {
 		return scExpression(addrArgument)
 	}
 
+	// arithmetic, binds tighter than concatenation
+	tokens := strings.Fields(strRaw)
+	if isArithmeticExpression(tokens) {
+		return ei.interpretArithmetic(tokens)
+	}
+
+	if isVariableReference(strRaw) {
+		return ei.interpretVariable(strRaw)
+	}
+
 	// fixed width numbers
 	parsed, result, err := ei.tryInterpretFixedWidth(strRaw)
 	if err != nil {
@@ -8,6 +8,9 @@ type FileResolver interface {
 	// SetContext sets directory where the test runs, to help resolve relative paths.
 	SetContext(contextPath string)
 
+	// ContextPath yields the path of the file where the test runs.
+	ContextPath() string
+
 	// ResolveAbsolutePath yields absolute value based on context.
 	ResolveAbsolutePath(value string) string
 
@@ -41,6 +41,11 @@ func (fr *DefaultFileResolver) SetContext(contextPath string) {
 	fr.contextPath = contextPath
 }
 
+// ContextPath yields the path of the file where the test runs.
+func (fr *DefaultFileResolver) ContextPath() string {
+	return fr.contextPath
+}
+
 // ResolveAbsolutePath yields absolute value based on context.
 func (fr *DefaultFileResolver) ResolveAbsolutePath(value string) string {
 	var fullPath string
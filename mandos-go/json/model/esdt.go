@@ -15,6 +15,7 @@ type ESDTInstance struct {
 	Royalties  JSONUint64
 	Hash       JSONBytesFromString
 	Uri        JSONBytesFromTree
+	Uris       []JSONBytesFromTree
 	Attributes JSONBytesFromString
 }
 
@@ -29,25 +30,30 @@ type ESDTData struct {
 
 // CheckESDTInstance checks an instance of an NFT/SFT, with its own nonce
 type CheckESDTInstance struct {
-	Nonce      JSONCheckUint64
-	Balance    JSONCheckBigInt
-	Creator    JSONCheckBytes
-	Royalties  JSONCheckUint64
-	Hash       JSONCheckBytes
-	Uri        JSONCheckBytes
-	Attributes JSONCheckBytes
+	Nonce           JSONCheckUint64
+	Balance         JSONCheckBigInt
+	Creator         JSONCheckBytes
+	Royalties       JSONCheckUint64
+	Hash            JSONCheckBytes
+	Uri             JSONCheckBytes
+	Uris            []JSONCheckBytes
+	UrisUnspecified bool
+	Attributes      JSONCheckBytes
+	Frozen          JSONCheckUint64
 }
 
 // NewCheckESDTInstance creates an instance with all fields unspecified.
 func NewCheckESDTInstance() *CheckESDTInstance {
 	return &CheckESDTInstance{
-		Nonce:      JSONCheckUint64Unspecified(),
-		Balance:    JSONCheckBigIntUnspecified(),
-		Creator:    JSONCheckBytesUnspecified(),
-		Royalties:  JSONCheckUint64Unspecified(),
-		Hash:       JSONCheckBytesUnspecified(),
-		Uri:        JSONCheckBytesUnspecified(),
-		Attributes: JSONCheckBytesUnspecified(),
+		Nonce:           JSONCheckUint64Unspecified(),
+		Balance:         JSONCheckBigIntUnspecified(),
+		Creator:         JSONCheckBytesUnspecified(),
+		Royalties:       JSONCheckUint64Unspecified(),
+		Hash:            JSONCheckBytesUnspecified(),
+		Uri:             JSONCheckBytesUnspecified(),
+		UrisUnspecified: true,
+		Attributes:      JSONCheckBytesUnspecified(),
+		Frozen:          JSONCheckUint64Unspecified(),
 	}
 }
 
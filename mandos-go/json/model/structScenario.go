@@ -30,10 +30,12 @@ type BlockInfo struct {
 	BlockRandomSeed *JSONBytesFromTree
 }
 
-// ExternalStepsStep allows including steps from another file
+// ExternalStepsStep allows including steps from another file.
+// The parameters are defined as variables of the included file, so it can be reused.
 type ExternalStepsStep struct {
-	Comment string
-	Path    string
+	Comment    string
+	Path       string
+	Parameters []*LetVariable
 }
 
 // SetStateStep is a step where data is saved to the blockchain mock.
@@ -57,6 +59,50 @@ type DumpStateStep struct {
 	Comment string
 }
 
+// LetStep is a step that defines variables, which the values of the following
+// steps can reference as "${name}". Variables are resolved when parsing.
+type LetStep struct {
+	Comment   string
+	Variables []*LetVariable
+}
+
+// LetVariable is a variable defined by a LetStep, or a parameter of an ExternalStepsStep.
+type LetVariable struct {
+	Name  string
+	Value JSONBytesFromTree
+}
+
+// SaveStateStep is a step that takes a snapshot of the entire state, under a name,
+// so that it can later be brought back by a RestoreStateStep.
+type SaveStateStep struct {
+	Comment string
+	Name    string
+}
+
+// RestoreStateStep is a step that replaces the entire state with a snapshot
+// previously taken by a SaveStateStep.
+type RestoreStateStep struct {
+	Comment string
+	Name    string
+}
+
+// AdvanceRoundsStep is a step that moves the current block forward by a number of rounds,
+// with one block per round. The timestamp increases by the round duration for each round.
+// The current block info becomes the previous one.
+type AdvanceRoundsStep struct {
+	Comment       string
+	Rounds        JSONUint64
+	RoundDuration JSONUint64
+}
+
+// AdvanceEpochStep is a step that moves to the next block, in a later epoch.
+// The current block info becomes the previous one.
+type AdvanceEpochStep struct {
+	Comment       string
+	Epochs        JSONUint64
+	RoundDuration JSONUint64
+}
+
 // TxStep is a step where a transaction is executed.
 type TxStep struct {
 	TxIdent        string
@@ -65,11 +111,26 @@ type TxStep struct {
 	ExpectedResult *TransactionResult
 }
 
+// CrossShardCallbackStep is a step where the oldest pending cross-shard
+// asynchronous call is executed in the shard of its destination, followed by
+// its callback, executed back in the shard of the caller.
+type CrossShardCallbackStep struct {
+	TxIdent        string
+	Comment        string
+	ExpectedResult *TransactionResult
+}
+
 var _ Step = (*ExternalStepsStep)(nil)
 var _ Step = (*SetStateStep)(nil)
 var _ Step = (*CheckStateStep)(nil)
 var _ Step = (*DumpStateStep)(nil)
+var _ Step = (*LetStep)(nil)
+var _ Step = (*SaveStateStep)(nil)
+var _ Step = (*RestoreStateStep)(nil)
+var _ Step = (*AdvanceRoundsStep)(nil)
+var _ Step = (*AdvanceEpochStep)(nil)
 var _ Step = (*TxStep)(nil)
+var _ Step = (*CrossShardCallbackStep)(nil)
 
 // StepNameExternalSteps is a json step type name.
 const StepNameExternalSteps = "externalSteps"
@@ -103,6 +164,54 @@ func (*DumpStateStep) StepTypeName() string {
 	return StepNameDumpState
 }
 
+// StepNameLet is a json step type name.
+const StepNameLet = "let"
+
+// StepTypeName type as string
+func (*LetStep) StepTypeName() string {
+	return StepNameLet
+}
+
+// StepNameSaveState is a json step type name.
+const StepNameSaveState = "saveState"
+
+// StepTypeName type as string
+func (*SaveStateStep) StepTypeName() string {
+	return StepNameSaveState
+}
+
+// StepNameRestoreState is a json step type name.
+const StepNameRestoreState = "restoreState"
+
+// StepTypeName type as string
+func (*RestoreStateStep) StepTypeName() string {
+	return StepNameRestoreState
+}
+
+// StepNameAdvanceRounds is a json step type name.
+const StepNameAdvanceRounds = "advanceRounds"
+
+// StepTypeName type as string
+func (*AdvanceRoundsStep) StepTypeName() string {
+	return StepNameAdvanceRounds
+}
+
+// StepNameAdvanceEpoch is a json step type name.
+const StepNameAdvanceEpoch = "advanceEpoch"
+
+// StepTypeName type as string
+func (*AdvanceEpochStep) StepTypeName() string {
+	return StepNameAdvanceEpoch
+}
+
+// StepNameCrossShardCallback is a json step type name.
+const StepNameCrossShardCallback = "crossShardCallback"
+
+// StepTypeName type as string
+func (*CrossShardCallbackStep) StepTypeName() string {
+	return StepNameCrossShardCallback
+}
+
 // StepNameScCall is a json step type name.
 const StepNameScCall = "scCall"
 
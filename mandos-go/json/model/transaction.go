@@ -78,6 +78,7 @@ type TransactionResult struct {
 	Status          JSONCheckBigInt
 	Message         JSONCheckBytes
 	Gas             JSONCheckUint64
+	GasUsedDelta    JSONUint64
 	Refund          JSONCheckBigInt
 	LogsStar        bool
 	LogsUnspecified bool
@@ -86,9 +87,11 @@ type TransactionResult struct {
 }
 
 // LogEntry is a json object representing an expected transaction result log entry.
+// "topics": "*" accepts any number of topics, with any values.
 type LogEntry struct {
 	Address    JSONCheckBytes
 	Identifier JSONCheckBytes
+	TopicsStar bool
 	Topics     []JSONCheckBytes
 	Data       JSONCheckBytes
 }
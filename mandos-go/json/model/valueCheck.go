@@ -10,9 +10,11 @@ import (
 // JSONCheckBytes holds a byte slice condition.
 // Values are checked for equality.
 // "*" allows all values.
+// Prefix conditions only check that the value starts with the given bytes.
 type JSONCheckBytes struct {
 	Value       []byte
 	IsStar      bool
+	IsPrefix    bool
 	Original    oj.OJsonObject
 	Unspecified bool
 }
@@ -66,6 +68,9 @@ func (jcbytes JSONCheckBytes) Check(other []byte) bool {
 	if jcbytes.IsStar {
 		return true
 	}
+	if jcbytes.IsPrefix {
+		return bytes.HasPrefix(other, jcbytes.Value)
+	}
 	return bytes.Equal(jcbytes.Value, other)
 }
 
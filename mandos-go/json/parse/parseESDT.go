@@ -127,6 +127,11 @@ func (p *Parser) tryProcessESDTInstanceField(kvp *oj.OJsonKeyValuePair, targetIn
 		if err != nil {
 			return false, fmt.Errorf("invalid ESDT NFT URI: %w", err)
 		}
+	case "uris":
+		targetInstance.Uris, err = p.parseSubTreeList(kvp.Value)
+		if err != nil {
+			return false, fmt.Errorf("invalid ESDT NFT URIs: %w", err)
+		}
 	case "attributes":
 		targetInstance.Attributes, err = p.processStringAsByteArray(kvp.Value)
 		if err != nil {
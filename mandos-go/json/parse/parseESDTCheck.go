@@ -24,8 +24,10 @@ func (p *Parser) processCheckESDTData(
 		}
 		esdtData.Instances = []*mj.CheckESDTInstance{
 			{
-				Nonce:   mj.JSONCheckUint64{Value: 0, Original: ""},
-				Balance: balance,
+				Nonce:           mj.JSONCheckUint64{Value: 0, Original: ""},
+				Balance:         balance,
+				UrisUnspecified: true,
+				Frozen:          mj.JSONCheckUint64Unspecified(),
 			},
 		}
 		return &esdtData, nil
@@ -46,15 +48,7 @@ func (p *Parser) processCheckESDTDataMap(tokenName mj.JSONBytesFromString, esdtD
 		TokenIdentifier: tokenName,
 	}
 	// var err error
-	firstInstance := &mj.CheckESDTInstance{
-		Nonce:      mj.JSONCheckUint64Unspecified(),
-		Balance:    mj.JSONCheckBigIntUnspecified(),
-		Creator:    mj.JSONCheckBytesUnspecified(),
-		Royalties:  mj.JSONCheckUint64Unspecified(),
-		Hash:       mj.JSONCheckBytesUnspecified(),
-		Uri:        mj.JSONCheckBytesUnspecified(),
-		Attributes: mj.JSONCheckBytesUnspecified(),
-	}
+	firstInstance := mj.NewCheckESDTInstance()
 	firstInstanceLoaded := false
 	var explicitInstances []*mj.CheckESDTInstance
 
@@ -138,6 +132,12 @@ func (p *Parser) tryProcessCheckESDTInstanceField(kvp *oj.OJsonKeyValuePair, tar
 		if err != nil {
 			return false, fmt.Errorf("invalid ESDT NFT URI: %w", err)
 		}
+	case "uris":
+		targetInstance.Uris, err = p.parseCheckBytesList(kvp.Value)
+		if err != nil {
+			return false, fmt.Errorf("invalid ESDT NFT URIs: %w", err)
+		}
+		targetInstance.UrisUnspecified = false
 	case "attributes":
 		targetInstance.Attributes, err = p.parseCheckBytes(kvp.Value)
 		if err != nil {
@@ -168,9 +168,17 @@ func (p *Parser) processCheckESDTInstances(esdtInstancesRaw oj.OJsonObject) ([]*
 			if err != nil {
 				return nil, fmt.Errorf("invalid account ESDT instance field in instances list: %w", err)
 			}
-			if !instanceFieldLoaded {
+			if instanceFieldLoaded {
+				continue
+			}
+			// the frozen flag is a token field in the compact form, but can also be checked per instance
+			if kvp.Key != "frozen" {
 				return nil, fmt.Errorf("invalid account ESDT instance field in instances list: `%s`", kvp.Key)
 			}
+			instance.Frozen, err = p.processCheckUint64(kvp.Value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid ESDT instance frozen flag: %w", err)
+			}
 		}
 
 		instancesResult = append(instancesResult, instance)
@@ -3,11 +3,14 @@ package mandosjsonparse
 import (
 	"errors"
 	"fmt"
+	"strings"
 
 	mj "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mandos-go/json/model"
 	oj "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mandos-go/orderedjson"
 )
 
+const logPrefixMatcher = "prefix:"
+
 func (p *Parser) processLogList(logsRaw oj.OJsonObject) ([]*mj.LogEntry, error) {
 	logList, isList := logsRaw.(*oj.OJsonList)
 	if !isList {
@@ -24,22 +27,26 @@ func (p *Parser) processLogList(logsRaw oj.OJsonObject) ([]*mj.LogEntry, error)
 		for _, kvp := range logMap.OrderedKV {
 			switch kvp.Key {
 			case "address":
-				logEntry.Address, err = p.parseCheckBytes(kvp.Value)
+				logEntry.Address, err = p.parseLogCheckBytes(kvp.Value)
 				if err != nil {
 					return nil, fmt.Errorf("invalid log address: %w", err)
 				}
 			case "identifier":
-				logEntry.Identifier, err = p.parseCheckBytes(kvp.Value)
+				logEntry.Identifier, err = p.parseLogCheckBytes(kvp.Value)
 				if err != nil {
 					return nil, fmt.Errorf("invalid log identifier: %w", err)
 				}
 			case "topics":
-				logEntry.Topics, err = p.parseCheckBytesList(kvp.Value)
+				if IsStar(kvp.Value) {
+					logEntry.TopicsStar = true
+					continue
+				}
+				logEntry.Topics, err = p.parseLogTopics(kvp.Value)
 				if err != nil {
 					return nil, fmt.Errorf("invalid log entry topics: %w", err)
 				}
 			case "data":
-				logEntry.Data, err = p.parseCheckBytes(kvp.Value)
+				logEntry.Data, err = p.parseLogCheckBytes(kvp.Value)
 				if err != nil {
 					return nil, fmt.Errorf("invalid log data: %w", err)
 				}
@@ -52,3 +59,43 @@ func (p *Parser) processLogList(logsRaw oj.OJsonObject) ([]*mj.LogEntry, error)
 
 	return logEntries, nil
 }
+
+func (p *Parser) parseLogTopics(obj oj.OJsonObject) ([]mj.JSONCheckBytes, error) {
+	listRaw, listOk := obj.(*oj.OJsonList)
+	if !listOk {
+		return nil, errors.New("not a JSON list")
+	}
+	var result []mj.JSONCheckBytes
+	for _, elemRaw := range listRaw.AsList() {
+		topic, err := p.parseLogCheckBytes(elemRaw)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, topic)
+	}
+	return result, nil
+}
+
+// parseLogCheckBytes also accepts "prefix:<expression>" in log fields,
+// which only checks that the logged value starts with the interpreted expression.
+func (p *Parser) parseLogCheckBytes(obj oj.OJsonObject) (mj.JSONCheckBytes, error) {
+	str, isStr := obj.(*oj.OJsonString)
+	if !isStr || !strings.HasPrefix(str.Value, logPrefixMatcher) {
+		return p.parseCheckBytes(obj)
+	}
+
+	prefixExpr := str.Value[len(logPrefixMatcher):]
+	prefix, err := p.ExprInterpreter.InterpretString(prefixExpr)
+	if err != nil {
+		return mj.JSONCheckBytes{}, fmt.Errorf("invalid prefix matcher: %w", err)
+	}
+	if len(prefix) == 0 {
+		return mj.JSONCheckBytes{}, errors.New("empty prefix matcher, use \"*\" to accept any value")
+	}
+	return mj.JSONCheckBytes{
+		Value:    prefix,
+		IsStar:   false,
+		IsPrefix: true,
+		Original: obj,
+	}, nil
+}
@@ -143,6 +143,11 @@ func (p *Parser) processScenarioStep(stepObj oj.OJsonObject) (mj.Step, error) {
 				if err != nil {
 					return nil, fmt.Errorf("bad externalSteps path: %w", err)
 				}
+			case "parameters":
+				step.Parameters, err = p.processExternalStepsParameters(kvp.Value)
+				if err != nil {
+					return nil, fmt.Errorf("bad externalSteps parameters: %w", err)
+				}
 			default:
 				return nil, fmt.Errorf("invalid externalSteps field: %s", kvp.Key)
 			}
@@ -223,6 +228,132 @@ func (p *Parser) processScenarioStep(stepObj oj.OJsonObject) (mj.Step, error) {
 			}
 		}
 		return step, nil
+	case mj.StepNameLet:
+		step := &mj.LetStep{}
+		for _, kvp := range stepMap.OrderedKV {
+			switch kvp.Key {
+			case "step":
+			case "comment":
+				step.Comment, err = p.parseString(kvp.Value)
+				if err != nil {
+					return nil, fmt.Errorf("bad let step comment: %w", err)
+				}
+			case "variables":
+				step.Variables, err = p.processLetVariables(kvp.Value)
+				if err != nil {
+					return nil, fmt.Errorf("cannot parse let step: %w", err)
+				}
+			default:
+				return nil, fmt.Errorf("invalid let step field: %s", kvp.Key)
+			}
+		}
+		return step, nil
+	case mj.StepNameSaveState:
+		step := &mj.SaveStateStep{}
+		for _, kvp := range stepMap.OrderedKV {
+			switch kvp.Key {
+			case "step":
+			case "comment":
+				step.Comment, err = p.parseString(kvp.Value)
+				if err != nil {
+					return nil, fmt.Errorf("bad save state step comment: %w", err)
+				}
+			case "name":
+				step.Name, err = p.parseString(kvp.Value)
+				if err != nil {
+					return nil, fmt.Errorf("bad save state step name: %w", err)
+				}
+			default:
+				return nil, fmt.Errorf("invalid save state field: %s", kvp.Key)
+			}
+		}
+		if len(step.Name) == 0 {
+			return nil, errors.New("save state step name missing")
+		}
+		return step, nil
+	case mj.StepNameRestoreState:
+		step := &mj.RestoreStateStep{}
+		for _, kvp := range stepMap.OrderedKV {
+			switch kvp.Key {
+			case "step":
+			case "comment":
+				step.Comment, err = p.parseString(kvp.Value)
+				if err != nil {
+					return nil, fmt.Errorf("bad restore state step comment: %w", err)
+				}
+			case "name":
+				step.Name, err = p.parseString(kvp.Value)
+				if err != nil {
+					return nil, fmt.Errorf("bad restore state step name: %w", err)
+				}
+			default:
+				return nil, fmt.Errorf("invalid restore state field: %s", kvp.Key)
+			}
+		}
+		if len(step.Name) == 0 {
+			return nil, errors.New("restore state step name missing")
+		}
+		return step, nil
+	case mj.StepNameAdvanceRounds:
+		step := &mj.AdvanceRoundsStep{}
+		for _, kvp := range stepMap.OrderedKV {
+			switch kvp.Key {
+			case "step":
+			case "comment":
+				step.Comment, err = p.parseString(kvp.Value)
+				if err != nil {
+					return nil, fmt.Errorf("bad advance rounds step comment: %w", err)
+				}
+			case "rounds":
+				step.Rounds, err = p.processUint64(kvp.Value)
+				if err != nil {
+					return nil, fmt.Errorf("bad advance rounds step rounds: %w", err)
+				}
+			case "roundDuration":
+				step.RoundDuration, err = p.processUint64(kvp.Value)
+				if err != nil {
+					return nil, fmt.Errorf("bad advance rounds step round duration: %w", err)
+				}
+			default:
+				return nil, fmt.Errorf("invalid advance rounds field: %s", kvp.Key)
+			}
+		}
+		if step.Rounds.Value == 0 {
+			return nil, errors.New("advance rounds step requires a positive number of rounds")
+		}
+		return step, nil
+	case mj.StepNameAdvanceEpoch:
+		step := &mj.AdvanceEpochStep{
+			Epochs: mj.JSONUint64{Value: 1, Original: ""},
+		}
+		for _, kvp := range stepMap.OrderedKV {
+			switch kvp.Key {
+			case "step":
+			case "comment":
+				step.Comment, err = p.parseString(kvp.Value)
+				if err != nil {
+					return nil, fmt.Errorf("bad advance epoch step comment: %w", err)
+				}
+			case "epochs":
+				step.Epochs, err = p.processUint64(kvp.Value)
+				if err != nil {
+					return nil, fmt.Errorf("bad advance epoch step epochs: %w", err)
+				}
+			case "roundDuration":
+				step.RoundDuration, err = p.processUint64(kvp.Value)
+				if err != nil {
+					return nil, fmt.Errorf("bad advance epoch step round duration: %w", err)
+				}
+			default:
+				return nil, fmt.Errorf("invalid advance epoch field: %s", kvp.Key)
+			}
+		}
+		if step.Epochs.Value == 0 {
+			return nil, errors.New("advance epoch step requires a positive number of epochs")
+		}
+		return step, nil
+	case mj.StepNameCrossShardCallback:
+		return p.parseCrossShardCallbackStep(stepMap)
 	case mj.StepNameScCall:
 		return p.parseTxStep(mj.ScCall, stepMap)
 	case mj.StepNameScDeploy:
@@ -273,3 +404,78 @@ func (p *Parser) parseTxStep(txType mj.TransactionType, stepMap *oj.OJsonMap) (*
 	}
 	return step, nil
 }
+
+func (p *Parser) parseCrossShardCallbackStep(stepMap *oj.OJsonMap) (*mj.CrossShardCallbackStep, error) {
+	step := &mj.CrossShardCallbackStep{}
+	var err error
+	for _, kvp := range stepMap.OrderedKV {
+		switch kvp.Key {
+		case "step":
+		case "txId":
+			step.TxIdent, err = p.parseString(kvp.Value)
+			if err != nil {
+				return nil, fmt.Errorf("bad cross-shard callback step id: %w", err)
+			}
+		case "comment":
+			step.Comment, err = p.parseString(kvp.Value)
+			if err != nil {
+				return nil, fmt.Errorf("bad cross-shard callback step comment: %w", err)
+			}
+		case "expect":
+			step.ExpectedResult, err = p.processTxExpectedResult(kvp.Value)
+			if err != nil {
+				return nil, fmt.Errorf("cannot parse cross-shard callback expected result: %w", err)
+			}
+		default:
+			return nil, fmt.Errorf("invalid cross-shard callback step field: %s", kvp.Key)
+		}
+	}
+	return step, nil
+}
+
+// processExternalStepsParameters parses the parameters of an externalSteps step.
+// They are interpreted in the context of the including scenario,
+// but are only defined as variables in the included one.
+func (p *Parser) processExternalStepsParameters(obj oj.OJsonObject) ([]*mj.LetVariable, error) {
+	parametersMap, isMap := obj.(*oj.OJsonMap)
+	if !isMap {
+		return nil, errors.New("externalSteps parameters not a map")
+	}
+	var parameters []*mj.LetVariable
+	for _, kvp := range parametersMap.OrderedKV {
+		value, err := p.processSubTreeAsByteArray(kvp.Value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value of parameter %s: %w", kvp.Key, err)
+		}
+		parameters = append(parameters, &mj.LetVariable{
+			Name:  kvp.Key,
+			Value: value,
+		})
+	}
+	return parameters, nil
+}
+
+// processLetVariables parses the variables of a let step, in order, and
+// defines them right away, so the following values can use them.
+func (p *Parser) processLetVariables(obj oj.OJsonObject) ([]*mj.LetVariable, error) {
+	variablesMap, isMap := obj.(*oj.OJsonMap)
+	if !isMap {
+		return nil, errors.New("let variables not a map")
+	}
+	var variables []*mj.LetVariable
+	for _, kvp := range variablesMap.OrderedKV {
+		value, err := p.processSubTreeAsByteArray(kvp.Value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value of variable %s: %w", kvp.Key, err)
+		}
+		err = p.ExprInterpreter.SetVariable(kvp.Key, value.Value)
+		if err != nil {
+			return nil, err
+		}
+		variables = append(variables, &mj.LetVariable{
+			Name:  kvp.Key,
+			Value: value,
+		})
+	}
+	return variables, nil
+}
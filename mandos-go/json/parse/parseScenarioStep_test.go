@@ -3,6 +3,7 @@ package mandosjsonparse
 import (
 	"testing"
 
+	mj "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mandos-go/json/model"
 	"github.com/stretchr/testify/require"
 )
 
@@ -51,3 +52,57 @@ func TestParseScenario(t *testing.T) {
 	require.NotNil(t, step)
 	require.Equal(t, "scCall", step.StepTypeName())
 }
+
+func TestParseScenarioSaveRestoreState(t *testing.T) {
+	p := Parser{}
+	step, parseErr := p.ParseScenarioStep(`
+	{
+		"step": "saveState",
+		"comment": "after setup",
+		"name": "setup"
+	}`)
+	require.Nil(t, parseErr)
+	require.Equal(t, &mj.SaveStateStep{Comment: "after setup", Name: "setup"}, step)
+
+	step, parseErr = p.ParseScenarioStep(`
+	{
+		"step": "restoreState",
+		"name": "setup"
+	}`)
+	require.Nil(t, parseErr)
+	require.Equal(t, &mj.RestoreStateStep{Name: "setup"}, step)
+
+	_, parseErr = p.ParseScenarioStep(`{"step": "restoreState"}`)
+	require.EqualError(t, parseErr, "restore state step name missing")
+}
+
+func TestParseScenarioLet(t *testing.T) {
+	p := Parser{}
+	step, parseErr := p.ParseScenarioStep(`
+	{
+		"step": "let",
+		"variables": {
+			"initial": "1,000",
+			"remaining": "${initial} - 300"
+		}
+	}`)
+	require.Nil(t, parseErr)
+	letStep, isLet := step.(*mj.LetStep)
+	require.True(t, isLet)
+	require.Equal(t, 2, len(letStep.Variables))
+	require.Equal(t, "remaining", letStep.Variables[1].Name)
+	require.Equal(t, []byte{0x02, 0xbc}, letStep.Variables[1].Value.Value)
+
+	balance, err := p.parseBigInt("${remaining} * 2", bigIntUnsignedBytes)
+	require.Nil(t, err)
+	require.Equal(t, int64(1400), balance.Int64())
+
+	_, parseErr = p.ParseScenarioStep(`
+	{
+		"step": "let",
+		"variables": {
+			"missing": "${unknown}"
+		}
+	}`)
+	require.NotNil(t, parseErr)
+}
@@ -60,6 +60,11 @@ func (p *Parser) processTxExpectedResult(blrRaw oj.OJsonObject) (*mj.Transaction
 			if err != nil {
 				return nil, fmt.Errorf("invalid block result gas: %w", err)
 			}
+		case "gasUsedDelta":
+			blr.GasUsedDelta, err = p.processUint64(kvp.Value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid block result gas used delta: %w", err)
+			}
 		case "refund":
 			blr.Refund, err = p.processCheckBigInt(kvp.Value, bigIntUnsignedBytes)
 			if err != nil {
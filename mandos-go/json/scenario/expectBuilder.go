@@ -0,0 +1,211 @@
+package mandosjsonscenario
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	ei "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mandos-go/expression/interpreter"
+	mj "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mandos-go/json/model"
+	oj "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mandos-go/orderedjson"
+	twos "github.com/ElrondNetwork/big-int-util/twos-complement"
+)
+
+const starExpr = "*"
+
+// ExpectBuilder builds the expected result of a tx step.
+// Fields that are not set are unspecified, just like the missing fields of an "expect" in a scenario file.
+// Any field can be set to "*", to accept any value.
+type ExpectBuilder struct {
+	out             []string
+	outSpecified    bool
+	status          *string
+	message         *string
+	gas             *string
+	gasUsedDelta    *uint64
+	refund          *string
+	logsUnspecified bool
+	logsStar        bool
+}
+
+// NewExpect starts building an expected result.
+func NewExpect() *ExpectBuilder {
+	return &ExpectBuilder{
+		logsUnspecified: true,
+		logsStar:        true,
+	}
+}
+
+// Out sets the expressions of the expected results, an empty list meaning no results.
+func (eb *ExpectBuilder) Out(outExprs ...string) *ExpectBuilder {
+	eb.out = outExprs
+	eb.outSpecified = true
+	return eb
+}
+
+// Status sets the expected status expression, "" meaning success.
+func (eb *ExpectBuilder) Status(statusExpr string) *ExpectBuilder {
+	eb.status = &statusExpr
+	return eb
+}
+
+// Message sets the expected message expression.
+func (eb *ExpectBuilder) Message(messageExpr string) *ExpectBuilder {
+	eb.message = &messageExpr
+	return eb
+}
+
+// Gas sets the expected remaining gas expression.
+func (eb *ExpectBuilder) Gas(gasExpr string) *ExpectBuilder {
+	eb.gas = &gasExpr
+	return eb
+}
+
+// GasUsedDelta sets how much the gas used may differ from the expected gas.
+func (eb *ExpectBuilder) GasUsedDelta(gasUsedDelta uint64) *ExpectBuilder {
+	eb.gasUsedDelta = &gasUsedDelta
+	return eb
+}
+
+// Refund sets the expected gas refund expression.
+func (eb *ExpectBuilder) Refund(refundExpr string) *ExpectBuilder {
+	eb.refund = &refundExpr
+	return eb
+}
+
+// NoLogs expects the tx not to produce any logs.
+func (eb *ExpectBuilder) NoLogs() *ExpectBuilder {
+	eb.logsUnspecified = false
+	eb.logsStar = false
+	return eb
+}
+
+// AnyLogs explicitly accepts any logs, like "logs": "*".
+func (eb *ExpectBuilder) AnyLogs() *ExpectBuilder {
+	eb.logsUnspecified = false
+	eb.logsStar = true
+	return eb
+}
+
+func (eb *ExpectBuilder) build(interpreter ei.ExprInterpreter) (*mj.TransactionResult, error) {
+	result := &mj.TransactionResult{
+		Status:          mj.JSONCheckBigIntUnspecified(),
+		Message:         mj.JSONCheckBytesUnspecified(),
+		Gas:             mj.JSONCheckUint64Unspecified(),
+		Refund:          mj.JSONCheckBigIntUnspecified(),
+		LogsStar:        eb.logsStar,
+		LogsUnspecified: eb.logsUnspecified,
+	}
+
+	var err error
+	if eb.outSpecified {
+		result.Out = make([]mj.JSONCheckBytes, 0, len(eb.out))
+		for _, outExpr := range eb.out {
+			out, err := interpretCheckBytes(interpreter, outExpr)
+			if err != nil {
+				return nil, fmt.Errorf("invalid block result out: %w", err)
+			}
+			result.Out = append(result.Out, out)
+		}
+	}
+	if eb.status != nil {
+		result.Status, err = interpretCheckBigInt(interpreter, *eb.status, true)
+		if err != nil {
+			return nil, fmt.Errorf("invalid block result status: %w", err)
+		}
+	}
+	if eb.message != nil {
+		result.Message, err = interpretCheckBytes(interpreter, *eb.message)
+		if err != nil {
+			return nil, fmt.Errorf("invalid block result message: %w", err)
+		}
+	}
+	if eb.gas != nil {
+		result.Gas, err = interpretCheckUint64(interpreter, *eb.gas)
+		if err != nil {
+			return nil, fmt.Errorf("invalid block result gas: %w", err)
+		}
+	}
+	if eb.gasUsedDelta != nil {
+		result.GasUsedDelta = uint64Value(*eb.gasUsedDelta)
+	}
+	if eb.refund != nil {
+		result.Refund, err = interpretCheckBigInt(interpreter, *eb.refund, false)
+		if err != nil {
+			return nil, fmt.Errorf("invalid block result refund: %w", err)
+		}
+	}
+
+	return result, nil
+}
+
+func interpretCheckBytes(interpreter ei.ExprInterpreter, expr string) (mj.JSONCheckBytes, error) {
+	if expr == starExpr {
+		return mj.JSONCheckBytesStar(), nil
+	}
+	value, err := interpreter.InterpretString(expr)
+	if err != nil {
+		return mj.JSONCheckBytes{}, err
+	}
+	return mj.JSONCheckBytes{
+		Value:    value,
+		IsStar:   false,
+		Original: &oj.OJsonString{Value: expr},
+	}, nil
+}
+
+func interpretCheckBigInt(interpreter ei.ExprInterpreter, expr string, signed bool) (mj.JSONCheckBigInt, error) {
+	if expr == starExpr {
+		return mj.JSONCheckBigInt{
+			Value:    nil,
+			IsStar:   true,
+			Original: starExpr,
+		}, nil
+	}
+	value, err := interpretBigInt(interpreter, expr, signed)
+	if err != nil {
+		return mj.JSONCheckBigInt{}, err
+	}
+	return mj.JSONCheckBigInt{
+		Value:    value.Value,
+		IsStar:   false,
+		Original: value.Original,
+	}, nil
+}
+
+func interpretCheckUint64(interpreter ei.ExprInterpreter, expr string) (mj.JSONCheckUint64, error) {
+	if expr == starExpr {
+		return mj.JSONCheckUint64{
+			Value:    0,
+			IsStar:   true,
+			Original: starExpr,
+		}, nil
+	}
+	value, err := interpretBigInt(interpreter, expr, false)
+	if err != nil {
+		return mj.JSONCheckUint64{}, err
+	}
+	if !value.Value.IsUint64() {
+		return mj.JSONCheckUint64{}, errors.New("value is not uint64")
+	}
+	return mj.JSONCheckUint64{
+		Value:    value.Value.Uint64(),
+		IsStar:   false,
+		Original: expr,
+	}, nil
+}
+
+func interpretBigInt(interpreter ei.ExprInterpreter, expr string, signed bool) (mj.JSONBigInt, error) {
+	bytes, err := interpreter.InterpretString(expr)
+	if err != nil {
+		return mj.JSONBigInt{}, err
+	}
+	value := big.NewInt(0).SetBytes(bytes)
+	if signed {
+		value = twos.FromBytes(bytes)
+	}
+	return mj.JSONBigInt{
+		Value:    value,
+		Original: expr,
+	}, nil
+}
@@ -0,0 +1,315 @@
+package mandosjsonscenario
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	ei "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mandos-go/expression/interpreter"
+	mj "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mandos-go/json/model"
+	oj "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mandos-go/orderedjson"
+)
+
+type esdtExpressions struct {
+	tokenIdentifier string
+	nonce           uint64
+	value           string
+}
+
+// TxBuilder builds a tx step as a mandos model object, without going through JSON.
+// The fields are given as the same mandos expressions a scenario file would contain,
+// and are only interpreted by Build, which also reports any error.
+type TxBuilder struct {
+	txType    mj.TransactionType
+	txID      string
+	comment   string
+	nonce     *uint64
+	from      string
+	to        string
+	value     string
+	esdt      *esdtExpressions
+	function  string
+	code      string
+	arguments []string
+	gasLimit  *uint64
+	gasPrice  *uint64
+	expect    *ExpectBuilder
+}
+
+func newTxBuilder(txType mj.TransactionType) *TxBuilder {
+	return &TxBuilder{
+		txType: txType,
+	}
+}
+
+// NewSCCall starts building a "scCall" step.
+func NewSCCall() *TxBuilder {
+	return newTxBuilder(mj.ScCall)
+}
+
+// NewSCDeploy starts building a "scDeploy" step.
+func NewSCDeploy() *TxBuilder {
+	return newTxBuilder(mj.ScDeploy)
+}
+
+// NewSCQuery starts building a "scQuery" step.
+func NewSCQuery() *TxBuilder {
+	return newTxBuilder(mj.ScQuery)
+}
+
+// NewTransfer starts building a "transfer" step.
+func NewTransfer() *TxBuilder {
+	return newTxBuilder(mj.Transfer)
+}
+
+// NewValidatorReward starts building a "validatorReward" step.
+func NewValidatorReward() *TxBuilder {
+	return newTxBuilder(mj.ValidatorReward)
+}
+
+// TxID sets the "txId" of the step.
+func (tb *TxBuilder) TxID(txID string) *TxBuilder {
+	tb.txID = txID
+	return tb
+}
+
+// Comment sets the comment of the step.
+func (tb *TxBuilder) Comment(comment string) *TxBuilder {
+	tb.comment = comment
+	return tb
+}
+
+// Nonce sets the nonce of the tx.
+func (tb *TxBuilder) Nonce(nonce uint64) *TxBuilder {
+	tb.nonce = &nonce
+	return tb
+}
+
+// From sets the sender address expression, e.g. "address:owner".
+func (tb *TxBuilder) From(addressExpr string) *TxBuilder {
+	tb.from = addressExpr
+	return tb
+}
+
+// To sets the receiver address expression, e.g. "sc:contract".
+func (tb *TxBuilder) To(addressExpr string) *TxBuilder {
+	tb.to = addressExpr
+	return tb
+}
+
+// Value sets the EGLD value expression of the tx.
+func (tb *TxBuilder) Value(valueExpr string) *TxBuilder {
+	tb.value = valueExpr
+	return tb
+}
+
+// ESDT sets the ESDT transferred with the tx.
+func (tb *TxBuilder) ESDT(tokenIdentifierExpr string, nonce uint64, valueExpr string) *TxBuilder {
+	tb.esdt = &esdtExpressions{
+		tokenIdentifier: tokenIdentifierExpr,
+		nonce:           nonce,
+		value:           valueExpr,
+	}
+	return tb
+}
+
+// Function sets the name of the function called.
+func (tb *TxBuilder) Function(function string) *TxBuilder {
+	tb.function = function
+	return tb
+}
+
+// Code sets the contract code expression of a deploy, e.g. "file:contract.wasm".
+func (tb *TxBuilder) Code(codeExpr string) *TxBuilder {
+	tb.code = codeExpr
+	return tb
+}
+
+// Arguments appends argument expressions.
+func (tb *TxBuilder) Arguments(argumentExprs ...string) *TxBuilder {
+	tb.arguments = append(tb.arguments, argumentExprs...)
+	return tb
+}
+
+// GasLimit sets the gas limit of the tx.
+func (tb *TxBuilder) GasLimit(gasLimit uint64) *TxBuilder {
+	tb.gasLimit = &gasLimit
+	return tb
+}
+
+// GasPrice sets the gas price of the tx.
+func (tb *TxBuilder) GasPrice(gasPrice uint64) *TxBuilder {
+	tb.gasPrice = &gasPrice
+	return tb
+}
+
+// Expect sets the expected result of the tx.
+func (tb *TxBuilder) Expect(expect *ExpectBuilder) *TxBuilder {
+	tb.expect = expect
+	return tb
+}
+
+// Build interprets the expressions and yields the tx step.
+// The interpreter provides the file resolver and the variables.
+func (tb *TxBuilder) Build(interpreter ei.ExprInterpreter) (*mj.TxStep, error) {
+	tx, err := tb.buildTx(interpreter)
+	if err != nil {
+		return nil, err
+	}
+
+	step := &mj.TxStep{
+		TxIdent: tb.txID,
+		Comment: tb.comment,
+		Tx:      tx,
+	}
+	if tb.expect != nil {
+		if !tb.txType.IsSmartContractTx() {
+			return nil, fmt.Errorf("no expected result allowed for step of type %s", step.StepTypeName())
+		}
+		step.ExpectedResult, err = tb.expect.build(interpreter)
+		if err != nil {
+			return nil, fmt.Errorf("cannot build tx expected result: %w", err)
+		}
+	}
+
+	return step, nil
+}
+
+func (tb *TxBuilder) buildTx(interpreter ei.ExprInterpreter) (*mj.Transaction, error) {
+	tx := &mj.Transaction{
+		Type:  tb.txType,
+		Value: mj.JSONBigIntZero(),
+	}
+
+	var err error
+	if tb.nonce != nil {
+		tx.Nonce = uint64Value(*tb.nonce)
+	}
+	if tb.txType.HasSender() {
+		tx.From, err = interpretAddress(interpreter, tb.from)
+		if err != nil {
+			return nil, fmt.Errorf("invalid transaction from: %w", err)
+		}
+	} else if len(tb.from) > 0 {
+		return nil, errors.New("`from` not allowed in transaction, it is always the zero address")
+	}
+	if tb.txType.HasReceiver() {
+		tx.To, err = interpretAddress(interpreter, tb.to)
+		if err != nil {
+			return nil, fmt.Errorf("invalid transaction to: %w", err)
+		}
+	} else if len(tb.to) > 0 {
+		return nil, errors.New("transaction to field not allowed for scDeploy transactions")
+	}
+	if len(tb.value) > 0 {
+		if !tb.txType.HasValue() {
+			return nil, errors.New("`value` not allowed in this context")
+		}
+		tx.Value, err = interpretBigInt(interpreter, tb.value, false)
+		if err != nil {
+			return nil, fmt.Errorf("invalid transaction value: %w", err)
+		}
+	}
+	if tb.esdt != nil {
+		if !tb.txType.HasESDT() {
+			return nil, errors.New("`esdt` not allowed in this context")
+		}
+		tx.ESDTValue, err = interpretESDT(interpreter, tb.esdt)
+		if err != nil {
+			return nil, fmt.Errorf("invalid transaction ESDT value: %w", err)
+		}
+	}
+	if len(tb.function) > 0 && !tb.txType.HasFunction() {
+		return nil, errors.New("transaction function field not allowed in this context")
+	}
+	tx.Function = tb.function
+	if len(tb.code) > 0 {
+		if tb.txType != mj.ScDeploy {
+			return nil, errors.New("transaction contractCode field only allowed int scDeploy transactions")
+		}
+		tx.Code, err = interpretBytes(interpreter, tb.code)
+		if err != nil {
+			return nil, fmt.Errorf("invalid transaction contract code: %w", err)
+		}
+	}
+	if len(tb.arguments) > 0 && tb.txType == mj.Transfer {
+		return nil, errors.New("function arguments not allowed for transfer transactions")
+	}
+	for _, argumentExpr := range tb.arguments {
+		argument, err := interpretSubTree(interpreter, argumentExpr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid transaction arguments: %w", err)
+		}
+		tx.Arguments = append(tx.Arguments, argument)
+	}
+	if tb.gasLimit != nil || tb.gasPrice != nil {
+		if !tb.txType.HasGas() {
+			return nil, errors.New("`gasLimit` not allowed in this context")
+		}
+		if tb.gasLimit != nil {
+			tx.GasLimit = uint64Value(*tb.gasLimit)
+		}
+		if tb.gasPrice != nil {
+			tx.GasPrice = uint64Value(*tb.gasPrice)
+		}
+	}
+
+	return tx, nil
+}
+
+func interpretESDT(interpreter ei.ExprInterpreter, esdt *esdtExpressions) (*mj.ESDTTxData, error) {
+	tokenIdentifier, err := interpretBytes(interpreter, esdt.tokenIdentifier)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ESDT token name: %w", err)
+	}
+	value, err := interpretBigInt(interpreter, esdt.value, false)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ESDT balance: %w", err)
+	}
+	return &mj.ESDTTxData{
+		TokenIdentifier: tokenIdentifier,
+		Nonce:           uint64Value(esdt.nonce),
+		Value:           value,
+	}, nil
+}
+
+func interpretAddress(interpreter ei.ExprInterpreter, addressExpr string) (mj.JSONBytesFromString, error) {
+	if len(addressExpr) == 0 {
+		return mj.JSONBytesFromString{}, errors.New("missing account address")
+	}
+	address, err := interpretBytes(interpreter, addressExpr)
+	if err != nil {
+		return mj.JSONBytesFromString{}, err
+	}
+	if len(address.Value) != 32 {
+		return mj.JSONBytesFromString{}, errors.New("account address is not 32 bytes in length")
+	}
+	return address, nil
+}
+
+func interpretBytes(interpreter ei.ExprInterpreter, expr string) (mj.JSONBytesFromString, error) {
+	value, err := interpreter.InterpretString(expr)
+	if err != nil {
+		return mj.JSONBytesFromString{}, err
+	}
+	return mj.NewJSONBytesFromString(value, expr), nil
+}
+
+func interpretSubTree(interpreter ei.ExprInterpreter, expr string) (mj.JSONBytesFromTree, error) {
+	original := &oj.OJsonString{Value: expr}
+	value, err := interpreter.InterpretSubTree(original)
+	if err != nil {
+		return mj.JSONBytesFromTree{}, err
+	}
+	return mj.JSONBytesFromTree{
+		Value:    value,
+		Original: original,
+	}, nil
+}
+
+func uint64Value(value uint64) mj.JSONUint64 {
+	return mj.JSONUint64{
+		Value:    value,
+		Original: strconv.FormatUint(value, 10),
+	}
+}
@@ -0,0 +1,126 @@
+package mandosjsonscenario
+
+import (
+	"testing"
+
+	ei "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mandos-go/expression/interpreter"
+	mjparse "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mandos-go/json/parse"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildSCCall(t *testing.T) {
+	built, err := NewSCCall().
+		TxID("1").
+		Comment("just an example").
+		From("address:owner").
+		To("sc:contract").
+		Value("0").
+		ESDT("str:TOKEN-123456", 5, "1,000").
+		Function("someFunctionName").
+		Arguments("0x1234", "", "str:a message").
+		GasLimit(100000000).
+		GasPrice(0).
+		Expect(NewExpect().
+			Out("*", "u32:7").
+			Status("4").
+			Message("str:error").
+			Gas("*").
+			GasUsedDelta(100).
+			Refund("*").
+			NoLogs()).
+		Build(ei.ExprInterpreter{})
+	require.Nil(t, err)
+
+	p := mjparse.Parser{}
+	parsed, err := p.ParseScenarioStep(`
+	{
+		"step": "scCall",
+		"txId": "1",
+		"comment": "just an example",
+		"tx": {
+			"from": "address:owner",
+			"to": "sc:contract",
+			"value": "0",
+			"esdt": {
+				"tokenIdentifier": "str:TOKEN-123456",
+				"nonce": "5",
+				"value": "1,000"
+			},
+			"function": "someFunctionName",
+			"arguments": [
+				"0x1234",
+				"",
+				"str:a message"
+			],
+			"gasLimit": "100000000",
+			"gasPrice": "0"
+		},
+		"expect": {
+			"out": [ "*", "u32:7" ],
+			"status": "4",
+			"message": "str:error",
+			"logs": [],
+			"gas": "*",
+			"gasUsedDelta": "100",
+			"refund": "*"
+		}
+	}`)
+	require.Nil(t, err)
+	require.Equal(t, parsed, built)
+}
+
+func TestBuildArgumentsNeedNoQuoting(t *testing.T) {
+	built, err := NewSCCall().
+		From("address:owner").
+		To("sc:contract").
+		Function("f").
+		Arguments(`str:a "quoted" \ message`).
+		Build(ei.ExprInterpreter{})
+	require.Nil(t, err)
+	require.Equal(t, []byte(`a "quoted" \ message`), built.Tx.Arguments[0].Value)
+}
+
+func TestBuildTransfer(t *testing.T) {
+	built, err := NewTransfer().
+		TxID("2").
+		From("address:owner").
+		To("address:other").
+		Value("1,000,000").
+		Build(ei.ExprInterpreter{})
+	require.Nil(t, err)
+
+	p := mjparse.Parser{}
+	parsed, err := p.ParseScenarioStep(`
+	{
+		"step": "transfer",
+		"txId": "2",
+		"tx": {
+			"from": "address:owner",
+			"to": "address:other",
+			"value": "1,000,000"
+		}
+	}`)
+	require.Nil(t, err)
+	require.Equal(t, parsed, built)
+}
+
+func TestBuildErrors(t *testing.T) {
+	_, err := NewSCCall().To("sc:contract").Build(ei.ExprInterpreter{})
+	require.EqualError(t, err, "invalid transaction from: missing account address")
+
+	_, err = NewSCCall().From("0x1234").To("sc:contract").Build(ei.ExprInterpreter{})
+	require.EqualError(t, err, "invalid transaction from: account address is not 32 bytes in length")
+
+	_, err = NewSCQuery().From("address:owner").To("sc:contract").Build(ei.ExprInterpreter{})
+	require.EqualError(t, err, "`from` not allowed in transaction, it is always the zero address")
+
+	_, err = NewTransfer().From("address:owner").To("address:other").
+		Expect(NewExpect().Status("")).
+		Build(ei.ExprInterpreter{})
+	require.EqualError(t, err, "no expected result allowed for step of type transfer")
+
+	_, err = NewSCCall().From("address:owner").To("sc:contract").
+		Expect(NewExpect().Gas("biguint:-1")).
+		Build(ei.ExprInterpreter{})
+	require.Error(t, err)
+}
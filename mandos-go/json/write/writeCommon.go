@@ -47,6 +47,9 @@ func resultToOJ(res *mj.TransactionResult) oj.OJsonObject {
 	if !res.Gas.IsUnspecified() {
 		resultOJ.Put("gas", checkUint64ToOJ(res.Gas))
 	}
+	if len(res.GasUsedDelta.Original) > 0 {
+		resultOJ.Put("gasUsedDelta", uint64ToOJ(res.GasUsedDelta))
+	}
 	if !res.Refund.IsUnspecified() {
 		resultOJ.Put("refund", checkBigIntToOJ(res.Refund))
 	}
@@ -65,12 +68,16 @@ func logToOJ(logEntry *mj.LogEntry) oj.OJsonObject {
 	logOJ.Put("address", checkBytesToOJ(logEntry.Address))
 	logOJ.Put("identifier", checkBytesToOJ(logEntry.Identifier))
 
-	var topicsList []oj.OJsonObject
-	for _, topic := range logEntry.Topics {
-		topicsList = append(topicsList, checkBytesToOJ(topic))
+	if logEntry.TopicsStar {
+		logOJ.Put("topics", stringToOJ("*"))
+	} else {
+		var topicsList []oj.OJsonObject
+		for _, topic := range logEntry.Topics {
+			topicsList = append(topicsList, checkBytesToOJ(topic))
+		}
+		topicsOJ := oj.OJsonList(topicsList)
+		logOJ.Put("topics", &topicsOJ)
 	}
-	topicsOJ := oj.OJsonList(topicsList)
-	logOJ.Put("topics", &topicsOJ)
 
 	logOJ.Put("data", checkBytesToOJ(logEntry.Data))
 
@@ -87,6 +87,14 @@ func appendESDTInstanceToOJ(esdtInstance *mj.ESDTInstance, targetOj *oj.OJsonMap
 	if len(esdtInstance.Uri.Value) > 0 {
 		targetOj.Put("uri", bytesFromTreeToOJ(esdtInstance.Uri))
 	}
+	if len(esdtInstance.Uris) > 0 {
+		var urisList []oj.OJsonObject
+		for _, uri := range esdtInstance.Uris {
+			urisList = append(urisList, bytesFromTreeToOJ(uri))
+		}
+		urisOJ := oj.OJsonList(urisList)
+		targetOj.Put("uris", &urisOJ)
+	}
 	if len(esdtInstance.Attributes.Original) > 0 {
 		targetOj.Put("attributes", bytesFromStringToOJ(esdtInstance.Attributes))
 	}
@@ -24,7 +24,8 @@ func checkESDTItemToOJ(esdtItem *mj.CheckESDTData) oj.OJsonObject {
 	esdtItemOJ := oj.NewMap()
 
 	// instances
-	if len(esdtItem.Instances) == 1 {
+	// the frozen flag of a single instance would be mistaken for the token flag
+	if len(esdtItem.Instances) == 1 && esdtItem.Instances[0].Frozen.IsUnspecified() {
 		appendCheckESDTInstanceToOJ(esdtItem.Instances[0], esdtItemOJ)
 	} else {
 		var convertedList []oj.OJsonObject
@@ -74,11 +75,22 @@ func appendCheckESDTInstanceToOJ(esdtInstance *mj.CheckESDTInstance, targetOj *o
 		targetOj.Put("hash", checkBytesToOJ(esdtInstance.Hash))
 	}
 	if !esdtInstance.Uri.Unspecified && len(esdtInstance.Uri.Value) > 0 {
-		targetOj.Put("uri", checkBytesToOJ(esdtInstance.Creator))
+		targetOj.Put("uri", checkBytesToOJ(esdtInstance.Uri))
+	}
+	if !esdtInstance.UrisUnspecified {
+		var urisList []oj.OJsonObject
+		for _, uri := range esdtInstance.Uris {
+			urisList = append(urisList, checkBytesToOJ(uri))
+		}
+		urisOJ := oj.OJsonList(urisList)
+		targetOj.Put("uris", &urisOJ)
 	}
 	if !esdtInstance.Attributes.Unspecified && len(esdtInstance.Attributes.Value) > 0 {
 		targetOj.Put("attributes", checkBytesToOJ(esdtInstance.Attributes))
 	}
+	if !esdtInstance.Frozen.IsUnspecified() {
+		targetOj.Put("frozen", checkUint64ToOJ(esdtInstance.Frozen))
+	}
 }
 
 func isCompactCheckESDT(esdtItem *mj.CheckESDTData) bool {
@@ -88,6 +100,9 @@ func isCompactCheckESDT(esdtItem *mj.CheckESDTData) bool {
 	if len(esdtItem.Instances[0].Nonce.Original) > 0 {
 		return false
 	}
+	if !esdtItem.Instances[0].UrisUnspecified || !esdtItem.Instances[0].Frozen.IsUnspecified() {
+		return false
+	}
 	if len(esdtItem.Roles) > 0 {
 		return false
 	}
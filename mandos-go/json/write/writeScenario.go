@@ -41,6 +41,13 @@ func ScenarioToOrderedJSON(scenario *mj.Scenario) oj.OJsonObject {
 				stepOJ.Put("comment", stringToOJ(step.Comment))
 			}
 			stepOJ.Put("path", stringToOJ(step.Path))
+			if len(step.Parameters) > 0 {
+				parametersOJ := oj.NewMap()
+				for _, parameter := range step.Parameters {
+					parametersOJ.Put(parameter.Name, bytesFromTreeToOJ(parameter.Value))
+				}
+				stepOJ.Put("parameters", parametersOJ)
+			}
 		case *mj.SetStateStep:
 			if len(step.Comment) > 0 {
 				stepOJ.Put("comment", stringToOJ(step.Comment))
@@ -69,6 +76,53 @@ func ScenarioToOrderedJSON(scenario *mj.Scenario) oj.OJsonObject {
 			if len(step.Comment) > 0 {
 				stepOJ.Put("comment", stringToOJ(step.Comment))
 			}
+		case *mj.LetStep:
+			if len(step.Comment) > 0 {
+				stepOJ.Put("comment", stringToOJ(step.Comment))
+			}
+			variablesOJ := oj.NewMap()
+			for _, variable := range step.Variables {
+				variablesOJ.Put(variable.Name, bytesFromTreeToOJ(variable.Value))
+			}
+			stepOJ.Put("variables", variablesOJ)
+		case *mj.SaveStateStep:
+			if len(step.Comment) > 0 {
+				stepOJ.Put("comment", stringToOJ(step.Comment))
+			}
+			stepOJ.Put("name", stringToOJ(step.Name))
+		case *mj.AdvanceRoundsStep:
+			if len(step.Comment) > 0 {
+				stepOJ.Put("comment", stringToOJ(step.Comment))
+			}
+			stepOJ.Put("rounds", uint64ToOJ(step.Rounds))
+			if len(step.RoundDuration.Original) > 0 {
+				stepOJ.Put("roundDuration", uint64ToOJ(step.RoundDuration))
+			}
+		case *mj.AdvanceEpochStep:
+			if len(step.Comment) > 0 {
+				stepOJ.Put("comment", stringToOJ(step.Comment))
+			}
+			if len(step.Epochs.Original) > 0 {
+				stepOJ.Put("epochs", uint64ToOJ(step.Epochs))
+			}
+			if len(step.RoundDuration.Original) > 0 {
+				stepOJ.Put("roundDuration", uint64ToOJ(step.RoundDuration))
+			}
+		case *mj.RestoreStateStep:
+			if len(step.Comment) > 0 {
+				stepOJ.Put("comment", stringToOJ(step.Comment))
+			}
+			stepOJ.Put("name", stringToOJ(step.Name))
+		case *mj.CrossShardCallbackStep:
+			if len(step.TxIdent) > 0 {
+				stepOJ.Put("txId", stringToOJ(step.TxIdent))
+			}
+			if len(step.Comment) > 0 {
+				stepOJ.Put("comment", stringToOJ(step.Comment))
+			}
+			if step.ExpectedResult != nil {
+				stepOJ.Put("expect", resultToOJ(step.ExpectedResult))
+			}
 		case *mj.TxStep:
 			if len(step.TxIdent) > 0 {
 				stepOJ.Put("txId", stringToOJ(step.TxIdent))
@@ -3,11 +3,14 @@ package mock
 import (
 	"math/big"
 
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
+
 	"github.com/ElrondNetwork/elrond-go/core/vmcommon"
 	"github.com/ElrondNetwork/elrond-go/data/esdt"
 )
 
 var _ vmcommon.BlockchainHook = (*BlockchainHookStub)(nil)
+var _ arwen.GasModifierProvider = (*BlockchainHookStub)(nil)
 
 // BlockchainHookStub is used in tests to check that interface methods were called
 type BlockchainHookStub struct {
@@ -37,6 +40,7 @@ type BlockchainHookStub struct {
 	GetCodeCalled                 func(account vmcommon.UserAccountHandler) []byte
 	GetESDTTokenCalled            func(address []byte, tokenID []byte, nonce uint64) (*esdt.ESDigitalToken, error)
 	GetSnapshotCalled             func() int
+	GetGasModifierCalled          func(address []byte) (arwen.GasModifier, bool)
 	RevertToSnapshotCalled        func(snapshot int) error
 }
 
@@ -251,6 +255,14 @@ func (b *BlockchainHookStub) GetSnapshot() int {
 	return 1
 }
 
+// GetGasModifier mocked method
+func (b *BlockchainHookStub) GetGasModifier(address []byte) (arwen.GasModifier, bool) {
+	if b.GetGasModifierCalled != nil {
+		return b.GetGasModifierCalled(address)
+	}
+	return arwen.GasModifier{}, false
+}
+
 // RevertToSnapshot mocked method
 func (b *BlockchainHookStub) RevertToSnapshot(snapshot int) error {
 	if b.RevertToSnapshotCalled != nil {
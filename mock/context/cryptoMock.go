@@ -21,16 +21,36 @@ func (c *CryptoHookMock) Ripemd160(data []byte) ([]byte, error) {
 	return c.Result, c.Err
 }
 
+// Poseidon mocked method
+func (c *CryptoHookMock) Poseidon(inputs [][]byte) ([]byte, error) {
+	return c.Result, c.Err
+}
+
+// MiMC mocked method
+func (c *CryptoHookMock) MiMC(inputs [][]byte) ([]byte, error) {
+	return c.Result, c.Err
+}
+
 // VerifyBLS mocked method
 func (c *CryptoHookMock) VerifyBLS(key []byte, msg []byte, sig []byte) error {
 	return c.Err
 }
 
+// VerifyBLSAggregated mocked method
+func (c *CryptoHookMock) VerifyBLSAggregated(keys [][]byte, msg []byte, aggregatedSig []byte) error {
+	return c.Err
+}
+
 // VerifyEd25519 mocked method
 func (c *CryptoHookMock) VerifyEd25519(key []byte, msg []byte, sig []byte) error {
 	return c.Err
 }
 
+// VerifyEd25519Batch mocked method
+func (c *CryptoHookMock) VerifyEd25519Batch(keys [][]byte, msgs [][]byte, sigs [][]byte) error {
+	return c.Err
+}
+
 // VerifySecp256k1 mocked method
 func (c *CryptoHookMock) VerifySecp256k1(key []byte, msg []byte, sig []byte) error {
 	return c.Err
@@ -40,3 +60,23 @@ func (c *CryptoHookMock) VerifySecp256k1(key []byte, msg []byte, sig []byte) err
 func (c *CryptoHookMock) Ecrecover(hash []byte, recoveryID []byte, r []byte, s []byte) ([]byte, error) {
 	return c.Result, c.Err
 }
+
+// ValidateCurvePoint mocked method
+func (c *CryptoHookMock) ValidateCurvePoint(curveID int32, point []byte) error {
+	return c.Err
+}
+
+// CurvePointAdd mocked method
+func (c *CryptoHookMock) CurvePointAdd(curveID int32, point1 []byte, point2 []byte) ([]byte, error) {
+	return c.Result, c.Err
+}
+
+// CurveScalarMult mocked method
+func (c *CryptoHookMock) CurveScalarMult(curveID int32, point []byte, scalar []byte) ([]byte, error) {
+	return c.Result, c.Err
+}
+
+// CurveScalarBaseMult mocked method
+func (c *CryptoHookMock) CurveScalarBaseMult(curveID int32, scalar []byte) ([]byte, error) {
+	return c.Result, c.Err
+}
@@ -9,6 +9,11 @@ import (
 	"github.com/ElrondNetwork/elrond-go/core/vmcommon"
 )
 
+var _ arwen.Executor = (*InstanceBuilderMock)(nil)
+
+// InstanceBuilderMockName is the executor name of the InstanceBuilderMock
+const InstanceBuilderMockName = "mock"
+
 // InstanceBuilderMock can be passed to RuntimeContext as an InstanceBuilder to
 // create mocked Wasmer instances.
 type InstanceBuilderMock struct {
@@ -24,6 +29,17 @@ func NewInstanceBuilderMock(world *worldmock.MockWorld) *InstanceBuilderMock {
 	}
 }
 
+// Name returns the executor name of the InstanceBuilderMock
+func (builder *InstanceBuilderMock) Name() string {
+	return InstanceBuilderMockName
+}
+
+// Version returns the version of the Wasmer library, which creates the
+// instances that are not mocked
+func (builder *InstanceBuilderMock) Version() string {
+	return wasmer.LibraryVersion
+}
+
 // CreateAndStoreInstanceMock creates a new InstanceMock and registers it as a
 // smart contract account in the World, using `code` as the address of the account
 func (builder *InstanceBuilderMock) CreateAndStoreInstanceMock(t testing.TB, host arwen.VMHost, code []byte, shardID uint32, balance int64) *InstanceMock {
@@ -62,14 +78,14 @@ func (builder *InstanceBuilderMock) getNewCopyOfStoredInstance(code []byte, gasL
 // instance with the provided contract code.
 func (builder *InstanceBuilderMock) NewInstanceWithOptions(
 	contractCode []byte,
-	options wasmer.CompilationOptions,
-) (wasmer.InstanceHandler, error) {
+	options arwen.CompilationOptions,
+) (arwen.Instance, error) {
 
 	instance, ok := builder.getNewCopyOfStoredInstance(contractCode, options.GasLimit)
 	if ok {
 		return instance, nil
 	}
-	return wasmer.NewInstanceWithOptions(contractCode, options)
+	return wasmer.NewInstanceWithOptions(contractCode, toWasmerCompilationOptions(options))
 }
 
 // NewInstanceFromCompiledCodeWithOptions attempts to load a prepared instance
@@ -77,11 +93,21 @@ func (builder *InstanceBuilderMock) NewInstanceWithOptions(
 // instance with the provided precompiled code.
 func (builder *InstanceBuilderMock) NewInstanceFromCompiledCodeWithOptions(
 	compiledCode []byte,
-	options wasmer.CompilationOptions,
-) (wasmer.InstanceHandler, error) {
+	options arwen.CompilationOptions,
+) (arwen.Instance, error) {
 	instance, ok := builder.getNewCopyOfStoredInstance(compiledCode, options.GasLimit)
 	if ok {
 		return instance, nil
 	}
-	return wasmer.NewInstanceFromCompiledCodeWithOptions(compiledCode, options)
+	return wasmer.NewInstanceFromCompiledCodeWithOptions(compiledCode, toWasmerCompilationOptions(options))
+}
+
+func toWasmerCompilationOptions(options arwen.CompilationOptions) wasmer.CompilationOptions {
+	return wasmer.CompilationOptions{
+		GasLimit:           options.GasLimit,
+		UnmeteredLocals:    options.UnmeteredLocals,
+		OpcodeTrace:        options.OpcodeTrace,
+		Metering:           options.Metering,
+		RuntimeBreakpoints: options.RuntimeBreakpoints,
+	}
 }
@@ -1,9 +1,12 @@
 package mock
 
 import (
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
 	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/wasmer"
 )
 
+var _ arwen.Executor = (*InstanceBuilderRecorderMock)(nil)
+
 // InstanceBuilderRecorderMock can be passed to RuntimeContext as an InstanceBuilder to
 // create mocked Wasmer instances.
 type InstanceBuilderRecorderMock struct {
@@ -17,12 +20,23 @@ func NewInstanceBuilderRecorderMock() *InstanceBuilderRecorderMock {
 	}
 }
 
+// Name returns the name of the executor recorded by the InstanceBuilderRecorderMock
+func (builder *InstanceBuilderRecorderMock) Name() string {
+	return "recorder"
+}
+
+// Version returns the version of the Wasmer library, which creates the
+// instances recorded by the InstanceBuilderRecorderMock
+func (builder *InstanceBuilderRecorderMock) Version() string {
+	return wasmer.LibraryVersion
+}
+
 // NewInstanceWithOptions - see InstanceBuilderMock.NewInstanceWithOptions()
 func (builder *InstanceBuilderRecorderMock) NewInstanceWithOptions(
 	contractCode []byte,
-	options wasmer.CompilationOptions,
-) (wasmer.InstanceHandler, error) {
-	instance, err := wasmer.NewInstanceWithOptions(contractCode, options)
+	options arwen.CompilationOptions,
+) (arwen.Instance, error) {
+	instance, err := wasmer.NewInstanceWithOptions(contractCode, toWasmerCompilationOptions(options))
 	if err == nil {
 		builder.addContractInstanceToInstanceMap(contractCode, instance)
 	}
@@ -32,9 +46,9 @@ func (builder *InstanceBuilderRecorderMock) NewInstanceWithOptions(
 // NewInstanceFromCompiledCodeWithOptions - see InstanceBuilderMock.NewInstanceFromCompiledCodeWithOptions()
 func (builder *InstanceBuilderRecorderMock) NewInstanceFromCompiledCodeWithOptions(
 	compiledCode []byte,
-	options wasmer.CompilationOptions,
-) (wasmer.InstanceHandler, error) {
-	instance, err := wasmer.NewInstanceFromCompiledCodeWithOptions(compiledCode, options)
+	options arwen.CompilationOptions,
+) (arwen.Instance, error) {
+	instance, err := wasmer.NewInstanceFromCompiledCodeWithOptions(compiledCode, toWasmerCompilationOptions(options))
 	if err == nil {
 		builder.addContractInstanceToInstanceMap(compiledCode, instance)
 	}
@@ -8,6 +8,8 @@ import (
 	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/wasmer"
 )
 
+var _ arwen.Instance = (*InstanceMock)(nil)
+
 // InstanceMock is a mock for Wasmer instances; it allows creating mock smart
 // contracts within tests, without needing actual WASM smart contracts.
 type InstanceMock struct {
@@ -45,6 +47,7 @@ func (instance *InstanceMock) AddMockMethod(name string, method func() *Instance
 func (instance *InstanceMock) AddMockMethodWithError(name string, method func() *InstanceMock, err error) {
 	wrappedMethod := func(...interface{}) (wasmer.Value, error) {
 		instance := method()
+		methodErr := err
 		if arwen.BreakpointValue(instance.GetBreakpointValue()) != arwen.BreakpointNone {
 			var errMsg string
 			if arwen.BreakpointValue(instance.GetBreakpointValue()) == arwen.BreakpointAsyncCall {
@@ -52,9 +55,9 @@ func (instance *InstanceMock) AddMockMethodWithError(name string, method func()
 			} else {
 				errMsg = instance.Host.Output().GetVMOutput().ReturnMessage
 			}
-			err = errors.New(errMsg)
+			methodErr = errors.New(errMsg)
 		}
-		return wasmer.Void(), err
+		return wasmer.Void(), methodErr
 	}
 
 	instance.Exports[name] = wrappedMethod
@@ -87,6 +87,54 @@ func (m *MeteringContextMock) InitStateFromContractCallInput(_ *vmcommon.VMInput
 func (m *MeteringContextMock) TrackGasUsedByBuiltinFunction(_ *vmcommon.ContractCallInput, _ *vmcommon.VMOutput, _ *vmcommon.ContractCallInput) {
 }
 
+// RestoreLockedGas mocked method
+func (m *MeteringContextMock) RestoreLockedGas(_ uint64) {
+}
+
+// Snapshot mocked method
+func (m *MeteringContextMock) Snapshot() int {
+	return 0
+}
+
+// RestoreToSnapshot mocked method
+func (m *MeteringContextMock) RestoreToSnapshot(_ int) {
+}
+
+// DiscardSnapshot mocked method
+func (m *MeteringContextMock) DiscardSnapshot(_ int) {
+}
+
+// EnableAudit mocked method
+func (m *MeteringContextMock) EnableAudit() {
+}
+
+// EnableGasProfiling mocked method
+func (m *MeteringContextMock) EnableGasProfiling() {
+}
+
+// EnableHostFunctionTracking mocked method
+func (m *MeteringContextMock) EnableHostFunctionTracking() {
+}
+
+// GetGasLeftOfCallers mocked method
+func (m *MeteringContextMock) GetGasLeftOfCallers() []uint64 {
+	return nil
+}
+
+// GetLastHostFunction mocked method
+func (m *MeteringContextMock) GetLastHostFunction() string {
+	return ""
+}
+
+// ResetGasProfile mocked method
+func (m *MeteringContextMock) ResetGasProfile() {
+}
+
+// GetGasProfile mocked method
+func (m *MeteringContextMock) GetGasProfile() *arwen.GasProfile {
+	return nil
+}
+
 // GasUsedByContract mocked method
 func (m *MeteringContextMock) GasUsedByContract() (uint64, uint64) {
 	return 0, 0
@@ -133,6 +181,11 @@ func (m *MeteringContextMock) ComputeGasLockedForAsync() uint64 {
 	return m.GasComputedToLock
 }
 
+// EstimateAsyncCallGas mocked method
+func (m *MeteringContextMock) EstimateAsyncCallGas(_ uint64, _ uint64, _ uint64) *arwen.AsyncCallGasEstimate {
+	return &arwen.AsyncCallGasEstimate{}
+}
+
 // DeductGasIfAsyncStep mocked method
 func (m *MeteringContextMock) DeductGasIfAsyncStep() error {
 	return m.Err
@@ -143,6 +196,14 @@ func (m *MeteringContextMock) UseGasBounded(_ uint64) error {
 	return m.Err
 }
 
+// EscrowGasForCallback mocked method
+func (m *MeteringContextMock) EscrowGasForCallback(_ bool) (uint64, error) {
+	if m.Err != nil {
+		return 0, m.Err
+	}
+	return m.GasComputedToLock, nil
+}
+
 // UnlockGasIfAsyncCallback mocked method
 func (m *MeteringContextMock) UnlockGasIfAsyncCallback() {
 }
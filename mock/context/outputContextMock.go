@@ -18,6 +18,7 @@ type OutputContextMock struct {
 	ReturnMessageMock  string
 	GasRemaining       uint64
 	GasRefund          *big.Int
+	ItemizedRefunds    arwen.ItemizedRefunds
 	OutputAccounts     map[string]*vmcommon.OutputAccount
 	DeletedAccounts    [][]byte
 	TouchedAccounts    [][]byte
@@ -78,6 +79,19 @@ func (o *OutputContextMock) PopDiscard() {
 func (o *OutputContextMock) ClearStateStack() {
 }
 
+// TakeSnapshot mocked method
+func (o *OutputContextMock) TakeSnapshot(_ string) {
+}
+
+// RevertToSnapshot mocked method
+func (o *OutputContextMock) RevertToSnapshot(_ string) error {
+	return nil
+}
+
+// DiscardSnapshot mocked method
+func (o *OutputContextMock) DiscardSnapshot(_ string) {
+}
+
 // CopyTopOfStackToActiveState mocked method
 func (o *OutputContextMock) CopyTopOfStackToActiveState() {
 }
@@ -110,6 +124,19 @@ func (o *OutputContextMock) SetRefund(refund uint64) {
 	o.GasRefund = big.NewInt(int64(refund))
 }
 
+// AddRefund mocked method
+func (o *OutputContextMock) AddRefund(category arwen.RefundCategory, gas uint64) {
+	if category == arwen.RefundStorageRelease {
+		o.GasRefund = big.NewInt(0).Add(o.GasRefund, big.NewInt(0).SetUint64(gas))
+	}
+	o.ItemizedRefunds = o.ItemizedRefunds.Add(category, gas)
+}
+
+// GetItemizedRefunds mocked method
+func (o *OutputContextMock) GetItemizedRefunds() arwen.ItemizedRefunds {
+	return o.ItemizedRefunds
+}
+
 // ReturnData mocked method
 func (o *OutputContextMock) ReturnData() [][]byte {
 	return o.ReturnDataMock
@@ -146,8 +173,9 @@ func (o *OutputContextMock) SelfDestruct(_ []byte, _ []byte) {
 }
 
 // Finish mocked method
-func (o *OutputContextMock) Finish(data []byte) {
+func (o *OutputContextMock) Finish(data []byte) error {
 	o.ReturnDataMock = append(o.ReturnDataMock, data)
+	return nil
 }
 
 // PrependFinish mocked method
@@ -155,10 +183,32 @@ func (o *OutputContextMock) PrependFinish(data []byte) {
 	o.ReturnDataMock = append([][]byte{data}, o.ReturnDataMock...)
 }
 
+// GetReturnDataBudget mocked method
+func (o *OutputContextMock) GetReturnDataBudget() int64 {
+	return -1
+}
+
+// AddReceipt mocked method
+func (o *OutputContextMock) AddReceipt(_ arwen.LogReceipt) {
+}
+
+// AttachReceipt mocked method
+func (o *OutputContextMock) AttachReceipt(_ *vmcommon.VMOutput, _ arwen.LogReceipt) {
+}
+
 // WriteLog mocked method
 func (o *OutputContextMock) WriteLog(_ []byte, _ [][]byte, _ []byte) {
 }
 
+// WriteContractLog mocked method
+func (o *OutputContextMock) WriteContractLog(_ []byte, _ [][]byte, _ []byte) {
+}
+
+// WriteEventLog mocked method
+func (o *OutputContextMock) WriteEventLog(_ []byte, _ *arwen.EventLog) error {
+	return nil
+}
+
 // TransferValueOnly mocked method
 func (o *OutputContextMock) TransferValueOnly(_ []byte, _ []byte, _ *big.Int, _ bool) error {
 	return o.TransferResult
@@ -174,10 +224,25 @@ func (o *OutputContextMock) TransferESDT(_ []byte, _ []byte, _ []byte, _ uint64,
 	return 0, nil
 }
 
+// TransferMultiESDT mocked method
+func (o *OutputContextMock) TransferMultiESDT(_ []byte, _ []byte, _ []*arwen.ESDTTransfer, _ *vmcommon.ContractCallInput) (uint64, error) {
+	return 0, nil
+}
+
 // AddTxValueToAccount mocked method
 func (o *OutputContextMock) AddTxValueToAccount(_ []byte, _ *big.Int) {
 }
 
+// CheckValueConservation mocked method
+func (o *OutputContextMock) CheckValueConservation() error {
+	return nil
+}
+
+// GetOrderedVMOutput mocked method
+func (o *OutputContextMock) GetOrderedVMOutput(_ *vmcommon.VMOutput) *arwen.OrderedVMOutput {
+	return nil
+}
+
 // GetVMOutput mocked method
 func (o *OutputContextMock) GetVMOutput() *vmcommon.VMOutput {
 	return o.OutputStateMock
@@ -17,27 +17,40 @@ type OutputContextStub struct {
 	PopMergeActiveStateCalled         func()
 	PopDiscardCalled                  func()
 	ClearStateStackCalled             func()
+	TakeSnapshotCalled                func(key string)
+	RevertToSnapshotCalled            func(key string) error
+	DiscardSnapshotCalled             func(key string)
 	CopyTopOfStackToActiveStateCalled func()
 	CensorVMOutputCalled              func()
 	GetOutputAccountsCalled           func() map[string]*vmcommon.OutputAccount
 	GetOutputAccountCalled            func(address []byte) (*vmcommon.OutputAccount, bool)
 	DeleteOutputAccountCalled         func(address []byte)
 	WriteLogCalled                    func(address []byte, topics [][]byte, data []byte)
+	WriteContractLogCalled            func(address []byte, topics [][]byte, data []byte)
+	WriteEventLogCalled               func(address []byte, event *arwen.EventLog) error
 	TransferCalled                    func(destination []byte, sender []byte, gasLimit uint64, gasLocked uint64, value *big.Int, input []byte) error
 	TransferESDTCalled                func(destination []byte, sender []byte, tokenIdentifier []byte, nonce uint64, value *big.Int, input *vmcommon.ContractCallInput) (uint64, error)
+	TransferMultiESDTCalled           func(destination []byte, sender []byte, transfers []*arwen.ESDTTransfer, input *vmcommon.ContractCallInput) (uint64, error)
 	SelfDestructCalled                func(address []byte, beneficiary []byte)
 	GetRefundCalled                   func() uint64
 	SetRefundCalled                   func(refund uint64)
+	AddRefundCalled                   func(category arwen.RefundCategory, gas uint64)
+	GetItemizedRefundsCalled          func() arwen.ItemizedRefunds
 	ReturnCodeCalled                  func() vmcommon.ReturnCode
 	SetReturnCodeCalled               func(returnCode vmcommon.ReturnCode)
 	ReturnMessageCalled               func() string
 	SetReturnMessageCalled            func(message string)
 	ReturnDataCalled                  func() [][]byte
 	ClearReturnDataCalled             func()
-	FinishCalled                      func(data []byte)
+	FinishCalled                      func(data []byte) error
 	PrependFinishCalled               func(data []byte)
+	GetReturnDataBudgetCalled         func() int64
 	GetVMOutputCalled                 func() *vmcommon.VMOutput
 	AddTxValueToAccountCalled         func(address []byte, value *big.Int)
+	CheckValueConservationCalled      func() error
+	GetOrderedVMOutputCalled          func(vmOutput *vmcommon.VMOutput) *arwen.OrderedVMOutput
+	AddReceiptCalled                  func(receipt arwen.LogReceipt)
+	AttachReceiptCalled               func(vmOutput *vmcommon.VMOutput, receipt arwen.LogReceipt)
 	DeployCodeCalled                  func(input arwen.CodeDeployInput)
 	CreateVMOutputInCaseOfErrorCalled func(err error) *vmcommon.VMOutput
 	AddToActiveStateCalled            func(vmOutput *vmcommon.VMOutput)
@@ -93,6 +106,28 @@ func (o *OutputContextStub) ClearStateStack() {
 	}
 }
 
+// TakeSnapshot mocked method
+func (o *OutputContextStub) TakeSnapshot(key string) {
+	if o.TakeSnapshotCalled != nil {
+		o.TakeSnapshotCalled(key)
+	}
+}
+
+// RevertToSnapshot mocked method
+func (o *OutputContextStub) RevertToSnapshot(key string) error {
+	if o.RevertToSnapshotCalled != nil {
+		return o.RevertToSnapshotCalled(key)
+	}
+	return nil
+}
+
+// DiscardSnapshot mocked method
+func (o *OutputContextStub) DiscardSnapshot(key string) {
+	if o.DiscardSnapshotCalled != nil {
+		o.DiscardSnapshotCalled(key)
+	}
+}
+
 // CopyTopOfStackToActiveState mocked method
 func (o *OutputContextStub) CopyTopOfStackToActiveState() {
 	if o.CopyTopOfStackToActiveStateCalled != nil {
@@ -137,6 +172,21 @@ func (o *OutputContextStub) WriteLog(address []byte, topics [][]byte, data []byt
 	}
 }
 
+// WriteContractLog mocked method
+func (o *OutputContextStub) WriteContractLog(address []byte, topics [][]byte, data []byte) {
+	if o.WriteContractLogCalled != nil {
+		o.WriteContractLogCalled(address, topics, data)
+	}
+}
+
+// WriteEventLog mocked method
+func (o *OutputContextStub) WriteEventLog(address []byte, event *arwen.EventLog) error {
+	if o.WriteEventLogCalled != nil {
+		return o.WriteEventLogCalled(address, event)
+	}
+	return nil
+}
+
 // TransferValueOnly mocked method
 func (o *OutputContextStub) TransferValueOnly(destination []byte, sender []byte, value *big.Int, checkPayable bool) error {
 	if o.TransferValueOnlyCalled != nil {
@@ -163,6 +213,14 @@ func (o *OutputContextStub) TransferESDT(destination []byte, sender []byte, toke
 	return 0, nil
 }
 
+// TransferMultiESDT mocked method
+func (o *OutputContextStub) TransferMultiESDT(destination []byte, sender []byte, transfers []*arwen.ESDTTransfer, callInput *vmcommon.ContractCallInput) (uint64, error) {
+	if o.TransferMultiESDTCalled != nil {
+		return o.TransferMultiESDTCalled(destination, sender, transfers, callInput)
+	}
+	return 0, nil
+}
+
 // SelfDestruct mocked method
 func (o *OutputContextStub) SelfDestruct(address []byte, beneficiary []byte) {
 	if o.SelfDestructCalled != nil {
@@ -185,6 +243,21 @@ func (o *OutputContextStub) SetRefund(refund uint64) {
 	}
 }
 
+// AddRefund mocked method
+func (o *OutputContextStub) AddRefund(category arwen.RefundCategory, gas uint64) {
+	if o.AddRefundCalled != nil {
+		o.AddRefundCalled(category, gas)
+	}
+}
+
+// GetItemizedRefunds mocked method
+func (o *OutputContextStub) GetItemizedRefunds() arwen.ItemizedRefunds {
+	if o.GetItemizedRefundsCalled != nil {
+		return o.GetItemizedRefundsCalled()
+	}
+	return arwen.ItemizedRefunds{}
+}
+
 // ReturnCode mocked method
 func (o *OutputContextStub) ReturnCode() vmcommon.ReturnCode {
 	if o.ReturnCodeCalled != nil {
@@ -231,10 +304,11 @@ func (o *OutputContextStub) ClearReturnData() {
 }
 
 // Finish mocked method
-func (o *OutputContextStub) Finish(data []byte) {
+func (o *OutputContextStub) Finish(data []byte) error {
 	if o.FinishCalled != nil {
-		o.FinishCalled(data)
+		return o.FinishCalled(data)
 	}
+	return nil
 }
 
 // PrependFinish mocked method
@@ -244,6 +318,28 @@ func (o *OutputContextStub) PrependFinish(data []byte) {
 	}
 }
 
+// GetReturnDataBudget mocked method
+func (o *OutputContextStub) GetReturnDataBudget() int64 {
+	if o.GetReturnDataBudgetCalled != nil {
+		return o.GetReturnDataBudgetCalled()
+	}
+	return -1
+}
+
+// AddReceipt mocked method
+func (o *OutputContextStub) AddReceipt(receipt arwen.LogReceipt) {
+	if o.AddReceiptCalled != nil {
+		o.AddReceiptCalled(receipt)
+	}
+}
+
+// AttachReceipt mocked method
+func (o *OutputContextStub) AttachReceipt(vmOutput *vmcommon.VMOutput, receipt arwen.LogReceipt) {
+	if o.AttachReceiptCalled != nil {
+		o.AttachReceiptCalled(vmOutput, receipt)
+	}
+}
+
 // GetVMOutput mocked method
 func (o *OutputContextStub) GetVMOutput() *vmcommon.VMOutput {
 	if o.GetVMOutputCalled != nil {
@@ -259,6 +355,22 @@ func (o *OutputContextStub) AddTxValueToAccount(address []byte, value *big.Int)
 	}
 }
 
+// CheckValueConservation mocked method
+func (o *OutputContextStub) CheckValueConservation() error {
+	if o.CheckValueConservationCalled != nil {
+		return o.CheckValueConservationCalled()
+	}
+	return nil
+}
+
+// GetOrderedVMOutput mocked method
+func (o *OutputContextStub) GetOrderedVMOutput(vmOutput *vmcommon.VMOutput) *arwen.OrderedVMOutput {
+	if o.GetOrderedVMOutputCalled != nil {
+		return o.GetOrderedVMOutputCalled(vmOutput)
+	}
+	return nil
+}
+
 // DeployCode mocked method
 func (o *OutputContextStub) DeployCode(input arwen.CodeDeployInput) {
 	if o.DeployCodeCalled != nil {
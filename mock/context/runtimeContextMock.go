@@ -12,6 +12,7 @@ var _ arwen.RuntimeContext = (*RuntimeContextMock)(nil)
 type RuntimeContextMock struct {
 	Err                    error
 	VMInput                *vmcommon.VMInput
+	ESDTTransfers          []*arwen.ESDTTransfer
 	SCAddress              []byte
 	SCCode                 []byte
 	SCCodeSize             uint64
@@ -33,12 +34,23 @@ type RuntimeContextMock struct {
 	RunningInstances       uint64
 	CurrentTxHash          []byte
 	OriginalTxHash         []byte
+	ManagedTypesContainer  arwen.ManagedTypesContainer
+	RandomCounter          uint64
 }
 
 // InitState mocked method
 func (r *RuntimeContextMock) InitState() {
 }
 
+// SetExecutor mocked method
+func (r *RuntimeContextMock) SetExecutor(_ arwen.Executor) {
+}
+
+// GetExecutorName mocked method
+func (r *RuntimeContextMock) GetExecutorName() string {
+	return ""
+}
+
 // ReplaceInstanceBuilder mocked method()
 func (r *RuntimeContextMock) ReplaceInstanceBuilder(_ arwen.InstanceBuilder) {
 }
@@ -96,6 +108,71 @@ func (r *RuntimeContextMock) IsWarmInstance() bool {
 func (r *RuntimeContextMock) ResetWarmInstance() {
 }
 
+// ClearCompiledCode mocked method
+func (r *RuntimeContextMock) ClearCompiledCode() {
+}
+
+// RegisterCodeUpgrade mocked method
+func (r *RuntimeContextMock) RegisterCodeUpgrade(_ []byte, _ []byte) {
+}
+
+// EnableInstancePool mocked method
+func (r *RuntimeContextMock) EnableInstancePool(_ uint64) {
+}
+
+// EnableCompiledCodeDiskCache mocked method
+func (r *RuntimeContextMock) EnableCompiledCodeDiskCache(_ string, _ uint64) error {
+	return nil
+}
+
+// SetInstanceMemoryLimits mocked method
+func (r *RuntimeContextMock) SetInstanceMemoryLimits(_ arwen.InstanceMemoryLimits) {
+}
+
+// GetInstanceMemoryLimits mocked method
+func (r *RuntimeContextMock) GetInstanceMemoryLimits() arwen.InstanceMemoryLimits {
+	return arwen.InstanceMemoryLimits{}
+}
+
+// SetValidationConfig mocked method
+func (r *RuntimeContextMock) SetValidationConfig(_ arwen.RuntimeValidationConfig) {
+}
+
+// GetValidationConfig mocked method
+func (r *RuntimeContextMock) GetValidationConfig() arwen.RuntimeValidationConfig {
+	return arwen.RuntimeValidationConfig{}
+}
+
+// SetFunctionActivationMap mocked method
+func (r *RuntimeContextMock) SetFunctionActivationMap(_ arwen.FunctionActivationMap) {
+}
+
+// ManagedTypes mocked method
+func (r *RuntimeContextMock) ManagedTypes() arwen.ManagedTypesContainer {
+	return r.ManagedTypesContainer
+}
+
+// NextRandomCounter mocked method
+func (r *RuntimeContextMock) NextRandomCounter() uint64 {
+	counter := r.RandomCounter
+	r.RandomCounter++
+	return counter
+}
+
+// CheckInstanceMemoryLimits mocked method
+func (r *RuntimeContextMock) CheckInstanceMemoryLimits() error {
+	return nil
+}
+
+// MemoryAccountant mocked method
+func (r *RuntimeContextMock) MemoryAccountant() *arwen.MemoryAccountant {
+	return arwen.NewMemoryAccountant()
+}
+
+// InterruptExecution mocked method
+func (r *RuntimeContextMock) InterruptExecution() {
+}
+
 // RunningInstancesCount mocked method
 func (r *RuntimeContextMock) RunningInstancesCount() uint64 {
 	return r.RunningInstances
@@ -105,6 +182,29 @@ func (r *RuntimeContextMock) RunningInstancesCount() uint64 {
 func (r *RuntimeContextMock) SetMaxInstanceCount(uint64) {
 }
 
+// SetMaxExecutionStackDepth mocked method
+func (r *RuntimeContextMock) SetMaxExecutionStackDepth(_ uint64) {
+}
+
+// GetExecutionStackDepth mocked method
+func (r *RuntimeContextMock) GetExecutionStackDepth() uint64 {
+	return 0
+}
+
+// CheckExecutionStackDepth mocked method
+func (r *RuntimeContextMock) CheckExecutionStackDepth() error {
+	return nil
+}
+
+// SetDebugStop mocked method
+func (r *RuntimeContextMock) SetDebugStop(_ arwen.DebugStop, _ arwen.DebugStepCallback) {
+}
+
+// TakeDebugStep mocked method
+func (r *RuntimeContextMock) TakeDebugStep() bool {
+	return false
+}
+
 // ClearInstanceStack mocked method
 func (r *RuntimeContextMock) ClearInstanceStack() {
 }
@@ -124,6 +224,16 @@ func (r *RuntimeContextMock) SetVMInput(vmInput *vmcommon.VMInput) {
 	r.VMInput = vmInput
 }
 
+// GetESDTTransfers mocked method
+func (r *RuntimeContextMock) GetESDTTransfers() []*arwen.ESDTTransfer {
+	return r.ESDTTransfers
+}
+
+// SetESDTTransfers mocked method
+func (r *RuntimeContextMock) SetESDTTransfers(transfers []*arwen.ESDTTransfer) {
+	r.ESDTTransfers = transfers
+}
+
 // IsContractOnTheStack mocked method
 func (r *RuntimeContextMock) IsContractOnTheStack(_ []byte) bool {
 	return r.IsContractOnStack
@@ -342,3 +452,46 @@ func (r *RuntimeContextMock) AddError(err error, otherInfo ...string) {
 func (r *RuntimeContextMock) GetAllErrors() error {
 	return nil
 }
+
+// GetUserErrorReason mocked method
+func (r *RuntimeContextMock) GetUserErrorReason() *arwen.UserErrorReason {
+	return nil
+}
+
+// SetUserErrorCaptureEnabled mocked method
+func (r *RuntimeContextMock) SetUserErrorCaptureEnabled(_ bool) {
+}
+
+// EnableUserErrorDebug mocked method
+func (r *RuntimeContextMock) EnableUserErrorDebug() {
+}
+
+// CaptureOutOfGasReceipt mocked method
+func (r *RuntimeContextMock) CaptureOutOfGasReceipt() {
+}
+
+// GetOutOfGasReceipt mocked method
+func (r *RuntimeContextMock) GetOutOfGasReceipt() *arwen.OutOfGasReceipt {
+	return nil
+}
+
+// EnableOutOfGasReceipts mocked method
+func (r *RuntimeContextMock) EnableOutOfGasReceipts() {
+}
+
+// CaptureCallStackTrace mocked method
+func (r *RuntimeContextMock) CaptureCallStackTrace(_ string) {
+}
+
+// GetCallStackTrace mocked method
+func (r *RuntimeContextMock) GetCallStackTrace() *arwen.CallStackTrace {
+	return nil
+}
+
+// DiscardHandledCallStackTrace mocked method
+func (r *RuntimeContextMock) DiscardHandledCallStackTrace() {
+}
+
+// EnableCallStackTraces mocked method
+func (r *RuntimeContextMock) EnableCallStackTraces() {
+}
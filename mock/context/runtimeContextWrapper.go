@@ -22,6 +22,10 @@ type RuntimeContextWrapper struct {
 	// function that will be called by the corresponding RuntimeContext function implementation (by default this will call the same wrapped context function)
 	SetVMInputFunc func(vmInput *vmcommon.VMInput)
 	// function that will be called by the corresponding RuntimeContext function implementation (by default this will call the same wrapped context function)
+	GetESDTTransfersFunc func() []*arwen.ESDTTransfer
+	// function that will be called by the corresponding RuntimeContext function implementation (by default this will call the same wrapped context function)
+	SetESDTTransfersFunc func(transfers []*arwen.ESDTTransfer)
+	// function that will be called by the corresponding RuntimeContext function implementation (by default this will call the same wrapped context function)
 	GetSCAddressFunc func() []byte
 	// function that will be called by the corresponding RuntimeContext function implementation (by default this will call the same wrapped context function)
 	SetSCAddressFunc func(scAddress []byte)
@@ -72,6 +76,34 @@ type RuntimeContextWrapper struct {
 	// function that will be called by the corresponding RuntimeContext function implementation (by default this will call the same wrapped context function)
 	ResetWarmInstanceFunc func()
 	// function that will be called by the corresponding RuntimeContext function implementation (by default this will call the same wrapped context function)
+	ClearCompiledCodeFunc func()
+	// function that will be called by the corresponding RuntimeContext function implementation (by default this will call the same wrapped context function)
+	RegisterCodeUpgradeFunc func(address []byte, code []byte)
+	// function that will be called by the corresponding RuntimeContext function implementation (by default this will call the same wrapped context function)
+	EnableInstancePoolFunc func(size uint64)
+	// function that will be called by the corresponding RuntimeContext function implementation (by default this will call the same wrapped context function)
+	EnableCompiledCodeDiskCacheFunc func(directory string, size uint64) error
+	// function that will be called by the corresponding RuntimeContext function implementation (by default this will call the same wrapped context function)
+	SetInstanceMemoryLimitsFunc func(limits arwen.InstanceMemoryLimits)
+	// function that will be called by the corresponding RuntimeContext function implementation (by default this will call the same wrapped context function)
+	GetInstanceMemoryLimitsFunc func() arwen.InstanceMemoryLimits
+	// function that will be called by the corresponding RuntimeContext function implementation (by default this will call the same wrapped context function)
+	CheckInstanceMemoryLimitsFunc func() error
+	// function that will be called by the corresponding RuntimeContext function implementation (by default this will call the same wrapped context function)
+	MemoryAccountantFunc func() *arwen.MemoryAccountant
+	// function that will be called by the corresponding RuntimeContext function implementation (by default this will call the same wrapped context function)
+	SetValidationConfigFunc func(config arwen.RuntimeValidationConfig)
+	// function that will be called by the corresponding RuntimeContext function implementation (by default this will call the same wrapped context function)
+	GetValidationConfigFunc func() arwen.RuntimeValidationConfig
+	// function that will be called by the corresponding RuntimeContext function implementation (by default this will call the same wrapped context function)
+	SetFunctionActivationMapFunc func(activationMap arwen.FunctionActivationMap)
+	// function that will be called by the corresponding RuntimeContext function implementation (by default this will call the same wrapped context function)
+	ManagedTypesFunc func() arwen.ManagedTypesContainer
+	// function that will be called by the corresponding RuntimeContext function implementation (by default this will call the same wrapped context function)
+	NextRandomCounterFunc func() uint64
+	// function that will be called by the corresponding RuntimeContext function implementation (by default this will call the same wrapped context function)
+	InterruptExecutionFunc func()
+	// function that will be called by the corresponding RuntimeContext function implementation (by default this will call the same wrapped context function)
 	ReadOnlyFunc func() bool
 	// function that will be called by the corresponding RuntimeContext function implementation (by default this will call the same wrapped context function)
 	SetReadOnlyFunc func(readOnly bool)
@@ -82,6 +114,16 @@ type RuntimeContextWrapper struct {
 	// function that will be called by the corresponding RuntimeContext function implementation (by default this will call the same wrapped context function)
 	SetMaxInstanceCountFunc func(maxInstances uint64)
 	// function that will be called by the corresponding RuntimeContext function implementation (by default this will call the same wrapped context function)
+	SetMaxExecutionStackDepthFunc func(maxDepth uint64)
+	// function that will be called by the corresponding RuntimeContext function implementation (by default this will call the same wrapped context function)
+	GetExecutionStackDepthFunc func() uint64
+	// function that will be called by the corresponding RuntimeContext function implementation (by default this will call the same wrapped context function)
+	CheckExecutionStackDepthFunc func() error
+	// function that will be called by the corresponding RuntimeContext function implementation (by default this will call the same wrapped context function)
+	SetDebugStopFunc func(stop arwen.DebugStop, callback arwen.DebugStepCallback)
+	// function that will be called by the corresponding RuntimeContext function implementation (by default this will call the same wrapped context function)
+	TakeDebugStepFunc func() bool
+	// function that will be called by the corresponding RuntimeContext function implementation (by default this will call the same wrapped context function)
 	VerifyContractCodeFunc func() error
 	// function that will be called by the corresponding RuntimeContext function implementation (by default this will call the same wrapped context function)
 	GetInstanceFunc func() wasmer.InstanceHandler
@@ -114,9 +156,33 @@ type RuntimeContextWrapper struct {
 	// function that will be called by the corresponding RuntimeContext function implementation (by default this will call the same wrapped context function)
 	ReplaceInstanceBuilderFunc func(builder arwen.InstanceBuilder)
 	// function that will be called by the corresponding RuntimeContext function implementation (by default this will call the same wrapped context function)
+	SetExecutorFunc func(executor arwen.Executor)
+	// function that will be called by the corresponding RuntimeContext function implementation (by default this will call the same wrapped context function)
+	GetExecutorNameFunc func() string
+	// function that will be called by the corresponding RuntimeContext function implementation (by default this will call the same wrapped context function)
 	AddErrorFunc func(err error, otherInfo ...string)
 	// function that will be called by the corresponding RuntimeContext function implementation (by default this will call the same wrapped context function)
 	GetAllErrorsFunc func() error
+	// function that will be called by the corresponding RuntimeContext function implementation (by default this will call the same wrapped context function)
+	GetUserErrorReasonFunc func() *arwen.UserErrorReason
+	// function that will be called by the corresponding RuntimeContext function implementation (by default this will call the same wrapped context function)
+	SetUserErrorCaptureEnabledFunc func(enabled bool)
+	// function that will be called by the corresponding RuntimeContext function implementation (by default this will call the same wrapped context function)
+	EnableUserErrorDebugFunc func()
+	// function that will be called by the corresponding RuntimeContext function implementation (by default this will call the same wrapped context function)
+	CaptureOutOfGasReceiptFunc func()
+	// function that will be called by the corresponding RuntimeContext function implementation (by default this will call the same wrapped context function)
+	GetOutOfGasReceiptFunc func() *arwen.OutOfGasReceipt
+	// function that will be called by the corresponding RuntimeContext function implementation (by default this will call the same wrapped context function)
+	EnableOutOfGasReceiptsFunc func()
+	// function that will be called by the corresponding RuntimeContext function implementation (by default this will call the same wrapped context function)
+	CaptureCallStackTraceFunc func(message string)
+	// function that will be called by the corresponding RuntimeContext function implementation (by default this will call the same wrapped context function)
+	GetCallStackTraceFunc func() *arwen.CallStackTrace
+	// function that will be called by the corresponding RuntimeContext function implementation (by default this will call the same wrapped context function)
+	DiscardHandledCallStackTraceFunc func()
+	// function that will be called by the corresponding RuntimeContext function implementation (by default this will call the same wrapped context function)
+	EnableCallStackTracesFunc func()
 
 	// function that will be called by the corresponding RuntimeContext function implementation (by default this will call the same wrapped context function)
 	InitStateFunc func()
@@ -155,6 +221,14 @@ func NewRuntimeContextWrapper(inputRuntimeContext *arwen.RuntimeContext) *Runtim
 		runtimeWrapper.runtimeContext.SetVMInput(vmInput)
 	}
 
+	runtimeWrapper.GetESDTTransfersFunc = func() []*arwen.ESDTTransfer {
+		return runtimeWrapper.runtimeContext.GetESDTTransfers()
+	}
+
+	runtimeWrapper.SetESDTTransfersFunc = func(transfers []*arwen.ESDTTransfer) {
+		runtimeWrapper.runtimeContext.SetESDTTransfers(transfers)
+	}
+
 	runtimeWrapper.GetSCAddressFunc = func() []byte {
 		return runtimeWrapper.runtimeContext.GetSCAddress()
 	}
@@ -255,6 +329,62 @@ func NewRuntimeContextWrapper(inputRuntimeContext *arwen.RuntimeContext) *Runtim
 		runtimeWrapper.runtimeContext.ResetWarmInstance()
 	}
 
+	runtimeWrapper.ClearCompiledCodeFunc = func() {
+		runtimeWrapper.runtimeContext.ClearCompiledCode()
+	}
+
+	runtimeWrapper.RegisterCodeUpgradeFunc = func(address []byte, code []byte) {
+		runtimeWrapper.runtimeContext.RegisterCodeUpgrade(address, code)
+	}
+
+	runtimeWrapper.EnableInstancePoolFunc = func(size uint64) {
+		runtimeWrapper.runtimeContext.EnableInstancePool(size)
+	}
+
+	runtimeWrapper.EnableCompiledCodeDiskCacheFunc = func(directory string, size uint64) error {
+		return runtimeWrapper.runtimeContext.EnableCompiledCodeDiskCache(directory, size)
+	}
+
+	runtimeWrapper.SetInstanceMemoryLimitsFunc = func(limits arwen.InstanceMemoryLimits) {
+		runtimeWrapper.runtimeContext.SetInstanceMemoryLimits(limits)
+	}
+
+	runtimeWrapper.GetInstanceMemoryLimitsFunc = func() arwen.InstanceMemoryLimits {
+		return runtimeWrapper.runtimeContext.GetInstanceMemoryLimits()
+	}
+
+	runtimeWrapper.CheckInstanceMemoryLimitsFunc = func() error {
+		return runtimeWrapper.runtimeContext.CheckInstanceMemoryLimits()
+	}
+
+	runtimeWrapper.MemoryAccountantFunc = func() *arwen.MemoryAccountant {
+		return runtimeWrapper.runtimeContext.MemoryAccountant()
+	}
+
+	runtimeWrapper.SetValidationConfigFunc = func(config arwen.RuntimeValidationConfig) {
+		runtimeWrapper.runtimeContext.SetValidationConfig(config)
+	}
+
+	runtimeWrapper.GetValidationConfigFunc = func() arwen.RuntimeValidationConfig {
+		return runtimeWrapper.runtimeContext.GetValidationConfig()
+	}
+
+	runtimeWrapper.SetFunctionActivationMapFunc = func(activationMap arwen.FunctionActivationMap) {
+		runtimeWrapper.runtimeContext.SetFunctionActivationMap(activationMap)
+	}
+
+	runtimeWrapper.ManagedTypesFunc = func() arwen.ManagedTypesContainer {
+		return runtimeWrapper.runtimeContext.ManagedTypes()
+	}
+
+	runtimeWrapper.NextRandomCounterFunc = func() uint64 {
+		return runtimeWrapper.runtimeContext.NextRandomCounter()
+	}
+
+	runtimeWrapper.InterruptExecutionFunc = func() {
+		runtimeWrapper.runtimeContext.InterruptExecution()
+	}
+
 	runtimeWrapper.ReadOnlyFunc = func() bool {
 		return runtimeWrapper.runtimeContext.ReadOnly()
 	}
@@ -275,6 +405,26 @@ func NewRuntimeContextWrapper(inputRuntimeContext *arwen.RuntimeContext) *Runtim
 		runtimeWrapper.runtimeContext.SetMaxInstanceCount(maxInstances)
 	}
 
+	runtimeWrapper.SetMaxExecutionStackDepthFunc = func(maxDepth uint64) {
+		runtimeWrapper.runtimeContext.SetMaxExecutionStackDepth(maxDepth)
+	}
+
+	runtimeWrapper.GetExecutionStackDepthFunc = func() uint64 {
+		return runtimeWrapper.runtimeContext.GetExecutionStackDepth()
+	}
+
+	runtimeWrapper.CheckExecutionStackDepthFunc = func() error {
+		return runtimeWrapper.runtimeContext.CheckExecutionStackDepth()
+	}
+
+	runtimeWrapper.SetDebugStopFunc = func(stop arwen.DebugStop, callback arwen.DebugStepCallback) {
+		runtimeWrapper.runtimeContext.SetDebugStop(stop, callback)
+	}
+
+	runtimeWrapper.TakeDebugStepFunc = func() bool {
+		return runtimeWrapper.runtimeContext.TakeDebugStep()
+	}
+
 	runtimeWrapper.VerifyContractCodeFunc = func() error {
 		return runtimeWrapper.runtimeContext.VerifyContractCode()
 	}
@@ -339,6 +489,14 @@ func NewRuntimeContextWrapper(inputRuntimeContext *arwen.RuntimeContext) *Runtim
 		runtimeWrapper.runtimeContext.ReplaceInstanceBuilder(builder)
 	}
 
+	runtimeWrapper.SetExecutorFunc = func(executor arwen.Executor) {
+		runtimeWrapper.runtimeContext.SetExecutor(executor)
+	}
+
+	runtimeWrapper.GetExecutorNameFunc = func() string {
+		return runtimeWrapper.runtimeContext.GetExecutorName()
+	}
+
 	runtimeWrapper.AddErrorFunc = func(err error, otherInfo ...string) {
 		runtimeWrapper.runtimeContext.AddError(err, otherInfo...)
 	}
@@ -347,6 +505,46 @@ func NewRuntimeContextWrapper(inputRuntimeContext *arwen.RuntimeContext) *Runtim
 		return runtimeWrapper.runtimeContext.GetAllErrors()
 	}
 
+	runtimeWrapper.GetUserErrorReasonFunc = func() *arwen.UserErrorReason {
+		return runtimeWrapper.runtimeContext.GetUserErrorReason()
+	}
+
+	runtimeWrapper.SetUserErrorCaptureEnabledFunc = func(enabled bool) {
+		runtimeWrapper.runtimeContext.SetUserErrorCaptureEnabled(enabled)
+	}
+
+	runtimeWrapper.EnableUserErrorDebugFunc = func() {
+		runtimeWrapper.runtimeContext.EnableUserErrorDebug()
+	}
+
+	runtimeWrapper.CaptureOutOfGasReceiptFunc = func() {
+		runtimeWrapper.runtimeContext.CaptureOutOfGasReceipt()
+	}
+
+	runtimeWrapper.GetOutOfGasReceiptFunc = func() *arwen.OutOfGasReceipt {
+		return runtimeWrapper.runtimeContext.GetOutOfGasReceipt()
+	}
+
+	runtimeWrapper.EnableOutOfGasReceiptsFunc = func() {
+		runtimeWrapper.runtimeContext.EnableOutOfGasReceipts()
+	}
+
+	runtimeWrapper.CaptureCallStackTraceFunc = func(message string) {
+		runtimeWrapper.runtimeContext.CaptureCallStackTrace(message)
+	}
+
+	runtimeWrapper.GetCallStackTraceFunc = func() *arwen.CallStackTrace {
+		return runtimeWrapper.runtimeContext.GetCallStackTrace()
+	}
+
+	runtimeWrapper.DiscardHandledCallStackTraceFunc = func() {
+		runtimeWrapper.runtimeContext.DiscardHandledCallStackTrace()
+	}
+
+	runtimeWrapper.EnableCallStackTracesFunc = func() {
+		runtimeWrapper.runtimeContext.EnableCallStackTraces()
+	}
+
 	runtimeWrapper.InitStateFunc = func() {
 		runtimeWrapper.runtimeContext.InitState()
 	}
@@ -395,6 +593,16 @@ func (contextWrapper *RuntimeContextWrapper) SetVMInput(vmInput *vmcommon.VMInpu
 	contextWrapper.SetVMInputFunc(vmInput)
 }
 
+// GetESDTTransfers calls corresponding xxxFunc function, that by default in turn calls the original method of the wrapped RuntimeContext
+func (contextWrapper *RuntimeContextWrapper) GetESDTTransfers() []*arwen.ESDTTransfer {
+	return contextWrapper.GetESDTTransfersFunc()
+}
+
+// SetESDTTransfers calls corresponding xxxFunc function, that by default in turn calls the original method of the wrapped RuntimeContext
+func (contextWrapper *RuntimeContextWrapper) SetESDTTransfers(transfers []*arwen.ESDTTransfer) {
+	contextWrapper.SetESDTTransfersFunc(transfers)
+}
+
 // GetSCAddress calls corresponding xxxFunc function, that by default in turn calls the original method of the wrapped RuntimeContext
 func (contextWrapper *RuntimeContextWrapper) GetSCAddress() []byte {
 	return contextWrapper.GetSCAddressFunc()
@@ -520,6 +728,76 @@ func (contextWrapper *RuntimeContextWrapper) ResetWarmInstance() {
 	contextWrapper.ResetWarmInstanceFunc()
 }
 
+// ClearCompiledCode calls corresponding xxxFunc function, that by default in turn calls the original method of the wrapped RuntimeContext
+func (contextWrapper *RuntimeContextWrapper) ClearCompiledCode() {
+	contextWrapper.ClearCompiledCodeFunc()
+}
+
+// RegisterCodeUpgrade calls corresponding xxxFunc function, that by default in turn calls the original method of the wrapped RuntimeContext
+func (contextWrapper *RuntimeContextWrapper) RegisterCodeUpgrade(address []byte, code []byte) {
+	contextWrapper.RegisterCodeUpgradeFunc(address, code)
+}
+
+// EnableInstancePool calls corresponding xxxFunc function, that by default in turn calls the original method of the wrapped RuntimeContext
+func (contextWrapper *RuntimeContextWrapper) EnableInstancePool(size uint64) {
+	contextWrapper.EnableInstancePoolFunc(size)
+}
+
+// EnableCompiledCodeDiskCache calls corresponding xxxFunc function, that by default in turn calls the original method of the wrapped RuntimeContext
+func (contextWrapper *RuntimeContextWrapper) EnableCompiledCodeDiskCache(directory string, size uint64) error {
+	return contextWrapper.EnableCompiledCodeDiskCacheFunc(directory, size)
+}
+
+// SetInstanceMemoryLimits calls corresponding xxxFunc function, that by default in turn calls the original method of the wrapped RuntimeContext
+func (contextWrapper *RuntimeContextWrapper) SetInstanceMemoryLimits(limits arwen.InstanceMemoryLimits) {
+	contextWrapper.SetInstanceMemoryLimitsFunc(limits)
+}
+
+// GetInstanceMemoryLimits calls corresponding xxxFunc function, that by default in turn calls the original method of the wrapped RuntimeContext
+func (contextWrapper *RuntimeContextWrapper) GetInstanceMemoryLimits() arwen.InstanceMemoryLimits {
+	return contextWrapper.GetInstanceMemoryLimitsFunc()
+}
+
+// CheckInstanceMemoryLimits calls corresponding xxxFunc function, that by default in turn calls the original method of the wrapped RuntimeContext
+func (contextWrapper *RuntimeContextWrapper) CheckInstanceMemoryLimits() error {
+	return contextWrapper.CheckInstanceMemoryLimitsFunc()
+}
+
+// MemoryAccountant calls corresponding xxxFunc function, that by default in turn calls the original method of the wrapped RuntimeContext
+func (contextWrapper *RuntimeContextWrapper) MemoryAccountant() *arwen.MemoryAccountant {
+	return contextWrapper.MemoryAccountantFunc()
+}
+
+// SetValidationConfig calls corresponding xxxFunc function, that by default in turn calls the original method of the wrapped RuntimeContext
+func (contextWrapper *RuntimeContextWrapper) SetValidationConfig(config arwen.RuntimeValidationConfig) {
+	contextWrapper.SetValidationConfigFunc(config)
+}
+
+// GetValidationConfig calls corresponding xxxFunc function, that by default in turn calls the original method of the wrapped RuntimeContext
+func (contextWrapper *RuntimeContextWrapper) GetValidationConfig() arwen.RuntimeValidationConfig {
+	return contextWrapper.GetValidationConfigFunc()
+}
+
+// SetFunctionActivationMap calls corresponding xxxFunc function, that by default in turn calls the original method of the wrapped RuntimeContext
+func (contextWrapper *RuntimeContextWrapper) SetFunctionActivationMap(activationMap arwen.FunctionActivationMap) {
+	contextWrapper.SetFunctionActivationMapFunc(activationMap)
+}
+
+// ManagedTypes calls corresponding xxxFunc function, that by default in turn calls the original method of the wrapped RuntimeContext
+func (contextWrapper *RuntimeContextWrapper) ManagedTypes() arwen.ManagedTypesContainer {
+	return contextWrapper.ManagedTypesFunc()
+}
+
+// NextRandomCounter calls corresponding xxxFunc function, that by default in turn calls the original method of the wrapped RuntimeContext
+func (contextWrapper *RuntimeContextWrapper) NextRandomCounter() uint64 {
+	return contextWrapper.NextRandomCounterFunc()
+}
+
+// InterruptExecution calls corresponding xxxFunc function, that by default in turn calls the original method of the wrapped RuntimeContext
+func (contextWrapper *RuntimeContextWrapper) InterruptExecution() {
+	contextWrapper.InterruptExecutionFunc()
+}
+
 // ReadOnly calls corresponding xxxFunc function, that by default in turn calls the original method of the wrapped RuntimeContext
 func (contextWrapper *RuntimeContextWrapper) ReadOnly() bool {
 	return contextWrapper.ReadOnlyFunc()
@@ -545,6 +823,31 @@ func (contextWrapper *RuntimeContextWrapper) SetMaxInstanceCount(maxInstances ui
 	contextWrapper.SetMaxInstanceCountFunc(maxInstances)
 }
 
+// SetMaxExecutionStackDepth calls corresponding xxxFunc function, that by default in turn calls the original method of the wrapped RuntimeContext
+func (contextWrapper *RuntimeContextWrapper) SetMaxExecutionStackDepth(maxDepth uint64) {
+	contextWrapper.SetMaxExecutionStackDepthFunc(maxDepth)
+}
+
+// GetExecutionStackDepth calls corresponding xxxFunc function, that by default in turn calls the original method of the wrapped RuntimeContext
+func (contextWrapper *RuntimeContextWrapper) GetExecutionStackDepth() uint64 {
+	return contextWrapper.GetExecutionStackDepthFunc()
+}
+
+// CheckExecutionStackDepth calls corresponding xxxFunc function, that by default in turn calls the original method of the wrapped RuntimeContext
+func (contextWrapper *RuntimeContextWrapper) CheckExecutionStackDepth() error {
+	return contextWrapper.CheckExecutionStackDepthFunc()
+}
+
+// SetDebugStop calls corresponding xxxFunc function, that by default in turn calls the original method of the wrapped RuntimeContext
+func (contextWrapper *RuntimeContextWrapper) SetDebugStop(stop arwen.DebugStop, callback arwen.DebugStepCallback) {
+	contextWrapper.SetDebugStopFunc(stop, callback)
+}
+
+// TakeDebugStep calls corresponding xxxFunc function, that by default in turn calls the original method of the wrapped RuntimeContext
+func (contextWrapper *RuntimeContextWrapper) TakeDebugStep() bool {
+	return contextWrapper.TakeDebugStepFunc()
+}
+
 // VerifyContractCode calls corresponding xxxFunc function, that by default in turn calls the original method of the wrapped RuntimeContext
 func (contextWrapper *RuntimeContextWrapper) VerifyContractCode() error {
 	return contextWrapper.VerifyContractCodeFunc()
@@ -625,6 +928,16 @@ func (contextWrapper *RuntimeContextWrapper) ReplaceInstanceBuilder(builder arwe
 	contextWrapper.ReplaceInstanceBuilderFunc(builder)
 }
 
+// SetExecutor calls corresponding xxxFunc function, that by default in turn calls the original method of the wrapped RuntimeContext
+func (contextWrapper *RuntimeContextWrapper) SetExecutor(executor arwen.Executor) {
+	contextWrapper.SetExecutorFunc(executor)
+}
+
+// GetExecutorName calls corresponding xxxFunc function, that by default in turn calls the original method of the wrapped RuntimeContext
+func (contextWrapper *RuntimeContextWrapper) GetExecutorName() string {
+	return contextWrapper.GetExecutorNameFunc()
+}
+
 // AddError calls corresponding xxxFunc function, that by default in turn calls the original method of the wrapped RuntimeContext
 func (contextWrapper *RuntimeContextWrapper) AddError(err error, otherInfo ...string) {
 	contextWrapper.AddErrorFunc(err, otherInfo...)
@@ -635,6 +948,56 @@ func (contextWrapper *RuntimeContextWrapper) GetAllErrors() error {
 	return contextWrapper.GetAllErrorsFunc()
 }
 
+// GetUserErrorReason calls corresponding xxxFunc function, that by default in turn calls the original method of the wrapped RuntimeContext
+func (contextWrapper *RuntimeContextWrapper) GetUserErrorReason() *arwen.UserErrorReason {
+	return contextWrapper.GetUserErrorReasonFunc()
+}
+
+// SetUserErrorCaptureEnabled calls corresponding xxxFunc function, that by default in turn calls the original method of the wrapped RuntimeContext
+func (contextWrapper *RuntimeContextWrapper) SetUserErrorCaptureEnabled(enabled bool) {
+	contextWrapper.SetUserErrorCaptureEnabledFunc(enabled)
+}
+
+// EnableUserErrorDebug calls corresponding xxxFunc function, that by default in turn calls the original method of the wrapped RuntimeContext
+func (contextWrapper *RuntimeContextWrapper) EnableUserErrorDebug() {
+	contextWrapper.EnableUserErrorDebugFunc()
+}
+
+// CaptureOutOfGasReceipt calls corresponding xxxFunc function, that by default in turn calls the original method of the wrapped RuntimeContext
+func (contextWrapper *RuntimeContextWrapper) CaptureOutOfGasReceipt() {
+	contextWrapper.CaptureOutOfGasReceiptFunc()
+}
+
+// GetOutOfGasReceipt calls corresponding xxxFunc function, that by default in turn calls the original method of the wrapped RuntimeContext
+func (contextWrapper *RuntimeContextWrapper) GetOutOfGasReceipt() *arwen.OutOfGasReceipt {
+	return contextWrapper.GetOutOfGasReceiptFunc()
+}
+
+// EnableOutOfGasReceipts calls corresponding xxxFunc function, that by default in turn calls the original method of the wrapped RuntimeContext
+func (contextWrapper *RuntimeContextWrapper) EnableOutOfGasReceipts() {
+	contextWrapper.EnableOutOfGasReceiptsFunc()
+}
+
+// CaptureCallStackTrace calls corresponding xxxFunc function, that by default in turn calls the original method of the wrapped RuntimeContext
+func (contextWrapper *RuntimeContextWrapper) CaptureCallStackTrace(message string) {
+	contextWrapper.CaptureCallStackTraceFunc(message)
+}
+
+// GetCallStackTrace calls corresponding xxxFunc function, that by default in turn calls the original method of the wrapped RuntimeContext
+func (contextWrapper *RuntimeContextWrapper) GetCallStackTrace() *arwen.CallStackTrace {
+	return contextWrapper.GetCallStackTraceFunc()
+}
+
+// DiscardHandledCallStackTrace calls corresponding xxxFunc function, that by default in turn calls the original method of the wrapped RuntimeContext
+func (contextWrapper *RuntimeContextWrapper) DiscardHandledCallStackTrace() {
+	contextWrapper.DiscardHandledCallStackTraceFunc()
+}
+
+// EnableCallStackTraces calls corresponding xxxFunc function, that by default in turn calls the original method of the wrapped RuntimeContext
+func (contextWrapper *RuntimeContextWrapper) EnableCallStackTraces() {
+	contextWrapper.EnableCallStackTracesFunc()
+}
+
 // InitState calls corresponding xxxFunc function, that by default in turn calls the original method of the wrapped RuntimeContext
 func (contextWrapper *RuntimeContextWrapper) InitState() {
 	contextWrapper.InitStateFunc()
@@ -28,6 +28,8 @@ type VMHostMock struct {
 
 	SCAPIMethods  *wasmer.Imports
 	IsBuiltinFunc bool
+
+	ReadOnlyEnforcementDisabled bool
 }
 
 // GetVersion mocked method
@@ -95,6 +97,71 @@ func (host *VMHostMock) IsESDTFunctionsEnabled() bool {
 	return true
 }
 
+// IsMultiAsyncCallGroupsEnabled mocked method
+func (host *VMHostMock) IsMultiAsyncCallGroupsEnabled() bool {
+	return true
+}
+
+// IsCallbackValidationEnabled mocked method
+func (host *VMHostMock) IsCallbackValidationEnabled() bool {
+	return true
+}
+
+// IsRefundReceiptsEnabled mocked method
+func (host *VMHostMock) IsRefundReceiptsEnabled() bool {
+	return true
+}
+
+// IsStorageLoadCacheEnabled mocked method
+func (host *VMHostMock) IsStorageLoadCacheEnabled() bool {
+	return true
+}
+
+// IsAsyncCallsFixEnabled mocked method
+func (host *VMHostMock) IsAsyncCallsFixEnabled() bool {
+	return true
+}
+
+// IsFailedExecutionGasEnabled mocked method
+func (host *VMHostMock) IsFailedExecutionGasEnabled() bool {
+	return true
+}
+
+// IsEventLogValidationEnabled mocked method
+func (host *VMHostMock) IsEventLogValidationEnabled() bool {
+	return true
+}
+
+// IsAsyncCallGasCapEnabled mocked method
+func (host *VMHostMock) IsAsyncCallGasCapEnabled() bool {
+	return true
+}
+
+// IsLogEntryGasEnabled mocked method
+func (host *VMHostMock) IsLogEntryGasEnabled() bool {
+	return true
+}
+
+// IsGroupCallbacksEnabled mocked method
+func (host *VMHostMock) IsGroupCallbacksEnabled() bool {
+	return true
+}
+
+// IsAsyncValueTransferEnabled mocked method
+func (host *VMHostMock) IsAsyncValueTransferEnabled() bool {
+	return true
+}
+
+// IsCallbackGasEscrowEnabled mocked method
+func (host *VMHostMock) IsCallbackGasEscrowEnabled() bool {
+	return true
+}
+
+// IsReadOnlyEnforcementEnabled mocked method
+func (host *VMHostMock) IsReadOnlyEnforcementEnabled() bool {
+	return !host.ReadOnlyEnforcementDisabled
+}
+
 // AreInSameShard mocked method
 func (host *VMHostMock) AreInSameShard(_ []byte, _ []byte) bool {
 	return true
@@ -105,6 +172,11 @@ func (host *VMHostMock) ExecuteESDTTransfer(_ []byte, _ []byte, _ []byte, _ uint
 	return nil, 0, nil
 }
 
+// ExecuteMultiESDTTransfer mocked method
+func (host *VMHostMock) ExecuteMultiESDTTransfer(_ []byte, _ []byte, _ []*arwen.ESDTTransfer, _ vmcommon.CallType) (*vmcommon.VMOutput, uint64, error) {
+	return nil, 0, nil
+}
+
 // CreateNewContract mocked method
 func (host *VMHostMock) CreateNewContract(_ *vmcommon.ContractCreateInput) ([]byte, error) {
 	return nil, nil
@@ -120,6 +192,11 @@ func (host *VMHostMock) ExecuteOnDestContext(_ *vmcommon.ContractCallInput) (*vm
 	return nil, nil, nil
 }
 
+// ExecuteOnDestContextWithESDTTransfers mocked method
+func (host *VMHostMock) ExecuteOnDestContextWithESDTTransfers(_ *vmcommon.ContractCallInput, _ []*arwen.ESDTTransfer) (*vmcommon.VMOutput, *arwen.AsyncContextInfo, error) {
+	return nil, nil, nil
+}
+
 // InitState mocked method
 func (host *VMHostMock) InitState() {
 }
@@ -165,6 +242,11 @@ func (host *VMHostMock) RunSmartContractCall(input *vmcommon.ContractCallInput)
 	return nil, nil
 }
 
+// RunSmartContractQuery mocked method
+func (host *VMHostMock) RunSmartContractQuery(input *vmcommon.ContractCallInput) (vmOutput *vmcommon.VMOutput, err error) {
+	return nil, nil
+}
+
 // RunSmartContractCreate mocked method
 func (host *VMHostMock) RunSmartContractCreate(input *vmcommon.ContractCreateInput) (vmOutput *vmcommon.VMOutput, err error) {
 	return nil, nil
@@ -174,6 +256,11 @@ func (host *VMHostMock) RunSmartContractCreate(input *vmcommon.ContractCreateInp
 func (host *VMHostMock) GasScheduleChange(newGasSchedule config.GasScheduleMap) {
 }
 
+// SetGasSchedule mocked method
+func (host *VMHostMock) SetGasSchedule(newGasSchedule config.GasScheduleMap) error {
+	return nil
+}
+
 // IsInterfaceNil mocked method
 func (host *VMHostMock) IsInterfaceNil() bool {
 	return false
@@ -191,6 +278,85 @@ func (host *VMHostMock) GetContexts() (
 	return host.BigIntContext, host.BlockchainContext, host.MeteringContext, host.OutputContext, host.RuntimeContext, host.StorageContext
 }
 
+// MigrateLegacyAsyncData mocked method
+func (host *VMHostMock) MigrateLegacyAsyncData(_ []byte) (*arwen.AsyncMigrationResult, error) {
+	return nil, nil
+}
+
+// SetAsyncTracer mocked method
+func (host *VMHostMock) SetAsyncTracer(_ arwen.AsyncTracer) {
+}
+
+// RegisterDeferredCall mocked method
+func (host *VMHostMock) RegisterDeferredCall(_ *arwen.DeferredCall) error {
+	return nil
+}
+
+// RunDeferredCalls mocked method
+func (host *VMHostMock) RunDeferredCalls(_ []byte) (*vmcommon.VMOutput, error) {
+	return nil, nil
+}
+
+// GetExecutionWitness mocked method
+func (host *VMHostMock) GetExecutionWitness() *arwen.ExecutionWitness {
+	return nil
+}
+
+// GetGasProfile mocked method
+func (host *VMHostMock) GetGasProfile() *arwen.GasProfile {
+	return nil
+}
+
+// GetExecutionProfile mocked method
+func (host *VMHostMock) GetExecutionProfile() *arwen.ExecutionProfile {
+	return nil
+}
+
+// GetAsyncCallTree mocked method
+func (host *VMHostMock) GetAsyncCallTree() *arwen.AsyncCallTree {
+	return nil
+}
+
+// GetHookCacheStats mocked method
+func (host *VMHostMock) GetHookCacheStats() *arwen.HookCacheStats {
+	return nil
+}
+
+// GetTouchedAccounts mocked method
+func (host *VMHostMock) GetTouchedAccounts() *arwen.TouchedAccounts {
+	return nil
+}
+
+// GetDeploymentLimits mocked method
+func (host *VMHostMock) GetDeploymentLimits() arwen.DeploymentLimits {
+	return arwen.DeploymentLimits{}
+}
+
+// GetRuntimeConfig mocked method
+func (host *VMHostMock) GetRuntimeConfig() arwen.RuntimeConfig {
+	return arwen.RuntimeConfig{}
+}
+
+// GetStorageDiff mocked method
+func (host *VMHostMock) GetStorageDiff() *arwen.StorageDiff {
+	return nil
+}
+
+// GetTouchedStorageKeys mocked method
+func (host *VMHostMock) GetTouchedStorageKeys() *arwen.TouchedStorageKeys {
+	return nil
+}
+
+// EstimateAsyncCallGas mocked method
+func (host *VMHostMock) EstimateAsyncCallGas(_ []byte, _ []byte, _ []byte, _ *big.Int) (*arwen.AsyncCallGasEstimate, error) {
+	return nil, nil
+}
+
+// RegisterBuiltinOutputProcessor mocked method
+func (host *VMHostMock) RegisterBuiltinOutputProcessor(_ string, _ arwen.BuiltinOutputProcessor) error {
+	return nil
+}
+
 // SetRuntimeContext mocked method
 func (host *VMHostMock) SetRuntimeContext(runtime arwen.RuntimeContext) {
 	host.RuntimeContext = runtime
@@ -20,31 +20,51 @@ type VMHostStub struct {
 	ClearStateStackCalled func()
 	GetVersionCalled      func() string
 
-	CryptoCalled                      func() crypto.VMCrypto
-	BlockchainCalled                  func() arwen.BlockchainContext
-	RuntimeCalled                     func() arwen.RuntimeContext
-	BigIntCalled                      func() arwen.BigIntContext
-	OutputCalled                      func() arwen.OutputContext
-	MeteringCalled                    func() arwen.MeteringContext
-	StorageCalled                     func() arwen.StorageContext
-	ExecuteESDTTransferCalled         func(destination []byte, sender []byte, tokenIdentifier []byte, nonce uint64, value *big.Int, callType vmcommon.CallType) (*vmcommon.VMOutput, uint64, error)
-	CreateNewContractCalled           func(input *vmcommon.ContractCreateInput) ([]byte, error)
-	ExecuteOnSameContextCalled        func(input *vmcommon.ContractCallInput) (*arwen.AsyncContextInfo, error)
-	ExecuteOnDestContextCalled        func(input *vmcommon.ContractCallInput) (*vmcommon.VMOutput, *arwen.AsyncContextInfo, error)
-	GetAPIMethodsCalled               func() *wasmer.Imports
-	GetProtocolBuiltinFunctionsCalled func() vmcommon.FunctionNames
-	SetProtocolBuiltinFunctionsCalled func(vmcommon.FunctionNames)
-	IsBuiltinFunctionNameCalled       func(functionName string) bool
-	AreInSameShardCalled              func(left []byte, right []byte) bool
+	CryptoCalled                                func() crypto.VMCrypto
+	BlockchainCalled                            func() arwen.BlockchainContext
+	RuntimeCalled                               func() arwen.RuntimeContext
+	BigIntCalled                                func() arwen.BigIntContext
+	OutputCalled                                func() arwen.OutputContext
+	MeteringCalled                              func() arwen.MeteringContext
+	StorageCalled                               func() arwen.StorageContext
+	ExecuteESDTTransferCalled                   func(destination []byte, sender []byte, tokenIdentifier []byte, nonce uint64, value *big.Int, callType vmcommon.CallType) (*vmcommon.VMOutput, uint64, error)
+	ExecuteMultiESDTTransferCalled              func(destination []byte, sender []byte, transfers []*arwen.ESDTTransfer, callType vmcommon.CallType) (*vmcommon.VMOutput, uint64, error)
+	CreateNewContractCalled                     func(input *vmcommon.ContractCreateInput) ([]byte, error)
+	ExecuteOnSameContextCalled                  func(input *vmcommon.ContractCallInput) (*arwen.AsyncContextInfo, error)
+	ExecuteOnDestContextCalled                  func(input *vmcommon.ContractCallInput) (*vmcommon.VMOutput, *arwen.AsyncContextInfo, error)
+	ExecuteOnDestContextWithESDTTransfersCalled func(input *vmcommon.ContractCallInput, transfers []*arwen.ESDTTransfer) (*vmcommon.VMOutput, *arwen.AsyncContextInfo, error)
+	GetAPIMethodsCalled                         func() *wasmer.Imports
+	GetProtocolBuiltinFunctionsCalled           func() vmcommon.FunctionNames
+	SetProtocolBuiltinFunctionsCalled           func(vmcommon.FunctionNames)
+	IsBuiltinFunctionNameCalled                 func(functionName string) bool
+	AreInSameShardCalled                        func(left []byte, right []byte) bool
 
 	RunSmartContractCallCalled   func(input *vmcommon.ContractCallInput) (vmOutput *vmcommon.VMOutput, err error)
+	RunSmartContractQueryCalled  func(input *vmcommon.ContractCallInput) (vmOutput *vmcommon.VMOutput, err error)
 	RunSmartContractCreateCalled func(input *vmcommon.ContractCreateInput) (vmOutput *vmcommon.VMOutput, err error)
 	GetGasScheduleMapCalled      func() config.GasScheduleMap
 	GasScheduleChangeCalled      func(newGasSchedule config.GasScheduleMap)
+	SetGasScheduleCalled         func(newGasSchedule config.GasScheduleMap) error
 	IsInterfaceNilCalled         func() bool
 
-	SetRuntimeContextCalled func(runtime arwen.RuntimeContext)
-	GetContextsCalled       func() (arwen.BigIntContext, arwen.BlockchainContext, arwen.MeteringContext, arwen.OutputContext, arwen.RuntimeContext, arwen.StorageContext)
+	SetRuntimeContextCalled              func(runtime arwen.RuntimeContext)
+	MigrateLegacyAsyncDataCalled         func(address []byte) (*arwen.AsyncMigrationResult, error)
+	SetAsyncTracerCalled                 func(tracer arwen.AsyncTracer)
+	RegisterDeferredCallCalled           func(call *arwen.DeferredCall) error
+	RunDeferredCallsCalled               func(address []byte) (*vmcommon.VMOutput, error)
+	GetExecutionWitnessCalled            func() *arwen.ExecutionWitness
+	GetHookCacheStatsCalled              func() *arwen.HookCacheStats
+	GetGasProfileCalled                  func() *arwen.GasProfile
+	GetExecutionProfileCalled            func() *arwen.ExecutionProfile
+	GetAsyncCallTreeCalled               func() *arwen.AsyncCallTree
+	GetTouchedAccountsCalled             func() *arwen.TouchedAccounts
+	GetStorageDiffCalled                 func() *arwen.StorageDiff
+	GetRuntimeConfigCalled               func() arwen.RuntimeConfig
+	GetDeploymentLimitsCalled            func() arwen.DeploymentLimits
+	GetTouchedStorageKeysCalled          func() *arwen.TouchedStorageKeys
+	EstimateAsyncCallGasCalled           func(caller []byte, destination []byte, data []byte, value *big.Int) (*arwen.AsyncCallGasEstimate, error)
+	RegisterBuiltinOutputProcessorCalled func(functionName string, processor arwen.BuiltinOutputProcessor) error
+	GetContextsCalled                    func() (arwen.BigIntContext, arwen.BlockchainContext, arwen.MeteringContext, arwen.OutputContext, arwen.RuntimeContext, arwen.StorageContext)
 }
 
 // GetVersion mocked method
@@ -141,6 +161,71 @@ func (vhs *VMHostStub) IsESDTFunctionsEnabled() bool {
 	return true
 }
 
+// IsMultiAsyncCallGroupsEnabled mocked method
+func (vhs *VMHostStub) IsMultiAsyncCallGroupsEnabled() bool {
+	return true
+}
+
+// IsCallbackValidationEnabled mocked method
+func (vhs *VMHostStub) IsCallbackValidationEnabled() bool {
+	return true
+}
+
+// IsRefundReceiptsEnabled mocked method
+func (vhs *VMHostStub) IsRefundReceiptsEnabled() bool {
+	return true
+}
+
+// IsStorageLoadCacheEnabled mocked method
+func (vhs *VMHostStub) IsStorageLoadCacheEnabled() bool {
+	return true
+}
+
+// IsAsyncCallsFixEnabled mocked method
+func (vhs *VMHostStub) IsAsyncCallsFixEnabled() bool {
+	return true
+}
+
+// IsFailedExecutionGasEnabled mocked method
+func (vhs *VMHostStub) IsFailedExecutionGasEnabled() bool {
+	return true
+}
+
+// IsEventLogValidationEnabled mocked method
+func (vhs *VMHostStub) IsEventLogValidationEnabled() bool {
+	return true
+}
+
+// IsReadOnlyEnforcementEnabled mocked method
+func (vhs *VMHostStub) IsReadOnlyEnforcementEnabled() bool {
+	return true
+}
+
+// IsAsyncCallGasCapEnabled mocked method
+func (vhs *VMHostStub) IsAsyncCallGasCapEnabled() bool {
+	return true
+}
+
+// IsLogEntryGasEnabled mocked method
+func (vhs *VMHostStub) IsLogEntryGasEnabled() bool {
+	return true
+}
+
+// IsGroupCallbacksEnabled mocked method
+func (vhs *VMHostStub) IsGroupCallbacksEnabled() bool {
+	return true
+}
+
+// IsAsyncValueTransferEnabled mocked method
+func (vhs *VMHostStub) IsAsyncValueTransferEnabled() bool {
+	return true
+}
+
+// IsCallbackGasEscrowEnabled mocked method
+func (vhs *VMHostStub) IsCallbackGasEscrowEnabled() bool {
+	return true
+}
+
 // Output mocked method
 func (vhs *VMHostStub) Output() arwen.OutputContext {
 	if vhs.OutputCalled != nil {
@@ -173,6 +258,14 @@ func (vhs *VMHostStub) ExecuteESDTTransfer(destination []byte, sender []byte, to
 	return nil, 0, nil
 }
 
+// ExecuteMultiESDTTransfer mocked method
+func (vhs *VMHostStub) ExecuteMultiESDTTransfer(destination []byte, sender []byte, transfers []*arwen.ESDTTransfer, callType vmcommon.CallType) (*vmcommon.VMOutput, uint64, error) {
+	if vhs.ExecuteMultiESDTTransferCalled != nil {
+		return vhs.ExecuteMultiESDTTransferCalled(destination, sender, transfers, callType)
+	}
+	return nil, 0, nil
+}
+
 // CreateNewContract mocked method
 func (vhs *VMHostStub) CreateNewContract(input *vmcommon.ContractCreateInput) ([]byte, error) {
 	if vhs.CreateNewContractCalled != nil {
@@ -197,6 +290,14 @@ func (vhs *VMHostStub) ExecuteOnDestContext(input *vmcommon.ContractCallInput) (
 	return nil, nil, nil
 }
 
+// ExecuteOnDestContextWithESDTTransfers mocked method
+func (vhs *VMHostStub) ExecuteOnDestContextWithESDTTransfers(input *vmcommon.ContractCallInput, transfers []*arwen.ESDTTransfer) (*vmcommon.VMOutput, *arwen.AsyncContextInfo, error) {
+	if vhs.ExecuteOnDestContextWithESDTTransfersCalled != nil {
+		return vhs.ExecuteOnDestContextWithESDTTransfersCalled(input, transfers)
+	}
+	return nil, nil, nil
+}
+
 // AreInSameShard mocked method
 func (vhs *VMHostStub) AreInSameShard(left []byte, right []byte) bool {
 	if vhs.AreInSameShardCalled != nil {
@@ -252,6 +353,14 @@ func (vhs *VMHostStub) RunSmartContractCall(input *vmcommon.ContractCallInput) (
 	return nil, nil
 }
 
+// RunSmartContractQuery mocked method
+func (vhs *VMHostStub) RunSmartContractQuery(input *vmcommon.ContractCallInput) (vmOutput *vmcommon.VMOutput, err error) {
+	if vhs.RunSmartContractQueryCalled != nil {
+		return vhs.RunSmartContractQueryCalled(input)
+	}
+	return nil, nil
+}
+
 // RunSmartContractCreate mocked method
 func (vhs *VMHostStub) RunSmartContractCreate(input *vmcommon.ContractCreateInput) (vmOutput *vmcommon.VMOutput, err error) {
 	if vhs.RunSmartContractCreateCalled != nil {
@@ -267,6 +376,14 @@ func (vhs *VMHostStub) GasScheduleChange(newGasSchedule config.GasScheduleMap) {
 	}
 }
 
+// SetGasSchedule mocked method
+func (vhs *VMHostStub) SetGasSchedule(newGasSchedule config.GasScheduleMap) error {
+	if vhs.SetGasScheduleCalled != nil {
+		return vhs.SetGasScheduleCalled(newGasSchedule)
+	}
+	return nil
+}
+
 // IsInterfaceNil mocked method
 func (vhs *VMHostStub) IsInterfaceNil() bool {
 	if vhs.IsInterfaceNilCalled != nil {
@@ -290,6 +407,133 @@ func (vhs *VMHostStub) GetContexts() (
 	return nil, nil, nil, nil, nil, nil
 }
 
+// MigrateLegacyAsyncData mocked method
+func (vhs *VMHostStub) MigrateLegacyAsyncData(address []byte) (*arwen.AsyncMigrationResult, error) {
+	if vhs.MigrateLegacyAsyncDataCalled != nil {
+		return vhs.MigrateLegacyAsyncDataCalled(address)
+	}
+	return nil, nil
+}
+
+// SetAsyncTracer mocked method
+func (vhs *VMHostStub) SetAsyncTracer(tracer arwen.AsyncTracer) {
+	if vhs.SetAsyncTracerCalled != nil {
+		vhs.SetAsyncTracerCalled(tracer)
+	}
+}
+
+// RegisterDeferredCall mocked method
+func (vhs *VMHostStub) RegisterDeferredCall(call *arwen.DeferredCall) error {
+	if vhs.RegisterDeferredCallCalled != nil {
+		return vhs.RegisterDeferredCallCalled(call)
+	}
+	return nil
+}
+
+// RunDeferredCalls mocked method
+func (vhs *VMHostStub) RunDeferredCalls(address []byte) (*vmcommon.VMOutput, error) {
+	if vhs.RunDeferredCallsCalled != nil {
+		return vhs.RunDeferredCallsCalled(address)
+	}
+	return nil, nil
+}
+
+// GetExecutionWitness mocked method
+func (vhs *VMHostStub) GetExecutionWitness() *arwen.ExecutionWitness {
+	if vhs.GetExecutionWitnessCalled != nil {
+		return vhs.GetExecutionWitnessCalled()
+	}
+	return nil
+}
+
+// GetGasProfile mocked method
+func (vhs *VMHostStub) GetGasProfile() *arwen.GasProfile {
+	if vhs.GetGasProfileCalled != nil {
+		return vhs.GetGasProfileCalled()
+	}
+	return nil
+}
+
+// GetExecutionProfile mocked method
+func (vhs *VMHostStub) GetExecutionProfile() *arwen.ExecutionProfile {
+	if vhs.GetExecutionProfileCalled != nil {
+		return vhs.GetExecutionProfileCalled()
+	}
+	return nil
+}
+
+// GetAsyncCallTree mocked method
+func (vhs *VMHostStub) GetAsyncCallTree() *arwen.AsyncCallTree {
+	if vhs.GetAsyncCallTreeCalled != nil {
+		return vhs.GetAsyncCallTreeCalled()
+	}
+	return nil
+}
+
+// GetHookCacheStats mocked method
+func (vhs *VMHostStub) GetHookCacheStats() *arwen.HookCacheStats {
+	if vhs.GetHookCacheStatsCalled != nil {
+		return vhs.GetHookCacheStatsCalled()
+	}
+	return nil
+}
+
+// GetTouchedAccounts mocked method
+func (vhs *VMHostStub) GetTouchedAccounts() *arwen.TouchedAccounts {
+	if vhs.GetTouchedAccountsCalled != nil {
+		return vhs.GetTouchedAccountsCalled()
+	}
+	return nil
+}
+
+// GetDeploymentLimits mocked method
+func (vhs *VMHostStub) GetDeploymentLimits() arwen.DeploymentLimits {
+	if vhs.GetDeploymentLimitsCalled != nil {
+		return vhs.GetDeploymentLimitsCalled()
+	}
+	return arwen.DeploymentLimits{}
+}
+
+// GetRuntimeConfig mocked method
+func (vhs *VMHostStub) GetRuntimeConfig() arwen.RuntimeConfig {
+	if vhs.GetRuntimeConfigCalled != nil {
+		return vhs.GetRuntimeConfigCalled()
+	}
+	return arwen.RuntimeConfig{}
+}
+
+// GetStorageDiff mocked method
+func (vhs *VMHostStub) GetStorageDiff() *arwen.StorageDiff {
+	if vhs.GetStorageDiffCalled != nil {
+		return vhs.GetStorageDiffCalled()
+	}
+	return nil
+}
+
+// GetTouchedStorageKeys mocked method
+func (vhs *VMHostStub) GetTouchedStorageKeys() *arwen.TouchedStorageKeys {
+	if vhs.GetTouchedStorageKeysCalled != nil {
+		return vhs.GetTouchedStorageKeysCalled()
+	}
+	return nil
+}
+
+// EstimateAsyncCallGas mocked method
+func (vhs *VMHostStub) EstimateAsyncCallGas(caller []byte, destination []byte, data []byte, value *big.Int) (*arwen.AsyncCallGasEstimate, error) {
+	if vhs.EstimateAsyncCallGasCalled != nil {
+		return vhs.EstimateAsyncCallGasCalled(caller, destination, data, value)
+	}
+	return nil, nil
+}
+
+// RegisterBuiltinOutputProcessor mocked method
+func (vhs *VMHostStub) RegisterBuiltinOutputProcessor(functionName string, processor arwen.BuiltinOutputProcessor) error {
+	if vhs.RegisterBuiltinOutputProcessorCalled != nil {
+		return vhs.RegisterBuiltinOutputProcessorCalled(functionName, processor)
+	}
+	return nil
+}
+
 // SetRuntimeContext mocked method
 func (vhs *VMHostStub) SetRuntimeContext(runtime arwen.RuntimeContext) {
 	if vhs.SetRuntimeContextCalled != nil {
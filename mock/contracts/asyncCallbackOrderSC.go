@@ -0,0 +1,95 @@
+package contracts
+
+import (
+	"math/big"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
+	mock "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/context"
+	test "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/testcommon"
+	"github.com/stretchr/testify/require"
+)
+
+// AsyncCallbackOrderTestConfig is configuration for async callback order tests
+type AsyncCallbackOrderTestConfig struct {
+	AsyncCallBaseTestConfig
+	GasProvidedToChild uint64
+	CallbackOrder      arwen.AsyncCallbackOrder
+}
+
+// AsyncCallbackOrderGroups are the groups of async calls registered by PerformAsyncCallbackOrderParentMock
+var AsyncCallbackOrderGroups = []struct {
+	Identifier string
+	Calls      []string
+}{
+	{Identifier: "second", Calls: []string{"s1"}},
+	{Identifier: "first", Calls: []string{"f1", "f2", "f3"}},
+}
+
+// PerformAsyncCallbackOrderParentMock is an exposed mock contract method
+func PerformAsyncCallbackOrderParentMock(instanceMock *mock.InstanceMock, config interface{}) {
+	testConfig := config.(*AsyncCallbackOrderTestConfig)
+	instanceMock.AddMockMethod("performAsyncCallbackOrder", func() *mock.InstanceMock {
+		host := instanceMock.Host
+		instance := mock.GetMockInstance(host)
+		t := instance.T
+
+		host.Metering().UseGas(testConfig.GasUsedByParent)
+
+		for _, group := range AsyncCallbackOrderGroups {
+			for _, callIdentifier := range group.Calls {
+				err := host.Runtime().AddAsyncContextCall([]byte(group.Identifier), &arwen.AsyncGeneratedCall{
+					Destination:     test.ChildAddress,
+					Data:            arwen.EncodeCallData("recordLog", [][]byte{[]byte(callIdentifier)}),
+					ValueBytes:      big.NewInt(0).Bytes(),
+					SuccessCallback: "appendToLog",
+					ErrorCallback:   "appendToLog",
+					ProvidedGas:     testConfig.GasProvidedToChild,
+				})
+				require.Nil(t, err)
+			}
+
+			asyncContext, err := host.Runtime().GetAsyncContext([]byte(group.Identifier))
+			require.Nil(t, err)
+			asyncContext.CallbackOrder = testConfig.CallbackOrder
+		}
+
+		return instance
+	})
+}
+
+// AppendToLogParentMock is an exposed mock contract method
+func AppendToLogParentMock(instanceMock *mock.InstanceMock, config interface{}) {
+	testConfig := config.(*AsyncCallbackOrderTestConfig)
+	instanceMock.AddMockMethod("appendToLog", func() *mock.InstanceMock {
+		host := instanceMock.Host
+		instance := mock.GetMockInstance(host)
+
+		host.Metering().UseGas(testConfig.GasUsedByCallback)
+
+		arguments := host.Runtime().Arguments()
+		log := host.Storage().GetStorage([]byte("log"))
+		log = append(log, arguments[1]...)
+		log = append(log, ';')
+		_, _ = host.Storage().SetStorage([]byte("log"), log)
+
+		return instance
+	})
+}
+
+// RecordLogChildMock is an exposed mock contract method
+func RecordLogChildMock(instanceMock *mock.InstanceMock, config interface{}) {
+	testConfig := config.(*AsyncCallbackOrderTestConfig)
+	instanceMock.AddMockMethod("recordLog", func() *mock.InstanceMock {
+		host := instanceMock.Host
+		instance := mock.GetMockInstance(host)
+
+		host.Metering().UseGas(testConfig.GasUsedByChild)
+
+		callIdentifier := host.Runtime().Arguments()[0]
+		parentLog := host.Storage().GetStorageFromAddress(test.ParentAddress, []byte("log"))
+		_, _ = host.Storage().SetStorage(append([]byte("seenBy_"), callIdentifier...), parentLog)
+		host.Output().Finish(callIdentifier)
+
+		return instance
+	})
+}
@@ -0,0 +1,78 @@
+package contracts
+
+import (
+	"math/big"
+
+	mock "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/context"
+	test "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/testcommon"
+	"github.com/stretchr/testify/require"
+)
+
+// AsyncCallbackOutputTestConfig is configuration for the tests of the merge of the output of callbacks
+type AsyncCallbackOutputTestConfig struct {
+	AsyncCallBaseTestConfig
+	FailCallback bool
+}
+
+// writeOutputInMockContract leaves the same kinds of traces in the output of each contract
+func writeOutputInMockContract(instance *mock.InstanceMock, name string) {
+	host := instance.Host
+
+	_, _ = host.Storage().SetStorage([]byte("writtenBy_"+name), []byte("yes"))
+	_, _ = host.Storage().SetStorage([]byte("lastWriter"), []byte(name))
+	host.Output().WriteLog(host.Runtime().GetSCAddress(), [][]byte{[]byte(name)}, []byte(name+" data"))
+	host.Output().Finish([]byte(name))
+}
+
+// PerformAsyncWithOutputParentMock is an exposed mock contract method, which
+// writes to its output before performing an async call to the child
+func PerformAsyncWithOutputParentMock(instanceMock *mock.InstanceMock, config interface{}) {
+	testConfig := config.(*AsyncCallbackOutputTestConfig)
+	instanceMock.AddMockMethod("performAsyncWithOutput", func() *mock.InstanceMock {
+		host := instanceMock.Host
+		instance := mock.GetMockInstance(host)
+		t := instance.T
+
+		host.Metering().UseGas(testConfig.GasUsedByParent)
+		writeOutputInMockContract(instance, "parent")
+
+		err := host.Runtime().ExecuteAsyncCall(test.ChildAddress, []byte("produceOutput"), big.NewInt(0).Bytes())
+		require.Nil(t, err)
+
+		return instance
+	})
+}
+
+// ProduceOutputChildMock is an exposed mock contract method, which is the
+// destination of the async call; it writes to its output
+func ProduceOutputChildMock(instanceMock *mock.InstanceMock, config interface{}) {
+	testConfig := config.(*AsyncCallbackOutputTestConfig)
+	instanceMock.AddMockMethod("produceOutput", func() *mock.InstanceMock {
+		host := instanceMock.Host
+		instance := mock.GetMockInstance(host)
+
+		host.Metering().UseGas(testConfig.GasUsedByChild)
+		writeOutputInMockContract(instance, "child")
+
+		return instance
+	})
+}
+
+// CallBackWithOutputParentMock is an exposed mock contract method, which is
+// the callback of the async call; it writes to its output, then fails if so configured
+func CallBackWithOutputParentMock(instanceMock *mock.InstanceMock, config interface{}) {
+	testConfig := config.(*AsyncCallbackOutputTestConfig)
+	instanceMock.AddMockMethod("callBack", func() *mock.InstanceMock {
+		host := instanceMock.Host
+		instance := mock.GetMockInstance(host)
+
+		host.Metering().UseGas(testConfig.GasUsedByCallback)
+		writeOutputInMockContract(instance, "callback")
+
+		if testConfig.FailCallback {
+			host.Runtime().SignalUserError("callback failed")
+		}
+
+		return instance
+	})
+}
@@ -0,0 +1,98 @@
+package contracts
+
+import (
+	"math/big"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
+	mock "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/context"
+	test "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/testcommon"
+	"github.com/ElrondNetwork/elrond-go/core/vmcommon"
+	"github.com/stretchr/testify/require"
+)
+
+// AsyncGroupCallbackTestConfig is configuration for the tests of the callbacks of AsyncContext groups
+type AsyncGroupCallbackTestConfig struct {
+	AsyncCallBaseTestConfig
+	GasProvidedToChild        uint64
+	TransferFromGroupCallback int64
+	FailGroupCallback         bool
+}
+
+// PerformAsyncGroupParentMock is an exposed mock contract method
+func PerformAsyncGroupParentMock(instanceMock *mock.InstanceMock, config interface{}) {
+	testConfig := config.(*AsyncGroupCallbackTestConfig)
+	instanceMock.AddMockMethod("performAsyncGroup", func() *mock.InstanceMock {
+		host := instanceMock.Host
+		instance := mock.GetMockInstance(host)
+		t := instance.T
+
+		host.Metering().UseGas(testConfig.GasUsedByParent)
+
+		err := host.Runtime().AddAsyncContextCall([]byte("group"), &arwen.AsyncGeneratedCall{
+			Destination:     test.ChildAddress,
+			Data:            []byte("storeInGroup"),
+			ValueBytes:      big.NewInt(0).Bytes(),
+			SuccessCallback: "groupCallCompleted",
+			ErrorCallback:   "groupCallCompleted",
+			ProvidedGas:     testConfig.GasProvidedToChild,
+		})
+		require.Nil(t, err)
+
+		asyncContext, err := host.Runtime().GetAsyncContext([]byte("group"))
+		require.Nil(t, err)
+		asyncContext.Callback = "groupCallback"
+
+		return instance
+	})
+}
+
+// GroupCallCompletedParentMock is an exposed mock contract method
+func GroupCallCompletedParentMock(instanceMock *mock.InstanceMock, config interface{}) {
+	testConfig := config.(*AsyncGroupCallbackTestConfig)
+	instanceMock.AddMockMethod("groupCallCompleted", func() *mock.InstanceMock {
+		host := instanceMock.Host
+		instance := mock.GetMockInstance(host)
+
+		host.Metering().UseGas(testConfig.GasUsedByCallback)
+		_, _ = host.Storage().SetStorage([]byte("callCompleted"), []byte("yes"))
+
+		return instance
+	})
+}
+
+// GroupCallbackParentMock is an exposed mock contract method
+func GroupCallbackParentMock(instanceMock *mock.InstanceMock, config interface{}) {
+	testConfig := config.(*AsyncGroupCallbackTestConfig)
+	instanceMock.AddMockMethod("groupCallback", func() *mock.InstanceMock {
+		host := instanceMock.Host
+		instance := mock.GetMockInstance(host)
+		t := instance.T
+
+		host.Metering().UseGas(testConfig.GasUsedByCallback)
+		_, _ = host.Storage().SetStorage([]byte("groupCallback"), host.Runtime().Arguments()[0])
+
+		value := big.NewInt(testConfig.TransferFromGroupCallback)
+		err := host.Output().Transfer(test.ThirdPartyAddress, test.ParentAddress, 0, 0, value, nil, vmcommon.DirectCall)
+		require.Nil(t, err)
+
+		if testConfig.FailGroupCallback {
+			host.Runtime().SignalUserError("group callback failed")
+		}
+
+		return instance
+	})
+}
+
+// StoreInGroupChildMock is an exposed mock contract method
+func StoreInGroupChildMock(instanceMock *mock.InstanceMock, config interface{}) {
+	testConfig := config.(*AsyncGroupCallbackTestConfig)
+	instanceMock.AddMockMethod("storeInGroup", func() *mock.InstanceMock {
+		host := instanceMock.Host
+		instance := mock.GetMockInstance(host)
+
+		host.Metering().UseGas(testConfig.GasUsedByChild)
+		_, _ = host.Storage().SetStorage([]byte("storedByChild"), []byte("yes"))
+
+		return instance
+	})
+}
@@ -0,0 +1,59 @@
+package contracts
+
+import (
+	"math/big"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
+	mock "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/context"
+	"github.com/stretchr/testify/require"
+)
+
+// NestedAsyncCallGroup is the group of the async calls registered by NestAsyncCallMock
+const NestedAsyncCallGroup = "nested"
+
+// NestAsyncCallMock is an exposed mock contract method, which registers an
+// async call to the contract given as its first argument, passing on the
+// remaining arguments; the destination thus registers the next async call,
+// nesting the async calls along the given path of contracts. Half of the gas
+// left is provided to the async call.
+func NestAsyncCallMock(instanceMock *mock.InstanceMock, config interface{}) {
+	testConfig := config.(*AsyncCallBaseTestConfig)
+	instanceMock.AddMockMethod("nestAsyncCall", func() *mock.InstanceMock {
+		host := instanceMock.Host
+		instance := mock.GetMockInstance(host)
+		t := instance.T
+
+		host.Metering().UseGas(testConfig.GasUsedByChild)
+
+		arguments := host.Runtime().Arguments()
+		if len(arguments) == 0 {
+			return instance
+		}
+
+		err := host.Runtime().AddAsyncContextCall([]byte(NestedAsyncCallGroup), &arwen.AsyncGeneratedCall{
+			Destination:     arguments[0],
+			Data:            arwen.EncodeCallData("nestAsyncCall", arguments[1:]),
+			ValueBytes:      big.NewInt(0).Bytes(),
+			SuccessCallback: "nestedCallBack",
+			ErrorCallback:   "nestedCallBack",
+			ProvidedGas:     host.Metering().GasLeft() / 2,
+		})
+		require.Nil(t, err)
+
+		return instance
+	})
+}
+
+// NestedCallBackMock is an exposed mock contract method, which is the
+// callback of the async calls registered by NestAsyncCallMock
+func NestedCallBackMock(instanceMock *mock.InstanceMock, config interface{}) {
+	testConfig := config.(*AsyncCallBaseTestConfig)
+	instanceMock.AddMockMethod("nestedCallBack", func() *mock.InstanceMock {
+		host := instanceMock.Host
+		instance := mock.GetMockInstance(host)
+
+		host.Metering().UseGas(testConfig.GasUsedByCallback)
+
+		return instance
+	})
+}
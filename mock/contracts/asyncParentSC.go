@@ -58,6 +58,55 @@ func PerformAsyncCallParentMock(instanceMock *mock.InstanceMock, config interfac
 	})
 }
 
+// PerformValueOnlyAsyncCallParentMock is an exposed mock contract method
+func PerformValueOnlyAsyncCallParentMock(instanceMock *mock.InstanceMock, config interface{}) {
+	testConfig := config.(*AsyncCallTestConfig)
+	instanceMock.AddMockMethod("performValueOnlyAsyncCall", func() *mock.InstanceMock {
+		host := instanceMock.Host
+		instance := mock.GetMockInstance(host)
+		t := instance.T
+		host.Metering().UseGas(testConfig.GasUsedByParent)
+
+		value := big.NewInt(testConfig.TransferFromParentToChild).Bytes()
+		err := host.Runtime().ExecuteAsyncCall(test.ChildAddress, nil, value)
+		require.Nil(t, err)
+
+		return instance
+	})
+}
+
+// CreateEscrowedAsyncCallParentMock is an exposed mock contract method which
+// registers an async call the way createAsyncCall does, escrowing the gas for its callback
+func CreateEscrowedAsyncCallParentMock(instanceMock *mock.InstanceMock, config interface{}) {
+	testConfig := config.(*AsyncCallTestConfig)
+	instanceMock.AddMockMethod("createEscrowedAsyncCall", func() *mock.InstanceMock {
+		host := instanceMock.Host
+		instance := mock.GetMockInstance(host)
+		t := instance.T
+
+		gasLocked := uint64(0)
+		if host.IsCallbackGasEscrowEnabled() {
+			var err error
+			gasLocked, err = host.Metering().EscrowGasForCallback(true)
+			require.Nil(t, err)
+		}
+
+		err := host.Runtime().AddAsyncContextCall([]byte("group"), &arwen.AsyncGeneratedCall{
+			Destination:     test.ChildAddress,
+			Data:            []byte(AsyncChildFunction),
+			ValueBytes:      big.NewInt(testConfig.TransferFromParentToChild).Bytes(),
+			SuccessCallback: "callBack",
+			ErrorCallback:   "callBack",
+			GasLocked:       gasLocked,
+		})
+		require.Nil(t, err)
+
+		host.Metering().UseGas(testConfig.GasUsedByParent)
+
+		return instance
+	})
+}
+
 // SimpleCallbackMock is an exposed mock contract method
 func SimpleCallbackMock(instanceMock *mock.InstanceMock, config interface{}) {
 	testConfig := config.(*AsyncCallTestConfig)
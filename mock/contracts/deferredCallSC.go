@@ -0,0 +1,36 @@
+package contracts
+
+import (
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
+	mock "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/context"
+)
+
+// DeferredCallTestConfig is configuration for deferred call tests
+type DeferredCallTestConfig struct {
+	GasProvided uint64
+	GasLimit    uint64
+	TargetRound uint64
+	TargetEpoch uint32
+}
+
+// RegisterDeferredCallMock is an exposed mock contract method
+func RegisterDeferredCallMock(instanceMock *mock.InstanceMock, config interface{}) {
+	testConfig := config.(*DeferredCallTestConfig)
+	instanceMock.AddMockMethod("registerDeferredCall", func() *mock.InstanceMock {
+		host := instanceMock.Host
+		instance := mock.GetMockInstance(host)
+
+		err := host.RegisterDeferredCall(&arwen.DeferredCall{
+			Function:    "deferredFunction",
+			Arguments:   host.Runtime().Arguments(),
+			GasLimit:    testConfig.GasLimit,
+			TargetRound: testConfig.TargetRound,
+			TargetEpoch: testConfig.TargetEpoch,
+		})
+		if err != nil {
+			host.Runtime().FailExecution(err)
+		}
+
+		return instance
+	})
+}
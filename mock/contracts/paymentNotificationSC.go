@@ -0,0 +1,55 @@
+package contracts
+
+import (
+	"math/big"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
+	mock "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/context"
+	test "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/testcommon"
+	"github.com/stretchr/testify/require"
+)
+
+// PaymentNotificationTestConfig is configuration for the tests of the notifications of received payments
+type PaymentNotificationTestConfig struct {
+	GasUsedByParent      uint64
+	GasUsedByHook        uint64
+	ESDTTokensToTransfer uint64
+}
+
+// TransferESDTToChildParentMock is an exposed mock contract method, which
+// transfers ESDT tokens to the child without calling any of its functions
+func TransferESDTToChildParentMock(instanceMock *mock.InstanceMock, config interface{}) {
+	testConfig := config.(*PaymentNotificationTestConfig)
+	instanceMock.AddMockMethod("transferESDTToChild", func() *mock.InstanceMock {
+		host := instanceMock.Host
+		instance := mock.GetMockInstance(host)
+		t := instance.T
+
+		host.Metering().UseGas(testConfig.GasUsedByParent)
+
+		value := big.NewInt(0).SetUint64(testConfig.ESDTTokensToTransfer)
+		_, err := host.Output().TransferESDT(test.ChildAddress, test.ParentAddress, test.ESDTTestTokenName, 0, value, nil)
+		require.Nil(t, err)
+
+		return instance
+	})
+}
+
+// OnPaymentReceivedChildMock is an exposed mock contract method, which stores
+// the arguments of the notification of a received payment
+func OnPaymentReceivedChildMock(instanceMock *mock.InstanceMock, config interface{}) {
+	testConfig := config.(*PaymentNotificationTestConfig)
+	instanceMock.AddMockMethod(arwen.PaymentReceivedFunctionName, func() *mock.InstanceMock {
+		host := instanceMock.Host
+		instance := mock.GetMockInstance(host)
+
+		host.Metering().UseGas(testConfig.GasUsedByHook)
+
+		arguments := host.Runtime().Arguments()
+		_, _ = host.Storage().SetStorage([]byte("paymentSender"), arguments[0])
+		_, _ = host.Storage().SetStorage([]byte("paymentToken"), arguments[1])
+		_, _ = host.Storage().SetStorage([]byte("paymentValue"), arguments[3])
+
+		return instance
+	})
+}
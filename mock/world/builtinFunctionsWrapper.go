@@ -1,6 +1,7 @@
 package worldmock
 
 import (
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
 	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/config"
 	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock"
 	"github.com/ElrondNetwork/elrond-go/core/vmcommon"
@@ -61,6 +62,11 @@ func NewBuiltinFunctionsWrapper(
 		World:           world,
 	}
 
+	err = builtinFuncs.Add(arwen.BuiltInFunctionMultiESDTNFTTransfer, &multiESDTNFTTransfer{wrapper: builtinFuncsWrapper})
+	if err != nil {
+		return nil, err
+	}
+
 	return builtinFuncsWrapper, nil
 }
 
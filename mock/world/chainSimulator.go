@@ -0,0 +1,222 @@
+package worldmock
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/binary"
+	"math/big"
+
+	"github.com/ElrondNetwork/elrond-go/core/vmcommon"
+)
+
+var _ vmcommon.BlockchainHook = (*ChainSimulator)(nil)
+
+// DefaultRoundDuration is the duration of a round of the ChainSimulator, in seconds
+const DefaultRoundDuration = uint64(6)
+
+// DefaultRoundsPerEpoch is the number of rounds in an epoch of the ChainSimulator
+const DefaultRoundsPerEpoch = uint64(14400)
+
+// ChainSimulator is a MockWorld which also behaves like a chain: the accounts
+// are assigned to shards by their address, the blocks progress with their
+// nonce, round, epoch, timestamp and random seed, and the output of the
+// executions is committed to the accounts. It is meant to be shared by the
+// tests, the fuzzers and arwendebug, instead of each of them configuring a
+// MockWorld by hand.
+type ChainSimulator struct {
+	*MockWorld
+	NumShards      uint32
+	RoundDuration  uint64
+	RoundsPerEpoch uint64
+}
+
+// NewChainSimulator creates a ChainSimulator with the given number of shards,
+// at its genesis block
+func NewChainSimulator(numShards uint32) *ChainSimulator {
+	return NewChainSimulatorWithWorld(NewMockWorld(), numShards)
+}
+
+// NewChainSimulatorWithWorld creates a ChainSimulator with the given number of
+// shards over an existing MockWorld, such as the one of a mandos executor; the
+// world is moved to the genesis block if it has no block hashes yet
+func NewChainSimulatorWithWorld(world *MockWorld, numShards uint32) *ChainSimulator {
+	if numShards == 0 {
+		numShards = 1
+	}
+
+	simulator := &ChainSimulator{
+		MockWorld:      world,
+		NumShards:      numShards,
+		RoundDuration:  DefaultRoundDuration,
+		RoundsPerEpoch: DefaultRoundsPerEpoch,
+	}
+	if len(simulator.Blockhashes) == 0 {
+		simulator.CurrentBlockInfo = &BlockInfo{
+			RandomSeed: nextRandomSeed(nil, 0),
+		}
+		simulator.Blockhashes = [][]byte{blockHash(simulator.CurrentBlockInfo)}
+	}
+
+	return simulator
+}
+
+// ComputeShardOfAddress returns the shard to which the given address is
+// assigned, by its last byte
+func (simulator *ChainSimulator) ComputeShardOfAddress(address []byte) uint32 {
+	if simulator.NumShards <= 1 || len(address) == 0 {
+		return 0
+	}
+
+	return uint32(address[len(address)-1]) % simulator.NumShards
+}
+
+// CreateUserAccount creates an account with the given balance, in the shard
+// of its address
+func (simulator *ChainSimulator) CreateUserAccount(address []byte, balance *big.Int) *Account {
+	account := simulator.AcctMap.CreateAccount(address)
+	account.Balance = big.NewInt(0).Set(balance)
+	account.ShardID = simulator.ComputeShardOfAddress(address)
+
+	return account
+}
+
+// CreateContractAccount creates a contract with the given owner, code and
+// balance, in the shard of its address
+func (simulator *ChainSimulator) CreateContractAccount(owner []byte, address []byte, code []byte, balance *big.Int) *Account {
+	account := simulator.AcctMap.CreateSmartContractAccount(owner, address, code)
+	account.Balance = big.NewInt(0).Set(balance)
+	account.ShardID = simulator.ComputeShardOfAddress(address)
+
+	return account
+}
+
+// SetESDTBalance sets the balance of the given fungible ESDT token of the
+// given account, creating the account if it does not exist
+func (simulator *ChainSimulator) SetESDTBalance(address []byte, tokenIdentifier []byte, balance *big.Int) error {
+	account := simulator.AcctMap.GetAccount(address)
+	if account == nil {
+		account = simulator.CreateUserAccount(address, big.NewInt(0))
+	}
+
+	return account.SetTokenBalance(MakeTokenKey(tokenIdentifier, 0), balance)
+}
+
+// GetESDTBalance returns the balance of the given fungible ESDT token of the
+// given account, which is 0 if the account does not exist
+func (simulator *ChainSimulator) GetESDTBalance(address []byte, tokenIdentifier []byte) (*big.Int, error) {
+	account := simulator.AcctMap.GetAccount(address)
+	if account == nil {
+		return big.NewInt(0), nil
+	}
+
+	return account.GetTokenBalance(MakeTokenKey(tokenIdentifier, 0))
+}
+
+// NextBlock moves the ChainSimulator to the next block, whose round follows
+// the current one
+func (simulator *ChainSimulator) NextBlock() {
+	simulator.SkipRounds(1)
+}
+
+// AdvanceBlocks moves the ChainSimulator forward by the given number of blocks
+func (simulator *ChainSimulator) AdvanceBlocks(numBlocks uint64) {
+	for i := uint64(0); i < numBlocks; i++ {
+		simulator.NextBlock()
+	}
+}
+
+// SkipRounds moves the ChainSimulator to the next block, proposed the given
+// number of rounds after the current one, as if the rounds in between had no
+// block
+func (simulator *ChainSimulator) SkipRounds(numRounds uint64) {
+	if numRounds == 0 {
+		return
+	}
+
+	current := simulator.CurrentBlockInfo
+	previous := *current
+	simulator.PreviousBlockInfo = &previous
+
+	round := current.BlockRound + numRounds
+	next := &BlockInfo{
+		BlockNonce:     current.BlockNonce + 1,
+		BlockRound:     round,
+		BlockEpoch:     current.BlockEpoch,
+		BlockTimestamp: current.BlockTimestamp + numRounds*simulator.RoundDuration,
+		RandomSeed:     nextRandomSeed(current.RandomSeed, current.BlockNonce+1),
+	}
+	if simulator.RoundsPerEpoch > 0 {
+		next.BlockEpoch = uint32(round / simulator.RoundsPerEpoch)
+	}
+
+	simulator.CurrentBlockInfo = next
+	simulator.Blockhashes = append([][]byte{blockHash(next)}, simulator.Blockhashes...)
+}
+
+// Commit applies the given VMOutput to the accounts of the ChainSimulator
+func (simulator *ChainSimulator) Commit(vmOutput *vmcommon.VMOutput) error {
+	for address := range vmOutput.OutputAccounts {
+		if simulator.AcctMap.GetAccount([]byte(address)) == nil {
+			simulator.CreateUserAccount([]byte(address), big.NewInt(0))
+		}
+	}
+
+	return simulator.UpdateAccounts(vmOutput.OutputAccounts, vmOutput.DeletedAccounts)
+}
+
+// GetShardOfAddress returns the shard of the given account, or the shard to
+// which its address is assigned, if it does not exist
+func (simulator *ChainSimulator) GetShardOfAddress(address []byte) uint32 {
+	account := simulator.AcctMap.GetAccount(address)
+	if account == nil {
+		return simulator.ComputeShardOfAddress(address)
+	}
+
+	return account.ShardID
+}
+
+// NumberOfShards returns the number of shards of the ChainSimulator
+func (simulator *ChainSimulator) NumberOfShards() uint32 {
+	return simulator.NumShards
+}
+
+// ComputeId returns the shard of the given address
+func (simulator *ChainSimulator) ComputeId(address []byte) uint32 {
+	return simulator.GetShardOfAddress(address)
+}
+
+// SameShard returns true if the given addresses are in the same shard
+func (simulator *ChainSimulator) SameShard(firstAddress []byte, secondAddress []byte) bool {
+	return simulator.GetShardOfAddress(firstAddress) == simulator.GetShardOfAddress(secondAddress)
+}
+
+// nextRandomSeed derives the random seed of the block with the given nonce
+// from the random seed of the block before it
+func nextRandomSeed(previousSeed *[48]byte, nonce uint64) *[48]byte {
+	data := make([]byte, 0, 48+8)
+	if previousSeed != nil {
+		data = append(data, previousSeed[:]...)
+	}
+	data = append(data, uint64Bytes(nonce)...)
+
+	digest := sha512.Sum512(data)
+	var seed [48]byte
+	copy(seed[:], digest[:48])
+	return &seed
+}
+
+func blockHash(block *BlockInfo) []byte {
+	data := uint64Bytes(block.BlockNonce)
+	if block.RandomSeed != nil {
+		data = append(data, block.RandomSeed[:]...)
+	}
+
+	hash := sha256.Sum256(data)
+	return hash[:]
+}
+
+func uint64Bytes(value uint64) []byte {
+	bytes := make([]byte, 8)
+	binary.BigEndian.PutUint64(bytes, value)
+	return bytes
+}
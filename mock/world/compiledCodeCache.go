@@ -0,0 +1,43 @@
+package worldmock
+
+import "sync"
+
+// CompiledCodeCache holds compiled contract code, keyed by code hash.
+// It can be shared by several MockWorlds running concurrently, so that each
+// contract is only compiled once, as long as they all use the same opcode costs.
+type CompiledCodeCache struct {
+	mutCodes sync.RWMutex
+	codes    map[string][]byte
+}
+
+// NewCompiledCodeCache creates an empty CompiledCodeCache.
+func NewCompiledCodeCache() *CompiledCodeCache {
+	return &CompiledCodeCache{
+		codes: make(map[string][]byte),
+	}
+}
+
+// Get returns the compiled code of the given code hash, if present.
+func (c *CompiledCodeCache) Get(codeHash []byte) (bool, []byte) {
+	c.mutCodes.RLock()
+	defer c.mutCodes.RUnlock()
+
+	code, found := c.codes[string(codeHash)]
+	return found, code
+}
+
+// Put saves the compiled code of the given code hash.
+func (c *CompiledCodeCache) Put(codeHash []byte, code []byte) {
+	c.mutCodes.Lock()
+	defer c.mutCodes.Unlock()
+
+	c.codes[string(codeHash)] = code
+}
+
+// Len returns the number of compiled codes in the cache.
+func (c *CompiledCodeCache) Len() int {
+	c.mutCodes.RLock()
+	defer c.mutCodes.RUnlock()
+
+	return len(c.codes)
+}
@@ -0,0 +1,95 @@
+package worldmock
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/ElrondNetwork/elrond-go/core/vmcommon"
+	"github.com/ElrondNetwork/elrond-go/data/state"
+	"github.com/ElrondNetwork/elrond-go/process"
+)
+
+// ErrInvalidCustomBuiltinFunction signals that a custom builtin function
+// without a name or without a handler was registered
+var ErrInvalidCustomBuiltinFunction = errors.New("invalid custom builtin function")
+
+// CustomBuiltinFunctionHandler processes a call to a custom builtin function.
+// It may return a nil VMOutput, meaning success without any effects; the gas
+// remaining of the VMOutput is always set by the BuiltinFunctionsWrapper.
+type CustomBuiltinFunctionHandler func(world *MockWorld, input *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error)
+
+// CustomBuiltinFunction describes a builtin function defined by a test, which
+// the MockWorld processes like the builtin functions of the protocol
+type CustomBuiltinFunction struct {
+	Name    string
+	GasCost uint64
+	Handler CustomBuiltinFunctionHandler
+}
+
+// RegisterBuiltinFunction adds a custom builtin function to the inner
+// BuiltInFunctionContainer; its name must not be already registered
+func (bf *BuiltinFunctionsWrapper) RegisterBuiltinFunction(function CustomBuiltinFunction) error {
+	if len(function.Name) == 0 || function.Handler == nil {
+		return ErrInvalidCustomBuiltinFunction
+	}
+
+	return bf.Container.Add(function.Name, &customBuiltinFunction{
+		world:    bf.World,
+		function: function,
+	})
+}
+
+// RegisterBuiltinFunction adds a custom builtin function to the MockWorld,
+// whose builtin functions must have been initialized beforehand; the VMHost
+// must then be given the new list of builtin function names
+func (b *MockWorld) RegisterBuiltinFunction(function CustomBuiltinFunction) error {
+	if b.BuiltinFuncs == nil {
+		return ErrBuiltinFuncWrapperNotInitialized
+	}
+
+	return b.BuiltinFuncs.RegisterBuiltinFunction(function)
+}
+
+// customBuiltinFunction adapts a CustomBuiltinFunction to the BuiltinFunction
+// interface expected by the BuiltInFunctionContainer
+type customBuiltinFunction struct {
+	world    *MockWorld
+	function CustomBuiltinFunction
+}
+
+// ProcessBuiltinFunction charges the gas cost of the custom builtin function
+// and calls its handler
+func (custom *customBuiltinFunction) ProcessBuiltinFunction(
+	_ state.UserAccountHandler,
+	_ state.UserAccountHandler,
+	input *vmcommon.ContractCallInput,
+) (*vmcommon.VMOutput, error) {
+	if input.GasProvided < custom.function.GasCost {
+		return nil, process.ErrNotEnoughGas
+	}
+
+	vmOutput, err := custom.function.Handler(custom.world, input)
+	if err != nil {
+		return nil, err
+	}
+	if vmOutput == nil {
+		vmOutput = &vmcommon.VMOutput{
+			ReturnCode:     vmcommon.Ok,
+			GasRefund:      big.NewInt(0),
+			OutputAccounts: make(map[string]*vmcommon.OutputAccount),
+		}
+	}
+
+	vmOutput.GasRemaining = input.GasProvided - custom.function.GasCost
+	return vmOutput, nil
+}
+
+// SetNewGasConfig does nothing, the gas cost of a custom builtin function
+// being fixed at registration
+func (custom *customBuiltinFunction) SetNewGasConfig(_ *process.GasCost) {
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (custom *customBuiltinFunction) IsInterfaceNil() bool {
+	return custom == nil
+}
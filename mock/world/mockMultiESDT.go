@@ -0,0 +1,100 @@
+package worldmock
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
+	"github.com/ElrondNetwork/elrond-go/core"
+	"github.com/ElrondNetwork/elrond-go/core/vmcommon"
+	"github.com/ElrondNetwork/elrond-go/data/state"
+	"github.com/ElrondNetwork/elrond-go/process"
+)
+
+// multiESDTNFTTransfer simulates the MultiESDTNFTTransfer builtin function,
+// which the builtin functions of the protocol do not provide yet, by
+// performing an ESDTTransfer or an ESDTNFTTransfer for each of its tokens
+type multiESDTNFTTransfer struct {
+	wrapper *BuiltinFunctionsWrapper
+}
+
+// ProcessBuiltinFunction transfers each token to the destination, reverting
+// all the transfers if any of them fails
+func (multi *multiESDTNFTTransfer) ProcessBuiltinFunction(
+	_ state.UserAccountHandler,
+	_ state.UserAccountHandler,
+	input *vmcommon.ContractCallInput,
+) (*vmcommon.VMOutput, error) {
+	destination, transfers, _, err := arwen.ParseMultiESDTNFTTransferArguments(input.Arguments)
+	if err != nil {
+		return nil, err
+	}
+
+	world := multi.wrapper.World
+	snapshot := world.GetSnapshot()
+
+	gasRemaining := input.GasProvided
+	for _, transfer := range transfers {
+		gasRemaining, err = multi.transferToken(input, destination, transfer, gasRemaining)
+		if err != nil {
+			_ = world.RevertToSnapshot(snapshot)
+			return nil, err
+		}
+	}
+
+	return &vmcommon.VMOutput{
+		ReturnCode:     vmcommon.Ok,
+		GasRemaining:   gasRemaining,
+		GasRefund:      big.NewInt(0),
+		OutputAccounts: make(map[string]*vmcommon.OutputAccount),
+	}, nil
+}
+
+func (multi *multiESDTNFTTransfer) transferToken(
+	input *vmcommon.ContractCallInput,
+	destination []byte,
+	transfer *arwen.ESDTTransfer,
+	gasProvided uint64,
+) (uint64, error) {
+	transferInput := &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr:  input.CallerAddr,
+			Arguments:   [][]byte{transfer.TokenIdentifier, transfer.Value.Bytes()},
+			CallValue:   big.NewInt(0),
+			CallType:    vmcommon.DirectCall,
+			GasPrice:    input.GasPrice,
+			GasProvided: gasProvided,
+		},
+		RecipientAddr: destination,
+		Function:      core.BuiltInFunctionESDTTransfer,
+	}
+	if transfer.Nonce > 0 {
+		nonceAsBytes := big.NewInt(0).SetUint64(transfer.Nonce).Bytes()
+		transferInput.Function = core.BuiltInFunctionESDTNFTTransfer
+		transferInput.RecipientAddr = input.CallerAddr
+		transferInput.Arguments = [][]byte{transfer.TokenIdentifier, nonceAsBytes, transfer.Value.Bytes(), destination}
+	}
+
+	vmOutput, err := multi.wrapper.ProcessBuiltInFunction(transferInput)
+	if err != nil {
+		return 0, err
+	}
+	if vmOutput.ReturnCode != vmcommon.Ok {
+		return 0, fmt.Errorf(
+			"%s failed: retcode = %d, msg = %s",
+			transferInput.Function,
+			vmOutput.ReturnCode,
+			vmOutput.ReturnMessage)
+	}
+
+	return vmOutput.GasRemaining, nil
+}
+
+// SetNewGasConfig does nothing, the gas being charged by the transfer of each token
+func (multi *multiESDTNFTTransfer) SetNewGasConfig(_ *process.GasCost) {
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (multi *multiESDTNFTTransfer) IsInterfaceNil() bool {
+	return multi == nil
+}
@@ -218,12 +218,17 @@ func (b *MockWorld) GetUserAccount(address []byte) (vmcommon.UserAccountHandler,
 	return account, nil
 }
 
-// GetCode retrieves the code from the given account, or nil if not found
+// GetCode retrieves the code from the given account, or nil if not found.
+// The code of the accounts of other shards is not available, as on a real
+// node, so the calls to their contracts can only be asynchronous.
 func (b *MockWorld) GetCode(acc vmcommon.UserAccountHandler) []byte {
 	account := b.AcctMap.GetAccount(acc.AddressBytes())
 	if account == nil {
 		return nil
 	}
+	if account.ShardID != b.SelfShardID {
+		return nil
+	}
 
 	return account.Code
 }
@@ -266,11 +271,17 @@ func (b *MockWorld) IsPayable(address []byte) (bool, error) {
 // SaveCompiledCode -
 func (b *MockWorld) SaveCompiledCode(codeHash []byte, code []byte) {
 	b.CompiledCode[string(codeHash)] = code
+	if b.SharedCompiledCode != nil {
+		b.SharedCompiledCode.Put(codeHash, code)
+	}
 }
 
 // GetCompiledCode -
 func (b *MockWorld) GetCompiledCode(codeHash []byte) (bool, []byte) {
 	code, found := b.CompiledCode[string(codeHash)]
+	if !found && b.SharedCompiledCode != nil {
+		return b.SharedCompiledCode.Get(codeHash)
+	}
 	return found, code
 }
 
@@ -23,6 +23,16 @@ type BlockInfo struct {
 	RandomSeed     *[48]byte
 }
 
+// WorldState is a copy of the mutable part of a MockWorld, which can be
+// loaded back into it any number of times.
+type WorldState struct {
+	AcctMap           AccountMap
+	PreviousBlockInfo *BlockInfo
+	CurrentBlockInfo  *BlockInfo
+	Blockhashes       [][]byte
+	NewAddressMocks   []*NewAddressMock
+}
+
 // MockWorld provides a mock representation of the blockchain to be used in VM tests.
 type MockWorld struct {
 	SelfShardID                uint32
@@ -36,6 +46,7 @@ type MockWorld struct {
 	Err                        error
 	LastCreatedContractAddress []byte
 	CompiledCode               map[string][]byte
+	SharedCompiledCode         *CompiledCodeCache
 	BuiltinFuncs               *BuiltinFunctionsWrapper
 }
 
@@ -43,15 +54,16 @@ type MockWorld struct {
 func NewMockWorld() *MockWorld {
 	accountMap := NewAccountMap()
 	world := &MockWorld{
-		SelfShardID:       0,
-		AcctMap:           accountMap,
-		AccountsAdapter:   nil,
-		PreviousBlockInfo: nil,
-		CurrentBlockInfo:  &BlockInfo{},
-		Blockhashes:       nil,
-		NewAddressMocks:   nil,
-		CompiledCode:      make(map[string][]byte),
-		BuiltinFuncs:      nil,
+		SelfShardID:        0,
+		AcctMap:            accountMap,
+		AccountsAdapter:    nil,
+		PreviousBlockInfo:  nil,
+		CurrentBlockInfo:   &BlockInfo{},
+		Blockhashes:        nil,
+		NewAddressMocks:    nil,
+		CompiledCode:       make(map[string][]byte),
+		SharedCompiledCode: nil,
+		BuiltinFuncs:       nil,
 	}
 	world.AccountsAdapter = NewMockAccountsAdapter(world)
 
@@ -81,6 +93,57 @@ func (b *MockWorld) Clear() {
 	b.CompiledCode = make(map[string][]byte)
 }
 
+// SaveState returns a deep copy of the accounts, the block info and the new
+// address mocks of the world.
+func (b *MockWorld) SaveState() *WorldState {
+	return &WorldState{
+		AcctMap:           b.AcctMap.Clone(),
+		PreviousBlockInfo: cloneBlockInfo(b.PreviousBlockInfo),
+		CurrentBlockInfo:  cloneBlockInfo(b.CurrentBlockInfo),
+		Blockhashes:       cloneBytesList(b.Blockhashes),
+		NewAddressMocks:   append([]*NewAddressMock{}, b.NewAddressMocks...),
+	}
+}
+
+// RestoreState replaces the accounts, the block info and the new address mocks
+// of the world with copies of those in the given state. The accounts adapter
+// snapshots are discarded.
+func (b *MockWorld) RestoreState(state *WorldState) {
+	b.AcctMap = state.AcctMap.Clone()
+	b.AccountsAdapter = NewMockAccountsAdapter(b)
+	b.PreviousBlockInfo = cloneBlockInfo(state.PreviousBlockInfo)
+	b.CurrentBlockInfo = cloneBlockInfo(state.CurrentBlockInfo)
+	b.Blockhashes = cloneBytesList(state.Blockhashes)
+	b.NewAddressMocks = append([]*NewAddressMock{}, state.NewAddressMocks...)
+}
+
+func cloneBlockInfo(blockInfo *BlockInfo) *BlockInfo {
+	if blockInfo == nil {
+		return nil
+	}
+
+	clone := *blockInfo
+	if blockInfo.RandomSeed != nil {
+		randomSeed := *blockInfo.RandomSeed
+		clone.RandomSeed = &randomSeed
+	}
+
+	return &clone
+}
+
+func cloneBytesList(list [][]byte) [][]byte {
+	if list == nil {
+		return nil
+	}
+
+	clone := make([][]byte, len(list))
+	for i, b := range list {
+		clone[i] = cloneBytes(b)
+	}
+
+	return clone
+}
+
 // SetCurrentBlockHash -
 func (b *MockWorld) SetCurrentBlockHash(blockHash []byte) {
 	if b.CurrentBlockInfo == nil {
@@ -123,8 +186,10 @@ func (b *MockWorld) CommunicationIdentifier(destShardID uint32) string {
 	return fmt.Sprintf("commID-dest-%d", destShardID)
 }
 
-// GetSnapshot -
+// GetSnapshot saves the current state of the accounts and returns its index,
+// leaving the earlier snapshots, such as the backup of the transaction, intact
 func (b *MockWorld) GetSnapshot() int {
+	b.CreateStateBackup()
 	return b.AccountsAdapter.JournalLen()
 }
 
@@ -0,0 +1,10 @@
+(module
+  (import "env" "mBufferNew" (func $mBufferNew (result i32)))
+  (import "env" "getBlockInfo" (func $getBlockInfo (param i32) (result i32)))
+  (import "env" "mBufferGetBytes" (func $mBufferGetBytes (param i32 i32) (result i32)))
+  (import "env" "finish" (func $finish (param i32 i32)))
+  (memory (export "memory") 1)
+  (func (export "getBlockInfo") (local $buffer i32)
+    (local.set $buffer (call $mBufferNew))
+    (drop (call $getBlockInfo (local.get $buffer)))
+    (call $finish (i32.const 0) (call $mBufferGetBytes (local.get $buffer) (i32.const 0)))))
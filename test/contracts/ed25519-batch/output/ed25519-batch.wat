@@ -0,0 +1,16 @@
+(module
+  (import "env" "int64getArgument" (func $int64getArgument (param i32) (result i64)))
+  (import "env" "verifyEd25519Batch" (func $verifyEd25519Batch (param i32 i32 i32 i32 i32) (result i32)))
+  (import "env" "int64finish" (func $int64finish (param i64)))
+  (memory (export "memory") 1)
+  (func (export "verifyBatch")
+    i32.const 0
+    call $int64getArgument
+    i32.wrap_i64
+    i32.const 0
+    i32.const 0
+    i32.const 0
+    i32.const 0
+    call $verifyEd25519Batch
+    i64.extend_i32_s
+    call $int64finish))
@@ -0,0 +1,20 @@
+#ifndef _BIGFLOAT_H_
+#define _BIGFLOAT_H_
+
+#include "types.h"
+#include "bigInt.h"
+
+typedef unsigned int bigFloat;
+
+bigFloat  bigFloatNewFromParts(int integralPart, int fractionalPart, int exponent);
+
+void      bigFloatAdd(bigFloat destination, bigFloat op1, bigFloat op2);
+void      bigFloatSub(bigFloat destination, bigFloat op1, bigFloat op2);
+void      bigFloatMul(bigFloat destination, bigFloat op1, bigFloat op2);
+void      bigFloatDiv(bigFloat destination, bigFloat op1, bigFloat op2);
+void      bigFloatSqrt(bigFloat destination, bigFloat op);
+void      bigFloatPow(bigFloat destination, bigFloat op, int exponent);
+int       bigFloatCmp(bigFloat op1, bigFloat op2);
+void      bigFloatTruncate(bigFloat op, bigInt destination);
+
+#endif
@@ -17,6 +17,9 @@ void      bigIntAdd(bigInt destination, bigInt op1, bigInt op2);
 void      bigIntSub(bigInt destination, bigInt op1, bigInt op2);
 void      bigIntMul(bigInt destination, bigInt op1, bigInt op2);
 int       bigIntCmp(bigInt op1, bigInt op2);
+void      bigIntPowMod(bigInt destination, bigInt base, bigInt exponent, bigInt modulus);
+int       bigIntIsPrime(bigInt op);
+void      bigIntGcd(bigInt destination, bigInt op1, bigInt op2);
 
 int       bigIntIsInt64(bigInt reference);
 long long bigIntGetInt64(bigInt reference);
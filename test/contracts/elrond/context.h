@@ -15,6 +15,7 @@ int getCallValue(byte *result);
 long long getGasLeft();
 void writeLog(byte *pointer, int length, byte *topicPtr, int numTopics);
 void asyncCall(byte *destination, byte *value, byte *data, int length);
+long long estimateAsyncCallGas(byte *destination, byte *value, byte *data, int length);
 void signalError(byte *message, int length);
 
 int executeOnSameContext(
@@ -47,6 +48,16 @@ int executeOnDestContextByCaller(
 		byte *argumentsLengths,
 		byte *arguments);
 
+int registerDeferredCall(
+		byte *function,
+		int functionLength,
+		long long gas,
+		long long targetRound,
+		int targetEpoch,
+		int numArguments,
+		byte *argumentsLengths,
+		byte *arguments);
+
 int createContract(
 		long long gas,
 		byte *value,
@@ -63,11 +74,13 @@ void finish(byte *data, int length);
 void int64finish(long long value);
 int getNumReturnData();
 int getReturnDataSize(int index);
+long long getReturnDataBudget();
 int getReturnData(int index, byte *data);
 
 // Blockchain-related functions
 long long getBlockTimestamp();
 int getBlockHash(long long nonce, byte *hash);
+int getBlockInfo(int resultHandle);
 
 // Argument-related functions
 int getNumArguments();
@@ -85,6 +98,8 @@ int storageStore(byte *key, int keyLength, byte *data, int dataLength);
 int storageLoad(byte *key, int keyLength, byte *data);
 int int64storageStore(byte *key, int keyLength, long long value);
 long long int64storageLoad(byte *key, int keyLength);
+int storageLoadFromContract(byte *address, byte *key, int keyLength, byte *data);
+int storageLoadLengthFromContract(byte *address, byte *key, int keyLength);
 
 // Timelocks-related functions
 int setStorageLock(byte *key, int keyLen, long long timeLock);
@@ -95,11 +110,41 @@ int clearStorageLock(byte *key, int keyLen);
 // ESDT-related functions
 int getESDTTokenName(byte *name);
 int getESDTValue(byte *value);
+int getNumESDTTransfers();
+int getESDTTokenNameByIndex(byte *name, int index);
+int getESDTValueByIndex(byte *value, int index);
+long long getESDTTokenNonceByIndex(int index);
 int getESDTBalance(
 		byte *address,
 		byte *tokenName,
 		int tokenNameLen,
 		long long nonce,
 		byte *result);
+int transferESDTAndExecute(
+		byte *destination,
+		byte *tokenName,
+		int tokenNameLen,
+		byte *value,
+		long long nonce,
+		long long gasLimit,
+		byte *function,
+		int functionLength,
+		int numArguments,
+		byte *argumentsLengths,
+		byte *arguments);
+int multiTransferESDTNFTExecute(
+		byte *destination,
+		int numTokenTransfers,
+		byte *tokenTransfersArgsLengths,
+		byte *tokenTransfersData,
+		long long gasLimit,
+		byte *function,
+		int functionLength,
+		int numArguments,
+		byte *argumentsLengths,
+		byte *arguments);
+int getESDTNFTAttributes(byte *address, byte *tokenName, int tokenNameLen, long long nonce, int resultHandle);
+int getESDTNFTURIs(byte *address, byte *tokenName, int tokenNameLen, long long nonce, int resultHandle);
+int getESDTRoyalties(byte *address, byte *tokenName, int tokenNameLen, long long nonce, int resultHandle);
 
 #endif
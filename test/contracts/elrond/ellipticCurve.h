@@ -0,0 +1,18 @@
+#ifndef _ELLIPTICCURVE_H_
+#define _ELLIPTICCURVE_H_
+
+#include "types.h"
+
+#define CURVE_RISTRETTO255 0
+#define CURVE_CURVE25519   1
+
+typedef unsigned int curvePoint;
+
+curvePoint  curvePointFromBytes(int curveID, byte *data);
+int         curvePointToBytes(curvePoint point, byte *result);
+curvePoint  curvePointAdd(curvePoint point1, curvePoint point2);
+curvePoint  curvePointScalarMult(curvePoint point, byte *scalar);
+curvePoint  curvePointScalarBaseMult(int curveID, byte *scalar);
+int         curvePointEqual(curvePoint point1, curvePoint point2);
+
+#endif
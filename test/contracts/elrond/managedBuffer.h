@@ -0,0 +1,20 @@
+#ifndef _MANAGEDBUFFER_H_
+#define _MANAGEDBUFFER_H_
+
+#include "types.h"
+
+typedef unsigned int mBuffer;
+
+mBuffer   mBufferNew();
+mBuffer   mBufferNewFromBytes(byte *data, int dataLength);
+int       mBufferGetLength(mBuffer handle);
+int       mBufferGetBytes(mBuffer handle, byte *result);
+int       mBufferSetBytes(mBuffer handle, byte *data, int dataLength);
+int       mBufferAppend(mBuffer accumulator, mBuffer data);
+
+int       mBufferCopySlice(mBuffer source, int startingPosition, int sliceLength, mBuffer destination);
+int       mBufferFind(mBuffer source, mBuffer pattern, int startingPosition);
+int       mBufferSplit(mBuffer source, mBuffer separator, int maxPieces, mBuffer *result);
+int       mBufferCompareSlice(mBuffer handle1, int startingPosition1, mBuffer handle2, int startingPosition2, int sliceLength);
+
+#endif
@@ -0,0 +1,20 @@
+#ifndef _MANAGEDMAP_H_
+#define _MANAGEDMAP_H_
+
+#include "types.h"
+
+typedef unsigned int managedMap;
+
+managedMap managedMapNew();
+
+int        managedMapPut(managedMap map, byte *key, int keyLength, byte *value, int valueLength);
+int        managedMapGetLength(managedMap map, byte *key, int keyLength);
+int        managedMapGet(managedMap map, byte *key, int keyLength, byte *result);
+int        managedMapRemove(managedMap map, byte *key, int keyLength);
+int        managedMapContains(managedMap map, byte *key, int keyLength);
+
+int        managedMapLength(managedMap map);
+int        managedMapKeyLengthAtIndex(managedMap map, int index);
+int        managedMapKeyAtIndex(managedMap map, int index, byte *result);
+
+#endif
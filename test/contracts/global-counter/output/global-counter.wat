@@ -0,0 +1,11 @@
+(module
+  (import "env" "int64finish" (func $int64finish (param i64)))
+  (memory (export "memory") 1)
+  (global $counter (mut i64) (i64.const 0))
+  (func (export "increment")
+    global.get $counter
+    i64.const 1
+    i64.add
+    global.set $counter
+    global.get $counter
+    call $int64finish))
@@ -0,0 +1,26 @@
+(module
+  (import "env" "int64getArgument" (func $int64getArgument (param i32) (result i64)))
+  (import "env" "mBufferNew" (func $mBufferNew (result i32)))
+  (import "env" "mBufferNewFromBytes" (func $mBufferNewFromBytes (param i32 i32) (result i32)))
+  (import "env" "mBufferCopySlice" (func $mBufferCopySlice (param i32 i32 i32 i32) (result i32)))
+  (import "env" "mBufferAppend" (func $mBufferAppend (param i32 i32) (result i32)))
+  (import "env" "mBufferCompareSlice" (func $mBufferCompareSlice (param i32 i32 i32 i32 i32) (result i32)))
+  (memory (export "memory") 1)
+  (func (export "copySlice")
+    (drop (call $mBufferCopySlice
+      (call $mBufferNewFromBytes (i32.const 0) (i32.const 64))
+      (i32.const 0)
+      (i32.wrap_i64 (call $int64getArgument (i32.const 0)))
+      (call $mBufferNew))))
+  (func (export "append")
+    (drop (call $mBufferAppend
+      (call $mBufferNew)
+      (call $mBufferNewFromBytes (i32.const 0) (i32.wrap_i64 (call $int64getArgument (i32.const 0)))))))
+  (func (export "compareSlice")
+    (drop (call $mBufferCompareSlice
+      (call $mBufferNewFromBytes (i32.const 0) (i32.const 64))
+      (i32.const 0)
+      (call $mBufferNewFromBytes (i32.const 0) (i32.const 64))
+      (i32.const 0)
+      (i32.wrap_i64 (call $int64getArgument (i32.const 0))))))
+  (data (i32.const 0) "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"))
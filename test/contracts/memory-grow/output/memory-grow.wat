@@ -0,0 +1,11 @@
+(module
+  (import "env" "int64getArgument" (func $int64getArgument (param i32) (result i64)))
+  (import "env" "int64finish" (func $int64finish (param i64)))
+  (memory (export "memory") 1)
+  (func (export "grow")
+    i32.const 0
+    call $int64getArgument
+    i32.wrap_i64
+    memory.grow
+    i64.extend_i32_s
+    call $int64finish))
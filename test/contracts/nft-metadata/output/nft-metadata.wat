@@ -0,0 +1,31 @@
+(module
+  (import "env" "getSCAddress" (func $getSCAddress (param i32)))
+  (import "env" "getArgument" (func $getArgument (param i32 i32) (result i32)))
+  (import "env" "int64getArgument" (func $int64getArgument (param i32) (result i64)))
+  (import "env" "mBufferNew" (func $mBufferNew (result i32)))
+  (import "env" "getESDTNFTAttributes" (func $getESDTNFTAttributes (param i32 i32 i32 i64 i32) (result i32)))
+  (import "env" "getESDTNFTURIs" (func $getESDTNFTURIs (param i32 i32 i32 i64 i32) (result i32)))
+  (import "env" "getESDTRoyalties" (func $getESDTRoyalties (param i32 i32 i32 i64 i32) (result i32)))
+  (import "env" "mBufferGetBytes" (func $mBufferGetBytes (param i32 i32) (result i32)))
+  (import "env" "finish" (func $finish (param i32 i32)))
+  (memory (export "memory") 1)
+  ;; each getter reads the metadata of the NFT of the contract whose token
+  ;; identifier and nonce are given as arguments, and finishes it
+  (func (export "getAttributes") (local $tokenIDLen i32) (local $buffer i32)
+    (call $getSCAddress (i32.const 0))
+    (local.set $tokenIDLen (call $getArgument (i32.const 0) (i32.const 64)))
+    (local.set $buffer (call $mBufferNew))
+    (drop (call $getESDTNFTAttributes (i32.const 0) (i32.const 64) (local.get $tokenIDLen) (call $int64getArgument (i32.const 1)) (local.get $buffer)))
+    (call $finish (i32.const 256) (call $mBufferGetBytes (local.get $buffer) (i32.const 256))))
+  (func (export "getURIs") (local $tokenIDLen i32) (local $buffer i32)
+    (call $getSCAddress (i32.const 0))
+    (local.set $tokenIDLen (call $getArgument (i32.const 0) (i32.const 64)))
+    (local.set $buffer (call $mBufferNew))
+    (drop (call $getESDTNFTURIs (i32.const 0) (i32.const 64) (local.get $tokenIDLen) (call $int64getArgument (i32.const 1)) (local.get $buffer)))
+    (call $finish (i32.const 256) (call $mBufferGetBytes (local.get $buffer) (i32.const 256))))
+  (func (export "getRoyalties") (local $tokenIDLen i32) (local $buffer i32)
+    (call $getSCAddress (i32.const 0))
+    (local.set $tokenIDLen (call $getArgument (i32.const 0) (i32.const 64)))
+    (local.set $buffer (call $mBufferNew))
+    (drop (call $getESDTRoyalties (i32.const 0) (i32.const 64) (local.get $tokenIDLen) (call $int64getArgument (i32.const 1)) (local.get $buffer)))
+    (call $finish (i32.const 256) (call $mBufferGetBytes (local.get $buffer) (i32.const 256)))))
@@ -0,0 +1,21 @@
+(module
+  (import "env" "int64storageLoad" (func $int64storageLoad (param i32 i32) (result i64)))
+  (import "env" "int64storageStore" (func $int64storageStore (param i32 i32 i64) (result i32)))
+  (import "env" "int64finish" (func $int64finish (param i64)))
+  (memory (export "memory") 1)
+  (func (export "increment")
+    (local $counter i64)
+    i32.const 0
+    i32.const 7
+    call $int64storageLoad
+    i64.const 1
+    i64.add
+    local.set $counter
+    i32.const 0
+    i32.const 7
+    local.get $counter
+    call $int64storageStore
+    drop
+    local.get $counter
+    call $int64finish)
+  (data (i32.const 0) "COUNTER"))
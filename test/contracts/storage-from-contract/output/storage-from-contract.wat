@@ -0,0 +1,17 @@
+(module
+  (import "env" "getArgument" (func $getArgument (param i32 i32) (result i32)))
+  (import "env" "storageLoadFromContract" (func $storageLoadFromContract (param i32 i32 i32 i32) (result i32)))
+  (import "env" "storageLoadLengthFromContract" (func $storageLoadLengthFromContract (param i32 i32 i32) (result i32)))
+  (import "env" "finish" (func $finish (param i32 i32)))
+  (import "env" "int64finish" (func $int64finish (param i64)))
+  (memory (export "memory") 1)
+  ;; arguments: the address of the contract and the key to read
+  (func (export "loadFromContract") (local $keyLength i32) (local $dataLength i32)
+    (drop (call $getArgument (i32.const 0) (i32.const 0)))
+    (local.set $keyLength (call $getArgument (i32.const 1) (i32.const 64)))
+    (local.set $dataLength (call $storageLoadFromContract (i32.const 0) (i32.const 64) (local.get $keyLength) (i32.const 256)))
+    (call $finish (i32.const 256) (local.get $dataLength)))
+  (func (export "loadLengthFromContract") (local $keyLength i32)
+    (drop (call $getArgument (i32.const 0) (i32.const 0)))
+    (local.set $keyLength (call $getArgument (i32.const 1) (i32.const 64)))
+    (call $int64finish (i64.extend_i32_s (call $storageLoadLengthFromContract (i32.const 0) (i32.const 64) (local.get $keyLength))))))
@@ -19,6 +19,7 @@ type testTemplateConfig struct {
 type MockInstancesTestTemplate struct {
 	testTemplateConfig
 	contracts     *[]MockTestSmartContract
+	parameters    *arwen.VMHostParameters
 	setup         func(arwen.VMHost, *worldmock.MockWorld)
 	assertResults func(*worldmock.MockWorld, *VMOutputVerifier)
 }
@@ -30,7 +31,8 @@ func BuildMockInstanceCallTest(t *testing.T) *MockInstancesTestTemplate {
 			t:        t,
 			useMocks: true,
 		},
-		setup: func(arwen.VMHost, *worldmock.MockWorld) {},
+		parameters: DefaultTestVMHostParameters(),
+		setup:      func(arwen.VMHost, *worldmock.MockWorld) {},
 	}
 }
 
@@ -46,6 +48,12 @@ func (callerTest *MockInstancesTestTemplate) WithInput(input *vmcommon.ContractC
 	return callerTest
 }
 
+// WithParameters provides the parameters of the host used by the mock contract call test
+func (callerTest *MockInstancesTestTemplate) WithParameters(parameters *arwen.VMHostParameters) *MockInstancesTestTemplate {
+	callerTest.parameters = parameters
+	return callerTest
+}
+
 // WithSetup provides the setup function to be used by the mock contract call test
 func (callerTest *MockInstancesTestTemplate) WithSetup(setup func(arwen.VMHost, *worldmock.MockWorld)) *MockInstancesTestTemplate {
 	callerTest.setup = setup
@@ -60,20 +68,20 @@ func (callerTest *MockInstancesTestTemplate) AndAssertResults(assertResults func
 
 func (callerTest *MockInstancesTestTemplate) runTest() {
 
-	host, world, imb := DefaultTestArwenForCallWithInstanceMocks(callerTest.t)
+	host, world, imb := DefaultTestArwenForCallWithInstanceMocksAndParameters(callerTest.t, callerTest.parameters)
 
 	for _, mockSC := range *callerTest.contracts {
 		mockSC.initialize(callerTest.t, host, imb)
 	}
 
-	callerTest.setup(host, world)
+	callerTest.setup(host, world.MockWorld)
 	// create snapshot (normaly done by node)
 	world.CreateStateBackup()
 
 	vmOutput, err := host.RunSmartContractCall(callerTest.input)
 
 	verify := NewVMOutputVerifierWithAllErrors(callerTest.t, vmOutput, err, host.Runtime().GetAllErrors())
-	callerTest.assertResults(world, verify)
+	callerTest.assertResults(world.MockWorld, verify)
 }
 
 // SimpleWasteGasMockMethod is a simple waste gas mock method
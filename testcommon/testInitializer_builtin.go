@@ -2,10 +2,14 @@ package testcommon
 
 import (
 	"errors"
+	"testing"
 
+	"github.com/ElrondNetwork/arwen-wasm-vm/v1_3/arwen"
+	worldmock "github.com/ElrondNetwork/arwen-wasm-vm/v1_3/mock/world"
 	"github.com/ElrondNetwork/elrond-go/core/vmcommon"
 	"github.com/ElrondNetwork/elrond-go/data/state"
 	"github.com/ElrondNetwork/elrond-go/process"
+	"github.com/stretchr/testify/require"
 )
 
 // MockBuiltin defined the functions that can be replaced in order to mock a builtin
@@ -37,3 +41,25 @@ func (m *MockBuiltin) IsInterfaceNil() bool {
 	}
 	return m.isInterfaceNil()
 }
+
+// RegisterCustomBuiltinFunctions registers the given custom builtin functions
+// in the MockWorld, initializing its builtin functions if needed, and makes
+// the VMHost treat them as builtin functions of the protocol
+func RegisterCustomBuiltinFunctions(
+	tb testing.TB,
+	host arwen.VMHost,
+	world *worldmock.MockWorld,
+	functions ...worldmock.CustomBuiltinFunction,
+) {
+	if world.BuiltinFuncs == nil {
+		err := world.InitBuiltinFunctions(host.GetGasScheduleMap())
+		require.Nil(tb, err)
+	}
+
+	for _, function := range functions {
+		err := world.RegisterBuiltinFunction(function)
+		require.Nil(tb, err)
+	}
+
+	host.SetProtocolBuiltinFunctions(world.BuiltinFuncs.GetBuiltinFunctionNames())
+}
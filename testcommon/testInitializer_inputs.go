@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"math"
 	"math/big"
 	"os"
 	"os/exec"
@@ -155,28 +156,26 @@ func DefaultTestArwenForCallWithInstanceRecorderMock(tb testing.TB, code []byte,
 }
 
 // DefaultTestArwenForCallWithInstanceMocks creates an InstanceBuilderMock
-func DefaultTestArwenForCallWithInstanceMocks(tb testing.TB) (arwen.VMHost, *worldmock.MockWorld, *contextmock.InstanceBuilderMock) {
-	world := worldmock.NewMockWorld()
-	host := DefaultTestArwen(tb, world)
+func DefaultTestArwenForCallWithInstanceMocks(tb testing.TB) (arwen.VMHost, *worldmock.ChainSimulator, *contextmock.InstanceBuilderMock) {
+	return DefaultTestArwenForCallWithInstanceMocksAndParameters(tb, DefaultTestVMHostParameters())
+}
+
+// DefaultTestArwenForCallWithInstanceMocksAndParameters creates an InstanceBuilderMock for a host with the given parameters
+func DefaultTestArwenForCallWithInstanceMocksAndParameters(tb testing.TB, parameters *arwen.VMHostParameters) (arwen.VMHost, *worldmock.ChainSimulator, *contextmock.InstanceBuilderMock) {
+	world := worldmock.NewChainSimulator(1)
+	host := DefaultTestArwenWithParameters(tb, world, parameters)
 
-	instanceBuilderMock := contextmock.NewInstanceBuilderMock(world)
+	instanceBuilderMock := contextmock.NewInstanceBuilderMock(world.MockWorld)
 	host.Runtime().ReplaceInstanceBuilder(instanceBuilderMock)
 
 	return host, world, instanceBuilderMock
 }
 
-// DefaultTestArwenForCallWithWorldMock creates a MockWorld
-func DefaultTestArwenForCallWithWorldMock(tb testing.TB, code []byte, balance *big.Int) (arwen.VMHost, *worldmock.MockWorld) {
-	world := worldmock.NewMockWorld()
-	host := DefaultTestArwen(tb, world)
-
-	err := world.InitBuiltinFunctions(host.GetGasScheduleMap())
-	require.Nil(tb, err)
-
-	host.SetProtocolBuiltinFunctions(world.BuiltinFuncs.GetBuiltinFunctionNames())
-
-	parentAccount := world.AcctMap.CreateSmartContractAccount(UserAddress, ParentAddress, code)
-	parentAccount.Balance = balance
+// DefaultTestArwenForCallWithWorldMock creates a ChainSimulator holding the
+// parent contract with the given code and balance
+func DefaultTestArwenForCallWithWorldMock(tb testing.TB, code []byte, balance *big.Int) (arwen.VMHost, *worldmock.ChainSimulator) {
+	host, world := DefaultTestArwenWithWorldMock(tb)
+	world.CreateContractAccount(UserAddress, ParentAddress, code, balance)
 
 	return host, world
 }
@@ -263,9 +262,9 @@ func defaultTestArwenForContracts(
 	return host, stubBlockchainHook
 }
 
-// DefaultTestArwenWithWorldMock creates a host configured with a mock world
-func DefaultTestArwenWithWorldMock(tb testing.TB) (arwen.VMHost, *worldmock.MockWorld) {
-	world := worldmock.NewMockWorld()
+// DefaultTestArwenWithWorldMock creates a host configured with a single-shard ChainSimulator
+func DefaultTestArwenWithWorldMock(tb testing.TB) (arwen.VMHost, *worldmock.ChainSimulator) {
+	world := worldmock.NewChainSimulator(1)
 	host := DefaultTestArwen(tb, world)
 
 	err := world.InitBuiltinFunctions(host.GetGasScheduleMap())
@@ -277,12 +276,26 @@ func DefaultTestArwenWithWorldMock(tb testing.TB) (arwen.VMHost, *worldmock.Mock
 
 // DefaultTestArwen creates a host configured with a configured blockchain hook
 func DefaultTestArwen(tb testing.TB, blockchain vmcommon.BlockchainHook) arwen.VMHost {
+	return DefaultTestArwenWithParameters(tb, blockchain, DefaultTestVMHostParameters())
+}
+
+// DefaultTestArwenWithParameters creates a host with the given parameters and blockchain hook
+func DefaultTestArwenWithParameters(tb testing.TB, blockchain vmcommon.BlockchainHook, parameters *arwen.VMHostParameters) arwen.VMHost {
+	host, err := arwenHost.NewArwenVM(blockchain, parameters)
+	require.Nil(tb, err)
+	require.NotNil(tb, host)
+
+	return host
+}
+
+// DefaultTestVMHostParameters creates the parameters of the hosts used in tests
+func DefaultTestVMHostParameters() *arwen.VMHostParameters {
 	gasSchedule := customGasSchedule
 	if gasSchedule == nil {
 		gasSchedule = config.MakeGasMapForTests()
 	}
 
-	host, err := arwenHost.NewArwenVM(blockchain, &arwen.VMHostParameters{
+	return &arwen.VMHostParameters{
 		VMType:                   DefaultVMType,
 		BlockGasLimit:            uint64(1000),
 		GasSchedule:              gasSchedule,
@@ -290,11 +303,10 @@ func DefaultTestArwen(tb testing.TB, blockchain vmcommon.BlockchainHook) arwen.V
 		ElrondProtectedKeyPrefix: []byte("ELROND"),
 		UseWarmInstance:          false,
 		DynGasLockEnableEpoch:    0,
-	})
-	require.Nil(tb, err)
-	require.NotNil(tb, host)
-
-	return host
+		MeteringAuditEnabled:     true,
+		// the refund receipts would be asserted as logs of every execution
+		RefundReceiptsEnableEpoch: math.MaxUint32,
+	}
 }
 
 // AddTestSmartContractToWorld directly deploys the provided code into the
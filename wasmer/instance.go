@@ -4,11 +4,20 @@ package wasmer
 import "C"
 import (
 	"fmt"
+	"sync"
 	"unsafe"
 )
 
 const OPCODE_COUNT = 448
 
+// importsMutex guards the process-wide cached import object, which is
+// replaced by every VM host on creation, against concurrent instantiations
+var importsMutex sync.RWMutex
+
+// cachedWasmImports keeps alive the imports array referenced by the cached
+// import object, which Wasmer does not copy
+var cachedWasmImports []cWasmerImportT
+
 // InstanceError represents any kind of errors related to a WebAssembly instance. It
 // is returned by `Instance` functions only.
 type InstanceError struct {
@@ -112,16 +121,26 @@ func newWrappedError(target error) error {
 }
 
 func SetImports(imports *Imports) error {
-	wasmImportsCPointer, numberOfImports := generateWasmerImports(imports)
+	wasmImports := generateWasmerImports(imports)
+
+	var wasmImportsCPointer *cWasmerImportT
+	if len(wasmImports) > 0 {
+		wasmImportsCPointer = (*cWasmerImportT)(unsafe.Pointer(&wasmImports[0]))
+	}
+
+	importsMutex.Lock()
+	defer importsMutex.Unlock()
 
 	var result = cWasmerCacheImportObjectFromImports(
 		wasmImportsCPointer,
-		cInt(numberOfImports),
+		cInt(len(wasmImports)),
 	)
 
 	if result != cWasmerOk {
 		return newWrappedError(ErrFailedCacheImports)
 	}
+
+	cachedWasmImports = wasmImports
 	return nil
 }
 
@@ -141,12 +160,14 @@ func NewInstanceWithOptions(
 	}
 
 	cOptions := unsafe.Pointer(&options)
+	importsMutex.RLock()
 	var compileResult = cWasmerInstantiateWithOptions(
 		&c_instance,
 		(*cUchar)(unsafe.Pointer(&bytes[0])),
 		cUint(len(bytes)),
 		(*cWasmerCompilationOptions)(cOptions),
 	)
+	importsMutex.RUnlock()
 
 	if compileResult != cWasmerOk {
 		var emptyInstance = &Instance{instance: nil, Exports: nil, Memory: nil}
@@ -204,12 +225,14 @@ func NewInstanceFromCompiledCodeWithOptions(
 	}
 
 	cOptions := unsafe.Pointer(&options)
+	importsMutex.RLock()
 	var instantiateResult = cWasmerInstanceFromCache(
 		&c_instance,
 		(*cUchar)(unsafe.Pointer(&compiledCode[0])),
 		cUint32T(len(compiledCode)),
 		(*cWasmerCompilationOptions)(cOptions),
 	)
+	importsMutex.RUnlock()
 
 	if instantiateResult != cWasmerOk {
 		var emptyInstance = &Instance{instance: nil, Exports: nil, Memory: nil}
@@ -5,7 +5,7 @@ import (
 	"unsafe"
 )
 
-func generateWasmerImports(imports *Imports) (*cWasmerImportT, int) {
+func generateWasmerImports(imports *Imports) []cWasmerImportT {
 	var numberOfImports = imports.Count()
 	var wasmImports = make([]cWasmerImportT, numberOfImports)
 	var importFunctionNth = 0
@@ -45,13 +45,7 @@ func generateWasmerImports(imports *Imports) (*cWasmerImportT, int) {
 		}
 	}
 
-	var wasmImportsCPointer *cWasmerImportT
-
-	if numberOfImports > 0 {
-		wasmImportsCPointer = (*cWasmerImportT)(unsafe.Pointer(&wasmImports[0]))
-	}
-
-	return wasmImportsCPointer, numberOfImports
+	return wasmImports
 }
 
 func retrieveExportedMemory(wasmExports *cWasmerExportsT) (Memory, bool, error) {
@@ -1,2 +1,7 @@
 // Package wasmer is a Go library to run WebAssembly binaries.
 package wasmer
+
+// LibraryVersion identifies the build of the Wasmer libraries shipped with
+// this package; it must change whenever they are rebuilt, because the code
+// compiled by one build is not meant to be loaded by another
+const LibraryVersion = "elrond-1"